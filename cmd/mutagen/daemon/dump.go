@@ -0,0 +1,140 @@
+package daemon
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"github.com/spf13/cobra"
+
+	forwardingmodels "github.com/mutagen-io/mutagen/pkg/api/models/forwarding"
+	synchronizationmodels "github.com/mutagen-io/mutagen/pkg/api/models/synchronization"
+	"github.com/mutagen-io/mutagen/pkg/encoding"
+	"github.com/mutagen-io/mutagen/pkg/grpcutil"
+	daemonsvc "github.com/mutagen-io/mutagen/pkg/service/daemon"
+)
+
+// dumpBundle is the diagnostic bundle format written by the dump command.
+type dumpBundle struct {
+	// Version is the daemon version.
+	Version string `json:"version"`
+	// Platform describes the platform on which the daemon is running.
+	Platform struct {
+		// OperatingSystem is the daemon's operating system.
+		OperatingSystem string `json:"operatingSystem"`
+		// Architecture is the daemon's architecture.
+		Architecture string `json:"architecture"`
+		// GoVersion is the Go version with which the daemon was built.
+		GoVersion string `json:"goVersion"`
+	} `json:"platform"`
+	// Capabilities describes the daemon's algorithm support.
+	Capabilities struct {
+		// HashingAlgorithms are the supported hashing algorithms.
+		HashingAlgorithms []string `json:"hashingAlgorithms"`
+		// CompressionAlgorithms are the supported compression algorithms.
+		CompressionAlgorithms []string `json:"compressionAlgorithms"`
+	} `json:"capabilities"`
+	// Stats are the daemon's aggregate resource usage statistics.
+	Stats *daemonsvc.StatsResponse `json:"stats"`
+	// SynchronizationSessions are the synchronization sessions.
+	SynchronizationSessions []synchronizationmodels.Session `json:"synchronizationSessions"`
+	// ForwardingSessions are the forwarding sessions.
+	ForwardingSessions []forwardingmodels.Session `json:"forwardingSessions"`
+	// Logs are the daemon's most recent log lines.
+	Logs []string `json:"logs"`
+}
+
+// newDumpBundle converts a daemon dump to a diagnostic bundle.
+func newDumpBundle(dump *daemonsvc.DumpResponse) *dumpBundle {
+	// Create the bundle.
+	bundle := &dumpBundle{
+		Stats:                   dump.Stats,
+		SynchronizationSessions: synchronizationmodels.ExportSessions(dump.SynchronizationSessions),
+		ForwardingSessions:      forwardingmodels.ExportSessions(dump.ForwardingSessions),
+		Logs:                    dump.Logs,
+	}
+
+	// Format the version.
+	bundle.Version = fmt.Sprintf("%d.%d.%d", dump.Version.Major, dump.Version.Minor, dump.Version.Patch)
+	if dump.Version.Tag != "" {
+		bundle.Version += "-" + dump.Version.Tag
+	}
+
+	// Propagate platform and capability information.
+	bundle.Platform.OperatingSystem = dump.OperatingSystem
+	bundle.Platform.Architecture = dump.Architecture
+	bundle.Platform.GoVersion = dump.GoVersion
+	bundle.Capabilities.HashingAlgorithms = dump.HashingAlgorithms
+	bundle.Capabilities.CompressionAlgorithms = dump.CompressionAlgorithms
+
+	// Ensure that empty log history is rendered as an empty list.
+	if bundle.Logs == nil {
+		bundle.Logs = []string{}
+	}
+
+	// Done.
+	return bundle
+}
+
+// dumpMain is the entry point for the dump command.
+func dumpMain(_ *cobra.Command, arguments []string) error {
+	// Validate arguments.
+	if len(arguments) != 1 {
+		return errors.New("a single output path must be specified")
+	}
+	path := arguments[0]
+
+	// Connect to the daemon and defer closure of the connection. We don't
+	// autostart the daemon since there's nothing to dump if it isn't running.
+	daemonConnection, err := Connect(false, true)
+	if err != nil {
+		return fmt.Errorf("unable to connect to daemon: %w", err)
+	}
+	defer daemonConnection.Close()
+
+	// Create a daemon service client.
+	daemonService := daemonsvc.NewDaemonClient(daemonConnection)
+
+	// Request a dump.
+	response, err := daemonService.Dump(context.Background(), &daemonsvc.DumpRequest{})
+	if err != nil {
+		return grpcutil.PeelAwayRPCErrorLayer(err)
+	}
+
+	// Write the bundle.
+	if err := encoding.MarshalAndSave(path, func() ([]byte, error) {
+		return json.MarshalIndent(newDumpBundle(response), "", "  ")
+	}); err != nil {
+		return fmt.Errorf("unable to write diagnostic bundle: %w", err)
+	}
+
+	// Success.
+	return nil
+}
+
+// dumpCommand is the dump command.
+var dumpCommand = &cobra.Command{
+	Use:          "dump <file>",
+	Short:        "Write a diagnostic dump of the Mutagen daemon's internal state to a file",
+	RunE:         dumpMain,
+	SilenceUsage: true,
+}
+
+// dumpConfiguration stores configuration for the dump command.
+var dumpConfiguration struct {
+	// help indicates whether or not to show help information and exit.
+	help bool
+}
+
+func init() {
+	// Grab a handle for the command line flags.
+	flags := dumpCommand.Flags()
+
+	// Disable alphabetical sorting of flags in help output.
+	flags.SortFlags = false
+
+	// Manually add a help flag to override the default message. Cobra will
+	// still implement its logic automatically.
+	flags.BoolVarP(&dumpConfiguration.help, "help", "h", false, "Show help information")
+}
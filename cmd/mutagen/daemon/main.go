@@ -49,6 +49,8 @@ func init() {
 		runCommand,
 		startCommand,
 		stopCommand,
+		statsCommand,
+		dumpCommand,
 	}
 	if daemon.RegistrationSupported {
 		supportedCommands = append(supportedCommands,
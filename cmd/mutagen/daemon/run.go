@@ -3,6 +3,7 @@ package daemon
 import (
 	"errors"
 	"fmt"
+	"io"
 	"io/fs"
 	"os"
 	"os/signal"
@@ -25,6 +26,12 @@ import (
 	"github.com/mutagen-io/mutagen/pkg/synchronization"
 )
 
+const (
+	// logHistoryCapacity is the maximum number of recent log lines that the
+	// daemon retains in memory for inclusion in diagnostic dumps.
+	logHistoryCapacity = 1000
+)
+
 // runMain is the entry point for the run command.
 func runMain(_ *cobra.Command, _ []string) error {
 	// Attempt to acquire the daemon lock and defer its release.
@@ -49,7 +56,10 @@ func runMain(_ *cobra.Command, _ []string) error {
 			logLevel = l
 		}
 	}
-	logger := logging.NewLogger(logLevel, os.Stderr)
+	// We retain recent log lines in memory so that they can be included in
+	// diagnostic dumps.
+	logHistory := logging.NewHistory(logHistoryCapacity)
+	logger := logging.NewLogger(logLevel, io.MultiWriter(logHistory, os.Stderr))
 
 	// Create a forwarding session manager and defer its shutdown.
 	forwardingManager, err := forwarding.NewManager(logger.Sublogger("forward"))
@@ -74,7 +84,7 @@ func runMain(_ *cobra.Command, _ []string) error {
 	defer server.Stop()
 
 	// Create the daemon server, defer its shutdown, and register it.
-	daemonServer := daemonsvc.NewServer()
+	daemonServer := daemonsvc.NewServer(forwardingManager, synchronizationManager, logHistory)
 	defer daemonServer.Shutdown()
 	daemonsvc.RegisterDaemonServer(server, daemonServer)
 
@@ -0,0 +1,83 @@
+package daemon
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/dustin/go-humanize"
+
+	"github.com/spf13/cobra"
+
+	"github.com/mutagen-io/mutagen/cmd"
+
+	"github.com/mutagen-io/mutagen/pkg/grpcutil"
+	daemonsvc "github.com/mutagen-io/mutagen/pkg/service/daemon"
+)
+
+// statsMain is the entry point for the stats command.
+func statsMain(_ *cobra.Command, _ []string) error {
+	// Connect to the daemon and defer closure of the connection. We don't
+	// autostart the daemon since there's nothing to report if it isn't running.
+	daemonConnection, err := Connect(false, true)
+	if err != nil {
+		return fmt.Errorf("unable to connect to daemon: %w", err)
+	}
+	defer daemonConnection.Close()
+
+	// Create a daemon service client.
+	daemonService := daemonsvc.NewDaemonClient(daemonConnection)
+
+	// Request statistics.
+	response, err := daemonService.Stats(context.Background(), &daemonsvc.StatsRequest{
+		IncludeDiskUsage: statsConfiguration.diskUsage,
+	})
+	if err != nil {
+		return grpcutil.PeelAwayRPCErrorLayer(err)
+	}
+
+	// Print statistics.
+	fmt.Println("Synchronization sessions:", response.SynchronizationSessions)
+	fmt.Println("Forwarding sessions:", response.ForwardingSessions)
+	fmt.Println("Tracked entries:", response.TrackedEntries)
+	fmt.Println("Approximate snapshot memory:", humanize.Bytes(response.SnapshotMemory))
+	if statsConfiguration.diskUsage {
+		fmt.Println("Staging disk usage:", humanize.Bytes(response.StagingDiskUsage))
+	}
+	fmt.Println("Connected synchronization endpoints:", response.SynchronizationConnections)
+	fmt.Println("Open forwarded connections:", response.ForwardingConnections)
+
+	// Success.
+	return nil
+}
+
+// statsCommand is the stats command.
+var statsCommand = &cobra.Command{
+	Use:          "stats",
+	Short:        "Show aggregate resource usage for the Mutagen daemon",
+	Args:         cmd.DisallowArguments,
+	RunE:         statsMain,
+	SilenceUsage: true,
+}
+
+// statsConfiguration stores configuration for the stats command.
+var statsConfiguration struct {
+	// help indicates whether or not to show help information and exit.
+	help bool
+	// diskUsage indicates whether or not to compute staging disk usage.
+	diskUsage bool
+}
+
+func init() {
+	// Grab a handle for the command line flags.
+	flags := statsCommand.Flags()
+
+	// Disable alphabetical sorting of flags in help output.
+	flags.SortFlags = false
+
+	// Manually add a help flag to override the default message. Cobra will
+	// still implement its logic automatically.
+	flags.BoolVarP(&statsConfiguration.help, "help", "h", false, "Show help information")
+
+	// Wire up stats flags.
+	flags.BoolVar(&statsConfiguration.diskUsage, "disk-usage", false, "Compute staging disk usage (may be expensive)")
+}
@@ -160,6 +160,24 @@ func createMain(_ *cobra.Command, arguments []string) error {
 		}
 	}
 
+	// Validate and convert TCP network mode specifications.
+	var tcpNetworkMode, tcpNetworkModeSource, tcpNetworkModeDestination forwarding.TCPNetworkMode
+	if createConfiguration.tcpNetworkMode != "" {
+		if err := tcpNetworkMode.UnmarshalText([]byte(createConfiguration.tcpNetworkMode)); err != nil {
+			return fmt.Errorf("unable to parse TCP network mode: %w", err)
+		}
+	}
+	if createConfiguration.tcpNetworkModeSource != "" {
+		if err := tcpNetworkModeSource.UnmarshalText([]byte(createConfiguration.tcpNetworkModeSource)); err != nil {
+			return fmt.Errorf("unable to parse TCP network mode for source: %w", err)
+		}
+	}
+	if createConfiguration.tcpNetworkModeDestination != "" {
+		if err := tcpNetworkModeDestination.UnmarshalText([]byte(createConfiguration.tcpNetworkModeDestination)); err != nil {
+			return fmt.Errorf("unable to parse TCP network mode for destination: %w", err)
+		}
+	}
+
 	// Validate and convert socket overwrite mode specifications.
 	var socketOverwriteMode, socketOverwriteModeSource, socketOverwriteModeDestination forwarding.SocketOverwriteMode
 	if createConfiguration.socketOverwriteMode != "" {
@@ -245,6 +263,8 @@ func createMain(_ *cobra.Command, arguments []string) error {
 	// Create the command line configuration and merge it into our cumulative
 	// configuration.
 	configuration = forwarding.MergeConfigurations(configuration, &forwarding.Configuration{
+		DrainTimeout:         createConfiguration.drainTimeout,
+		NetworkMode:          tcpNetworkMode,
 		SocketOverwriteMode:  socketOverwriteMode,
 		SocketOwner:          createConfiguration.socketOwner,
 		SocketGroup:          createConfiguration.socketGroup,
@@ -257,12 +277,14 @@ func createMain(_ *cobra.Command, arguments []string) error {
 		Destination:   destination,
 		Configuration: configuration,
 		ConfigurationSource: &forwarding.Configuration{
+			NetworkMode:          tcpNetworkModeSource,
 			SocketOverwriteMode:  socketOverwriteModeSource,
 			SocketOwner:          createConfiguration.socketOwnerSource,
 			SocketGroup:          createConfiguration.socketGroupSource,
 			SocketPermissionMode: uint32(socketPermissionModeSource),
 		},
 		ConfigurationDestination: &forwarding.Configuration{
+			NetworkMode:          tcpNetworkModeDestination,
 			SocketOverwriteMode:  socketOverwriteModeDestination,
 			SocketOwner:          createConfiguration.socketOwnerDestination,
 			SocketGroup:          createConfiguration.socketGroupDestination,
@@ -318,6 +340,18 @@ var createConfiguration struct {
 	// configurationFiles stores paths of additional files from which to load
 	// default configuration.
 	configurationFiles []string
+	// drainTimeout specifies the maximum amount of time (in milliseconds) to
+	// wait for existing connections to close when halting the session.
+	drainTimeout uint32
+	// tcpNetworkMode specifies the TCP network mode to use for the session.
+	tcpNetworkMode string
+	// tcpNetworkModeSource specifies the TCP network mode to use for the
+	// session, taking priority over tcpNetworkMode on source if specified.
+	tcpNetworkModeSource string
+	// tcpNetworkModeDestination specifies the TCP network mode to use for the
+	// session, taking priority over tcpNetworkMode on destination if
+	// specified.
+	tcpNetworkModeDestination string
 	// socketOverwriteMode specifies the socket overwrite mode to use for the
 	// session.
 	socketOverwriteMode string
@@ -387,6 +421,14 @@ func init() {
 	flags.BoolVar(&createConfiguration.noGlobalConfiguration, "no-global-configuration", false, "Ignore the global configuration file")
 	flags.StringSliceVarP(&createConfiguration.configurationFiles, "configuration-file", "c", nil, "Specify additional files from which to load (and merge) default configuration parameters")
 
+	// Wire up connection draining flags.
+	flags.Uint32Var(&createConfiguration.drainTimeout, "drain-timeout", 0, "Specify the maximum time (in milliseconds) to wait for existing connections to close when halting the session")
+
+	// Wire up TCP flags.
+	flags.StringVar(&createConfiguration.tcpNetworkMode, "tcp-network-mode", "", "Specify TCP network mode (auto|ipv4|ipv6)")
+	flags.StringVar(&createConfiguration.tcpNetworkModeSource, "tcp-network-mode-source", "", "Specify TCP network mode for source (auto|ipv4|ipv6)")
+	flags.StringVar(&createConfiguration.tcpNetworkModeDestination, "tcp-network-mode-destination", "", "Specify TCP network mode for destination (auto|ipv4|ipv6)")
+
 	// Wire up socket flags.
 	flags.StringVar(&createConfiguration.socketOverwriteMode, "socket-overwrite-mode", "", "Specify socket overwrite mode (leave|overwrite)")
 	flags.StringVar(&createConfiguration.socketOverwriteModeSource, "socket-overwrite-mode-source", "", "Specify socket overwrite mode for source (leave|overwrite)")
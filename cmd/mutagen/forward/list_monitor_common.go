@@ -35,6 +35,13 @@ func printEndpoint(name string, url *url.URL, configuration *forwarding.Configur
 		// Print configuration header.
 		fmt.Println("\tConfiguration:")
 
+		// Compute and print the TCP network mode.
+		tcpNetworkModeDescription := configuration.NetworkMode.Description()
+		if configuration.NetworkMode.IsDefault() {
+			tcpNetworkModeDescription += fmt.Sprintf(" (%s)", version.DefaultTCPNetworkMode().Description())
+		}
+		fmt.Println("\t\tTCP network mode:", tcpNetworkModeDescription)
+
 		// Compute and print the socket overwrite mode.
 		socketOverwriteModeDescription := configuration.SocketOverwriteMode.Description()
 		if configuration.SocketOverwriteMode.IsDefault() {
@@ -103,11 +110,17 @@ func printSession(state *forwarding.State, mode common.SessionDisplayMode) {
 			}
 		}
 
-		// Print the configuration header and configuration.
-		// TODO: Implement this in the future if we have any session-level
-		// configuration behaviors. We currently only have endpoint-level
-		// configuration behaviors and thus we don't print any session-level
-		// configuration information like we do for synchronization.
+		// Print the configuration header.
+		fmt.Println("Configuration:")
+
+		// Compute and print the drain timeout.
+		var drainTimeoutDescription string
+		if state.Session.Configuration.DrainTimeout == 0 {
+			drainTimeoutDescription = "Default (Immediate)"
+		} else {
+			drainTimeoutDescription = fmt.Sprintf("%d ms", state.Session.Configuration.DrainTimeout)
+		}
+		fmt.Println("\tDrain timeout:", drainTimeoutDescription)
 	}
 
 	// Compute and print source-specific configuration.
@@ -0,0 +1,93 @@
+package sync
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+
+	"github.com/spf13/cobra"
+
+	"github.com/mutagen-io/mutagen/cmd/mutagen/daemon"
+
+	"github.com/mutagen-io/mutagen/pkg/grpcutil"
+	"github.com/mutagen-io/mutagen/pkg/selection"
+	synchronizationsvc "github.com/mutagen-io/mutagen/pkg/service/synchronization"
+)
+
+// catMain is the entry point for the cat command.
+func catMain(_ *cobra.Command, arguments []string) error {
+	// Validate arguments.
+	if len(arguments) != 2 {
+		return errors.New("session and path must both be specified")
+	}
+
+	// Create session selection specification.
+	selection := &selection.Selection{
+		Specifications: arguments[:1],
+	}
+	if err := selection.EnsureValid(); err != nil {
+		return fmt.Errorf("invalid session selection specification: %w", err)
+	}
+
+	// Connect to the daemon and defer closure of the connection.
+	daemonConnection, err := daemon.Connect(true, true)
+	if err != nil {
+		return fmt.Errorf("unable to connect to daemon: %w", err)
+	}
+	defer daemonConnection.Close()
+
+	// Perform the fetch operation.
+	synchronizationService := synchronizationsvc.NewSynchronizationClient(daemonConnection)
+	request := &synchronizationsvc.FetchRequest{
+		Selection: selection,
+		Beta:      catConfiguration.beta,
+		Path:      arguments[1],
+	}
+	response, err := synchronizationService.Fetch(context.Background(), request)
+	if err != nil {
+		return grpcutil.PeelAwayRPCErrorLayer(err)
+	} else if err = response.EnsureValid(); err != nil {
+		return fmt.Errorf("invalid fetch response received: %w", err)
+	}
+
+	// Write the content to standard output.
+	if _, err := os.Stdout.Write(response.Content); err != nil {
+		return fmt.Errorf("unable to write content: %w", err)
+	}
+
+	// Success.
+	return nil
+}
+
+// catCommand is the cat command.
+var catCommand = &cobra.Command{
+	Use:          "cat <session> <path>",
+	Short:        "Print the content of a file on a session endpoint",
+	RunE:         catMain,
+	SilenceUsage: true,
+}
+
+// catConfiguration stores configuration for the cat command.
+var catConfiguration struct {
+	// help indicates whether or not to show help information and exit.
+	help bool
+	// beta indicates whether or not the file should be fetched from beta
+	// (rather than alpha).
+	beta bool
+}
+
+func init() {
+	// Grab a handle for the command line flags.
+	flags := catCommand.Flags()
+
+	// Disable alphabetical sorting of flags in help output.
+	flags.SortFlags = false
+
+	// Manually add a help flag to override the default message. Cobra will
+	// still implement its logic automatically.
+	flags.BoolVarP(&catConfiguration.help, "help", "h", false, "Show help information")
+
+	// Wire up cat flags.
+	flags.BoolVar(&catConfiguration.beta, "beta", false, "Fetch the file from beta (rather than alpha)")
+}
@@ -0,0 +1,112 @@
+package sync
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+
+	"github.com/spf13/cobra"
+
+	"github.com/mutagen-io/mutagen/cmd"
+	"github.com/mutagen-io/mutagen/cmd/mutagen/daemon"
+
+	synchronizationmodels "github.com/mutagen-io/mutagen/pkg/api/models/synchronization"
+	"github.com/mutagen-io/mutagen/pkg/grpcutil"
+	"github.com/mutagen-io/mutagen/pkg/selection"
+	synchronizationsvc "github.com/mutagen-io/mutagen/pkg/service/synchronization"
+)
+
+// conflictsMain is the entry point for the conflicts command.
+func conflictsMain(_ *cobra.Command, arguments []string) error {
+	// Validate arguments.
+	if len(arguments) != 1 {
+		return errors.New("a single session must be specified")
+	}
+
+	// Create session selection specification.
+	selection := &selection.Selection{
+		Specifications: arguments,
+	}
+	if err := selection.EnsureValid(); err != nil {
+		return fmt.Errorf("invalid session selection specification: %w", err)
+	}
+
+	// Connect to the daemon and defer closure of the connection.
+	daemonConnection, err := daemon.Connect(true, true)
+	if err != nil {
+		return fmt.Errorf("unable to connect to daemon: %w", err)
+	}
+	defer daemonConnection.Close()
+
+	// Perform the list operation.
+	synchronizationService := synchronizationsvc.NewSynchronizationClient(daemonConnection)
+	request := &synchronizationsvc.ListRequest{
+		Selection: selection,
+	}
+	response, err := synchronizationService.List(context.Background(), request)
+	if err != nil {
+		return grpcutil.PeelAwayRPCErrorLayer(err)
+	} else if err = response.EnsureValid(); err != nil {
+		return fmt.Errorf("invalid list response received: %w", err)
+	} else if len(response.SessionStates) != 1 {
+		return errors.New("invalid number of sessions returned")
+	}
+	state := response.SessionStates[0]
+
+	// If an export has been requested, then encode a conflict resolution
+	// manifest to standard output.
+	if conflictsConfiguration.export {
+		encoder := json.NewEncoder(os.Stdout)
+		encoder.SetIndent("", "  ")
+		if err := encoder.Encode(synchronizationmodels.ExportConflictResolutions(state)); err != nil {
+			return fmt.Errorf("unable to encode conflict resolution manifest: %w", err)
+		}
+		return nil
+	}
+
+	// Otherwise print the conflicts.
+	fmt.Println(cmd.DelimiterLine)
+	if len(state.Conflicts) > 0 {
+		printConflicts(state.Conflicts, state.ExcludedConflicts)
+	} else {
+		fmt.Println("No conflicts found")
+	}
+	fmt.Println(cmd.DelimiterLine)
+
+	// Success.
+	return nil
+}
+
+// conflictsCommand is the conflicts command.
+var conflictsCommand = &cobra.Command{
+	Use:          "conflicts <session>",
+	Short:        "Show or export the conflicts for a synchronization session",
+	RunE:         conflictsMain,
+	SilenceUsage: true,
+}
+
+// conflictsConfiguration stores configuration for the conflicts command.
+var conflictsConfiguration struct {
+	// help indicates whether or not to show help information and exit.
+	help bool
+	// export indicates whether or not conflicts should be exported as a
+	// conflict resolution manifest.
+	export bool
+}
+
+func init() {
+	// Grab a handle for the command line flags.
+	flags := conflictsCommand.Flags()
+
+	// Disable alphabetical sorting of flags in help output.
+	flags.SortFlags = false
+
+	// Manually add a help flag to override the default message. Cobra will
+	// still implement its logic automatically.
+	flags.BoolVarP(&conflictsConfiguration.help, "help", "h", false, "Show help information")
+
+	// Wire up conflicts flags.
+	flags.BoolVar(&conflictsConfiguration.export, "export", false, "Export conflicts and their resolutions as a JSON manifest")
+}
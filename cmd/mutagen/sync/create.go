@@ -53,6 +53,32 @@ func loadAndValidateGlobalSynchronizationConfiguration(path string) (*synchroniz
 	return configuration, nil
 }
 
+// loadManifest loads a synchronization manifest from a file containing one path
+// per line. Empty lines and lines beginning with '#' are skipped. The resulting
+// paths are validated.
+func loadManifest(path string) ([]string, error) {
+	// Read the manifest file.
+	contents, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("unable to read manifest file: %w", err)
+	}
+
+	// Parse and validate paths.
+	var manifest []string
+	for _, line := range strings.Split(string(contents), "\n") {
+		line = strings.TrimSpace(line)
+		if line == "" || line[0] == '#' {
+			continue
+		} else if err := ignore.EnsureManifestPathValid(line); err != nil {
+			return nil, fmt.Errorf("invalid manifest path (%s): %w", line, err)
+		}
+		manifest = append(manifest, line)
+	}
+
+	// Success.
+	return manifest, nil
+}
+
 // CreateWithSpecification is an orchestration convenience method that performs
 // a create operation using the provided daemon connection and session
 // specification.
@@ -176,6 +202,14 @@ func createMain(_ *cobra.Command, arguments []string) error {
 		}
 	}
 
+	// Validate and convert the type change mode specification.
+	var typeChangeMode core.TypeChangeMode
+	if createConfiguration.typeChangeMode != "" {
+		if err := typeChangeMode.UnmarshalText([]byte(createConfiguration.typeChangeMode)); err != nil {
+			return fmt.Errorf("unable to parse type change mode: %w", err)
+		}
+	}
+
 	// Validate and convert the hashing algorithm specification.
 	var hashingAlgorithm hashing.Algorithm
 	if createConfiguration.hash != "" {
@@ -187,8 +221,8 @@ func createMain(_ *cobra.Command, arguments []string) error {
 	// There's no need to validate the maximum entry count - any uint64 value is
 	// valid.
 
-	// Validate and convert the maximum staging file size.
-	var maximumStagingFileSize uint64
+	// Validate and convert the maximum staging file sizes.
+	var maximumStagingFileSize, maximumStagingFileSizeAlpha, maximumStagingFileSizeBeta uint64
 	if createConfiguration.maximumStagingFileSize != "" {
 		if s, err := humanize.ParseBytes(createConfiguration.maximumStagingFileSize); err != nil {
 			return fmt.Errorf("unable to parse maximum staging file size: %w", err)
@@ -196,6 +230,96 @@ func createMain(_ *cobra.Command, arguments []string) error {
 			maximumStagingFileSize = s
 		}
 	}
+	if createConfiguration.maximumStagingFileSizeAlpha != "" {
+		if s, err := humanize.ParseBytes(createConfiguration.maximumStagingFileSizeAlpha); err != nil {
+			return fmt.Errorf("unable to parse maximum staging file size for alpha: %w", err)
+		} else {
+			maximumStagingFileSizeAlpha = s
+		}
+	}
+	if createConfiguration.maximumStagingFileSizeBeta != "" {
+		if s, err := humanize.ParseBytes(createConfiguration.maximumStagingFileSizeBeta); err != nil {
+			return fmt.Errorf("unable to parse maximum staging file size for beta: %w", err)
+		} else {
+			maximumStagingFileSizeBeta = s
+		}
+	}
+
+	// Validate and convert the rsync block size.
+	var rsyncBlockSize uint64
+	if createConfiguration.rsyncBlockSize != "" {
+		if s, err := humanize.ParseBytes(createConfiguration.rsyncBlockSize); err != nil {
+			return fmt.Errorf("unable to parse rsync block size: %w", err)
+		} else {
+			rsyncBlockSize = s
+		}
+	}
+
+	// Validate and convert the digest sampling threshold.
+	var digestSamplingThreshold uint64
+	if createConfiguration.digestSamplingThreshold != "" {
+		if s, err := humanize.ParseBytes(createConfiguration.digestSamplingThreshold); err != nil {
+			return fmt.Errorf("unable to parse digest sampling threshold: %w", err)
+		} else {
+			digestSamplingThreshold = s
+		}
+	}
+
+	// Validate and convert the oversized file mode.
+	var oversizedFileMode synchronization.OversizedFileMode
+	if createConfiguration.oversizedFileMode != "" {
+		if err := oversizedFileMode.UnmarshalText([]byte(createConfiguration.oversizedFileMode)); err != nil {
+			return fmt.Errorf("unable to parse oversized file mode: %w", err)
+		}
+	}
+
+	// Validate and convert the maximum rename detection file size.
+	var maximumRenameDetectionFileSize uint64
+	if createConfiguration.maximumRenameDetectionFileSize != "" {
+		if s, err := humanize.ParseBytes(createConfiguration.maximumRenameDetectionFileSize); err != nil {
+			return fmt.Errorf("unable to parse maximum rename detection file size: %w", err)
+		} else {
+			maximumRenameDetectionFileSize = s
+		}
+	}
+
+	// Validate and convert the maximum content cache size.
+	var maximumContentCacheSize uint64
+	if createConfiguration.maximumContentCacheSize != "" {
+		if s, err := humanize.ParseBytes(createConfiguration.maximumContentCacheSize); err != nil {
+			return fmt.Errorf("unable to parse maximum content cache size: %w", err)
+		} else {
+			maximumContentCacheSize = s
+		}
+	}
+
+	// Validate and convert the staging concurrency mode specification.
+	var stagingConcurrencyMode synchronization.StagingConcurrencyMode
+	if createConfiguration.stagingConcurrencyMode != "" {
+		if err := stagingConcurrencyMode.UnmarshalText([]byte(createConfiguration.stagingConcurrencyMode)); err != nil {
+			return fmt.Errorf("unable to parse staging concurrency mode: %w", err)
+		}
+	}
+
+	// Validate and convert the maximum signature memory.
+	var maximumSignatureMemory uint64
+	if createConfiguration.maximumSignatureMemory != "" {
+		if s, err := humanize.ParseBytes(createConfiguration.maximumSignatureMemory); err != nil {
+			return fmt.Errorf("unable to parse maximum signature memory: %w", err)
+		} else {
+			maximumSignatureMemory = s
+		}
+	}
+
+	// Validate and convert the maximum in-flight bytes.
+	var maximumInFlightBytes uint64
+	if createConfiguration.maximumInFlightBytes != "" {
+		if s, err := humanize.ParseBytes(createConfiguration.maximumInFlightBytes); err != nil {
+			return fmt.Errorf("unable to parse maximum in-flight bytes: %w", err)
+		} else {
+			maximumInFlightBytes = s
+		}
+	}
 
 	// Validate and convert probe mode specifications.
 	var probeMode, probeModeAlpha, probeModeBeta behavior.ProbeMode
@@ -233,6 +357,42 @@ func createMain(_ *cobra.Command, arguments []string) error {
 		}
 	}
 
+	// Validate and convert scan sharing mode specifications.
+	var scanSharingMode, scanSharingModeAlpha, scanSharingModeBeta synchronization.ScanSharingMode
+	if createConfiguration.scanSharingMode != "" {
+		if err := scanSharingMode.UnmarshalText([]byte(createConfiguration.scanSharingMode)); err != nil {
+			return fmt.Errorf("unable to parse scan sharing mode: %w", err)
+		}
+	}
+	if createConfiguration.scanSharingModeAlpha != "" {
+		if err := scanSharingModeAlpha.UnmarshalText([]byte(createConfiguration.scanSharingModeAlpha)); err != nil {
+			return fmt.Errorf("unable to parse scan sharing mode for alpha: %w", err)
+		}
+	}
+	if createConfiguration.scanSharingModeBeta != "" {
+		if err := scanSharingModeBeta.UnmarshalText([]byte(createConfiguration.scanSharingModeBeta)); err != nil {
+			return fmt.Errorf("unable to parse scan sharing mode for beta: %w", err)
+		}
+	}
+
+	// Validate and convert aggregate digest mode specifications.
+	var aggregateDigestMode, aggregateDigestModeAlpha, aggregateDigestModeBeta synchronization.AggregateDigestMode
+	if createConfiguration.aggregateDigestMode != "" {
+		if err := aggregateDigestMode.UnmarshalText([]byte(createConfiguration.aggregateDigestMode)); err != nil {
+			return fmt.Errorf("unable to parse aggregate digest mode: %w", err)
+		}
+	}
+	if createConfiguration.aggregateDigestModeAlpha != "" {
+		if err := aggregateDigestModeAlpha.UnmarshalText([]byte(createConfiguration.aggregateDigestModeAlpha)); err != nil {
+			return fmt.Errorf("unable to parse aggregate digest mode for alpha: %w", err)
+		}
+	}
+	if createConfiguration.aggregateDigestModeBeta != "" {
+		if err := aggregateDigestModeBeta.UnmarshalText([]byte(createConfiguration.aggregateDigestModeBeta)); err != nil {
+			return fmt.Errorf("unable to parse aggregate digest mode for beta: %w", err)
+		}
+	}
+
 	// Validate and convert staging mode specifications.
 	var stageMode, stageModeAlpha, stageModeBeta synchronization.StageMode
 	if createConfiguration.stageMode != "" {
@@ -251,6 +411,24 @@ func createMain(_ *cobra.Command, arguments []string) error {
 		}
 	}
 
+	// Validate and convert transition mode specifications.
+	var transitionMode, transitionModeAlpha, transitionModeBeta core.TransitionMode
+	if createConfiguration.transitionMode != "" {
+		if err := transitionMode.UnmarshalText([]byte(createConfiguration.transitionMode)); err != nil {
+			return fmt.Errorf("unable to parse transition mode: %w", err)
+		}
+	}
+	if createConfiguration.transitionModeAlpha != "" {
+		if err := transitionModeAlpha.UnmarshalText([]byte(createConfiguration.transitionModeAlpha)); err != nil {
+			return fmt.Errorf("unable to parse transition mode for alpha: %w", err)
+		}
+	}
+	if createConfiguration.transitionModeBeta != "" {
+		if err := transitionModeBeta.UnmarshalText([]byte(createConfiguration.transitionModeBeta)); err != nil {
+			return fmt.Errorf("unable to parse transition mode for beta: %w", err)
+		}
+	}
+
 	// Validate and convert the symbolic link mode specification.
 	var symbolicLinkMode core.SymbolicLinkMode
 	if createConfiguration.symbolicLinkMode != "" {
@@ -259,6 +437,89 @@ func createMain(_ *cobra.Command, arguments []string) error {
 		}
 	}
 
+	// Validate and convert the symbolic link replacement mode specification.
+	var symbolicLinkReplacementMode core.SymbolicLinkReplacementMode
+	if createConfiguration.symbolicLinkReplacementMode != "" {
+		if err := symbolicLinkReplacementMode.UnmarshalText([]byte(createConfiguration.symbolicLinkReplacementMode)); err != nil {
+			return fmt.Errorf("unable to parse symbolic link replacement mode: %w", err)
+		}
+	}
+
+	// Validate and convert the symbolic link cycle mode specification.
+	var symbolicLinkCycleMode core.SymbolicLinkCycleMode
+	if createConfiguration.symbolicLinkCycleMode != "" {
+		if err := symbolicLinkCycleMode.UnmarshalText([]byte(createConfiguration.symbolicLinkCycleMode)); err != nil {
+			return fmt.Errorf("unable to parse symbolic link cycle mode: %w", err)
+		}
+	}
+
+	// Validate and convert the special file mode specification.
+	var specialFileMode core.SpecialFileMode
+	if createConfiguration.specialFileMode != "" {
+		if err := specialFileMode.UnmarshalText([]byte(createConfiguration.specialFileMode)); err != nil {
+			return fmt.Errorf("unable to parse special file mode: %w", err)
+		}
+	}
+
+	// Validate and convert the cache trust mode specification.
+	var cacheTrustMode core.CacheTrustMode
+	if createConfiguration.cacheTrustMode != "" {
+		if err := cacheTrustMode.UnmarshalText([]byte(createConfiguration.cacheTrustMode)); err != nil {
+			return fmt.Errorf("unable to parse cache trust mode: %w", err)
+		}
+	}
+
+	// Validate and convert the name normalization mode specification.
+	var nameNormalizationMode core.NameNormalizationMode
+	if createConfiguration.nameNormalizationMode != "" {
+		if err := nameNormalizationMode.UnmarshalText([]byte(createConfiguration.nameNormalizationMode)); err != nil {
+			return fmt.Errorf("unable to parse name normalization mode: %w", err)
+		}
+	}
+
+	// Validate and convert the capability mismatch mode specification.
+	var capabilityMismatchMode synchronization.CapabilityMismatchMode
+	if createConfiguration.capabilityMismatchMode != "" {
+		if err := capabilityMismatchMode.UnmarshalText([]byte(createConfiguration.capabilityMismatchMode)); err != nil {
+			return fmt.Errorf("unable to parse capability mismatch mode: %w", err)
+		}
+	}
+
+	// Validate and convert the root overlap mode specification.
+	var rootOverlapMode synchronization.RootOverlapMode
+	if createConfiguration.rootOverlapMode != "" {
+		if err := rootOverlapMode.UnmarshalText([]byte(createConfiguration.rootOverlapMode)); err != nil {
+			return fmt.Errorf("unable to parse root overlap mode: %w", err)
+		}
+	}
+
+	// Validate and convert the configuration incompatibility mode
+	// specification.
+	var configurationIncompatibilityMode synchronization.ConfigurationIncompatibilityMode
+	if createConfiguration.configurationIncompatibilityMode != "" {
+		if err := configurationIncompatibilityMode.UnmarshalText([]byte(createConfiguration.configurationIncompatibilityMode)); err != nil {
+			return fmt.Errorf("unable to parse configuration incompatibility mode: %w", err)
+		}
+	}
+
+	// Validate and convert root existence mode specifications.
+	var rootExistenceMode, rootExistenceModeAlpha, rootExistenceModeBeta synchronization.RootExistenceMode
+	if createConfiguration.rootExistenceMode != "" {
+		if err := rootExistenceMode.UnmarshalText([]byte(createConfiguration.rootExistenceMode)); err != nil {
+			return fmt.Errorf("unable to parse root existence mode: %w", err)
+		}
+	}
+	if createConfiguration.rootExistenceModeAlpha != "" {
+		if err := rootExistenceModeAlpha.UnmarshalText([]byte(createConfiguration.rootExistenceModeAlpha)); err != nil {
+			return fmt.Errorf("unable to parse root existence mode for alpha: %w", err)
+		}
+	}
+	if createConfiguration.rootExistenceModeBeta != "" {
+		if err := rootExistenceModeBeta.UnmarshalText([]byte(createConfiguration.rootExistenceModeBeta)); err != nil {
+			return fmt.Errorf("unable to parse root existence mode for beta: %w", err)
+		}
+	}
+
 	// Validate and convert watch mode specifications.
 	var watchMode, watchModeAlpha, watchModeBeta synchronization.WatchMode
 	if createConfiguration.watchMode != "" {
@@ -277,6 +538,32 @@ func createMain(_ *cobra.Command, arguments []string) error {
 		}
 	}
 
+	// Validate and convert initial poll modification mode specifications.
+	var initialPollModificationMode, initialPollModificationModeAlpha, initialPollModificationModeBeta synchronization.InitialPollModificationMode
+	if createConfiguration.initialPollModificationMode != "" {
+		if err := initialPollModificationMode.UnmarshalText([]byte(createConfiguration.initialPollModificationMode)); err != nil {
+			return fmt.Errorf("unable to parse initial poll modification mode: %w", err)
+		}
+	}
+	if createConfiguration.initialPollModificationModeAlpha != "" {
+		if err := initialPollModificationModeAlpha.UnmarshalText([]byte(createConfiguration.initialPollModificationModeAlpha)); err != nil {
+			return fmt.Errorf("unable to parse initial poll modification mode for alpha: %w", err)
+		}
+	}
+	if createConfiguration.initialPollModificationModeBeta != "" {
+		if err := initialPollModificationModeBeta.UnmarshalText([]byte(createConfiguration.initialPollModificationModeBeta)); err != nil {
+			return fmt.Errorf("unable to parse initial poll modification mode for beta: %w", err)
+		}
+	}
+
+	// Validate and convert the initial synchronization mode specification.
+	var initialSynchronizationMode synchronization.InitialSynchronizationMode
+	if createConfiguration.initialSynchronizationMode != "" {
+		if err := initialSynchronizationMode.UnmarshalText([]byte(createConfiguration.initialSynchronizationMode)); err != nil {
+			return fmt.Errorf("unable to parse initial synchronization mode: %w", err)
+		}
+	}
+
 	// There's no need to validate the watch polling intervals - any uint32
 	// values are valid.
 
@@ -305,6 +592,78 @@ func createMain(_ *cobra.Command, arguments []string) error {
 		ignoreVCSMode = ignore.IgnoreVCSMode_IgnoreVCSModePropagate
 	}
 
+	// Validate and convert the OS metadata ignore mode specification.
+	var ignoreOSMetadataMode ignore.IgnoreOSMetadataMode
+	if createConfiguration.ignoreOSMetadata && createConfiguration.noIgnoreOSMetadata {
+		return errors.New("conflicting OS metadata ignore behavior specified")
+	} else if createConfiguration.ignoreOSMetadata {
+		ignoreOSMetadataMode = ignore.IgnoreOSMetadataMode_IgnoreOSMetadataModeIgnore
+	} else if createConfiguration.noIgnoreOSMetadata {
+		ignoreOSMetadataMode = ignore.IgnoreOSMetadataMode_IgnoreOSMetadataModePropagate
+	}
+
+	// Parse the maximum file size.
+	var maximumFileSize uint64
+	if createConfiguration.maximumFileSize != "" {
+		if s, err := humanize.ParseBytes(createConfiguration.maximumFileSize); err != nil {
+			return fmt.Errorf("unable to parse maximum file size: %w", err)
+		} else {
+			maximumFileSize = s
+		}
+	}
+
+	// Validate and convert the phantom directory mode specification.
+	var phantomDirectoryMode core.PhantomDirectoryMode
+	if createConfiguration.phantomDirectoryMode != "" {
+		if err := phantomDirectoryMode.UnmarshalText([]byte(createConfiguration.phantomDirectoryMode)); err != nil {
+			return fmt.Errorf("unable to parse phantom directory mode: %w", err)
+		}
+	}
+
+	// Validate and convert the verification mode specification.
+	var verificationMode synchronization.VerificationMode
+	if createConfiguration.verificationMode != "" {
+		if err := verificationMode.UnmarshalText([]byte(createConfiguration.verificationMode)); err != nil {
+			return fmt.Errorf("unable to parse verification mode: %w", err)
+		}
+	}
+
+	// Load the manifest, if specified.
+	var manifest []string
+	if createConfiguration.manifest != "" {
+		if m, err := loadManifest(createConfiguration.manifest); err != nil {
+			return err
+		} else if len(m) == 0 {
+			return errors.New("manifest file contains no paths")
+		} else {
+			manifest = m
+		}
+	}
+
+	// Validate and normalize the scan subpaths.
+	var scanSubpaths, scanSubpathsAlpha, scanSubpathsBeta []string
+	if len(createConfiguration.scanSubpaths) > 0 {
+		if s, err := synchronization.NormalizeScope(createConfiguration.scanSubpaths); err != nil {
+			return fmt.Errorf("unable to parse scan subpaths: %w", err)
+		} else {
+			scanSubpaths = s
+		}
+	}
+	if len(createConfiguration.scanSubpathsAlpha) > 0 {
+		if s, err := synchronization.NormalizeScope(createConfiguration.scanSubpathsAlpha); err != nil {
+			return fmt.Errorf("unable to parse scan subpaths for alpha: %w", err)
+		} else {
+			scanSubpathsAlpha = s
+		}
+	}
+	if len(createConfiguration.scanSubpathsBeta) > 0 {
+		if s, err := synchronization.NormalizeScope(createConfiguration.scanSubpathsBeta); err != nil {
+			return fmt.Errorf("unable to parse scan subpaths for beta: %w", err)
+		} else {
+			scanSubpathsBeta = s
+		}
+	}
+
 	// Validate and convert the permissions mode specification.
 	var permissionsMode core.PermissionsMode
 	if createConfiguration.permissionsMode != "" {
@@ -437,29 +796,145 @@ func createMain(_ *cobra.Command, arguments []string) error {
 			return fmt.Errorf("unable to parse compression algorithm for beta: %w", err)
 		}
 	}
+	var stagingCompressionAlgorithm compression.Algorithm
+	if createConfiguration.stagingCompression != "" {
+		if err := stagingCompressionAlgorithm.UnmarshalText([]byte(createConfiguration.stagingCompression)); err != nil {
+			return fmt.Errorf("unable to parse staging compression algorithm: %w", err)
+		}
+	}
+
+	// Validate and convert clock skew mode specifications.
+	var clockSkewMode, clockSkewModeAlpha, clockSkewModeBeta synchronization.ClockSkewMode
+	if createConfiguration.clockSkewMode != "" {
+		if err := clockSkewMode.UnmarshalText([]byte(createConfiguration.clockSkewMode)); err != nil {
+			return fmt.Errorf("unable to parse clock skew mode: %w", err)
+		}
+	}
+	if createConfiguration.clockSkewModeAlpha != "" {
+		if err := clockSkewModeAlpha.UnmarshalText([]byte(createConfiguration.clockSkewModeAlpha)); err != nil {
+			return fmt.Errorf("unable to parse clock skew mode for alpha: %w", err)
+		}
+	}
+	if createConfiguration.clockSkewModeBeta != "" {
+		if err := clockSkewModeBeta.UnmarshalText([]byte(createConfiguration.clockSkewModeBeta)); err != nil {
+			return fmt.Errorf("unable to parse clock skew mode for beta: %w", err)
+		}
+	}
+
+	// Validate and convert executability preservation assumption
+	// specifications.
+	var assumeExecutabilityPreservation, assumeExecutabilityPreservationAlpha, assumeExecutabilityPreservationBeta behavior.ProbeAssumption
+	if createConfiguration.assumeExecutabilityPreservation != "" {
+		if err := assumeExecutabilityPreservation.UnmarshalText([]byte(createConfiguration.assumeExecutabilityPreservation)); err != nil {
+			return fmt.Errorf("unable to parse executability preservation assumption: %w", err)
+		}
+	}
+	if createConfiguration.assumeExecutabilityPreservationAlpha != "" {
+		if err := assumeExecutabilityPreservationAlpha.UnmarshalText([]byte(createConfiguration.assumeExecutabilityPreservationAlpha)); err != nil {
+			return fmt.Errorf("unable to parse executability preservation assumption for alpha: %w", err)
+		}
+	}
+	if createConfiguration.assumeExecutabilityPreservationBeta != "" {
+		if err := assumeExecutabilityPreservationBeta.UnmarshalText([]byte(createConfiguration.assumeExecutabilityPreservationBeta)); err != nil {
+			return fmt.Errorf("unable to parse executability preservation assumption for beta: %w", err)
+		}
+	}
+
+	// Validate and convert Unicode decomposition assumption specifications.
+	var assumeUnicodeDecomposition, assumeUnicodeDecompositionAlpha, assumeUnicodeDecompositionBeta behavior.ProbeAssumption
+	if createConfiguration.assumeUnicodeDecomposition != "" {
+		if err := assumeUnicodeDecomposition.UnmarshalText([]byte(createConfiguration.assumeUnicodeDecomposition)); err != nil {
+			return fmt.Errorf("unable to parse Unicode decomposition assumption: %w", err)
+		}
+	}
+	if createConfiguration.assumeUnicodeDecompositionAlpha != "" {
+		if err := assumeUnicodeDecompositionAlpha.UnmarshalText([]byte(createConfiguration.assumeUnicodeDecompositionAlpha)); err != nil {
+			return fmt.Errorf("unable to parse Unicode decomposition assumption for alpha: %w", err)
+		}
+	}
+	if createConfiguration.assumeUnicodeDecompositionBeta != "" {
+		if err := assumeUnicodeDecompositionBeta.UnmarshalText([]byte(createConfiguration.assumeUnicodeDecompositionBeta)); err != nil {
+			return fmt.Errorf("unable to parse Unicode decomposition assumption for beta: %w", err)
+		}
+	}
 
 	// Create the command line configuration and merge it into our cumulative
 	// configuration.
 	configuration = synchronization.MergeConfigurations(configuration, &synchronization.Configuration{
-		SynchronizationMode:    synchronizationMode,
-		HashingAlgorithm:       hashingAlgorithm,
-		MaximumEntryCount:      createConfiguration.maximumEntryCount,
-		MaximumStagingFileSize: maximumStagingFileSize,
-		ProbeMode:              probeMode,
-		ScanMode:               scanMode,
-		StageMode:              stageMode,
-		SymbolicLinkMode:       symbolicLinkMode,
-		WatchMode:              watchMode,
-		WatchPollingInterval:   createConfiguration.watchPollingInterval,
-		IgnoreSyntax:           ignoreSyntax,
-		Ignores:                createConfiguration.ignores,
-		IgnoreVCSMode:          ignoreVCSMode,
-		PermissionsMode:        permissionsMode,
-		DefaultFileMode:        uint32(defaultFileMode),
-		DefaultDirectoryMode:   uint32(defaultDirectoryMode),
-		DefaultOwner:           createConfiguration.defaultOwner,
-		DefaultGroup:           createConfiguration.defaultGroup,
-		CompressionAlgorithm:   compressionAlgorithm,
+		SynchronizationMode:              synchronizationMode,
+		TypeChangeMode:                   typeChangeMode,
+		HashingAlgorithm:                 hashingAlgorithm,
+		MaximumEntryCount:                createConfiguration.maximumEntryCount,
+		MaximumStagingFileSize:           maximumStagingFileSize,
+		OversizedFileMode:                oversizedFileMode,
+		MaximumRenameDetectionFileSize:   maximumRenameDetectionFileSize,
+		MaximumContentCacheSize:          maximumContentCacheSize,
+		StagingConcurrencyMode:           stagingConcurrencyMode,
+		MaximumDeltificationTime:         createConfiguration.maximumDeltificationTime,
+		MaximumStagedContentAge:          createConfiguration.maximumStagedContentAge,
+		RsyncBlockSize:                   rsyncBlockSize,
+		MaximumSignatureMemory:           maximumSignatureMemory,
+		MaximumInFlightBytes:             maximumInFlightBytes,
+		RenameDetectionDigestLength:      createConfiguration.renameDetectionDigestLength,
+		RenameDetectionRetries:           createConfiguration.renameDetectionRetries,
+		MaximumConflictCount:             createConfiguration.maximumConflictCount,
+		MaximumConflictPersistence:       createConfiguration.maximumConflictPersistence,
+		WatchdogTimeout:                  createConfiguration.watchdogTimeout,
+		AgentTerminationReconnectDelay:   createConfiguration.agentTerminationReconnectDelay,
+		MaximumBetaScanDeferral:          createConfiguration.maximumBetaScanDeferral,
+		ProbeMode:                        probeMode,
+		ScanMode:                         scanMode,
+		ScanSharingMode:                  scanSharingMode,
+		AggregateDigestMode:              aggregateDigestMode,
+		DirectoryListingRetries:          createConfiguration.directoryListingRetries,
+		SlowDirectoryListingThreshold:    createConfiguration.slowDirectoryListingThreshold,
+		CacheSaveThreshold:               createConfiguration.cacheSaveThreshold,
+		MaximumRecheckPaths:              createConfiguration.maximumRecheckPaths,
+		ScanSubpaths:                     scanSubpaths,
+		DigestSamplingThreshold:          digestSamplingThreshold,
+		DigestLength:                     createConfiguration.digestLength,
+		CacheTrustMode:                   cacheTrustMode,
+		NameNormalizationMode:            nameNormalizationMode,
+		CapabilityMismatchMode:           capabilityMismatchMode,
+		RootOverlapMode:                  rootOverlapMode,
+		ConfigurationIncompatibilityMode: configurationIncompatibilityMode,
+		RootExistenceMode:                rootExistenceMode,
+		StageMode:                        stageMode,
+		TransitionMode:                   transitionMode,
+		SymbolicLinkMode:                 symbolicLinkMode,
+		SymbolicLinkReplacementMode:      symbolicLinkReplacementMode,
+		SymbolicLinkCycleMode:            symbolicLinkCycleMode,
+		SpecialFileMode:                  specialFileMode,
+		WatchMode:                        watchMode,
+		WatchPollingInterval:             createConfiguration.watchPollingInterval,
+		WatchQueueSize:                   createConfiguration.watchQueueSize,
+		InitialPollModificationMode:      initialPollModificationMode,
+		InitialSynchronizationMode:       initialSynchronizationMode,
+		IgnoreSyntax:                     ignoreSyntax,
+		Ignores:                          createConfiguration.ignores,
+		IgnoreVCSMode:                    ignoreVCSMode,
+		IgnoreOSMetadataMode:             ignoreOSMetadataMode,
+		Manifest:                         manifest,
+		PhantomDirectoryMode:             phantomDirectoryMode,
+		MaximumFileSize:                  maximumFileSize,
+		PermissionsMode:                  permissionsMode,
+		DefaultFileMode:                  uint32(defaultFileMode),
+		DefaultDirectoryMode:             uint32(defaultDirectoryMode),
+		DefaultOwner:                     createConfiguration.defaultOwner,
+		DefaultGroup:                     createConfiguration.defaultGroup,
+		CompressionAlgorithm:             compressionAlgorithm,
+		StagingCompressionAlgorithm:      stagingCompressionAlgorithm,
+		StreamConcurrency:                createConfiguration.streamConcurrency,
+		ClockSkewMode:                    clockSkewMode,
+		ClockSkewTolerance:               createConfiguration.clockSkewTolerance,
+		ProbeDirectory:                   createConfiguration.probeDirectory,
+		AssumeExecutabilityPreservation:  assumeExecutabilityPreservation,
+		AssumeUnicodeDecomposition:       assumeUnicodeDecomposition,
+		OverlayBase:                      createConfiguration.overlayBase,
+		VerificationMode:                 verificationMode,
+		VerificationInterval:             createConfiguration.verificationInterval,
+		MaximumReportedProblems:          createConfiguration.maximumReportedProblems,
+		MaximumReconciliationRecordings:  createConfiguration.maximumReconciliationRecordings,
 	})
 
 	// Create the creation specification.
@@ -468,32 +943,71 @@ func createMain(_ *cobra.Command, arguments []string) error {
 		Beta:          beta,
 		Configuration: configuration,
 		ConfigurationAlpha: &synchronization.Configuration{
-			ProbeMode:            probeModeAlpha,
-			ScanMode:             scanModeAlpha,
-			StageMode:            stageModeAlpha,
-			WatchMode:            watchModeAlpha,
-			WatchPollingInterval: createConfiguration.watchPollingIntervalAlpha,
-			DefaultFileMode:      uint32(defaultFileModeAlpha),
-			DefaultDirectoryMode: uint32(defaultDirectoryModeAlpha),
-			DefaultOwner:         createConfiguration.defaultOwnerAlpha,
-			DefaultGroup:         createConfiguration.defaultGroupAlpha,
-			CompressionAlgorithm: compressionAlgorithmAlpha,
+			ProbeMode:                       probeModeAlpha,
+			ScanMode:                        scanModeAlpha,
+			ScanSharingMode:                 scanSharingModeAlpha,
+			AggregateDigestMode:             aggregateDigestModeAlpha,
+			DirectoryListingRetries:         createConfiguration.directoryListingRetriesAlpha,
+			SlowDirectoryListingThreshold:   createConfiguration.slowDirectoryListingThresholdAlpha,
+			CacheSaveThreshold:              createConfiguration.cacheSaveThresholdAlpha,
+			MaximumRecheckPaths:             createConfiguration.maximumRecheckPathsAlpha,
+			ScanSubpaths:                    scanSubpathsAlpha,
+			MaximumStagingFileSize:          maximumStagingFileSizeAlpha,
+			RootExistenceMode:               rootExistenceModeAlpha,
+			StageMode:                       stageModeAlpha,
+			TransitionMode:                  transitionModeAlpha,
+			WatchMode:                       watchModeAlpha,
+			WatchPollingInterval:            createConfiguration.watchPollingIntervalAlpha,
+			WatchQueueSize:                  createConfiguration.watchQueueSizeAlpha,
+			InitialPollModificationMode:     initialPollModificationModeAlpha,
+			DefaultFileMode:                 uint32(defaultFileModeAlpha),
+			DefaultDirectoryMode:            uint32(defaultDirectoryModeAlpha),
+			DefaultOwner:                    createConfiguration.defaultOwnerAlpha,
+			DefaultGroup:                    createConfiguration.defaultGroupAlpha,
+			CompressionAlgorithm:            compressionAlgorithmAlpha,
+			StreamConcurrency:               createConfiguration.streamConcurrencyAlpha,
+			ClockSkewMode:                   clockSkewModeAlpha,
+			ClockSkewTolerance:              createConfiguration.clockSkewToleranceAlpha,
+			ProbeDirectory:                  createConfiguration.probeDirectoryAlpha,
+			AssumeExecutabilityPreservation: assumeExecutabilityPreservationAlpha,
+			AssumeUnicodeDecomposition:      assumeUnicodeDecompositionAlpha,
+			OverlayBase:                     createConfiguration.overlayBaseAlpha,
 		},
 		ConfigurationBeta: &synchronization.Configuration{
-			ProbeMode:            probeModeBeta,
-			ScanMode:             scanModeBeta,
-			StageMode:            stageModeBeta,
-			WatchMode:            watchModeBeta,
-			WatchPollingInterval: createConfiguration.watchPollingIntervalBeta,
-			DefaultFileMode:      uint32(defaultFileModeBeta),
-			DefaultDirectoryMode: uint32(defaultDirectoryModeBeta),
-			DefaultOwner:         createConfiguration.defaultOwnerBeta,
-			DefaultGroup:         createConfiguration.defaultGroupBeta,
-			CompressionAlgorithm: compressionAlgorithmBeta,
+			ProbeMode:                       probeModeBeta,
+			ScanMode:                        scanModeBeta,
+			ScanSharingMode:                 scanSharingModeBeta,
+			AggregateDigestMode:             aggregateDigestModeBeta,
+			DirectoryListingRetries:         createConfiguration.directoryListingRetriesBeta,
+			SlowDirectoryListingThreshold:   createConfiguration.slowDirectoryListingThresholdBeta,
+			CacheSaveThreshold:              createConfiguration.cacheSaveThresholdBeta,
+			MaximumRecheckPaths:             createConfiguration.maximumRecheckPathsBeta,
+			ScanSubpaths:                    scanSubpathsBeta,
+			MaximumStagingFileSize:          maximumStagingFileSizeBeta,
+			RootExistenceMode:               rootExistenceModeBeta,
+			StageMode:                       stageModeBeta,
+			TransitionMode:                  transitionModeBeta,
+			WatchMode:                       watchModeBeta,
+			WatchPollingInterval:            createConfiguration.watchPollingIntervalBeta,
+			WatchQueueSize:                  createConfiguration.watchQueueSizeBeta,
+			InitialPollModificationMode:     initialPollModificationModeBeta,
+			DefaultFileMode:                 uint32(defaultFileModeBeta),
+			DefaultDirectoryMode:            uint32(defaultDirectoryModeBeta),
+			DefaultOwner:                    createConfiguration.defaultOwnerBeta,
+			DefaultGroup:                    createConfiguration.defaultGroupBeta,
+			CompressionAlgorithm:            compressionAlgorithmBeta,
+			StreamConcurrency:               createConfiguration.streamConcurrencyBeta,
+			ClockSkewMode:                   clockSkewModeBeta,
+			ClockSkewTolerance:              createConfiguration.clockSkewToleranceBeta,
+			ProbeDirectory:                  createConfiguration.probeDirectoryBeta,
+			AssumeExecutabilityPreservation: assumeExecutabilityPreservationBeta,
+			AssumeUnicodeDecomposition:      assumeUnicodeDecompositionBeta,
+			OverlayBase:                     createConfiguration.overlayBaseBeta,
 		},
-		Name:   createConfiguration.name,
-		Labels: labels,
-		Paused: createConfiguration.paused,
+		Name:                    createConfiguration.name,
+		Labels:                  labels,
+		Paused:                  createConfiguration.paused,
+		DeterministicIdentifier: createConfiguration.deterministicIdentifier,
 	}
 
 	// Connect to the daemon and defer closure of the connection.
@@ -535,6 +1049,9 @@ var createConfiguration struct {
 	// paused indicates whether or not to create the session in a pre-paused
 	// state.
 	paused bool
+	// deterministicIdentifier indicates whether or not to derive the session
+	// identifier deterministically from the session's defining parameters.
+	deterministicIdentifier bool
 	// noGlobalConfiguration specifies whether or not the global configuration
 	// file should be ignored.
 	noGlobalConfiguration bool
@@ -543,6 +1060,8 @@ var createConfiguration struct {
 	configurationFiles []string
 	// synchronizationMode specifies the synchronization mode for the session.
 	synchronizationMode string
+	// typeChangeMode specifies the type change mode for the session.
+	typeChangeMode string
 	// hash specifies the hashing algorithm to use for the session.
 	hash string
 	// maximumEntryCount specifies the maximum number of filesystem entries that
@@ -551,6 +1070,79 @@ var createConfiguration struct {
 	// maximumStagingFileSize is the maximum file size that endpoints will
 	// stage. It can be specified in human-friendly units.
 	maximumStagingFileSize string
+	// maximumStagingFileSizeAlpha is the maximum file size that alpha will
+	// stage, taking priority over maximumStagingFileSize on alpha if specified.
+	maximumStagingFileSizeAlpha string
+	// maximumStagingFileSizeBeta is the maximum file size that beta will
+	// stage, taking priority over maximumStagingFileSize on beta if specified.
+	maximumStagingFileSizeBeta string
+	// oversizedFileMode specifies the behavior that endpoints should use for
+	// files exceeding the maximum staging file size.
+	oversizedFileMode string
+	// maximumRenameDetectionFileSize is the maximum file size for which
+	// endpoints will perform rename and copy detection. It can be specified in
+	// human-friendly units.
+	maximumRenameDetectionFileSize string
+	// maximumContentCacheSize is the maximum total size of the persistent
+	// content cache. It can be specified in human-friendly units.
+	maximumContentCacheSize string
+	// stagingConcurrencyMode specifies whether staging on alpha and beta should
+	// be performed sequentially or concurrently.
+	stagingConcurrencyMode string
+	// maximumDeltificationTime is the maximum amount of time (in milliseconds)
+	// that endpoints will spend computing the delta for an individual file.
+	maximumDeltificationTime uint32
+	// maximumStagedContentAge is the maximum age (in seconds) of staged content
+	// left over from previous staging operations before it's removed.
+	maximumStagedContentAge uint32
+	// rsyncBlockSize is the block size that endpoints will use when computing
+	// rsync signatures. It can be specified in human-friendly units.
+	rsyncBlockSize string
+	// maximumSignatureMemory is the maximum total rsync signature memory that
+	// endpoints will use in a single staging operation. It can be specified in
+	// human-friendly units.
+	maximumSignatureMemory string
+	// maximumInFlightBytes is the maximum total size of rsync operation data
+	// that endpoints will buffer while supplying files. It can be specified in
+	// human-friendly units.
+	maximumInFlightBytes string
+	// renameDetectionDigestLength is the length (in bytes) to which digests are
+	// truncated in the reverse lookup map used for rename and copy detection.
+	renameDetectionDigestLength uint32
+	// renameDetectionRetries is the maximum number of times that staging from
+	// an existing file will be retried during rename and copy detection.
+	renameDetectionRetries uint32
+	// maximumConflictCount specifies the maximum number of conflicts that the
+	// session will tolerate before halting.
+	maximumConflictCount uint64
+	// maximumConflictPersistence specifies the maximum number of consecutive
+	// synchronization cycles in which an unchanged conflict will be tolerated
+	// before the session halts.
+	maximumConflictPersistence uint32
+	// watchdogTimeout is the amount of time (in seconds) for which a
+	// synchronization cycle may go without making progress before the
+	// synchronization loop is restarted.
+	watchdogTimeout uint32
+	// agentTerminationReconnectDelay is the amount of time (in milliseconds)
+	// to wait before reconnecting after an endpoint's agent process terminates
+	// unexpectedly.
+	agentTerminationReconnectDelay uint32
+	// maximumBetaScanDeferral specifies the maximum number of consecutive
+	// synchronization cycles for which the beta scan may be deferred when
+	// using a unidirectional synchronization mode.
+	maximumBetaScanDeferral uint32
+	// verificationMode specifies the background verification mode to use for
+	// the session.
+	verificationMode string
+	// verificationInterval specifies the interval (in seconds) between
+	// background verification passes.
+	verificationInterval uint32
+	// maximumReportedProblems specifies the maximum number of scan or
+	// transition problems to report for each endpoint.
+	maximumReportedProblems uint32
+	// maximumReconciliationRecordings specifies the maximum number of
+	// reconciliation recordings to retain for the session.
+	maximumReconciliationRecordings uint32
 	// probeMode specifies the filesystem probing mode to use for the session.
 	probeMode string
 	// probeModeAlpha specifies the filesystem probing mode to use for the
@@ -567,6 +1159,92 @@ var createConfiguration struct {
 	// scanModeBeta specifies the scan mode to use for the session, taking
 	// priority over scanMode on beta if specified.
 	scanModeBeta string
+	// scanSharingMode specifies the scan sharing mode to use for the session.
+	scanSharingMode string
+	// scanSharingModeAlpha specifies the scan sharing mode to use for the
+	// session, taking priority over scanSharingMode on alpha if specified.
+	scanSharingModeAlpha string
+	// scanSharingModeBeta specifies the scan sharing mode to use for the
+	// session, taking priority over scanSharingMode on beta if specified.
+	scanSharingModeBeta string
+	// aggregateDigestMode specifies the aggregate digest mode to use for the
+	// session.
+	aggregateDigestMode string
+	// aggregateDigestModeAlpha specifies the aggregate digest mode to use for
+	// the session, taking priority over aggregateDigestMode on alpha if
+	// specified.
+	aggregateDigestModeAlpha string
+	// aggregateDigestModeBeta specifies the aggregate digest mode to use for
+	// the session, taking priority over aggregateDigestMode on beta if
+	// specified.
+	aggregateDigestModeBeta string
+	// directoryListingRetries specifies the maximum number of times that a
+	// directory listing will be re-read during scanning in an attempt to
+	// obtain a stable listing.
+	directoryListingRetries uint32
+	// directoryListingRetriesAlpha specifies the directory listing retry count
+	// to use for alpha, taking priority over directoryListingRetries on alpha
+	// if specified.
+	directoryListingRetriesAlpha uint32
+	// directoryListingRetriesBeta specifies the directory listing retry count
+	// to use for beta, taking priority over directoryListingRetries on beta if
+	// specified.
+	directoryListingRetriesBeta uint32
+	// slowDirectoryListingThreshold specifies the minimum directory listing
+	// duration (in milliseconds) for which listings are logged as slow.
+	slowDirectoryListingThreshold uint32
+	// slowDirectoryListingThresholdAlpha specifies the slow directory listing
+	// threshold to use for alpha, taking priority over
+	// slowDirectoryListingThreshold on alpha if specified.
+	slowDirectoryListingThresholdAlpha uint32
+	// slowDirectoryListingThresholdBeta specifies the slow directory listing
+	// threshold to use for beta, taking priority over
+	// slowDirectoryListingThreshold on beta if specified.
+	slowDirectoryListingThresholdBeta uint32
+	// cacheSaveThreshold specifies the minimum number of scan cache entries
+	// that must differ from the last saved cache before the cache is written.
+	cacheSaveThreshold uint64
+	// cacheSaveThresholdAlpha specifies the cache save threshold to use for
+	// alpha, taking priority over cacheSaveThreshold on alpha if specified.
+	cacheSaveThresholdAlpha uint64
+	// cacheSaveThresholdBeta specifies the cache save threshold to use for
+	// beta, taking priority over cacheSaveThreshold on beta if specified.
+	cacheSaveThresholdBeta uint64
+	// maximumRecheckPaths specifies the maximum number of re-check paths that
+	// will be accumulated before falling back to a full scan.
+	maximumRecheckPaths uint64
+	// maximumRecheckPathsAlpha specifies the maximum re-check path count to use
+	// for alpha, taking priority over maximumRecheckPaths on alpha if
+	// specified.
+	maximumRecheckPathsAlpha uint64
+	// maximumRecheckPathsBeta specifies the maximum re-check path count to use
+	// for beta, taking priority over maximumRecheckPaths on beta if specified.
+	maximumRecheckPathsBeta uint64
+	// scanSubpaths specifies the paths whose subtrees are the only content
+	// re-scanned by regular scans after the initial full scan.
+	scanSubpaths []string
+	// scanSubpathsAlpha specifies the scan subpaths to use for alpha, taking
+	// priority over scanSubpaths on alpha if specified.
+	scanSubpathsAlpha []string
+	// scanSubpathsBeta specifies the scan subpaths to use for beta, taking
+	// priority over scanSubpaths on beta if specified.
+	scanSubpathsBeta []string
+	// digestSamplingThreshold is the file size above which sampled digests are
+	// used to detect file content changes. It can be specified in
+	// human-friendly units.
+	digestSamplingThreshold string
+	// digestLength is the length (in bytes) to which file digests are
+	// truncated in snapshots.
+	digestLength uint32
+	// rootExistenceMode specifies how the absence of a synchronization root
+	// should be handled.
+	rootExistenceMode string
+	// rootExistenceModeAlpha specifies the root existence mode to use for
+	// alpha, taking priority over rootExistenceMode on alpha if specified.
+	rootExistenceModeAlpha string
+	// rootExistenceModeBeta specifies the root existence mode to use for beta,
+	// taking priority over rootExistenceMode on beta if specified.
+	rootExistenceModeBeta string
 	// stageMode specifies the file staging mode to use for the session.
 	stageMode string
 	// stageModeAlpha specifies the file staging mode to use for the session,
@@ -575,9 +1253,39 @@ var createConfiguration struct {
 	// stageModeBeta specifies the file staging mode to use for the session,
 	// taking priority over stageMode on beta if specified.
 	stageModeBeta string
+	// transitionMode specifies the transition mode to use for the session.
+	transitionMode string
+	// transitionModeAlpha specifies the transition mode to use for the
+	// session, taking priority over transitionMode on alpha if specified.
+	transitionModeAlpha string
+	// transitionModeBeta specifies the transition mode to use for the session,
+	// taking priority over transitionMode on beta if specified.
+	transitionModeBeta string
 	// symbolicLinkMode specifies the symbolic link handling mode to use for
 	// the session.
 	symbolicLinkMode string
+	// symbolicLinkReplacementMode specifies the handling of non-empty
+	// directories that need to be replaced by symbolic links.
+	symbolicLinkReplacementMode string
+	// symbolicLinkCycleMode specifies the handling of symbolic links that
+	// would form cycles.
+	symbolicLinkCycleMode string
+	// specialFileMode specifies the special file handling mode to use for the
+	// session.
+	specialFileMode string
+	// cacheTrustMode specifies the cache trust mode to use for the session.
+	cacheTrustMode string
+	// nameNormalizationMode specifies the name normalization mode to use for
+	// the session.
+	nameNormalizationMode string
+	// capabilityMismatchMode specifies the capability mismatch mode to use for
+	// the session.
+	capabilityMismatchMode string
+	// rootOverlapMode specifies the root overlap mode to use for the session.
+	rootOverlapMode string
+	// configurationIncompatibilityMode specifies the configuration
+	// incompatibility mode to use for the session.
+	configurationIncompatibilityMode string
 	// watchMode specifies the filesystem watching mode to use for the session.
 	watchMode string
 	// watchModeAlpha specifies the filesystem watching mode to use for the
@@ -597,6 +1305,28 @@ var createConfiguration struct {
 	// poll-based or hybrid watching, taking priority over watchPollingInterval
 	// on beta if specified.
 	watchPollingIntervalBeta uint32
+	// watchQueueSize specifies the native watcher event queue size.
+	watchQueueSize uint32
+	// watchQueueSizeAlpha specifies the native watcher event queue size,
+	// taking priority over watchQueueSize on alpha if specified.
+	watchQueueSizeAlpha uint32
+	// watchQueueSizeBeta specifies the native watcher event queue size, taking
+	// priority over watchQueueSize on beta if specified.
+	watchQueueSizeBeta uint32
+	// initialPollModificationMode specifies the initial poll modification mode
+	// to use for the session.
+	initialPollModificationMode string
+	// initialPollModificationModeAlpha specifies the initial poll modification
+	// mode to use for the session, taking priority over
+	// initialPollModificationMode on alpha if specified.
+	initialPollModificationModeAlpha string
+	// initialPollModificationModeBeta specifies the initial poll modification
+	// mode to use for the session, taking priority over
+	// initialPollModificationMode on beta if specified.
+	initialPollModificationModeBeta string
+	// initialSynchronizationMode specifies whether or not a synchronization
+	// cycle should be forced when the synchronization loop starts.
+	initialSynchronizationMode string
 	// ignoreSyntax specifies the ignore syntax and semantics for the session.
 	ignoreSyntax string
 	// ignores is the list of ignore specifications for the session.
@@ -606,6 +1336,21 @@ var createConfiguration struct {
 	// noIgnoreVCS specifies whether or not to disable VCS ignores for the
 	// session.
 	noIgnoreVCS bool
+	// ignoreOSMetadata specifies whether or not to enable OS metadata ignores
+	// for the session.
+	ignoreOSMetadata bool
+	// noIgnoreOSMetadata specifies whether or not to disable OS metadata
+	// ignores for the session.
+	noIgnoreOSMetadata bool
+	// manifest is the path to a file listing the paths to which
+	// synchronization should be restricted.
+	manifest string
+	// phantomDirectoryMode specifies the phantom directory mode to use for the
+	// session.
+	phantomDirectoryMode string
+	// maximumFileSize is the maximum size of files that will be considered for
+	// synchronization.
+	maximumFileSize string
 	// permissionsMode specifies the permissions mode to use for the session.
 	permissionsMode string
 	// defaultFileMode specifies the default permission mode to use for new
@@ -669,6 +1414,78 @@ var createConfiguration struct {
 	// compressionBeta specifies the compression algorithm to use when
 	// communicating with a remote beta endpoint.
 	compressionBeta string
+	// stagingCompression specifies the compression algorithm to use for rsync
+	// operation data transmitted during staging.
+	stagingCompression string
+	// streamConcurrency specifies the number of concurrent request streams to
+	// use when communicating with remote endpoints.
+	streamConcurrency uint32
+	// streamConcurrencyAlpha specifies the stream concurrency to use for
+	// alpha, taking priority over streamConcurrency on alpha if specified.
+	streamConcurrencyAlpha uint32
+	// streamConcurrencyBeta specifies the stream concurrency to use for beta,
+	// taking priority over streamConcurrency on beta if specified.
+	streamConcurrencyBeta uint32
+	// clockSkewMode specifies the behavior to use when a remote endpoint's
+	// clock differs from the local clock by more than the clock skew tolerance.
+	clockSkewMode string
+	// clockSkewModeAlpha specifies the clock skew mode to use for alpha, taking
+	// priority over clockSkewMode on alpha if specified.
+	clockSkewModeAlpha string
+	// clockSkewModeBeta specifies the clock skew mode to use for beta, taking
+	// priority over clockSkewMode on beta if specified.
+	clockSkewModeBeta string
+	// clockSkewTolerance specifies the clock skew tolerance (in seconds) to use
+	// for remote endpoints.
+	clockSkewTolerance uint32
+	// clockSkewToleranceAlpha specifies the clock skew tolerance to use for
+	// alpha, taking priority over clockSkewTolerance on alpha if specified.
+	clockSkewToleranceAlpha uint32
+	// clockSkewToleranceBeta specifies the clock skew tolerance to use for
+	// beta, taking priority over clockSkewTolerance on beta if specified.
+	clockSkewToleranceBeta uint32
+	// probeDirectory specifies an alternate directory in which filesystem
+	// behavior probe files should be created.
+	probeDirectory string
+	// probeDirectoryAlpha specifies the probe directory to use for alpha,
+	// taking priority over probeDirectory on alpha if specified.
+	probeDirectoryAlpha string
+	// probeDirectoryBeta specifies the probe directory to use for beta,
+	// taking priority over probeDirectory on beta if specified.
+	probeDirectoryBeta string
+	// assumeExecutabilityPreservation specifies an explicit assumption about
+	// executability preservation behavior, in which case probing for that
+	// behavior is skipped.
+	assumeExecutabilityPreservation string
+	// assumeExecutabilityPreservationAlpha specifies the executability
+	// preservation assumption to use for alpha, taking priority over
+	// assumeExecutabilityPreservation on alpha if specified.
+	assumeExecutabilityPreservationAlpha string
+	// assumeExecutabilityPreservationBeta specifies the executability
+	// preservation assumption to use for beta, taking priority over
+	// assumeExecutabilityPreservation on beta if specified.
+	assumeExecutabilityPreservationBeta string
+	// assumeUnicodeDecomposition specifies an explicit assumption about
+	// Unicode decomposition behavior, in which case probing for that behavior
+	// is skipped.
+	assumeUnicodeDecomposition string
+	// assumeUnicodeDecompositionAlpha specifies the Unicode decomposition
+	// assumption to use for alpha, taking priority over
+	// assumeUnicodeDecomposition on alpha if specified.
+	assumeUnicodeDecompositionAlpha string
+	// assumeUnicodeDecompositionBeta specifies the Unicode decomposition
+	// assumption to use for beta, taking priority over
+	// assumeUnicodeDecomposition on beta if specified.
+	assumeUnicodeDecompositionBeta string
+	// overlayBase specifies an immutable base directory to overlay beneath
+	// the synchronization root.
+	overlayBase string
+	// overlayBaseAlpha specifies the overlay base directory to use for alpha,
+	// taking priority over overlayBase on alpha if specified.
+	overlayBaseAlpha string
+	// overlayBaseBeta specifies the overlay base directory to use for beta,
+	// taking priority over overlayBase on beta if specified.
+	overlayBaseBeta string
 }
 
 func init() {
@@ -689,27 +1506,102 @@ func init() {
 	// Wire up paused flags.
 	flags.BoolVarP(&createConfiguration.paused, "paused", "p", false, "Create the session pre-paused")
 
+	// Wire up identifier flags.
+	flags.BoolVar(&createConfiguration.deterministicIdentifier, "deterministic-identifier", false, "Derive the session identifier from the session parameters and skip creation if a matching session exists")
+
 	// Wire up general configuration flags.
 	flags.BoolVar(&createConfiguration.noGlobalConfiguration, "no-global-configuration", false, "Ignore the global configuration file")
 	flags.StringSliceVarP(&createConfiguration.configurationFiles, "configuration-file", "c", nil, "Specify additional files from which to load (and merge) default configuration parameters")
 
 	// Wire up synchronization flags.
-	flags.StringVarP(&createConfiguration.synchronizationMode, "mode", "m", "", "Specify synchronization mode (two-way-safe|two-way-resolved|one-way-safe|one-way-replica)")
+	flags.StringVarP(&createConfiguration.synchronizationMode, "mode", "m", "", "Specify synchronization mode (two-way-safe|two-way-resolved|two-way-newest|one-way-safe|one-way-replica)")
+	flags.StringVar(&createConfiguration.typeChangeMode, "type-change-mode", "", "Specify handling of non-root type changes in bidirectional modes (propagate|conflict)")
 	flags.StringVarP(&createConfiguration.hash, "hash", "H", "", "Specify content hashing algorithm ("+hashFlagOptions+")")
 	flags.Uint64Var(&createConfiguration.maximumEntryCount, "max-entry-count", 0, "Specify the maximum number of entries that endpoints will manage")
 	flags.StringVar(&createConfiguration.maximumStagingFileSize, "max-staging-file-size", "", "Specify the maximum (individual) file size that endpoints will stage")
+	flags.StringVar(&createConfiguration.maximumStagingFileSizeAlpha, "max-staging-file-size-alpha", "", "Specify the maximum (individual) file size that alpha will stage")
+	flags.StringVar(&createConfiguration.maximumStagingFileSizeBeta, "max-staging-file-size-beta", "", "Specify the maximum (individual) file size that beta will stage")
+	flags.StringVar(&createConfiguration.oversizedFileMode, "oversized-file-mode", "", "Specify the handling of files exceeding the maximum staging file size (skip|halt|warn|placeholder)")
+	flags.StringVar(&createConfiguration.maximumRenameDetectionFileSize, "max-rename-detection-file-size", "", "Specify the maximum (individual) file size for which endpoints will perform rename and copy detection")
+	flags.StringVar(&createConfiguration.maximumContentCacheSize, "max-content-cache-size", "", "Specify the maximum total size of the persistent content cache (enables the content cache)")
+	flags.StringVar(&createConfiguration.stagingConcurrencyMode, "staging-concurrency-mode", "", "Specify staging concurrency mode (sequential|concurrent)")
+	flags.StringVar(&createConfiguration.rsyncBlockSize, "rsync-block-size", "", "Specify the block size (a power of two) that endpoints will use when computing rsync signatures")
+	flags.Uint32Var(&createConfiguration.maximumStagedContentAge, "max-staged-content-age", 0, "Specify the maximum age (in seconds) of staged content left over from previous staging operations before it's removed")
+	flags.Uint32Var(&createConfiguration.maximumDeltificationTime, "max-deltification-time", 0, "Specify the maximum time (in milliseconds) spent computing the delta for an individual file before sending it as literal data")
+	flags.StringVar(&createConfiguration.maximumSignatureMemory, "max-signature-memory", "", "Specify the maximum total signature memory that endpoints will use when staging")
+	flags.StringVar(&createConfiguration.maximumInFlightBytes, "max-in-flight-bytes", "", "Specify the maximum total size of rsync operation data that endpoints will buffer when supplying files (enables pipelining)")
+	flags.Uint32Var(&createConfiguration.renameDetectionDigestLength, "rename-detection-digest-length", 0, "Specify the length (in bytes) to which digests are truncated for rename and copy detection (trades detection effectiveness for memory)")
+	flags.Uint32Var(&createConfiguration.renameDetectionRetries, "rename-detection-retries", 0, "Specify the maximum number of times that staging from an existing file will be retried if the file changes during copying")
+	flags.Uint64Var(&createConfiguration.maximumConflictCount, "max-conflict-count", 0, "Specify the maximum number of conflicts that the session will tolerate before halting")
+	flags.Uint32Var(&createConfiguration.maximumConflictPersistence, "max-conflict-persistence", 0, "Specify the maximum number of consecutive synchronization cycles in which an unchanged conflict will be tolerated before halting")
+	flags.Uint32Var(&createConfiguration.watchdogTimeout, "watchdog-timeout", 0, "Specify the time (in seconds) after which a synchronization cycle that isn't making progress will be restarted")
+	flags.Uint32Var(&createConfiguration.agentTerminationReconnectDelay, "agent-termination-reconnect-delay", 0, "Specify the time (in milliseconds) to wait before reconnecting after an agent process terminates unexpectedly")
+	flags.Uint32Var(&createConfiguration.maximumBetaScanDeferral, "max-beta-scan-deferral", 0, "Specify the maximum number of consecutive synchronization cycles for which beta scanning may be deferred in one-way modes")
+	flags.StringVar(&createConfiguration.verificationMode, "verification-mode", "", "Specify background verification mode (disabled|periodic)")
+	flags.Uint32Var(&createConfiguration.verificationInterval, "verification-interval", 0, "Specify the interval (in seconds) between background verification passes")
+	flags.Uint32Var(&createConfiguration.maximumReportedProblems, "max-reported-problems", 0, "Specify the maximum number of scan or transition problems to report for each endpoint")
+	flags.Uint32Var(&createConfiguration.maximumReconciliationRecordings, "max-reconciliation-recordings", 0, "Specify the maximum number of reconciliation recordings to retain for debugging")
 	flags.StringVar(&createConfiguration.probeMode, "probe-mode", "", "Specify probe mode (probe|assume)")
 	flags.StringVar(&createConfiguration.probeModeAlpha, "probe-mode-alpha", "", "Specify probe mode for alpha (probe|assume)")
 	flags.StringVar(&createConfiguration.probeModeBeta, "probe-mode-beta", "", "Specify probe mode for beta (probe|assume)")
 	flags.StringVar(&createConfiguration.scanMode, "scan-mode", "", "Specify scan mode (full|accelerated)")
 	flags.StringVar(&createConfiguration.scanModeAlpha, "scan-mode-alpha", "", "Specify scan mode for alpha (full|accelerated)")
 	flags.StringVar(&createConfiguration.scanModeBeta, "scan-mode-beta", "", "Specify scan mode for beta (full|accelerated)")
+	flags.StringVar(&createConfiguration.scanSharingMode, "scan-sharing-mode", "", "Specify scan sharing mode (disabled|enabled)")
+	flags.StringVar(&createConfiguration.scanSharingModeAlpha, "scan-sharing-mode-alpha", "", "Specify scan sharing mode for alpha (disabled|enabled)")
+	flags.StringVar(&createConfiguration.scanSharingModeBeta, "scan-sharing-mode-beta", "", "Specify scan sharing mode for beta (disabled|enabled)")
+	flags.StringVar(&createConfiguration.aggregateDigestMode, "aggregate-digest-mode", "", "Specify aggregate digest mode (disabled|enabled)")
+	flags.StringVar(&createConfiguration.aggregateDigestModeAlpha, "aggregate-digest-mode-alpha", "", "Specify aggregate digest mode for alpha (disabled|enabled)")
+	flags.StringVar(&createConfiguration.aggregateDigestModeBeta, "aggregate-digest-mode-beta", "", "Specify aggregate digest mode for beta (disabled|enabled)")
+	flags.Uint32Var(&createConfiguration.directoryListingRetries, "directory-listing-retries", 0, "Specify the maximum number of directory listing re-reads used to obtain stable listings when scanning")
+	flags.Uint32Var(&createConfiguration.directoryListingRetriesAlpha, "directory-listing-retries-alpha", 0, "Specify the maximum number of directory listing re-reads for alpha")
+	flags.Uint32Var(&createConfiguration.directoryListingRetriesBeta, "directory-listing-retries-beta", 0, "Specify the maximum number of directory listing re-reads for beta")
+	flags.Uint32Var(&createConfiguration.slowDirectoryListingThreshold, "slow-directory-listing-threshold", 0, "Specify the minimum directory listing duration (in milliseconds) for which listings are logged as slow")
+	flags.Uint32Var(&createConfiguration.slowDirectoryListingThresholdAlpha, "slow-directory-listing-threshold-alpha", 0, "Specify the slow directory listing threshold (in milliseconds) for alpha")
+	flags.Uint32Var(&createConfiguration.slowDirectoryListingThresholdBeta, "slow-directory-listing-threshold-beta", 0, "Specify the slow directory listing threshold (in milliseconds) for beta")
+	flags.Uint64Var(&createConfiguration.cacheSaveThreshold, "cache-save-threshold", 0, "Specify the minimum number of changed cache entries required to trigger a cache write")
+	flags.Uint64Var(&createConfiguration.cacheSaveThresholdAlpha, "cache-save-threshold-alpha", 0, "Specify the minimum number of changed cache entries required to trigger a cache write for alpha")
+	flags.Uint64Var(&createConfiguration.cacheSaveThresholdBeta, "cache-save-threshold-beta", 0, "Specify the minimum number of changed cache entries required to trigger a cache write for beta")
+	flags.Uint64Var(&createConfiguration.maximumRecheckPaths, "max-recheck-paths", 0, "Specify the maximum number of re-check paths accumulated before falling back to a full scan")
+	flags.Uint64Var(&createConfiguration.maximumRecheckPathsAlpha, "max-recheck-paths-alpha", 0, "Specify the maximum number of re-check paths accumulated before falling back to a full scan for alpha")
+	flags.Uint64Var(&createConfiguration.maximumRecheckPathsBeta, "max-recheck-paths-beta", 0, "Specify the maximum number of re-check paths accumulated before falling back to a full scan for beta")
+	flags.StringSliceVar(&createConfiguration.scanSubpaths, "scan-subpath", nil, "Restrict regular scans (after the initial scan) to the specified subpaths")
+	flags.StringSliceVar(&createConfiguration.scanSubpathsAlpha, "scan-subpath-alpha", nil, "Restrict regular scans (after the initial scan) to the specified subpaths for alpha")
+	flags.StringSliceVar(&createConfiguration.scanSubpathsBeta, "scan-subpath-beta", nil, "Restrict regular scans (after the initial scan) to the specified subpaths for beta")
+	flags.StringVar(&createConfiguration.digestSamplingThreshold, "digest-sampling-threshold", "", "Specify the file size above which change detection uses sampled digests (trades correctness for speed)")
+	flags.Uint32Var(&createConfiguration.digestLength, "digest-length", 0, "Specify the length (in bytes) to which file digests are truncated in snapshots (trades correctness for memory and disables rename detection and content caching)")
+	flags.StringVar(&createConfiguration.rootExistenceMode, "root-existence-mode", "", "Specify root existence mode (create|require)")
+	flags.StringVar(&createConfiguration.rootExistenceModeAlpha, "root-existence-mode-alpha", "", "Specify root existence mode for alpha (create|require)")
+	flags.StringVar(&createConfiguration.rootExistenceModeBeta, "root-existence-mode-beta", "", "Specify root existence mode for beta (create|require)")
 	flags.StringVar(&createConfiguration.stageMode, "stage-mode", "", "Specify staging mode (mutagen|neighboring)")
 	flags.StringVar(&createConfiguration.stageModeAlpha, "stage-mode-alpha", "", "Specify staging mode for alpha (mutagen|neighboring)")
 	flags.StringVar(&createConfiguration.stageModeBeta, "stage-mode-beta", "", "Specify staging mode for beta (mutagen|neighboring)")
+	flags.StringVar(&createConfiguration.transitionMode, "transition-mode", "", "Specify transition mode (in-place|shadow-directory)")
+	flags.StringVar(&createConfiguration.transitionModeAlpha, "transition-mode-alpha", "", "Specify transition mode for alpha (in-place|shadow-directory)")
+	flags.StringVar(&createConfiguration.transitionModeBeta, "transition-mode-beta", "", "Specify transition mode for beta (in-place|shadow-directory)")
 
 	// Wire up symbolic link flags.
 	flags.StringVar(&createConfiguration.symbolicLinkMode, "symlink-mode", "", "Specify symlink mode (ignore|portable|posix-raw)")
+	flags.StringVar(&createConfiguration.symbolicLinkReplacementMode, "symlink-replacement-mode", "", "Specify handling of non-empty directories replaced by symlinks (require-empty|replace)")
+	flags.StringVar(&createConfiguration.symbolicLinkCycleMode, "symlink-cycle-mode", "", "Specify handling of symlinks that would form cycles (allow|refuse)")
+
+	// Wire up special file flags.
+	flags.StringVar(&createConfiguration.specialFileMode, "special-file-mode", "", "Specify special file mode (ignore|placeholder)")
+
+	// Wire up cache trust flags.
+	flags.StringVar(&createConfiguration.cacheTrustMode, "cache-trust-mode", "", "Specify the metadata that must match persisted cache entries for digest reuse after restarts (strict|ignore-file-id)")
+
+	// Wire up name normalization flags.
+	flags.StringVar(&createConfiguration.nameNormalizationMode, "name-normalization-mode", "", "Specify name normalization mode (preserve|require-nfc)")
+
+	// Wire up capability mismatch flags.
+	flags.StringVar(&createConfiguration.capabilityMismatchMode, "capability-mismatch-mode", "", "Specify filesystem capability mismatch mode (allow|halt)")
+
+	// Wire up root overlap flags.
+	flags.StringVar(&createConfiguration.rootOverlapMode, "root-overlap-mode", "", "Specify handling of local roots that overlap with those of existing sessions (warn|refuse)")
+
+	// Wire up configuration incompatibility flags.
+	flags.StringVar(&createConfiguration.configurationIncompatibilityMode, "configuration-incompatibility-mode", "", "Specify configuration incompatibility mode (allow|halt)")
 
 	// Wire up watch flags.
 	flags.StringVar(&createConfiguration.watchMode, "watch-mode", "", "Specify watch mode (portable|force-poll|no-watch)")
@@ -718,12 +1610,24 @@ func init() {
 	flags.Uint32Var(&createConfiguration.watchPollingInterval, "watch-polling-interval", 0, "Specify watch polling interval in seconds")
 	flags.Uint32Var(&createConfiguration.watchPollingIntervalAlpha, "watch-polling-interval-alpha", 0, "Specify watch polling interval in seconds for alpha")
 	flags.Uint32Var(&createConfiguration.watchPollingIntervalBeta, "watch-polling-interval-beta", 0, "Specify watch polling interval in seconds for beta")
+	flags.Uint32Var(&createConfiguration.watchQueueSize, "watch-queue-size", 0, "Specify native watcher event queue size")
+	flags.Uint32Var(&createConfiguration.watchQueueSizeAlpha, "watch-queue-size-alpha", 0, "Specify native watcher event queue size for alpha")
+	flags.Uint32Var(&createConfiguration.watchQueueSizeBeta, "watch-queue-size-beta", 0, "Specify native watcher event queue size for beta")
+	flags.StringVar(&createConfiguration.initialPollModificationMode, "initial-poll-modification-mode", "", "Specify initial poll modification mode (suppress|report)")
+	flags.StringVar(&createConfiguration.initialPollModificationModeAlpha, "initial-poll-modification-mode-alpha", "", "Specify initial poll modification mode for alpha (suppress|report)")
+	flags.StringVar(&createConfiguration.initialPollModificationModeBeta, "initial-poll-modification-mode-beta", "", "Specify initial poll modification mode for beta (suppress|report)")
+	flags.StringVar(&createConfiguration.initialSynchronizationMode, "initial-synchronization-mode", "", "Specify initial synchronization mode (automatic|force)")
 
 	// Wire up ignore flags.
 	flags.StringVar(&createConfiguration.ignoreSyntax, "ignore-syntax", "", "Specify ignore syntax (mutagen|docker)")
 	flags.StringSliceVarP(&createConfiguration.ignores, "ignore", "i", nil, "Specify ignore paths")
 	flags.BoolVar(&createConfiguration.ignoreVCS, "ignore-vcs", false, "Ignore VCS directories")
 	flags.BoolVar(&createConfiguration.noIgnoreVCS, "no-ignore-vcs", false, "Propagate VCS directories")
+	flags.BoolVar(&createConfiguration.ignoreOSMetadata, "ignore-os-metadata", false, "Ignore OS metadata files and directories")
+	flags.BoolVar(&createConfiguration.noIgnoreOSMetadata, "no-ignore-os-metadata", false, "Propagate OS metadata files and directories")
+	flags.StringVar(&createConfiguration.manifest, "manifest", "", "Restrict synchronization to the paths listed in a manifest file")
+	flags.StringVar(&createConfiguration.maximumFileSize, "max-file-size", "", "Specify the maximum (individual) file size to synchronize, excluding larger files as if ignored")
+	flags.StringVar(&createConfiguration.phantomDirectoryMode, "phantom-directory-mode", "", "Specify handling of fully ignored directories with Docker-style ignores (ancestor-driven|tracked|ignored)")
 
 	// Wire up permission flags.
 	flags.StringVar(&createConfiguration.permissionsMode, "permissions-mode", "", "Specify permissions mode (portable|manual)")
@@ -744,6 +1648,36 @@ func init() {
 	flags.StringVarP(&createConfiguration.compression, "compression", "C", "", "Specify compression algorithm ("+compressionFlagOptions+")")
 	flags.StringVar(&createConfiguration.compressionAlpha, "compression-alpha", "", "Specify compression algorithm for alpha ("+compressionFlagOptions+")")
 	flags.StringVar(&createConfiguration.compressionBeta, "compression-beta", "", "Specify compression algorithm for beta ("+compressionFlagOptions+")")
+	flags.StringVar(&createConfiguration.stagingCompression, "staging-compression", "", "Specify compression algorithm for staged file data ("+compressionFlagOptions+")")
+
+	// Wire up transport flags.
+	flags.Uint32Var(&createConfiguration.streamConcurrency, "stream-concurrency", 0, "Specify number of concurrent request streams for remote endpoints")
+	flags.Uint32Var(&createConfiguration.streamConcurrencyAlpha, "stream-concurrency-alpha", 0, "Specify number of concurrent request streams for alpha")
+	flags.Uint32Var(&createConfiguration.streamConcurrencyBeta, "stream-concurrency-beta", 0, "Specify number of concurrent request streams for beta")
+
+	// Wire up clock flags.
+	flags.StringVar(&createConfiguration.clockSkewMode, "clock-skew-mode", "", "Specify clock skew mode (warn|refuse)")
+	flags.StringVar(&createConfiguration.clockSkewModeAlpha, "clock-skew-mode-alpha", "", "Specify clock skew mode for alpha (warn|refuse)")
+	flags.StringVar(&createConfiguration.clockSkewModeBeta, "clock-skew-mode-beta", "", "Specify clock skew mode for beta (warn|refuse)")
+	flags.Uint32Var(&createConfiguration.clockSkewTolerance, "clock-skew-tolerance", 0, "Specify clock skew tolerance in seconds")
+	flags.Uint32Var(&createConfiguration.clockSkewToleranceAlpha, "clock-skew-tolerance-alpha", 0, "Specify clock skew tolerance in seconds for alpha")
+	flags.Uint32Var(&createConfiguration.clockSkewToleranceBeta, "clock-skew-tolerance-beta", 0, "Specify clock skew tolerance in seconds for beta")
+
+	// Wire up probe flags.
+	flags.StringVar(&createConfiguration.probeDirectory, "probe-directory", "", "Specify alternate directory for probe files")
+	flags.StringVar(&createConfiguration.probeDirectoryAlpha, "probe-directory-alpha", "", "Specify alternate directory for probe files on alpha")
+	flags.StringVar(&createConfiguration.probeDirectoryBeta, "probe-directory-beta", "", "Specify alternate directory for probe files on beta")
+	flags.StringVar(&createConfiguration.assumeExecutabilityPreservation, "assume-executability-preservation", "", "Assume executability preservation behavior instead of probing (true|false)")
+	flags.StringVar(&createConfiguration.assumeExecutabilityPreservationAlpha, "assume-executability-preservation-alpha", "", "Assume executability preservation behavior on alpha instead of probing (true|false)")
+	flags.StringVar(&createConfiguration.assumeExecutabilityPreservationBeta, "assume-executability-preservation-beta", "", "Assume executability preservation behavior on beta instead of probing (true|false)")
+	flags.StringVar(&createConfiguration.assumeUnicodeDecomposition, "assume-unicode-decomposition", "", "Assume Unicode decomposition behavior instead of probing (true|false)")
+	flags.StringVar(&createConfiguration.assumeUnicodeDecompositionAlpha, "assume-unicode-decomposition-alpha", "", "Assume Unicode decomposition behavior on alpha instead of probing (true|false)")
+	flags.StringVar(&createConfiguration.assumeUnicodeDecompositionBeta, "assume-unicode-decomposition-beta", "", "Assume Unicode decomposition behavior on beta instead of probing (true|false)")
+
+	// Wire up overlay flags.
+	flags.StringVar(&createConfiguration.overlayBase, "overlay-base", "", "Specify an immutable base directory to overlay beneath the synchronization root")
+	flags.StringVar(&createConfiguration.overlayBaseAlpha, "overlay-base-alpha", "", "Specify an immutable base directory to overlay beneath the synchronization root on alpha")
+	flags.StringVar(&createConfiguration.overlayBaseBeta, "overlay-base-beta", "", "Specify an immutable base directory to overlay beneath the synchronization root on beta")
 
 	// Set up flag normalization. This is only required to handle aliases.
 	flags.SetNormalizeFunc(func(f *pflag.FlagSet, name string) pflag.NormalizedName {
@@ -4,7 +4,7 @@ package sync
 
 const (
 	// hashFlagOptions are the value options to display for the hash flag.
-	hashFlagOptions = "sha1|sha256|xxh128"
+	hashFlagOptions = "sha1|sha256|xxh128|xxh3"
 	// compressionFlagOptions are the value options to display for the
 	// compression flag.
 	compressionFlagOptions = "none|deflate|zstandard"
@@ -0,0 +1,159 @@
+package sync
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/dustin/go-humanize"
+
+	"github.com/spf13/cobra"
+
+	"github.com/mutagen-io/mutagen/pkg/synchronization"
+	"github.com/mutagen-io/mutagen/pkg/synchronization/core/ignore"
+	"github.com/mutagen-io/mutagen/pkg/url"
+)
+
+// estimateMain is the entry point for the estimate command.
+func estimateMain(_ *cobra.Command, arguments []string) error {
+	// Validate, extract, and parse URLs.
+	if len(arguments) != 2 {
+		return errors.New("invalid number of endpoint URLs provided")
+	}
+	alpha, err := url.Parse(arguments[0], url.Kind_Synchronization, true)
+	if err != nil {
+		return fmt.Errorf("unable to parse alpha URL: %w", err)
+	}
+	beta, err := url.Parse(arguments[1], url.Kind_Synchronization, false)
+	if err != nil {
+		return fmt.Errorf("unable to parse beta URL: %w", err)
+	}
+
+	// Estimates are computed in-process and thus only support local endpoints.
+	if alpha.Protocol != url.Protocol_Local {
+		return errors.New("estimates are only supported for local alpha endpoints")
+	} else if beta.Protocol != url.Protocol_Local {
+		return errors.New("estimates are only supported for local beta endpoints")
+	}
+
+	// Validate and convert the ignore syntax specification.
+	var ignoreSyntax ignore.Syntax
+	if estimateConfiguration.ignoreSyntax != "" {
+		if err := ignoreSyntax.UnmarshalText([]byte(estimateConfiguration.ignoreSyntax)); err != nil {
+			return fmt.Errorf("unable to parse ignore syntax: %w", err)
+		}
+	}
+
+	// Validate and convert the VCS ignore mode specification.
+	var ignoreVCSMode ignore.IgnoreVCSMode
+	if estimateConfiguration.ignoreVCS && estimateConfiguration.noIgnoreVCS {
+		return errors.New("conflicting VCS ignore behavior specified")
+	} else if estimateConfiguration.ignoreVCS {
+		ignoreVCSMode = ignore.IgnoreVCSMode_IgnoreVCSModeIgnore
+	} else if estimateConfiguration.noIgnoreVCS {
+		ignoreVCSMode = ignore.IgnoreVCSMode_IgnoreVCSModePropagate
+	}
+
+	// Validate and convert the OS metadata ignore mode specification.
+	var ignoreOSMetadataMode ignore.IgnoreOSMetadataMode
+	if estimateConfiguration.ignoreOSMetadata && estimateConfiguration.noIgnoreOSMetadata {
+		return errors.New("conflicting OS metadata ignore behavior specified")
+	} else if estimateConfiguration.ignoreOSMetadata {
+		ignoreOSMetadataMode = ignore.IgnoreOSMetadataMode_IgnoreOSMetadataModeIgnore
+	} else if estimateConfiguration.noIgnoreOSMetadata {
+		ignoreOSMetadataMode = ignore.IgnoreOSMetadataMode_IgnoreOSMetadataModePropagate
+	}
+
+	// Create the configuration.
+	configuration := &synchronization.Configuration{
+		IgnoreSyntax:         ignoreSyntax,
+		Ignores:              estimateConfiguration.ignores,
+		IgnoreVCSMode:        ignoreVCSMode,
+		IgnoreOSMetadataMode: ignoreOSMetadataMode,
+	}
+
+	// Compute the estimate.
+	estimate, err := synchronization.EstimateLocal(
+		context.Background(),
+		alpha.Path, beta.Path,
+		synchronization.DefaultVersion,
+		configuration,
+	)
+	if err != nil {
+		return fmt.Errorf("unable to compute estimate: %w", err)
+	}
+
+	// Print the estimate.
+	fmt.Println("Entries to transfer:", estimate.Entries)
+	fmt.Printf("Files to transfer: %s\n", formatFileCountAndSize(estimate.Files, estimate.Bytes))
+	if estimate.Throughput > 0 {
+		throughputDescription := fmt.Sprintf("%s/s", humanize.Bytes(uint64(estimate.Throughput)))
+		if estimate.ThroughputCached {
+			throughputDescription += " (may reflect cached reads)"
+		}
+		fmt.Println("Measured alpha read throughput:", throughputDescription)
+		duration := estimate.Duration.Round(time.Second)
+		if estimate.Duration < time.Second {
+			duration = estimate.Duration.Round(time.Millisecond)
+		}
+		fmt.Println("Estimated transfer time:", duration,
+			"(lower bound, excludes transmission and beta write time)",
+		)
+	} else {
+		fmt.Println("Estimated transfer time: Unknown (no throughput measurement)")
+	}
+
+	// Success.
+	return nil
+}
+
+// estimateCommand is the estimate command.
+var estimateCommand = &cobra.Command{
+	Use:          "estimate <alpha> <beta>",
+	Short:        "Estimate the cost of an initial synchronization without creating a session",
+	RunE:         estimateMain,
+	SilenceUsage: true,
+}
+
+// estimateConfiguration stores configuration for the estimate command.
+var estimateConfiguration struct {
+	// help indicates whether or not to show help information and exit.
+	help bool
+	// ignoreSyntax specifies the ignore syntax and semantics for the estimate.
+	ignoreSyntax string
+	// ignores is the list of ignore specifications for the estimate.
+	ignores []string
+	// ignoreVCS specifies whether or not to enable VCS ignores for the
+	// estimate.
+	ignoreVCS bool
+	// noIgnoreVCS specifies whether or not to disable VCS ignores for the
+	// estimate.
+	noIgnoreVCS bool
+	// ignoreOSMetadata specifies whether or not to enable OS metadata ignores
+	// for the estimate.
+	ignoreOSMetadata bool
+	// noIgnoreOSMetadata specifies whether or not to disable OS metadata
+	// ignores for the estimate.
+	noIgnoreOSMetadata bool
+}
+
+func init() {
+	// Grab a handle for the command line flags.
+	flags := estimateCommand.Flags()
+
+	// Disable alphabetical sorting of flags in help output.
+	flags.SortFlags = false
+
+	// Manually add a help flag to override the default message. Cobra will
+	// still implement its logic automatically.
+	flags.BoolVarP(&estimateConfiguration.help, "help", "h", false, "Show help information")
+
+	// Wire up ignore flags.
+	flags.StringVar(&estimateConfiguration.ignoreSyntax, "ignore-syntax", "", "Specify ignore syntax (mutagen|docker)")
+	flags.StringSliceVarP(&estimateConfiguration.ignores, "ignore", "i", nil, "Specify ignore paths")
+	flags.BoolVar(&estimateConfiguration.ignoreVCS, "ignore-vcs", false, "Ignore VCS directories")
+	flags.BoolVar(&estimateConfiguration.noIgnoreVCS, "no-ignore-vcs", false, "Propagate VCS directories")
+	flags.BoolVar(&estimateConfiguration.ignoreOSMetadata, "ignore-os-metadata", false, "Ignore OS metadata files and directories")
+	flags.BoolVar(&estimateConfiguration.noIgnoreOSMetadata, "no-ignore-os-metadata", false, "Propagate OS metadata files and directories")
+}
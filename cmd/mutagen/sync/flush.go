@@ -19,10 +19,13 @@ import (
 
 // FlushWithSelection is an orchestration convenience method that performs a
 // flush operation using the provided daemon connection and session selection.
+// If a non-empty scope is provided, then the flush is restricted to the
+// specified synchronization-root-relative paths.
 func FlushWithSelection(
 	daemonConnection *grpc.ClientConn,
 	selection *selection.Selection,
 	skipWait bool,
+	scope []string,
 ) error {
 	// Initiate command line messaging.
 	statusLinePrinter := &cmd.StatusLinePrinter{}
@@ -42,6 +45,7 @@ func FlushWithSelection(
 		Prompter:  prompter,
 		Selection: selection,
 		SkipWait:  skipWait,
+		Scope:     scope,
 	}
 	response, err := synchronizationService.Flush(context.Background(), request)
 	promptingCancel()
@@ -79,7 +83,7 @@ func flushMain(_ *cobra.Command, arguments []string) error {
 	defer daemonConnection.Close()
 
 	// Perform the flush operation.
-	return FlushWithSelection(daemonConnection, selection, flushConfiguration.skipWait)
+	return FlushWithSelection(daemonConnection, selection, flushConfiguration.skipWait, flushConfiguration.paths)
 }
 
 // flushCommand is the flush command.
@@ -102,6 +106,9 @@ var flushConfiguration struct {
 	// skipWait indicates whether or not the flush operation should block until
 	// a synchronization cycle completes for each sesion requested.
 	skipWait bool
+	// paths are the synchronization-root-relative paths to which the flush
+	// operation should be restricted.
+	paths []string
 }
 
 func init() {
@@ -119,4 +126,5 @@ func init() {
 	flags.BoolVarP(&flushConfiguration.all, "all", "a", false, "Flush all sessions")
 	flags.StringVar(&flushConfiguration.labelSelector, "label-selector", "", "Flush sessions matching the specified label selector")
 	flags.BoolVar(&flushConfiguration.skipWait, "skip-wait", false, "Avoid waiting for the resulting synchronization cycle(s) to complete")
+	flags.StringSliceVar(&flushConfiguration.paths, "path", nil, "Restrict the flush to the specified synchronization-root-relative path(s)")
 }
@@ -2,6 +2,7 @@ package sync
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"os"
 
@@ -16,6 +17,7 @@ import (
 
 	synchronizationmodels "github.com/mutagen-io/mutagen/pkg/api/models/synchronization"
 	"github.com/mutagen-io/mutagen/pkg/grpcutil"
+	"github.com/mutagen-io/mutagen/pkg/project"
 	"github.com/mutagen-io/mutagen/pkg/selection"
 	synchronizationsvc "github.com/mutagen-io/mutagen/pkg/service/synchronization"
 )
@@ -79,11 +81,27 @@ func ListWithSelection(
 
 // listMain is the entry point for the list command.
 func listMain(_ *cobra.Command, arguments []string) error {
+	// If a project has been specified, then restrict the label selector to
+	// sessions belonging to that project.
+	labelSelector := listConfiguration.labelSelector
+	if listConfiguration.project != "" {
+		if len(arguments) > 0 {
+			return errors.New("project filtering cannot be combined with session specifications")
+		}
+		projectIdentifier, err := project.ReadIdentifier(listConfiguration.project)
+		if err != nil {
+			return fmt.Errorf("unable to determine project identifier: %w", err)
+		}
+		if labelSelector, err = project.RestrictLabelSelector(labelSelector, projectIdentifier); err != nil {
+			return fmt.Errorf("unable to compute project label selector: %w", err)
+		}
+	}
+
 	// Create session selection specification.
 	selection := &selection.Selection{
-		All:            len(arguments) == 0 && listConfiguration.labelSelector == "",
+		All:            len(arguments) == 0 && labelSelector == "",
 		Specifications: arguments,
-		LabelSelector:  listConfiguration.labelSelector,
+		LabelSelector:  labelSelector,
 	}
 	if err := selection.EnsureValid(); err != nil {
 		return fmt.Errorf("invalid session selection specification: %w", err)
@@ -117,6 +135,9 @@ var listConfiguration struct {
 	// labelSelector encodes a label selector to be used in identifying which
 	// sessions should be paused.
 	labelSelector string
+	// project specifies the configuration file of a running project to whose
+	// sessions the listing should be restricted.
+	project string
 	// TemplateFlags store custom templating behavior.
 	templating.TemplateFlags
 }
@@ -135,6 +156,7 @@ func init() {
 	// Wire up list flags.
 	flags.BoolVarP(&listConfiguration.long, "long", "l", false, "Show detailed session information")
 	flags.StringVar(&listConfiguration.labelSelector, "label-selector", "", "List sessions matching the specified label selector")
+	flags.StringVar(&listConfiguration.project, "project", "", "List only sessions belonging to the running project defined by the specified configuration file")
 
 	// Wire up templating flags.
 	listConfiguration.TemplateFlags.Register(flags)
@@ -3,6 +3,7 @@ package sync
 import (
 	"fmt"
 	"math"
+	"time"
 
 	"github.com/dustin/go-humanize"
 
@@ -45,6 +46,19 @@ func formatFileCountAndSize(count uint64, totalSize uint64) string {
 	return fmt.Sprintf("%d files (%s)", count, humanize.Bytes(totalSize))
 }
 
+// formatHashingStatistics formats hashed byte and hashing duration statistics
+// for display, including the effective hashing throughput.
+func formatHashingStatistics(hashedBytes, hashingDuration uint64) string {
+	duration := time.Duration(hashingDuration)
+	if duration <= 0 {
+		return fmt.Sprintf("%s hashed", humanize.Bytes(hashedBytes))
+	}
+	throughput := uint64(float64(hashedBytes) / duration.Seconds())
+	return fmt.Sprintf("%s hashed in %s (%s/s)",
+		humanize.Bytes(hashedBytes), duration.Round(time.Microsecond), humanize.Bytes(throughput),
+	)
+}
+
 // formatSymbolicLinkCount formats a symbolic link count for display.
 func formatSymbolicLinkCount(count uint64) string {
 	if count == 1 {
@@ -74,14 +88,35 @@ func formatEntry(entry *core.Entry) string {
 		return fmt.Sprintf("File (%x)", entry.Digest)
 	} else if entry.Kind == core.EntryKind_SymbolicLink {
 		return fmt.Sprintf("Symbolic Link (%s)", entry.Target)
+	} else if entry.Kind == core.EntryKind_SpecialFile {
+		return "Special File"
 	} else if entry.Kind == core.EntryKind_Untracked {
 		return "Untracked content"
 	} else if entry.Kind == core.EntryKind_Problematic {
 		return fmt.Sprintf("Problematic content (%s)", entry.Problem)
+	} else if entry.Kind == core.EntryKind_Oversized {
+		return "Oversized content"
 	}
 	return "<unknown>"
 }
 
+// formatProblem formats a problem's error and (if known) code for display.
+func formatProblem(problem *core.Problem) string {
+	if problem.Code == core.ProblemCode_ProblemCodeUnknown {
+		return problem.Error
+	}
+	code, _ := problem.Code.MarshalText()
+	return fmt.Sprintf("%s [%s]", problem.Error, code)
+}
+
+// formatCapability formats the presence of a filesystem capability for display.
+func formatCapability(present bool) string {
+	if present {
+		return "Yes"
+	}
+	return "No"
+}
+
 // printEndpoint prints the configuration for a synchronization endpoint.
 func printEndpoint(name string, url *urlpkg.URL, configuration *synchronization.Configuration, state *synchronization.EndpointState, version synchronization.Version, mode common.SessionDisplayMode) {
 	// Print the endpoint header.
@@ -112,6 +147,22 @@ func printEndpoint(name string, url *urlpkg.URL, configuration *synchronization.
 				watchPollingIntervalDescription = fmt.Sprintf("%d seconds", configuration.WatchPollingInterval)
 			}
 			fmt.Println("\t\tWatch polling interval:", watchPollingIntervalDescription)
+
+			// Compute and print the watch queue size.
+			var watchQueueSizeDescription string
+			if configuration.WatchQueueSize == 0 {
+				watchQueueSizeDescription = fmt.Sprintf("Default (%d)", version.DefaultWatchQueueSize())
+			} else {
+				watchQueueSizeDescription = fmt.Sprint(configuration.WatchQueueSize)
+			}
+			fmt.Println("\t\tWatch queue size:", watchQueueSizeDescription)
+
+			// Compute and print the initial poll modification mode.
+			initialPollModificationModeDescription := configuration.InitialPollModificationMode.Description()
+			if configuration.InitialPollModificationMode.IsDefault() {
+				initialPollModificationModeDescription += fmt.Sprintf(" (%s)", version.DefaultInitialPollModificationMode().Description())
+			}
+			fmt.Println("\t\tInitial poll modification mode:", initialPollModificationModeDescription)
 		}
 
 		// Compute and print the probe mode.
@@ -121,6 +172,24 @@ func printEndpoint(name string, url *urlpkg.URL, configuration *synchronization.
 		}
 		fmt.Println("\t\tProbe mode:", probeModeDescription)
 
+		// Print the probe directory, if any.
+		if configuration.ProbeDirectory != "" {
+			fmt.Println("\t\tProbe directory:", terminal.NeutralizeControlCharacters(configuration.ProbeDirectory))
+		}
+
+		// Print any explicitly stated behavior assumptions.
+		if !configuration.AssumeExecutabilityPreservation.IsDefault() {
+			fmt.Println("\t\tAssumed executability preservation:", configuration.AssumeExecutabilityPreservation.Description())
+		}
+		if !configuration.AssumeUnicodeDecomposition.IsDefault() {
+			fmt.Println("\t\tAssumed Unicode decomposition:", configuration.AssumeUnicodeDecomposition.Description())
+		}
+
+		// Print the overlay base, if any.
+		if configuration.OverlayBase != "" {
+			fmt.Println("\t\tOverlay base:", terminal.NeutralizeControlCharacters(configuration.OverlayBase))
+		}
+
 		// Compute and print the scan mode.
 		scanModeDescription := configuration.ScanMode.Description()
 		if configuration.ScanMode.IsDefault() {
@@ -128,6 +197,91 @@ func printEndpoint(name string, url *urlpkg.URL, configuration *synchronization.
 		}
 		fmt.Println("\t\tScan mode:", scanModeDescription)
 
+		// Compute and print the scan sharing mode.
+		scanSharingModeDescription := configuration.ScanSharingMode.Description()
+		if configuration.ScanSharingMode.IsDefault() {
+			scanSharingModeDescription += fmt.Sprintf(" (%s)", version.DefaultScanSharingMode().Description())
+		}
+		fmt.Println("\t\tScan sharing mode:", scanSharingModeDescription)
+
+		// Compute and print the aggregate digest mode.
+		aggregateDigestModeDescription := configuration.AggregateDigestMode.Description()
+		if configuration.AggregateDigestMode.IsDefault() {
+			aggregateDigestModeDescription += fmt.Sprintf(" (%s)", version.DefaultAggregateDigestMode().Description())
+		}
+		fmt.Println("\t\tAggregate digest mode:", aggregateDigestModeDescription)
+
+		// Compute and print the directory listing retry count.
+		var directoryListingRetriesDescription string
+		if configuration.DirectoryListingRetries == 0 {
+			directoryListingRetriesDescription = fmt.Sprintf("Default (%d)", version.DefaultDirectoryListingRetries())
+		} else {
+			directoryListingRetriesDescription = fmt.Sprint(configuration.DirectoryListingRetries)
+		}
+		fmt.Println("\t\tDirectory listing retries:", directoryListingRetriesDescription)
+
+		// Compute and print the slow directory listing threshold.
+		var slowDirectoryListingThresholdDescription string
+		if configuration.SlowDirectoryListingThreshold == 0 {
+			slowDirectoryListingThresholdDescription = fmt.Sprintf("Default (%d ms)", version.DefaultSlowDirectoryListingThreshold())
+		} else {
+			slowDirectoryListingThresholdDescription = fmt.Sprintf("%d ms", configuration.SlowDirectoryListingThreshold)
+		}
+		fmt.Println("\t\tSlow directory listing threshold:", slowDirectoryListingThresholdDescription)
+
+		// Compute and print the cache save threshold.
+		var cacheSaveThresholdDescription string
+		if configuration.CacheSaveThreshold == 0 {
+			cacheSaveThresholdDescription = fmt.Sprintf("Default (%d)", version.DefaultCacheSaveThreshold())
+		} else {
+			cacheSaveThresholdDescription = fmt.Sprint(configuration.CacheSaveThreshold)
+		}
+		fmt.Println("\t\tCache save threshold:", cacheSaveThresholdDescription)
+
+		// Compute and print the maximum re-check path count.
+		var maximumRecheckPathsDescription string
+		if configuration.MaximumRecheckPaths == 0 {
+			if d := version.DefaultMaximumRecheckPaths(); d == 0 {
+				maximumRecheckPathsDescription = "Default (Unlimited)"
+			} else {
+				maximumRecheckPathsDescription = fmt.Sprintf("Default (%d)", d)
+			}
+		} else {
+			maximumRecheckPathsDescription = fmt.Sprint(configuration.MaximumRecheckPaths)
+		}
+		fmt.Println("\t\tMaximum recheck paths:", maximumRecheckPathsDescription)
+
+		// Print the scan subpaths, if any.
+		if len(configuration.ScanSubpaths) > 0 {
+			fmt.Println("\t\tScan subpaths:")
+			for _, p := range configuration.ScanSubpaths {
+				fmt.Printf("\t\t\t%s\n", terminal.NeutralizeControlCharacters(p))
+			}
+		}
+
+		// Compute and print maximum staging file size.
+		var maximumStagingFileSizeDescription string
+		if configuration.MaximumStagingFileSize == 0 {
+			maximumStagingFileSizeDescription = fmt.Sprintf(
+				"Default (%s)",
+				humanize.Bytes(version.DefaultMaximumStagingFileSize()),
+			)
+		} else {
+			maximumStagingFileSizeDescription = fmt.Sprintf(
+				"%d (%s)",
+				configuration.MaximumStagingFileSize,
+				humanize.Bytes(configuration.MaximumStagingFileSize),
+			)
+		}
+		fmt.Println("\t\tMaximum staging file size:", maximumStagingFileSizeDescription)
+
+		// Compute and print the root existence mode.
+		rootExistenceModeDescription := configuration.RootExistenceMode.Description()
+		if configuration.RootExistenceMode.IsDefault() {
+			rootExistenceModeDescription += fmt.Sprintf(" (%s)", version.DefaultRootExistenceMode().Description())
+		}
+		fmt.Println("\t\tRoot existence mode:", rootExistenceModeDescription)
+
 		// Compute and print the staging mode.
 		stageModeDescription := configuration.StageMode.Description()
 		if configuration.StageMode.IsDefault() {
@@ -135,6 +289,13 @@ func printEndpoint(name string, url *urlpkg.URL, configuration *synchronization.
 		}
 		fmt.Println("\t\tStage mode:", stageModeDescription)
 
+		// Compute and print the transition mode.
+		transitionModeDescription := configuration.TransitionMode.Description()
+		if configuration.TransitionMode.IsDefault() {
+			transitionModeDescription += fmt.Sprintf(" (%s)", version.DefaultTransitionMode().Description())
+		}
+		fmt.Println("\t\tTransition mode:", transitionModeDescription)
+
 		// Compute and print the default file mode.
 		var defaultFileModeDescription string
 		if configuration.DefaultFileMode == 0 {
@@ -175,6 +336,31 @@ func printEndpoint(name string, url *urlpkg.URL, configuration *synchronization.
 				compressionAlgorithm += fmt.Sprintf(" (%s)", version.DefaultCompressionAlgorithm().Description())
 			}
 			fmt.Println("\t\tCompression:", compressionAlgorithm)
+
+			// Compute and print the stream concurrency.
+			var streamConcurrencyDescription string
+			if configuration.StreamConcurrency == 0 {
+				streamConcurrencyDescription = fmt.Sprintf("Default (%d)", version.DefaultStreamConcurrency())
+			} else {
+				streamConcurrencyDescription = fmt.Sprint(configuration.StreamConcurrency)
+			}
+			fmt.Println("\t\tStream concurrency:", streamConcurrencyDescription)
+
+			// Compute and print the clock skew mode.
+			clockSkewModeDescription := configuration.ClockSkewMode.Description()
+			if configuration.ClockSkewMode.IsDefault() {
+				clockSkewModeDescription += fmt.Sprintf(" (%s)", version.DefaultClockSkewMode().Description())
+			}
+			fmt.Println("\t\tClock skew mode:", clockSkewModeDescription)
+
+			// Compute and print the clock skew tolerance.
+			var clockSkewToleranceDescription string
+			if configuration.ClockSkewTolerance == 0 {
+				clockSkewToleranceDescription = fmt.Sprintf("Default (%d seconds)", version.DefaultClockSkewTolerance())
+			} else {
+				clockSkewToleranceDescription = fmt.Sprintf("%d seconds", configuration.ClockSkewTolerance)
+			}
+			fmt.Println("\t\tClock skew tolerance:", clockSkewToleranceDescription)
 		}
 	}
 
@@ -188,6 +374,11 @@ func printEndpoint(name string, url *urlpkg.URL, configuration *synchronization.
 	// Print connection status.
 	fmt.Println("\tConnected:", common.FormatConnectionStatus(state.Connected))
 
+	// Print the measured clock offset for connected remote endpoints.
+	if state.Connected && url.Protocol != urlpkg.Protocol_Local {
+		fmt.Println("\tClock offset:", time.Duration(state.ClockOffset).Round(time.Millisecond))
+	}
+
 	// Print content information, if available.
 	if state.Scanned {
 		fmt.Printf("\tSynchronizable contents:\n\t\t%s\n\t\t%s\n\t\t%s\n",
@@ -197,6 +388,32 @@ func printEndpoint(name string, url *urlpkg.URL, configuration *synchronization.
 		)
 	}
 
+	// Print hashing statistics for the last scan, if in long listing mode and
+	// any hashing was performed.
+	if mode == common.SessionDisplayModeListLong && state.Scanned && state.HashedBytes > 0 {
+		fmt.Println("\tLast scan hashing:", formatHashingStatistics(state.HashedBytes, state.HashingDuration))
+	}
+
+	// Print the watch overflow count, if non-zero.
+	if state.WatchOverflows > 0 {
+		color.Yellow("\tWatch overflows: %d\n", state.WatchOverflows)
+	}
+
+	// Print the reason that accelerated scanning is unavailable, if any.
+	if state.AccelerationUnavailable != "" {
+		color.Yellow("\tAccelerated scanning unavailable: %s\n",
+			terminal.NeutralizeControlCharacters(state.AccelerationUnavailable),
+		)
+	}
+
+	// Print configuration incompatibilities, if any.
+	if len(state.ConfigurationIncompatibilities) > 0 {
+		color.Red("\tConfiguration incompatibilities:\n")
+		for _, i := range state.ConfigurationIncompatibilities {
+			color.Red("\t\t%s\n", terminal.NeutralizeControlCharacters(i))
+		}
+	}
+
 	// Print scan problems, if any.
 	if len(state.ScanProblems) > 0 {
 		if mode == common.SessionDisplayModeList {
@@ -208,7 +425,7 @@ func printEndpoint(name string, url *urlpkg.URL, configuration *synchronization.
 			for _, p := range state.ScanProblems {
 				color.Red("\t\t%s: %v\n",
 					terminal.NeutralizeControlCharacters(formatPath(p.Path)),
-					terminal.NeutralizeControlCharacters(p.Error),
+					terminal.NeutralizeControlCharacters(formatProblem(p)),
 				)
 			}
 			if state.ExcludedScanProblems > 0 {
@@ -228,7 +445,7 @@ func printEndpoint(name string, url *urlpkg.URL, configuration *synchronization.
 			for _, p := range state.TransitionProblems {
 				color.Red("\t\t%s: %v\n",
 					terminal.NeutralizeControlCharacters(formatPath(p.Path)),
-					terminal.NeutralizeControlCharacters(p.Error),
+					terminal.NeutralizeControlCharacters(formatProblem(p)),
 				)
 			}
 			if state.ExcludedTransitionProblems > 0 {
@@ -236,6 +453,23 @@ func printEndpoint(name string, url *urlpkg.URL, configuration *synchronization.
 			}
 		}
 	}
+
+	// Print verification divergences, if any.
+	if len(state.VerificationDivergences) > 0 {
+		if mode == common.SessionDisplayModeList {
+			color.Red("\tVerification divergences: %d\n",
+				uint64(len(state.VerificationDivergences))+state.ExcludedVerificationDivergences,
+			)
+		} else if mode == common.SessionDisplayModeListLong {
+			color.Red("\tVerification divergences:\n")
+			for _, path := range state.VerificationDivergences {
+				color.Red("\t\t%s\n", terminal.NeutralizeControlCharacters(formatPath(path)))
+			}
+			if state.ExcludedVerificationDivergences > 0 {
+				color.Red("\t\t...+%d more...\n", state.ExcludedVerificationDivergences)
+			}
+		}
+	}
 }
 
 // printConflictCount prints a count of synchronization conflicts.
@@ -283,6 +517,66 @@ func printConflicts(conflicts []*core.Conflict, excludedConflicts uint64) {
 	}
 }
 
+// formatTransferRate formats the transfer rate and estimated time remaining for
+// the current file in staging progress. It returns an empty string if the rate
+// is not yet known.
+func formatTransferRate(stagingProgress *rsync.ReceiverState) string {
+	if stagingProgress.BytesPerSecond == 0 {
+		return ""
+	}
+	result := fmt.Sprintf(" - %s/s", humanize.Bytes(stagingProgress.BytesPerSecond))
+	if stagingProgress.EstimatedRemaining != nil {
+		remaining := stagingProgress.EstimatedRemaining.AsDuration().Round(time.Second)
+		result += fmt.Sprintf(", %s remaining", remaining)
+	}
+	return result
+}
+
+// printStagingProgress prints staging progress for an endpoint, if available.
+// If a label is specified, then it's used to prefix the output. Because there's
+// no built-in mechanism for knowing the total expected size of a staging
+// operation, the source endpoint state is used to estimate the total size if
+// the staging operation encompasses all of the source's files.
+func printStagingProgress(label string, stagingProgress *rsync.ReceiverState, source *synchronization.EndpointState) {
+	// If there's no progress information, then there's nothing to print.
+	if stagingProgress == nil {
+		return
+	}
+
+	// Compute the headers.
+	progressHeader, fileHeader := "Staging progress", "Current file"
+	if label != "" {
+		progressHeader = label + " staging progress"
+		fileHeader = label + " current file"
+	}
+
+	// Compute the fraction complete.
+	var totalExpectedSize uint64
+	if stagingProgress.ExpectedFiles == source.Files {
+		totalExpectedSize = source.TotalFileSize
+	}
+	var fractionComplete float32
+	var totalSizeDenominator string
+	if totalExpectedSize != 0 {
+		fractionComplete = float32(stagingProgress.TotalReceivedSize) / float32(totalExpectedSize)
+		totalSizeDenominator = "/" + humanize.Bytes(totalExpectedSize)
+	} else {
+		fractionComplete = float32(stagingProgress.ReceivedFiles) / float32(stagingProgress.ExpectedFiles)
+	}
+
+	// Print the progress.
+	fmt.Printf("%s: %d/%d - %s%s - %.0f%%\n%s: %s (%s/%s)%s\n",
+		progressHeader,
+		stagingProgress.ReceivedFiles, stagingProgress.ExpectedFiles,
+		humanize.Bytes(stagingProgress.TotalReceivedSize), totalSizeDenominator,
+		100.0*fractionComplete,
+		fileHeader,
+		terminal.NeutralizeControlCharacters(stagingProgress.Path),
+		humanize.Bytes(stagingProgress.ReceivedSize), humanize.Bytes(stagingProgress.ExpectedSize),
+		formatTransferRate(stagingProgress),
+	)
+}
+
 // printSession prints the configuration and status of a synchronization
 // session and its endpoints.
 func printSession(state *synchronization.State, mode common.SessionDisplayMode) {
@@ -323,6 +617,22 @@ func printSession(state *synchronization.State, mode common.SessionDisplayMode)
 		}
 		fmt.Println("\tSynchronization mode:", synchronizationMode)
 
+		// Compute and print the type change mode.
+		typeChangeMode := configuration.TypeChangeMode.Description()
+		if configuration.TypeChangeMode.IsDefault() {
+			defaultTypeChangeMode := state.Session.Version.DefaultTypeChangeMode()
+			typeChangeMode += fmt.Sprintf(" (%s)", defaultTypeChangeMode.Description())
+		}
+		fmt.Println("\tType change mode:", typeChangeMode)
+
+		// Compute and print the initial synchronization mode.
+		initialSynchronizationMode := configuration.InitialSynchronizationMode.Description()
+		if configuration.InitialSynchronizationMode.IsDefault() {
+			defaultInitialSynchronizationMode := state.Session.Version.DefaultInitialSynchronizationMode()
+			initialSynchronizationMode += fmt.Sprintf(" (%s)", defaultInitialSynchronizationMode.Description())
+		}
+		fmt.Println("\tInitial synchronization mode:", initialSynchronizationMode)
+
 		// Compute and print the hashing algorithm.
 		hashingAlgorithmDescription := configuration.HashingAlgorithm.Description()
 		if configuration.HashingAlgorithm.IsDefault() {
@@ -331,6 +641,28 @@ func printSession(state *synchronization.State, mode common.SessionDisplayMode)
 		}
 		fmt.Println("\tHashing algorithm:", hashingAlgorithmDescription)
 
+		// Compute and print the digest sampling threshold.
+		var digestSamplingThresholdDescription string
+		if configuration.DigestSamplingThreshold == 0 {
+			digestSamplingThresholdDescription = "Default (Disabled)"
+		} else {
+			digestSamplingThresholdDescription = fmt.Sprintf(
+				"%d (%s)",
+				configuration.DigestSamplingThreshold,
+				humanize.Bytes(configuration.DigestSamplingThreshold),
+			)
+		}
+		fmt.Println("\tDigest sampling threshold:", digestSamplingThresholdDescription)
+
+		// Compute and print the digest length.
+		var digestLengthDescription string
+		if configuration.DigestLength == 0 {
+			digestLengthDescription = "Default (Full)"
+		} else {
+			digestLengthDescription = fmt.Sprintf("%d bytes", configuration.DigestLength)
+		}
+		fmt.Println("\tDigest length:", digestLengthDescription)
+
 		// Compute and print maximum entry count.
 		var maximumEntryCountDescription string
 		if configuration.MaximumEntryCount == 0 {
@@ -344,21 +676,236 @@ func printSession(state *synchronization.State, mode common.SessionDisplayMode)
 		}
 		fmt.Println("\tMaximum allowed entry count:", maximumEntryCountDescription)
 
-		// Compute and print maximum staging file size.
-		var maximumStagingFileSizeDescription string
-		if configuration.MaximumStagingFileSize == 0 {
-			maximumStagingFileSizeDescription = fmt.Sprintf(
+		// Compute and print maximum conflict count.
+		var maximumConflictCountDescription string
+		if configuration.MaximumConflictCount == 0 {
+			if m := state.Session.Version.DefaultMaximumConflictCount(); m == math.MaxUint64 {
+				maximumConflictCountDescription = fmt.Sprintf("Default (%s)", maxUint64Description)
+			} else {
+				maximumConflictCountDescription = fmt.Sprintf("Default (%d)", m)
+			}
+		} else {
+			maximumConflictCountDescription = fmt.Sprintf("%d", configuration.MaximumConflictCount)
+		}
+		fmt.Println("\tMaximum allowed conflict count:", maximumConflictCountDescription)
+
+		// Compute and print maximum conflict persistence.
+		var maximumConflictPersistenceDescription string
+		if configuration.MaximumConflictPersistence == 0 {
+			maximumConflictPersistenceDescription = "Default (Unlimited)"
+		} else {
+			maximumConflictPersistenceDescription = fmt.Sprintf("%d cycles", configuration.MaximumConflictPersistence)
+		}
+		fmt.Println("\tMaximum conflict persistence:", maximumConflictPersistenceDescription)
+
+		// Compute and print the watchdog timeout.
+		var watchdogTimeoutDescription string
+		if configuration.WatchdogTimeout == 0 {
+			watchdogTimeoutDescription = "Default (Disabled)"
+		} else {
+			watchdogTimeoutDescription = fmt.Sprintf("%d seconds", configuration.WatchdogTimeout)
+		}
+		fmt.Println("\tWatchdog timeout:", watchdogTimeoutDescription)
+
+		// Compute and print the agent termination reconnect delay.
+		var agentTerminationReconnectDelayDescription string
+		if configuration.AgentTerminationReconnectDelay == 0 {
+			agentTerminationReconnectDelayDescription = fmt.Sprintf(
+				"Default (%d milliseconds)",
+				state.Session.Version.DefaultAgentTerminationReconnectDelay(),
+			)
+		} else {
+			agentTerminationReconnectDelayDescription = fmt.Sprintf("%d milliseconds", configuration.AgentTerminationReconnectDelay)
+		}
+		fmt.Println("\tAgent termination reconnect delay:", agentTerminationReconnectDelayDescription)
+
+		// Compute and print the maximum beta scan deferral.
+		var maximumBetaScanDeferralDescription string
+		if configuration.MaximumBetaScanDeferral == 0 {
+			maximumBetaScanDeferralDescription = "Default (Disabled)"
+		} else {
+			maximumBetaScanDeferralDescription = fmt.Sprintf("%d cycles", configuration.MaximumBetaScanDeferral)
+		}
+		fmt.Println("\tMaximum beta scan deferral:", maximumBetaScanDeferralDescription)
+
+		// Compute and print the verification mode.
+		verificationModeDescription := configuration.VerificationMode.Description()
+		if configuration.VerificationMode.IsDefault() {
+			defaultVerificationMode := state.Session.Version.DefaultVerificationMode()
+			verificationModeDescription += fmt.Sprintf(" (%s)", defaultVerificationMode.Description())
+		}
+		fmt.Println("\tVerification mode:", verificationModeDescription)
+
+		// Compute and print the verification interval.
+		var verificationIntervalDescription string
+		if configuration.VerificationInterval == 0 {
+			verificationIntervalDescription = fmt.Sprintf(
+				"Default (%d seconds)",
+				state.Session.Version.DefaultVerificationInterval(),
+			)
+		} else {
+			verificationIntervalDescription = fmt.Sprintf("%d seconds", configuration.VerificationInterval)
+		}
+		fmt.Println("\tVerification interval:", verificationIntervalDescription)
+
+		// Compute and print the maximum reported problem count.
+		var maximumReportedProblemsDescription string
+		if configuration.MaximumReportedProblems == 0 {
+			maximumReportedProblemsDescription = fmt.Sprintf(
+				"Default (%d)",
+				state.Session.Version.DefaultMaximumReportedProblems(),
+			)
+		} else {
+			maximumReportedProblemsDescription = fmt.Sprint(configuration.MaximumReportedProblems)
+		}
+		fmt.Println("\tMaximum reported problems:", maximumReportedProblemsDescription)
+
+		// Compute and print the maximum reconciliation recording count.
+		var maximumReconciliationRecordingsDescription string
+		if configuration.MaximumReconciliationRecordings == 0 {
+			if d := state.Session.Version.DefaultMaximumReconciliationRecordings(); d == 0 {
+				maximumReconciliationRecordingsDescription = "Default (disabled)"
+			} else {
+				maximumReconciliationRecordingsDescription = fmt.Sprintf("Default (%d)", d)
+			}
+		} else {
+			maximumReconciliationRecordingsDescription = fmt.Sprint(configuration.MaximumReconciliationRecordings)
+		}
+		fmt.Println("\tMaximum reconciliation recordings:", maximumReconciliationRecordingsDescription)
+
+		// Compute and print the oversized file mode.
+		oversizedFileModeDescription := configuration.OversizedFileMode.Description()
+		if configuration.OversizedFileMode.IsDefault() {
+			defaultOversizedFileMode := state.Session.Version.DefaultOversizedFileMode()
+			oversizedFileModeDescription += fmt.Sprintf(" (%s)", defaultOversizedFileMode.Description())
+		}
+		fmt.Println("\tOversized file mode:", oversizedFileModeDescription)
+
+		// Compute and print maximum rename detection file size.
+		var maximumRenameDetectionFileSizeDescription string
+		if configuration.MaximumRenameDetectionFileSize == 0 {
+			maximumRenameDetectionFileSizeDescription = fmt.Sprintf(
 				"Default (%s)",
-				humanize.Bytes(state.Session.Version.DefaultMaximumStagingFileSize()),
+				humanize.Bytes(state.Session.Version.DefaultMaximumRenameDetectionFileSize()),
 			)
 		} else {
-			maximumStagingFileSizeDescription = fmt.Sprintf(
+			maximumRenameDetectionFileSizeDescription = fmt.Sprintf(
 				"%d (%s)",
-				configuration.MaximumStagingFileSize,
-				humanize.Bytes(configuration.MaximumStagingFileSize),
+				configuration.MaximumRenameDetectionFileSize,
+				humanize.Bytes(configuration.MaximumRenameDetectionFileSize),
+			)
+		}
+		fmt.Println("\tMaximum rename detection file size:", maximumRenameDetectionFileSizeDescription)
+
+		// Compute and print the rename detection digest length.
+		var renameDetectionDigestLengthDescription string
+		if configuration.RenameDetectionDigestLength == 0 {
+			renameDetectionDigestLengthDescription = "Default (Full)"
+		} else {
+			renameDetectionDigestLengthDescription = fmt.Sprintf("%d bytes", configuration.RenameDetectionDigestLength)
+		}
+		fmt.Println("\tRename detection digest length:", renameDetectionDigestLengthDescription)
+
+		// Compute and print the rename detection retry count.
+		var renameDetectionRetriesDescription string
+		if configuration.RenameDetectionRetries == 0 {
+			renameDetectionRetriesDescription = fmt.Sprintf("Default (%d)", state.Session.Version.DefaultRenameDetectionRetries())
+		} else {
+			renameDetectionRetriesDescription = fmt.Sprint(configuration.RenameDetectionRetries)
+		}
+		fmt.Println("\tRename detection retries:", renameDetectionRetriesDescription)
+
+		// Compute and print maximum content cache size.
+		var maximumContentCacheSizeDescription string
+		if configuration.MaximumContentCacheSize == 0 {
+			maximumContentCacheSizeDescription = "Default (Disabled)"
+		} else {
+			maximumContentCacheSizeDescription = fmt.Sprintf(
+				"%d (%s)",
+				configuration.MaximumContentCacheSize,
+				humanize.Bytes(configuration.MaximumContentCacheSize),
+			)
+		}
+		fmt.Println("\tMaximum content cache size:", maximumContentCacheSizeDescription)
+
+		// Compute and print the staging concurrency mode.
+		stagingConcurrencyModeDescription := configuration.StagingConcurrencyMode.Description()
+		if configuration.StagingConcurrencyMode.IsDefault() {
+			defaultStagingConcurrencyMode := state.Session.Version.DefaultStagingConcurrencyMode()
+			stagingConcurrencyModeDescription += fmt.Sprintf(" (%s)", defaultStagingConcurrencyMode.Description())
+		}
+		fmt.Println("\tStaging concurrency mode:", stagingConcurrencyModeDescription)
+
+		// Compute and print the maximum deltification time.
+		var maximumDeltificationTimeDescription string
+		if configuration.MaximumDeltificationTime == 0 {
+			maximumDeltificationTimeDescription = "Default (Unlimited)"
+		} else {
+			maximumDeltificationTimeDescription = fmt.Sprintf("%d ms", configuration.MaximumDeltificationTime)
+		}
+		fmt.Println("\tMaximum deltification time:", maximumDeltificationTimeDescription)
+
+		// Compute and print the maximum staged content age.
+		var maximumStagedContentAgeDescription string
+		if configuration.MaximumStagedContentAge == 0 {
+			maximumStagedContentAgeDescription = "Default (Unlimited)"
+		} else {
+			maximumStagedContentAgeDescription = fmt.Sprintf("%d seconds", configuration.MaximumStagedContentAge)
+		}
+		fmt.Println("\tMaximum staged content age:", maximumStagedContentAgeDescription)
+
+		// Compute and print the rsync block size.
+		var rsyncBlockSizeDescription string
+		if configuration.RsyncBlockSize == 0 {
+			rsyncBlockSizeDescription = "Default (Automatic)"
+		} else {
+			rsyncBlockSizeDescription = fmt.Sprintf(
+				"%d (%s)",
+				configuration.RsyncBlockSize,
+				humanize.IBytes(configuration.RsyncBlockSize),
+			)
+		}
+		fmt.Println("\tRsync block size:", rsyncBlockSizeDescription)
+
+		// Print the staging compression algorithm.
+		stagingCompressionAlgorithm := configuration.StagingCompressionAlgorithm.Description()
+		if configuration.StagingCompressionAlgorithm.IsDefault() {
+			stagingCompressionAlgorithm += fmt.Sprintf(" (%s)", state.Session.Version.DefaultStagingCompressionAlgorithm().Description())
+		}
+		fmt.Println("\tStaging compression:", stagingCompressionAlgorithm)
+
+		// Compute and print maximum signature memory.
+		var maximumSignatureMemoryDescription string
+		if configuration.MaximumSignatureMemory == 0 {
+			maximumSignatureMemoryDescription = fmt.Sprintf(
+				"Default (%s)",
+				humanize.Bytes(state.Session.Version.DefaultMaximumSignatureMemory()),
+			)
+		} else {
+			maximumSignatureMemoryDescription = fmt.Sprintf(
+				"%d (%s)",
+				configuration.MaximumSignatureMemory,
+				humanize.Bytes(configuration.MaximumSignatureMemory),
+			)
+		}
+		fmt.Println("\tMaximum signature memory:", maximumSignatureMemoryDescription)
+
+		// Compute and print maximum in-flight bytes.
+		var maximumInFlightBytesDescription string
+		if configuration.MaximumInFlightBytes == 0 {
+			if d := state.Session.Version.DefaultMaximumInFlightBytes(); d == 0 {
+				maximumInFlightBytesDescription = "Default (synchronous)"
+			} else {
+				maximumInFlightBytesDescription = fmt.Sprintf("Default (%s)", humanize.Bytes(d))
+			}
+		} else {
+			maximumInFlightBytesDescription = fmt.Sprintf(
+				"%d (%s)",
+				configuration.MaximumInFlightBytes,
+				humanize.Bytes(configuration.MaximumInFlightBytes),
 			)
 		}
-		fmt.Println("\tMaximum staging file size:", maximumStagingFileSizeDescription)
+		fmt.Println("\tMaximum in-flight bytes:", maximumInFlightBytesDescription)
 
 		// Compute and print symbolic link mode.
 		symbolicLinkModeDescription := configuration.SymbolicLinkMode.Description()
@@ -368,6 +915,70 @@ func printSession(state *synchronization.State, mode common.SessionDisplayMode)
 		}
 		fmt.Println("\tSymbolic link mode:", symbolicLinkModeDescription)
 
+		// Compute and print symbolic link replacement mode.
+		symbolicLinkReplacementModeDescription := configuration.SymbolicLinkReplacementMode.Description()
+		if configuration.SymbolicLinkReplacementMode.IsDefault() {
+			defaultSymbolicLinkReplacementMode := state.Session.Version.DefaultSymbolicLinkReplacementMode()
+			symbolicLinkReplacementModeDescription += fmt.Sprintf(" (%s)", defaultSymbolicLinkReplacementMode.Description())
+		}
+		fmt.Println("\tSymbolic link replacement mode:", symbolicLinkReplacementModeDescription)
+
+		// Compute and print symbolic link cycle mode.
+		symbolicLinkCycleModeDescription := configuration.SymbolicLinkCycleMode.Description()
+		if configuration.SymbolicLinkCycleMode.IsDefault() {
+			defaultSymbolicLinkCycleMode := state.Session.Version.DefaultSymbolicLinkCycleMode()
+			symbolicLinkCycleModeDescription += fmt.Sprintf(" (%s)", defaultSymbolicLinkCycleMode.Description())
+		}
+		fmt.Println("\tSymbolic link cycle mode:", symbolicLinkCycleModeDescription)
+
+		// Compute and print special file mode.
+		specialFileModeDescription := configuration.SpecialFileMode.Description()
+		if configuration.SpecialFileMode.IsDefault() {
+			defaultSpecialFileMode := state.Session.Version.DefaultSpecialFileMode()
+			specialFileModeDescription += fmt.Sprintf(" (%s)", defaultSpecialFileMode.Description())
+		}
+		fmt.Println("\tSpecial file mode:", specialFileModeDescription)
+
+		// Compute and print cache trust mode.
+		cacheTrustModeDescription := configuration.CacheTrustMode.Description()
+		if configuration.CacheTrustMode.IsDefault() {
+			defaultCacheTrustMode := state.Session.Version.DefaultCacheTrustMode()
+			cacheTrustModeDescription += fmt.Sprintf(" (%s)", defaultCacheTrustMode.Description())
+		}
+		fmt.Println("\tCache trust mode:", cacheTrustModeDescription)
+
+		// Compute and print name normalization mode.
+		nameNormalizationModeDescription := configuration.NameNormalizationMode.Description()
+		if configuration.NameNormalizationMode.IsDefault() {
+			defaultNameNormalizationMode := state.Session.Version.DefaultNameNormalizationMode()
+			nameNormalizationModeDescription += fmt.Sprintf(" (%s)", defaultNameNormalizationMode.Description())
+		}
+		fmt.Println("\tName normalization mode:", nameNormalizationModeDescription)
+
+		// Compute and print capability mismatch mode.
+		capabilityMismatchModeDescription := configuration.CapabilityMismatchMode.Description()
+		if configuration.CapabilityMismatchMode.IsDefault() {
+			defaultCapabilityMismatchMode := state.Session.Version.DefaultCapabilityMismatchMode()
+			capabilityMismatchModeDescription += fmt.Sprintf(" (%s)", defaultCapabilityMismatchMode.Description())
+		}
+		fmt.Println("\tCapability mismatch mode:", capabilityMismatchModeDescription)
+
+		// Compute and print root overlap mode.
+		rootOverlapModeDescription := configuration.RootOverlapMode.Description()
+		if configuration.RootOverlapMode.IsDefault() {
+			defaultRootOverlapMode := state.Session.Version.DefaultRootOverlapMode()
+			rootOverlapModeDescription += fmt.Sprintf(" (%s)", defaultRootOverlapMode.Description())
+		}
+		fmt.Println("\tRoot overlap mode:", rootOverlapModeDescription)
+
+		// Compute and print configuration incompatibility mode.
+		configurationIncompatibilityModeDescription := configuration.ConfigurationIncompatibilityMode.Description()
+		if configuration.ConfigurationIncompatibilityMode.IsDefault() {
+			defaultConfigurationIncompatibilityMode := state.Session.Version.DefaultConfigurationIncompatibilityMode()
+			configurationIncompatibilityModeDescription += fmt.Sprintf(" (%s)", defaultConfigurationIncompatibilityMode.Description())
+		}
+		fmt.Println("\tConfiguration incompatibility mode:", configurationIncompatibilityModeDescription)
+
 		// Compute and print the ignore syntax.
 		ignoreSyntaxDescription := configuration.IgnoreSyntax.Description()
 		if configuration.IgnoreSyntax.IsDefault() {
@@ -403,6 +1014,40 @@ func printSession(state *synchronization.State, mode common.SessionDisplayMode)
 		}
 		fmt.Println("\tIgnore VCS mode:", ignoreVCSModeDescription)
 
+		// Compute and print the OS metadata ignore mode.
+		ignoreOSMetadataModeDescription := configuration.IgnoreOSMetadataMode.Description()
+		if configuration.IgnoreOSMetadataMode.IsDefault() {
+			defaultIgnoreOSMetadataMode := state.Session.Version.DefaultIgnoreOSMetadataMode()
+			ignoreOSMetadataModeDescription += fmt.Sprintf(" (%s)", defaultIgnoreOSMetadataMode.Description())
+		}
+		fmt.Println("\tIgnore OS metadata mode:", ignoreOSMetadataModeDescription)
+
+		// Compute and print the phantom directory mode.
+		phantomDirectoryModeDescription := configuration.PhantomDirectoryMode.Description()
+		if configuration.PhantomDirectoryMode.IsDefault() {
+			defaultPhantomDirectoryMode := state.Session.Version.DefaultPhantomDirectoryMode()
+			phantomDirectoryModeDescription += fmt.Sprintf(" (%s)", defaultPhantomDirectoryMode.Description())
+		}
+		fmt.Println("\tPhantom directory mode:", phantomDirectoryModeDescription)
+
+		// Compute and print the maximum file size.
+		maximumFileSizeDescription := "Unlimited"
+		if configuration.MaximumFileSize != 0 {
+			maximumFileSizeDescription = fmt.Sprintf("%d (%s)",
+				configuration.MaximumFileSize,
+				humanize.Bytes(configuration.MaximumFileSize),
+			)
+		}
+		fmt.Println("\tMaximum file size:", maximumFileSizeDescription)
+
+		// Print the manifest, if any.
+		if len(configuration.Manifest) > 0 {
+			fmt.Println("\tManifest:")
+			for _, p := range configuration.Manifest {
+				fmt.Printf("\t\t%s\n", terminal.NeutralizeControlCharacters(p))
+			}
+		}
+
 		// Compute and print permissions mode.
 		permissionsModeDescription := configuration.PermissionsMode.Description()
 		if configuration.PermissionsMode.IsDefault() {
@@ -452,9 +1097,29 @@ func printSession(state *synchronization.State, mode common.SessionDisplayMode)
 		}
 	}
 
+	// Print capability mismatches, if any.
+	if len(state.CapabilityMismatches) > 0 {
+		color.Yellow("Filesystem capability mismatches:\n")
+		for _, m := range state.CapabilityMismatches {
+			color.Yellow("\t%s (alpha: %s, beta: %s)\n",
+				terminal.NeutralizeControlCharacters(m.Capability),
+				formatCapability(m.Alpha), formatCapability(m.Beta),
+			)
+		}
+	}
+
+	// Print warnings, if any.
+	for _, warning := range state.Warnings {
+		color.Yellow("Warning: %s\n", terminal.NeutralizeControlCharacters(warning))
+	}
+
 	// Print the last error, if any.
 	if state.LastError != "" {
-		color.Red("Last error: %s\n", terminal.NeutralizeControlCharacters(state.LastError))
+		if state.AgentTerminated {
+			color.Red("Last error (agent terminated): %s\n", terminal.NeutralizeControlCharacters(state.LastError))
+		} else {
+			color.Red("Last error: %s\n", terminal.NeutralizeControlCharacters(state.LastError))
+		}
 	}
 
 	// Print the session status .
@@ -465,35 +1130,15 @@ func printSession(state *synchronization.State, mode common.SessionDisplayMode)
 	fmt.Fprintln(color.Output, "Status:", statusString)
 
 	// Print staging progress if we're staging files and progress information is
-	// available for the target endpoint.
-	var stagingProgress *rsync.ReceiverState
-	var totalExpectedSize uint64
-	if state.Status == synchronization.Status_StagingAlpha {
-		stagingProgress = state.AlphaState.StagingProgress
-		if stagingProgress != nil && stagingProgress.ExpectedFiles == state.BetaState.Files {
-			totalExpectedSize = state.BetaState.TotalFileSize
-		}
-	} else if state.Status == synchronization.Status_StagingBeta {
-		stagingProgress = state.BetaState.StagingProgress
-		if stagingProgress != nil && stagingProgress.ExpectedFiles == state.AlphaState.Files {
-			totalExpectedSize = state.AlphaState.TotalFileSize
-		}
-	}
-	if stagingProgress != nil {
-		var fractionComplete float32
-		var totalSizeDenominator string
-		if totalExpectedSize != 0 {
-			fractionComplete = float32(stagingProgress.TotalReceivedSize) / float32(totalExpectedSize)
-			totalSizeDenominator = "/" + humanize.Bytes(totalExpectedSize)
-		} else {
-			fractionComplete = float32(stagingProgress.ReceivedFiles) / float32(stagingProgress.ExpectedFiles)
-		}
-		fmt.Printf("Staging progress: %d/%d - %s%s - %.0f%%\nCurrent file: %s (%s/%s)\n",
-			stagingProgress.ReceivedFiles, stagingProgress.ExpectedFiles,
-			humanize.Bytes(stagingProgress.TotalReceivedSize), totalSizeDenominator,
-			100.0*fractionComplete,
-			terminal.NeutralizeControlCharacters(stagingProgress.Path),
-			humanize.Bytes(stagingProgress.ReceivedSize), humanize.Bytes(stagingProgress.ExpectedSize),
-		)
+	// available for the target endpoint(s). If both endpoints are staging
+	// concurrently, then we label their progress to distinguish them.
+	switch state.Status {
+	case synchronization.Status_StagingAlpha:
+		printStagingProgress("", state.AlphaState.StagingProgress, state.BetaState)
+	case synchronization.Status_StagingBeta:
+		printStagingProgress("", state.BetaState.StagingProgress, state.AlphaState)
+	case synchronization.Status_Staging:
+		printStagingProgress("Alpha", state.AlphaState.StagingProgress, state.BetaState)
+		printStagingProgress("Beta", state.BetaState.StagingProgress, state.AlphaState)
 	}
 }
@@ -44,9 +44,13 @@ func init() {
 	// Register commands.
 	SyncCommand.AddCommand(
 		createCommand,
+		estimateCommand,
+		previewCommand,
 		listCommand,
 		monitorCommand,
 		flushCommand,
+		catCommand,
+		conflictsCommand,
 		pauseCommand,
 		resumeCommand,
 		resetCommand,
@@ -80,6 +80,16 @@ func computeMonitorStatusLine(state *synchronization.State) string {
 			} else if stagingProgress.ExpectedFiles == state.AlphaState.Files {
 				totalExpectedSize = state.AlphaState.TotalFileSize
 			}
+		} else if state.Status == synchronization.Status_Staging {
+			// When staging concurrently, there isn't room to display detailed
+			// progress for both endpoints, so we just show file counts.
+			status += "[↔] Staging files"
+			if progress := state.AlphaState.StagingProgress; progress != nil {
+				status += fmt.Sprintf(" [alpha: %d/%d]", progress.ReceivedFiles, progress.ExpectedFiles)
+			}
+			if progress := state.BetaState.StagingProgress; progress != nil {
+				status += fmt.Sprintf(" [beta: %d/%d]", progress.ReceivedFiles, progress.ExpectedFiles)
+			}
 		} else {
 			status += state.Status.Description()
 		}
@@ -94,12 +104,13 @@ func computeMonitorStatusLine(state *synchronization.State) string {
 			} else {
 				fractionComplete = float32(stagingProgress.ReceivedFiles) / float32(stagingProgress.ExpectedFiles)
 			}
-			status += fmt.Sprintf("[%d/%d - %s%s - %.0f%%] %s (%s/%s)",
+			status += fmt.Sprintf("[%d/%d - %s%s - %.0f%%] %s (%s/%s)%s",
 				stagingProgress.ReceivedFiles, stagingProgress.ExpectedFiles,
 				humanize.Bytes(stagingProgress.TotalReceivedSize), totalSizeDenominator,
 				100.0*fractionComplete,
 				terminal.NeutralizeControlCharacters(path.Base(stagingProgress.Path)),
 				humanize.Bytes(stagingProgress.ReceivedSize), humanize.Bytes(stagingProgress.ExpectedSize),
+				formatTransferRate(stagingProgress),
 			)
 		}
 	}
@@ -0,0 +1,268 @@
+package sync
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/fatih/color"
+
+	"github.com/spf13/cobra"
+
+	"github.com/mutagen-io/mutagen/cmd"
+	"github.com/mutagen-io/mutagen/cmd/mutagen/daemon"
+
+	"github.com/mutagen-io/mutagen/pkg/grpcutil"
+	"github.com/mutagen-io/mutagen/pkg/platform/terminal"
+	"github.com/mutagen-io/mutagen/pkg/selection"
+	promptingsvc "github.com/mutagen-io/mutagen/pkg/service/prompting"
+	synchronizationsvc "github.com/mutagen-io/mutagen/pkg/service/synchronization"
+	"github.com/mutagen-io/mutagen/pkg/synchronization"
+	"github.com/mutagen-io/mutagen/pkg/synchronization/core"
+	"github.com/mutagen-io/mutagen/pkg/synchronization/core/ignore"
+	"github.com/mutagen-io/mutagen/pkg/url"
+)
+
+// printPreviewTransitions prints the planned transitions and expected problems
+// for an endpoint.
+func printPreviewTransitions(name string, transitions []*core.Change, problems []*core.Problem) {
+	// Print transitions.
+	fmt.Printf("%s changes: %d\n", name, len(transitions))
+	for _, t := range transitions {
+		fmt.Printf("\t%s (%s -> %s)\n",
+			terminal.NeutralizeControlCharacters(formatPath(t.Path)),
+			terminal.NeutralizeControlCharacters(formatEntry(t.Old)),
+			terminal.NeutralizeControlCharacters(formatEntry(t.New)),
+		)
+	}
+
+	// Print problems, if any.
+	if len(problems) > 0 {
+		color.Red("%s problems:\n", name)
+		for _, p := range problems {
+			color.Red("\t%s: %v\n",
+				terminal.NeutralizeControlCharacters(formatPath(p.Path)),
+				terminal.NeutralizeControlCharacters(p.Error),
+			)
+		}
+	}
+}
+
+// printPreview prints a preview.
+func printPreview(preview *synchronization.Preview) {
+	printPreviewTransitions("Alpha", preview.AlphaTransitions, preview.AlphaProblems)
+	printPreviewTransitions("Beta", preview.BetaTransitions, preview.BetaProblems)
+	if len(preview.Conflicts) > 0 {
+		printConflicts(preview.Conflicts, 0)
+	}
+}
+
+// previewSession previews the next synchronization cycle of an existing
+// (paused) session via the daemon.
+func previewSession(specification string) error {
+	// Create session selection specification.
+	selection := &selection.Selection{
+		Specifications: []string{specification},
+	}
+	if err := selection.EnsureValid(); err != nil {
+		return fmt.Errorf("invalid session selection specification: %w", err)
+	}
+
+	// Connect to the daemon and defer closure of the connection.
+	daemonConnection, err := daemon.Connect(true, true)
+	if err != nil {
+		return fmt.Errorf("unable to connect to daemon: %w", err)
+	}
+	defer daemonConnection.Close()
+
+	// Initiate command line messaging.
+	statusLinePrinter := &cmd.StatusLinePrinter{}
+	promptingCtx, promptingCancel := context.WithCancel(context.Background())
+	prompter, promptingErrors, err := promptingsvc.Host(
+		promptingCtx, promptingsvc.NewPromptingClient(daemonConnection),
+		&cmd.StatusLinePrompter{Printer: statusLinePrinter}, true,
+	)
+	if err != nil {
+		promptingCancel()
+		return fmt.Errorf("unable to initiate prompting: %w", err)
+	}
+
+	// Perform the preview operation, cancel prompting, and handle errors.
+	synchronizationService := synchronizationsvc.NewSynchronizationClient(daemonConnection)
+	request := &synchronizationsvc.PreviewRequest{
+		Prompter:  prompter,
+		Selection: selection,
+	}
+	response, err := synchronizationService.Preview(context.Background(), request)
+	promptingCancel()
+	<-promptingErrors
+	if err != nil {
+		statusLinePrinter.BreakIfPopulated()
+		return grpcutil.PeelAwayRPCErrorLayer(err)
+	} else if err = response.EnsureValid(); err != nil {
+		statusLinePrinter.BreakIfPopulated()
+		return fmt.Errorf("invalid preview response received: %w", err)
+	}
+	statusLinePrinter.Clear()
+
+	// Print the preview.
+	printPreview(response.Preview)
+
+	// Success.
+	return nil
+}
+
+// previewMain is the entry point for the preview command.
+func previewMain(_ *cobra.Command, arguments []string) error {
+	// If a single argument has been provided, then treat it as a session
+	// specification. The session's own configuration is used in that case, so
+	// configuration flags aren't supported.
+	if len(arguments) == 1 {
+		configured := previewConfiguration.synchronizationMode != "" ||
+			previewConfiguration.ignoreSyntax != "" ||
+			len(previewConfiguration.ignores) > 0 ||
+			previewConfiguration.ignoreVCS || previewConfiguration.noIgnoreVCS ||
+			previewConfiguration.ignoreOSMetadata || previewConfiguration.noIgnoreOSMetadata
+		if configured {
+			return errors.New("configuration flags can't be used when previewing a session")
+		}
+		return previewSession(arguments[0])
+	}
+
+	// Otherwise, validate, extract, and parse URLs.
+	if len(arguments) != 2 {
+		return errors.New("a session or a pair of endpoint URLs must be specified")
+	}
+	alpha, err := url.Parse(arguments[0], url.Kind_Synchronization, true)
+	if err != nil {
+		return fmt.Errorf("unable to parse alpha URL: %w", err)
+	}
+	beta, err := url.Parse(arguments[1], url.Kind_Synchronization, false)
+	if err != nil {
+		return fmt.Errorf("unable to parse beta URL: %w", err)
+	}
+
+	// Previews are computed in-process and thus only support local endpoints.
+	if alpha.Protocol != url.Protocol_Local {
+		return errors.New("previews are only supported for local alpha endpoints")
+	} else if beta.Protocol != url.Protocol_Local {
+		return errors.New("previews are only supported for local beta endpoints")
+	}
+
+	// Validate and convert the synchronization mode specification.
+	var synchronizationMode core.SynchronizationMode
+	if previewConfiguration.synchronizationMode != "" {
+		if err := synchronizationMode.UnmarshalText([]byte(previewConfiguration.synchronizationMode)); err != nil {
+			return fmt.Errorf("unable to parse synchronization mode: %w", err)
+		}
+	}
+
+	// Validate and convert the ignore syntax specification.
+	var ignoreSyntax ignore.Syntax
+	if previewConfiguration.ignoreSyntax != "" {
+		if err := ignoreSyntax.UnmarshalText([]byte(previewConfiguration.ignoreSyntax)); err != nil {
+			return fmt.Errorf("unable to parse ignore syntax: %w", err)
+		}
+	}
+
+	// Validate and convert the VCS ignore mode specification.
+	var ignoreVCSMode ignore.IgnoreVCSMode
+	if previewConfiguration.ignoreVCS && previewConfiguration.noIgnoreVCS {
+		return errors.New("conflicting VCS ignore behavior specified")
+	} else if previewConfiguration.ignoreVCS {
+		ignoreVCSMode = ignore.IgnoreVCSMode_IgnoreVCSModeIgnore
+	} else if previewConfiguration.noIgnoreVCS {
+		ignoreVCSMode = ignore.IgnoreVCSMode_IgnoreVCSModePropagate
+	}
+
+	// Validate and convert the OS metadata ignore mode specification.
+	var ignoreOSMetadataMode ignore.IgnoreOSMetadataMode
+	if previewConfiguration.ignoreOSMetadata && previewConfiguration.noIgnoreOSMetadata {
+		return errors.New("conflicting OS metadata ignore behavior specified")
+	} else if previewConfiguration.ignoreOSMetadata {
+		ignoreOSMetadataMode = ignore.IgnoreOSMetadataMode_IgnoreOSMetadataModeIgnore
+	} else if previewConfiguration.noIgnoreOSMetadata {
+		ignoreOSMetadataMode = ignore.IgnoreOSMetadataMode_IgnoreOSMetadataModePropagate
+	}
+
+	// Create the configuration.
+	configuration := &synchronization.Configuration{
+		SynchronizationMode:  synchronizationMode,
+		IgnoreSyntax:         ignoreSyntax,
+		Ignores:              previewConfiguration.ignores,
+		IgnoreVCSMode:        ignoreVCSMode,
+		IgnoreOSMetadataMode: ignoreOSMetadataMode,
+	}
+
+	// Compute the preview.
+	preview, err := synchronization.PreviewLocal(
+		context.Background(),
+		alpha.Path, beta.Path,
+		synchronization.DefaultVersion,
+		configuration,
+	)
+	if err != nil {
+		return fmt.Errorf("unable to compute preview: %w", err)
+	}
+
+	// Print the preview.
+	printPreview(preview)
+
+	// Success.
+	return nil
+}
+
+// previewCommand is the preview command.
+var previewCommand = &cobra.Command{
+	Use:          "preview {<session>|<alpha> <beta>}",
+	Short:        "Preview the changes that synchronization would make for a paused session or a pair of endpoints",
+	RunE:         previewMain,
+	SilenceUsage: true,
+}
+
+// previewConfiguration stores configuration for the preview command.
+var previewConfiguration struct {
+	// help indicates whether or not to show help information and exit.
+	help bool
+	// synchronizationMode specifies the synchronization mode for the preview.
+	synchronizationMode string
+	// ignoreSyntax specifies the ignore syntax and semantics for the preview.
+	ignoreSyntax string
+	// ignores is the list of ignore specifications for the preview.
+	ignores []string
+	// ignoreVCS specifies whether or not to enable VCS ignores for the
+	// preview.
+	ignoreVCS bool
+	// noIgnoreVCS specifies whether or not to disable VCS ignores for the
+	// preview.
+	noIgnoreVCS bool
+	// ignoreOSMetadata specifies whether or not to enable OS metadata ignores
+	// for the preview.
+	ignoreOSMetadata bool
+	// noIgnoreOSMetadata specifies whether or not to disable OS metadata
+	// ignores for the preview.
+	noIgnoreOSMetadata bool
+}
+
+func init() {
+	// Grab a handle for the command line flags.
+	flags := previewCommand.Flags()
+
+	// Disable alphabetical sorting of flags in help output.
+	flags.SortFlags = false
+
+	// Manually add a help flag to override the default message. Cobra will
+	// still implement its logic automatically.
+	flags.BoolVarP(&previewConfiguration.help, "help", "h", false, "Show help information")
+
+	// Wire up synchronization flags.
+	flags.StringVarP(&previewConfiguration.synchronizationMode, "mode", "m", "", "Specify synchronization mode (two-way-safe|two-way-resolved|two-way-newest|one-way-safe|one-way-replica)")
+
+	// Wire up ignore flags.
+	flags.StringVar(&previewConfiguration.ignoreSyntax, "ignore-syntax", "", "Specify ignore syntax (mutagen|docker)")
+	flags.StringSliceVarP(&previewConfiguration.ignores, "ignore", "i", nil, "Specify ignore paths")
+	flags.BoolVar(&previewConfiguration.ignoreVCS, "ignore-vcs", false, "Ignore VCS directories")
+	flags.BoolVar(&previewConfiguration.noIgnoreVCS, "no-ignore-vcs", false, "Propagate VCS directories")
+	flags.BoolVar(&previewConfiguration.ignoreOSMetadata, "ignore-os-metadata", false, "Ignore OS metadata files and directories")
+	flags.BoolVar(&previewConfiguration.noIgnoreOSMetadata, "no-ignore-os-metadata", false, "Propagate OS metadata files and directories")
+}
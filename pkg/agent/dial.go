@@ -28,6 +28,39 @@ const (
 	agentErrorInMemoryCutoff = 32 * 1024
 )
 
+// ErrAgentTerminated indicates that a connected agent process terminated
+// unexpectedly, as opposed to the connection to the remote being lost. It is
+// wrapped by errors returned from reads on streams returned by Dial.
+var ErrAgentTerminated = errors.New("agent process terminated")
+
+// ErrAuthenticationFailed indicates that a transport was unable to authenticate
+// with or obtain authorization from the remote when dialing an agent. It is
+// wrapped by errors returned from Dial for transports that implement
+// AuthenticationTransport. Retrying after such failures is unlikely to succeed
+// without user intervention.
+var ErrAuthenticationFailed = errors.New("authentication failed")
+
+// agentStream wraps an agent process stream and classifies any process exit
+// observed while reading using the transport that created the process.
+type agentStream struct {
+	// Stream is the underlying process stream.
+	*transportpkg.Stream
+	// transport is the transport that created the process.
+	transport Transport
+}
+
+// Read implements io.Reader.Read. If the underlying process exits and the
+// transport indicates that the exit was due to agent termination, then the
+// resulting error will wrap ErrAgentTerminated.
+func (s *agentStream) Read(buffer []byte) (int, error) {
+	n, err := s.Stream.Read(buffer)
+	var exitErr *transportpkg.ProcessExitError
+	if errors.As(err, &exitErr) && s.transport.ClassifyExit(exitErr.State) {
+		err = fmt.Errorf("%w: %w", ErrAgentTerminated, err)
+	}
+	return n, err
+}
+
 // connect connects to an agent-based endpoint using the specified transport,
 // connection mode, and prompter. It accepts a hint as to whether or not the
 // remote environment is cmd.exe-based and returns hints as to whether or not
@@ -146,6 +179,15 @@ func connect(logger *logging.Logger, transport Transport, mode, prompter string,
 			err = fmt.Errorf("unable to handshake with agent process: %w", err)
 		}
 
+		// If the transport can identify authentication failures, then check
+		// whether or not that's the cause of the handshake failure. There's no
+		// point in attempting installation in that case, since it will fail
+		// for the same reason.
+		if authenticationTransport, ok := transport.(AuthenticationTransport); ok &&
+			authenticationTransport.ClassifyAuthenticationFailure(agentProcess.ProcessState, errorOutput) {
+			return nil, false, false, fmt.Errorf("%w: %w", ErrAuthenticationFailed, err)
+		}
+
 		// See if we can classify the exact nature of the handshake failure. In
 		// particular, we want to identify whether or not we should try to
 		// (re-)install the agent binary and whether or not we're talking to a
@@ -173,7 +215,7 @@ func connect(logger *logging.Logger, transport Transport, mode, prompter string,
 	}
 
 	// Done.
-	return stream, false, false, nil
+	return &agentStream{stream, transport}, false, false, nil
 }
 
 // Dial connects to an agent-based endpoint using the specified transport,
@@ -40,16 +40,61 @@ type Transport interface {
 	// Otherwise, if the first bool indicates that the agent binary simply needs
 	// to be (re-)installed, it will attempt to do so and then reconnect.
 	ClassifyError(processState *os.ProcessState, errorOutput string) (bool, bool, error)
+	// ClassifyExit is used to determine whether the exit of an agent process
+	// that had been successfully connected indicates that the agent itself
+	// terminated (e.g. by crashing or being killed on the remote) rather than
+	// the transport losing connectivity to the remote. It is provided with the
+	// process exit state. It may be invoked after dialing completes.
+	ClassifyExit(processState *os.ProcessState) bool
+}
+
+// BootstrapTransport is an optional extension of Transport that can be
+// implemented by transports that distinguish the agent bootstrap phase (i.e.
+// probing and installation) from steady-state agent operation. Bootstrap
+// operations may need to transfer large amounts of data (e.g. the agent binary)
+// and thus often warrant more generous timeouts than steady-state connections,
+// especially over slow links. Transports implementing this interface should
+// apply bootstrap timing to their Copy method, since copies are only performed
+// during installation.
+type BootstrapTransport interface {
+	Transport
+	// BootstrapCommand is the same as Command, except that it will only be used
+	// for short-lived commands invoked while probing the remote and installing
+	// agent binaries. It should apply the transport's bootstrap timing.
+	BootstrapCommand(command string) (*exec.Cmd, error)
+}
+
+// bootstrapCommand creates a command for use during the agent bootstrap phase,
+// using the transport's bootstrap timing if supported.
+func bootstrapCommand(transport Transport, command string) (*exec.Cmd, error) {
+	if bootstrapTransport, ok := transport.(BootstrapTransport); ok {
+		return bootstrapTransport.BootstrapCommand(command)
+	}
+	return transport.Command(command)
+}
+
+// AuthenticationTransport is an optional extension of Transport that can be
+// implemented by transports that are able to identify authentication and
+// authorization failures. Such failures generally require user intervention to
+// resolve, so identifying them allows callers to avoid futile retries.
+type AuthenticationTransport interface {
+	Transport
+	// ClassifyAuthenticationFailure is used to determine whether or not a
+	// failure to launch an agent was caused by the transport failing to
+	// authenticate with or obtain authorization from the remote. It is
+	// provided with the same information as ClassifyError.
+	ClassifyAuthenticationFailure(processState *os.ProcessState, errorOutput string) bool
 }
 
 // run is a utility method that invokes a command via a transport, waits for it
-// to complete, and returns its exit error. If there is an error creating the
-// command, it will be returned wrapped, but otherwise the result of the run
-// method will be returned un-wrapped, so it can be treated as an
-// os/exec.ExitError.
+// to complete, and returns its exit error. The command is created using
+// bootstrap timing, since this method is only used during bootstrap. If there
+// is an error creating the command, it will be returned wrapped, but otherwise
+// the result of the run method will be returned un-wrapped, so it can be
+// treated as an os/exec.ExitError.
 func run(transport Transport, command string) error {
 	// Create the process.
-	process, err := transport.Command(command)
+	process, err := bootstrapCommand(transport, command)
 	if err != nil {
 		return fmt.Errorf("unable to create command: %w", err)
 	}
@@ -80,10 +125,10 @@ func run(transport Transport, command string) error {
 // it to complete, and returns its standard output and exit error. If there is
 // an error creating the command, it will be returned wrapped, but otherwise the
 // result of the run method will be returned un-wrapped, so it can be treated as
-// an os/exec.ExitError.
+// an os/exec.ExitError. Like run, it uses bootstrap timing.
 func output(transport Transport, command string) ([]byte, error) {
 	// Create the process.
-	process, err := transport.Command(command)
+	process, err := bootstrapCommand(transport, command)
 	if err != nil {
 		return nil, fmt.Errorf("unable to create command: %w", err)
 	}
@@ -33,6 +33,10 @@ const (
 	// containerNotRunningFragment is a fragment of text that will appear in the
 	// error output of docker exec commands if the container is not running.
 	containerNotRunningFragment = "is not running"
+	// daemonPermissionDeniedFragment is a fragment of text that will appear in
+	// the error output of docker commands if the user lacks permission to
+	// access the Docker daemon.
+	daemonPermissionDeniedFragment = "permission denied while trying to connect to the Docker daemon"
 )
 
 // dockerTransport implements the agent.Transport interface using Docker.
@@ -502,6 +506,37 @@ func (t *dockerTransport) Command(command string) (*exec.Cmd, error) {
 	return t.command(command, t.containerHomeDirectory, "")
 }
 
+// ClassifyExit implements the ClassifyExit method of agent.Transport.
+func (t *dockerTransport) ClassifyExit(processState *os.ProcessState) bool {
+	// The Docker CLI exits with the exit code of the command executed in the
+	// container, except that it uses an exit code of 1 for its own errors
+	// (including loss of connectivity to the Docker daemon) and reserves exit
+	// codes 125-127 for errors launching the command. The agent itself also
+	// uses an exit code of 1 for fatal errors, but we can't distinguish those
+	// cases, so we conservatively treat them as transport failures. If the
+	// Docker CLI was terminated by a signal, then the exit code will be -1, in
+	// which case we can't say anything about the container.
+	switch exitCode := processState.ExitCode(); {
+	case exitCode < 0:
+		return false
+	case exitCode == 1:
+		return false
+	case exitCode >= 125 && exitCode <= 127:
+		return false
+	default:
+		return true
+	}
+}
+
+// ClassifyAuthenticationFailure implements the ClassifyAuthenticationFailure
+// method of agent.AuthenticationTransport.
+func (t *dockerTransport) ClassifyAuthenticationFailure(processState *os.ProcessState, errorOutput string) bool {
+	// The Docker CLI uses an exit code of 1 for its own errors, including
+	// failure to access the Docker daemon.
+	return processState.ExitCode() == 1 &&
+		strings.Contains(errorOutput, daemonPermissionDeniedFragment)
+}
+
 // ClassifyError implements the ClassifyError method of agent.Transport.
 func (t *dockerTransport) ClassifyError(processState *os.ProcessState, errorOutput string) (bool, bool, error) {
 	// Ensure that the container has been probed.
@@ -8,6 +8,7 @@ import (
 	"os/exec"
 	"path/filepath"
 	"strconv"
+	"strings"
 
 	"github.com/mutagen-io/mutagen/pkg/agent"
 	"github.com/mutagen-io/mutagen/pkg/agent/transport"
@@ -23,12 +24,31 @@ const (
 	// serverAliveCountMax is the count to use for OpenSSH's ServerAliveCountMax
 	// configuration option.
 	serverAliveCountMax = 1
+	// bootstrapServerAliveCountMax is the count to use for OpenSSH's
+	// ServerAliveCountMax configuration option during the agent bootstrap
+	// phase (i.e. probing and installation). It's more generous than
+	// serverAliveCountMax because agent binary copies can saturate slow links
+	// for extended periods of time.
+	bootstrapServerAliveCountMax = 6
 )
 
+// authenticationFailureFragments are fragments of text that will appear in the
+// error output of the OpenSSH client if it's unable to authenticate with (or
+// verify the identity of) the remote.
+var authenticationFailureFragments = []string{
+	"Permission denied (",
+	"Too many authentication failures",
+	"Host key verification failed",
+}
+
 var (
 	// connectTimeoutSeconds is the number of seconds to use for OpenSSH's
 	// ConnectTimeout configuration option.
 	connectTimeoutSeconds uint64 = 5
+	// bootstrapConnectTimeoutSeconds is the number of seconds to use for
+	// OpenSSH's ConnectTimeout configuration option during the agent bootstrap
+	// phase.
+	bootstrapConnectTimeoutSeconds uint64 = 30
 )
 
 func init() {
@@ -37,6 +57,28 @@ func init() {
 	if t, err := strconv.ParseUint(os.Getenv("MUTAGEN_SSH_CONNECT_TIMEOUT"), 10, 64); err == nil && t > 0 {
 		connectTimeoutSeconds = t
 	}
+
+	// If a valid bootstrap connection timeout has been specified in the
+	// environment, then override the default bootstrap connection timeout
+	// setting.
+	if t, err := strconv.ParseUint(os.Getenv("MUTAGEN_SSH_BOOTSTRAP_CONNECT_TIMEOUT"), 10, 64); err == nil && t > 0 {
+		bootstrapConnectTimeoutSeconds = t
+	}
+}
+
+// timeoutFlags returns the OpenSSH connection timeout and server alive flags
+// to use for an operation, applying bootstrap timing if requested. The
+// bootstrap timing will never be less generous than the steady-state timing.
+func timeoutFlags(bootstrap bool) []string {
+	connectTimeout, countMax := connectTimeoutSeconds, serverAliveCountMax
+	if bootstrap {
+		connectTimeout = max(connectTimeout, bootstrapConnectTimeoutSeconds)
+		countMax = max(countMax, bootstrapServerAliveCountMax)
+	}
+	return append(
+		[]string{ssh.ConnectTimeoutFlag(connectTimeout)},
+		ssh.ServerAliveFlags(serverAliveIntervalSeconds, countMax)...,
+	)
 }
 
 // sshTransport implements the agent.Transport interface using SSH.
@@ -61,7 +103,8 @@ func NewTransport(user, host string, port uint16, prompter string) (agent.Transp
 	}, nil
 }
 
-// Copy implements the Copy method of agent.Transport.
+// Copy implements the Copy method of agent.Transport. Since copies are only
+// performed during agent installation, it uses bootstrap timing.
 func (t *sshTransport) Copy(localPath, remoteName string) error {
 	// HACK: On Windows, we attempt to use SCP executables that might not
 	// understand Windows paths because they're designed to run inside a POSIX-
@@ -96,8 +139,7 @@ func (t *sshTransport) Copy(localPath, remoteName string) error {
 	// Set up arguments.
 	var scpArguments []string
 	scpArguments = append(scpArguments, ssh.CompressionFlag())
-	scpArguments = append(scpArguments, ssh.ConnectTimeoutFlag(connectTimeoutSeconds))
-	scpArguments = append(scpArguments, ssh.ServerAliveFlags(serverAliveIntervalSeconds, serverAliveCountMax)...)
+	scpArguments = append(scpArguments, timeoutFlags(true)...)
 	if t.port != 0 {
 		scpArguments = append(scpArguments, "-P", fmt.Sprintf("%d", t.port))
 	}
@@ -142,8 +184,9 @@ func (t *sshTransport) Copy(localPath, remoteName string) error {
 	return nil
 }
 
-// Command implements the Command method of agent.Transport.
-func (t *sshTransport) Command(command string) (*exec.Cmd, error) {
+// command creates an SSH process that will invoke the specified command on the
+// remote, applying bootstrap timing if requested.
+func (t *sshTransport) command(command string, bootstrap bool) (*exec.Cmd, error) {
 	// Compute the target.
 	target := t.host
 	if t.user != "" {
@@ -155,8 +198,7 @@ func (t *sshTransport) Command(command string) (*exec.Cmd, error) {
 	// more efficient to compress at that layer, even with the slower Go
 	// implementation.
 	var sshArguments []string
-	sshArguments = append(sshArguments, ssh.ConnectTimeoutFlag(connectTimeoutSeconds))
-	sshArguments = append(sshArguments, ssh.ServerAliveFlags(serverAliveIntervalSeconds, serverAliveCountMax)...)
+	sshArguments = append(sshArguments, timeoutFlags(bootstrap)...)
 	if t.port != 0 {
 		sshArguments = append(sshArguments, "-p", fmt.Sprintf("%d", t.port))
 	}
@@ -190,6 +232,45 @@ func (t *sshTransport) Command(command string) (*exec.Cmd, error) {
 	return sshCommand, nil
 }
 
+// Command implements the Command method of agent.Transport.
+func (t *sshTransport) Command(command string) (*exec.Cmd, error) {
+	return t.command(command, false)
+}
+
+// BootstrapCommand implements the BootstrapCommand method of
+// agent.BootstrapTransport.
+func (t *sshTransport) BootstrapCommand(command string) (*exec.Cmd, error) {
+	return t.command(command, true)
+}
+
+// ClassifyExit implements the ClassifyExit method of agent.Transport.
+func (t *sshTransport) ClassifyExit(processState *os.ProcessState) bool {
+	// OpenSSH exits with the exit code of the remote command, unless an error
+	// occurs within OpenSSH itself (including loss of connectivity), in which
+	// case it exits with a dedicated exit code. If OpenSSH was terminated by a
+	// signal, then the exit code will be -1, in which case we can't say
+	// anything about the remote.
+	exitCode := processState.ExitCode()
+	return exitCode >= 0 && exitCode != ssh.ClientErrorExitCode
+}
+
+// ClassifyAuthenticationFailure implements the ClassifyAuthenticationFailure
+// method of agent.AuthenticationTransport.
+func (t *sshTransport) ClassifyAuthenticationFailure(processState *os.ProcessState, errorOutput string) bool {
+	// Authentication failures occur within the OpenSSH client itself, so
+	// they'll always be accompanied by its dedicated exit code. We require the
+	// exit code to avoid misclassifying remote command output.
+	if processState.ExitCode() != ssh.ClientErrorExitCode {
+		return false
+	}
+	for _, fragment := range authenticationFailureFragments {
+		if strings.Contains(errorOutput, fragment) {
+			return true
+		}
+	}
+	return false
+}
+
 // ClassifyError implements the ClassifyError method of agent.Transport.
 func (t *sshTransport) ClassifyError(processState *os.ProcessState, errorOutput string) (bool, bool, error) {
 	// SSH faithfully returns exit codes and error output, so we can use direct
@@ -1,7 +1,9 @@
 package ssh
 
 import (
+	"fmt"
 	"os"
+	"os/exec"
 	"os/user"
 	"path/filepath"
 	"runtime"
@@ -10,6 +12,7 @@ import (
 	"unicode/utf8"
 
 	"github.com/mutagen-io/mutagen/pkg/filesystem"
+	"github.com/mutagen-io/mutagen/pkg/ssh"
 )
 
 func TestCopy(t *testing.T) {
@@ -105,3 +108,82 @@ func TestCommandOutput(t *testing.T) {
 		t.Error("output not in UTF-8 encoding")
 	}
 }
+
+// TestBootstrapTiming tests that bootstrap commands use the bootstrap timeouts
+// while steady-state commands use the normal timeouts.
+func TestBootstrapTiming(t *testing.T) {
+	// Create a transport.
+	transport := &sshTransport{host: "localhost"}
+
+	// Create steady-state and bootstrap commands.
+	steadyState, err := transport.Command("true")
+	if err != nil {
+		t.Skip("unable to create command:", err)
+	}
+	bootstrap, err := transport.BootstrapCommand("true")
+	if err != nil {
+		t.Fatal("unable to create bootstrap command:", err)
+	}
+
+	// Verify the timing of each command.
+	steadyStateArguments := strings.Join(steadyState.Args, " ")
+	if !strings.Contains(steadyStateArguments, ssh.ConnectTimeoutFlag(connectTimeoutSeconds)) {
+		t.Error("steady-state command does not use normal connect timeout:", steadyStateArguments)
+	} else if !strings.Contains(steadyStateArguments, fmt.Sprintf("-oServerAliveCountMax=%d", serverAliveCountMax)) {
+		t.Error("steady-state command does not use normal server alive count:", steadyStateArguments)
+	}
+	bootstrapArguments := strings.Join(bootstrap.Args, " ")
+	if !strings.Contains(bootstrapArguments, ssh.ConnectTimeoutFlag(bootstrapConnectTimeoutSeconds)) {
+		t.Error("bootstrap command does not use bootstrap connect timeout:", bootstrapArguments)
+	} else if !strings.Contains(bootstrapArguments, fmt.Sprintf("-oServerAliveCountMax=%d", bootstrapServerAliveCountMax)) {
+		t.Error("bootstrap command does not use bootstrap server alive count:", bootstrapArguments)
+	}
+	if bootstrapConnectTimeoutSeconds <= connectTimeoutSeconds {
+		t.Error("bootstrap connect timeout not longer than normal connect timeout")
+	}
+}
+
+// TestClassifyAuthenticationFailure tests that authentication failures are
+// identified using both the OpenSSH client's exit code and error output.
+func TestClassifyAuthenticationFailure(t *testing.T) {
+	// If we're not running in a POSIX environment, then skip this test, since
+	// we rely on a POSIX shell to generate exit codes.
+	if runtime.GOOS == "windows" {
+		t.Skip()
+	}
+
+	// Generate process states with the OpenSSH client error exit code and an
+	// arbitrary remote exit code.
+	clientError := exec.Command("/bin/sh", "-c", fmt.Sprintf("exit %d", ssh.ClientErrorExitCode))
+	if err := clientError.Run(); err == nil {
+		t.Fatal("expected non-nil error when generating client error exit code")
+	}
+	remoteError := exec.Command("/bin/sh", "-c", "exit 1")
+	if err := remoteError.Run(); err == nil {
+		t.Fatal("expected non-nil error when generating remote error exit code")
+	}
+
+	// Define test cases.
+	testCases := []struct {
+		processState *os.ProcessState
+		errorOutput  string
+		expected     bool
+	}{
+		{clientError.ProcessState, "user@host: Permission denied (publickey,password).", true},
+		{clientError.ProcessState, "Received disconnect from host: Too many authentication failures", true},
+		{clientError.ProcessState, "Host key verification failed.", true},
+		{clientError.ProcessState, "ssh: connect to host host port 22: Connection refused", false},
+		{clientError.ProcessState, "ssh: Could not resolve hostname host: Name or service not known", false},
+		{remoteError.ProcessState, "user@host: Permission denied (publickey,password).", false},
+	}
+
+	// Process test cases.
+	transport := &sshTransport{}
+	for i, testCase := range testCases {
+		if classified := transport.ClassifyAuthenticationFailure(testCase.processState, testCase.errorOutput); classified != testCase.expected {
+			t.Errorf("test index %d: authentication failure classification does not match expected: %t != %t",
+				i, classified, testCase.expected,
+			)
+		}
+	}
+}
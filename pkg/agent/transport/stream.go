@@ -3,6 +3,7 @@ package transport
 import (
 	"fmt"
 	"io"
+	"os"
 	"os/exec"
 	"runtime"
 	"sync"
@@ -10,6 +11,24 @@ import (
 	"time"
 )
 
+// processExitObservationWindow is the maximum amount of time that a stream will
+// wait for its underlying process to exit after the process' standard output
+// stream is closed, in order to report the process' exit state.
+const processExitObservationWindow = time.Second
+
+// ProcessExitError is returned by Stream.Read if the underlying process exited,
+// closing its standard output stream. It provides the process' exit state,
+// which can be used to determine the cause of the exit.
+type ProcessExitError struct {
+	// State is the exit state of the process.
+	State *os.ProcessState
+}
+
+// Error implements error.Error.
+func (e *ProcessExitError) Error() string {
+	return fmt.Sprintf("transport process exited (%s)", e.State)
+}
+
 // Stream implements io.ReadWriteCloser using the standard input and output
 // streams of an agent process, with closure implemented via termination
 // signaling heuristics designed to shut down agent processes reliably. It
@@ -27,6 +46,13 @@ type Stream struct {
 	// terminationDelay specifies the duration that the stream should wait for
 	// the underlying process to exit on its own before performing termination.
 	terminationDelay time.Duration
+	// waitOnce ensures that the process is only waited on once.
+	waitOnce sync.Once
+	// exited is closed once the process has exited and been waited on.
+	exited chan struct{}
+	// waitErr is the error returned by the process' Wait method. It may only
+	// be accessed after exited is closed.
+	waitErr error
 }
 
 // NewStream creates a new Stream instance that wraps the specified command
@@ -72,12 +98,50 @@ func NewStream(process *exec.Cmd, standardErrorReceiver io.Writer) (*Stream, err
 		process:        process,
 		standardInput:  standardInput,
 		standardOutput: standardOutput,
+		exited:         make(chan struct{}),
 	}, nil
 }
 
-// Read implements io.Reader.Read.
+// wait starts a background Goroutine (if one isn't already running) that waits
+// for the process to exit, records the wait result, and closes exited. We rely
+// on this call to Wait to close the standard output and error streams. We
+// don't have to worry about golang/go#23019 in this case because we're only
+// using pipes and thus Wait doesn't have any internal copying Goroutines to
+// wait on.
+func (s *Stream) wait() {
+	s.waitOnce.Do(func() {
+		go func() {
+			s.waitErr = s.process.Wait()
+			close(s.exited)
+		}()
+	})
+}
+
+// Read implements io.Reader.Read. If the process' standard output stream is
+// closed because the process exited, then the resulting error will be a
+// *ProcessExitError describing the process' exit state.
 func (s *Stream) Read(buffer []byte) (int, error) {
-	return s.standardOutput.Read(buffer)
+	// Perform the read.
+	n, err := s.standardOutput.Read(buffer)
+	if err == nil {
+		return n, nil
+	}
+
+	// If the read failed, then check whether or not the process has exited
+	// (or exits shortly), in which case we return its exit state. The standard
+	// output stream has already failed at this point, so waiting on the
+	// process can't discard any unread output.
+	s.wait()
+	timer := time.NewTimer(processExitObservationWindow)
+	defer timer.Stop()
+	select {
+	case <-s.exited:
+		if s.process.ProcessState != nil {
+			return n, &ProcessExitError{State: s.process.ProcessState}
+		}
+	case <-timer.C:
+	}
+	return n, err
 }
 
 // Write implements io.Writer.Write.
@@ -156,15 +220,8 @@ func (s *Stream) SetTerminationDelay(terminationDelay time.Duration) {
 // correctly handle and forward standard input closure and SIGTERM signals, and
 // that they'll terminate when their underlying agent process terminates.
 func (s *Stream) Close() error {
-	// Start a background Goroutine that will wait for the process to exit and
-	// return the wait result. We'll rely on this call to Wait to close the
-	// standard output and error streams. We don't have to worry about
-	// golang/go#23019 in this case because we're only using pipes and thus Wait
-	// doesn't have any internal copying Goroutines to wait on.
-	waitResults := make(chan error, 1)
-	go func() {
-		waitResults <- s.process.Wait()
-	}()
+	// Ensure that a background Goroutine is waiting for the process to exit.
+	s.wait()
 
 	// Start by waiting for the process to terminate on its own.
 	s.terminationDelayLock.Lock()
@@ -172,9 +229,9 @@ func (s *Stream) Close() error {
 	s.terminationDelayLock.Unlock()
 	waitTimer := time.NewTimer(terminationDelay)
 	select {
-	case err := <-waitResults:
+	case <-s.exited:
 		waitTimer.Stop()
-		return err
+		return s.waitErr
 	case <-waitTimer.C:
 	}
 
@@ -183,9 +240,9 @@ func (s *Stream) Close() error {
 	s.standardInput.Close()
 	waitTimer.Reset(time.Second)
 	select {
-	case err := <-waitResults:
+	case <-s.exited:
 		waitTimer.Stop()
-		return err
+		return s.waitErr
 	case <-waitTimer.C:
 	}
 
@@ -195,9 +252,9 @@ func (s *Stream) Close() error {
 		s.process.Process.Signal(syscall.SIGTERM)
 		waitTimer.Reset(time.Second)
 		select {
-		case err := <-waitResults:
+		case <-s.exited:
 			waitTimer.Stop()
-			return err
+			return s.waitErr
 		case <-waitTimer.C:
 		}
 	}
@@ -205,5 +262,6 @@ func (s *Stream) Close() error {
 	// Kill the process (via SIGKILL on POSIX and TerminateProcess on Windows)
 	// and wait for it to exit.
 	s.process.Process.Kill()
-	return <-waitResults
+	<-s.exited
+	return s.waitErr
 }
@@ -1,3 +1,38 @@
 package transport
 
-// TODO: Implement.
+import (
+	"errors"
+	"os/exec"
+	"runtime"
+	"testing"
+)
+
+// TestStreamProcessExit tests that Stream.Read reports process exit using a
+// ProcessExitError.
+func TestStreamProcessExit(t *testing.T) {
+	// Skip this test on Windows, where we don't have a POSIX shell.
+	if runtime.GOOS == "windows" {
+		t.Skip()
+	}
+
+	// Create a stream for a process that exits immediately.
+	process := exec.Command("sh", "-c", "exit 3")
+	stream, err := NewStream(process, nil)
+	if err != nil {
+		t.Fatal("unable to create stream:", err)
+	}
+	if err := process.Start(); err != nil {
+		t.Fatal("unable to start process:", err)
+	}
+	defer stream.Close()
+
+	// Perform a read and ensure that it reports process exit.
+	buffer := make([]byte, 1)
+	_, err = stream.Read(buffer)
+	var exitErr *ProcessExitError
+	if !errors.As(err, &exitErr) {
+		t.Fatal("read did not report process exit:", err)
+	} else if code := exitErr.State.ExitCode(); code != 3 {
+		t.Error("process exit code does not match expected:", code, "!=", 3)
+	}
+}
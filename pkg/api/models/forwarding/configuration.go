@@ -7,6 +7,15 @@ import (
 
 // Configuration represents forwarding session configuration.
 type Configuration struct {
+	// DrainTimeout specifies the maximum amount of time (in milliseconds) to
+	// wait for existing connections to close when halting the session.
+	DrainTimeout uint32 `json:"drainTimeout,omitempty" yaml:"drainTimeout" mapstructure:"drainTimeout"`
+	// TCP contains parameters related to TCP endpoint handling.
+	TCP struct {
+		// NetworkMode specifies the network (i.e. address family) to use for
+		// TCP endpoints.
+		NetworkMode forwarding.TCPNetworkMode `json:"networkMode,omitempty" yaml:"networkMode" mapstructure:"networkMode"`
+	} `json:"tcp" yaml:"tcp" mapstructure:"tcp"`
 	// Socket contains parameters related to Unix domain socket handling.
 	Socket struct {
 		// OverwriteMode specifies the default socket overwrite mode to use for
@@ -27,6 +36,12 @@ type Configuration struct {
 // loadFromInternal sets a configuration to match an internal Protocol Buffers
 // representation. The configuration must be valid.
 func (c *Configuration) loadFromInternal(configuration *forwarding.Configuration) {
+	// Propagate top-level configuration.
+	c.DrainTimeout = configuration.DrainTimeout
+
+	// Propagate TCP configuration.
+	c.TCP.NetworkMode = configuration.NetworkMode
+
 	// Propagate socket configuration.
 	c.Socket.OverwriteMode = configuration.SocketOverwriteMode
 	c.Socket.Owner = configuration.SocketOwner
@@ -39,6 +54,8 @@ func (c *Configuration) loadFromInternal(configuration *forwarding.Configuration
 // configuration.
 func (c *Configuration) ToInternal() *forwarding.Configuration {
 	return &forwarding.Configuration{
+		DrainTimeout:         c.DrainTimeout,
+		NetworkMode:          c.TCP.NetworkMode,
 		SocketOverwriteMode:  c.Socket.OverwriteMode,
 		SocketOwner:          c.Socket.Owner,
 		SocketGroup:          c.Socket.Group,
@@ -10,6 +10,9 @@ import (
 
 const (
 	testYAMLConfiguration = `
+drainTimeout: 5000
+tcp:
+  networkMode: "ipv6"
 socket:
   overwriteMode: "overwrite"
   owner: "george"
@@ -21,6 +24,8 @@ socket:
 // expectedConfiguration is the configuration that's expected based on the
 // human-readable configuration given above.
 var expectedConfiguration = &forwarding.Configuration{
+	DrainTimeout:         5000,
+	NetworkMode:          forwarding.TCPNetworkMode_TCPNetworkModeIPv6,
 	SocketOverwriteMode:  forwarding.SocketOverwriteMode_SocketOverwriteModeOverwrite,
 	SocketOwner:          "george",
 	SocketGroup:          "presidents",
@@ -55,6 +60,12 @@ func TestLoadConfiguration(t *testing.T) {
 	}
 
 	// Verify that the configuration matches what's expected.
+	if configuration.DrainTimeout != expectedConfiguration.DrainTimeout {
+		t.Error("drain timeout mismatch:", configuration.DrainTimeout, "!=", expectedConfiguration.DrainTimeout)
+	}
+	if configuration.NetworkMode != expectedConfiguration.NetworkMode {
+		t.Error("TCP network mode mismatch:", configuration.NetworkMode, "!=", expectedConfiguration.NetworkMode)
+	}
 	if configuration.SocketOverwriteMode != expectedConfiguration.SocketOverwriteMode {
 		t.Error("socket overwrite mode mismatch:", configuration.SocketOverwriteMode, "!=", expectedConfiguration.SocketOverwriteMode)
 	}
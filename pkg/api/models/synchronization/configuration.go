@@ -15,6 +15,9 @@ import (
 type Configuration struct {
 	// Mode specifies the default synchronization mode.
 	Mode core.SynchronizationMode `json:"mode,omitempty" yaml:"mode" mapstructure:"mode"`
+	// TypeChangeMode specifies the handling of non-root type changes in
+	// bidirectional synchronization modes.
+	TypeChangeMode core.TypeChangeMode `json:"typeChangeMode,omitempty" yaml:"typeChangeMode" mapstructure:"typeChangeMode"`
 	// Hash specifies the hashing algorithm to use for content.
 	Hash hashing.Algorithm `json:"hash,omitempty" yaml:"hash" mapstructure:"hash"`
 	// MaximumEntryCount specifies the maximum number of filesystem entries
@@ -23,12 +26,127 @@ type Configuration struct {
 	// MaximumStagingFileSize is the maximum (individual) file size that
 	// endpoints will stage. It can be specified in human-friendly units.
 	MaximumStagingFileSize types.ByteSize `json:"maxStagingFileSize,omitempty" yaml:"maxStagingFileSize" mapstructure:"maxStagingFileSize"`
+	// OversizedFileMode specifies the handling of files that exceed the
+	// maximum staging file size.
+	OversizedFileMode synchronization.OversizedFileMode `json:"oversizedFileMode,omitempty" yaml:"oversizedFileMode" mapstructure:"oversizedFileMode"`
+	// MaximumRenameDetectionFileSize is the maximum (individual) file size for
+	// which endpoints will perform rename and copy detection. It can be
+	// specified in human-friendly units.
+	MaximumRenameDetectionFileSize types.ByteSize `json:"maxRenameDetectionFileSize,omitempty" yaml:"maxRenameDetectionFileSize" mapstructure:"maxRenameDetectionFileSize"`
+	// MaximumContentCacheSize is the maximum total size of the persistent
+	// content cache. It can be specified in human-friendly units.
+	MaximumContentCacheSize types.ByteSize `json:"maxContentCacheSize,omitempty" yaml:"maxContentCacheSize" mapstructure:"maxContentCacheSize"`
+	// StagingConcurrencyMode specifies whether staging on alpha and beta
+	// should be performed sequentially or concurrently.
+	StagingConcurrencyMode synchronization.StagingConcurrencyMode `json:"stagingConcurrencyMode,omitempty" yaml:"stagingConcurrencyMode" mapstructure:"stagingConcurrencyMode"`
+	// MaximumDeltificationTime is the maximum amount of time (in milliseconds)
+	// that endpoints will spend computing the delta for an individual file.
+	MaximumDeltificationTime uint32 `json:"maxDeltificationTime,omitempty" yaml:"maxDeltificationTime" mapstructure:"maxDeltificationTime"`
+	// MaximumStagedContentAge is the maximum age (in seconds) of staged
+	// content left over from previous staging operations before it's removed.
+	MaximumStagedContentAge uint32 `json:"maxStagedContentAge,omitempty" yaml:"maxStagedContentAge" mapstructure:"maxStagedContentAge"`
+	// RsyncBlockSize is the block size that endpoints will use when computing
+	// rsync signatures. It can be specified in human-friendly units.
+	RsyncBlockSize types.ByteSize `json:"rsyncBlockSize,omitempty" yaml:"rsyncBlockSize" mapstructure:"rsyncBlockSize"`
+	// MaximumInFlightBytes is the maximum total size of rsync operation data
+	// that endpoints will buffer while supplying files. If unset, pipelining is
+	// disabled. It can be specified in human-friendly units.
+	MaximumInFlightBytes types.ByteSize `json:"maxInFlightBytes,omitempty" yaml:"maxInFlightBytes" mapstructure:"maxInFlightBytes"`
+	// RenameDetectionDigestLength is the length (in bytes) to which digests
+	// are truncated in the reverse lookup map used for rename and copy
+	// detection.
+	RenameDetectionDigestLength uint32 `json:"renameDetectionDigestLength,omitempty" yaml:"renameDetectionDigestLength" mapstructure:"renameDetectionDigestLength"`
+	// RenameDetectionRetries is the maximum number of times that staging from
+	// an existing file will be retried during rename and copy detection.
+	RenameDetectionRetries uint32 `json:"renameDetectionRetries,omitempty" yaml:"renameDetectionRetries" mapstructure:"renameDetectionRetries"`
+	// MaximumSignatureMemory is the maximum total rsync signature memory that
+	// endpoints will use in a single staging operation. It can be specified in
+	// human-friendly units.
+	MaximumSignatureMemory types.ByteSize `json:"maxSignatureMemory,omitempty" yaml:"maxSignatureMemory" mapstructure:"maxSignatureMemory"`
+	// MaximumConflictCount specifies the maximum number of conflicts that the
+	// session will tolerate before halting.
+	MaximumConflictCount uint64 `json:"maxConflictCount,omitempty" yaml:"maxConflictCount" mapstructure:"maxConflictCount"`
+	// MaximumConflictPersistence specifies the maximum number of consecutive
+	// synchronization cycles in which an unchanged conflict will be tolerated
+	// before the session halts.
+	MaximumConflictPersistence uint32 `json:"maxConflictPersistence,omitempty" yaml:"maxConflictPersistence" mapstructure:"maxConflictPersistence"`
+	// WatchdogTimeout is the amount of time (in seconds) for which a
+	// synchronization cycle may go without making progress before the
+	// synchronization loop is restarted.
+	WatchdogTimeout uint32 `json:"watchdogTimeout,omitempty" yaml:"watchdogTimeout" mapstructure:"watchdogTimeout"`
+	// AgentTerminationReconnectDelay is the amount of time (in milliseconds)
+	// to wait before reconnecting after an endpoint's agent process terminates
+	// unexpectedly.
+	AgentTerminationReconnectDelay uint32 `json:"agentTerminationReconnectDelay,omitempty" yaml:"agentTerminationReconnectDelay" mapstructure:"agentTerminationReconnectDelay"`
+	// MaximumBetaScanDeferral specifies the maximum number of consecutive
+	// synchronization cycles for which the beta scan may be deferred when
+	// using a unidirectional synchronization mode.
+	MaximumBetaScanDeferral uint32 `json:"maxBetaScanDeferral,omitempty" yaml:"maxBetaScanDeferral" mapstructure:"maxBetaScanDeferral"`
 	// ProbeMode specifies the filesystem probing mode.
 	ProbeMode behavior.ProbeMode `json:"probeMode,omitempty" yaml:"probeMode" mapstructure:"probeMode"`
 	// ScanMode specifies the filesystem scanning mode.
 	ScanMode synchronization.ScanMode `json:"scanMode,omitempty" yaml:"scanMode" mapstructure:"scanMode"`
+	// ScanSharingMode specifies whether or not scan results should be shared
+	// with other sessions targeting the same synchronization root.
+	ScanSharingMode synchronization.ScanSharingMode `json:"scanSharingMode,omitempty" yaml:"scanSharingMode" mapstructure:"scanSharingMode"`
+	// AggregateDigestMode specifies whether or not directory aggregate digests
+	// should be computed during scans.
+	AggregateDigestMode synchronization.AggregateDigestMode `json:"aggregateDigestMode,omitempty" yaml:"aggregateDigestMode" mapstructure:"aggregateDigestMode"`
+	// DirectoryListingRetries specifies the maximum number of times that a
+	// directory listing will be re-read during scanning in an attempt to
+	// obtain a stable listing.
+	DirectoryListingRetries uint32 `json:"directoryListingRetries,omitempty" yaml:"directoryListingRetries" mapstructure:"directoryListingRetries"`
+	// SlowDirectoryListingThreshold specifies the minimum directory listing
+	// duration (in milliseconds) for which listings are logged as slow.
+	SlowDirectoryListingThreshold uint32 `json:"slowDirectoryListingThreshold,omitempty" yaml:"slowDirectoryListingThreshold" mapstructure:"slowDirectoryListingThreshold"`
+	// CacheSaveThreshold specifies the minimum number of scan cache entries
+	// that must differ from the last saved cache before the cache is written.
+	CacheSaveThreshold uint64 `json:"cacheSaveThreshold,omitempty" yaml:"cacheSaveThreshold" mapstructure:"cacheSaveThreshold"`
+	// MaximumRecheckPaths is the maximum number of re-check paths that will be
+	// accumulated for an accelerated scan before falling back to a full scan.
+	MaximumRecheckPaths uint64 `json:"maxRecheckPaths,omitempty" yaml:"maxRecheckPaths" mapstructure:"maxRecheckPaths"`
+	// ScanSubpaths specifies the synchronization-root-relative paths whose
+	// subtrees are the only content re-scanned by regular scans after the
+	// initial full scan.
+	ScanSubpaths []string `json:"scanSubpaths,omitempty" yaml:"scanSubpaths" mapstructure:"scanSubpaths"`
+	// DigestSamplingThreshold is the file size above which sampled digests are
+	// used to detect file content changes. It can be specified in
+	// human-friendly units.
+	DigestSamplingThreshold types.ByteSize `json:"digestSamplingThreshold,omitempty" yaml:"digestSamplingThreshold" mapstructure:"digestSamplingThreshold"`
+	// DigestLength is the length (in bytes) to which file digests are
+	// truncated in snapshots.
+	DigestLength uint32 `json:"digestLength,omitempty" yaml:"digestLength" mapstructure:"digestLength"`
+	// CacheTrustMode specifies the file metadata that must match persisted
+	// cache entries for their digests to be reused after an endpoint restart.
+	CacheTrustMode core.CacheTrustMode `json:"cacheTrustMode,omitempty" yaml:"cacheTrustMode" mapstructure:"cacheTrustMode"`
+	// NameNormalizationMode specifies the canonical form that content names
+	// must satisfy in order to be synchronized.
+	NameNormalizationMode core.NameNormalizationMode `json:"nameNormalizationMode,omitempty" yaml:"nameNormalizationMode" mapstructure:"nameNormalizationMode"`
+	// CapabilityMismatchMode specifies the handling of differing filesystem
+	// capabilities between endpoints.
+	CapabilityMismatchMode synchronization.CapabilityMismatchMode `json:"capabilityMismatchMode,omitempty" yaml:"capabilityMismatchMode" mapstructure:"capabilityMismatchMode"`
+	// ConfigurationIncompatibilityMode specifies the handling of endpoint
+	// configurations that are incompatible with filesystem capabilities.
+	ConfigurationIncompatibilityMode synchronization.ConfigurationIncompatibilityMode `json:"configurationIncompatibilityMode,omitempty" yaml:"configurationIncompatibilityMode" mapstructure:"configurationIncompatibilityMode"`
+	// RootOverlapMode specifies how overlap with the local synchronization
+	// roots of existing sessions is handled at session creation.
+	RootOverlapMode synchronization.RootOverlapMode `json:"rootOverlapMode,omitempty" yaml:"rootOverlapMode" mapstructure:"rootOverlapMode"`
+	// RootExistenceMode specifies how the absence of a synchronization root is
+	// handled.
+	RootExistenceMode synchronization.RootExistenceMode `json:"rootExistenceMode,omitempty" yaml:"rootExistenceMode" mapstructure:"rootExistenceMode"`
 	// StageMode specifies the filesystem staging mode.
 	StageMode synchronization.StageMode `json:"stageMode,omitempty" yaml:"stageMode" mapstructure:"stageMode"`
+	// TransitionMode specifies the strategy used to apply changes to disk.
+	TransitionMode core.TransitionMode `json:"transitionMode,omitempty" yaml:"transitionMode" mapstructure:"transitionMode"`
+	// InitialSynchronizationMode specifies whether or not a synchronization
+	// cycle should be forced when the synchronization loop starts.
+	InitialSynchronizationMode synchronization.InitialSynchronizationMode `json:"initialSynchronizationMode,omitempty" yaml:"initialSynchronizationMode" mapstructure:"initialSynchronizationMode"`
+	// MaximumReportedProblems specifies the maximum number of scan or
+	// transition problems reported for each endpoint.
+	MaximumReportedProblems uint32 `json:"maxReportedProblems,omitempty" yaml:"maxReportedProblems" mapstructure:"maxReportedProblems"`
+	// MaximumReconciliationRecordings specifies the maximum number of
+	// reconciliation recordings retained for the session.
+	MaximumReconciliationRecordings uint32 `json:"maxReconciliationRecordings,omitempty" yaml:"maxReconciliationRecordings" mapstructure:"maxReconciliationRecordings"`
 	// Ignore contains parameters related to synchronization ignore
 	// specifications.
 	Ignore struct {
@@ -38,12 +156,34 @@ type Configuration struct {
 		Paths []string `json:"paths,omitempty" yaml:"paths" mapstructure:"paths"`
 		// VCS specifies the VCS ignore mode.
 		VCS ignore.IgnoreVCSMode `json:"vcs,omitempty" yaml:"vcs" mapstructure:"vcs"`
+		// Manifest specifies an explicit list of paths to which
+		// synchronization should be restricted.
+		Manifest []string `json:"manifest,omitempty" yaml:"manifest" mapstructure:"manifest"`
+		// PhantomDirectoryMode specifies the handling of fully phantom
+		// directories with Docker-style ignores.
+		PhantomDirectoryMode core.PhantomDirectoryMode `json:"phantomDirectoryMode,omitempty" yaml:"phantomDirectoryMode" mapstructure:"phantomDirectoryMode"`
+		// MaximumFileSize specifies the maximum size of files that will be
+		// considered for synchronization.
+		MaximumFileSize types.ByteSize `json:"maxFileSize,omitempty" yaml:"maxFileSize" mapstructure:"maxFileSize"`
+		// OSMetadata specifies the OS metadata ignore mode.
+		OSMetadata ignore.IgnoreOSMetadataMode `json:"osMetadata,omitempty" yaml:"osMetadata" mapstructure:"osMetadata"`
 	} `json:"ignore" yaml:"ignore" mapstructure:"ignore"`
 	// Symlink contains parameters related to symbolic link handling.
 	Symlink struct {
 		// Mode specifies the symbolic link mode.
 		Mode core.SymbolicLinkMode `json:"mode,omitempty" yaml:"mode" mapstructure:"mode"`
+		// ReplacementMode specifies the handling of non-empty directories that
+		// need to be replaced by symbolic links.
+		ReplacementMode core.SymbolicLinkReplacementMode `json:"replacementMode,omitempty" yaml:"replacementMode" mapstructure:"replacementMode"`
+		// CycleMode specifies the handling of symbolic links that would form
+		// cycles.
+		CycleMode core.SymbolicLinkCycleMode `json:"cycleMode,omitempty" yaml:"cycleMode" mapstructure:"cycleMode"`
 	} `json:"symlink" yaml:"symlink" mapstructure:"symlink"`
+	// SpecialFile contains parameters related to special file handling.
+	SpecialFile struct {
+		// Mode specifies the special file mode.
+		Mode core.SpecialFileMode `json:"mode,omitempty" yaml:"mode" mapstructure:"mode"`
+	} `json:"specialFile" yaml:"specialFile" mapstructure:"specialFile"`
 	// Watch contains parameters related to filesystem monitoring.
 	Watch struct {
 		// Mode specifies the file watching mode.
@@ -52,6 +192,14 @@ type Configuration struct {
 		// file monitoring. A value of 0 specifies that Mutagen's internal
 		// default interval should be used.
 		PollingInterval uint32 `json:"pollingInterval,omitempty" yaml:"pollingInterval" mapstructure:"pollingInterval"`
+		// QueueSize specifies the capacity of the internal event queue used by
+		// native filesystem watchers. A value of 0 specifies that Mutagen's
+		// internal default queue size should be used.
+		QueueSize uint32 `json:"queueSize,omitempty" yaml:"queueSize" mapstructure:"queueSize"`
+		// InitialPollModificationMode specifies whether or not poll-based
+		// watching should report the modifications observed by its initial
+		// scan.
+		InitialPollModificationMode synchronization.InitialPollModificationMode `json:"initialPollModificationMode,omitempty" yaml:"initialPollModificationMode" mapstructure:"initialPollModificationMode"`
 	} `json:"watch" yaml:"watch" mapstructure:"watch"`
 	// Permissions contains parameters related to permission handling.
 	Permissions struct {
@@ -76,7 +224,49 @@ type Configuration struct {
 	Compression struct {
 		// Algorithm specifies the compression algorithm.
 		Algorithm compression.Algorithm `json:"algorithm,omitempty" yaml:"algorithm" mapstructure:"algorithm"`
+		// StagingAlgorithm specifies the compression algorithm for rsync
+		// operation data transmitted during staging.
+		StagingAlgorithm compression.Algorithm `json:"stagingAlgorithm,omitempty" yaml:"stagingAlgorithm" mapstructure:"stagingAlgorithm"`
 	} `json:"compression" yaml:"compression" mapstructure:"compression"`
+	// Transport contains parameters related to remote endpoint transport.
+	Transport struct {
+		// StreamConcurrency specifies the number of concurrent request streams
+		// to multiplex over the connection to a remote endpoint.
+		StreamConcurrency uint32 `json:"streamConcurrency,omitempty" yaml:"streamConcurrency" mapstructure:"streamConcurrency"`
+	} `json:"transport" yaml:"transport" mapstructure:"transport"`
+	// Clock contains parameters related to endpoint clock handling.
+	Clock struct {
+		// SkewMode specifies the clock skew mode.
+		SkewMode synchronization.ClockSkewMode `json:"skewMode,omitempty" yaml:"skewMode" mapstructure:"skewMode"`
+		// SkewTolerance specifies the clock skew tolerance (in seconds).
+		SkewTolerance uint32 `json:"skewTolerance,omitempty" yaml:"skewTolerance" mapstructure:"skewTolerance"`
+	} `json:"clock" yaml:"clock" mapstructure:"clock"`
+	// Probe contains parameters related to filesystem behavior probing.
+	Probe struct {
+		// Directory specifies an alternate directory (on the same filesystem
+		// as the synchronization root) in which probe files should be created.
+		Directory string `json:"directory,omitempty" yaml:"directory" mapstructure:"directory"`
+		// AssumeExecutabilityPreservation specifies an explicit assumption
+		// about executability preservation behavior.
+		AssumeExecutabilityPreservation behavior.ProbeAssumption `json:"assumeExecutabilityPreservation,omitempty" yaml:"assumeExecutabilityPreservation" mapstructure:"assumeExecutabilityPreservation"`
+		// AssumeUnicodeDecomposition specifies an explicit assumption about
+		// Unicode decomposition behavior.
+		AssumeUnicodeDecomposition behavior.ProbeAssumption `json:"assumeUnicodeDecomposition,omitempty" yaml:"assumeUnicodeDecomposition" mapstructure:"assumeUnicodeDecomposition"`
+	} `json:"probe" yaml:"probe" mapstructure:"probe"`
+	// Overlay contains parameters related to overlay synchronization.
+	Overlay struct {
+		// Base specifies an immutable base directory to present beneath the
+		// synchronization root.
+		Base string `json:"base,omitempty" yaml:"base" mapstructure:"base"`
+	} `json:"overlay" yaml:"overlay" mapstructure:"overlay"`
+	// Verification contains parameters related to background verification.
+	Verification struct {
+		// Mode specifies the verification mode.
+		Mode synchronization.VerificationMode `json:"mode,omitempty" yaml:"mode" mapstructure:"mode"`
+		// Interval specifies the interval (in seconds) between background
+		// verification passes.
+		Interval uint32 `json:"interval,omitempty" yaml:"interval" mapstructure:"interval"`
+	} `json:"verification" yaml:"verification" mapstructure:"verification"`
 }
 
 // loadFromInternal sets a configuration to match an internal
@@ -84,12 +274,48 @@ type Configuration struct {
 func (c *Configuration) loadFromInternal(configuration *synchronization.Configuration) {
 	// Propagate top-level configuration.
 	c.Mode = configuration.SynchronizationMode
+	c.TypeChangeMode = configuration.TypeChangeMode
 	c.Hash = configuration.HashingAlgorithm
 	c.MaximumEntryCount = configuration.MaximumEntryCount
 	c.MaximumStagingFileSize = types.ByteSize(configuration.MaximumStagingFileSize)
+	c.OversizedFileMode = configuration.OversizedFileMode
+	c.MaximumRenameDetectionFileSize = types.ByteSize(configuration.MaximumRenameDetectionFileSize)
+	c.MaximumContentCacheSize = types.ByteSize(configuration.MaximumContentCacheSize)
+	c.StagingConcurrencyMode = configuration.StagingConcurrencyMode
+	c.MaximumDeltificationTime = configuration.MaximumDeltificationTime
+	c.MaximumStagedContentAge = configuration.MaximumStagedContentAge
+	c.RsyncBlockSize = types.ByteSize(configuration.RsyncBlockSize)
+	c.MaximumInFlightBytes = types.ByteSize(configuration.MaximumInFlightBytes)
+	c.RenameDetectionDigestLength = configuration.RenameDetectionDigestLength
+	c.RenameDetectionRetries = configuration.RenameDetectionRetries
+	c.MaximumSignatureMemory = types.ByteSize(configuration.MaximumSignatureMemory)
+	c.MaximumConflictCount = configuration.MaximumConflictCount
+	c.MaximumConflictPersistence = configuration.MaximumConflictPersistence
+	c.WatchdogTimeout = configuration.WatchdogTimeout
+	c.AgentTerminationReconnectDelay = configuration.AgentTerminationReconnectDelay
+	c.MaximumBetaScanDeferral = configuration.MaximumBetaScanDeferral
 	c.ProbeMode = configuration.ProbeMode
 	c.ScanMode = configuration.ScanMode
+	c.ScanSharingMode = configuration.ScanSharingMode
+	c.AggregateDigestMode = configuration.AggregateDigestMode
+	c.DirectoryListingRetries = configuration.DirectoryListingRetries
+	c.SlowDirectoryListingThreshold = configuration.SlowDirectoryListingThreshold
+	c.CacheSaveThreshold = configuration.CacheSaveThreshold
+	c.MaximumRecheckPaths = configuration.MaximumRecheckPaths
+	c.ScanSubpaths = configuration.ScanSubpaths
+	c.DigestSamplingThreshold = types.ByteSize(configuration.DigestSamplingThreshold)
+	c.DigestLength = configuration.DigestLength
+	c.CacheTrustMode = configuration.CacheTrustMode
+	c.NameNormalizationMode = configuration.NameNormalizationMode
+	c.CapabilityMismatchMode = configuration.CapabilityMismatchMode
+	c.ConfigurationIncompatibilityMode = configuration.ConfigurationIncompatibilityMode
+	c.RootOverlapMode = configuration.RootOverlapMode
+	c.RootExistenceMode = configuration.RootExistenceMode
 	c.StageMode = configuration.StageMode
+	c.TransitionMode = configuration.TransitionMode
+	c.InitialSynchronizationMode = configuration.InitialSynchronizationMode
+	c.MaximumReportedProblems = configuration.MaximumReportedProblems
+	c.MaximumReconciliationRecordings = configuration.MaximumReconciliationRecordings
 
 	// Propagate ignore configuration.
 	c.Ignore.Syntax = configuration.IgnoreSyntax
@@ -97,13 +323,24 @@ func (c *Configuration) loadFromInternal(configuration *synchronization.Configur
 	c.Ignore.Paths = append(c.Ignore.Paths, configuration.DefaultIgnores...)
 	c.Ignore.Paths = append(c.Ignore.Paths, configuration.Ignores...)
 	c.Ignore.VCS = configuration.IgnoreVCSMode
+	c.Ignore.Manifest = configuration.Manifest
+	c.Ignore.PhantomDirectoryMode = configuration.PhantomDirectoryMode
+	c.Ignore.MaximumFileSize = types.ByteSize(configuration.MaximumFileSize)
+	c.Ignore.OSMetadata = configuration.IgnoreOSMetadataMode
 
 	// Propagate symbolic link configuration.
 	c.Symlink.Mode = configuration.SymbolicLinkMode
+	c.Symlink.ReplacementMode = configuration.SymbolicLinkReplacementMode
+	c.Symlink.CycleMode = configuration.SymbolicLinkCycleMode
+
+	// Propagate special file configuration.
+	c.SpecialFile.Mode = configuration.SpecialFileMode
 
 	// Propagate watch configuration.
 	c.Watch.Mode = configuration.WatchMode
 	c.Watch.PollingInterval = configuration.WatchPollingInterval
+	c.Watch.QueueSize = configuration.WatchQueueSize
+	c.Watch.InitialPollModificationMode = configuration.InitialPollModificationMode
 
 	// Propagate permission configuration.
 	c.Permissions.Mode = configuration.PermissionsMode
@@ -114,6 +351,26 @@ func (c *Configuration) loadFromInternal(configuration *synchronization.Configur
 
 	// Propagate compression configuration.
 	c.Compression.Algorithm = configuration.CompressionAlgorithm
+	c.Compression.StagingAlgorithm = configuration.StagingCompressionAlgorithm
+
+	// Propagate transport configuration.
+	c.Transport.StreamConcurrency = configuration.StreamConcurrency
+
+	// Propagate clock configuration.
+	c.Clock.SkewMode = configuration.ClockSkewMode
+	c.Clock.SkewTolerance = configuration.ClockSkewTolerance
+
+	// Propagate probe configuration.
+	c.Probe.Directory = configuration.ProbeDirectory
+	c.Probe.AssumeExecutabilityPreservation = configuration.AssumeExecutabilityPreservation
+	c.Probe.AssumeUnicodeDecomposition = configuration.AssumeUnicodeDecomposition
+
+	// Propagate overlay configuration.
+	c.Overlay.Base = configuration.OverlayBase
+
+	// Propagate verification configuration.
+	c.Verification.Mode = configuration.VerificationMode
+	c.Verification.Interval = configuration.VerificationInterval
 }
 
 // ToInternal converts a public configuration representation to an internal
@@ -121,24 +378,79 @@ func (c *Configuration) loadFromInternal(configuration *synchronization.Configur
 // configuration.
 func (c *Configuration) ToInternal() *synchronization.Configuration {
 	return &synchronization.Configuration{
-		SynchronizationMode:    c.Mode,
-		HashingAlgorithm:       c.Hash,
-		MaximumEntryCount:      c.MaximumEntryCount,
-		MaximumStagingFileSize: uint64(c.MaximumStagingFileSize),
-		ProbeMode:              c.ProbeMode,
-		ScanMode:               c.ScanMode,
-		StageMode:              c.StageMode,
-		SymbolicLinkMode:       c.Symlink.Mode,
-		WatchMode:              c.Watch.Mode,
-		WatchPollingInterval:   c.Watch.PollingInterval,
-		IgnoreSyntax:           c.Ignore.Syntax,
-		Ignores:                c.Ignore.Paths,
-		IgnoreVCSMode:          c.Ignore.VCS,
-		PermissionsMode:        c.Permissions.Mode,
-		DefaultFileMode:        uint32(c.Permissions.DefaultFileMode),
-		DefaultDirectoryMode:   uint32(c.Permissions.DefaultDirectoryMode),
-		DefaultOwner:           c.Permissions.DefaultOwner,
-		DefaultGroup:           c.Permissions.DefaultGroup,
-		CompressionAlgorithm:   c.Compression.Algorithm,
+		SynchronizationMode:              c.Mode,
+		TypeChangeMode:                   c.TypeChangeMode,
+		HashingAlgorithm:                 c.Hash,
+		MaximumEntryCount:                c.MaximumEntryCount,
+		MaximumStagingFileSize:           uint64(c.MaximumStagingFileSize),
+		OversizedFileMode:                c.OversizedFileMode,
+		MaximumRenameDetectionFileSize:   uint64(c.MaximumRenameDetectionFileSize),
+		MaximumContentCacheSize:          uint64(c.MaximumContentCacheSize),
+		StagingConcurrencyMode:           c.StagingConcurrencyMode,
+		MaximumDeltificationTime:         c.MaximumDeltificationTime,
+		MaximumStagedContentAge:          c.MaximumStagedContentAge,
+		RsyncBlockSize:                   uint64(c.RsyncBlockSize),
+		MaximumInFlightBytes:             uint64(c.MaximumInFlightBytes),
+		RenameDetectionDigestLength:      c.RenameDetectionDigestLength,
+		RenameDetectionRetries:           c.RenameDetectionRetries,
+		MaximumSignatureMemory:           uint64(c.MaximumSignatureMemory),
+		MaximumConflictCount:             c.MaximumConflictCount,
+		MaximumConflictPersistence:       c.MaximumConflictPersistence,
+		WatchdogTimeout:                  c.WatchdogTimeout,
+		AgentTerminationReconnectDelay:   c.AgentTerminationReconnectDelay,
+		MaximumBetaScanDeferral:          c.MaximumBetaScanDeferral,
+		ProbeMode:                        c.ProbeMode,
+		ScanMode:                         c.ScanMode,
+		ScanSharingMode:                  c.ScanSharingMode,
+		AggregateDigestMode:              c.AggregateDigestMode,
+		DirectoryListingRetries:          c.DirectoryListingRetries,
+		SlowDirectoryListingThreshold:    c.SlowDirectoryListingThreshold,
+		CacheSaveThreshold:               c.CacheSaveThreshold,
+		MaximumRecheckPaths:              c.MaximumRecheckPaths,
+		ScanSubpaths:                     c.ScanSubpaths,
+		DigestSamplingThreshold:          uint64(c.DigestSamplingThreshold),
+		DigestLength:                     c.DigestLength,
+		CacheTrustMode:                   c.CacheTrustMode,
+		NameNormalizationMode:            c.NameNormalizationMode,
+		CapabilityMismatchMode:           c.CapabilityMismatchMode,
+		ConfigurationIncompatibilityMode: c.ConfigurationIncompatibilityMode,
+		RootOverlapMode:                  c.RootOverlapMode,
+		RootExistenceMode:                c.RootExistenceMode,
+		StageMode:                        c.StageMode,
+		TransitionMode:                   c.TransitionMode,
+		SymbolicLinkMode:                 c.Symlink.Mode,
+		SymbolicLinkReplacementMode:      c.Symlink.ReplacementMode,
+		SymbolicLinkCycleMode:            c.Symlink.CycleMode,
+		SpecialFileMode:                  c.SpecialFile.Mode,
+		WatchMode:                        c.Watch.Mode,
+		WatchPollingInterval:             c.Watch.PollingInterval,
+		WatchQueueSize:                   c.Watch.QueueSize,
+		InitialPollModificationMode:      c.Watch.InitialPollModificationMode,
+		InitialSynchronizationMode:       c.InitialSynchronizationMode,
+		IgnoreSyntax:                     c.Ignore.Syntax,
+		Ignores:                          c.Ignore.Paths,
+		IgnoreVCSMode:                    c.Ignore.VCS,
+		Manifest:                         c.Ignore.Manifest,
+		PhantomDirectoryMode:             c.Ignore.PhantomDirectoryMode,
+		MaximumFileSize:                  uint64(c.Ignore.MaximumFileSize),
+		IgnoreOSMetadataMode:             c.Ignore.OSMetadata,
+		PermissionsMode:                  c.Permissions.Mode,
+		DefaultFileMode:                  uint32(c.Permissions.DefaultFileMode),
+		DefaultDirectoryMode:             uint32(c.Permissions.DefaultDirectoryMode),
+		DefaultOwner:                     c.Permissions.DefaultOwner,
+		DefaultGroup:                     c.Permissions.DefaultGroup,
+		CompressionAlgorithm:             c.Compression.Algorithm,
+		StagingCompressionAlgorithm:      c.Compression.StagingAlgorithm,
+		StreamConcurrency:                c.Transport.StreamConcurrency,
+		ClockSkewMode:                    c.Clock.SkewMode,
+		ClockSkewTolerance:               c.Clock.SkewTolerance,
+		ProbeDirectory:                   c.Probe.Directory,
+		AssumeExecutabilityPreservation:  c.Probe.AssumeExecutabilityPreservation,
+		AssumeUnicodeDecomposition:       c.Probe.AssumeUnicodeDecomposition,
+		OverlayBase:                      c.Overlay.Base,
+		VerificationMode:                 c.Verification.Mode,
+		VerificationInterval:             c.Verification.Interval,
+		MaximumReportedProblems:          c.MaximumReportedProblems,
+		MaximumReconciliationRecordings:  c.MaximumReconciliationRecordings,
 	}
 }
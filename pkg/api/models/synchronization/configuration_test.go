@@ -7,6 +7,7 @@ import (
 	"github.com/mutagen-io/mutagen/pkg/encoding"
 	"github.com/mutagen-io/mutagen/pkg/filesystem/behavior"
 	"github.com/mutagen-io/mutagen/pkg/synchronization"
+	"github.com/mutagen-io/mutagen/pkg/synchronization/compression"
 	"github.com/mutagen-io/mutagen/pkg/synchronization/core"
 	"github.com/mutagen-io/mutagen/pkg/synchronization/core/ignore"
 )
@@ -14,19 +15,64 @@ import (
 const (
 	testYAMLConfiguration = `
 mode: "two-way-resolved"
+typeChangeMode: "conflict"
 hash: sha256
 maxEntryCount: 500
 maxStagingFileSize: "1000 GB"
+oversizedFileMode: "halt"
+maxRenameDetectionFileSize: "1 GB"
+maxContentCacheSize: "10 GB"
+stagingConcurrencyMode: "concurrent"
+maxDeltificationTime: 250
+maxStagedContentAge: 3600
+rsyncBlockSize: "64 KiB"
+maxInFlightBytes: "8 MB"
+renameDetectionDigestLength: 12
+renameDetectionRetries: 2
+maxSignatureMemory: "64 MB"
+maxConflictCount: 25
+maxConflictPersistence: 5
+watchdogTimeout: 300
+agentTerminationReconnectDelay: 250
+maxBetaScanDeferral: 4
 probeMode: "assume"
 scanMode: "accelerated"
+scanSharingMode: "enabled"
+aggregateDigestMode: "disabled"
+directoryListingRetries: 3
+slowDirectoryListingThreshold: 250
+cacheSaveThreshold: 50
+maxRecheckPaths: 10000
+scanSubpaths:
+  - "src/app"
+  - "src/lib"
+digestSamplingThreshold: "1 GB"
+digestLength: 12
+cacheTrustMode: "ignore-file-id"
+nameNormalizationMode: "require-nfc"
+capabilityMismatchMode: "halt"
+configurationIncompatibilityMode: "halt"
+rootOverlapMode: "refuse"
+rootExistenceMode: "require"
 stageMode: "neighboring"
+transitionMode: "shadow-directory"
+initialSynchronizationMode: "force"
+maxReportedProblems: 25
+maxReconciliationRecordings: 50
 
 symlink:
   mode: "portable"
+  replacementMode: "replace"
+  cycleMode: "refuse"
+
+specialFile:
+  mode: "placeholder"
 
 watch:
   mode: "force-poll"
   pollingInterval: 5
+  queueSize: 500
+  initialPollModificationMode: "report"
 
 ignore:
   syntax: mutagen
@@ -34,6 +80,12 @@ ignore:
     - "ignore/this/**"
     - "!ignore/this/that"
   vcs: true
+  manifest:
+    - "deploy/app"
+    - "deploy/config.yml"
+  phantomDirectoryMode: "ignored"
+  maxFileSize: "10 KB"
+  osMetadata: true
 
 permissions:
   mode: "portable"
@@ -44,6 +96,24 @@ permissions:
 
 compression:
   algorithm: deflate
+  stagingAlgorithm: deflate
+
+transport:
+  streamConcurrency: 4
+
+clock:
+  skewMode: refuse
+  skewTolerance: 30
+
+probe:
+  directory: "/probe/directory"
+  assumeExecutabilityPreservation: true
+  assumeUnicodeDecomposition: false
+overlay:
+  base: "/overlay/base"
+verification:
+  mode: "periodic"
+  interval: 604800
 `
 )
 
@@ -51,26 +121,84 @@ compression:
 // human-readable configuration given above.
 var expectedConfiguration = &synchronization.Configuration{
 	SynchronizationMode: core.SynchronizationMode_SynchronizationModeTwoWayResolved,
+	TypeChangeMode:      core.TypeChangeMode_TypeChangeModeConflict,
 	MaximumEntryCount:   500,
 	// TODO: This will mis-match.
-	MaximumStagingFileSize: 1000000000000,
-	ProbeMode:              behavior.ProbeMode_ProbeModeAssume,
-	ScanMode:               synchronization.ScanMode_ScanModeAccelerated,
-	StageMode:              synchronization.StageMode_StageModeNeighboring,
-	SymbolicLinkMode:       core.SymbolicLinkMode_SymbolicLinkModePortable,
-	WatchMode:              synchronization.WatchMode_WatchModeForcePoll,
-	WatchPollingInterval:   5,
-	IgnoreSyntax:           ignore.Syntax_SyntaxMutagen,
+	MaximumStagingFileSize:           1000000000000,
+	OversizedFileMode:                synchronization.OversizedFileMode_OversizedFileModeHalt,
+	MaximumRenameDetectionFileSize:   1000000000,
+	MaximumContentCacheSize:          10000000000,
+	StagingConcurrencyMode:           synchronization.StagingConcurrencyMode_StagingConcurrencyModeConcurrent,
+	MaximumDeltificationTime:         250,
+	MaximumStagedContentAge:          3600,
+	RsyncBlockSize:                   65536,
+	MaximumInFlightBytes:             8000000,
+	RenameDetectionDigestLength:      12,
+	RenameDetectionRetries:           2,
+	MaximumSignatureMemory:           64000000,
+	MaximumConflictCount:             25,
+	MaximumConflictPersistence:       5,
+	WatchdogTimeout:                  300,
+	AgentTerminationReconnectDelay:   250,
+	MaximumBetaScanDeferral:          4,
+	ProbeMode:                        behavior.ProbeMode_ProbeModeAssume,
+	ScanMode:                         synchronization.ScanMode_ScanModeAccelerated,
+	ScanSharingMode:                  synchronization.ScanSharingMode_ScanSharingModeEnabled,
+	AggregateDigestMode:              synchronization.AggregateDigestMode_AggregateDigestModeDisabled,
+	DirectoryListingRetries:          3,
+	SlowDirectoryListingThreshold:    250,
+	CacheSaveThreshold:               50,
+	MaximumRecheckPaths:              10000,
+	ScanSubpaths:                     []string{"src/app", "src/lib"},
+	DigestSamplingThreshold:          1000000000,
+	DigestLength:                     12,
+	CacheTrustMode:                   core.CacheTrustMode_CacheTrustModeIgnoreFileID,
+	NameNormalizationMode:            core.NameNormalizationMode_NameNormalizationModeRequireNFC,
+	CapabilityMismatchMode:           synchronization.CapabilityMismatchMode_CapabilityMismatchModeHalt,
+	ConfigurationIncompatibilityMode: synchronization.ConfigurationIncompatibilityMode_ConfigurationIncompatibilityModeHalt,
+	RootOverlapMode:                  synchronization.RootOverlapMode_RootOverlapModeRefuse,
+	RootExistenceMode:                synchronization.RootExistenceMode_RootExistenceModeRequire,
+	StageMode:                        synchronization.StageMode_StageModeNeighboring,
+	TransitionMode:                   core.TransitionMode_TransitionModeShadowDirectory,
+	SymbolicLinkMode:                 core.SymbolicLinkMode_SymbolicLinkModePortable,
+	SymbolicLinkReplacementMode:      core.SymbolicLinkReplacementMode_SymbolicLinkReplacementModeReplace,
+	SymbolicLinkCycleMode:            core.SymbolicLinkCycleMode_SymbolicLinkCycleModeRefuse,
+	SpecialFileMode:                  core.SpecialFileMode_SpecialFileModePlaceholder,
+	WatchMode:                        synchronization.WatchMode_WatchModeForcePoll,
+	WatchPollingInterval:             5,
+	WatchQueueSize:                   500,
+	InitialPollModificationMode:      synchronization.InitialPollModificationMode_InitialPollModificationModeReport,
+	InitialSynchronizationMode:       synchronization.InitialSynchronizationMode_InitialSynchronizationModeForce,
+	IgnoreSyntax:                     ignore.Syntax_SyntaxMutagen,
 	Ignores: []string{
 		"ignore/this/**",
 		"!ignore/this/that",
 	},
-	IgnoreVCSMode:        ignore.IgnoreVCSMode_IgnoreVCSModeIgnore,
-	PermissionsMode:      core.PermissionsMode_PermissionsModePortable,
-	DefaultFileMode:      0644,
-	DefaultDirectoryMode: 0755,
-	DefaultOwner:         "george",
-	DefaultGroup:         "presidents",
+	IgnoreVCSMode: ignore.IgnoreVCSMode_IgnoreVCSModeIgnore,
+	Manifest: []string{
+		"deploy/app",
+		"deploy/config.yml",
+	},
+	PhantomDirectoryMode:            core.PhantomDirectoryMode_PhantomDirectoryModeIgnored,
+	MaximumFileSize:                 10000,
+	IgnoreOSMetadataMode:            ignore.IgnoreOSMetadataMode_IgnoreOSMetadataModeIgnore,
+	PermissionsMode:                 core.PermissionsMode_PermissionsModePortable,
+	DefaultFileMode:                 0644,
+	DefaultDirectoryMode:            0755,
+	DefaultOwner:                    "george",
+	DefaultGroup:                    "presidents",
+	StagingCompressionAlgorithm:     compression.Algorithm_AlgorithmDeflate,
+	StreamConcurrency:               4,
+	ClockSkewMode:                   synchronization.ClockSkewMode_ClockSkewModeRefuse,
+	ClockSkewTolerance:              30,
+	ProbeDirectory:                  "/probe/directory",
+	AssumeExecutabilityPreservation: behavior.ProbeAssumption_ProbeAssumptionTrue,
+	AssumeUnicodeDecomposition:      behavior.ProbeAssumption_ProbeAssumptionFalse,
+	OverlayBase:                     "/overlay/base",
+	VerificationMode:                synchronization.VerificationMode_VerificationModePeriodic,
+	VerificationInterval:            604800,
+	MaximumReportedProblems:         25,
+	MaximumReconciliationRecordings: 50,
 }
 
 // TestLoadConfiguration tests loading a YAML-based session configuration.
@@ -104,30 +232,159 @@ func TestLoadConfiguration(t *testing.T) {
 	if configuration.SynchronizationMode != expectedConfiguration.SynchronizationMode {
 		t.Error("synchronization mode mismatch:", configuration.SynchronizationMode, "!=", expectedConfiguration.SynchronizationMode)
 	}
+	if configuration.TypeChangeMode != expectedConfiguration.TypeChangeMode {
+		t.Error("type change mode mismatch:", configuration.TypeChangeMode, "!=", expectedConfiguration.TypeChangeMode)
+	}
 	if configuration.MaximumEntryCount != expectedConfiguration.MaximumEntryCount {
 		t.Error("maximum entry count mismatch:", configuration.MaximumEntryCount, "!=", expectedConfiguration.MaximumEntryCount)
 	}
 	if configuration.MaximumStagingFileSize != expectedConfiguration.MaximumStagingFileSize {
 		t.Error("maximum staging file size mismatch:", configuration.MaximumStagingFileSize, "!=", expectedConfiguration.MaximumStagingFileSize)
 	}
+	if configuration.OversizedFileMode != expectedConfiguration.OversizedFileMode {
+		t.Error("oversized file mode mismatch:", configuration.OversizedFileMode, "!=", expectedConfiguration.OversizedFileMode)
+	}
+	if configuration.MaximumRenameDetectionFileSize != expectedConfiguration.MaximumRenameDetectionFileSize {
+		t.Error("maximum rename detection file size mismatch:", configuration.MaximumRenameDetectionFileSize, "!=", expectedConfiguration.MaximumRenameDetectionFileSize)
+	}
+	if configuration.MaximumContentCacheSize != expectedConfiguration.MaximumContentCacheSize {
+		t.Error("maximum content cache size mismatch:", configuration.MaximumContentCacheSize, "!=", expectedConfiguration.MaximumContentCacheSize)
+	}
+	if configuration.StagingConcurrencyMode != expectedConfiguration.StagingConcurrencyMode {
+		t.Error("staging concurrency mode mismatch:", configuration.StagingConcurrencyMode, "!=", expectedConfiguration.StagingConcurrencyMode)
+	}
+	if configuration.MaximumDeltificationTime != expectedConfiguration.MaximumDeltificationTime {
+		t.Error("maximum deltification time mismatch:", configuration.MaximumDeltificationTime, "!=", expectedConfiguration.MaximumDeltificationTime)
+	}
+	if configuration.MaximumStagedContentAge != expectedConfiguration.MaximumStagedContentAge {
+		t.Error("maximum staged content age mismatch:", configuration.MaximumStagedContentAge, "!=", expectedConfiguration.MaximumStagedContentAge)
+	}
+	if configuration.RsyncBlockSize != expectedConfiguration.RsyncBlockSize {
+		t.Error("rsync block size mismatch:", configuration.RsyncBlockSize, "!=", expectedConfiguration.RsyncBlockSize)
+	}
+	if configuration.MaximumInFlightBytes != expectedConfiguration.MaximumInFlightBytes {
+		t.Error("maximum in-flight bytes mismatch:", configuration.MaximumInFlightBytes, "!=", expectedConfiguration.MaximumInFlightBytes)
+	}
+	if configuration.RenameDetectionDigestLength != expectedConfiguration.RenameDetectionDigestLength {
+		t.Error("rename detection digest length mismatch:", configuration.RenameDetectionDigestLength, "!=", expectedConfiguration.RenameDetectionDigestLength)
+	}
+	if configuration.RenameDetectionRetries != expectedConfiguration.RenameDetectionRetries {
+		t.Error("rename detection retries mismatch:", configuration.RenameDetectionRetries, "!=", expectedConfiguration.RenameDetectionRetries)
+	}
+	if configuration.MaximumSignatureMemory != expectedConfiguration.MaximumSignatureMemory {
+		t.Error("maximum signature memory mismatch:", configuration.MaximumSignatureMemory, "!=", expectedConfiguration.MaximumSignatureMemory)
+	}
+	if configuration.MaximumConflictCount != expectedConfiguration.MaximumConflictCount {
+		t.Error("maximum conflict count mismatch:", configuration.MaximumConflictCount, "!=", expectedConfiguration.MaximumConflictCount)
+	}
+	if configuration.MaximumConflictPersistence != expectedConfiguration.MaximumConflictPersistence {
+		t.Error("maximum conflict persistence mismatch:", configuration.MaximumConflictPersistence, "!=", expectedConfiguration.MaximumConflictPersistence)
+	}
+	if configuration.WatchdogTimeout != expectedConfiguration.WatchdogTimeout {
+		t.Error("watchdog timeout mismatch:", configuration.WatchdogTimeout, "!=", expectedConfiguration.WatchdogTimeout)
+	}
+	if configuration.AgentTerminationReconnectDelay != expectedConfiguration.AgentTerminationReconnectDelay {
+		t.Error("agent termination reconnect delay mismatch:", configuration.AgentTerminationReconnectDelay, "!=", expectedConfiguration.AgentTerminationReconnectDelay)
+	}
+	if configuration.MaximumBetaScanDeferral != expectedConfiguration.MaximumBetaScanDeferral {
+		t.Error("maximum beta scan deferral mismatch:", configuration.MaximumBetaScanDeferral, "!=", expectedConfiguration.MaximumBetaScanDeferral)
+	}
 	if configuration.ProbeMode != expectedConfiguration.ProbeMode {
 		t.Error("probe mode mismatch:", configuration.ProbeMode, "!=", expectedConfiguration.ProbeMode)
 	}
 	if configuration.ScanMode != expectedConfiguration.ScanMode {
 		t.Error("scan mode mismatch:", configuration.ScanMode, "!=", expectedConfiguration.ScanMode)
 	}
+	if configuration.ScanSharingMode != expectedConfiguration.ScanSharingMode {
+		t.Error("scan sharing mode mismatch:", configuration.ScanSharingMode, "!=", expectedConfiguration.ScanSharingMode)
+	}
+	if configuration.AggregateDigestMode != expectedConfiguration.AggregateDigestMode {
+		t.Error("aggregate digest mode mismatch:", configuration.AggregateDigestMode, "!=", expectedConfiguration.AggregateDigestMode)
+	}
+	if configuration.DirectoryListingRetries != expectedConfiguration.DirectoryListingRetries {
+		t.Error("directory listing retries mismatch:", configuration.DirectoryListingRetries, "!=", expectedConfiguration.DirectoryListingRetries)
+	}
+	if configuration.SlowDirectoryListingThreshold != expectedConfiguration.SlowDirectoryListingThreshold {
+		t.Error("slow directory listing threshold mismatch:", configuration.SlowDirectoryListingThreshold, "!=", expectedConfiguration.SlowDirectoryListingThreshold)
+	}
+	if configuration.CacheSaveThreshold != expectedConfiguration.CacheSaveThreshold {
+		t.Error("cache save threshold mismatch:", configuration.CacheSaveThreshold, "!=", expectedConfiguration.CacheSaveThreshold)
+	}
+	if configuration.MaximumRecheckPaths != expectedConfiguration.MaximumRecheckPaths {
+		t.Error("maximum recheck paths mismatch:", configuration.MaximumRecheckPaths, "!=", expectedConfiguration.MaximumRecheckPaths)
+	}
+	if len(configuration.ScanSubpaths) != len(expectedConfiguration.ScanSubpaths) {
+		t.Error("scan subpath count mismatch:", len(configuration.ScanSubpaths), "!=", len(expectedConfiguration.ScanSubpaths))
+	} else {
+		for i, path := range configuration.ScanSubpaths {
+			if path != expectedConfiguration.ScanSubpaths[i] {
+				t.Error("scan subpath mismatch:", path, "!=", expectedConfiguration.ScanSubpaths[i], "at index", i)
+			}
+		}
+	}
+	if configuration.DigestSamplingThreshold != expectedConfiguration.DigestSamplingThreshold {
+		t.Error("digest sampling threshold mismatch:", configuration.DigestSamplingThreshold, "!=", expectedConfiguration.DigestSamplingThreshold)
+	}
+	if configuration.DigestLength != expectedConfiguration.DigestLength {
+		t.Error("digest length mismatch:", configuration.DigestLength, "!=", expectedConfiguration.DigestLength)
+	}
+	if configuration.CacheTrustMode != expectedConfiguration.CacheTrustMode {
+		t.Error("cache trust mode mismatch:", configuration.CacheTrustMode, "!=", expectedConfiguration.CacheTrustMode)
+	}
+	if configuration.NameNormalizationMode != expectedConfiguration.NameNormalizationMode {
+		t.Error("name normalization mode mismatch:", configuration.NameNormalizationMode, "!=", expectedConfiguration.NameNormalizationMode)
+	}
+	if configuration.CapabilityMismatchMode != expectedConfiguration.CapabilityMismatchMode {
+		t.Error("capability mismatch mode mismatch:", configuration.CapabilityMismatchMode, "!=", expectedConfiguration.CapabilityMismatchMode)
+	}
+	if configuration.ConfigurationIncompatibilityMode != expectedConfiguration.ConfigurationIncompatibilityMode {
+		t.Error("configuration incompatibility mode mismatch:", configuration.ConfigurationIncompatibilityMode, "!=", expectedConfiguration.ConfigurationIncompatibilityMode)
+	}
+	if configuration.RootOverlapMode != expectedConfiguration.RootOverlapMode {
+		t.Error("root overlap mode mismatch:", configuration.RootOverlapMode, "!=", expectedConfiguration.RootOverlapMode)
+	}
+	if configuration.RootExistenceMode != expectedConfiguration.RootExistenceMode {
+		t.Error("root existence mode mismatch:", configuration.RootExistenceMode, "!=", expectedConfiguration.RootExistenceMode)
+	}
 	if configuration.StageMode != expectedConfiguration.StageMode {
 		t.Error("stage mode mismatch:", configuration.StageMode, "!=", expectedConfiguration.StageMode)
 	}
+	if configuration.TransitionMode != expectedConfiguration.TransitionMode {
+		t.Error("transition mode mismatch:", configuration.TransitionMode, "!=", expectedConfiguration.TransitionMode)
+	}
 	if configuration.SymbolicLinkMode != expectedConfiguration.SymbolicLinkMode {
 		t.Error("symbolic link mode mismatch:", configuration.SymbolicLinkMode, "!=", expectedConfiguration.SymbolicLinkMode)
 	}
+	if configuration.SymbolicLinkReplacementMode != expectedConfiguration.SymbolicLinkReplacementMode {
+		t.Error("symbolic link replacement mode mismatch:", configuration.SymbolicLinkReplacementMode, "!=", expectedConfiguration.SymbolicLinkReplacementMode)
+	}
+	if configuration.SymbolicLinkCycleMode != expectedConfiguration.SymbolicLinkCycleMode {
+		t.Error("symbolic link cycle mode mismatch:", configuration.SymbolicLinkCycleMode, "!=", expectedConfiguration.SymbolicLinkCycleMode)
+	}
+	if configuration.SpecialFileMode != expectedConfiguration.SpecialFileMode {
+		t.Error("special file mode mismatch:", configuration.SpecialFileMode, "!=", expectedConfiguration.SpecialFileMode)
+	}
 	if configuration.WatchMode != expectedConfiguration.WatchMode {
 		t.Error("watch mode mismatch:", configuration.WatchMode, "!=", expectedConfiguration.WatchMode)
 	}
 	if configuration.WatchPollingInterval != expectedConfiguration.WatchPollingInterval {
 		t.Error("watch polling interval mismatch:", configuration.WatchPollingInterval, "!=", expectedConfiguration.WatchPollingInterval)
 	}
+	if configuration.WatchQueueSize != expectedConfiguration.WatchQueueSize {
+		t.Error("watch queue size mismatch:", configuration.WatchQueueSize, "!=", expectedConfiguration.WatchQueueSize)
+	}
+	if configuration.InitialPollModificationMode != expectedConfiguration.InitialPollModificationMode {
+		t.Error("initial poll modification mode mismatch:", configuration.InitialPollModificationMode, "!=", expectedConfiguration.InitialPollModificationMode)
+	}
+	if configuration.InitialSynchronizationMode != expectedConfiguration.InitialSynchronizationMode {
+		t.Error("initial synchronization mode mismatch:", configuration.InitialSynchronizationMode, "!=", expectedConfiguration.InitialSynchronizationMode)
+	}
+	if configuration.MaximumReportedProblems != expectedConfiguration.MaximumReportedProblems {
+		t.Error("maximum reported problems mismatch:", configuration.MaximumReportedProblems, "!=", expectedConfiguration.MaximumReportedProblems)
+	}
+	if configuration.MaximumReconciliationRecordings != expectedConfiguration.MaximumReconciliationRecordings {
+		t.Error("maximum reconciliation recordings mismatch:", configuration.MaximumReconciliationRecordings, "!=", expectedConfiguration.MaximumReconciliationRecordings)
+	}
 	if configuration.IgnoreSyntax != expectedConfiguration.IgnoreSyntax {
 		t.Error("ignore syntax mismatch:", configuration.IgnoreSyntax, "!=", expectedConfiguration.IgnoreSyntax)
 	}
@@ -143,6 +400,24 @@ func TestLoadConfiguration(t *testing.T) {
 	if configuration.IgnoreVCSMode != expectedConfiguration.IgnoreVCSMode {
 		t.Error("ignore VCS mode mismatch:", configuration.IgnoreVCSMode, "!=", expectedConfiguration.IgnoreVCSMode)
 	}
+	if len(configuration.Manifest) != len(expectedConfiguration.Manifest) {
+		t.Error("manifest path count mismatch:", len(configuration.Manifest), "!=", len(expectedConfiguration.Manifest))
+	} else {
+		for i, path := range configuration.Manifest {
+			if path != expectedConfiguration.Manifest[i] {
+				t.Error("manifest path mismatch:", path, "!=", expectedConfiguration.Manifest[i], "at index", i)
+			}
+		}
+	}
+	if configuration.PhantomDirectoryMode != expectedConfiguration.PhantomDirectoryMode {
+		t.Error("phantom directory mode mismatch:", configuration.PhantomDirectoryMode, "!=", expectedConfiguration.PhantomDirectoryMode)
+	}
+	if configuration.MaximumFileSize != expectedConfiguration.MaximumFileSize {
+		t.Error("maximum file size mismatch:", configuration.MaximumFileSize, "!=", expectedConfiguration.MaximumFileSize)
+	}
+	if configuration.IgnoreOSMetadataMode != expectedConfiguration.IgnoreOSMetadataMode {
+		t.Error("ignore OS metadata mode mismatch:", configuration.IgnoreOSMetadataMode, "!=", expectedConfiguration.IgnoreOSMetadataMode)
+	}
 	if configuration.PermissionsMode != expectedConfiguration.PermissionsMode {
 		t.Errorf("permissions mode mismatch: %o != %o", configuration.PermissionsMode, expectedConfiguration.PermissionsMode)
 	}
@@ -158,6 +433,36 @@ func TestLoadConfiguration(t *testing.T) {
 	if configuration.DefaultGroup != expectedConfiguration.DefaultGroup {
 		t.Error("default owner mismatch:", configuration.DefaultGroup, "!=", expectedConfiguration.DefaultGroup)
 	}
+	if configuration.StagingCompressionAlgorithm != expectedConfiguration.StagingCompressionAlgorithm {
+		t.Error("staging compression algorithm mismatch:", configuration.StagingCompressionAlgorithm, "!=", expectedConfiguration.StagingCompressionAlgorithm)
+	}
+	if configuration.StreamConcurrency != expectedConfiguration.StreamConcurrency {
+		t.Error("stream concurrency mismatch:", configuration.StreamConcurrency, "!=", expectedConfiguration.StreamConcurrency)
+	}
+	if configuration.ClockSkewMode != expectedConfiguration.ClockSkewMode {
+		t.Error("clock skew mode mismatch:", configuration.ClockSkewMode, "!=", expectedConfiguration.ClockSkewMode)
+	}
+	if configuration.ClockSkewTolerance != expectedConfiguration.ClockSkewTolerance {
+		t.Error("clock skew tolerance mismatch:", configuration.ClockSkewTolerance, "!=", expectedConfiguration.ClockSkewTolerance)
+	}
+	if configuration.ProbeDirectory != expectedConfiguration.ProbeDirectory {
+		t.Error("probe directory mismatch:", configuration.ProbeDirectory, "!=", expectedConfiguration.ProbeDirectory)
+	}
+	if configuration.AssumeExecutabilityPreservation != expectedConfiguration.AssumeExecutabilityPreservation {
+		t.Error("executability preservation assumption mismatch:", configuration.AssumeExecutabilityPreservation, "!=", expectedConfiguration.AssumeExecutabilityPreservation)
+	}
+	if configuration.AssumeUnicodeDecomposition != expectedConfiguration.AssumeUnicodeDecomposition {
+		t.Error("Unicode decomposition assumption mismatch:", configuration.AssumeUnicodeDecomposition, "!=", expectedConfiguration.AssumeUnicodeDecomposition)
+	}
+	if configuration.OverlayBase != expectedConfiguration.OverlayBase {
+		t.Error("overlay base mismatch:", configuration.OverlayBase, "!=", expectedConfiguration.OverlayBase)
+	}
+	if configuration.VerificationMode != expectedConfiguration.VerificationMode {
+		t.Error("verification mode mismatch:", configuration.VerificationMode, "!=", expectedConfiguration.VerificationMode)
+	}
+	if configuration.VerificationInterval != expectedConfiguration.VerificationInterval {
+		t.Error("verification interval mismatch:", configuration.VerificationInterval, "!=", expectedConfiguration.VerificationInterval)
+	}
 }
 
 // TODO: Expand tests, including testing for invalid configurations.
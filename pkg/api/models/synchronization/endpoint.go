@@ -70,6 +70,35 @@ type EndpointState struct {
 	// StagingProgress is the rsync staging progress. It is non-nil if and only
 	// if the endpoint is currently staging files.
 	StagingProgress *ReceiverState `json:"stagingProgress,omitempty"`
+	// ClockOffset is the measured offset (in nanoseconds) of the endpoint's
+	// clock relative to the controller's clock.
+	ClockOffset int64 `json:"clockOffset,omitempty"`
+	// WatchOverflows is the number of times that the endpoint's native
+	// filesystem watcher has failed due to an internal event overflow.
+	WatchOverflows uint64 `json:"watchOverflows,omitempty"`
+	// AccelerationUnavailable is a description of the reason that accelerated
+	// scanning has been unavailable on the endpoint for a sustained period.
+	AccelerationUnavailable string `json:"accelerationUnavailable,omitempty"`
+	// HashedBytes is the number of bytes of file content hashed during the last
+	// scan of the endpoint.
+	HashedBytes uint64 `json:"hashedBytes,omitempty"`
+	// HashingDuration is the time (in nanoseconds) spent hashing file content
+	// during the last scan of the endpoint.
+	HashingDuration uint64 `json:"hashingDuration,omitempty"`
+	// ConfigurationIncompatibilities are descriptions of the ways in which the
+	// endpoint's effective configuration was incompatible with the
+	// capabilities of its filesystem.
+	ConfigurationIncompatibilities []string `json:"configurationIncompatibilities,omitempty"`
+	// VerificationDivergences are the paths at which the endpoint's content
+	// diverged from the last synchronized state during the last background
+	// verification pass. This list may be a truncated version of the full list
+	// if too many divergences are encountered to report via the API, in which
+	// case ExcludedVerificationDivergences will be non-zero.
+	VerificationDivergences []string `json:"verificationDivergences,omitempty"`
+	// ExcludedVerificationDivergences is the number of divergences that have
+	// been excluded from VerificationDivergences due to truncation. This value
+	// can be non-zero only if VerificationDivergences is non-empty.
+	ExcludedVerificationDivergences uint64 `json:"excludedVerificationDivergences,omitempty"`
 }
 
 // loadFromInternal sets an Endpoint to match internal Protocol Buffers
@@ -95,16 +124,24 @@ func (e *Endpoint) loadFromInternal(url *url.URL, configuration *synchronization
 		e.EndpointState = nil
 	} else {
 		e.EndpointState = &EndpointState{
-			Scanned:                    state.Scanned,
-			Directories:                state.Directories,
-			Files:                      state.Files,
-			SymbolicLinks:              state.SymbolicLinks,
-			TotalFileSize:              state.TotalFileSize,
-			ScanProblems:               exportProblems(state.ScanProblems),
-			ExcludedScanProblems:       state.ExcludedScanProblems,
-			TransitionProblems:         exportProblems(state.TransitionProblems),
-			ExcludedTransitionProblems: state.ExcludedTransitionProblems,
-			StagingProgress:            newReceiverStateFromInternalReceiverState(state.StagingProgress),
+			Scanned:                         state.Scanned,
+			Directories:                     state.Directories,
+			Files:                           state.Files,
+			SymbolicLinks:                   state.SymbolicLinks,
+			TotalFileSize:                   state.TotalFileSize,
+			ScanProblems:                    exportProblems(state.ScanProblems),
+			ExcludedScanProblems:            state.ExcludedScanProblems,
+			TransitionProblems:              exportProblems(state.TransitionProblems),
+			ExcludedTransitionProblems:      state.ExcludedTransitionProblems,
+			StagingProgress:                 newReceiverStateFromInternalReceiverState(state.StagingProgress),
+			ClockOffset:                     state.ClockOffset,
+			WatchOverflows:                  state.WatchOverflows,
+			AccelerationUnavailable:         state.AccelerationUnavailable,
+			HashedBytes:                     state.HashedBytes,
+			HashingDuration:                 state.HashingDuration,
+			ConfigurationIncompatibilities:  state.ConfigurationIncompatibilities,
+			VerificationDivergences:         state.VerificationDivergences,
+			ExcludedVerificationDivergences: state.ExcludedVerificationDivergences,
 		}
 	}
 }
@@ -48,6 +48,8 @@ type SymbolicLinkEntry struct {
 type ProblematicEntry struct {
 	// Problem indicates the relevant error for problematic content.
 	Problem string `json:"problem"`
+	// ProblemCode is a machine-readable classification of the problem.
+	ProblemCode core.ProblemCode `json:"problemCode"`
 }
 
 // newEntryFromInternalEntry creates a new entry representation from an internal
@@ -78,10 +80,17 @@ func newEntryFromInternalEntry(entry *core.Entry) *Entry {
 		}
 	case core.EntryKind_SymbolicLink:
 		result.SymbolicLinkEntry = &SymbolicLinkEntry{Target: entry.Target}
+	case core.EntryKind_SpecialFile:
+		// There are no fields to propagate for special files.
 	case core.EntryKind_Untracked:
 		// There are no fields to propagate for untracked content.
 	case core.EntryKind_Problematic:
-		result.ProblematicEntry = &ProblematicEntry{Problem: entry.Problem}
+		result.ProblematicEntry = &ProblematicEntry{
+			Problem:     entry.Problem,
+			ProblemCode: entry.ProblemCode,
+		}
+	case core.EntryKind_Oversized:
+		// There are no fields to propagate for oversized content.
 	default:
 		panic("invalid entry kind")
 	}
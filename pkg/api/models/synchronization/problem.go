@@ -11,6 +11,8 @@ type Problem struct {
 	Path string `json:"path"`
 	// Error is a human-readable summary of the problem.
 	Error string `json:"error"`
+	// Code is a machine-readable classification of the problem's cause.
+	Code core.ProblemCode `json:"code"`
 }
 
 // loadFromInternal sets a problem to match an internal Protocol Buffers
@@ -18,6 +20,7 @@ type Problem struct {
 func (p *Problem) loadFromInternal(problem *core.Problem) {
 	p.Path = problem.Path
 	p.Error = problem.Error
+	p.Code = problem.Code
 }
 
 // exportProblems is a convenience function that calls Problem.loadFromInternal
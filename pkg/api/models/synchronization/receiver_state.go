@@ -1,6 +1,8 @@
 package synchronization
 
 import (
+	"time"
+
 	"github.com/mutagen-io/mutagen/pkg/synchronization/rsync"
 )
 
@@ -20,6 +22,12 @@ type ReceiverState struct {
 	// TotalReceivedSize is the total number of bytes that have been received
 	// for all paths from both block and data operations.
 	TotalReceivedSize uint64 `json:"totalReceivedSize"`
+	// BytesPerSecond is the receive rate for the current path. It is zero if
+	// the rate is not yet known.
+	BytesPerSecond uint64 `json:"bytesPerSecond,omitempty"`
+	// EstimatedRemaining is the estimated time remaining to receive the current
+	// path. It is zero if no estimate is available.
+	EstimatedRemaining time.Duration `json:"estimatedRemaining,omitempty"`
 }
 
 // newReceiverStateFromInternalReceiverState creates a new receiver state
@@ -33,11 +41,13 @@ func newReceiverStateFromInternalReceiverState(state *rsync.ReceiverState) *Rece
 
 	// Perform conversion.
 	return &ReceiverState{
-		Path:              state.Path,
-		ReceivedSize:      state.ReceivedSize,
-		ExpectedSize:      state.ExpectedSize,
-		ReceivedFiles:     state.ReceivedFiles,
-		ExpectedFiles:     state.ExpectedFiles,
-		TotalReceivedSize: state.TotalReceivedSize,
+		Path:               state.Path,
+		ReceivedSize:       state.ReceivedSize,
+		ExpectedSize:       state.ExpectedSize,
+		ReceivedFiles:      state.ReceivedFiles,
+		ExpectedFiles:      state.ExpectedFiles,
+		TotalReceivedSize:  state.TotalReceivedSize,
+		BytesPerSecond:     state.BytesPerSecond,
+		EstimatedRemaining: state.EstimatedRemaining.AsDuration(),
 	}
 }
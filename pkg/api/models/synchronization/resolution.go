@@ -0,0 +1,137 @@
+package synchronization
+
+import (
+	"github.com/mutagen-io/mutagen/pkg/synchronization"
+	"github.com/mutagen-io/mutagen/pkg/synchronization/core"
+)
+
+const (
+	// ResolutionEndpointAlpha identifies the alpha endpoint in conflict
+	// resolutions.
+	ResolutionEndpointAlpha = "alpha"
+	// ResolutionEndpointBeta identifies the beta endpoint in conflict
+	// resolutions.
+	ResolutionEndpointBeta = "beta"
+	// ResolutionOperationDelete indicates that the content at a path should be
+	// deleted.
+	ResolutionOperationDelete = "delete"
+)
+
+// ConflictResolutionManifest describes the conflicts for a synchronization
+// session along with the actions that can be taken to resolve each of them.
+type ConflictResolutionManifest struct {
+	// Session is the session identifier.
+	Session string `json:"session"`
+	// Name is the session name.
+	Name string `json:"name,omitempty"`
+	// Alpha is the formatted alpha endpoint URL.
+	Alpha string `json:"alpha"`
+	// Beta is the formatted beta endpoint URL.
+	Beta string `json:"beta"`
+	// Conflicts are the session conflicts and their resolutions. This list may
+	// be truncated if too many conflicts were reported via the API.
+	Conflicts []ResolvableConflict `json:"conflicts"`
+	// ExcludedConflicts is the number of conflicts that have been excluded from
+	// Conflicts due to truncation.
+	ExcludedConflicts uint64 `json:"excludedConflicts,omitempty"`
+}
+
+// ResolvableConflict represents a conflict along with its resolution options.
+type ResolvableConflict struct {
+	// Conflict is the conflict being resolved.
+	Conflict
+	// Resolutions are the available resolution options. Each resolution
+	// corresponds to choosing a different endpoint's content.
+	Resolutions []ConflictResolution `json:"resolutions"`
+}
+
+// ConflictResolution describes how to resolve a conflict by retaining the
+// content from one endpoint. Once its actions have been performed, the next
+// synchronization cycle will propagate the winning content.
+type ConflictResolution struct {
+	// Winner is the endpoint whose content is retained.
+	Winner string `json:"winner"`
+	// Actions are the filesystem actions to perform.
+	Actions []ResolutionAction `json:"actions"`
+}
+
+// ResolutionAction is a filesystem action to perform on an endpoint.
+type ResolutionAction struct {
+	// Endpoint is the endpoint on which the action should be performed.
+	Endpoint string `json:"endpoint"`
+	// Operation is the operation to perform.
+	Operation string `json:"operation"`
+	// Path is the path on which to operate, relative to the synchronization
+	// root.
+	Path string `json:"path"`
+}
+
+// newConflictResolution computes a resolution for a conflict that retains the
+// winning endpoint's content. Mutagen resolves conflicts in favor of
+// modifications over deletions, so the resolution deletes the content created
+// or modified on the losing endpoint. If the losing endpoint has no such
+// content, then no resolution exists and nil is returned.
+func newConflictResolution(winner, loser string, losingChanges []*core.Change) *ConflictResolution {
+	// Compute the deletion actions.
+	var actions []ResolutionAction
+	for _, change := range losingChanges {
+		if change.New != nil {
+			actions = append(actions, ResolutionAction{
+				Endpoint:  loser,
+				Operation: ResolutionOperationDelete,
+				Path:      change.Path,
+			})
+		}
+	}
+
+	// If there are no actions, then this isn't a valid resolution.
+	if len(actions) == 0 {
+		return nil
+	}
+
+	// Create the resolution.
+	return &ConflictResolution{
+		Winner:  winner,
+		Actions: actions,
+	}
+}
+
+// loadFromInternal sets a resolvable conflict to match an internal Protocol
+// Buffers representation. The conflict must be valid.
+func (c *ResolvableConflict) loadFromInternal(conflict *core.Conflict) {
+	// Propagate the conflict.
+	c.Conflict.loadFromInternal(conflict)
+
+	// Compute the available resolutions.
+	c.Resolutions = nil
+	if r := newConflictResolution(ResolutionEndpointAlpha, ResolutionEndpointBeta, conflict.BetaChanges); r != nil {
+		c.Resolutions = append(c.Resolutions, *r)
+	}
+	if r := newConflictResolution(ResolutionEndpointBeta, ResolutionEndpointAlpha, conflict.AlphaChanges); r != nil {
+		c.Resolutions = append(c.Resolutions, *r)
+	}
+}
+
+// ExportConflictResolutions creates a conflict resolution manifest from an
+// internal session state representation. The session state must be valid. It
+// is guaranteed to return a non-nil Conflicts slice, even if the session has no
+// conflicts.
+func ExportConflictResolutions(state *synchronization.State) *ConflictResolutionManifest {
+	// Create the manifest.
+	result := &ConflictResolutionManifest{
+		Session:           state.Session.Identifier,
+		Name:              state.Session.Name,
+		Alpha:             state.Session.Alpha.Format(" "),
+		Beta:              state.Session.Beta.Format(" "),
+		Conflicts:         make([]ResolvableConflict, len(state.Conflicts)),
+		ExcludedConflicts: state.ExcludedConflicts,
+	}
+
+	// Propagate conflicts.
+	for i, conflict := range state.Conflicts {
+		result.Conflicts[i].loadFromInternal(conflict)
+	}
+
+	// Done.
+	return result
+}
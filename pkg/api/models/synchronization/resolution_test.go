@@ -0,0 +1,140 @@
+package synchronization
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+
+	"github.com/mutagen-io/mutagen/pkg/synchronization"
+	"github.com/mutagen-io/mutagen/pkg/synchronization/core"
+	"github.com/mutagen-io/mutagen/pkg/url"
+)
+
+// TestExportConflictResolutions tests that ExportConflictResolutions generates
+// a manifest reflecting a set of conflicts and their resolution options.
+func TestExportConflictResolutions(t *testing.T) {
+	// Create test entries.
+	file := &core.Entry{Kind: core.EntryKind_File, Digest: []byte{0x01}}
+	modified := &core.Entry{Kind: core.EntryKind_File, Digest: []byte{0x02}}
+	directory := &core.Entry{
+		Kind:     core.EntryKind_Directory,
+		Contents: map[string]*core.Entry{"file": file},
+	}
+
+	// Create a session state with a set of conflicts.
+	state := &synchronization.State{
+		Session: &synchronization.Session{
+			Identifier: "sync_test",
+			Name:       "test",
+			Alpha:      &url.URL{Kind: url.Kind_Synchronization, Protocol: url.Protocol_Local, Path: "/alpha"},
+			Beta:       &url.URL{Kind: url.Kind_Synchronization, Protocol: url.Protocol_Local, Path: "/beta"},
+		},
+		Conflicts: []*core.Conflict{
+			// A modification conflict, which is resolvable in favor of either
+			// endpoint.
+			{
+				Root:         "file",
+				AlphaChanges: []*core.Change{{Path: "file", Old: file, New: modified}},
+				BetaChanges:  []*core.Change{{Path: "file", Old: file, New: directory}},
+			},
+			// A conflict with a deletion on alpha and multiple changes on beta,
+			// which is only resolvable in favor of alpha.
+			{
+				Root:         "directory",
+				AlphaChanges: []*core.Change{{Path: "directory", Old: directory}},
+				BetaChanges: []*core.Change{
+					{Path: "directory/file", Old: file, New: modified},
+					{Path: "directory/new", New: file},
+				},
+			},
+		},
+		ExcludedConflicts: 3,
+	}
+
+	// Perform the export.
+	manifest := ExportConflictResolutions(state)
+
+	// Verify session information.
+	if manifest.Session != "sync_test" {
+		t.Error("session identifier mismatch:", manifest.Session, "!=", "sync_test")
+	}
+	if manifest.Name != "test" {
+		t.Error("session name mismatch:", manifest.Name, "!=", "test")
+	}
+	if manifest.Alpha != "/alpha" {
+		t.Error("alpha URL mismatch:", manifest.Alpha, "!=", "/alpha")
+	}
+	if manifest.Beta != "/beta" {
+		t.Error("beta URL mismatch:", manifest.Beta, "!=", "/beta")
+	}
+	if manifest.ExcludedConflicts != 3 {
+		t.Error("excluded conflict count mismatch:", manifest.ExcludedConflicts, "!=", 3)
+	}
+
+	// Verify conflicts.
+	if len(manifest.Conflicts) != 2 {
+		t.Fatal("conflict count mismatch:", len(manifest.Conflicts), "!=", 2)
+	}
+	if manifest.Conflicts[0].Root != "file" || manifest.Conflicts[1].Root != "directory" {
+		t.Error("conflict roots do not match expected")
+	}
+	if len(manifest.Conflicts[1].BetaChanges) != 2 {
+		t.Error("conflict changes not propagated")
+	}
+
+	// Verify resolutions.
+	expected := [][]ConflictResolution{
+		{
+			{
+				Winner: ResolutionEndpointAlpha,
+				Actions: []ResolutionAction{
+					{Endpoint: ResolutionEndpointBeta, Operation: ResolutionOperationDelete, Path: "file"},
+				},
+			},
+			{
+				Winner: ResolutionEndpointBeta,
+				Actions: []ResolutionAction{
+					{Endpoint: ResolutionEndpointAlpha, Operation: ResolutionOperationDelete, Path: "file"},
+				},
+			},
+		},
+		{
+			{
+				Winner: ResolutionEndpointAlpha,
+				Actions: []ResolutionAction{
+					{Endpoint: ResolutionEndpointBeta, Operation: ResolutionOperationDelete, Path: "directory/file"},
+					{Endpoint: ResolutionEndpointBeta, Operation: ResolutionOperationDelete, Path: "directory/new"},
+				},
+			},
+		},
+	}
+	for i, conflict := range manifest.Conflicts {
+		if !reflect.DeepEqual(conflict.Resolutions, expected[i]) {
+			t.Errorf("conflict %d: resolutions do not match expected: %v != %v",
+				i, conflict.Resolutions, expected[i],
+			)
+		}
+	}
+
+	// Verify that the manifest can be encoded.
+	if _, err := json.Marshal(manifest); err != nil {
+		t.Error("unable to encode manifest:", err)
+	}
+}
+
+// TestExportConflictResolutionsEmpty tests that ExportConflictResolutions
+// generates a non-nil conflict list for sessions without conflicts.
+func TestExportConflictResolutionsEmpty(t *testing.T) {
+	manifest := ExportConflictResolutions(&synchronization.State{
+		Session: &synchronization.Session{
+			Identifier: "sync_test",
+			Alpha:      &url.URL{Kind: url.Kind_Synchronization, Protocol: url.Protocol_Local, Path: "/alpha"},
+			Beta:       &url.URL{Kind: url.Kind_Synchronization, Protocol: url.Protocol_Local, Path: "/beta"},
+		},
+	})
+	if manifest.Conflicts == nil {
+		t.Error("conflict list is nil")
+	} else if len(manifest.Conflicts) != 0 {
+		t.Error("conflict list is non-empty")
+	}
+}
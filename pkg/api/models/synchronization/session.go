@@ -40,6 +40,9 @@ type Session struct {
 type SessionState struct {
 	// LastError is the last synchronization error to occur.
 	LastError string `json:"lastError,omitempty"`
+	// AgentTerminated indicates whether or not the last synchronization error
+	// was caused by an endpoint's agent process terminating unexpectedly.
+	AgentTerminated bool `json:"agentTerminated,omitempty"`
 	// SuccessfulCycles is the number of successful synchronization cycles to
 	// occur since successfully connecting to the endpoints.
 	SuccessfulCycles uint64 `json:"successfulCycles,omitempty"`
@@ -51,6 +54,49 @@ type SessionState struct {
 	// Conflicts due to truncation. This value can only be non-zero if conflicts
 	// is non-empty.
 	ExcludedConflicts uint64 `json:"excludedConflicts,omitempty"`
+	// CapabilityMismatches are the filesystem capabilities that differed
+	// between the endpoints in the last successful scan.
+	CapabilityMismatches []CapabilityMismatch `json:"capabilityMismatches,omitempty"`
+	// Verifications is the number of background verification passes to
+	// complete since successfully connecting to the endpoints.
+	Verifications uint64 `json:"verifications,omitempty"`
+	// Warnings are non-fatal conditions identified during the last successful
+	// scan that may require user attention.
+	Warnings []string `json:"warnings,omitempty"`
+}
+
+// CapabilityMismatch describes a filesystem capability that differs between
+// endpoints.
+type CapabilityMismatch struct {
+	// Capability is a description of the capability.
+	Capability string `json:"capability"`
+	// Alpha indicates whether or not the alpha filesystem has the capability.
+	Alpha bool `json:"alpha"`
+	// Beta indicates whether or not the beta filesystem has the capability.
+	Beta bool `json:"beta"`
+}
+
+// exportCapabilityMismatches converts a slice of internal capability mismatch
+// representations to a slice of public capability mismatch representations.
+func exportCapabilityMismatches(mismatches []*synchronization.CapabilityMismatch) []CapabilityMismatch {
+	// If there are no mismatches, then return a nil slice so that the field is
+	// omitted.
+	if len(mismatches) == 0 {
+		return nil
+	}
+
+	// Convert the mismatches.
+	results := make([]CapabilityMismatch, len(mismatches))
+	for i, m := range mismatches {
+		results[i] = CapabilityMismatch{
+			Capability: m.Capability,
+			Alpha:      m.Alpha,
+			Beta:       m.Beta,
+		}
+	}
+
+	// Done.
+	return results
 }
 
 // loadFromInternal sets a session to match an internal Protocol Buffers session
@@ -90,10 +136,14 @@ func (s *Session) loadFromInternal(state *synchronization.State) {
 		s.SessionState = nil
 	} else {
 		s.SessionState = &SessionState{
-			LastError:         state.LastError,
-			SuccessfulCycles:  state.SuccessfulCycles,
-			Conflicts:         exportConflicts(state.Conflicts),
-			ExcludedConflicts: state.ExcludedConflicts,
+			LastError:            state.LastError,
+			AgentTerminated:      state.AgentTerminated,
+			SuccessfulCycles:     state.SuccessfulCycles,
+			Conflicts:            exportConflicts(state.Conflicts),
+			ExcludedConflicts:    state.ExcludedConflicts,
+			CapabilityMismatches: exportCapabilityMismatches(state.CapabilityMismatches),
+			Verifications:        state.Verifications,
+			Warnings:             state.Warnings,
 		}
 	}
 }
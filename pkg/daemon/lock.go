@@ -2,10 +2,18 @@ package daemon
 
 import (
 	"fmt"
+	"time"
 
 	"github.com/mutagen-io/mutagen/pkg/filesystem/locking"
 )
 
+const (
+	// staleLockRecoveryTimeout is the maximum amount of time that daemon lock
+	// acquisition will wait for a stale daemon lock (i.e. one whose recorded
+	// owner has terminated) to be released by the system.
+	staleLockRecoveryTimeout = 10 * time.Second
+)
+
 // Lock represents the global daemon lock. It is held by a single daemon
 // instance at a time.
 type Lock struct {
@@ -13,7 +21,11 @@ type Lock struct {
 	locker *locking.Locker
 }
 
-// AcquireLock attempts to acquire the global daemon lock.
+// AcquireLock attempts to acquire the global daemon lock. If the lock is held
+// by a running daemon, then acquisition fails immediately. If the lock is stale
+// (i.e. its recorded owner has terminated but the system has yet to release the
+// lock), then acquisition will wait (for a bounded period) for the lock to be
+// released. On success, the current process is recorded as the lock owner.
 func AcquireLock() (*Lock, error) {
 	// Compute the lock path.
 	lockPath, err := subpath(lockName)
@@ -25,7 +37,7 @@ func AcquireLock() (*Lock, error) {
 	locker, err := locking.NewLocker(lockPath, 0600)
 	if err != nil {
 		return nil, fmt.Errorf("unable to create daemon file locker: %w", err)
-	} else if err = locker.Lock(false); err != nil {
+	} else if err = locker.LockRecoveringStale(staleLockRecoveryTimeout); err != nil {
 		locker.Close()
 		return nil, err
 	}
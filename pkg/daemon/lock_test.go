@@ -1,6 +1,9 @@
 package daemon
 
 import (
+	"os"
+	"strconv"
+	"strings"
 	"testing"
 )
 
@@ -17,3 +20,31 @@ func TestLockCycle(t *testing.T) {
 		t.Fatal("unable to release lock:", err)
 	}
 }
+
+// TestLockRecordsOwner tests that acquiring the daemon lock records the current
+// process as the lock owner.
+func TestLockRecordsOwner(t *testing.T) {
+	// Attempt to acquire the daemon lock.
+	lock, err := AcquireLock()
+	if err != nil {
+		t.Fatal("unable to acquire lock:", err)
+	}
+
+	// Release the lock. We have to do this before reading the lock file because
+	// closing any other file descriptor for the lock file would release the
+	// lock on POSIX systems anyway.
+	if err := lock.Release(); err != nil {
+		t.Fatal("unable to release lock:", err)
+	}
+
+	// Verify the owner record.
+	path, err := lockPath()
+	if err != nil {
+		t.Fatal("unable to compute lock path:", err)
+	}
+	if contents, err := os.ReadFile(path); err != nil {
+		t.Fatal("unable to read lock file:", err)
+	} else if owner := strings.TrimSpace(string(contents)); owner != strconv.Itoa(os.Getpid()) {
+		t.Error("lock owner record does not match current process:", owner)
+	}
+}
@@ -0,0 +1,122 @@
+package behavior
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+
+	"github.com/mutagen-io/mutagen/pkg/filesystem"
+)
+
+const (
+	// lowerCaseFileNamePrefix is the prefix used for temporary files created
+	// by the case sensitivity test. Its test-specific portion is lower case.
+	lowerCaseFileNamePrefix = filesystem.TemporaryNamePrefix + "case-test-entry"
+	// upperCaseFileNamePrefix is the upper case equivalent of
+	// lowerCaseFileNamePrefix.
+	upperCaseFileNamePrefix = filesystem.TemporaryNamePrefix + "CASE-TEST-ENTRY"
+)
+
+// IgnoresCaseByPath determines whether or not the filesystem on which the
+// directory at the specified path resides treats filenames differing only in
+// case as equivalent. The second value returned by this function indicates
+// whether or not probe files were used in determining behavior.
+func IgnoresCaseByPath(path string, probeMode ProbeMode) (bool, bool, error) {
+	// Check the filesystem probing mode and see if we can return an assumption.
+	if probeMode == ProbeMode_ProbeModeAssume {
+		return assumeCaseInsensitivity, false, nil
+	} else if !probeMode.Supported() {
+		panic("invalid probe mode")
+	}
+
+	// Check if we have a fast test that will work.
+	if result, ok := probeCaseInsensitivityFastByPath(path); ok {
+		return result, false, nil
+	} else if runtime.GOOS == "windows" {
+		panic("fast path not used on Windows")
+	}
+
+	// Create and close a temporary file using the lower case filename.
+	file, err := os.CreateTemp(path, lowerCaseFileNamePrefix)
+	if err != nil {
+		return false, true, fmt.Errorf("unable to create test file: %w", err)
+	} else if err = file.Close(); err != nil {
+		return false, true, fmt.Errorf("unable to close test file: %w", err)
+	}
+
+	// Defer removal of the file.
+	defer os.Remove(file.Name())
+
+	// Compute the upper case variant of the file's name. The name is
+	// calculated from the parameters passed to CreateTemp, so it will still
+	// contain the lower case prefix.
+	upperCaseFilename := strings.Replace(
+		filepath.Base(file.Name()),
+		lowerCaseFileNamePrefix,
+		upperCaseFileNamePrefix,
+		1,
+	)
+
+	// Check whether or not the file is accessible using the upper case name.
+	if _, err := os.Lstat(filepath.Join(path, upperCaseFilename)); err == nil {
+		return true, true, nil
+	} else if errors.Is(err, fs.ErrNotExist) {
+		return false, true, nil
+	} else {
+		return false, true, fmt.Errorf("unable to query test file using alternate case: %w", err)
+	}
+}
+
+// IgnoresCase determines whether or not the specified directory (and its
+// underlying filesystem) treats filenames differing only in case as
+// equivalent. The second value returned by this function indicates whether or
+// not probe files were used in determining behavior.
+func IgnoresCase(directory *filesystem.Directory, probeMode ProbeMode) (bool, bool, error) {
+	// Check the filesystem probing mode and see if we can return an assumption.
+	if probeMode == ProbeMode_ProbeModeAssume {
+		return assumeCaseInsensitivity, false, nil
+	} else if !probeMode.Supported() {
+		panic("invalid probe mode")
+	}
+
+	// Check if we have a fast test that will work.
+	if result, ok := probeCaseInsensitivityFast(directory); ok {
+		return result, false, nil
+	} else if runtime.GOOS == "windows" {
+		panic("fast path not used on Windows")
+	}
+
+	// Create and close a temporary file using the lower case filename.
+	lowerCaseName, file, err := directory.CreateTemporaryFile(lowerCaseFileNamePrefix)
+	if err != nil {
+		return false, true, fmt.Errorf("unable to create test file: %w", err)
+	} else if err = file.Close(); err != nil {
+		return false, true, fmt.Errorf("unable to close test file: %w", err)
+	}
+
+	// Defer removal of the file.
+	defer directory.RemoveFile(lowerCaseName)
+
+	// The name returned from CreateTemporaryFile is calculated from the
+	// provided pattern, so it will still contain the lower case prefix. Compute
+	// the upper case variant.
+	upperCaseName := strings.Replace(
+		lowerCaseName,
+		lowerCaseFileNamePrefix,
+		upperCaseFileNamePrefix,
+		1,
+	)
+
+	// Check whether or not the file is accessible using the upper case name.
+	if _, err := directory.ReadContentMetadata(upperCaseName); err == nil {
+		return true, true, nil
+	} else if errors.Is(err, fs.ErrNotExist) {
+		return false, true, nil
+	} else {
+		return false, true, fmt.Errorf("unable to query test file using alternate case: %w", err)
+	}
+}
@@ -0,0 +1,9 @@
+//go:build !darwin && !windows
+
+package behavior
+
+const (
+	// assumeCaseInsensitivity indicates whether or not case insensitivity
+	// should be assumed for the platform.
+	assumeCaseInsensitivity = false
+)
@@ -0,0 +1,30 @@
+//go:build darwin || linux
+
+package behavior
+
+import (
+	"github.com/mutagen-io/mutagen/pkg/filesystem"
+	"github.com/mutagen-io/mutagen/pkg/filesystem/behavior/internal/format"
+)
+
+// probeCaseInsensitivityFastByPath attempts to perform a fast case
+// insensitivity test by path, without probe files. The successfulness of the
+// test is indicated by the second return parameter.
+func probeCaseInsensitivityFastByPath(path string) (bool, bool) {
+	if f, err := format.QueryByPath(path); err != nil {
+		return false, false
+	} else {
+		return probeCaseInsensitivityFastByFormat(f)
+	}
+}
+
+// probeCaseInsensitivityFast attempts to perform a fast case insensitivity
+// test, without probe files. The successfulness of the test is indicated by the
+// second return parameter.
+func probeCaseInsensitivityFast(directory *filesystem.Directory) (bool, bool) {
+	if f, err := format.Query(directory); err != nil {
+		return false, false
+	} else {
+		return probeCaseInsensitivityFastByFormat(f)
+	}
+}
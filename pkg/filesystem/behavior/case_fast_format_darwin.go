@@ -0,0 +1,13 @@
+package behavior
+
+import (
+	"github.com/mutagen-io/mutagen/pkg/filesystem/behavior/internal/format"
+)
+
+// probeCaseInsensitivityFastByFormat checks if the specified format matches
+// well-known case sensitivity behavior. Both APFS and HFS+ volumes can be
+// formatted as either case-sensitive or case-insensitive, so there's no
+// well-known behavior for any format.
+func probeCaseInsensitivityFastByFormat(_ format.Format) (bool, bool) {
+	return false, false
+}
@@ -0,0 +1,18 @@
+package behavior
+
+import (
+	"github.com/mutagen-io/mutagen/pkg/filesystem/behavior/internal/format"
+)
+
+// probeCaseInsensitivityFastByFormat checks if the specified format matches
+// well-known case sensitivity behavior.
+func probeCaseInsensitivityFastByFormat(f format.Format) (bool, bool) {
+	switch f {
+	case format.FormatEXT:
+		return false, true
+	case format.FormatNFS:
+		return false, true
+	default:
+		return false, false
+	}
+}
@@ -0,0 +1,21 @@
+//go:build !windows && !darwin && !linux
+
+package behavior
+
+import (
+	"github.com/mutagen-io/mutagen/pkg/filesystem"
+)
+
+// probeCaseInsensitivityFastByPath attempts to perform a fast case
+// insensitivity test by path, without probe files. The successfulness of the
+// test is indicated by the second return parameter.
+func probeCaseInsensitivityFastByPath(path string) (bool, bool) {
+	return false, false
+}
+
+// probeCaseInsensitivityFast attempts to perform a fast case insensitivity
+// test, without probe files. The successfulness of the test is indicated by the
+// second return parameter.
+func probeCaseInsensitivityFast(directory *filesystem.Directory) (bool, bool) {
+	return false, false
+}
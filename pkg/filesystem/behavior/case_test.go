@@ -0,0 +1,72 @@
+package behavior
+
+import (
+	"os"
+	"runtime"
+	"testing"
+
+	"github.com/mutagen-io/mutagen/pkg/filesystem"
+)
+
+// TestIgnoresCaseByPathAssumedHomeDirectory tests assumed case insensitivity
+// behavior on the home directory.
+func TestIgnoresCaseByPathAssumedHomeDirectory(t *testing.T) {
+	// Compute the path to the user's home directory.
+	homeDirectory, err := os.UserHomeDir()
+	if err != nil {
+		t.Fatal("unable to compute home directory:", err)
+	}
+
+	// Query the assumed behavior of the home directory and ensure it matches
+	// what's expected.
+	expected := runtime.GOOS == "darwin" || runtime.GOOS == "windows"
+	if ignores, usedFiles, err := IgnoresCaseByPath(homeDirectory, ProbeMode_ProbeModeAssume); err != nil {
+		t.Fatal("unable to query case insensitivity:", err)
+	} else if usedFiles {
+		t.Error("probe files used for assumed behavior")
+	} else if ignores != expected {
+		t.Error("case insensitivity behavior does not match expected")
+	}
+}
+
+// TestIgnoresCaseTemporaryDirectoryLinux tests that probing (both by path and
+// by directory) reports case sensitivity for a temporary directory on Linux
+// and that no probe files are left behind.
+func TestIgnoresCaseTemporaryDirectoryLinux(t *testing.T) {
+	// If we're not on Linux, then skip this test, since the behavior of the
+	// temporary directory isn't well-known on other platforms.
+	if runtime.GOOS != "linux" {
+		t.Skip()
+	}
+
+	// Create a temporary directory.
+	path := t.TempDir()
+
+	// Probe by path.
+	if ignores, _, err := IgnoresCaseByPath(path, ProbeMode_ProbeModeProbe); err != nil {
+		t.Fatal("unable to probe case insensitivity by path:", err)
+	} else if ignores {
+		t.Error("case insensitivity behavior by path does not match expected")
+	}
+
+	// Open the directory and defer its closure.
+	directory, _, err := filesystem.OpenDirectory(path, false)
+	if err != nil {
+		t.Fatal("unable to open directory:", err)
+	}
+	defer directory.Close()
+
+	// Probe by directory.
+	if ignores, _, err := IgnoresCase(directory, ProbeMode_ProbeModeProbe); err != nil {
+		t.Fatal("unable to probe case insensitivity:", err)
+	} else if ignores {
+		t.Error("case insensitivity behavior does not match expected")
+	}
+
+	// Verify that no probe files remain.
+	if names, err := directory.ReadContentNames(); err != nil {
+		t.Fatal("unable to read directory contents:", err)
+	} else if len(names) != 0 {
+		t.Error("probe files remain after probing:", names)
+	}
+}
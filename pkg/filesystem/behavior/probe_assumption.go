@@ -0,0 +1,86 @@
+package behavior
+
+import (
+	"fmt"
+)
+
+// IsDefault indicates whether or not the probe assumption is
+// ProbeAssumption_ProbeAssumptionDefault.
+func (a ProbeAssumption) IsDefault() bool {
+	return a == ProbeAssumption_ProbeAssumptionDefault
+}
+
+// MarshalText implements encoding.TextMarshaler.MarshalText.
+func (a ProbeAssumption) MarshalText() ([]byte, error) {
+	var result string
+	switch a {
+	case ProbeAssumption_ProbeAssumptionDefault:
+	case ProbeAssumption_ProbeAssumptionTrue:
+		result = "true"
+	case ProbeAssumption_ProbeAssumptionFalse:
+		result = "false"
+	default:
+		result = "unknown"
+	}
+	return []byte(result), nil
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler.UnmarshalText.
+func (a *ProbeAssumption) UnmarshalText(textBytes []byte) error {
+	// Convert the bytes to a string.
+	text := string(textBytes)
+
+	// Convert to a probe assumption.
+	switch text {
+	case "true":
+		*a = ProbeAssumption_ProbeAssumptionTrue
+	case "false":
+		*a = ProbeAssumption_ProbeAssumptionFalse
+	default:
+		return fmt.Errorf("unknown probe assumption specification: %s", text)
+	}
+
+	// Success.
+	return nil
+}
+
+// Supported indicates whether or not a particular probe assumption is a valid,
+// non-default value.
+func (a ProbeAssumption) Supported() bool {
+	switch a {
+	case ProbeAssumption_ProbeAssumptionTrue:
+		return true
+	case ProbeAssumption_ProbeAssumptionFalse:
+		return true
+	default:
+		return false
+	}
+}
+
+// Description returns a human-readable description of a probe assumption.
+func (a ProbeAssumption) Description() string {
+	switch a {
+	case ProbeAssumption_ProbeAssumptionDefault:
+		return "Default"
+	case ProbeAssumption_ProbeAssumptionTrue:
+		return "True"
+	case ProbeAssumption_ProbeAssumptionFalse:
+		return "False"
+	default:
+		return "Unknown"
+	}
+}
+
+// Assumed returns the assumed behavior value and whether or not an assumption
+// is actually specified. It returns false for both values if the assumption is
+// a default or unsupported value.
+func (a ProbeAssumption) Assumed() (bool, bool) {
+	switch a {
+	case ProbeAssumption_ProbeAssumptionTrue:
+		return true, true
+	case ProbeAssumption_ProbeAssumptionFalse:
+		return false, true
+	default:
+		return false, false
+	}
+}
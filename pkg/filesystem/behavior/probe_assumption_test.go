@@ -0,0 +1,121 @@
+package behavior
+
+import (
+	"testing"
+)
+
+// TestProbeAssumptionUnmarshal tests that unmarshaling from a string
+// specification succeeeds for ProbeAssumption.
+func TestProbeAssumptionUnmarshal(t *testing.T) {
+	// Set up test cases.
+	testCases := []struct {
+		text               string
+		expectedAssumption ProbeAssumption
+		expectFailure      bool
+	}{
+		{"", ProbeAssumption_ProbeAssumptionDefault, true},
+		{"asdf", ProbeAssumption_ProbeAssumptionDefault, true},
+		{"true", ProbeAssumption_ProbeAssumptionTrue, false},
+		{"false", ProbeAssumption_ProbeAssumptionFalse, false},
+	}
+
+	// Process test cases.
+	for _, testCase := range testCases {
+		var assumption ProbeAssumption
+		if err := assumption.UnmarshalText([]byte(testCase.text)); err != nil {
+			if !testCase.expectFailure {
+				t.Errorf("unable to unmarshal text (%s): %s", testCase.text, err)
+			}
+		} else if testCase.expectFailure {
+			t.Error("unmarshaling succeeded unexpectedly for text:", testCase.text)
+		} else if assumption != testCase.expectedAssumption {
+			t.Errorf(
+				"unmarshaled assumption (%s) does not match expected (%s)",
+				assumption,
+				testCase.expectedAssumption,
+			)
+		}
+	}
+}
+
+// TestProbeAssumptionSupported tests that ProbeAssumption support detection
+// works as expected.
+func TestProbeAssumptionSupported(t *testing.T) {
+	// Set up test cases.
+	testCases := []struct {
+		assumption      ProbeAssumption
+		expectSupported bool
+	}{
+		{ProbeAssumption_ProbeAssumptionDefault, false},
+		{ProbeAssumption_ProbeAssumptionTrue, true},
+		{ProbeAssumption_ProbeAssumptionFalse, true},
+		{(ProbeAssumption_ProbeAssumptionFalse + 1), false},
+	}
+
+	// Process test cases.
+	for _, testCase := range testCases {
+		if supported := testCase.assumption.Supported(); supported != testCase.expectSupported {
+			t.Errorf(
+				"assumption support status (%t) does not match expected (%t)",
+				supported,
+				testCase.expectSupported,
+			)
+		}
+	}
+}
+
+// TestProbeAssumptionDescription tests that ProbeAssumption description
+// generation works as expected.
+func TestProbeAssumptionDescription(t *testing.T) {
+	// Set up test cases.
+	testCases := []struct {
+		assumption          ProbeAssumption
+		expectedDescription string
+	}{
+		{ProbeAssumption_ProbeAssumptionDefault, "Default"},
+		{ProbeAssumption_ProbeAssumptionTrue, "True"},
+		{ProbeAssumption_ProbeAssumptionFalse, "False"},
+		{(ProbeAssumption_ProbeAssumptionFalse + 1), "Unknown"},
+	}
+
+	// Process test cases.
+	for _, testCase := range testCases {
+		if description := testCase.assumption.Description(); description != testCase.expectedDescription {
+			t.Errorf(
+				"assumption description (%s) does not match expected (%s)",
+				description,
+				testCase.expectedDescription,
+			)
+		}
+	}
+}
+
+// TestProbeAssumptionAssumed tests that ProbeAssumption value extraction works
+// as expected.
+func TestProbeAssumptionAssumed(t *testing.T) {
+	// Set up test cases.
+	testCases := []struct {
+		assumption    ProbeAssumption
+		expectedValue bool
+		expectAssumed bool
+	}{
+		{ProbeAssumption_ProbeAssumptionDefault, false, false},
+		{ProbeAssumption_ProbeAssumptionTrue, true, true},
+		{ProbeAssumption_ProbeAssumptionFalse, false, true},
+		{(ProbeAssumption_ProbeAssumptionFalse + 1), false, false},
+	}
+
+	// Process test cases.
+	for i, testCase := range testCases {
+		value, assumed := testCase.assumption.Assumed()
+		if assumed != testCase.expectAssumed {
+			t.Errorf("test index %d: assumption presence (%t) does not match expected (%t)",
+				i, assumed, testCase.expectAssumed,
+			)
+		} else if value != testCase.expectedValue {
+			t.Errorf("test index %d: assumed value (%t) does not match expected (%t)",
+				i, value, testCase.expectedValue,
+			)
+		}
+	}
+}
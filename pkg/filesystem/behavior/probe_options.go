@@ -0,0 +1,24 @@
+package behavior
+
+// ProbeOptions specifies optional adjustments to filesystem behavior probing.
+// The zero value corresponds to unadjusted probing behavior.
+type ProbeOptions struct {
+	// Directory is an alternate directory in which probe files should be
+	// created. If empty, then probe files are created in the directory being
+	// probed. Since probe results are only meaningful for the filesystem on
+	// which probe files are created, this directory must reside on the same
+	// filesystem as the directory being probed.
+	Directory string
+	// ExecutabilityPreservation is an explicitly stated assumption about
+	// executability preservation behavior. If specified, then executability
+	// preservation probing is skipped entirely.
+	ExecutabilityPreservation ProbeAssumption
+	// UnicodeDecomposition is an explicitly stated assumption about Unicode
+	// decomposition behavior. If specified, then Unicode decomposition probing
+	// is skipped entirely.
+	UnicodeDecomposition ProbeAssumption
+	// CaseInsensitivity is an explicitly stated assumption about case
+	// insensitivity behavior. If specified, then case insensitivity probing is
+	// skipped entirely.
+	CaseInsensitivity ProbeAssumption
+}
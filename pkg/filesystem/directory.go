@@ -1,10 +1,15 @@
 package filesystem
 
 import (
+	"errors"
 	"fmt"
 	"os"
 )
 
+// ErrExchangeUnsupported indicates that atomic exchange operations are not
+// supported on the current platform or by the underlying filesystem.
+var ErrExchangeUnsupported = errors.New("atomic exchange not supported")
+
 // DirectoryContentsByPath returns the contents of the directory at the
 // specified path. The ordering of the contents is non-deterministic.
 func DirectoryContentsByPath(path string) ([]os.FileInfo, error) {
@@ -585,6 +585,22 @@ func Rename(
 	)
 }
 
+// Exchange performs an atomic exchange of two filesystem locations (specified by
+// path), both of which must exist. If atomic exchange isn't supported on the
+// current platform or by the underlying filesystem, then ErrExchangeUnsupported
+// is returned.
+//
+// This function does not support cross-device exchanges. To detect whether or
+// not an error is due to an attempted cross-device exchange, use the
+// IsCrossDeviceError function.
+func Exchange(firstPath, secondPath string) error {
+	err := renameatExchangeRetryingOnEINTR(unix.AT_FDCWD, firstPath, unix.AT_FDCWD, secondPath)
+	if err == unix.ENOTSUP || err == unix.ENOSYS {
+		return ErrExchangeUnsupported
+	}
+	return err
+}
+
 // IsCrossDeviceError checks whether or not an error returned from rename
 // represents a cross-device error.
 func IsCrossDeviceError(err error) bool {
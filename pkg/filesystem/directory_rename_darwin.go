@@ -4,6 +4,10 @@ import (
 	"golang.org/x/sys/unix"
 )
 
+// ExchangeSupported indicates whether or not the platform supports atomic
+// exchange operations via Exchange.
+const ExchangeSupported = true
+
 // renameatNoReplaceRetryingOnEINTR is a wrapper around platform-specific
 // renameat variants that can perform a renameat operation that fails (with
 // EEXIST) if the target already exists. It returns ENOTSUP if the functionality
@@ -19,3 +23,19 @@ func renameatNoReplaceRetryingOnEINTR(oldDirectory int, oldPath string, newDirec
 		return err
 	}
 }
+
+// renameatExchangeRetryingOnEINTR is a wrapper around platform-specific
+// renameat variants that can atomically exchange two filesystem locations, both
+// of which must exist. It returns ENOTSUP if the functionality is not supported
+// on the target filesystem and ENOSYS if the functionality is not supported on
+// the platform as a whole. It retries on EINTR errors and returns on the first
+// successful call or non-EINTR error.
+func renameatExchangeRetryingOnEINTR(oldDirectory int, oldPath string, newDirectory int, newPath string) error {
+	for {
+		err := unix.RenameatxNp(oldDirectory, oldPath, newDirectory, newPath, unix.RENAME_SWAP)
+		if err == unix.EINTR {
+			continue
+		}
+		return err
+	}
+}
@@ -9,6 +9,10 @@ import (
 // renameat2FailedWithENOSYS tracks if renameat2 previously failed with ENOSYS.
 var renameat2FailedWithENOSYS state.Marker
 
+// ExchangeSupported indicates whether or not the platform supports atomic
+// exchange operations via Exchange.
+const ExchangeSupported = true
+
 // renameatNoReplaceRetryingOnEINTR is a wrapper around platform-specific
 // renameat variants that can perform a renameat operation that fails (with
 // EEXIST) if the target already exists. It returns ENOTSUP if the functionality
@@ -44,3 +48,32 @@ func renameatNoReplaceRetryingOnEINTR(oldDirectory int, oldPath string, newDirec
 		return err
 	}
 }
+
+// renameatExchangeRetryingOnEINTR is a wrapper around platform-specific
+// renameat variants that can atomically exchange two filesystem locations, both
+// of which must exist. It returns ENOTSUP if the functionality is not supported
+// on the target filesystem and ENOSYS if the functionality is not supported on
+// the platform as a whole. It retries on EINTR errors and returns on the first
+// successful call or non-EINTR error.
+func renameatExchangeRetryingOnEINTR(oldDirectory int, oldPath string, newDirectory int, newPath string) error {
+	// If renameat2 is known to be unavailable, then return immediately.
+	if renameat2FailedWithENOSYS.Marked() {
+		return unix.ENOSYS
+	}
+
+	// Loop until renameat2 completes with a return value other that EINTR.
+	for {
+		err := unix.Renameat2(oldDirectory, oldPath, newDirectory, newPath, unix.RENAME_EXCHANGE)
+		if err == unix.EINTR {
+			continue
+		} else if err == unix.EINVAL {
+			// HACK: As with RENAME_NOREPLACE, using RENAME_EXCHANGE with a
+			// target filesystem that doesn't support it will yield EINVAL, so
+			// we alias this case to ENOTSUP.
+			return unix.ENOTSUP
+		} else if err == unix.ENOSYS {
+			renameat2FailedWithENOSYS.Mark()
+		}
+		return err
+	}
+}
@@ -6,6 +6,10 @@ import (
 	"golang.org/x/sys/unix"
 )
 
+// ExchangeSupported indicates whether or not the platform supports atomic
+// exchange operations via Exchange.
+const ExchangeSupported = false
+
 // renameatNoReplaceRetryingOnEINTR is a wrapper around platform-specific
 // renameat variants that can perform a renameat operation that fails (with
 // EEXIST) if the target already exists. It returns ENOTSUP if the functionality
@@ -15,3 +19,13 @@ import (
 func renameatNoReplaceRetryingOnEINTR(_ int, _ string, _ int, _ string) error {
 	return unix.ENOSYS
 }
+
+// renameatExchangeRetryingOnEINTR is a wrapper around platform-specific
+// renameat variants that can atomically exchange two filesystem locations, both
+// of which must exist. It returns ENOTSUP if the functionality is not supported
+// on the target filesystem and ENOSYS if the functionality is not supported on
+// the platform as a whole. It retries on EINTR errors and returns on the first
+// successful call or non-EINTR error.
+func renameatExchangeRetryingOnEINTR(_ int, _ string, _ int, _ string) error {
+	return unix.ENOSYS
+}
@@ -128,3 +128,41 @@ func TestDirectorySymbolicLinkRemoval(t *testing.T) {
 		t.Error("unable to read target content metadata:", err)
 	}
 }
+
+// TestExchange tests that Exchange atomically swaps two non-empty directories.
+func TestExchange(t *testing.T) {
+	// Create two directories with distinct contents.
+	parent := t.TempDir()
+	first := filepath.Join(parent, "first")
+	second := filepath.Join(parent, "second")
+	for _, path := range []string{first, second} {
+		if err := os.Mkdir(path, 0700); err != nil {
+			t.Fatal("unable to create directory:", err)
+		} else if err = os.WriteFile(filepath.Join(path, "name"), []byte(filepath.Base(path)), 0600); err != nil {
+			t.Fatal("unable to create directory content:", err)
+		}
+	}
+
+	// Perform the exchange, skipping the test if exchange isn't supported.
+	// Note that we can't require support on platforms that nominally support
+	// exchange because the underlying filesystem might not.
+	if err := Exchange(first, second); err == ErrExchangeUnsupported {
+		t.Skip("atomic exchange not supported")
+	} else if err != nil {
+		t.Fatal("unable to perform exchange:", err)
+	} else if !ExchangeSupported {
+		t.Error("exchange succeeded on platform that shouldn't support it")
+	}
+
+	// Verify that the contents were exchanged.
+	if content, err := os.ReadFile(filepath.Join(first, "name")); err != nil {
+		t.Fatal("unable to read exchanged content:", err)
+	} else if string(content) != "second" {
+		t.Error("first directory content does not match expected:", string(content))
+	}
+	if content, err := os.ReadFile(filepath.Join(second, "name")); err != nil {
+		t.Fatal("unable to read exchanged content:", err)
+	} else if string(content) != "first" {
+		t.Error("second directory content does not match expected:", string(content))
+	}
+}
@@ -551,6 +551,17 @@ const (
 	_ERROR_NOT_SAME_DEVICE = 0x11
 )
 
+// ExchangeSupported indicates whether or not the platform supports atomic
+// exchange operations via Exchange.
+const ExchangeSupported = false
+
+// Exchange performs an atomic exchange of two filesystem locations (specified by
+// path), both of which must exist. Windows doesn't support atomic exchange, so
+// this function always returns ErrExchangeUnsupported.
+func Exchange(_, _ string) error {
+	return ErrExchangeUnsupported
+}
+
 // IsCrossDeviceError checks whether or not an error returned from rename
 // represents a cross-device error.
 func IsCrossDeviceError(err error) bool {
@@ -6,6 +6,11 @@ import (
 	"os"
 )
 
+// ErrLocked indicates that a non-blocking lock acquisition failed because the
+// lock is held by another process. Errors returned by Lock will wrap this error
+// (as well as the underlying system error) in that case.
+var ErrLocked = errors.New("lock held by another process")
+
 // Locker provides file locking facilities.
 type Locker struct {
 	// file is the underlying file object that's locked.
@@ -8,6 +8,7 @@ package locking
 
 import (
 	"errors"
+	"fmt"
 	"os"
 
 	"golang.org/x/sys/unix"
@@ -53,7 +54,13 @@ func (l *Locker) Lock(block bool) error {
 	// F_SETLK if it occurs before the lock is checked or acquired. Given that
 	// Go's runtime preemption can also cause spurious interrupts, it's best to
 	// handle EINTR in all cases.
+	//
+	// POSIX allows either EAGAIN or EACCES to indicate contention when using
+	// F_SETLK, so we treat both as indicating that the lock is held elsewhere.
 	if err := fcntlFlockRetryingOnEINTR(l.file.Fd(), operation, &lockSpec); err != nil {
+		if err == unix.EAGAIN || err == unix.EACCES {
+			return fmt.Errorf("%w: %w", ErrLocked, err)
+		}
 		return err
 	}
 
@@ -1,11 +1,17 @@
 package locking
 
 import (
+	"bufio"
 	"bytes"
+	"errors"
+	"io"
 	"os"
 	"os/exec"
+	"path/filepath"
+	"strconv"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/mutagen-io/mutagen/pkg/mutagen"
 )
@@ -20,6 +26,9 @@ const (
 	// but "go run" doesn't forward them and different systems might handle them
 	// differently.
 	lockerTestFailMessage = "lock acquisition failed"
+	// lockerTestHoldLockedMessage is the sentinel message printed by the test
+	// executable once it has acquired the lock in hold mode.
+	lockerTestHoldLockedMessage = "locked"
 )
 
 // TestLockerFailOnDirectory tests that a locker creation fails for a directory.
@@ -110,3 +119,174 @@ func TestLockDuplicateFail(t *testing.T) {
 		t.Error("test command error output did not contain failure message", errorBuffer.String())
 	}
 }
+
+// TestLockerOwnerRecord tests owner recording and loading.
+func TestLockerOwnerRecord(t *testing.T) {
+	// Create a locker for a new lock file and defer its closure.
+	locker, err := NewLocker(filepath.Join(t.TempDir(), "lock"), 0600)
+	if err != nil {
+		t.Fatal("unable to create locker:", err)
+	}
+	defer locker.Close()
+
+	// Verify that no owner is recorded initially.
+	if owner, err := locker.Owner(); err != nil {
+		t.Fatal("unable to load owner:", err)
+	} else if owner != 0 {
+		t.Error("owner unexpectedly recorded:", owner)
+	}
+
+	// Verify that owner recording fails if the lock isn't held.
+	if err := locker.RecordOwner(); err == nil {
+		t.Error("owner recording succeeded without lock held")
+	}
+
+	// Acquire the lock, record ownership, and verify the owner.
+	if err := locker.Lock(false); err != nil {
+		t.Fatal("unable to acquire lock:", err)
+	} else if err = locker.RecordOwner(); err != nil {
+		t.Fatal("unable to record owner:", err)
+	}
+	if owner, err := locker.Owner(); err != nil {
+		t.Fatal("unable to load owner:", err)
+	} else if owner != os.Getpid() {
+		t.Error("recorded owner does not match current process:", owner, "!=", os.Getpid())
+	}
+
+	// Release the lock.
+	if err := locker.Unlock(); err != nil {
+		t.Fatal("unable to release lock:", err)
+	}
+}
+
+// startLockHolder starts the test executable in hold mode for the specified
+// lock path and owner specification. It waits for the lock to be acquired and
+// returns a function that signals the holder to release the lock and waits for
+// it to exit.
+func startLockHolder(t *testing.T, path, owner string) func() {
+	// Compute the path to the Mutagen source tree.
+	mutagenSourcePath, err := mutagen.SourceTreePath()
+	if err != nil {
+		t.Fatal("unable to compute path to Mutagen source tree:", err)
+	}
+
+	// Start the holder.
+	holder := exec.Command("go", "run", lockerTestExecutablePackage, path, "hold", owner)
+	holder.Dir = mutagenSourcePath
+	holder.Stderr = os.Stderr
+	input, err := holder.StdinPipe()
+	if err != nil {
+		t.Fatal("unable to create holder input pipe:", err)
+	}
+	output, err := holder.StdoutPipe()
+	if err != nil {
+		t.Fatal("unable to create holder output pipe:", err)
+	}
+	if err := holder.Start(); err != nil {
+		t.Fatal("unable to start holder:", err)
+	}
+
+	// Wait for the holder to acquire the lock.
+	scanner := bufio.NewScanner(output)
+	if !scanner.Scan() || scanner.Text() != lockerTestHoldLockedMessage {
+		input.Close()
+		holder.Wait()
+		t.Fatal("holder failed to acquire lock")
+	}
+	go io.Copy(io.Discard, output)
+
+	// Create the release function.
+	return func() {
+		input.Close()
+		if err := holder.Wait(); err != nil {
+			t.Error("holder failed:", err)
+		}
+	}
+}
+
+// TestLockRecoveringStaleLiveHolder tests that LockRecoveringStale refuses to
+// recover a lock whose recorded owner is still running.
+func TestLockRecoveringStaleLiveHolder(t *testing.T) {
+	// Start a holder that records itself as the owner and defer its release.
+	path := filepath.Join(t.TempDir(), "lock")
+	release := startLockHolder(t, path, "self")
+	defer release()
+
+	// Create a locker and defer its closure.
+	locker, err := NewLocker(path, 0600)
+	if err != nil {
+		t.Fatal("unable to create locker:", err)
+	}
+	defer locker.Close()
+
+	// Verify that lock acquisition fails immediately, without waiting for the
+	// recovery timeout.
+	start := time.Now()
+	if err := locker.LockRecoveringStale(time.Minute); err == nil {
+		t.Fatal("live lock acquired")
+	} else if !errors.Is(err, ErrLocked) {
+		t.Error("lock acquisition failed with unexpected error:", err)
+	}
+	if time.Since(start) >= time.Minute {
+		t.Error("lock acquisition waited for recovery timeout")
+	}
+	if locker.Held() {
+		t.Error("lock incorrectly reported as held")
+	}
+}
+
+// TestLockRecoveringStaleStaleHolder tests that LockRecoveringStale recovers a
+// lock whose recorded owner has terminated once the lock is released, and that
+// it gives up if the lock isn't released within the recovery timeout.
+func TestLockRecoveringStaleStaleHolder(t *testing.T) {
+	// Run a short-lived process to obtain the identifier of a terminated
+	// process.
+	terminated := exec.Command("go", "version")
+	if err := terminated.Run(); err != nil {
+		t.Fatal("unable to run short-lived process:", err)
+	}
+	owner := strconv.Itoa(terminated.Process.Pid)
+
+	// Start a holder that records the terminated process as the owner,
+	// simulating a lock that the system has yet to release.
+	path := filepath.Join(t.TempDir(), "lock")
+	release := startLockHolder(t, path, owner)
+
+	// Create a locker and defer its closure.
+	locker, err := NewLocker(path, 0600)
+	if err != nil {
+		release()
+		t.Fatal("unable to create locker:", err)
+	}
+	defer locker.Close()
+
+	// Verify that recovery fails if the lock isn't released in time.
+	if err := locker.LockRecoveringStale(250 * time.Millisecond); err == nil {
+		release()
+		t.Fatal("stale lock acquired while held")
+	} else if !errors.Is(err, ErrLocked) {
+		t.Error("lock acquisition failed with unexpected error:", err)
+	}
+
+	// Start recovery with a longer timeout, release the stale lock, and verify
+	// that recovery succeeds and records the new owner.
+	results := make(chan error, 1)
+	go func() {
+		results <- locker.LockRecoveringStale(time.Minute)
+	}()
+	time.Sleep(250 * time.Millisecond)
+	release()
+	if err := <-results; err != nil {
+		t.Fatal("unable to recover stale lock:", err)
+	}
+	if recorded, err := locker.Owner(); err != nil {
+		t.Error("unable to load owner:", err)
+	} else if recorded != os.Getpid() {
+		t.Error("recovered lock has incorrect owner:", recorded)
+	}
+
+	// Release the lock.
+	if err := locker.Unlock(); err != nil {
+		t.Fatal("unable to release lock:", err)
+	}
+}
@@ -41,6 +41,7 @@ package locking
 
 import (
 	"errors"
+	"fmt"
 	"syscall"
 	"unsafe"
 
@@ -132,9 +133,12 @@ func (l *Locker) Lock(block bool) error {
 	// Attempt to perform locking.
 	err := callLockFileEx(syscall.Handle(l.file.Fd()), flags, 0, 1, 0, &ol)
 
-	// Check for success and update the internal state.
+	// Check for success and update the internal state. If locking failed due
+	// to contention, then wrap the error accordingly.
 	if err == nil {
 		l.held = true
+	} else if err == windows.ERROR_LOCK_VIOLATION {
+		err = fmt.Errorf("%w: %w", ErrLocked, err)
 	}
 
 	// Done.
@@ -3,15 +3,23 @@ package main
 import (
 	"errors"
 	"fmt"
+	"io"
 	"os"
+	"strconv"
 
 	"github.com/mutagen-io/mutagen/cmd"
 	"github.com/mutagen-io/mutagen/pkg/filesystem/locking"
 )
 
+// holdLockedMessage is the sentinel message printed to standard output once
+// the lock has been acquired in hold mode.
+const holdLockedMessage = "locked"
+
 func main() {
-	// Validate arguments and extract the lock path.
-	if len(os.Args) != 2 {
+	// Validate arguments and extract the lock path. In addition to the lock
+	// path, hold mode accepts a "hold" argument and an owner specification
+	// (either "self" or a process identifier to record as the owner).
+	if len(os.Args) != 2 && !(len(os.Args) == 4 && os.Args[2] == "hold") {
 		cmd.Fatal(errors.New("invalid number of arguments"))
 	} else if os.Args[1] == "" {
 		cmd.Fatal(errors.New("empty lock path"))
@@ -19,11 +27,33 @@ func main() {
 	path := os.Args[1]
 
 	// Create a locker and attempt to acquire the lock.
-	if locker, err := locking.NewLocker(path, 0600); err != nil {
+	locker, err := locking.NewLocker(path, 0600)
+	if err != nil {
 		cmd.Fatal(errors.New("unable to create filesystem locker"))
 	} else if err = locker.Lock(false); err != nil {
 		cmd.Fatal(fmt.Errorf("lock acquisition failed: %w", err))
-	} else if err = locker.Unlock(); err != nil {
+	}
+
+	// If we're in hold mode, then record the owner, signal that the lock is
+	// held, and wait for standard input to be closed before releasing it.
+	if len(os.Args) == 4 {
+		if owner := os.Args[3]; owner == "self" {
+			if err := locker.RecordOwner(); err != nil {
+				cmd.Fatal(fmt.Errorf("unable to record owner: %w", err))
+			}
+		} else if _, err := strconv.Atoi(owner); err != nil {
+			cmd.Fatal(errors.New("invalid owner specification"))
+		} else if err = locker.Truncate(0); err != nil {
+			cmd.Fatal(fmt.Errorf("unable to truncate lock file: %w", err))
+		} else if _, err = locker.Write([]byte("\n" + owner)); err != nil {
+			cmd.Fatal(fmt.Errorf("unable to write owner record: %w", err))
+		}
+		fmt.Println(holdLockedMessage)
+		io.Copy(io.Discard, os.Stdin)
+	}
+
+	// Release the lock.
+	if err = locker.Unlock(); err != nil {
 		cmd.Fatal(fmt.Errorf("lock release failed: %w", err))
 	} else if err = locker.Close(); err != nil {
 		cmd.Fatal(fmt.Errorf("locker closure failed: %w", err))
@@ -0,0 +1,121 @@
+package locking
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	// ownerRecordOffset is the offset within the lock file at which the owner
+	// record is stored. The record is offset by a single (newline) byte
+	// because Windows byte-range locks (which cover the first byte of the lock
+	// file) are mandatory and would prevent other processes from reading the
+	// record.
+	ownerRecordOffset = 1
+	// maximumOwnerRecordSize is the maximum number of bytes that will be read
+	// when loading an owner record from a lock file.
+	maximumOwnerRecordSize = 32
+	// staleLockRetryInterval is the interval at which lock acquisition will be
+	// retried while waiting for a stale lock to be released.
+	staleLockRetryInterval = 100 * time.Millisecond
+)
+
+// RecordOwner records the current process as the owner of the lock by replacing
+// the contents of the lock file with a newline followed by the decimal process
+// identifier of the current process. The lock must be held. Owner records are
+// purely advisory and are only used to detect stale locks.
+func (l *Locker) RecordOwner() error {
+	// Verify that the lock is held.
+	if !l.held {
+		return errors.New("lock not held")
+	}
+
+	// Replace the lock file contents. Since the file is opened in append mode,
+	// truncation is sufficient to reset the write position.
+	if err := l.file.Truncate(0); err != nil {
+		return fmt.Errorf("unable to truncate lock file: %w", err)
+	} else if _, err = l.file.Write([]byte("\n" + strconv.Itoa(os.Getpid()))); err != nil {
+		return fmt.Errorf("unable to write owner record: %w", err)
+	}
+
+	// Success.
+	return nil
+}
+
+// Owner returns the process identifier recorded in the lock file by the most
+// recent call to RecordOwner (potentially by another process). It does not
+// require that the lock be held. If no valid owner record is present, then it
+// returns 0 and a nil error.
+func (l *Locker) Owner() (int, error) {
+	// Read the owner record.
+	buffer := make([]byte, maximumOwnerRecordSize)
+	count, err := l.file.ReadAt(buffer, ownerRecordOffset)
+	if err != nil && count == 0 && !errors.Is(err, io.EOF) {
+		return 0, fmt.Errorf("unable to read owner record: %w", err)
+	}
+
+	// Parse the owner record, treating malformed records as absent.
+	owner, err := strconv.Atoi(strings.TrimSpace(string(buffer[:count])))
+	if err != nil || owner <= 0 {
+		return 0, nil
+	}
+
+	// Success.
+	return owner, nil
+}
+
+// LockRecoveringStale attempts to acquire the lock without blocking and, on
+// success, records the current process as the lock owner. If the lock is held
+// by another process, then the lock's owner record is used to determine whether
+// or not the lock is stale. A lock is considered stale only if it has an owner
+// record and the recorded owner process no longer exists. Since locks are
+// released automatically when their holding process exits, a stale lock can
+// only persist if the system has yet to release it (e.g. on Windows, where
+// release may be deferred after process termination, or on network filesystems,
+// where release may require server-side lock recovery). In that case, lock
+// acquisition is retried for up to the specified timeout. If the lock has no
+// owner record or its recorded owner still exists, then the lock is considered
+// live and an error wrapping ErrLocked is returned immediately. A live lock is
+// never broken, even if its owner appears to be unresponsive.
+func (l *Locker) LockRecoveringStale(timeout time.Duration) error {
+	// Compute the deadline for recovery.
+	deadline := time.Now().Add(timeout)
+
+	// Loop until we acquire the lock or determine that we can't.
+	for {
+		// Attempt to acquire the lock. If we succeed, then record ownership.
+		err := l.Lock(false)
+		if err == nil {
+			if err := l.RecordOwner(); err != nil {
+				l.Unlock()
+				return err
+			}
+			return nil
+		} else if !errors.Is(err, ErrLocked) {
+			return err
+		}
+
+		// Determine whether or not the lock is stale. If we can't make that
+		// determination, then we have to assume that the lock is live.
+		owner, ownerErr := l.Owner()
+		if ownerErr != nil {
+			return fmt.Errorf("%w (unable to determine owner: %v)", err, ownerErr)
+		} else if owner == 0 {
+			return err
+		} else if processExists(owner) {
+			return fmt.Errorf("%w (owner process %d is running)", err, owner)
+		}
+
+		// The lock is stale. If we've exhausted our recovery timeout, then
+		// we're done, otherwise wait and retry.
+		if time.Now().After(deadline) {
+			return fmt.Errorf("%w (stale lock from terminated process %d could not be recovered)", err, owner)
+		}
+		time.Sleep(staleLockRetryInterval)
+	}
+}
@@ -0,0 +1,17 @@
+//go:build !windows && !plan9
+
+package locking
+
+import (
+	"golang.org/x/sys/unix"
+)
+
+// processExists determines whether or not a process with the specified process
+// identifier exists. It errs on the side of reporting existence if the process
+// can't be queried.
+func processExists(pid int) bool {
+	// Send a null signal to the process. A permission error indicates that the
+	// process exists but is owned by another user.
+	err := unix.Kill(pid, 0)
+	return err == nil || err == unix.EPERM
+}
@@ -0,0 +1,35 @@
+package locking
+
+import (
+	"golang.org/x/sys/windows"
+)
+
+const (
+	// stillActive is the exit code reported by GetExitCodeProcess for processes
+	// that have yet to terminate (STILL_ACTIVE).
+	stillActive = 259
+)
+
+// processExists determines whether or not a process with the specified process
+// identifier exists. It errs on the side of reporting existence if the process
+// can't be queried.
+func processExists(pid int) bool {
+	// Attempt to open the process. If the identifier doesn't correspond to any
+	// process, then we'll see ERROR_INVALID_PARAMETER. Any other error (e.g. an
+	// access denied error) indicates that the process exists.
+	process, err := windows.OpenProcess(windows.PROCESS_QUERY_LIMITED_INFORMATION, false, uint32(pid))
+	if err == windows.ERROR_INVALID_PARAMETER {
+		return false
+	} else if err != nil {
+		return true
+	}
+	defer windows.CloseHandle(process)
+
+	// Check whether or not the process has exited. Process objects can outlive
+	// their processes if other handles to them remain open.
+	var exitCode uint32
+	if err := windows.GetExitCodeProcess(process, &exitCode); err != nil {
+		return true
+	}
+	return exitCode == stillActive
+}
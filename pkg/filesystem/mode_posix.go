@@ -23,4 +23,8 @@ const (
 	ModeTypeFile = Mode(unix.S_IFREG)
 	// ModeTypeSymbolicLink represents a symbolic link.
 	ModeTypeSymbolicLink = Mode(unix.S_IFLNK)
+	// ModeTypeNamedPipe represents a named pipe (FIFO).
+	ModeTypeNamedPipe = Mode(unix.S_IFIFO)
+	// ModeTypeSocket represents a Unix domain socket.
+	ModeTypeSocket = Mode(unix.S_IFSOCK)
 )
@@ -20,4 +20,8 @@ const (
 	ModeTypeFile = Mode(0)
 	// ModeTypeSymbolicLink represents a symbolic link.
 	ModeTypeSymbolicLink = Mode(os.ModeSymlink)
+	// ModeTypeNamedPipe represents a named pipe.
+	ModeTypeNamedPipe = Mode(os.ModeNamedPipe)
+	// ModeTypeSocket represents a Unix domain socket.
+	ModeTypeSocket = Mode(os.ModeSocket)
 )
@@ -51,6 +51,16 @@ const (
 	// directory.
 	MutagenSynchronizationStagingDirectoryName = "staging"
 
+	// MutagenSynchronizationContentDirectoryName is the name of the
+	// synchronization content cache storage directory within the Mutagen data
+	// directory.
+	MutagenSynchronizationContentDirectoryName = "content"
+
+	// MutagenSynchronizationRecordingsDirectoryName is the name of the
+	// synchronization reconciliation recording storage directory within the
+	// Mutagen data directory.
+	MutagenSynchronizationRecordingsDirectoryName = "recordings"
+
 	// MutagenForwardingDirectoryName is the name of the forwarding data
 	// directory within the Mutagen data directory.
 	MutagenForwardingDirectoryName = "forwarding"
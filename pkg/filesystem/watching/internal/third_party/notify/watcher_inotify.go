@@ -79,17 +79,21 @@ type inotify struct {
 	buffer       [eventBufferSize]byte // inotify event buffer
 	wg           sync.WaitGroup        // wait group used to close main loop
 	c            chan<- EventInfo      // event dispatcher channel
+	overflow     chan<- struct{}       // overflow signaling channel
 }
 
-// NewWatcher creates new non-recursive inotify backed by inotify.
-func NewWatcher(c chan<- EventInfo) Watcher {
+// NewWatcher creates new non-recursive inotify backed by inotify. If events are
+// dropped because c is full, or if the kernel reports an inotify queue
+// overflow, then a non-blocking signal is sent on overflow.
+func NewWatcher(c chan<- EventInfo, overflow chan<- struct{}) Watcher {
 	i := &inotify{
-		m:      make(map[int32]*watched),
-		fd:     invalidDescriptor,
-		pipefd: []int{invalidDescriptor, invalidDescriptor},
-		epfd:   invalidDescriptor,
-		epes:   make([]unix.EpollEvent, 0),
-		c:      c,
+		m:        make(map[int32]*watched),
+		fd:       invalidDescriptor,
+		pipefd:   []int{invalidDescriptor, invalidDescriptor},
+		epfd:     invalidDescriptor,
+		epes:     make([]unix.EpollEvent, 0),
+		c:        c,
+		overflow: overflow,
 	}
 	runtime.SetFinalizer(i, func(i *inotify) {
 		i.epollclose()
@@ -282,6 +286,7 @@ func (i *inotify) send(esch <-chan []*event) {
 				select {
 				case i.c <- e:
 				default:
+					i.signalOverflow()
 				}
 			}
 		}
@@ -289,6 +294,14 @@ func (i *inotify) send(esch <-chan []*event) {
 	i.wg.Done()
 }
 
+// signalOverflow performs a non-blocking overflow signal.
+func (i *inotify) signalOverflow() {
+	select {
+	case i.overflow <- struct{}{}:
+	default:
+	}
+}
+
 // transform prepares events read from inotify file descriptor for sending to
 // user. It removes invalid events and these which are no longer present in
 // inotify map. This method may also split one raw event into two different ones
@@ -297,6 +310,9 @@ func (i *inotify) transform(es []*event) []*event {
 	var multi []*event
 	i.RLock()
 	for idx, e := range es {
+		if e.sys.Mask&unix.IN_Q_OVERFLOW != 0 {
+			i.signalOverflow()
+		}
 		if e.sys.Mask&(unix.IN_IGNORED|unix.IN_Q_OVERFLOW) != 0 {
 			es[idx] = nil
 			continue
@@ -114,8 +114,9 @@ type Watcher struct {
 	cookie   uint32
 }
 
-// NewWatcher creates and returns a Watcher.
-func NewWatcher() (*Watcher, error) {
+// NewWatcher creates and returns a Watcher. The capacity specifies the capacity
+// of the event channel.
+func NewWatcher(capacity int) (*Watcher, error) {
 	port, e := syscall.CreateIoCompletionPort(syscall.InvalidHandle, 0, 0, 0)
 	if e != nil {
 		return nil, os.NewSyscallError("CreateIoCompletionPort", e)
@@ -124,7 +125,7 @@ func NewWatcher() (*Watcher, error) {
 		port:    port,
 		watches: make(watchMap),
 		input:   make(chan *input, 1),
-		Event:   make(chan *Event, 50),
+		Event:   make(chan *Event, capacity),
 		Error:   make(chan error, 1),
 		quit:    make(chan chan<- error, 1),
 	}
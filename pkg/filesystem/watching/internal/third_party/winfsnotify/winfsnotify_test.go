@@ -72,7 +72,7 @@ func expect(t *testing.T, watcher *Watcher, name string, mask uint32) {
 }
 
 func TestNotifyEvents(t *testing.T) {
-	watcher, err := NewWatcher()
+	watcher, err := NewWatcher(50)
 	if err != nil {
 		t.Fatalf("NewWatcher() failed: %s", err)
 	}
@@ -141,7 +141,7 @@ func TestNotifyEvents(t *testing.T) {
 }
 
 func TestNotifyClose(t *testing.T) {
-	watcher, _ := NewWatcher()
+	watcher, _ := NewWatcher(50)
 	watcher.Close()
 
 	var done int32
@@ -176,7 +176,7 @@ func TestWatchLongPath(t *testing.T) {
 		t.Fatalf("MkdirAll(%s) failed: %s", path, err)
 	}
 
-	watcher, err := NewWatcher()
+	watcher, err := NewWatcher(50)
 	if err != nil {
 		t.Fatalf("NewWatcher() failed: %s", err)
 	}
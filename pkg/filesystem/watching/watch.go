@@ -4,6 +4,21 @@ import (
 	"errors"
 )
 
+// NOTE: Watcher constructors accept a queue size parameter that controls the
+// capacity of the internal queue used to buffer events from the underlying
+// watching mechanism. If this queue overflows, then watchers will fail with
+// ErrWatchInternalOverflow. Larger queues can better absorb bursty workloads at
+// the cost of memory. Not all watching mechanisms use an internal queue, in
+// which case the queue size is validated but otherwise ignored.
+
+// ensureQueueSizeValid verifies that a watcher queue size is valid.
+func ensureQueueSizeValid(queueSize int) error {
+	if queueSize <= 0 {
+		return errors.New("invalid queue size")
+	}
+	return nil
+}
+
 var (
 	// ErrWatchInternalOverflow indicates that a watcher saw an event buffering
 	// overflow in its underlying watching mechanism.
@@ -17,9 +17,6 @@ const (
 	// platform supports native non-recursive watching.
 	NonRecursiveWatchingSupported = true
 
-	// inotifyChannelCapacity is the capacity to use for the internal inotify
-	// events channel.
-	inotifyChannelCapacity = 50
 	// inotifyDefaultMaximumWatches is the default maximum number of inotify
 	// watches that will be allowed to exist per-watcher.
 	inotifyDefaultMaximumWatches = 50
@@ -43,16 +40,25 @@ type nonRecursiveWatcher struct {
 }
 
 // NewNonRecursiveWatcher creates a new inotify-based non-recursive watcher.
-func NewNonRecursiveWatcher() (NonRecursiveWatcher, error) {
-	// Create the raw event channel.
-	rawEvents := make(chan notify.EventInfo, inotifyChannelCapacity)
+// The queue size controls the capacity of the internal inotify event channel.
+// If this channel (or the kernel's inotify queue) overflows, then the watcher
+// will fail with ErrWatchInternalOverflow.
+func NewNonRecursiveWatcher(queueSize int) (NonRecursiveWatcher, error) {
+	// Validate the queue size.
+	if err := ensureQueueSizeValid(queueSize); err != nil {
+		return nil, err
+	}
+
+	// Create the raw event channel and the overflow signaling channel.
+	rawEvents := make(chan notify.EventInfo, queueSize)
+	overflow := make(chan struct{}, 1)
 
 	// Create a context to regulate the watcher's run loop.
 	ctx, cancel := context.WithCancel(context.Background())
 
 	// Create the watcher.
 	watcher := &nonRecursiveWatcher{
-		watch:   notify.NewWatcher(rawEvents),
+		watch:   notify.NewWatcher(rawEvents, overflow),
 		evictor: lru.New(inotifyDefaultMaximumWatches),
 		events:  make(chan string),
 		errors:  make(chan error, 1),
@@ -79,7 +85,7 @@ func NewNonRecursiveWatcher() (NonRecursiveWatcher, error) {
 	// Start the run loop.
 	go func() {
 		select {
-		case watcher.errors <- watcher.run(ctx, rawEvents):
+		case watcher.errors <- watcher.run(ctx, rawEvents, overflow):
 		default:
 		}
 		watcher.done.Done()
@@ -90,12 +96,14 @@ func NewNonRecursiveWatcher() (NonRecursiveWatcher, error) {
 }
 
 // run implements the event processing run loop for nonRecursiveWatcher.
-func (w *nonRecursiveWatcher) run(ctx context.Context, rawEvents <-chan notify.EventInfo) error {
-	// Loop indefinitely, polling for cancellation and events.
+func (w *nonRecursiveWatcher) run(ctx context.Context, rawEvents <-chan notify.EventInfo, overflow <-chan struct{}) error {
+	// Loop indefinitely, polling for cancellation, overflows, and events.
 	for {
 		select {
 		case <-ctx.Done():
 			return ErrWatchTerminated
+		case <-overflow:
+			return ErrWatchInternalOverflow
 		case e, ok := <-rawEvents:
 			// Ensure that the event channel wasn't closed.
 			if !ok {
@@ -105,6 +113,8 @@ func (w *nonRecursiveWatcher) run(ctx context.Context, rawEvents <-chan notify.E
 			// Transmit the path.
 			select {
 			case w.events <- e.Path():
+			case <-overflow:
+				return ErrWatchInternalOverflow
 			case <-ctx.Done():
 				return ErrWatchTerminated
 			}
@@ -11,6 +11,6 @@ const (
 // NewNonRecursiveWatcher creates a new non-recursive watcher on platforms that
 // support native non-recursive watching. This platform does not support
 // recursive watching and this function will panic if called.
-func NewNonRecursiveWatcher() (NonRecursiveWatcher, error) {
+func NewNonRecursiveWatcher(_ int) (NonRecursiveWatcher, error) {
 	panic("non-recursive watching not supported on this platform")
 }
@@ -19,12 +19,6 @@ const (
 	// supports native recursive watching.
 	RecursiveWatchingSupported = true
 
-	// fseventsChannelCapacity is the capacity to use for the internal FSEvents
-	// events channel. This doesn't need to be extremely large because (a) we
-	// service that channel as fast as the scheduler will allow and (b) FSEvents
-	// will perform event coalescing anyway, so each channel entry can store
-	// more than one event.
-	fseventsChannelCapacity = 50
 	// fseventsLatency is the internal latency (coalescing) parameter to use for
 	// FSEvents watches. Setting this to a non-zero value allows FSEvents to
 	// optimize the transfer of events from kernel to user space by grouping
@@ -56,8 +50,17 @@ type recursiveWatcher struct {
 }
 
 // NewRecursiveWatcher creates a new FSEvents-based recursive watcher using the
-// specified target path.
-func NewRecursiveWatcher(target string) (RecursiveWatcher, error) {
+// specified target path. The queue size controls the capacity of the internal
+// FSEvents events channel. This doesn't need to be extremely large because (a)
+// we service that channel as fast as the scheduler will allow and (b) FSEvents
+// will perform event coalescing anyway, so each channel entry can store more
+// than one event.
+func NewRecursiveWatcher(target string, queueSize int) (RecursiveWatcher, error) {
+	// Validate the queue size.
+	if err := ensureQueueSizeValid(queueSize); err != nil {
+		return nil, err
+	}
+
 	// Enforce that the watch target path is absolute. This is necessary because
 	// FSEvents will return event paths as absolute paths rooted at the system
 	// root (at least with the per-host streams that we're using), and thus
@@ -106,7 +109,7 @@ func NewRecursiveWatcher(target string) (RecursiveWatcher, error) {
 
 	// Create and start the underlying event stream.
 	watch := &fsevents.EventStream{
-		Events:  make(chan []fsevents.Event, fseventsChannelCapacity),
+		Events:  make(chan []fsevents.Event, queueSize),
 		Paths:   []string{target},
 		Latency: fseventsLatency,
 		Flags:   fseventsFlags,
@@ -17,7 +17,11 @@ func init() {
 }
 
 // NewRecursiveWatcher creates a new fanotify-based recursive watcher using the
-// specified target path.
-func NewRecursiveWatcher(target string) (RecursiveWatcher, error) {
+// specified target path. The fanotify-based watcher doesn't use an internal
+// event queue, so the queue size is validated but otherwise ignored.
+func NewRecursiveWatcher(target string, queueSize int) (RecursiveWatcher, error) {
+	if err := ensureQueueSizeValid(queueSize); err != nil {
+		return nil, err
+	}
 	return fanotify.NewRecursiveWatcher(target)
 }
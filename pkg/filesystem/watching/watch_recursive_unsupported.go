@@ -9,6 +9,6 @@ const RecursiveWatchingSupported = false
 // NewRecursiveWatcher creates a new recursive watcher on platforms that support
 // native recursive watching. This platform does not support recursive watching
 // and this function will panic if called.
-func NewRecursiveWatcher(_ string) (RecursiveWatcher, error) {
+func NewRecursiveWatcher(_ string, _ int) (RecursiveWatcher, error) {
 	panic("recursive watching not supported on this platform")
 }
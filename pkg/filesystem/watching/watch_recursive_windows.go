@@ -55,9 +55,15 @@ type recursiveWatcher struct {
 	done sync.WaitGroup
 }
 
-// NewRecursiveWatcher creates a new FSEvents-based recursive watcher using the
-// specified target path.
-func NewRecursiveWatcher(target string) (RecursiveWatcher, error) {
+// NewRecursiveWatcher creates a new ReadDirectoryChangesW-based recursive
+// watcher using the specified target path. The queue size controls the
+// capacity of the internal event channel.
+func NewRecursiveWatcher(target string, queueSize int) (RecursiveWatcher, error) {
+	// Validate the queue size.
+	if err := ensureQueueSizeValid(queueSize); err != nil {
+		return nil, err
+	}
+
 	// Resolve any symbolic links in the watch target. This is necessary because
 	// we're using the parent directory of the target path as the watch root and
 	// ReadDirectoryChangesW doesn't watch across symbolic link boundaries, so
@@ -126,7 +132,7 @@ func NewRecursiveWatcher(target string) (RecursiveWatcher, error) {
 	// disk at the target location.
 
 	// Create the underlying watcher and add the watch.
-	watch, err := winfsnotify.NewWatcher()
+	watch, err := winfsnotify.NewWatcher(queueSize)
 	if err != nil {
 		return nil, fmt.Errorf("unable to create watcher: %w", err)
 	} else if err = watch.AddWatch(watchRoot, winfsnotifyFlags); err != nil {
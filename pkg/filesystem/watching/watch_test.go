@@ -1,6 +1,7 @@
 package watching
 
 import (
+	"fmt"
 	"os"
 	"path/filepath"
 	"runtime"
@@ -12,6 +13,8 @@ const (
 	// maximumEventWaitTime is the maximum amount of time that verifyWatchEvent
 	// will wait for an event to be received.
 	maximumEventWaitTime = 5 * time.Second
+	// testingQueueSize is the watcher queue size to use for tests.
+	testingQueueSize = 50
 )
 
 // verifyWatchEvent is a helper function to verify that events are received by a
@@ -51,7 +54,7 @@ func TestRecursiveWatcher(t *testing.T) {
 	directory := t.TempDir()
 
 	// Create the watcher and defer its termination.
-	watcher, err := NewRecursiveWatcher(directory)
+	watcher, err := NewRecursiveWatcher(directory, testingQueueSize)
 	if err != nil {
 		t.Fatal("unable to establish watch:", err)
 	}
@@ -106,7 +109,7 @@ func TestNonRecursiveWatcher(t *testing.T) {
 	directory := t.TempDir()
 
 	// Create the watcher and defer its termination.
-	watcher, err := NewNonRecursiveWatcher()
+	watcher, err := NewNonRecursiveWatcher(testingQueueSize)
 	if err != nil {
 		t.Fatal("unable to create watcher:", err)
 	}
@@ -147,3 +150,79 @@ func TestNonRecursiveWatcher(t *testing.T) {
 	}
 	verifyWatchEvent(t, watcher, map[string]bool{filePath: true})
 }
+
+// TestWatcherQueueSizeValidation tests that watcher creation fails with an
+// invalid queue size.
+func TestWatcherQueueSizeValidation(t *testing.T) {
+	if NonRecursiveWatchingSupported {
+		if _, err := NewNonRecursiveWatcher(0); err == nil {
+			t.Error("non-recursive watcher created with zero queue size")
+		}
+	}
+	if RecursiveWatchingSupported {
+		if _, err := NewRecursiveWatcher(t.TempDir(), 0); err == nil {
+			t.Error("recursive watcher created with zero queue size")
+		}
+	}
+}
+
+// TestNonRecursiveWatcherOverflow tests that the platform's NonRecursiveWatcher
+// implementation (if any) honors its configured queue size, reporting an
+// internal overflow if and only if the queue is insufficient to buffer the
+// events that it receives while its events channel isn't being serviced.
+func TestNonRecursiveWatcherOverflow(t *testing.T) {
+	// Skip this test if non-recursive watchig is unsupported.
+	if !NonRecursiveWatchingSupported {
+		t.Skip()
+	}
+
+	// Set up test cases. Each file creation generates a small number of events,
+	// so the larger queue size can comfortably absorb the generated events.
+	const fileCount = 16
+	testCases := []struct {
+		queueSize      int
+		expectOverflow bool
+	}{
+		{1, true},
+		{1024, false},
+	}
+
+	// Process test cases.
+	for i, testCase := range testCases {
+		// Create a temporary directory and watcher.
+		directory := t.TempDir()
+		watcher, err := NewNonRecursiveWatcher(testCase.queueSize)
+		if err != nil {
+			t.Fatalf("test index %d: unable to create watcher: %v", i, err)
+		}
+		watcher.Watch(directory)
+
+		// Create files without servicing the events channel.
+		for f := 0; f < fileCount; f++ {
+			name := filepath.Join(directory, fmt.Sprintf("file%02d", f))
+			if err := os.WriteFile(name, []byte("data"), 0600); err != nil {
+				t.Fatalf("test index %d: unable to create test file: %v", i, err)
+			}
+		}
+
+		// Check whether or not an overflow is reported, allowing some time for
+		// events to propagate.
+		var overflowed bool
+		select {
+		case err := <-watcher.Errors():
+			if err != ErrWatchInternalOverflow {
+				t.Fatalf("test index %d: unexpected watcher error: %v", i, err)
+			}
+			overflowed = true
+		case <-time.After(time.Second):
+		}
+		if overflowed != testCase.expectOverflow {
+			t.Errorf("test index %d: overflow status does not match expected: %t != %t",
+				i, overflowed, testCase.expectOverflow,
+			)
+		}
+
+		// Terminate the watcher.
+		watcher.Terminate()
+	}
+}
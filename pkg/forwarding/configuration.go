@@ -15,6 +15,11 @@ func (c *Configuration) EnsureValid(endpointSpecific bool) error {
 		return errors.New("nil configuration")
 	}
 
+	// Verify that the TCP network mode is unspecified or supported.
+	if !(c.NetworkMode.IsDefault() || c.NetworkMode.Supported()) {
+		return errors.New("unknown or unsupported TCP network mode")
+	}
+
 	// Verify that the socket overwrite mode is unspecified or supported.
 	if !(c.SocketOverwriteMode.IsDefault() || c.SocketOverwriteMode.Supported()) {
 		return errors.New("unknown or unsupported socket overwrite mode")
@@ -37,6 +42,13 @@ func (c *Configuration) EnsureValid(endpointSpecific bool) error {
 	// We don't verify the socket permission mode because there's not really any
 	// way to know if it's a sane value.
 
+	// Verify that the drain timeout isn't specified on a per-endpoint basis,
+	// since it's a property of the session as a whole. Its value doesn't need
+	// to be validated.
+	if endpointSpecific && c.DrainTimeout != 0 {
+		return errors.New("drain timeout cannot be specified on an endpoint-specific basis")
+	}
+
 	// Success.
 	return nil
 }
@@ -50,7 +62,9 @@ func (c *Configuration) Equal(other *Configuration) bool {
 	}
 
 	// Perform an equivalence check.
-	return c.SocketOverwriteMode == other.SocketOverwriteMode &&
+	return c.DrainTimeout == other.DrainTimeout &&
+		c.NetworkMode == other.NetworkMode &&
+		c.SocketOverwriteMode == other.SocketOverwriteMode &&
 		c.SocketOwner == other.SocketOwner &&
 		c.SocketGroup == other.SocketGroup &&
 		c.SocketPermissionMode == other.SocketPermissionMode
@@ -62,6 +76,20 @@ func MergeConfigurations(lower, higher *Configuration) *Configuration {
 	// Create the resulting configuration.
 	result := &Configuration{}
 
+	// Merge the drain timeout.
+	if higher.DrainTimeout != 0 {
+		result.DrainTimeout = higher.DrainTimeout
+	} else {
+		result.DrainTimeout = lower.DrainTimeout
+	}
+
+	// Merge the TCP network mode.
+	if !higher.NetworkMode.IsDefault() {
+		result.NetworkMode = higher.NetworkMode
+	} else {
+		result.NetworkMode = lower.NetworkMode
+	}
+
 	// Merge the socket overwrite mode.
 	if !higher.SocketOverwriteMode.IsDefault() {
 		result.SocketOverwriteMode = higher.SocketOverwriteMode
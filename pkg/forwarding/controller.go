@@ -25,6 +25,56 @@ const (
 	autoReconnectInterval = 15 * time.Second
 )
 
+// connectionTracker tracks the forwarding connections that are active within a
+// single forwarding loop and facilitates draining them. It is safe for
+// concurrent usage. The zero value is ready for use.
+type connectionTracker struct {
+	// lock serializes the registration of connections against the
+	// transition to draining.
+	lock sync.Mutex
+	// draining indicates whether or not draining has started.
+	draining bool
+	// active tracks active connections.
+	active sync.WaitGroup
+}
+
+// add attempts to register a new connection with the tracker. It returns false
+// if the tracker is draining, in which case the connection should be rejected.
+// If it returns true, then the caller must invoke done once the connection has
+// closed.
+func (t *connectionTracker) add() bool {
+	t.lock.Lock()
+	defer t.lock.Unlock()
+	if t.draining {
+		return false
+	}
+	t.active.Add(1)
+	return true
+}
+
+// done indicates that a connection registered by add has closed.
+func (t *connectionTracker) done() {
+	t.active.Done()
+}
+
+// drain transitions the tracker to draining (causing future add calls to fail)
+// and returns a channel that will be closed once all registered connections
+// have closed.
+func (t *connectionTracker) drain() <-chan struct{} {
+	// Mark the tracker as draining.
+	t.lock.Lock()
+	t.draining = true
+	t.lock.Unlock()
+
+	// Wait for active connections to close in a background Goroutine.
+	drained := make(chan struct{})
+	go func() {
+		t.active.Wait()
+		close(drained)
+	}()
+	return drained
+}
+
 // controller manages and executes a single session.
 type controller struct {
 	// logger is the controller logger.
@@ -511,6 +561,13 @@ func (c *controller) run(ctx context.Context, source, destination Endpoint) {
 		close(c.done)
 	}()
 
+	// Compute the connection drain timeout.
+	drainTimeoutMilliseconds := c.session.Configuration.DrainTimeout
+	if drainTimeoutMilliseconds == 0 {
+		drainTimeoutMilliseconds = c.session.Version.DefaultDrainTimeout()
+	}
+	drainTimeout := time.Duration(drainTimeoutMilliseconds) * time.Millisecond
+
 	// Track the last time that forwarding failed.
 	var lastForwardingFailureTime time.Time
 
@@ -599,8 +656,11 @@ func (c *controller) run(ctx context.Context, source, destination Endpoint) {
 		sourceTransportErrors := source.TransportErrors()
 		destinationTransportErrors := destination.TransportErrors()
 
-		// Create a cancellable subcontext that we can use to manage shutdown.
-		shutdownCtx, forceShutdown := context.WithCancel(ctx)
+		// Create a cancellable context that we can use to manage shutdown. We
+		// don't derive it from the run loop context because cancellation of the
+		// run loop may need to be followed by connection draining before
+		// shutdown.
+		shutdownCtx, forceShutdown := context.WithCancel(context.Background())
 
 		// Create a Goroutine that will shut down (and unblock) endpoints. This
 		// is the only way to unblock forwarding on cancellation.
@@ -613,10 +673,11 @@ func (c *controller) run(ctx context.Context, source, destination Endpoint) {
 		}()
 
 		// Perform forwarding in a background Goroutine and monitor for errors.
+		connections := &connectionTracker{}
 		forwardingErrors := make(chan error, 1)
 		go func() {
 			c.logger.Debug("Entering forwarding loop")
-			forwardingErrors <- c.forward(source, destination)
+			forwardingErrors <- c.forward(source, destination, connections)
 		}()
 
 		// Wait for cancellation, an error from forwarding, or an error from
@@ -640,7 +701,30 @@ func (c *controller) run(ctx context.Context, source, destination Endpoint) {
 			sessionErr = fmt.Errorf("destination transport failure: %w", err)
 		}
 
-		// Force shutdown, which may have already occurred due to cancellation.
+		// If we were cancelled and connection draining is enabled, then stop
+		// forwarding new connections and wait (up to the drain timeout) for
+		// existing connections to close. We also stop waiting if forwarding or
+		// either transport fails, since existing connections won't survive.
+		if cancelled && drainTimeout > 0 {
+			c.logger.Debug("Draining connections")
+			drainTimer := time.NewTimer(drainTimeout)
+			select {
+			case <-connections.drain():
+				c.logger.Debug("Connections drained")
+			case <-drainTimer.C:
+				c.logger.Debug("Connection draining timed out")
+			case err := <-forwardingErrors:
+				c.logger.Debug("Forwarding loop terminated with error during draining:", err)
+				forwardingErrorReceived = true
+			case err := <-sourceTransportErrors:
+				c.logger.Debug("Source transport failure during draining:", err)
+			case err := <-destinationTransportErrors:
+				c.logger.Debug("Destination transport failure during draining:", err)
+			}
+			drainTimer.Stop()
+		}
+
+		// Force shutdown.
 		forceShutdown()
 
 		// Wait for shutdown to complete.
@@ -689,8 +773,10 @@ func (c *controller) run(ctx context.Context, source, destination Endpoint) {
 	}
 }
 
-// forward is the main forwarding loop for the controller.
-func (c *controller) forward(source, destination Endpoint) error {
+// forward is the main forwarding loop for the controller. It registers each
+// forwarded connection with the specified connection tracker and rejects
+// incoming connections once the tracker is draining.
+func (c *controller) forward(source, destination Endpoint, connections *connectionTracker) error {
 	// Create a context that we can use to regulate the lifecycle of forwarding
 	// Goroutines and defer its cancellation.
 	ctx, cancel := context.WithCancel(context.Background())
@@ -730,10 +816,17 @@ func (c *controller) forward(source, destination Endpoint) error {
 			return fmt.Errorf("unable to accept connection: %w", err)
 		}
 
+		// If we're draining connections, then reject the incoming connection.
+		if !connections.add() {
+			incoming.Close()
+			continue
+		}
+
 		// Open the outgoing connection to which we should forward.
 		outgoing, err := destination.Open()
 		if err != nil {
 			incoming.Close()
+			connections.done()
 			return fmt.Errorf("unable to open forwarding connection: %w", err)
 		}
 
@@ -752,6 +845,9 @@ func (c *controller) forward(source, destination Endpoint) error {
 			c.stateLock.Lock()
 			state.OpenConnections--
 			c.stateLock.Unlock()
+
+			// Signal connection closure to the tracker.
+			connections.done()
 		}()
 	}
 }
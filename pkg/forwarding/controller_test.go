@@ -1,3 +1,161 @@
 package forwarding
 
-// TODO: Implement.
+import (
+	"bytes"
+	"context"
+	"io"
+	"net"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/mutagen-io/mutagen/pkg/logging"
+	"github.com/mutagen-io/mutagen/pkg/state"
+)
+
+// testListenerEndpoint is a source endpoint implementation for testing that
+// accepts TCP connections on the loopback interface.
+type testListenerEndpoint struct {
+	// listener is the underlying listener.
+	listener net.Listener
+}
+
+// TransportErrors implements Endpoint.TransportErrors.
+func (e *testListenerEndpoint) TransportErrors() <-chan error {
+	return nil
+}
+
+// Open implements Endpoint.Open.
+func (e *testListenerEndpoint) Open() (net.Conn, error) {
+	return e.listener.Accept()
+}
+
+// Shutdown implements Endpoint.Shutdown.
+func (e *testListenerEndpoint) Shutdown() error {
+	return e.listener.Close()
+}
+
+// testDialerEndpoint is a destination endpoint implementation for testing that
+// dials a TCP address.
+type testDialerEndpoint struct {
+	// address is the target address.
+	address string
+}
+
+// TransportErrors implements Endpoint.TransportErrors.
+func (e *testDialerEndpoint) TransportErrors() <-chan error {
+	return nil
+}
+
+// Open implements Endpoint.Open.
+func (e *testDialerEndpoint) Open() (net.Conn, error) {
+	return net.Dial("tcp", e.address)
+}
+
+// Shutdown implements Endpoint.Shutdown.
+func (e *testDialerEndpoint) Shutdown() error {
+	return nil
+}
+
+// TestControllerDrainsConnectionsOnHalt tests that a controller with a drain
+// timeout allows in-flight connections to finish before tearing down.
+func TestControllerDrainsConnectionsOnHalt(t *testing.T) {
+	// Create a target server that responds to a request after a delay and
+	// defer its shutdown.
+	target, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal("unable to create target listener:", err)
+	}
+	defer target.Close()
+	requestReceived := make(chan struct{})
+	var serverWaitGroup sync.WaitGroup
+	serverWaitGroup.Add(1)
+	go func() {
+		defer serverWaitGroup.Done()
+		connection, err := target.Accept()
+		if err != nil {
+			return
+		}
+		defer connection.Close()
+		request := make([]byte, 4)
+		if _, err := io.ReadFull(connection, request); err != nil {
+			return
+		}
+		close(requestReceived)
+		time.Sleep(250 * time.Millisecond)
+		connection.Write(request)
+	}()
+
+	// Create the source listener.
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal("unable to create source listener:", err)
+	}
+
+	// Create a controller with a drain timeout that's substantially longer
+	// than the target's response delay.
+	session := &Session{
+		Version:       Version_Version1,
+		Configuration: &Configuration{DrainTimeout: 10000},
+	}
+	controller := &controller{
+		logger:    logging.NewLogger(logging.LevelDisabled, io.Discard),
+		stateLock: state.NewTrackingLock(state.NewTracker()),
+		session:   session,
+		state: &State{
+			Session:          session,
+			SourceState:      &EndpointState{},
+			DestinationState: &EndpointState{},
+		},
+		done: make(chan struct{}),
+	}
+
+	// Start the run loop.
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go controller.run(ctx,
+		&testListenerEndpoint{listener},
+		&testDialerEndpoint{target.Addr().String()},
+	)
+
+	// Open a connection through the forwarding session and send a request.
+	connection, err := net.Dial("tcp", listener.Addr().String())
+	if err != nil {
+		t.Fatal("unable to connect to source listener:", err)
+	}
+	defer connection.Close()
+	request := []byte("ping")
+	if _, err := connection.Write(request); err != nil {
+		t.Fatal("unable to write request:", err)
+	}
+
+	// Wait for the request to reach the target, then halt the run loop while
+	// the response is still pending.
+	select {
+	case <-requestReceived:
+	case <-time.After(10 * time.Second):
+		t.Fatal("request not received by target")
+	}
+	cancel()
+
+	// Verify that the response still arrives.
+	connection.SetReadDeadline(time.Now().Add(5 * time.Second))
+	response := make([]byte, len(request))
+	if _, err := io.ReadFull(connection, response); err != nil {
+		t.Fatal("unable to read response:", err)
+	} else if !bytes.Equal(response, request) {
+		t.Error("response does not match request")
+	}
+
+	// Close the connection and verify that the run loop terminates, well
+	// before the drain timeout expires.
+	connection.Close()
+	select {
+	case <-controller.done:
+	case <-time.After(5 * time.Second):
+		t.Error("run loop did not terminate after connections drained")
+	}
+
+	// Wait for the target server to exit.
+	serverWaitGroup.Wait()
+}
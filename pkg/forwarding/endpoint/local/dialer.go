@@ -20,6 +20,9 @@ type dialerEndpoint struct {
 	dialer *net.Dialer
 	// protocol is the protocol to use for dialing.
 	protocol string
+	// network is the network to use for dialing. It is computed from protocol
+	// based on the TCP network mode.
+	network string
 	// address is the address to use for dialing.
 	address string
 }
@@ -41,6 +44,12 @@ func NewDialerEndpoint(
 		dialer = &net.Dialer{}
 	}
 
+	// Compute the effective TCP network mode.
+	tcpNetworkMode := configuration.NetworkMode
+	if tcpNetworkMode.IsDefault() {
+		tcpNetworkMode = version.DefaultTCPNetworkMode()
+	}
+
 	// Create the endpoint.
 	return &dialerEndpoint{
 		logger:        logger,
@@ -48,6 +57,7 @@ func NewDialerEndpoint(
 		dialingCancel: dialingCancel,
 		dialer:        dialer,
 		protocol:      protocol,
+		network:       tcpNetworkMode.Network(protocol),
 		address:       address,
 	}, nil
 }
@@ -67,7 +77,7 @@ func (e *dialerEndpoint) Open() (net.Conn, error) {
 
 	// For all other protocols (i.e. TCP and Unix domain sockets), use the
 	// standard dialer.
-	return e.dialer.DialContext(e.dialingCtx, e.protocol, e.address)
+	return e.dialer.DialContext(e.dialingCtx, e.network, e.address)
 }
 
 // Shutdown implements forwarding.Endpoint.Shutdown.
@@ -1,3 +1,61 @@
 package local
 
-// TODO: Implement.
+import (
+	"fmt"
+	"io"
+	"net"
+	"testing"
+
+	"github.com/mutagen-io/mutagen/pkg/forwarding"
+	"github.com/mutagen-io/mutagen/pkg/logging"
+)
+
+// TestDialerEndpointTCPNetworkMode tests that dialer endpoints use the network
+// specified by the TCP network mode.
+func TestDialerEndpointTCPNetworkMode(t *testing.T) {
+	// Create an IPv6 listener to serve as a target and defer its closure.
+	listener, err := net.Listen("tcp6", "[::1]:0")
+	if err != nil {
+		t.Skip("IPv6 unavailable:", err)
+	}
+	defer listener.Close()
+	address := fmt.Sprintf("[::1]:%d", listener.Addr().(*net.TCPAddr).Port)
+
+	// Create a logger.
+	logger := logging.NewLogger(logging.LevelDisabled, io.Discard)
+
+	// Set up test cases.
+	testCases := []struct {
+		mode          forwarding.TCPNetworkMode
+		expectFailure bool
+	}{
+		{forwarding.TCPNetworkMode_TCPNetworkModeDefault, false},
+		{forwarding.TCPNetworkMode_TCPNetworkModeAutomatic, false},
+		{forwarding.TCPNetworkMode_TCPNetworkModeIPv4, true},
+		{forwarding.TCPNetworkMode_TCPNetworkModeIPv6, false},
+	}
+
+	// Process test cases.
+	for _, testCase := range testCases {
+		endpoint, err := NewDialerEndpoint(
+			logger,
+			forwarding.Version_Version1,
+			&forwarding.Configuration{NetworkMode: testCase.mode},
+			"tcp",
+			address,
+		)
+		if err != nil {
+			t.Fatal("unable to create dialer endpoint:", err)
+		}
+		connection, err := endpoint.Open()
+		if err != nil && !testCase.expectFailure {
+			t.Errorf("dialing failed with mode %s: %v", testCase.mode, err)
+		} else if err == nil {
+			connection.Close()
+			if testCase.expectFailure {
+				t.Errorf("dialing succeeded unexpectedly with mode %s", testCase.mode)
+			}
+		}
+		endpoint.Shutdown()
+	}
+}
@@ -120,8 +120,16 @@ func (e *listenerEndpoint) initialize(shutdown bool) {
 		return
 	}
 
+	// Compute the effective TCP network mode and the resulting network. This
+	// only has an effect for generic TCP endpoints.
+	tcpNetworkMode := e.configuration.NetworkMode
+	if tcpNetworkMode.IsDefault() {
+		tcpNetworkMode = e.version.DefaultTCPNetworkMode()
+	}
+	network := tcpNetworkMode.Network(e.protocol)
+
 	// Otherwise attempt to create a listener using the generic method.
-	listener, err := net.Listen(e.protocol, e.address)
+	listener, err := net.Listen(network, e.address)
 	if err != nil {
 		// If we're not targeting a Unix domain socket or the error isn't due to
 		// a conflicting socket, then abort.
@@ -150,7 +158,7 @@ func (e *listenerEndpoint) initialize(shutdown bool) {
 		}
 
 		// Retry listening.
-		listener, err = net.Listen(e.protocol, e.address)
+		listener, err = net.Listen(network, e.address)
 		if err != nil {
 			e.initializeError = fmt.Errorf("unable to create listener after conflicting socket removal: %w", err)
 			return
@@ -1,3 +1,68 @@
 package local
 
-// TODO: Implement.
+import (
+	"fmt"
+	"io"
+	"net"
+	"testing"
+
+	"github.com/mutagen-io/mutagen/pkg/forwarding"
+	"github.com/mutagen-io/mutagen/pkg/logging"
+)
+
+// TestListenerEndpointTCPNetworkMode tests that listener endpoints use the
+// network specified by the TCP network mode.
+func TestListenerEndpointTCPNetworkMode(t *testing.T) {
+	// Verify that IPv6 is available.
+	if probe, err := net.Listen("tcp6", "[::1]:0"); err != nil {
+		t.Skip("IPv6 unavailable:", err)
+	} else {
+		probe.Close()
+	}
+
+	// Create a logger.
+	logger := logging.NewLogger(logging.LevelDisabled, io.Discard)
+
+	// Create a listener that's forced to use IPv6 on a wildcard address and
+	// defer its shutdown.
+	endpoint, err := NewListenerEndpoint(
+		logger,
+		forwarding.Version_Version1,
+		&forwarding.Configuration{NetworkMode: forwarding.TCPNetworkMode_TCPNetworkModeIPv6},
+		"tcp",
+		":0",
+		false,
+	)
+	if err != nil {
+		t.Fatal("unable to create IPv6 listener endpoint:", err)
+	}
+	defer endpoint.Shutdown()
+	port := endpoint.(*listenerEndpoint).listener.Addr().(*net.TCPAddr).Port
+
+	// Verify that an IPv4 connection attempt is rejected.
+	if connection, err := net.Dial("tcp4", fmt.Sprintf("127.0.0.1:%d", port)); err == nil {
+		connection.Close()
+		t.Error("IPv4 connection to IPv6 listener succeeded")
+	}
+
+	// Verify that an IPv6 connection attempt succeeds.
+	if connection, err := net.Dial("tcp6", fmt.Sprintf("[::1]:%d", port)); err != nil {
+		t.Error("IPv6 connection to IPv6 listener failed:", err)
+	} else {
+		connection.Close()
+	}
+
+	// Verify that a listener forced to use IPv4 can't listen on an IPv6
+	// address.
+	if endpoint, err := NewListenerEndpoint(
+		logger,
+		forwarding.Version_Version1,
+		&forwarding.Configuration{NetworkMode: forwarding.TCPNetworkMode_TCPNetworkModeIPv4},
+		"tcp",
+		"[::1]:0",
+		false,
+	); err == nil {
+		endpoint.Shutdown()
+		t.Error("IPv4 listener endpoint created on IPv6 address")
+	}
+}
@@ -321,3 +321,15 @@ func (m *Manager) Terminate(ctx context.Context, selection *selection.Selection,
 	// Success.
 	return nil
 }
+
+// Usage computes the total number of sessions and the total number of open
+// forwarded connections across all sessions.
+func (m *Manager) Usage() (sessions, openConnections uint64) {
+	controllers := m.allControllers()
+	for _, controller := range controllers {
+		controller.stateLock.Lock()
+		openConnections += controller.state.OpenConnections
+		controller.stateLock.UnlockWithoutNotify()
+	}
+	return uint64(len(controllers)), openConnections
+}
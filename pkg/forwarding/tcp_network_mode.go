@@ -0,0 +1,98 @@
+package forwarding
+
+import (
+	"fmt"
+)
+
+// IsDefault indicates whether or not the TCP network mode is
+// TCPNetworkMode_TCPNetworkModeDefault.
+func (m TCPNetworkMode) IsDefault() bool {
+	return m == TCPNetworkMode_TCPNetworkModeDefault
+}
+
+// Network computes the network to use for dialing or listening with the
+// specified protocol. Only the generic "tcp" protocol is affected by the mode;
+// all other protocols (including the explicit "tcp4" and "tcp6" protocols) are
+// returned unmodified.
+func (m TCPNetworkMode) Network(protocol string) string {
+	if protocol != "tcp" {
+		return protocol
+	}
+	switch m {
+	case TCPNetworkMode_TCPNetworkModeIPv4:
+		return "tcp4"
+	case TCPNetworkMode_TCPNetworkModeIPv6:
+		return "tcp6"
+	default:
+		return protocol
+	}
+}
+
+// MarshalText implements encoding.TextMarshaler.MarshalText.
+func (m TCPNetworkMode) MarshalText() ([]byte, error) {
+	var result string
+	switch m {
+	case TCPNetworkMode_TCPNetworkModeDefault:
+	case TCPNetworkMode_TCPNetworkModeAutomatic:
+		result = "auto"
+	case TCPNetworkMode_TCPNetworkModeIPv4:
+		result = "ipv4"
+	case TCPNetworkMode_TCPNetworkModeIPv6:
+		result = "ipv6"
+	default:
+		result = "unknown"
+	}
+	return []byte(result), nil
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler.UnmarshalText.
+func (m *TCPNetworkMode) UnmarshalText(textBytes []byte) error {
+	// Convert the bytes to a string.
+	text := string(textBytes)
+
+	// Convert to a TCP network mode.
+	switch text {
+	case "auto":
+		*m = TCPNetworkMode_TCPNetworkModeAutomatic
+	case "ipv4":
+		*m = TCPNetworkMode_TCPNetworkModeIPv4
+	case "ipv6":
+		*m = TCPNetworkMode_TCPNetworkModeIPv6
+	default:
+		return fmt.Errorf("unknown TCP network mode specification: %s", text)
+	}
+
+	// Success.
+	return nil
+}
+
+// Supported indicates whether or not a particular TCP network mode is a valid,
+// non-default value.
+func (m TCPNetworkMode) Supported() bool {
+	switch m {
+	case TCPNetworkMode_TCPNetworkModeAutomatic:
+		return true
+	case TCPNetworkMode_TCPNetworkModeIPv4:
+		return true
+	case TCPNetworkMode_TCPNetworkModeIPv6:
+		return true
+	default:
+		return false
+	}
+}
+
+// Description returns a human-readable description of a TCP network mode.
+func (m TCPNetworkMode) Description() string {
+	switch m {
+	case TCPNetworkMode_TCPNetworkModeDefault:
+		return "Default"
+	case TCPNetworkMode_TCPNetworkModeAutomatic:
+		return "Automatic"
+	case TCPNetworkMode_TCPNetworkModeIPv4:
+		return "IPv4"
+	case TCPNetworkMode_TCPNetworkModeIPv6:
+		return "IPv6"
+	default:
+		return "Unknown"
+	}
+}
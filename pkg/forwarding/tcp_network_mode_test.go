@@ -0,0 +1,126 @@
+package forwarding
+
+import (
+	"testing"
+)
+
+// TestTCPNetworkModeUnmarshal tests that unmarshaling from a string
+// specification succeeeds for TCPNetworkMode.
+func TestTCPNetworkModeUnmarshal(t *testing.T) {
+	// Set up test cases.
+	testCases := []struct {
+		text          string
+		expectedMode  TCPNetworkMode
+		expectFailure bool
+	}{
+		{"", TCPNetworkMode_TCPNetworkModeDefault, true},
+		{"asdf", TCPNetworkMode_TCPNetworkModeDefault, true},
+		{"auto", TCPNetworkMode_TCPNetworkModeAutomatic, false},
+		{"ipv4", TCPNetworkMode_TCPNetworkModeIPv4, false},
+		{"ipv6", TCPNetworkMode_TCPNetworkModeIPv6, false},
+	}
+
+	// Process test cases.
+	for _, testCase := range testCases {
+		var mode TCPNetworkMode
+		if err := mode.UnmarshalText([]byte(testCase.text)); err != nil {
+			if !testCase.expectFailure {
+				t.Errorf("unable to unmarshal text (%s): %s", testCase.text, err)
+			}
+		} else if testCase.expectFailure {
+			t.Error("unmarshaling succeeded unexpectedly for text:", testCase.text)
+		} else if mode != testCase.expectedMode {
+			t.Errorf(
+				"unmarshaled mode (%s) does not match expected (%s)",
+				mode,
+				testCase.expectedMode,
+			)
+		}
+	}
+}
+
+// TestTCPNetworkModeSupported tests that TCPNetworkMode support
+// detection works as expected.
+func TestTCPNetworkModeSupported(t *testing.T) {
+	// Set up test cases.
+	testCases := []struct {
+		mode            TCPNetworkMode
+		expectSupported bool
+	}{
+		{TCPNetworkMode_TCPNetworkModeDefault, false},
+		{TCPNetworkMode_TCPNetworkModeAutomatic, true},
+		{TCPNetworkMode_TCPNetworkModeIPv4, true},
+		{TCPNetworkMode_TCPNetworkModeIPv6, true},
+		{(TCPNetworkMode_TCPNetworkModeIPv6 + 1), false},
+	}
+
+	// Process test cases.
+	for _, testCase := range testCases {
+		if supported := testCase.mode.Supported(); supported != testCase.expectSupported {
+			t.Errorf(
+				"mode support status (%t) does not match expected (%t)",
+				supported,
+				testCase.expectSupported,
+			)
+		}
+	}
+}
+
+// TestTCPNetworkModeNetwork tests that TCPNetworkMode network computation works
+// as expected.
+func TestTCPNetworkModeNetwork(t *testing.T) {
+	// Set up test cases.
+	testCases := []struct {
+		mode            TCPNetworkMode
+		protocol        string
+		expectedNetwork string
+	}{
+		{TCPNetworkMode_TCPNetworkModeDefault, "tcp", "tcp"},
+		{TCPNetworkMode_TCPNetworkModeAutomatic, "tcp", "tcp"},
+		{TCPNetworkMode_TCPNetworkModeIPv4, "tcp", "tcp4"},
+		{TCPNetworkMode_TCPNetworkModeIPv6, "tcp", "tcp6"},
+		{TCPNetworkMode_TCPNetworkModeIPv6, "tcp4", "tcp4"},
+		{TCPNetworkMode_TCPNetworkModeIPv4, "tcp6", "tcp6"},
+		{TCPNetworkMode_TCPNetworkModeIPv4, "unix", "unix"},
+		{TCPNetworkMode_TCPNetworkModeIPv6, "npipe", "npipe"},
+	}
+
+	// Process test cases.
+	for _, testCase := range testCases {
+		if network := testCase.mode.Network(testCase.protocol); network != testCase.expectedNetwork {
+			t.Errorf(
+				"network (%s) for protocol (%s) does not match expected (%s)",
+				network,
+				testCase.protocol,
+				testCase.expectedNetwork,
+			)
+		}
+	}
+}
+
+// TestTCPNetworkModeDescription tests that TCPNetworkMode description
+// generation works as expected.
+func TestTCPNetworkModeDescription(t *testing.T) {
+	// Set up test cases.
+	testCases := []struct {
+		mode                TCPNetworkMode
+		expectedDescription string
+	}{
+		{TCPNetworkMode_TCPNetworkModeDefault, "Default"},
+		{TCPNetworkMode_TCPNetworkModeAutomatic, "Automatic"},
+		{TCPNetworkMode_TCPNetworkModeIPv4, "IPv4"},
+		{TCPNetworkMode_TCPNetworkModeIPv6, "IPv6"},
+		{(TCPNetworkMode_TCPNetworkModeIPv6 + 1), "Unknown"},
+	}
+
+	// Process test cases.
+	for _, testCase := range testCases {
+		if description := testCase.mode.Description(); description != testCase.expectedDescription {
+			t.Errorf(
+				"mode description (%s) does not match expected (%s)",
+				description,
+				testCase.expectedDescription,
+			)
+		}
+	}
+}
@@ -17,6 +17,17 @@ func (v Version) Supported() bool {
 	}
 }
 
+// DefaultTCPNetworkMode returns the default TCP network mode for the session
+// version.
+func (v Version) DefaultTCPNetworkMode() TCPNetworkMode {
+	switch v {
+	case Version_Version1:
+		return TCPNetworkMode_TCPNetworkModeAutomatic
+	default:
+		panic("unknown or unsupported session version")
+	}
+}
+
 // DefaultSocketOverwriteMode returns the default socket overwrite mode for the
 // session version.
 func (v Version) DefaultSocketOverwriteMode() SocketOverwriteMode {
@@ -60,3 +71,14 @@ func (v Version) DefaultSocketGroupSpecification() string {
 		panic("unknown or unsupported session version")
 	}
 }
+
+// DefaultDrainTimeout returns the default connection drain timeout (in
+// milliseconds) for the session version.
+func (v Version) DefaultDrainTimeout() uint32 {
+	switch v {
+	case Version_Version1:
+		return 0
+	default:
+		panic("unknown or unsupported session version")
+	}
+}
@@ -2,18 +2,18 @@
 
 //go:generate go build google.golang.org/protobuf/cmd/protoc-gen-go
 //go:generate go build google.golang.org/grpc/cmd/protoc-gen-go-grpc
-//go:generate protoc --plugin=./protoc-gen-go -I. --go_out=. --go_opt=paths=source_relative filesystem/behavior/probe_mode.proto
-//go:generate protoc --plugin=./protoc-gen-go -I. --go_out=. --go_opt=paths=source_relative forwarding/configuration.proto forwarding/session.proto forwarding/socket_overwrite_mode.proto forwarding/state.proto forwarding/version.proto
+//go:generate protoc --plugin=./protoc-gen-go -I. --go_out=. --go_opt=paths=source_relative filesystem/behavior/probe_assumption.proto filesystem/behavior/probe_mode.proto
+//go:generate protoc --plugin=./protoc-gen-go -I. --go_out=. --go_opt=paths=source_relative forwarding/configuration.proto forwarding/session.proto forwarding/socket_overwrite_mode.proto forwarding/state.proto forwarding/tcp_network_mode.proto forwarding/version.proto
 //go:generate protoc --plugin=./protoc-gen-go -I. --go_out=. --go_opt=paths=source_relative forwarding/endpoint/remote/protocol.proto
 //go:generate protoc --plugin=./protoc-gen-go -I. --go_out=. --go_opt=paths=source_relative selection/selection.proto
 //go:generate protoc --plugin=./protoc-gen-go --plugin=./protoc-gen-go-grpc -I. --go_out=. --go_opt=paths=source_relative --go-grpc_out=. --go-grpc_opt=paths=source_relative service/daemon/daemon.proto
 //go:generate protoc --plugin=./protoc-gen-go --plugin=./protoc-gen-go-grpc -I. --go_out=. --go_opt=paths=source_relative --go-grpc_out=. --go-grpc_opt=paths=source_relative service/forwarding/forwarding.proto
 //go:generate protoc --plugin=./protoc-gen-go --plugin=./protoc-gen-go-grpc -I. --go_out=. --go_opt=paths=source_relative --go-grpc_out=. --go-grpc_opt=paths=source_relative service/prompting/prompting.proto
 //go:generate protoc --plugin=./protoc-gen-go --plugin=./protoc-gen-go-grpc -I. --go_out=. --go_opt=paths=source_relative --go-grpc_out=. --go-grpc_opt=paths=source_relative service/synchronization/synchronization.proto
-//go:generate protoc --plugin=./protoc-gen-go -I. --go_out=. --go_opt=paths=source_relative synchronization/configuration.proto synchronization/scan_mode.proto synchronization/session.proto synchronization/stage_mode.proto synchronization/state.proto synchronization/version.proto synchronization/watch_mode.proto
+//go:generate protoc --plugin=./protoc-gen-go -I. --go_out=. --go_opt=paths=source_relative synchronization/aggregate_digest_mode.proto synchronization/archive_log.proto synchronization/capability_mismatch_mode.proto synchronization/clock_skew_mode.proto synchronization/configuration.proto synchronization/configuration_incompatibility_mode.proto synchronization/initial_poll_modification_mode.proto synchronization/initial_synchronization_mode.proto synchronization/oversized_file_mode.proto synchronization/preview.proto synchronization/root_existence_mode.proto synchronization/root_overlap_mode.proto synchronization/scan_mode.proto synchronization/scan_sharing_mode.proto synchronization/session.proto synchronization/stage_mode.proto synchronization/staging_concurrency_mode.proto synchronization/state.proto synchronization/verification_mode.proto synchronization/version.proto synchronization/watch_mode.proto
 //go:generate protoc --plugin=./protoc-gen-go -I. --go_out=. --go_opt=paths=source_relative synchronization/compression/algorithm.proto
-//go:generate protoc --plugin=./protoc-gen-go -I. --go_out=. --go_opt=paths=source_relative synchronization/core/archive.proto synchronization/core/cache.proto synchronization/core/change.proto synchronization/core/conflict.proto synchronization/core/entry.proto synchronization/core/mode.proto synchronization/core/permissions_mode.proto synchronization/core/problem.proto synchronization/core/snapshot.proto synchronization/core/symbolic_link_mode.proto
-//go:generate protoc --plugin=./protoc-gen-go -I. --go_out=. --go_opt=paths=source_relative synchronization/core/ignore/ignore_vcs_mode.proto synchronization/core/ignore/syntax.proto
+//go:generate protoc --plugin=./protoc-gen-go -I. --go_out=. --go_opt=paths=source_relative synchronization/core/archive.proto synchronization/core/cache.proto synchronization/core/cache_trust_mode.proto synchronization/core/change.proto synchronization/core/conflict.proto synchronization/core/entry.proto synchronization/core/executability_mode.proto synchronization/core/mode.proto synchronization/core/name_normalization_mode.proto synchronization/core/permissions_mode.proto synchronization/core/phantom_directory_mode.proto synchronization/core/problem.proto synchronization/core/recording.proto synchronization/core/snapshot.proto synchronization/core/special_file_mode.proto synchronization/core/symbolic_link_cycle_mode.proto synchronization/core/symbolic_link_mode.proto synchronization/core/symbolic_link_replacement_mode.proto synchronization/core/transition_mode.proto synchronization/core/type_change_mode.proto
+//go:generate protoc --plugin=./protoc-gen-go -I. --go_out=. --go_opt=paths=source_relative synchronization/core/ignore/ignore_os_metadata_mode.proto synchronization/core/ignore/ignore_vcs_mode.proto synchronization/core/ignore/syntax.proto
 //go:generate protoc --plugin=./protoc-gen-go -I. --go_out=. --go_opt=paths=source_relative synchronization/endpoint/remote/protocol.proto
 //go:generate protoc --plugin=./protoc-gen-go -I. --go_out=. --go_opt=paths=source_relative synchronization/hashing/algorithm.proto
 //go:generate protoc --plugin=./protoc-gen-go -I. --go_out=. --go_opt=paths=source_relative synchronization/rsync/engine.proto synchronization/rsync/receive.proto synchronization/rsync/transmission.proto
@@ -1,6 +1,7 @@
 package identifier
 
 import (
+	"crypto/sha256"
 	"errors"
 	"regexp"
 	"strings"
@@ -40,32 +41,33 @@ var legacyMatcher = regexp.MustCompile("^[0-9a-f]{8}-[0-9a-f]{4}-[0-9a-f]{4}-[0-
 // matcher is a regular expression that matches Mutagen's identifiers.
 var matcher = regexp.MustCompile("^[a-z]{4}_[0-9a-zA-Z]{43}$")
 
-// New generates a new collision-resistant identifier with the specified prefix.
-// The prefix should have a length of RequiredPrefixLength.
-func New(prefix string) (string, error) {
+// validatePrefix verifies that an identifier prefix is valid.
+func validatePrefix(prefix string) error {
 	// Ensure that the prefix length is correct.
 	if len(prefix) != requiredPrefixLength {
-		return "", errors.New("incorrect prefix length")
+		return errors.New("incorrect prefix length")
 	}
 
 	// Ensure that each prefix character is allowed.
 	for _, r := range prefix {
 		if !('a' <= r && r <= 'z') {
-			return "", errors.New("invalid prefix character")
+			return errors.New("invalid prefix character")
 		}
 	}
 
-	// Create the random value.
-	random, err := random.New(collisionResistantLength)
-	if err != nil {
-		return "", err
-	}
+	// Success.
+	return nil
+}
 
-	// Encode the random value using a Base62 encoding scheme. As a sanity
-	// check, ensure that the encoded value doesn't exceed the target length.
-	encoded := encoding.EncodeBase62(random)
+// format formats an identifier from a prefix and a collision-resistant value.
+// The prefix must have already been validated and the value must have a length
+// of collisionResistantLength.
+func format(prefix string, value []byte) string {
+	// Encode the value using a Base62 encoding scheme. As a sanity check,
+	// ensure that the encoded value doesn't exceed the target length.
+	encoded := encoding.EncodeBase62(value)
 	if len(encoded) > targetBase62Length {
-		panic("encoded random data length longer than expected")
+		panic("encoded value length longer than expected")
 	}
 
 	// Create a string builder.
@@ -87,8 +89,47 @@ func New(prefix string) (string, error) {
 	// Write the encoded value.
 	builder.WriteString(encoded)
 
-	// Success.
-	return builder.String(), nil
+	// Done.
+	return builder.String()
+}
+
+// New generates a new collision-resistant identifier with the specified prefix.
+// The prefix should have a length of RequiredPrefixLength.
+func New(prefix string) (string, error) {
+	// Validate the prefix.
+	if err := validatePrefix(prefix); err != nil {
+		return "", err
+	}
+
+	// Create the random value.
+	random, err := random.New(collisionResistantLength)
+	if err != nil {
+		return "", err
+	}
+
+	// Format the identifier.
+	return format(prefix, random), nil
+}
+
+// NewDeterministic generates a collision-resistant identifier with the
+// specified prefix that is derived deterministically from the specified data.
+// The same prefix and data will always yield the same identifier. The prefix
+// should have a length of RequiredPrefixLength.
+func NewDeterministic(prefix string, data []byte) (string, error) {
+	// Validate the prefix.
+	if err := validatePrefix(prefix); err != nil {
+		return "", err
+	}
+
+	// Hash the data. SHA-256 digests happen to be collisionResistantLength
+	// bytes long, but we verify that here in case that relationship changes.
+	digest := sha256.Sum256(data)
+	if len(digest) != collisionResistantLength {
+		panic("digest length does not match collision-resistant length")
+	}
+
+	// Format the identifier.
+	return format(prefix, digest[:]), nil
 }
 
 // IsValid determines whether or not a string is a valid identifier.
@@ -52,6 +52,39 @@ func TestIdentifierCreation(t *testing.T) {
 	}
 }
 
+// TestDeterministicIdentifierCreation tests deterministic identifier creation.
+func TestDeterministicIdentifierCreation(t *testing.T) {
+	// Create identifiers from the same data and from different data.
+	first, err := NewDeterministic(PrefixSynchronization, []byte("data"))
+	if err != nil {
+		t.Fatal("unable to create identifier:", err)
+	}
+	second, err := NewDeterministic(PrefixSynchronization, []byte("data"))
+	if err != nil {
+		t.Fatal("unable to create identifier:", err)
+	}
+	other, err := NewDeterministic(PrefixSynchronization, []byte("other"))
+	if err != nil {
+		t.Fatal("unable to create identifier:", err)
+	}
+
+	// Verify that the identifiers are valid and have the expected relationship.
+	if !IsValid(first) || !IsValid(other) {
+		t.Error("deterministic identifier is invalid")
+	} else if !strings.HasPrefix(first, PrefixSynchronization) {
+		t.Error("identifier does not have correct prefix")
+	} else if first != second {
+		t.Error("identifiers created from the same data differ")
+	} else if first == other {
+		t.Error("identifiers created from different data match")
+	}
+
+	// Verify that prefixes are still validated.
+	if _, err := NewDeterministic("XYZ", []byte("data")); err == nil {
+		t.Error("invalid prefix accepted")
+	}
+}
+
 // TestInvalidPrefixLength tests that identifier creation fails with an invalid
 // prefix length.
 func TestPrefixLengthEnforcement(t *testing.T) {
@@ -39,6 +39,10 @@ var forwardingManager *forwarding.Manager
 // at the API level (as opposed to the gRPC or command line level).
 var synchronizationManager *synchronization.Manager
 
+// daemonServer is the daemon service server for the integration testing daemon.
+// It is exposed for integration tests that operate at the API level.
+var daemonServer *daemonsvc.Server
+
 // TestMain is the entry point for integration tests. It replaces the default
 // test entry point so that it can copy the mutagen executable to a well-known
 // path, set up the agent bundle to work during testing, set up a functionally
@@ -78,7 +82,7 @@ func TestMain(m *testing.M) {
 	defer server.Stop()
 
 	// Create and register the daemon service and defer its shutdown.
-	daemonServer := daemonsvc.NewServer()
+	daemonServer = daemonsvc.NewServer(forwardingManager, synchronizationManager, nil)
 	daemonsvc.RegisterDaemonServer(server, daemonServer)
 	defer daemonServer.Shutdown()
 
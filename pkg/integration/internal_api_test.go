@@ -5,22 +5,28 @@ import (
 	"errors"
 	"fmt"
 	"io"
+	"net"
 	"net/http"
 	"os"
 	"path/filepath"
 	"runtime"
 	"testing"
+	"time"
 
 	"github.com/google/uuid"
 
+	"github.com/mutagen-io/mutagen/pkg/filesystem/behavior"
 	"github.com/mutagen-io/mutagen/pkg/forwarding"
 	"github.com/mutagen-io/mutagen/pkg/forwarding/endpoint/local"
 	"github.com/mutagen-io/mutagen/pkg/integration/fixtures/constants"
 	"github.com/mutagen-io/mutagen/pkg/integration/protocols/netpipe"
+	"github.com/mutagen-io/mutagen/pkg/mutagen"
 	"github.com/mutagen-io/mutagen/pkg/prompting"
 	"github.com/mutagen-io/mutagen/pkg/selection"
+	daemonsvc "github.com/mutagen-io/mutagen/pkg/service/daemon"
 	"github.com/mutagen-io/mutagen/pkg/synchronization"
 	"github.com/mutagen-io/mutagen/pkg/synchronization/compression"
+	"github.com/mutagen-io/mutagen/pkg/synchronization/core"
 	"github.com/mutagen-io/mutagen/pkg/synchronization/hashing"
 	"github.com/mutagen-io/mutagen/pkg/url"
 )
@@ -65,6 +71,7 @@ func testSessionLifecycle(ctx context.Context, prompter string, alpha, beta *url
 		"testSynchronizationSession",
 		nil,
 		false,
+		false,
 		prompter,
 	)
 	if err != nil {
@@ -214,6 +221,730 @@ func TestSynchronizationGOROOTSrcToAlpha(t *testing.T) {
 	}
 }
 
+func TestSynchronizationOneWayReplicaRestoresBetaPermissions(t *testing.T) {
+	// Executability information isn't preserved on Windows, so there's nothing
+	// to test there.
+	if runtime.GOOS == "windows" {
+		t.Skip()
+	}
+
+	// Allow this test to run in parallel.
+	t.Parallel()
+
+	// Calculate alpha and beta paths.
+	directory := t.TempDir()
+	alphaRoot := filepath.Join(directory, "alpha")
+	betaRoot := filepath.Join(directory, "beta")
+
+	// Create alpha with read-only content, one file of which is executable.
+	if err := os.Mkdir(alphaRoot, 0755); err != nil {
+		t.Fatal("unable to create alpha root:", err)
+	} else if err = os.WriteFile(filepath.Join(alphaRoot, "data"), []byte("data"), 0444); err != nil {
+		t.Fatal("unable to create alpha data file:", err)
+	} else if err = os.WriteFile(filepath.Join(alphaRoot, "tool"), []byte("tool"), 0555); err != nil {
+		t.Fatal("unable to create alpha tool file:", err)
+	}
+
+	// Compute alpha and beta URLs.
+	alphaURL := &url.URL{Path: alphaRoot}
+	betaURL := &url.URL{Path: betaRoot}
+
+	// Compute configuration.
+	configuration := &synchronization.Configuration{
+		SynchronizationMode: core.SynchronizationMode_SynchronizationModeOneWayReplica,
+	}
+
+	// Create a session.
+	ctx := context.Background()
+	sessionID, err := synchronizationManager.Create(
+		ctx,
+		alphaURL, betaURL,
+		configuration,
+		&synchronization.Configuration{},
+		&synchronization.Configuration{},
+		"testSynchronizationSession",
+		nil,
+		false,
+		false,
+		"",
+	)
+	if err != nil {
+		t.Fatal("unable to create session:", err)
+	}
+
+	// Create a session selection specification and ensure that the session is
+	// terminated when we're done.
+	selection := &selection.Selection{
+		Specifications: []string{sessionID},
+	}
+	defer func() {
+		if err := synchronizationManager.Terminate(ctx, selection, ""); err != nil {
+			t.Error("unable to terminate session:", err)
+		}
+	}()
+
+	// Wait for the initial synchronization cycle.
+	if err := waitForSuccessfulSynchronizationCycle(ctx, sessionID, false, false, false); err != nil {
+		t.Fatal("unable to wait for successful synchronization:", err)
+	}
+
+	// Modify permissions (but not contents) on beta.
+	if err := os.Chmod(filepath.Join(betaRoot, "data"), 0755); err != nil {
+		t.Fatal("unable to modify beta data file permissions:", err)
+	} else if err = os.Chmod(filepath.Join(betaRoot, "tool"), 0644); err != nil {
+		t.Fatal("unable to modify beta tool file permissions:", err)
+	}
+
+	// Force a synchronization cycle.
+	if err := synchronizationManager.Flush(ctx, selection, "", false, nil); err != nil {
+		t.Fatal("unable to flush session:", err)
+	}
+
+	// Verify that executability was restored from alpha without modifying
+	// contents.
+	for name, executable := range map[string]bool{"data": false, "tool": true} {
+		path := filepath.Join(betaRoot, name)
+		if metadata, err := os.Stat(path); err != nil {
+			t.Error("unable to query beta file metadata:", err)
+		} else if (metadata.Mode()&0111 != 0) != executable {
+			t.Errorf("beta file (%s) executability not restored: %v", name, metadata.Mode())
+		}
+		if contents, err := os.ReadFile(path); err != nil {
+			t.Error("unable to read beta file:", err)
+		} else if string(contents) != name {
+			t.Errorf("beta file (%s) contents modified", name)
+		}
+	}
+}
+
+func TestSynchronizationOneWayReplicaRevertsBetaExecutabilityWithoutAlphaPreservation(t *testing.T) {
+	// Executability information isn't preserved on Windows, so there's nothing
+	// to test there.
+	if runtime.GOOS == "windows" {
+		t.Skip()
+	}
+
+	// Allow this test to run in parallel.
+	t.Parallel()
+
+	// Calculate alpha and beta paths.
+	directory := t.TempDir()
+	alphaRoot := filepath.Join(directory, "alpha")
+	betaRoot := filepath.Join(directory, "beta")
+
+	// Create alpha with an executable file. Since alpha will be treated as
+	// not preserving executability, this bit won't be recorded by its scans.
+	if err := os.Mkdir(alphaRoot, 0755); err != nil {
+		t.Fatal("unable to create alpha root:", err)
+	} else if err = os.WriteFile(filepath.Join(alphaRoot, "tool"), []byte("tool"), 0755); err != nil {
+		t.Fatal("unable to create alpha tool file:", err)
+	}
+
+	// Compute alpha and beta URLs.
+	alphaURL := &url.URL{Path: alphaRoot}
+	betaURL := &url.URL{Path: betaRoot}
+
+	// Compute configuration. We force alpha to be treated as not preserving
+	// executability so that executability is propagated to alpha from the
+	// ancestor rather than from beta.
+	configuration := &synchronization.Configuration{
+		SynchronizationMode: core.SynchronizationMode_SynchronizationModeOneWayReplica,
+	}
+	configurationAlpha := &synchronization.Configuration{
+		AssumeExecutabilityPreservation: behavior.ProbeAssumption_ProbeAssumptionFalse,
+	}
+
+	// Create a session.
+	ctx := context.Background()
+	sessionID, err := synchronizationManager.Create(
+		ctx,
+		alphaURL, betaURL,
+		configuration,
+		configurationAlpha,
+		&synchronization.Configuration{},
+		"testSynchronizationSession",
+		nil,
+		false,
+		false,
+		"",
+	)
+	if err != nil {
+		t.Fatal("unable to create session:", err)
+	}
+
+	// Create a session selection specification and ensure that the session is
+	// terminated when we're done.
+	selection := &selection.Selection{
+		Specifications: []string{sessionID},
+	}
+	defer func() {
+		if err := synchronizationManager.Terminate(ctx, selection, ""); err != nil {
+			t.Error("unable to terminate session:", err)
+		}
+	}()
+
+	// Wait for the initial synchronization cycle.
+	if err := waitForSuccessfulSynchronizationCycle(ctx, sessionID, false, false, false); err != nil {
+		t.Fatal("unable to wait for successful synchronization:", err)
+	}
+
+	// Verify that the beta file was created without executability, since none
+	// was recorded on alpha.
+	path := filepath.Join(betaRoot, "tool")
+	if metadata, err := os.Stat(path); err != nil {
+		t.Fatal("unable to query beta file metadata:", err)
+	} else if metadata.Mode()&0111 != 0 {
+		t.Fatal("beta file unexpectedly executable:", metadata.Mode())
+	}
+
+	// Make the file executable on beta.
+	if err := os.Chmod(path, 0755); err != nil {
+		t.Fatal("unable to modify beta file permissions:", err)
+	}
+
+	// Force a synchronization cycle.
+	if err := synchronizationManager.Flush(ctx, selection, "", false, nil); err != nil {
+		t.Fatal("unable to flush session:", err)
+	}
+
+	// Verify that the executability drift on beta was reverted rather than
+	// adopted by alpha, and that contents weren't modified.
+	if metadata, err := os.Stat(path); err != nil {
+		t.Error("unable to query beta file metadata:", err)
+	} else if metadata.Mode()&0111 != 0 {
+		t.Error("beta file executability not reverted:", metadata.Mode())
+	}
+	if contents, err := os.ReadFile(path); err != nil {
+		t.Error("unable to read beta file:", err)
+	} else if string(contents) != "tool" {
+		t.Error("beta file contents modified")
+	}
+}
+
+func TestSynchronizationSocketPlaceholder(t *testing.T) {
+	// Unix domain sockets aren't surfaced as special files on Windows, so
+	// there's nothing to test there.
+	if runtime.GOOS == "windows" {
+		t.Skip()
+	}
+
+	// Allow this test to run in parallel.
+	t.Parallel()
+
+	// Calculate alpha and beta paths.
+	directory := t.TempDir()
+	alphaRoot := filepath.Join(directory, "alpha")
+	betaRoot := filepath.Join(directory, "beta")
+
+	// Create alpha with a regular file and a Unix domain socket.
+	if err := os.MkdirAll(filepath.Join(alphaRoot, "run"), 0755); err != nil {
+		t.Fatal("unable to create alpha root:", err)
+	} else if err = os.WriteFile(filepath.Join(alphaRoot, "file"), []byte("file"), 0644); err != nil {
+		t.Fatal("unable to create alpha file:", err)
+	}
+	listener, err := net.Listen("unix", filepath.Join(alphaRoot, "run", "socket"))
+	if err != nil {
+		t.Fatal("unable to create alpha socket:", err)
+	}
+	defer listener.Close()
+
+	// Compute alpha and beta URLs.
+	alphaURL := &url.URL{Path: alphaRoot}
+	betaURL := &url.URL{Path: betaRoot}
+
+	// Compute configuration.
+	configuration := &synchronization.Configuration{
+		SpecialFileMode: core.SpecialFileMode_SpecialFileModePlaceholder,
+	}
+
+	// Create a session.
+	ctx := context.Background()
+	sessionID, err := synchronizationManager.Create(
+		ctx,
+		alphaURL, betaURL,
+		configuration,
+		&synchronization.Configuration{},
+		&synchronization.Configuration{},
+		"testSynchronizationSession",
+		nil,
+		false,
+		false,
+		"",
+	)
+	if err != nil {
+		t.Fatal("unable to create session:", err)
+	}
+
+	// Create a session selection specification and ensure that the session is
+	// terminated when we're done.
+	selection := &selection.Selection{
+		Specifications: []string{sessionID},
+	}
+	defer func() {
+		if err := synchronizationManager.Terminate(ctx, selection, ""); err != nil {
+			t.Error("unable to terminate session:", err)
+		}
+	}()
+
+	// Wait for the initial synchronization cycle.
+	if err := waitForSuccessfulSynchronizationCycle(ctx, sessionID, false, false, false); err != nil {
+		t.Fatal("unable to wait for successful synchronization:", err)
+	}
+
+	// Verify that the socket was recorded as a placeholder on beta.
+	if contents, err := os.ReadFile(filepath.Join(betaRoot, "run", "socket")); err != nil {
+		t.Fatal("unable to read beta placeholder:", err)
+	} else if string(contents) != string(core.SpecialFileMarkerContent) {
+		t.Error("beta placeholder has incorrect contents")
+	}
+
+	// Force another synchronization cycle and verify that alpha's socket was
+	// left in place and that no conflicts were generated by the placeholder.
+	if err := synchronizationManager.Flush(ctx, selection, "", false, nil); err != nil {
+		t.Fatal("unable to flush session:", err)
+	}
+	if err := waitForSuccessfulSynchronizationCycle(ctx, sessionID, false, false, false); err != nil {
+		t.Fatal("unable to wait for successful synchronization:", err)
+	}
+	if metadata, err := os.Lstat(filepath.Join(alphaRoot, "run", "socket")); err != nil {
+		t.Error("unable to query alpha socket metadata:", err)
+	} else if metadata.Mode()&os.ModeSocket == 0 {
+		t.Error("alpha socket replaced:", metadata.Mode())
+	}
+}
+
+func TestSynchronizationHaltsOnConflictThreshold(t *testing.T) {
+	// Allow this test to run in parallel.
+	t.Parallel()
+
+	// Calculate alpha and beta paths.
+	directory := t.TempDir()
+	alphaRoot := filepath.Join(directory, "alpha")
+	betaRoot := filepath.Join(directory, "beta")
+
+	// Create alpha and beta with conflicting contents for several files.
+	for _, root := range []string{alphaRoot, betaRoot} {
+		if err := os.Mkdir(root, 0755); err != nil {
+			t.Fatal("unable to create root:", err)
+		}
+		for _, name := range []string{"first", "second", "third"} {
+			if err := os.WriteFile(filepath.Join(root, name), []byte(root), 0644); err != nil {
+				t.Fatal("unable to create file:", err)
+			}
+		}
+	}
+
+	// Compute alpha and beta URLs.
+	alphaURL := &url.URL{Path: alphaRoot}
+	betaURL := &url.URL{Path: betaRoot}
+
+	// Compute configuration.
+	configuration := &synchronization.Configuration{
+		MaximumConflictCount: 2,
+	}
+
+	// Create a session.
+	ctx := context.Background()
+	sessionID, err := synchronizationManager.Create(
+		ctx,
+		alphaURL, betaURL,
+		configuration,
+		&synchronization.Configuration{},
+		&synchronization.Configuration{},
+		"testSynchronizationSession",
+		nil,
+		false,
+		false,
+		"",
+	)
+	if err != nil {
+		t.Fatal("unable to create session:", err)
+	}
+
+	// Create a session selection specification and ensure that the session is
+	// terminated when we're done.
+	selection := &selection.Selection{
+		Specifications: []string{sessionID},
+	}
+	defer func() {
+		if err := synchronizationManager.Terminate(ctx, selection, ""); err != nil {
+			t.Error("unable to terminate session:", err)
+		}
+	}()
+
+	// Wait for the session to halt.
+	waitCtx, waitCancel := context.WithTimeout(ctx, time.Minute)
+	defer waitCancel()
+	var previousStateIndex uint64
+	var states []*synchronization.State
+	for {
+		previousStateIndex, states, err = synchronizationManager.List(waitCtx, selection, previousStateIndex)
+		if err != nil {
+			t.Fatal("unable to list session states:", err)
+		} else if len(states) != 1 {
+			t.Fatal("invalid number of session states returned")
+		} else if states[0].SuccessfulCycles > 0 {
+			t.Fatal("session completed synchronization cycle despite conflicts exceeding threshold")
+		} else if states[0].Status == synchronization.Status_HaltedOnConflictThreshold {
+			break
+		}
+	}
+
+	// Verify that the conflicts are still reported.
+	if len(states[0].Conflicts) != 3 {
+		t.Error("unexpected number of conflicts reported:", len(states[0].Conflicts), "!=", 3)
+	}
+}
+
+func TestSynchronizationContentAlreadyHeldByBeta(t *testing.T) {
+	// Allow this test to run in parallel.
+	t.Parallel()
+
+	// Calculate alpha and beta paths.
+	directory := t.TempDir()
+	alphaRoot := filepath.Join(directory, "alpha")
+	betaRoot := filepath.Join(directory, "beta")
+
+	// Create alpha with a file and beta with the same content at a different
+	// path (e.g. as if restored from a backup).
+	content := []byte("restored content")
+	if err := os.Mkdir(alphaRoot, 0755); err != nil {
+		t.Fatal("unable to create alpha root:", err)
+	} else if err = os.WriteFile(filepath.Join(alphaRoot, "file"), content, 0644); err != nil {
+		t.Fatal("unable to create alpha file:", err)
+	} else if err = os.MkdirAll(filepath.Join(betaRoot, "backup"), 0755); err != nil {
+		t.Fatal("unable to create beta root:", err)
+	} else if err = os.WriteFile(filepath.Join(betaRoot, "backup", "file"), content, 0644); err != nil {
+		t.Fatal("unable to create beta file:", err)
+	}
+
+	// Compute alpha and beta URLs.
+	alphaURL := &url.URL{Path: alphaRoot}
+	betaURL := &url.URL{Path: betaRoot}
+
+	// Create a session.
+	ctx := context.Background()
+	sessionID, err := synchronizationManager.Create(
+		ctx,
+		alphaURL, betaURL,
+		&synchronization.Configuration{},
+		&synchronization.Configuration{},
+		&synchronization.Configuration{},
+		"testSynchronizationSession",
+		nil,
+		false,
+		false,
+		"",
+	)
+	if err != nil {
+		t.Fatal("unable to create session:", err)
+	}
+
+	// Create a session selection specification and ensure that the session is
+	// terminated when we're done.
+	selection := &selection.Selection{
+		Specifications: []string{sessionID},
+	}
+	defer func() {
+		if err := synchronizationManager.Terminate(ctx, selection, ""); err != nil {
+			t.Error("unable to terminate session:", err)
+		}
+	}()
+
+	// Wait for the initial synchronization cycle.
+	if err := waitForSuccessfulSynchronizationCycle(ctx, sessionID, false, false, false); err != nil {
+		t.Fatal("unable to wait for successful synchronization:", err)
+	}
+
+	// Verify that both endpoints hold the content at both paths.
+	for _, root := range []string{alphaRoot, betaRoot} {
+		for _, path := range []string{"file", filepath.Join("backup", "file")} {
+			if data, err := os.ReadFile(filepath.Join(root, path)); err != nil {
+				t.Error("unable to read file:", err)
+			} else if string(data) != string(content) {
+				t.Errorf("file (%s) has incorrect contents", path)
+			}
+		}
+	}
+}
+
+func TestSynchronizationScopedFlush(t *testing.T) {
+	// Allow this test to run in parallel.
+	t.Parallel()
+
+	// Calculate alpha and beta paths.
+	directory := t.TempDir()
+	alphaRoot := filepath.Join(directory, "alpha")
+	betaRoot := filepath.Join(directory, "beta")
+
+	// Create alpha with two subtrees.
+	for _, subtree := range []string{"scoped", "unscoped"} {
+		if err := os.MkdirAll(filepath.Join(alphaRoot, subtree), 0755); err != nil {
+			t.Fatal("unable to create alpha subtree:", err)
+		} else if err = os.WriteFile(filepath.Join(alphaRoot, subtree, "initial"), []byte(subtree), 0644); err != nil {
+			t.Fatal("unable to create alpha file:", err)
+		}
+	}
+
+	// Compute alpha and beta URLs.
+	alphaURL := &url.URL{Path: alphaRoot}
+	betaURL := &url.URL{Path: betaRoot}
+
+	// Create a session that uses poll-based watching with a polling interval
+	// long enough that accelerated scans on alpha will re-use the baseline
+	// snapshot for the duration of the test.
+	ctx := context.Background()
+	sessionID, err := synchronizationManager.Create(
+		ctx,
+		alphaURL, betaURL,
+		&synchronization.Configuration{
+			WatchMode:            synchronization.WatchMode_WatchModeForcePoll,
+			WatchPollingInterval: 3600,
+		},
+		&synchronization.Configuration{},
+		&synchronization.Configuration{},
+		"testSynchronizationSession",
+		nil,
+		false,
+		false,
+		"",
+	)
+	if err != nil {
+		t.Fatal("unable to create session:", err)
+	}
+
+	// Create a session selection specification and ensure that the session is
+	// terminated when we're done.
+	selection := &selection.Selection{
+		Specifications: []string{sessionID},
+	}
+	defer func() {
+		if err := synchronizationManager.Terminate(ctx, selection, ""); err != nil {
+			t.Error("unable to terminate session:", err)
+		}
+	}()
+
+	// Wait for the initial synchronization cycle.
+	if err := waitForSuccessfulSynchronizationCycle(ctx, sessionID, false, false, false); err != nil {
+		t.Fatal("unable to wait for successful synchronization:", err)
+	}
+
+	// Create new files in both subtrees on alpha. These won't be seen by alpha's
+	// accelerated scans until the next polling operation.
+	for _, subtree := range []string{"scoped", "unscoped"} {
+		if err := os.WriteFile(filepath.Join(alphaRoot, subtree, "new"), []byte(subtree), 0644); err != nil {
+			t.Fatal("unable to create new alpha file:", err)
+		}
+	}
+
+	// Perform a scoped flush and verify that the scoped subtree has converged.
+	// We also verify that the other subtree hasn't, since that indicates that
+	// the flush didn't simply force a full scan.
+	if err := synchronizationManager.Flush(ctx, selection, "", false, []string{"scoped"}); err != nil {
+		t.Fatal("unable to perform scoped flush:", err)
+	}
+	if data, err := os.ReadFile(filepath.Join(betaRoot, "scoped", "new")); err != nil {
+		t.Error("scoped file not propagated to beta:", err)
+	} else if string(data) != "scoped" {
+		t.Error("scoped file has incorrect contents on beta")
+	}
+	if _, err := os.Lstat(filepath.Join(betaRoot, "unscoped", "new")); err == nil {
+		t.Error("unscoped file propagated to beta by scoped flush")
+	} else if !os.IsNotExist(err) {
+		t.Error("unable to query unscoped file on beta:", err)
+	}
+
+	// Perform an unscoped flush and verify that the other subtree converges.
+	if err := synchronizationManager.Flush(ctx, selection, "", false, nil); err != nil {
+		t.Fatal("unable to perform unscoped flush:", err)
+	}
+	if data, err := os.ReadFile(filepath.Join(betaRoot, "unscoped", "new")); err != nil {
+		t.Error("unscoped file not propagated to beta:", err)
+	} else if string(data) != "unscoped" {
+		t.Error("unscoped file has incorrect contents on beta")
+	}
+}
+
+func TestDaemonDump(t *testing.T) {
+	// Calculate alpha and beta paths.
+	directory := t.TempDir()
+	alphaRoot := filepath.Join(directory, "alpha")
+	betaRoot := filepath.Join(directory, "beta")
+
+	// Create alpha.
+	if err := os.Mkdir(alphaRoot, 0755); err != nil {
+		t.Fatal("unable to create alpha:", err)
+	} else if err = os.WriteFile(filepath.Join(alphaRoot, "file"), []byte("content"), 0644); err != nil {
+		t.Fatal("unable to create alpha file:", err)
+	}
+
+	// Create a session.
+	ctx := context.Background()
+	sessionID, err := synchronizationManager.Create(
+		ctx,
+		&url.URL{Path: alphaRoot}, &url.URL{Path: betaRoot},
+		&synchronization.Configuration{},
+		&synchronization.Configuration{},
+		&synchronization.Configuration{},
+		"testDaemonDumpSession",
+		nil,
+		false,
+		false,
+		"",
+	)
+	if err != nil {
+		t.Fatal("unable to create session:", err)
+	}
+
+	// Ensure that the session is terminated when we're done.
+	selection := &selection.Selection{
+		Specifications: []string{sessionID},
+	}
+	defer func() {
+		if err := synchronizationManager.Terminate(ctx, selection, ""); err != nil {
+			t.Error("unable to terminate session:", err)
+		}
+	}()
+
+	// Wait for the session to be running.
+	if err := waitForSuccessfulSynchronizationCycle(ctx, sessionID, false, false, false); err != nil {
+		t.Fatal("unable to wait for successful synchronization:", err)
+	}
+
+	// Perform a dump.
+	dump, err := daemonServer.Dump(ctx, &daemonsvc.DumpRequest{})
+	if err != nil {
+		t.Fatal("unable to perform dump:", err)
+	}
+
+	// Verify version, platform, and capability information.
+	if dump.Version == nil || dump.Version.Major != mutagen.VersionMajor {
+		t.Error("dump version information missing or incorrect")
+	}
+	if dump.OperatingSystem != runtime.GOOS || dump.Architecture != runtime.GOARCH {
+		t.Error("dump platform information incorrect")
+	} else if dump.GoVersion == "" {
+		t.Error("dump Go version missing")
+	}
+	if len(dump.HashingAlgorithms) == 0 {
+		t.Error("dump hashing algorithms missing")
+	} else if len(dump.CompressionAlgorithms) == 0 {
+		t.Error("dump compression algorithms missing")
+	}
+
+	// Verify resource statistics.
+	if dump.Stats == nil {
+		t.Fatal("dump statistics missing")
+	} else if dump.Stats.SynchronizationSessions < 1 {
+		t.Error("dump statistics do not reflect session")
+	}
+
+	// Verify that the session is included with its state and configuration.
+	var found bool
+	for _, state := range dump.SynchronizationSessions {
+		if state.Session.Identifier != sessionID {
+			continue
+		}
+		found = true
+		if state.Session.Name != "testDaemonDumpSession" {
+			t.Error("dumped session name incorrect")
+		} else if state.Session.Configuration == nil {
+			t.Error("dumped session configuration missing")
+		} else if state.SuccessfulCycles == 0 {
+			t.Error("dumped session state does not reflect synchronization")
+		} else if state.Session.Alpha.Path != alphaRoot {
+			t.Error("dumped session alpha URL incorrect")
+		}
+	}
+	if !found {
+		t.Error("session missing from dump")
+	}
+}
+
+func TestSynchronizationContentHeldByBetaUnsourced(t *testing.T) {
+	// Allow this test to run in parallel.
+	t.Parallel()
+
+	// Define configurations that prevent beta from staging the file from the
+	// content that it already holds: a rename detection size threshold below
+	// the file size, a truncated digest length (which disables the reverse
+	// lookup map), and a truncated rename detection digest length.
+	testCases := []*synchronization.Configuration{
+		{MaximumRenameDetectionFileSize: 1},
+		{DigestLength: 8},
+		{RenameDetectionDigestLength: 8},
+	}
+
+	// Process test cases.
+	for i, configuration := range testCases {
+		// Calculate alpha and beta paths.
+		directory := t.TempDir()
+		alphaRoot := filepath.Join(directory, "alpha")
+		betaRoot := filepath.Join(directory, "beta")
+
+		// Create alpha with a file and beta with the same content at a
+		// different path.
+		content := []byte("restored content")
+		if err := os.Mkdir(alphaRoot, 0755); err != nil {
+			t.Fatal("unable to create alpha root:", err)
+		} else if err = os.WriteFile(filepath.Join(alphaRoot, "file"), content, 0644); err != nil {
+			t.Fatal("unable to create alpha file:", err)
+		} else if err = os.MkdirAll(filepath.Join(betaRoot, "backup"), 0755); err != nil {
+			t.Fatal("unable to create beta root:", err)
+		} else if err = os.WriteFile(filepath.Join(betaRoot, "backup", "file"), content, 0644); err != nil {
+			t.Fatal("unable to create beta file:", err)
+		}
+
+		// Compute alpha and beta URLs.
+		alphaURL := &url.URL{Path: alphaRoot}
+		betaURL := &url.URL{Path: betaRoot}
+
+		// Create a session.
+		ctx := context.Background()
+		sessionID, err := synchronizationManager.Create(
+			ctx,
+			alphaURL, betaURL,
+			configuration,
+			&synchronization.Configuration{},
+			&synchronization.Configuration{},
+			"testSynchronizationSession",
+			nil,
+			false,
+			false,
+			"",
+		)
+		if err != nil {
+			t.Fatalf("unable to create session (test case %d): %v", i, err)
+		}
+		selection := &selection.Selection{
+			Specifications: []string{sessionID},
+		}
+
+		// Wait for the initial synchronization cycle. The file should be
+		// supplied normally, so no transition problems are allowed.
+		if err := waitForSuccessfulSynchronizationCycle(ctx, sessionID, false, false, false); err != nil {
+			t.Errorf("unable to wait for successful synchronization (test case %d): %v", i, err)
+		}
+
+		// Verify that both endpoints have converged on the content at both
+		// paths.
+		for _, root := range []string{alphaRoot, betaRoot} {
+			for _, path := range []string{"file", filepath.Join("backup", "file")} {
+				if data, err := os.ReadFile(filepath.Join(root, path)); err != nil {
+					t.Errorf("unable to read file (test case %d): %v", i, err)
+				} else if string(data) != string(content) {
+					t.Errorf("file (%s) has incorrect contents (test case %d)", path, i)
+				}
+			}
+		}
+
+		// Terminate the session.
+		if err := synchronizationManager.Terminate(ctx, selection, ""); err != nil {
+			t.Errorf("unable to terminate session (test case %d): %v", i, err)
+		}
+	}
+}
+
 func TestSynchronizationGOROOTSrcToBetaInMemory(t *testing.T) {
 	// Define configuration variations.
 	testCases := []*synchronization.Configuration{
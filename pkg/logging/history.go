@@ -0,0 +1,77 @@
+package logging
+
+import (
+	"strings"
+	"sync"
+)
+
+// History is an io.Writer that retains the most recent log lines written to
+// it, for example to support post-mortem diagnostics. It's intended to be used
+// (potentially via io.MultiWriter) as the writer for a Logger, which writes
+// exactly one complete line per write operation. It is safe for concurrent
+// usage. A nil History is valid, discards writes, and retains no lines.
+type History struct {
+	// lock serializes access to the history.
+	lock sync.Mutex
+	// lines is the circular buffer of retained lines.
+	lines []string
+	// next is the index at which the next line will be stored.
+	next int
+	// full indicates whether or not the circular buffer has wrapped.
+	full bool
+}
+
+// NewHistory creates a new history that retains up to the specified number of
+// lines. The capacity must be greater than 0, otherwise this function will
+// panic.
+func NewHistory(capacity int) *History {
+	// Validate the capacity.
+	if capacity < 1 {
+		panic("invalid history capacity")
+	}
+
+	// Create the history.
+	return &History{lines: make([]string, capacity)}
+}
+
+// Write implements io.Writer.Write.
+func (h *History) Write(buffer []byte) (int, error) {
+	// If the history is nil, then discard the write.
+	if h == nil {
+		return len(buffer), nil
+	}
+
+	// Record the line, without its trailing newline.
+	line := strings.TrimSuffix(string(buffer), "\n")
+	h.lock.Lock()
+	h.lines[h.next] = line
+	h.next++
+	if h.next == len(h.lines) {
+		h.next = 0
+		h.full = true
+	}
+	h.lock.Unlock()
+
+	// Success.
+	return len(buffer), nil
+}
+
+// Lines returns a copy of the retained lines, from oldest to newest.
+func (h *History) Lines() []string {
+	// If the history is nil, then there are no lines.
+	if h == nil {
+		return nil
+	}
+
+	// Lock the history and defer its release.
+	h.lock.Lock()
+	defer h.lock.Unlock()
+
+	// Copy the retained lines.
+	if !h.full {
+		return append([]string(nil), h.lines[:h.next]...)
+	}
+	result := make([]string, 0, len(h.lines))
+	result = append(result, h.lines[h.next:]...)
+	return append(result, h.lines[:h.next]...)
+}
@@ -0,0 +1,38 @@
+package logging
+
+import (
+	"fmt"
+	"slices"
+	"testing"
+)
+
+// TestHistory tests that History retains the most recent lines in order.
+func TestHistory(t *testing.T) {
+	// Create a history and verify that it's initially empty.
+	history := NewHistory(3)
+	if lines := history.Lines(); len(lines) != 0 {
+		t.Fatal("new history not empty:", lines)
+	}
+
+	// Write lines and verify retention before and after wrapping.
+	for i := 0; i < 5; i++ {
+		fmt.Fprintf(history, "line %d\n", i)
+		var expected []string
+		for j := max(0, i-2); j <= i; j++ {
+			expected = append(expected, fmt.Sprintf("line %d", j))
+		}
+		if lines := history.Lines(); !slices.Equal(lines, expected) {
+			t.Errorf("lines after write %d do not match expected: %v != %v", i, lines, expected)
+		}
+	}
+}
+
+// TestHistoryNil tests that a nil History discards writes.
+func TestHistoryNil(t *testing.T) {
+	var history *History
+	if n, err := history.Write([]byte("line\n")); err != nil || n != 5 {
+		t.Error("nil history write failed")
+	} else if lines := history.Lines(); lines != nil {
+		t.Error("nil history returned lines:", lines)
+	}
+}
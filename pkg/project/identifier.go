@@ -0,0 +1,61 @@
+package project
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+
+	"github.com/mutagen-io/mutagen/pkg/filesystem/locking"
+	"github.com/mutagen-io/mutagen/pkg/identifier"
+)
+
+// ReadIdentifier reads the identifier of the running project defined by the
+// specified configuration file. The identifier is read from the project lock
+// file (while holding the project lock) in the same manner as the project
+// commands. An error is returned if the project isn't running.
+func ReadIdentifier(configurationFile string) (string, error) {
+	// Compute the lock path and verify that the lock file exists. We perform
+	// this check to avoid creating the lock file if the project has never been
+	// started.
+	lockPath := configurationFile + LockFileExtension
+	if _, err := os.Stat(lockPath); err != nil {
+		if errors.Is(err, fs.ErrNotExist) {
+			return "", errors.New("project not running")
+		}
+		return "", fmt.Errorf("unable to probe project lock: %w", err)
+	}
+
+	// Create a locker and defer its closure.
+	locker, err := locking.NewLocker(lockPath, 0600)
+	if err != nil {
+		return "", fmt.Errorf("unable to create project locker: %w", err)
+	}
+	defer locker.Close()
+
+	// Acquire the project lock and defer its release.
+	if err := locker.Lock(true); err != nil {
+		return "", fmt.Errorf("unable to acquire project lock: %w", err)
+	}
+	defer locker.Unlock()
+
+	// Read the project identifier from the lock file. If the lock file is
+	// empty, then the project isn't running. We leave the removal of empty lock
+	// files to the project commands.
+	buffer := &bytes.Buffer{}
+	if length, err := buffer.ReadFrom(locker); err != nil {
+		return "", fmt.Errorf("unable to read project lock: %w", err)
+	} else if length == 0 {
+		return "", errors.New("project not running")
+	}
+	projectIdentifier := buffer.String()
+
+	// Ensure that the project identifier is valid.
+	if !identifier.IsValid(projectIdentifier) {
+		return "", errors.New("invalid project identifier found in project lock")
+	}
+
+	// Success.
+	return projectIdentifier, nil
+}
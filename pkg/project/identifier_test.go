@@ -0,0 +1,84 @@
+package project
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/mutagen-io/mutagen/pkg/identifier"
+	"github.com/mutagen-io/mutagen/pkg/selection"
+)
+
+// TestReadIdentifierNotRunning tests that ReadIdentifier fails for projects
+// that have never been started or that have been terminated.
+func TestReadIdentifierNotRunning(t *testing.T) {
+	// Create a project configuration file.
+	configurationFile := filepath.Join(t.TempDir(), DefaultConfigurationFileName)
+	if err := os.WriteFile(configurationFile, nil, 0600); err != nil {
+		t.Fatal("unable to create configuration file:", err)
+	}
+
+	// Verify that reading fails without a lock file and that no lock file is
+	// created in the process.
+	lockPath := configurationFile + LockFileExtension
+	if _, err := ReadIdentifier(configurationFile); err == nil {
+		t.Error("identifier read succeeded without lock file")
+	}
+	if _, err := os.Lstat(lockPath); !os.IsNotExist(err) {
+		t.Error("lock file created by identifier read")
+	}
+
+	// Verify that reading fails with an empty lock file.
+	if err := os.WriteFile(lockPath, nil, 0600); err != nil {
+		t.Fatal("unable to create lock file:", err)
+	}
+	if _, err := ReadIdentifier(configurationFile); err == nil {
+		t.Error("identifier read succeeded with empty lock file")
+	}
+}
+
+// TestReadIdentifierFiltering tests that the identifier read from the lock file
+// of a running project can be used to select that project's sessions.
+func TestReadIdentifierFiltering(t *testing.T) {
+	// Create a project configuration file and a lock file recording a project
+	// identifier in the same manner as project startup.
+	configurationFile := filepath.Join(t.TempDir(), DefaultConfigurationFileName)
+	if err := os.WriteFile(configurationFile, nil, 0600); err != nil {
+		t.Fatal("unable to create configuration file:", err)
+	}
+	projectIdentifier, err := identifier.New(identifier.PrefixProject)
+	if err != nil {
+		t.Fatal("unable to generate project identifier:", err)
+	}
+	lockPath := configurationFile + LockFileExtension
+	if err := os.WriteFile(lockPath, []byte(projectIdentifier), 0600); err != nil {
+		t.Fatal("unable to create lock file:", err)
+	}
+
+	// Read the identifier.
+	read, err := ReadIdentifier(configurationFile)
+	if err != nil {
+		t.Fatal("unable to read project identifier:", err)
+	} else if read != projectIdentifier {
+		t.Fatalf("read project identifier (%s) does not match expected (%s)", read, projectIdentifier)
+	}
+
+	// Compute and parse the restricted selector.
+	restricted, err := RestrictLabelSelector("", read)
+	if err != nil {
+		t.Fatal("unable to restrict label selector:", err)
+	}
+	selector, err := selection.ParseLabelSelector(restricted)
+	if err != nil {
+		t.Fatal("unable to parse restricted label selector:", err)
+	}
+
+	// Verify that sessions labeled by project startup are matched and that
+	// sessions labeled with the project name are not.
+	if !selector.Matches(map[string]string{LabelKey: projectIdentifier}) {
+		t.Error("selector did not match project session")
+	}
+	if selector.Matches(map[string]string{LabelKey: "mutagen"}) {
+		t.Error("selector matched session labeled with project name")
+	}
+}
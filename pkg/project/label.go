@@ -1,7 +1,48 @@
 package project
 
+import (
+	"errors"
+	"fmt"
+
+	"github.com/mutagen-io/mutagen/pkg/selection"
+)
+
 const (
 	// LabelKey is the label key that's applied to sessions orchestrated by the
-	// project, with the label value being the orchestration session identifier.
+	// project, with the label value being the project identifier (as recorded
+	// in the project lock file). It also serves as the record of project
+	// membership for session filtering.
 	LabelKey = "io.mutagen.project"
 )
+
+// LabelSelector returns a label selector that matches sessions belonging to the
+// specified project, i.e. those whose LabelKey label has the specified value.
+// The project must be a valid label value.
+func LabelSelector(project string) (string, error) {
+	// Validate the project.
+	if project == "" {
+		return "", errors.New("empty project")
+	} else if err := selection.EnsureLabelValueValid(project); err != nil {
+		return "", fmt.Errorf("invalid project: %w", err)
+	}
+
+	// Create the selector.
+	return fmt.Sprintf("%s=%s", LabelKey, project), nil
+}
+
+// RestrictLabelSelector combines an existing (potentially empty) label selector
+// with a restriction to sessions belonging to the specified project. The
+// resulting selector matches only those sessions matched by both.
+func RestrictLabelSelector(labelSelector, project string) (string, error) {
+	// Create the project selector.
+	projectSelector, err := LabelSelector(project)
+	if err != nil {
+		return "", err
+	}
+
+	// Combine the selectors.
+	if labelSelector == "" {
+		return projectSelector, nil
+	}
+	return labelSelector + "," + projectSelector, nil
+}
@@ -0,0 +1,72 @@
+package project
+
+import (
+	"testing"
+
+	"github.com/mutagen-io/mutagen/pkg/selection"
+)
+
+// TestLabelSelectorInvalid tests that LabelSelector rejects invalid projects.
+func TestLabelSelectorInvalid(t *testing.T) {
+	for _, project := range []string{"", "has space", "-leading", "a=b"} {
+		if _, err := LabelSelector(project); err == nil {
+			t.Errorf("label selector creation succeeded unexpectedly for project \"%s\"", project)
+		}
+	}
+}
+
+// TestRestrictLabelSelectorFiltering tests that label selectors computed by
+// RestrictLabelSelector filter a mixed set of sessions by project.
+func TestRestrictLabelSelectorFiltering(t *testing.T) {
+	// Define a mixed set of session labels.
+	sessions := map[string]map[string]string{
+		"foo-web":       {LabelKey: "foo", "tier": "web"},
+		"foo-db":        {LabelKey: "foo", "tier": "db"},
+		"bar-web":       {LabelKey: "bar", "tier": "web"},
+		"unlabeled":     nil,
+		"other-labeled": {"tier": "web"},
+	}
+
+	// Define test cases.
+	tests := []struct {
+		labelSelector string
+		project       string
+		expected      []string
+	}{
+		{"", "foo", []string{"foo-db", "foo-web"}},
+		{"", "bar", []string{"bar-web"}},
+		{"", "baz", nil},
+		{"tier=web", "foo", []string{"foo-web"}},
+		{"tier!=web", "foo", []string{"foo-db"}},
+	}
+
+	// Process test cases.
+	for _, test := range tests {
+		// Compute and parse the restricted selector.
+		restricted, err := RestrictLabelSelector(test.labelSelector, test.project)
+		if err != nil {
+			t.Errorf("unable to restrict label selector (%s) to project (%s): %v",
+				test.labelSelector, test.project, err,
+			)
+			continue
+		}
+		selector, err := selection.ParseLabelSelector(restricted)
+		if err != nil {
+			t.Errorf("unable to parse restricted label selector (%s): %v", restricted, err)
+			continue
+		}
+
+		// Verify that the expected sessions are matched.
+		expected := make(map[string]bool, len(test.expected))
+		for _, name := range test.expected {
+			expected[name] = true
+		}
+		for name, labels := range sessions {
+			if matched := selector.Matches(labels); matched && !expected[name] {
+				t.Errorf("selector (%s) unexpectedly matched session %s", restricted, name)
+			} else if !matched && expected[name] {
+				t.Errorf("selector (%s) did not match session %s", restricted, name)
+			}
+		}
+	}
+}
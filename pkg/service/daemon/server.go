@@ -2,16 +2,28 @@ package daemon
 
 import (
 	"context"
+	"fmt"
+	"runtime"
+	"sort"
 	"time"
 
+	"github.com/mutagen-io/mutagen/pkg/forwarding"
 	"github.com/mutagen-io/mutagen/pkg/housekeeping"
+	"github.com/mutagen-io/mutagen/pkg/logging"
 	"github.com/mutagen-io/mutagen/pkg/mutagen"
+	"github.com/mutagen-io/mutagen/pkg/selection"
+	"github.com/mutagen-io/mutagen/pkg/synchronization"
+	"github.com/mutagen-io/mutagen/pkg/synchronization/compression"
+	"github.com/mutagen-io/mutagen/pkg/synchronization/hashing"
+	"github.com/mutagen-io/mutagen/pkg/url"
 )
 
 const (
 	// housekeepingInterval is the interval at which housekeeping will be
 	// invoked by the daemon.
 	housekeepingInterval = 24 * time.Hour
+	// redactedValue is the value used in place of redacted values in dumps.
+	redactedValue = "<redacted>"
 )
 
 // Server provides an implementation of the Daemon service.
@@ -26,6 +38,15 @@ type Server struct {
 	// just bounce off once the channel is populated. We do this, instead of
 	// closing the channel, because we can't close the channel multiple times.
 	Termination chan struct{}
+	// forwardingManager is the forwarding session manager used for computing
+	// resource usage statistics.
+	forwardingManager *forwarding.Manager
+	// synchronizationManager is the synchronization session manager used for
+	// computing resource usage statistics.
+	synchronizationManager *synchronization.Manager
+	// logHistory is the daemon's log history, used for diagnostic dumps. It
+	// may be nil.
+	logHistory *logging.History
 	// workerCtx is the context regulating the server's internal operations.
 	workerCtx context.Context
 	// shutdown is the context cancellation function for the server's internal
@@ -33,16 +54,21 @@ type Server struct {
 	shutdown context.CancelFunc
 }
 
-// NewServer creates a new daemon server.
-func NewServer() *Server {
+// NewServer creates a new daemon server. The provided session managers are used
+// to compute resource usage statistics and diagnostic dumps. The provided log
+// history is included in diagnostic dumps and may be nil.
+func NewServer(forwardingManager *forwarding.Manager, synchronizationManager *synchronization.Manager, logHistory *logging.History) *Server {
 	// Create a cancellable context for daemon background operations.
 	workerCtx, shutdown := context.WithCancel(context.Background())
 
 	// Create the server.
 	server := &Server{
-		Termination: make(chan struct{}, 1),
-		workerCtx:   workerCtx,
-		shutdown:    shutdown,
+		Termination:            make(chan struct{}, 1),
+		forwardingManager:      forwardingManager,
+		synchronizationManager: synchronizationManager,
+		logHistory:             logHistory,
+		workerCtx:              workerCtx,
+		shutdown:               shutdown,
 	}
 
 	// Start the housekeeping Goroutine.
@@ -100,3 +126,129 @@ func (s *Server) Terminate(_ context.Context, _ *TerminateRequest) (*TerminateRe
 	// Success.
 	return &TerminateResponse{}, nil
 }
+
+// Stats provides aggregate resource usage statistics.
+func (s *Server) Stats(_ context.Context, request *StatsRequest) (*StatsResponse, error) {
+	// Compute synchronization resource usage.
+	usage, err := s.synchronizationManager.Usage(request.IncludeDiskUsage)
+	if err != nil {
+		return nil, err
+	}
+
+	// Compute forwarding resource usage.
+	forwardingSessions, forwardingConnections := s.forwardingManager.Usage()
+
+	// Success.
+	return &StatsResponse{
+		SynchronizationSessions:    usage.Sessions,
+		ForwardingSessions:         forwardingSessions,
+		TrackedEntries:             usage.TrackedEntries,
+		SnapshotMemory:             usage.SnapshotMemory,
+		StagingDiskUsage:           usage.StagingDiskUsage,
+		SynchronizationConnections: usage.Connections,
+		ForwardingConnections:      forwardingConnections,
+	}, nil
+}
+
+// redactURL redacts the values of sensitive components of a URL in-place. URL
+// environment variables and transport parameters may contain credentials, so
+// their keys are preserved but their values are replaced.
+func redactURL(u *url.URL) {
+	if u == nil {
+		return
+	}
+	for key := range u.Environment {
+		u.Environment[key] = redactedValue
+	}
+	for key := range u.Parameters {
+		u.Parameters[key] = redactedValue
+	}
+}
+
+// supportedHashingAlgorithms returns descriptions of the hashing algorithms
+// supported by the daemon.
+func supportedHashingAlgorithms() (result []string) {
+	algorithms := make([]int, 0, len(hashing.Algorithm_name))
+	for value := range hashing.Algorithm_name {
+		algorithms = append(algorithms, int(value))
+	}
+	sort.Ints(algorithms)
+	for _, value := range algorithms {
+		algorithm := hashing.Algorithm(value)
+		if algorithm != hashing.Algorithm_AlgorithmDefault &&
+			algorithm.SupportStatus() == hashing.AlgorithmSupportStatusSupported {
+			result = append(result, algorithm.Description())
+		}
+	}
+	return
+}
+
+// supportedCompressionAlgorithms returns descriptions of the compression
+// algorithms supported by the daemon.
+func supportedCompressionAlgorithms() (result []string) {
+	algorithms := make([]int, 0, len(compression.Algorithm_name))
+	for value := range compression.Algorithm_name {
+		algorithms = append(algorithms, int(value))
+	}
+	sort.Ints(algorithms)
+	for _, value := range algorithms {
+		algorithm := compression.Algorithm(value)
+		if algorithm != compression.Algorithm_AlgorithmDefault &&
+			algorithm.SupportStatus() == compression.AlgorithmSupportStatusSupported {
+			result = append(result, algorithm.Description())
+		}
+	}
+	return
+}
+
+// Dump provides a diagnostic dump of the daemon's internal state. It's composed
+// entirely of data that the daemon already holds, and session states are
+// captured using the same (non-blocking) mechanism used for session listing, so
+// it won't interfere with synchronization or forwarding.
+func (s *Server) Dump(ctx context.Context, _ *DumpRequest) (*DumpResponse, error) {
+	// Compute version information.
+	version, err := s.Version(ctx, &VersionRequest{})
+	if err != nil {
+		return nil, fmt.Errorf("unable to compute version information: %w", err)
+	}
+
+	// Compute resource usage statistics.
+	stats, err := s.Stats(ctx, &StatsRequest{})
+	if err != nil {
+		return nil, fmt.Errorf("unable to compute resource usage statistics: %w", err)
+	}
+
+	// Capture session states. Listing returns copies of session states, so we
+	// can redact them in-place.
+	all := &selection.Selection{All: true}
+	_, synchronizationStates, err := s.synchronizationManager.List(ctx, all, 0)
+	if err != nil {
+		return nil, fmt.Errorf("unable to list synchronization sessions: %w", err)
+	}
+	for _, state := range synchronizationStates {
+		redactURL(state.Session.Alpha)
+		redactURL(state.Session.Beta)
+	}
+	_, forwardingStates, err := s.forwardingManager.List(ctx, all, 0)
+	if err != nil {
+		return nil, fmt.Errorf("unable to list forwarding sessions: %w", err)
+	}
+	for _, state := range forwardingStates {
+		redactURL(state.Session.Source)
+		redactURL(state.Session.Destination)
+	}
+
+	// Success.
+	return &DumpResponse{
+		Version:                 version,
+		OperatingSystem:         runtime.GOOS,
+		Architecture:            runtime.GOARCH,
+		GoVersion:               runtime.Version(),
+		HashingAlgorithms:       supportedHashingAlgorithms(),
+		CompressionAlgorithms:   supportedCompressionAlgorithms(),
+		Stats:                   stats,
+		SynchronizationSessions: synchronizationStates,
+		ForwardingSessions:      forwardingStates,
+		Logs:                    s.logHistory.Lines(),
+	}, nil
+}
@@ -40,6 +40,7 @@ func (s *Server) Create(ctx context.Context, request *CreateRequest) (*CreateRes
 		request.Specification.Name,
 		request.Specification.Labels,
 		request.Specification.Paused,
+		request.Specification.DeterministicIdentifier,
 		request.Prompter,
 	)
 	if err != nil {
@@ -78,7 +79,7 @@ func (s *Server) Flush(ctx context.Context, request *FlushRequest) (*FlushRespon
 	}
 
 	// Perform flushing.
-	if err := s.manager.Flush(ctx, request.Selection, request.Prompter, request.SkipWait); err != nil {
+	if err := s.manager.Flush(ctx, request.Selection, request.Prompter, request.SkipWait, request.Scope); err != nil {
 		return nil, err
 	}
 
@@ -86,6 +87,40 @@ func (s *Server) Flush(ctx context.Context, request *FlushRequest) (*FlushRespon
 	return &FlushResponse{}, nil
 }
 
+// Fetch fetches a single file's content from a session endpoint.
+func (s *Server) Fetch(ctx context.Context, request *FetchRequest) (*FetchResponse, error) {
+	// Validate the request.
+	if err := request.ensureValid(); err != nil {
+		return nil, fmt.Errorf("invalid fetch request: %w", err)
+	}
+
+	// Perform fetching.
+	content, err := s.manager.Fetch(ctx, request.Selection, request.Beta, request.Path)
+	if err != nil {
+		return nil, err
+	}
+
+	// Success.
+	return &FetchResponse{Content: content}, nil
+}
+
+// Preview previews the next synchronization cycle for a paused session.
+func (s *Server) Preview(ctx context.Context, request *PreviewRequest) (*PreviewResponse, error) {
+	// Validate the request.
+	if err := request.ensureValid(); err != nil {
+		return nil, fmt.Errorf("invalid preview request: %w", err)
+	}
+
+	// Compute the preview.
+	preview, err := s.manager.Preview(ctx, request.Selection, request.Prompter)
+	if err != nil {
+		return nil, err
+	}
+
+	// Success.
+	return &PreviewResponse{Preview: preview}, nil
+}
+
 // Pause pauses sessions.
 func (s *Server) Pause(ctx context.Context, request *PauseRequest) (*PauseResponse, error) {
 	// Validate the request.
@@ -60,6 +60,9 @@ func (s *CreationSpecification) ensureValid() error {
 
 	// There's no need to validate the Paused field - either value is valid.
 
+	// There's no need to validate the DeterministicIdentifier field - either
+	// value is valid.
+
 	// Success.
 	return nil
 }
@@ -156,6 +159,8 @@ func (r *FlushRequest) ensureValid() error {
 
 	// Any value of SkipWait is considered valid.
 
+	// The scope is validated and normalized by the session manager.
+
 	// Success.
 	return nil
 }
@@ -171,6 +176,80 @@ func (r *FlushResponse) EnsureValid() error {
 	return nil
 }
 
+// ensureValid verifies that a FetchRequest is valid.
+func (r *FetchRequest) ensureValid() error {
+	// A nil fetch request is not valid.
+	if r == nil {
+		return errors.New("nil fetch request")
+	}
+
+	// Ensure that the session selection is valid.
+	if err := r.Selection.EnsureValid(); err != nil {
+		return fmt.Errorf("invalid selection specification: %w", err)
+	}
+
+	// Any value of Beta is considered valid.
+
+	// Ensure that a path has been specified. The path is otherwise validated
+	// and normalized by the session manager.
+	if r.Path == "" {
+		return errors.New("no path specified")
+	}
+
+	// Success.
+	return nil
+}
+
+// EnsureValid verifies that a FetchResponse is valid.
+func (r *FetchResponse) EnsureValid() error {
+	// A nil fetch response is not valid.
+	if r == nil {
+		return errors.New("nil fetch response")
+	}
+
+	// Any content is considered valid.
+
+	// Success.
+	return nil
+}
+
+// ensureValid verifies that a PreviewRequest is valid.
+func (r *PreviewRequest) ensureValid() error {
+	// A nil preview request is not valid.
+	if r == nil {
+		return errors.New("nil preview request")
+	}
+
+	// Ensure that a prompter has been specified.
+	if r.Prompter == "" {
+		return errors.New("no prompter specified")
+	}
+
+	// Ensure that the session selection is valid.
+	if err := r.Selection.EnsureValid(); err != nil {
+		return fmt.Errorf("invalid selection specification: %w", err)
+	}
+
+	// Success.
+	return nil
+}
+
+// EnsureValid verifies that a PreviewResponse is valid.
+func (r *PreviewResponse) EnsureValid() error {
+	// A nil preview response is not valid.
+	if r == nil {
+		return errors.New("nil preview response")
+	}
+
+	// Ensure that the preview is valid.
+	if err := r.Preview.EnsureValid(); err != nil {
+		return fmt.Errorf("invalid preview: %w", err)
+	}
+
+	// Success.
+	return nil
+}
+
 // ensureValid verifies that a PauseRequest is valid.
 func (r *PauseRequest) ensureValid() error {
 	// A nil pause request is not valid.
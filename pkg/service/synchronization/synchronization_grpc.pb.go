@@ -22,6 +22,8 @@ const (
 	Synchronization_Create_FullMethodName    = "/synchronization.Synchronization/Create"
 	Synchronization_List_FullMethodName      = "/synchronization.Synchronization/List"
 	Synchronization_Flush_FullMethodName     = "/synchronization.Synchronization/Flush"
+	Synchronization_Fetch_FullMethodName     = "/synchronization.Synchronization/Fetch"
+	Synchronization_Preview_FullMethodName   = "/synchronization.Synchronization/Preview"
 	Synchronization_Pause_FullMethodName     = "/synchronization.Synchronization/Pause"
 	Synchronization_Resume_FullMethodName    = "/synchronization.Synchronization/Resume"
 	Synchronization_Reset_FullMethodName     = "/synchronization.Synchronization/Reset"
@@ -40,6 +42,10 @@ type SynchronizationClient interface {
 	List(ctx context.Context, in *ListRequest, opts ...grpc.CallOption) (*ListResponse, error)
 	// Flush flushes sessions.
 	Flush(ctx context.Context, in *FlushRequest, opts ...grpc.CallOption) (*FlushResponse, error)
+	// Fetch fetches a single file's content from a session endpoint.
+	Fetch(ctx context.Context, in *FetchRequest, opts ...grpc.CallOption) (*FetchResponse, error)
+	// Preview previews the next synchronization cycle for a paused session.
+	Preview(ctx context.Context, in *PreviewRequest, opts ...grpc.CallOption) (*PreviewResponse, error)
 	// Pause pauses sessions.
 	Pause(ctx context.Context, in *PauseRequest, opts ...grpc.CallOption) (*PauseResponse, error)
 	// Resume resumes paused or disconnected sessions.
@@ -88,6 +94,26 @@ func (c *synchronizationClient) Flush(ctx context.Context, in *FlushRequest, opt
 	return out, nil
 }
 
+func (c *synchronizationClient) Fetch(ctx context.Context, in *FetchRequest, opts ...grpc.CallOption) (*FetchResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(FetchResponse)
+	err := c.cc.Invoke(ctx, Synchronization_Fetch_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *synchronizationClient) Preview(ctx context.Context, in *PreviewRequest, opts ...grpc.CallOption) (*PreviewResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(PreviewResponse)
+	err := c.cc.Invoke(ctx, Synchronization_Preview_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *synchronizationClient) Pause(ctx context.Context, in *PauseRequest, opts ...grpc.CallOption) (*PauseResponse, error) {
 	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
 	out := new(PauseResponse)
@@ -140,6 +166,10 @@ type SynchronizationServer interface {
 	List(context.Context, *ListRequest) (*ListResponse, error)
 	// Flush flushes sessions.
 	Flush(context.Context, *FlushRequest) (*FlushResponse, error)
+	// Fetch fetches a single file's content from a session endpoint.
+	Fetch(context.Context, *FetchRequest) (*FetchResponse, error)
+	// Preview previews the next synchronization cycle for a paused session.
+	Preview(context.Context, *PreviewRequest) (*PreviewResponse, error)
 	// Pause pauses sessions.
 	Pause(context.Context, *PauseRequest) (*PauseResponse, error)
 	// Resume resumes paused or disconnected sessions.
@@ -167,6 +197,12 @@ func (UnimplementedSynchronizationServer) List(context.Context, *ListRequest) (*
 func (UnimplementedSynchronizationServer) Flush(context.Context, *FlushRequest) (*FlushResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method Flush not implemented")
 }
+func (UnimplementedSynchronizationServer) Fetch(context.Context, *FetchRequest) (*FetchResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Fetch not implemented")
+}
+func (UnimplementedSynchronizationServer) Preview(context.Context, *PreviewRequest) (*PreviewResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Preview not implemented")
+}
 func (UnimplementedSynchronizationServer) Pause(context.Context, *PauseRequest) (*PauseResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method Pause not implemented")
 }
@@ -254,6 +290,42 @@ func _Synchronization_Flush_Handler(srv interface{}, ctx context.Context, dec fu
 	return interceptor(ctx, in, info, handler)
 }
 
+func _Synchronization_Fetch_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(FetchRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SynchronizationServer).Fetch(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Synchronization_Fetch_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SynchronizationServer).Fetch(ctx, req.(*FetchRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Synchronization_Preview_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(PreviewRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SynchronizationServer).Preview(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Synchronization_Preview_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SynchronizationServer).Preview(ctx, req.(*PreviewRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _Synchronization_Pause_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(PauseRequest)
 	if err := dec(in); err != nil {
@@ -345,6 +417,14 @@ var Synchronization_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "Flush",
 			Handler:    _Synchronization_Flush_Handler,
 		},
+		{
+			MethodName: "Fetch",
+			Handler:    _Synchronization_Fetch_Handler,
+		},
+		{
+			MethodName: "Preview",
+			Handler:    _Synchronization_Preview_Handler,
+		},
 		{
 			MethodName: "Pause",
 			Handler:    _Synchronization_Pause_Handler,
@@ -9,6 +9,11 @@ import (
 	"github.com/mutagen-io/mutagen/pkg/platform"
 )
 
+// ClientErrorExitCode is the exit code used by the OpenSSH client when an error
+// occurs within the client itself (e.g. a connection failure), as opposed to
+// the exit code of the remote command.
+const ClientErrorExitCode = 255
+
 // CompressionFlag returns a flag that can be passed to scp or ssh to enable
 // compression. Note that while SSH does have a CompressionLevel configuration
 // option, this only applies to SSHv1. SSHv2 defaults to a DEFLATE level of 6,
@@ -0,0 +1,73 @@
+package synchronization
+
+import (
+	"fmt"
+)
+
+// IsDefault indicates whether or not the aggregate digest mode is
+// AggregateDigestMode_AggregateDigestModeDefault.
+func (m AggregateDigestMode) IsDefault() bool {
+	return m == AggregateDigestMode_AggregateDigestModeDefault
+}
+
+// MarshalText implements encoding.TextMarshaler.MarshalText.
+func (m AggregateDigestMode) MarshalText() ([]byte, error) {
+	var result string
+	switch m {
+	case AggregateDigestMode_AggregateDigestModeDefault:
+	case AggregateDigestMode_AggregateDigestModeDisabled:
+		result = "disabled"
+	case AggregateDigestMode_AggregateDigestModeEnabled:
+		result = "enabled"
+	default:
+		result = "unknown"
+	}
+	return []byte(result), nil
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler.UnmarshalText.
+func (m *AggregateDigestMode) UnmarshalText(textBytes []byte) error {
+	// Convert the bytes to a string.
+	text := string(textBytes)
+
+	// Convert to a aggregate digest mode.
+	switch text {
+	case "disabled":
+		*m = AggregateDigestMode_AggregateDigestModeDisabled
+	case "enabled":
+		*m = AggregateDigestMode_AggregateDigestModeEnabled
+	default:
+		return fmt.Errorf("unknown aggregate digest mode specification: %s", text)
+	}
+
+	// Success.
+	return nil
+}
+
+// Supported indicates whether or not a particular aggregate digest mode is a
+// valid, non-default value.
+func (m AggregateDigestMode) Supported() bool {
+	switch m {
+	case AggregateDigestMode_AggregateDigestModeDisabled:
+		return true
+	case AggregateDigestMode_AggregateDigestModeEnabled:
+		return true
+	default:
+		return false
+	}
+}
+
+// Description returns a human-readable description of an aggregate digest
+// mode.
+func (m AggregateDigestMode) Description() string {
+	switch m {
+	case AggregateDigestMode_AggregateDigestModeDefault:
+		return "Default"
+	case AggregateDigestMode_AggregateDigestModeDisabled:
+		return "Disabled"
+	case AggregateDigestMode_AggregateDigestModeEnabled:
+		return "Enabled"
+	default:
+		return "Unknown"
+	}
+}
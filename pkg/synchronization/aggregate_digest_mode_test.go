@@ -0,0 +1,91 @@
+package synchronization
+
+import (
+	"testing"
+)
+
+// TestAggregateDigestModeUnmarshal tests that unmarshaling from a string
+// specification succeeeds for AggregateDigestMode.
+func TestAggregateDigestModeUnmarshal(t *testing.T) {
+	// Set up test cases.
+	testCases := []struct {
+		text          string
+		expectedMode  AggregateDigestMode
+		expectFailure bool
+	}{
+		{"", AggregateDigestMode_AggregateDigestModeDefault, true},
+		{"asdf", AggregateDigestMode_AggregateDigestModeDefault, true},
+		{"disabled", AggregateDigestMode_AggregateDigestModeDisabled, false},
+		{"enabled", AggregateDigestMode_AggregateDigestModeEnabled, false},
+	}
+
+	// Process test cases.
+	for _, testCase := range testCases {
+		var mode AggregateDigestMode
+		if err := mode.UnmarshalText([]byte(testCase.text)); err != nil {
+			if !testCase.expectFailure {
+				t.Errorf("unable to unmarshal text (%s): %s", testCase.text, err)
+			}
+		} else if testCase.expectFailure {
+			t.Error("unmarshaling succeeded unexpectedly for text:", testCase.text)
+		} else if mode != testCase.expectedMode {
+			t.Errorf(
+				"unmarshaled mode (%s) does not match expected (%s)",
+				mode,
+				testCase.expectedMode,
+			)
+		}
+	}
+}
+
+// TestAggregateDigestModeSupported tests that AggregateDigestMode support
+// detection works as expected.
+func TestAggregateDigestModeSupported(t *testing.T) {
+	// Set up test cases.
+	testCases := []struct {
+		mode            AggregateDigestMode
+		expectSupported bool
+	}{
+		{AggregateDigestMode_AggregateDigestModeDefault, false},
+		{AggregateDigestMode_AggregateDigestModeDisabled, true},
+		{AggregateDigestMode_AggregateDigestModeEnabled, true},
+		{(AggregateDigestMode_AggregateDigestModeEnabled + 1), false},
+	}
+
+	// Process test cases.
+	for _, testCase := range testCases {
+		if supported := testCase.mode.Supported(); supported != testCase.expectSupported {
+			t.Errorf(
+				"mode support status (%t) does not match expected (%t)",
+				supported,
+				testCase.expectSupported,
+			)
+		}
+	}
+}
+
+// TestAggregateDigestModeDescription tests that AggregateDigestMode
+// description generation works as expected.
+func TestAggregateDigestModeDescription(t *testing.T) {
+	// Set up test cases.
+	testCases := []struct {
+		mode                AggregateDigestMode
+		expectedDescription string
+	}{
+		{AggregateDigestMode_AggregateDigestModeDefault, "Default"},
+		{AggregateDigestMode_AggregateDigestModeDisabled, "Disabled"},
+		{AggregateDigestMode_AggregateDigestModeEnabled, "Enabled"},
+		{(AggregateDigestMode_AggregateDigestModeEnabled + 1), "Unknown"},
+	}
+
+	// Process test cases.
+	for _, testCase := range testCases {
+		if description := testCase.mode.Description(); description != testCase.expectedDescription {
+			t.Errorf(
+				"mode description (%s) does not match expected (%s)",
+				description,
+				testCase.expectedDescription,
+			)
+		}
+	}
+}
@@ -0,0 +1,255 @@
+package synchronization
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"hash/crc32"
+	"math/rand"
+	"os"
+
+	"google.golang.org/protobuf/encoding/protowire"
+	"google.golang.org/protobuf/proto"
+
+	"github.com/mutagen-io/mutagen/pkg/filesystem"
+	"github.com/mutagen-io/mutagen/pkg/synchronization/core"
+)
+
+const (
+	// archiveLogSuffix is the suffix appended to an archive path to compute the
+	// path of its change log.
+	archiveLogSuffix = ".log"
+	// archiveLogRecordChecksumSize is the size of the checksum that follows
+	// each record in an archive change log.
+	archiveLogRecordChecksumSize = 4
+)
+
+// archiveLogMinimumCompactionSize is the minimum change log size at which an
+// archive's change log will be compacted into its base archive. Above this
+// size, compaction occurs once the change log is larger than the base archive.
+// It is a variable so that it can be lowered for testing.
+var archiveLogMinimumCompactionSize int64 = 1024 * 1024
+
+// archiveLogChecksumTable is the CRC-32 table used for change log checksums.
+var archiveLogChecksumTable = crc32.MakeTable(crc32.Castagnoli)
+
+// archiveStore manages the on-disk persistence of a session's archive. It
+// stores a base archive alongside an append-only change log so that small
+// ancestor updates don't require rewriting the entire archive. The change log
+// is compacted into the base archive once it becomes large relative to the base
+// archive. Each full write of the base archive assigns it a new generation, and
+// change log records are only replayed onto a base archive with a matching
+// generation, so records left behind by an interrupted compaction are ignored.
+// An archiveStore is not safe for concurrent usage.
+type archiveStore struct {
+	// path is the path to the base archive.
+	path string
+	// generation is the generation of the base archive.
+	generation uint64
+	// baseSize is the size of the base archive.
+	baseSize int64
+	// logSize is the size of the valid portion of the change log.
+	logSize int64
+}
+
+// newArchiveStore creates a new archive store for the archive at the specified
+// path.
+func newArchiveStore(path string) *archiveStore {
+	return &archiveStore{path: path}
+}
+
+// logPath returns the path to the change log.
+func (s *archiveStore) logPath() string {
+	return s.path + archiveLogSuffix
+}
+
+// load loads the base archive and replays any valid change log records on top
+// of it. If the change log ends with an incomplete or corrupt record (e.g. due
+// to a crash mid-write), then replay stops at that record and the change log is
+// truncated to its valid prefix. Since the change log only ever records ancestor
+// updates, losing trailing records can only result in an outdated ancestor,
+// which reconciliation already tolerates. The resulting archive is not
+// validated, so callers should validate it with EnsureValid.
+func (s *archiveStore) load() (*core.Archive, error) {
+	// Load the base archive.
+	data, err := os.ReadFile(s.path)
+	if err != nil {
+		return nil, fmt.Errorf("unable to load base archive: %w", err)
+	}
+	archive := &core.Archive{}
+	if err := proto.Unmarshal(data, archive); err != nil {
+		return nil, fmt.Errorf("unable to unmarshal base archive: %w", err)
+	}
+	s.generation = archive.Generation
+	s.baseSize = int64(len(data))
+	s.logSize = 0
+
+	// Load the change log, if any.
+	data, err = os.ReadFile(s.logPath())
+	if err != nil {
+		if os.IsNotExist(err) {
+			return archive, nil
+		}
+		return nil, fmt.Errorf("unable to load change log: %w", err)
+	}
+
+	// Replay valid change log records.
+	var offset int
+	for offset < len(data) {
+		// Decode the record length.
+		length, n := protowire.ConsumeVarint(data[offset:])
+		if n < 0 {
+			break
+		}
+
+		// Verify that the record and its checksum are complete and intact.
+		start := offset + n
+		end := start + int(length)
+		if length > uint64(len(data)) || end+archiveLogRecordChecksumSize > len(data) {
+			break
+		}
+		checksum := binary.LittleEndian.Uint32(data[end:])
+		if crc32.Checksum(data[start:end], archiveLogChecksumTable) != checksum {
+			break
+		}
+
+		// Decode the record.
+		record := &ArchiveLogRecord{}
+		if err := proto.Unmarshal(data[start:end], record); err != nil {
+			break
+		}
+		offset = end + archiveLogRecordChecksumSize
+
+		// Apply the record if it corresponds to the base archive.
+		if record.Generation != s.generation {
+			continue
+		}
+		if archive.Content, err = core.Apply(archive.Content, record.Changes); err != nil {
+			return nil, fmt.Errorf("unable to apply change log record: %w", err)
+		}
+	}
+
+	// If we encountered an invalid record, then truncate the change log to its
+	// valid prefix so that subsequent records aren't appended after it.
+	if offset < len(data) {
+		if err := os.Truncate(s.logPath(), int64(offset)); err != nil {
+			return nil, fmt.Errorf("unable to truncate corrupt change log: %w", err)
+		}
+	}
+	s.logSize = int64(offset)
+
+	// Success.
+	return archive, nil
+}
+
+// save writes the archive in full as a new base archive generation and removes
+// the change log.
+func (s *archiveStore) save(archive *core.Archive) error {
+	// Assign a new generation to the archive. We avoid a zero-valued
+	// generation since that's the generation of archives written before the
+	// introduction of change logs.
+	generation := rand.Uint64()
+	for generation == 0 || generation == s.generation {
+		generation = rand.Uint64()
+	}
+	archive.Generation = generation
+
+	// Write the base archive.
+	data, err := proto.Marshal(archive)
+	if err != nil {
+		return fmt.Errorf("unable to marshal archive: %w", err)
+	}
+	if err := filesystem.WriteFileAtomic(s.path, data, 0600); err != nil {
+		return fmt.Errorf("unable to save base archive: %w", err)
+	}
+	s.generation = generation
+	s.baseSize = int64(len(data))
+
+	// Remove the change log. If this fails, then any remaining records will be
+	// ignored on load due to their generation mismatch, but we still report the
+	// failure since the change log will continue to occupy space.
+	s.logSize = 0
+	if err := os.Remove(s.logPath()); err != nil && !os.IsNotExist(err) {
+		return fmt.Errorf("unable to remove change log: %w", err)
+	}
+
+	// Success.
+	return nil
+}
+
+// append records changes that have been applied to the archive. It appends
+// the changes to the change log unless the change log has grown large enough
+// to warrant compaction, in which case it writes the archive in full. It also
+// falls back to a full write if appending fails.
+func (s *archiveStore) append(archive *core.Archive, changes []*core.Change) error {
+	// If the change log is large enough, then compact it.
+	if s.logSize >= max(s.baseSize, archiveLogMinimumCompactionSize) {
+		return s.save(archive)
+	}
+
+	// Encode the record, omitting old values from changes.
+	record := &ArchiveLogRecord{
+		Generation: s.generation,
+		Changes:    make([]*core.Change, len(changes)),
+	}
+	for c, change := range changes {
+		record.Changes[c] = &core.Change{Path: change.Path, New: change.New}
+	}
+	payload, err := proto.Marshal(record)
+	if err != nil {
+		return fmt.Errorf("unable to marshal change log record: %w", err)
+	}
+	data := protowire.AppendVarint(nil, uint64(len(payload)))
+	data = append(data, payload...)
+	data = binary.LittleEndian.AppendUint32(data, crc32.Checksum(payload, archiveLogChecksumTable))
+
+	// Append the record and ensure that it's persisted. If this fails, then
+	// the change log may contain a partial record, so fall back to a full
+	// write, which will discard the change log.
+	if err := s.appendToLog(data); err != nil {
+		if saveErr := s.save(archive); saveErr != nil {
+			return fmt.Errorf("unable to append to change log (%v) or save archive: %w", err, saveErr)
+		}
+		return nil
+	}
+	s.logSize += int64(len(data))
+
+	// Success.
+	return nil
+}
+
+// appendToLog appends data to the change log and syncs it to disk.
+func (s *archiveStore) appendToLog(data []byte) error {
+	// Open the change log.
+	log, err := os.OpenFile(s.logPath(), os.O_WRONLY|os.O_CREATE|os.O_APPEND, 0600)
+	if err != nil {
+		return fmt.Errorf("unable to open change log: %w", err)
+	}
+
+	// Write and sync the data.
+	if _, err := log.Write(data); err != nil {
+		log.Close()
+		return fmt.Errorf("unable to write change log: %w", err)
+	} else if err := log.Sync(); err != nil {
+		log.Close()
+		return fmt.Errorf("unable to sync change log: %w", err)
+	}
+
+	// Close the change log.
+	if err := log.Close(); err != nil {
+		return fmt.Errorf("unable to close change log: %w", err)
+	}
+
+	// Success.
+	return nil
+}
+
+// remove removes the base archive and change log.
+func (s *archiveStore) remove() error {
+	baseErr := os.Remove(s.path)
+	logErr := os.Remove(s.logPath())
+	if logErr != nil && os.IsNotExist(logErr) {
+		logErr = nil
+	}
+	return errors.Join(baseErr, logErr)
+}
@@ -0,0 +1,199 @@
+package synchronization
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/mutagen-io/mutagen/pkg/synchronization/core"
+)
+
+// testingArchiveChanges returns a sequence of ancestor change sets for
+// testing, along with the ancestor resulting from applying each set in order.
+func testingArchiveChanges(t *testing.T) ([][]*core.Change, []*core.Entry) {
+	// Mark this as a helper function.
+	t.Helper()
+
+	// Define the change sets.
+	changes := [][]*core.Change{
+		{{Path: "", New: &core.Entry{Kind: core.EntryKind_Directory}}},
+		{{Path: "file", New: &core.Entry{Kind: core.EntryKind_File, Digest: []byte{1}}}},
+		{
+			{Path: "directory", New: &core.Entry{Kind: core.EntryKind_Directory}},
+			{Path: "directory/link", New: &core.Entry{Kind: core.EntryKind_SymbolicLink, Target: "../file"}},
+		},
+		{{Path: "file", Old: &core.Entry{Kind: core.EntryKind_File, Digest: []byte{1}}}},
+	}
+
+	// Compute the resulting ancestors.
+	ancestors := make([]*core.Entry, len(changes))
+	var ancestor *core.Entry
+	for c, set := range changes {
+		var err error
+		if ancestor, err = core.Apply(ancestor, set); err != nil {
+			t.Fatal("unable to apply changes:", err)
+		}
+		ancestors[c] = ancestor
+	}
+
+	// Done.
+	return changes, ancestors
+}
+
+// TestArchiveStore tests that archive changes recorded via archiveStore are
+// replayed on load and that the change log is compacted once it's large.
+func TestArchiveStore(t *testing.T) {
+	// Create an initial archive.
+	path := filepath.Join(t.TempDir(), "archive")
+	if err := newArchiveStore(path).save(&core.Archive{}); err != nil {
+		t.Fatal("unable to save initial archive:", err)
+	}
+
+	// Record changes, verifying that they're replayed after each save.
+	changes, ancestors := testingArchiveChanges(t)
+	for c, set := range changes {
+		archives := newArchiveStore(path)
+		archive, err := archives.load()
+		if err != nil {
+			t.Fatalf("change set %d: unable to load archive: %v", c, err)
+		}
+		archive.Content = ancestors[c]
+		if err := archives.append(archive, set); err != nil {
+			t.Fatalf("change set %d: unable to record changes: %v", c, err)
+		}
+		if archive, err = newArchiveStore(path).load(); err != nil {
+			t.Fatalf("change set %d: unable to reload archive: %v", c, err)
+		} else if err = archive.EnsureValid(true); err != nil {
+			t.Fatalf("change set %d: reloaded archive invalid: %v", c, err)
+		} else if !archive.Content.Equal(ancestors[c], true) {
+			t.Errorf("change set %d: reloaded ancestor does not match expected", c)
+		}
+	}
+
+	// Verify that changes were recorded in the change log.
+	if _, err := os.Stat(newArchiveStore(path).logPath()); err != nil {
+		t.Fatal("unable to find change log:", err)
+	}
+
+	// Lower the compaction threshold and verify that the next save compacts
+	// the change log.
+	defer func(threshold int64) {
+		archiveLogMinimumCompactionSize = threshold
+	}(archiveLogMinimumCompactionSize)
+	archiveLogMinimumCompactionSize = 1
+	archives := newArchiveStore(path)
+	archive, err := archives.load()
+	if err != nil {
+		t.Fatal("unable to load archive:", err)
+	}
+	compactionChanges := []*core.Change{{Path: "directory"}}
+	if archive.Content, err = core.Apply(ancestors[len(ancestors)-1], compactionChanges); err != nil {
+		t.Fatal("unable to apply compaction changes:", err)
+	}
+	if err := archives.append(archive, compactionChanges); err != nil {
+		t.Fatal("unable to record compaction changes:", err)
+	}
+	if _, err := os.Stat(archives.logPath()); !os.IsNotExist(err) {
+		t.Error("change log not removed by compaction")
+	}
+	if reloaded, err := newArchiveStore(path).load(); err != nil {
+		t.Fatal("unable to reload compacted archive:", err)
+	} else if !reloaded.Content.Equal(archive.Content, true) {
+		t.Error("compacted ancestor does not match expected")
+	}
+}
+
+// TestArchiveStoreTruncatedLog tests that a change log truncated mid-write is
+// recovered by discarding the incomplete record.
+func TestArchiveStoreTruncatedLog(t *testing.T) {
+	// Create an initial archive and record the first two change sets.
+	path := filepath.Join(t.TempDir(), "archive")
+	archives := newArchiveStore(path)
+	archive := &core.Archive{}
+	if err := archives.save(archive); err != nil {
+		t.Fatal("unable to save initial archive:", err)
+	}
+	changes, ancestors := testingArchiveChanges(t)
+	for c := 0; c < 2; c++ {
+		archive.Content = ancestors[c]
+		if err := archives.append(archive, changes[c]); err != nil {
+			t.Fatalf("change set %d: unable to record changes: %v", c, err)
+		}
+	}
+
+	// Truncate the change log in the middle of the second record.
+	info, err := os.Stat(archives.logPath())
+	if err != nil {
+		t.Fatal("unable to query change log:", err)
+	}
+	if err := os.Truncate(archives.logPath(), info.Size()-3); err != nil {
+		t.Fatal("unable to truncate change log:", err)
+	}
+
+	// Verify that loading recovers the ancestor from the intact record and
+	// trims the incomplete record from the change log.
+	archives = newArchiveStore(path)
+	if archive, err = archives.load(); err != nil {
+		t.Fatal("unable to load archive with truncated change log:", err)
+	} else if err = archive.EnsureValid(true); err != nil {
+		t.Fatal("recovered archive invalid:", err)
+	} else if !archive.Content.Equal(ancestors[0], true) {
+		t.Error("recovered ancestor does not match expected")
+	}
+	if info, err := os.Stat(archives.logPath()); err != nil {
+		t.Fatal("unable to query recovered change log:", err)
+	} else if info.Size() != archives.logSize {
+		t.Error("incomplete record not trimmed from change log")
+	}
+
+	// Verify that subsequently recorded changes are replayed correctly.
+	archive.Content = ancestors[1]
+	if err := archives.append(archive, changes[1]); err != nil {
+		t.Fatal("unable to record changes after recovery:", err)
+	}
+	if archive, err = newArchiveStore(path).load(); err != nil {
+		t.Fatal("unable to reload recovered archive:", err)
+	} else if !archive.Content.Equal(ancestors[1], true) {
+		t.Error("ancestor after recovery does not match expected")
+	}
+}
+
+// TestArchiveStoreStaleLog tests that change log records left behind by an
+// interrupted compaction aren't replayed onto the new base archive.
+func TestArchiveStoreStaleLog(t *testing.T) {
+	// Create an initial archive and record changes.
+	path := filepath.Join(t.TempDir(), "archive")
+	archives := newArchiveStore(path)
+	archive := &core.Archive{}
+	if err := archives.save(archive); err != nil {
+		t.Fatal("unable to save initial archive:", err)
+	}
+	changes, ancestors := testingArchiveChanges(t)
+	for c := 0; c < 2; c++ {
+		archive.Content = ancestors[c]
+		if err := archives.append(archive, changes[c]); err != nil {
+			t.Fatalf("change set %d: unable to record changes: %v", c, err)
+		}
+	}
+
+	// Simulate a compaction that was interrupted after writing the new base
+	// archive but before removing the change log.
+	log, err := os.ReadFile(archives.logPath())
+	if err != nil {
+		t.Fatal("unable to read change log:", err)
+	}
+	archive.Content = &core.Entry{Kind: core.EntryKind_Directory}
+	if err := archives.save(archive); err != nil {
+		t.Fatal("unable to save archive:", err)
+	}
+	if err := os.WriteFile(archives.logPath(), log, 0600); err != nil {
+		t.Fatal("unable to restore change log:", err)
+	}
+
+	// Verify that the stale records are ignored.
+	if archive, err := newArchiveStore(path).load(); err != nil {
+		t.Fatal("unable to load archive with stale change log:", err)
+	} else if !archive.Content.Equal(&core.Entry{Kind: core.EntryKind_Directory}, true) {
+		t.Error("stale change log records replayed")
+	}
+}
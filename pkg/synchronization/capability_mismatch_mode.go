@@ -0,0 +1,73 @@
+package synchronization
+
+import (
+	"fmt"
+)
+
+// IsDefault indicates whether or not the capability mismatch mode is
+// CapabilityMismatchMode_CapabilityMismatchModeDefault.
+func (m CapabilityMismatchMode) IsDefault() bool {
+	return m == CapabilityMismatchMode_CapabilityMismatchModeDefault
+}
+
+// MarshalText implements encoding.TextMarshaler.MarshalText.
+func (m CapabilityMismatchMode) MarshalText() ([]byte, error) {
+	var result string
+	switch m {
+	case CapabilityMismatchMode_CapabilityMismatchModeDefault:
+	case CapabilityMismatchMode_CapabilityMismatchModeAllow:
+		result = "allow"
+	case CapabilityMismatchMode_CapabilityMismatchModeHalt:
+		result = "halt"
+	default:
+		result = "unknown"
+	}
+	return []byte(result), nil
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler.UnmarshalText.
+func (m *CapabilityMismatchMode) UnmarshalText(textBytes []byte) error {
+	// Convert the bytes to a string.
+	text := string(textBytes)
+
+	// Convert to a capability mismatch mode.
+	switch text {
+	case "allow":
+		*m = CapabilityMismatchMode_CapabilityMismatchModeAllow
+	case "halt":
+		*m = CapabilityMismatchMode_CapabilityMismatchModeHalt
+	default:
+		return fmt.Errorf("unknown capability mismatch mode specification: %s", text)
+	}
+
+	// Success.
+	return nil
+}
+
+// Supported indicates whether or not a particular capability mismatch mode is a
+// valid, non-default value.
+func (m CapabilityMismatchMode) Supported() bool {
+	switch m {
+	case CapabilityMismatchMode_CapabilityMismatchModeAllow:
+		return true
+	case CapabilityMismatchMode_CapabilityMismatchModeHalt:
+		return true
+	default:
+		return false
+	}
+}
+
+// Description returns a human-readable description of a capability mismatch
+// mode.
+func (m CapabilityMismatchMode) Description() string {
+	switch m {
+	case CapabilityMismatchMode_CapabilityMismatchModeDefault:
+		return "Default"
+	case CapabilityMismatchMode_CapabilityMismatchModeAllow:
+		return "Allow"
+	case CapabilityMismatchMode_CapabilityMismatchModeHalt:
+		return "Halt"
+	default:
+		return "Unknown"
+	}
+}
@@ -0,0 +1,91 @@
+package synchronization
+
+import (
+	"testing"
+)
+
+// TestCapabilityMismatchModeUnmarshal tests that unmarshaling from a string
+// specification succeeeds for CapabilityMismatchMode.
+func TestCapabilityMismatchModeUnmarshal(t *testing.T) {
+	// Set up test cases.
+	testCases := []struct {
+		text          string
+		expectedMode  CapabilityMismatchMode
+		expectFailure bool
+	}{
+		{"", CapabilityMismatchMode_CapabilityMismatchModeDefault, true},
+		{"asdf", CapabilityMismatchMode_CapabilityMismatchModeDefault, true},
+		{"allow", CapabilityMismatchMode_CapabilityMismatchModeAllow, false},
+		{"halt", CapabilityMismatchMode_CapabilityMismatchModeHalt, false},
+	}
+
+	// Process test cases.
+	for _, testCase := range testCases {
+		var mode CapabilityMismatchMode
+		if err := mode.UnmarshalText([]byte(testCase.text)); err != nil {
+			if !testCase.expectFailure {
+				t.Errorf("unable to unmarshal text (%s): %s", testCase.text, err)
+			}
+		} else if testCase.expectFailure {
+			t.Error("unmarshaling succeeded unexpectedly for text:", testCase.text)
+		} else if mode != testCase.expectedMode {
+			t.Errorf(
+				"unmarshaled mode (%s) does not match expected (%s)",
+				mode,
+				testCase.expectedMode,
+			)
+		}
+	}
+}
+
+// TestCapabilityMismatchModeSupported tests that CapabilityMismatchMode support
+// detection works as expected.
+func TestCapabilityMismatchModeSupported(t *testing.T) {
+	// Set up test cases.
+	testCases := []struct {
+		mode            CapabilityMismatchMode
+		expectSupported bool
+	}{
+		{CapabilityMismatchMode_CapabilityMismatchModeDefault, false},
+		{CapabilityMismatchMode_CapabilityMismatchModeAllow, true},
+		{CapabilityMismatchMode_CapabilityMismatchModeHalt, true},
+		{(CapabilityMismatchMode_CapabilityMismatchModeHalt + 1), false},
+	}
+
+	// Process test cases.
+	for _, testCase := range testCases {
+		if supported := testCase.mode.Supported(); supported != testCase.expectSupported {
+			t.Errorf(
+				"mode support status (%t) does not match expected (%t)",
+				supported,
+				testCase.expectSupported,
+			)
+		}
+	}
+}
+
+// TestCapabilityMismatchModeDescription tests that CapabilityMismatchMode
+// description generation works as expected.
+func TestCapabilityMismatchModeDescription(t *testing.T) {
+	// Set up test cases.
+	testCases := []struct {
+		mode                CapabilityMismatchMode
+		expectedDescription string
+	}{
+		{CapabilityMismatchMode_CapabilityMismatchModeDefault, "Default"},
+		{CapabilityMismatchMode_CapabilityMismatchModeAllow, "Allow"},
+		{CapabilityMismatchMode_CapabilityMismatchModeHalt, "Halt"},
+		{(CapabilityMismatchMode_CapabilityMismatchModeHalt + 1), "Unknown"},
+	}
+
+	// Process test cases.
+	for _, testCase := range testCases {
+		if description := testCase.mode.Description(); description != testCase.expectedDescription {
+			t.Errorf(
+				"mode description (%s) does not match expected (%s)",
+				description,
+				testCase.expectedDescription,
+			)
+		}
+	}
+}
@@ -0,0 +1,72 @@
+package synchronization
+
+import (
+	"fmt"
+)
+
+// IsDefault indicates whether or not the clock skew mode is
+// ClockSkewMode_ClockSkewModeDefault.
+func (m ClockSkewMode) IsDefault() bool {
+	return m == ClockSkewMode_ClockSkewModeDefault
+}
+
+// MarshalText implements encoding.TextMarshaler.MarshalText.
+func (m ClockSkewMode) MarshalText() ([]byte, error) {
+	var result string
+	switch m {
+	case ClockSkewMode_ClockSkewModeDefault:
+	case ClockSkewMode_ClockSkewModeWarn:
+		result = "warn"
+	case ClockSkewMode_ClockSkewModeRefuse:
+		result = "refuse"
+	default:
+		result = "unknown"
+	}
+	return []byte(result), nil
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler.UnmarshalText.
+func (m *ClockSkewMode) UnmarshalText(textBytes []byte) error {
+	// Convert the bytes to a string.
+	text := string(textBytes)
+
+	// Convert to a clock skew mode.
+	switch text {
+	case "warn":
+		*m = ClockSkewMode_ClockSkewModeWarn
+	case "refuse":
+		*m = ClockSkewMode_ClockSkewModeRefuse
+	default:
+		return fmt.Errorf("unknown clock skew mode specification: %s", text)
+	}
+
+	// Success.
+	return nil
+}
+
+// Supported indicates whether or not a particular clock skew mode is a valid,
+// non-default value.
+func (m ClockSkewMode) Supported() bool {
+	switch m {
+	case ClockSkewMode_ClockSkewModeWarn:
+		return true
+	case ClockSkewMode_ClockSkewModeRefuse:
+		return true
+	default:
+		return false
+	}
+}
+
+// Description returns a human-readable description of a clock skew mode.
+func (m ClockSkewMode) Description() string {
+	switch m {
+	case ClockSkewMode_ClockSkewModeDefault:
+		return "Default"
+	case ClockSkewMode_ClockSkewModeWarn:
+		return "Warn"
+	case ClockSkewMode_ClockSkewModeRefuse:
+		return "Refuse"
+	default:
+		return "Unknown"
+	}
+}
@@ -0,0 +1,91 @@
+package synchronization
+
+import (
+	"testing"
+)
+
+// TestClockSkewModeUnmarshal tests that unmarshaling from a string
+// specification succeeeds for ClockSkewMode.
+func TestClockSkewModeUnmarshal(t *testing.T) {
+	// Set up test cases.
+	testCases := []struct {
+		text          string
+		expectedMode  ClockSkewMode
+		expectFailure bool
+	}{
+		{"", ClockSkewMode_ClockSkewModeDefault, true},
+		{"asdf", ClockSkewMode_ClockSkewModeDefault, true},
+		{"warn", ClockSkewMode_ClockSkewModeWarn, false},
+		{"refuse", ClockSkewMode_ClockSkewModeRefuse, false},
+	}
+
+	// Process test cases.
+	for _, testCase := range testCases {
+		var mode ClockSkewMode
+		if err := mode.UnmarshalText([]byte(testCase.text)); err != nil {
+			if !testCase.expectFailure {
+				t.Errorf("unable to unmarshal text (%s): %s", testCase.text, err)
+			}
+		} else if testCase.expectFailure {
+			t.Error("unmarshaling succeeded unexpectedly for text:", testCase.text)
+		} else if mode != testCase.expectedMode {
+			t.Errorf(
+				"unmarshaled mode (%s) does not match expected (%s)",
+				mode,
+				testCase.expectedMode,
+			)
+		}
+	}
+}
+
+// TestClockSkewModeSupported tests that ClockSkewMode support detection works
+// as expected.
+func TestClockSkewModeSupported(t *testing.T) {
+	// Set up test cases.
+	testCases := []struct {
+		mode            ClockSkewMode
+		expectSupported bool
+	}{
+		{ClockSkewMode_ClockSkewModeDefault, false},
+		{ClockSkewMode_ClockSkewModeWarn, true},
+		{ClockSkewMode_ClockSkewModeRefuse, true},
+		{(ClockSkewMode_ClockSkewModeRefuse + 1), false},
+	}
+
+	// Process test cases.
+	for _, testCase := range testCases {
+		if supported := testCase.mode.Supported(); supported != testCase.expectSupported {
+			t.Errorf(
+				"mode support status (%t) does not match expected (%t)",
+				supported,
+				testCase.expectSupported,
+			)
+		}
+	}
+}
+
+// TestClockSkewModeDescription tests that ClockSkewMode description generation
+// works as expected.
+func TestClockSkewModeDescription(t *testing.T) {
+	// Set up test cases.
+	testCases := []struct {
+		mode                ClockSkewMode
+		expectedDescription string
+	}{
+		{ClockSkewMode_ClockSkewModeDefault, "Default"},
+		{ClockSkewMode_ClockSkewModeWarn, "Warn"},
+		{ClockSkewMode_ClockSkewModeRefuse, "Refuse"},
+		{(ClockSkewMode_ClockSkewModeRefuse + 1), "Unknown"},
+	}
+
+	// Process test cases.
+	for _, testCase := range testCases {
+		if description := testCase.mode.Description(); description != testCase.expectedDescription {
+			t.Errorf(
+				"mode description (%s) does not match expected (%s)",
+				description,
+				testCase.expectedDescription,
+			)
+		}
+	}
+}
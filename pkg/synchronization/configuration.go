@@ -8,9 +8,23 @@ import (
 	"github.com/mutagen-io/mutagen/pkg/filesystem"
 	"github.com/mutagen-io/mutagen/pkg/synchronization/compression"
 	"github.com/mutagen-io/mutagen/pkg/synchronization/core"
+	"github.com/mutagen-io/mutagen/pkg/synchronization/core/ignore"
 	"github.com/mutagen-io/mutagen/pkg/synchronization/hashing"
+	"github.com/mutagen-io/mutagen/pkg/synchronization/rsync"
 )
 
+// MaximumStreamConcurrency is the maximum number of concurrent request streams
+// that can be multiplexed over the connection to a remote endpoint.
+const MaximumStreamConcurrency = 16
+
+// MaximumDirectoryListingRetries is the maximum number of times that a
+// directory listing can be re-read during scanning.
+const MaximumDirectoryListingRetries = 10
+
+// MaximumRenameDetectionRetries is the maximum number of times that staging
+// from an existing file can be retried during rename and copy detection.
+const MaximumRenameDetectionRetries = 10
+
 // EnsureValid ensures that Configuration's invariants are respected. The
 // validation of the configuration depends on whether or not it is
 // endpoint-specific.
@@ -37,7 +51,9 @@ func (c *Configuration) EnsureValid(endpointSpecific bool) error {
 			return errors.New("hashing algorithm cannot be specified on an endpoint-specific basis")
 		}
 	} else {
-		if !c.HashingAlgorithm.IsDefault() {
+		if c.HashingAlgorithm.IsUnregistered() {
+			return errors.New("hashing algorithm has not been registered")
+		} else if !c.HashingAlgorithm.IsDefault() {
 			supportStatus := c.HashingAlgorithm.SupportStatus()
 			if supportStatus == hashing.AlgorithmSupportStatusUnsupported {
 				return errors.New("unknown or unsupported hashing algorithm")
@@ -53,6 +69,15 @@ func (c *Configuration) EnsureValid(endpointSpecific bool) error {
 	// The maximum staging file size doesn't need to be validated - any of its
 	// values are technically valid regardless of the source.
 
+	// The maximum signature memory doesn't need to be validated - any of its
+	// values are technically valid regardless of the source.
+
+	// Verify that the maximum conflict count is unspecified for
+	// endpoint-specific configurations. Any of its values are otherwise valid.
+	if endpointSpecific && c.MaximumConflictCount != 0 {
+		return errors.New("maximum conflict count cannot be specified on an endpoint-specific basis")
+	}
+
 	// Verify that the probe mode is unspecified or supported.
 	if !(c.ProbeMode.IsDefault() || c.ProbeMode.Supported()) {
 		return errors.New("unknown or unsupported probe mode")
@@ -63,11 +88,26 @@ func (c *Configuration) EnsureValid(endpointSpecific bool) error {
 		return errors.New("unknown or unsupported scan mode")
 	}
 
+	// Verify that the scan sharing mode is unspecified or supported.
+	if !(c.ScanSharingMode.IsDefault() || c.ScanSharingMode.Supported()) {
+		return errors.New("unknown or unsupported scan sharing mode")
+	}
+
+	// Verify that the aggregate digest mode is unspecified or supported.
+	if !(c.AggregateDigestMode.IsDefault() || c.AggregateDigestMode.Supported()) {
+		return errors.New("unknown or unsupported aggregate digest mode")
+	}
+
 	// Verify that the staging mode is unspecified or supported.
 	if !(c.StageMode.IsDefault() || c.StageMode.Supported()) {
 		return errors.New("unknown or unsupported staging mode")
 	}
 
+	// Verify that the transition mode is unspecified or supported.
+	if !(c.TransitionMode.IsDefault() || c.TransitionMode.Supported()) {
+		return errors.New("unknown or unsupported transition mode")
+	}
+
 	// Verify that the symbolic link mode is unspecified or supported.
 	if endpointSpecific {
 		if !c.SymbolicLinkMode.IsDefault() {
@@ -79,6 +119,29 @@ func (c *Configuration) EnsureValid(endpointSpecific bool) error {
 		}
 	}
 
+	// Verify that the symbolic link replacement mode is unspecified or
+	// supported.
+	if endpointSpecific {
+		if !c.SymbolicLinkReplacementMode.IsDefault() {
+			return errors.New("symbolic link replacement mode cannot be specified on an endpoint-specific basis")
+		}
+	} else {
+		if !(c.SymbolicLinkReplacementMode.IsDefault() || c.SymbolicLinkReplacementMode.Supported()) {
+			return errors.New("unknown or unsupported symbolic link replacement mode")
+		}
+	}
+
+	// Verify that the symbolic link cycle mode is unspecified or supported.
+	if endpointSpecific {
+		if !c.SymbolicLinkCycleMode.IsDefault() {
+			return errors.New("symbolic link cycle mode cannot be specified on an endpoint-specific basis")
+		}
+	} else {
+		if !(c.SymbolicLinkCycleMode.IsDefault() || c.SymbolicLinkCycleMode.Supported()) {
+			return errors.New("unknown or unsupported symbolic link cycle mode")
+		}
+	}
+
 	// Verify that the watch mode is unspecified or supported.
 	if !(c.WatchMode.IsDefault() || c.WatchMode.Supported()) {
 		return errors.New("unknown or unsupported watch mode")
@@ -87,6 +150,26 @@ func (c *Configuration) EnsureValid(endpointSpecific bool) error {
 	// The watch polling interval doesn't need to be validated - any of its
 	// values are technically valid regardless of the source.
 
+	// The watch queue size doesn't need to be validated - any of its values are
+	// technically valid regardless of the source.
+
+	// Verify that the initial synchronization mode is unspecified or supported.
+	if endpointSpecific {
+		if !c.InitialSynchronizationMode.IsDefault() {
+			return errors.New("initial synchronization mode cannot be specified on an endpoint-specific basis")
+		}
+	} else {
+		if !(c.InitialSynchronizationMode.IsDefault() || c.InitialSynchronizationMode.Supported()) {
+			return errors.New("unknown or unsupported initial synchronization mode")
+		}
+	}
+
+	// Verify that the initial poll modification mode is unspecified or
+	// supported.
+	if !(c.InitialPollModificationMode.IsDefault() || c.InitialPollModificationMode.Supported()) {
+		return errors.New("unknown or unsupported initial poll modification mode")
+	}
+
 	// Verify that the ignore syntax is unspecified or supported.
 	if endpointSpecific {
 		if !c.IgnoreSyntax.IsDefault() {
@@ -134,6 +217,45 @@ func (c *Configuration) EnsureValid(endpointSpecific bool) error {
 		}
 	}
 
+	// Verify that the OS metadata ignore mode is unspecified or supported.
+	if endpointSpecific {
+		if !c.IgnoreOSMetadataMode.IsDefault() {
+			return errors.New("OS metadata ignore mode cannot be specified on an endpoint-specific basis")
+		}
+	} else {
+		if !(c.IgnoreOSMetadataMode.IsDefault() || c.IgnoreOSMetadataMode.Supported()) {
+			return errors.New("unknown or unsupported OS metadata ignore mode")
+		}
+	}
+
+	// Verify that the phantom directory mode is unspecified or supported.
+	if endpointSpecific {
+		if !c.PhantomDirectoryMode.IsDefault() {
+			return errors.New("phantom directory mode cannot be specified on an endpoint-specific basis")
+		}
+	} else {
+		if !(c.PhantomDirectoryMode.IsDefault() || c.PhantomDirectoryMode.Supported()) {
+			return errors.New("unknown or unsupported phantom directory mode")
+		}
+	}
+
+	// Verify that the maximum file size is unset for endpoint-specific
+	// configurations. Any value is valid otherwise.
+	if endpointSpecific && c.MaximumFileSize != 0 {
+		return errors.New("maximum file size cannot be specified on an endpoint-specific basis")
+	}
+
+	// Verify that the manifest is unset for endpoint-specific configurations
+	// and that manifest paths are valid.
+	if endpointSpecific && len(c.Manifest) > 0 {
+		return errors.New("manifest cannot be specified on an endpoint-specific basis")
+	}
+	for _, path := range c.Manifest {
+		if err := ignore.EnsureManifestPathValid(path); err != nil {
+			return fmt.Errorf("invalid manifest path (%s): %w", path, err)
+		}
+	}
+
 	// Verify that the permissions mode is unspecified or supported. Also
 	// determine the effective permissions mode for validating file and
 	// directory modes.
@@ -193,6 +315,17 @@ func (c *Configuration) EnsureValid(endpointSpecific bool) error {
 		}
 	}
 
+	// Verify that the special file mode is unspecified or supported.
+	if endpointSpecific {
+		if !c.SpecialFileMode.IsDefault() {
+			return errors.New("special file mode cannot be specified on an endpoint-specific basis")
+		}
+	} else {
+		if !(c.SpecialFileMode.IsDefault() || c.SpecialFileMode.Supported()) {
+			return errors.New("unknown or unsupported special file mode")
+		}
+	}
+
 	// Verify that the compression algorithm is unspecified or supported.
 	if !c.CompressionAlgorithm.IsDefault() {
 		supportStatus := c.CompressionAlgorithm.SupportStatus()
@@ -203,6 +336,267 @@ func (c *Configuration) EnsureValid(endpointSpecific bool) error {
 		}
 	}
 
+	// Verify that the staging compression algorithm is unspecified for
+	// endpoint-specific configurations and otherwise unspecified or supported.
+	if endpointSpecific {
+		if !c.StagingCompressionAlgorithm.IsDefault() {
+			return errors.New("staging compression algorithm cannot be specified on an endpoint-specific basis")
+		}
+	} else if !c.StagingCompressionAlgorithm.IsDefault() {
+		supportStatus := c.StagingCompressionAlgorithm.SupportStatus()
+		if supportStatus == compression.AlgorithmSupportStatusUnsupported {
+			return errors.New("unknown or unsupported staging compression algorithm")
+		} else if supportStatus == compression.AlgorithmSupportStatusRequiresLicense {
+			return errors.New("staging compression algorithm requires Mutagen Pro license")
+		}
+	}
+
+	// Verify that the clock skew mode is unspecified or supported.
+	if !(c.ClockSkewMode.IsDefault() || c.ClockSkewMode.Supported()) {
+		return errors.New("unknown or unsupported clock skew mode")
+	}
+
+	// The clock skew tolerance doesn't need to be validated - any of its values
+	// are technically valid regardless of the source.
+
+	// The probe directory doesn't need to be validated here - its validity can
+	// only be determined by the endpoint on which it's used.
+
+	// Verify that the executability preservation assumption is unspecified or
+	// supported.
+	if !(c.AssumeExecutabilityPreservation.IsDefault() || c.AssumeExecutabilityPreservation.Supported()) {
+		return errors.New("unknown or unsupported executability preservation assumption")
+	}
+
+	// Verify that the Unicode decomposition assumption is unspecified or
+	// supported.
+	if !(c.AssumeUnicodeDecomposition.IsDefault() || c.AssumeUnicodeDecomposition.Supported()) {
+		return errors.New("unknown or unsupported Unicode decomposition assumption")
+	}
+
+	// Verify that the oversized file mode is unspecified or supported.
+	if !(c.OversizedFileMode.IsDefault() || c.OversizedFileMode.Supported()) {
+		return errors.New("unknown or unsupported oversized file mode")
+	}
+
+	// The maximum rename detection file size doesn't need to be validated - any
+	// of its values are technically valid regardless of the source.
+
+	// The maximum content cache size doesn't need to be validated - any of its
+	// values are technically valid regardless of the source.
+
+	// Verify that the rename detection digest length is unspecified or long
+	// enough to keep key collisions rare.
+	if c.RenameDetectionDigestLength != 0 && c.RenameDetectionDigestLength < hashing.MinimumTruncatedDigestLength {
+		return fmt.Errorf("rename detection digest length must be at least %d bytes", hashing.MinimumTruncatedDigestLength)
+	}
+
+	// Verify that the rename detection retry count is within bounds.
+	if c.RenameDetectionRetries > MaximumRenameDetectionRetries {
+		return errors.New("rename detection retry count exceeds maximum")
+	}
+
+	// Verify that the staging concurrency mode is unspecified or supported.
+	if endpointSpecific {
+		if !c.StagingConcurrencyMode.IsDefault() {
+			return errors.New("staging concurrency mode cannot be specified on an endpoint-specific basis")
+		}
+	} else {
+		if !(c.StagingConcurrencyMode.IsDefault() || c.StagingConcurrencyMode.Supported()) {
+			return errors.New("unknown or unsupported staging concurrency mode")
+		}
+	}
+
+	// The maximum deltification time doesn't need to be validated - any of its
+	// values are technically valid regardless of the source.
+
+	// The maximum staged content age doesn't need to be validated - any of its
+	// values are technically valid regardless of the source.
+
+	// Verify that the rsync block size is unspecified or a power of two within
+	// the allowed range.
+	if c.RsyncBlockSize != 0 {
+		if c.RsyncBlockSize&(c.RsyncBlockSize-1) != 0 {
+			return errors.New("rsync block size is not a power of two")
+		} else if c.RsyncBlockSize < rsync.MinimumBlockSize || c.RsyncBlockSize > rsync.MaximumBlockSize {
+			return fmt.Errorf("rsync block size must be between %d and %d bytes",
+				rsync.MinimumBlockSize, rsync.MaximumBlockSize,
+			)
+		}
+	}
+
+	// The maximum in-flight bytes value doesn't need to be validated - any of
+	// its values are technically valid regardless of the source.
+
+	// Verify that the stream concurrency is within bounds.
+	if c.StreamConcurrency > MaximumStreamConcurrency {
+		return errors.New("stream concurrency exceeds maximum")
+	}
+
+	// Verify that the directory listing retry count is within bounds.
+	if c.DirectoryListingRetries > MaximumDirectoryListingRetries {
+		return errors.New("directory listing retry count exceeds maximum")
+	}
+
+	// The cache save threshold doesn't need to be validated - any of its
+	// values are technically valid regardless of the source.
+
+	// The maximum re-check path count doesn't need to be validated - any of
+	// its values are technically valid regardless of the source.
+
+	// Verify that the digest sampling threshold is unspecified for
+	// endpoint-specific configurations and is otherwise unspecified or large
+	// enough to prevent sampled regions from overlapping.
+	if endpointSpecific {
+		if c.DigestSamplingThreshold != 0 {
+			return errors.New("digest sampling threshold cannot be specified on an endpoint-specific basis")
+		}
+	} else if c.DigestSamplingThreshold != 0 && c.DigestSamplingThreshold < hashing.MinimumSamplingThreshold {
+		return fmt.Errorf("digest sampling threshold must be at least %d bytes", hashing.MinimumSamplingThreshold)
+	}
+
+	// Verify that the digest length is unspecified for endpoint-specific
+	// configurations and is otherwise unspecified or long enough to keep the
+	// collision probability negligible.
+	if endpointSpecific {
+		if c.DigestLength != 0 {
+			return errors.New("digest length cannot be specified on an endpoint-specific basis")
+		}
+	} else if c.DigestLength != 0 && c.DigestLength < hashing.MinimumTruncatedDigestLength {
+		return fmt.Errorf("digest length must be at least %d bytes", hashing.MinimumTruncatedDigestLength)
+	}
+
+	// Verify that the cache trust mode is unspecified or supported.
+	if !(c.CacheTrustMode.IsDefault() || c.CacheTrustMode.Supported()) {
+		return errors.New("unknown or unsupported cache trust mode")
+	}
+
+	// Verify that the name normalization mode is unspecified or supported.
+	if endpointSpecific {
+		if !c.NameNormalizationMode.IsDefault() {
+			return errors.New("name normalization mode cannot be specified on an endpoint-specific basis")
+		}
+	} else {
+		if !(c.NameNormalizationMode.IsDefault() || c.NameNormalizationMode.Supported()) {
+			return errors.New("unknown or unsupported name normalization mode")
+		}
+	}
+
+	// Verify that the capability mismatch mode is unspecified or supported.
+	if endpointSpecific {
+		if !c.CapabilityMismatchMode.IsDefault() {
+			return errors.New("capability mismatch mode cannot be specified on an endpoint-specific basis")
+		}
+	} else {
+		if !(c.CapabilityMismatchMode.IsDefault() || c.CapabilityMismatchMode.Supported()) {
+			return errors.New("unknown or unsupported capability mismatch mode")
+		}
+	}
+
+	// Verify that the root overlap mode is unspecified or supported.
+	if endpointSpecific {
+		if !c.RootOverlapMode.IsDefault() {
+			return errors.New("root overlap mode cannot be specified on an endpoint-specific basis")
+		}
+	} else {
+		if !(c.RootOverlapMode.IsDefault() || c.RootOverlapMode.Supported()) {
+			return errors.New("unknown or unsupported root overlap mode")
+		}
+	}
+
+	// Verify that the configuration incompatibility mode is unspecified or
+	// supported.
+	if endpointSpecific {
+		if !c.ConfigurationIncompatibilityMode.IsDefault() {
+			return errors.New("configuration incompatibility mode cannot be specified on an endpoint-specific basis")
+		}
+	} else {
+		if !(c.ConfigurationIncompatibilityMode.IsDefault() || c.ConfigurationIncompatibilityMode.Supported()) {
+			return errors.New("unknown or unsupported configuration incompatibility mode")
+		}
+	}
+
+	// Verify that the root existence mode is unspecified or supported.
+	if !(c.RootExistenceMode.IsDefault() || c.RootExistenceMode.Supported()) {
+		return errors.New("unknown or unsupported root existence mode")
+	}
+
+	// Verify that the type change mode is unspecified or supported.
+	if endpointSpecific {
+		if !c.TypeChangeMode.IsDefault() {
+			return errors.New("type change mode cannot be specified on an endpoint-specific basis")
+		}
+	} else {
+		if !(c.TypeChangeMode.IsDefault() || c.TypeChangeMode.Supported()) {
+			return errors.New("unknown or unsupported type change mode")
+		}
+	}
+
+	// Verify that the maximum conflict persistence is unspecified for
+	// endpoint-specific configurations. Any of its values are otherwise valid.
+	if endpointSpecific && c.MaximumConflictPersistence != 0 {
+		return errors.New("maximum conflict persistence cannot be specified on an endpoint-specific basis")
+	}
+
+	// Verify that the scan subpaths are valid.
+	if err := EnsureValidScope(c.ScanSubpaths); err != nil {
+		return fmt.Errorf("invalid scan subpaths: %w", err)
+	}
+
+	// Verify that the watchdog timeout is unspecified for endpoint-specific
+	// configurations. Any of its values are otherwise valid.
+	if endpointSpecific && c.WatchdogTimeout != 0 {
+		return errors.New("watchdog timeout cannot be specified on an endpoint-specific basis")
+	}
+
+	// Verify that the agent termination reconnect delay is unspecified for
+	// endpoint-specific configurations. Any of its values are otherwise valid.
+	if endpointSpecific && c.AgentTerminationReconnectDelay != 0 {
+		return errors.New("agent termination reconnect delay cannot be specified on an endpoint-specific basis")
+	}
+
+	// Verify that the maximum beta scan deferral is unspecified for
+	// endpoint-specific configurations. Any of its values are otherwise valid.
+	if endpointSpecific && c.MaximumBetaScanDeferral != 0 {
+		return errors.New("maximum beta scan deferral cannot be specified on an endpoint-specific basis")
+	}
+
+	// Verify that the verification mode is unspecified or supported.
+	if endpointSpecific {
+		if !c.VerificationMode.IsDefault() {
+			return errors.New("verification mode cannot be specified on an endpoint-specific basis")
+		}
+	} else {
+		if !(c.VerificationMode.IsDefault() || c.VerificationMode.Supported()) {
+			return errors.New("unknown or unsupported verification mode")
+		}
+	}
+
+	// Verify that the verification interval is unspecified for
+	// endpoint-specific configurations. Any of its values are otherwise valid.
+	if endpointSpecific && c.VerificationInterval != 0 {
+		return errors.New("verification interval cannot be specified on an endpoint-specific basis")
+	}
+
+	// Verify that the maximum reported problem count is unspecified for
+	// endpoint-specific configurations. Any of its values are otherwise valid.
+	if endpointSpecific && c.MaximumReportedProblems != 0 {
+		return errors.New("maximum reported problems cannot be specified on an endpoint-specific basis")
+	}
+
+	// Verify that the maximum reconciliation recording count is unspecified
+	// for endpoint-specific configurations. Any of its values are otherwise
+	// valid.
+	if endpointSpecific && c.MaximumReconciliationRecordings != 0 {
+		return errors.New("maximum reconciliation recordings cannot be specified on an endpoint-specific basis")
+	}
+
+	// The slow directory listing threshold doesn't need to be validated - any
+	// of its values are technically valid regardless of the source.
+
+	// The overlay base doesn't need to be validated here - its validity can
+	// only be determined by the endpoint on which it's used.
+
 	// Success.
 	return nil
 }
@@ -220,22 +614,77 @@ func (c *Configuration) Equal(other *Configuration) bool {
 		c.HashingAlgorithm == other.HashingAlgorithm &&
 		c.MaximumEntryCount == other.MaximumEntryCount &&
 		c.MaximumStagingFileSize == other.MaximumStagingFileSize &&
+		c.MaximumSignatureMemory == other.MaximumSignatureMemory &&
+		c.MaximumConflictCount == other.MaximumConflictCount &&
 		c.ProbeMode == other.ProbeMode &&
 		c.ScanMode == other.ScanMode &&
 		c.StageMode == other.StageMode &&
+		c.TransitionMode == other.TransitionMode &&
 		c.SymbolicLinkMode == other.SymbolicLinkMode &&
+		c.SymbolicLinkReplacementMode == other.SymbolicLinkReplacementMode &&
+		c.SymbolicLinkCycleMode == other.SymbolicLinkCycleMode &&
 		c.WatchMode == other.WatchMode &&
 		c.WatchPollingInterval == other.WatchPollingInterval &&
+		c.WatchQueueSize == other.WatchQueueSize &&
+		c.InitialSynchronizationMode == other.InitialSynchronizationMode &&
+		c.InitialPollModificationMode == other.InitialPollModificationMode &&
 		c.IgnoreSyntax == other.IgnoreSyntax &&
 		comparison.StringSlicesEqual(c.DefaultIgnores, other.DefaultIgnores) &&
 		comparison.StringSlicesEqual(c.Ignores, other.Ignores) &&
 		c.IgnoreVCSMode == other.IgnoreVCSMode &&
+		comparison.StringSlicesEqual(c.Manifest, other.Manifest) &&
+		c.PhantomDirectoryMode == other.PhantomDirectoryMode &&
+		c.MaximumFileSize == other.MaximumFileSize &&
+		c.IgnoreOSMetadataMode == other.IgnoreOSMetadataMode &&
 		c.PermissionsMode == other.PermissionsMode &&
 		c.DefaultFileMode == other.DefaultFileMode &&
 		c.DefaultDirectoryMode == other.DefaultDirectoryMode &&
 		c.DefaultOwner == other.DefaultOwner &&
 		c.DefaultGroup == other.DefaultGroup &&
-		c.CompressionAlgorithm == other.CompressionAlgorithm
+		c.CompressionAlgorithm == other.CompressionAlgorithm &&
+		c.StagingCompressionAlgorithm == other.StagingCompressionAlgorithm &&
+		c.SpecialFileMode == other.SpecialFileMode &&
+		c.ClockSkewMode == other.ClockSkewMode &&
+		c.ClockSkewTolerance == other.ClockSkewTolerance &&
+		c.ProbeDirectory == other.ProbeDirectory &&
+		c.AssumeExecutabilityPreservation == other.AssumeExecutabilityPreservation &&
+		c.AssumeUnicodeDecomposition == other.AssumeUnicodeDecomposition &&
+		c.OversizedFileMode == other.OversizedFileMode &&
+		c.MaximumRenameDetectionFileSize == other.MaximumRenameDetectionFileSize &&
+		c.MaximumContentCacheSize == other.MaximumContentCacheSize &&
+		c.StagingConcurrencyMode == other.StagingConcurrencyMode &&
+		c.MaximumDeltificationTime == other.MaximumDeltificationTime &&
+		c.MaximumStagedContentAge == other.MaximumStagedContentAge &&
+		c.RsyncBlockSize == other.RsyncBlockSize &&
+		c.MaximumInFlightBytes == other.MaximumInFlightBytes &&
+		c.RenameDetectionDigestLength == other.RenameDetectionDigestLength &&
+		c.RenameDetectionRetries == other.RenameDetectionRetries &&
+		c.StreamConcurrency == other.StreamConcurrency &&
+		c.DirectoryListingRetries == other.DirectoryListingRetries &&
+		c.CacheSaveThreshold == other.CacheSaveThreshold &&
+		c.MaximumRecheckPaths == other.MaximumRecheckPaths &&
+		comparison.StringSlicesEqual(c.ScanSubpaths, other.ScanSubpaths) &&
+		c.DigestSamplingThreshold == other.DigestSamplingThreshold &&
+		c.DigestLength == other.DigestLength &&
+		c.NameNormalizationMode == other.NameNormalizationMode &&
+		c.CacheTrustMode == other.CacheTrustMode &&
+		c.CapabilityMismatchMode == other.CapabilityMismatchMode &&
+		c.ConfigurationIncompatibilityMode == other.ConfigurationIncompatibilityMode &&
+		c.RootExistenceMode == other.RootExistenceMode &&
+		c.TypeChangeMode == other.TypeChangeMode &&
+		c.MaximumConflictPersistence == other.MaximumConflictPersistence &&
+		c.WatchdogTimeout == other.WatchdogTimeout &&
+		c.AgentTerminationReconnectDelay == other.AgentTerminationReconnectDelay &&
+		c.MaximumBetaScanDeferral == other.MaximumBetaScanDeferral &&
+		c.OverlayBase == other.OverlayBase &&
+		c.RootOverlapMode == other.RootOverlapMode &&
+		c.ScanSharingMode == other.ScanSharingMode &&
+		c.VerificationMode == other.VerificationMode &&
+		c.VerificationInterval == other.VerificationInterval &&
+		c.MaximumReportedProblems == other.MaximumReportedProblems &&
+		c.AggregateDigestMode == other.AggregateDigestMode &&
+		c.MaximumReconciliationRecordings == other.MaximumReconciliationRecordings &&
+		c.SlowDirectoryListingThreshold == other.SlowDirectoryListingThreshold
 }
 
 // MergeConfigurations merges two configurations of differing priorities. Both
@@ -272,6 +721,20 @@ func MergeConfigurations(lower, higher *Configuration) *Configuration {
 		result.MaximumStagingFileSize = lower.MaximumStagingFileSize
 	}
 
+	// Merge the maximum signature memory.
+	if higher.MaximumSignatureMemory != 0 {
+		result.MaximumSignatureMemory = higher.MaximumSignatureMemory
+	} else {
+		result.MaximumSignatureMemory = lower.MaximumSignatureMemory
+	}
+
+	// Merge the maximum conflict count.
+	if higher.MaximumConflictCount != 0 {
+		result.MaximumConflictCount = higher.MaximumConflictCount
+	} else {
+		result.MaximumConflictCount = lower.MaximumConflictCount
+	}
+
 	// Merge the probing mode.
 	if !higher.ProbeMode.IsDefault() {
 		result.ProbeMode = higher.ProbeMode
@@ -293,6 +756,13 @@ func MergeConfigurations(lower, higher *Configuration) *Configuration {
 		result.StageMode = lower.StageMode
 	}
 
+	// Merge the transition mode.
+	if !higher.TransitionMode.IsDefault() {
+		result.TransitionMode = higher.TransitionMode
+	} else {
+		result.TransitionMode = lower.TransitionMode
+	}
+
 	// Merge the symbolic link mode.
 	if !higher.SymbolicLinkMode.IsDefault() {
 		result.SymbolicLinkMode = higher.SymbolicLinkMode
@@ -300,6 +770,20 @@ func MergeConfigurations(lower, higher *Configuration) *Configuration {
 		result.SymbolicLinkMode = lower.SymbolicLinkMode
 	}
 
+	// Merge the symbolic link replacement mode.
+	if !higher.SymbolicLinkReplacementMode.IsDefault() {
+		result.SymbolicLinkReplacementMode = higher.SymbolicLinkReplacementMode
+	} else {
+		result.SymbolicLinkReplacementMode = lower.SymbolicLinkReplacementMode
+	}
+
+	// Merge the symbolic link cycle mode.
+	if !higher.SymbolicLinkCycleMode.IsDefault() {
+		result.SymbolicLinkCycleMode = higher.SymbolicLinkCycleMode
+	} else {
+		result.SymbolicLinkCycleMode = lower.SymbolicLinkCycleMode
+	}
+
 	// Merge the watching mode.
 	if !higher.WatchMode.IsDefault() {
 		result.WatchMode = higher.WatchMode
@@ -314,6 +798,27 @@ func MergeConfigurations(lower, higher *Configuration) *Configuration {
 		result.WatchPollingInterval = lower.WatchPollingInterval
 	}
 
+	// Merge the watch queue size.
+	if higher.WatchQueueSize != 0 {
+		result.WatchQueueSize = higher.WatchQueueSize
+	} else {
+		result.WatchQueueSize = lower.WatchQueueSize
+	}
+
+	// Merge the initial synchronization mode.
+	if !higher.InitialSynchronizationMode.IsDefault() {
+		result.InitialSynchronizationMode = higher.InitialSynchronizationMode
+	} else {
+		result.InitialSynchronizationMode = lower.InitialSynchronizationMode
+	}
+
+	// Merge the initial poll modification mode.
+	if !higher.InitialPollModificationMode.IsDefault() {
+		result.InitialPollModificationMode = higher.InitialPollModificationMode
+	} else {
+		result.InitialPollModificationMode = lower.InitialPollModificationMode
+	}
+
 	// Merge the ignore syntax.
 	if !higher.IgnoreSyntax.IsDefault() {
 		result.IgnoreSyntax = higher.IgnoreSyntax
@@ -338,6 +843,35 @@ func MergeConfigurations(lower, higher *Configuration) *Configuration {
 		result.IgnoreVCSMode = lower.IgnoreVCSMode
 	}
 
+	// Merge the phantom directory mode.
+	if !higher.PhantomDirectoryMode.IsDefault() {
+		result.PhantomDirectoryMode = higher.PhantomDirectoryMode
+	} else {
+		result.PhantomDirectoryMode = lower.PhantomDirectoryMode
+	}
+
+	// Merge the maximum file size.
+	if higher.MaximumFileSize != 0 {
+		result.MaximumFileSize = higher.MaximumFileSize
+	} else {
+		result.MaximumFileSize = lower.MaximumFileSize
+	}
+
+	// Merge the OS metadata ignore mode.
+	if !higher.IgnoreOSMetadataMode.IsDefault() {
+		result.IgnoreOSMetadataMode = higher.IgnoreOSMetadataMode
+	} else {
+		result.IgnoreOSMetadataMode = lower.IgnoreOSMetadataMode
+	}
+
+	// Merge the manifest. Unlike ignores, manifests aren't combined, since
+	// doing so would broaden rather than restrict synchronization.
+	if len(higher.Manifest) > 0 {
+		result.Manifest = higher.Manifest
+	} else {
+		result.Manifest = lower.Manifest
+	}
+
 	// Merge the permissions mode.
 	if !higher.PermissionsMode.IsDefault() {
 		result.PermissionsMode = higher.PermissionsMode
@@ -380,6 +914,307 @@ func MergeConfigurations(lower, higher *Configuration) *Configuration {
 		result.CompressionAlgorithm = lower.CompressionAlgorithm
 	}
 
+	// Merge the staging compression algorithm.
+	if !higher.StagingCompressionAlgorithm.IsDefault() {
+		result.StagingCompressionAlgorithm = higher.StagingCompressionAlgorithm
+	} else {
+		result.StagingCompressionAlgorithm = lower.StagingCompressionAlgorithm
+	}
+
+	// Merge the special file mode.
+	if !higher.SpecialFileMode.IsDefault() {
+		result.SpecialFileMode = higher.SpecialFileMode
+	} else {
+		result.SpecialFileMode = lower.SpecialFileMode
+	}
+
+	// Merge the clock skew mode.
+	if !higher.ClockSkewMode.IsDefault() {
+		result.ClockSkewMode = higher.ClockSkewMode
+	} else {
+		result.ClockSkewMode = lower.ClockSkewMode
+	}
+
+	// Merge the clock skew tolerance.
+	if higher.ClockSkewTolerance != 0 {
+		result.ClockSkewTolerance = higher.ClockSkewTolerance
+	} else {
+		result.ClockSkewTolerance = lower.ClockSkewTolerance
+	}
+
+	// Merge the probe directory.
+	if higher.ProbeDirectory != "" {
+		result.ProbeDirectory = higher.ProbeDirectory
+	} else {
+		result.ProbeDirectory = lower.ProbeDirectory
+	}
+
+	// Merge the executability preservation assumption.
+	if !higher.AssumeExecutabilityPreservation.IsDefault() {
+		result.AssumeExecutabilityPreservation = higher.AssumeExecutabilityPreservation
+	} else {
+		result.AssumeExecutabilityPreservation = lower.AssumeExecutabilityPreservation
+	}
+
+	// Merge the Unicode decomposition assumption.
+	if !higher.AssumeUnicodeDecomposition.IsDefault() {
+		result.AssumeUnicodeDecomposition = higher.AssumeUnicodeDecomposition
+	} else {
+		result.AssumeUnicodeDecomposition = lower.AssumeUnicodeDecomposition
+	}
+
+	// Merge the oversized file mode.
+	if !higher.OversizedFileMode.IsDefault() {
+		result.OversizedFileMode = higher.OversizedFileMode
+	} else {
+		result.OversizedFileMode = lower.OversizedFileMode
+	}
+
+	// Merge the maximum rename detection file size.
+	if higher.MaximumRenameDetectionFileSize != 0 {
+		result.MaximumRenameDetectionFileSize = higher.MaximumRenameDetectionFileSize
+	} else {
+		result.MaximumRenameDetectionFileSize = lower.MaximumRenameDetectionFileSize
+	}
+
+	// Merge the maximum content cache size.
+	if higher.MaximumContentCacheSize != 0 {
+		result.MaximumContentCacheSize = higher.MaximumContentCacheSize
+	} else {
+		result.MaximumContentCacheSize = lower.MaximumContentCacheSize
+	}
+
+	// Merge the staging concurrency mode.
+	if !higher.StagingConcurrencyMode.IsDefault() {
+		result.StagingConcurrencyMode = higher.StagingConcurrencyMode
+	} else {
+		result.StagingConcurrencyMode = lower.StagingConcurrencyMode
+	}
+
+	// Merge the maximum deltification time.
+	if higher.MaximumDeltificationTime != 0 {
+		result.MaximumDeltificationTime = higher.MaximumDeltificationTime
+	} else {
+		result.MaximumDeltificationTime = lower.MaximumDeltificationTime
+	}
+
+	// Merge the maximum staged content age.
+	if higher.MaximumStagedContentAge != 0 {
+		result.MaximumStagedContentAge = higher.MaximumStagedContentAge
+	} else {
+		result.MaximumStagedContentAge = lower.MaximumStagedContentAge
+	}
+
+	// Merge the rsync block size.
+	if higher.RsyncBlockSize != 0 {
+		result.RsyncBlockSize = higher.RsyncBlockSize
+	} else {
+		result.RsyncBlockSize = lower.RsyncBlockSize
+	}
+
+	// Merge the maximum in-flight bytes.
+	if higher.MaximumInFlightBytes != 0 {
+		result.MaximumInFlightBytes = higher.MaximumInFlightBytes
+	} else {
+		result.MaximumInFlightBytes = lower.MaximumInFlightBytes
+	}
+
+	// Merge the rename detection digest length.
+	if higher.RenameDetectionDigestLength != 0 {
+		result.RenameDetectionDigestLength = higher.RenameDetectionDigestLength
+	} else {
+		result.RenameDetectionDigestLength = lower.RenameDetectionDigestLength
+	}
+
+	// Merge the rename detection retry count.
+	if higher.RenameDetectionRetries != 0 {
+		result.RenameDetectionRetries = higher.RenameDetectionRetries
+	} else {
+		result.RenameDetectionRetries = lower.RenameDetectionRetries
+	}
+
+	// Merge the stream concurrency.
+	if higher.StreamConcurrency != 0 {
+		result.StreamConcurrency = higher.StreamConcurrency
+	} else {
+		result.StreamConcurrency = lower.StreamConcurrency
+	}
+
+	// Merge the directory listing retry count.
+	if higher.DirectoryListingRetries != 0 {
+		result.DirectoryListingRetries = higher.DirectoryListingRetries
+	} else {
+		result.DirectoryListingRetries = lower.DirectoryListingRetries
+	}
+
+	// Merge the cache save threshold.
+	if higher.CacheSaveThreshold != 0 {
+		result.CacheSaveThreshold = higher.CacheSaveThreshold
+	} else {
+		result.CacheSaveThreshold = lower.CacheSaveThreshold
+	}
+
+	// Merge the maximum re-check path count.
+	if higher.MaximumRecheckPaths != 0 {
+		result.MaximumRecheckPaths = higher.MaximumRecheckPaths
+	} else {
+		result.MaximumRecheckPaths = lower.MaximumRecheckPaths
+	}
+
+	// Merge the scan subpaths.
+	if len(higher.ScanSubpaths) > 0 {
+		result.ScanSubpaths = higher.ScanSubpaths
+	} else {
+		result.ScanSubpaths = lower.ScanSubpaths
+	}
+
+	// Merge the digest sampling threshold.
+	if higher.DigestSamplingThreshold != 0 {
+		result.DigestSamplingThreshold = higher.DigestSamplingThreshold
+	} else {
+		result.DigestSamplingThreshold = lower.DigestSamplingThreshold
+	}
+
+	// Merge the digest length.
+	if higher.DigestLength != 0 {
+		result.DigestLength = higher.DigestLength
+	} else {
+		result.DigestLength = lower.DigestLength
+	}
+
+	// Merge the name normalization mode.
+	if !higher.NameNormalizationMode.IsDefault() {
+		result.NameNormalizationMode = higher.NameNormalizationMode
+	} else {
+		result.NameNormalizationMode = lower.NameNormalizationMode
+	}
+
+	// Merge the cache trust mode.
+	if !higher.CacheTrustMode.IsDefault() {
+		result.CacheTrustMode = higher.CacheTrustMode
+	} else {
+		result.CacheTrustMode = lower.CacheTrustMode
+	}
+
+	// Merge the capability mismatch mode.
+	if !higher.CapabilityMismatchMode.IsDefault() {
+		result.CapabilityMismatchMode = higher.CapabilityMismatchMode
+	} else {
+		result.CapabilityMismatchMode = lower.CapabilityMismatchMode
+	}
+
+	// Merge the configuration incompatibility mode.
+	if !higher.ConfigurationIncompatibilityMode.IsDefault() {
+		result.ConfigurationIncompatibilityMode = higher.ConfigurationIncompatibilityMode
+	} else {
+		result.ConfigurationIncompatibilityMode = lower.ConfigurationIncompatibilityMode
+	}
+
+	// Merge the root existence mode.
+	if !higher.RootExistenceMode.IsDefault() {
+		result.RootExistenceMode = higher.RootExistenceMode
+	} else {
+		result.RootExistenceMode = lower.RootExistenceMode
+	}
+
+	// Merge the type change mode.
+	if !higher.TypeChangeMode.IsDefault() {
+		result.TypeChangeMode = higher.TypeChangeMode
+	} else {
+		result.TypeChangeMode = lower.TypeChangeMode
+	}
+
+	// Merge the maximum conflict persistence.
+	if higher.MaximumConflictPersistence != 0 {
+		result.MaximumConflictPersistence = higher.MaximumConflictPersistence
+	} else {
+		result.MaximumConflictPersistence = lower.MaximumConflictPersistence
+	}
+
+	// Merge the watchdog timeout.
+	if higher.WatchdogTimeout != 0 {
+		result.WatchdogTimeout = higher.WatchdogTimeout
+	} else {
+		result.WatchdogTimeout = lower.WatchdogTimeout
+	}
+
+	// Merge the agent termination reconnect delay.
+	if higher.AgentTerminationReconnectDelay != 0 {
+		result.AgentTerminationReconnectDelay = higher.AgentTerminationReconnectDelay
+	} else {
+		result.AgentTerminationReconnectDelay = lower.AgentTerminationReconnectDelay
+	}
+
+	// Merge the maximum beta scan deferral.
+	if higher.MaximumBetaScanDeferral != 0 {
+		result.MaximumBetaScanDeferral = higher.MaximumBetaScanDeferral
+	} else {
+		result.MaximumBetaScanDeferral = lower.MaximumBetaScanDeferral
+	}
+
+	// Merge the overlay base.
+	if higher.OverlayBase != "" {
+		result.OverlayBase = higher.OverlayBase
+	} else {
+		result.OverlayBase = lower.OverlayBase
+	}
+
+	// Merge the root overlap mode.
+	if !higher.RootOverlapMode.IsDefault() {
+		result.RootOverlapMode = higher.RootOverlapMode
+	} else {
+		result.RootOverlapMode = lower.RootOverlapMode
+	}
+
+	// Merge the scan sharing mode.
+	if !higher.ScanSharingMode.IsDefault() {
+		result.ScanSharingMode = higher.ScanSharingMode
+	} else {
+		result.ScanSharingMode = lower.ScanSharingMode
+	}
+
+	// Merge the verification mode.
+	if !higher.VerificationMode.IsDefault() {
+		result.VerificationMode = higher.VerificationMode
+	} else {
+		result.VerificationMode = lower.VerificationMode
+	}
+
+	// Merge the verification interval.
+	if higher.VerificationInterval != 0 {
+		result.VerificationInterval = higher.VerificationInterval
+	} else {
+		result.VerificationInterval = lower.VerificationInterval
+	}
+
+	// Merge the maximum reported problem count.
+	if higher.MaximumReportedProblems != 0 {
+		result.MaximumReportedProblems = higher.MaximumReportedProblems
+	} else {
+		result.MaximumReportedProblems = lower.MaximumReportedProblems
+	}
+
+	// Merge the aggregate digest mode.
+	if !higher.AggregateDigestMode.IsDefault() {
+		result.AggregateDigestMode = higher.AggregateDigestMode
+	} else {
+		result.AggregateDigestMode = lower.AggregateDigestMode
+	}
+
+	// Merge the maximum reconciliation recording count.
+	if higher.MaximumReconciliationRecordings != 0 {
+		result.MaximumReconciliationRecordings = higher.MaximumReconciliationRecordings
+	} else {
+		result.MaximumReconciliationRecordings = lower.MaximumReconciliationRecordings
+	}
+
+	// Merge the slow directory listing threshold.
+	if higher.SlowDirectoryListingThreshold != 0 {
+		result.SlowDirectoryListingThreshold = higher.SlowDirectoryListingThreshold
+	} else {
+		result.SlowDirectoryListingThreshold = lower.SlowDirectoryListingThreshold
+	}
+
 	// Done.
 	return result
 }
@@ -52,14 +52,42 @@ type Configuration struct {
 	ScanMode ScanMode `protobuf:"varint,15,opt,name=scanMode,proto3,enum=synchronization.ScanMode" json:"scanMode,omitempty"`
 	// StageMode specifies the file staging mode.
 	StageMode StageMode `protobuf:"varint,16,opt,name=stageMode,proto3,enum=synchronization.StageMode" json:"stageMode,omitempty"`
+	// MaximumConflictCount specifies the maximum number of conflicts that a
+	// session will tolerate before halting for safety. A zero value indicates no
+	// limit.
+	MaximumConflictCount uint64 `protobuf:"varint,18,opt,name=maximumConflictCount,proto3" json:"maximumConflictCount,omitempty"`
+	// TransitionMode specifies the strategy used to apply changes to disk.
+	TransitionMode core.TransitionMode `protobuf:"varint,19,opt,name=transitionMode,proto3,enum=core.TransitionMode" json:"transitionMode,omitempty"`
+	// MaximumSignatureMemory is the maximum total (estimated) memory that
+	// endpoints will dedicate to rsync signatures in a single staging
+	// operation. Files whose signatures would exceed this limit are deferred to
+	// subsequent staging operations. A zero value indicates no limit.
+	MaximumSignatureMemory uint64 `protobuf:"varint,20,opt,name=maximumSignatureMemory,proto3" json:"maximumSignatureMemory,omitempty"`
 	// SymbolicLinkMode specifies the symbolic link mode.
 	SymbolicLinkMode core.SymbolicLinkMode `protobuf:"varint,1,opt,name=symbolicLinkMode,proto3,enum=core.SymbolicLinkMode" json:"symbolicLinkMode,omitempty"`
+	// SymbolicLinkReplacementMode specifies the handling of non-empty
+	// directories that need to be replaced by symbolic links.
+	SymbolicLinkReplacementMode core.SymbolicLinkReplacementMode `protobuf:"varint,2,opt,name=symbolicLinkReplacementMode,proto3,enum=core.SymbolicLinkReplacementMode" json:"symbolicLinkReplacementMode,omitempty"`
+	// SymbolicLinkCycleMode specifies the handling of symbolic links that
+	// would form cycles when created by transitions.
+	SymbolicLinkCycleMode core.SymbolicLinkCycleMode `protobuf:"varint,3,opt,name=symbolicLinkCycleMode,proto3,enum=core.SymbolicLinkCycleMode" json:"symbolicLinkCycleMode,omitempty"`
 	// WatchMode specifies the filesystem watching mode.
 	WatchMode WatchMode `protobuf:"varint,21,opt,name=watchMode,proto3,enum=synchronization.WatchMode" json:"watchMode,omitempty"`
 	// WatchPollingInterval specifies the interval (in seconds) for poll-based
 	// file monitoring. A value of 0 specifies that the default interval should
 	// be used.
 	WatchPollingInterval uint32 `protobuf:"varint,22,opt,name=watchPollingInterval,proto3" json:"watchPollingInterval,omitempty"`
+	// WatchQueueSize specifies the capacity of the internal event queue used by
+	// native filesystem watchers. A value of 0 specifies that the default queue
+	// size should be used.
+	WatchQueueSize uint32 `protobuf:"varint,23,opt,name=watchQueueSize,proto3" json:"watchQueueSize,omitempty"`
+	// InitialSynchronizationMode specifies whether or not a synchronization
+	// cycle should be forced when the synchronization loop starts. It can only
+	// be specified on a session-wide basis.
+	InitialSynchronizationMode InitialSynchronizationMode `protobuf:"varint,24,opt,name=initialSynchronizationMode,proto3,enum=synchronization.InitialSynchronizationMode" json:"initialSynchronizationMode,omitempty"`
+	// InitialPollModificationMode specifies whether or not poll-based watching
+	// should report the modifications observed by its initial scan.
+	InitialPollModificationMode InitialPollModificationMode `protobuf:"varint,25,opt,name=initialPollModificationMode,proto3,enum=synchronization.InitialPollModificationMode" json:"initialPollModificationMode,omitempty"`
 	// IgnoreSyntax specifies the syntax and semantics to use for ignores.
 	// NOTE: This field is out of order due to the historical order in which it
 	// was added.
@@ -76,6 +104,22 @@ type Configuration struct {
 	// IgnoreVCSMode specifies the VCS ignore mode that should be used in
 	// synchronization.
 	IgnoreVCSMode ignore.IgnoreVCSMode `protobuf:"varint,33,opt,name=ignoreVCSMode,proto3,enum=ignore.IgnoreVCSMode" json:"ignoreVCSMode,omitempty"`
+	// Manifest specifies an explicit list of paths (relative to the
+	// synchronization root) to which synchronization should be restricted. If
+	// non-empty, only the listed paths and their parent directories are
+	// considered. Ignores still apply to manifested paths.
+	Manifest []string `protobuf:"bytes,35,rep,name=manifest,proto3" json:"manifest,omitempty"`
+	// PhantomDirectoryMode specifies the manner in which fully phantom
+	// directories are reified when using Docker-style ignore syntax and
+	// semantics.
+	PhantomDirectoryMode core.PhantomDirectoryMode `protobuf:"varint,36,opt,name=phantomDirectoryMode,proto3,enum=core.PhantomDirectoryMode" json:"phantomDirectoryMode,omitempty"`
+	// MaximumFileSize is the maximum size of files that will be considered for
+	// synchronization. Larger files are excluded from synchronization as if
+	// they were ignored. A value of 0 indicates that no limit is applied.
+	MaximumFileSize uint64 `protobuf:"varint,37,opt,name=maximumFileSize,proto3" json:"maximumFileSize,omitempty"`
+	// IgnoreOSMetadataMode specifies the OS metadata ignore mode that should be
+	// used in synchronization.
+	IgnoreOSMetadataMode ignore.IgnoreOSMetadataMode `protobuf:"varint,38,opt,name=ignoreOSMetadataMode,proto3,enum=ignore.IgnoreOSMetadataMode" json:"ignoreOSMetadataMode,omitempty"`
 	// PermissionsMode species the manner in which permissions should be
 	// propagated between endpoints.
 	PermissionsMode core.PermissionsMode `protobuf:"varint,61,opt,name=permissionsMode,proto3,enum=core.PermissionsMode" json:"permissionsMode,omitempty"`
@@ -96,6 +140,262 @@ type Configuration struct {
 	// CompressionAlgorithm specifies the compression algorithm to use when
 	// communicating with the endpoint. This only applies to remote endpoints.
 	CompressionAlgorithm compression.Algorithm `protobuf:"varint,81,opt,name=compressionAlgorithm,proto3,enum=compression.Algorithm" json:"compressionAlgorithm,omitempty"`
+	// StagingCompressionAlgorithm specifies the compression algorithm to use
+	// for rsync operation data transmitted during staging. Unlike
+	// CompressionAlgorithm, it is applied to each data operation individually
+	// and is negotiated with the receiving endpoint, with operation data being
+	// transmitted uncompressed if the receiving endpoint doesn't support the
+	// algorithm. It can only be specified on a session-wide basis.
+	StagingCompressionAlgorithm compression.Algorithm `protobuf:"varint,82,opt,name=stagingCompressionAlgorithm,proto3,enum=compression.Algorithm" json:"stagingCompressionAlgorithm,omitempty"`
+	// SpecialFileMode specifies the manner in which special files (e.g. FIFOs
+	// and sockets) should be handled.
+	SpecialFileMode core.SpecialFileMode `protobuf:"varint,91,opt,name=specialFileMode,proto3,enum=core.SpecialFileMode" json:"specialFileMode,omitempty"`
+	// ClockSkewMode specifies the behavior to use when a remote endpoint's
+	// clock differs from the controller's clock by more than the clock skew
+	// tolerance. This only applies to remote endpoints.
+	ClockSkewMode ClockSkewMode `protobuf:"varint,101,opt,name=clockSkewMode,proto3,enum=synchronization.ClockSkewMode" json:"clockSkewMode,omitempty"`
+	// ClockSkewTolerance specifies the maximum tolerated offset (in seconds)
+	// between a remote endpoint's clock and the controller's clock. A zero
+	// value indicates that the default tolerance should be used. This only
+	// applies to remote endpoints.
+	ClockSkewTolerance uint32 `protobuf:"varint,102,opt,name=clockSkewTolerance,proto3" json:"clockSkewTolerance,omitempty"`
+	// ProbeDirectory specifies an alternate directory in which filesystem
+	// behavior probe files should be created. It must reside on the same
+	// filesystem as the synchronization root. An empty value indicates that
+	// probe files should be created within the synchronization root (or its
+	// parent directory for file roots).
+	ProbeDirectory string `protobuf:"bytes,111,opt,name=probeDirectory,proto3" json:"probeDirectory,omitempty"`
+	// AssumeExecutabilityPreservation specifies an explicitly stated
+	// assumption about executability preservation behavior. If specified, then
+	// executability preservation probing is skipped.
+	AssumeExecutabilityPreservation behavior.ProbeAssumption `protobuf:"varint,112,opt,name=assumeExecutabilityPreservation,proto3,enum=behavior.ProbeAssumption" json:"assumeExecutabilityPreservation,omitempty"`
+	// AssumeUnicodeDecomposition specifies an explicitly stated assumption
+	// about Unicode decomposition behavior. If specified, then Unicode
+	// decomposition probing is skipped.
+	AssumeUnicodeDecomposition behavior.ProbeAssumption `protobuf:"varint,113,opt,name=assumeUnicodeDecomposition,proto3,enum=behavior.ProbeAssumption" json:"assumeUnicodeDecomposition,omitempty"`
+	// OversizedFileMode specifies the behavior to use when a file that needs
+	// to be staged exceeds the maximum staging file size.
+	OversizedFileMode OversizedFileMode `protobuf:"varint,121,opt,name=oversizedFileMode,proto3,enum=synchronization.OversizedFileMode" json:"oversizedFileMode,omitempty"`
+	// MaximumRenameDetectionFileSize is the maximum (individual) file size for
+	// which endpoints will attempt to source staged content from existing
+	// files with matching digests (i.e. perform rename and copy detection).
+	// Larger files are staged normally. A zero value indicates no limit.
+	MaximumRenameDetectionFileSize uint64 `protobuf:"varint,122,opt,name=maximumRenameDetectionFileSize,proto3" json:"maximumRenameDetectionFileSize,omitempty"`
+	// MaximumContentCacheSize is the maximum total size of the persistent
+	// content cache, which endpoints use to source previously received content
+	// (keyed by digest) without re-transferring it. A zero value indicates the
+	// default, which disables the content cache.
+	MaximumContentCacheSize uint64 `protobuf:"varint,123,opt,name=maximumContentCacheSize,proto3" json:"maximumContentCacheSize,omitempty"`
+	// StagingConcurrencyMode specifies whether staging on alpha and beta should
+	// be performed sequentially or concurrently. It can only be specified on a
+	// session-wide basis.
+	StagingConcurrencyMode StagingConcurrencyMode `protobuf:"varint,124,opt,name=stagingConcurrencyMode,proto3,enum=synchronization.StagingConcurrencyMode" json:"stagingConcurrencyMode,omitempty"`
+	// MaximumDeltificationTime is the maximum amount of time (in milliseconds)
+	// that an endpoint will spend computing the rsync delta for an individual
+	// file that it's supplying. Once this limit is exceeded, the remainder of
+	// the file is transmitted as literal data, which is correct but less
+	// efficient. A zero value indicates the default, which imposes no limit.
+	MaximumDeltificationTime uint32 `protobuf:"varint,125,opt,name=maximumDeltificationTime,proto3" json:"maximumDeltificationTime,omitempty"`
+	// MaximumStagedContentAge is the maximum age (in seconds) of staged content
+	// that persists from previous staging operations which weren't followed by
+	// a transition. Older content is removed when staging next begins. A zero
+	// value indicates the default, which never removes content based on age.
+	MaximumStagedContentAge uint32 `protobuf:"varint,126,opt,name=maximumStagedContentAge,proto3" json:"maximumStagedContentAge,omitempty"`
+	// RsyncBlockSize specifies the block size (in bytes) that endpoints will
+	// use when computing rsync signatures for staging. If non-zero, it must be
+	// a power of two. A zero value indicates that the block size should be
+	// chosen automatically based on file size.
+	RsyncBlockSize uint64 `protobuf:"varint,127,opt,name=rsyncBlockSize,proto3" json:"rsyncBlockSize,omitempty"`
+	// MaximumInFlightBytes is the maximum total size (in bytes) of rsync
+	// operation data that an endpoint will buffer while supplying files, i.e.
+	// data that has been computed but not yet processed by the receiver. Once
+	// this limit is reached, computation of further operations blocks until
+	// the receiver catches up. A zero value indicates the default, which
+	// disables pipelining, in which case operations are forwarded to the
+	// receiver synchronously.
+	MaximumInFlightBytes uint64 `protobuf:"varint,128,opt,name=maximumInFlightBytes,proto3" json:"maximumInFlightBytes,omitempty"`
+	// RenameDetectionDigestLength specifies the length (in bytes) to which
+	// digests are truncated when building the in-memory reverse lookup map used
+	// for rename and copy detection during staging. Shorter keys reduce the
+	// map's memory usage for very large synchronization roots. Truncation can
+	// cause distinct digests to share a key, but candidate paths are always
+	// verified against full digests, so this can only reduce the effectiveness
+	// of rename and copy detection, never correctness. Digests stored in caches
+	// aren't affected. A zero value indicates the default, which uses
+	// full-length keys.
+	RenameDetectionDigestLength uint32 `protobuf:"varint,129,opt,name=renameDetectionDigestLength,proto3" json:"renameDetectionDigestLength,omitempty"`
+	// RenameDetectionRetries specifies the maximum number of times that an
+	// endpoint will retry staging content from an existing file with a
+	// matching digest (i.e. during rename and copy detection) if the staged
+	// content fails verification, which typically indicates that the source
+	// file was modified during the copy. A zero value indicates the default,
+	// which performs a single attempt before falling back to transfer.
+	RenameDetectionRetries uint32 `protobuf:"varint,130,opt,name=renameDetectionRetries,proto3" json:"renameDetectionRetries,omitempty"`
+	// StreamConcurrency specifies the number of concurrent request streams to
+	// multiplex over the connection to the endpoint. A value of 1 disables
+	// multiplexing and a zero value indicates that the default concurrency
+	// should be used. This only applies to remote endpoints.
+	StreamConcurrency uint32 `protobuf:"varint,131,opt,name=streamConcurrency,proto3" json:"streamConcurrency,omitempty"`
+	// DirectoryListingRetries specifies the maximum number of times that a
+	// directory listing will be re-read during scanning in an attempt to obtain
+	// a stable listing before any inconsistency is treated as a genuine
+	// concurrent modification. This is useful for filesystems (typically
+	// network filesystems) that can provide momentarily inconsistent directory
+	// listings. A zero value indicates the default, which performs no
+	// verification.
+	DirectoryListingRetries uint32 `protobuf:"varint,141,opt,name=directoryListingRetries,proto3" json:"directoryListingRetries,omitempty"`
+	// CacheSaveThreshold specifies the minimum number of scan cache entries
+	// that must differ from the last saved cache before the cache will be
+	// written to disk again. Changes below the threshold accumulate (since
+	// they're measured against the last saved cache) until the threshold is
+	// reached. A zero value indicates the default, which writes the cache
+	// after any change.
+	CacheSaveThreshold uint64 `protobuf:"varint,142,opt,name=cacheSaveThreshold,proto3" json:"cacheSaveThreshold,omitempty"`
+	// RootExistenceMode specifies how an endpoint handles the absence of its
+	// synchronization root.
+	RootExistenceMode RootExistenceMode `protobuf:"varint,143,opt,name=rootExistenceMode,proto3,enum=synchronization.RootExistenceMode" json:"rootExistenceMode,omitempty"`
+	// DigestSamplingThreshold specifies the file size above which sampled
+	// digests (covering the file size and its leading and trailing content)
+	// are used to detect changes to files whose metadata has changed. If a
+	// file's sampled digest is unchanged, then its previously computed digest
+	// is reused rather than recomputed from full file content. This
+	// accelerates change detection for very large files at the cost of missing
+	// changes that are confined to unsampled regions and don't alter file
+	// size. Sampled digests are never used to identify content (e.g. for
+	// staging or caching). A zero value indicates the default, which disables
+	// sampling. It can only be specified on a session-wide basis.
+	DigestSamplingThreshold uint64 `protobuf:"varint,144,opt,name=digestSamplingThreshold,proto3" json:"digestSamplingThreshold,omitempty"`
+	// NameNormalizationMode specifies the canonical form (if any) that content
+	// names must satisfy in order to be synchronized. It can only be specified
+	// on a session-wide basis.
+	NameNormalizationMode core.NameNormalizationMode `protobuf:"varint,145,opt,name=nameNormalizationMode,proto3,enum=core.NameNormalizationMode" json:"nameNormalizationMode,omitempty"`
+	// CapabilityMismatchMode specifies the behavior to use when the alpha and
+	// beta filesystems report differing capabilities (e.g. executability
+	// preservation or Unicode decomposition). It can only be specified on a
+	// session-wide basis.
+	CapabilityMismatchMode CapabilityMismatchMode `protobuf:"varint,146,opt,name=capabilityMismatchMode,proto3,enum=synchronization.CapabilityMismatchMode" json:"capabilityMismatchMode,omitempty"`
+	// MaximumRecheckPaths specifies the maximum number of re-check paths that
+	// will be accumulated for an accelerated (or scoped) scan before falling
+	// back to a full scan. A zero value indicates the default, which imposes
+	// no limit.
+	MaximumRecheckPaths uint64 `protobuf:"varint,147,opt,name=maximumRecheckPaths,proto3" json:"maximumRecheckPaths,omitempty"`
+	// ConfigurationIncompatibilityMode specifies the behavior to use when an
+	// endpoint's effective configuration is incompatible with the capabilities
+	// probed for its filesystem (e.g. a default file mode with executability
+	// bits on a filesystem that doesn't preserve executability). Validation is
+	// performed after each successful scan and before any transitions. It can
+	// only be specified on a session-wide basis.
+	ConfigurationIncompatibilityMode ConfigurationIncompatibilityMode `protobuf:"varint,148,opt,name=configurationIncompatibilityMode,proto3,enum=synchronization.ConfigurationIncompatibilityMode" json:"configurationIncompatibilityMode,omitempty"`
+	// DigestLength specifies the length (in bytes) to which file digests are
+	// truncated in snapshots. Shorter digests reduce memory usage and transfer
+	// sizes for very large synchronization roots, but increase the probability
+	// that a content change will go undetected. Caches and staged content are
+	// always verified using full-length digests, but truncated digests can't
+	// identify content across paths, so truncation disables rename and copy
+	// detection as well as the content cache. With those disabled, snapshot
+	// digests are only compared against digests for the same path, and a
+	// length of 8 bytes (the minimum) leaves a collision probability of
+	// roughly 2^-64 per change. A zero value indicates the default, which uses
+	// full-length digests. Values that are not less than the hashing
+	// algorithm's digest length also disable truncation. It can only be
+	// specified on a session-wide basis.
+	DigestLength uint32 `protobuf:"varint,149,opt,name=digestLength,proto3" json:"digestLength,omitempty"`
+	// CacheTrustMode specifies the file metadata that must match an endpoint's
+	// persisted cache for cached digests to be reused during the first scan
+	// after the endpoint starts (e.g. after a daemon restart). Subsequent scans
+	// always use strict matching, since their caches are generated by the
+	// running endpoint. A default value indicates strict matching.
+	CacheTrustMode core.CacheTrustMode `protobuf:"varint,150,opt,name=cacheTrustMode,proto3,enum=core.CacheTrustMode" json:"cacheTrustMode,omitempty"`
+	// TypeChangeMode specifies the manner in which non-root entry type changes
+	// on one endpoint are handled in bidirectional synchronization modes. It
+	// can only be specified on a session-wide basis.
+	TypeChangeMode core.TypeChangeMode `protobuf:"varint,151,opt,name=typeChangeMode,proto3,enum=core.TypeChangeMode" json:"typeChangeMode,omitempty"`
+	// MaximumConflictPersistence specifies the maximum number of consecutive
+	// synchronization cycles in which an unchanged conflict will be tolerated
+	// before the session halts for safety. A zero value indicates no limit. It
+	// can only be specified on a session-wide basis.
+	MaximumConflictPersistence uint32 `protobuf:"varint,152,opt,name=maximumConflictPersistence,proto3" json:"maximumConflictPersistence,omitempty"`
+	// WatchdogTimeout specifies the amount of time (in seconds) for which a
+	// synchronization cycle may go without making progress before the
+	// synchronization loop is forcibly restarted. A zero value indicates the
+	// default, which disables the watchdog. It can only be specified on a
+	// session-wide basis.
+	WatchdogTimeout uint32 `protobuf:"varint,161,opt,name=watchdogTimeout,proto3" json:"watchdogTimeout,omitempty"`
+	// AgentTerminationReconnectDelay specifies the amount of time (in
+	// milliseconds) to wait before reconnecting after synchronization fails due
+	// to an endpoint's agent process terminating unexpectedly. This is
+	// typically much shorter than the delay used after other failures (such as
+	// loss of connectivity), since the remote is likely still reachable. A zero
+	// value indicates the default. It can only be specified on a session-wide
+	// basis.
+	AgentTerminationReconnectDelay uint32 `protobuf:"varint,162,opt,name=agentTerminationReconnectDelay,proto3" json:"agentTerminationReconnectDelay,omitempty"`
+	// MaximumBetaScanDeferral specifies the maximum number of consecutive
+	// synchronization cycles for which the beta scan may be deferred when using
+	// a unidirectional synchronization mode. During a deferred scan, beta
+	// reports its last scanned contents (updated to reflect any changes that
+	// synchronization has since applied to it) instead of re-scanning, so
+	// modifications made on beta by other means may go unobserved for this
+	// many cycles. A zero value indicates the default, which disables scan
+	// deferral. It can only be specified on a session-wide basis.
+	MaximumBetaScanDeferral uint32 `protobuf:"varint,163,opt,name=maximumBetaScanDeferral,proto3" json:"maximumBetaScanDeferral,omitempty"`
+	// OverlayBase specifies a base directory that should be treated as an
+	// immutable lower layer beneath the synchronization root. If specified,
+	// the endpoint presents the merged view of the base directory and the
+	// synchronization root (which acts as the upper layer) for scanning and
+	// applies all transitions to the synchronization root, using whiteout
+	// markers to hide removed base content. The base directory is never
+	// modified. An empty value disables overlay behavior.
+	OverlayBase string `protobuf:"bytes,171,opt,name=overlayBase,proto3" json:"overlayBase,omitempty"`
+	// RootOverlapMode specifies the behavior to use when a session's local
+	// synchronization root overlaps with a local synchronization root of an
+	// existing session. It is only evaluated at session creation time. It can
+	// only be specified on a session-wide basis.
+	RootOverlapMode RootOverlapMode `protobuf:"varint,181,opt,name=rootOverlapMode,proto3,enum=synchronization.RootOverlapMode" json:"rootOverlapMode,omitempty"`
+	// ScanSharingMode specifies whether or not the endpoint should share its
+	// scan results with (and reuse scan results from) other sessions in the
+	// same process that target the same synchronization root with equivalent
+	// scan parameters. Sharing is only performed by local endpoints.
+	ScanSharingMode ScanSharingMode `protobuf:"varint,191,opt,name=scanSharingMode,proto3,enum=synchronization.ScanSharingMode" json:"scanSharingMode,omitempty"`
+	// ScanSubpaths specifies normalized, non-root, synchronization-root-
+	// relative paths (see NormalizeScope) whose subtrees are the only content
+	// re-scanned by an endpoint's regular scans once an initial full scan has
+	// been performed. Content outside of these subtrees is treated as unchanged
+	// from the last snapshot (except where modified by synchronization), so
+	// modifications made there by other means aren't observed until the next
+	// full scan (e.g. one forced by an unscoped flush). This only affects scans
+	// that can't otherwise be accelerated. An empty list indicates the default,
+	// which scans the entire synchronization root.
+	ScanSubpaths []string `protobuf:"bytes,201,rep,name=scanSubpaths,proto3" json:"scanSubpaths,omitempty"`
+	// VerificationMode specifies whether or not the session should periodically
+	// verify the content of its endpoints in the background by performing a
+	// full (cold) re-hash of their content and comparing it against the last
+	// synchronized state. It can only be specified on a session-wide basis.
+	VerificationMode VerificationMode `protobuf:"varint,211,opt,name=verificationMode,proto3,enum=synchronization.VerificationMode" json:"verificationMode,omitempty"`
+	// VerificationInterval specifies the interval (in seconds) between
+	// background verification passes. It is only used if periodic verification
+	// is enabled. It can only be specified on a session-wide basis.
+	VerificationInterval uint32 `protobuf:"varint,212,opt,name=verificationInterval,proto3" json:"verificationInterval,omitempty"`
+	// MaximumReportedProblems is the maximum number of scan or transition
+	// problems that the session will retain and report for each endpoint.
+	// Excess problems are only reported as a count. A value of 0 indicates the
+	// default. It can only be specified on a session-wide basis.
+	MaximumReportedProblems uint32 `protobuf:"varint,221,opt,name=maximumReportedProblems,proto3" json:"maximumReportedProblems,omitempty"`
+	// AggregateDigestMode specifies whether or not the endpoint should compute
+	// aggregate digests for the directories in its snapshots, allowing
+	// unchanged subtrees to be detected without examining their contents when
+	// comparing snapshots.
+	AggregateDigestMode AggregateDigestMode `protobuf:"varint,231,opt,name=aggregateDigestMode,proto3,enum=synchronization.AggregateDigestMode" json:"aggregateDigestMode,omitempty"`
+	// MaximumReconciliationRecordings is the maximum number of reconciliation
+	// recordings that the session will retain in the Mutagen data directory.
+	// Once the limit is reached, the oldest recordings are removed. A value of
+	// 0 indicates the default. It can only be specified on a session-wide
+	// basis.
+	MaximumReconciliationRecordings uint32 `protobuf:"varint,241,opt,name=maximumReconciliationRecordings,proto3" json:"maximumReconciliationRecordings,omitempty"`
+	// SlowDirectoryListingThreshold is the minimum amount of time (in
+	// milliseconds) that a directory listing must take during scanning in order
+	// to be logged (at the trace level) as slow. A value of 0 indicates the
+	// default.
+	SlowDirectoryListingThreshold uint32 `protobuf:"varint,242,opt,name=slowDirectoryListingThreshold,proto3" json:"slowDirectoryListingThreshold,omitempty"`
 }
 
 func (x *Configuration) Reset() {
@@ -177,6 +477,27 @@ func (x *Configuration) GetStageMode() StageMode {
 	return StageMode_StageModeDefault
 }
 
+func (x *Configuration) GetMaximumConflictCount() uint64 {
+	if x != nil {
+		return x.MaximumConflictCount
+	}
+	return 0
+}
+
+func (x *Configuration) GetTransitionMode() core.TransitionMode {
+	if x != nil {
+		return x.TransitionMode
+	}
+	return core.TransitionMode(0)
+}
+
+func (x *Configuration) GetMaximumSignatureMemory() uint64 {
+	if x != nil {
+		return x.MaximumSignatureMemory
+	}
+	return 0
+}
+
 func (x *Configuration) GetSymbolicLinkMode() core.SymbolicLinkMode {
 	if x != nil {
 		return x.SymbolicLinkMode
@@ -184,6 +505,20 @@ func (x *Configuration) GetSymbolicLinkMode() core.SymbolicLinkMode {
 	return core.SymbolicLinkMode(0)
 }
 
+func (x *Configuration) GetSymbolicLinkReplacementMode() core.SymbolicLinkReplacementMode {
+	if x != nil {
+		return x.SymbolicLinkReplacementMode
+	}
+	return core.SymbolicLinkReplacementMode(0)
+}
+
+func (x *Configuration) GetSymbolicLinkCycleMode() core.SymbolicLinkCycleMode {
+	if x != nil {
+		return x.SymbolicLinkCycleMode
+	}
+	return core.SymbolicLinkCycleMode(0)
+}
+
 func (x *Configuration) GetWatchMode() WatchMode {
 	if x != nil {
 		return x.WatchMode
@@ -198,6 +533,27 @@ func (x *Configuration) GetWatchPollingInterval() uint32 {
 	return 0
 }
 
+func (x *Configuration) GetWatchQueueSize() uint32 {
+	if x != nil {
+		return x.WatchQueueSize
+	}
+	return 0
+}
+
+func (x *Configuration) GetInitialSynchronizationMode() InitialSynchronizationMode {
+	if x != nil {
+		return x.InitialSynchronizationMode
+	}
+	return InitialSynchronizationMode_InitialSynchronizationModeDefault
+}
+
+func (x *Configuration) GetInitialPollModificationMode() InitialPollModificationMode {
+	if x != nil {
+		return x.InitialPollModificationMode
+	}
+	return InitialPollModificationMode_InitialPollModificationModeDefault
+}
+
 func (x *Configuration) GetIgnoreSyntax() ignore.Syntax {
 	if x != nil {
 		return x.IgnoreSyntax
@@ -226,6 +582,34 @@ func (x *Configuration) GetIgnoreVCSMode() ignore.IgnoreVCSMode {
 	return ignore.IgnoreVCSMode(0)
 }
 
+func (x *Configuration) GetManifest() []string {
+	if x != nil {
+		return x.Manifest
+	}
+	return nil
+}
+
+func (x *Configuration) GetPhantomDirectoryMode() core.PhantomDirectoryMode {
+	if x != nil {
+		return x.PhantomDirectoryMode
+	}
+	return core.PhantomDirectoryMode(0)
+}
+
+func (x *Configuration) GetMaximumFileSize() uint64 {
+	if x != nil {
+		return x.MaximumFileSize
+	}
+	return 0
+}
+
+func (x *Configuration) GetIgnoreOSMetadataMode() ignore.IgnoreOSMetadataMode {
+	if x != nil {
+		return x.IgnoreOSMetadataMode
+	}
+	return ignore.IgnoreOSMetadataMode(0)
+}
+
 func (x *Configuration) GetPermissionsMode() core.PermissionsMode {
 	if x != nil {
 		return x.PermissionsMode
@@ -268,112 +652,711 @@ func (x *Configuration) GetCompressionAlgorithm() compression.Algorithm {
 	return compression.Algorithm(0)
 }
 
+func (x *Configuration) GetStagingCompressionAlgorithm() compression.Algorithm {
+	if x != nil {
+		return x.StagingCompressionAlgorithm
+	}
+	return compression.Algorithm(0)
+}
+
+func (x *Configuration) GetSpecialFileMode() core.SpecialFileMode {
+	if x != nil {
+		return x.SpecialFileMode
+	}
+	return core.SpecialFileMode(0)
+}
+
+func (x *Configuration) GetClockSkewMode() ClockSkewMode {
+	if x != nil {
+		return x.ClockSkewMode
+	}
+	return ClockSkewMode_ClockSkewModeDefault
+}
+
+func (x *Configuration) GetClockSkewTolerance() uint32 {
+	if x != nil {
+		return x.ClockSkewTolerance
+	}
+	return 0
+}
+
+func (x *Configuration) GetProbeDirectory() string {
+	if x != nil {
+		return x.ProbeDirectory
+	}
+	return ""
+}
+
+func (x *Configuration) GetAssumeExecutabilityPreservation() behavior.ProbeAssumption {
+	if x != nil {
+		return x.AssumeExecutabilityPreservation
+	}
+	return behavior.ProbeAssumption(0)
+}
+
+func (x *Configuration) GetAssumeUnicodeDecomposition() behavior.ProbeAssumption {
+	if x != nil {
+		return x.AssumeUnicodeDecomposition
+	}
+	return behavior.ProbeAssumption(0)
+}
+
+func (x *Configuration) GetOversizedFileMode() OversizedFileMode {
+	if x != nil {
+		return x.OversizedFileMode
+	}
+	return OversizedFileMode_OversizedFileModeDefault
+}
+
+func (x *Configuration) GetMaximumRenameDetectionFileSize() uint64 {
+	if x != nil {
+		return x.MaximumRenameDetectionFileSize
+	}
+	return 0
+}
+
+func (x *Configuration) GetMaximumContentCacheSize() uint64 {
+	if x != nil {
+		return x.MaximumContentCacheSize
+	}
+	return 0
+}
+
+func (x *Configuration) GetStagingConcurrencyMode() StagingConcurrencyMode {
+	if x != nil {
+		return x.StagingConcurrencyMode
+	}
+	return StagingConcurrencyMode_StagingConcurrencyModeDefault
+}
+
+func (x *Configuration) GetMaximumDeltificationTime() uint32 {
+	if x != nil {
+		return x.MaximumDeltificationTime
+	}
+	return 0
+}
+
+func (x *Configuration) GetMaximumStagedContentAge() uint32 {
+	if x != nil {
+		return x.MaximumStagedContentAge
+	}
+	return 0
+}
+
+func (x *Configuration) GetRsyncBlockSize() uint64 {
+	if x != nil {
+		return x.RsyncBlockSize
+	}
+	return 0
+}
+
+func (x *Configuration) GetMaximumInFlightBytes() uint64 {
+	if x != nil {
+		return x.MaximumInFlightBytes
+	}
+	return 0
+}
+
+func (x *Configuration) GetRenameDetectionDigestLength() uint32 {
+	if x != nil {
+		return x.RenameDetectionDigestLength
+	}
+	return 0
+}
+
+func (x *Configuration) GetRenameDetectionRetries() uint32 {
+	if x != nil {
+		return x.RenameDetectionRetries
+	}
+	return 0
+}
+
+func (x *Configuration) GetStreamConcurrency() uint32 {
+	if x != nil {
+		return x.StreamConcurrency
+	}
+	return 0
+}
+
+func (x *Configuration) GetDirectoryListingRetries() uint32 {
+	if x != nil {
+		return x.DirectoryListingRetries
+	}
+	return 0
+}
+
+func (x *Configuration) GetCacheSaveThreshold() uint64 {
+	if x != nil {
+		return x.CacheSaveThreshold
+	}
+	return 0
+}
+
+func (x *Configuration) GetRootExistenceMode() RootExistenceMode {
+	if x != nil {
+		return x.RootExistenceMode
+	}
+	return RootExistenceMode_RootExistenceModeDefault
+}
+
+func (x *Configuration) GetDigestSamplingThreshold() uint64 {
+	if x != nil {
+		return x.DigestSamplingThreshold
+	}
+	return 0
+}
+
+func (x *Configuration) GetNameNormalizationMode() core.NameNormalizationMode {
+	if x != nil {
+		return x.NameNormalizationMode
+	}
+	return core.NameNormalizationMode(0)
+}
+
+func (x *Configuration) GetCapabilityMismatchMode() CapabilityMismatchMode {
+	if x != nil {
+		return x.CapabilityMismatchMode
+	}
+	return CapabilityMismatchMode_CapabilityMismatchModeDefault
+}
+
+func (x *Configuration) GetMaximumRecheckPaths() uint64 {
+	if x != nil {
+		return x.MaximumRecheckPaths
+	}
+	return 0
+}
+
+func (x *Configuration) GetConfigurationIncompatibilityMode() ConfigurationIncompatibilityMode {
+	if x != nil {
+		return x.ConfigurationIncompatibilityMode
+	}
+	return ConfigurationIncompatibilityMode_ConfigurationIncompatibilityModeDefault
+}
+
+func (x *Configuration) GetDigestLength() uint32 {
+	if x != nil {
+		return x.DigestLength
+	}
+	return 0
+}
+
+func (x *Configuration) GetCacheTrustMode() core.CacheTrustMode {
+	if x != nil {
+		return x.CacheTrustMode
+	}
+	return core.CacheTrustMode(0)
+}
+
+func (x *Configuration) GetTypeChangeMode() core.TypeChangeMode {
+	if x != nil {
+		return x.TypeChangeMode
+	}
+	return core.TypeChangeMode(0)
+}
+
+func (x *Configuration) GetMaximumConflictPersistence() uint32 {
+	if x != nil {
+		return x.MaximumConflictPersistence
+	}
+	return 0
+}
+
+func (x *Configuration) GetWatchdogTimeout() uint32 {
+	if x != nil {
+		return x.WatchdogTimeout
+	}
+	return 0
+}
+
+func (x *Configuration) GetAgentTerminationReconnectDelay() uint32 {
+	if x != nil {
+		return x.AgentTerminationReconnectDelay
+	}
+	return 0
+}
+
+func (x *Configuration) GetMaximumBetaScanDeferral() uint32 {
+	if x != nil {
+		return x.MaximumBetaScanDeferral
+	}
+	return 0
+}
+
+func (x *Configuration) GetOverlayBase() string {
+	if x != nil {
+		return x.OverlayBase
+	}
+	return ""
+}
+
+func (x *Configuration) GetRootOverlapMode() RootOverlapMode {
+	if x != nil {
+		return x.RootOverlapMode
+	}
+	return RootOverlapMode_RootOverlapModeDefault
+}
+
+func (x *Configuration) GetScanSharingMode() ScanSharingMode {
+	if x != nil {
+		return x.ScanSharingMode
+	}
+	return ScanSharingMode_ScanSharingModeDefault
+}
+
+func (x *Configuration) GetScanSubpaths() []string {
+	if x != nil {
+		return x.ScanSubpaths
+	}
+	return nil
+}
+
+func (x *Configuration) GetVerificationMode() VerificationMode {
+	if x != nil {
+		return x.VerificationMode
+	}
+	return VerificationMode_VerificationModeDefault
+}
+
+func (x *Configuration) GetVerificationInterval() uint32 {
+	if x != nil {
+		return x.VerificationInterval
+	}
+	return 0
+}
+
+func (x *Configuration) GetMaximumReportedProblems() uint32 {
+	if x != nil {
+		return x.MaximumReportedProblems
+	}
+	return 0
+}
+
+func (x *Configuration) GetAggregateDigestMode() AggregateDigestMode {
+	if x != nil {
+		return x.AggregateDigestMode
+	}
+	return AggregateDigestMode_AggregateDigestModeDefault
+}
+
+func (x *Configuration) GetMaximumReconciliationRecordings() uint32 {
+	if x != nil {
+		return x.MaximumReconciliationRecordings
+	}
+	return 0
+}
+
+func (x *Configuration) GetSlowDirectoryListingThreshold() uint32 {
+	if x != nil {
+		return x.SlowDirectoryListingThreshold
+	}
+	return 0
+}
+
 var File_synchronization_configuration_proto protoreflect.FileDescriptor
 
 var file_synchronization_configuration_proto_rawDesc = []byte{
 	0x0a, 0x23, 0x73, 0x79, 0x6e, 0x63, 0x68, 0x72, 0x6f, 0x6e, 0x69, 0x7a, 0x61, 0x74, 0x69, 0x6f,
 	0x6e, 0x2f, 0x63, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x75, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x2e,
 	0x70, 0x72, 0x6f, 0x74, 0x6f, 0x12, 0x0f, 0x73, 0x79, 0x6e, 0x63, 0x68, 0x72, 0x6f, 0x6e, 0x69,
-	0x7a, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x1a, 0x24, 0x66, 0x69, 0x6c, 0x65, 0x73, 0x79, 0x73, 0x74,
+	0x7a, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x1a, 0x2a, 0x66, 0x69, 0x6c, 0x65, 0x73, 0x79, 0x73, 0x74,
 	0x65, 0x6d, 0x2f, 0x62, 0x65, 0x68, 0x61, 0x76, 0x69, 0x6f, 0x72, 0x2f, 0x70, 0x72, 0x6f, 0x62,
-	0x65, 0x5f, 0x6d, 0x6f, 0x64, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x1a, 0x1f, 0x73, 0x79,
-	0x6e, 0x63, 0x68, 0x72, 0x6f, 0x6e, 0x69, 0x7a, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x2f, 0x73, 0x63,
-	0x61, 0x6e, 0x5f, 0x6d, 0x6f, 0x64, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x1a, 0x20, 0x73,
-	0x79, 0x6e, 0x63, 0x68, 0x72, 0x6f, 0x6e, 0x69, 0x7a, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x2f, 0x73,
-	0x74, 0x61, 0x67, 0x65, 0x5f, 0x6d, 0x6f, 0x64, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x1a,
-	0x20, 0x73, 0x79, 0x6e, 0x63, 0x68, 0x72, 0x6f, 0x6e, 0x69, 0x7a, 0x61, 0x74, 0x69, 0x6f, 0x6e,
-	0x2f, 0x77, 0x61, 0x74, 0x63, 0x68, 0x5f, 0x6d, 0x6f, 0x64, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74,
-	0x6f, 0x1a, 0x2b, 0x73, 0x79, 0x6e, 0x63, 0x68, 0x72, 0x6f, 0x6e, 0x69, 0x7a, 0x61, 0x74, 0x69,
-	0x6f, 0x6e, 0x2f, 0x63, 0x6f, 0x6d, 0x70, 0x72, 0x65, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x2f, 0x61,
-	0x6c, 0x67, 0x6f, 0x72, 0x69, 0x74, 0x68, 0x6d, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x1a, 0x1f,
-	0x73, 0x79, 0x6e, 0x63, 0x68, 0x72, 0x6f, 0x6e, 0x69, 0x7a, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x2f,
-	0x63, 0x6f, 0x72, 0x65, 0x2f, 0x6d, 0x6f, 0x64, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x1a,
-	0x2b, 0x73, 0x79, 0x6e, 0x63, 0x68, 0x72, 0x6f, 0x6e, 0x69, 0x7a, 0x61, 0x74, 0x69, 0x6f, 0x6e,
-	0x2f, 0x63, 0x6f, 0x72, 0x65, 0x2f, 0x70, 0x65, 0x72, 0x6d, 0x69, 0x73, 0x73, 0x69, 0x6f, 0x6e,
-	0x73, 0x5f, 0x6d, 0x6f, 0x64, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x1a, 0x2d, 0x73, 0x79,
+	0x65, 0x5f, 0x61, 0x73, 0x73, 0x75, 0x6d, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x2e, 0x70, 0x72, 0x6f,
+	0x74, 0x6f, 0x1a, 0x24, 0x66, 0x69, 0x6c, 0x65, 0x73, 0x79, 0x73, 0x74, 0x65, 0x6d, 0x2f, 0x62,
+	0x65, 0x68, 0x61, 0x76, 0x69, 0x6f, 0x72, 0x2f, 0x70, 0x72, 0x6f, 0x62, 0x65, 0x5f, 0x6d, 0x6f,
+	0x64, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x1a, 0x2b, 0x73, 0x79, 0x6e, 0x63, 0x68, 0x72,
+	0x6f, 0x6e, 0x69, 0x7a, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x2f, 0x61, 0x67, 0x67, 0x72, 0x65, 0x67,
+	0x61, 0x74, 0x65, 0x5f, 0x64, 0x69, 0x67, 0x65, 0x73, 0x74, 0x5f, 0x6d, 0x6f, 0x64, 0x65, 0x2e,
+	0x70, 0x72, 0x6f, 0x74, 0x6f, 0x1a, 0x2e, 0x73, 0x79, 0x6e, 0x63, 0x68, 0x72, 0x6f, 0x6e, 0x69,
+	0x7a, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x2f, 0x63, 0x61, 0x70, 0x61, 0x62, 0x69, 0x6c, 0x69, 0x74,
+	0x79, 0x5f, 0x6d, 0x69, 0x73, 0x6d, 0x61, 0x74, 0x63, 0x68, 0x5f, 0x6d, 0x6f, 0x64, 0x65, 0x2e,
+	0x70, 0x72, 0x6f, 0x74, 0x6f, 0x1a, 0x25, 0x73, 0x79, 0x6e, 0x63, 0x68, 0x72, 0x6f, 0x6e, 0x69,
+	0x7a, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x2f, 0x63, 0x6c, 0x6f, 0x63, 0x6b, 0x5f, 0x73, 0x6b, 0x65,
+	0x77, 0x5f, 0x6d, 0x6f, 0x64, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x1a, 0x38, 0x73, 0x79,
 	0x6e, 0x63, 0x68, 0x72, 0x6f, 0x6e, 0x69, 0x7a, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x2f, 0x63, 0x6f,
-	0x72, 0x65, 0x2f, 0x73, 0x79, 0x6d, 0x62, 0x6f, 0x6c, 0x69, 0x63, 0x5f, 0x6c, 0x69, 0x6e, 0x6b,
+	0x6e, 0x66, 0x69, 0x67, 0x75, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x5f, 0x69, 0x6e, 0x63, 0x6f,
+	0x6d, 0x70, 0x61, 0x74, 0x69, 0x62, 0x69, 0x6c, 0x69, 0x74, 0x79, 0x5f, 0x6d, 0x6f, 0x64, 0x65,
+	0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x1a, 0x34, 0x73, 0x79, 0x6e, 0x63, 0x68, 0x72, 0x6f, 0x6e,
+	0x69, 0x7a, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x2f, 0x69, 0x6e, 0x69, 0x74, 0x69, 0x61, 0x6c, 0x5f,
+	0x70, 0x6f, 0x6c, 0x6c, 0x5f, 0x6d, 0x6f, 0x64, 0x69, 0x66, 0x69, 0x63, 0x61, 0x74, 0x69, 0x6f,
+	0x6e, 0x5f, 0x6d, 0x6f, 0x64, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x1a, 0x32, 0x73, 0x79,
+	0x6e, 0x63, 0x68, 0x72, 0x6f, 0x6e, 0x69, 0x7a, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x2f, 0x69, 0x6e,
+	0x69, 0x74, 0x69, 0x61, 0x6c, 0x5f, 0x73, 0x79, 0x6e, 0x63, 0x68, 0x72, 0x6f, 0x6e, 0x69, 0x7a,
+	0x61, 0x74, 0x69, 0x6f, 0x6e, 0x5f, 0x6d, 0x6f, 0x64, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f,
+	0x1a, 0x29, 0x73, 0x79, 0x6e, 0x63, 0x68, 0x72, 0x6f, 0x6e, 0x69, 0x7a, 0x61, 0x74, 0x69, 0x6f,
+	0x6e, 0x2f, 0x6f, 0x76, 0x65, 0x72, 0x73, 0x69, 0x7a, 0x65, 0x64, 0x5f, 0x66, 0x69, 0x6c, 0x65,
+	0x5f, 0x6d, 0x6f, 0x64, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x1a, 0x29, 0x73, 0x79, 0x6e,
+	0x63, 0x68, 0x72, 0x6f, 0x6e, 0x69, 0x7a, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x2f, 0x72, 0x6f, 0x6f,
+	0x74, 0x5f, 0x65, 0x78, 0x69, 0x73, 0x74, 0x65, 0x6e, 0x63, 0x65, 0x5f, 0x6d, 0x6f, 0x64, 0x65,
+	0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x1a, 0x27, 0x73, 0x79, 0x6e, 0x63, 0x68, 0x72, 0x6f, 0x6e,
+	0x69, 0x7a, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x2f, 0x72, 0x6f, 0x6f, 0x74, 0x5f, 0x6f, 0x76, 0x65,
+	0x72, 0x6c, 0x61, 0x70, 0x5f, 0x6d, 0x6f, 0x64, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x1a,
+	0x1f, 0x73, 0x79, 0x6e, 0x63, 0x68, 0x72, 0x6f, 0x6e, 0x69, 0x7a, 0x61, 0x74, 0x69, 0x6f, 0x6e,
+	0x2f, 0x73, 0x63, 0x61, 0x6e, 0x5f, 0x6d, 0x6f, 0x64, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f,
+	0x1a, 0x27, 0x73, 0x79, 0x6e, 0x63, 0x68, 0x72, 0x6f, 0x6e, 0x69, 0x7a, 0x61, 0x74, 0x69, 0x6f,
+	0x6e, 0x2f, 0x73, 0x63, 0x61, 0x6e, 0x5f, 0x73, 0x68, 0x61, 0x72, 0x69, 0x6e, 0x67, 0x5f, 0x6d,
+	0x6f, 0x64, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x1a, 0x20, 0x73, 0x79, 0x6e, 0x63, 0x68,
+	0x72, 0x6f, 0x6e, 0x69, 0x7a, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x2f, 0x73, 0x74, 0x61, 0x67, 0x65,
+	0x5f, 0x6d, 0x6f, 0x64, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x1a, 0x2e, 0x73, 0x79, 0x6e,
+	0x63, 0x68, 0x72, 0x6f, 0x6e, 0x69, 0x7a, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x2f, 0x73, 0x74, 0x61,
+	0x67, 0x69, 0x6e, 0x67, 0x5f, 0x63, 0x6f, 0x6e, 0x63, 0x75, 0x72, 0x72, 0x65, 0x6e, 0x63, 0x79,
+	0x5f, 0x6d, 0x6f, 0x64, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x1a, 0x27, 0x73, 0x79, 0x6e,
+	0x63, 0x68, 0x72, 0x6f, 0x6e, 0x69, 0x7a, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x2f, 0x76, 0x65, 0x72,
+	0x69, 0x66, 0x69, 0x63, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x5f, 0x6d, 0x6f, 0x64, 0x65, 0x2e, 0x70,
+	0x72, 0x6f, 0x74, 0x6f, 0x1a, 0x20, 0x73, 0x79, 0x6e, 0x63, 0x68, 0x72, 0x6f, 0x6e, 0x69, 0x7a,
+	0x61, 0x74, 0x69, 0x6f, 0x6e, 0x2f, 0x77, 0x61, 0x74, 0x63, 0x68, 0x5f, 0x6d, 0x6f, 0x64, 0x65,
+	0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x1a, 0x2b, 0x73, 0x79, 0x6e, 0x63, 0x68, 0x72, 0x6f, 0x6e,
+	0x69, 0x7a, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x2f, 0x63, 0x6f, 0x6d, 0x70, 0x72, 0x65, 0x73, 0x73,
+	0x69, 0x6f, 0x6e, 0x2f, 0x61, 0x6c, 0x67, 0x6f, 0x72, 0x69, 0x74, 0x68, 0x6d, 0x2e, 0x70, 0x72,
+	0x6f, 0x74, 0x6f, 0x1a, 0x2b, 0x73, 0x79, 0x6e, 0x63, 0x68, 0x72, 0x6f, 0x6e, 0x69, 0x7a, 0x61,
+	0x74, 0x69, 0x6f, 0x6e, 0x2f, 0x63, 0x6f, 0x72, 0x65, 0x2f, 0x63, 0x61, 0x63, 0x68, 0x65, 0x5f,
+	0x74, 0x72, 0x75, 0x73, 0x74, 0x5f, 0x6d, 0x6f, 0x64, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f,
+	0x1a, 0x1f, 0x73, 0x79, 0x6e, 0x63, 0x68, 0x72, 0x6f, 0x6e, 0x69, 0x7a, 0x61, 0x74, 0x69, 0x6f,
+	0x6e, 0x2f, 0x63, 0x6f, 0x72, 0x65, 0x2f, 0x6d, 0x6f, 0x64, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74,
+	0x6f, 0x1a, 0x32, 0x73, 0x79, 0x6e, 0x63, 0x68, 0x72, 0x6f, 0x6e, 0x69, 0x7a, 0x61, 0x74, 0x69,
+	0x6f, 0x6e, 0x2f, 0x63, 0x6f, 0x72, 0x65, 0x2f, 0x6e, 0x61, 0x6d, 0x65, 0x5f, 0x6e, 0x6f, 0x72,
+	0x6d, 0x61, 0x6c, 0x69, 0x7a, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x5f, 0x6d, 0x6f, 0x64, 0x65, 0x2e,
+	0x70, 0x72, 0x6f, 0x74, 0x6f, 0x1a, 0x2b, 0x73, 0x79, 0x6e, 0x63, 0x68, 0x72, 0x6f, 0x6e, 0x69,
+	0x7a, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x2f, 0x63, 0x6f, 0x72, 0x65, 0x2f, 0x70, 0x65, 0x72, 0x6d,
+	0x69, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x73, 0x5f, 0x6d, 0x6f, 0x64, 0x65, 0x2e, 0x70, 0x72, 0x6f,
+	0x74, 0x6f, 0x1a, 0x31, 0x73, 0x79, 0x6e, 0x63, 0x68, 0x72, 0x6f, 0x6e, 0x69, 0x7a, 0x61, 0x74,
+	0x69, 0x6f, 0x6e, 0x2f, 0x63, 0x6f, 0x72, 0x65, 0x2f, 0x70, 0x68, 0x61, 0x6e, 0x74, 0x6f, 0x6d,
+	0x5f, 0x64, 0x69, 0x72, 0x65, 0x63, 0x74, 0x6f, 0x72, 0x79, 0x5f, 0x6d, 0x6f, 0x64, 0x65, 0x2e,
+	0x70, 0x72, 0x6f, 0x74, 0x6f, 0x1a, 0x2c, 0x73, 0x79, 0x6e, 0x63, 0x68, 0x72, 0x6f, 0x6e, 0x69,
+	0x7a, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x2f, 0x63, 0x6f, 0x72, 0x65, 0x2f, 0x73, 0x70, 0x65, 0x63,
+	0x69, 0x61, 0x6c, 0x5f, 0x66, 0x69, 0x6c, 0x65, 0x5f, 0x6d, 0x6f, 0x64, 0x65, 0x2e, 0x70, 0x72,
+	0x6f, 0x74, 0x6f, 0x1a, 0x33, 0x73, 0x79, 0x6e, 0x63, 0x68, 0x72, 0x6f, 0x6e, 0x69, 0x7a, 0x61,
+	0x74, 0x69, 0x6f, 0x6e, 0x2f, 0x63, 0x6f, 0x72, 0x65, 0x2f, 0x73, 0x79, 0x6d, 0x62, 0x6f, 0x6c,
+	0x69, 0x63, 0x5f, 0x6c, 0x69, 0x6e, 0x6b, 0x5f, 0x63, 0x79, 0x63, 0x6c, 0x65, 0x5f, 0x6d, 0x6f,
+	0x64, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x1a, 0x2d, 0x73, 0x79, 0x6e, 0x63, 0x68, 0x72,
+	0x6f, 0x6e, 0x69, 0x7a, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x2f, 0x63, 0x6f, 0x72, 0x65, 0x2f, 0x73,
+	0x79, 0x6d, 0x62, 0x6f, 0x6c, 0x69, 0x63, 0x5f, 0x6c, 0x69, 0x6e, 0x6b, 0x5f, 0x6d, 0x6f, 0x64,
+	0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x1a, 0x39, 0x73, 0x79, 0x6e, 0x63, 0x68, 0x72, 0x6f,
+	0x6e, 0x69, 0x7a, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x2f, 0x63, 0x6f, 0x72, 0x65, 0x2f, 0x73, 0x79,
+	0x6d, 0x62, 0x6f, 0x6c, 0x69, 0x63, 0x5f, 0x6c, 0x69, 0x6e, 0x6b, 0x5f, 0x72, 0x65, 0x70, 0x6c,
+	0x61, 0x63, 0x65, 0x6d, 0x65, 0x6e, 0x74, 0x5f, 0x6d, 0x6f, 0x64, 0x65, 0x2e, 0x70, 0x72, 0x6f,
+	0x74, 0x6f, 0x1a, 0x2a, 0x73, 0x79, 0x6e, 0x63, 0x68, 0x72, 0x6f, 0x6e, 0x69, 0x7a, 0x61, 0x74,
+	0x69, 0x6f, 0x6e, 0x2f, 0x63, 0x6f, 0x72, 0x65, 0x2f, 0x74, 0x72, 0x61, 0x6e, 0x73, 0x69, 0x74,
+	0x69, 0x6f, 0x6e, 0x5f, 0x6d, 0x6f, 0x64, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x1a, 0x2b,
+	0x73, 0x79, 0x6e, 0x63, 0x68, 0x72, 0x6f, 0x6e, 0x69, 0x7a, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x2f,
+	0x63, 0x6f, 0x72, 0x65, 0x2f, 0x74, 0x79, 0x70, 0x65, 0x5f, 0x63, 0x68, 0x61, 0x6e, 0x67, 0x65,
 	0x5f, 0x6d, 0x6f, 0x64, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x1a, 0x28, 0x73, 0x79, 0x6e,
 	0x63, 0x68, 0x72, 0x6f, 0x6e, 0x69, 0x7a, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x2f, 0x63, 0x6f, 0x72,
 	0x65, 0x2f, 0x69, 0x67, 0x6e, 0x6f, 0x72, 0x65, 0x2f, 0x73, 0x79, 0x6e, 0x74, 0x61, 0x78, 0x2e,
 	0x70, 0x72, 0x6f, 0x74, 0x6f, 0x1a, 0x31, 0x73, 0x79, 0x6e, 0x63, 0x68, 0x72, 0x6f, 0x6e, 0x69,
 	0x7a, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x2f, 0x63, 0x6f, 0x72, 0x65, 0x2f, 0x69, 0x67, 0x6e, 0x6f,
 	0x72, 0x65, 0x2f, 0x69, 0x67, 0x6e, 0x6f, 0x72, 0x65, 0x5f, 0x76, 0x63, 0x73, 0x5f, 0x6d, 0x6f,
-	0x64, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x1a, 0x27, 0x73, 0x79, 0x6e, 0x63, 0x68, 0x72,
-	0x6f, 0x6e, 0x69, 0x7a, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x2f, 0x68, 0x61, 0x73, 0x68, 0x69, 0x6e,
-	0x67, 0x2f, 0x61, 0x6c, 0x67, 0x6f, 0x72, 0x69, 0x74, 0x68, 0x6d, 0x2e, 0x70, 0x72, 0x6f, 0x74,
-	0x6f, 0x22, 0xbe, 0x08, 0x0a, 0x0d, 0x43, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x75, 0x72, 0x61, 0x74,
-	0x69, 0x6f, 0x6e, 0x12, 0x4b, 0x0a, 0x13, 0x73, 0x79, 0x6e, 0x63, 0x68, 0x72, 0x6f, 0x6e, 0x69,
-	0x7a, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x4d, 0x6f, 0x64, 0x65, 0x18, 0x0b, 0x20, 0x01, 0x28, 0x0e,
-	0x32, 0x19, 0x2e, 0x63, 0x6f, 0x72, 0x65, 0x2e, 0x53, 0x79, 0x6e, 0x63, 0x68, 0x72, 0x6f, 0x6e,
-	0x69, 0x7a, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x4d, 0x6f, 0x64, 0x65, 0x52, 0x13, 0x73, 0x79, 0x6e,
-	0x63, 0x68, 0x72, 0x6f, 0x6e, 0x69, 0x7a, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x4d, 0x6f, 0x64, 0x65,
-	0x12, 0x3e, 0x0a, 0x10, 0x68, 0x61, 0x73, 0x68, 0x69, 0x6e, 0x67, 0x41, 0x6c, 0x67, 0x6f, 0x72,
-	0x69, 0x74, 0x68, 0x6d, 0x18, 0x11, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x12, 0x2e, 0x68, 0x61, 0x73,
-	0x68, 0x69, 0x6e, 0x67, 0x2e, 0x41, 0x6c, 0x67, 0x6f, 0x72, 0x69, 0x74, 0x68, 0x6d, 0x52, 0x10,
-	0x68, 0x61, 0x73, 0x68, 0x69, 0x6e, 0x67, 0x41, 0x6c, 0x67, 0x6f, 0x72, 0x69, 0x74, 0x68, 0x6d,
-	0x12, 0x2c, 0x0a, 0x11, 0x6d, 0x61, 0x78, 0x69, 0x6d, 0x75, 0x6d, 0x45, 0x6e, 0x74, 0x72, 0x79,
-	0x43, 0x6f, 0x75, 0x6e, 0x74, 0x18, 0x0c, 0x20, 0x01, 0x28, 0x04, 0x52, 0x11, 0x6d, 0x61, 0x78,
-	0x69, 0x6d, 0x75, 0x6d, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x43, 0x6f, 0x75, 0x6e, 0x74, 0x12, 0x36,
-	0x0a, 0x16, 0x6d, 0x61, 0x78, 0x69, 0x6d, 0x75, 0x6d, 0x53, 0x74, 0x61, 0x67, 0x69, 0x6e, 0x67,
-	0x46, 0x69, 0x6c, 0x65, 0x53, 0x69, 0x7a, 0x65, 0x18, 0x0d, 0x20, 0x01, 0x28, 0x04, 0x52, 0x16,
-	0x6d, 0x61, 0x78, 0x69, 0x6d, 0x75, 0x6d, 0x53, 0x74, 0x61, 0x67, 0x69, 0x6e, 0x67, 0x46, 0x69,
-	0x6c, 0x65, 0x53, 0x69, 0x7a, 0x65, 0x12, 0x31, 0x0a, 0x09, 0x70, 0x72, 0x6f, 0x62, 0x65, 0x4d,
-	0x6f, 0x64, 0x65, 0x18, 0x0e, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x13, 0x2e, 0x62, 0x65, 0x68, 0x61,
-	0x76, 0x69, 0x6f, 0x72, 0x2e, 0x50, 0x72, 0x6f, 0x62, 0x65, 0x4d, 0x6f, 0x64, 0x65, 0x52, 0x09,
-	0x70, 0x72, 0x6f, 0x62, 0x65, 0x4d, 0x6f, 0x64, 0x65, 0x12, 0x35, 0x0a, 0x08, 0x73, 0x63, 0x61,
-	0x6e, 0x4d, 0x6f, 0x64, 0x65, 0x18, 0x0f, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x19, 0x2e, 0x73, 0x79,
-	0x6e, 0x63, 0x68, 0x72, 0x6f, 0x6e, 0x69, 0x7a, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x2e, 0x53, 0x63,
-	0x61, 0x6e, 0x4d, 0x6f, 0x64, 0x65, 0x52, 0x08, 0x73, 0x63, 0x61, 0x6e, 0x4d, 0x6f, 0x64, 0x65,
-	0x12, 0x38, 0x0a, 0x09, 0x73, 0x74, 0x61, 0x67, 0x65, 0x4d, 0x6f, 0x64, 0x65, 0x18, 0x10, 0x20,
-	0x01, 0x28, 0x0e, 0x32, 0x1a, 0x2e, 0x73, 0x79, 0x6e, 0x63, 0x68, 0x72, 0x6f, 0x6e, 0x69, 0x7a,
-	0x61, 0x74, 0x69, 0x6f, 0x6e, 0x2e, 0x53, 0x74, 0x61, 0x67, 0x65, 0x4d, 0x6f, 0x64, 0x65, 0x52,
-	0x09, 0x73, 0x74, 0x61, 0x67, 0x65, 0x4d, 0x6f, 0x64, 0x65, 0x12, 0x42, 0x0a, 0x10, 0x73, 0x79,
-	0x6d, 0x62, 0x6f, 0x6c, 0x69, 0x63, 0x4c, 0x69, 0x6e, 0x6b, 0x4d, 0x6f, 0x64, 0x65, 0x18, 0x01,
-	0x20, 0x01, 0x28, 0x0e, 0x32, 0x16, 0x2e, 0x63, 0x6f, 0x72, 0x65, 0x2e, 0x53, 0x79, 0x6d, 0x62,
-	0x6f, 0x6c, 0x69, 0x63, 0x4c, 0x69, 0x6e, 0x6b, 0x4d, 0x6f, 0x64, 0x65, 0x52, 0x10, 0x73, 0x79,
-	0x6d, 0x62, 0x6f, 0x6c, 0x69, 0x63, 0x4c, 0x69, 0x6e, 0x6b, 0x4d, 0x6f, 0x64, 0x65, 0x12, 0x38,
-	0x0a, 0x09, 0x77, 0x61, 0x74, 0x63, 0x68, 0x4d, 0x6f, 0x64, 0x65, 0x18, 0x15, 0x20, 0x01, 0x28,
-	0x0e, 0x32, 0x1a, 0x2e, 0x73, 0x79, 0x6e, 0x63, 0x68, 0x72, 0x6f, 0x6e, 0x69, 0x7a, 0x61, 0x74,
-	0x69, 0x6f, 0x6e, 0x2e, 0x57, 0x61, 0x74, 0x63, 0x68, 0x4d, 0x6f, 0x64, 0x65, 0x52, 0x09, 0x77,
-	0x61, 0x74, 0x63, 0x68, 0x4d, 0x6f, 0x64, 0x65, 0x12, 0x32, 0x0a, 0x14, 0x77, 0x61, 0x74, 0x63,
-	0x68, 0x50, 0x6f, 0x6c, 0x6c, 0x69, 0x6e, 0x67, 0x49, 0x6e, 0x74, 0x65, 0x72, 0x76, 0x61, 0x6c,
-	0x18, 0x16, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x14, 0x77, 0x61, 0x74, 0x63, 0x68, 0x50, 0x6f, 0x6c,
-	0x6c, 0x69, 0x6e, 0x67, 0x49, 0x6e, 0x74, 0x65, 0x72, 0x76, 0x61, 0x6c, 0x12, 0x32, 0x0a, 0x0c,
-	0x69, 0x67, 0x6e, 0x6f, 0x72, 0x65, 0x53, 0x79, 0x6e, 0x74, 0x61, 0x78, 0x18, 0x22, 0x20, 0x01,
-	0x28, 0x0e, 0x32, 0x0e, 0x2e, 0x69, 0x67, 0x6e, 0x6f, 0x72, 0x65, 0x2e, 0x53, 0x79, 0x6e, 0x74,
-	0x61, 0x78, 0x52, 0x0c, 0x69, 0x67, 0x6e, 0x6f, 0x72, 0x65, 0x53, 0x79, 0x6e, 0x74, 0x61, 0x78,
-	0x12, 0x26, 0x0a, 0x0e, 0x64, 0x65, 0x66, 0x61, 0x75, 0x6c, 0x74, 0x49, 0x67, 0x6e, 0x6f, 0x72,
-	0x65, 0x73, 0x18, 0x1f, 0x20, 0x03, 0x28, 0x09, 0x52, 0x0e, 0x64, 0x65, 0x66, 0x61, 0x75, 0x6c,
-	0x74, 0x49, 0x67, 0x6e, 0x6f, 0x72, 0x65, 0x73, 0x12, 0x18, 0x0a, 0x07, 0x69, 0x67, 0x6e, 0x6f,
-	0x72, 0x65, 0x73, 0x18, 0x20, 0x20, 0x03, 0x28, 0x09, 0x52, 0x07, 0x69, 0x67, 0x6e, 0x6f, 0x72,
-	0x65, 0x73, 0x12, 0x3b, 0x0a, 0x0d, 0x69, 0x67, 0x6e, 0x6f, 0x72, 0x65, 0x56, 0x43, 0x53, 0x4d,
-	0x6f, 0x64, 0x65, 0x18, 0x21, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x15, 0x2e, 0x69, 0x67, 0x6e, 0x6f,
-	0x72, 0x65, 0x2e, 0x49, 0x67, 0x6e, 0x6f, 0x72, 0x65, 0x56, 0x43, 0x53, 0x4d, 0x6f, 0x64, 0x65,
-	0x52, 0x0d, 0x69, 0x67, 0x6e, 0x6f, 0x72, 0x65, 0x56, 0x43, 0x53, 0x4d, 0x6f, 0x64, 0x65, 0x12,
-	0x3f, 0x0a, 0x0f, 0x70, 0x65, 0x72, 0x6d, 0x69, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x73, 0x4d, 0x6f,
-	0x64, 0x65, 0x18, 0x3d, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x15, 0x2e, 0x63, 0x6f, 0x72, 0x65, 0x2e,
-	0x50, 0x65, 0x72, 0x6d, 0x69, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x73, 0x4d, 0x6f, 0x64, 0x65, 0x52,
-	0x0f, 0x70, 0x65, 0x72, 0x6d, 0x69, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x73, 0x4d, 0x6f, 0x64, 0x65,
-	0x12, 0x28, 0x0a, 0x0f, 0x64, 0x65, 0x66, 0x61, 0x75, 0x6c, 0x74, 0x46, 0x69, 0x6c, 0x65, 0x4d,
-	0x6f, 0x64, 0x65, 0x18, 0x3f, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x0f, 0x64, 0x65, 0x66, 0x61, 0x75,
-	0x6c, 0x74, 0x46, 0x69, 0x6c, 0x65, 0x4d, 0x6f, 0x64, 0x65, 0x12, 0x32, 0x0a, 0x14, 0x64, 0x65,
-	0x66, 0x61, 0x75, 0x6c, 0x74, 0x44, 0x69, 0x72, 0x65, 0x63, 0x74, 0x6f, 0x72, 0x79, 0x4d, 0x6f,
-	0x64, 0x65, 0x18, 0x40, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x14, 0x64, 0x65, 0x66, 0x61, 0x75, 0x6c,
-	0x74, 0x44, 0x69, 0x72, 0x65, 0x63, 0x74, 0x6f, 0x72, 0x79, 0x4d, 0x6f, 0x64, 0x65, 0x12, 0x22,
-	0x0a, 0x0c, 0x64, 0x65, 0x66, 0x61, 0x75, 0x6c, 0x74, 0x4f, 0x77, 0x6e, 0x65, 0x72, 0x18, 0x41,
-	0x20, 0x01, 0x28, 0x09, 0x52, 0x0c, 0x64, 0x65, 0x66, 0x61, 0x75, 0x6c, 0x74, 0x4f, 0x77, 0x6e,
-	0x65, 0x72, 0x12, 0x22, 0x0a, 0x0c, 0x64, 0x65, 0x66, 0x61, 0x75, 0x6c, 0x74, 0x47, 0x72, 0x6f,
-	0x75, 0x70, 0x18, 0x42, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0c, 0x64, 0x65, 0x66, 0x61, 0x75, 0x6c,
-	0x74, 0x47, 0x72, 0x6f, 0x75, 0x70, 0x12, 0x4a, 0x0a, 0x14, 0x63, 0x6f, 0x6d, 0x70, 0x72, 0x65,
-	0x73, 0x73, 0x69, 0x6f, 0x6e, 0x41, 0x6c, 0x67, 0x6f, 0x72, 0x69, 0x74, 0x68, 0x6d, 0x18, 0x51,
-	0x20, 0x01, 0x28, 0x0e, 0x32, 0x16, 0x2e, 0x63, 0x6f, 0x6d, 0x70, 0x72, 0x65, 0x73, 0x73, 0x69,
-	0x6f, 0x6e, 0x2e, 0x41, 0x6c, 0x67, 0x6f, 0x72, 0x69, 0x74, 0x68, 0x6d, 0x52, 0x14, 0x63, 0x6f,
-	0x6d, 0x70, 0x72, 0x65, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x41, 0x6c, 0x67, 0x6f, 0x72, 0x69, 0x74,
-	0x68, 0x6d, 0x42, 0x33, 0x5a, 0x31, 0x67, 0x69, 0x74, 0x68, 0x75, 0x62, 0x2e, 0x63, 0x6f, 0x6d,
-	0x2f, 0x6d, 0x75, 0x74, 0x61, 0x67, 0x65, 0x6e, 0x2d, 0x69, 0x6f, 0x2f, 0x6d, 0x75, 0x74, 0x61,
-	0x67, 0x65, 0x6e, 0x2f, 0x70, 0x6b, 0x67, 0x2f, 0x73, 0x79, 0x6e, 0x63, 0x68, 0x72, 0x6f, 0x6e,
-	0x69, 0x7a, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
+	0x64, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x1a, 0x39, 0x73, 0x79, 0x6e, 0x63, 0x68, 0x72,
+	0x6f, 0x6e, 0x69, 0x7a, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x2f, 0x63, 0x6f, 0x72, 0x65, 0x2f, 0x69,
+	0x67, 0x6e, 0x6f, 0x72, 0x65, 0x2f, 0x69, 0x67, 0x6e, 0x6f, 0x72, 0x65, 0x5f, 0x6f, 0x73, 0x5f,
+	0x6d, 0x65, 0x74, 0x61, 0x64, 0x61, 0x74, 0x61, 0x5f, 0x6d, 0x6f, 0x64, 0x65, 0x2e, 0x70, 0x72,
+	0x6f, 0x74, 0x6f, 0x1a, 0x27, 0x73, 0x79, 0x6e, 0x63, 0x68, 0x72, 0x6f, 0x6e, 0x69, 0x7a, 0x61,
+	0x74, 0x69, 0x6f, 0x6e, 0x2f, 0x68, 0x61, 0x73, 0x68, 0x69, 0x6e, 0x67, 0x2f, 0x61, 0x6c, 0x67,
+	0x6f, 0x72, 0x69, 0x74, 0x68, 0x6d, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x22, 0xbc, 0x25, 0x0a,
+	0x0d, 0x43, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x75, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x4b,
+	0x0a, 0x13, 0x73, 0x79, 0x6e, 0x63, 0x68, 0x72, 0x6f, 0x6e, 0x69, 0x7a, 0x61, 0x74, 0x69, 0x6f,
+	0x6e, 0x4d, 0x6f, 0x64, 0x65, 0x18, 0x0b, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x19, 0x2e, 0x63, 0x6f,
+	0x72, 0x65, 0x2e, 0x53, 0x79, 0x6e, 0x63, 0x68, 0x72, 0x6f, 0x6e, 0x69, 0x7a, 0x61, 0x74, 0x69,
+	0x6f, 0x6e, 0x4d, 0x6f, 0x64, 0x65, 0x52, 0x13, 0x73, 0x79, 0x6e, 0x63, 0x68, 0x72, 0x6f, 0x6e,
+	0x69, 0x7a, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x4d, 0x6f, 0x64, 0x65, 0x12, 0x3e, 0x0a, 0x10, 0x68,
+	0x61, 0x73, 0x68, 0x69, 0x6e, 0x67, 0x41, 0x6c, 0x67, 0x6f, 0x72, 0x69, 0x74, 0x68, 0x6d, 0x18,
+	0x11, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x12, 0x2e, 0x68, 0x61, 0x73, 0x68, 0x69, 0x6e, 0x67, 0x2e,
+	0x41, 0x6c, 0x67, 0x6f, 0x72, 0x69, 0x74, 0x68, 0x6d, 0x52, 0x10, 0x68, 0x61, 0x73, 0x68, 0x69,
+	0x6e, 0x67, 0x41, 0x6c, 0x67, 0x6f, 0x72, 0x69, 0x74, 0x68, 0x6d, 0x12, 0x2c, 0x0a, 0x11, 0x6d,
+	0x61, 0x78, 0x69, 0x6d, 0x75, 0x6d, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x43, 0x6f, 0x75, 0x6e, 0x74,
+	0x18, 0x0c, 0x20, 0x01, 0x28, 0x04, 0x52, 0x11, 0x6d, 0x61, 0x78, 0x69, 0x6d, 0x75, 0x6d, 0x45,
+	0x6e, 0x74, 0x72, 0x79, 0x43, 0x6f, 0x75, 0x6e, 0x74, 0x12, 0x36, 0x0a, 0x16, 0x6d, 0x61, 0x78,
+	0x69, 0x6d, 0x75, 0x6d, 0x53, 0x74, 0x61, 0x67, 0x69, 0x6e, 0x67, 0x46, 0x69, 0x6c, 0x65, 0x53,
+	0x69, 0x7a, 0x65, 0x18, 0x0d, 0x20, 0x01, 0x28, 0x04, 0x52, 0x16, 0x6d, 0x61, 0x78, 0x69, 0x6d,
+	0x75, 0x6d, 0x53, 0x74, 0x61, 0x67, 0x69, 0x6e, 0x67, 0x46, 0x69, 0x6c, 0x65, 0x53, 0x69, 0x7a,
+	0x65, 0x12, 0x31, 0x0a, 0x09, 0x70, 0x72, 0x6f, 0x62, 0x65, 0x4d, 0x6f, 0x64, 0x65, 0x18, 0x0e,
+	0x20, 0x01, 0x28, 0x0e, 0x32, 0x13, 0x2e, 0x62, 0x65, 0x68, 0x61, 0x76, 0x69, 0x6f, 0x72, 0x2e,
+	0x50, 0x72, 0x6f, 0x62, 0x65, 0x4d, 0x6f, 0x64, 0x65, 0x52, 0x09, 0x70, 0x72, 0x6f, 0x62, 0x65,
+	0x4d, 0x6f, 0x64, 0x65, 0x12, 0x35, 0x0a, 0x08, 0x73, 0x63, 0x61, 0x6e, 0x4d, 0x6f, 0x64, 0x65,
+	0x18, 0x0f, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x19, 0x2e, 0x73, 0x79, 0x6e, 0x63, 0x68, 0x72, 0x6f,
+	0x6e, 0x69, 0x7a, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x2e, 0x53, 0x63, 0x61, 0x6e, 0x4d, 0x6f, 0x64,
+	0x65, 0x52, 0x08, 0x73, 0x63, 0x61, 0x6e, 0x4d, 0x6f, 0x64, 0x65, 0x12, 0x38, 0x0a, 0x09, 0x73,
+	0x74, 0x61, 0x67, 0x65, 0x4d, 0x6f, 0x64, 0x65, 0x18, 0x10, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x1a,
+	0x2e, 0x73, 0x79, 0x6e, 0x63, 0x68, 0x72, 0x6f, 0x6e, 0x69, 0x7a, 0x61, 0x74, 0x69, 0x6f, 0x6e,
+	0x2e, 0x53, 0x74, 0x61, 0x67, 0x65, 0x4d, 0x6f, 0x64, 0x65, 0x52, 0x09, 0x73, 0x74, 0x61, 0x67,
+	0x65, 0x4d, 0x6f, 0x64, 0x65, 0x12, 0x32, 0x0a, 0x14, 0x6d, 0x61, 0x78, 0x69, 0x6d, 0x75, 0x6d,
+	0x43, 0x6f, 0x6e, 0x66, 0x6c, 0x69, 0x63, 0x74, 0x43, 0x6f, 0x75, 0x6e, 0x74, 0x18, 0x12, 0x20,
+	0x01, 0x28, 0x04, 0x52, 0x14, 0x6d, 0x61, 0x78, 0x69, 0x6d, 0x75, 0x6d, 0x43, 0x6f, 0x6e, 0x66,
+	0x6c, 0x69, 0x63, 0x74, 0x43, 0x6f, 0x75, 0x6e, 0x74, 0x12, 0x3c, 0x0a, 0x0e, 0x74, 0x72, 0x61,
+	0x6e, 0x73, 0x69, 0x74, 0x69, 0x6f, 0x6e, 0x4d, 0x6f, 0x64, 0x65, 0x18, 0x13, 0x20, 0x01, 0x28,
+	0x0e, 0x32, 0x14, 0x2e, 0x63, 0x6f, 0x72, 0x65, 0x2e, 0x54, 0x72, 0x61, 0x6e, 0x73, 0x69, 0x74,
+	0x69, 0x6f, 0x6e, 0x4d, 0x6f, 0x64, 0x65, 0x52, 0x0e, 0x74, 0x72, 0x61, 0x6e, 0x73, 0x69, 0x74,
+	0x69, 0x6f, 0x6e, 0x4d, 0x6f, 0x64, 0x65, 0x12, 0x36, 0x0a, 0x16, 0x6d, 0x61, 0x78, 0x69, 0x6d,
+	0x75, 0x6d, 0x53, 0x69, 0x67, 0x6e, 0x61, 0x74, 0x75, 0x72, 0x65, 0x4d, 0x65, 0x6d, 0x6f, 0x72,
+	0x79, 0x18, 0x14, 0x20, 0x01, 0x28, 0x04, 0x52, 0x16, 0x6d, 0x61, 0x78, 0x69, 0x6d, 0x75, 0x6d,
+	0x53, 0x69, 0x67, 0x6e, 0x61, 0x74, 0x75, 0x72, 0x65, 0x4d, 0x65, 0x6d, 0x6f, 0x72, 0x79, 0x12,
+	0x42, 0x0a, 0x10, 0x73, 0x79, 0x6d, 0x62, 0x6f, 0x6c, 0x69, 0x63, 0x4c, 0x69, 0x6e, 0x6b, 0x4d,
+	0x6f, 0x64, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x16, 0x2e, 0x63, 0x6f, 0x72, 0x65,
+	0x2e, 0x53, 0x79, 0x6d, 0x62, 0x6f, 0x6c, 0x69, 0x63, 0x4c, 0x69, 0x6e, 0x6b, 0x4d, 0x6f, 0x64,
+	0x65, 0x52, 0x10, 0x73, 0x79, 0x6d, 0x62, 0x6f, 0x6c, 0x69, 0x63, 0x4c, 0x69, 0x6e, 0x6b, 0x4d,
+	0x6f, 0x64, 0x65, 0x12, 0x63, 0x0a, 0x1b, 0x73, 0x79, 0x6d, 0x62, 0x6f, 0x6c, 0x69, 0x63, 0x4c,
+	0x69, 0x6e, 0x6b, 0x52, 0x65, 0x70, 0x6c, 0x61, 0x63, 0x65, 0x6d, 0x65, 0x6e, 0x74, 0x4d, 0x6f,
+	0x64, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x21, 0x2e, 0x63, 0x6f, 0x72, 0x65, 0x2e,
+	0x53, 0x79, 0x6d, 0x62, 0x6f, 0x6c, 0x69, 0x63, 0x4c, 0x69, 0x6e, 0x6b, 0x52, 0x65, 0x70, 0x6c,
+	0x61, 0x63, 0x65, 0x6d, 0x65, 0x6e, 0x74, 0x4d, 0x6f, 0x64, 0x65, 0x52, 0x1b, 0x73, 0x79, 0x6d,
+	0x62, 0x6f, 0x6c, 0x69, 0x63, 0x4c, 0x69, 0x6e, 0x6b, 0x52, 0x65, 0x70, 0x6c, 0x61, 0x63, 0x65,
+	0x6d, 0x65, 0x6e, 0x74, 0x4d, 0x6f, 0x64, 0x65, 0x12, 0x51, 0x0a, 0x15, 0x73, 0x79, 0x6d, 0x62,
+	0x6f, 0x6c, 0x69, 0x63, 0x4c, 0x69, 0x6e, 0x6b, 0x43, 0x79, 0x63, 0x6c, 0x65, 0x4d, 0x6f, 0x64,
+	0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x1b, 0x2e, 0x63, 0x6f, 0x72, 0x65, 0x2e, 0x53,
+	0x79, 0x6d, 0x62, 0x6f, 0x6c, 0x69, 0x63, 0x4c, 0x69, 0x6e, 0x6b, 0x43, 0x79, 0x63, 0x6c, 0x65,
+	0x4d, 0x6f, 0x64, 0x65, 0x52, 0x15, 0x73, 0x79, 0x6d, 0x62, 0x6f, 0x6c, 0x69, 0x63, 0x4c, 0x69,
+	0x6e, 0x6b, 0x43, 0x79, 0x63, 0x6c, 0x65, 0x4d, 0x6f, 0x64, 0x65, 0x12, 0x38, 0x0a, 0x09, 0x77,
+	0x61, 0x74, 0x63, 0x68, 0x4d, 0x6f, 0x64, 0x65, 0x18, 0x15, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x1a,
+	0x2e, 0x73, 0x79, 0x6e, 0x63, 0x68, 0x72, 0x6f, 0x6e, 0x69, 0x7a, 0x61, 0x74, 0x69, 0x6f, 0x6e,
+	0x2e, 0x57, 0x61, 0x74, 0x63, 0x68, 0x4d, 0x6f, 0x64, 0x65, 0x52, 0x09, 0x77, 0x61, 0x74, 0x63,
+	0x68, 0x4d, 0x6f, 0x64, 0x65, 0x12, 0x32, 0x0a, 0x14, 0x77, 0x61, 0x74, 0x63, 0x68, 0x50, 0x6f,
+	0x6c, 0x6c, 0x69, 0x6e, 0x67, 0x49, 0x6e, 0x74, 0x65, 0x72, 0x76, 0x61, 0x6c, 0x18, 0x16, 0x20,
+	0x01, 0x28, 0x0d, 0x52, 0x14, 0x77, 0x61, 0x74, 0x63, 0x68, 0x50, 0x6f, 0x6c, 0x6c, 0x69, 0x6e,
+	0x67, 0x49, 0x6e, 0x74, 0x65, 0x72, 0x76, 0x61, 0x6c, 0x12, 0x26, 0x0a, 0x0e, 0x77, 0x61, 0x74,
+	0x63, 0x68, 0x51, 0x75, 0x65, 0x75, 0x65, 0x53, 0x69, 0x7a, 0x65, 0x18, 0x17, 0x20, 0x01, 0x28,
+	0x0d, 0x52, 0x0e, 0x77, 0x61, 0x74, 0x63, 0x68, 0x51, 0x75, 0x65, 0x75, 0x65, 0x53, 0x69, 0x7a,
+	0x65, 0x12, 0x6b, 0x0a, 0x1a, 0x69, 0x6e, 0x69, 0x74, 0x69, 0x61, 0x6c, 0x53, 0x79, 0x6e, 0x63,
+	0x68, 0x72, 0x6f, 0x6e, 0x69, 0x7a, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x4d, 0x6f, 0x64, 0x65, 0x18,
+	0x18, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x2b, 0x2e, 0x73, 0x79, 0x6e, 0x63, 0x68, 0x72, 0x6f, 0x6e,
+	0x69, 0x7a, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x2e, 0x49, 0x6e, 0x69, 0x74, 0x69, 0x61, 0x6c, 0x53,
+	0x79, 0x6e, 0x63, 0x68, 0x72, 0x6f, 0x6e, 0x69, 0x7a, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x4d, 0x6f,
+	0x64, 0x65, 0x52, 0x1a, 0x69, 0x6e, 0x69, 0x74, 0x69, 0x61, 0x6c, 0x53, 0x79, 0x6e, 0x63, 0x68,
+	0x72, 0x6f, 0x6e, 0x69, 0x7a, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x4d, 0x6f, 0x64, 0x65, 0x12, 0x6e,
+	0x0a, 0x1b, 0x69, 0x6e, 0x69, 0x74, 0x69, 0x61, 0x6c, 0x50, 0x6f, 0x6c, 0x6c, 0x4d, 0x6f, 0x64,
+	0x69, 0x66, 0x69, 0x63, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x4d, 0x6f, 0x64, 0x65, 0x18, 0x19, 0x20,
+	0x01, 0x28, 0x0e, 0x32, 0x2c, 0x2e, 0x73, 0x79, 0x6e, 0x63, 0x68, 0x72, 0x6f, 0x6e, 0x69, 0x7a,
+	0x61, 0x74, 0x69, 0x6f, 0x6e, 0x2e, 0x49, 0x6e, 0x69, 0x74, 0x69, 0x61, 0x6c, 0x50, 0x6f, 0x6c,
+	0x6c, 0x4d, 0x6f, 0x64, 0x69, 0x66, 0x69, 0x63, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x4d, 0x6f, 0x64,
+	0x65, 0x52, 0x1b, 0x69, 0x6e, 0x69, 0x74, 0x69, 0x61, 0x6c, 0x50, 0x6f, 0x6c, 0x6c, 0x4d, 0x6f,
+	0x64, 0x69, 0x66, 0x69, 0x63, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x4d, 0x6f, 0x64, 0x65, 0x12, 0x32,
+	0x0a, 0x0c, 0x69, 0x67, 0x6e, 0x6f, 0x72, 0x65, 0x53, 0x79, 0x6e, 0x74, 0x61, 0x78, 0x18, 0x22,
+	0x20, 0x01, 0x28, 0x0e, 0x32, 0x0e, 0x2e, 0x69, 0x67, 0x6e, 0x6f, 0x72, 0x65, 0x2e, 0x53, 0x79,
+	0x6e, 0x74, 0x61, 0x78, 0x52, 0x0c, 0x69, 0x67, 0x6e, 0x6f, 0x72, 0x65, 0x53, 0x79, 0x6e, 0x74,
+	0x61, 0x78, 0x12, 0x26, 0x0a, 0x0e, 0x64, 0x65, 0x66, 0x61, 0x75, 0x6c, 0x74, 0x49, 0x67, 0x6e,
+	0x6f, 0x72, 0x65, 0x73, 0x18, 0x1f, 0x20, 0x03, 0x28, 0x09, 0x52, 0x0e, 0x64, 0x65, 0x66, 0x61,
+	0x75, 0x6c, 0x74, 0x49, 0x67, 0x6e, 0x6f, 0x72, 0x65, 0x73, 0x12, 0x18, 0x0a, 0x07, 0x69, 0x67,
+	0x6e, 0x6f, 0x72, 0x65, 0x73, 0x18, 0x20, 0x20, 0x03, 0x28, 0x09, 0x52, 0x07, 0x69, 0x67, 0x6e,
+	0x6f, 0x72, 0x65, 0x73, 0x12, 0x3b, 0x0a, 0x0d, 0x69, 0x67, 0x6e, 0x6f, 0x72, 0x65, 0x56, 0x43,
+	0x53, 0x4d, 0x6f, 0x64, 0x65, 0x18, 0x21, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x15, 0x2e, 0x69, 0x67,
+	0x6e, 0x6f, 0x72, 0x65, 0x2e, 0x49, 0x67, 0x6e, 0x6f, 0x72, 0x65, 0x56, 0x43, 0x53, 0x4d, 0x6f,
+	0x64, 0x65, 0x52, 0x0d, 0x69, 0x67, 0x6e, 0x6f, 0x72, 0x65, 0x56, 0x43, 0x53, 0x4d, 0x6f, 0x64,
+	0x65, 0x12, 0x1a, 0x0a, 0x08, 0x6d, 0x61, 0x6e, 0x69, 0x66, 0x65, 0x73, 0x74, 0x18, 0x23, 0x20,
+	0x03, 0x28, 0x09, 0x52, 0x08, 0x6d, 0x61, 0x6e, 0x69, 0x66, 0x65, 0x73, 0x74, 0x12, 0x4e, 0x0a,
+	0x14, 0x70, 0x68, 0x61, 0x6e, 0x74, 0x6f, 0x6d, 0x44, 0x69, 0x72, 0x65, 0x63, 0x74, 0x6f, 0x72,
+	0x79, 0x4d, 0x6f, 0x64, 0x65, 0x18, 0x24, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x1a, 0x2e, 0x63, 0x6f,
+	0x72, 0x65, 0x2e, 0x50, 0x68, 0x61, 0x6e, 0x74, 0x6f, 0x6d, 0x44, 0x69, 0x72, 0x65, 0x63, 0x74,
+	0x6f, 0x72, 0x79, 0x4d, 0x6f, 0x64, 0x65, 0x52, 0x14, 0x70, 0x68, 0x61, 0x6e, 0x74, 0x6f, 0x6d,
+	0x44, 0x69, 0x72, 0x65, 0x63, 0x74, 0x6f, 0x72, 0x79, 0x4d, 0x6f, 0x64, 0x65, 0x12, 0x28, 0x0a,
+	0x0f, 0x6d, 0x61, 0x78, 0x69, 0x6d, 0x75, 0x6d, 0x46, 0x69, 0x6c, 0x65, 0x53, 0x69, 0x7a, 0x65,
+	0x18, 0x25, 0x20, 0x01, 0x28, 0x04, 0x52, 0x0f, 0x6d, 0x61, 0x78, 0x69, 0x6d, 0x75, 0x6d, 0x46,
+	0x69, 0x6c, 0x65, 0x53, 0x69, 0x7a, 0x65, 0x12, 0x50, 0x0a, 0x14, 0x69, 0x67, 0x6e, 0x6f, 0x72,
+	0x65, 0x4f, 0x53, 0x4d, 0x65, 0x74, 0x61, 0x64, 0x61, 0x74, 0x61, 0x4d, 0x6f, 0x64, 0x65, 0x18,
+	0x26, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x1c, 0x2e, 0x69, 0x67, 0x6e, 0x6f, 0x72, 0x65, 0x2e, 0x49,
+	0x67, 0x6e, 0x6f, 0x72, 0x65, 0x4f, 0x53, 0x4d, 0x65, 0x74, 0x61, 0x64, 0x61, 0x74, 0x61, 0x4d,
+	0x6f, 0x64, 0x65, 0x52, 0x14, 0x69, 0x67, 0x6e, 0x6f, 0x72, 0x65, 0x4f, 0x53, 0x4d, 0x65, 0x74,
+	0x61, 0x64, 0x61, 0x74, 0x61, 0x4d, 0x6f, 0x64, 0x65, 0x12, 0x3f, 0x0a, 0x0f, 0x70, 0x65, 0x72,
+	0x6d, 0x69, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x73, 0x4d, 0x6f, 0x64, 0x65, 0x18, 0x3d, 0x20, 0x01,
+	0x28, 0x0e, 0x32, 0x15, 0x2e, 0x63, 0x6f, 0x72, 0x65, 0x2e, 0x50, 0x65, 0x72, 0x6d, 0x69, 0x73,
+	0x73, 0x69, 0x6f, 0x6e, 0x73, 0x4d, 0x6f, 0x64, 0x65, 0x52, 0x0f, 0x70, 0x65, 0x72, 0x6d, 0x69,
+	0x73, 0x73, 0x69, 0x6f, 0x6e, 0x73, 0x4d, 0x6f, 0x64, 0x65, 0x12, 0x28, 0x0a, 0x0f, 0x64, 0x65,
+	0x66, 0x61, 0x75, 0x6c, 0x74, 0x46, 0x69, 0x6c, 0x65, 0x4d, 0x6f, 0x64, 0x65, 0x18, 0x3f, 0x20,
+	0x01, 0x28, 0x0d, 0x52, 0x0f, 0x64, 0x65, 0x66, 0x61, 0x75, 0x6c, 0x74, 0x46, 0x69, 0x6c, 0x65,
+	0x4d, 0x6f, 0x64, 0x65, 0x12, 0x32, 0x0a, 0x14, 0x64, 0x65, 0x66, 0x61, 0x75, 0x6c, 0x74, 0x44,
+	0x69, 0x72, 0x65, 0x63, 0x74, 0x6f, 0x72, 0x79, 0x4d, 0x6f, 0x64, 0x65, 0x18, 0x40, 0x20, 0x01,
+	0x28, 0x0d, 0x52, 0x14, 0x64, 0x65, 0x66, 0x61, 0x75, 0x6c, 0x74, 0x44, 0x69, 0x72, 0x65, 0x63,
+	0x74, 0x6f, 0x72, 0x79, 0x4d, 0x6f, 0x64, 0x65, 0x12, 0x22, 0x0a, 0x0c, 0x64, 0x65, 0x66, 0x61,
+	0x75, 0x6c, 0x74, 0x4f, 0x77, 0x6e, 0x65, 0x72, 0x18, 0x41, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0c,
+	0x64, 0x65, 0x66, 0x61, 0x75, 0x6c, 0x74, 0x4f, 0x77, 0x6e, 0x65, 0x72, 0x12, 0x22, 0x0a, 0x0c,
+	0x64, 0x65, 0x66, 0x61, 0x75, 0x6c, 0x74, 0x47, 0x72, 0x6f, 0x75, 0x70, 0x18, 0x42, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x0c, 0x64, 0x65, 0x66, 0x61, 0x75, 0x6c, 0x74, 0x47, 0x72, 0x6f, 0x75, 0x70,
+	0x12, 0x4a, 0x0a, 0x14, 0x63, 0x6f, 0x6d, 0x70, 0x72, 0x65, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x41,
+	0x6c, 0x67, 0x6f, 0x72, 0x69, 0x74, 0x68, 0x6d, 0x18, 0x51, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x16,
+	0x2e, 0x63, 0x6f, 0x6d, 0x70, 0x72, 0x65, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x2e, 0x41, 0x6c, 0x67,
+	0x6f, 0x72, 0x69, 0x74, 0x68, 0x6d, 0x52, 0x14, 0x63, 0x6f, 0x6d, 0x70, 0x72, 0x65, 0x73, 0x73,
+	0x69, 0x6f, 0x6e, 0x41, 0x6c, 0x67, 0x6f, 0x72, 0x69, 0x74, 0x68, 0x6d, 0x12, 0x58, 0x0a, 0x1b,
+	0x73, 0x74, 0x61, 0x67, 0x69, 0x6e, 0x67, 0x43, 0x6f, 0x6d, 0x70, 0x72, 0x65, 0x73, 0x73, 0x69,
+	0x6f, 0x6e, 0x41, 0x6c, 0x67, 0x6f, 0x72, 0x69, 0x74, 0x68, 0x6d, 0x18, 0x52, 0x20, 0x01, 0x28,
+	0x0e, 0x32, 0x16, 0x2e, 0x63, 0x6f, 0x6d, 0x70, 0x72, 0x65, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x2e,
+	0x41, 0x6c, 0x67, 0x6f, 0x72, 0x69, 0x74, 0x68, 0x6d, 0x52, 0x1b, 0x73, 0x74, 0x61, 0x67, 0x69,
+	0x6e, 0x67, 0x43, 0x6f, 0x6d, 0x70, 0x72, 0x65, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x41, 0x6c, 0x67,
+	0x6f, 0x72, 0x69, 0x74, 0x68, 0x6d, 0x12, 0x3f, 0x0a, 0x0f, 0x73, 0x70, 0x65, 0x63, 0x69, 0x61,
+	0x6c, 0x46, 0x69, 0x6c, 0x65, 0x4d, 0x6f, 0x64, 0x65, 0x18, 0x5b, 0x20, 0x01, 0x28, 0x0e, 0x32,
+	0x15, 0x2e, 0x63, 0x6f, 0x72, 0x65, 0x2e, 0x53, 0x70, 0x65, 0x63, 0x69, 0x61, 0x6c, 0x46, 0x69,
+	0x6c, 0x65, 0x4d, 0x6f, 0x64, 0x65, 0x52, 0x0f, 0x73, 0x70, 0x65, 0x63, 0x69, 0x61, 0x6c, 0x46,
+	0x69, 0x6c, 0x65, 0x4d, 0x6f, 0x64, 0x65, 0x12, 0x44, 0x0a, 0x0d, 0x63, 0x6c, 0x6f, 0x63, 0x6b,
+	0x53, 0x6b, 0x65, 0x77, 0x4d, 0x6f, 0x64, 0x65, 0x18, 0x65, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x1e,
+	0x2e, 0x73, 0x79, 0x6e, 0x63, 0x68, 0x72, 0x6f, 0x6e, 0x69, 0x7a, 0x61, 0x74, 0x69, 0x6f, 0x6e,
+	0x2e, 0x43, 0x6c, 0x6f, 0x63, 0x6b, 0x53, 0x6b, 0x65, 0x77, 0x4d, 0x6f, 0x64, 0x65, 0x52, 0x0d,
+	0x63, 0x6c, 0x6f, 0x63, 0x6b, 0x53, 0x6b, 0x65, 0x77, 0x4d, 0x6f, 0x64, 0x65, 0x12, 0x2e, 0x0a,
+	0x12, 0x63, 0x6c, 0x6f, 0x63, 0x6b, 0x53, 0x6b, 0x65, 0x77, 0x54, 0x6f, 0x6c, 0x65, 0x72, 0x61,
+	0x6e, 0x63, 0x65, 0x18, 0x66, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x12, 0x63, 0x6c, 0x6f, 0x63, 0x6b,
+	0x53, 0x6b, 0x65, 0x77, 0x54, 0x6f, 0x6c, 0x65, 0x72, 0x61, 0x6e, 0x63, 0x65, 0x12, 0x26, 0x0a,
+	0x0e, 0x70, 0x72, 0x6f, 0x62, 0x65, 0x44, 0x69, 0x72, 0x65, 0x63, 0x74, 0x6f, 0x72, 0x79, 0x18,
+	0x6f, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0e, 0x70, 0x72, 0x6f, 0x62, 0x65, 0x44, 0x69, 0x72, 0x65,
+	0x63, 0x74, 0x6f, 0x72, 0x79, 0x12, 0x63, 0x0a, 0x1f, 0x61, 0x73, 0x73, 0x75, 0x6d, 0x65, 0x45,
+	0x78, 0x65, 0x63, 0x75, 0x74, 0x61, 0x62, 0x69, 0x6c, 0x69, 0x74, 0x79, 0x50, 0x72, 0x65, 0x73,
+	0x65, 0x72, 0x76, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x70, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x19,
+	0x2e, 0x62, 0x65, 0x68, 0x61, 0x76, 0x69, 0x6f, 0x72, 0x2e, 0x50, 0x72, 0x6f, 0x62, 0x65, 0x41,
+	0x73, 0x73, 0x75, 0x6d, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x1f, 0x61, 0x73, 0x73, 0x75, 0x6d,
+	0x65, 0x45, 0x78, 0x65, 0x63, 0x75, 0x74, 0x61, 0x62, 0x69, 0x6c, 0x69, 0x74, 0x79, 0x50, 0x72,
+	0x65, 0x73, 0x65, 0x72, 0x76, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x59, 0x0a, 0x1a, 0x61, 0x73,
+	0x73, 0x75, 0x6d, 0x65, 0x55, 0x6e, 0x69, 0x63, 0x6f, 0x64, 0x65, 0x44, 0x65, 0x63, 0x6f, 0x6d,
+	0x70, 0x6f, 0x73, 0x69, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x71, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x19,
+	0x2e, 0x62, 0x65, 0x68, 0x61, 0x76, 0x69, 0x6f, 0x72, 0x2e, 0x50, 0x72, 0x6f, 0x62, 0x65, 0x41,
+	0x73, 0x73, 0x75, 0x6d, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x1a, 0x61, 0x73, 0x73, 0x75, 0x6d,
+	0x65, 0x55, 0x6e, 0x69, 0x63, 0x6f, 0x64, 0x65, 0x44, 0x65, 0x63, 0x6f, 0x6d, 0x70, 0x6f, 0x73,
+	0x69, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x50, 0x0a, 0x11, 0x6f, 0x76, 0x65, 0x72, 0x73, 0x69, 0x7a,
+	0x65, 0x64, 0x46, 0x69, 0x6c, 0x65, 0x4d, 0x6f, 0x64, 0x65, 0x18, 0x79, 0x20, 0x01, 0x28, 0x0e,
+	0x32, 0x22, 0x2e, 0x73, 0x79, 0x6e, 0x63, 0x68, 0x72, 0x6f, 0x6e, 0x69, 0x7a, 0x61, 0x74, 0x69,
+	0x6f, 0x6e, 0x2e, 0x4f, 0x76, 0x65, 0x72, 0x73, 0x69, 0x7a, 0x65, 0x64, 0x46, 0x69, 0x6c, 0x65,
+	0x4d, 0x6f, 0x64, 0x65, 0x52, 0x11, 0x6f, 0x76, 0x65, 0x72, 0x73, 0x69, 0x7a, 0x65, 0x64, 0x46,
+	0x69, 0x6c, 0x65, 0x4d, 0x6f, 0x64, 0x65, 0x12, 0x46, 0x0a, 0x1e, 0x6d, 0x61, 0x78, 0x69, 0x6d,
+	0x75, 0x6d, 0x52, 0x65, 0x6e, 0x61, 0x6d, 0x65, 0x44, 0x65, 0x74, 0x65, 0x63, 0x74, 0x69, 0x6f,
+	0x6e, 0x46, 0x69, 0x6c, 0x65, 0x53, 0x69, 0x7a, 0x65, 0x18, 0x7a, 0x20, 0x01, 0x28, 0x04, 0x52,
+	0x1e, 0x6d, 0x61, 0x78, 0x69, 0x6d, 0x75, 0x6d, 0x52, 0x65, 0x6e, 0x61, 0x6d, 0x65, 0x44, 0x65,
+	0x74, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x46, 0x69, 0x6c, 0x65, 0x53, 0x69, 0x7a, 0x65, 0x12,
+	0x38, 0x0a, 0x17, 0x6d, 0x61, 0x78, 0x69, 0x6d, 0x75, 0x6d, 0x43, 0x6f, 0x6e, 0x74, 0x65, 0x6e,
+	0x74, 0x43, 0x61, 0x63, 0x68, 0x65, 0x53, 0x69, 0x7a, 0x65, 0x18, 0x7b, 0x20, 0x01, 0x28, 0x04,
+	0x52, 0x17, 0x6d, 0x61, 0x78, 0x69, 0x6d, 0x75, 0x6d, 0x43, 0x6f, 0x6e, 0x74, 0x65, 0x6e, 0x74,
+	0x43, 0x61, 0x63, 0x68, 0x65, 0x53, 0x69, 0x7a, 0x65, 0x12, 0x5f, 0x0a, 0x16, 0x73, 0x74, 0x61,
+	0x67, 0x69, 0x6e, 0x67, 0x43, 0x6f, 0x6e, 0x63, 0x75, 0x72, 0x72, 0x65, 0x6e, 0x63, 0x79, 0x4d,
+	0x6f, 0x64, 0x65, 0x18, 0x7c, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x27, 0x2e, 0x73, 0x79, 0x6e, 0x63,
+	0x68, 0x72, 0x6f, 0x6e, 0x69, 0x7a, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x2e, 0x53, 0x74, 0x61, 0x67,
+	0x69, 0x6e, 0x67, 0x43, 0x6f, 0x6e, 0x63, 0x75, 0x72, 0x72, 0x65, 0x6e, 0x63, 0x79, 0x4d, 0x6f,
+	0x64, 0x65, 0x52, 0x16, 0x73, 0x74, 0x61, 0x67, 0x69, 0x6e, 0x67, 0x43, 0x6f, 0x6e, 0x63, 0x75,
+	0x72, 0x72, 0x65, 0x6e, 0x63, 0x79, 0x4d, 0x6f, 0x64, 0x65, 0x12, 0x3a, 0x0a, 0x18, 0x6d, 0x61,
+	0x78, 0x69, 0x6d, 0x75, 0x6d, 0x44, 0x65, 0x6c, 0x74, 0x69, 0x66, 0x69, 0x63, 0x61, 0x74, 0x69,
+	0x6f, 0x6e, 0x54, 0x69, 0x6d, 0x65, 0x18, 0x7d, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x18, 0x6d, 0x61,
+	0x78, 0x69, 0x6d, 0x75, 0x6d, 0x44, 0x65, 0x6c, 0x74, 0x69, 0x66, 0x69, 0x63, 0x61, 0x74, 0x69,
+	0x6f, 0x6e, 0x54, 0x69, 0x6d, 0x65, 0x12, 0x38, 0x0a, 0x17, 0x6d, 0x61, 0x78, 0x69, 0x6d, 0x75,
+	0x6d, 0x53, 0x74, 0x61, 0x67, 0x65, 0x64, 0x43, 0x6f, 0x6e, 0x74, 0x65, 0x6e, 0x74, 0x41, 0x67,
+	0x65, 0x18, 0x7e, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x17, 0x6d, 0x61, 0x78, 0x69, 0x6d, 0x75, 0x6d,
+	0x53, 0x74, 0x61, 0x67, 0x65, 0x64, 0x43, 0x6f, 0x6e, 0x74, 0x65, 0x6e, 0x74, 0x41, 0x67, 0x65,
+	0x12, 0x26, 0x0a, 0x0e, 0x72, 0x73, 0x79, 0x6e, 0x63, 0x42, 0x6c, 0x6f, 0x63, 0x6b, 0x53, 0x69,
+	0x7a, 0x65, 0x18, 0x7f, 0x20, 0x01, 0x28, 0x04, 0x52, 0x0e, 0x72, 0x73, 0x79, 0x6e, 0x63, 0x42,
+	0x6c, 0x6f, 0x63, 0x6b, 0x53, 0x69, 0x7a, 0x65, 0x12, 0x33, 0x0a, 0x14, 0x6d, 0x61, 0x78, 0x69,
+	0x6d, 0x75, 0x6d, 0x49, 0x6e, 0x46, 0x6c, 0x69, 0x67, 0x68, 0x74, 0x42, 0x79, 0x74, 0x65, 0x73,
+	0x18, 0x80, 0x01, 0x20, 0x01, 0x28, 0x04, 0x52, 0x14, 0x6d, 0x61, 0x78, 0x69, 0x6d, 0x75, 0x6d,
+	0x49, 0x6e, 0x46, 0x6c, 0x69, 0x67, 0x68, 0x74, 0x42, 0x79, 0x74, 0x65, 0x73, 0x12, 0x41, 0x0a,
+	0x1b, 0x72, 0x65, 0x6e, 0x61, 0x6d, 0x65, 0x44, 0x65, 0x74, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e,
+	0x44, 0x69, 0x67, 0x65, 0x73, 0x74, 0x4c, 0x65, 0x6e, 0x67, 0x74, 0x68, 0x18, 0x81, 0x01, 0x20,
+	0x01, 0x28, 0x0d, 0x52, 0x1b, 0x72, 0x65, 0x6e, 0x61, 0x6d, 0x65, 0x44, 0x65, 0x74, 0x65, 0x63,
+	0x74, 0x69, 0x6f, 0x6e, 0x44, 0x69, 0x67, 0x65, 0x73, 0x74, 0x4c, 0x65, 0x6e, 0x67, 0x74, 0x68,
+	0x12, 0x37, 0x0a, 0x16, 0x72, 0x65, 0x6e, 0x61, 0x6d, 0x65, 0x44, 0x65, 0x74, 0x65, 0x63, 0x74,
+	0x69, 0x6f, 0x6e, 0x52, 0x65, 0x74, 0x72, 0x69, 0x65, 0x73, 0x18, 0x82, 0x01, 0x20, 0x01, 0x28,
+	0x0d, 0x52, 0x16, 0x72, 0x65, 0x6e, 0x61, 0x6d, 0x65, 0x44, 0x65, 0x74, 0x65, 0x63, 0x74, 0x69,
+	0x6f, 0x6e, 0x52, 0x65, 0x74, 0x72, 0x69, 0x65, 0x73, 0x12, 0x2d, 0x0a, 0x11, 0x73, 0x74, 0x72,
+	0x65, 0x61, 0x6d, 0x43, 0x6f, 0x6e, 0x63, 0x75, 0x72, 0x72, 0x65, 0x6e, 0x63, 0x79, 0x18, 0x83,
+	0x01, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x11, 0x73, 0x74, 0x72, 0x65, 0x61, 0x6d, 0x43, 0x6f, 0x6e,
+	0x63, 0x75, 0x72, 0x72, 0x65, 0x6e, 0x63, 0x79, 0x12, 0x39, 0x0a, 0x17, 0x64, 0x69, 0x72, 0x65,
+	0x63, 0x74, 0x6f, 0x72, 0x79, 0x4c, 0x69, 0x73, 0x74, 0x69, 0x6e, 0x67, 0x52, 0x65, 0x74, 0x72,
+	0x69, 0x65, 0x73, 0x18, 0x8d, 0x01, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x17, 0x64, 0x69, 0x72, 0x65,
+	0x63, 0x74, 0x6f, 0x72, 0x79, 0x4c, 0x69, 0x73, 0x74, 0x69, 0x6e, 0x67, 0x52, 0x65, 0x74, 0x72,
+	0x69, 0x65, 0x73, 0x12, 0x2f, 0x0a, 0x12, 0x63, 0x61, 0x63, 0x68, 0x65, 0x53, 0x61, 0x76, 0x65,
+	0x54, 0x68, 0x72, 0x65, 0x73, 0x68, 0x6f, 0x6c, 0x64, 0x18, 0x8e, 0x01, 0x20, 0x01, 0x28, 0x04,
+	0x52, 0x12, 0x63, 0x61, 0x63, 0x68, 0x65, 0x53, 0x61, 0x76, 0x65, 0x54, 0x68, 0x72, 0x65, 0x73,
+	0x68, 0x6f, 0x6c, 0x64, 0x12, 0x51, 0x0a, 0x11, 0x72, 0x6f, 0x6f, 0x74, 0x45, 0x78, 0x69, 0x73,
+	0x74, 0x65, 0x6e, 0x63, 0x65, 0x4d, 0x6f, 0x64, 0x65, 0x18, 0x8f, 0x01, 0x20, 0x01, 0x28, 0x0e,
+	0x32, 0x22, 0x2e, 0x73, 0x79, 0x6e, 0x63, 0x68, 0x72, 0x6f, 0x6e, 0x69, 0x7a, 0x61, 0x74, 0x69,
+	0x6f, 0x6e, 0x2e, 0x52, 0x6f, 0x6f, 0x74, 0x45, 0x78, 0x69, 0x73, 0x74, 0x65, 0x6e, 0x63, 0x65,
+	0x4d, 0x6f, 0x64, 0x65, 0x52, 0x11, 0x72, 0x6f, 0x6f, 0x74, 0x45, 0x78, 0x69, 0x73, 0x74, 0x65,
+	0x6e, 0x63, 0x65, 0x4d, 0x6f, 0x64, 0x65, 0x12, 0x39, 0x0a, 0x17, 0x64, 0x69, 0x67, 0x65, 0x73,
+	0x74, 0x53, 0x61, 0x6d, 0x70, 0x6c, 0x69, 0x6e, 0x67, 0x54, 0x68, 0x72, 0x65, 0x73, 0x68, 0x6f,
+	0x6c, 0x64, 0x18, 0x90, 0x01, 0x20, 0x01, 0x28, 0x04, 0x52, 0x17, 0x64, 0x69, 0x67, 0x65, 0x73,
+	0x74, 0x53, 0x61, 0x6d, 0x70, 0x6c, 0x69, 0x6e, 0x67, 0x54, 0x68, 0x72, 0x65, 0x73, 0x68, 0x6f,
+	0x6c, 0x64, 0x12, 0x52, 0x0a, 0x15, 0x6e, 0x61, 0x6d, 0x65, 0x4e, 0x6f, 0x72, 0x6d, 0x61, 0x6c,
+	0x69, 0x7a, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x4d, 0x6f, 0x64, 0x65, 0x18, 0x91, 0x01, 0x20, 0x01,
+	0x28, 0x0e, 0x32, 0x1b, 0x2e, 0x63, 0x6f, 0x72, 0x65, 0x2e, 0x4e, 0x61, 0x6d, 0x65, 0x4e, 0x6f,
+	0x72, 0x6d, 0x61, 0x6c, 0x69, 0x7a, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x4d, 0x6f, 0x64, 0x65, 0x52,
+	0x15, 0x6e, 0x61, 0x6d, 0x65, 0x4e, 0x6f, 0x72, 0x6d, 0x61, 0x6c, 0x69, 0x7a, 0x61, 0x74, 0x69,
+	0x6f, 0x6e, 0x4d, 0x6f, 0x64, 0x65, 0x12, 0x60, 0x0a, 0x16, 0x63, 0x61, 0x70, 0x61, 0x62, 0x69,
+	0x6c, 0x69, 0x74, 0x79, 0x4d, 0x69, 0x73, 0x6d, 0x61, 0x74, 0x63, 0x68, 0x4d, 0x6f, 0x64, 0x65,
+	0x18, 0x92, 0x01, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x27, 0x2e, 0x73, 0x79, 0x6e, 0x63, 0x68, 0x72,
+	0x6f, 0x6e, 0x69, 0x7a, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x2e, 0x43, 0x61, 0x70, 0x61, 0x62, 0x69,
+	0x6c, 0x69, 0x74, 0x79, 0x4d, 0x69, 0x73, 0x6d, 0x61, 0x74, 0x63, 0x68, 0x4d, 0x6f, 0x64, 0x65,
+	0x52, 0x16, 0x63, 0x61, 0x70, 0x61, 0x62, 0x69, 0x6c, 0x69, 0x74, 0x79, 0x4d, 0x69, 0x73, 0x6d,
+	0x61, 0x74, 0x63, 0x68, 0x4d, 0x6f, 0x64, 0x65, 0x12, 0x31, 0x0a, 0x13, 0x6d, 0x61, 0x78, 0x69,
+	0x6d, 0x75, 0x6d, 0x52, 0x65, 0x63, 0x68, 0x65, 0x63, 0x6b, 0x50, 0x61, 0x74, 0x68, 0x73, 0x18,
+	0x93, 0x01, 0x20, 0x01, 0x28, 0x04, 0x52, 0x13, 0x6d, 0x61, 0x78, 0x69, 0x6d, 0x75, 0x6d, 0x52,
+	0x65, 0x63, 0x68, 0x65, 0x63, 0x6b, 0x50, 0x61, 0x74, 0x68, 0x73, 0x12, 0x7e, 0x0a, 0x20, 0x63,
+	0x6f, 0x6e, 0x66, 0x69, 0x67, 0x75, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x49, 0x6e, 0x63, 0x6f,
+	0x6d, 0x70, 0x61, 0x74, 0x69, 0x62, 0x69, 0x6c, 0x69, 0x74, 0x79, 0x4d, 0x6f, 0x64, 0x65, 0x18,
+	0x94, 0x01, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x31, 0x2e, 0x73, 0x79, 0x6e, 0x63, 0x68, 0x72, 0x6f,
+	0x6e, 0x69, 0x7a, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x2e, 0x43, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x75,
+	0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x49, 0x6e, 0x63, 0x6f, 0x6d, 0x70, 0x61, 0x74, 0x69, 0x62,
+	0x69, 0x6c, 0x69, 0x74, 0x79, 0x4d, 0x6f, 0x64, 0x65, 0x52, 0x20, 0x63, 0x6f, 0x6e, 0x66, 0x69,
+	0x67, 0x75, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x49, 0x6e, 0x63, 0x6f, 0x6d, 0x70, 0x61, 0x74,
+	0x69, 0x62, 0x69, 0x6c, 0x69, 0x74, 0x79, 0x4d, 0x6f, 0x64, 0x65, 0x12, 0x23, 0x0a, 0x0c, 0x64,
+	0x69, 0x67, 0x65, 0x73, 0x74, 0x4c, 0x65, 0x6e, 0x67, 0x74, 0x68, 0x18, 0x95, 0x01, 0x20, 0x01,
+	0x28, 0x0d, 0x52, 0x0c, 0x64, 0x69, 0x67, 0x65, 0x73, 0x74, 0x4c, 0x65, 0x6e, 0x67, 0x74, 0x68,
+	0x12, 0x3d, 0x0a, 0x0e, 0x63, 0x61, 0x63, 0x68, 0x65, 0x54, 0x72, 0x75, 0x73, 0x74, 0x4d, 0x6f,
+	0x64, 0x65, 0x18, 0x96, 0x01, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x14, 0x2e, 0x63, 0x6f, 0x72, 0x65,
+	0x2e, 0x43, 0x61, 0x63, 0x68, 0x65, 0x54, 0x72, 0x75, 0x73, 0x74, 0x4d, 0x6f, 0x64, 0x65, 0x52,
+	0x0e, 0x63, 0x61, 0x63, 0x68, 0x65, 0x54, 0x72, 0x75, 0x73, 0x74, 0x4d, 0x6f, 0x64, 0x65, 0x12,
+	0x3d, 0x0a, 0x0e, 0x74, 0x79, 0x70, 0x65, 0x43, 0x68, 0x61, 0x6e, 0x67, 0x65, 0x4d, 0x6f, 0x64,
+	0x65, 0x18, 0x97, 0x01, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x14, 0x2e, 0x63, 0x6f, 0x72, 0x65, 0x2e,
+	0x54, 0x79, 0x70, 0x65, 0x43, 0x68, 0x61, 0x6e, 0x67, 0x65, 0x4d, 0x6f, 0x64, 0x65, 0x52, 0x0e,
+	0x74, 0x79, 0x70, 0x65, 0x43, 0x68, 0x61, 0x6e, 0x67, 0x65, 0x4d, 0x6f, 0x64, 0x65, 0x12, 0x3f,
+	0x0a, 0x1a, 0x6d, 0x61, 0x78, 0x69, 0x6d, 0x75, 0x6d, 0x43, 0x6f, 0x6e, 0x66, 0x6c, 0x69, 0x63,
+	0x74, 0x50, 0x65, 0x72, 0x73, 0x69, 0x73, 0x74, 0x65, 0x6e, 0x63, 0x65, 0x18, 0x98, 0x01, 0x20,
+	0x01, 0x28, 0x0d, 0x52, 0x1a, 0x6d, 0x61, 0x78, 0x69, 0x6d, 0x75, 0x6d, 0x43, 0x6f, 0x6e, 0x66,
+	0x6c, 0x69, 0x63, 0x74, 0x50, 0x65, 0x72, 0x73, 0x69, 0x73, 0x74, 0x65, 0x6e, 0x63, 0x65, 0x12,
+	0x29, 0x0a, 0x0f, 0x77, 0x61, 0x74, 0x63, 0x68, 0x64, 0x6f, 0x67, 0x54, 0x69, 0x6d, 0x65, 0x6f,
+	0x75, 0x74, 0x18, 0xa1, 0x01, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x0f, 0x77, 0x61, 0x74, 0x63, 0x68,
+	0x64, 0x6f, 0x67, 0x54, 0x69, 0x6d, 0x65, 0x6f, 0x75, 0x74, 0x12, 0x47, 0x0a, 0x1e, 0x61, 0x67,
+	0x65, 0x6e, 0x74, 0x54, 0x65, 0x72, 0x6d, 0x69, 0x6e, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x65,
+	0x63, 0x6f, 0x6e, 0x6e, 0x65, 0x63, 0x74, 0x44, 0x65, 0x6c, 0x61, 0x79, 0x18, 0xa2, 0x01, 0x20,
+	0x01, 0x28, 0x0d, 0x52, 0x1e, 0x61, 0x67, 0x65, 0x6e, 0x74, 0x54, 0x65, 0x72, 0x6d, 0x69, 0x6e,
+	0x61, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x65, 0x63, 0x6f, 0x6e, 0x6e, 0x65, 0x63, 0x74, 0x44, 0x65,
+	0x6c, 0x61, 0x79, 0x12, 0x39, 0x0a, 0x17, 0x6d, 0x61, 0x78, 0x69, 0x6d, 0x75, 0x6d, 0x42, 0x65,
+	0x74, 0x61, 0x53, 0x63, 0x61, 0x6e, 0x44, 0x65, 0x66, 0x65, 0x72, 0x72, 0x61, 0x6c, 0x18, 0xa3,
+	0x01, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x17, 0x6d, 0x61, 0x78, 0x69, 0x6d, 0x75, 0x6d, 0x42, 0x65,
+	0x74, 0x61, 0x53, 0x63, 0x61, 0x6e, 0x44, 0x65, 0x66, 0x65, 0x72, 0x72, 0x61, 0x6c, 0x12, 0x21,
+	0x0a, 0x0b, 0x6f, 0x76, 0x65, 0x72, 0x6c, 0x61, 0x79, 0x42, 0x61, 0x73, 0x65, 0x18, 0xab, 0x01,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x0b, 0x6f, 0x76, 0x65, 0x72, 0x6c, 0x61, 0x79, 0x42, 0x61, 0x73,
+	0x65, 0x12, 0x4b, 0x0a, 0x0f, 0x72, 0x6f, 0x6f, 0x74, 0x4f, 0x76, 0x65, 0x72, 0x6c, 0x61, 0x70,
+	0x4d, 0x6f, 0x64, 0x65, 0x18, 0xb5, 0x01, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x20, 0x2e, 0x73, 0x79,
+	0x6e, 0x63, 0x68, 0x72, 0x6f, 0x6e, 0x69, 0x7a, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x2e, 0x52, 0x6f,
+	0x6f, 0x74, 0x4f, 0x76, 0x65, 0x72, 0x6c, 0x61, 0x70, 0x4d, 0x6f, 0x64, 0x65, 0x52, 0x0f, 0x72,
+	0x6f, 0x6f, 0x74, 0x4f, 0x76, 0x65, 0x72, 0x6c, 0x61, 0x70, 0x4d, 0x6f, 0x64, 0x65, 0x12, 0x4b,
+	0x0a, 0x0f, 0x73, 0x63, 0x61, 0x6e, 0x53, 0x68, 0x61, 0x72, 0x69, 0x6e, 0x67, 0x4d, 0x6f, 0x64,
+	0x65, 0x18, 0xbf, 0x01, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x20, 0x2e, 0x73, 0x79, 0x6e, 0x63, 0x68,
+	0x72, 0x6f, 0x6e, 0x69, 0x7a, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x2e, 0x53, 0x63, 0x61, 0x6e, 0x53,
+	0x68, 0x61, 0x72, 0x69, 0x6e, 0x67, 0x4d, 0x6f, 0x64, 0x65, 0x52, 0x0f, 0x73, 0x63, 0x61, 0x6e,
+	0x53, 0x68, 0x61, 0x72, 0x69, 0x6e, 0x67, 0x4d, 0x6f, 0x64, 0x65, 0x12, 0x23, 0x0a, 0x0c, 0x73,
+	0x63, 0x61, 0x6e, 0x53, 0x75, 0x62, 0x70, 0x61, 0x74, 0x68, 0x73, 0x18, 0xc9, 0x01, 0x20, 0x03,
+	0x28, 0x09, 0x52, 0x0c, 0x73, 0x63, 0x61, 0x6e, 0x53, 0x75, 0x62, 0x70, 0x61, 0x74, 0x68, 0x73,
+	0x12, 0x4e, 0x0a, 0x10, 0x76, 0x65, 0x72, 0x69, 0x66, 0x69, 0x63, 0x61, 0x74, 0x69, 0x6f, 0x6e,
+	0x4d, 0x6f, 0x64, 0x65, 0x18, 0xd3, 0x01, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x21, 0x2e, 0x73, 0x79,
+	0x6e, 0x63, 0x68, 0x72, 0x6f, 0x6e, 0x69, 0x7a, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x2e, 0x56, 0x65,
+	0x72, 0x69, 0x66, 0x69, 0x63, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x4d, 0x6f, 0x64, 0x65, 0x52, 0x10,
+	0x76, 0x65, 0x72, 0x69, 0x66, 0x69, 0x63, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x4d, 0x6f, 0x64, 0x65,
+	0x12, 0x33, 0x0a, 0x14, 0x76, 0x65, 0x72, 0x69, 0x66, 0x69, 0x63, 0x61, 0x74, 0x69, 0x6f, 0x6e,
+	0x49, 0x6e, 0x74, 0x65, 0x72, 0x76, 0x61, 0x6c, 0x18, 0xd4, 0x01, 0x20, 0x01, 0x28, 0x0d, 0x52,
+	0x14, 0x76, 0x65, 0x72, 0x69, 0x66, 0x69, 0x63, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x49, 0x6e, 0x74,
+	0x65, 0x72, 0x76, 0x61, 0x6c, 0x12, 0x39, 0x0a, 0x17, 0x6d, 0x61, 0x78, 0x69, 0x6d, 0x75, 0x6d,
+	0x52, 0x65, 0x70, 0x6f, 0x72, 0x74, 0x65, 0x64, 0x50, 0x72, 0x6f, 0x62, 0x6c, 0x65, 0x6d, 0x73,
+	0x18, 0xdd, 0x01, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x17, 0x6d, 0x61, 0x78, 0x69, 0x6d, 0x75, 0x6d,
+	0x52, 0x65, 0x70, 0x6f, 0x72, 0x74, 0x65, 0x64, 0x50, 0x72, 0x6f, 0x62, 0x6c, 0x65, 0x6d, 0x73,
+	0x12, 0x57, 0x0a, 0x13, 0x61, 0x67, 0x67, 0x72, 0x65, 0x67, 0x61, 0x74, 0x65, 0x44, 0x69, 0x67,
+	0x65, 0x73, 0x74, 0x4d, 0x6f, 0x64, 0x65, 0x18, 0xe7, 0x01, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x24,
+	0x2e, 0x73, 0x79, 0x6e, 0x63, 0x68, 0x72, 0x6f, 0x6e, 0x69, 0x7a, 0x61, 0x74, 0x69, 0x6f, 0x6e,
+	0x2e, 0x41, 0x67, 0x67, 0x72, 0x65, 0x67, 0x61, 0x74, 0x65, 0x44, 0x69, 0x67, 0x65, 0x73, 0x74,
+	0x4d, 0x6f, 0x64, 0x65, 0x52, 0x13, 0x61, 0x67, 0x67, 0x72, 0x65, 0x67, 0x61, 0x74, 0x65, 0x44,
+	0x69, 0x67, 0x65, 0x73, 0x74, 0x4d, 0x6f, 0x64, 0x65, 0x12, 0x49, 0x0a, 0x1f, 0x6d, 0x61, 0x78,
+	0x69, 0x6d, 0x75, 0x6d, 0x52, 0x65, 0x63, 0x6f, 0x6e, 0x63, 0x69, 0x6c, 0x69, 0x61, 0x74, 0x69,
+	0x6f, 0x6e, 0x52, 0x65, 0x63, 0x6f, 0x72, 0x64, 0x69, 0x6e, 0x67, 0x73, 0x18, 0xf1, 0x01, 0x20,
+	0x01, 0x28, 0x0d, 0x52, 0x1f, 0x6d, 0x61, 0x78, 0x69, 0x6d, 0x75, 0x6d, 0x52, 0x65, 0x63, 0x6f,
+	0x6e, 0x63, 0x69, 0x6c, 0x69, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x65, 0x63, 0x6f, 0x72, 0x64,
+	0x69, 0x6e, 0x67, 0x73, 0x12, 0x45, 0x0a, 0x1d, 0x73, 0x6c, 0x6f, 0x77, 0x44, 0x69, 0x72, 0x65,
+	0x63, 0x74, 0x6f, 0x72, 0x79, 0x4c, 0x69, 0x73, 0x74, 0x69, 0x6e, 0x67, 0x54, 0x68, 0x72, 0x65,
+	0x73, 0x68, 0x6f, 0x6c, 0x64, 0x18, 0xf2, 0x01, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x1d, 0x73, 0x6c,
+	0x6f, 0x77, 0x44, 0x69, 0x72, 0x65, 0x63, 0x74, 0x6f, 0x72, 0x79, 0x4c, 0x69, 0x73, 0x74, 0x69,
+	0x6e, 0x67, 0x54, 0x68, 0x72, 0x65, 0x73, 0x68, 0x6f, 0x6c, 0x64, 0x42, 0x33, 0x5a, 0x31, 0x67,
+	0x69, 0x74, 0x68, 0x75, 0x62, 0x2e, 0x63, 0x6f, 0x6d, 0x2f, 0x6d, 0x75, 0x74, 0x61, 0x67, 0x65,
+	0x6e, 0x2d, 0x69, 0x6f, 0x2f, 0x6d, 0x75, 0x74, 0x61, 0x67, 0x65, 0x6e, 0x2f, 0x70, 0x6b, 0x67,
+	0x2f, 0x73, 0x79, 0x6e, 0x63, 0x68, 0x72, 0x6f, 0x6e, 0x69, 0x7a, 0x61, 0x74, 0x69, 0x6f, 0x6e,
+	0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
 }
 
 var (
@@ -390,18 +1373,40 @@ func file_synchronization_configuration_proto_rawDescGZIP() []byte {
 
 var file_synchronization_configuration_proto_msgTypes = make([]protoimpl.MessageInfo, 1)
 var file_synchronization_configuration_proto_goTypes = []any{
-	(*Configuration)(nil),         // 0: synchronization.Configuration
-	(core.SynchronizationMode)(0), // 1: core.SynchronizationMode
-	(hashing.Algorithm)(0),        // 2: hashing.Algorithm
-	(behavior.ProbeMode)(0),       // 3: behavior.ProbeMode
-	(ScanMode)(0),                 // 4: synchronization.ScanMode
-	(StageMode)(0),                // 5: synchronization.StageMode
-	(core.SymbolicLinkMode)(0),    // 6: core.SymbolicLinkMode
-	(WatchMode)(0),                // 7: synchronization.WatchMode
-	(ignore.Syntax)(0),            // 8: ignore.Syntax
-	(ignore.IgnoreVCSMode)(0),     // 9: ignore.IgnoreVCSMode
-	(core.PermissionsMode)(0),     // 10: core.PermissionsMode
-	(compression.Algorithm)(0),    // 11: compression.Algorithm
+	(*Configuration)(nil),                 // 0: synchronization.Configuration
+	(core.SynchronizationMode)(0),         // 1: core.SynchronizationMode
+	(hashing.Algorithm)(0),                // 2: hashing.Algorithm
+	(behavior.ProbeMode)(0),               // 3: behavior.ProbeMode
+	(ScanMode)(0),                         // 4: synchronization.ScanMode
+	(StageMode)(0),                        // 5: synchronization.StageMode
+	(core.TransitionMode)(0),              // 6: core.TransitionMode
+	(core.SymbolicLinkMode)(0),            // 7: core.SymbolicLinkMode
+	(core.SymbolicLinkReplacementMode)(0), // 8: core.SymbolicLinkReplacementMode
+	(core.SymbolicLinkCycleMode)(0),       // 9: core.SymbolicLinkCycleMode
+	(WatchMode)(0),                        // 10: synchronization.WatchMode
+	(InitialSynchronizationMode)(0),       // 11: synchronization.InitialSynchronizationMode
+	(InitialPollModificationMode)(0),      // 12: synchronization.InitialPollModificationMode
+	(ignore.Syntax)(0),                    // 13: ignore.Syntax
+	(ignore.IgnoreVCSMode)(0),             // 14: ignore.IgnoreVCSMode
+	(core.PhantomDirectoryMode)(0),        // 15: core.PhantomDirectoryMode
+	(ignore.IgnoreOSMetadataMode)(0),      // 16: ignore.IgnoreOSMetadataMode
+	(core.PermissionsMode)(0),             // 17: core.PermissionsMode
+	(compression.Algorithm)(0),            // 18: compression.Algorithm
+	(core.SpecialFileMode)(0),             // 19: core.SpecialFileMode
+	(ClockSkewMode)(0),                    // 20: synchronization.ClockSkewMode
+	(behavior.ProbeAssumption)(0),         // 21: behavior.ProbeAssumption
+	(OversizedFileMode)(0),                // 22: synchronization.OversizedFileMode
+	(StagingConcurrencyMode)(0),           // 23: synchronization.StagingConcurrencyMode
+	(RootExistenceMode)(0),                // 24: synchronization.RootExistenceMode
+	(core.NameNormalizationMode)(0),       // 25: core.NameNormalizationMode
+	(CapabilityMismatchMode)(0),           // 26: synchronization.CapabilityMismatchMode
+	(ConfigurationIncompatibilityMode)(0), // 27: synchronization.ConfigurationIncompatibilityMode
+	(core.CacheTrustMode)(0),              // 28: core.CacheTrustMode
+	(core.TypeChangeMode)(0),              // 29: core.TypeChangeMode
+	(RootOverlapMode)(0),                  // 30: synchronization.RootOverlapMode
+	(ScanSharingMode)(0),                  // 31: synchronization.ScanSharingMode
+	(VerificationMode)(0),                 // 32: synchronization.VerificationMode
+	(AggregateDigestMode)(0),              // 33: synchronization.AggregateDigestMode
 }
 var file_synchronization_configuration_proto_depIdxs = []int32{
 	1,  // 0: synchronization.Configuration.synchronizationMode:type_name -> core.SynchronizationMode
@@ -409,17 +1414,41 @@ var file_synchronization_configuration_proto_depIdxs = []int32{
 	3,  // 2: synchronization.Configuration.probeMode:type_name -> behavior.ProbeMode
 	4,  // 3: synchronization.Configuration.scanMode:type_name -> synchronization.ScanMode
 	5,  // 4: synchronization.Configuration.stageMode:type_name -> synchronization.StageMode
-	6,  // 5: synchronization.Configuration.symbolicLinkMode:type_name -> core.SymbolicLinkMode
-	7,  // 6: synchronization.Configuration.watchMode:type_name -> synchronization.WatchMode
-	8,  // 7: synchronization.Configuration.ignoreSyntax:type_name -> ignore.Syntax
-	9,  // 8: synchronization.Configuration.ignoreVCSMode:type_name -> ignore.IgnoreVCSMode
-	10, // 9: synchronization.Configuration.permissionsMode:type_name -> core.PermissionsMode
-	11, // 10: synchronization.Configuration.compressionAlgorithm:type_name -> compression.Algorithm
-	11, // [11:11] is the sub-list for method output_type
-	11, // [11:11] is the sub-list for method input_type
-	11, // [11:11] is the sub-list for extension type_name
-	11, // [11:11] is the sub-list for extension extendee
-	0,  // [0:11] is the sub-list for field type_name
+	6,  // 5: synchronization.Configuration.transitionMode:type_name -> core.TransitionMode
+	7,  // 6: synchronization.Configuration.symbolicLinkMode:type_name -> core.SymbolicLinkMode
+	8,  // 7: synchronization.Configuration.symbolicLinkReplacementMode:type_name -> core.SymbolicLinkReplacementMode
+	9,  // 8: synchronization.Configuration.symbolicLinkCycleMode:type_name -> core.SymbolicLinkCycleMode
+	10, // 9: synchronization.Configuration.watchMode:type_name -> synchronization.WatchMode
+	11, // 10: synchronization.Configuration.initialSynchronizationMode:type_name -> synchronization.InitialSynchronizationMode
+	12, // 11: synchronization.Configuration.initialPollModificationMode:type_name -> synchronization.InitialPollModificationMode
+	13, // 12: synchronization.Configuration.ignoreSyntax:type_name -> ignore.Syntax
+	14, // 13: synchronization.Configuration.ignoreVCSMode:type_name -> ignore.IgnoreVCSMode
+	15, // 14: synchronization.Configuration.phantomDirectoryMode:type_name -> core.PhantomDirectoryMode
+	16, // 15: synchronization.Configuration.ignoreOSMetadataMode:type_name -> ignore.IgnoreOSMetadataMode
+	17, // 16: synchronization.Configuration.permissionsMode:type_name -> core.PermissionsMode
+	18, // 17: synchronization.Configuration.compressionAlgorithm:type_name -> compression.Algorithm
+	18, // 18: synchronization.Configuration.stagingCompressionAlgorithm:type_name -> compression.Algorithm
+	19, // 19: synchronization.Configuration.specialFileMode:type_name -> core.SpecialFileMode
+	20, // 20: synchronization.Configuration.clockSkewMode:type_name -> synchronization.ClockSkewMode
+	21, // 21: synchronization.Configuration.assumeExecutabilityPreservation:type_name -> behavior.ProbeAssumption
+	21, // 22: synchronization.Configuration.assumeUnicodeDecomposition:type_name -> behavior.ProbeAssumption
+	22, // 23: synchronization.Configuration.oversizedFileMode:type_name -> synchronization.OversizedFileMode
+	23, // 24: synchronization.Configuration.stagingConcurrencyMode:type_name -> synchronization.StagingConcurrencyMode
+	24, // 25: synchronization.Configuration.rootExistenceMode:type_name -> synchronization.RootExistenceMode
+	25, // 26: synchronization.Configuration.nameNormalizationMode:type_name -> core.NameNormalizationMode
+	26, // 27: synchronization.Configuration.capabilityMismatchMode:type_name -> synchronization.CapabilityMismatchMode
+	27, // 28: synchronization.Configuration.configurationIncompatibilityMode:type_name -> synchronization.ConfigurationIncompatibilityMode
+	28, // 29: synchronization.Configuration.cacheTrustMode:type_name -> core.CacheTrustMode
+	29, // 30: synchronization.Configuration.typeChangeMode:type_name -> core.TypeChangeMode
+	30, // 31: synchronization.Configuration.rootOverlapMode:type_name -> synchronization.RootOverlapMode
+	31, // 32: synchronization.Configuration.scanSharingMode:type_name -> synchronization.ScanSharingMode
+	32, // 33: synchronization.Configuration.verificationMode:type_name -> synchronization.VerificationMode
+	33, // 34: synchronization.Configuration.aggregateDigestMode:type_name -> synchronization.AggregateDigestMode
+	35, // [35:35] is the sub-list for method output_type
+	35, // [35:35] is the sub-list for method input_type
+	35, // [35:35] is the sub-list for extension type_name
+	35, // [35:35] is the sub-list for extension extendee
+	0,  // [0:35] is the sub-list for field type_name
 }
 
 func init() { file_synchronization_configuration_proto_init() }
@@ -427,8 +1456,20 @@ func file_synchronization_configuration_proto_init() {
 	if File_synchronization_configuration_proto != nil {
 		return
 	}
+	file_synchronization_aggregate_digest_mode_proto_init()
+	file_synchronization_capability_mismatch_mode_proto_init()
+	file_synchronization_clock_skew_mode_proto_init()
+	file_synchronization_configuration_incompatibility_mode_proto_init()
+	file_synchronization_initial_poll_modification_mode_proto_init()
+	file_synchronization_initial_synchronization_mode_proto_init()
+	file_synchronization_oversized_file_mode_proto_init()
+	file_synchronization_root_existence_mode_proto_init()
+	file_synchronization_root_overlap_mode_proto_init()
 	file_synchronization_scan_mode_proto_init()
+	file_synchronization_scan_sharing_mode_proto_init()
 	file_synchronization_stage_mode_proto_init()
+	file_synchronization_staging_concurrency_mode_proto_init()
+	file_synchronization_verification_mode_proto_init()
 	file_synchronization_watch_mode_proto_init()
 	type x struct{}
 	out := protoimpl.TypeBuilder{
@@ -0,0 +1,73 @@
+package synchronization
+
+import (
+	"fmt"
+)
+
+// IsDefault indicates whether or not the configuration incompatibility mode is
+// ConfigurationIncompatibilityMode_ConfigurationIncompatibilityModeDefault.
+func (m ConfigurationIncompatibilityMode) IsDefault() bool {
+	return m == ConfigurationIncompatibilityMode_ConfigurationIncompatibilityModeDefault
+}
+
+// MarshalText implements encoding.TextMarshaler.MarshalText.
+func (m ConfigurationIncompatibilityMode) MarshalText() ([]byte, error) {
+	var result string
+	switch m {
+	case ConfigurationIncompatibilityMode_ConfigurationIncompatibilityModeDefault:
+	case ConfigurationIncompatibilityMode_ConfigurationIncompatibilityModeAllow:
+		result = "allow"
+	case ConfigurationIncompatibilityMode_ConfigurationIncompatibilityModeHalt:
+		result = "halt"
+	default:
+		result = "unknown"
+	}
+	return []byte(result), nil
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler.UnmarshalText.
+func (m *ConfigurationIncompatibilityMode) UnmarshalText(textBytes []byte) error {
+	// Convert the bytes to a string.
+	text := string(textBytes)
+
+	// Convert to a configuration incompatibility mode.
+	switch text {
+	case "allow":
+		*m = ConfigurationIncompatibilityMode_ConfigurationIncompatibilityModeAllow
+	case "halt":
+		*m = ConfigurationIncompatibilityMode_ConfigurationIncompatibilityModeHalt
+	default:
+		return fmt.Errorf("unknown configuration incompatibility mode specification: %s", text)
+	}
+
+	// Success.
+	return nil
+}
+
+// Supported indicates whether or not a particular configuration
+// incompatibility mode is a valid, non-default value.
+func (m ConfigurationIncompatibilityMode) Supported() bool {
+	switch m {
+	case ConfigurationIncompatibilityMode_ConfigurationIncompatibilityModeAllow:
+		return true
+	case ConfigurationIncompatibilityMode_ConfigurationIncompatibilityModeHalt:
+		return true
+	default:
+		return false
+	}
+}
+
+// Description returns a human-readable description of a configuration
+// incompatibility mode.
+func (m ConfigurationIncompatibilityMode) Description() string {
+	switch m {
+	case ConfigurationIncompatibilityMode_ConfigurationIncompatibilityModeDefault:
+		return "Default"
+	case ConfigurationIncompatibilityMode_ConfigurationIncompatibilityModeAllow:
+		return "Allow"
+	case ConfigurationIncompatibilityMode_ConfigurationIncompatibilityModeHalt:
+		return "Halt"
+	default:
+		return "Unknown"
+	}
+}
@@ -0,0 +1,91 @@
+package synchronization
+
+import (
+	"testing"
+)
+
+// TestConfigurationIncompatibilityModeUnmarshal tests that unmarshaling from a
+// string specification succeeeds for ConfigurationIncompatibilityMode.
+func TestConfigurationIncompatibilityModeUnmarshal(t *testing.T) {
+	// Set up test cases.
+	testCases := []struct {
+		text          string
+		expectedMode  ConfigurationIncompatibilityMode
+		expectFailure bool
+	}{
+		{"", ConfigurationIncompatibilityMode_ConfigurationIncompatibilityModeDefault, true},
+		{"asdf", ConfigurationIncompatibilityMode_ConfigurationIncompatibilityModeDefault, true},
+		{"allow", ConfigurationIncompatibilityMode_ConfigurationIncompatibilityModeAllow, false},
+		{"halt", ConfigurationIncompatibilityMode_ConfigurationIncompatibilityModeHalt, false},
+	}
+
+	// Process test cases.
+	for _, testCase := range testCases {
+		var mode ConfigurationIncompatibilityMode
+		if err := mode.UnmarshalText([]byte(testCase.text)); err != nil {
+			if !testCase.expectFailure {
+				t.Errorf("unable to unmarshal text (%s): %s", testCase.text, err)
+			}
+		} else if testCase.expectFailure {
+			t.Error("unmarshaling succeeded unexpectedly for text:", testCase.text)
+		} else if mode != testCase.expectedMode {
+			t.Errorf(
+				"unmarshaled mode (%s) does not match expected (%s)",
+				mode,
+				testCase.expectedMode,
+			)
+		}
+	}
+}
+
+// TestConfigurationIncompatibilityModeSupported tests that
+// ConfigurationIncompatibilityMode support detection works as expected.
+func TestConfigurationIncompatibilityModeSupported(t *testing.T) {
+	// Set up test cases.
+	testCases := []struct {
+		mode            ConfigurationIncompatibilityMode
+		expectSupported bool
+	}{
+		{ConfigurationIncompatibilityMode_ConfigurationIncompatibilityModeDefault, false},
+		{ConfigurationIncompatibilityMode_ConfigurationIncompatibilityModeAllow, true},
+		{ConfigurationIncompatibilityMode_ConfigurationIncompatibilityModeHalt, true},
+		{(ConfigurationIncompatibilityMode_ConfigurationIncompatibilityModeHalt + 1), false},
+	}
+
+	// Process test cases.
+	for _, testCase := range testCases {
+		if supported := testCase.mode.Supported(); supported != testCase.expectSupported {
+			t.Errorf(
+				"mode support status (%t) does not match expected (%t)",
+				supported,
+				testCase.expectSupported,
+			)
+		}
+	}
+}
+
+// TestConfigurationIncompatibilityModeDescription tests that
+// ConfigurationIncompatibilityMode description generation works as expected.
+func TestConfigurationIncompatibilityModeDescription(t *testing.T) {
+	// Set up test cases.
+	testCases := []struct {
+		mode                ConfigurationIncompatibilityMode
+		expectedDescription string
+	}{
+		{ConfigurationIncompatibilityMode_ConfigurationIncompatibilityModeDefault, "Default"},
+		{ConfigurationIncompatibilityMode_ConfigurationIncompatibilityModeAllow, "Allow"},
+		{ConfigurationIncompatibilityMode_ConfigurationIncompatibilityModeHalt, "Halt"},
+		{(ConfigurationIncompatibilityMode_ConfigurationIncompatibilityModeHalt + 1), "Unknown"},
+	}
+
+	// Process test cases.
+	for _, testCase := range testCases {
+		if description := testCase.mode.Description(); description != testCase.expectedDescription {
+			t.Errorf(
+				"mode description (%s) does not match expected (%s)",
+				description,
+				testCase.expectedDescription,
+			)
+		}
+	}
+}
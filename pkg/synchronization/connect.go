@@ -2,8 +2,10 @@ package synchronization
 
 import (
 	"context"
+	"errors"
 	"fmt"
 
+	"github.com/mutagen-io/mutagen/pkg/agent"
 	"github.com/mutagen-io/mutagen/pkg/logging"
 	urlpkg "github.com/mutagen-io/mutagen/pkg/url"
 )
@@ -58,3 +60,12 @@ func connect(
 	// Success.
 	return endpoint, nil
 }
+
+// isRetryableConnectError determines whether or not a connection failure might
+// be resolved by retrying the connection. Authentication and authorization
+// failures require user intervention to resolve, so there's no point in
+// retrying them automatically, whereas other failures (e.g. network issues) are
+// treated as transient.
+func isRetryableConnectError(err error) bool {
+	return !errors.Is(err, agent.ErrAuthenticationFailed)
+}
@@ -6,11 +6,13 @@ import (
 	"fmt"
 	"os"
 	"sync"
+	"sync/atomic"
 	"time"
 
 	"google.golang.org/protobuf/proto"
 	"google.golang.org/protobuf/types/known/timestamppb"
 
+	"github.com/mutagen-io/mutagen/pkg/agent"
 	"github.com/mutagen-io/mutagen/pkg/encoding"
 	"github.com/mutagen-io/mutagen/pkg/logging"
 	"github.com/mutagen-io/mutagen/pkg/mutagen"
@@ -22,15 +24,48 @@ import (
 	"github.com/mutagen-io/mutagen/pkg/url"
 )
 
+// autoReconnectInterval is the period of time to wait before attempting an
+// automatic reconnect after disconnection or a failed reconnect. It is a
+// variable so that it can be shortened for testing.
+var autoReconnectInterval = 15 * time.Second
+
 const (
-	// autoReconnectInterval is the period of time to wait before attempting an
-	// automatic reconnect after disconnection or a failed reconnect.
-	autoReconnectInterval = 15 * time.Second
+	// watchdogShutdownGracePeriod is the period of time that the watchdog will
+	// wait for a stalled synchronization loop to respond to cancellation
+	// before shutting down its endpoints to unblock any pending operations.
+	watchdogShutdownGracePeriod = 5 * time.Second
 	// rescanWaitDuration is the period of time to wait before attempting to
 	// rescan after an ephemeral scan failure.
 	rescanWaitDuration = 5 * time.Second
 )
 
+// flushRequest encodes a request to force a synchronization cycle.
+type flushRequest struct {
+	// scope is the normalized synchronization scope to which the flush is
+	// restricted. If empty, then the flush is unscoped and will force both
+	// endpoints to perform a full (warm) scan.
+	scope []string
+	// result is the channel used to report completion of the flush. It must be
+	// buffered and contain room for one error.
+	result chan error
+}
+
+// fetchRequest encodes a request to fetch the content of a single file from
+// one of the session's endpoints.
+type fetchRequest struct {
+	// beta indicates whether the content should be fetched from beta (rather
+	// than alpha).
+	beta bool
+	// path is the normalized synchronization-root-relative path of the file.
+	path string
+	// content is the fetched content. It is set by the synchronization loop
+	// before a nil error is sent to result.
+	content []byte
+	// result is the channel used to report completion of the fetch. It must be
+	// buffered and contain room for one error.
+	result chan error
+}
+
 // controller manages and executes a single session.
 type controller struct {
 	// logger is the controller logger.
@@ -66,13 +101,28 @@ type controller struct {
 	// a state where it can perform synchronization. It is closed when
 	// synchronization fails due to an error.
 	synchronizing chan struct{}
-	// lifecycleLock guards access to disabled, cancel, flushRequests, and done.
-	// Only the current holder of the lifecycle lock may set any of these fields
-	// or invoke cancel. The synchronization loop may close close done or
-	// receive from flushRequests without holding the lifecycle lock. Moreover,
-	// previous lifecycle lock holders may continue to send to flushRequests and
-	// poll on done after storing them in separate variables and releasing the
-	// lifecycle lock. Any code wishing to set these fields must first acquire
+	// heldAlphaSnapshot, heldBetaSnapshot, and heldAncestor are the snapshots
+	// and ancestor held by the synchronization loop as of its last successful
+	// scan. They're used only for estimating resource usage. They are guarded
+	// by stateLock and are nil if no synchronization loop is running or no scan
+	// has completed.
+	heldAlphaSnapshot *core.Snapshot
+	heldBetaSnapshot  *core.Snapshot
+	heldAncestor      *core.Entry
+	// progress is the time (in Unix nanoseconds) of the most recent progress
+	// heartbeat from a synchronization cycle. It is zero if no synchronization
+	// cycle is in progress (e.g. if the synchronization loop is polling). It is
+	// used by the synchronization loop watchdog and must be accessed
+	// atomically.
+	progress atomic.Int64
+	// lifecycleLock guards access to disabled, cancel, flushRequests,
+	// fetchRequests, and done. Only the current holder of the lifecycle lock
+	// may set any of these fields or invoke cancel. The synchronization loop may
+	// close close done or receive from flushRequests and fetchRequests without
+	// holding the lifecycle lock. Moreover, previous lifecycle lock holders may
+	// continue to send to flushRequests and fetchRequests and poll on done
+	// after storing them in separate variables and releasing the lifecycle
+	// lock. Any code wishing to set these fields must first acquire
 	// the lock, then cancel the synchronization loop and wait for it to
 	// complete before making any changes.
 	lifecycleLock sync.Mutex
@@ -86,11 +136,21 @@ type controller struct {
 	// and only if there is no synchronization loop running.
 	cancel context.CancelFunc
 	// flushRequests is used pass flush requests to the synchronization loop. It
-	// is buffered, allowing a single request to be queued. All requests passed
-	// via this channel must be buffered and contain room for one error.
-	flushRequests chan chan error
+	// is buffered, allowing a single request to be queued. The result channels
+	// of all requests passed via this channel must be buffered and contain room
+	// for one error.
+	flushRequests chan *flushRequest
+	// fetchRequests is used to pass fetch requests to the synchronization loop.
+	// It is buffered, allowing a single request to be queued. The result
+	// channels of all requests passed via this channel must be buffered and
+	// contain room for one error.
+	fetchRequests chan *fetchRequest
 	// done will be closed by the current synchronization loop when it exits.
 	done chan struct{}
+	// verificationClock creates the timers used to schedule background
+	// verification passes. If nil, time.After is used. It's only overridden by
+	// tests that need to control verification scheduling.
+	verificationClock func(time.Duration) <-chan time.Time
 }
 
 // newSession creates a new session and corresponding controller.
@@ -203,7 +263,7 @@ func newSession(
 	if err := encoding.MarshalAndSaveProtobuf(sessionPath, session); err != nil {
 		return nil, fmt.Errorf("unable to save session: %w", err)
 	}
-	if err := encoding.MarshalAndSaveProtobuf(archivePath, archive); err != nil {
+	if err := newArchiveStore(archivePath).save(archive); err != nil {
 		os.Remove(sessionPath)
 		return nil, fmt.Errorf("unable to save archive: %w", err)
 	}
@@ -230,7 +290,8 @@ func newSession(
 	if !paused {
 		ctx, cancel := context.WithCancel(context.Background())
 		controller.cancel = cancel
-		controller.flushRequests = make(chan chan error, 1)
+		controller.flushRequests = make(chan *flushRequest, 1)
+		controller.fetchRequests = make(chan *fetchRequest, 1)
 		controller.done = make(chan struct{})
 		go controller.run(ctx, alphaEndpoint, betaEndpoint)
 		alphaEndpoint = nil
@@ -298,7 +359,8 @@ func loadSession(logger *logging.Logger, tracker *state.Tracker, identifier stri
 	if !session.Paused {
 		ctx, cancel := context.WithCancel(context.Background())
 		controller.cancel = cancel
-		controller.flushRequests = make(chan chan error, 1)
+		controller.flushRequests = make(chan *flushRequest, 1)
+		controller.fetchRequests = make(chan *fetchRequest, 1)
 		controller.done = make(chan struct{})
 		go controller.run(ctx, nil, nil)
 	}
@@ -320,11 +382,47 @@ func (c *controller) currentState() *State {
 	return proto.Clone(c.state).(*State)
 }
 
+// usage computes the resource usage of the session. The tracked entry count
+// reflects the last snapshot from each endpoint. The snapshot memory usage is
+// approximated by the serialized size of the snapshots and ancestor held by the
+// synchronization loop.
+func (c *controller) usage() (trackedEntries, snapshotMemory, connections uint64) {
+	// Extract statistics and held content while holding the state lock.
+	c.stateLock.Lock()
+	for _, endpointState := range []*EndpointState{c.state.AlphaState, c.state.BetaState} {
+		trackedEntries += endpointState.Directories + endpointState.Files + endpointState.SymbolicLinks
+		if endpointState.Connected {
+			connections++
+		}
+	}
+	alphaSnapshot, betaSnapshot := c.heldAlphaSnapshot, c.heldBetaSnapshot
+	ancestor := c.heldAncestor
+	c.stateLock.UnlockWithoutNotify()
+
+	// Compute the approximate snapshot memory usage. Since snapshots and
+	// entries are immutable, we can do this without holding the state lock.
+	if alphaSnapshot != nil {
+		snapshotMemory += uint64(proto.Size(alphaSnapshot))
+	}
+	if betaSnapshot != nil {
+		snapshotMemory += uint64(proto.Size(betaSnapshot))
+	}
+	if ancestor != nil {
+		snapshotMemory += uint64(proto.Size(ancestor))
+	}
+
+	// Done.
+	return
+}
+
 // flush attempts to force a synchronization cycle for the session. If wait is
 // specified, then the method will wait until a post-flush synchronization cycle
 // has completed. The provided context (which must be non-nil) can terminate
-// this wait early.
-func (c *controller) flush(ctx context.Context, prompter string, skipWait bool) error {
+// this wait early. If a non-empty scope (which must be normalized) is provided,
+// then the synchronization cycle will guarantee convergence for only the
+// subtrees specified by the scope, allowing endpoints to use scan acceleration
+// for other content (see the synchronization loop for more details).
+func (c *controller) flush(ctx context.Context, prompter string, skipWait bool, scope []string) error {
 	// Update status.
 	prompting.Message(prompter, fmt.Sprintf("Forcing synchronization cycle for session %s...", c.session.Identifier))
 
@@ -365,13 +463,19 @@ func (c *controller) flush(ctx context.Context, prompter string, skipWait bool)
 	c.lifecycleLock.Unlock()
 
 	// Create a flush request.
-	request := make(chan error, 1)
+	request := &flushRequest{
+		scope:  scope,
+		result: make(chan error, 1),
+	}
 
 	// If we don't want to wait, then we can simply send the request in a
 	// non-blocking manner, in which case either this request (or one that's
 	// already queued) will be processed eventually. After that, we're done. In
 	// this case, we'll still check for an inability to synchronize, since we
-	// may as well report it if we can.
+	// may as well report it if we can. Note that an already queued request may
+	// have a narrower scope than this request, in which case the eventual cycle
+	// won't provide a convergence guarantee for this request's full scope, but
+	// callers that skip waiting can't observe that guarantee anyway.
 	if skipWait {
 		select {
 		case flushRequests <- request:
@@ -400,7 +504,7 @@ func (c *controller) flush(ctx context.Context, prompter string, skipWait bool)
 	// Now we need to wait for a response to the request, again watching for
 	// cancellation, failure, or termination.
 	select {
-	case err := <-request:
+	case err := <-request.result:
 		return err
 	case <-ctx.Done():
 		return errors.New("flush cancelled while waiting for response")
@@ -411,6 +515,239 @@ func (c *controller) flush(ctx context.Context, prompter string, skipWait bool)
 	}
 }
 
+// fetch fetches the content of a single file from one of the session's
+// endpoints without affecting session state. The request is serviced by the
+// synchronization loop while it's waiting for changes, so the fetch will wait
+// for any in-progress synchronization cycle to complete. The provided context
+// (which must be non-nil) can terminate this wait early. The path must be a
+// normalized, non-root, synchronization-root-relative path.
+func (c *controller) fetch(ctx context.Context, beta bool, path string) ([]byte, error) {
+	// Lock the controller's lifecycle.
+	c.lifecycleLock.Lock()
+
+	// Don't allow any operations if the controller is disabled.
+	if c.disabled {
+		c.lifecycleLock.Unlock()
+		return nil, errors.New("controller disabled")
+	}
+
+	// Check if the session is paused.
+	if c.cancel == nil {
+		c.lifecycleLock.Unlock()
+		return nil, errors.New("session is paused")
+	}
+
+	// Check if the session is currently synchronizing and store the channel
+	// that we'll use to track synchronizability.
+	c.stateLock.Lock()
+	synchronizing := c.synchronizing
+	c.stateLock.UnlockWithoutNotify()
+	if synchronizing == nil {
+		c.lifecycleLock.Unlock()
+		return nil, errors.New("session is not currently connected")
+	}
+
+	// Store the channels that we'll need to submit fetch requests and track
+	// synchronization termination.
+	fetchRequests := c.fetchRequests
+	done := c.done
+
+	// Release the lifecycle lock.
+	c.lifecycleLock.Unlock()
+
+	// Create a fetch request.
+	request := &fetchRequest{
+		beta:   beta,
+		path:   path,
+		result: make(chan error, 1),
+	}
+
+	// Send the request, watching for cancellation, failure, or termination.
+	select {
+	case fetchRequests <- request:
+	case <-ctx.Done():
+		return nil, errors.New("fetch cancelled before request could be sent")
+	case <-synchronizing:
+		return nil, errors.New("synchronization failed before fetch request could be sent")
+	case <-done:
+		return nil, errors.New("synchronization terminated before fetch request could be sent")
+	}
+
+	// Wait for a response to the request, again watching for cancellation,
+	// failure, or termination.
+	select {
+	case err := <-request.result:
+		if err != nil {
+			return nil, err
+		}
+		return request.content, nil
+	case <-ctx.Done():
+		return nil, errors.New("fetch cancelled while waiting for response")
+	case <-synchronizing:
+		return nil, errors.New("synchronization failed while waiting for fetch response")
+	case <-done:
+		return nil, errors.New("synchronization terminated while waiting for fetch response")
+	}
+}
+
+// preview computes a preview of the next synchronization cycle for a paused
+// session. Both endpoints are connected and scanned, their contents are
+// reconciled against the session's ancestor, and the resulting transitions are
+// validated using a dry run on each endpoint. Nothing is staged, neither
+// endpoint is modified, the archive isn't updated, and the session remains
+// paused. Safety checks that would halt the session aren't evaluated.
+func (c *controller) preview(ctx context.Context, prompter string) (*Preview, error) {
+	// Update status.
+	prompting.Message(prompter, fmt.Sprintf("Previewing session %s...", c.session.Identifier))
+
+	// Lock the controller's lifecycle and defer its release. Holding the
+	// lifecycle lock for the duration of the preview ensures that the session
+	// can't be resumed while we're connected to its endpoints.
+	c.lifecycleLock.Lock()
+	defer c.lifecycleLock.Unlock()
+
+	// Don't allow any operations if the controller is disabled.
+	if c.disabled {
+		return nil, errors.New("controller disabled")
+	}
+
+	// Previews are only supported for paused sessions, since the endpoints of
+	// an unpaused session are owned by its synchronization loop.
+	if c.cancel != nil {
+		return nil, errors.New("session is not paused")
+	}
+
+	// Connect to alpha and beta, deferring their shutdown.
+	alpha, err := connect(
+		ctx,
+		c.logger.Sublogger("alpha"),
+		c.session.Alpha,
+		prompter,
+		c.session.Identifier,
+		c.session.Version,
+		c.mergedAlphaConfiguration,
+		true,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("unable to connect to alpha: %w", err)
+	}
+	defer alpha.Shutdown()
+	beta, err := connect(
+		ctx,
+		c.logger.Sublogger("beta"),
+		c.session.Beta,
+		prompter,
+		c.session.Identifier,
+		c.session.Version,
+		c.mergedBetaConfiguration,
+		false,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("unable to connect to beta: %w", err)
+	}
+	defer beta.Shutdown()
+
+	// Load the archive and extract the ancestor.
+	archive, err := newArchiveStore(c.archivePath).load()
+	if err != nil {
+		return nil, fmt.Errorf("unable to load archive: %w", err)
+	} else if err = archive.EnsureValid(true); err != nil {
+		return nil, fmt.Errorf("invalid archive found on disk: %w", err)
+	}
+	ancestor := archive.Content
+
+	// Compute the effective synchronization parameters.
+	synchronizationMode := c.session.Configuration.SynchronizationMode
+	if synchronizationMode.IsDefault() {
+		synchronizationMode = c.session.Version.DefaultSynchronizationMode()
+	}
+	typeChangeMode := c.session.Configuration.TypeChangeMode
+	if typeChangeMode.IsDefault() {
+		typeChangeMode = c.session.Version.DefaultTypeChangeMode()
+	}
+	ignoreSyntax := c.session.Configuration.IgnoreSyntax
+	if ignoreSyntax.IsDefault() {
+		ignoreSyntax = c.session.Version.DefaultIgnoreSyntax()
+	}
+	phantomDirectoryMode := c.session.Configuration.PhantomDirectoryMode
+	if phantomDirectoryMode.IsDefault() {
+		phantomDirectoryMode = c.session.Version.DefaultPhantomDirectoryMode()
+	}
+	permissionsMode := c.session.Configuration.PermissionsMode
+	if permissionsMode.IsDefault() {
+		permissionsMode = c.session.Version.DefaultPermissionsMode()
+	}
+
+	// Perform full scans of both endpoints.
+	prompting.Message(prompter, "Scanning files...")
+	αSnapshot, err, _ := alpha.Scan(ctx, ancestor, true, nil, false)
+	if err != nil {
+		return nil, fmt.Errorf("unable to scan alpha: %w", err)
+	}
+	βSnapshot, err, _ := beta.Scan(ctx, ancestor, true, nil, false)
+	if err != nil {
+		return nil, fmt.Errorf("unable to scan beta: %w", err)
+	}
+
+	// Perform the same content pre-processing as the synchronization loop.
+	αContent, βContent := αSnapshot.Content, βSnapshot.Content
+	if ignoreSyntax == ignore.Syntax_SyntaxDocker {
+		αContent, βContent, _, _ = core.ReifyPhantomDirectories(
+			ancestor, αContent, βContent, phantomDirectoryMode,
+		)
+	}
+	if permissionsMode == core.PermissionsMode_PermissionsModePortable {
+		if αSnapshot.PreservesExecutability && βContent != nil && !βSnapshot.PreservesExecutability {
+			βContent = core.PropagateExecutability(ancestor, αContent, βContent)
+		} else if βSnapshot.PreservesExecutability && αContent != nil && !αSnapshot.PreservesExecutability {
+			if synchronizationMode == core.SynchronizationMode_SynchronizationModeOneWaySafe ||
+				synchronizationMode == core.SynchronizationMode_SynchronizationModeOneWayReplica {
+				αContent = core.PropagateExecutability(ancestor, nil, αContent)
+			} else {
+				αContent = core.PropagateExecutability(ancestor, βContent, αContent)
+			}
+		}
+	}
+	if αSnapshot.IgnoresCase && βContent != nil {
+		βContent = core.MarkCaseCollisions(βContent)
+	}
+	if βSnapshot.IgnoresCase && αContent != nil {
+		αContent = core.MarkCaseCollisions(αContent)
+	}
+
+	// Perform reconciliation.
+	_, αTransitions, βTransitions, conflicts := core.Reconcile(
+		ancestor,
+		αContent,
+		βContent,
+		synchronizationMode,
+		typeChangeMode,
+		alpha.ClockOffset(), beta.ClockOffset(),
+		nil,
+	)
+
+	// Perform a dry run of the transitions on each endpoint.
+	prompting.Message(prompter, "Validating changes...")
+	result := &Preview{
+		AlphaTransitions: αTransitions,
+		BetaTransitions:  βTransitions,
+		Conflicts:        conflicts,
+	}
+	if len(αTransitions) > 0 {
+		if _, result.AlphaProblems, _, err = alpha.Transition(ctx, αTransitions, true); err != nil {
+			return nil, fmt.Errorf("unable to validate alpha transitions: %w", err)
+		}
+	}
+	if len(βTransitions) > 0 {
+		if _, result.BetaProblems, _, err = beta.Transition(ctx, βTransitions, true); err != nil {
+			return nil, fmt.Errorf("unable to validate beta transitions: %w", err)
+		}
+	}
+
+	// Success.
+	return result, nil
+}
+
 // resume attempts to reconnect and resume the session if it isn't currently
 // connected and synchronizing. If lifecycleLockHeld is true, then halt will
 // assume that the lifecycle lock is held by the caller and will not attempt to
@@ -439,7 +776,12 @@ func (c *controller) resume(ctx context.Context, prompter string, lifecycleLockH
 		// If there is an existing synchronization loop, check if it's already
 		// in a state that's considered "connected".
 		c.stateLock.Lock()
-		connected := c.state.Status >= Status_Watching
+		connected := c.state.Status >= Status_Watching &&
+			c.state.Status != Status_HaltedOnConflictThreshold &&
+			c.state.Status != Status_HaltedOnPersistentConflict &&
+			c.state.Status != Status_HaltedOnCapabilityMismatch &&
+			c.state.Status != Status_HaltedOnConfigurationIncompatibility &&
+			c.state.Status != Status_HaltedOnOversizedFile
 		c.stateLock.UnlockWithoutNotify()
 
 		// If we're already connected, then there's nothing we need to do. We
@@ -465,6 +807,7 @@ func (c *controller) resume(ctx context.Context, prompter string, lifecycleLockH
 		// Nil out any lifecycle state.
 		c.cancel = nil
 		c.flushRequests = nil
+		c.fetchRequests = nil
 		c.done = nil
 	}
 
@@ -515,7 +858,8 @@ func (c *controller) resume(ctx context.Context, prompter string, lifecycleLockH
 	// loop keep trying to connect.
 	ctx, cancel := context.WithCancel(context.Background())
 	c.cancel = cancel
-	c.flushRequests = make(chan chan error, 1)
+	c.flushRequests = make(chan *flushRequest, 1)
+	c.fetchRequests = make(chan *fetchRequest, 1)
 	c.done = make(chan struct{})
 	go c.run(ctx, alpha, beta)
 
@@ -594,6 +938,7 @@ func (c *controller) halt(_ context.Context, mode controllerHaltMode, prompter s
 		// Nil out any lifecycle state.
 		c.cancel = nil
 		c.flushRequests = nil
+		c.fetchRequests = nil
 		c.done = nil
 	}
 
@@ -614,13 +959,20 @@ func (c *controller) halt(_ context.Context, mode controllerHaltMode, prompter s
 		// Disable the controller.
 		c.disabled = true
 
-		// Wipe the session information from disk.
+		// Wipe the session information from disk, including any reconciliation
+		// recordings.
 		sessionRemoveErr := os.Remove(c.sessionPath)
-		archiveRemoveErr := os.Remove(c.archivePath)
+		archiveRemoveErr := newArchiveStore(c.archivePath).remove()
+		recordingsPath, recordingsRemoveErr := pathForReconciliationRecordings(c.session.Identifier)
+		if recordingsRemoveErr == nil {
+			recordingsRemoveErr = os.RemoveAll(recordingsPath)
+		}
 		if sessionRemoveErr != nil {
 			return fmt.Errorf("unable to remove session from disk: %w", sessionRemoveErr)
 		} else if archiveRemoveErr != nil {
 			return fmt.Errorf("unable to remove archive from disk: %w", archiveRemoveErr)
+		} else if recordingsRemoveErr != nil {
+			return fmt.Errorf("unable to remove reconciliation recordings from disk: %w", recordingsRemoveErr)
 		}
 	} else {
 		panic("invalid halt mode specified")
@@ -651,7 +1003,7 @@ func (c *controller) reset(ctx context.Context, prompter string) error {
 	// Reset the session archive on disk.
 	c.logger.Infof("Resetting ancestor")
 	archive := &core.Archive{}
-	if err := encoding.MarshalAndSaveProtobuf(c.archivePath, archive); err != nil {
+	if err := newArchiveStore(c.archivePath).save(archive); err != nil {
 		return fmt.Errorf("unable to clear session history: %w", err)
 	}
 
@@ -705,6 +1057,18 @@ func (c *controller) run(ctx context.Context, alpha, beta Endpoint) {
 		close(c.done)
 	}()
 
+	// Compute the effective watchdog timeout.
+	watchdogTimeout := time.Duration(c.session.Configuration.WatchdogTimeout) * time.Second
+	if watchdogTimeout == 0 {
+		watchdogTimeout = time.Duration(c.session.Version.DefaultWatchdogTimeout()) * time.Second
+	}
+
+	// Compute the effective agent termination reconnect delay.
+	agentTerminationReconnectDelay := time.Duration(c.session.Configuration.AgentTerminationReconnectDelay) * time.Millisecond
+	if agentTerminationReconnectDelay == 0 {
+		agentTerminationReconnectDelay = time.Duration(c.session.Version.DefaultAgentTerminationReconnectDelay()) * time.Millisecond
+	}
+
 	// Track the last time that synchronization failed.
 	var lastSynchronizationFailureTime time.Time
 
@@ -720,7 +1084,8 @@ func (c *controller) run(ctx context.Context, alpha, beta Endpoint) {
 				c.stateLock.Lock()
 				c.state.Status = Status_ConnectingAlpha
 				c.stateLock.Unlock()
-				alpha, _ = connect(
+				var err error
+				alpha, err = connect(
 					ctx,
 					c.logger.Sublogger("alpha"),
 					c.session.Alpha,
@@ -730,6 +1095,10 @@ func (c *controller) run(ctx context.Context, alpha, beta Endpoint) {
 					c.mergedAlphaConfiguration,
 					true,
 				)
+				if err != nil && !isRetryableConnectError(err) {
+					c.haltOnConnectError(ctx, fmt.Errorf("unable to connect to alpha: %w", err))
+					return
+				}
 			}
 			c.stateLock.Lock()
 			c.state.AlphaState.Connected = (alpha != nil)
@@ -748,7 +1117,8 @@ func (c *controller) run(ctx context.Context, alpha, beta Endpoint) {
 				c.stateLock.Lock()
 				c.state.Status = Status_ConnectingBeta
 				c.stateLock.Unlock()
-				beta, _ = connect(
+				var err error
+				beta, err = connect(
 					ctx,
 					c.logger.Sublogger("beta"),
 					c.session.Beta,
@@ -758,6 +1128,10 @@ func (c *controller) run(ctx context.Context, alpha, beta Endpoint) {
 					c.mergedBetaConfiguration,
 					false,
 				)
+				if err != nil && !isRetryableConnectError(err) {
+					c.haltOnConnectError(ctx, fmt.Errorf("unable to connect to beta: %w", err))
+					return
+				}
 			}
 			c.stateLock.Lock()
 			c.state.BetaState.Connected = (beta != nil)
@@ -789,7 +1163,7 @@ func (c *controller) run(ctx context.Context, alpha, beta Endpoint) {
 
 		// Perform synchronization.
 		c.logger.Debug("Entering synchronization loop")
-		err := c.synchronize(ctx, alpha, beta)
+		err := c.synchronizeWithWatchdog(ctx, alpha, beta, watchdogTimeout)
 		c.logger.Debug("Synchronization loop terminated with error:", err)
 
 		// Indicate that the synchronization loop is no longer synchronizing.
@@ -814,13 +1188,16 @@ func (c *controller) run(ctx context.Context, alpha, beta Endpoint) {
 		}
 
 		// Otherwise, reset the synchronization state, but propagate the error
-		// that caused failure.
+		// that caused failure and whether or not it was caused by agent
+		// termination.
+		agentTerminated := errors.Is(err, agent.ErrAgentTerminated)
 		c.stateLock.Lock()
 		c.state = &State{
-			Session:    c.session,
-			LastError:  err.Error(),
-			AlphaState: &EndpointState{},
-			BetaState:  &EndpointState{},
+			Session:         c.session,
+			LastError:       err.Error(),
+			AgentTerminated: agentTerminated,
+			AlphaState:      &EndpointState{},
+			BetaState:       &EndpointState{},
 		}
 		c.stateLock.Unlock()
 
@@ -831,20 +1208,240 @@ func (c *controller) run(ctx context.Context, alpha, beta Endpoint) {
 		default:
 		}
 
-		// If less than one auto-reconnect interval has elapsed since the last
+		// If less than one reconnect interval has elapsed since the last
 		// synchronization failure, then wait before attempting reconnection.
+		// If the failure was caused by agent termination, then the remote is
+		// likely still reachable, so we use the (typically shorter) agent
+		// termination reconnect delay instead of the auto-reconnect interval.
+		reconnectInterval := autoReconnectInterval
+		if agentTerminated {
+			reconnectInterval = agentTerminationReconnectDelay
+		}
 		now := time.Now()
-		if now.Sub(lastSynchronizationFailureTime) < autoReconnectInterval {
+		if now.Sub(lastSynchronizationFailureTime) < reconnectInterval {
 			select {
 			case <-ctx.Done():
 				return
-			case <-time.After(autoReconnectInterval):
+			case <-time.After(reconnectInterval):
 			}
 		}
 		lastSynchronizationFailureTime = now
 	}
 }
 
+// haltOnConnectError surfaces a non-retryable connection error and then waits
+// for the session to be manually resumed (which will cancel the run loop).
+func (c *controller) haltOnConnectError(ctx context.Context, err error) {
+	// Log the failure.
+	c.logger.Error("Halting after non-retryable connection failure:", err)
+
+	// Surface the error.
+	c.stateLock.Lock()
+	c.state.Status = Status_Disconnected
+	c.state.LastError = err.Error()
+	c.stateLock.Unlock()
+
+	// Wait for cancellation.
+	<-ctx.Done()
+}
+
+// truncateProblems limits a list of problems to the specified maximum length
+// in order to avoid bloating session state. If truncation is necessary, then
+// the problems are sorted by path before truncation so that the retained subset
+// is deterministic. It returns the (potentially) truncated list and the number
+// of problems that were excluded. The input slice is not modified.
+func truncateProblems(problems []*core.Problem, maximum uint32) ([]*core.Problem, uint64) {
+	// If no truncation is necessary, then return the problems unmodified.
+	if uint64(len(problems)) <= uint64(maximum) {
+		return problems, 0
+	}
+
+	// Sort a copy of the problems and truncate it.
+	result := core.CopyProblems(problems)
+	core.SortProblems(result)
+	return result[:maximum], uint64(len(problems)) - uint64(maximum)
+}
+
+// supportsConcurrentOperations determines whether or not an endpoint with the
+// specified URL and (merged) configuration can serve concurrent operations.
+// Local endpoints always can, but remote endpoints require more than one
+// request stream, since operations hold their request stream until complete.
+func supportsConcurrentOperations(endpointURL *url.URL, configuration *Configuration, version Version) bool {
+	// Local endpoints support concurrent operations.
+	if endpointURL.Protocol == url.Protocol_Local {
+		return true
+	}
+
+	// Compute the effective stream concurrency for remote endpoints.
+	streamConcurrency := configuration.StreamConcurrency
+	if streamConcurrency == 0 {
+		streamConcurrency = version.DefaultStreamConcurrency()
+	}
+	return streamConcurrency > 1
+}
+
+// stage performs staging for the specified transitions on the destination
+// endpoint, using the source endpoint to supply content. The alpha parameter
+// indicates whether the destination is alpha (rather than beta) and is used for
+// logging and progress reporting. The destination content is the destination's
+// most recent scan content. Files whose content the destination already holds
+// (at any path) are generally staged by the destination from that content
+// without being transferred, but if the destination is unable to source such a
+// file locally (e.g. due to a rename detection size limit), then it's supplied
+// by the source like any other file. This method is safe for concurrent
+// invocation for different destinations, so long as both endpoints support
+// concurrent operations.
+func (c *controller) stage(ctx context.Context, alpha bool, destination, source Endpoint, transitions []*core.Change, destinationContent *core.Entry) error {
+	// Compute the staging dependencies. If there are none, then we're done.
+	paths, digests := core.TransitionDependencies(transitions)
+	if len(paths) == 0 {
+		return nil
+	}
+
+	// Determine the destination name for logging and error reporting.
+	name, capitalizedName := "beta", "Beta"
+	if alpha {
+		name, capitalizedName = "alpha", "Alpha"
+	}
+
+	// Identify files whose content is already held by the destination.
+	heldPaths := heldStagingPaths(paths, digests, heldDigests(destinationContent))
+	if len(heldPaths) > 0 {
+		c.logger.Debugf("%s already holds content for %d/%d files", capitalizedName, len(heldPaths), len(paths))
+	}
+
+	// Perform staging.
+	c.logger.Debugf("Staging %d file(s) on %s", len(paths), name)
+	for round := 0; ; round++ {
+		// Stage is allowed to modify its arguments, so we pass copies in case
+		// we need to invoke it again for deferred paths.
+		stagePaths := append([]string(nil), paths...)
+		stageDigests := append([][]byte(nil), digests...)
+		filteredPaths, signatures, receiver, deferred, err := destination.Stage(stagePaths, stageDigests)
+		if err != nil {
+			return fmt.Errorf("unable to begin staging on %s: %w", name, err)
+		}
+		if !filteredPathsAreSubset(filteredPaths, paths) {
+			return fmt.Errorf("%s returned incorrect subset of staging paths", name)
+		}
+		if deferred && len(filteredPaths) == 0 {
+			return fmt.Errorf("%s deferred staging without staging any paths", name)
+		}
+		if round == 0 && len(filteredPaths) < len(paths) && !deferred {
+			c.logger.Debugf("%s pre-staged %d/%d files", capitalizedName, len(paths)-len(filteredPaths), len(paths))
+		}
+		if unsourced := countHeldStagingPaths(filteredPaths, heldPaths); unsourced > 0 {
+			c.logger.Debugf("%s unable to source %d held file(s) locally, supplying them normally",
+				capitalizedName, unsourced,
+			)
+		}
+		if len(filteredPaths) > 0 {
+			monitor := func(state *rsync.ReceiverState) error {
+				c.heartbeat()
+				c.stateLock.Lock()
+				endpointState := c.state.BetaState
+				if alpha {
+					endpointState = c.state.AlphaState
+				}
+				if state == nil {
+					endpointState.StagingProgress = nil
+				} else {
+					if endpointState.StagingProgress == nil {
+						endpointState.StagingProgress = &rsync.ReceiverState{}
+					} else {
+						// Merging won't clear fields that have been reset
+						// to zero values (e.g. an unknown time remaining).
+						proto.Reset(endpointState.StagingProgress)
+					}
+					proto.Merge(endpointState.StagingProgress, state)
+				}
+				c.stateLock.Unlock()
+				return nil
+			}
+			receiver = rsync.NewMonitoringReceiver(receiver, filteredPaths, signatures, monitor)
+			receiver = rsync.NewPreemptableReceiver(ctx, receiver)
+			if err = source.Supply(filteredPaths, signatures, receiver); err != nil {
+				return fmt.Errorf("unable to stage files on %s: %w", name, err)
+			}
+		}
+		if !deferred {
+			break
+		}
+		c.logger.Debugf("%s deferred remaining files after staging %d file(s)", capitalizedName, len(filteredPaths))
+	}
+
+	// Success.
+	return nil
+}
+
+// heartbeat records that the current synchronization cycle has made progress.
+func (c *controller) heartbeat() {
+	c.progress.Store(time.Now().UnixNano())
+}
+
+// synchronizeWithWatchdog invokes synchronize while monitoring the progress of
+// its synchronization cycles. If a synchronization cycle goes without making
+// progress for longer than the specified timeout, then synchronization is
+// cancelled and an error is returned, allowing the run loop to reconnect and
+// restart synchronization. If synchronization fails to respond to cancellation
+// within watchdogShutdownGracePeriod, then both endpoints are shut down in
+// order to unblock any pending operations. A zero timeout disables the
+// watchdog.
+func (c *controller) synchronizeWithWatchdog(ctx context.Context, alpha, beta Endpoint, timeout time.Duration) error {
+	// If the watchdog is disabled, then just perform synchronization.
+	if timeout == 0 {
+		return c.synchronize(ctx, alpha, beta)
+	}
+
+	// Create a cancellable subcontext for synchronization and defer its
+	// cancellation.
+	synchronizeCtx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	// Start synchronization.
+	results := make(chan error, 1)
+	go func() {
+		results <- c.synchronize(synchronizeCtx, alpha, beta)
+	}()
+
+	// Periodically check for stalled synchronization cycles until
+	// synchronization terminates.
+	ticker := time.NewTicker(timeout / 4)
+	defer ticker.Stop()
+	for {
+		select {
+		case err := <-results:
+			return err
+		case <-ticker.C:
+		}
+
+		// Determine whether or not a synchronization cycle has stalled. If no
+		// cycle is in progress, then there's nothing to monitor.
+		last := c.progress.Load()
+		if last == 0 {
+			continue
+		}
+		stalled := time.Since(time.Unix(0, last))
+		if stalled < timeout {
+			continue
+		}
+
+		// Cancel synchronization and wait for it to terminate, shutting down
+		// the endpoints if it doesn't respond to cancellation.
+		c.logger.Warnf("Synchronization cycle made no progress for %v, restarting synchronization loop", stalled)
+		cancel()
+		select {
+		case <-results:
+		case <-time.After(watchdogShutdownGracePeriod):
+			c.logger.Warn("Synchronization loop unresponsive to cancellation, shutting down endpoints")
+			alpha.Shutdown()
+			beta.Shutdown()
+			<-results
+		}
+		return fmt.Errorf("synchronization loop restarted by watchdog after no progress for %v", stalled)
+	}
+}
+
 // synchronize is the main synchronization loop for the controller.
 func (c *controller) synchronize(ctx context.Context, alpha, beta Endpoint) error {
 	// Clear any error state upon restart of this function. If there was a
@@ -854,18 +1451,44 @@ func (c *controller) synchronize(ctx context.Context, alpha, beta Endpoint) erro
 	c.stateLock.Lock()
 	if c.state.LastError != "" {
 		c.state.LastError = ""
+		c.state.AgentTerminated = false
 		c.stateLock.Unlock()
 	} else {
 		c.stateLock.UnlockWithoutNotify()
 	}
 
+	// Record the clock offsets measured when connecting to the endpoints.
+	c.stateLock.Lock()
+	c.state.AlphaState.ClockOffset = int64(alpha.ClockOffset())
+	c.state.BetaState.ClockOffset = int64(beta.ClockOffset())
+	c.stateLock.Unlock()
+
+	// Release any held content and clear progress tracking when
+	// synchronization terminates.
+	defer func() {
+		c.progress.Store(0)
+		c.stateLock.Lock()
+		c.heldAlphaSnapshot = nil
+		c.heldBetaSnapshot = nil
+		c.heldAncestor = nil
+		c.stateLock.UnlockWithoutNotify()
+	}()
+
 	// Track whether or not a flush request triggered the synchronization loop.
-	var flushRequest chan error
+	var flush *flushRequest
 
-	// Load the archive and extract the ancestor. We enforce that the archive
+	// Track any fetch request that interrupted polling.
+	var fetch *fetchRequest
+
+	// Track whether or not the verification timer interrupted polling.
+	var verify bool
+
+	// Load the archive (replaying any changes recorded since it was last
+	// written in full) and extract the ancestor. We enforce that the archive
 	// contains only synchronizable content.
-	archive := &core.Archive{}
-	if err := encoding.LoadAndUnmarshalProtobuf(c.archivePath, archive); err != nil {
+	archives := newArchiveStore(c.archivePath)
+	archive, err := archives.load()
+	if err != nil {
 		return fmt.Errorf("unable to load archive: %w", err)
 	} else if err = archive.EnsureValid(true); err != nil {
 		return fmt.Errorf("invalid archive found on disk: %w", err)
@@ -878,18 +1501,83 @@ func (c *controller) synchronize(ctx context.Context, alpha, beta Endpoint) erro
 		synchronizationMode = c.session.Version.DefaultSynchronizationMode()
 	}
 
+	// Determine whether or not the synchronization mode is unidirectional.
+	unidirectional := synchronizationMode == core.SynchronizationMode_SynchronizationModeOneWaySafe ||
+		synchronizationMode == core.SynchronizationMode_SynchronizationModeOneWayReplica
+
+	// Compute the effective type change mode.
+	typeChangeMode := c.session.Configuration.TypeChangeMode
+	if typeChangeMode.IsDefault() {
+		typeChangeMode = c.session.Version.DefaultTypeChangeMode()
+	}
+
+	// Compute the effective maximum conflict count.
+	maximumConflictCount := c.session.Configuration.MaximumConflictCount
+	if maximumConflictCount == 0 {
+		maximumConflictCount = c.session.Version.DefaultMaximumConflictCount()
+	}
+
+	// Compute the effective maximum conflict persistence.
+	maximumConflictPersistence := c.session.Configuration.MaximumConflictPersistence
+	if maximumConflictPersistence == 0 {
+		maximumConflictPersistence = c.session.Version.DefaultMaximumConflictPersistence()
+	}
+
+	// Compute the effective capability mismatch mode.
+	capabilityMismatchMode := c.session.Configuration.CapabilityMismatchMode
+	if capabilityMismatchMode.IsDefault() {
+		capabilityMismatchMode = c.session.Version.DefaultCapabilityMismatchMode()
+	}
+
+	// Compute the effective configuration incompatibility mode.
+	configurationIncompatibilityMode := c.session.Configuration.ConfigurationIncompatibilityMode
+	if configurationIncompatibilityMode.IsDefault() {
+		configurationIncompatibilityMode = c.session.Version.DefaultConfigurationIncompatibilityMode()
+	}
+
 	// Compute the effective ignore syntax.
 	ignoreSyntax := c.session.Configuration.IgnoreSyntax
 	if ignoreSyntax.IsDefault() {
 		ignoreSyntax = c.session.Version.DefaultIgnoreSyntax()
 	}
 
+	// Compute the effective phantom directory mode.
+	phantomDirectoryMode := c.session.Configuration.PhantomDirectoryMode
+	if phantomDirectoryMode.IsDefault() {
+		phantomDirectoryMode = c.session.Version.DefaultPhantomDirectoryMode()
+	}
+
 	// Compute the effective permissions mode.
 	permissionsMode := c.session.Configuration.PermissionsMode
 	if permissionsMode.IsDefault() {
 		permissionsMode = c.session.Version.DefaultPermissionsMode()
 	}
 
+	// Compute the effective maximum beta scan deferral. When beta scanning is
+	// deferred, beta reports its last scanned contents (updated to reflect the
+	// changes that we've applied to it) rather than re-scanning, which allows
+	// cycles to proceed at the pace of alpha scanning if beta is slow to scan.
+	// We only allow this in unidirectional synchronization modes, where beta
+	// modifications are never propagated and thus a stale view of beta can't
+	// affect alpha. The correctness bounds are as follows: Modifications made
+	// to beta by means other than synchronization will go unobserved for at
+	// most maximumBetaScanDeferral cycles (or until beta triggers a cycle via
+	// polling, whichever comes first). Unobserved modifications can't be
+	// overwritten or removed, because Transition verifies that the on-disk
+	// contents match its expectations just-in-time, and any resulting
+	// transition problems (or missing staged files) force a regular beta scan
+	// on the next cycle. Flush requests and scan retries also force a regular
+	// beta scan, so flush guarantees are unaffected. We disable deferral with
+	// Docker-style ignores because phantom directory reification requires
+	// contents that were scanned at the same time.
+	maximumBetaScanDeferral := c.session.Configuration.MaximumBetaScanDeferral
+	if maximumBetaScanDeferral == 0 {
+		maximumBetaScanDeferral = c.session.Version.DefaultMaximumBetaScanDeferral()
+	}
+	if !unidirectional || ignoreSyntax == ignore.Syntax_SyntaxDocker {
+		maximumBetaScanDeferral = 0
+	}
+
 	// Compute, on a per-endpoint basis, whether or not polling should be
 	// disabled.
 	αWatchMode := c.mergedAlphaConfiguration.WatchMode
@@ -903,24 +1591,130 @@ func (c *controller) synchronize(ctx context.Context, alpha, beta Endpoint) erro
 	αDisablePolling := (αWatchMode == WatchMode_WatchModeNoWatch)
 	βDisablePolling := (βWatchMode == WatchMode_WatchModeNoWatch)
 
+	// Determine whether or not staging should be performed concurrently on both
+	// endpoints. Even if concurrent staging is requested, we only enable it if
+	// both endpoints can serve concurrent operations, since each endpoint will
+	// need to stage and supply files at the same time.
+	stagingConcurrencyMode := c.session.Configuration.StagingConcurrencyMode
+	if stagingConcurrencyMode.IsDefault() {
+		stagingConcurrencyMode = c.session.Version.DefaultStagingConcurrencyMode()
+	}
+	concurrentStaging := stagingConcurrencyMode == StagingConcurrencyMode_StagingConcurrencyModeConcurrent
+	if concurrentStaging {
+		αConcurrent := supportsConcurrentOperations(c.session.Alpha, c.mergedAlphaConfiguration, c.session.Version)
+		βConcurrent := supportsConcurrentOperations(c.session.Beta, c.mergedBetaConfiguration, c.session.Version)
+		if !αConcurrent || !βConcurrent {
+			c.logger.Debug("Endpoints unable to support concurrent staging, staging will be sequential")
+			concurrentStaging = false
+		}
+	}
+
+	// Compute, on a per-endpoint basis, whether or not oversized files should
+	// halt synchronization.
+	αOversizedFileMode := c.mergedAlphaConfiguration.OversizedFileMode
+	βOversizedFileMode := c.mergedBetaConfiguration.OversizedFileMode
+	if αOversizedFileMode.IsDefault() {
+		αOversizedFileMode = c.session.Version.DefaultOversizedFileMode()
+	}
+	if βOversizedFileMode.IsDefault() {
+		βOversizedFileMode = c.session.Version.DefaultOversizedFileMode()
+	}
+	αHaltOnOversizedFiles := (αOversizedFileMode == OversizedFileMode_OversizedFileModeHalt)
+	βHaltOnOversizedFiles := (βOversizedFileMode == OversizedFileMode_OversizedFileModeHalt)
+
+	// Determine the initial synchronization mode.
+	initialSynchronizationMode := c.session.Configuration.InitialSynchronizationMode
+	if initialSynchronizationMode.IsDefault() {
+		initialSynchronizationMode = c.session.Version.DefaultInitialSynchronizationMode()
+	}
+	forceInitialSynchronization := initialSynchronizationMode == InitialSynchronizationMode_InitialSynchronizationModeForce
+
+	// Compute the effective verification mode and interval.
+	verificationMode := c.session.Configuration.VerificationMode
+	if verificationMode.IsDefault() {
+		verificationMode = c.session.Version.DefaultVerificationMode()
+	}
+	verificationInterval := time.Duration(c.session.Configuration.VerificationInterval) * time.Second
+	if verificationInterval == 0 {
+		verificationInterval = time.Duration(c.session.Version.DefaultVerificationInterval()) * time.Second
+	}
+
+	// Set up the periodic verification timer, if any. If periodic verification
+	// is disabled, then the timer channel remains nil and never fires.
+	verificationClock := c.verificationClock
+	if verificationClock == nil {
+		verificationClock = time.After
+	}
+	var verificationTimer <-chan time.Time
+	if verificationMode == VerificationMode_VerificationModePeriodic {
+		verificationTimer = verificationClock(verificationInterval)
+	}
+
+	// Compute the effective maximum number of problems to report for each
+	// endpoint.
+	maximumReportedProblems := c.session.Configuration.MaximumReportedProblems
+	if maximumReportedProblems == 0 {
+		maximumReportedProblems = c.session.Version.DefaultMaximumReportedProblems()
+	}
+
+	// Compute the effective maximum number of reconciliation recordings to
+	// retain. If recording is enabled, then compute the recording directory.
+	maximumReconciliationRecordings := c.session.Configuration.MaximumReconciliationRecordings
+	if maximumReconciliationRecordings == 0 {
+		maximumReconciliationRecordings = c.session.Version.DefaultMaximumReconciliationRecordings()
+	}
+	var reconciliationRecordingDirectory string
+	if maximumReconciliationRecordings > 0 {
+		if d, err := pathForReconciliationRecordings(c.session.Identifier); err != nil {
+			c.logger.Warn("Unable to compute reconciliation recording directory:", err)
+		} else {
+			reconciliationRecordingDirectory = d
+		}
+	}
+
+	// Compute the effective maximum entry counts for each endpoint.
+	αMaximumEntryCount := c.mergedAlphaConfiguration.MaximumEntryCount
+	if αMaximumEntryCount == 0 {
+		αMaximumEntryCount = c.session.Version.DefaultMaximumEntryCount()
+	}
+	βMaximumEntryCount := c.mergedBetaConfiguration.MaximumEntryCount
+	if βMaximumEntryCount == 0 {
+		βMaximumEntryCount = c.session.Version.DefaultMaximumEntryCount()
+	}
+
 	// Create a switch that will allow us to skip polling and force a
 	// synchronization cycle. On startup, we enable this switch and skip polling
 	// to immediately force a check for changes that may have occurred while the
-	// synchronization loop wasn't running. The only time we don't force this
-	// check on startup is when both endpoints have polling disabled, which is
-	// an indication that the session should operate in a fully manual mode.
-	skipPolling := (!αDisablePolling || !βDisablePolling)
+	// synchronization loop wasn't running. In automatic mode, the only time we
+	// don't force this check on startup is when both endpoints have polling
+	// disabled, which is an indication that the session should operate in a
+	// fully manual mode. In force mode, we always perform this check.
+	skipPolling := forceInitialSynchronization || !αDisablePolling || !βDisablePolling
 
 	// Create variables to track our reasons for skipping polling.
 	var skippingPollingDueToScanError, skippingPollingDueToMissingFiles bool
 
+	// Create a map to track the number of consecutive synchronization cycles in
+	// which each conflict (keyed by its identifier) has been observed. This
+	// tracking is reset whenever the synchronization loop is restarted, which
+	// includes resuming the session after a halt.
+	var conflictPersistence map[string]uint32
+
+	// Track the number of consecutive synchronization cycles in which beta's
+	// scan has been deferred, as well as whether or not the next cycle requires
+	// a regular beta scan. The first cycle always requires a regular scan.
+	var βScanDeferrals uint32
+	βScanRequired := true
+
 	// Loop until there is a synchronization error.
 	for {
 		// Unless we've been requested to skip polling, wait for a dirty state
 		// while monitoring for cancellation. If we've been requested to skip
 		// polling, it should only be for one iteration.
 		if !skipPolling {
-			// Update status to watching.
+			// Update status to watching. No synchronization cycle is in
+			// progress while polling, so clear progress tracking.
+			c.progress.Store(0)
 			c.stateLock.Lock()
 			c.state.Status = Status_Watching
 			c.stateLock.Unlock()
@@ -976,8 +1770,9 @@ func (c *controller) synchronize(ctx context.Context, alpha, beta Endpoint) erro
 			}()
 
 			// Wait for either poll to return an event or an error, for a flush
-			// request, or for cancellation. In any of these cases, cancel
-			// polling and ensure that both polling operations have completed.
+			// or fetch request, for the verification timer, or for
+			// cancellation. In any of these cases, cancel polling and ensure
+			// that both polling operations have completed.
 			var αPollErr, βPollErr error
 			cancelled := false
 			select {
@@ -987,16 +1782,31 @@ func (c *controller) synchronize(ctx context.Context, alpha, beta Endpoint) erro
 				βPollErr = <-βPollResults
 			case βPollErr = <-βPollResults:
 				c.logger.Debug("Triggered by beta endpoint")
+				βScanRequired = true
 				pollCancel()
 				αPollErr = <-αPollResults
-			case flushRequest = <-c.flushRequests:
-				if cap(flushRequest) < 1 {
+			case flush = <-c.flushRequests:
+				if cap(flush.result) < 1 {
 					panic("unbuffered flush request")
 				}
 				c.logger.Debug("Triggered by flush request")
 				pollCancel()
 				αPollErr = <-αPollResults
 				βPollErr = <-βPollResults
+			case fetch = <-c.fetchRequests:
+				if cap(fetch.result) < 1 {
+					panic("unbuffered fetch request")
+				}
+				c.logger.Debug("Interrupted by fetch request")
+				pollCancel()
+				αPollErr = <-αPollResults
+				βPollErr = <-βPollResults
+			case <-verificationTimer:
+				c.logger.Debug("Interrupted by verification timer")
+				verify = true
+				pollCancel()
+				αPollErr = <-αPollResults
+				βPollErr = <-βPollResults
 			case <-ctx.Done():
 				cancelled = true
 				pollCancel()
@@ -1012,30 +1822,95 @@ func (c *controller) synchronize(ctx context.Context, alpha, beta Endpoint) erro
 			} else if βPollErr != nil {
 				return fmt.Errorf("beta polling error: %w", βPollErr)
 			}
+
+			// If polling was interrupted by a fetch request, then service the
+			// request and resume polling. Fetching doesn't modify either
+			// endpoint, so there's no need for a synchronization cycle. Any
+			// failure to fetch the file itself is reported only to the
+			// requester, but a failure of the supply operation indicates that
+			// the endpoint has failed.
+			if fetch != nil {
+				endpoint, name := alpha, "alpha"
+				if fetch.beta {
+					endpoint, name = beta, "beta"
+				}
+				content, err, endpointErr := fetchFromEndpoint(endpoint, fetch.path)
+				if endpointErr != nil {
+					fetch.result <- fmt.Errorf("unable to fetch from %s: %w", name, endpointErr)
+					return fmt.Errorf("%s supply error: %w", name, endpointErr)
+				}
+				fetch.content = content
+				fetch.result <- err
+				fetch = nil
+				continue
+			}
+
+			// If polling was interrupted by the verification timer, then
+			// perform a verification pass, re-arm the timer, and resume
+			// polling. Verification doesn't modify either endpoint, so there's
+			// no need for a synchronization cycle. Verification failures
+			// (e.g. due to concurrent modifications) aren't fatal, since any
+			// underlying endpoint failure will be detected by polling, so we
+			// just log them and wait for the next verification pass.
+			if verify {
+				verify = false
+				if err := c.verifyEndpoints(ctx, alpha, beta, ancestor); err != nil {
+					if ctx.Err() != nil {
+						return errors.New("cancelled during verification")
+					}
+					c.logger.Warn("Background verification failed:", err)
+				}
+				verificationTimer = verificationClock(verificationInterval)
+				continue
+			}
 		} else {
 			c.logger.Debug("Skipping polling")
 			skipPolling = false
 		}
 
-		// Scan both endpoints in parallel and check for errors. If a flush
-		// request is present, then force both endpoints to perform a full
-		// (warm) re-scan rather than using acceleration.
+		// Scan both endpoints in parallel and check for errors. If an unscoped
+		// flush request is present, then force both endpoints to perform a
+		// full (warm) re-scan rather than using acceleration. If a scoped flush
+		// request is present, then we still allow acceleration, but require
+		// that both endpoints fully re-check the scoped subtrees.
+		//
+		// The correctness of a scoped flush cycle is the same as that of any
+		// accelerated cycle: reconciliation and transitioning still operate on
+		// complete snapshots, so content outside of the scope is never treated
+		// as deleted, it's merely represented by the endpoint's last-known
+		// state (plus any changes detected by watching). The only difference
+		// from an unscoped flush is the guarantee provided upon completion: a
+		// scoped flush guarantees only that modifications made within the
+		// scoped subtrees before the flush request are propagated, whereas an
+		// unscoped flush guarantees this for the entire synchronization root.
+		//
+		// Flush requests always require a regular beta scan. Otherwise, beta's
+		// scan may be deferred if permitted (see maximumBetaScanDeferral).
 		c.logger.Debug("Scanning endpoints")
+		c.heartbeat()
 		c.stateLock.Lock()
 		c.state.Status = Status_Scanning
 		c.stateLock.Unlock()
-		forceFullScan := flushRequest != nil
+		forceFullScan := flush != nil && len(flush.scope) == 0
+		var scanScope []string
+		if flush != nil {
+			scanScope = flush.scope
+		}
+		deferβScan := flush == nil && !βScanRequired && βScanDeferrals < maximumBetaScanDeferral
+		if deferβScan {
+			c.logger.Debug("Deferring beta scan")
+		}
 		var αSnapshot, βSnapshot *core.Snapshot
 		var αScanErr, βScanErr error
 		var αTryAgain, βTryAgain bool
 		scanDone := &sync.WaitGroup{}
 		scanDone.Add(2)
 		go func() {
-			αSnapshot, αScanErr, αTryAgain = alpha.Scan(ctx, ancestor, forceFullScan)
+			αSnapshot, αScanErr, αTryAgain = alpha.Scan(ctx, ancestor, forceFullScan, scanScope, false)
 			scanDone.Done()
 		}()
 		go func() {
-			βSnapshot, βScanErr, βTryAgain = beta.Scan(ctx, ancestor, forceFullScan)
+			βSnapshot, βScanErr, βTryAgain = beta.Scan(ctx, ancestor, forceFullScan, scanScope, deferβScan)
 			scanDone.Done()
 		}()
 		scanDone.Wait()
@@ -1094,15 +1969,25 @@ func (c *controller) synchronize(ctx context.Context, alpha, beta Endpoint) erro
 				case <-ctx.Done():
 					return errors.New("cancelled during rescan wait")
 				}
+				c.heartbeat()
 			}
 
 			// Retry.
 			skipPolling = true
 			skippingPollingDueToScanError = true
+			βScanRequired = true
 			continue
 		}
 		skippingPollingDueToScanError = false
 
+		// Update beta scan deferral tracking.
+		if deferβScan {
+			βScanDeferrals++
+		} else {
+			βScanDeferrals = 0
+			βScanRequired = false
+		}
+
 		// Extract contents.
 		αContent := αSnapshot.Content
 		βContent := βSnapshot.Content
@@ -1120,35 +2005,111 @@ func (c *controller) synchronize(ctx context.Context, alpha, beta Endpoint) erro
 		βDirectoryCount := βSnapshot.Directories
 		if ignoreSyntax == ignore.Syntax_SyntaxDocker {
 			αContent, βContent, αDirectoryCount, βDirectoryCount = core.ReifyPhantomDirectories(
-				ancestor, αContent, βContent,
+				ancestor, αContent, βContent, phantomDirectoryMode,
 			)
 		}
 
+		// Determine whether or not the endpoint filesystems have differing
+		// capabilities.
+		mismatches := capabilityMismatches(αSnapshot, βSnapshot)
+		for _, mismatch := range mismatches {
+			c.logger.Debugf("Filesystem capability mismatch: %s (alpha: %t, beta: %t)",
+				mismatch.Capability, mismatch.Alpha, mismatch.Beta,
+			)
+		}
+
+		// Determine whether or not the effective endpoint configurations are
+		// compatible with the probed endpoint filesystem capabilities.
+		αIncompatibilities := configurationIncompatibilities(c.mergedAlphaConfiguration, permissionsMode, αSnapshot)
+		βIncompatibilities := configurationIncompatibilities(c.mergedBetaConfiguration, permissionsMode, βSnapshot)
+		for _, incompatibility := range αIncompatibilities {
+			c.logger.Debug("Alpha configuration incompatibility:", incompatibility)
+		}
+		for _, incompatibility := range βIncompatibilities {
+			c.logger.Debug("Beta configuration incompatibility:", incompatibility)
+		}
+
+		// Determine whether or not either endpoint's entry count is
+		// approaching its maximum allowed entry count. We use the same count
+		// that the endpoints use to enforce the maximum.
+		var warnings []string
+		if warning := entryCountWarning("Alpha", αContent.Count(), αMaximumEntryCount); warning != "" {
+			c.logger.Warn(warning)
+			warnings = append(warnings, warning)
+		}
+		if warning := entryCountWarning("Beta", βContent.Count(), βMaximumEntryCount); warning != "" {
+			c.logger.Warn(warning)
+			warnings = append(warnings, warning)
+		}
+
 		// Now that we've had a successful scan, clear the last error (if any),
-		// record scan statistics and problems (if any), and update the status
-		// to reconciling.
+		// record scan statistics, problems, and capability mismatches (if
+		// any), and update the status to reconciling.
 		//
 		// We know that it's okay to clear the error here (if there is one)
 		// because we know that it originated from scan (since all other errors
 		// are terminal and any previous terminal error would have been cleared
 		// at the start of this function).
+		c.heartbeat()
 		c.stateLock.Lock()
 		c.state.LastError = ""
+		c.state.AgentTerminated = false
 		c.state.AlphaState.Scanned = true
 		c.state.AlphaState.Directories = αDirectoryCount
 		c.state.AlphaState.Files = αSnapshot.Files
 		c.state.AlphaState.SymbolicLinks = αSnapshot.SymbolicLinks
 		c.state.AlphaState.TotalFileSize = αSnapshot.TotalFileSize
-		c.state.AlphaState.ScanProblems = αContent.Problems()
+		c.state.AlphaState.HashedBytes = αSnapshot.HashedBytes
+		c.state.AlphaState.HashingDuration = αSnapshot.HashingDuration
+		c.state.AlphaState.ScanProblems, c.state.AlphaState.ExcludedScanProblems =
+			truncateProblems(αContent.Problems(), maximumReportedProblems)
+		c.state.AlphaState.WatchOverflows = alpha.WatchOverflows()
+		c.state.AlphaState.AccelerationUnavailable = alpha.AccelerationUnavailable()
+		c.state.AlphaState.ConfigurationIncompatibilities = αIncompatibilities
 		c.state.BetaState.Scanned = true
 		c.state.BetaState.Directories = βDirectoryCount
 		c.state.BetaState.Files = βSnapshot.Files
 		c.state.BetaState.SymbolicLinks = βSnapshot.SymbolicLinks
 		c.state.BetaState.TotalFileSize = βSnapshot.TotalFileSize
-		c.state.BetaState.ScanProblems = βContent.Problems()
+		c.state.BetaState.HashedBytes = βSnapshot.HashedBytes
+		c.state.BetaState.HashingDuration = βSnapshot.HashingDuration
+		c.state.BetaState.ScanProblems, c.state.BetaState.ExcludedScanProblems =
+			truncateProblems(βContent.Problems(), maximumReportedProblems)
+		c.state.BetaState.WatchOverflows = beta.WatchOverflows()
+		c.state.BetaState.AccelerationUnavailable = beta.AccelerationUnavailable()
+		c.state.BetaState.ConfigurationIncompatibilities = βIncompatibilities
+		c.state.CapabilityMismatches = mismatches
+		c.state.Warnings = warnings
 		c.state.Status = Status_Reconciling
+		c.heldAlphaSnapshot = αSnapshot
+		c.heldBetaSnapshot = βSnapshot
+		c.heldAncestor = ancestor
 		c.stateLock.Unlock()
 
+		// If the endpoint filesystems have differing capabilities and we've
+		// been configured to treat this as unacceptable, then switch to a
+		// halted state (leaving the mismatches visible) and wait for the user
+		// to reconfigure the session or endpoints and resume the session.
+		if len(mismatches) > 0 && capabilityMismatchMode == CapabilityMismatchMode_CapabilityMismatchModeHalt {
+			c.stateLock.Lock()
+			c.state.Status = Status_HaltedOnCapabilityMismatch
+			c.stateLock.Unlock()
+			return errHaltedForSafety
+		}
+
+		// If either endpoint's configuration is incompatible with its
+		// filesystem's capabilities and we've been configured to treat this as
+		// unacceptable, then switch to a halted state (leaving the
+		// incompatibilities visible) before attempting any transitions and wait
+		// for the user to reconfigure the session and resume it.
+		if (len(αIncompatibilities) > 0 || len(βIncompatibilities) > 0) &&
+			configurationIncompatibilityMode == ConfigurationIncompatibilityMode_ConfigurationIncompatibilityModeHalt {
+			c.stateLock.Lock()
+			c.state.Status = Status_HaltedOnConfigurationIncompatibility
+			c.stateLock.Unlock()
+			return errHaltedForSafety
+		}
+
 		// If we're propagating executability bits and one endpoint preserves
 		// executability information while the the other does not, then
 		// propagate executability information from the preserving side to the
@@ -1162,8 +2123,17 @@ func (c *controller) synchronize(ctx context.Context, alpha, beta Endpoint) erro
 				c.logger.Debug("Propagating alpha executability to beta")
 				βContent = core.PropagateExecutability(ancestor, αContent, βContent)
 			} else if βSnapshot.PreservesExecutability && αContent != nil && !αSnapshot.PreservesExecutability {
-				c.logger.Debug("Propagating beta executability to alpha")
-				αContent = core.PropagateExecutability(ancestor, βContent, αContent)
+				// In unidirectional modes, alpha is authoritative, so we only
+				// propagate executability to alpha from the ancestor. Using
+				// beta as a source would cause executability drift on beta to
+				// be adopted by alpha instead of being reverted.
+				if unidirectional {
+					c.logger.Debug("Propagating ancestor executability to alpha")
+					αContent = core.PropagateExecutability(ancestor, nil, αContent)
+				} else {
+					c.logger.Debug("Propagating beta executability to alpha")
+					αContent = core.PropagateExecutability(ancestor, βContent, αContent)
+				}
 			}
 		}
 
@@ -1181,14 +2151,52 @@ func (c *controller) synchronize(ctx context.Context, alpha, beta Endpoint) erro
 			return errHaltedForSafety
 		}
 
-		// Perform reconciliation.
+		// If one endpoint ignores case, then mark content on the other endpoint
+		// whose names differ only in case, since that content would collide if
+		// propagated. Reconciliation will report the marked paths as conflicts.
+		if αSnapshot.IgnoresCase && βContent != nil {
+			βContent = core.MarkCaseCollisions(βContent)
+		}
+		if βSnapshot.IgnoresCase && αContent != nil {
+			αContent = core.MarkCaseCollisions(αContent)
+		}
+
+		// Perform reconciliation. If recording is enabled, then we record the
+		// operation's inputs and outputs, but we don't treat a failure to save
+		// the recording as fatal since it's purely a debugging aid.
 		c.logger.Debug("Performing reconciliation")
-		ancestorChanges, αTransitions, βTransitions, conflicts := core.Reconcile(
-			ancestor,
-			αContent,
-			βContent,
-			synchronizationMode,
-		)
+		var ancestorChanges, αTransitions, βTransitions []*core.Change
+		var conflicts []*core.Conflict
+		if reconciliationRecordingDirectory != "" {
+			recording := core.NewReconciliationRecording(
+				ancestor,
+				αContent,
+				βContent,
+				synchronizationMode,
+				typeChangeMode,
+				alpha.ClockOffset(), beta.ClockOffset(),
+				nil,
+			)
+			ancestorChanges, αTransitions, βTransitions, conflicts = recording.AncestorChanges,
+				recording.AlphaChanges, recording.BetaChanges, recording.Conflicts
+			if path, err := saveReconciliationRecording(
+				reconciliationRecordingDirectory, recording, maximumReconciliationRecordings,
+			); err != nil {
+				c.logger.Warn("Unable to save reconciliation recording:", err)
+			} else {
+				c.logger.Debug("Saved reconciliation recording to", path)
+			}
+		} else {
+			ancestorChanges, αTransitions, βTransitions, conflicts = core.Reconcile(
+				ancestor,
+				αContent,
+				βContent,
+				synchronizationMode,
+				typeChangeMode,
+				alpha.ClockOffset(), beta.ClockOffset(),
+				nil,
+			)
+		}
 		if c.logger.Level() >= logging.LevelTrace {
 			for _, change := range ancestorChanges {
 				c.logger.Tracef("Ancestor change at \"%s\" to %s",
@@ -1222,6 +2230,50 @@ func (c *controller) synchronize(ctx context.Context, alpha, beta Endpoint) erro
 		c.state.Conflicts = conflicts
 		c.stateLock.Unlock()
 
+		// Check if the number of conflicts exceeds the configured threshold.
+		// A flood of conflicts usually indicates that something has gone wrong
+		// (e.g. a botched merge or checkout on one endpoint), so we switch to a
+		// halted state (leaving the conflicts visible) and wait for the user to
+		// resolve the conflicts and resume the session.
+		if uint64(len(conflicts)) > maximumConflictCount {
+			c.stateLock.Lock()
+			c.state.Status = Status_HaltedOnConflictThreshold
+			c.stateLock.Unlock()
+			return errHaltedForSafety
+		}
+
+		// Check if any conflict has persisted unchanged for longer than the
+		// configured number of cycles. A conflict that isn't being resolved
+		// means that its path isn't being synchronized, so we switch to a
+		// halted state (leaving the conflicts visible) and wait for the user to
+		// resolve the conflict and resume the session. Conflicts that change
+		// (or disappear) between cycles have their tracking reset.
+		if maximumConflictPersistence != 0 {
+			persistence := make(map[string]uint32, len(conflicts))
+			var persistent bool
+			for _, conflict := range conflicts {
+				identifier, err := conflict.Identifier()
+				if err != nil {
+					return fmt.Errorf("unable to compute conflict identifier: %w", err)
+				}
+				cycles := conflictPersistence[identifier] + 1
+				persistence[identifier] = cycles
+				if cycles > maximumConflictPersistence {
+					c.logger.Debugf("Conflict rooted at \"%s\" persisted for %d cycles",
+						formatPathForLogging(conflict.Root), cycles,
+					)
+					persistent = true
+				}
+			}
+			conflictPersistence = persistence
+			if persistent {
+				c.stateLock.Lock()
+				c.state.Status = Status_HaltedOnPersistentConflict
+				c.stateLock.Unlock()
+				return errHaltedForSafety
+			}
+		}
+
 		// Check if a root deletion operation is being propagated. This can be
 		// intentional, accidental, or an indication of a non-persistent
 		// filesystem (such as a container filesystem). In any case, we switch
@@ -1246,79 +2298,43 @@ func (c *controller) synchronize(ctx context.Context, alpha, beta Endpoint) erro
 			return errHaltedForSafety
 		}
 
-		// Stage files on alpha.
-		c.stateLock.Lock()
-		c.state.Status = Status_StagingAlpha
-		c.stateLock.Unlock()
-		if paths, digests := core.TransitionDependencies(αTransitions); len(paths) > 0 {
-			c.logger.Debugf("Staging %d file(s) on alpha", len(paths))
-			filteredPaths, signatures, receiver, err := alpha.Stage(paths, digests)
-			if err != nil {
-				return fmt.Errorf("unable to begin staging on alpha: %w", err)
-			}
-			if !filteredPathsAreSubset(filteredPaths, paths) {
-				return errors.New("alpha returned incorrect subset of staging paths")
-			}
-			if len(filteredPaths) < len(paths) {
-				c.logger.Debugf("Alpha pre-staged %d/%d files", len(paths)-len(filteredPaths), len(paths))
-			}
-			if len(filteredPaths) > 0 {
-				monitor := func(state *rsync.ReceiverState) error {
-					c.stateLock.Lock()
-					if state == nil {
-						c.state.AlphaState.StagingProgress = nil
-					} else {
-						if c.state.AlphaState.StagingProgress == nil {
-							c.state.AlphaState.StagingProgress = &rsync.ReceiverState{}
-						}
-						proto.Merge(c.state.AlphaState.StagingProgress, state)
-					}
-					c.stateLock.Unlock()
-					return nil
-				}
-				receiver = rsync.NewMonitoringReceiver(receiver, filteredPaths, signatures, monitor)
-				receiver = rsync.NewPreemptableReceiver(ctx, receiver)
-				if err = beta.Supply(filteredPaths, signatures, receiver); err != nil {
-					return fmt.Errorf("unable to stage files on alpha: %w", err)
-				}
-			}
-		}
-
-		// Stage files on beta.
-		c.stateLock.Lock()
-		c.state.Status = Status_StagingBeta
-		c.stateLock.Unlock()
-		if paths, digests := core.TransitionDependencies(βTransitions); len(paths) > 0 {
-			c.logger.Debugf("Staging %d file(s) on beta", len(paths))
-			filteredPaths, signatures, receiver, err := beta.Stage(paths, digests)
-			if err != nil {
-				return fmt.Errorf("unable to begin staging on beta: %w", err)
-			}
-			if !filteredPathsAreSubset(filteredPaths, paths) {
-				return errors.New("beta returned incorrect subset of staging paths")
+		// Stage files on both endpoints. If concurrent staging is enabled, then
+		// alpha and beta are staged in parallel, with each side reporting its
+		// own progress. Otherwise, alpha is staged before beta.
+		c.heartbeat()
+		if concurrentStaging {
+			c.stateLock.Lock()
+			c.state.Status = Status_Staging
+			c.stateLock.Unlock()
+			var αStagingErr, βStagingErr error
+			stagingDone := &sync.WaitGroup{}
+			stagingDone.Add(2)
+			go func() {
+				αStagingErr = c.stage(ctx, true, alpha, beta, αTransitions, αContent)
+				stagingDone.Done()
+			}()
+			go func() {
+				βStagingErr = c.stage(ctx, false, beta, alpha, βTransitions, βContent)
+				stagingDone.Done()
+			}()
+			stagingDone.Wait()
+			if αStagingErr != nil {
+				return αStagingErr
+			} else if βStagingErr != nil {
+				return βStagingErr
 			}
-			if len(filteredPaths) < len(paths) {
-				c.logger.Debugf("Beta pre-staged %d/%d files", len(paths)-len(filteredPaths), len(paths))
+		} else {
+			c.stateLock.Lock()
+			c.state.Status = Status_StagingAlpha
+			c.stateLock.Unlock()
+			if err := c.stage(ctx, true, alpha, beta, αTransitions, αContent); err != nil {
+				return err
 			}
-			if len(filteredPaths) > 0 {
-				monitor := func(state *rsync.ReceiverState) error {
-					c.stateLock.Lock()
-					if state == nil {
-						c.state.BetaState.StagingProgress = nil
-					} else {
-						if c.state.BetaState.StagingProgress == nil {
-							c.state.BetaState.StagingProgress = &rsync.ReceiverState{}
-						}
-						proto.Merge(c.state.BetaState.StagingProgress, state)
-					}
-					c.stateLock.Unlock()
-					return nil
-				}
-				receiver = rsync.NewMonitoringReceiver(receiver, filteredPaths, signatures, monitor)
-				receiver = rsync.NewPreemptableReceiver(ctx, receiver)
-				if err = alpha.Supply(filteredPaths, signatures, receiver); err != nil {
-					return fmt.Errorf("unable to stage files on beta: %w", err)
-				}
+			c.stateLock.Lock()
+			c.state.Status = Status_StagingBeta
+			c.stateLock.Unlock()
+			if err := c.stage(ctx, false, beta, alpha, βTransitions, βContent); err != nil {
+				return err
 			}
 		}
 
@@ -1326,6 +2342,7 @@ func (c *controller) synchronize(ctx context.Context, alpha, beta Endpoint) erro
 		// doesn't completely error out, convert its results to ancestor
 		// changes. Transition errors are checked later, once the ancestor has
 		// been updated.
+		c.heartbeat()
 		c.stateLock.Lock()
 		c.state.Status = Status_Transitioning
 		c.stateLock.Unlock()
@@ -1344,7 +2361,7 @@ func (c *controller) synchronize(ctx context.Context, alpha, beta Endpoint) erro
 		if len(αTransitions) > 0 {
 			c.logger.Debug("Transitioning alpha")
 			go func() {
-				αResults, αProblems, αMissingFiles, αTransitionErr = alpha.Transition(ctx, αTransitions)
+				αResults, αProblems, αMissingFiles, αTransitionErr = alpha.Transition(ctx, αTransitions, false)
 				if αTransitionErr == nil {
 					for t, transition := range αTransitions {
 						αChanges = append(αChanges, &core.Change{Path: transition.Path, New: αResults[t]})
@@ -1356,7 +2373,7 @@ func (c *controller) synchronize(ctx context.Context, alpha, beta Endpoint) erro
 		if len(βTransitions) > 0 {
 			c.logger.Debug("Transitioning beta")
 			go func() {
-				βResults, βProblems, βMissingFiles, βTransitionErr = beta.Transition(ctx, βTransitions)
+				βResults, βProblems, βMissingFiles, βTransitionErr = beta.Transition(ctx, βTransitions, false)
 				if βTransitionErr == nil {
 					for t, transition := range βTransitions {
 						βChanges = append(βChanges, &core.Change{Path: transition.Path, New: βResults[t]})
@@ -1368,12 +2385,22 @@ func (c *controller) synchronize(ctx context.Context, alpha, beta Endpoint) erro
 		transitionDone.Wait()
 
 		// Record transition problems.
+		c.heartbeat()
 		c.stateLock.Lock()
 		c.state.Status = Status_Saving
-		c.state.AlphaState.TransitionProblems = αProblems
-		c.state.BetaState.TransitionProblems = βProblems
+		c.state.AlphaState.TransitionProblems, c.state.AlphaState.ExcludedTransitionProblems =
+			truncateProblems(αProblems, maximumReportedProblems)
+		c.state.BetaState.TransitionProblems, c.state.BetaState.ExcludedTransitionProblems =
+			truncateProblems(βProblems, maximumReportedProblems)
 		c.stateLock.Unlock()
 
+		// If beta encountered transition problems or was missing staged files,
+		// then its view may be inaccurate, so require a regular beta scan on
+		// the next cycle.
+		if len(βProblems) > 0 || βMissingFiles {
+			βScanRequired = true
+		}
+
 		// Fold applied changes into the ancestor's change list and update the
 		// ancestor if any changes are present.
 		ancestorChanges = append(ancestorChanges, αChanges...)
@@ -1399,10 +2426,12 @@ func (c *controller) synchronize(ctx context.Context, alpha, beta Endpoint) erro
 				return fmt.Errorf("new ancestor is invalid: %w", err)
 			}
 
-			// Save the ancestor.
+			// Save the ancestor. We only need to record the changes that were
+			// applied, though the archive store may opt to write the ancestor
+			// in full.
 			c.logger.Debug("Saving ancestor")
 			archive.Content = ancestor
-			if err := encoding.MarshalAndSaveProtobuf(c.archivePath, archive); err != nil {
+			if err := archives.append(archive, ancestorChanges); err != nil {
 				return fmt.Errorf("unable to save ancestor: %w", err)
 			}
 		}
@@ -1414,6 +2443,20 @@ func (c *controller) synchronize(ctx context.Context, alpha, beta Endpoint) erro
 			return fmt.Errorf("unable to apply changes to beta: %w", βTransitionErr)
 		}
 
+		// Check if either endpoint refused to stage files that exceeded the
+		// maximum staging file size in a mode that requires halting. These
+		// files will already have been recorded as transition problems (and
+		// nothing will have been partially staged for them), so we switch to a
+		// halted state and wait for the user to either adjust the session
+		// configuration or remove the files and resume the session.
+		if (αHaltOnOversizedFiles && len(αTransitions) > 0 && alpha.OversizedFiles() > 0) ||
+			(βHaltOnOversizedFiles && len(βTransitions) > 0 && beta.OversizedFiles() > 0) {
+			c.stateLock.Lock()
+			c.state.Status = Status_HaltedOnOversizedFile
+			c.stateLock.Unlock()
+			return errHaltedForSafety
+		}
+
 		// If there were files missing from either endpoint's stager during the
 		// transition operations, then there were likely concurrent
 		// modifications during staging. If we see this, then skip polling and
@@ -1435,9 +2478,9 @@ func (c *controller) synchronize(ctx context.Context, alpha, beta Endpoint) erro
 
 		// If a flush request triggered this synchronization cycle, then tell it
 		// that the cycle has completed and remove it from our tracking.
-		if flushRequest != nil {
-			flushRequest <- nil
-			flushRequest = nil
+		if flush != nil {
+			flush.result <- nil
+			flush = nil
 		}
 	}
 }
@@ -1,3 +1,1504 @@
 package synchronization
 
-// TODO: Implement.
+import (
+	"bytes"
+	"context"
+	"crypto/sha1"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/mutagen-io/mutagen/pkg/agent"
+	"github.com/mutagen-io/mutagen/pkg/encoding"
+	"github.com/mutagen-io/mutagen/pkg/logging"
+	"github.com/mutagen-io/mutagen/pkg/state"
+	"github.com/mutagen-io/mutagen/pkg/synchronization/core"
+	"github.com/mutagen-io/mutagen/pkg/synchronization/rsync"
+	"github.com/mutagen-io/mutagen/pkg/url"
+)
+
+// TODO: Implement tests for additional functionality.
+
+// errTestScan is the error returned by testEndpoint scans.
+var errTestScan = errors.New("test scan error")
+
+// testEndpoint is an Endpoint implementation that records scan operations. Its
+// polling never reports changes, its scans always fail, and it supplies files
+// from a local root. Methods that aren't explicitly implemented will panic if
+// invoked.
+type testEndpoint struct {
+	Endpoint
+	// root is the root from which files are supplied.
+	root string
+	// scanned indicates whether or not a scan has been performed.
+	scanned atomic.Bool
+}
+
+// Poll implements Endpoint.Poll.
+func (e *testEndpoint) Poll(ctx context.Context) error {
+	<-ctx.Done()
+	return nil
+}
+
+// Scan implements Endpoint.Scan.
+func (e *testEndpoint) Scan(_ context.Context, _ *core.Entry, _ bool, _ []string, _ bool) (*core.Snapshot, error, bool) {
+	e.scanned.Store(true)
+	return nil, errTestScan, false
+}
+
+// Supply implements Endpoint.Supply.
+func (e *testEndpoint) Supply(paths []string, signatures []*rsync.Signature, receiver rsync.Receiver) error {
+	return rsync.Transmit(e.root, paths, signatures, receiver)
+}
+
+// ClockOffset implements Endpoint.ClockOffset.
+func (e *testEndpoint) ClockOffset() time.Duration {
+	return 0
+}
+
+// newTestController creates a controller suitable for testing the
+// synchronization loop, with both endpoints configured in no-watch mode.
+func newTestController(t *testing.T, initialSynchronizationMode InitialSynchronizationMode) *controller {
+	// Create an empty archive.
+	archivePath := filepath.Join(t.TempDir(), "archive")
+	if err := encoding.MarshalAndSaveProtobuf(archivePath, &core.Archive{}); err != nil {
+		t.Fatal("unable to save archive:", err)
+	}
+
+	// Create the controller.
+	return &controller{
+		logger:      logging.NewLogger(logging.LevelDisabled, io.Discard),
+		archivePath: archivePath,
+		stateLock:   state.NewTrackingLock(state.NewTracker()),
+		session: &Session{
+			Alpha:   &url.URL{Protocol: url.Protocol_Local, Path: "/alpha"},
+			Beta:    &url.URL{Protocol: url.Protocol_Local, Path: "/beta"},
+			Version: Version_Version1,
+			Configuration: &Configuration{
+				InitialSynchronizationMode: initialSynchronizationMode,
+			},
+		},
+		mergedAlphaConfiguration: &Configuration{WatchMode: WatchMode_WatchModeNoWatch},
+		mergedBetaConfiguration:  &Configuration{WatchMode: WatchMode_WatchModeNoWatch},
+		state: &State{
+			AlphaState: &EndpointState{},
+			BetaState:  &EndpointState{},
+		},
+		flushRequests: make(chan *flushRequest, 1),
+		fetchRequests: make(chan *fetchRequest, 1),
+	}
+}
+
+// TestControllerInitialSynchronizationMode tests that the initial
+// synchronization mode controls whether or not an initial synchronization cycle
+// is performed when both endpoints have watching disabled.
+func TestControllerInitialSynchronizationMode(t *testing.T) {
+	// Set up test cases.
+	testCases := []struct {
+		mode          InitialSynchronizationMode
+		expectScan    bool
+		expectedError error
+	}{
+		{InitialSynchronizationMode_InitialSynchronizationModeDefault, false, nil},
+		{InitialSynchronizationMode_InitialSynchronizationModeAutomatic, false, nil},
+		{InitialSynchronizationMode_InitialSynchronizationModeForce, true, errTestScan},
+	}
+
+	// Process test cases.
+	for i, testCase := range testCases {
+		// Create the controller and endpoints.
+		controller := newTestController(t, testCase.mode)
+		alpha, beta := &testEndpoint{}, &testEndpoint{}
+
+		// Run the synchronization loop. If no initial cycle is performed, then
+		// the loop will sit in polling until the context times out.
+		ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+		err := controller.synchronize(ctx, alpha, beta)
+		cancel()
+
+		// Check the results.
+		if err == nil {
+			t.Errorf("test case %d: synchronization loop terminated without error", i)
+		} else if testCase.expectedError != nil && !errors.Is(err, testCase.expectedError) {
+			t.Errorf("test case %d: unexpected error: %v", i, err)
+		}
+		if scanned := alpha.scanned.Load() || beta.scanned.Load(); scanned != testCase.expectScan {
+			t.Errorf("test case %d: scan status does not match expected: %t != %t",
+				i, scanned, testCase.expectScan,
+			)
+		}
+	}
+}
+
+// TestControllerFetch tests that file content can be fetched from a session
+// endpoint without triggering a synchronization cycle.
+func TestControllerFetch(t *testing.T) {
+	// Create endpoint roots and populate beta with a known file and a file that
+	// exceeds the maximum fetch size.
+	content := []byte("fetched content")
+	alphaRoot, betaRoot := t.TempDir(), t.TempDir()
+	if err := os.WriteFile(filepath.Join(betaRoot, "file"), content, 0600); err != nil {
+		t.Fatal("unable to create file:", err)
+	}
+	if err := os.WriteFile(filepath.Join(betaRoot, "large"), make([]byte, MaximumFetchSize+1), 0600); err != nil {
+		t.Fatal("unable to create large file:", err)
+	}
+
+	// Create the controller and endpoints.
+	controller := newTestController(t, InitialSynchronizationMode_InitialSynchronizationModeAutomatic)
+	alpha, beta := &testEndpoint{root: alphaRoot}, &testEndpoint{root: betaRoot}
+
+	// Start the synchronization loop in the same manner as the run loop and
+	// defer its termination.
+	ctx, cancel := context.WithCancel(context.Background())
+	controller.cancel = cancel
+	controller.synchronizing = make(chan struct{})
+	controller.done = make(chan struct{})
+	go func() {
+		controller.synchronize(ctx, alpha, beta)
+		close(controller.done)
+	}()
+	defer func() {
+		cancel()
+		<-controller.done
+	}()
+
+	// Fetch the known file from beta and verify its content.
+	if fetched, err := controller.fetch(context.Background(), true, "file"); err != nil {
+		t.Fatal("unable to fetch file:", err)
+	} else if !bytes.Equal(fetched, content) {
+		t.Error("fetched content does not match expected")
+	}
+
+	// Verify that fetching a non-existent file fails without terminating the
+	// synchronization loop.
+	if _, err := controller.fetch(context.Background(), false, "file"); err == nil {
+		t.Error("fetch of non-existent file succeeded unexpectedly")
+	}
+
+	// Verify that fetching a file exceeding the maximum fetch size fails.
+	if _, err := controller.fetch(context.Background(), true, "large"); !errors.Is(err, errFetchTooLarge) {
+		t.Error("fetch of large file did not fail with expected error:", err)
+	}
+
+	// Verify that the synchronization loop is still running and that no
+	// synchronization cycle was triggered.
+	select {
+	case <-controller.done:
+		t.Error("synchronization loop terminated unexpectedly")
+	default:
+	}
+	if alpha.scanned.Load() || beta.scanned.Load() {
+		t.Error("fetch triggered a synchronization cycle")
+	}
+}
+
+// stagingTestTarget is an io.WriteCloser that stores staged content for a
+// stagingTestEndpoint once the content has been verified.
+type stagingTestTarget struct {
+	// endpoint is the parent endpoint.
+	endpoint *stagingTestEndpoint
+	// path is the path being staged.
+	path string
+	// digest is the expected content digest.
+	digest []byte
+	// buffer stores the received content.
+	buffer bytes.Buffer
+}
+
+// Write implements io.Writer.Write.
+func (t *stagingTestTarget) Write(data []byte) (int, error) {
+	return t.buffer.Write(data)
+}
+
+// Close implements io.Closer.Close.
+func (t *stagingTestTarget) Close() error {
+	if digest := sha1.Sum(t.buffer.Bytes()); !bytes.Equal(digest[:], t.digest) {
+		return errors.New("staged content does not match expected digest")
+	}
+	t.endpoint.stagedLock.Lock()
+	t.endpoint.staged[t.path] = t.buffer.Bytes()
+	t.endpoint.stagedLock.Unlock()
+	return nil
+}
+
+// stagingTestEndpoint is an Endpoint implementation that reports fixed content
+// from scans, stages files in memory, and supplies files from a local root. Its
+// supply operations wait (for a bounded period of time) for the supply
+// operation of the opposite endpoint to start, allowing tests to detect whether
+// or not staging occurs concurrently. Methods that aren't explicitly
+// implemented will panic if invoked.
+type stagingTestEndpoint struct {
+	Endpoint
+	// root is the root from which files are supplied.
+	root string
+	// content is the content reported by scans.
+	content *core.Entry
+	// supplying is the wait group shared with the opposite endpoint to track
+	// the start of supply operations.
+	supplying *sync.WaitGroup
+	// overlapped is shared with the opposite endpoint and set if supply
+	// operations on both endpoints were in progress at the same time.
+	overlapped *atomic.Bool
+	// stagedLock serializes access to staged.
+	stagedLock sync.Mutex
+	// staged stores staged content by path.
+	staged map[string][]byte
+	// transitioned is closed when a transition operation is performed.
+	transitioned chan struct{}
+}
+
+// Poll implements Endpoint.Poll.
+func (e *stagingTestEndpoint) Poll(ctx context.Context) error {
+	<-ctx.Done()
+	return nil
+}
+
+// Scan implements Endpoint.Scan.
+func (e *stagingTestEndpoint) Scan(_ context.Context, _ *core.Entry, _ bool, _ []string, _ bool) (*core.Snapshot, error, bool) {
+	return &core.Snapshot{Content: e.content, Directories: 1, Files: 1}, nil, false
+}
+
+// Stage implements Endpoint.Stage.
+func (e *stagingTestEndpoint) Stage(paths []string, digests [][]byte) ([]string, []*rsync.Signature, rsync.Receiver, bool, error) {
+	signatures := make([]*rsync.Signature, len(paths))
+	for i := range signatures {
+		signatures[i] = &rsync.Signature{}
+	}
+	receiver, err := rsync.NewReceiver(e.root, paths, digests, signatures, e)
+	if err != nil {
+		return nil, nil, nil, false, err
+	}
+	return paths, signatures, receiver, false, nil
+}
+
+// Sink implements rsync.Sinker.Sink.
+func (e *stagingTestEndpoint) Sink(path string, digest []byte, _ uint64) (io.WriteCloser, error) {
+	return &stagingTestTarget{endpoint: e, path: path, digest: digest}, nil
+}
+
+// Supply implements Endpoint.Supply.
+func (e *stagingTestEndpoint) Supply(paths []string, signatures []*rsync.Signature, receiver rsync.Receiver) error {
+	// Signal the start of supplying and wait for the opposite endpoint to do
+	// the same.
+	e.supplying.Done()
+	waited := make(chan struct{})
+	go func() {
+		e.supplying.Wait()
+		close(waited)
+	}()
+	select {
+	case <-waited:
+		e.overlapped.Store(true)
+	case <-time.After(time.Second):
+	}
+
+	// Perform supplying.
+	return rsync.Transmit(e.root, paths, signatures, receiver)
+}
+
+// Transition implements Endpoint.Transition.
+func (e *stagingTestEndpoint) Transition(_ context.Context, transitions []*core.Change, _ bool) ([]*core.Entry, []*core.Problem, bool, error) {
+	results := make([]*core.Entry, len(transitions))
+	for t, transition := range transitions {
+		results[t] = transition.New
+	}
+	close(e.transitioned)
+	return results, nil, false, nil
+}
+
+// ClockOffset implements Endpoint.ClockOffset.
+func (e *stagingTestEndpoint) ClockOffset() time.Duration {
+	return 0
+}
+
+// WatchOverflows implements Endpoint.WatchOverflows.
+func (e *stagingTestEndpoint) WatchOverflows() uint64 {
+	return 0
+}
+
+// AccelerationUnavailable implements Endpoint.AccelerationUnavailable.
+func (e *stagingTestEndpoint) AccelerationUnavailable() string {
+	return ""
+}
+
+// OversizedFiles implements Endpoint.OversizedFiles.
+func (e *stagingTestEndpoint) OversizedFiles() uint64 {
+	return 0
+}
+
+// newStagingTestEndpoint creates a new staging test endpoint with a root
+// containing a single file with the specified name and content.
+func newStagingTestEndpoint(t *testing.T, name string, content []byte, supplying *sync.WaitGroup, overlapped *atomic.Bool) *stagingTestEndpoint {
+	// Create the root and file.
+	root := t.TempDir()
+	if err := os.WriteFile(filepath.Join(root, name), content, 0600); err != nil {
+		t.Fatal("unable to create file:", err)
+	}
+
+	// Create the endpoint.
+	digest := sha1.Sum(content)
+	return &stagingTestEndpoint{
+		root: root,
+		content: &core.Entry{
+			Kind: core.EntryKind_Directory,
+			Contents: map[string]*core.Entry{
+				name: {Kind: core.EntryKind_File, Digest: digest[:]},
+			},
+		},
+		supplying:    supplying,
+		overlapped:   overlapped,
+		staged:       make(map[string][]byte),
+		transitioned: make(chan struct{}),
+	}
+}
+
+// TestControllerConcurrentStaging tests that staging is performed concurrently
+// on both endpoints when concurrent staging is enabled and that the staged
+// content is correct.
+func TestControllerConcurrentStaging(t *testing.T) {
+	// Create endpoints, each of which has a file to be staged on the other.
+	alphaContent, betaContent := []byte("alpha content"), []byte("beta content")
+	supplying := &sync.WaitGroup{}
+	supplying.Add(2)
+	overlapped := &atomic.Bool{}
+	alpha := newStagingTestEndpoint(t, "alpha", alphaContent, supplying, overlapped)
+	beta := newStagingTestEndpoint(t, "beta", betaContent, supplying, overlapped)
+
+	// Create the controller and enable concurrent staging.
+	controller := newTestController(t, InitialSynchronizationMode_InitialSynchronizationModeForce)
+	controller.session.Configuration.StagingConcurrencyMode = StagingConcurrencyMode_StagingConcurrencyModeConcurrent
+
+	// Run the synchronization loop and wait for both endpoints to transition.
+	ctx, cancel := context.WithCancel(context.Background())
+	synchronizeErrors := make(chan error, 1)
+	go func() {
+		synchronizeErrors <- controller.synchronize(ctx, alpha, beta)
+	}()
+	for _, transitioned := range []chan struct{}{alpha.transitioned, beta.transitioned} {
+		select {
+		case <-transitioned:
+		case err := <-synchronizeErrors:
+			cancel()
+			t.Fatal("synchronization loop terminated unexpectedly:", err)
+		case <-time.After(10 * time.Second):
+			cancel()
+			t.Fatal("timed out waiting for transitions")
+		}
+	}
+	cancel()
+	<-synchronizeErrors
+
+	// Verify that staging was concurrent.
+	if !overlapped.Load() {
+		t.Error("staging was not performed concurrently")
+	}
+
+	// Verify that the correct content was staged on each endpoint.
+	if staged := alpha.staged["beta"]; !bytes.Equal(staged, betaContent) {
+		t.Error("content staged on alpha does not match expected")
+	}
+	if staged := beta.staged["alpha"]; !bytes.Equal(staged, alphaContent) {
+		t.Error("content staged on beta does not match expected")
+	}
+}
+
+// conflictTestEndpoint is an Endpoint implementation that reports a file from
+// scans and triggers a synchronization cycle on each poll, up to a fixed
+// number of cycles. Methods that aren't explicitly implemented will panic if
+// invoked.
+type conflictTestEndpoint struct {
+	Endpoint
+	// content is the file content reported by scans.
+	content string
+	// changing indicates whether or not the reported file content should change
+	// on each scan.
+	changing bool
+	// maximumCycles is the maximum number of synchronization cycles that
+	// polling will trigger.
+	maximumCycles uint32
+	// preservesExecutability is the executability preservation capability
+	// reported by scans.
+	preservesExecutability bool
+	// decomposesUnicode is the Unicode decomposition capability reported by
+	// scans.
+	decomposesUnicode bool
+	// scans is the number of scans that have been performed.
+	scans atomic.Uint32
+}
+
+// Poll implements Endpoint.Poll.
+func (e *conflictTestEndpoint) Poll(ctx context.Context) error {
+	if e.scans.Load() < e.maximumCycles {
+		return nil
+	}
+	<-ctx.Done()
+	return nil
+}
+
+// Scan implements Endpoint.Scan.
+func (e *conflictTestEndpoint) Scan(_ context.Context, _ *core.Entry, _ bool, _ []string, _ bool) (*core.Snapshot, error, bool) {
+	scans := e.scans.Add(1)
+	content := []byte(e.content)
+	if e.changing {
+		content = append(content, byte(scans))
+	}
+	digest := sha1.Sum(content)
+	return &core.Snapshot{
+		Content: &core.Entry{
+			Kind: core.EntryKind_Directory,
+			Contents: map[string]*core.Entry{
+				"file": {Kind: core.EntryKind_File, Digest: digest[:]},
+			},
+		},
+		PreservesExecutability: e.preservesExecutability,
+		DecomposesUnicode:      e.decomposesUnicode,
+		Directories:            1,
+		Files:                  1,
+	}, nil, false
+}
+
+// Transition implements Endpoint.Transition.
+func (e *conflictTestEndpoint) Transition(_ context.Context, transitions []*core.Change, _ bool) ([]*core.Entry, []*core.Problem, bool, error) {
+	results := make([]*core.Entry, len(transitions))
+	for t, transition := range transitions {
+		results[t] = transition.New
+	}
+	return results, nil, false, nil
+}
+
+// ClockOffset implements Endpoint.ClockOffset.
+func (e *conflictTestEndpoint) ClockOffset() time.Duration {
+	return 0
+}
+
+// WatchOverflows implements Endpoint.WatchOverflows.
+func (e *conflictTestEndpoint) WatchOverflows() uint64 {
+	return 0
+}
+
+// AccelerationUnavailable implements Endpoint.AccelerationUnavailable.
+func (e *conflictTestEndpoint) AccelerationUnavailable() string {
+	return ""
+}
+
+// OversizedFiles implements Endpoint.OversizedFiles.
+func (e *conflictTestEndpoint) OversizedFiles() uint64 {
+	return 0
+}
+
+// TestControllerMaximumConflictPersistence tests that the synchronization loop
+// halts when a conflict persists unchanged for more than the maximum conflict
+// persistence, but not when the conflict changes between cycles.
+func TestControllerMaximumConflictPersistence(t *testing.T) {
+	// Set up test cases.
+	testCases := []struct {
+		changing       bool
+		expectedHalt   bool
+		expectedScans  uint32
+		expectedStatus Status
+	}{
+		{false, true, 4, Status_HaltedOnPersistentConflict},
+		{true, false, 10, Status_Watching},
+	}
+
+	// Process test cases.
+	for i, testCase := range testCases {
+		// Create the controller and enable polling-triggered cycles.
+		controller := newTestController(t, InitialSynchronizationMode_InitialSynchronizationModeForce)
+		controller.session.Configuration.MaximumConflictPersistence = 3
+		controller.mergedAlphaConfiguration = &Configuration{}
+		controller.mergedBetaConfiguration = &Configuration{}
+
+		// Create endpoints with conflicting file content.
+		alpha := &conflictTestEndpoint{content: "alpha", changing: testCase.changing, maximumCycles: 10}
+		beta := &conflictTestEndpoint{content: "beta", maximumCycles: 10}
+
+		// Run the synchronization loop. If no halt occurs, then the loop will
+		// sit in polling until the context times out.
+		ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+		err := controller.synchronize(ctx, alpha, beta)
+		cancel()
+
+		// Check the results.
+		if halted := err == errHaltedForSafety; halted != testCase.expectedHalt {
+			t.Errorf("test case %d: halt status does not match expected: %t != %t (error: %v)",
+				i, halted, testCase.expectedHalt, err,
+			)
+		}
+		if scans := alpha.scans.Load(); scans != testCase.expectedScans {
+			t.Errorf("test case %d: scan count does not match expected: %d != %d",
+				i, scans, testCase.expectedScans,
+			)
+		}
+		if status := controller.state.Status; status != testCase.expectedStatus {
+			t.Errorf("test case %d: status does not match expected: %s != %s",
+				i, status, testCase.expectedStatus,
+			)
+		}
+		if len(controller.state.Conflicts) != 1 {
+			t.Errorf("test case %d: unexpected number of conflicts: %d",
+				i, len(controller.state.Conflicts),
+			)
+		}
+	}
+}
+
+// previewTestEndpoint is a conflictTestEndpoint that records transition and
+// shutdown operations.
+type previewTestEndpoint struct {
+	*conflictTestEndpoint
+	// transitions are the transitions requested from the endpoint.
+	transitions []*core.Change
+	// dryRun indicates whether or not the transitions were requested as a dry
+	// run.
+	dryRun bool
+	// shutdown indicates whether or not the endpoint has been shut down.
+	shutdown bool
+}
+
+// Transition implements Endpoint.Transition.
+func (e *previewTestEndpoint) Transition(_ context.Context, transitions []*core.Change, dryRun bool) ([]*core.Entry, []*core.Problem, bool, error) {
+	e.transitions = transitions
+	e.dryRun = dryRun
+	results := make([]*core.Entry, len(transitions))
+	for t, transition := range transitions {
+		results[t] = transition.Old
+	}
+	return results, []*core.Problem{{Path: "file", Error: "test problem"}}, false, nil
+}
+
+// Shutdown implements Endpoint.Shutdown.
+func (e *previewTestEndpoint) Shutdown() error {
+	e.shutdown = true
+	return nil
+}
+
+// previewTestProtocolHandler is a ProtocolHandler implementation that returns
+// fixed endpoints.
+type previewTestProtocolHandler struct {
+	// alpha is the alpha endpoint.
+	alpha *previewTestEndpoint
+	// beta is the beta endpoint.
+	beta *previewTestEndpoint
+}
+
+// Connect implements ProtocolHandler.Connect.
+func (h *previewTestProtocolHandler) Connect(
+	_ context.Context,
+	_ *logging.Logger,
+	_ *url.URL,
+	_ string,
+	_ string,
+	_ Version,
+	_ *Configuration,
+	alpha bool,
+) (Endpoint, error) {
+	if alpha {
+		return h.alpha, nil
+	}
+	return h.beta, nil
+}
+
+// TestControllerPreview tests that previewing a paused session reconciles the
+// endpoint contents and validates the resulting transitions using a dry run.
+func TestControllerPreview(t *testing.T) {
+	// Restore the local protocol handler after testing.
+	original, registered := ProtocolHandlers[url.Protocol_Local]
+	defer func() {
+		if registered {
+			ProtocolHandlers[url.Protocol_Local] = original
+		} else {
+			delete(ProtocolHandlers, url.Protocol_Local)
+		}
+	}()
+
+	// Create the controller and register a protocol handler that provides
+	// endpoints with differing file content.
+	controller := newTestController(t, InitialSynchronizationMode_InitialSynchronizationModeAutomatic)
+	controller.session.Configuration.SynchronizationMode = core.SynchronizationMode_SynchronizationModeTwoWayResolved
+	handler := &previewTestProtocolHandler{
+		alpha: &previewTestEndpoint{conflictTestEndpoint: &conflictTestEndpoint{content: "alpha"}},
+		beta:  &previewTestEndpoint{conflictTestEndpoint: &conflictTestEndpoint{content: "beta"}},
+	}
+	ProtocolHandlers[url.Protocol_Local] = handler
+
+	// Compute the preview.
+	preview, err := controller.preview(context.Background(), "")
+	if err != nil {
+		t.Fatal("unable to compute preview:", err)
+	} else if err = preview.EnsureValid(); err != nil {
+		t.Fatal("invalid preview:", err)
+	}
+
+	// Verify that alpha's content would be propagated to beta and that the
+	// transition was only validated.
+	if len(preview.AlphaTransitions) != 0 {
+		t.Error("unexpected alpha transitions:", preview.AlphaTransitions)
+	}
+	if len(preview.BetaTransitions) != 1 || preview.BetaTransitions[0].Path != "file" {
+		t.Error("unexpected beta transitions:", preview.BetaTransitions)
+	}
+	if len(preview.BetaProblems) != 1 {
+		t.Error("beta transition problems not reported")
+	}
+	if len(preview.Conflicts) != 0 {
+		t.Error("unexpected conflicts:", preview.Conflicts)
+	}
+	if handler.alpha.transitions != nil {
+		t.Error("transitions requested from alpha")
+	}
+	if len(handler.beta.transitions) != 1 || !handler.beta.dryRun {
+		t.Error("beta transitions not requested as a dry run")
+	}
+	if !handler.alpha.shutdown || !handler.beta.shutdown {
+		t.Error("endpoints not shut down after preview")
+	}
+
+	// Verify that previews are refused for unpaused sessions.
+	controller.cancel = func() {}
+	if _, err := controller.preview(context.Background(), ""); err == nil {
+		t.Error("preview of unpaused session succeeded unexpectedly")
+	}
+}
+
+// TestControllerCapabilityMismatch tests that the synchronization loop reports
+// filesystem capability mismatches between endpoints and halts on them only if
+// configured to do so.
+func TestControllerCapabilityMismatch(t *testing.T) {
+	// Set up test cases.
+	testCases := []struct {
+		mode               CapabilityMismatchMode
+		betaExecutability  bool
+		expectedMismatches int
+		expectedHalt       bool
+		expectedStatus     Status
+	}{
+		{CapabilityMismatchMode_CapabilityMismatchModeDefault, false, 1, false, Status_Watching},
+		{CapabilityMismatchMode_CapabilityMismatchModeAllow, false, 1, false, Status_Watching},
+		{CapabilityMismatchMode_CapabilityMismatchModeHalt, false, 1, true, Status_HaltedOnCapabilityMismatch},
+		{CapabilityMismatchMode_CapabilityMismatchModeHalt, true, 0, false, Status_Watching},
+	}
+
+	// Process test cases.
+	for i, testCase := range testCases {
+		// Create the controller and enable polling-triggered cycles.
+		controller := newTestController(t, InitialSynchronizationMode_InitialSynchronizationModeForce)
+		controller.session.Configuration.CapabilityMismatchMode = testCase.mode
+		controller.mergedAlphaConfiguration = &Configuration{}
+		controller.mergedBetaConfiguration = &Configuration{}
+
+		// Create endpoints with identical content but (potentially) differing
+		// executability preservation. Both decompose Unicode.
+		alpha := &conflictTestEndpoint{
+			content:                "content",
+			maximumCycles:          1,
+			preservesExecutability: true,
+			decomposesUnicode:      true,
+		}
+		beta := &conflictTestEndpoint{
+			content:                "content",
+			maximumCycles:          1,
+			preservesExecutability: testCase.betaExecutability,
+			decomposesUnicode:      true,
+		}
+
+		// Run the synchronization loop. If no halt occurs, then the loop will
+		// sit in polling until the context times out.
+		ctx, cancel := context.WithTimeout(context.Background(), 250*time.Millisecond)
+		err := controller.synchronize(ctx, alpha, beta)
+		cancel()
+
+		// Check the results.
+		if halted := err == errHaltedForSafety; halted != testCase.expectedHalt {
+			t.Errorf("test case %d: halt status does not match expected: %t != %t (error: %v)",
+				i, halted, testCase.expectedHalt, err,
+			)
+		}
+		if status := controller.state.Status; status != testCase.expectedStatus {
+			t.Errorf("test case %d: status does not match expected: %s != %s",
+				i, status, testCase.expectedStatus,
+			)
+		}
+		mismatches := controller.state.CapabilityMismatches
+		if len(mismatches) != testCase.expectedMismatches {
+			t.Errorf("test case %d: unexpected number of capability mismatches: %d != %d",
+				i, len(mismatches), testCase.expectedMismatches,
+			)
+		} else if len(mismatches) == 1 {
+			if mismatches[0].Capability != "executability preservation" {
+				t.Errorf("test case %d: unexpected capability mismatch: %s", i, mismatches[0].Capability)
+			} else if !mismatches[0].Alpha || mismatches[0].Beta {
+				t.Errorf("test case %d: capability mismatch values incorrect", i)
+			}
+		}
+	}
+}
+
+// TestControllerConfigurationIncompatibility tests that the synchronization
+// loop reports endpoint configurations that are incompatible with the probed
+// filesystem capabilities and halts on them (before any transitions) only if
+// configured to do so.
+func TestControllerConfigurationIncompatibility(t *testing.T) {
+	// Set up test cases.
+	testCases := []struct {
+		mode                      ConfigurationIncompatibilityMode
+		betaConfiguration         *Configuration
+		betaExecutability         bool
+		expectedIncompatibilities int
+		expectedHalt              bool
+		expectedStatus            Status
+	}{
+		{
+			ConfigurationIncompatibilityMode_ConfigurationIncompatibilityModeDefault,
+			&Configuration{DefaultFileMode: 0755},
+			false, 1, false, Status_Watching,
+		},
+		{
+			ConfigurationIncompatibilityMode_ConfigurationIncompatibilityModeHalt,
+			&Configuration{DefaultFileMode: 0755},
+			false, 1, true, Status_HaltedOnConfigurationIncompatibility,
+		},
+		{
+			ConfigurationIncompatibilityMode_ConfigurationIncompatibilityModeHalt,
+			&Configuration{DefaultFileMode: 0755, DefaultOwner: "id:1000"},
+			false, 2, true, Status_HaltedOnConfigurationIncompatibility,
+		},
+		{
+			ConfigurationIncompatibilityMode_ConfigurationIncompatibilityModeHalt,
+			&Configuration{DefaultFileMode: 0644},
+			false, 0, false, Status_Watching,
+		},
+		{
+			ConfigurationIncompatibilityMode_ConfigurationIncompatibilityModeHalt,
+			&Configuration{DefaultFileMode: 0755},
+			true, 0, false, Status_Watching,
+		},
+	}
+
+	// Process test cases.
+	for i, testCase := range testCases {
+		// Create the controller and enable polling-triggered cycles. We use
+		// manual permissions propagation so that default file modes may
+		// include executability bits.
+		controller := newTestController(t, InitialSynchronizationMode_InitialSynchronizationModeForce)
+		controller.session.Configuration.PermissionsMode = core.PermissionsMode_PermissionsModeManual
+		controller.session.Configuration.ConfigurationIncompatibilityMode = testCase.mode
+		controller.mergedAlphaConfiguration = &Configuration{}
+		controller.mergedBetaConfiguration = testCase.betaConfiguration
+
+		// Create endpoints with identical content and capabilities, except for
+		// (potentially) beta's executability preservation.
+		alpha := &conflictTestEndpoint{
+			content:                "content",
+			maximumCycles:          1,
+			preservesExecutability: true,
+			decomposesUnicode:      true,
+		}
+		beta := &conflictTestEndpoint{
+			content:                "content",
+			maximumCycles:          1,
+			preservesExecutability: testCase.betaExecutability,
+			decomposesUnicode:      true,
+		}
+
+		// Run the synchronization loop. If no halt occurs, then the loop will
+		// sit in polling until the context times out.
+		ctx, cancel := context.WithTimeout(context.Background(), 250*time.Millisecond)
+		err := controller.synchronize(ctx, alpha, beta)
+		cancel()
+
+		// Check the results.
+		if halted := err == errHaltedForSafety; halted != testCase.expectedHalt {
+			t.Errorf("test case %d: halt status does not match expected: %t != %t (error: %v)",
+				i, halted, testCase.expectedHalt, err,
+			)
+		}
+		if status := controller.state.Status; status != testCase.expectedStatus {
+			t.Errorf("test case %d: status does not match expected: %s != %s",
+				i, status, testCase.expectedStatus,
+			)
+		}
+		if len(controller.state.AlphaState.ConfigurationIncompatibilities) != 0 {
+			t.Errorf("test case %d: unexpected alpha configuration incompatibilities", i)
+		}
+		incompatibilities := controller.state.BetaState.ConfigurationIncompatibilities
+		if len(incompatibilities) != testCase.expectedIncompatibilities {
+			t.Errorf("test case %d: unexpected number of beta configuration incompatibilities: %d != %d",
+				i, len(incompatibilities), testCase.expectedIncompatibilities,
+			)
+		} else if len(incompatibilities) > 0 && !strings.Contains(incompatibilities[0], "executability") {
+			t.Errorf("test case %d: unexpected configuration incompatibility: %s", i, incompatibilities[0])
+		}
+	}
+}
+
+// stalledTestEndpoint is an Endpoint implementation whose scans stall until
+// cancelled. It is otherwise identical to testEndpoint.
+type stalledTestEndpoint struct {
+	testEndpoint
+}
+
+// Scan implements Endpoint.Scan.
+func (e *stalledTestEndpoint) Scan(ctx context.Context, _ *core.Entry, _ bool, _ []string, _ bool) (*core.Snapshot, error, bool) {
+	e.scanned.Store(true)
+	<-ctx.Done()
+	return nil, errTestScan, false
+}
+
+// TestControllerWatchdog tests that the synchronization loop watchdog restarts
+// stalled synchronization cycles but leaves idle and unmonitored
+// synchronization loops alone.
+func TestControllerWatchdog(t *testing.T) {
+	// Set up test cases.
+	testCases := []struct {
+		mode           InitialSynchronizationMode
+		timeout        time.Duration
+		expectRestart  bool
+		expectedStatus Status
+	}{
+		{InitialSynchronizationMode_InitialSynchronizationModeForce, 100 * time.Millisecond, true, Status_Scanning},
+		{InitialSynchronizationMode_InitialSynchronizationModeForce, 0, false, Status_Scanning},
+		{InitialSynchronizationMode_InitialSynchronizationModeAutomatic, 100 * time.Millisecond, false, Status_Watching},
+	}
+
+	// Process test cases.
+	for i, testCase := range testCases {
+		// Create the controller and endpoints.
+		controller := newTestController(t, testCase.mode)
+		alpha, beta := &stalledTestEndpoint{}, &stalledTestEndpoint{}
+
+		// Run the synchronization loop. If the watchdog doesn't restart the
+		// loop, then it will sit in scanning or polling until the context
+		// times out.
+		ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+		err := controller.synchronizeWithWatchdog(ctx, alpha, beta, testCase.timeout)
+		cancel()
+
+		// Check the results.
+		if err == nil {
+			t.Errorf("test case %d: synchronization loop terminated without error", i)
+			continue
+		}
+		if restarted := strings.Contains(err.Error(), "watchdog"); restarted != testCase.expectRestart {
+			t.Errorf("test case %d: restart status does not match expected: %t != %t (error: %v)",
+				i, restarted, testCase.expectRestart, err,
+			)
+		}
+		if status := controller.state.Status; status != testCase.expectedStatus {
+			t.Errorf("test case %d: status does not match expected: %s != %s",
+				i, status, testCase.expectedStatus,
+			)
+		}
+		if progress := controller.progress.Load(); progress != 0 {
+			t.Errorf("test case %d: progress tracking not cleared", i)
+		}
+	}
+}
+
+// agentTerminationTestEndpoint is an Endpoint implementation whose scans fail
+// as if the connection to the endpoint had been lost, optionally due to agent
+// termination. It is otherwise identical to testEndpoint.
+type agentTerminationTestEndpoint struct {
+	testEndpoint
+	// agentTerminated indicates whether or not scan failures should be due to
+	// agent termination.
+	agentTerminated bool
+}
+
+// Scan implements Endpoint.Scan.
+func (e *agentTerminationTestEndpoint) Scan(_ context.Context, _ *core.Entry, _ bool, _ []string, _ bool) (*core.Snapshot, error, bool) {
+	e.scanned.Store(true)
+	if e.agentTerminated {
+		return nil, fmt.Errorf("unable to receive scan response: %w", agent.ErrAgentTerminated), false
+	}
+	return nil, errors.New("unable to receive scan response: connection lost"), false
+}
+
+// Shutdown implements Endpoint.Shutdown.
+func (e *agentTerminationTestEndpoint) Shutdown() error {
+	return nil
+}
+
+// agentTerminationTestProtocolHandler is a ProtocolHandler implementation that
+// creates agentTerminationTestEndpoint instances and records the controller's
+// agent termination state at the time of each alpha reconnection.
+type agentTerminationTestProtocolHandler struct {
+	// controller is the controller using the handler.
+	controller *controller
+	// agentTerminated indicates whether or not endpoints should simulate agent
+	// termination.
+	agentTerminated bool
+	// lock serializes access to reconnections.
+	lock sync.Mutex
+	// reconnections records the controller's agent termination state at the
+	// time of each alpha reconnection.
+	reconnections []bool
+	// connected indicates whether or not alpha has been connected.
+	connected bool
+}
+
+// Connect implements ProtocolHandler.Connect.
+func (h *agentTerminationTestProtocolHandler) Connect(
+	_ context.Context,
+	_ *logging.Logger,
+	_ *url.URL,
+	_ string,
+	_ string,
+	_ Version,
+	_ *Configuration,
+	alpha bool,
+) (Endpoint, error) {
+	if alpha {
+		h.controller.stateLock.Lock()
+		agentTerminated := h.controller.state.AgentTerminated
+		h.controller.stateLock.UnlockWithoutNotify()
+		h.lock.Lock()
+		if h.connected {
+			h.reconnections = append(h.reconnections, agentTerminated)
+		}
+		h.connected = true
+		h.lock.Unlock()
+	}
+	return &agentTerminationTestEndpoint{agentTerminated: h.agentTerminated}, nil
+}
+
+// TestControllerAgentTermination tests that synchronization failures caused by
+// agent termination are reported distinctly and that reconnection after such
+// failures uses the agent termination reconnect delay rather than the
+// auto-reconnect interval.
+func TestControllerAgentTermination(t *testing.T) {
+	// Restore the local protocol handler after testing.
+	original, registered := ProtocolHandlers[url.Protocol_Local]
+	defer func() {
+		if registered {
+			ProtocolHandlers[url.Protocol_Local] = original
+		} else {
+			delete(ProtocolHandlers, url.Protocol_Local)
+		}
+	}()
+
+	// Set up test cases. The first failure always triggers an immediate
+	// reconnection, but subsequent failures wait for the reconnect interval, so
+	// only agent termination failures should yield more than one reconnection.
+	testCases := []struct {
+		agentTerminated         bool
+		expectMultipleReconnect bool
+	}{
+		{false, false},
+		{true, true},
+	}
+
+	// Process test cases.
+	for i, testCase := range testCases {
+		// Create the controller and register the test protocol handler.
+		controller := newTestController(t, InitialSynchronizationMode_InitialSynchronizationModeForce)
+		controller.session.Configuration.AgentTerminationReconnectDelay = 10
+		controller.done = make(chan struct{})
+		handler := &agentTerminationTestProtocolHandler{
+			controller:      controller,
+			agentTerminated: testCase.agentTerminated,
+		}
+		ProtocolHandlers[url.Protocol_Local] = handler
+
+		// Run the run loop for a period that is much longer than the agent
+		// termination reconnect delay, but much shorter than the auto-reconnect
+		// interval.
+		ctx, cancel := context.WithCancel(context.Background())
+		go controller.run(ctx, nil, nil)
+		time.Sleep(500 * time.Millisecond)
+		cancel()
+		<-controller.done
+
+		// Check the results.
+		handler.lock.Lock()
+		reconnections := handler.reconnections
+		handler.lock.Unlock()
+		if len(reconnections) == 0 {
+			t.Errorf("test case %d: no reconnections performed", i)
+			continue
+		}
+		if multiple := len(reconnections) > 1; multiple != testCase.expectMultipleReconnect {
+			t.Errorf("test case %d: multiple reconnection status does not match expected: %t != %t (%d reconnections)",
+				i, multiple, testCase.expectMultipleReconnect, len(reconnections),
+			)
+		}
+		for r, agentTerminated := range reconnections {
+			if agentTerminated != testCase.agentTerminated {
+				t.Errorf("test case %d: agent termination state at reconnection %d does not match expected: %t != %t",
+					i, r, agentTerminated, testCase.agentTerminated,
+				)
+			}
+		}
+	}
+}
+
+// connectErrorTestProtocolHandler is a ProtocolHandler implementation that
+// always fails to connect with a fixed error and records connection attempts.
+type connectErrorTestProtocolHandler struct {
+	// err is the connection error.
+	err error
+	// attempts is the number of connection attempts that have been made.
+	attempts atomic.Uint32
+}
+
+// Connect implements ProtocolHandler.Connect.
+func (h *connectErrorTestProtocolHandler) Connect(
+	_ context.Context,
+	_ *logging.Logger,
+	_ *url.URL,
+	_ string,
+	_ string,
+	_ Version,
+	_ *Configuration,
+	_ bool,
+) (Endpoint, error) {
+	h.attempts.Add(1)
+	return nil, h.err
+}
+
+// TestControllerConnectErrorClassification tests that authentication failures
+// when connecting are surfaced immediately without retrying, while other
+// connection failures are retried on the auto-reconnect interval.
+func TestControllerConnectErrorClassification(t *testing.T) {
+	// Restore the local protocol handler and auto-reconnect interval after
+	// testing.
+	original, registered := ProtocolHandlers[url.Protocol_Local]
+	originalAutoReconnectInterval := autoReconnectInterval
+	defer func() {
+		if registered {
+			ProtocolHandlers[url.Protocol_Local] = original
+		} else {
+			delete(ProtocolHandlers, url.Protocol_Local)
+		}
+		autoReconnectInterval = originalAutoReconnectInterval
+	}()
+
+	// Shorten the auto-reconnect interval so that retries are observable.
+	autoReconnectInterval = 10 * time.Millisecond
+
+	// Set up test cases.
+	testCases := []struct {
+		err            error
+		expectRetry    bool
+		expectSurfaced bool
+	}{
+		{fmt.Errorf("%w: permission denied (publickey)", agent.ErrAuthenticationFailed), false, true},
+		{errors.New("connection refused"), true, false},
+	}
+
+	// Process test cases.
+	for i, testCase := range testCases {
+		// Create the controller and register the test protocol handler.
+		controller := newTestController(t, InitialSynchronizationMode_InitialSynchronizationModeForce)
+		controller.done = make(chan struct{})
+		handler := &connectErrorTestProtocolHandler{err: testCase.err}
+		ProtocolHandlers[url.Protocol_Local] = handler
+
+		// Run the run loop for a period that is much longer than the
+		// auto-reconnect interval and then capture its state.
+		ctx, cancel := context.WithCancel(context.Background())
+		go controller.run(ctx, nil, nil)
+		time.Sleep(250 * time.Millisecond)
+		controller.stateLock.Lock()
+		lastError := controller.state.LastError
+		controller.stateLock.UnlockWithoutNotify()
+		cancel()
+		<-controller.done
+
+		// Check the results.
+		if retried := handler.attempts.Load() > 1; retried != testCase.expectRetry {
+			t.Errorf("test case %d: retry status does not match expected: %t != %t (%d attempts)",
+				i, retried, testCase.expectRetry, handler.attempts.Load(),
+			)
+		}
+		if surfaced := strings.Contains(lastError, testCase.err.Error()); surfaced != testCase.expectSurfaced {
+			t.Errorf("test case %d: error surfacing does not match expected: %t != %t (last error: %q)",
+				i, surfaced, testCase.expectSurfaced, lastError,
+			)
+		}
+	}
+}
+
+// deferralTestEndpoint is an Endpoint implementation that maintains its content
+// in memory and records regular and deferred scans separately. If growing is
+// true, then each regular scan observes a new directory (simulating external
+// modifications) and polling triggers a synchronization cycle, up to a fixed
+// number of cycles. Otherwise, polling never reports changes. Methods that
+// aren't explicitly implemented will panic if invoked.
+type deferralTestEndpoint struct {
+	Endpoint
+	// growing indicates whether or not each regular scan should observe a new
+	// directory.
+	growing bool
+	// maximumCycles is the maximum number of synchronization cycles that
+	// polling will trigger if growing is true.
+	maximumCycles uint32
+	// content is the endpoint content.
+	content *core.Entry
+	// scans is the number of regular scans that have been performed.
+	scans atomic.Uint32
+	// deferredScans is the number of deferred scans that have been performed.
+	deferredScans atomic.Uint32
+}
+
+// Poll implements Endpoint.Poll.
+func (e *deferralTestEndpoint) Poll(ctx context.Context) error {
+	if e.growing && e.scans.Load() < e.maximumCycles {
+		return nil
+	}
+	<-ctx.Done()
+	return nil
+}
+
+// Scan implements Endpoint.Scan.
+func (e *deferralTestEndpoint) Scan(_ context.Context, _ *core.Entry, _ bool, _ []string, deferred bool) (*core.Snapshot, error, bool) {
+	if deferred && e.scans.Load() > 0 {
+		e.deferredScans.Add(1)
+	} else {
+		scans := e.scans.Add(1)
+		if e.content == nil {
+			e.content = &core.Entry{Kind: core.EntryKind_Directory}
+		}
+		if e.growing {
+			e.content = e.content.Copy(core.EntryCopyBehaviorDeepPreservingLeaves)
+			if e.content.Contents == nil {
+				e.content.Contents = make(map[string]*core.Entry)
+			}
+			e.content.Contents[fmt.Sprintf("directory%d", scans)] = &core.Entry{Kind: core.EntryKind_Directory}
+		}
+	}
+	return &core.Snapshot{
+		Content:                e.content,
+		PreservesExecutability: true,
+		Directories:            e.content.Count(),
+	}, nil, false
+}
+
+// Transition implements Endpoint.Transition.
+func (e *deferralTestEndpoint) Transition(_ context.Context, transitions []*core.Change, _ bool) ([]*core.Entry, []*core.Problem, bool, error) {
+	results := make([]*core.Entry, len(transitions))
+	changes := make([]*core.Change, len(transitions))
+	for t, transition := range transitions {
+		results[t] = transition.New
+		changes[t] = &core.Change{Path: transition.Path, New: transition.New}
+	}
+	content, err := core.Apply(e.content, changes)
+	if err != nil {
+		return nil, nil, false, err
+	}
+	e.content = content
+	return results, nil, false, nil
+}
+
+// ClockOffset implements Endpoint.ClockOffset.
+func (e *deferralTestEndpoint) ClockOffset() time.Duration {
+	return 0
+}
+
+// WatchOverflows implements Endpoint.WatchOverflows.
+func (e *deferralTestEndpoint) WatchOverflows() uint64 {
+	return 0
+}
+
+// AccelerationUnavailable implements Endpoint.AccelerationUnavailable.
+func (e *deferralTestEndpoint) AccelerationUnavailable() string {
+	return ""
+}
+
+// OversizedFiles implements Endpoint.OversizedFiles.
+func (e *deferralTestEndpoint) OversizedFiles() uint64 {
+	return 0
+}
+
+// TestControllerBetaScanDeferral tests that beta scans are deferred for at most
+// the maximum beta scan deferral in unidirectional synchronization modes (and
+// never in bidirectional modes), and that beta still converges to alpha.
+func TestControllerBetaScanDeferral(t *testing.T) {
+	// Set up test cases.
+	testCases := []struct {
+		mode                  core.SynchronizationMode
+		maximumDeferral       uint32
+		expectedScans         uint32
+		expectedDeferredScans uint32
+	}{
+		{core.SynchronizationMode_SynchronizationModeOneWayReplica, 0, 10, 0},
+		{core.SynchronizationMode_SynchronizationModeOneWayReplica, 3, 3, 7},
+		{core.SynchronizationMode_SynchronizationModeOneWaySafe, 4, 2, 8},
+		{core.SynchronizationMode_SynchronizationModeTwoWayResolved, 3, 10, 0},
+	}
+
+	// Process test cases.
+	for i, testCase := range testCases {
+		// Create the controller and enable polling-triggered cycles.
+		controller := newTestController(t, InitialSynchronizationMode_InitialSynchronizationModeForce)
+		controller.session.Configuration.SynchronizationMode = testCase.mode
+		controller.session.Configuration.MaximumBetaScanDeferral = testCase.maximumDeferral
+		controller.mergedAlphaConfiguration = &Configuration{}
+		controller.mergedBetaConfiguration = &Configuration{}
+
+		// Create endpoints, with alpha observing new content on each scan.
+		alpha := &deferralTestEndpoint{growing: true, maximumCycles: 10}
+		beta := &deferralTestEndpoint{}
+
+		// Run the synchronization loop. It will sit in polling once alpha
+		// stops triggering cycles, until the context times out.
+		ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+		err := controller.synchronize(ctx, alpha, beta)
+		cancel()
+		if err == nil || err == errHaltedForSafety {
+			t.Errorf("test case %d: unexpected synchronization loop termination: %v", i, err)
+			continue
+		}
+
+		// Check the results.
+		if scans := alpha.scans.Load(); scans != 10 {
+			t.Errorf("test case %d: alpha scan count does not match expected: %d != 10", i, scans)
+		}
+		if scans := beta.scans.Load(); scans != testCase.expectedScans {
+			t.Errorf("test case %d: beta scan count does not match expected: %d != %d",
+				i, scans, testCase.expectedScans,
+			)
+		}
+		if scans := beta.deferredScans.Load(); scans != testCase.expectedDeferredScans {
+			t.Errorf("test case %d: beta deferred scan count does not match expected: %d != %d",
+				i, scans, testCase.expectedDeferredScans,
+			)
+		}
+		if !beta.content.Equal(alpha.content, true) {
+			t.Errorf("test case %d: beta content did not converge to alpha content", i)
+		}
+	}
+}
+
+// verificationTestEndpoint is an Endpoint implementation for testing background
+// verification. Its polling never reports changes and its verification scans
+// return fixed content. Methods that aren't explicitly implemented will panic
+// if invoked.
+type verificationTestEndpoint struct {
+	Endpoint
+	// content is the content returned by verification scans.
+	content *core.Entry
+	// verifications is the number of verification scans that have been
+	// performed.
+	verifications atomic.Uint32
+}
+
+// Poll implements Endpoint.Poll.
+func (e *verificationTestEndpoint) Poll(ctx context.Context) error {
+	<-ctx.Done()
+	return nil
+}
+
+// Verify implements Endpoint.Verify.
+func (e *verificationTestEndpoint) Verify(_ context.Context) (*core.Snapshot, error) {
+	e.verifications.Add(1)
+	return &core.Snapshot{Content: e.content}, nil
+}
+
+// ClockOffset implements Endpoint.ClockOffset.
+func (e *verificationTestEndpoint) ClockOffset() time.Duration {
+	return 0
+}
+
+// testVerificationClock is a controllable clock for scheduling verification.
+type testVerificationClock struct {
+	// requests receives the duration of each timer requested.
+	requests chan time.Duration
+	// ticks is returned as the channel for every requested timer.
+	ticks chan time.Time
+}
+
+// after implements the controller's verification clock.
+func (c *testVerificationClock) after(duration time.Duration) <-chan time.Time {
+	c.requests <- duration
+	return c.ticks
+}
+
+// TestControllerPeriodicVerification tests that background verification is
+// performed when the verification timer fires (and not before), that the timer
+// is armed with the configured interval and re-armed after each pass, and that
+// divergences from the ancestor are recorded in the session state.
+func TestControllerPeriodicVerification(t *testing.T) {
+	// Create the controller with periodic verification enabled and a
+	// controllable clock.
+	controller := newTestController(t, InitialSynchronizationMode_InitialSynchronizationModeDefault)
+	controller.session.Configuration.VerificationMode = VerificationMode_VerificationModePeriodic
+	controller.session.Configuration.VerificationInterval = 3600
+	clock := &testVerificationClock{
+		requests: make(chan time.Duration),
+		ticks:    make(chan time.Time),
+	}
+	controller.verificationClock = clock.after
+
+	// Create an ancestor and save it to the archive.
+	ancestor := &core.Entry{
+		Kind: core.EntryKind_Directory,
+		Contents: map[string]*core.Entry{
+			"file": {Kind: core.EntryKind_File, Digest: []byte{1}},
+		},
+	}
+	if err := encoding.MarshalAndSaveProtobuf(controller.archivePath, &core.Archive{Content: ancestor}); err != nil {
+		t.Fatal("unable to save archive:", err)
+	}
+
+	// Create endpoints, with beta's content having silently diverged.
+	alpha := &verificationTestEndpoint{content: ancestor}
+	beta := &verificationTestEndpoint{
+		content: &core.Entry{
+			Kind: core.EntryKind_Directory,
+			Contents: map[string]*core.Entry{
+				"file": {Kind: core.EntryKind_File, Digest: []byte{2}},
+			},
+		},
+	}
+
+	// Start the synchronization loop, which will sit in polling until the
+	// verification timer fires.
+	ctx, cancel := context.WithCancel(context.Background())
+	errs := make(chan error, 1)
+	go func() {
+		errs <- controller.synchronize(ctx, alpha, beta)
+	}()
+
+	// Define a helper to wait for a timer request and verify its duration.
+	waitForTimer := func() {
+		select {
+		case duration := <-clock.requests:
+			if duration != time.Hour {
+				t.Error("verification timer duration does not match expected:", duration)
+			}
+		case err := <-errs:
+			t.Fatal("synchronization loop terminated unexpectedly:", err)
+		case <-time.After(5 * time.Second):
+			t.Fatal("timed out waiting for verification timer")
+		}
+	}
+
+	// Wait for the verification timer to be armed and verify that no
+	// verification has been performed.
+	waitForTimer()
+	if alpha.verifications.Load() != 0 || beta.verifications.Load() != 0 {
+		t.Error("verification performed before timer fired")
+	}
+
+	// Fire the timer twice, verifying that each firing results in exactly one
+	// verification pass and a re-armed timer.
+	for i := uint32(1); i <= 2; i++ {
+		clock.ticks <- time.Now()
+		waitForTimer()
+		if count := alpha.verifications.Load(); count != i {
+			t.Errorf("alpha verification count does not match expected: %d != %d", count, i)
+		}
+		if count := beta.verifications.Load(); count != i {
+			t.Errorf("beta verification count does not match expected: %d != %d", count, i)
+		}
+	}
+
+	// Verify the recorded state.
+	controller.stateLock.Lock()
+	verifications := controller.state.Verifications
+	αDivergences := controller.state.AlphaState.VerificationDivergences
+	βDivergences := controller.state.BetaState.VerificationDivergences
+	controller.stateLock.UnlockWithoutNotify()
+	if verifications != 2 {
+		t.Error("verification count does not match expected:", verifications)
+	}
+	if len(αDivergences) != 0 {
+		t.Error("unexpected alpha divergences:", αDivergences)
+	}
+	if len(βDivergences) != 1 || βDivergences[0] != "file" {
+		t.Error("beta divergences do not match expected:", βDivergences)
+	}
+
+	// Terminate the synchronization loop.
+	cancel()
+	if err := <-errs; err == nil {
+		t.Error("synchronization loop terminated without error")
+	}
+}
+
+// TestControllerVerificationDisabledByDefault tests that no verification timer
+// is armed unless periodic verification is enabled.
+func TestControllerVerificationDisabledByDefault(t *testing.T) {
+	// Create the controller with a clock that fails the test if used.
+	controller := newTestController(t, InitialSynchronizationMode_InitialSynchronizationModeDefault)
+	controller.verificationClock = func(time.Duration) <-chan time.Time {
+		t.Error("verification timer armed with verification disabled")
+		return nil
+	}
+
+	// Run the synchronization loop until it times out in polling.
+	alpha, beta := &verificationTestEndpoint{}, &verificationTestEndpoint{}
+	ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+	controller.synchronize(ctx, alpha, beta)
+	cancel()
+
+	// Verify that no verification was performed.
+	if alpha.verifications.Load() != 0 || beta.verifications.Load() != 0 {
+		t.Error("verification performed with verification disabled")
+	}
+}
+
+// TestTruncateProblems tests that truncateProblems caps problem lists at the
+// specified maximum and reports the correct excluded problem count.
+func TestTruncateProblems(t *testing.T) {
+	// Create a problem list in reverse path order.
+	problems := make([]*core.Problem, 25)
+	for p := range problems {
+		problems[p] = &core.Problem{
+			Path:  fmt.Sprintf("path%02d", len(problems)-1-p),
+			Error: "error",
+		}
+	}
+
+	// Define test cases.
+	testCases := []struct {
+		maximum          uint32
+		expectedRetained int
+		expectedExcluded uint64
+	}{
+		{100, 25, 0},
+		{25, 25, 0},
+		{10, 10, 15},
+		{1, 1, 24},
+	}
+
+	// Process test cases.
+	for i, testCase := range testCases {
+		retained, excluded := truncateProblems(problems, testCase.maximum)
+		if len(retained) != testCase.expectedRetained {
+			t.Errorf("test case %d: retained problem count does not match expected: %d != %d",
+				i, len(retained), testCase.expectedRetained,
+			)
+		}
+		if excluded != testCase.expectedExcluded {
+			t.Errorf("test case %d: excluded problem count does not match expected: %d != %d",
+				i, excluded, testCase.expectedExcluded,
+			)
+		}
+		if excluded > 0 {
+			for r, problem := range retained {
+				if expected := fmt.Sprintf("path%02d", r); problem.Path != expected {
+					t.Errorf("test case %d: retained problem %d has unexpected path: %s != %s",
+						i, r, problem.Path, expected,
+					)
+				}
+			}
+		}
+	}
+
+	// Verify that the input list wasn't modified.
+	for p, problem := range problems {
+		if expected := fmt.Sprintf("path%02d", len(problems)-1-p); problem.Path != expected {
+			t.Fatalf("input problem %d modified: %s != %s", p, problem.Path, expected)
+		}
+	}
+}
@@ -0,0 +1,134 @@
+package core
+
+import (
+	"bytes"
+	"encoding/binary"
+	"hash"
+	"sort"
+
+	"github.com/mutagen-io/mutagen/pkg/synchronization/core/fastpath"
+)
+
+// aggregateDigestWriter encodes entry data into a hasher in an unambiguous
+// (length-prefixed) format for the purposes of computing aggregate digests.
+type aggregateDigestWriter struct {
+	// hasher is the underlying hasher.
+	hasher hash.Hash
+	// buffer is a scratch buffer for encoding variable-length integers.
+	buffer [binary.MaxVarintLen64]byte
+}
+
+// writeUvarint writes a variable-length unsigned integer.
+func (w *aggregateDigestWriter) writeUvarint(value uint64) {
+	w.hasher.Write(w.buffer[:binary.PutUvarint(w.buffer[:], value)])
+}
+
+// writeBytes writes a length-prefixed byte sequence.
+func (w *aggregateDigestWriter) writeBytes(value []byte) {
+	w.writeUvarint(uint64(len(value)))
+	w.hasher.Write(value)
+}
+
+// writeString writes a length-prefixed string.
+func (w *aggregateDigestWriter) writeString(value string) {
+	w.writeBytes([]byte(value))
+}
+
+// aggregateDigest computes the aggregate digest for a directory with the
+// specified contents. The digest covers the name and kind of each content entry
+// along with the properties relevant to Entry.Equal: digests and executability
+// for files, targets for symbolic links, problems for problematic content, and
+// aggregate digests for directories (making the digest recursive). Contents are
+// processed in sorted name order to ensure that the result is deterministic. If
+// any directory contained in the contents lacks an aggregate digest (e.g.
+// because it was reused from a baseline scanned without aggregate digests),
+// then no aggregate digest can be computed and nil is returned.
+func aggregateDigest(hasher hash.Hash, contents map[string]*Entry) []byte {
+	// Sort content names.
+	names := make([]string, 0, len(contents))
+	for name := range contents {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	// Encode contents.
+	hasher.Reset()
+	writer := &aggregateDigestWriter{hasher: hasher}
+	for _, name := range names {
+		entry := contents[name]
+		writer.writeString(name)
+		writer.writeUvarint(uint64(entry.Kind))
+		switch entry.Kind {
+		case EntryKind_Directory, EntryKind_PhantomDirectory:
+			if entry.AggregateDigest == nil {
+				return nil
+			}
+			writer.writeBytes(entry.AggregateDigest)
+		case EntryKind_File:
+			writer.writeBytes(entry.Digest)
+			if entry.Executable {
+				writer.writeUvarint(1)
+			} else {
+				writer.writeUvarint(0)
+			}
+		case EntryKind_SymbolicLink:
+			writer.writeString(entry.Target)
+		case EntryKind_Problematic:
+			writer.writeString(entry.Problem)
+		}
+	}
+
+	// Compute the digest.
+	return hasher.Sum(nil)
+}
+
+// DivergentPaths compares two entry hierarchies and returns the paths of the
+// top-most locations at which they differ (in sorted depth-first order). Paths
+// are computed assuming that the entries represent the synchronization root.
+// Directories present on both sides with equal (non-nil) aggregate digests are
+// considered identical and aren't descended into, allowing the comparison to
+// prune matching subtrees. Directories lacking aggregate digests are compared
+// recursively, so the result is correct (modulo digest collisions) regardless
+// of whether or not aggregate digests are available, though the comparison is
+// only fast if they are.
+func DivergentPaths(first, second *Entry) []string {
+	var result []string
+	divergentPaths("", first, second, &result)
+	return result
+}
+
+// divergentPaths is the recursive implementation of DivergentPaths.
+func divergentPaths(path string, first, second *Entry, result *[]string) {
+	// Handle the case where the entries aren't both directories of the same
+	// kind. In this case, there's no need to recurse.
+	bothDirectories := first != nil && second != nil && first.Kind == second.Kind &&
+		(first.Kind == EntryKind_Directory || first.Kind == EntryKind_PhantomDirectory)
+	if !bothDirectories {
+		if !first.Equal(second, true) {
+			*result = append(*result, path)
+		}
+		return
+	}
+
+	// If both directories have matching aggregate digests, then we can prune
+	// this subtree.
+	if first.AggregateDigest != nil && second.AggregateDigest != nil &&
+		bytes.Equal(first.AggregateDigest, second.AggregateDigest) {
+		return
+	}
+
+	// Otherwise compute the union of content names, sort them, and recurse.
+	names := make([]string, 0, len(first.Contents))
+	for name := range first.Contents {
+		names = append(names, name)
+	}
+	for name := range second.Contents {
+		if _, ok := first.Contents[name]; !ok {
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		divergentPaths(fastpath.Joinable(path)+name, first.Contents[name], second.Contents[name], result)
+	}
+}
@@ -0,0 +1,205 @@
+package core
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"slices"
+	"testing"
+
+	"github.com/mutagen-io/mutagen/pkg/filesystem/behavior"
+	mutagenignore "github.com/mutagen-io/mutagen/pkg/synchronization/core/ignore/mutagen"
+)
+
+// testingAggregateScan performs a scan with aggregate digests enabled.
+func testingAggregateScan(t *testing.T, root string, baseline *Snapshot, recheckPaths map[string]bool) *Snapshot {
+	// Create an ignorer.
+	ignorer, err := mutagenignore.NewIgnorer(nil)
+	if err != nil {
+		t.Fatal("unable to create ignorer:", err)
+	}
+
+	// Perform the scan.
+	snapshot, _, _, err := Scan(
+		context.Background(),
+		root,
+		baseline, recheckPaths,
+		newTestingHasher(), nil, CacheTrustMode_CacheTrustModeStrict,
+		ignorer, nil,
+		behavior.ProbeMode_ProbeModeProbe, nil,
+		SymbolicLinkMode_SymbolicLinkModePortable,
+		SpecialFileMode_SpecialFileModeIgnore,
+		NameNormalizationMode_NameNormalizationModePreserve,
+		PermissionsMode_PermissionsModePortable,
+		true,
+		false,
+		0,
+		0,
+		nil, 0,
+	)
+	if err != nil {
+		t.Fatal("unable to perform scan:", err)
+	} else if err = snapshot.EnsureValid(); err != nil {
+		t.Fatal("scan produced invalid snapshot:", err)
+	}
+
+	// Done.
+	return snapshot
+}
+
+// testingAggregateDirectory creates a directory entry with the specified
+// contents and computes its aggregate digest.
+func testingAggregateDirectory(contents map[string]*Entry) *Entry {
+	return &Entry{
+		Kind:            EntryKind_Directory,
+		Contents:        contents,
+		AggregateDigest: aggregateDigest(newTestingHasher(), contents),
+	}
+}
+
+// TestScanAggregateDigests tests that scans compute deterministic aggregate
+// digests, including when reusing baseline content in accelerated scans.
+func TestScanAggregateDigests(t *testing.T) {
+	// Create a directory hierarchy.
+	root := t.TempDir()
+	if err := os.MkdirAll(filepath.Join(root, "a", "b"), 0700); err != nil {
+		t.Fatal("unable to create directories:", err)
+	} else if err = os.Mkdir(filepath.Join(root, "c"), 0700); err != nil {
+		t.Fatal("unable to create directory:", err)
+	} else if err = os.WriteFile(filepath.Join(root, "a", "b", "file"), []byte("original"), 0600); err != nil {
+		t.Fatal("unable to create file:", err)
+	}
+
+	// Perform two cold scans and ensure that their aggregate digests match.
+	first := testingAggregateScan(t, root, nil, nil)
+	second := testingAggregateScan(t, root, nil, nil)
+	if first.Content.AggregateDigest == nil {
+		t.Fatal("scan did not compute aggregate digest")
+	} else if !slices.Equal(first.Content.AggregateDigest, second.Content.AggregateDigest) {
+		t.Error("aggregate digests not deterministic")
+	}
+
+	// Modify a file deep in the hierarchy and perform an accelerated scan.
+	if err := os.WriteFile(filepath.Join(root, "a", "b", "file"), []byte("modified"), 0600); err != nil {
+		t.Fatal("unable to modify file:", err)
+	}
+	accelerated := testingAggregateScan(t, root, first, map[string]bool{"a/b/file": true})
+
+	// Ensure that the aggregate digest changed for the modified directories
+	// but not for unmodified ones, and that they match a cold scan.
+	cold := testingAggregateScan(t, root, nil, nil)
+	if slices.Equal(first.Content.AggregateDigest, accelerated.Content.AggregateDigest) {
+		t.Error("root aggregate digest unchanged after modification")
+	} else if slices.Equal(first.Content.Contents["a"].AggregateDigest, accelerated.Content.Contents["a"].AggregateDigest) {
+		t.Error("modified directory aggregate digest unchanged after modification")
+	} else if !slices.Equal(first.Content.Contents["c"].AggregateDigest, accelerated.Content.Contents["c"].AggregateDigest) {
+		t.Error("unmodified directory aggregate digest changed")
+	} else if !slices.Equal(cold.Content.AggregateDigest, accelerated.Content.AggregateDigest) {
+		t.Error("accelerated scan aggregate digest does not match cold scan")
+	}
+}
+
+// TestEntryEqualAggregateDigests tests that deep entry comparisons short-circuit
+// on matching aggregate digests and otherwise fall back to content comparison.
+func TestEntryEqualAggregateDigests(t *testing.T) {
+	// Create directories with differing contents but a shared (fabricated)
+	// aggregate digest, which allows us to detect short-circuiting.
+	fabricated := []byte("fabricated")
+	first := &Entry{Kind: EntryKind_Directory, Contents: tD1.Contents, AggregateDigest: fabricated}
+	second := &Entry{Kind: EntryKind_Directory, Contents: tD2.Contents, AggregateDigest: fabricated}
+	if !first.Equal(second, true) {
+		t.Error("deep comparison did not short-circuit on matching aggregate digests")
+	}
+
+	// Verify that directories with equal contents but differing or absent
+	// aggregate digests are still compared by content.
+	firstAggregate := testingAggregateDirectory(tD1.Contents)
+	secondAggregate := &Entry{Kind: EntryKind_Directory, Contents: tD1.Contents, AggregateDigest: fabricated}
+	if !firstAggregate.Equal(secondAggregate, true) {
+		t.Error("directories with differing aggregate digests compared unequal")
+	} else if !firstAggregate.Equal(tD1, true) || !tD1.Equal(firstAggregate, true) {
+		t.Error("directories with absent aggregate digest compared unequal")
+	}
+
+	// Verify that directories with differing contents are still found unequal
+	// when aggregate digests don't match.
+	if testingAggregateDirectory(tD1.Contents).Equal(testingAggregateDirectory(tD2.Contents), true) {
+		t.Error("directories with differing contents compared equal")
+	} else if second.Equal(tD1, true) {
+		t.Error("directories with differing contents compared equal with absent aggregate digest")
+	}
+}
+
+// TestAggregateDigestRequiresChildAggregates tests that aggregate digests are
+// not computed if a child directory lacks an aggregate digest.
+func TestAggregateDigestRequiresChildAggregates(t *testing.T) {
+	contents := map[string]*Entry{"directory": {Kind: EntryKind_Directory}}
+	if aggregateDigest(newTestingHasher(), contents) != nil {
+		t.Error("aggregate digest computed despite missing child aggregate digest")
+	}
+}
+
+// TestDivergentPaths tests that DivergentPaths identifies a single divergent
+// deep subtree and prunes subtrees with matching aggregate digests.
+func TestDivergentPaths(t *testing.T) {
+	// Create a helper to generate hierarchies that differ only by the contents
+	// of a deeply nested file. Each hierarchy also contains a subtree
+	// ("pruned") whose contents differ, but which has been assigned a shared
+	// (fabricated) aggregate digest, allowing us to detect pruning.
+	fabricated := []byte("fabricated")
+	hierarchy := func(content string, aggregates bool) *Entry {
+		directory := testingAggregateDirectory
+		if !aggregates {
+			directory = func(contents map[string]*Entry) *Entry {
+				return &Entry{Kind: EntryKind_Directory, Contents: contents}
+			}
+		}
+		pruned := directory(map[string]*Entry{
+			content: {Kind: EntryKind_File, Digest: testingDigest(content)},
+		})
+		if aggregates {
+			pruned.AggregateDigest = fabricated
+		}
+		return directory(map[string]*Entry{
+			"shared": directory(map[string]*Entry{
+				"file": {Kind: EntryKind_File, Digest: testingDigest("shared")},
+				"link": {Kind: EntryKind_SymbolicLink, Target: "file"},
+			}),
+			"pruned": pruned,
+			"a": directory(map[string]*Entry{
+				"b": directory(map[string]*Entry{
+					"c": directory(map[string]*Entry{
+						"file": {Kind: EntryKind_File, Digest: testingDigest(content)},
+					}),
+				}),
+			}),
+		})
+	}
+
+	// Verify that comparison with aggregate digests identifies only the deep
+	// subtree and prunes the fabricated subtree.
+	alpha, beta := hierarchy("alpha", true), hierarchy("beta", true)
+	if result := DivergentPaths(alpha, beta); !slices.Equal(result, []string{"a/b/c/file"}) {
+		t.Error("divergent paths do not match expected:", result)
+	}
+
+	// Verify that identical hierarchies are pruned at the root.
+	if result := DivergentPaths(alpha, hierarchy("alpha", true)); len(result) != 0 {
+		t.Error("identical hierarchies reported as divergent:", result)
+	}
+
+	// Verify that comparison without aggregate digests still produces correct
+	// results (including the differences in the previously pruned subtree).
+	alpha, beta = hierarchy("alpha", false), hierarchy("beta", false)
+	expected := []string{"a/b/c/file", "pruned/alpha", "pruned/beta"}
+	if result := DivergentPaths(alpha, beta); !slices.Equal(result, expected) {
+		t.Error("divergent paths do not match expected:", result)
+	}
+
+	// Verify handling of absent and differing roots.
+	if result := DivergentPaths(nil, nil); len(result) != 0 {
+		t.Error("nil hierarchies reported as divergent:", result)
+	} else if result = DivergentPaths(alpha, nil); !slices.Equal(result, []string{""}) {
+		t.Error("root divergence not reported:", result)
+	}
+}
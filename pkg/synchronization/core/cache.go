@@ -61,21 +61,8 @@ func (c *Cache) Equal(other *Cache) bool {
 			return false
 		}
 
-		// Watch for nil values as a sanity check.
-		if entry == nil || otherEntry == nil {
-			panic("cache has nil entry")
-		} else if entry.ModificationTime == nil || otherEntry.ModificationTime == nil {
-			panic("nil modification time in cache")
-		}
-
 		// Verify equivalence
-		equivalent := otherEntry.Mode == entry.Mode &&
-			otherEntry.ModificationTime.Seconds == entry.ModificationTime.Seconds &&
-			otherEntry.ModificationTime.Nanos == entry.ModificationTime.Nanos &&
-			otherEntry.Size == entry.Size &&
-			otherEntry.FileID == entry.FileID &&
-			bytes.Equal(otherEntry.Digest, entry.Digest)
-		if !equivalent {
+		if !entry.equal(otherEntry) {
 			return false
 		}
 	}
@@ -84,6 +71,61 @@ func (c *Cache) Equal(other *Cache) bool {
 	return true
 }
 
+// cachedDigestMatches determines whether or not a cached digest matches a
+// snapshot digest. Snapshot digests may be truncated (whereas cached digests
+// are not), in which case the snapshot digest need only match a prefix of the
+// cached digest.
+func cachedDigestMatches(cached, snapshot []byte) bool {
+	return len(snapshot) > 0 && bytes.HasPrefix(cached, snapshot)
+}
+
+// equal determines whether or not another cache entry is equal to this one.
+func (e *CacheEntry) equal(other *CacheEntry) bool {
+	// Watch for nil values as a sanity check.
+	if e == nil || other == nil {
+		panic("cache has nil entry")
+	} else if e.ModificationTime == nil || other.ModificationTime == nil {
+		panic("nil modification time in cache")
+	}
+
+	// Check equivalence.
+	return other.Mode == e.Mode &&
+		other.ModificationTime.Seconds == e.ModificationTime.Seconds &&
+		other.ModificationTime.Nanos == e.ModificationTime.Nanos &&
+		other.Size == e.Size &&
+		other.FileID == e.FileID &&
+		bytes.Equal(other.Digest, e.Digest) &&
+		bytes.Equal(other.SampledDigest, e.SampledDigest)
+}
+
+// Differences computes the number of paths whose cache entries differ between
+// this cache and another, including paths that are present in only one of the
+// caches. A nil cache is treated as empty.
+func (c *Cache) Differences(other *Cache) uint64 {
+	// Handle the trivial case of identical caches.
+	if c == other {
+		return 0
+	}
+
+	// Count entries that are absent from or modified in the other cache.
+	var result uint64
+	for path, entry := range c.GetEntries() {
+		if otherEntry, ok := other.GetEntries()[path]; !ok || !entry.equal(otherEntry) {
+			result++
+		}
+	}
+
+	// Count entries that are present only in the other cache.
+	for path := range other.GetEntries() {
+		if _, ok := c.GetEntries()[path]; !ok {
+			result++
+		}
+	}
+
+	// Done.
+	return result
+}
+
 // byteLookupMap is the interface implemented by all byteLookupMap types.
 type byteLookupMap interface {
 	// length returns the length of the map.
@@ -101,6 +143,13 @@ type byteLookupMap interface {
 type ReverseLookupMap struct {
 	// lookupMap is the underlying map.
 	lookupMap byteLookupMap
+	// keyLength is the length to which digests are truncated to form map keys.
+	// A zero value indicates that digests aren't truncated.
+	keyLength int
+	// entries are the cache entries from which the map was generated. They're
+	// used to verify full digests when keys are truncated and to provide the
+	// scanned sizes of paths.
+	entries map[string]*CacheEntry
 }
 
 // Length returns the number of entries in the map.
@@ -108,39 +157,90 @@ func (m *ReverseLookupMap) Length() int {
 	return m.lookupMap.length()
 }
 
-// Lookup attempts a lookup in the map.
+// Lookup attempts a lookup in the map. If the map uses truncated keys, then the
+// full digest of any candidate path is verified before it's returned, so a key
+// collision can only cause a lookup to miss, never to return a path with
+// different content.
 func (m *ReverseLookupMap) Lookup(digest []byte) (string, bool) {
-	return m.lookupMap.find(digest)
+	// Handle the full digest case.
+	if m.keyLength == 0 {
+		return m.lookupMap.find(digest)
+	}
+
+	// Look up a candidate using the truncated digest.
+	if len(digest) < m.keyLength {
+		return "", false
+	}
+	path, ok := m.lookupMap.find(digest[:m.keyLength])
+	if !ok {
+		return "", false
+	}
+
+	// Verify the candidate's full digest.
+	if entry, ok := m.entries[path]; !ok || !bytes.Equal(entry.Digest, digest) {
+		return "", false
+	}
+	return path, true
 }
 
-// GenerateReverseLookupMap creates a reverse lookup map from a cache.
-func (c *Cache) GenerateReverseLookupMap() (*ReverseLookupMap, error) {
+// Size returns the size of the specified path as recorded by the scan from
+// which the map was generated, as well as whether or not the path was present.
+func (m *ReverseLookupMap) Size(path string) (uint64, bool) {
+	if entry, ok := m.entries[path]; ok {
+		return entry.Size, true
+	}
+	return 0, false
+}
+
+// Contains determines whether or not a lookup for the specified digest would
+// succeed.
+func (m *ReverseLookupMap) Contains(digest []byte) bool {
+	_, ok := m.Lookup(digest)
+	return ok
+}
+
+// GenerateReverseLookupMap creates a reverse lookup map from a cache. If
+// keyLength is non-zero and less than the length of the cache's digests, then
+// digests are truncated to keyLength bytes to form map keys, reducing the map's
+// memory usage. Truncation can cause distinct digests to share a key, in which
+// case only one of the corresponding paths is retained. This reduces the
+// effectiveness of rename and copy detection, but doesn't affect correctness,
+// since lookups always verify full digests.
+func (c *Cache) GenerateReverseLookupMap(keyLength uint32) (*ReverseLookupMap, error) {
 	// Create a placeholder for the map that we're going to initialize.
 	var lookupMap byteLookupMap
 
 	// Track the digest size and ensure it's consistent.
 	digestSize := -1
 
+	// Track the effective key size.
+	var keySize int
+
 	// Loop over entries.
 	for p, e := range c.Entries {
-		// Compute and validate the digest size and allocate the map.
+		// Compute and validate the digest size, compute the key size, and
+		// allocate the map.
 		if digestSize == -1 {
 			digestSize = len(e.Digest)
-			if digestSize == 20 {
+			keySize = digestSize
+			if keyLength != 0 && uint64(keyLength) < uint64(digestSize) {
+				keySize = int(keyLength)
+			}
+			if keySize == 20 {
 				lookupMap = make(byteLookupMap20, len(c.Entries))
-			} else if digestSize == 32 {
+			} else if keySize == 32 {
 				lookupMap = make(byteLookupMap32, len(c.Entries))
-			} else if digestSize == 16 {
+			} else if keySize == 16 {
 				lookupMap = make(byteLookupMap16, len(c.Entries))
 			} else {
-				return nil, errors.New("unsupported digest size")
+				lookupMap = make(byteLookupMapGeneric, len(c.Entries))
 			}
 		} else if len(e.Digest) != digestSize {
 			return nil, errors.New("inconsistent digest sizes")
 		}
 
 		// Insert the entry.
-		lookupMap.insert(e.Digest, p)
+		lookupMap.insert(e.Digest[:keySize], p)
 	}
 
 	// If there are no entries, then we'll still need a lookup map.
@@ -148,6 +248,12 @@ func (c *Cache) GenerateReverseLookupMap() (*ReverseLookupMap, error) {
 		lookupMap = &emptyByteLookupMap{}
 	}
 
+	// Create the result, recording truncation information if necessary.
+	result := &ReverseLookupMap{lookupMap: lookupMap, entries: c.Entries}
+	if keySize < digestSize {
+		result.keyLength = keySize
+	}
+
 	// Success.
-	return &ReverseLookupMap{lookupMap}, nil
+	return result, nil
 }
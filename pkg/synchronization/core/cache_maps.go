@@ -92,3 +92,26 @@ func (m byteLookupMap16) find(k []byte) (string, bool) {
 	result, ok := m[key]
 	return result, ok
 }
+
+// byteLookupMapGeneric implements byteLookupMap for digests of arbitrary size.
+// It's used for digest sizes without a fixed-size implementation, such as those
+// produced by registered hashing algorithms or digest truncation.
+type byteLookupMapGeneric map[string]string
+
+// length returns the length of the map.
+func (m byteLookupMapGeneric) length() int {
+	return len(m)
+}
+
+// insert adds a key-value pair to the map.
+func (m byteLookupMapGeneric) insert(k []byte, v string) {
+	m[string(k)] = v
+}
+
+// find looks for a key in the map, returning the associated value (defaulting
+// to an empty string if the key was not present) and whether or not the key was
+// found.
+func (m byteLookupMapGeneric) find(k []byte) (string, bool) {
+	result, ok := m[string(k)]
+	return result, ok
+}
@@ -1,6 +1,7 @@
 package core
 
 import (
+	"bytes"
 	"math"
 	"testing"
 
@@ -66,4 +67,175 @@ func TestCacheEnsureValid(t *testing.T) {
 // TODO: Implement TestCacheEqual. This is purely an internal testing method,
 // but it's worth testing for completeness.
 
-// TODO: Implement TestReverseLookupMap.
+// TestReverseLookupMap tests reverse lookup map generation and lookups for a
+// variety of digest sizes.
+func TestReverseLookupMap(t *testing.T) {
+	// Process test cases, which include sizes with and without fixed-size map
+	// implementations.
+	for _, size := range []int{8, 12, 16, 20, 32, 64} {
+		// Create test digests.
+		first := bytes.Repeat([]byte{1}, size)
+		second := bytes.Repeat([]byte{2}, size)
+		absent := bytes.Repeat([]byte{3}, size)
+
+		// Generate a reverse lookup map.
+		cache := &Cache{Entries: map[string]*CacheEntry{
+			"first":  {Digest: first, Size: 1},
+			"second": {Digest: second, Size: 2},
+		}}
+		lookupMap, err := cache.GenerateReverseLookupMap(0)
+		if err != nil {
+			t.Errorf("size %d: unable to generate reverse lookup map: %v", size, err)
+			continue
+		} else if length := lookupMap.Length(); length != 2 {
+			t.Errorf("size %d: reverse lookup map length does not match expected: %d != 2", size, length)
+		}
+
+		// Perform lookups.
+		if path, ok := lookupMap.Lookup(first); !ok || path != "first" {
+			t.Errorf("size %d: unable to look up first digest", size)
+		}
+		if path, ok := lookupMap.Lookup(second); !ok || path != "second" {
+			t.Errorf("size %d: unable to look up second digest", size)
+		}
+		if _, ok := lookupMap.Lookup(absent); ok {
+			t.Errorf("size %d: lookup of absent digest succeeded", size)
+		}
+
+		// Perform size queries.
+		if s, ok := lookupMap.Size("second"); !ok || s != 2 {
+			t.Errorf("size %d: unable to query size of second path", size)
+		}
+		if _, ok := lookupMap.Size("absent"); ok {
+			t.Errorf("size %d: size query for absent path succeeded", size)
+		}
+	}
+
+	// Verify that inconsistent digest sizes are rejected.
+	cache := &Cache{Entries: map[string]*CacheEntry{
+		"first":  {Digest: bytes.Repeat([]byte{1}, 16)},
+		"second": {Digest: bytes.Repeat([]byte{2}, 20)},
+	}}
+	if _, err := cache.GenerateReverseLookupMap(0); err == nil {
+		t.Error("reverse lookup map generation succeeded with inconsistent digest sizes")
+	}
+}
+
+// TestReverseLookupMapTruncation tests that reverse lookup maps with truncated
+// keys never return paths with mismatched digests, even in the presence of key
+// collisions, and that Lookup and Contains agree.
+func TestReverseLookupMapTruncation(t *testing.T) {
+	// Create test digests. The first two share a truncated key, while the
+	// third has a distinct truncated key. The absent digest shares the first
+	// two digests' truncated key but isn't present in the cache.
+	prefix := bytes.Repeat([]byte{1}, 8)
+	first := append(append([]byte{}, prefix...), bytes.Repeat([]byte{2}, 12)...)
+	second := append(append([]byte{}, prefix...), bytes.Repeat([]byte{3}, 12)...)
+	distinct := bytes.Repeat([]byte{4}, 20)
+	absent := append(append([]byte{}, prefix...), bytes.Repeat([]byte{5}, 12)...)
+	digests := map[string][]byte{"first": first, "second": second, "distinct": distinct}
+
+	// Create the cache.
+	cache := &Cache{Entries: make(map[string]*CacheEntry, len(digests))}
+	for path, digest := range digests {
+		cache.Entries[path] = &CacheEntry{Digest: digest}
+	}
+
+	// Process test cases, including key lengths that disable truncation.
+	for _, keyLength := range []uint32{8, 16, 20, 32} {
+		// Generate the reverse lookup map.
+		lookupMap, err := cache.GenerateReverseLookupMap(keyLength)
+		if err != nil {
+			t.Errorf("key length %d: unable to generate reverse lookup map: %v", keyLength, err)
+			continue
+		}
+
+		// Verify that lookups never return a path with a different digest and
+		// that Lookup and Contains agree.
+		var found int
+		for _, digest := range [][]byte{first, second, distinct, absent} {
+			path, ok := lookupMap.Lookup(digest)
+			if ok {
+				found++
+				if !bytes.Equal(digests[path], digest) {
+					t.Errorf("key length %d: lookup returned path with mismatched digest: %s", keyLength, path)
+				}
+			}
+			if contains := lookupMap.Contains(digest); contains != ok {
+				t.Errorf("key length %d: Contains and Lookup disagree", keyLength)
+			}
+		}
+
+		// Verify that the distinct digest is always found and that colliding
+		// keys only affect lookup effectiveness.
+		if !lookupMap.Contains(distinct) {
+			t.Errorf("key length %d: unable to look up distinct digest", keyLength)
+		}
+		expectedFound := 3
+		if keyLength == 8 {
+			expectedFound = 2
+		}
+		if found != expectedFound {
+			t.Errorf("key length %d: lookup count does not match expected: %d != %d", keyLength, found, expectedFound)
+		}
+	}
+}
+
+// TestCacheDifferences tests Cache.Differences.
+func TestCacheDifferences(t *testing.T) {
+	// Create test caches.
+	base := &Cache{Entries: map[string]*CacheEntry{
+		"unchanged": {ModificationTime: &timestamppb.Timestamp{Seconds: 1}, Size: 1},
+		"modified":  {ModificationTime: &timestamppb.Timestamp{Seconds: 1}, Size: 1},
+		"removed":   {ModificationTime: &timestamppb.Timestamp{Seconds: 1}, Size: 1},
+	}}
+	changed := &Cache{Entries: map[string]*CacheEntry{
+		"unchanged": {ModificationTime: &timestamppb.Timestamp{Seconds: 1}, Size: 1},
+		"modified":  {ModificationTime: &timestamppb.Timestamp{Seconds: 2}, Size: 1},
+		"added":     {ModificationTime: &timestamppb.Timestamp{Seconds: 1}, Size: 1},
+	}}
+
+	// Define test cases.
+	tests := []struct {
+		first    *Cache
+		second   *Cache
+		expected uint64
+	}{
+		{nil, nil, 0},
+		{base, base, 0},
+		{base, nil, 3},
+		{nil, base, 3},
+		{base, changed, 3},
+		{changed, base, 3},
+	}
+
+	// Process test cases.
+	for i, test := range tests {
+		if result := test.first.Differences(test.second); result != test.expected {
+			t.Errorf("test index %d: difference count (%d) does not match expected (%d)", i, result, test.expected)
+		}
+	}
+}
+
+// TestCachedDigestMatches tests cachedDigestMatches.
+func TestCachedDigestMatches(t *testing.T) {
+	// Define test cases.
+	cached := []byte{0, 1, 2, 3, 4, 5, 6, 7, 8, 9}
+	tests := []struct {
+		snapshot []byte
+		expected bool
+	}{
+		{nil, false},
+		{cached, true},
+		{cached[:8], true},
+		{[]byte{1, 1, 2, 3, 4, 5, 6, 7}, false},
+		{append(cached, 10), false},
+	}
+
+	// Process test cases.
+	for i, test := range tests {
+		if result := cachedDigestMatches(cached, test.snapshot); result != test.expected {
+			t.Errorf("test index %d: match result (%t) does not match expected (%t)", i, result, test.expected)
+		}
+	}
+}
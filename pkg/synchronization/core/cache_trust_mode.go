@@ -0,0 +1,72 @@
+package core
+
+import (
+	"fmt"
+)
+
+// IsDefault indicates whether or not the cache trust mode is
+// CacheTrustMode_CacheTrustModeDefault.
+func (m CacheTrustMode) IsDefault() bool {
+	return m == CacheTrustMode_CacheTrustModeDefault
+}
+
+// MarshalText implements encoding.TextMarshaler.MarshalText.
+func (m CacheTrustMode) MarshalText() ([]byte, error) {
+	var result string
+	switch m {
+	case CacheTrustMode_CacheTrustModeDefault:
+	case CacheTrustMode_CacheTrustModeStrict:
+		result = "strict"
+	case CacheTrustMode_CacheTrustModeIgnoreFileID:
+		result = "ignore-file-id"
+	default:
+		result = "unknown"
+	}
+	return []byte(result), nil
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler.UnmarshalText.
+func (m *CacheTrustMode) UnmarshalText(textBytes []byte) error {
+	// Convert the bytes to a string.
+	text := string(textBytes)
+
+	// Convert to a cache trust mode.
+	switch text {
+	case "strict":
+		*m = CacheTrustMode_CacheTrustModeStrict
+	case "ignore-file-id":
+		*m = CacheTrustMode_CacheTrustModeIgnoreFileID
+	default:
+		return fmt.Errorf("unknown cache trust mode specification: %s", text)
+	}
+
+	// Success.
+	return nil
+}
+
+// Supported indicates whether or not a particular cache trust mode is a valid,
+// non-default value.
+func (m CacheTrustMode) Supported() bool {
+	switch m {
+	case CacheTrustMode_CacheTrustModeStrict:
+		return true
+	case CacheTrustMode_CacheTrustModeIgnoreFileID:
+		return true
+	default:
+		return false
+	}
+}
+
+// Description returns a human-readable description of a cache trust mode.
+func (m CacheTrustMode) Description() string {
+	switch m {
+	case CacheTrustMode_CacheTrustModeDefault:
+		return "Default"
+	case CacheTrustMode_CacheTrustModeStrict:
+		return "Strict"
+	case CacheTrustMode_CacheTrustModeIgnoreFileID:
+		return "Ignore File ID"
+	default:
+		return "Unknown"
+	}
+}
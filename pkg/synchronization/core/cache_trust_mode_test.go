@@ -0,0 +1,112 @@
+package core
+
+import (
+	"testing"
+)
+
+// TestCacheTrustModeIsDefault tests CacheTrustMode.IsDefault.
+func TestCacheTrustModeIsDefault(t *testing.T) {
+	// Define test cases.
+	tests := []struct {
+		value    CacheTrustMode
+		expected bool
+	}{
+		{CacheTrustMode_CacheTrustModeDefault - 1, false},
+		{CacheTrustMode_CacheTrustModeDefault, true},
+		{CacheTrustMode_CacheTrustModeStrict, false},
+		{CacheTrustMode_CacheTrustModeIgnoreFileID, false},
+		{CacheTrustMode_CacheTrustModeIgnoreFileID + 1, false},
+	}
+
+	// Process test cases.
+	for i, test := range tests {
+		if result := test.value.IsDefault(); result && !test.expected {
+			t.Errorf("test index %d: value was unexpectedly classified as default", i)
+		} else if !result && test.expected {
+			t.Errorf("test index %d: value was unexpectedly classified as non-default", i)
+		}
+	}
+}
+
+// TestCacheTrustModeUnmarshalText tests CacheTrustMode.UnmarshalText.
+func TestCacheTrustModeUnmarshalText(t *testing.T) {
+	// Define test cases.
+	tests := []struct {
+		text          string
+		expectedMode  CacheTrustMode
+		expectFailure bool
+	}{
+		{"", CacheTrustMode_CacheTrustModeDefault, true},
+		{"asdf", CacheTrustMode_CacheTrustModeDefault, true},
+		{"strict", CacheTrustMode_CacheTrustModeStrict, false},
+		{"ignore-file-id", CacheTrustMode_CacheTrustModeIgnoreFileID, false},
+	}
+
+	// Process test cases.
+	for _, test := range tests {
+		var mode CacheTrustMode
+		if err := mode.UnmarshalText([]byte(test.text)); err != nil {
+			if !test.expectFailure {
+				t.Errorf("unable to unmarshal text (%s): %s", test.text, err)
+			}
+		} else if test.expectFailure {
+			t.Error("unmarshaling succeeded unexpectedly for text:", test.text)
+		} else if mode != test.expectedMode {
+			t.Errorf(
+				"unmarshaled mode (%s) does not match expected (%s)",
+				mode,
+				test.expectedMode,
+			)
+		}
+	}
+}
+
+// TestCacheTrustModeSupported tests CacheTrustMode.Supported.
+func TestCacheTrustModeSupported(t *testing.T) {
+	// Set up test cases.
+	testCases := []struct {
+		mode            CacheTrustMode
+		expectSupported bool
+	}{
+		{CacheTrustMode_CacheTrustModeDefault, false},
+		{CacheTrustMode_CacheTrustModeStrict, true},
+		{CacheTrustMode_CacheTrustModeIgnoreFileID, true},
+		{(CacheTrustMode_CacheTrustModeIgnoreFileID + 1), false},
+	}
+
+	// Process test cases.
+	for _, testCase := range testCases {
+		if supported := testCase.mode.Supported(); supported != testCase.expectSupported {
+			t.Errorf(
+				"mode support status (%t) does not match expected (%t)",
+				supported,
+				testCase.expectSupported,
+			)
+		}
+	}
+}
+
+// TestCacheTrustModeDescription tests CacheTrustMode.Description.
+func TestCacheTrustModeDescription(t *testing.T) {
+	// Set up test cases.
+	testCases := []struct {
+		mode                CacheTrustMode
+		expectedDescription string
+	}{
+		{CacheTrustMode_CacheTrustModeDefault, "Default"},
+		{CacheTrustMode_CacheTrustModeStrict, "Strict"},
+		{CacheTrustMode_CacheTrustModeIgnoreFileID, "Ignore File ID"},
+		{(CacheTrustMode_CacheTrustModeIgnoreFileID + 1), "Unknown"},
+	}
+
+	// Process test cases.
+	for _, testCase := range testCases {
+		if description := testCase.mode.Description(); description != testCase.expectedDescription {
+			t.Errorf(
+				"mode description (%s) does not match expected (%s)",
+				description,
+				testCase.expectedDescription,
+			)
+		}
+	}
+}
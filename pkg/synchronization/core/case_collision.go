@@ -0,0 +1,96 @@
+package core
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// foldCase computes the case-folded form of a content name used to detect
+// names that would collide on a case-insensitive filesystem.
+func foldCase(name string) string {
+	return strings.ToLower(name)
+}
+
+// markCaseCollisions is the recursive implementation of MarkCaseCollisions. It
+// returns the (possibly unmodified) entry and whether or not it was modified.
+func markCaseCollisions(entry *Entry) (*Entry, bool) {
+	// If this isn't a directory with contents, then there's nothing to mark.
+	if entry == nil || entry.Kind != EntryKind_Directory || len(entry.Contents) == 0 {
+		return entry, false
+	}
+
+	// Group synchronizable content names by their case-folded form. We ignore
+	// unsynchronizable content because it won't be propagated.
+	groups := make(map[string][]string, len(entry.Contents))
+	for name, child := range entry.Contents {
+		if child != nil && child.Kind.synchronizable() {
+			folded := foldCase(name)
+			groups[folded] = append(groups[folded], name)
+		}
+	}
+
+	// Compute the replacement contents, marking colliding names and recursing
+	// into non-colliding content. We only allocate a new content map once a
+	// modification is detected.
+	var contents map[string]*Entry
+	for name, child := range entry.Contents {
+		var replacement *Entry
+		if group := groups[foldCase(name)]; len(group) > 1 {
+			others := make([]string, 0, len(group)-1)
+			for _, other := range group {
+				if other != name {
+					others = append(others, fmt.Sprintf("\"%s\"", other))
+				}
+			}
+			sort.Strings(others)
+			replacement = &Entry{
+				Kind:        EntryKind_Problematic,
+				Problem:     "name differs only in case from " + strings.Join(others, ", "),
+				ProblemCode: ProblemCode_ProblemCodeCaseCollision,
+			}
+		} else if marked, modified := markCaseCollisions(child); modified {
+			replacement = marked
+		} else {
+			continue
+		}
+		if contents == nil {
+			contents = make(map[string]*Entry, len(entry.Contents))
+			for n, c := range entry.Contents {
+				contents[n] = c
+			}
+		}
+		contents[name] = replacement
+	}
+
+	// If nothing was modified, then we can return the original entry.
+	if contents == nil {
+		return entry, false
+	}
+
+	// Create a modified copy of the entry.
+	result := entry.Copy(EntryCopyBehaviorSlim)
+	result.Contents = contents
+	return result, true
+}
+
+// MarkCaseCollisions returns a derived version of an entry hierarchy in which
+// all synchronizable entries whose names differ only in case from those of
+// sibling entries are replaced by problematic entries with a problem code of
+// ProblemCode_ProblemCodeCaseCollision. It is designed to be applied to the
+// content of one endpoint before reconciliation if the other endpoint ignores
+// case, since such entries would collide when propagated. Reconcile will then
+// report the affected paths as conflicts rather than propagating either name.
+// The original entry hierarchy isn't modified and unmodified subtrees are
+// shared with the result.
+func MarkCaseCollisions(entry *Entry) *Entry {
+	result, _ := markCaseCollisions(entry)
+	return result
+}
+
+// isCaseCollision returns whether or not an entry has been marked as a case
+// collision by MarkCaseCollisions.
+func (e *Entry) isCaseCollision() bool {
+	return e != nil && e.Kind == EntryKind_Problematic &&
+		e.ProblemCode == ProblemCode_ProblemCodeCaseCollision
+}
@@ -0,0 +1,115 @@
+package core
+
+import (
+	"testing"
+)
+
+// TestMarkCaseCollisions tests MarkCaseCollisions.
+func TestMarkCaseCollisions(t *testing.T) {
+	// Define test cases.
+	testCases := []struct {
+		// description is a human readable description of the test case.
+		description string
+		// entry is the entry to mark.
+		entry *Entry
+		// expected is the expected result.
+		expected *Entry
+	}{
+		{"nil", nil, nil},
+		{"file", tF1, tF1},
+		{"directory without collisions", tDM, tDM},
+		{"directory with unsynchronizable content", tDMU, tDMU},
+		{"directory with collisions", tDRC, tDRCP},
+		{
+			"nested directory with collisions",
+			&Entry{Contents: map[string]*Entry{"nested": tDRC, "file": tF1}},
+			&Entry{Contents: map[string]*Entry{"nested": tDRCP, "file": tF1}},
+		},
+		{
+			"collision with untracked content",
+			&Entry{Contents: map[string]*Entry{"README": tF1, "readme": tU}},
+			&Entry{Contents: map[string]*Entry{"README": tF1, "readme": tU}},
+		},
+	}
+
+	// Process test cases.
+	for _, testCase := range testCases {
+		// Create a deep copy of the entry so that we can verify that it isn't
+		// modified.
+		original := testCase.entry.Copy(EntryCopyBehaviorDeep)
+
+		// Perform marking and verify the result.
+		result := MarkCaseCollisions(testCase.entry)
+		if !result.Equal(testCase.expected, true) {
+			t.Errorf("%s: result does not match expected", testCase.description)
+		} else if err := result.EnsureValid(false); err != nil {
+			t.Errorf("%s: result is invalid: %v", testCase.description, err)
+		}
+
+		// Verify that entries without collisions are returned unmodified.
+		if testCase.entry == testCase.expected && result != testCase.entry {
+			t.Errorf("%s: entry without collisions was copied", testCase.description)
+		}
+
+		// Verify that the original entry wasn't modified.
+		if !testCase.entry.Equal(original, true) {
+			t.Errorf("%s: original entry modified", testCase.description)
+		}
+	}
+}
+
+// TestReconcileCaseCollisions tests that Reconcile reports conflicts for
+// content marked as colliding by MarkCaseCollisions and still propagates
+// non-colliding content.
+func TestReconcileCaseCollisions(t *testing.T) {
+	// Perform reconciliation between an empty case-insensitive alpha and a
+	// beta containing names differing only in case.
+	ancestorChanges, alphaChanges, betaChanges, conflicts := Reconcile(
+		nil,
+		tD0,
+		MarkCaseCollisions(tDRC),
+		SynchronizationMode_SynchronizationModeTwoWaySafe,
+		TypeChangeMode_TypeChangeModePropagate,
+		0, 0,
+		nil,
+	)
+
+	// Verify the ancestor changes.
+	expectedAncestorChanges := []*Change{{New: tD0}}
+	if !testingChangeListsEqual(ancestorChanges, expectedAncestorChanges) {
+		t.Errorf("ancestor changes do not match expected: %v != %v", ancestorChanges, expectedAncestorChanges)
+	}
+
+	// Verify the alpha changes.
+	expectedAlphaChanges := []*Change{{Path: "other", New: tF3E}}
+	if !testingChangeListsEqual(alphaChanges, expectedAlphaChanges) {
+		t.Errorf("alpha changes do not match expected: %v != %v", alphaChanges, expectedAlphaChanges)
+	}
+
+	// Verify the beta changes.
+	if len(betaChanges) != 0 {
+		t.Error("unexpected beta changes:", betaChanges)
+	}
+
+	// Verify the conflicts.
+	expectedConflicts := []*Conflict{
+		{
+			Root:         "README",
+			AlphaChanges: []*Change{{Path: "README"}},
+			BetaChanges:  []*Change{{Path: "README", New: tDRCP.Contents["README"]}},
+		},
+		{
+			Root:         "readme",
+			AlphaChanges: []*Change{{Path: "readme"}},
+			BetaChanges:  []*Change{{Path: "readme", New: tDRCP.Contents["readme"]}},
+		},
+	}
+	if !testingConflictListsEqual(conflicts, expectedConflicts) {
+		t.Errorf("conflicts do not match expected: %v != %v", conflicts, expectedConflicts)
+	}
+	for _, conflict := range conflicts {
+		if err := conflict.EnsureValid(); err != nil {
+			t.Error("invalid conflict:", err)
+		}
+	}
+}
@@ -3,6 +3,9 @@ package core
 import (
 	"errors"
 	"fmt"
+	"sort"
+
+	"github.com/mutagen-io/mutagen/pkg/synchronization/core/fastpath"
 )
 
 // EnsureValid ensures that Change's invariants are respected. If synchronizable
@@ -52,3 +55,29 @@ func (c *Change) IsRootDeletion() bool {
 func (c *Change) IsRootTypeChange() bool {
 	return c.Path == "" && c.Old != nil && c.New != nil && c.Old.Kind != c.New.Kind
 }
+
+// sortableChangeList implements sort.Interface for change lists.
+type sortableChangeList []*Change
+
+// Len implements sort.Interface.Len.
+func (l sortableChangeList) Len() int {
+	return len(l)
+}
+
+// Less implements sort.Interface.Less.
+func (l sortableChangeList) Less(i, j int) bool {
+	return fastpath.Less(l[i].Path, l[j].Path)
+}
+
+// Swap implements sort.Interface.Swap.
+func (l sortableChangeList) Swap(i, j int) {
+	l[i], l[j] = l[j], l[i]
+}
+
+// SortChanges sorts a list of changes based on their paths. The sort is stable,
+// so changes with identical paths retain their relative order. Since the
+// ordering is that of a depth-first traversal, changes to parent paths will
+// still precede changes to their descendants.
+func SortChanges(changes []*Change) {
+	sort.Stable(sortableChangeList(changes))
+}
@@ -1,10 +1,15 @@
 package core
 
 import (
+	"crypto/sha256"
+	"encoding/hex"
 	"errors"
 	"fmt"
+	"slices"
 	"sort"
 
+	"google.golang.org/protobuf/proto"
+
 	"github.com/mutagen-io/mutagen/pkg/synchronization/core/fastpath"
 )
 
@@ -78,6 +83,42 @@ func (c *Conflict) Slim() *Conflict {
 	}
 }
 
+// Identifier computes a stable identifier for the conflict. Two conflicts will
+// have the same identifier if and only if they have the same root and their
+// changes are identical (up to the limits of the underlying hash function), so
+// the identifier can be used to track a conflict that persists unchanged across
+// synchronization cycles. Changes are sorted by path before computing the
+// identifier, so the order in which they were recorded doesn't affect it.
+// Identifiers are only stable within a single process and should not be
+// persisted. The conflict should not be slimmed, since that discards content
+// that distinguishes conflicts.
+func (c *Conflict) Identifier() (string, error) {
+	// Create a copy of the conflict with sorted copies of its change lists.
+	// The original lists aren't modified, since their order may be meaningful
+	// to callers.
+	alphaChanges := slices.Clone(c.AlphaChanges)
+	SortChanges(alphaChanges)
+	betaChanges := slices.Clone(c.BetaChanges)
+	SortChanges(betaChanges)
+	sorted := &Conflict{
+		Root:         c.Root,
+		AlphaChanges: alphaChanges,
+		BetaChanges:  betaChanges,
+	}
+
+	// Perform a deterministic serialization of the conflict.
+	serialized, err := proto.MarshalOptions{Deterministic: true}.Marshal(sorted)
+	if err != nil {
+		return "", fmt.Errorf("unable to serialize conflict: %w", err)
+	}
+
+	// Hash the serialized conflict.
+	digest := sha256.Sum256(serialized)
+
+	// Done.
+	return hex.EncodeToString(digest[:]), nil
+}
+
 // CopyConflicts creates a copy of a list of conflicts in a new slice, usually
 // for the purpose of modifying the list. The conflict objects themselves are
 // not copied. It preserves nil vs. non-nil characteristics for empty slices.
@@ -0,0 +1,32 @@
+package core
+
+// ConflictResolution is the resolution returned by a ConflictResolver.
+type ConflictResolution uint8
+
+const (
+	// ConflictResolutionNone indicates that a conflict should be left in place
+	// and reported as usual.
+	ConflictResolutionNone ConflictResolution = iota
+	// ConflictResolutionAlpha indicates that a conflict should be resolved in
+	// favor of alpha, i.e. that alpha's content should be propagated to beta.
+	ConflictResolutionAlpha
+	// ConflictResolutionBeta indicates that a conflict should be resolved in
+	// favor of beta, i.e. that beta's content should be propagated to alpha.
+	ConflictResolutionBeta
+)
+
+// ConflictResolver is the interface for custom conflict resolution strategies
+// used during reconciliation. A conflict resolver is only consulted in
+// bidirectional synchronization modes and only for conflicts where both alpha
+// and beta contain non-deletion changes that the synchronization mode itself
+// doesn't resolve (e.g. files modified on both sides in the two-way-safe mode).
+// Conflicts due to unsynchronizable content or type change restrictions are
+// never passed to a conflict resolver, since they can't be resolved by choosing
+// a side.
+type ConflictResolver interface {
+	// ResolveConflict determines the resolution for a conflict at the specified
+	// path. The alpha and beta entries are the (non-nil) synchronizable
+	// contents of each side at that path. Implementations must not modify the
+	// entries.
+	ResolveConflict(path string, alpha, beta *Entry) ConflictResolution
+}
@@ -102,6 +102,130 @@ func TestConflictSlim(t *testing.T) {
 	}
 }
 
+// TestConflictIdentifier tests Conflict.Identifier.
+func TestConflictIdentifier(t *testing.T) {
+	// Define test cases. Each conflict is compared against a reference conflict
+	// constructed independently.
+	reference := &Conflict{
+		Root:         "conflict",
+		AlphaChanges: []*Change{{Path: "conflict", New: tF1}},
+		BetaChanges:  []*Change{{Path: "conflict", New: tD2}},
+	}
+	tests := []struct {
+		conflict *Conflict
+		expected bool
+	}{
+		{
+			&Conflict{
+				Root:         "conflict",
+				AlphaChanges: []*Change{{Path: "conflict", New: tF1}},
+				BetaChanges:  []*Change{{Path: "conflict", New: tD2}},
+			},
+			true,
+		},
+		{
+			&Conflict{
+				Root:         "other",
+				AlphaChanges: []*Change{{Path: "other", New: tF1}},
+				BetaChanges:  []*Change{{Path: "other", New: tD2}},
+			},
+			false,
+		},
+		{
+			&Conflict{
+				Root:         "conflict",
+				AlphaChanges: []*Change{{Path: "conflict", New: tF2}},
+				BetaChanges:  []*Change{{Path: "conflict", New: tD2}},
+			},
+			false,
+		},
+		{
+			&Conflict{
+				Root:         "conflict",
+				AlphaChanges: []*Change{{Path: "conflict", New: tF1}},
+				BetaChanges:  []*Change{{Path: "conflict", New: tD1}},
+			},
+			false,
+		},
+	}
+
+	// Compute the reference identifier.
+	referenceIdentifier, err := reference.Identifier()
+	if err != nil {
+		t.Fatal("unable to compute reference identifier:", err)
+	}
+
+	// Process test cases.
+	for i, test := range tests {
+		identifier, err := test.conflict.Identifier()
+		if err != nil {
+			t.Errorf("test index %d: unable to compute identifier: %v", i, err)
+		} else if match := identifier == referenceIdentifier; match != test.expected {
+			t.Errorf("test index %d: identifier match does not match expected: %t != %t",
+				i, match, test.expected,
+			)
+		}
+	}
+}
+
+// TestConflictIdentifierChangeOrder tests that Conflict.Identifier is
+// independent of the order of each side's changes and that it doesn't modify
+// the conflict's change lists.
+func TestConflictIdentifierChangeOrder(t *testing.T) {
+	// Create conflicts with several changes on each side, recorded in
+	// different orders.
+	first := &Conflict{
+		Root: "conflict",
+		AlphaChanges: []*Change{
+			{Path: "conflict/file", New: tF1},
+			{Path: "conflict/directory", New: tD1},
+			{Path: "conflict/other", New: tF2},
+		},
+		BetaChanges: []*Change{
+			{Path: "conflict/file", New: tF3},
+			{Path: "conflict/directory", Old: tD1},
+		},
+	}
+	second := &Conflict{
+		Root: "conflict",
+		AlphaChanges: []*Change{
+			{Path: "conflict/other", New: tF2},
+			{Path: "conflict/file", New: tF1},
+			{Path: "conflict/directory", New: tD1},
+		},
+		BetaChanges: []*Change{
+			{Path: "conflict/directory", Old: tD1},
+			{Path: "conflict/file", New: tF3},
+		},
+	}
+
+	// Compute and compare identifiers.
+	firstIdentifier, err := first.Identifier()
+	if err != nil {
+		t.Fatal("unable to compute first identifier:", err)
+	}
+	secondIdentifier, err := second.Identifier()
+	if err != nil {
+		t.Fatal("unable to compute second identifier:", err)
+	}
+	if firstIdentifier != secondIdentifier {
+		t.Error("identifiers differ for conflicts with reordered changes")
+	}
+
+	// Verify that the change lists weren't reordered.
+	if second.AlphaChanges[0].Path != "conflict/other" || second.BetaChanges[0].Path != "conflict/directory" {
+		t.Error("identifier computation modified change order")
+	}
+
+	// Verify that a differing change is still detected.
+	second.AlphaChanges[0] = &Change{Path: "conflict/other", New: tF1}
+	if differentIdentifier, err := second.Identifier(); err != nil {
+		t.Fatal("unable to compute different identifier:", err)
+	} else if differentIdentifier == firstIdentifier {
+		t.Error("identifiers match for conflicts with differing changes")
+	}
+}
+
 // TODO: Implement TestCopyConflicts.
 
 // TODO: Implement TestSortConflicts.
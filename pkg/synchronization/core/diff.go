@@ -8,10 +8,30 @@ import (
 type differ struct {
 	// changes is the list of changes being tracked by the diff.
 	changes []*Change
+	// maximumDepth is the maximum depth to which the diff will descend. A
+	// negative value indicates no limit.
+	maximumDepth int
 }
 
-// diff is the recursive diff entry point.
-func (d *differ) diff(path string, base, target *Entry) {
+// diff is the recursive diff entry point. The depth is the depth of the path
+// relative to the path at which the diff started.
+func (d *differ) diff(path string, depth int, base, target *Entry) {
+	// If we've reached the maximum depth, then don't descend any further. If
+	// the nodes at this path differ in any way, then do a complete replacement.
+	// Note that deep comparison is cheap for the common case of unmodified
+	// content shared between successive snapshots, since Equal recognizes
+	// pointer equality.
+	if d.maximumDepth >= 0 && depth >= d.maximumDepth {
+		if !target.Equal(base, true) {
+			d.changes = append(d.changes, &Change{
+				Path: path,
+				Old:  base,
+				New:  target,
+			})
+		}
+		return
+	}
+
 	// If the nodes at this path aren't equal, then do a complete replacement.
 	if !target.Equal(base, false) {
 		d.changes = append(d.changes, &Change{
@@ -34,7 +54,7 @@ func (d *differ) diff(path string, base, target *Entry) {
 
 	// The nodes were equal at this path, so check their contents.
 	for name := range nameUnion(baseContents, targetContents) {
-		d.diff(contentPathPrefix+name, baseContents[name], targetContents[name])
+		d.diff(contentPathPrefix+name, depth+1, baseContents[name], targetContents[name])
 	}
 }
 
@@ -43,10 +63,10 @@ func (d *differ) diff(path string, base, target *Entry) {
 // applied to base, would transform it into target.
 func diff(path string, base, target *Entry) []*Change {
 	// Create the differ.
-	d := &differ{}
+	d := &differ{maximumDepth: -1}
 
 	// Populate changes.
-	d.diff(path, base, target)
+	d.diff(path, 0, base, target)
 
 	// Done.
 	return d.changes
@@ -57,3 +77,20 @@ func diff(path string, base, target *Entry) []*Change {
 func Diff(base, target *Entry) []*Change {
 	return diff("", base, target)
 }
+
+// DiffLimited is a variant of Diff that doesn't descend beyond the specified
+// maximum depth (where the root is at depth 0 and its contents are at depth 1).
+// Any differences within content at the maximum depth are reported as a single
+// coarse change that replaces that content entirely. The resulting changes
+// still transform base into target if applied. A negative maximum depth
+// indicates no limit, in which case DiffLimited is equivalent to Diff.
+func DiffLimited(base, target *Entry, maximumDepth int) []*Change {
+	// Create the differ.
+	d := &differ{maximumDepth: maximumDepth}
+
+	// Populate changes.
+	d.diff("", 0, base, target)
+
+	// Done.
+	return d.changes
+}
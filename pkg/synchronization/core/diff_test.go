@@ -29,3 +29,37 @@ func TestDiff(t *testing.T) {
 		}
 	}
 }
+
+// TestDiffLimited tests DiffLimited.
+func TestDiffLimited(t *testing.T) {
+	// Create base and target entries that differ only deep in the hierarchy.
+	base := nested("a", nested("b", nested("c", tD1)))
+	target := nested("a", nested("b", nested("c", tD2)))
+
+	// Define test cases.
+	tests := []struct {
+		base         *Entry
+		target       *Entry
+		maximumDepth int
+		expected     []*Change
+	}{
+		{base, base, 0, nil},
+		{base, base, 2, nil},
+		{base, target, -1, []*Change{{Path: "a/b/c/file", Old: tF1, New: tF2}}},
+		{base, target, 0, []*Change{{Old: base, New: target}}},
+		{base, target, 1, []*Change{{Path: "a", Old: base.Contents["a"], New: target.Contents["a"]}}},
+		{base, target, 2, []*Change{{Path: "a/b", Old: nested("c", tD1), New: nested("c", tD2)}}},
+		{base, target, 3, []*Change{{Path: "a/b/c", Old: tD1, New: tD2}}},
+		{base, target, 4, []*Change{{Path: "a/b/c/file", Old: tF1, New: tF2}}},
+		{base, target, 10, []*Change{{Path: "a/b/c/file", Old: tF1, New: tF2}}},
+		{tD0, tD1, 0, []*Change{{Old: tD0, New: tD1}}},
+		{tD0, tD1, 1, []*Change{{Path: "file", New: tF1}}},
+	}
+
+	// Process test cases.
+	for i, test := range tests {
+		if delta := DiffLimited(test.base, test.target, test.maximumDepth); !testingChangeListsEqual(delta, test.expected) {
+			t.Errorf("test index %d: diff result does not match expected", i)
+		}
+	}
+}
@@ -14,7 +14,8 @@ import (
 func (k EntryKind) synchronizable() bool {
 	return k == EntryKind_Directory ||
 		k == EntryKind_File ||
-		k == EntryKind_SymbolicLink
+		k == EntryKind_SymbolicLink ||
+		k == EntryKind_SpecialFile
 }
 
 // MarshalText implements encoding.TextMarshaler.MarshalText.
@@ -27,12 +28,16 @@ func (k EntryKind) MarshalText() ([]byte, error) {
 		result = "file"
 	case EntryKind_SymbolicLink:
 		result = "symlink"
+	case EntryKind_SpecialFile:
+		result = "special-file"
 	case EntryKind_Untracked:
 		result = "untracked"
 	case EntryKind_Problematic:
 		result = "problematic"
 	case EntryKind_PhantomDirectory:
 		result = "phantom-directory"
+	case EntryKind_Oversized:
+		result = "oversized"
 	default:
 		result = "unknown"
 	}
@@ -52,12 +57,16 @@ func (k *EntryKind) UnmarshalText(textBytes []byte) error {
 		*k = EntryKind_File
 	case "symlink":
 		*k = EntryKind_SymbolicLink
+	case "special-file":
+		*k = EntryKind_SpecialFile
 	case "untracked":
 		*k = EntryKind_Untracked
 	case "problematic":
 		*k = EntryKind_Problematic
 	case "phantom-directory":
 		*k = EntryKind_PhantomDirectory
+	case "oversized":
+		*k = EntryKind_Oversized
 	default:
 		return fmt.Errorf("unknown entry kind: %s", text)
 	}
@@ -74,7 +83,17 @@ func (e *Entry) EnsureValid(synchronizable bool) error {
 		return nil
 	}
 
-	// Otherwise validate based on kind.
+	// Ensure that aggregate digests are only set for directory kinds.
+	if e.AggregateDigest != nil && e.Kind != EntryKind_Directory && e.Kind != EntryKind_PhantomDirectory {
+		return errors.New("non-nil aggregate digest detected for non-directory")
+	}
+
+	// Ensure that modification times are only set for file kinds.
+	if e.ModificationTime != nil && e.Kind != EntryKind_File {
+		return errors.New("non-nil modification time detected for non-file")
+	}
+
+	// Validate based on kind.
 	if e.Kind == EntryKind_Directory {
 		// Ensure that no invalid fields are set.
 		if e.Digest != nil {
@@ -85,6 +104,8 @@ func (e *Entry) EnsureValid(synchronizable bool) error {
 			return errors.New("non-empty symbolic link target detected for directory")
 		} else if e.Problem != "" {
 			return errors.New("non-empty problem detected for directory")
+		} else if e.ProblemCode != ProblemCode_ProblemCodeUnknown {
+			return errors.New("problem code detected for directory")
 		}
 
 		// Validate contents. Nil entries are not considered valid for contents.
@@ -109,6 +130,8 @@ func (e *Entry) EnsureValid(synchronizable bool) error {
 			return errors.New("non-empty symbolic link target detected for file")
 		} else if e.Problem != "" {
 			return errors.New("non-empty problem detected for file")
+		} else if e.ProblemCode != ProblemCode_ProblemCodeUnknown {
+			return errors.New("problem code detected for file")
 		}
 
 		// Ensure that the digest is non-empty.
@@ -125,6 +148,8 @@ func (e *Entry) EnsureValid(synchronizable bool) error {
 			return errors.New("executable symbolic link detected")
 		} else if e.Problem != "" {
 			return errors.New("non-empty problem detected for symbolic link")
+		} else if e.ProblemCode != ProblemCode_ProblemCodeUnknown {
+			return errors.New("problem code detected for symbolic link")
 		}
 
 		// Ensure that the target is non-empty. We avoid any further validation
@@ -132,6 +157,21 @@ func (e *Entry) EnsureValid(synchronizable bool) error {
 		if e.Target == "" {
 			return errors.New("symbolic link with empty target detected")
 		}
+	} else if e.Kind == EntryKind_SpecialFile {
+		// Ensure that no invalid fields are set.
+		if e.Contents != nil {
+			return errors.New("non-nil special file content map detected")
+		} else if e.Digest != nil {
+			return errors.New("non-nil special file digest detected")
+		} else if e.Executable {
+			return errors.New("executable special file detected")
+		} else if e.Target != "" {
+			return errors.New("non-empty symbolic link target detected for special file")
+		} else if e.Problem != "" {
+			return errors.New("non-empty problem detected for special file")
+		} else if e.ProblemCode != ProblemCode_ProblemCodeUnknown {
+			return errors.New("problem code detected for special file")
+		}
 	} else if e.Kind == EntryKind_Untracked {
 		// Verify that unsynchronizable content is allowed.
 		if synchronizable {
@@ -149,6 +189,8 @@ func (e *Entry) EnsureValid(synchronizable bool) error {
 			return errors.New("non-empty symbolic link target detected for untracked content")
 		} else if e.Problem != "" {
 			return errors.New("non-empty problem detected for untracked content")
+		} else if e.ProblemCode != ProblemCode_ProblemCodeUnknown {
+			return errors.New("problem code detected for untracked content")
 		}
 	} else if e.Kind == EntryKind_Problematic {
 		// Verify that unsynchronizable content is allowed.
@@ -186,6 +228,8 @@ func (e *Entry) EnsureValid(synchronizable bool) error {
 			return errors.New("non-empty symbolic link target detected for phantom directory")
 		} else if e.Problem != "" {
 			return errors.New("non-empty problem detected for phantom directory")
+		} else if e.ProblemCode != ProblemCode_ProblemCodeUnknown {
+			return errors.New("problem code detected for phantom directory")
 		}
 
 		// Validate contents. Nil entries are not considered valid for contents.
@@ -202,6 +246,26 @@ func (e *Entry) EnsureValid(synchronizable bool) error {
 				return err
 			}
 		}
+	} else if e.Kind == EntryKind_Oversized {
+		// Verify that unsynchronizable content is allowed.
+		if synchronizable {
+			return errors.New("oversized content is not synchronizable")
+		}
+
+		// Ensure that no invalid fields are set.
+		if e.Contents != nil {
+			return errors.New("non-nil oversized content map detected")
+		} else if e.Digest != nil {
+			return errors.New("non-nil oversized content digest detected")
+		} else if e.Executable {
+			return errors.New("executable oversized content detected")
+		} else if e.Target != "" {
+			return errors.New("non-empty symbolic link target detected for oversized content")
+		} else if e.Problem != "" {
+			return errors.New("non-empty problem detected for oversized content")
+		} else if e.ProblemCode != ProblemCode_ProblemCodeUnknown {
+			return errors.New("problem code detected for oversized content")
+		}
 	} else {
 		return errors.New("unknown entry kind detected")
 	}
@@ -297,7 +361,10 @@ var entryEqualWildcardProblemMatch bool
 // Equal performs an equivalence comparison between this entry and another. If
 // deep is true, then the comparison is performed recursively, otherwise the
 // comparison is only performed between entry properties at the top level and
-// content maps are ignored.
+// content maps are ignored. Aggregate digests aren't compared, but a deep
+// comparison of directories that both carry matching aggregate digests treats
+// them as equal without examining their contents (modulo digest collisions,
+// this is equivalent to a full comparison).
 func (e *Entry) Equal(other *Entry, deep bool) bool {
 	// If the pointers are equal, then the entries are equal, both shallowly and
 	// recursively. This includes the case where both pointers are nil, which
@@ -339,6 +406,14 @@ func (e *Entry) Equal(other *Entry, deep bool) bool {
 		return true
 	}
 
+	// If both entries carry matching aggregate digests, then their contents are
+	// equal. Aggregate digests are only present on directories, and the kind
+	// equality check above ensures that both entries are of the same kind.
+	if e.AggregateDigest != nil && other.AggregateDigest != nil &&
+		bytes.Equal(e.AggregateDigest, other.AggregateDigest) {
+		return true
+	}
+
 	// Compare entry contents.
 	if len(e.Contents) != len(other.Contents) {
 		return false
@@ -392,11 +467,13 @@ func (e *Entry) Copy(behavior EntryCopyBehavior) *Entry {
 
 	// Create a slim copy.
 	result := &Entry{
-		Kind:       e.Kind,
-		Executable: e.Executable,
-		Digest:     e.Digest,
-		Target:     e.Target,
-		Problem:    e.Problem,
+		Kind:             e.Kind,
+		Executable:       e.Executable,
+		Digest:           e.Digest,
+		ModificationTime: e.ModificationTime,
+		Target:           e.Target,
+		Problem:          e.Problem,
+		ProblemCode:      e.ProblemCode,
 	}
 
 	// If a slim copy was requested, then we're done.
@@ -502,6 +579,7 @@ func (e *Entry) Problems() []*Problem {
 			result = append(result, &Problem{
 				Path:  path,
 				Error: entry.Problem,
+				Code:  entry.ProblemCode,
 			})
 		}
 	}, false)
@@ -9,6 +9,7 @@ package core
 import (
 	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
 	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	timestamppb "google.golang.org/protobuf/types/known/timestamppb"
 	reflect "reflect"
 	sync "sync"
 )
@@ -30,6 +31,10 @@ const (
 	EntryKind_File EntryKind = 1
 	// EntryKind_SymbolicLink indicates a symbolic link.
 	EntryKind_SymbolicLink EntryKind = 2
+	// EntryKind_SpecialFile indicates a special file (e.g. a FIFO or socket)
+	// whose presence (but not content) is synchronized. When created by
+	// transition operations, it is represented by a regular marker file.
+	EntryKind_SpecialFile EntryKind = 3
 	// EntryKind_Untracked indicates content (or the root of content) that is
 	// intentionally excluded from synchronization by Mutagen. This includes
 	// explicitly ignored content, content that is ignored due to settings (such
@@ -56,6 +61,15 @@ const (
 	// containing phantom contents must have those contents reified (to tracked
 	// or ignored directories) using ReifyPhantomDirectories before Reconcile.
 	EntryKind_PhantomDirectory EntryKind = 102
+	// EntryKind_Oversized indicates a regular file whose size exceeds the
+	// maximum file size used when scanning. Oversized content is treated as if
+	// it were ignored, in that it's never propagated and never reported as a
+	// problem, but (unlike untracked content) it also prevents reconciliation
+	// at its path, so that a file growing beyond the maximum file size isn't
+	// seen as a deletion (and thus doesn't drive the removal of the
+	// corresponding content on the other endpoint). This type of entry is not
+	// synchronizable.
+	EntryKind_Oversized EntryKind = 103
 )
 
 // Enum value maps for EntryKind.
@@ -64,17 +78,21 @@ var (
 		0:   "Directory",
 		1:   "File",
 		2:   "SymbolicLink",
+		3:   "SpecialFile",
 		100: "Untracked",
 		101: "Problematic",
 		102: "PhantomDirectory",
+		103: "Oversized",
 	}
 	EntryKind_value = map[string]int32{
 		"Directory":        0,
 		"File":             1,
 		"SymbolicLink":     2,
+		"SpecialFile":      3,
 		"Untracked":        100,
 		"Problematic":      101,
 		"PhantomDirectory": 102,
+		"Oversized":        103,
 	}
 )
 
@@ -117,20 +135,42 @@ type Entry struct {
 	// Kind encodes the type of filesystem entry being represented.
 	Kind EntryKind `protobuf:"varint,1,opt,name=kind,proto3,enum=core.EntryKind" json:"kind,omitempty"`
 	// Contents represents a directory entry's contents. It must only be non-nil
-	// for directory entries.
+	// for directory entries. Content names are single path components: they
+	// are non-empty, are never "." or "..", and never contain a path separator,
+	// so a name can't carry a leading or trailing slash (i.e. "dir" and "dir/"
+	// can't be distinct names). Names are compared byte-for-byte, so names that
+	// differ only in case or Unicode normalization are distinct.
 	Contents map[string]*Entry `protobuf:"bytes,5,rep,name=contents,proto3" json:"contents,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+	// AggregateDigest is an optional digest summarizing a directory entry's
+	// contents (recursively), which allows for pruning identical subtrees when
+	// comparing entry hierarchies. It may only be non-nil for directory (and
+	// phantom directory) entries and, even then, is only populated by scans that
+	// explicitly request it. It's derived data and thus isn't preserved by
+	// copies or compared by entry equality comparisons, though deep equality
+	// comparisons use matching aggregate digests to skip content comparison.
+	AggregateDigest []byte `protobuf:"bytes,6,opt,name=aggregateDigest,proto3" json:"aggregateDigest,omitempty"`
 	// Digest represents the hash of a file entry's contents. It must only be
 	// non-nil for file entries.
 	Digest []byte `protobuf:"bytes,8,opt,name=digest,proto3" json:"digest,omitempty"`
 	// Executable indicates whether or not a file entry is marked as executable.
 	// It must only be set (if appropriate) for file entries.
 	Executable bool `protobuf:"varint,9,opt,name=executable,proto3" json:"executable,omitempty"`
+	// ModificationTime is the modification time of a file entry. It may only be
+	// non-nil for file entries and, even then, is only populated by scans that
+	// explicitly request it. Like AggregateDigest, it isn't considered by entry
+	// equality comparisons or preserved by copies.
+	ModificationTime *timestamppb.Timestamp `protobuf:"bytes,10,opt,name=modificationTime,proto3" json:"modificationTime,omitempty"`
 	// Target is the symbolic link target for symbolic link entries. It must be
 	// non-empty if and only if the entry is a symbolic link.
 	Target string `protobuf:"bytes,12,opt,name=target,proto3" json:"target,omitempty"`
 	// Problem indicates the relevant error for problematic content. It must be
 	// non-empty if and only if the entry represents problematic content.
 	Problem string `protobuf:"bytes,15,opt,name=problem,proto3" json:"problem,omitempty"`
+	// ProblemCode is a machine-readable classification of the problem for
+	// problematic content. It must be ProblemCode_ProblemCodeUnknown for
+	// other entry kinds. It's informational and isn't considered in entry
+	// comparisons.
+	ProblemCode ProblemCode `protobuf:"varint,16,opt,name=problemCode,proto3,enum=core.ProblemCode" json:"problemCode,omitempty"`
 }
 
 func (x *Entry) Reset() {
@@ -177,6 +217,13 @@ func (x *Entry) GetContents() map[string]*Entry {
 	return nil
 }
 
+func (x *Entry) GetAggregateDigest() []byte {
+	if x != nil {
+		return x.AggregateDigest
+	}
+	return nil
+}
+
 func (x *Entry) GetDigest() []byte {
 	if x != nil {
 		return x.Digest
@@ -191,6 +238,13 @@ func (x *Entry) GetExecutable() bool {
 	return false
 }
 
+func (x *Entry) GetModificationTime() *timestamppb.Timestamp {
+	if x != nil {
+		return x.ModificationTime
+	}
+	return nil
+}
+
 func (x *Entry) GetTarget() string {
 	if x != nil {
 		return x.Target
@@ -205,41 +259,64 @@ func (x *Entry) GetProblem() string {
 	return ""
 }
 
+func (x *Entry) GetProblemCode() ProblemCode {
+	if x != nil {
+		return x.ProblemCode
+	}
+	return ProblemCode_ProblemCodeUnknown
+}
+
 var File_synchronization_core_entry_proto protoreflect.FileDescriptor
 
 var file_synchronization_core_entry_proto_rawDesc = []byte{
 	0x0a, 0x20, 0x73, 0x79, 0x6e, 0x63, 0x68, 0x72, 0x6f, 0x6e, 0x69, 0x7a, 0x61, 0x74, 0x69, 0x6f,
 	0x6e, 0x2f, 0x63, 0x6f, 0x72, 0x65, 0x2f, 0x65, 0x6e, 0x74, 0x72, 0x79, 0x2e, 0x70, 0x72, 0x6f,
-	0x74, 0x6f, 0x12, 0x04, 0x63, 0x6f, 0x72, 0x65, 0x22, 0x97, 0x02, 0x0a, 0x05, 0x45, 0x6e, 0x74,
-	0x72, 0x79, 0x12, 0x23, 0x0a, 0x04, 0x6b, 0x69, 0x6e, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0e,
-	0x32, 0x0f, 0x2e, 0x63, 0x6f, 0x72, 0x65, 0x2e, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x4b, 0x69, 0x6e,
-	0x64, 0x52, 0x04, 0x6b, 0x69, 0x6e, 0x64, 0x12, 0x35, 0x0a, 0x08, 0x63, 0x6f, 0x6e, 0x74, 0x65,
-	0x6e, 0x74, 0x73, 0x18, 0x05, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x19, 0x2e, 0x63, 0x6f, 0x72, 0x65,
-	0x2e, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x2e, 0x43, 0x6f, 0x6e, 0x74, 0x65, 0x6e, 0x74, 0x73, 0x45,
-	0x6e, 0x74, 0x72, 0x79, 0x52, 0x08, 0x63, 0x6f, 0x6e, 0x74, 0x65, 0x6e, 0x74, 0x73, 0x12, 0x16,
-	0x0a, 0x06, 0x64, 0x69, 0x67, 0x65, 0x73, 0x74, 0x18, 0x08, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x06,
-	0x64, 0x69, 0x67, 0x65, 0x73, 0x74, 0x12, 0x1e, 0x0a, 0x0a, 0x65, 0x78, 0x65, 0x63, 0x75, 0x74,
-	0x61, 0x62, 0x6c, 0x65, 0x18, 0x09, 0x20, 0x01, 0x28, 0x08, 0x52, 0x0a, 0x65, 0x78, 0x65, 0x63,
-	0x75, 0x74, 0x61, 0x62, 0x6c, 0x65, 0x12, 0x16, 0x0a, 0x06, 0x74, 0x61, 0x72, 0x67, 0x65, 0x74,
-	0x18, 0x0c, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x74, 0x61, 0x72, 0x67, 0x65, 0x74, 0x12, 0x18,
-	0x0a, 0x07, 0x70, 0x72, 0x6f, 0x62, 0x6c, 0x65, 0x6d, 0x18, 0x0f, 0x20, 0x01, 0x28, 0x09, 0x52,
-	0x07, 0x70, 0x72, 0x6f, 0x62, 0x6c, 0x65, 0x6d, 0x1a, 0x48, 0x0a, 0x0d, 0x43, 0x6f, 0x6e, 0x74,
-	0x65, 0x6e, 0x74, 0x73, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x12, 0x10, 0x0a, 0x03, 0x6b, 0x65, 0x79,
-	0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x6b, 0x65, 0x79, 0x12, 0x21, 0x0a, 0x05, 0x76,
-	0x61, 0x6c, 0x75, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x0b, 0x2e, 0x63, 0x6f, 0x72,
-	0x65, 0x2e, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x3a, 0x02,
-	0x38, 0x01, 0x2a, 0x6c, 0x0a, 0x09, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x4b, 0x69, 0x6e, 0x64, 0x12,
-	0x0d, 0x0a, 0x09, 0x44, 0x69, 0x72, 0x65, 0x63, 0x74, 0x6f, 0x72, 0x79, 0x10, 0x00, 0x12, 0x08,
-	0x0a, 0x04, 0x46, 0x69, 0x6c, 0x65, 0x10, 0x01, 0x12, 0x10, 0x0a, 0x0c, 0x53, 0x79, 0x6d, 0x62,
-	0x6f, 0x6c, 0x69, 0x63, 0x4c, 0x69, 0x6e, 0x6b, 0x10, 0x02, 0x12, 0x0d, 0x0a, 0x09, 0x55, 0x6e,
-	0x74, 0x72, 0x61, 0x63, 0x6b, 0x65, 0x64, 0x10, 0x64, 0x12, 0x0f, 0x0a, 0x0b, 0x50, 0x72, 0x6f,
-	0x62, 0x6c, 0x65, 0x6d, 0x61, 0x74, 0x69, 0x63, 0x10, 0x65, 0x12, 0x14, 0x0a, 0x10, 0x50, 0x68,
-	0x61, 0x6e, 0x74, 0x6f, 0x6d, 0x44, 0x69, 0x72, 0x65, 0x63, 0x74, 0x6f, 0x72, 0x79, 0x10, 0x66,
-	0x42, 0x38, 0x5a, 0x36, 0x67, 0x69, 0x74, 0x68, 0x75, 0x62, 0x2e, 0x63, 0x6f, 0x6d, 0x2f, 0x6d,
-	0x75, 0x74, 0x61, 0x67, 0x65, 0x6e, 0x2d, 0x69, 0x6f, 0x2f, 0x6d, 0x75, 0x74, 0x61, 0x67, 0x65,
-	0x6e, 0x2f, 0x70, 0x6b, 0x67, 0x2f, 0x73, 0x79, 0x6e, 0x63, 0x68, 0x72, 0x6f, 0x6e, 0x69, 0x7a,
-	0x61, 0x74, 0x69, 0x6f, 0x6e, 0x2f, 0x63, 0x6f, 0x72, 0x65, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74,
-	0x6f, 0x33,
+	0x74, 0x6f, 0x12, 0x04, 0x63, 0x6f, 0x72, 0x65, 0x1a, 0x1f, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65,
+	0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2f, 0x74, 0x69, 0x6d, 0x65, 0x73, 0x74,
+	0x61, 0x6d, 0x70, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x1a, 0x22, 0x73, 0x79, 0x6e, 0x63, 0x68,
+	0x72, 0x6f, 0x6e, 0x69, 0x7a, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x2f, 0x63, 0x6f, 0x72, 0x65, 0x2f,
+	0x70, 0x72, 0x6f, 0x62, 0x6c, 0x65, 0x6d, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x22, 0xbe, 0x03,
+	0x0a, 0x05, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x12, 0x23, 0x0a, 0x04, 0x6b, 0x69, 0x6e, 0x64, 0x18,
+	0x01, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x0f, 0x2e, 0x63, 0x6f, 0x72, 0x65, 0x2e, 0x45, 0x6e, 0x74,
+	0x72, 0x79, 0x4b, 0x69, 0x6e, 0x64, 0x52, 0x04, 0x6b, 0x69, 0x6e, 0x64, 0x12, 0x35, 0x0a, 0x08,
+	0x63, 0x6f, 0x6e, 0x74, 0x65, 0x6e, 0x74, 0x73, 0x18, 0x05, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x19,
+	0x2e, 0x63, 0x6f, 0x72, 0x65, 0x2e, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x2e, 0x43, 0x6f, 0x6e, 0x74,
+	0x65, 0x6e, 0x74, 0x73, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x52, 0x08, 0x63, 0x6f, 0x6e, 0x74, 0x65,
+	0x6e, 0x74, 0x73, 0x12, 0x28, 0x0a, 0x0f, 0x61, 0x67, 0x67, 0x72, 0x65, 0x67, 0x61, 0x74, 0x65,
+	0x44, 0x69, 0x67, 0x65, 0x73, 0x74, 0x18, 0x06, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x0f, 0x61, 0x67,
+	0x67, 0x72, 0x65, 0x67, 0x61, 0x74, 0x65, 0x44, 0x69, 0x67, 0x65, 0x73, 0x74, 0x12, 0x16, 0x0a,
+	0x06, 0x64, 0x69, 0x67, 0x65, 0x73, 0x74, 0x18, 0x08, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x06, 0x64,
+	0x69, 0x67, 0x65, 0x73, 0x74, 0x12, 0x1e, 0x0a, 0x0a, 0x65, 0x78, 0x65, 0x63, 0x75, 0x74, 0x61,
+	0x62, 0x6c, 0x65, 0x18, 0x09, 0x20, 0x01, 0x28, 0x08, 0x52, 0x0a, 0x65, 0x78, 0x65, 0x63, 0x75,
+	0x74, 0x61, 0x62, 0x6c, 0x65, 0x12, 0x46, 0x0a, 0x10, 0x6d, 0x6f, 0x64, 0x69, 0x66, 0x69, 0x63,
+	0x61, 0x74, 0x69, 0x6f, 0x6e, 0x54, 0x69, 0x6d, 0x65, 0x18, 0x0a, 0x20, 0x01, 0x28, 0x0b, 0x32,
+	0x1a, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75,
+	0x66, 0x2e, 0x54, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x52, 0x10, 0x6d, 0x6f, 0x64,
+	0x69, 0x66, 0x69, 0x63, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x54, 0x69, 0x6d, 0x65, 0x12, 0x16, 0x0a,
+	0x06, 0x74, 0x61, 0x72, 0x67, 0x65, 0x74, 0x18, 0x0c, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x74,
+	0x61, 0x72, 0x67, 0x65, 0x74, 0x12, 0x18, 0x0a, 0x07, 0x70, 0x72, 0x6f, 0x62, 0x6c, 0x65, 0x6d,
+	0x18, 0x0f, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x70, 0x72, 0x6f, 0x62, 0x6c, 0x65, 0x6d, 0x12,
+	0x33, 0x0a, 0x0b, 0x70, 0x72, 0x6f, 0x62, 0x6c, 0x65, 0x6d, 0x43, 0x6f, 0x64, 0x65, 0x18, 0x10,
+	0x20, 0x01, 0x28, 0x0e, 0x32, 0x11, 0x2e, 0x63, 0x6f, 0x72, 0x65, 0x2e, 0x50, 0x72, 0x6f, 0x62,
+	0x6c, 0x65, 0x6d, 0x43, 0x6f, 0x64, 0x65, 0x52, 0x0b, 0x70, 0x72, 0x6f, 0x62, 0x6c, 0x65, 0x6d,
+	0x43, 0x6f, 0x64, 0x65, 0x1a, 0x48, 0x0a, 0x0d, 0x43, 0x6f, 0x6e, 0x74, 0x65, 0x6e, 0x74, 0x73,
+	0x45, 0x6e, 0x74, 0x72, 0x79, 0x12, 0x10, 0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18, 0x01, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x03, 0x6b, 0x65, 0x79, 0x12, 0x21, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65,
+	0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x0b, 0x2e, 0x63, 0x6f, 0x72, 0x65, 0x2e, 0x45, 0x6e,
+	0x74, 0x72, 0x79, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x3a, 0x02, 0x38, 0x01, 0x2a, 0x8c,
+	0x01, 0x0a, 0x09, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x4b, 0x69, 0x6e, 0x64, 0x12, 0x0d, 0x0a, 0x09,
+	0x44, 0x69, 0x72, 0x65, 0x63, 0x74, 0x6f, 0x72, 0x79, 0x10, 0x00, 0x12, 0x08, 0x0a, 0x04, 0x46,
+	0x69, 0x6c, 0x65, 0x10, 0x01, 0x12, 0x10, 0x0a, 0x0c, 0x53, 0x79, 0x6d, 0x62, 0x6f, 0x6c, 0x69,
+	0x63, 0x4c, 0x69, 0x6e, 0x6b, 0x10, 0x02, 0x12, 0x0f, 0x0a, 0x0b, 0x53, 0x70, 0x65, 0x63, 0x69,
+	0x61, 0x6c, 0x46, 0x69, 0x6c, 0x65, 0x10, 0x03, 0x12, 0x0d, 0x0a, 0x09, 0x55, 0x6e, 0x74, 0x72,
+	0x61, 0x63, 0x6b, 0x65, 0x64, 0x10, 0x64, 0x12, 0x0f, 0x0a, 0x0b, 0x50, 0x72, 0x6f, 0x62, 0x6c,
+	0x65, 0x6d, 0x61, 0x74, 0x69, 0x63, 0x10, 0x65, 0x12, 0x14, 0x0a, 0x10, 0x50, 0x68, 0x61, 0x6e,
+	0x74, 0x6f, 0x6d, 0x44, 0x69, 0x72, 0x65, 0x63, 0x74, 0x6f, 0x72, 0x79, 0x10, 0x66, 0x12, 0x0d,
+	0x0a, 0x09, 0x4f, 0x76, 0x65, 0x72, 0x73, 0x69, 0x7a, 0x65, 0x64, 0x10, 0x67, 0x42, 0x38, 0x5a,
+	0x36, 0x67, 0x69, 0x74, 0x68, 0x75, 0x62, 0x2e, 0x63, 0x6f, 0x6d, 0x2f, 0x6d, 0x75, 0x74, 0x61,
+	0x67, 0x65, 0x6e, 0x2d, 0x69, 0x6f, 0x2f, 0x6d, 0x75, 0x74, 0x61, 0x67, 0x65, 0x6e, 0x2f, 0x70,
+	0x6b, 0x67, 0x2f, 0x73, 0x79, 0x6e, 0x63, 0x68, 0x72, 0x6f, 0x6e, 0x69, 0x7a, 0x61, 0x74, 0x69,
+	0x6f, 0x6e, 0x2f, 0x63, 0x6f, 0x72, 0x65, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
 }
 
 var (
@@ -257,19 +334,23 @@ func file_synchronization_core_entry_proto_rawDescGZIP() []byte {
 var file_synchronization_core_entry_proto_enumTypes = make([]protoimpl.EnumInfo, 1)
 var file_synchronization_core_entry_proto_msgTypes = make([]protoimpl.MessageInfo, 2)
 var file_synchronization_core_entry_proto_goTypes = []any{
-	(EntryKind)(0), // 0: core.EntryKind
-	(*Entry)(nil),  // 1: core.Entry
-	nil,            // 2: core.Entry.ContentsEntry
+	(EntryKind)(0),                // 0: core.EntryKind
+	(*Entry)(nil),                 // 1: core.Entry
+	nil,                           // 2: core.Entry.ContentsEntry
+	(*timestamppb.Timestamp)(nil), // 3: google.protobuf.Timestamp
+	(ProblemCode)(0),              // 4: core.ProblemCode
 }
 var file_synchronization_core_entry_proto_depIdxs = []int32{
 	0, // 0: core.Entry.kind:type_name -> core.EntryKind
 	2, // 1: core.Entry.contents:type_name -> core.Entry.ContentsEntry
-	1, // 2: core.Entry.ContentsEntry.value:type_name -> core.Entry
-	3, // [3:3] is the sub-list for method output_type
-	3, // [3:3] is the sub-list for method input_type
-	3, // [3:3] is the sub-list for extension type_name
-	3, // [3:3] is the sub-list for extension extendee
-	0, // [0:3] is the sub-list for field type_name
+	3, // 2: core.Entry.modificationTime:type_name -> google.protobuf.Timestamp
+	4, // 3: core.Entry.problemCode:type_name -> core.ProblemCode
+	1, // 4: core.Entry.ContentsEntry.value:type_name -> core.Entry
+	5, // [5:5] is the sub-list for method output_type
+	5, // [5:5] is the sub-list for method input_type
+	5, // [5:5] is the sub-list for extension type_name
+	5, // [5:5] is the sub-list for extension extendee
+	0, // [0:5] is the sub-list for field type_name
 }
 
 func init() { file_synchronization_core_entry_proto_init() }
@@ -277,6 +358,7 @@ func file_synchronization_core_entry_proto_init() {
 	if File_synchronization_core_entry_proto != nil {
 		return
 	}
+	file_synchronization_core_problem_proto_init()
 	type x struct{}
 	out := protoimpl.TypeBuilder{
 		File: protoimpl.DescBuilder{
@@ -2,6 +2,8 @@ package core
 
 import (
 	"testing"
+
+	"google.golang.org/protobuf/proto"
 )
 
 // TestEntryKindSynchronizable tests EntryKind.synchronizable.
@@ -14,9 +16,11 @@ func TestEntryKindSynchronizable(t *testing.T) {
 		{EntryKind_Directory, true},
 		{EntryKind_File, true},
 		{EntryKind_SymbolicLink, true},
+		{EntryKind_SpecialFile, true},
 		{EntryKind_Untracked, false},
 		{EntryKind_Problematic, false},
 		{EntryKind_PhantomDirectory, false},
+		{EntryKind_Oversized, false},
 	}
 
 	// Process test cases.
@@ -43,9 +47,11 @@ func TestEntryKindUnmarshal(t *testing.T) {
 		{"directory", EntryKind_Directory, false},
 		{"file", EntryKind_File, false},
 		{"symlink", EntryKind_SymbolicLink, false},
+		{"special-file", EntryKind_SpecialFile, false},
 		{"untracked", EntryKind_Untracked, false},
 		{"problematic", EntryKind_Problematic, false},
 		{"phantom-directory", EntryKind_PhantomDirectory, false},
+		{"oversized", EntryKind_Oversized, false},
 	}
 
 	// Process test cases.
@@ -88,16 +94,22 @@ var entryEnsureValidTestCases = []struct {
 	{tN, true, true},
 	{tF1, false, true},
 	{tF1, true, true},
+	{tF1M, false, true},
+	{tF1M, true, true},
 	{tF3E, false, true},
 	{tF3E, true, true},
 	{tSR, false, true},
 	{tSR, true, true},
 	{tSA, false, true},
 	{tSA, true, true},
+	{tX, false, true},
+	{tX, true, true},
 	{tD0, false, true},
 	{tD0, true, true},
 	{tD1, false, true},
 	{tD1, true, true},
+	{tD1A, false, true},
+	{tD1A, true, true},
 	{tD3E, false, true},
 	{tD3E, true, true},
 	{tDCC, false, true},
@@ -122,6 +134,10 @@ var entryEnsureValidTestCases = []struct {
 	{tPD0, true, false},
 	{tDPD0, false, true},
 	{tDPD0, true, false},
+	{tO, false, true},
+	{tO, true, false},
+	{tDO, false, true},
+	{tDO, true, false},
 
 	// Test invalid content.
 	{tIDDE, false, false},
@@ -134,6 +150,8 @@ var entryEnsureValidTestCases = []struct {
 	{tIDT, true, false},
 	{tIDP, false, false},
 	{tIDP, true, false},
+	{tIDM, false, false},
+	{tIDM, true, false},
 	{tIDCE, false, false},
 	{tIDCE, true, false},
 	{tIDCD, false, false},
@@ -150,6 +168,8 @@ var entryEnsureValidTestCases = []struct {
 	{tIFC, true, false},
 	{tIFT, false, false},
 	{tIFT, true, false},
+	{tIFA, false, false},
+	{tIFA, true, false},
 	{tIFP, false, false},
 	{tIFP, true, false},
 	{tIFDN, false, false},
@@ -170,6 +190,18 @@ var entryEnsureValidTestCases = []struct {
 	{tISP, true, false},
 	{tISTE, false, false},
 	{tISTE, true, false},
+	{tISM, false, false},
+	{tISM, true, false},
+	{tIXC, false, false},
+	{tIXC, true, false},
+	{tIXD, false, false},
+	{tIXD, true, false},
+	{tIXE, false, false},
+	{tIXE, true, false},
+	{tIXT, false, false},
+	{tIXT, true, false},
+	{tIXP, false, false},
+	{tIXP, true, false},
 	{tIUCE, false, false},
 	{tIUCE, true, false},
 	{tIUC, false, false},
@@ -225,6 +257,81 @@ func TestEntryEnsureValid(t *testing.T) {
 	}
 }
 
+// TestEntryEnsureValidContentNames tests that Entry.EnsureValid enforces that
+// content names are single, non-empty path components without separators.
+func TestEntryEnsureValidContentNames(t *testing.T) {
+	// Define test cases.
+	tests := []struct {
+		name     string
+		expected bool
+	}{
+		{"", false},
+		{".", false},
+		{"..", false},
+		{"dir/", false},
+		{"/dir", false},
+		{"dir/child", false},
+		{"/", false},
+		{"dir", true},
+		{"...", true},
+		{".dir", true},
+		{"dir.", true},
+		{" dir", true},
+		{"dir ", true},
+		{"dir\\", true},
+		{"caf\u00e9", true},
+		{"cafe\u0301", true},
+	}
+
+	// Process test cases.
+	for i, test := range tests {
+		entry := &Entry{
+			Kind:     EntryKind_Directory,
+			Contents: map[string]*Entry{test.name: tD0},
+		}
+		if err := entry.EnsureValid(true); err == nil && !test.expected {
+			t.Errorf("test index %d: content name %q incorrectly classified as valid", i, test.name)
+		} else if err != nil && test.expected {
+			t.Errorf("test index %d: content name %q incorrectly classified as invalid: %v", i, test.name, err)
+		}
+	}
+}
+
+// TestEntryEqualContentNames tests that Entry.Equal compares content names
+// exactly, without case folding or Unicode normalization.
+func TestEntryEqualContentNames(t *testing.T) {
+	// Define test cases.
+	tests := []struct {
+		first    string
+		second   string
+		expected bool
+	}{
+		{"dir", "dir", true},
+		{"dir", "Dir", false},
+		{"dir", "dir ", false},
+		{"dir", "dir.", false},
+		{"caf\u00e9", "caf\u00e9", true},
+		{"caf\u00e9", "cafe\u0301", false},
+	}
+
+	// Process test cases.
+	for i, test := range tests {
+		first := &Entry{
+			Kind:     EntryKind_Directory,
+			Contents: map[string]*Entry{test.first: tD0},
+		}
+		second := &Entry{
+			Kind:     EntryKind_Directory,
+			Contents: map[string]*Entry{test.second: tD0},
+		}
+		if equal := first.Equal(second, true); equal != test.expected {
+			t.Errorf("test index %d: equality of %q and %q does not match expected: %t != %t",
+				i, test.first, test.second, equal, test.expected,
+			)
+		}
+	}
+}
+
 // testEntryWalkVisit encodes a visit operation from Entry.walk.
 type testEntryWalkVisit struct {
 	// path is the visited path.
@@ -448,6 +555,25 @@ func TestEntryCopy(t *testing.T) {
 			t.Errorf("test index %d: copy result does not match expected", i)
 		}
 	}
+
+	// Verify that modification times are preserved by all copy behaviors,
+	// since they aren't considered by Equal.
+	for _, behavior := range []EntryCopyBehavior{
+		EntryCopyBehaviorDeep,
+		EntryCopyBehaviorDeepPreservingLeaves,
+		EntryCopyBehaviorShallow,
+		EntryCopyBehaviorSlim,
+	} {
+		if result := tF1M.Copy(behavior); !proto.Equal(result.ModificationTime, tF1M.ModificationTime) {
+			t.Errorf("copy behavior %d: modification time not preserved", behavior)
+		}
+		directory := nested("file", tF1M).Copy(behavior)
+		if behavior == EntryCopyBehaviorSlim {
+			continue
+		} else if !proto.Equal(directory.Contents["file"].ModificationTime, tF1M.ModificationTime) {
+			t.Errorf("copy behavior %d: content modification time not preserved", behavior)
+		}
+	}
 }
 
 // TestEntrySynchronizable tests Entry.synchronizable.
@@ -131,3 +131,46 @@ func PropagateExecutability(ancestor, source, target *Entry) *Entry {
 	// Done.
 	return result
 }
+
+// normalizeExecutabilityRecursive sets executability recursively.
+func normalizeExecutabilityRecursive(entry *Entry, executable bool) {
+	// Handle based on entry kind.
+	if entry == nil {
+		return
+	} else if entry.Kind == EntryKind_Directory {
+		for _, child := range entry.Contents {
+			normalizeExecutabilityRecursive(child, executable)
+		}
+	} else if entry.Kind == EntryKind_File {
+		entry.Executable = executable
+	}
+}
+
+// NormalizeExecutability forces file executability to a canonical state based
+// on the specified executability mode. It is complementary to
+// PropagateExecutability and can be used to eliminate executability churn
+// between endpoints with differing executability preservation behavior. If the
+// mode is ExecutabilityMode_ExecutabilityModePreserve (or the default mode),
+// then content is returned unmodified. Otherwise a normalized copy of content
+// is returned.
+func NormalizeExecutability(content *Entry, mode ExecutabilityMode) *Entry {
+	// Determine the target executability setting.
+	var executable bool
+	switch mode {
+	case ExecutabilityMode_ExecutabilityModeNoExecutable:
+		executable = false
+	case ExecutabilityMode_ExecutabilityModeAllExecutable:
+		executable = true
+	default:
+		return content
+	}
+
+	// Create a copy of the content that we can mutate.
+	result := content.Copy(EntryCopyBehaviorDeep)
+
+	// Perform normalization.
+	normalizeExecutabilityRecursive(result, executable)
+
+	// Done.
+	return result
+}
@@ -0,0 +1,80 @@
+package core
+
+import (
+	"fmt"
+)
+
+// IsDefault indicates whether or not the executability mode is
+// ExecutabilityMode_ExecutabilityModeDefault.
+func (m ExecutabilityMode) IsDefault() bool {
+	return m == ExecutabilityMode_ExecutabilityModeDefault
+}
+
+// MarshalText implements encoding.TextMarshaler.MarshalText.
+func (m ExecutabilityMode) MarshalText() ([]byte, error) {
+	var result string
+	switch m {
+	case ExecutabilityMode_ExecutabilityModeDefault:
+	case ExecutabilityMode_ExecutabilityModePreserve:
+		result = "preserve"
+	case ExecutabilityMode_ExecutabilityModeNoExecutable:
+		result = "no-exec"
+	case ExecutabilityMode_ExecutabilityModeAllExecutable:
+		result = "all-exec"
+	default:
+		result = "unknown"
+	}
+	return []byte(result), nil
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler.UnmarshalText.
+func (m *ExecutabilityMode) UnmarshalText(textBytes []byte) error {
+	// Convert the bytes to a string.
+	text := string(textBytes)
+
+	// Convert to an executability mode.
+	switch text {
+	case "preserve":
+		*m = ExecutabilityMode_ExecutabilityModePreserve
+	case "no-exec":
+		*m = ExecutabilityMode_ExecutabilityModeNoExecutable
+	case "all-exec":
+		*m = ExecutabilityMode_ExecutabilityModeAllExecutable
+	default:
+		return fmt.Errorf("unknown executability mode specification: %s", text)
+	}
+
+	// Success.
+	return nil
+}
+
+// Supported indicates whether or not a particular executability mode is a
+// valid, non-default value.
+func (m ExecutabilityMode) Supported() bool {
+	switch m {
+	case ExecutabilityMode_ExecutabilityModePreserve:
+		return true
+	case ExecutabilityMode_ExecutabilityModeNoExecutable:
+		return true
+	case ExecutabilityMode_ExecutabilityModeAllExecutable:
+		return true
+	default:
+		return false
+	}
+}
+
+// Description returns a human-readable description of an executability mode.
+func (m ExecutabilityMode) Description() string {
+	switch m {
+	case ExecutabilityMode_ExecutabilityModeDefault:
+		return "Default"
+	case ExecutabilityMode_ExecutabilityModePreserve:
+		return "Preserve"
+	case ExecutabilityMode_ExecutabilityModeNoExecutable:
+		return "No Executable"
+	case ExecutabilityMode_ExecutabilityModeAllExecutable:
+		return "All Executable"
+	default:
+		return "Unknown"
+	}
+}
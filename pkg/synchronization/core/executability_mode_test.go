@@ -0,0 +1,116 @@
+package core
+
+import (
+	"testing"
+)
+
+// TestExecutabilityModeIsDefault tests ExecutabilityMode.IsDefault.
+func TestExecutabilityModeIsDefault(t *testing.T) {
+	// Define test cases.
+	tests := []struct {
+		value    ExecutabilityMode
+		expected bool
+	}{
+		{ExecutabilityMode_ExecutabilityModeDefault - 1, false},
+		{ExecutabilityMode_ExecutabilityModeDefault, true},
+		{ExecutabilityMode_ExecutabilityModePreserve, false},
+		{ExecutabilityMode_ExecutabilityModeNoExecutable, false},
+		{ExecutabilityMode_ExecutabilityModeAllExecutable, false},
+		{ExecutabilityMode_ExecutabilityModeAllExecutable + 1, false},
+	}
+
+	// Process test cases.
+	for i, test := range tests {
+		if result := test.value.IsDefault(); result && !test.expected {
+			t.Errorf("test index %d: value was unexpectedly classified as default", i)
+		} else if !result && test.expected {
+			t.Errorf("test index %d: value was unexpectedly classified as non-default", i)
+		}
+	}
+}
+
+// TestExecutabilityModeUnmarshalText tests ExecutabilityMode.UnmarshalText.
+func TestExecutabilityModeUnmarshalText(t *testing.T) {
+	// Define test cases.
+	tests := []struct {
+		text          string
+		expectedMode  ExecutabilityMode
+		expectFailure bool
+	}{
+		{"", ExecutabilityMode_ExecutabilityModeDefault, true},
+		{"asdf", ExecutabilityMode_ExecutabilityModeDefault, true},
+		{"preserve", ExecutabilityMode_ExecutabilityModePreserve, false},
+		{"no-exec", ExecutabilityMode_ExecutabilityModeNoExecutable, false},
+		{"all-exec", ExecutabilityMode_ExecutabilityModeAllExecutable, false},
+	}
+
+	// Process test cases.
+	for _, test := range tests {
+		var mode ExecutabilityMode
+		if err := mode.UnmarshalText([]byte(test.text)); err != nil {
+			if !test.expectFailure {
+				t.Errorf("unable to unmarshal text (%s): %s", test.text, err)
+			}
+		} else if test.expectFailure {
+			t.Error("unmarshaling succeeded unexpectedly for text:", test.text)
+		} else if mode != test.expectedMode {
+			t.Errorf(
+				"unmarshaled mode (%s) does not match expected (%s)",
+				mode,
+				test.expectedMode,
+			)
+		}
+	}
+}
+
+// TestExecutabilityModeSupported tests ExecutabilityMode.Supported.
+func TestExecutabilityModeSupported(t *testing.T) {
+	// Set up test cases.
+	testCases := []struct {
+		mode            ExecutabilityMode
+		expectSupported bool
+	}{
+		{ExecutabilityMode_ExecutabilityModeDefault, false},
+		{ExecutabilityMode_ExecutabilityModePreserve, true},
+		{ExecutabilityMode_ExecutabilityModeNoExecutable, true},
+		{ExecutabilityMode_ExecutabilityModeAllExecutable, true},
+		{(ExecutabilityMode_ExecutabilityModeAllExecutable + 1), false},
+	}
+
+	// Process test cases.
+	for _, testCase := range testCases {
+		if supported := testCase.mode.Supported(); supported != testCase.expectSupported {
+			t.Errorf(
+				"mode support status (%t) does not match expected (%t)",
+				supported,
+				testCase.expectSupported,
+			)
+		}
+	}
+}
+
+// TestExecutabilityModeDescription tests ExecutabilityMode.Description.
+func TestExecutabilityModeDescription(t *testing.T) {
+	// Set up test cases.
+	testCases := []struct {
+		mode                ExecutabilityMode
+		expectedDescription string
+	}{
+		{ExecutabilityMode_ExecutabilityModeDefault, "Default"},
+		{ExecutabilityMode_ExecutabilityModePreserve, "Preserve"},
+		{ExecutabilityMode_ExecutabilityModeNoExecutable, "No Executable"},
+		{ExecutabilityMode_ExecutabilityModeAllExecutable, "All Executable"},
+		{(ExecutabilityMode_ExecutabilityModeAllExecutable + 1), "Unknown"},
+	}
+
+	// Process test cases.
+	for _, testCase := range testCases {
+		if description := testCase.mode.Description(); description != testCase.expectedDescription {
+			t.Errorf(
+				"mode description (%s) does not match expected (%s)",
+				description,
+				testCase.expectedDescription,
+			)
+		}
+	}
+}
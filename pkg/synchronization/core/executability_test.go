@@ -92,3 +92,108 @@ func TestPropagateExecutability(t *testing.T) {
 		t.Error("executability propagation from ancestor and source incorrect")
 	}
 }
+
+// TestPropagateExecutabilityTwoWayNewest tests that executability propagation
+// preserves modification times, allowing two-way-newest reconciliation to
+// resolve changes involving an endpoint that doesn't preserve executability.
+func TestPropagateExecutabilityTwoWayNewest(t *testing.T) {
+	// Create an ancestor and alpha and beta entries where both sides have
+	// modified a file, but alpha's modification is newer. Alpha is treated as
+	// not preserving executability, so executability is propagated to it.
+	ancestor := nested("file", tF3)
+	alpha := PropagateExecutability(ancestor, nested("file", modified(tF2, 10)), nested("file", modified(tF1, 20)))
+	beta := nested("file", modified(tF2, 10))
+
+	// Verify that the modification time survived propagation.
+	if alpha.Contents["file"].ModificationTime == nil {
+		t.Fatal("executability propagation discarded modification time")
+	}
+
+	// Perform reconciliation and verify that alpha's change wins.
+	_, alphaChanges, betaChanges, conflicts := Reconcile(
+		ancestor, alpha, beta,
+		SynchronizationMode_SynchronizationModeTwoWayNewest,
+		TypeChangeMode_TypeChangeModePropagate,
+		0, 0,
+		nil,
+	)
+	if len(conflicts) != 0 {
+		t.Error("unexpected conflicts:", len(conflicts))
+	}
+	if len(alphaChanges) != 0 {
+		t.Error("unexpected alpha changes:", len(alphaChanges))
+	}
+	if len(betaChanges) != 1 {
+		t.Error("unexpected beta change count:", len(betaChanges), "!=", 1)
+	}
+}
+
+// checkExecutabilityRecursive verifies that all file entries in a hierarchy
+// have the specified executability setting.
+func checkExecutabilityRecursive(entry *Entry, executable bool) bool {
+	if entry == nil {
+		return true
+	} else if entry.Kind == EntryKind_File {
+		return entry.Executable == executable
+	}
+	for _, child := range entry.Contents {
+		if !checkExecutabilityRecursive(child, executable) {
+			return false
+		}
+	}
+	return true
+}
+
+// TestNormalizeExecutability tests NormalizeExecutability.
+func TestNormalizeExecutability(t *testing.T) {
+	// Test normalization of nil content.
+	for _, mode := range []ExecutabilityMode{
+		ExecutabilityMode_ExecutabilityModePreserve,
+		ExecutabilityMode_ExecutabilityModeNoExecutable,
+		ExecutabilityMode_ExecutabilityModeAllExecutable,
+	} {
+		if NormalizeExecutability(nil, mode) != nil {
+			t.Errorf("normalization of nil content with mode %s did not return nil", mode.Description())
+		}
+	}
+
+	// Test that preservation (including via the default mode) returns content
+	// unmodified.
+	if NormalizeExecutability(tDMU, ExecutabilityMode_ExecutabilityModeDefault) != tDMU {
+		t.Error("normalization with default mode modified content")
+	} else if NormalizeExecutability(tDMU, ExecutabilityMode_ExecutabilityModePreserve) != tDMU {
+		t.Error("normalization with preserve mode modified content")
+	}
+
+	// Test the no-exec normalization policy.
+	stripped := NormalizeExecutability(tDMU, ExecutabilityMode_ExecutabilityModeNoExecutable)
+	if stripped == tDMU {
+		t.Fatal("no-exec normalization did not make entry copy")
+	} else if !checkExecutabilityRecursive(stripped, false) {
+		t.Error("no-exec normalization left executable files")
+	} else if !stripped.Equal(stripExecutability(tDMU), true) {
+		t.Error("no-exec normalization does not match stripped entry")
+	}
+
+	// Test the all-exec normalization policy.
+	marked := NormalizeExecutability(tDMU, ExecutabilityMode_ExecutabilityModeAllExecutable)
+	if marked == tDMU {
+		t.Fatal("all-exec normalization did not make entry copy")
+	} else if !checkExecutabilityRecursive(marked, true) {
+		t.Error("all-exec normalization left non-executable files")
+	} else if !stripExecutability(marked).Equal(stripped, true) {
+		t.Error("all-exec normalization modified non-executability content")
+	}
+	if tF1.Executable {
+		t.Fatal("test file entry unexpectedly executable")
+	} else if result := NormalizeExecutability(tF1, ExecutabilityMode_ExecutabilityModeAllExecutable); !result.Executable {
+		t.Error("all-exec normalization of file entry did not mark file executable")
+	} else if tF1.Executable {
+		t.Error("all-exec normalization modified original entry")
+	}
+
+	// Test that normalization doesn't affect non-file entries.
+	if result := NormalizeExecutability(tSR, ExecutabilityMode_ExecutabilityModeAllExecutable); !result.Equal(tSR, true) {
+		t.Error("all-exec normalization modified symbolic link entry")
+	}
+}
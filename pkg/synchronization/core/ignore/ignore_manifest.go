@@ -0,0 +1,77 @@
+package ignore
+
+import (
+	"errors"
+	pathpkg "path"
+	"strings"
+)
+
+// EnsureManifestPathValid ensures that the provided path is valid for use in a
+// synchronization manifest. Manifest paths must be non-empty, relative to the
+// synchronization root, slash-separated, and in clean form.
+func EnsureManifestPathValid(path string) error {
+	if path == "" {
+		return errors.New("empty path")
+	} else if path[0] == '/' {
+		return errors.New("absolute path")
+	} else if pathpkg.Clean(path) != path {
+		return errors.New("path not in clean form")
+	} else if path == "." {
+		return errors.New("root path")
+	} else if path == ".." || strings.HasPrefix(path, "../") {
+		return errors.New("path escapes synchronization root")
+	}
+	return nil
+}
+
+// manifestIgnorer is a wrapper Ignorer that restricts synchronization to the
+// paths specified in a manifest.
+type manifestIgnorer struct {
+	// ignorer is the underlying ignorer.
+	ignorer Ignorer
+	// included is the set of paths included by the manifest, including the
+	// parent paths of manifest entries.
+	included map[string]bool
+}
+
+// Ignore implements Ignorer.Ignore.
+func (i *manifestIgnorer) Ignore(path string, directory bool) (IgnoreStatus, bool) {
+	// Ignore any content not included by the manifest. There's no need to
+	// continue traversal in this case, because any content beneath a manifest
+	// path's parent would itself be included.
+	if !i.included[path] {
+		return IgnoreStatusIgnored, false
+	}
+
+	// Dispatch all other requests to the underlying ignorer.
+	return i.ignorer.Ignore(path, directory)
+}
+
+// IgnoreUnmanifested wraps an ignorer, modifying it to ignore any content that
+// isn't specified in a manifest (or a parent directory of content specified in
+// the manifest). Manifest paths are matched exactly, so content beneath a
+// manifested directory isn't included unless it's also listed. The underlying
+// ignorer is still consulted for manifested content, so ignores take precedence
+// over the manifest. The manifest paths must be valid (as determined by
+// EnsureManifestPathValid). If the manifest is empty, then the ignorer is
+// returned unmodified.
+func IgnoreUnmanifested(ignorer Ignorer, manifest []string) Ignorer {
+	// If there's no manifest, then no restriction is necessary.
+	if len(manifest) == 0 {
+		return ignorer
+	}
+
+	// Compute the set of included paths.
+	included := make(map[string]bool, len(manifest))
+	for _, path := range manifest {
+		for ; path != "."; path = pathpkg.Dir(path) {
+			if included[path] {
+				break
+			}
+			included[path] = true
+		}
+	}
+
+	// Create the ignorer.
+	return &manifestIgnorer{ignorer, included}
+}
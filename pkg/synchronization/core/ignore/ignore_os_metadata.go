@@ -0,0 +1,117 @@
+package ignore
+
+import (
+	"strings"
+
+	"github.com/mutagen-io/mutagen/pkg/synchronization/core/fastpath"
+)
+
+// osMetadataNames are the names of metadata files and directories that
+// operating systems and desktop environments commonly create alongside user
+// content. Since this metadata tends to travel between systems (e.g. via
+// archives and removable media), the set isn't restricted to the names used by
+// the current platform. Names ending in "*" match by prefix.
+var osMetadataNames = []string{
+	// macOS (Finder, Spotlight, and the file system event daemon).
+	".DS_Store",
+	"._*",
+	".AppleDouble",
+	".LSOverride",
+	".Spotlight-V100",
+	".Trashes",
+	".fseventsd",
+	".TemporaryItems",
+	".DocumentRevisions-V100",
+	".VolumeIcon.icns",
+	".apdisk",
+	// Windows (Explorer and volume management).
+	"Thumbs.db",
+	"ehthumbs.db",
+	"ehthumbs_vista.db",
+	"desktop.ini",
+	"Desktop.ini",
+	"$RECYCLE.BIN",
+	"System Volume Information",
+	// Linux (desktop environments, FUSE, and NFS).
+	".directory",
+	".Trash-*",
+	".fuse_hidden*",
+	".nfs*",
+}
+
+// DefaultOSMetadataNames returns the default set of OS metadata names ignored
+// by IgnoreOSMetadata. The result is a new slice that callers may extend or
+// modify before passing it to IgnoreOSMetadata.
+func DefaultOSMetadataNames() []string {
+	return append([]string(nil), osMetadataNames...)
+}
+
+// osMetadataIgnorer is a wrapper Ignorer that provides OS metadata ignoring
+// behavior.
+type osMetadataIgnorer struct {
+	// ignorer is the underlying ignorer.
+	ignorer Ignorer
+	// names is the set of exact names to ignore.
+	names map[string]bool
+	// prefixes are the name prefixes to ignore.
+	prefixes []string
+}
+
+// matches returns whether or not a name is an OS metadata name.
+func (i *osMetadataIgnorer) matches(name string) bool {
+	if i.names[name] {
+		return true
+	}
+	for _, prefix := range i.prefixes {
+		if strings.HasPrefix(name, prefix) {
+			return true
+		}
+	}
+	return false
+}
+
+// Ignore implements Ignorer.Ignore.
+func (i *osMetadataIgnorer) Ignore(path string, directory bool) (IgnoreStatus, bool) {
+	// Dispatch the request to the underlying ignorer. If it explicitly
+	// unignores the path, then that takes precedence, allowing individual
+	// metadata names to be overridden using negated ignore patterns.
+	status, continueTraversal := i.ignorer.Ignore(path, directory)
+	if status == IgnoreStatusUnignored {
+		return status, continueTraversal
+	}
+
+	// Watch for and ignore any OS metadata.
+	if i.matches(fastpath.Base(path)) {
+		return IgnoreStatusIgnored, false
+	}
+
+	// Otherwise defer to the underlying ignorer's result.
+	return status, continueTraversal
+}
+
+// IgnoreOSMetadata wraps an ignorer, modifying it to ignore OS metadata files
+// and directories with the specified names. Names ending in "*" match by
+// prefix. If names is nil, then DefaultOSMetadataNames is used. Content that the
+// underlying ignorer explicitly unignores is not ignored.
+func IgnoreOSMetadata(ignorer Ignorer, names []string) Ignorer {
+	// Use the default names if none have been specified.
+	if names == nil {
+		names = osMetadataNames
+	}
+
+	// Split names into exact names and prefixes.
+	result := &osMetadataIgnorer{
+		ignorer: ignorer,
+		names:   make(map[string]bool, len(names)),
+	}
+	for _, name := range names {
+		if prefix, ok := strings.CutSuffix(name, "*"); ok {
+			result.prefixes = append(result.prefixes, prefix)
+		} else {
+			result.names[name] = true
+		}
+	}
+
+	// Done.
+	return result
+}
@@ -0,0 +1,80 @@
+package ignore
+
+import (
+	"errors"
+	"fmt"
+)
+
+// IsDefault indicates whether or not the OS metadata ignore mode is
+// IgnoreOSMetadataMode_IgnoreOSMetadataModeDefault.
+func (m IgnoreOSMetadataMode) IsDefault() bool {
+	return m == IgnoreOSMetadataMode_IgnoreOSMetadataModeDefault
+}
+
+// MarshalJSON implements encoding/json.Marshaler.MarshalJSON.
+func (m IgnoreOSMetadataMode) MarshalJSON() ([]byte, error) {
+	var result string
+	switch m {
+	case IgnoreOSMetadataMode_IgnoreOSMetadataModeDefault:
+		return nil, errors.New("default OS metadata ignore mode has no JSON representation")
+	case IgnoreOSMetadataMode_IgnoreOSMetadataModeIgnore:
+		result = "true"
+	case IgnoreOSMetadataMode_IgnoreOSMetadataModePropagate:
+		result = "false"
+	default:
+		return nil, fmt.Errorf("invalid OS metadata ignore mode: %d", m)
+	}
+	return []byte(result), nil
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler.UnmarshalText.
+func (m *IgnoreOSMetadataMode) UnmarshalText(textBytes []byte) error {
+	// Convert the bytes to a string.
+	text := string(textBytes)
+
+	// Convert to an OS metadata ignore mode.
+	switch text {
+	case "true":
+		*m = IgnoreOSMetadataMode_IgnoreOSMetadataModeIgnore
+	case "false":
+		*m = IgnoreOSMetadataMode_IgnoreOSMetadataModePropagate
+	default:
+		return fmt.Errorf("unknown OS metadata ignore specification: %s", text)
+	}
+
+	// Success.
+	return nil
+}
+
+// UnmarshalJSON implements encoding/json.Unmarshaler.UnmarshalJSON.
+func (m *IgnoreOSMetadataMode) UnmarshalJSON(textBytes []byte) error {
+	return m.UnmarshalText(textBytes)
+}
+
+// Supported indicates whether or not a particular OS metadata ignore mode is a
+// valid, non-default value.
+func (m IgnoreOSMetadataMode) Supported() bool {
+	switch m {
+	case IgnoreOSMetadataMode_IgnoreOSMetadataModeIgnore:
+		return true
+	case IgnoreOSMetadataMode_IgnoreOSMetadataModePropagate:
+		return true
+	default:
+		return false
+	}
+}
+
+// Description returns a human-readable description of an OS metadata ignore
+// mode.
+func (m IgnoreOSMetadataMode) Description() string {
+	switch m {
+	case IgnoreOSMetadataMode_IgnoreOSMetadataModeDefault:
+		return "Default"
+	case IgnoreOSMetadataMode_IgnoreOSMetadataModeIgnore:
+		return "Ignore"
+	case IgnoreOSMetadataMode_IgnoreOSMetadataModePropagate:
+		return "Propagate"
+	default:
+		return "Unknown"
+	}
+}
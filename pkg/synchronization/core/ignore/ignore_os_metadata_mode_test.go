@@ -0,0 +1,115 @@
+package ignore
+
+import (
+	"testing"
+)
+
+// TestIgnoreOSMetadataModeIsDefault tests IgnoreOSMetadataMode.IsDefault.
+func TestIgnoreOSMetadataModeIsDefault(t *testing.T) {
+	// Define test cases.
+	tests := []struct {
+		value    IgnoreOSMetadataMode
+		expected bool
+	}{
+		{IgnoreOSMetadataMode_IgnoreOSMetadataModeDefault - 1, false},
+		{IgnoreOSMetadataMode_IgnoreOSMetadataModeDefault, true},
+		{IgnoreOSMetadataMode_IgnoreOSMetadataModeIgnore, false},
+		{IgnoreOSMetadataMode_IgnoreOSMetadataModePropagate, false},
+		{IgnoreOSMetadataMode_IgnoreOSMetadataModePropagate + 1, false},
+	}
+
+	// Process test cases.
+	for i, test := range tests {
+		if result := test.value.IsDefault(); result && !test.expected {
+			t.Errorf("test index %d: value was unexpectedly classified as default", i)
+		} else if !result && test.expected {
+			t.Errorf("test index %d: value was unexpectedly classified as non-default", i)
+		}
+	}
+}
+
+// TestIgnoreOSMetadataModeUnmarshalText tests
+// IgnoreOSMetadataMode.UnmarshalText.
+func TestIgnoreOSMetadataModeUnmarshalText(t *testing.T) {
+	// Define test cases.
+	tests := []struct {
+		text          string
+		expectedMode  IgnoreOSMetadataMode
+		expectFailure bool
+	}{
+		{"", IgnoreOSMetadataMode_IgnoreOSMetadataModeDefault, true},
+		{"asdf", IgnoreOSMetadataMode_IgnoreOSMetadataModeDefault, true},
+		{"true", IgnoreOSMetadataMode_IgnoreOSMetadataModeIgnore, false},
+		{"false", IgnoreOSMetadataMode_IgnoreOSMetadataModePropagate, false},
+	}
+
+	// Process test cases.
+	for _, test := range tests {
+		var mode IgnoreOSMetadataMode
+		if err := mode.UnmarshalText([]byte(test.text)); err != nil {
+			if !test.expectFailure {
+				t.Errorf("unable to unmarshal text (%s): %s", test.text, err)
+			}
+		} else if test.expectFailure {
+			t.Error("unmarshaling succeeded unexpectedly for text:", test.text)
+		} else if mode != test.expectedMode {
+			t.Errorf(
+				"unmarshaled mode (%s) does not match expected (%s)",
+				mode,
+				test.expectedMode,
+			)
+		}
+	}
+}
+
+// TestIgnoreOSMetadataModeSupported tests that IgnoreOSMetadataMode support
+// detection works as expected.
+func TestIgnoreOSMetadataModeSupported(t *testing.T) {
+	// Set up test cases.
+	testCases := []struct {
+		mode            IgnoreOSMetadataMode
+		expectSupported bool
+	}{
+		{IgnoreOSMetadataMode_IgnoreOSMetadataModeDefault, false},
+		{IgnoreOSMetadataMode_IgnoreOSMetadataModeIgnore, true},
+		{IgnoreOSMetadataMode_IgnoreOSMetadataModePropagate, true},
+		{(IgnoreOSMetadataMode_IgnoreOSMetadataModePropagate + 1), false},
+	}
+
+	// Process test cases.
+	for _, testCase := range testCases {
+		if supported := testCase.mode.Supported(); supported != testCase.expectSupported {
+			t.Errorf(
+				"mode support status (%t) does not match expected (%t)",
+				supported,
+				testCase.expectSupported,
+			)
+		}
+	}
+}
+
+// TestIgnoreOSMetadataModeDescription tests that IgnoreOSMetadataMode
+// description generation works as expected.
+func TestIgnoreOSMetadataModeDescription(t *testing.T) {
+	// Set up test cases.
+	testCases := []struct {
+		mode                IgnoreOSMetadataMode
+		expectedDescription string
+	}{
+		{IgnoreOSMetadataMode_IgnoreOSMetadataModeDefault, "Default"},
+		{IgnoreOSMetadataMode_IgnoreOSMetadataModeIgnore, "Ignore"},
+		{IgnoreOSMetadataMode_IgnoreOSMetadataModePropagate, "Propagate"},
+		{(IgnoreOSMetadataMode_IgnoreOSMetadataModePropagate + 1), "Unknown"},
+	}
+
+	// Process test cases.
+	for _, testCase := range testCases {
+		if description := testCase.mode.Description(); description != testCase.expectedDescription {
+			t.Errorf(
+				"mode description (%s) does not match expected (%s)",
+				description,
+				testCase.expectedDescription,
+			)
+		}
+	}
+}
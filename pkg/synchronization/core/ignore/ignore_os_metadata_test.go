@@ -0,0 +1,79 @@
+package ignore
+
+import (
+	"testing"
+)
+
+// testingIgnorer is an Ignorer that returns fixed results for specified paths
+// and nominal results for all other paths.
+type testingIgnorer map[string]IgnoreStatus
+
+// Ignore implements Ignorer.Ignore.
+func (i testingIgnorer) Ignore(path string, _ bool) (IgnoreStatus, bool) {
+	if status, ok := i[path]; ok {
+		return status, status != IgnoreStatusIgnored
+	}
+	return IgnoreStatusNominal, true
+}
+
+// TestIgnoreOSMetadata tests IgnoreOSMetadata.
+func TestIgnoreOSMetadata(t *testing.T) {
+	// Define test cases.
+	testCases := []struct {
+		names                     []string
+		path                      string
+		directory                 bool
+		expectedStatus            IgnoreStatus
+		expectedContinueTraversal bool
+	}{
+		{nil, "file", false, IgnoreStatusNominal, true},
+		{nil, "directory", true, IgnoreStatusNominal, true},
+		{nil, ".DS_Store", false, IgnoreStatusIgnored, false},
+		{nil, "sub/.DS_Store", false, IgnoreStatusIgnored, false},
+		{nil, "sub/._file.txt", false, IgnoreStatusIgnored, false},
+		{nil, "sub/Thumbs.db", false, IgnoreStatusIgnored, false},
+		{nil, "$RECYCLE.BIN", true, IgnoreStatusIgnored, false},
+		{nil, ".Trash-1000", true, IgnoreStatusIgnored, false},
+		{nil, "sub/.directory", false, IgnoreStatusIgnored, false},
+		{nil, "DS_Store", false, IgnoreStatusNominal, true},
+		{nil, "sub/.DS_Store.txt", false, IgnoreStatusNominal, true},
+		{nil, "ignored", false, IgnoreStatusIgnored, false},
+		{nil, "unignored/.DS_Store", false, IgnoreStatusUnignored, true},
+		{[]string{"custom"}, "sub/custom", false, IgnoreStatusIgnored, false},
+		{[]string{"custom"}, ".DS_Store", false, IgnoreStatusNominal, true},
+		{append(DefaultOSMetadataNames(), "custom*"), "custom.db", false, IgnoreStatusIgnored, false},
+		{append(DefaultOSMetadataNames(), "custom*"), ".DS_Store", false, IgnoreStatusIgnored, false},
+	}
+
+	// Create the underlying ignorer.
+	underlying := testingIgnorer{
+		"ignored":             IgnoreStatusIgnored,
+		"unignored/.DS_Store": IgnoreStatusUnignored,
+	}
+
+	// Process test cases.
+	for i, testCase := range testCases {
+		ignorer := IgnoreOSMetadata(underlying, testCase.names)
+		status, continueTraversal := ignorer.Ignore(testCase.path, testCase.directory)
+		if status != testCase.expectedStatus {
+			t.Errorf("test index %d: ignore status does not match expected: %v != %v",
+				i, status, testCase.expectedStatus,
+			)
+		}
+		if continueTraversal != testCase.expectedContinueTraversal {
+			t.Errorf("test index %d: traversal continuation does not match expected: %t != %t",
+				i, continueTraversal, testCase.expectedContinueTraversal,
+			)
+		}
+	}
+}
+
+// TestDefaultOSMetadataNamesCopy tests that DefaultOSMetadataNames returns a
+// copy of the default names.
+func TestDefaultOSMetadataNamesCopy(t *testing.T) {
+	names := DefaultOSMetadataNames()
+	names[0] = "modified"
+	if DefaultOSMetadataNames()[0] == "modified" {
+		t.Error("default OS metadata names modified via returned slice")
+	}
+}
@@ -0,0 +1,158 @@
+package core
+
+import (
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// jsonEntry is the JSON representation of an Entry used by ExportJSON and
+// ImportJSON. Digests are hex-encoded and directory contents are encoded as a
+// JSON object, which encoding/json emits with sorted keys.
+type jsonEntry struct {
+	// Kind is the entry kind.
+	Kind EntryKind `json:"kind"`
+	// Contents are the directory contents.
+	Contents map[string]*jsonEntry `json:"contents,omitempty"`
+	// AggregateDigest is the hex-encoded aggregate digest.
+	AggregateDigest string `json:"aggregateDigest,omitempty"`
+	// Digest is the hex-encoded file digest.
+	Digest string `json:"digest,omitempty"`
+	// Executable indicates file executability.
+	Executable bool `json:"executable,omitempty"`
+	// ModificationTime is the file modification time.
+	ModificationTime *time.Time `json:"modificationTime,omitempty"`
+	// Target is the symbolic link target.
+	Target string `json:"target,omitempty"`
+	// Problem is the problem for problematic content.
+	Problem string `json:"problem,omitempty"`
+	// ProblemCode is the problem code for problematic content.
+	ProblemCode *ProblemCode `json:"problemCode,omitempty"`
+}
+
+// newJSONEntry converts an entry to its JSON representation.
+func newJSONEntry(entry *Entry) *jsonEntry {
+	// Handle nil entries.
+	if entry == nil {
+		return nil
+	}
+
+	// Convert the entry.
+	result := &jsonEntry{
+		Kind:       entry.Kind,
+		Executable: entry.Executable,
+		Target:     entry.Target,
+		Problem:    entry.Problem,
+	}
+	if len(entry.Contents) > 0 {
+		result.Contents = make(map[string]*jsonEntry, len(entry.Contents))
+		for name, child := range entry.Contents {
+			result.Contents[name] = newJSONEntry(child)
+		}
+	}
+	if entry.AggregateDigest != nil {
+		result.AggregateDigest = hex.EncodeToString(entry.AggregateDigest)
+	}
+	if entry.Digest != nil {
+		result.Digest = hex.EncodeToString(entry.Digest)
+	}
+	if entry.ModificationTime != nil {
+		modificationTime := entry.ModificationTime.AsTime()
+		result.ModificationTime = &modificationTime
+	}
+	if entry.ProblemCode != ProblemCode_ProblemCodeUnknown {
+		problemCode := entry.ProblemCode
+		result.ProblemCode = &problemCode
+	}
+
+	// Done.
+	return result
+}
+
+// entry converts a JSON representation back to an entry.
+func (e *jsonEntry) entry() (*Entry, error) {
+	// Handle nil entries.
+	if e == nil {
+		return nil, nil
+	}
+
+	// Convert the entry.
+	result := &Entry{
+		Kind:       e.Kind,
+		Executable: e.Executable,
+		Target:     e.Target,
+		Problem:    e.Problem,
+	}
+	if len(e.Contents) > 0 {
+		result.Contents = make(map[string]*Entry, len(e.Contents))
+		for name, child := range e.Contents {
+			if child == nil {
+				return nil, fmt.Errorf("nil content entry for name \"%s\"", name)
+			} else if converted, err := child.entry(); err != nil {
+				return nil, err
+			} else {
+				result.Contents[name] = converted
+			}
+		}
+	}
+	if e.AggregateDigest != "" {
+		if digest, err := hex.DecodeString(e.AggregateDigest); err != nil {
+			return nil, fmt.Errorf("invalid aggregate digest: %w", err)
+		} else {
+			result.AggregateDigest = digest
+		}
+	}
+	if e.Digest != "" {
+		if digest, err := hex.DecodeString(e.Digest); err != nil {
+			return nil, fmt.Errorf("invalid digest: %w", err)
+		} else {
+			result.Digest = digest
+		}
+	}
+	if e.ModificationTime != nil {
+		result.ModificationTime = timestamppb.New(*e.ModificationTime)
+	}
+	if e.ProblemCode != nil {
+		result.ProblemCode = *e.ProblemCode
+	}
+
+	// Done.
+	return result, nil
+}
+
+// ExportJSON encodes an entry hierarchy as indented JSON intended for external
+// inspection and test fixture construction. Entry kinds and problem codes are
+// encoded using their textual representations, digests are hex-encoded, and
+// directory contents are emitted in sorted order, so the encoding of a given
+// entry is deterministic. A nil entry is encoded as null. The encoding can be
+// decoded losslessly using ImportJSON.
+func ExportJSON(entry *Entry) ([]byte, error) {
+	return json.MarshalIndent(newJSONEntry(entry), "", "\t")
+}
+
+// ImportJSON decodes an entry hierarchy encoded by ExportJSON. The resulting
+// entry is validated before being returned.
+func ImportJSON(data []byte) (*Entry, error) {
+	// Decode the JSON representation.
+	var decoded *jsonEntry
+	if err := json.Unmarshal(data, &decoded); err != nil {
+		return nil, fmt.Errorf("unable to decode JSON: %w", err)
+	}
+
+	// Convert the representation.
+	entry, err := decoded.entry()
+	if err != nil {
+		return nil, err
+	}
+
+	// Validate the entry.
+	if err := entry.EnsureValid(false); err != nil {
+		return nil, fmt.Errorf("invalid entry: %w", err)
+	}
+
+	// Success.
+	return entry, nil
+}
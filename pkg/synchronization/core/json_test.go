@@ -0,0 +1,111 @@
+package core
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"google.golang.org/protobuf/proto"
+)
+
+// TestExportImportJSON tests that ExportJSON and ImportJSON round-trip entries
+// losslessly and that exports are deterministic.
+func TestExportImportJSON(t *testing.T) {
+	// Define test cases.
+	testCases := []*Entry{
+		tN,
+		tF1,
+		tF3E,
+		tF1M,
+		tSR,
+		tX,
+		tU,
+		tP1,
+		tO,
+		tPD1,
+		tD0,
+		tD1A,
+		tDM,
+		tDMU,
+		tDPD0,
+		{Kind: EntryKind_Problematic, Problem: "access denied", ProblemCode: ProblemCode_ProblemCodePermissionDenied},
+	}
+
+	// Process test cases.
+	for i, entry := range testCases {
+		// Export the entry.
+		data, err := ExportJSON(entry)
+		if err != nil {
+			t.Errorf("test index %d: unable to export entry: %v", i, err)
+			continue
+		}
+
+		// Verify that exports are deterministic.
+		for r := 0; r < 5; r++ {
+			if repeated, err := ExportJSON(entry); err != nil {
+				t.Errorf("test index %d: unable to repeat export: %v", i, err)
+			} else if !bytes.Equal(repeated, data) {
+				t.Errorf("test index %d: repeated export does not match original", i)
+			}
+		}
+
+		// Import the entry and verify that it matches the original.
+		imported, err := ImportJSON(data)
+		if err != nil {
+			t.Errorf("test index %d: unable to import entry: %v", i, err)
+		} else if !proto.Equal(imported, entry) {
+			t.Errorf("test index %d: imported entry does not match original", i)
+		}
+	}
+}
+
+// TestExportJSONFormat tests that ExportJSON hex-encodes digests and emits
+// directory contents in sorted order.
+func TestExportJSONFormat(t *testing.T) {
+	// Export a directory with multiple children.
+	data, err := ExportJSON(&Entry{
+		Contents: map[string]*Entry{
+			"zeta":  {Kind: EntryKind_File, Digest: []byte{0xde, 0xad}, Executable: true},
+			"alpha": {Kind: EntryKind_SymbolicLink, Target: "zeta"},
+			"mu":    {},
+		},
+	})
+	if err != nil {
+		t.Fatal("unable to export entry:", err)
+	}
+	exported := string(data)
+
+	// Verify encoding.
+	if !strings.Contains(exported, `"digest": "dead"`) {
+		t.Error("digest not hex-encoded:", exported)
+	}
+	if !strings.Contains(exported, `"kind": "symlink"`) {
+		t.Error("kind not textually encoded:", exported)
+	}
+	alpha := strings.Index(exported, `"alpha": {`)
+	mu := strings.Index(exported, `"mu": {`)
+	zeta := strings.Index(exported, `"zeta": {`)
+	if alpha < 0 || mu < 0 || zeta < 0 || !(alpha < mu && mu < zeta) {
+		t.Error("directory contents not sorted:", exported)
+	}
+}
+
+// TestImportJSONInvalid tests that ImportJSON rejects invalid input.
+func TestImportJSONInvalid(t *testing.T) {
+	// Define test cases.
+	testCases := []string{
+		`{`,
+		`{"kind": "invalid"}`,
+		`{"kind": "file", "digest": "not-hex"}`,
+		`{"kind": "file"}`,
+		`{"contents": {"child": null}}`,
+		`{"kind": "directory", "digest": "00"}`,
+	}
+
+	// Process test cases.
+	for i, data := range testCases {
+		if _, err := ImportJSON([]byte(data)); err == nil {
+			t.Errorf("test index %d: invalid input imported successfully", i)
+		}
+	}
+}
@@ -23,6 +23,8 @@ func (m SynchronizationMode) MarshalText() ([]byte, error) {
 		result = "one-way-safe"
 	case SynchronizationMode_SynchronizationModeOneWayReplica:
 		result = "one-way-replica"
+	case SynchronizationMode_SynchronizationModeTwoWayNewest:
+		result = "two-way-newest"
 	default:
 		result = "unknown"
 	}
@@ -44,6 +46,8 @@ func (m *SynchronizationMode) UnmarshalText(textBytes []byte) error {
 		*m = SynchronizationMode_SynchronizationModeOneWaySafe
 	case "one-way-replica":
 		*m = SynchronizationMode_SynchronizationModeOneWayReplica
+	case "two-way-newest":
+		*m = SynchronizationMode_SynchronizationModeTwoWayNewest
 	default:
 		return fmt.Errorf("unknown synchronization mode specification: %s", text)
 	}
@@ -64,6 +68,8 @@ func (m SynchronizationMode) Supported() bool {
 		return true
 	case SynchronizationMode_SynchronizationModeOneWayReplica:
 		return true
+	case SynchronizationMode_SynchronizationModeTwoWayNewest:
+		return true
 	default:
 		return false
 	}
@@ -82,6 +88,8 @@ func (m SynchronizationMode) Description() string {
 		return "One Way Safe"
 	case SynchronizationMode_SynchronizationModeOneWayReplica:
 		return "One Way Replica"
+	case SynchronizationMode_SynchronizationModeTwoWayNewest:
+		return "Two Way Newest"
 	default:
 		return "Unknown"
 	}
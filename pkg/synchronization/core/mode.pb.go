@@ -53,6 +53,14 @@ const (
 	// (verbatim) to beta, overwriting any conflicting contents on beta and
 	// deleting any extraneous contents on beta.
 	SynchronizationMode_SynchronizationModeOneWayReplica SynchronizationMode = 4
+	// SynchronizationMode_SynchronizationModeTwoWayNewest is the same as
+	// SynchronizationMode_SynchronizationModeTwoWaySafe, but specifies that
+	// conflicts between files modified on both alpha and beta should be
+	// resolved automatically in favor of the file with the most recent
+	// modification time (after adjusting for any measured clock offset between
+	// the endpoints). Files with identical modification times, as well as
+	// conflicts involving non-file content, are left unresolved.
+	SynchronizationMode_SynchronizationModeTwoWayNewest SynchronizationMode = 5
 )
 
 // Enum value maps for SynchronizationMode.
@@ -63,6 +71,7 @@ var (
 		2: "SynchronizationModeTwoWayResolved",
 		3: "SynchronizationModeOneWaySafe",
 		4: "SynchronizationModeOneWayReplica",
+		5: "SynchronizationModeTwoWayNewest",
 	}
 	SynchronizationMode_value = map[string]int32{
 		"SynchronizationModeDefault":        0,
@@ -70,6 +79,7 @@ var (
 		"SynchronizationModeTwoWayResolved": 2,
 		"SynchronizationModeOneWaySafe":     3,
 		"SynchronizationModeOneWayReplica":  4,
+		"SynchronizationModeTwoWayNewest":   5,
 	}
 )
 
@@ -105,7 +115,7 @@ var File_synchronization_core_mode_proto protoreflect.FileDescriptor
 var file_synchronization_core_mode_proto_rawDesc = []byte{
 	0x0a, 0x1f, 0x73, 0x79, 0x6e, 0x63, 0x68, 0x72, 0x6f, 0x6e, 0x69, 0x7a, 0x61, 0x74, 0x69, 0x6f,
 	0x6e, 0x2f, 0x63, 0x6f, 0x72, 0x65, 0x2f, 0x6d, 0x6f, 0x64, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74,
-	0x6f, 0x12, 0x04, 0x63, 0x6f, 0x72, 0x65, 0x2a, 0xc8, 0x01, 0x0a, 0x13, 0x53, 0x79, 0x6e, 0x63,
+	0x6f, 0x12, 0x04, 0x63, 0x6f, 0x72, 0x65, 0x2a, 0xed, 0x01, 0x0a, 0x13, 0x53, 0x79, 0x6e, 0x63,
 	0x68, 0x72, 0x6f, 0x6e, 0x69, 0x7a, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x4d, 0x6f, 0x64, 0x65, 0x12,
 	0x1e, 0x0a, 0x1a, 0x53, 0x79, 0x6e, 0x63, 0x68, 0x72, 0x6f, 0x6e, 0x69, 0x7a, 0x61, 0x74, 0x69,
 	0x6f, 0x6e, 0x4d, 0x6f, 0x64, 0x65, 0x44, 0x65, 0x66, 0x61, 0x75, 0x6c, 0x74, 0x10, 0x00, 0x12,
@@ -118,11 +128,13 @@ var file_synchronization_core_mode_proto_rawDesc = []byte{
 	0x4f, 0x6e, 0x65, 0x57, 0x61, 0x79, 0x53, 0x61, 0x66, 0x65, 0x10, 0x03, 0x12, 0x24, 0x0a, 0x20,
 	0x53, 0x79, 0x6e, 0x63, 0x68, 0x72, 0x6f, 0x6e, 0x69, 0x7a, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x4d,
 	0x6f, 0x64, 0x65, 0x4f, 0x6e, 0x65, 0x57, 0x61, 0x79, 0x52, 0x65, 0x70, 0x6c, 0x69, 0x63, 0x61,
-	0x10, 0x04, 0x42, 0x38, 0x5a, 0x36, 0x67, 0x69, 0x74, 0x68, 0x75, 0x62, 0x2e, 0x63, 0x6f, 0x6d,
-	0x2f, 0x6d, 0x75, 0x74, 0x61, 0x67, 0x65, 0x6e, 0x2d, 0x69, 0x6f, 0x2f, 0x6d, 0x75, 0x74, 0x61,
-	0x67, 0x65, 0x6e, 0x2f, 0x70, 0x6b, 0x67, 0x2f, 0x73, 0x79, 0x6e, 0x63, 0x68, 0x72, 0x6f, 0x6e,
-	0x69, 0x7a, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x2f, 0x63, 0x6f, 0x72, 0x65, 0x62, 0x06, 0x70, 0x72,
-	0x6f, 0x74, 0x6f, 0x33,
+	0x10, 0x04, 0x12, 0x23, 0x0a, 0x1f, 0x53, 0x79, 0x6e, 0x63, 0x68, 0x72, 0x6f, 0x6e, 0x69, 0x7a,
+	0x61, 0x74, 0x69, 0x6f, 0x6e, 0x4d, 0x6f, 0x64, 0x65, 0x54, 0x77, 0x6f, 0x57, 0x61, 0x79, 0x4e,
+	0x65, 0x77, 0x65, 0x73, 0x74, 0x10, 0x05, 0x42, 0x38, 0x5a, 0x36, 0x67, 0x69, 0x74, 0x68, 0x75,
+	0x62, 0x2e, 0x63, 0x6f, 0x6d, 0x2f, 0x6d, 0x75, 0x74, 0x61, 0x67, 0x65, 0x6e, 0x2d, 0x69, 0x6f,
+	0x2f, 0x6d, 0x75, 0x74, 0x61, 0x67, 0x65, 0x6e, 0x2f, 0x70, 0x6b, 0x67, 0x2f, 0x73, 0x79, 0x6e,
+	0x63, 0x68, 0x72, 0x6f, 0x6e, 0x69, 0x7a, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x2f, 0x63, 0x6f, 0x72,
+	0x65, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
 }
 
 var (
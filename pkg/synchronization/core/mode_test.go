@@ -17,7 +17,8 @@ func TestSynchronizationModeIsDefault(t *testing.T) {
 		{SynchronizationMode_SynchronizationModeTwoWayResolved, false},
 		{SynchronizationMode_SynchronizationModeOneWaySafe, false},
 		{SynchronizationMode_SynchronizationModeOneWayReplica, false},
-		{SynchronizationMode_SynchronizationModeOneWayReplica + 1, false},
+		{SynchronizationMode_SynchronizationModeTwoWayNewest, false},
+		{SynchronizationMode_SynchronizationModeTwoWayNewest + 1, false},
 	}
 
 	// Process test cases.
@@ -44,6 +45,7 @@ func TestSynchronizationModeUnmarshalText(t *testing.T) {
 		{"two-way-resolved", SynchronizationMode_SynchronizationModeTwoWayResolved, false},
 		{"one-way-safe", SynchronizationMode_SynchronizationModeOneWaySafe, false},
 		{"one-way-replica", SynchronizationMode_SynchronizationModeOneWayReplica, false},
+		{"two-way-newest", SynchronizationMode_SynchronizationModeTwoWayNewest, false},
 	}
 
 	// Process test cases.
@@ -78,7 +80,8 @@ func TestSynchronizationModeSupported(t *testing.T) {
 		{SynchronizationMode_SynchronizationModeTwoWayResolved, true},
 		{SynchronizationMode_SynchronizationModeOneWaySafe, true},
 		{SynchronizationMode_SynchronizationModeOneWayReplica, true},
-		{(SynchronizationMode_SynchronizationModeOneWayReplica + 1), false},
+		{SynchronizationMode_SynchronizationModeTwoWayNewest, true},
+		{(SynchronizationMode_SynchronizationModeTwoWayNewest + 1), false},
 	}
 
 	// Process test cases.
@@ -106,7 +109,8 @@ func TestSynchronizationModeDescription(t *testing.T) {
 		{SynchronizationMode_SynchronizationModeTwoWayResolved, "Two Way Resolved"},
 		{SynchronizationMode_SynchronizationModeOneWaySafe, "One Way Safe"},
 		{SynchronizationMode_SynchronizationModeOneWayReplica, "One Way Replica"},
-		{(SynchronizationMode_SynchronizationModeOneWayReplica + 1), "Unknown"},
+		{SynchronizationMode_SynchronizationModeTwoWayNewest, "Two Way Newest"},
+		{(SynchronizationMode_SynchronizationModeTwoWayNewest + 1), "Unknown"},
 	}
 
 	// Process test cases.
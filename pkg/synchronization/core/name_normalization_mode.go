@@ -0,0 +1,73 @@
+package core
+
+import (
+	"fmt"
+)
+
+// IsDefault indicates whether or not the name normalization mode is
+// NameNormalizationMode_NameNormalizationModeDefault.
+func (m NameNormalizationMode) IsDefault() bool {
+	return m == NameNormalizationMode_NameNormalizationModeDefault
+}
+
+// MarshalText implements encoding.TextMarshaler.MarshalText.
+func (m NameNormalizationMode) MarshalText() ([]byte, error) {
+	var result string
+	switch m {
+	case NameNormalizationMode_NameNormalizationModeDefault:
+	case NameNormalizationMode_NameNormalizationModePreserve:
+		result = "preserve"
+	case NameNormalizationMode_NameNormalizationModeRequireNFC:
+		result = "require-nfc"
+	default:
+		result = "unknown"
+	}
+	return []byte(result), nil
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler.UnmarshalText.
+func (m *NameNormalizationMode) UnmarshalText(textBytes []byte) error {
+	// Convert the bytes to a string.
+	text := string(textBytes)
+
+	// Convert to a name normalization mode.
+	switch text {
+	case "preserve":
+		*m = NameNormalizationMode_NameNormalizationModePreserve
+	case "require-nfc":
+		*m = NameNormalizationMode_NameNormalizationModeRequireNFC
+	default:
+		return fmt.Errorf("unknown name normalization mode specification: %s", text)
+	}
+
+	// Success.
+	return nil
+}
+
+// Supported indicates whether or not a particular name normalization mode is a
+// valid, non-default value.
+func (m NameNormalizationMode) Supported() bool {
+	switch m {
+	case NameNormalizationMode_NameNormalizationModePreserve:
+		return true
+	case NameNormalizationMode_NameNormalizationModeRequireNFC:
+		return true
+	default:
+		return false
+	}
+}
+
+// Description returns a human-readable description of a name normalization
+// mode.
+func (m NameNormalizationMode) Description() string {
+	switch m {
+	case NameNormalizationMode_NameNormalizationModeDefault:
+		return "Default"
+	case NameNormalizationMode_NameNormalizationModePreserve:
+		return "Preserve"
+	case NameNormalizationMode_NameNormalizationModeRequireNFC:
+		return "Require NFC"
+	default:
+		return "Unknown"
+	}
+}
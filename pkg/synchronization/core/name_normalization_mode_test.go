@@ -0,0 +1,112 @@
+package core
+
+import (
+	"testing"
+)
+
+// TestNameNormalizationModeIsDefault tests NameNormalizationMode.IsDefault.
+func TestNameNormalizationModeIsDefault(t *testing.T) {
+	// Define test cases.
+	tests := []struct {
+		value    NameNormalizationMode
+		expected bool
+	}{
+		{NameNormalizationMode_NameNormalizationModeDefault - 1, false},
+		{NameNormalizationMode_NameNormalizationModeDefault, true},
+		{NameNormalizationMode_NameNormalizationModePreserve, false},
+		{NameNormalizationMode_NameNormalizationModeRequireNFC, false},
+		{NameNormalizationMode_NameNormalizationModeRequireNFC + 1, false},
+	}
+
+	// Process test cases.
+	for i, test := range tests {
+		if result := test.value.IsDefault(); result && !test.expected {
+			t.Errorf("test index %d: value was unexpectedly classified as default", i)
+		} else if !result && test.expected {
+			t.Errorf("test index %d: value was unexpectedly classified as non-default", i)
+		}
+	}
+}
+
+// TestNameNormalizationModeUnmarshalText tests NameNormalizationMode.UnmarshalText.
+func TestNameNormalizationModeUnmarshalText(t *testing.T) {
+	// Define test cases.
+	tests := []struct {
+		text          string
+		expectedMode  NameNormalizationMode
+		expectFailure bool
+	}{
+		{"", NameNormalizationMode_NameNormalizationModeDefault, true},
+		{"asdf", NameNormalizationMode_NameNormalizationModeDefault, true},
+		{"preserve", NameNormalizationMode_NameNormalizationModePreserve, false},
+		{"require-nfc", NameNormalizationMode_NameNormalizationModeRequireNFC, false},
+	}
+
+	// Process test cases.
+	for _, test := range tests {
+		var mode NameNormalizationMode
+		if err := mode.UnmarshalText([]byte(test.text)); err != nil {
+			if !test.expectFailure {
+				t.Errorf("unable to unmarshal text (%s): %s", test.text, err)
+			}
+		} else if test.expectFailure {
+			t.Error("unmarshaling succeeded unexpectedly for text:", test.text)
+		} else if mode != test.expectedMode {
+			t.Errorf(
+				"unmarshaled mode (%s) does not match expected (%s)",
+				mode,
+				test.expectedMode,
+			)
+		}
+	}
+}
+
+// TestNameNormalizationModeSupported tests NameNormalizationMode.Supported.
+func TestNameNormalizationModeSupported(t *testing.T) {
+	// Set up test cases.
+	testCases := []struct {
+		mode            NameNormalizationMode
+		expectSupported bool
+	}{
+		{NameNormalizationMode_NameNormalizationModeDefault, false},
+		{NameNormalizationMode_NameNormalizationModePreserve, true},
+		{NameNormalizationMode_NameNormalizationModeRequireNFC, true},
+		{(NameNormalizationMode_NameNormalizationModeRequireNFC + 1), false},
+	}
+
+	// Process test cases.
+	for _, testCase := range testCases {
+		if supported := testCase.mode.Supported(); supported != testCase.expectSupported {
+			t.Errorf(
+				"mode support status (%t) does not match expected (%t)",
+				supported,
+				testCase.expectSupported,
+			)
+		}
+	}
+}
+
+// TestNameNormalizationModeDescription tests NameNormalizationMode.Description.
+func TestNameNormalizationModeDescription(t *testing.T) {
+	// Set up test cases.
+	testCases := []struct {
+		mode                NameNormalizationMode
+		expectedDescription string
+	}{
+		{NameNormalizationMode_NameNormalizationModeDefault, "Default"},
+		{NameNormalizationMode_NameNormalizationModePreserve, "Preserve"},
+		{NameNormalizationMode_NameNormalizationModeRequireNFC, "Require NFC"},
+		{(NameNormalizationMode_NameNormalizationModeRequireNFC + 1), "Unknown"},
+	}
+
+	// Process test cases.
+	for _, testCase := range testCases {
+		if description := testCase.mode.Description(); description != testCase.expectedDescription {
+			t.Errorf(
+				"mode description (%s) does not match expected (%s)",
+				description,
+				testCase.expectedDescription,
+			)
+		}
+	}
+}
@@ -0,0 +1,199 @@
+package core
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/mutagen-io/mutagen/pkg/filesystem"
+	"github.com/mutagen-io/mutagen/pkg/synchronization/core/fastpath"
+)
+
+// reconstructor encapsulates state for ReconstructSnapshot.
+type reconstructor struct {
+	// root is the reconstructed root entry.
+	root *Entry
+	// directories is the number of directories in the reconstructed snapshot.
+	directories uint64
+	// files is the number of files in the reconstructed snapshot.
+	files uint64
+	// symbolicLinks is the number of symbolic links in the reconstructed
+	// snapshot.
+	symbolicLinks uint64
+	// totalFileSize is the total size of files in the reconstructed snapshot.
+	totalFileSize uint64
+}
+
+// directory returns the directory entry at the specified path, creating it and
+// any parent directories as necessary. It returns nil if the path (or one of
+// its parents) is already occupied by a non-directory entry.
+func (r *reconstructor) directory(path string) *Entry {
+	// Handle the root case.
+	if path == "" {
+		if r.root == nil {
+			r.root = &Entry{Kind: EntryKind_Directory}
+			r.directories++
+		} else if r.root.Kind != EntryKind_Directory {
+			return nil
+		}
+		return r.root
+	}
+
+	// Grab the parent directory.
+	parent := r.directory(fastpath.Dir(path))
+	if parent == nil {
+		return nil
+	}
+
+	// Look for an existing entry, otherwise create one.
+	name := fastpath.Base(path)
+	if existing, ok := parent.Contents[name]; ok {
+		if existing.Kind != EntryKind_Directory {
+			return nil
+		}
+		return existing
+	}
+	if parent.Contents == nil {
+		parent.Contents = make(map[string]*Entry)
+	}
+	result := &Entry{Kind: EntryKind_Directory}
+	parent.Contents[name] = result
+	r.directories++
+	return result
+}
+
+// ancestor merges directories and symbolic links from the ancestor entry at
+// the specified path into the reconstruction. Content that conflicts with the
+// reconstruction (which is derived from the cache) is considered stale and is
+// skipped.
+func (r *reconstructor) ancestor(path string, entry *Entry) {
+	switch entry.Kind {
+	case EntryKind_Directory:
+		if r.directory(path) == nil {
+			return
+		}
+		contentPathPrefix := fastpath.Joinable(path)
+		for name, child := range entry.Contents {
+			r.ancestor(contentPathPrefix+name, child)
+		}
+	case EntryKind_SymbolicLink:
+		if path == "" {
+			return
+		}
+		parent := r.directory(fastpath.Dir(path))
+		if parent == nil {
+			return
+		}
+		name := fastpath.Base(path)
+		if _, ok := parent.Contents[name]; ok {
+			return
+		}
+		if parent.Contents == nil {
+			parent.Contents = make(map[string]*Entry)
+		}
+		parent.Contents[name] = &Entry{
+			Kind:   EntryKind_SymbolicLink,
+			Target: entry.Target,
+		}
+		r.symbolicLinks++
+	}
+}
+
+// truncateDigest truncates a digest to the specified length. If length is zero
+// or not less than the digest length, then the digest is returned unmodified.
+func truncateDigest(digest []byte, length uint32) []byte {
+	if length > 0 && uint64(length) < uint64(len(digest)) {
+		return digest[:length]
+	}
+	return digest
+}
+
+// ReconstructSnapshot reconstructs a snapshot from a digest cache and an
+// ancestor without accessing the filesystem. It is designed for offline
+// analysis of synchronization state (e.g. reproducing a bug report from a
+// saved cache and archive) and is not a substitute for Scan.
+//
+// The cache is treated as authoritative for file content, since every file
+// encountered by a scan is recorded in the cache that it produces. File
+// executability is derived from the cached mode bits if preservesExecutability
+// is true. Because the cache only records files, directories are inferred from
+// cached file paths and otherwise taken from the ancestor, as are symbolic
+// links. This imposes a number of limitations on the reconstruction: only
+// cached digests can be reconstructed, directories and symbolic links that were
+// created or removed since the ancestor was recorded won't be reflected, ignored
+// and unsynchronizable content won't be present, special file placeholders will
+// appear as files, modification times won't be included, and the Unicode
+// decomposition behavior of the original filesystem isn't known. Ancestor
+// content that conflicts with cached files is considered stale and discarded.
+//
+// Since cached digests aren't truncated, digestLength should be set to the
+// session's digest length (if any) so that reconstructed digests match those
+// recorded by scans. A zero value indicates that digests aren't truncated.
+//
+// The ancestor may be nil. The cache must be valid. An error is returned if
+// the cache describes an impossible hierarchy.
+func ReconstructSnapshot(cache *Cache, ancestor *Entry, preservesExecutability bool, digestLength uint32) (*Snapshot, error) {
+	// Create the reconstructor.
+	r := &reconstructor{}
+
+	// Handle the case of a file root.
+	if rootEntry, ok := cache.GetEntries()[""]; ok {
+		if len(cache.GetEntries()) != 1 {
+			return nil, errors.New("cache contains a root file and other content")
+		}
+		if filesystem.Mode(rootEntry.Mode)&filesystem.ModeTypeMask != filesystem.ModeTypeFile {
+			return nil, errors.New("cache contains a non-file root entry")
+		}
+		return &Snapshot{
+			Content: &Entry{
+				Kind:       EntryKind_File,
+				Executable: preservesExecutability && anyExecutableBitSet(filesystem.Mode(rootEntry.Mode)),
+				Digest:     truncateDigest(rootEntry.Digest, digestLength),
+			},
+			PreservesExecutability: preservesExecutability,
+			Files:                  1,
+			TotalFileSize:          rootEntry.Size,
+		}, nil
+	}
+
+	// Add cached files to the reconstruction.
+	for path, cacheEntry := range cache.GetEntries() {
+		mode := filesystem.Mode(cacheEntry.Mode)
+		if mode&filesystem.ModeTypeMask != filesystem.ModeTypeFile {
+			return nil, fmt.Errorf("cache contains a non-file entry at path: %s", path)
+		}
+		parent := r.directory(fastpath.Dir(path))
+		if parent == nil {
+			return nil, fmt.Errorf("cache contains file with a non-directory parent: %s", path)
+		}
+		name := fastpath.Base(path)
+		if _, ok := parent.Contents[name]; ok {
+			return nil, fmt.Errorf("cache contains file at directory path: %s", path)
+		}
+		if parent.Contents == nil {
+			parent.Contents = make(map[string]*Entry)
+		}
+		parent.Contents[name] = &Entry{
+			Kind:       EntryKind_File,
+			Executable: preservesExecutability && anyExecutableBitSet(mode),
+			Digest:     truncateDigest(cacheEntry.Digest, digestLength),
+		}
+		r.files++
+		r.totalFileSize += cacheEntry.Size
+	}
+
+	// Merge directories and symbolic links from the ancestor. A file root in
+	// the ancestor is stale if it's not present in the cache, so we ignore it.
+	if ancestor != nil && ancestor.Kind == EntryKind_Directory {
+		r.ancestor("", ancestor)
+	}
+
+	// Success.
+	return &Snapshot{
+		Content:                r.root,
+		PreservesExecutability: preservesExecutability,
+		Directories:            r.directories,
+		Files:                  r.files,
+		SymbolicLinks:          r.symbolicLinks,
+		TotalFileSize:          r.totalFileSize,
+	}, nil
+}
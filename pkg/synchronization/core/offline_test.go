@@ -0,0 +1,136 @@
+package core
+
+import (
+	"testing"
+
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	"github.com/mutagen-io/mutagen/pkg/filesystem"
+)
+
+// testingCacheEntry creates a cache entry for the specified content with the
+// specified permission bits.
+func testingCacheEntry(content string, permissions filesystem.Mode) *CacheEntry {
+	return &CacheEntry{
+		Mode:             uint32(filesystem.ModeTypeFile | permissions),
+		ModificationTime: &timestamppb.Timestamp{Seconds: 1},
+		Size:             uint64(len(content)),
+		Digest:           testingDigest(content),
+	}
+}
+
+// TestReconstructSnapshot tests that ReconstructSnapshot reconstructs a
+// snapshot from a cache and ancestor without filesystem access.
+func TestReconstructSnapshot(t *testing.T) {
+	// Create a cache.
+	cache := &Cache{Entries: map[string]*CacheEntry{
+		"file":           testingCacheEntry(tF1Content, 0644),
+		"directory/file": testingCacheEntry(tF3Content, 0755),
+		"replaced":       testingCacheEntry(tF1Content, 0644),
+	}}
+
+	// Create an ancestor with a symbolic link and empty directory (which
+	// should be preserved), a file that's not present in the cache (which
+	// should be dropped), and a directory where the cache has a file (which
+	// should be discarded).
+	ancestor := &Entry{Contents: map[string]*Entry{
+		"symlink":  tSR,
+		"empty":    tD0,
+		"stale":    tF2,
+		"replaced": tDD0,
+	}}
+
+	// Perform reconstruction.
+	snapshot, err := ReconstructSnapshot(cache, ancestor, true, 0)
+	if err != nil {
+		t.Fatal("unable to reconstruct snapshot:", err)
+	} else if err = snapshot.EnsureValid(); err != nil {
+		t.Fatal("reconstructed snapshot invalid:", err)
+	}
+
+	// Verify the reconstructed content.
+	expected := &Entry{Contents: map[string]*Entry{
+		"file":      tF1,
+		"directory": {Contents: map[string]*Entry{"file": tF3E}},
+		"replaced":  tF1,
+		"symlink":   tSR,
+		"empty":     tD0,
+	}}
+	if !snapshot.Content.Equal(expected, true) {
+		t.Error("reconstructed content does not match expected")
+	}
+
+	// Verify the reconstructed metadata.
+	if !snapshot.PreservesExecutability {
+		t.Error("reconstructed snapshot does not preserve executability")
+	}
+	if snapshot.Directories != 3 {
+		t.Error("directory count mismatch:", snapshot.Directories, "!=", 3)
+	}
+	if snapshot.Files != 3 {
+		t.Error("file count mismatch:", snapshot.Files, "!=", 3)
+	}
+	if snapshot.SymbolicLinks != 1 {
+		t.Error("symbolic link count mismatch:", snapshot.SymbolicLinks, "!=", 1)
+	}
+	expectedTotalFileSize := uint64(2*len(tF1Content) + len(tF3Content))
+	if snapshot.TotalFileSize != expectedTotalFileSize {
+		t.Error("total file size mismatch:", snapshot.TotalFileSize, "!=", expectedTotalFileSize)
+	}
+
+	// Verify that executability isn't reconstructed if not preserved.
+	snapshot, err = ReconstructSnapshot(cache, nil, false, 0)
+	if err != nil {
+		t.Fatal("unable to reconstruct snapshot without executability:", err)
+	}
+	if snapshot.Content.Contents["directory"].Contents["file"].Executable {
+		t.Error("executability reconstructed when not preserved")
+	}
+}
+
+// TestReconstructSnapshotFileRoot tests that ReconstructSnapshot reconstructs
+// a file root.
+func TestReconstructSnapshotFileRoot(t *testing.T) {
+	cache := &Cache{Entries: map[string]*CacheEntry{
+		"": testingCacheEntry(tF1Content, 0644),
+	}}
+	snapshot, err := ReconstructSnapshot(cache, tD1, true, 0)
+	if err != nil {
+		t.Fatal("unable to reconstruct snapshot:", err)
+	} else if !snapshot.Content.Equal(tF1, true) {
+		t.Error("reconstructed content does not match expected")
+	} else if snapshot.Files != 1 || snapshot.Directories != 0 {
+		t.Error("reconstructed snapshot has incorrect counts")
+	}
+}
+
+// TestReconstructSnapshotEmpty tests that ReconstructSnapshot reconstructs an
+// absence of content from an empty cache and ancestor.
+func TestReconstructSnapshotEmpty(t *testing.T) {
+	snapshot, err := ReconstructSnapshot(&Cache{}, nil, true, 0)
+	if err != nil {
+		t.Fatal("unable to reconstruct snapshot:", err)
+	} else if snapshot.Content != nil {
+		t.Error("reconstructed snapshot has unexpected content")
+	}
+}
+
+// TestReconstructSnapshotInvalidCache tests that ReconstructSnapshot rejects
+// caches that describe impossible hierarchies.
+func TestReconstructSnapshotInvalidCache(t *testing.T) {
+	caches := []*Cache{
+		{Entries: map[string]*CacheEntry{
+			"":     testingCacheEntry(tF1Content, 0644),
+			"file": testingCacheEntry(tF1Content, 0644),
+		}},
+		{Entries: map[string]*CacheEntry{
+			"file":       testingCacheEntry(tF1Content, 0644),
+			"file/child": testingCacheEntry(tF2Content, 0644),
+		}},
+	}
+	for i, cache := range caches {
+		if _, err := ReconstructSnapshot(cache, nil, true, 0); err == nil {
+			t.Errorf("test index %d: reconstruction succeeded unexpectedly", i)
+		}
+	}
+}
@@ -0,0 +1,358 @@
+package core
+
+import (
+	"sort"
+	"strings"
+
+	"github.com/mutagen-io/mutagen/pkg/synchronization/core/fastpath"
+)
+
+// OverlayWhiteoutPrefix is the name prefix used for whiteout markers in the
+// upper layer of an overlay. A whiteout marker for a name is an empty directory
+// named by prefixing the name with OverlayWhiteoutPrefix. It hides the lower
+// layer entry with that name (if any) from the merged view, regardless of
+// whether or not the upper layer also contains an entry with that name. All
+// upper layer entries whose names begin with OverlayWhiteoutPrefix are treated
+// as markers and are thus never presented in the merged view. Whiteout markers
+// are represented as directories (rather than files) so that they can be
+// created by Transition without any file staging.
+const OverlayWhiteoutPrefix = ".wh."
+
+// isOverlayMarker determines whether or not an upper layer content name refers
+// to an overlay marker.
+func isOverlayMarker(name string) bool {
+	return strings.HasPrefix(name, OverlayWhiteoutPrefix)
+}
+
+// containsOverlayMarkerNames determines whether or not an entry hierarchy
+// contains any content with names reserved for overlay markers.
+func containsOverlayMarkerNames(entry *Entry) bool {
+	if entry == nil {
+		return false
+	}
+	for name, child := range entry.Contents {
+		if isOverlayMarker(name) || containsOverlayMarkerNames(child) {
+			return true
+		}
+	}
+	return false
+}
+
+// MergeOverlay computes the merged view of an overlay consisting of an
+// immutable lower layer and a mutable upper layer. The merge is defined
+// recursively as follows: If the upper entry is nil, then the lower entry is
+// visible unmodified. If the upper entry is not a directory, then it hides the
+// lower entry entirely. If the upper entry is a directory, then the result is a
+// directory containing (a) the contents of the lower entry (if it's also a
+// directory) that aren't hidden by a whiteout marker and (b) the non-marker
+// contents of the upper entry, each merged with the corresponding (unhidden)
+// lower content. Merged directories don't carry aggregate digests, since they
+// don't correspond to any single on-disk directory.
+func MergeOverlay(lower, upper *Entry) *Entry {
+	// Handle the cases where one layer fully determines the result.
+	if upper == nil {
+		return lower
+	} else if upper.Kind != EntryKind_Directory {
+		return upper
+	}
+
+	// Only lower directories contribute contents to the merge.
+	if lower != nil && lower.Kind != EntryKind_Directory {
+		lower = nil
+	}
+
+	// Compute the visible lower contents.
+	contents := make(map[string]*Entry, len(lower.GetContents())+len(upper.Contents))
+	for name, entry := range lower.GetContents() {
+		if _, whiteout := upper.Contents[OverlayWhiteoutPrefix+name]; !whiteout {
+			contents[name] = entry
+		}
+	}
+
+	// Merge in the upper contents.
+	for name, entry := range upper.Contents {
+		if isOverlayMarker(name) {
+			continue
+		}
+		var lowerEntry *Entry
+		if _, whiteout := upper.Contents[OverlayWhiteoutPrefix+name]; !whiteout {
+			lowerEntry = lower.GetContents()[name]
+		}
+		contents[name] = MergeOverlay(lowerEntry, entry)
+	}
+
+	// Create the merged directory.
+	result := upper.Copy(EntryCopyBehaviorSlim)
+	if len(contents) > 0 {
+		result.Contents = contents
+	}
+	return result
+}
+
+// MergeOverlaySnapshots computes the merged snapshot of an overlay from the
+// snapshots of its lower and upper layers (and the caches generated when
+// scanning them, which are used to compute file sizes). Filesystem behavior
+// information is taken from the upper layer snapshot if the upper layer exists,
+// since it's the layer that will be modified, and otherwise from the lower
+// layer snapshot. Hashing statistics are combined from both layers.
+func MergeOverlaySnapshots(lower, upper *Snapshot, lowerCache, upperCache *Cache) *Snapshot {
+	// Create the merged snapshot.
+	result := &Snapshot{
+		Content:                MergeOverlay(lower.Content, upper.Content),
+		PreservesExecutability: upper.PreservesExecutability,
+		DecomposesUnicode:      upper.DecomposesUnicode,
+		IgnoresCase:            upper.IgnoresCase,
+		HashedBytes:            lower.HashedBytes + upper.HashedBytes,
+		HashingDuration:        lower.HashingDuration + upper.HashingDuration,
+	}
+	if upper.Content == nil {
+		result.PreservesExecutability = lower.PreservesExecutability
+		result.DecomposesUnicode = lower.DecomposesUnicode
+		result.IgnoresCase = lower.IgnoresCase
+	}
+
+	// Compute content statistics. Upper layer paths correspond directly to
+	// merged view paths, so a file's size is found in the upper layer cache if
+	// the file originates from the upper layer and the lower layer cache
+	// otherwise.
+	result.Content.walk("", func(path string, entry *Entry) {
+		if entry == nil {
+			return
+		}
+		switch entry.Kind {
+		case EntryKind_Directory:
+			result.Directories++
+		case EntryKind_File:
+			result.Files++
+			if cacheEntry, ok := upperCache.GetEntries()[path]; ok {
+				result.TotalFileSize += cacheEntry.Size
+			} else if cacheEntry, ok := lowerCache.GetEntries()[path]; ok {
+				result.TotalFileSize += cacheEntry.Size
+			}
+		case EntryKind_SymbolicLink:
+			result.SymbolicLinks++
+		}
+	}, false)
+
+	// Done.
+	return result
+}
+
+// overlayResolve resolves a path within the layers of an overlay. It returns
+// the raw upper layer entry at the path, the lower layer entry at the path that
+// would be visible in the absence of a whiteout marker for the path itself, and
+// whether or not such a whiteout marker exists.
+func overlayResolve(lower, upper *Entry, path string) (*Entry, *Entry, bool) {
+	// Handle the root case.
+	if path == "" {
+		return lower, upper, false
+	}
+
+	// Traverse the layers in parallel.
+	var whiteout bool
+	for _, component := range strings.Split(path, "/") {
+		// A whiteout marker or a non-directory lower entry hides any lower
+		// content beneath it.
+		if whiteout || (lower != nil && lower.Kind != EntryKind_Directory) {
+			lower = nil
+		}
+
+		// A non-directory upper entry hides all content beneath it.
+		if upper != nil && upper.Kind != EntryKind_Directory {
+			lower, upper = nil, nil
+		}
+
+		// Descend.
+		if upper != nil {
+			_, whiteout = upper.Contents[OverlayWhiteoutPrefix+component]
+			upper = upper.Contents[component]
+		} else {
+			whiteout = false
+		}
+		if lower != nil {
+			lower = lower.Contents[component]
+		}
+	}
+
+	// Done.
+	return lower, upper, whiteout
+}
+
+// overlayTransitioner converts transitions expressed against the merged view of
+// an overlay into transitions against its upper layer.
+type overlayTransitioner struct {
+	// lower is the lower layer content.
+	lower *Entry
+	// upper is the upper layer content.
+	upper *Entry
+	// created is the set of upper layer directory paths that have already been
+	// created by generated transitions.
+	created map[string]bool
+	// transitions are the generated upper layer transitions.
+	transitions []*Change
+	// problems are the problems encountered during conversion.
+	problems []*Problem
+}
+
+// ensureDirectory ensures that the upper layer contains a directory at the
+// specified path, generating transitions to create it and any missing parent
+// directories. Existing upper layer content is never replaced.
+func (t *overlayTransitioner) ensureDirectory(path string) {
+	// Compute the paths that need to exist, from the root downward.
+	paths := []string{""}
+	if path != "" {
+		components := strings.Split(path, "/")
+		for c := range components {
+			paths = append(paths, strings.Join(components[:c+1], "/"))
+		}
+	}
+
+	// Create any missing directories.
+	for _, p := range paths {
+		if t.created[p] {
+			continue
+		} else if _, upper, _ := overlayResolve(t.lower, t.upper, p); upper != nil {
+			continue
+		}
+		t.transitions = append(t.transitions, &Change{Path: p, New: &Entry{}})
+		t.created[p] = true
+	}
+}
+
+// overlaySplit splits a non-root path into its parent path and leaf name.
+func overlaySplit(path string) (string, string) {
+	if slash := strings.LastIndexByte(path, '/'); slash >= 0 {
+		return path[:slash], path[slash+1:]
+	}
+	return "", path
+}
+
+// transition converts a single merged view transition.
+func (t *overlayTransitioner) transition(path string, old, new *Entry) {
+	// Resolve the path within the layers.
+	lower, upper, whiteout := overlayResolve(t.lower, t.upper, path)
+
+	// If the path is a directory both before and after the transition, then
+	// decompose the transition into transitions for the directory contents.
+	// This avoids copying lower layer content up into the upper layer.
+	if old != nil && new != nil && old.Kind == EntryKind_Directory && new.Kind == EntryKind_Directory {
+		t.ensureDirectory(path)
+		names := make([]string, 0, len(old.Contents)+len(new.Contents))
+		for name := range old.Contents {
+			names = append(names, name)
+		}
+		for name := range new.Contents {
+			if _, ok := old.Contents[name]; !ok {
+				names = append(names, name)
+			}
+		}
+		sort.Strings(names)
+		for _, name := range names {
+			if oldChild, newChild := old.Contents[name], new.Contents[name]; !oldChild.Equal(newChild, true) {
+				t.transition(fastpath.Joinable(path)+name, oldChild, newChild)
+			}
+		}
+		return
+	}
+
+	// Determine whether or not the lower layer entry needs to be hidden. This
+	// is necessary if it would otherwise become visible after removal or merge
+	// with a new directory.
+	hide := lower != nil && !whiteout &&
+		(new == nil || (new.Kind == EntryKind_Directory && lower.Kind == EntryKind_Directory))
+	if hide && path == "" {
+		t.problems = append(t.problems, &Problem{
+			Path:  path,
+			Error: "unable to hide overlay base root",
+		})
+		return
+	}
+
+	// Ensure that the parent directory exists in the upper layer if we'll be
+	// creating content.
+	parent, name := overlaySplit(path)
+	if path != "" && (new != nil || hide) {
+		t.ensureDirectory(parent)
+	}
+
+	// Replace the upper layer entry. Any lower layer content is overridden by
+	// the new entry or hidden by a whiteout marker.
+	if upper != nil || new != nil {
+		t.transitions = append(t.transitions, &Change{Path: path, Old: upper, New: new})
+	}
+
+	// Create a whiteout marker if necessary.
+	if hide {
+		t.transitions = append(t.transitions, &Change{Path: fastpath.Joinable(parent) + OverlayWhiteoutPrefix + name, New: &Entry{}})
+	}
+}
+
+// OverlayTransitions converts transitions expressed against the merged view of
+// an overlay (as computed by MergeOverlay) into transitions against its upper
+// layer, which can then be applied using Transition. The lower layer is never
+// modified. Transitions that can't be represented (such as removal of the root
+// or creation of content with names reserved for overlay markers) are omitted
+// and reported as problems.
+func OverlayTransitions(lower, upper *Entry, transitions []*Change) ([]*Change, []*Problem) {
+	// Create the transitioner.
+	transitioner := &overlayTransitioner{
+		lower:   lower,
+		upper:   upper,
+		created: make(map[string]bool),
+	}
+
+	// Convert transitions.
+	for _, transition := range transitions {
+		_, name := overlaySplit(transition.Path)
+		if transition.Path == "" && transition.New == nil {
+			transitioner.problems = append(transitioner.problems, &Problem{
+				Error: "unable to remove overlay root",
+			})
+		} else if isOverlayMarker(name) || containsOverlayMarkerNames(transition.New) {
+			transitioner.problems = append(transitioner.problems, &Problem{
+				Path:  transition.Path,
+				Error: "content name conflicts with overlay marker naming",
+			})
+		} else {
+			transitioner.transition(transition.Path, transition.Old, transition.New)
+		}
+	}
+
+	// Done.
+	return transitioner.transitions, transitioner.problems
+}
+
+// OverlayResults computes the merged view results for transitions converted
+// using OverlayTransitions, given the results of applying the converted upper
+// layer transitions. The results correspond to the original (merged view)
+// transitions.
+func OverlayResults(lower, upper *Entry, transitions, upperTransitions []*Change, upperResults []*Entry) []*Entry {
+	// Compute the resulting upper layer content. If the results can't be
+	// applied, then we conservatively report that no changes were made, in
+	// which case the next scan will correct the view.
+	changes := make([]*Change, len(upperTransitions))
+	for u, transition := range upperTransitions {
+		changes[u] = &Change{Path: transition.Path, New: upperResults[u]}
+	}
+	results := make([]*Entry, len(transitions))
+	upper, err := Apply(upper, changes)
+	if err != nil {
+		for t, transition := range transitions {
+			results[t] = transition.Old
+		}
+		return results
+	}
+
+	// Compute the merged view and extract the results.
+	merged := MergeOverlay(lower, upper)
+	for t, transition := range transitions {
+		entry := merged
+		if transition.Path != "" {
+			for _, component := range strings.Split(transition.Path, "/") {
+				entry = entry.GetContents()[component]
+			}
+		}
+		results[t] = entry
+	}
+	return results
+}
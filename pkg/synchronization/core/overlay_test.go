@@ -0,0 +1,135 @@
+package core
+
+import (
+	"testing"
+)
+
+// tWH is a whiteout marker entry.
+var tWH = &Entry{}
+
+// TestMergeOverlay tests MergeOverlay.
+func TestMergeOverlay(t *testing.T) {
+	// Define test cases.
+	tests := []struct {
+		lower    *Entry
+		upper    *Entry
+		expected *Entry
+	}{
+		{tN, tN, tN},
+		{tD1, tN, tD1},
+		{tN, tD1, tD1},
+		{tD1, tF2, tF2},
+		{tF1, tD2, tD2},
+		{tD1, tD2, tD2},
+		{tD1, tD0, tD1},
+		{tD1, &Entry{Contents: map[string]*Entry{".wh.file": tWH}}, tD0},
+		{tD1, &Entry{Contents: map[string]*Entry{".wh.file": tWH, ".wh.other": tWH}}, tD0},
+		{
+			tD1,
+			&Entry{Contents: map[string]*Entry{"other": tF2}},
+			&Entry{Contents: map[string]*Entry{"file": tF1, "other": tF2}},
+		},
+		{
+			nested("child", tD1),
+			nested("child", &Entry{Contents: map[string]*Entry{"other": tF2}}),
+			nested("child", &Entry{Contents: map[string]*Entry{"file": tF1, "other": tF2}}),
+		},
+		{
+			nested("child", tD1),
+			&Entry{Contents: map[string]*Entry{
+				".wh.child": tWH,
+				"child":     {Contents: map[string]*Entry{"other": tF2}},
+			}},
+			nested("child", &Entry{Contents: map[string]*Entry{"other": tF2}}),
+		},
+	}
+
+	// Process test cases.
+	for i, test := range tests {
+		if result := MergeOverlay(test.lower, test.upper); !result.Equal(test.expected, true) {
+			t.Errorf("test index %d: result did not match expected", i)
+		}
+	}
+}
+
+// TestOverlayTransitions tests that OverlayTransitions generates upper layer
+// transitions that produce the expected merged view and that OverlayResults
+// reports the resulting merged view content.
+func TestOverlayTransitions(t *testing.T) {
+	// Define test cases.
+	tests := []struct {
+		lower          *Entry
+		upper          *Entry
+		transitions    []*Change
+		expectProblems bool
+	}{
+		{tD1, tN, []*Change{{Path: "file", Old: tF1, New: tF2}}, false},
+		{tD1, tN, []*Change{{Path: "file", Old: tF1}}, false},
+		{tD1, tN, []*Change{{Path: "other", New: tF2}}, false},
+		{tD1, tN, []*Change{{Path: "file", Old: tF1, New: tD2}}, false},
+		{tD1, tD0, []*Change{{Old: tD1, New: tD2}}, false},
+		{tD1, tD0, []*Change{{Old: tD1, New: tF1}}, false},
+		{tDM, tN, []*Change{{Old: tDM, New: tD0}}, false},
+		{nested("child", tD1), tN, []*Change{{Path: "child", Old: tD1, New: tF1}}, false},
+		{nested("child", tD1), tN, []*Change{{Path: "child", Old: tD1, New: tD2}}, false},
+		{nested("child", tD1), tN, []*Change{{Path: "child/file", Old: tF1, New: tD1}}, false},
+		{nested("child", tD1), tN, []*Change{{Path: "child", Old: tD1}}, false},
+		{
+			nested("child", tD1),
+			&Entry{Contents: map[string]*Entry{"child": tF2}},
+			[]*Change{{Path: "child", Old: tF2, New: tD2}},
+			false,
+		},
+		{
+			nested("child", tD1),
+			&Entry{Contents: map[string]*Entry{".wh.child": tWH}},
+			[]*Change{{Path: "child", New: tD2}},
+			false,
+		},
+		{tD1, tN, []*Change{{Old: tD1}}, true},
+		{tD1, tN, []*Change{{Path: ".wh.file", New: tF1}}, true},
+		{tD1, tN, []*Change{{Path: "other", New: &Entry{Contents: map[string]*Entry{".wh.file": tF2}}}}, true},
+	}
+
+	// Process test cases.
+	for i, test := range tests {
+		// Convert the transitions.
+		upperTransitions, problems := OverlayTransitions(test.lower, test.upper, test.transitions)
+		if test.expectProblems {
+			if len(problems) == 0 {
+				t.Errorf("test index %d: problems not reported", i)
+			}
+			continue
+		} else if len(problems) > 0 {
+			t.Errorf("test index %d: unexpected problems reported: %v", i, problems)
+			continue
+		}
+
+		// Simulate successful application of the upper layer transitions.
+		upperResults := make([]*Entry, len(upperTransitions))
+		for u, transition := range upperTransitions {
+			upperResults[u] = transition.New
+		}
+		upper, err := Apply(test.upper, upperTransitions)
+		if err != nil {
+			t.Errorf("test index %d: unable to apply upper layer transitions: %v", i, err)
+			continue
+		}
+
+		// Verify that the merged view matches the expected content.
+		expected, err := Apply(MergeOverlay(test.lower, test.upper), test.transitions)
+		if err != nil {
+			t.Fatalf("test index %d: unable to compute expected content: %v", i, err)
+		} else if !MergeOverlay(test.lower, upper).Equal(expected, true) {
+			t.Errorf("test index %d: merged view did not match expected", i)
+		}
+
+		// Verify the reported results.
+		results := OverlayResults(test.lower, test.upper, test.transitions, upperTransitions, upperResults)
+		for r, result := range results {
+			if !result.Equal(test.transitions[r].New, true) {
+				t.Errorf("test index %d: result %d did not match expected", i, r)
+			}
+		}
+	}
+}
@@ -5,7 +5,7 @@ package core
 // returns whether or not trackable (though not necessarily synchronizable)
 // content is present at this level, along with updated directory counts for
 // alpha and beta, respectively.
-func reifyPhantomDirectories(ancestor, alpha, beta *Entry) (bool, uint64, uint64) {
+func reifyPhantomDirectories(ancestor, alpha, beta *Entry, mode PhantomDirectoryMode) (bool, uint64, uint64) {
 	// If neither alpha nor beta is a directory kind, then we won't continue
 	// recursion any further. In this case, we'll return an indication of
 	// whether either alpha or beta represents non-nil, tracked content. This
@@ -42,6 +42,7 @@ func reifyPhantomDirectories(ancestor, alpha, beta *Entry) (bool, uint64, uint64
 			ancestorContents[name],
 			alphaContents[name],
 			betaContents[name],
+			mode,
 		)
 		if tracked {
 			trackedContentExistsAtLowerLevels = true
@@ -52,16 +53,34 @@ func reifyPhantomDirectories(ancestor, alpha, beta *Entry) (bool, uint64, uint64
 
 	// Determine how to reify any phantom directories at this level. Any tracked
 	// content at lower levels indicates that we should reify phantom
-	// directories to tracked directories, as does the presence of a tracked
-	// directory in the ancestor. We also take this opportunity to update the
-	// directory counts to include this level. In the case that we reify to
-	// untracked, we could theoretically lean into the invariant that if both
-	// alpha and beta are directory kinds, then they must both be either tracked
-	// or phantom, which might allow us to save a few comparisons, but that
-	// invariant relies on endpoints behaving correctly, and relying on that
-	// would make this code somewhat fragile.
-	ancestorIsDirectory := ancestor != nil && ancestor.Kind == EntryKind_Directory
-	reifyToTracked := trackedContentExistsAtLowerLevels || ancestorIsDirectory
+	// directories to tracked directories, regardless of mode. Otherwise, any
+	// phantom directories at this level are fully phantom, and the phantom
+	// directory mode determines how they're reified: they're always tracked,
+	// always untracked, or (in the ancestor-driven case) tracked only if the
+	// ancestor contains a tracked directory at this path. The ancestor-driven
+	// behavior means that a directory whose contents all become ignored remains
+	// tracked (because it was previously synchronized), whereas an identical
+	// directory that was never synchronized remains untracked, so the other
+	// modes exist to provide history-independent behavior. We also take this
+	// opportunity to update the directory counts to include this level. In the
+	// case that we reify to untracked, we could theoretically lean into the
+	// invariant that if both alpha and beta are directory kinds, then they must
+	// both be either tracked or phantom, which might allow us to save a few
+	// comparisons, but that invariant relies on endpoints behaving correctly,
+	// and relying on that would make this code somewhat fragile.
+	var reifyToTracked bool
+	if trackedContentExistsAtLowerLevels {
+		reifyToTracked = true
+	} else {
+		switch mode {
+		case PhantomDirectoryMode_PhantomDirectoryModeTracked:
+			reifyToTracked = true
+		case PhantomDirectoryMode_PhantomDirectoryModeIgnored:
+			reifyToTracked = false
+		default:
+			reifyToTracked = ancestor != nil && ancestor.Kind == EntryKind_Directory
+		}
+	}
 	if reifyToTracked {
 		if alphaIsDirectoryKind {
 			alpha.Kind = EntryKind_Directory
@@ -105,15 +124,18 @@ func reifyPhantomDirectories(ancestor, alpha, beta *Entry) (bool, uint64, uint64
 // both alpha and beta (since these can change during directory reification
 // (though note that other counts cannot)). This function is only necessary if
 // Docker-style ignore syntax and semantics are being used, because phantom
-// directories don't exist with standard Mutagen-style ignores.
-func ReifyPhantomDirectories(ancestor, alpha, beta *Entry) (*Entry, *Entry, uint64, uint64) {
+// directories don't exist with standard Mutagen-style ignores. The phantom
+// directory mode controls the reification of fully phantom directories (see
+// PhantomDirectoryMode for details), with the default mode being treated as
+// PhantomDirectoryMode_PhantomDirectoryModeAncestorDriven.
+func ReifyPhantomDirectories(ancestor, alpha, beta *Entry, mode PhantomDirectoryMode) (*Entry, *Entry, uint64, uint64) {
 	// Create deep copies of alpha and beta that we can mutate. We won't mutate
 	// any leaf entries, so we can perform a more efficient copy.
 	alpha = alpha.Copy(EntryCopyBehaviorDeepPreservingLeaves)
 	beta = beta.Copy(EntryCopyBehaviorDeepPreservingLeaves)
 
 	// Perform reification.
-	_, alphaDirectoryCount, betaDirectoryCount := reifyPhantomDirectories(ancestor, alpha, beta)
+	_, alphaDirectoryCount, betaDirectoryCount := reifyPhantomDirectories(ancestor, alpha, beta, mode)
 
 	// Done.
 	return alpha, beta, alphaDirectoryCount, betaDirectoryCount
@@ -0,0 +1,80 @@
+package core
+
+import (
+	"fmt"
+)
+
+// IsDefault indicates whether or not the phantom directory mode is
+// PhantomDirectoryMode_PhantomDirectoryModeDefault.
+func (m PhantomDirectoryMode) IsDefault() bool {
+	return m == PhantomDirectoryMode_PhantomDirectoryModeDefault
+}
+
+// MarshalText implements encoding.TextMarshaler.MarshalText.
+func (m PhantomDirectoryMode) MarshalText() ([]byte, error) {
+	var result string
+	switch m {
+	case PhantomDirectoryMode_PhantomDirectoryModeDefault:
+	case PhantomDirectoryMode_PhantomDirectoryModeAncestorDriven:
+		result = "ancestor-driven"
+	case PhantomDirectoryMode_PhantomDirectoryModeTracked:
+		result = "tracked"
+	case PhantomDirectoryMode_PhantomDirectoryModeIgnored:
+		result = "ignored"
+	default:
+		result = "unknown"
+	}
+	return []byte(result), nil
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler.UnmarshalText.
+func (m *PhantomDirectoryMode) UnmarshalText(textBytes []byte) error {
+	// Convert the bytes to a string.
+	text := string(textBytes)
+
+	// Convert to a phantom directory mode.
+	switch text {
+	case "ancestor-driven":
+		*m = PhantomDirectoryMode_PhantomDirectoryModeAncestorDriven
+	case "tracked":
+		*m = PhantomDirectoryMode_PhantomDirectoryModeTracked
+	case "ignored":
+		*m = PhantomDirectoryMode_PhantomDirectoryModeIgnored
+	default:
+		return fmt.Errorf("unknown phantom directory mode specification: %s", text)
+	}
+
+	// Success.
+	return nil
+}
+
+// Supported indicates whether or not a particular phantom directory mode is a
+// valid, non-default value.
+func (m PhantomDirectoryMode) Supported() bool {
+	switch m {
+	case PhantomDirectoryMode_PhantomDirectoryModeAncestorDriven:
+		return true
+	case PhantomDirectoryMode_PhantomDirectoryModeTracked:
+		return true
+	case PhantomDirectoryMode_PhantomDirectoryModeIgnored:
+		return true
+	default:
+		return false
+	}
+}
+
+// Description returns a human-readable description of a phantom directory mode.
+func (m PhantomDirectoryMode) Description() string {
+	switch m {
+	case PhantomDirectoryMode_PhantomDirectoryModeDefault:
+		return "Default"
+	case PhantomDirectoryMode_PhantomDirectoryModeAncestorDriven:
+		return "Ancestor Driven"
+	case PhantomDirectoryMode_PhantomDirectoryModeTracked:
+		return "Tracked"
+	case PhantomDirectoryMode_PhantomDirectoryModeIgnored:
+		return "Ignored"
+	default:
+		return "Unknown"
+	}
+}
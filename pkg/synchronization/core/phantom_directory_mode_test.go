@@ -0,0 +1,116 @@
+package core
+
+import (
+	"testing"
+)
+
+// TestPhantomDirectoryModeIsDefault tests PhantomDirectoryMode.IsDefault.
+func TestPhantomDirectoryModeIsDefault(t *testing.T) {
+	// Define test cases.
+	tests := []struct {
+		value    PhantomDirectoryMode
+		expected bool
+	}{
+		{PhantomDirectoryMode_PhantomDirectoryModeDefault - 1, false},
+		{PhantomDirectoryMode_PhantomDirectoryModeDefault, true},
+		{PhantomDirectoryMode_PhantomDirectoryModeAncestorDriven, false},
+		{PhantomDirectoryMode_PhantomDirectoryModeTracked, false},
+		{PhantomDirectoryMode_PhantomDirectoryModeIgnored, false},
+		{PhantomDirectoryMode_PhantomDirectoryModeIgnored + 1, false},
+	}
+
+	// Process test cases.
+	for i, test := range tests {
+		if result := test.value.IsDefault(); result && !test.expected {
+			t.Errorf("test index %d: value was unexpectedly classified as default", i)
+		} else if !result && test.expected {
+			t.Errorf("test index %d: value was unexpectedly classified as non-default", i)
+		}
+	}
+}
+
+// TestPhantomDirectoryModeUnmarshalText tests PhantomDirectoryMode.UnmarshalText.
+func TestPhantomDirectoryModeUnmarshalText(t *testing.T) {
+	// Define test cases.
+	tests := []struct {
+		text          string
+		expectedMode  PhantomDirectoryMode
+		expectFailure bool
+	}{
+		{"", PhantomDirectoryMode_PhantomDirectoryModeDefault, true},
+		{"asdf", PhantomDirectoryMode_PhantomDirectoryModeDefault, true},
+		{"ancestor-driven", PhantomDirectoryMode_PhantomDirectoryModeAncestorDriven, false},
+		{"tracked", PhantomDirectoryMode_PhantomDirectoryModeTracked, false},
+		{"ignored", PhantomDirectoryMode_PhantomDirectoryModeIgnored, false},
+	}
+
+	// Process test cases.
+	for _, test := range tests {
+		var mode PhantomDirectoryMode
+		if err := mode.UnmarshalText([]byte(test.text)); err != nil {
+			if !test.expectFailure {
+				t.Errorf("unable to unmarshal text (%s): %s", test.text, err)
+			}
+		} else if test.expectFailure {
+			t.Error("unmarshaling succeeded unexpectedly for text:", test.text)
+		} else if mode != test.expectedMode {
+			t.Errorf(
+				"unmarshaled mode (%s) does not match expected (%s)",
+				mode,
+				test.expectedMode,
+			)
+		}
+	}
+}
+
+// TestPhantomDirectoryModeSupported tests PhantomDirectoryMode.Supported.
+func TestPhantomDirectoryModeSupported(t *testing.T) {
+	// Set up test cases.
+	testCases := []struct {
+		mode            PhantomDirectoryMode
+		expectSupported bool
+	}{
+		{PhantomDirectoryMode_PhantomDirectoryModeDefault, false},
+		{PhantomDirectoryMode_PhantomDirectoryModeAncestorDriven, true},
+		{PhantomDirectoryMode_PhantomDirectoryModeTracked, true},
+		{PhantomDirectoryMode_PhantomDirectoryModeIgnored, true},
+		{(PhantomDirectoryMode_PhantomDirectoryModeIgnored + 1), false},
+	}
+
+	// Process test cases.
+	for _, testCase := range testCases {
+		if supported := testCase.mode.Supported(); supported != testCase.expectSupported {
+			t.Errorf(
+				"mode support status (%t) does not match expected (%t)",
+				supported,
+				testCase.expectSupported,
+			)
+		}
+	}
+}
+
+// TestPhantomDirectoryModeDescription tests PhantomDirectoryMode.Description.
+func TestPhantomDirectoryModeDescription(t *testing.T) {
+	// Set up test cases.
+	testCases := []struct {
+		mode                PhantomDirectoryMode
+		expectedDescription string
+	}{
+		{PhantomDirectoryMode_PhantomDirectoryModeDefault, "Default"},
+		{PhantomDirectoryMode_PhantomDirectoryModeAncestorDriven, "Ancestor Driven"},
+		{PhantomDirectoryMode_PhantomDirectoryModeTracked, "Tracked"},
+		{PhantomDirectoryMode_PhantomDirectoryModeIgnored, "Ignored"},
+		{(PhantomDirectoryMode_PhantomDirectoryModeIgnored + 1), "Unknown"},
+	}
+
+	// Process test cases.
+	for _, testCase := range testCases {
+		if description := testCase.mode.Description(); description != testCase.expectedDescription {
+			t.Errorf(
+				"mode description (%s) does not match expected (%s)",
+				description,
+				testCase.expectedDescription,
+			)
+		}
+	}
+}
@@ -105,6 +105,7 @@ func TestReifyPhantomDirectories(t *testing.T) {
 	for i, test := range tests {
 		alpha, beta, alphaDirectoryCount, betaDirectoryCount := ReifyPhantomDirectories(
 			test.ancestor, test.alpha, test.beta,
+			PhantomDirectoryMode_PhantomDirectoryModeAncestorDriven,
 		)
 		if !alpha.Equal(test.expectedAlpha, true) {
 			t.Errorf("test index %d: alpha does not match expected: %v != %v",
@@ -128,3 +129,154 @@ func TestReifyPhantomDirectories(t *testing.T) {
 		}
 	}
 }
+
+// TestReifyPhantomDirectoriesModes tests ReifyPhantomDirectories with each
+// phantom directory mode.
+func TestReifyPhantomDirectoriesModes(t *testing.T) {
+	// Create entries representing reified nested phantom directories.
+	tDPDReified := &Entry{Contents: map[string]*Entry{"phantom": tD0}}
+
+	// Define test cases.
+	tests := []struct {
+		mode                        PhantomDirectoryMode
+		ancestor                    *Entry
+		alpha                       *Entry
+		beta                        *Entry
+		expectedAlpha               *Entry
+		expectedBeta                *Entry
+		expectedAlphaDirectoryCount uint64
+		expectedBetaDirectoryCount  uint64
+	}{
+		{PhantomDirectoryMode_PhantomDirectoryModeDefault, tN, tPD0, tPD0, tU, tU, 0, 0},
+		{PhantomDirectoryMode_PhantomDirectoryModeDefault, tD0, tPD0, tPD0, tD0, tD0, 1, 1},
+
+		{PhantomDirectoryMode_PhantomDirectoryModeAncestorDriven, tN, tPD0, tPD0, tU, tU, 0, 0},
+		{PhantomDirectoryMode_PhantomDirectoryModeAncestorDriven, tD0, tPD0, tPD0, tD0, tD0, 1, 1},
+
+		{PhantomDirectoryMode_PhantomDirectoryModeTracked, tN, tPD0, tPD0, tD0, tD0, 1, 1},
+		{PhantomDirectoryMode_PhantomDirectoryModeTracked, tF1, tPD0, tN, tD0, tN, 1, 0},
+		{PhantomDirectoryMode_PhantomDirectoryModeTracked, tN, tF1, tPD0, tF1, tD0, 0, 1},
+		{PhantomDirectoryMode_PhantomDirectoryModeTracked, tN, tPDU, tPD0, tDU, tD0, 1, 1},
+		{PhantomDirectoryMode_PhantomDirectoryModeTracked, tN, tPDPD0, tPD0, tDPDReified, tD0, 2, 1},
+		{PhantomDirectoryMode_PhantomDirectoryModeTracked, tN, tPD1, tPD0, tD1, tD0, 1, 1},
+
+		{PhantomDirectoryMode_PhantomDirectoryModeIgnored, tN, tPD0, tPD0, tU, tU, 0, 0},
+		{PhantomDirectoryMode_PhantomDirectoryModeIgnored, tD0, tPD0, tPD0, tU, tU, 0, 0},
+		{PhantomDirectoryMode_PhantomDirectoryModeIgnored, tD1, tPD0, tN, tU, tN, 0, 0},
+		{PhantomDirectoryMode_PhantomDirectoryModeIgnored, tD0, tPDU, tPDU, tU, tU, 0, 0},
+		{PhantomDirectoryMode_PhantomDirectoryModeIgnored, tD0, tPDPD0, tPD0, tU, tU, 0, 0},
+		{PhantomDirectoryMode_PhantomDirectoryModeIgnored, tD0, tD0, tPD0, tD0, tU, 1, 0},
+		{PhantomDirectoryMode_PhantomDirectoryModeIgnored, tD0, tPD1, tPD0, tD1, tD0, 1, 1},
+	}
+
+	// Process test cases.
+	for i, test := range tests {
+		alpha, beta, alphaDirectoryCount, betaDirectoryCount := ReifyPhantomDirectories(
+			test.ancestor, test.alpha, test.beta, test.mode,
+		)
+		if !alpha.Equal(test.expectedAlpha, true) {
+			t.Errorf("test index %d (%s): alpha does not match expected: %v != %v",
+				i, test.mode.Description(), alpha, test.expectedAlpha,
+			)
+		}
+		if !beta.Equal(test.expectedBeta, true) {
+			t.Errorf("test index %d (%s): beta does not match expected: %v != %v",
+				i, test.mode.Description(), beta, test.expectedBeta,
+			)
+		}
+		if alphaDirectoryCount != test.expectedAlphaDirectoryCount {
+			t.Errorf("test index %d (%s): alpha directory count does not match expected: %d != %d",
+				i, test.mode.Description(), alphaDirectoryCount, test.expectedAlphaDirectoryCount,
+			)
+		}
+		if betaDirectoryCount != test.expectedBetaDirectoryCount {
+			t.Errorf("test index %d (%s): beta directory count does not match expected: %d != %d",
+				i, test.mode.Description(), betaDirectoryCount, test.expectedBetaDirectoryCount,
+			)
+		}
+	}
+}
+
+// TestReifyPhantomDirectoriesTrackedContentBecomesPhantom tests the reification
+// and reconciliation of a directory whose tracked content becomes ignored (thus
+// making the directory fully phantom) across multiple synchronization cycles,
+// as well as the reification of the same content in a new session.
+func TestReifyPhantomDirectoriesTrackedContentBecomesPhantom(t *testing.T) {
+	// Define the synchronized content (a directory with tracked content) and
+	// the content that's observed once the directory's content is ignored.
+	synchronized := &Entry{Contents: map[string]*Entry{
+		"directory": {Contents: map[string]*Entry{"file": tF1}},
+	}}
+	phantom := &Entry{Contents: map[string]*Entry{
+		"directory": {Kind: EntryKind_PhantomDirectory, Contents: map[string]*Entry{"file": tU}},
+	}}
+
+	// Define test cases. The expected tracking status applies to subsequent
+	// cycles of an existing session and to the first cycle of a new session.
+	tests := []struct {
+		mode                   PhantomDirectoryMode
+		expectTrackedExisting  bool
+		expectTrackedNew       bool
+		expectedDirectoryCount uint64
+	}{
+		{PhantomDirectoryMode_PhantomDirectoryModeAncestorDriven, true, false, 2},
+		{PhantomDirectoryMode_PhantomDirectoryModeTracked, true, true, 2},
+		{PhantomDirectoryMode_PhantomDirectoryModeIgnored, false, false, 1},
+	}
+
+	// Process test cases.
+	for _, test := range tests {
+		// Perform several cycles with the synchronized content as the initial
+		// ancestor, verifying that the result is stable across cycles and that
+		// no endpoint changes are generated.
+		ancestor := synchronized
+		for cycle := 0; cycle < 3; cycle++ {
+			alpha, beta, alphaDirectoryCount, betaDirectoryCount := ReifyPhantomDirectories(
+				ancestor, phantom, phantom, test.mode,
+			)
+			if tracked := alpha.Contents["directory"].Kind == EntryKind_Directory; tracked != test.expectTrackedExisting {
+				t.Errorf("%s: cycle %d: alpha directory tracking status (%t) does not match expected",
+					test.mode.Description(), cycle, tracked,
+				)
+			}
+			if !beta.Equal(alpha, true) {
+				t.Errorf("%s: cycle %d: beta does not match alpha", test.mode.Description(), cycle)
+			}
+			if alphaDirectoryCount != test.expectedDirectoryCount || betaDirectoryCount != test.expectedDirectoryCount {
+				t.Errorf("%s: cycle %d: directory counts (%d, %d) do not match expected (%d)",
+					test.mode.Description(), cycle,
+					alphaDirectoryCount, betaDirectoryCount, test.expectedDirectoryCount,
+				)
+			}
+			ancestorChanges, alphaChanges, betaChanges, conflicts := Reconcile(
+				ancestor, alpha, beta,
+				SynchronizationMode_SynchronizationModeTwoWaySafe,
+				TypeChangeMode_TypeChangeModePropagate,
+				0, 0, nil,
+			)
+			if len(alphaChanges) > 0 || len(betaChanges) > 0 {
+				t.Errorf("%s: cycle %d: unexpected endpoint changes", test.mode.Description(), cycle)
+			}
+			if len(conflicts) > 0 {
+				t.Errorf("%s: cycle %d: unexpected conflicts", test.mode.Description(), cycle)
+			}
+			if cycle > 0 && len(ancestorChanges) > 0 {
+				t.Errorf("%s: cycle %d: ancestor not stable", test.mode.Description(), cycle)
+			}
+			var err error
+			if ancestor, err = Apply(ancestor, ancestorChanges); err != nil {
+				t.Fatalf("%s: cycle %d: unable to apply ancestor changes: %v",
+					test.mode.Description(), cycle, err,
+				)
+			}
+		}
+
+		// Verify the tracking status in a new session.
+		alpha, _, _, _ := ReifyPhantomDirectories(nil, phantom, phantom, test.mode)
+		if tracked := alpha.Contents["directory"].Kind == EntryKind_Directory; tracked != test.expectTrackedNew {
+			t.Errorf("%s: new session: alpha directory tracking status (%t) does not match expected",
+				test.mode.Description(), tracked,
+			)
+		}
+	}
+}
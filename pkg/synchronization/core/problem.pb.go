@@ -20,6 +20,125 @@ const (
 	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
 )
 
+// ProblemCode is a machine-readable classification of a problem's cause. Codes
+// are derived from the underlying error (see ProblemCodeForError) and are
+// intended for automation, with Problem.Error remaining the authoritative
+// human-readable description.
+type ProblemCode int32
+
+const (
+	// ProblemCode_ProblemCodeUnknown indicates that the problem's cause
+	// couldn't be classified.
+	ProblemCode_ProblemCodeUnknown ProblemCode = 0
+	// ProblemCode_ProblemCodePermissionDenied indicates that access to
+	// content was denied.
+	ProblemCode_ProblemCodePermissionDenied ProblemCode = 1
+	// ProblemCode_ProblemCodeNotFound indicates that content didn't exist.
+	ProblemCode_ProblemCodeNotFound ProblemCode = 2
+	// ProblemCode_ProblemCodeAlreadyExists indicates that content already
+	// existed.
+	ProblemCode_ProblemCodeAlreadyExists ProblemCode = 3
+	// ProblemCode_ProblemCodeTooManySymbolicLinks indicates that too many
+	// symbolic links were encountered while resolving a path.
+	ProblemCode_ProblemCodeTooManySymbolicLinks ProblemCode = 4
+	// ProblemCode_ProblemCodeNameTooLong indicates that a path or path
+	// component was too long.
+	ProblemCode_ProblemCodeNameTooLong ProblemCode = 5
+	// ProblemCode_ProblemCodeNotDirectory indicates that a path component
+	// expected to be a directory wasn't.
+	ProblemCode_ProblemCodeNotDirectory ProblemCode = 6
+	// ProblemCode_ProblemCodeDirectoryNotEmpty indicates that a directory
+	// couldn't be removed because it wasn't empty.
+	ProblemCode_ProblemCodeDirectoryNotEmpty ProblemCode = 7
+	// ProblemCode_ProblemCodeNoSpace indicates that the filesystem was out of
+	// space.
+	ProblemCode_ProblemCodeNoSpace ProblemCode = 8
+	// ProblemCode_ProblemCodeReadOnlyFilesystem indicates that the filesystem
+	// was read-only.
+	ProblemCode_ProblemCodeReadOnlyFilesystem ProblemCode = 9
+	// ProblemCode_ProblemCodeInputOutput indicates a low-level I/O error.
+	ProblemCode_ProblemCodeInputOutput ProblemCode = 10
+	// ProblemCode_ProblemCodeInvalidName indicates that a content name was
+	// invalid (e.g. not valid UTF-8 or not in the required normalization
+	// form).
+	ProblemCode_ProblemCodeInvalidName ProblemCode = 11
+	// ProblemCode_ProblemCodeModified indicates that content was modified
+	// concurrently with synchronization.
+	ProblemCode_ProblemCodeModified ProblemCode = 12
+	// ProblemCode_ProblemCodeCancelled indicates that an operation was
+	// cancelled before it could complete.
+	ProblemCode_ProblemCodeCancelled ProblemCode = 13
+	// ProblemCode_ProblemCodeCaseCollision indicates that content names
+	// differed only in case and would thus collide on a case-insensitive
+	// filesystem.
+	ProblemCode_ProblemCodeCaseCollision ProblemCode = 14
+)
+
+// Enum value maps for ProblemCode.
+var (
+	ProblemCode_name = map[int32]string{
+		0:  "ProblemCodeUnknown",
+		1:  "ProblemCodePermissionDenied",
+		2:  "ProblemCodeNotFound",
+		3:  "ProblemCodeAlreadyExists",
+		4:  "ProblemCodeTooManySymbolicLinks",
+		5:  "ProblemCodeNameTooLong",
+		6:  "ProblemCodeNotDirectory",
+		7:  "ProblemCodeDirectoryNotEmpty",
+		8:  "ProblemCodeNoSpace",
+		9:  "ProblemCodeReadOnlyFilesystem",
+		10: "ProblemCodeInputOutput",
+		11: "ProblemCodeInvalidName",
+		12: "ProblemCodeModified",
+		13: "ProblemCodeCancelled",
+		14: "ProblemCodeCaseCollision",
+	}
+	ProblemCode_value = map[string]int32{
+		"ProblemCodeUnknown":              0,
+		"ProblemCodePermissionDenied":     1,
+		"ProblemCodeNotFound":             2,
+		"ProblemCodeAlreadyExists":        3,
+		"ProblemCodeTooManySymbolicLinks": 4,
+		"ProblemCodeNameTooLong":          5,
+		"ProblemCodeNotDirectory":         6,
+		"ProblemCodeDirectoryNotEmpty":    7,
+		"ProblemCodeNoSpace":              8,
+		"ProblemCodeReadOnlyFilesystem":   9,
+		"ProblemCodeInputOutput":          10,
+		"ProblemCodeInvalidName":          11,
+		"ProblemCodeModified":             12,
+		"ProblemCodeCancelled":            13,
+		"ProblemCodeCaseCollision":        14,
+	}
+)
+
+func (x ProblemCode) Enum() *ProblemCode {
+	p := new(ProblemCode)
+	*p = x
+	return p
+}
+
+func (x ProblemCode) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (ProblemCode) Descriptor() protoreflect.EnumDescriptor {
+	return file_synchronization_core_problem_proto_enumTypes[0].Descriptor()
+}
+
+func (ProblemCode) Type() protoreflect.EnumType {
+	return &file_synchronization_core_problem_proto_enumTypes[0]
+}
+
+func (x ProblemCode) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use ProblemCode.Descriptor instead.
+func (ProblemCode) EnumDescriptor() ([]byte, []int) {
+	return file_synchronization_core_problem_proto_rawDescGZIP(), []int{0}
+}
+
 // Problem indicates an issue or error encountered at some stage of a
 // synchronization cycle. Problem objects should be considered immutable and
 // must not be modified.
@@ -33,6 +152,8 @@ type Problem struct {
 	Path string `protobuf:"bytes,1,opt,name=path,proto3" json:"path,omitempty"`
 	// Error is a human-readable summary of the problem.
 	Error string `protobuf:"bytes,2,opt,name=error,proto3" json:"error,omitempty"`
+	// Code is a machine-readable classification of the problem's cause.
+	Code ProblemCode `protobuf:"varint,3,opt,name=code,proto3,enum=core.ProblemCode" json:"code,omitempty"`
 }
 
 func (x *Problem) Reset() {
@@ -79,20 +200,57 @@ func (x *Problem) GetError() string {
 	return ""
 }
 
+func (x *Problem) GetCode() ProblemCode {
+	if x != nil {
+		return x.Code
+	}
+	return ProblemCode_ProblemCodeUnknown
+}
+
 var File_synchronization_core_problem_proto protoreflect.FileDescriptor
 
 var file_synchronization_core_problem_proto_rawDesc = []byte{
 	0x0a, 0x22, 0x73, 0x79, 0x6e, 0x63, 0x68, 0x72, 0x6f, 0x6e, 0x69, 0x7a, 0x61, 0x74, 0x69, 0x6f,
 	0x6e, 0x2f, 0x63, 0x6f, 0x72, 0x65, 0x2f, 0x70, 0x72, 0x6f, 0x62, 0x6c, 0x65, 0x6d, 0x2e, 0x70,
-	0x72, 0x6f, 0x74, 0x6f, 0x12, 0x04, 0x63, 0x6f, 0x72, 0x65, 0x22, 0x33, 0x0a, 0x07, 0x50, 0x72,
+	0x72, 0x6f, 0x74, 0x6f, 0x12, 0x04, 0x63, 0x6f, 0x72, 0x65, 0x22, 0x5a, 0x0a, 0x07, 0x50, 0x72,
 	0x6f, 0x62, 0x6c, 0x65, 0x6d, 0x12, 0x12, 0x0a, 0x04, 0x70, 0x61, 0x74, 0x68, 0x18, 0x01, 0x20,
 	0x01, 0x28, 0x09, 0x52, 0x04, 0x70, 0x61, 0x74, 0x68, 0x12, 0x14, 0x0a, 0x05, 0x65, 0x72, 0x72,
-	0x6f, 0x72, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x65, 0x72, 0x72, 0x6f, 0x72, 0x42,
-	0x38, 0x5a, 0x36, 0x67, 0x69, 0x74, 0x68, 0x75, 0x62, 0x2e, 0x63, 0x6f, 0x6d, 0x2f, 0x6d, 0x75,
-	0x74, 0x61, 0x67, 0x65, 0x6e, 0x2d, 0x69, 0x6f, 0x2f, 0x6d, 0x75, 0x74, 0x61, 0x67, 0x65, 0x6e,
-	0x2f, 0x70, 0x6b, 0x67, 0x2f, 0x73, 0x79, 0x6e, 0x63, 0x68, 0x72, 0x6f, 0x6e, 0x69, 0x7a, 0x61,
-	0x74, 0x69, 0x6f, 0x6e, 0x2f, 0x63, 0x6f, 0x72, 0x65, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f,
-	0x33,
+	0x6f, 0x72, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x65, 0x72, 0x72, 0x6f, 0x72, 0x12,
+	0x25, 0x0a, 0x04, 0x63, 0x6f, 0x64, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x11, 0x2e,
+	0x63, 0x6f, 0x72, 0x65, 0x2e, 0x50, 0x72, 0x6f, 0x62, 0x6c, 0x65, 0x6d, 0x43, 0x6f, 0x64, 0x65,
+	0x52, 0x04, 0x63, 0x6f, 0x64, 0x65, 0x2a, 0xc1, 0x03, 0x0a, 0x0b, 0x50, 0x72, 0x6f, 0x62, 0x6c,
+	0x65, 0x6d, 0x43, 0x6f, 0x64, 0x65, 0x12, 0x16, 0x0a, 0x12, 0x50, 0x72, 0x6f, 0x62, 0x6c, 0x65,
+	0x6d, 0x43, 0x6f, 0x64, 0x65, 0x55, 0x6e, 0x6b, 0x6e, 0x6f, 0x77, 0x6e, 0x10, 0x00, 0x12, 0x1f,
+	0x0a, 0x1b, 0x50, 0x72, 0x6f, 0x62, 0x6c, 0x65, 0x6d, 0x43, 0x6f, 0x64, 0x65, 0x50, 0x65, 0x72,
+	0x6d, 0x69, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x44, 0x65, 0x6e, 0x69, 0x65, 0x64, 0x10, 0x01, 0x12,
+	0x17, 0x0a, 0x13, 0x50, 0x72, 0x6f, 0x62, 0x6c, 0x65, 0x6d, 0x43, 0x6f, 0x64, 0x65, 0x4e, 0x6f,
+	0x74, 0x46, 0x6f, 0x75, 0x6e, 0x64, 0x10, 0x02, 0x12, 0x1c, 0x0a, 0x18, 0x50, 0x72, 0x6f, 0x62,
+	0x6c, 0x65, 0x6d, 0x43, 0x6f, 0x64, 0x65, 0x41, 0x6c, 0x72, 0x65, 0x61, 0x64, 0x79, 0x45, 0x78,
+	0x69, 0x73, 0x74, 0x73, 0x10, 0x03, 0x12, 0x23, 0x0a, 0x1f, 0x50, 0x72, 0x6f, 0x62, 0x6c, 0x65,
+	0x6d, 0x43, 0x6f, 0x64, 0x65, 0x54, 0x6f, 0x6f, 0x4d, 0x61, 0x6e, 0x79, 0x53, 0x79, 0x6d, 0x62,
+	0x6f, 0x6c, 0x69, 0x63, 0x4c, 0x69, 0x6e, 0x6b, 0x73, 0x10, 0x04, 0x12, 0x1a, 0x0a, 0x16, 0x50,
+	0x72, 0x6f, 0x62, 0x6c, 0x65, 0x6d, 0x43, 0x6f, 0x64, 0x65, 0x4e, 0x61, 0x6d, 0x65, 0x54, 0x6f,
+	0x6f, 0x4c, 0x6f, 0x6e, 0x67, 0x10, 0x05, 0x12, 0x1b, 0x0a, 0x17, 0x50, 0x72, 0x6f, 0x62, 0x6c,
+	0x65, 0x6d, 0x43, 0x6f, 0x64, 0x65, 0x4e, 0x6f, 0x74, 0x44, 0x69, 0x72, 0x65, 0x63, 0x74, 0x6f,
+	0x72, 0x79, 0x10, 0x06, 0x12, 0x20, 0x0a, 0x1c, 0x50, 0x72, 0x6f, 0x62, 0x6c, 0x65, 0x6d, 0x43,
+	0x6f, 0x64, 0x65, 0x44, 0x69, 0x72, 0x65, 0x63, 0x74, 0x6f, 0x72, 0x79, 0x4e, 0x6f, 0x74, 0x45,
+	0x6d, 0x70, 0x74, 0x79, 0x10, 0x07, 0x12, 0x16, 0x0a, 0x12, 0x50, 0x72, 0x6f, 0x62, 0x6c, 0x65,
+	0x6d, 0x43, 0x6f, 0x64, 0x65, 0x4e, 0x6f, 0x53, 0x70, 0x61, 0x63, 0x65, 0x10, 0x08, 0x12, 0x21,
+	0x0a, 0x1d, 0x50, 0x72, 0x6f, 0x62, 0x6c, 0x65, 0x6d, 0x43, 0x6f, 0x64, 0x65, 0x52, 0x65, 0x61,
+	0x64, 0x4f, 0x6e, 0x6c, 0x79, 0x46, 0x69, 0x6c, 0x65, 0x73, 0x79, 0x73, 0x74, 0x65, 0x6d, 0x10,
+	0x09, 0x12, 0x1a, 0x0a, 0x16, 0x50, 0x72, 0x6f, 0x62, 0x6c, 0x65, 0x6d, 0x43, 0x6f, 0x64, 0x65,
+	0x49, 0x6e, 0x70, 0x75, 0x74, 0x4f, 0x75, 0x74, 0x70, 0x75, 0x74, 0x10, 0x0a, 0x12, 0x1a, 0x0a,
+	0x16, 0x50, 0x72, 0x6f, 0x62, 0x6c, 0x65, 0x6d, 0x43, 0x6f, 0x64, 0x65, 0x49, 0x6e, 0x76, 0x61,
+	0x6c, 0x69, 0x64, 0x4e, 0x61, 0x6d, 0x65, 0x10, 0x0b, 0x12, 0x17, 0x0a, 0x13, 0x50, 0x72, 0x6f,
+	0x62, 0x6c, 0x65, 0x6d, 0x43, 0x6f, 0x64, 0x65, 0x4d, 0x6f, 0x64, 0x69, 0x66, 0x69, 0x65, 0x64,
+	0x10, 0x0c, 0x12, 0x18, 0x0a, 0x14, 0x50, 0x72, 0x6f, 0x62, 0x6c, 0x65, 0x6d, 0x43, 0x6f, 0x64,
+	0x65, 0x43, 0x61, 0x6e, 0x63, 0x65, 0x6c, 0x6c, 0x65, 0x64, 0x10, 0x0d, 0x12, 0x1c, 0x0a, 0x18,
+	0x50, 0x72, 0x6f, 0x62, 0x6c, 0x65, 0x6d, 0x43, 0x6f, 0x64, 0x65, 0x43, 0x61, 0x73, 0x65, 0x43,
+	0x6f, 0x6c, 0x6c, 0x69, 0x73, 0x69, 0x6f, 0x6e, 0x10, 0x0e, 0x42, 0x38, 0x5a, 0x36, 0x67, 0x69,
+	0x74, 0x68, 0x75, 0x62, 0x2e, 0x63, 0x6f, 0x6d, 0x2f, 0x6d, 0x75, 0x74, 0x61, 0x67, 0x65, 0x6e,
+	0x2d, 0x69, 0x6f, 0x2f, 0x6d, 0x75, 0x74, 0x61, 0x67, 0x65, 0x6e, 0x2f, 0x70, 0x6b, 0x67, 0x2f,
+	0x73, 0x79, 0x6e, 0x63, 0x68, 0x72, 0x6f, 0x6e, 0x69, 0x7a, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x2f,
+	0x63, 0x6f, 0x72, 0x65, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
 }
 
 var (
@@ -107,16 +265,19 @@ func file_synchronization_core_problem_proto_rawDescGZIP() []byte {
 	return file_synchronization_core_problem_proto_rawDescData
 }
 
+var file_synchronization_core_problem_proto_enumTypes = make([]protoimpl.EnumInfo, 1)
 var file_synchronization_core_problem_proto_msgTypes = make([]protoimpl.MessageInfo, 1)
 var file_synchronization_core_problem_proto_goTypes = []any{
-	(*Problem)(nil), // 0: core.Problem
+	(ProblemCode)(0), // 0: core.ProblemCode
+	(*Problem)(nil),  // 1: core.Problem
 }
 var file_synchronization_core_problem_proto_depIdxs = []int32{
-	0, // [0:0] is the sub-list for method output_type
-	0, // [0:0] is the sub-list for method input_type
-	0, // [0:0] is the sub-list for extension type_name
-	0, // [0:0] is the sub-list for extension extendee
-	0, // [0:0] is the sub-list for field type_name
+	0, // 0: core.Problem.code:type_name -> core.ProblemCode
+	1, // [1:1] is the sub-list for method output_type
+	1, // [1:1] is the sub-list for method input_type
+	1, // [1:1] is the sub-list for extension type_name
+	1, // [1:1] is the sub-list for extension extendee
+	0, // [0:1] is the sub-list for field type_name
 }
 
 func init() { file_synchronization_core_problem_proto_init() }
@@ -129,13 +290,14 @@ func file_synchronization_core_problem_proto_init() {
 		File: protoimpl.DescBuilder{
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: file_synchronization_core_problem_proto_rawDesc,
-			NumEnums:      0,
+			NumEnums:      1,
 			NumMessages:   1,
 			NumExtensions: 0,
 			NumServices:   0,
 		},
 		GoTypes:           file_synchronization_core_problem_proto_goTypes,
 		DependencyIndexes: file_synchronization_core_problem_proto_depIdxs,
+		EnumInfos:         file_synchronization_core_problem_proto_enumTypes,
 		MessageInfos:      file_synchronization_core_problem_proto_msgTypes,
 	}.Build()
 	File_synchronization_core_problem_proto = out.File
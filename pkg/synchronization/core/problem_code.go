@@ -0,0 +1,187 @@
+package core
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"syscall"
+)
+
+// MarshalText implements encoding.TextMarshaler.MarshalText.
+func (c ProblemCode) MarshalText() ([]byte, error) {
+	var result string
+	switch c {
+	case ProblemCode_ProblemCodeUnknown:
+		result = "unknown"
+	case ProblemCode_ProblemCodePermissionDenied:
+		result = "permission-denied"
+	case ProblemCode_ProblemCodeNotFound:
+		result = "not-found"
+	case ProblemCode_ProblemCodeAlreadyExists:
+		result = "already-exists"
+	case ProblemCode_ProblemCodeTooManySymbolicLinks:
+		result = "too-many-symbolic-links"
+	case ProblemCode_ProblemCodeNameTooLong:
+		result = "name-too-long"
+	case ProblemCode_ProblemCodeNotDirectory:
+		result = "not-directory"
+	case ProblemCode_ProblemCodeDirectoryNotEmpty:
+		result = "directory-not-empty"
+	case ProblemCode_ProblemCodeNoSpace:
+		result = "no-space"
+	case ProblemCode_ProblemCodeReadOnlyFilesystem:
+		result = "read-only-filesystem"
+	case ProblemCode_ProblemCodeInputOutput:
+		result = "input-output"
+	case ProblemCode_ProblemCodeInvalidName:
+		result = "invalid-name"
+	case ProblemCode_ProblemCodeModified:
+		result = "modified"
+	case ProblemCode_ProblemCodeCancelled:
+		result = "cancelled"
+	case ProblemCode_ProblemCodeCaseCollision:
+		result = "case-collision"
+	default:
+		result = "unknown"
+	}
+	return []byte(result), nil
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler.UnmarshalText.
+func (c *ProblemCode) UnmarshalText(textBytes []byte) error {
+	// Convert the bytes to a string.
+	text := string(textBytes)
+
+	// Convert to a problem code.
+	switch text {
+	case "unknown":
+		*c = ProblemCode_ProblemCodeUnknown
+	case "permission-denied":
+		*c = ProblemCode_ProblemCodePermissionDenied
+	case "not-found":
+		*c = ProblemCode_ProblemCodeNotFound
+	case "already-exists":
+		*c = ProblemCode_ProblemCodeAlreadyExists
+	case "too-many-symbolic-links":
+		*c = ProblemCode_ProblemCodeTooManySymbolicLinks
+	case "name-too-long":
+		*c = ProblemCode_ProblemCodeNameTooLong
+	case "not-directory":
+		*c = ProblemCode_ProblemCodeNotDirectory
+	case "directory-not-empty":
+		*c = ProblemCode_ProblemCodeDirectoryNotEmpty
+	case "no-space":
+		*c = ProblemCode_ProblemCodeNoSpace
+	case "read-only-filesystem":
+		*c = ProblemCode_ProblemCodeReadOnlyFilesystem
+	case "input-output":
+		*c = ProblemCode_ProblemCodeInputOutput
+	case "invalid-name":
+		*c = ProblemCode_ProblemCodeInvalidName
+	case "modified":
+		*c = ProblemCode_ProblemCodeModified
+	case "cancelled":
+		*c = ProblemCode_ProblemCodeCancelled
+	case "case-collision":
+		*c = ProblemCode_ProblemCodeCaseCollision
+	default:
+		return fmt.Errorf("unknown problem code specification: %s", text)
+	}
+
+	// Success.
+	return nil
+}
+
+// Description returns a human-readable description of a problem code.
+func (c ProblemCode) Description() string {
+	switch c {
+	case ProblemCode_ProblemCodeUnknown:
+		return "Unknown"
+	case ProblemCode_ProblemCodePermissionDenied:
+		return "Permission denied"
+	case ProblemCode_ProblemCodeNotFound:
+		return "Not found"
+	case ProblemCode_ProblemCodeAlreadyExists:
+		return "Already exists"
+	case ProblemCode_ProblemCodeTooManySymbolicLinks:
+		return "Too many symbolic links"
+	case ProblemCode_ProblemCodeNameTooLong:
+		return "Name too long"
+	case ProblemCode_ProblemCodeNotDirectory:
+		return "Not a directory"
+	case ProblemCode_ProblemCodeDirectoryNotEmpty:
+		return "Directory not empty"
+	case ProblemCode_ProblemCodeNoSpace:
+		return "No space left"
+	case ProblemCode_ProblemCodeReadOnlyFilesystem:
+		return "Read-only filesystem"
+	case ProblemCode_ProblemCodeInputOutput:
+		return "Input/output error"
+	case ProblemCode_ProblemCodeInvalidName:
+		return "Invalid name"
+	case ProblemCode_ProblemCodeModified:
+		return "Modified"
+	case ProblemCode_ProblemCodeCancelled:
+		return "Cancelled"
+	case ProblemCode_ProblemCodeCaseCollision:
+		return "Case collision"
+	default:
+		return "Unknown"
+	}
+}
+
+// errModificationDetected indicates that content was found to have been
+// modified since it was last scanned.
+var errModificationDetected = errors.New("modification detected")
+
+// problemCodeMapping maps an error to a problem code.
+type problemCodeMapping struct {
+	// target is the error to match.
+	target error
+	// code is the corresponding problem code.
+	code ProblemCode
+}
+
+// problemCodeMappings maps errors to problem codes. Errors are matched using
+// errors.Is, in order, so more specific errors should be listed before more
+// general ones. Platform-specific errors are handled separately by
+// platformProblemCodeMappings, which are checked first.
+var problemCodeMappings = []problemCodeMapping{
+	{errModificationDetected, ProblemCode_ProblemCodeModified},
+	{errTransitionCancelled, ProblemCode_ProblemCodeCancelled},
+	{ErrScanCancelled, ProblemCode_ProblemCodeCancelled},
+	{syscall.ELOOP, ProblemCode_ProblemCodeTooManySymbolicLinks},
+	{syscall.ENAMETOOLONG, ProblemCode_ProblemCodeNameTooLong},
+	{syscall.ENOTDIR, ProblemCode_ProblemCodeNotDirectory},
+	{syscall.ENOTEMPTY, ProblemCode_ProblemCodeDirectoryNotEmpty},
+	{syscall.ENOSPC, ProblemCode_ProblemCodeNoSpace},
+	{syscall.EROFS, ProblemCode_ProblemCodeReadOnlyFilesystem},
+	{syscall.EIO, ProblemCode_ProblemCodeInputOutput},
+	{fs.ErrPermission, ProblemCode_ProblemCodePermissionDenied},
+	{fs.ErrNotExist, ProblemCode_ProblemCodeNotFound},
+	{fs.ErrExist, ProblemCode_ProblemCodeAlreadyExists},
+}
+
+// ProblemCodeForError classifies an error as a problem code. Errors that can't
+// be classified (including nil errors) yield ProblemCode_ProblemCodeUnknown.
+func ProblemCodeForError(err error) ProblemCode {
+	// Handle nil errors.
+	if err == nil {
+		return ProblemCode_ProblemCodeUnknown
+	}
+
+	// Check mappings.
+	for _, mapping := range platformProblemCodeMappings {
+		if errors.Is(err, mapping.target) {
+			return mapping.code
+		}
+	}
+	for _, mapping := range problemCodeMappings {
+		if errors.Is(err, mapping.target) {
+			return mapping.code
+		}
+	}
+
+	// No classification was possible.
+	return ProblemCode_ProblemCodeUnknown
+}
@@ -0,0 +1,7 @@
+//go:build !windows
+
+package core
+
+// platformProblemCodeMappings are platform-specific problem code mappings. On
+// POSIX systems, all relevant errors are covered by problemCodeMappings.
+var platformProblemCodeMappings []problemCodeMapping
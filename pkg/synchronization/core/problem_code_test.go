@@ -0,0 +1,134 @@
+package core
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"syscall"
+	"testing"
+)
+
+// TestProblemCodeForError tests ProblemCodeForError with common error classes.
+func TestProblemCodeForError(t *testing.T) {
+	// Set up test cases.
+	testCases := []struct {
+		err      error
+		expected ProblemCode
+	}{
+		{nil, ProblemCode_ProblemCodeUnknown},
+		{errors.New("unclassified"), ProblemCode_ProblemCodeUnknown},
+		{&fs.PathError{Op: "open", Path: "path", Err: syscall.EACCES}, ProblemCode_ProblemCodePermissionDenied},
+		{&fs.PathError{Op: "open", Path: "path", Err: syscall.EPERM}, ProblemCode_ProblemCodePermissionDenied},
+		{&fs.PathError{Op: "open", Path: "path", Err: syscall.ENOENT}, ProblemCode_ProblemCodeNotFound},
+		{&fs.PathError{Op: "mkdir", Path: "path", Err: syscall.EEXIST}, ProblemCode_ProblemCodeAlreadyExists},
+		{&fs.PathError{Op: "open", Path: "path", Err: syscall.ELOOP}, ProblemCode_ProblemCodeTooManySymbolicLinks},
+		{&fs.PathError{Op: "open", Path: "path", Err: syscall.ENAMETOOLONG}, ProblemCode_ProblemCodeNameTooLong},
+		{&fs.PathError{Op: "open", Path: "path", Err: syscall.ENOTDIR}, ProblemCode_ProblemCodeNotDirectory},
+		{&fs.PathError{Op: "rmdir", Path: "path", Err: syscall.ENOTEMPTY}, ProblemCode_ProblemCodeDirectoryNotEmpty},
+		{&fs.PathError{Op: "write", Path: "path", Err: syscall.ENOSPC}, ProblemCode_ProblemCodeNoSpace},
+		{&fs.PathError{Op: "open", Path: "path", Err: syscall.EROFS}, ProblemCode_ProblemCodeReadOnlyFilesystem},
+		{&fs.PathError{Op: "read", Path: "path", Err: syscall.EIO}, ProblemCode_ProblemCodeInputOutput},
+		{fmt.Errorf("unable to open file: %w", &fs.PathError{Op: "open", Path: "path", Err: syscall.EACCES}), ProblemCode_ProblemCodePermissionDenied},
+		{fmt.Errorf("unable to validate existing file: %w", errModificationDetected), ProblemCode_ProblemCodeModified},
+		{errTransitionCancelled, ProblemCode_ProblemCodeCancelled},
+		{ErrScanCancelled, ProblemCode_ProblemCodeCancelled},
+	}
+
+	// Process test cases.
+	for i, testCase := range testCases {
+		if code := ProblemCodeForError(testCase.err); code != testCase.expected {
+			t.Errorf("test case %d: problem code does not match expected: %s != %s",
+				i, code.Description(), testCase.expected.Description(),
+			)
+		}
+	}
+}
+
+// TestProblemCodeForFilesystemErrors tests ProblemCodeForError with errors
+// generated by actual filesystem operations.
+func TestProblemCodeForFilesystemErrors(t *testing.T) {
+	// Create a temporary directory.
+	directory := t.TempDir()
+
+	// Test non-existence.
+	if _, err := os.Lstat(filepath.Join(directory, "missing")); err == nil {
+		t.Error("non-existent path unexpectedly found")
+	} else if code := ProblemCodeForError(err); code != ProblemCode_ProblemCodeNotFound {
+		t.Error("non-existence classified incorrectly:", code.Description())
+	}
+
+	// Test prior existence.
+	if err := os.Mkdir(directory, 0700); err == nil {
+		t.Error("existing directory unexpectedly created")
+	} else if code := ProblemCodeForError(err); code != ProblemCode_ProblemCodeAlreadyExists {
+		t.Error("prior existence classified incorrectly:", code.Description())
+	}
+
+	// The remaining errors are POSIX-specific.
+	if runtime.GOOS == "windows" {
+		return
+	}
+
+	// Test symbolic link loops.
+	loop := filepath.Join(directory, "loop")
+	if err := os.Symlink("loop", loop); err != nil {
+		t.Fatal("unable to create symbolic link loop:", err)
+	}
+	if _, err := os.Stat(loop); err == nil {
+		t.Error("symbolic link loop unexpectedly resolved")
+	} else if code := ProblemCodeForError(err); code != ProblemCode_ProblemCodeTooManySymbolicLinks {
+		t.Error("symbolic link loop classified incorrectly:", code.Description())
+	}
+
+	// Test excessive name lengths.
+	if _, err := os.Lstat(filepath.Join(directory, strings.Repeat("n", 1024))); err == nil {
+		t.Error("excessively long name unexpectedly found")
+	} else if code := ProblemCodeForError(err); code != ProblemCode_ProblemCodeNameTooLong {
+		t.Error("excessive name length classified incorrectly:", code.Description())
+	}
+
+	// Test non-directory path components.
+	file := filepath.Join(directory, "file")
+	if err := os.WriteFile(file, nil, 0600); err != nil {
+		t.Fatal("unable to create file:", err)
+	}
+	if _, err := os.Lstat(filepath.Join(file, "child")); err == nil {
+		t.Error("path beneath file unexpectedly found")
+	} else if code := ProblemCodeForError(err); code != ProblemCode_ProblemCodeNotDirectory {
+		t.Error("non-directory path component classified incorrectly:", code.Description())
+	}
+
+	// Test non-empty directory removal.
+	if err := syscall.Rmdir(directory); err == nil {
+		t.Error("non-empty directory unexpectedly removed")
+	} else if code := ProblemCodeForError(err); code != ProblemCode_ProblemCodeDirectoryNotEmpty {
+		t.Error("non-empty directory removal classified incorrectly:", code.Description())
+	}
+}
+
+// TestProblemCodeTextRoundTrip tests that problem codes survive text
+// marshaling and unmarshaling.
+func TestProblemCodeTextRoundTrip(t *testing.T) {
+	for code := range ProblemCode_name {
+		original := ProblemCode(code)
+		text, err := original.MarshalText()
+		if err != nil {
+			t.Errorf("unable to marshal problem code %d: %v", code, err)
+			continue
+		}
+		var unmarshaled ProblemCode
+		if err := unmarshaled.UnmarshalText(text); err != nil {
+			t.Errorf("unable to unmarshal problem code %d: %v", code, err)
+		} else if unmarshaled != original {
+			t.Errorf("problem code %d did not survive round trip", code)
+		}
+	}
+	var code ProblemCode
+	if err := code.UnmarshalText([]byte("invalid")); err == nil {
+		t.Error("invalid problem code specification unmarshaled successfully")
+	}
+}
@@ -0,0 +1,18 @@
+package core
+
+import (
+	"golang.org/x/sys/windows"
+)
+
+// platformProblemCodeMappings are platform-specific problem code mappings. On
+// Windows, they cover native error codes that don't match the POSIX-style
+// errors used in problemCodeMappings.
+var platformProblemCodeMappings = []problemCodeMapping{
+	{windows.ERROR_CANT_RESOLVE_FILENAME, ProblemCode_ProblemCodeTooManySymbolicLinks},
+	{windows.ERROR_FILENAME_EXCED_RANGE, ProblemCode_ProblemCodeNameTooLong},
+	{windows.ERROR_DIRECTORY, ProblemCode_ProblemCodeNotDirectory},
+	{windows.ERROR_DIR_NOT_EMPTY, ProblemCode_ProblemCodeDirectoryNotEmpty},
+	{windows.ERROR_DISK_FULL, ProblemCode_ProblemCodeNoSpace},
+	{windows.ERROR_HANDLE_DISK_FULL, ProblemCode_ProblemCodeNoSpace},
+	{windows.ERROR_WRITE_PROTECT, ProblemCode_ProblemCodeReadOnlyFilesystem},
+}
@@ -1,6 +1,8 @@
 package core
 
 import (
+	"time"
+
 	"github.com/mutagen-io/mutagen/pkg/synchronization/core/fastpath"
 )
 
@@ -21,6 +23,21 @@ type reconciler struct {
 	// mode is the synchronization mode to use when determining directionality
 	// and conflict resolution behavior.
 	mode SynchronizationMode
+	// typeChangeMode is the type change mode to use when determining whether
+	// or not non-root type changes should be propagated automatically in
+	// bidirectional synchronization modes.
+	typeChangeMode TypeChangeMode
+	// alphaClockOffset is the offset of alpha's clock relative to the local
+	// clock. It's used to adjust alpha modification times in the two-way-newest
+	// synchronization mode.
+	alphaClockOffset time.Duration
+	// betaClockOffset is the offset of beta's clock relative to the local
+	// clock. It's used to adjust beta modification times in the two-way-newest
+	// synchronization mode.
+	betaClockOffset time.Duration
+	// conflictResolver is the conflict resolver to consult for conflicts that
+	// the synchronization mode doesn't resolve. It may be nil.
+	conflictResolver ConflictResolver
 	// ancestorChanges are the changes to be applied to the ancestor.
 	ancestorChanges []*Change
 	// alphaChanges are the changes to be applied to alpha.
@@ -40,6 +57,21 @@ func (r *reconciler) reconcile(path string, ancestor, alpha, beta *Entry) {
 	// contains only deletion changes, then the other entry neither represents
 	// nor contains unsynchronizable content.
 
+	// If either side has been marked as a case collision (see
+	// MarkCaseCollisions), then we can't propagate content at this path without
+	// effectively picking one of the colliding names on the case-insensitive
+	// endpoint. Unlike other problematic content, this won't have been reported
+	// as a scan problem, so we report a conflict to make the situation visible.
+	// As with other problematic content, we leave the ancestor untouched.
+	if alpha.isCaseCollision() || beta.isCaseCollision() {
+		r.conflicts = append(r.conflicts, &Conflict{
+			Root:         path,
+			AlphaChanges: []*Change{{Path: path, Old: ancestor, New: alpha}},
+			BetaChanges:  []*Change{{Path: path, Old: ancestor, New: beta}},
+		})
+		return
+	}
+
 	// If either side represents purely problematic content at this path, then
 	// there's no point in continuing reconciliation at this path. It's not even
 	// worth reporting a conflict because the corresponding problem(s) for this
@@ -54,6 +86,18 @@ func (r *reconciler) reconcile(path string, ancestor, alpha, beta *Entry) {
 		return
 	}
 
+	// Similarly, if either side represents oversized content at this path,
+	// then we skip reconciliation at this path. Oversized content is excluded
+	// from synchronization, but, unlike untracked content, it may have replaced
+	// (or may have been grown from) previously synchronized content, and we
+	// don't want that to be treated as a deletion that propagates to the other
+	// side. Thus, we leave both sides and the ancestor untouched at this path.
+	if alpha != nil && alpha.Kind == EntryKind_Oversized {
+		return
+	} else if beta != nil && beta.Kind == EntryKind_Oversized {
+		return
+	}
+
 	// If both sides are nil or untracked at this path, then we can trivially
 	// perform reconciliation by simply niling out the ancestor (if it isn't
 	// nil already) because there's nothing to track at this path and no
@@ -160,6 +204,8 @@ func (r *reconciler) reconcile(path string, ancestor, alpha, beta *Entry) {
 		r.handleDisagreementBidirectional(path, ancestor, alpha, beta)
 	case SynchronizationMode_SynchronizationModeTwoWayResolved:
 		r.handleDisagreementBidirectional(path, ancestor, alpha, beta)
+	case SynchronizationMode_SynchronizationModeTwoWayNewest:
+		r.handleDisagreementBidirectional(path, ancestor, alpha, beta)
 	case SynchronizationMode_SynchronizationModeOneWaySafe:
 		r.handleDisagreementOneWaySafe(path, ancestor, alpha, beta)
 	case SynchronizationMode_SynchronizationModeOneWayReplica:
@@ -209,6 +255,15 @@ func (r *reconciler) handleDisagreementBidirectional(path string, ancestor, alph
 	// unsynchronizable content (since it would show up in the diff), so there
 	// won't be any problems with removal. This is the classic three-way merge
 	// behavior, which propagates most creations, modifications, and deletions.
+	//
+	// The only exception (aside from blockage due to unsynchronizable content)
+	// is a non-root type change when the type change mode requires that such
+	// changes be treated as conflicts. In that case, we use a "synthetic"
+	// change for the unmodified side (for the reasons outlined in
+	// handleDisagreementOneWaySafe). These conflicts can be resolved in the
+	// usual manner, i.e. by deleting the losing side, because a deletion on the
+	// unmodified side will be handled by the heuristics below and a deletion on
+	// the modified side isn't a type change.
 	αDiff := diff(path, ancestor, α)
 	βDiff := diff(path, ancestor, β)
 	if len(βDiff) == 0 {
@@ -218,6 +273,12 @@ func (r *reconciler) handleDisagreementBidirectional(path string, ancestor, alph
 				AlphaChanges: αDiff,
 				BetaChanges:  betaUnsynchronizable,
 			})
+		} else if r.typeChangeBlocked(path, ancestor, α) {
+			r.conflicts = append(r.conflicts, &Conflict{
+				Root:         path,
+				AlphaChanges: αDiff,
+				BetaChanges:  []*Change{{Path: path, Old: ancestor, New: beta}},
+			})
 		} else {
 			r.betaChanges = append(r.betaChanges, &Change{
 				Path: path,
@@ -233,6 +294,12 @@ func (r *reconciler) handleDisagreementBidirectional(path string, ancestor, alph
 				AlphaChanges: alphaUnsynchronizable,
 				BetaChanges:  βDiff,
 			})
+		} else if r.typeChangeBlocked(path, ancestor, β) {
+			r.conflicts = append(r.conflicts, &Conflict{
+				Root:         path,
+				AlphaChanges: []*Change{{Path: path, Old: ancestor, New: alpha}},
+				BetaChanges:  βDiff,
+			})
 		} else {
 			r.alphaChanges = append(r.alphaChanges, &Change{
 				Path: path,
@@ -359,13 +426,25 @@ func (r *reconciler) handleDisagreementBidirectional(path string, ancestor, alph
 	// At this point, we've seen that both sides have non-deletion chanages, so
 	// there are no other heuristics we can apply that don't involve overwriting
 	// new content. We need to either indicate a conflict or force a resolution.
-	if r.mode == SynchronizationMode_SynchronizationModeTwoWaySafe {
-		r.conflicts = append(r.conflicts, &Conflict{
-			Root:         path,
-			AlphaChanges: αDiffNonDeletion,
-			BetaChanges:  βDiffNonDeletion,
-		})
-	} else {
+	// In the two-way-resolved mode, alpha always wins. In the two-way-newest
+	// mode, the newer side wins if a winner can be determined, otherwise we
+	// fall back to the two-way-safe behavior. If the mode doesn't determine a
+	// winner, then we give the conflict resolver (if any) a chance to do so.
+	var alphaWins, betaWins bool
+	if r.mode == SynchronizationMode_SynchronizationModeTwoWayResolved {
+		alphaWins = true
+	} else if r.mode == SynchronizationMode_SynchronizationModeTwoWayNewest {
+		alphaWins, betaWins = r.newest(α, β)
+	}
+	if !alphaWins && !betaWins && r.conflictResolver != nil {
+		switch r.conflictResolver.ResolveConflict(path, α, β) {
+		case ConflictResolutionAlpha:
+			alphaWins = true
+		case ConflictResolutionBeta:
+			betaWins = true
+		}
+	}
+	if alphaWins {
 		if betaUnsynchronizable := diff(path, β, beta); len(betaUnsynchronizable) > 0 {
 			r.conflicts = append(r.conflicts, &Conflict{
 				Root:         path,
@@ -379,9 +458,61 @@ func (r *reconciler) handleDisagreementBidirectional(path string, ancestor, alph
 				New:  α,
 			})
 		}
+	} else if betaWins {
+		if alphaUnsynchronizable := diff(path, α, alpha); len(alphaUnsynchronizable) > 0 {
+			r.conflicts = append(r.conflicts, &Conflict{
+				Root:         path,
+				AlphaChanges: alphaUnsynchronizable,
+				BetaChanges:  βDiffNonDeletion,
+			})
+		} else {
+			r.alphaChanges = append(r.alphaChanges, &Change{
+				Path: path,
+				Old:  α,
+				New:  β,
+			})
+		}
+	} else {
+		r.conflicts = append(r.conflicts, &Conflict{
+			Root:         path,
+			AlphaChanges: αDiffNonDeletion,
+			BetaChanges:  βDiffNonDeletion,
+		})
 	}
 }
 
+// typeChangeBlocked determines whether or not the propagation of a (non-nil)
+// synchronizable entry that replaces the ancestor at the specified path should
+// be blocked because it represents a non-root type change and the type change
+// mode requires that such changes be treated as conflicts. Root type changes
+// are excluded because they're handled separately by the controller.
+func (r *reconciler) typeChangeBlocked(path string, ancestor, entry *Entry) bool {
+	return r.typeChangeMode == TypeChangeMode_TypeChangeModeConflict &&
+		path != "" &&
+		ancestor != nil && entry != nil &&
+		ancestor.Kind != entry.Kind
+}
+
+// newest determines which of the (non-nil) synchronizable α and β entries is
+// newer for the purposes of conflict resolution in the two-way-newest
+// synchronization mode. A winner is only determined if both entries are files
+// with modification times. Modification times are adjusted by the respective
+// endpoint clock offsets before comparison. If the adjusted modification times
+// are identical, then neither side wins.
+func (r *reconciler) newest(α, β *Entry) (alphaNewer, betaNewer bool) {
+	// Ensure that both sides are files with modification times.
+	if α.Kind != EntryKind_File || β.Kind != EntryKind_File {
+		return
+	} else if α.ModificationTime == nil || β.ModificationTime == nil {
+		return
+	}
+
+	// Convert modification times to the local clock and compare them.
+	αTime := α.ModificationTime.AsTime().Add(-r.alphaClockOffset)
+	βTime := β.ModificationTime.AsTime().Add(-r.betaClockOffset)
+	return αTime.After(βTime), βTime.After(αTime)
+}
+
 // handleDisagreementOneWaySafe handles content disagreements between alpha and
 // beta at a particular path in the one-way-safe synchronization mode.
 func (r *reconciler) handleDisagreementOneWaySafe(path string, ancestor, alpha, beta *Entry) {
@@ -519,14 +650,43 @@ func (r *reconciler) handleDisagreementOneWayReplica(path string, ancestor, alph
 
 // Reconcile performs a recursive three-way merge and generates a list of
 // changes for the ancestor, alpha, and beta, as well as a list of conflicts.
-// All of these lists are returned in depth-first but non-deterministic order.
-func Reconcile(ancestor, alpha, beta *Entry, mode SynchronizationMode) ([]*Change, []*Change, []*Change, []*Conflict) {
+// All of these lists are returned in depth-first order, with sibling paths
+// ordered lexicographically, so the results are deterministic for a given set
+// of inputs.
+// The typeChangeMode argument controls whether or not non-root type changes
+// are propagated automatically in bidirectional synchronization modes.
+// The alphaClockOffset and betaClockOffset arguments specify the offsets of the
+// respective endpoint clocks relative to the local clock and are only used to
+// compare file modification times in the two-way-newest synchronization mode.
+// The conflictResolver argument specifies an optional ConflictResolver to
+// consult for conflicts that the synchronization mode doesn't resolve. If it's
+// nil, then such conflicts are always reported.
+func Reconcile(
+	ancestor, alpha, beta *Entry,
+	mode SynchronizationMode,
+	typeChangeMode TypeChangeMode,
+	alphaClockOffset, betaClockOffset time.Duration,
+	conflictResolver ConflictResolver,
+) ([]*Change, []*Change, []*Change, []*Conflict) {
 	// Create the reconciler.
-	r := &reconciler{mode: mode}
+	r := &reconciler{
+		mode:             mode,
+		typeChangeMode:   typeChangeMode,
+		alphaClockOffset: alphaClockOffset,
+		betaClockOffset:  betaClockOffset,
+		conflictResolver: conflictResolver,
+	}
 
 	// Perform reconciliation.
 	r.reconcile("", ancestor, alpha, beta)
 
+	// Sort the results. Reconciliation traverses directory contents in map
+	// iteration order, so sibling paths would otherwise appear in random order.
+	SortChanges(r.ancestorChanges)
+	SortChanges(r.alphaChanges)
+	SortChanges(r.betaChanges)
+	SortConflicts(r.conflicts)
+
 	// Done.
 	return r.ancestorChanges, r.alphaChanges, r.betaChanges, r.conflicts
 }
@@ -1,13 +1,19 @@
 package core
 
 import (
+	"sort"
+	"strings"
 	"testing"
+	"time"
+
+	"google.golang.org/protobuf/types/known/timestamppb"
 )
 
 // allModes is shorthand for all synchronization modes.
 var allModes = []SynchronizationMode{
 	SynchronizationMode_SynchronizationModeTwoWaySafe,
 	SynchronizationMode_SynchronizationModeTwoWayResolved,
+	SynchronizationMode_SynchronizationModeTwoWayNewest,
 	SynchronizationMode_SynchronizationModeOneWaySafe,
 	SynchronizationMode_SynchronizationModeOneWayReplica,
 }
@@ -16,6 +22,7 @@ var allModes = []SynchronizationMode{
 var twoWayModes = []SynchronizationMode{
 	SynchronizationMode_SynchronizationModeTwoWaySafe,
 	SynchronizationMode_SynchronizationModeTwoWayResolved,
+	SynchronizationMode_SynchronizationModeTwoWayNewest,
 }
 
 // oneWayModes is shorthand for all unidirectional synchronization modes.
@@ -24,10 +31,13 @@ var oneWayModes = []SynchronizationMode{
 	SynchronizationMode_SynchronizationModeOneWayReplica,
 }
 
-// safeModes is shorthand for all safe synchronization modes.
+// safeModes is shorthand for all safe synchronization modes. It includes the
+// two-way-newest mode, which behaves safely for entries without modification
+// times (which is the case for all of the standard testing entries).
 var safeModes = []SynchronizationMode{
 	SynchronizationMode_SynchronizationModeTwoWaySafe,
 	SynchronizationMode_SynchronizationModeOneWaySafe,
+	SynchronizationMode_SynchronizationModeTwoWayNewest,
 }
 
 // resolvedModes is shorthand for all resolved synchronization modes.
@@ -363,6 +373,46 @@ func TestReconcile(t *testing.T) {
 			beta:        tP1,
 		},
 
+		// Test cases where one or both sides is oversized.
+		{
+			description: "alpha oversized others nil",
+			modes:       allModes,
+			alpha:       tO,
+		},
+		{
+			description: "alpha oversized ancestor file",
+			modes:       allModes,
+			ancestor:    tF1,
+			alpha:       tO,
+		},
+		{
+			description: "alpha oversized others file",
+			modes:       allModes,
+			ancestor:    tF1,
+			alpha:       tO,
+			beta:        tF1,
+		},
+		{
+			description: "beta oversized others file",
+			modes:       allModes,
+			ancestor:    tF1,
+			alpha:       tF1,
+			beta:        tO,
+		},
+		{
+			description: "both oversized ancestor directory",
+			modes:       allModes,
+			ancestor:    tD1,
+			alpha:       tO,
+			beta:        tO,
+		},
+		{
+			description:         "alpha created directory with oversized content",
+			modes:               allModes,
+			alpha:               tDO,
+			expectedBetaChanges: []*Change{{New: tD0}},
+		},
+
 		// Test cases where only alpha has modified content.
 		{
 			description:         "alpha created file",
@@ -1043,7 +1093,7 @@ func TestReconcile(t *testing.T) {
 		for _, mode := range test.modes {
 			// Perform reconciliation.
 			ancestorChanges, alphaChanges, betaChanges, conflicts := Reconcile(
-				test.ancestor, test.alpha, test.beta, mode,
+				test.ancestor, test.alpha, test.beta, mode, TypeChangeMode_TypeChangeModePropagate, 0, 0, nil,
 			)
 
 			// Verify the ancestor changes.
@@ -1077,6 +1127,421 @@ func TestReconcile(t *testing.T) {
 	}
 }
 
+// modified creates a copy of a file entry with the specified modification time
+// (expressed in seconds since the Unix epoch). This function panics if the entry
+// is not a file entry.
+func modified(entry *Entry, seconds int64) *Entry {
+	if entry.Kind != EntryKind_File {
+		panic("entry is not a file")
+	}
+	return &Entry{
+		Kind:             EntryKind_File,
+		Digest:           entry.Digest,
+		Executable:       entry.Executable,
+		ModificationTime: &timestamppb.Timestamp{Seconds: seconds},
+	}
+}
+
+// TestReconcileTwoWayNewest tests modification-time-based conflict resolution
+// in the two-way-newest synchronization mode.
+func TestReconcileTwoWayNewest(t *testing.T) {
+	// Define test cases.
+	tests := []struct {
+		description          string
+		ancestor             *Entry
+		alpha                *Entry
+		beta                 *Entry
+		alphaClockOffset     time.Duration
+		betaClockOffset      time.Duration
+		expectedAlphaChanges []*Change
+		expectedBetaChanges  []*Change
+		expectedConflicts    []*Conflict
+	}{
+		{
+			description:         "both modified file, alpha newer",
+			ancestor:            tF3,
+			alpha:               modified(tF1, 20),
+			beta:                modified(tF2, 10),
+			expectedBetaChanges: []*Change{{Old: tF2, New: tF1}},
+		},
+		{
+			description:          "both modified file, beta newer",
+			ancestor:             tF3,
+			alpha:                modified(tF1, 10),
+			beta:                 modified(tF2, 20),
+			expectedAlphaChanges: []*Change{{Old: tF1, New: tF2}},
+		},
+		{
+			description:         "both created file, alpha newer",
+			alpha:               modified(tF1, 20),
+			beta:                modified(tF2, 10),
+			expectedBetaChanges: []*Change{{Old: tF2, New: tF1}},
+		},
+		{
+			description: "both modified file, identical modification times",
+			ancestor:    tF3,
+			alpha:       modified(tF1, 10),
+			beta:        modified(tF2, 10),
+			expectedConflicts: []*Conflict{{
+				AlphaChanges: []*Change{{Old: tF3, New: tF1}},
+				BetaChanges:  []*Change{{Old: tF3, New: tF2}},
+			}},
+		},
+		{
+			description:         "both modified file, beta newer before clock offset adjustment",
+			ancestor:            tF3,
+			alpha:               modified(tF1, 20),
+			beta:                modified(tF2, 30),
+			betaClockOffset:     time.Minute,
+			expectedBetaChanges: []*Change{{Old: tF2, New: tF1}},
+		},
+		{
+			description:          "both modified file, alpha newer before clock offset adjustment",
+			ancestor:             tF3,
+			alpha:                modified(tF1, 30),
+			beta:                 modified(tF2, 20),
+			alphaClockOffset:     time.Minute,
+			expectedAlphaChanges: []*Change{{Old: tF1, New: tF2}},
+		},
+		{
+			description:      "both modified file, identical after clock offset adjustment",
+			ancestor:         tF3,
+			alpha:            modified(tF1, 70),
+			beta:             modified(tF2, 10),
+			alphaClockOffset: time.Minute,
+			expectedConflicts: []*Conflict{{
+				AlphaChanges: []*Change{{Old: tF3, New: tF1}},
+				BetaChanges:  []*Change{{Old: tF3, New: tF2}},
+			}},
+		},
+		{
+			description: "both modified file, beta missing modification time",
+			ancestor:    tF3,
+			alpha:       modified(tF1, 20),
+			beta:        tF2,
+			expectedConflicts: []*Conflict{{
+				AlphaChanges: []*Change{{Old: tF3, New: tF1}},
+				BetaChanges:  []*Change{{Old: tF3, New: tF2}},
+			}},
+		},
+		{
+			description: "alpha created newer file, beta created directory",
+			alpha:       modified(tF1, 20),
+			beta:        tD2,
+			expectedConflicts: []*Conflict{{
+				AlphaChanges: []*Change{{New: tF1}},
+				BetaChanges:  []*Change{{New: tD2}},
+			}},
+		},
+		{
+			description:         "both created directory with different file, alpha newer",
+			alpha:               nested("file", modified(tF1, 20)),
+			beta:                nested("file", modified(tF2, 10)),
+			expectedBetaChanges: []*Change{{Path: "file", Old: tF2, New: tF1}},
+		},
+	}
+
+	// Process test cases.
+	for _, test := range tests {
+		// Perform reconciliation.
+		_, alphaChanges, betaChanges, conflicts := Reconcile(
+			test.ancestor, test.alpha, test.beta,
+			SynchronizationMode_SynchronizationModeTwoWayNewest,
+			TypeChangeMode_TypeChangeModePropagate,
+			test.alphaClockOffset, test.betaClockOffset,
+			nil,
+		)
+
+		// Verify the alpha changes.
+		if !testingChangeListsEqual(alphaChanges, test.expectedAlphaChanges) {
+			t.Errorf("%s: alpha changes do not match expected: %v != %v",
+				test.description, alphaChanges, test.expectedAlphaChanges,
+			)
+		}
+
+		// Verify the beta changes.
+		if !testingChangeListsEqual(betaChanges, test.expectedBetaChanges) {
+			t.Errorf("%s: beta changes do not match expected: %v != %v",
+				test.description, betaChanges, test.expectedBetaChanges,
+			)
+		}
+
+		// Verify the conflicts.
+		if !testingConflictListsEqual(conflicts, test.expectedConflicts) {
+			t.Errorf("%s: conflicts do not match expected: %v != %v",
+				test.description, conflicts, test.expectedConflicts,
+			)
+		}
+	}
+}
+
+// TestReconcileTypeChangeMode tests the handling of non-root type changes under
+// each type change mode in bidirectional synchronization modes.
+func TestReconcileTypeChangeMode(t *testing.T) {
+	// Define test cases.
+	tests := []struct {
+		description          string
+		typeChangeMode       TypeChangeMode
+		ancestor             *Entry
+		alpha                *Entry
+		beta                 *Entry
+		expectedAlphaChanges []*Change
+		expectedBetaChanges  []*Change
+		expectedConflicts    []*Conflict
+	}{
+		{
+			description:         "alpha file to directory, propagate",
+			typeChangeMode:      TypeChangeMode_TypeChangeModePropagate,
+			ancestor:            nested("file", tF1),
+			alpha:               nested("file", tD1),
+			beta:                nested("file", tF1),
+			expectedBetaChanges: []*Change{{Path: "file", Old: tF1, New: tD1}},
+		},
+		{
+			description:    "alpha file to directory, conflict",
+			typeChangeMode: TypeChangeMode_TypeChangeModeConflict,
+			ancestor:       nested("file", tF1),
+			alpha:          nested("file", tD1),
+			beta:           nested("file", tF1),
+			expectedConflicts: []*Conflict{{
+				Root:         "file",
+				AlphaChanges: []*Change{{Path: "file", Old: tF1, New: tD1}},
+				BetaChanges:  []*Change{{Path: "file", Old: tF1, New: tF1}},
+			}},
+		},
+		{
+			description:          "beta file to directory, propagate",
+			typeChangeMode:       TypeChangeMode_TypeChangeModePropagate,
+			ancestor:             nested("file", tF1),
+			alpha:                nested("file", tF1),
+			beta:                 nested("file", tD1),
+			expectedAlphaChanges: []*Change{{Path: "file", Old: tF1, New: tD1}},
+		},
+		{
+			description:    "beta file to directory, conflict",
+			typeChangeMode: TypeChangeMode_TypeChangeModeConflict,
+			ancestor:       nested("file", tF1),
+			alpha:          nested("file", tF1),
+			beta:           nested("file", tD1),
+			expectedConflicts: []*Conflict{{
+				Root:         "file",
+				AlphaChanges: []*Change{{Path: "file", Old: tF1, New: tF1}},
+				BetaChanges:  []*Change{{Path: "file", Old: tF1, New: tD1}},
+			}},
+		},
+		{
+			description:         "alpha file to directory, conflict resolved by beta deletion",
+			typeChangeMode:      TypeChangeMode_TypeChangeModeConflict,
+			ancestor:            nested("file", tF1),
+			alpha:               nested("file", tD1),
+			beta:                tD0,
+			expectedBetaChanges: []*Change{{Path: "file", New: tD1}},
+		},
+		{
+			description:         "alpha file modification, conflict",
+			typeChangeMode:      TypeChangeMode_TypeChangeModeConflict,
+			ancestor:            nested("file", tF1),
+			alpha:               nested("file", tF2),
+			beta:                nested("file", tF1),
+			expectedBetaChanges: []*Change{{Path: "file", Old: tF1, New: tF2}},
+		},
+		{
+			description:         "alpha root file to directory, conflict",
+			typeChangeMode:      TypeChangeMode_TypeChangeModeConflict,
+			ancestor:            tF1,
+			alpha:               tD1,
+			beta:                tF1,
+			expectedBetaChanges: []*Change{{Old: tF1, New: tD1}},
+		},
+	}
+
+	// Process test cases.
+	for _, test := range tests {
+		// Perform reconciliation.
+		_, alphaChanges, betaChanges, conflicts := Reconcile(
+			test.ancestor, test.alpha, test.beta,
+			SynchronizationMode_SynchronizationModeTwoWaySafe,
+			test.typeChangeMode,
+			0, 0,
+			nil,
+		)
+
+		// Verify the alpha changes.
+		if !testingChangeListsEqual(alphaChanges, test.expectedAlphaChanges) {
+			t.Errorf("%s: alpha changes do not match expected: %v != %v",
+				test.description, alphaChanges, test.expectedAlphaChanges,
+			)
+		}
+
+		// Verify the beta changes.
+		if !testingChangeListsEqual(betaChanges, test.expectedBetaChanges) {
+			t.Errorf("%s: beta changes do not match expected: %v != %v",
+				test.description, betaChanges, test.expectedBetaChanges,
+			)
+		}
+
+		// Verify the conflicts.
+		if !testingConflictListsEqual(conflicts, test.expectedConflicts) {
+			t.Errorf("%s: conflicts do not match expected: %v != %v",
+				test.description, conflicts, test.expectedConflicts,
+			)
+		}
+	}
+}
+
+// testingNewerConflictResolver is a ConflictResolver that resolves conflicts
+// between files within a particular directory in favor of whichever side has
+// the newer modification time.
+type testingNewerConflictResolver struct {
+	// directory is the directory within which conflicts are resolved.
+	directory string
+}
+
+// ResolveConflict implements ConflictResolver.ResolveConflict.
+func (r *testingNewerConflictResolver) ResolveConflict(path string, alpha, beta *Entry) ConflictResolution {
+	if !strings.HasPrefix(path, r.directory+"/") {
+		return ConflictResolutionNone
+	} else if alpha.Kind != EntryKind_File || beta.Kind != EntryKind_File {
+		return ConflictResolutionNone
+	}
+	alphaTime, betaTime := alpha.ModificationTime.AsTime(), beta.ModificationTime.AsTime()
+	if alphaTime.After(betaTime) {
+		return ConflictResolutionAlpha
+	} else if betaTime.After(alphaTime) {
+		return ConflictResolutionBeta
+	}
+	return ConflictResolutionNone
+}
+
+// testingConflictedConflictResolver is a ConflictResolver that leaves all
+// conflicts in place.
+type testingConflictedConflictResolver struct{}
+
+// ResolveConflict implements ConflictResolver.ResolveConflict.
+func (testingConflictedConflictResolver) ResolveConflict(_ string, _, _ *Entry) ConflictResolution {
+	return ConflictResolutionNone
+}
+
+// TestReconcileConflictResolver tests the use of custom conflict resolvers.
+func TestReconcileConflictResolver(t *testing.T) {
+	// Define test cases.
+	tests := []struct {
+		description          string
+		mode                 SynchronizationMode
+		resolver             ConflictResolver
+		ancestor             *Entry
+		alpha                *Entry
+		beta                 *Entry
+		expectedAlphaChanges []*Change
+		expectedBetaChanges  []*Change
+		expectedConflicts    []*Conflict
+	}{
+		{
+			description:         "newer resolver, alpha newer",
+			mode:                SynchronizationMode_SynchronizationModeTwoWaySafe,
+			resolver:            &testingNewerConflictResolver{"cache"},
+			alpha:               nested("cache", nested("file", modified(tF1, 20))),
+			beta:                nested("cache", nested("file", modified(tF2, 10))),
+			expectedBetaChanges: []*Change{{Path: "cache/file", Old: modified(tF2, 10), New: modified(tF1, 20)}},
+		},
+		{
+			description:          "newer resolver, beta newer",
+			mode:                 SynchronizationMode_SynchronizationModeTwoWaySafe,
+			resolver:             &testingNewerConflictResolver{"cache"},
+			ancestor:             nested("cache", nested("file", modified(tF3, 0))),
+			alpha:                nested("cache", nested("file", modified(tF1, 10))),
+			beta:                 nested("cache", nested("file", modified(tF2, 20))),
+			expectedAlphaChanges: []*Change{{Path: "cache/file", Old: modified(tF1, 10), New: modified(tF2, 20)}},
+		},
+		{
+			description: "newer resolver, identical modification times",
+			mode:        SynchronizationMode_SynchronizationModeTwoWaySafe,
+			resolver:    &testingNewerConflictResolver{"cache"},
+			alpha:       nested("cache", nested("file", modified(tF1, 10))),
+			beta:        nested("cache", nested("file", modified(tF2, 10))),
+			expectedConflicts: []*Conflict{{
+				Root:         "cache/file",
+				AlphaChanges: []*Change{{Path: "cache/file", New: modified(tF1, 10)}},
+				BetaChanges:  []*Change{{Path: "cache/file", New: modified(tF2, 10)}},
+			}},
+		},
+		{
+			description: "newer resolver, path not covered",
+			mode:        SynchronizationMode_SynchronizationModeTwoWaySafe,
+			resolver:    &testingNewerConflictResolver{"cache"},
+			alpha:       nested("source", nested("file", modified(tF1, 20))),
+			beta:        nested("source", nested("file", modified(tF2, 10))),
+			expectedConflicts: []*Conflict{{
+				Root:         "source/file",
+				AlphaChanges: []*Change{{Path: "source/file", New: modified(tF1, 20)}},
+				BetaChanges:  []*Change{{Path: "source/file", New: modified(tF2, 10)}},
+			}},
+		},
+		{
+			description:         "newer resolver, not consulted when mode resolves",
+			mode:                SynchronizationMode_SynchronizationModeTwoWayResolved,
+			resolver:            &testingNewerConflictResolver{"cache"},
+			alpha:               nested("cache", nested("file", modified(tF1, 10))),
+			beta:                nested("cache", nested("file", modified(tF2, 20))),
+			expectedBetaChanges: []*Change{{Path: "cache/file", Old: modified(tF2, 20), New: modified(tF1, 10)}},
+		},
+		{
+			description: "conflicted resolver",
+			mode:        SynchronizationMode_SynchronizationModeTwoWaySafe,
+			resolver:    testingConflictedConflictResolver{},
+			alpha:       nested("cache", nested("file", modified(tF1, 20))),
+			beta:        nested("cache", nested("file", modified(tF2, 10))),
+			expectedConflicts: []*Conflict{{
+				Root:         "cache/file",
+				AlphaChanges: []*Change{{Path: "cache/file", New: modified(tF1, 20)}},
+				BetaChanges:  []*Change{{Path: "cache/file", New: modified(tF2, 10)}},
+			}},
+		},
+		{
+			description:          "conflicted resolver, non-conflicting change",
+			mode:                 SynchronizationMode_SynchronizationModeTwoWaySafe,
+			resolver:             testingConflictedConflictResolver{},
+			ancestor:             nested("file", tF1),
+			alpha:                nested("file", tF1),
+			beta:                 nested("file", tF2),
+			expectedAlphaChanges: []*Change{{Path: "file", Old: tF1, New: tF2}},
+		},
+	}
+
+	// Process test cases.
+	for _, test := range tests {
+		// Perform reconciliation.
+		_, alphaChanges, betaChanges, conflicts := Reconcile(
+			test.ancestor, test.alpha, test.beta,
+			test.mode,
+			TypeChangeMode_TypeChangeModePropagate,
+			0, 0,
+			test.resolver,
+		)
+
+		// Verify the alpha changes.
+		if !testingChangeListsEqual(alphaChanges, test.expectedAlphaChanges) {
+			t.Errorf("%s: alpha changes do not match expected: %v != %v",
+				test.description, alphaChanges, test.expectedAlphaChanges,
+			)
+		}
+
+		// Verify the beta changes.
+		if !testingChangeListsEqual(betaChanges, test.expectedBetaChanges) {
+			t.Errorf("%s: beta changes do not match expected: %v != %v",
+				test.description, betaChanges, test.expectedBetaChanges,
+			)
+		}
+
+		// Verify the conflicts.
+		if !testingConflictListsEqual(conflicts, test.expectedConflicts) {
+			t.Errorf("%s: conflicts do not match expected: %v != %v",
+				test.description, conflicts, test.expectedConflicts,
+			)
+		}
+	}
+}
+
 // TestReconcilePanicWithInvalidSynchronizationMode tests that Reconcile panics
 // when provided with disagreeing contents and an invalid synchronization mode.
 func TestReconcilePanicWithInvalidSynchronizationMode(t *testing.T) {
@@ -1085,5 +1550,65 @@ func TestReconcilePanicWithInvalidSynchronizationMode(t *testing.T) {
 			t.Error("Reconcile did not panic with invalid synchronization mode")
 		}
 	}()
-	Reconcile(nil, tF1, nil, SynchronizationMode(-1))
+	Reconcile(nil, tF1, nil, SynchronizationMode(-1), TypeChangeMode_TypeChangeModePropagate, 0, 0, nil)
+}
+
+// TestReconcileDeterministicOrdering tests that Reconcile returns changes and
+// conflicts sorted by path, independent of map iteration order.
+func TestReconcileDeterministicOrdering(t *testing.T) {
+	// Create a beta directory that conflicts with tDM at every path.
+	conflicting := &Entry{Contents: make(map[string]*Entry, len(tDM.Contents))}
+	for name, entry := range tDM.Contents {
+		if entry.Equal(tF2, true) {
+			conflicting.Contents[name] = tF1
+		} else {
+			conflicting.Contents[name] = tF2
+		}
+	}
+
+	// Define test cases.
+	var tests = []struct {
+		// description is a human readable description of the test case.
+		description string
+		// ancestor is the root ancestor entry.
+		ancestor *Entry
+		// alpha is the root alpha entry.
+		alpha *Entry
+		// beta is the root beta entry.
+		beta *Entry
+	}{
+		{"both deleted contents", tDM, tD0, tD0},
+		{"alpha created contents", tD0, tDM, tD0},
+		{"beta created contents", tD0, tD0, tDM},
+		{"both created conflicting contents", tD0, tDM, conflicting},
+	}
+
+	// Process test cases. We perform reconciliation repeatedly in order to
+	// exercise different map iteration orders.
+	for _, test := range tests {
+		for i := 0; i < 10; i++ {
+			// Perform reconciliation.
+			ancestorChanges, alphaChanges, betaChanges, conflicts := Reconcile(
+				test.ancestor, test.alpha, test.beta,
+				SynchronizationMode_SynchronizationModeTwoWaySafe,
+				TypeChangeMode_TypeChangeModePropagate,
+				0, 0,
+				nil,
+			)
+
+			// Verify that the changes are sorted.
+			for _, changes := range [][]*Change{ancestorChanges, alphaChanges, betaChanges} {
+				if !sort.IsSorted(sortableChangeList(changes)) {
+					t.Errorf("%s: changes not sorted: %v", test.description, changes)
+				}
+			}
+
+			// Verify that the conflicts are sorted.
+			if len(conflicts) == 0 && test.beta == conflicting {
+				t.Errorf("%s: no conflicts generated", test.description)
+			} else if !sort.IsSorted(sortableConflictList(conflicts)) {
+				t.Errorf("%s: conflicts not sorted: %v", test.description, conflicts)
+			}
+		}
+	}
 }
@@ -0,0 +1,246 @@
+package core
+
+import (
+	"errors"
+	"fmt"
+	"time"
+)
+
+// recordingConflictResolver is a ConflictResolver that records the resolutions
+// returned by another ConflictResolver.
+type recordingConflictResolver struct {
+	// resolver is the underlying conflict resolver.
+	resolver ConflictResolver
+	// resolutions are the recorded resolutions, keyed by path. Resolutions of
+	// ConflictResolutionNone are not recorded.
+	resolutions map[string]uint32
+}
+
+// ResolveConflict implements ConflictResolver.ResolveConflict.
+func (r *recordingConflictResolver) ResolveConflict(path string, alpha, beta *Entry) ConflictResolution {
+	resolution := r.resolver.ResolveConflict(path, alpha, beta)
+	if resolution != ConflictResolutionNone {
+		if r.resolutions == nil {
+			r.resolutions = make(map[string]uint32)
+		}
+		r.resolutions[path] = uint32(resolution)
+	}
+	return resolution
+}
+
+// replayingConflictResolver is a ConflictResolver that returns recorded
+// resolutions.
+type replayingConflictResolver map[string]uint32
+
+// ResolveConflict implements ConflictResolver.ResolveConflict.
+func (r replayingConflictResolver) ResolveConflict(path string, _, _ *Entry) ConflictResolution {
+	return ConflictResolution(r[path])
+}
+
+// NewReconciliationRecording performs reconciliation using Reconcile and
+// returns a recording of the operation's inputs and outputs. If
+// conflictResolver is non-nil, then its resolutions are recorded so that it can
+// be emulated on replay. The inputs are recorded by reference and thus must not
+// be modified while the recording is in use.
+func NewReconciliationRecording(
+	ancestor, alpha, beta *Entry,
+	mode SynchronizationMode,
+	typeChangeMode TypeChangeMode,
+	alphaClockOffset, betaClockOffset time.Duration,
+	conflictResolver ConflictResolver,
+) *ReconciliationRecording {
+	// If a conflict resolver has been provided, then wrap it so that its
+	// resolutions are recorded.
+	var recorder *recordingConflictResolver
+	if conflictResolver != nil {
+		recorder = &recordingConflictResolver{resolver: conflictResolver}
+		conflictResolver = recorder
+	}
+
+	// Perform reconciliation.
+	ancestorChanges, alphaChanges, betaChanges, conflicts := Reconcile(
+		ancestor, alpha, beta,
+		mode,
+		typeChangeMode,
+		alphaClockOffset, betaClockOffset,
+		conflictResolver,
+	)
+
+	// Extract recorded resolutions.
+	var resolutions map[string]uint32
+	if recorder != nil {
+		resolutions = recorder.resolutions
+	}
+
+	// Create the recording.
+	return &ReconciliationRecording{
+		Ancestor:            ancestor,
+		Alpha:               alpha,
+		Beta:                beta,
+		SynchronizationMode: mode,
+		TypeChangeMode:      typeChangeMode,
+		AlphaClockOffset:    int64(alphaClockOffset),
+		BetaClockOffset:     int64(betaClockOffset),
+		ConflictResolutions: resolutions,
+		AncestorChanges:     ancestorChanges,
+		AlphaChanges:        alphaChanges,
+		BetaChanges:         betaChanges,
+		Conflicts:           conflicts,
+	}
+}
+
+// EnsureValid ensures that ReconciliationRecording's invariants are respected.
+func (r *ReconciliationRecording) EnsureValid() error {
+	// A nil recording is not valid.
+	if r == nil {
+		return errors.New("nil recording")
+	}
+
+	// Ensure that the input entries are valid. The ancestor can't contain
+	// unsynchronizable content, but the endpoint contents can.
+	if err := r.Ancestor.EnsureValid(true); err != nil {
+		return fmt.Errorf("invalid ancestor: %w", err)
+	} else if err = r.Alpha.EnsureValid(false); err != nil {
+		return fmt.Errorf("invalid alpha: %w", err)
+	} else if err = r.Beta.EnsureValid(false); err != nil {
+		return fmt.Errorf("invalid beta: %w", err)
+	}
+
+	// Ensure that the reconciliation parameters are supported.
+	if !r.SynchronizationMode.Supported() {
+		return errors.New("unknown or unsupported synchronization mode")
+	} else if !r.TypeChangeMode.Supported() {
+		return errors.New("unknown or unsupported type change mode")
+	}
+
+	// Ensure that the recorded conflict resolutions are valid.
+	for _, resolution := range r.ConflictResolutions {
+		switch ConflictResolution(resolution) {
+		case ConflictResolutionAlpha, ConflictResolutionBeta:
+		default:
+			return errors.New("invalid conflict resolution")
+		}
+	}
+
+	// Ensure that the output changes are valid.
+	for _, change := range r.AncestorChanges {
+		if err := change.EnsureValid(true); err != nil {
+			return fmt.Errorf("invalid ancestor change: %w", err)
+		}
+	}
+	for _, change := range r.AlphaChanges {
+		if err := change.EnsureValid(false); err != nil {
+			return fmt.Errorf("invalid alpha change: %w", err)
+		}
+	}
+	for _, change := range r.BetaChanges {
+		if err := change.EnsureValid(false); err != nil {
+			return fmt.Errorf("invalid beta change: %w", err)
+		}
+	}
+
+	// Ensure that the output conflicts are valid.
+	for _, conflict := range r.Conflicts {
+		if err := conflict.EnsureValid(); err != nil {
+			return fmt.Errorf("invalid conflict: %w", err)
+		}
+	}
+
+	// Success.
+	return nil
+}
+
+// changeListsEquivalent determines whether or not two lists of changes are
+// equivalent, ignoring ordering. It assumes that change paths are unique
+// within each list, which is the case for lists generated by Reconcile.
+func changeListsEquivalent(first, second []*Change) bool {
+	// Verify that the lists have the same length.
+	if len(first) != len(second) {
+		return false
+	}
+
+	// Index the first list by path.
+	pathToChange := make(map[string]*Change, len(first))
+	for _, change := range first {
+		pathToChange[change.Path] = change
+	}
+
+	// Verify that each change in the second list has an equivalent in the
+	// first list.
+	for _, change := range second {
+		if other, ok := pathToChange[change.Path]; !ok {
+			return false
+		} else if !other.Old.Equal(change.Old, true) || !other.New.Equal(change.New, true) {
+			return false
+		}
+	}
+
+	// Success.
+	return true
+}
+
+// conflictListsEquivalent determines whether or not two lists of conflicts
+// are equivalent, ignoring ordering. It assumes that conflict roots are unique
+// within each list, which is the case for lists generated by Reconcile.
+func conflictListsEquivalent(first, second []*Conflict) bool {
+	// Verify that the lists have the same length.
+	if len(first) != len(second) {
+		return false
+	}
+
+	// Index the first list by root.
+	rootToConflict := make(map[string]*Conflict, len(first))
+	for _, conflict := range first {
+		rootToConflict[conflict.Root] = conflict
+	}
+
+	// Verify that each conflict in the second list has an equivalent in the
+	// first list.
+	for _, conflict := range second {
+		if other, ok := rootToConflict[conflict.Root]; !ok {
+			return false
+		} else if !changeListsEquivalent(other.AlphaChanges, conflict.AlphaChanges) {
+			return false
+		} else if !changeListsEquivalent(other.BetaChanges, conflict.BetaChanges) {
+			return false
+		}
+	}
+
+	// Success.
+	return true
+}
+
+// Replay re-performs the recorded reconciliation operation and verifies that
+// its results are equivalent to the recorded results (modulo ordering, which
+// isn't deterministic). Any recorded conflict resolutions are returned by an
+// emulated conflict resolver. The recording must be valid.
+func (r *ReconciliationRecording) Replay() error {
+	// Create a conflict resolver to emulate recorded resolutions, if any.
+	var conflictResolver ConflictResolver
+	if len(r.ConflictResolutions) > 0 {
+		conflictResolver = replayingConflictResolver(r.ConflictResolutions)
+	}
+
+	// Perform reconciliation.
+	ancestorChanges, alphaChanges, betaChanges, conflicts := Reconcile(
+		r.Ancestor, r.Alpha, r.Beta,
+		r.SynchronizationMode,
+		r.TypeChangeMode,
+		time.Duration(r.AlphaClockOffset), time.Duration(r.BetaClockOffset),
+		conflictResolver,
+	)
+
+	// Compare the results.
+	if !changeListsEquivalent(ancestorChanges, r.AncestorChanges) {
+		return errors.New("ancestor changes differ from recording")
+	} else if !changeListsEquivalent(alphaChanges, r.AlphaChanges) {
+		return errors.New("alpha changes differ from recording")
+	} else if !changeListsEquivalent(betaChanges, r.BetaChanges) {
+		return errors.New("beta changes differ from recording")
+	} else if !conflictListsEquivalent(conflicts, r.Conflicts) {
+		return errors.New("conflicts differ from recording")
+	}
+
+	// Success.
+	return nil
+}
@@ -0,0 +1,152 @@
+package core
+
+import (
+	"testing"
+
+	"google.golang.org/protobuf/proto"
+)
+
+// TestReconciliationRecordingReplay tests that a reconciliation recording
+// survives serialization and replays to identical results, and that replay
+// detects divergence from recorded results.
+func TestReconciliationRecordingReplay(t *testing.T) {
+	// Create a recording of a reconciliation operation that yields ancestor
+	// changes, beta changes, and a conflict.
+	ancestor := &Entry{Contents: map[string]*Entry{
+		"conflicting": tF1,
+		"modified":    tF1,
+	}}
+	alpha := &Entry{Contents: map[string]*Entry{
+		"conflicting": tF2,
+		"modified":    tF2,
+		"created":     tD1,
+	}}
+	beta := &Entry{Contents: map[string]*Entry{
+		"conflicting": tF3,
+		"modified":    tF1,
+		"created":     tD1,
+	}}
+	recording := NewReconciliationRecording(
+		ancestor, alpha, beta,
+		SynchronizationMode_SynchronizationModeTwoWaySafe,
+		TypeChangeMode_TypeChangeModePropagate,
+		0, 0,
+		nil,
+	)
+	if len(recording.AncestorChanges) == 0 {
+		t.Fatal("recording has no ancestor changes")
+	} else if len(recording.BetaChanges) == 0 {
+		t.Fatal("recording has no beta changes")
+	} else if len(recording.Conflicts) == 0 {
+		t.Fatal("recording has no conflicts")
+	}
+
+	// Perform a serialization round trip.
+	encoded, err := proto.Marshal(recording)
+	if err != nil {
+		t.Fatal("unable to marshal recording:", err)
+	}
+	decoded := &ReconciliationRecording{}
+	if err := proto.Unmarshal(encoded, decoded); err != nil {
+		t.Fatal("unable to unmarshal recording:", err)
+	} else if err = decoded.EnsureValid(); err != nil {
+		t.Fatal("decoded recording invalid:", err)
+	}
+
+	// Verify that the decoded recording replays to identical results.
+	if err := decoded.Replay(); err != nil {
+		t.Error("replay failed:", err)
+	}
+
+	// Verify that divergent results are detected.
+	decoded.BetaChanges = decoded.BetaChanges[1:]
+	if decoded.Replay() == nil {
+		t.Error("replay succeeded despite divergent beta changes")
+	}
+	decoded.BetaChanges = recording.BetaChanges
+	decoded.Conflicts = nil
+	if decoded.Replay() == nil {
+		t.Error("replay succeeded despite divergent conflicts")
+	}
+}
+
+// TestReconciliationRecordingConflictResolver tests that the resolutions of a
+// conflict resolver are recorded and emulated on replay.
+func TestReconciliationRecordingConflictResolver(t *testing.T) {
+	// Create a recording of a reconciliation operation with a conflict that's
+	// resolved by a conflict resolver.
+	recording := NewReconciliationRecording(
+		nil,
+		nested("cache", nested("file", modified(tF1, 20))),
+		nested("cache", nested("file", modified(tF2, 10))),
+		SynchronizationMode_SynchronizationModeTwoWaySafe,
+		TypeChangeMode_TypeChangeModePropagate,
+		0, 0,
+		&testingNewerConflictResolver{"cache"},
+	)
+	if len(recording.Conflicts) != 0 {
+		t.Fatal("recording has unexpected conflicts")
+	} else if len(recording.BetaChanges) != 1 {
+		t.Fatal("recording has unexpected beta changes")
+	} else if len(recording.ConflictResolutions) != 1 {
+		t.Fatal("recording has unexpected conflict resolution count")
+	} else if ConflictResolution(recording.ConflictResolutions["cache/file"]) != ConflictResolutionAlpha {
+		t.Fatal("recording has unexpected conflict resolution")
+	}
+
+	// Perform a serialization round trip.
+	encoded, err := proto.Marshal(recording)
+	if err != nil {
+		t.Fatal("unable to marshal recording:", err)
+	}
+	decoded := &ReconciliationRecording{}
+	if err := proto.Unmarshal(encoded, decoded); err != nil {
+		t.Fatal("unable to unmarshal recording:", err)
+	} else if err = decoded.EnsureValid(); err != nil {
+		t.Fatal("decoded recording invalid:", err)
+	}
+
+	// Verify that the decoded recording replays to identical results.
+	if err := decoded.Replay(); err != nil {
+		t.Error("replay failed:", err)
+	}
+
+	// Verify that replay without the recorded resolutions diverges.
+	decoded.ConflictResolutions = nil
+	if decoded.Replay() == nil {
+		t.Error("replay succeeded without recorded conflict resolutions")
+	}
+
+	// Verify that invalid resolutions are rejected.
+	decoded.ConflictResolutions = map[string]uint32{"cache/file": uint32(ConflictResolutionNone)}
+	if decoded.EnsureValid() == nil {
+		t.Error("recording with invalid conflict resolution considered valid")
+	}
+}
+
+// TestReconciliationRecordingEnsureValid tests
+// ReconciliationRecording.EnsureValid.
+func TestReconciliationRecordingEnsureValid(t *testing.T) {
+	// Verify that a nil recording is invalid.
+	var recording *ReconciliationRecording
+	if recording.EnsureValid() == nil {
+		t.Error("nil recording considered valid")
+	}
+
+	// Verify that a recording with unspecified modes is invalid.
+	if (&ReconciliationRecording{}).EnsureValid() == nil {
+		t.Error("recording with unspecified modes considered valid")
+	}
+
+	// Verify that a recording of an empty reconciliation is valid.
+	recording = NewReconciliationRecording(
+		nil, nil, nil,
+		SynchronizationMode_SynchronizationModeOneWayReplica,
+		TypeChangeMode_TypeChangeModeConflict,
+		0, 0,
+		nil,
+	)
+	if err := recording.EnsureValid(); err != nil {
+		t.Error("recording of empty reconciliation considered invalid:", err)
+	}
+}
@@ -1,6 +1,7 @@
 package core
 
 import (
+	"bytes"
 	"context"
 	"errors"
 	"fmt"
@@ -11,6 +12,7 @@ import (
 	"runtime"
 	"strings"
 	"sync"
+	"time"
 	"unicode/utf8"
 
 	"golang.org/x/text/unicode/norm"
@@ -22,6 +24,7 @@ import (
 	"github.com/mutagen-io/mutagen/pkg/stream"
 	"github.com/mutagen-io/mutagen/pkg/synchronization/core/fastpath"
 	"github.com/mutagen-io/mutagen/pkg/synchronization/core/ignore"
+	"github.com/mutagen-io/mutagen/pkg/synchronization/hashing"
 )
 
 const (
@@ -50,6 +53,10 @@ const (
 // ErrScanCancelled indicates that the scan was cancelled.
 var ErrScanCancelled = errors.New("scan cancelled")
 
+// readDirectoryContents reads the contents of a directory. It is a variable so
+// that tests can simulate directory listing behavior.
+var readDirectoryContents = (*filesystem.Directory).ReadContents
+
 // behaviorCache is a cache mapping filesystem device IDs to behavioral
 // information. It is only used in cases where probe files are required for
 // probing behavior, because those cases are (a) more expensive and (b) cause
@@ -84,12 +91,15 @@ var behaviorCache struct {
 	preservesExecutability map[uint64]bool
 	// decomposesUnicode maps device IDs to Unicode decomposition behavior.
 	decomposesUnicode map[uint64]bool
+	// ignoresCase maps device IDs to case insensitivity behavior.
+	ignoresCase map[uint64]bool
 }
 
 func init() {
 	// Initialize the behavior cache.
 	behaviorCache.preservesExecutability = make(map[uint64]bool)
 	behaviorCache.decomposesUnicode = make(map[uint64]bool)
+	behaviorCache.ignoresCase = make(map[uint64]bool)
 }
 
 // scanner provides the recursive implementation of scanning.
@@ -103,16 +113,52 @@ type scanner struct {
 	dirtyPaths map[string]bool
 	// hasher is the hashing function to use for computing file digests.
 	hasher hash.Hash
+	// sampler is the sampling hasher to use for computing sampled file digests.
+	// It is nil if hasher isn't a sampling hasher.
+	sampler *hashing.SamplingHasher
+	// digestLength is the length to which digests recorded in snapshot entries
+	// are truncated. It is zero if hasher isn't a truncating hasher, in which
+	// case digests aren't truncated. Cached digests are never truncated.
+	digestLength uint32
 	// cache is the existing cache to use for fast digest lookups.
 	cache *Cache
+	// cacheTrustMode is the cache trust mode being used.
+	cacheTrustMode CacheTrustMode
 	// ignorer is the ignorer identifying ignored paths.
 	ignorer ignore.Ignorer
 	// ignoreCache is the cache of ignored path behavior.
 	ignoreCache ignore.IgnoreCache
 	// symbolicLinkMode is the symbolic link mode being used.
 	symbolicLinkMode SymbolicLinkMode
+	// specialFileMode is the special file mode being used.
+	specialFileMode SpecialFileMode
+	// specialFileMarkerDigest is the digest of SpecialFileMarkerContent. It is
+	// only computed if specialFileMode is SpecialFileModePlaceholder.
+	specialFileMarkerDigest []byte
+	// nameNormalizationMode is the name normalization mode being used.
+	nameNormalizationMode NameNormalizationMode
 	// permissionsMode is the permissions mode being used.
 	permissionsMode PermissionsMode
+	// aggregateDigests indicates whether or not directory aggregate digests
+	// should be computed.
+	aggregateDigests bool
+	// modificationTimes indicates whether or not file entries should include
+	// modification times.
+	modificationTimes bool
+	// directoryListingRetries is the maximum number of times that a directory
+	// listing will be re-read in an attempt to obtain a stable listing. If
+	// zero, then listings are not verified.
+	directoryListingRetries uint32
+	// maximumFileSize is the maximum size of files to include in the snapshot.
+	// Files exceeding this size are recorded as oversized content. If zero,
+	// then no limit is applied.
+	maximumFileSize uint64
+	// directoryListingObserver is the observer to invoke for slow directory
+	// listings. It may be nil, in which case listings are not timed.
+	directoryListingObserver DirectoryListingObserver
+	// directoryListingThreshold is the minimum directory listing duration for
+	// which directoryListingObserver will be invoked.
+	directoryListingThreshold time.Duration
 	// newCache is the new file digest cache to populate.
 	newCache *Cache
 	// newIgnoreCache is the new ignored path behavior cache to populate.
@@ -136,6 +182,52 @@ type scanner struct {
 	symbolicLinks uint64
 	// totalFileSize is the total size of all synchronizable files encountered.
 	totalFileSize uint64
+	// hashedBytes is the number of bytes of file content hashed.
+	hashedBytes uint64
+	// hashingDuration is the time spent hashing file content.
+	hashingDuration time.Duration
+}
+
+// timedWriter is an io.Writer that forwards writes to an underlying writer and
+// accumulates the time spent in those writes.
+type timedWriter struct {
+	// writer is the underlying writer.
+	writer io.Writer
+	// elapsed is the accumulated time spent writing.
+	elapsed *time.Duration
+}
+
+// Write implements io.Writer.Write.
+func (w *timedWriter) Write(data []byte) (int, error) {
+	start := time.Now()
+	n, err := w.writer.Write(data)
+	*w.elapsed += time.Since(start)
+	return n, err
+}
+
+// timedReadSeeker is an io.ReadSeeker that forwards operations to an underlying
+// io.ReadSeeker and accumulates the time spent in those operations.
+type timedReadSeeker struct {
+	// ReadSeeker is the underlying io.ReadSeeker.
+	io.ReadSeeker
+	// elapsed is the accumulated time spent reading and seeking.
+	elapsed time.Duration
+}
+
+// Read implements io.Reader.Read.
+func (r *timedReadSeeker) Read(buffer []byte) (int, error) {
+	start := time.Now()
+	n, err := r.ReadSeeker.Read(buffer)
+	r.elapsed += time.Since(start)
+	return n, err
+}
+
+// Seek implements io.Seeker.Seek.
+func (r *timedReadSeeker) Seek(offset int64, whence int) (int64, error) {
+	start := time.Now()
+	result, err := r.ReadSeeker.Seek(offset, whence)
+	r.elapsed += time.Since(start)
+	return result, err
 }
 
 // file performs processing of a file entry. Exactly one of parent or file will
@@ -151,6 +243,14 @@ func (s *scanner) file(
 	metadata *filesystem.Metadata,
 	file io.ReadSeekCloser,
 ) (*Entry, error) {
+	// If the file exceeds the maximum file size, then record it as oversized
+	// content. We don't compute a digest or record a cache entry in this case,
+	// which avoids reading the file entirely, and we don't include the file in
+	// the total file count or size.
+	if s.maximumFileSize > 0 && metadata.Size > s.maximumFileSize {
+		return &Entry{Kind: EntryKind_Oversized}, nil
+	}
+
 	// Compute executability.
 	var executable bool
 	if s.permissionsMode == PermissionsMode_PermissionsModePortable {
@@ -163,25 +263,34 @@ func (s *scanner) file(
 	// Check if we can reuse the cached digest (in order to avoid recomputation)
 	// and the cache entry itself (in order to avoid allocation). In order for
 	// the cached digest to be considered valid, we require that type,
-	// modification time, file size, and file ID haven't changed. We don't check
-	// for permission bit changes when assessing digest reusability since they
-	// don't affect content, but we do check for full mode equivalence when
-	// assessing cache entry reusability since permission changes need to be
-	// detected during transition operations (where the cache is also used).
-	cacheContentMatch := cacheHit &&
+	// modification time, and file size haven't changed, and, unless the cache
+	// trust mode indicates otherwise, that file ID hasn't changed. We don't
+	// check for permission bit changes when assessing digest reusability since
+	// they don't affect content, but we do check for full mode equivalence (and
+	// file ID equivalence) when assessing cache entry reusability since the
+	// cache is also used to detect modifications during transition operations.
+	cacheSizeMatch := cacheHit &&
 		(metadata.Mode&filesystem.ModeTypeMask) == (filesystem.Mode(cached.Mode)&filesystem.ModeTypeMask) &&
-		metadata.ModificationTime.Equal(cached.ModificationTime.AsTime()) &&
-		metadata.Size == cached.Size &&
-		metadata.FileID == cached.FileID
-	cacheEntryReusable := cacheContentMatch &&
+		metadata.Size == cached.Size
+	cacheMetadataMatch := cacheSizeMatch &&
+		metadata.ModificationTime.Equal(cached.ModificationTime.AsTime())
+	cacheFileIDMatch := cacheHit && metadata.FileID == cached.FileID
+	cacheFileIDTrusted := cacheFileIDMatch || s.cacheTrustMode == CacheTrustMode_CacheTrustModeIgnoreFileID
+	cacheContentMatch := cacheMetadataMatch && cacheFileIDTrusted
+	cacheEntryReusable := cacheMetadataMatch && cacheFileIDMatch &&
 		metadata.Mode == filesystem.Mode(cached.Mode)
 
+	// Determine whether or not the file is subject to digest sampling.
+	sampled := s.sampler != nil && metadata.Size > s.sampler.Threshold()
+
 	// Compute the digest, either by pulling it from the cache or computing it
-	// from the on-disk contents.
-	var digest []byte
+	// from the on-disk contents. If the file is subject to sampling, then also
+	// compute (or reuse) its sampled digest.
+	var digest, sampledDigest []byte
 	var err error
 	if cacheContentMatch {
 		digest = cached.Digest
+		sampledDigest = cached.SampledDigest
 	} else {
 		// If the file is not yet opened, then open it and defer its closure. We
 		// can also update the metadata at this point since we'll pay the cost
@@ -193,49 +302,110 @@ func (s *scanner) file(
 					return nil, err
 				}
 				return &Entry{
-					Kind:    EntryKind_Problematic,
-					Problem: fmt.Errorf("unable to open file: %w", err).Error(),
+					Kind:        EntryKind_Problematic,
+					Problem:     fmt.Errorf("unable to open file: %w", err).Error(),
+					ProblemCode: ProblemCodeForError(err),
 				}, nil
 			}
 			defer file.Close()
 		}
 
-		// Reset the hash state.
-		s.hasher.Reset()
-
-		// Copy data into the hash and verify that we copied the amount
-		// expected. We use a preemptable wrapper around the hasher to enable
-		// timely cancellation.
-		preemptableHasher := stream.NewPreemptableWriter(s.hasher, s.cancelled, scannerCopyPreemptionInterval)
-		if copied, err := io.CopyBuffer(preemptableHasher, file, s.copyBuffer); err != nil {
-			if err == stream.ErrWritePreempted {
-				return nil, ErrScanCancelled
+		// If the file is subject to sampling, then compute its sampled digest
+		// by reading only the sampled regions of the file. If the sampled
+		// digest matches that of a cached entry with the same type and size,
+		// then we assume that the file's content is unchanged and reuse the
+		// cached digest. Sampled digests are only used for this purpose (and
+		// never as content identity), since they don't cover full content. We
+		// only count time spent in the sampler (and not time spent reading the
+		// file) as hashing time, which we do by subtracting the time spent
+		// reading.
+		if sampled {
+			timedFile := &timedReadSeeker{ReadSeeker: file}
+			start := time.Now()
+			sampledDigest, err = s.sampler.Sample(timedFile, metadata.Size)
+			s.hashingDuration += time.Since(start) - timedFile.elapsed
+			s.hashedBytes += 2 * hashing.SampleSize
+			if err == nil {
+				err = hashing.Err(s.sampler)
+			}
+			if err != nil {
+				return &Entry{
+					Kind:        EntryKind_Problematic,
+					Problem:     fmt.Errorf("unable to sample file contents: %w", err).Error(),
+					ProblemCode: ProblemCodeForError(err),
+				}, nil
+			}
+			if cacheSizeMatch && cacheFileIDTrusted && len(cached.SampledDigest) > 0 &&
+				bytes.Equal(sampledDigest, cached.SampledDigest) {
+				digest = cached.Digest
+			} else if _, err := file.Seek(0, io.SeekStart); err != nil {
+				return &Entry{
+					Kind:        EntryKind_Problematic,
+					Problem:     fmt.Errorf("unable to seek to start of file: %w", err).Error(),
+					ProblemCode: ProblemCodeForError(err),
+				}, nil
 			}
-			return &Entry{
-				Kind:    EntryKind_Problematic,
-				Problem: fmt.Errorf("unable to hash file contents: %w", err).Error(),
-			}, nil
-		} else if uint64(copied) != metadata.Size {
-			return &Entry{
-				Kind:    EntryKind_Problematic,
-				Problem: fmt.Sprintf("hashed size mismatch: %d != %d", copied, metadata.Size),
-			}, nil
 		}
 
-		// Compute the digest.
-		digest = s.hasher.Sum(nil)
+		// If we weren't able to reuse a cached digest, then compute the digest
+		// from the file's full contents.
+		if digest == nil {
+			// Reset the hash state.
+			s.hasher.Reset()
+
+			// Copy data into the hash and verify that we copied the amount
+			// expected. We use a preemptable wrapper around the hasher to
+			// enable timely cancellation.
+			timedHasher := &timedWriter{s.hasher, &s.hashingDuration}
+			preemptableHasher := stream.NewPreemptableWriter(timedHasher, s.cancelled, scannerCopyPreemptionInterval)
+			copied, err := io.CopyBuffer(preemptableHasher, file, s.copyBuffer)
+			s.hashedBytes += uint64(copied)
+			if err != nil {
+				if err == stream.ErrWritePreempted {
+					return nil, ErrScanCancelled
+				}
+				return &Entry{
+					Kind:        EntryKind_Problematic,
+					Problem:     fmt.Errorf("unable to hash file contents: %w", err).Error(),
+					ProblemCode: ProblemCodeForError(err),
+				}, nil
+			} else if uint64(copied) != metadata.Size {
+				return &Entry{
+					Kind:        EntryKind_Problematic,
+					Problem:     fmt.Sprintf("hashed size mismatch: %d != %d", copied, metadata.Size),
+					ProblemCode: ProblemCode_ProblemCodeModified,
+				}, nil
+			}
+
+			// Compute the digest.
+			start := time.Now()
+			digest = s.hasher.Sum(nil)
+			s.hashingDuration += time.Since(start)
+
+			// Verify that the hasher didn't fail while computing the digest.
+			if err := hashing.Err(s.hasher); err != nil {
+				return &Entry{
+					Kind:        EntryKind_Problematic,
+					Problem:     fmt.Errorf("unable to hash file contents: %w", err).Error(),
+					ProblemCode: ProblemCodeForError(err),
+				}, nil
+			}
+		}
 	}
 
 	// Add an entry to the new cache.
+	var modificationTime *timestamppb.Timestamp
 	if cacheEntryReusable {
 		s.newCache.Entries[path] = cached
+		modificationTime = cached.ModificationTime
 	} else {
 		// Convert the new modification time to Protocol Buffers format.
-		modificationTime := timestamppb.New(metadata.ModificationTime)
+		modificationTime = timestamppb.New(metadata.ModificationTime)
 		if err := modificationTime.CheckValid(); err != nil {
 			return &Entry{
-				Kind:    EntryKind_Problematic,
-				Problem: fmt.Errorf("unable to convert file modification time: %w", err).Error(),
+				Kind:        EntryKind_Problematic,
+				Problem:     fmt.Errorf("unable to convert file modification time: %w", err).Error(),
+				ProblemCode: ProblemCodeForError(err),
 			}, nil
 		}
 
@@ -246,19 +416,38 @@ func (s *scanner) file(
 			Size:             metadata.Size,
 			FileID:           metadata.FileID,
 			Digest:           digest,
+			SampledDigest:    sampledDigest,
 		}
 	}
 
+	// If we're recording special files as placeholders and this (non-root)
+	// file is a special file marker, then record it as a special file. We
+	// still record the cache entry for the marker above since it's required
+	// to validate the marker during removal. The size check lets us avoid a
+	// digest comparison for the vast majority of files.
+	isSpecialFileMarker := s.specialFileMode == SpecialFileMode_SpecialFileModePlaceholder &&
+		path != "" && metadata.Size == uint64(len(SpecialFileMarkerContent)) &&
+		bytes.Equal(digest, s.specialFileMarkerDigest)
+	if isSpecialFileMarker {
+		return &Entry{Kind: EntryKind_SpecialFile}, nil
+	}
+
 	// Increment the total file count and size.
 	s.files++
 	s.totalFileSize += metadata.Size
 
-	// Success.
-	return &Entry{
+	// Create the entry, including the modification time if requested.
+	entry := &Entry{
 		Kind:       EntryKind_File,
 		Executable: executable,
-		Digest:     digest,
-	}, nil
+		Digest:     truncateDigest(digest, s.digestLength),
+	}
+	if s.modificationTimes {
+		entry.ModificationTime = modificationTime
+	}
+
+	// Success.
+	return entry, nil
 }
 
 // symbolicLink performs processing of a symbolic link entry.
@@ -275,8 +464,9 @@ func (s *scanner) symbolicLink(
 			return nil, err
 		}
 		return &Entry{
-			Kind:    EntryKind_Problematic,
-			Problem: fmt.Errorf("unable to read symbolic link target: %w", err).Error(),
+			Kind:        EntryKind_Problematic,
+			Problem:     fmt.Errorf("unable to read symbolic link target: %w", err).Error(),
+			ProblemCode: ProblemCodeForError(err),
 		}, nil
 	}
 
@@ -286,8 +476,9 @@ func (s *scanner) symbolicLink(
 		target, err = normalizeSymbolicLinkAndEnsurePortable(path, target)
 		if err != nil {
 			return &Entry{
-				Kind:    EntryKind_Problematic,
-				Problem: fmt.Errorf("invalid symbolic link: %w", err).Error(),
+				Kind:        EntryKind_Problematic,
+				Problem:     fmt.Errorf("invalid symbolic link: %w", err).Error(),
+				ProblemCode: ProblemCodeForError(err),
 			}, nil
 		}
 	} else if target == "" {
@@ -344,8 +535,9 @@ func (s *scanner) directory(
 				return nil, err
 			}
 			return &Entry{
-				Kind:    EntryKind_Problematic,
-				Problem: fmt.Errorf("unable to open directory: %w", err).Error(),
+				Kind:        EntryKind_Problematic,
+				Problem:     fmt.Errorf("unable to open directory: %w", err).Error(),
+				ProblemCode: ProblemCodeForError(err),
 			}, nil
 		} else {
 			directory = d
@@ -353,12 +545,23 @@ func (s *scanner) directory(
 		}
 	}
 
-	// Read directory contents.
-	directoryContents, err := directory.ReadContents()
+	// Read directory contents, timing the operation if an observer has been
+	// provided.
+	var listingStart time.Time
+	if s.directoryListingObserver != nil {
+		listingStart = time.Now()
+	}
+	directoryContents, err := s.readDirectoryContents(directory)
+	if s.directoryListingObserver != nil {
+		if elapsed := time.Since(listingStart); elapsed >= s.directoryListingThreshold {
+			s.directoryListingObserver(path, elapsed)
+		}
+	}
 	if err != nil {
 		return &Entry{
-			Kind:    EntryKind_Problematic,
-			Problem: fmt.Errorf("unable to read directory contents: %w", err).Error(),
+			Kind:        EntryKind_Problematic,
+			Problem:     fmt.Errorf("unable to read directory contents: %w", err).Error(),
+			ProblemCode: ProblemCodeForError(err),
 		}, nil
 	}
 
@@ -422,8 +625,9 @@ func (s *scanner) directory(
 				contents[escapedContentName] = &Entry{Kind: EntryKind_Untracked}
 			} else {
 				contents[escapedContentName] = &Entry{
-					Kind:    EntryKind_Problematic,
-					Problem: "non-UTF-8 filename",
+					Kind:        EntryKind_Problematic,
+					Problem:     "non-UTF-8 filename",
+					ProblemCode: ProblemCode_ProblemCodeInvalidName,
 				}
 			}
 			continue
@@ -434,6 +638,27 @@ func (s *scanner) directory(
 			contentName = norm.NFC.String(contentName)
 		}
 
+		// If content names are required to be in canonical form, then flag
+		// content with non-canonical names as either untracked or problematic,
+		// depending on the ignore mask (for the same reasons as non-UTF-8
+		// names). We can't record such content under a normalized name because
+		// that name wouldn't correspond to anything on disk, and propagating
+		// the original name would allow visually identical names that compare
+		// differently to appear on the other endpoint.
+		if s.nameNormalizationMode == NameNormalizationMode_NameNormalizationModeRequireNFC &&
+			!norm.NFC.IsNormalString(contentName) {
+			if ignoreMask {
+				contents[contentName] = &Entry{Kind: EntryKind_Untracked}
+			} else {
+				contents[contentName] = &Entry{
+					Kind:        EntryKind_Problematic,
+					Problem:     "filename not in Unicode Normalization Form C",
+					ProblemCode: ProblemCode_ProblemCodeInvalidName,
+				}
+			}
+			continue
+		}
+
 		// Compute the content path.
 		contentPath := contentPathPrefix + contentName
 
@@ -447,6 +672,12 @@ func (s *scanner) directory(
 			contentKind = EntryKind_File
 		case filesystem.ModeTypeSymbolicLink:
 			contentKind = EntryKind_SymbolicLink
+		case filesystem.ModeTypeNamedPipe, filesystem.ModeTypeSocket:
+			if s.specialFileMode != SpecialFileMode_SpecialFileModePlaceholder {
+				contents[contentName] = &Entry{Kind: EntryKind_Untracked}
+				continue
+			}
+			contentKind = EntryKind_SpecialFile
 		default:
 			contents[contentName] = &Entry{Kind: EntryKind_Untracked}
 			continue
@@ -566,6 +797,13 @@ func (s *scanner) directory(
 						} else {
 							missingCacheEntries = true
 						}
+					} else if entry.Kind == EntryKind_SpecialFile {
+						// Special file markers will have cache entries, but
+						// actual special files won't, so we can only perform
+						// opportunistic propagation.
+						if oldCacheEntry, ok := s.cache.Entries[path]; ok {
+							s.newCache.Entries[path] = oldCacheEntry
+						}
 					}
 				}, false)
 				if missingCacheEntries {
@@ -593,6 +831,8 @@ func (s *scanner) directory(
 			} else {
 				panic("unsupported symbolic link mode")
 			}
+		} else if contentKind == EntryKind_SpecialFile {
+			entry = &Entry{Kind: EntryKind_SpecialFile}
 		} else if contentKind == EntryKind_Directory {
 			entry, err = s.directory(
 				contentPath,
@@ -637,26 +877,130 @@ func (s *scanner) directory(
 	// will be updated during reification, if necessary.
 	s.directories++
 
+	// Compute the aggregate digest, if requested.
+	var aggregate []byte
+	if s.aggregateDigests {
+		aggregate = truncateDigest(aggregateDigest(s.hasher, contents), s.digestLength)
+	}
+
 	// Success.
 	return &Entry{
-		Kind:     directoryKind,
-		Contents: contents,
+		Kind:            directoryKind,
+		Contents:        contents,
+		AggregateDigest: aggregate,
 	}, nil
 }
 
+// listingsEqual determines whether or not two directory listings contain the
+// same content names with the same content types.
+func listingsEqual(first, second []*filesystem.Metadata) bool {
+	// Check that the listings have the same length.
+	if len(first) != len(second) {
+		return false
+	}
+
+	// Index the first listing.
+	types := make(map[string]filesystem.Mode, len(first))
+	for _, m := range first {
+		types[m.Name] = m.Mode & filesystem.ModeTypeMask
+	}
+
+	// Compare the second listing against the index.
+	for _, m := range second {
+		if t, ok := types[m.Name]; !ok || t != m.Mode&filesystem.ModeTypeMask {
+			return false
+		}
+	}
+
+	// The listings match.
+	return true
+}
+
+// DirectoryListingObserver is a callback that can be provided to Scan to observe
+// slow directory listings, for example to diagnose filesystems with expensive
+// metadata operations. It receives the path of the directory (relative to the
+// synchronization root) and the time taken to read its listing (including any
+// verification reads). It's invoked synchronously on the scanning goroutine, so
+// it should return quickly and must not block on operations that might be
+// waiting for the scan to complete.
+type DirectoryListingObserver func(path string, duration time.Duration)
+
+// readDirectoryContents reads the contents of a directory. If directory listing
+// verification is enabled, then the listing is re-read until two consecutive
+// listings agree (in terms of content names and types) or until the maximum
+// number of retries has been reached, in which case the most recent listing is
+// used and any inconsistency is treated as a genuine concurrent modification.
+// This accommodates filesystems (typically network filesystems) that can
+// momentarily provide inconsistent directory listings.
+func (s *scanner) readDirectoryContents(directory *filesystem.Directory) ([]*filesystem.Metadata, error) {
+	// Perform the initial read. If verification is disabled, then we're done.
+	contents, err := readDirectoryContents(directory)
+	if err != nil || s.directoryListingRetries == 0 {
+		return contents, err
+	}
+
+	// Perform verification reads until the listing stabilizes.
+	for r := uint32(0); r < s.directoryListingRetries; r++ {
+		verification, err := readDirectoryContents(directory)
+		if err != nil {
+			return nil, err
+		} else if listingsEqual(contents, verification) {
+			return verification, nil
+		}
+		contents = verification
+	}
+
+	// The listing didn't stabilize, so use the most recent listing.
+	return contents, nil
+}
+
 // Scan creates a new filesystem snapshot at the specified root. The only
 // required arguments are ctx, root, hasher, ignores, probeMode,
-// symbolicLinkMode, and permissionsMode. The baseline, recheckPaths, cache, and
-// ignoreCache fields merely provide acceleration options.
+// symbolicLinkMode, specialFileMode, nameNormalizationMode, and
+// permissionsMode. The baseline, recheckPaths, cache, and ignoreCache fields
+// merely provide acceleration options. The cacheTrustMode argument controls the
+// file metadata that must match a cache entry for its digest to be reused (see
+// CacheTrustMode), with the default value treated as
+// CacheTrustMode_CacheTrustModeStrict. If aggregateDigests is true, then
+// directory entries will include aggregate digests (computed using hasher),
+// though directories reused from a baseline will only carry aggregate digests
+// if the baseline did. Similarly, if modificationTimes is true, then file
+// entries will include modification times, though files reused from a baseline
+// will only carry modification times if the baseline did. If
+// directoryListingRetries is non-zero, then each directory listing is verified
+// by re-reading it (up to the specified number of times) until two consecutive
+// listings agree, which is useful on filesystems with unstable directory
+// listings. This verification is not supported on Windows, where it is ignored.
+// If maximumFileSize is non-zero, then files larger than maximumFileSize bytes
+// are recorded as oversized content (see EntryKind_Oversized) without having
+// their contents read. The probeOptions argument may be nil, in which case
+// probing is determined solely by probeMode. If hasher is a
+// hashing.SamplingHasher, then sampled digests are recorded in the cache for
+// files exceeding its threshold and are used to detect content changes for
+// files whose metadata has changed, with full digests only being recomputed if
+// the sampled digest has changed. Sampled digests are never used as snapshot
+// digests. If hasher is (or wraps) a hashing.TruncatingHasher, then digests
+// recorded in snapshot entries are truncated, but digests recorded in the cache
+// are not. If directoryListingObserver is non-nil, then it will be invoked for
+// each directory whose listing takes at least directoryListingThreshold to
+// read.
 func Scan(
 	ctx context.Context,
 	root string,
 	baseline *Snapshot, recheckPaths map[string]bool,
-	hasher hash.Hash, cache *Cache,
+	hasher hash.Hash, cache *Cache, cacheTrustMode CacheTrustMode,
 	ignorer ignore.Ignorer, ignoreCache ignore.IgnoreCache,
-	probeMode behavior.ProbeMode,
+	probeMode behavior.ProbeMode, probeOptions *behavior.ProbeOptions,
 	symbolicLinkMode SymbolicLinkMode,
+	specialFileMode SpecialFileMode,
+	nameNormalizationMode NameNormalizationMode,
 	permissionsMode PermissionsMode,
+	aggregateDigests bool,
+	modificationTimes bool,
+	directoryListingRetries uint32,
+	maximumFileSize uint64,
+	directoryListingObserver DirectoryListingObserver,
+	directoryListingThreshold time.Duration,
 ) (*Snapshot, *Cache, ignore.IgnoreCache, error) {
 	// Verify that the symbolic link mode is valid for this platform.
 	if symbolicLinkMode == SymbolicLinkMode_SymbolicLinkModePOSIXRaw && runtime.GOOS == "windows" {
@@ -698,36 +1042,128 @@ func Scan(
 		panic("invalid filesystem type returned from root open operation")
 	}
 
+	// Extract any explicitly stated behavior assumptions. These take precedence
+	// over both cached and probed behavior information.
+	var assumedPreserves, assumedPreservesOk bool
+	var assumedDecomposes, assumedDecomposesOk bool
+	var assumedIgnoresCase, assumedIgnoresCaseOk bool
+	if probeOptions != nil {
+		assumedPreserves, assumedPreservesOk = probeOptions.ExecutabilityPreservation.Assumed()
+		assumedDecomposes, assumedDecomposesOk = probeOptions.UnicodeDecomposition.Assumed()
+		assumedIgnoresCase, assumedIgnoresCaseOk = probeOptions.CaseInsensitivity.Assumed()
+	}
+
 	// Check if there is cached behavior information.
 	behaviorCache.RLock()
 	cachedPreserves, cachedPreservesOk := behaviorCache.preservesExecutability[metadata.DeviceID]
 	cachedDecomposes, cachedDecomposesOk := behaviorCache.decomposesUnicode[metadata.DeviceID]
+	cachedIgnoresCase, cachedIgnoresCaseOk := behaviorCache.ignoresCase[metadata.DeviceID]
 	behaviorCache.RUnlock()
 
-	// Track whether or not we use probe files when determining behavior.
-	var usedProbeFiles bool
+	// If an alternate probe directory has been specified and we may need to
+	// probe, then open it and verify that it resides on the same filesystem as
+	// the synchronization root, because any probe results would otherwise be
+	// meaningless for the root.
+	var probeDirectory *filesystem.Directory
+	probeRequired := probeMode == behavior.ProbeMode_ProbeModeProbe &&
+		!((assumedPreservesOk || cachedPreservesOk) && (assumedDecomposesOk || cachedDecomposesOk) && (assumedIgnoresCaseOk || cachedIgnoresCaseOk))
+	if probeRequired && probeOptions != nil && probeOptions.Directory != "" {
+		directory, directoryMetadata, err := filesystem.OpenDirectory(probeOptions.Directory, false)
+		if err != nil {
+			return nil, nil, nil, fmt.Errorf("unable to open probe directory: %w", err)
+		}
+		defer directory.Close()
+		if directoryMetadata.DeviceID != metadata.DeviceID {
+			return nil, nil, nil, errors.New("probe directory does not reside on the same filesystem as synchronization root")
+		}
+		probeDirectory = directory
+	}
+
+	// Track whether or not we use probe files when determining each behavior.
+	var preservesUsedProbeFiles, decomposesUsedProbeFiles, ignoresCaseUsedProbeFiles bool
 
 	// Probe the behavior of the synchronization root.
-	var preservesExecutability, decomposesUnicode bool
+	var preservesExecutability, decomposesUnicode, ignoresCase bool
 	if rootKind == EntryKind_Directory {
+		// If no alternate probe directory has been specified, then probe within
+		// the root itself.
+		if probeDirectory == nil {
+			probeDirectory = directoryRoot
+		}
+
 		// Check executability preservation behavior.
-		if cachedPreservesOk {
+		if assumedPreservesOk {
+			preservesExecutability = assumedPreserves
+		} else if cachedPreservesOk {
 			preservesExecutability = cachedPreserves
-		} else if preserves, usedFiles, err := behavior.PreservesExecutability(directoryRoot, probeMode); err != nil {
+		} else if preserves, usedFiles, err := behavior.PreservesExecutability(probeDirectory, probeMode); err != nil {
 			return nil, nil, nil, fmt.Errorf("unable to probe root executability preservation behavior: %w", err)
 		} else {
 			preservesExecutability = preserves
-			usedProbeFiles = usedProbeFiles || usedFiles
+			preservesUsedProbeFiles = usedFiles
 		}
 
 		// Check Unicode decomposition behavior.
-		if cachedDecomposesOk {
+		if assumedDecomposesOk {
+			decomposesUnicode = assumedDecomposes
+		} else if cachedDecomposesOk {
 			decomposesUnicode = cachedDecomposes
-		} else if decomposes, usedFiles, err := behavior.DecomposesUnicode(directoryRoot, probeMode); err != nil {
+		} else if decomposes, usedFiles, err := behavior.DecomposesUnicode(probeDirectory, probeMode); err != nil {
 			return nil, nil, nil, fmt.Errorf("unable to probe root Unicode decomposition behavior: %w", err)
 		} else {
 			decomposesUnicode = decomposes
-			usedProbeFiles = usedProbeFiles || usedFiles
+			decomposesUsedProbeFiles = usedFiles
+		}
+
+		// Check case insensitivity behavior.
+		if assumedIgnoresCaseOk {
+			ignoresCase = assumedIgnoresCase
+		} else if cachedIgnoresCaseOk {
+			ignoresCase = cachedIgnoresCase
+		} else if ignores, usedFiles, err := behavior.IgnoresCase(probeDirectory, probeMode); err != nil {
+			return nil, nil, nil, fmt.Errorf("unable to probe root case insensitivity behavior: %w", err)
+		} else {
+			ignoresCase = ignores
+			ignoresCaseUsedProbeFiles = usedFiles
+		}
+	} else if rootKind == EntryKind_File && probeDirectory != nil {
+		// For file roots with an alternate probe directory, we can probe using
+		// the probe directory directly.
+
+		// Check executability preservation behavior.
+		if assumedPreservesOk {
+			preservesExecutability = assumedPreserves
+		} else if cachedPreservesOk {
+			preservesExecutability = cachedPreserves
+		} else if preserves, usedFiles, err := behavior.PreservesExecutability(probeDirectory, probeMode); err != nil {
+			return nil, nil, nil, fmt.Errorf("unable to probe executability preservation behavior: %w", err)
+		} else {
+			preservesExecutability = preserves
+			preservesUsedProbeFiles = usedFiles
+		}
+
+		// Check Unicode decomposition behavior.
+		if assumedDecomposesOk {
+			decomposesUnicode = assumedDecomposes
+		} else if cachedDecomposesOk {
+			decomposesUnicode = cachedDecomposes
+		} else if decomposes, usedFiles, err := behavior.DecomposesUnicode(probeDirectory, probeMode); err != nil {
+			return nil, nil, nil, fmt.Errorf("unable to probe Unicode decomposition behavior: %w", err)
+		} else {
+			decomposesUnicode = decomposes
+			decomposesUsedProbeFiles = usedFiles
+		}
+
+		// Check case insensitivity behavior.
+		if assumedIgnoresCaseOk {
+			ignoresCase = assumedIgnoresCase
+		} else if cachedIgnoresCaseOk {
+			ignoresCase = cachedIgnoresCase
+		} else if ignores, usedFiles, err := behavior.IgnoresCase(probeDirectory, probeMode); err != nil {
+			return nil, nil, nil, fmt.Errorf("unable to probe case insensitivity behavior: %w", err)
+		} else {
+			ignoresCase = ignores
+			ignoresCaseUsedProbeFiles = usedFiles
 		}
 	} else if rootKind == EntryKind_File {
 		// For file roots, we use the behavioral information of their parent
@@ -757,23 +1193,39 @@ func Scan(
 		parent := filepath.Dir(root)
 
 		// Check executability preservation behavior for the parent directory.
-		if cachedPreservesOk {
+		if assumedPreservesOk {
+			preservesExecutability = assumedPreserves
+		} else if cachedPreservesOk {
 			preservesExecutability = cachedPreserves
 		} else if preserves, usedFiles, err := behavior.PreservesExecutabilityByPath(parent, probeMode); err != nil {
 			return nil, nil, nil, fmt.Errorf("unable to probe parent executability preservation behavior: %w", err)
 		} else {
 			preservesExecutability = preserves
-			usedProbeFiles = usedProbeFiles || usedFiles
+			preservesUsedProbeFiles = usedFiles
 		}
 
 		// Check Unicode decomposition behavior for the parent directory.
-		if cachedDecomposesOk {
+		if assumedDecomposesOk {
+			decomposesUnicode = assumedDecomposes
+		} else if cachedDecomposesOk {
 			decomposesUnicode = cachedDecomposes
 		} else if decomposes, usedFiles, err := behavior.DecomposesUnicodeByPath(parent, probeMode); err != nil {
 			return nil, nil, nil, fmt.Errorf("unable to probe parent Unicode decomposition behavior: %w", err)
 		} else {
 			decomposesUnicode = decomposes
-			usedProbeFiles = usedProbeFiles || usedFiles
+			decomposesUsedProbeFiles = usedFiles
+		}
+
+		// Check case insensitivity behavior for the parent directory.
+		if assumedIgnoresCaseOk {
+			ignoresCase = assumedIgnoresCase
+		} else if cachedIgnoresCaseOk {
+			ignoresCase = cachedIgnoresCase
+		} else if ignores, usedFiles, err := behavior.IgnoresCaseByPath(parent, probeMode); err != nil {
+			return nil, nil, nil, fmt.Errorf("unable to probe parent case insensitivity behavior: %w", err)
+		} else {
+			ignoresCase = ignores
+			ignoresCaseUsedProbeFiles = usedFiles
 		}
 	} else {
 		panic("unhandled root kind")
@@ -782,10 +1234,20 @@ func Scan(
 	// If we used probe files, then update the behavior cache, because probing
 	// was relatively expensive. Probe files are never used on Windows, so we're
 	// safe to use the device ID (which is always 0 on Windows) as a cache key.
-	if usedProbeFiles {
+	// We only cache behavior that was actually probed, because explicitly
+	// stated assumptions are specific to this scan and shouldn't affect other
+	// sessions sharing the same filesystem.
+	if preservesUsedProbeFiles || decomposesUsedProbeFiles || ignoresCaseUsedProbeFiles {
 		behaviorCache.Lock()
-		behaviorCache.preservesExecutability[metadata.DeviceID] = preservesExecutability
-		behaviorCache.decomposesUnicode[metadata.DeviceID] = decomposesUnicode
+		if preservesUsedProbeFiles {
+			behaviorCache.preservesExecutability[metadata.DeviceID] = preservesExecutability
+		}
+		if decomposesUsedProbeFiles {
+			behaviorCache.decomposesUnicode[metadata.DeviceID] = decomposesUnicode
+		}
+		if ignoresCaseUsedProbeFiles {
+			behaviorCache.ignoresCase[metadata.DeviceID] = ignoresCase
+		}
 		behaviorCache.Unlock()
 	}
 
@@ -795,7 +1257,8 @@ func Scan(
 		baselineInvalid := baseline.Content == nil ||
 			baseline.Content.Kind != rootKind ||
 			baseline.PreservesExecutability != preservesExecutability ||
-			baseline.DecomposesUnicode != decomposesUnicode
+			baseline.DecomposesUnicode != decomposesUnicode ||
+			baseline.IgnoresCase != ignoresCase
 		if baselineInvalid {
 			baseline = nil
 		}
@@ -853,23 +1316,69 @@ func Scan(
 	}
 	newIgnoreCache := make(ignore.IgnoreCache, initialIgnoreCacheCapacity)
 
+	// If the hasher is a sampling hasher, then only use it for computing
+	// sampled digests and use its underlying hasher for everything else (e.g.
+	// full file digests and aggregate digests).
+	sampler, _ := hasher.(*hashing.SamplingHasher)
+	if sampler != nil {
+		hasher = sampler.Unwrap()
+	}
+
+	// If the hasher is a truncating hasher, then use its underlying hasher to
+	// compute full digests and only truncate the digests recorded in snapshot
+	// entries. This ensures that cached digests (which are used for rename and
+	// copy detection) always identify content.
+	var digestLength uint32
+	if truncator, ok := hasher.(*hashing.TruncatingHasher); ok {
+		digestLength = uint32(truncator.Size())
+		hasher = truncator.Unwrap()
+	}
+
+	// If special files are being recorded as placeholders, then compute the
+	// digest of the special file marker content so that markers can be
+	// identified.
+	var specialFileMarkerDigest []byte
+	if specialFileMode == SpecialFileMode_SpecialFileModePlaceholder {
+		hasher.Reset()
+		hasher.Write(SpecialFileMarkerContent)
+		specialFileMarkerDigest = hasher.Sum(nil)
+	}
+
+	// Directory listing verification relies on being able to rewind directory
+	// handles, which isn't supported on Windows, so disable it there.
+	if runtime.GOOS == "windows" {
+		directoryListingRetries = 0
+	}
+
 	// Create a scanner.
 	s := &scanner{
-		cancelled:              ctx.Done(),
-		root:                   root,
-		dirtyPaths:             dirtyPaths,
-		hasher:                 hasher,
-		cache:                  cache,
-		ignorer:                ignorer,
-		ignoreCache:            ignoreCache,
-		symbolicLinkMode:       symbolicLinkMode,
-		permissionsMode:        permissionsMode,
-		newCache:               newCache,
-		newIgnoreCache:         newIgnoreCache,
-		copyBuffer:             make([]byte, scannerCopyBufferSize),
-		deviceID:               metadata.DeviceID,
-		recomposeUnicode:       decomposesUnicode,
-		preservesExecutability: preservesExecutability,
+		cancelled:                 ctx.Done(),
+		root:                      root,
+		dirtyPaths:                dirtyPaths,
+		hasher:                    hasher,
+		sampler:                   sampler,
+		digestLength:              digestLength,
+		cache:                     cache,
+		cacheTrustMode:            cacheTrustMode,
+		ignorer:                   ignorer,
+		ignoreCache:               ignoreCache,
+		symbolicLinkMode:          symbolicLinkMode,
+		specialFileMode:           specialFileMode,
+		specialFileMarkerDigest:   specialFileMarkerDigest,
+		nameNormalizationMode:     nameNormalizationMode,
+		permissionsMode:           permissionsMode,
+		aggregateDigests:          aggregateDigests,
+		modificationTimes:         modificationTimes,
+		directoryListingRetries:   directoryListingRetries,
+		maximumFileSize:           maximumFileSize,
+		directoryListingObserver:  directoryListingObserver,
+		directoryListingThreshold: directoryListingThreshold,
+		newCache:                  newCache,
+		newIgnoreCache:            newIgnoreCache,
+		copyBuffer:                make([]byte, scannerCopyBufferSize),
+		deviceID:                  metadata.DeviceID,
+		recomposeUnicode:          decomposesUnicode,
+		preservesExecutability:    preservesExecutability,
 	}
 
 	// Handle the scan based on the root type.
@@ -894,9 +1403,12 @@ func Scan(
 		Content:                content,
 		PreservesExecutability: preservesExecutability,
 		DecomposesUnicode:      decomposesUnicode,
+		IgnoresCase:            ignoresCase,
 		Directories:            s.directories,
 		Files:                  s.files,
 		SymbolicLinks:          s.symbolicLinks,
 		TotalFileSize:          s.totalFileSize,
+		HashedBytes:            s.hashedBytes,
+		HashingDuration:        uint64(s.hashingDuration),
 	}, newCache, newIgnoreCache, nil
 }
@@ -0,0 +1,166 @@
+package core
+
+import (
+	"context"
+	"crypto/sha1"
+	"fmt"
+	"hash"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/mutagen-io/mutagen/pkg/filesystem/behavior"
+	"github.com/mutagen-io/mutagen/pkg/synchronization/core/ignore"
+	mutagenignore "github.com/mutagen-io/mutagen/pkg/synchronization/core/ignore/mutagen"
+	"github.com/mutagen-io/mutagen/pkg/synchronization/hashing"
+)
+
+const (
+	// benchmarkScanDirectories is the number of directories in the tree used
+	// for scan benchmarks.
+	benchmarkScanDirectories = 100
+	// benchmarkScanFilesPerDirectory is the number of files in each directory
+	// of the tree used for scan benchmarks.
+	benchmarkScanFilesPerDirectory = 1000
+	// benchmarkScanFileSize is the size of each file in the tree used for scan
+	// benchmarks.
+	benchmarkScanFileSize = 4096
+)
+
+// createBenchmarkScanTree creates a tree of benchmarkScanDirectories
+// directories, each containing benchmarkScanFilesPerDirectory files of
+// benchmarkScanFileSize bytes, within the specified root.
+func createBenchmarkScanTree(b *testing.B, root string) {
+	content := make([]byte, benchmarkScanFileSize)
+	for d := 0; d < benchmarkScanDirectories; d++ {
+		directory := filepath.Join(root, fmt.Sprintf("directory%d", d))
+		if err := os.Mkdir(directory, 0700); err != nil {
+			b.Fatal("unable to create directory:", err)
+		}
+		for f := 0; f < benchmarkScanFilesPerDirectory; f++ {
+			content[0], content[1], content[2] = byte(d), byte(f), byte(f>>8)
+			path := filepath.Join(directory, fmt.Sprintf("file%d", f))
+			if err := os.WriteFile(path, content, 0600); err != nil {
+				b.Fatal("unable to create file:", err)
+			}
+		}
+	}
+}
+
+// benchmarkScan performs repeated cold scans of the specified root using the
+// specified hasher. Cold scans are used so that every file is hashed.
+func benchmarkScan(b *testing.B, root string, hasher hash.Hash, ignorer ignore.Ignorer) {
+	for i := 0; i < b.N; i++ {
+		if _, _, _, err := Scan(
+			context.Background(),
+			root,
+			nil, nil,
+			hasher, nil, CacheTrustMode_CacheTrustModeStrict,
+			ignorer, nil,
+			behavior.ProbeMode_ProbeModeProbe, nil,
+			SymbolicLinkMode_SymbolicLinkModePortable,
+			SpecialFileMode_SpecialFileModeIgnore,
+			NameNormalizationMode_NameNormalizationModePreserve,
+			PermissionsMode_PermissionsModePortable,
+			false,
+			false,
+			0,
+			0,
+			nil, 0,
+		); err != nil {
+			b.Fatal("unable to perform scan:", err)
+		}
+	}
+}
+
+// benchmarkScanSnapshot performs a single cold scan of the specified root,
+// optionally computing aggregate digests.
+func benchmarkScanSnapshot(b *testing.B, root string, ignorer ignore.Ignorer, aggregateDigests bool) *Snapshot {
+	snapshot, _, _, err := Scan(
+		context.Background(),
+		root,
+		nil, nil,
+		sha1.New(), nil, CacheTrustMode_CacheTrustModeStrict,
+		ignorer, nil,
+		behavior.ProbeMode_ProbeModeProbe, nil,
+		SymbolicLinkMode_SymbolicLinkModePortable,
+		SpecialFileMode_SpecialFileModeIgnore,
+		NameNormalizationMode_NameNormalizationModePreserve,
+		PermissionsMode_PermissionsModePortable,
+		aggregateDigests,
+		false,
+		0,
+		0,
+		nil, 0,
+	)
+	if err != nil {
+		b.Fatal("unable to perform scan:", err)
+	}
+	return snapshot
+}
+
+// BenchmarkSnapshotEqualUnchanged compares the performance of comparing the
+// snapshots from two independent scans of an unchanged 100k-file tree (as
+// performed by poll-based watching) with and without aggregate digests. The
+// snapshots share no entries, so the comparison can't short-circuit on pointer
+// equality.
+func BenchmarkSnapshotEqualUnchanged(b *testing.B) {
+	// Create the tree.
+	root := b.TempDir()
+	createBenchmarkScanTree(b, root)
+
+	// Create an ignorer.
+	ignorer, err := mutagenignore.NewIgnorer(nil)
+	if err != nil {
+		b.Fatal("unable to create ignorer:", err)
+	}
+
+	// Perform the benchmarks.
+	for _, aggregateDigests := range []bool{false, true} {
+		name := "Full"
+		if aggregateDigests {
+			name = "Aggregate"
+		}
+		b.Run(name, func(b *testing.B) {
+			first := benchmarkScanSnapshot(b, root, ignorer, aggregateDigests)
+			second := benchmarkScanSnapshot(b, root, ignorer, aggregateDigests)
+			b.ResetTimer()
+			for i := 0; i < b.N; i++ {
+				if !first.Equal(second) {
+					b.Fatal("snapshots of unchanged tree compared unequal")
+				}
+			}
+		})
+	}
+}
+
+// BenchmarkScanHashing compares cold scan performance for a 100k-file tree
+// using each supported built-in hashing algorithm. XXH128 hashing is only
+// benchmarked if it's supported by the current build.
+func BenchmarkScanHashing(b *testing.B) {
+	// Create the tree.
+	root := b.TempDir()
+	createBenchmarkScanTree(b, root)
+
+	// Create an ignorer.
+	ignorer, err := mutagenignore.NewIgnorer(nil)
+	if err != nil {
+		b.Fatal("unable to create ignorer:", err)
+	}
+
+	// Perform the benchmarks.
+	algorithms := []hashing.Algorithm{
+		hashing.Algorithm_AlgorithmSHA1,
+		hashing.Algorithm_AlgorithmSHA256,
+		hashing.Algorithm_AlgorithmXXH128,
+		hashing.Algorithm_AlgorithmXXH3,
+	}
+	for _, algorithm := range algorithms {
+		if algorithm.SupportStatus() != hashing.AlgorithmSupportStatusSupported {
+			continue
+		}
+		b.Run(algorithm.Description(), func(b *testing.B) {
+			benchmarkScan(b, root, algorithm.Factory()(), ignorer)
+		})
+	}
+}
@@ -1,6 +1,7 @@
 package core
 
 import (
+	"bytes"
 	"context"
 	"errors"
 	"fmt"
@@ -8,6 +9,9 @@ import (
 	"os"
 	"path/filepath"
 	"runtime"
+	"slices"
+	"sort"
+	"strings"
 	"testing"
 	"time"
 
@@ -16,6 +20,7 @@ import (
 	"github.com/mutagen-io/mutagen/pkg/synchronization/core/ignore"
 	dockerignore "github.com/mutagen-io/mutagen/pkg/synchronization/core/ignore/docker"
 	mutagenignore "github.com/mutagen-io/mutagen/pkg/synchronization/core/ignore/mutagen"
+	"github.com/mutagen-io/mutagen/pkg/synchronization/hashing"
 )
 
 // testingIgnoreCachesEqual verifies that two ignore caches are equal.
@@ -572,11 +577,18 @@ func TestScan(t *testing.T) {
 				test.ctx,
 				root,
 				nil, nil,
-				hasher, nil,
+				hasher, nil, CacheTrustMode_CacheTrustModeStrict,
 				ignorer, nil,
-				behavior.ProbeMode_ProbeModeProbe,
+				behavior.ProbeMode_ProbeModeProbe, nil,
 				test.symbolicLinkMode,
+				SpecialFileMode_SpecialFileModeIgnore,
+				NameNormalizationMode_NameNormalizationModePreserve,
 				test.permissionsMode,
+				false,
+				false,
+				0,
+				0,
+				nil, 0,
 			)
 			if test.expectFailure {
 				if err == nil {
@@ -654,11 +666,18 @@ func TestScan(t *testing.T) {
 				test.ctx,
 				root,
 				nil, nil,
-				rescanHasher, cache,
+				rescanHasher, cache, CacheTrustMode_CacheTrustModeStrict,
 				ignorer, ignoreCache,
-				behavior.ProbeMode_ProbeModeProbe,
+				behavior.ProbeMode_ProbeModeProbe, nil,
 				test.symbolicLinkMode,
+				SpecialFileMode_SpecialFileModeIgnore,
+				NameNormalizationMode_NameNormalizationModePreserve,
 				test.permissionsMode,
+				false,
+				false,
+				0,
+				0,
+				nil, 0,
 			)
 
 			// Handle scan failure (which isn't expected at this point).
@@ -726,11 +745,18 @@ func TestScan(t *testing.T) {
 				test.ctx,
 				root,
 				snapshot, nil,
-				hasher, cache,
+				hasher, cache, CacheTrustMode_CacheTrustModeStrict,
 				ignorer, ignoreCache,
-				behavior.ProbeMode_ProbeModeProbe,
+				behavior.ProbeMode_ProbeModeProbe, nil,
 				test.symbolicLinkMode,
+				SpecialFileMode_SpecialFileModeIgnore,
+				NameNormalizationMode_NameNormalizationModePreserve,
 				test.permissionsMode,
+				false,
+				false,
+				0,
+				0,
+				nil, 0,
 			)
 
 			// Handle scan failure (which isn't expected at this point).
@@ -825,11 +851,18 @@ func TestScan(t *testing.T) {
 				test.ctx,
 				root,
 				snapshot, recheckPaths,
-				hasher, cache,
+				hasher, cache, CacheTrustMode_CacheTrustModeStrict,
 				ignorer, ignoreCache,
-				behavior.ProbeMode_ProbeModeProbe,
+				behavior.ProbeMode_ProbeModeProbe, nil,
 				test.symbolicLinkMode,
+				SpecialFileMode_SpecialFileModeIgnore,
+				NameNormalizationMode_NameNormalizationModePreserve,
 				test.permissionsMode,
+				false,
+				false,
+				0,
+				0,
+				nil, 0,
 			)
 
 			// Handle scan failure (which isn't expected at this point).
@@ -944,11 +977,18 @@ func TestScanCrossFilesystemBoundary(t *testing.T) {
 		context.Background(),
 		parent,
 		nil, nil,
-		newTestingHasher(), nil,
+		newTestingHasher(), nil, CacheTrustMode_CacheTrustModeStrict,
 		ignorer, nil,
-		behavior.ProbeMode_ProbeModeProbe,
+		behavior.ProbeMode_ProbeModeProbe, nil,
 		SymbolicLinkMode_SymbolicLinkModePortable,
+		SpecialFileMode_SpecialFileModeIgnore,
+		NameNormalizationMode_NameNormalizationModePreserve,
 		PermissionsMode_PermissionsModePortable,
+		false,
+		false,
+		0,
+		0,
+		nil, 0,
 	)
 	if err != nil {
 		t.Fatalf("unable to perform scan: %v", err)
@@ -964,3 +1004,960 @@ func TestScanCrossFilesystemBoundary(t *testing.T) {
 		t.Errorf("result does not match expected: %v != %v", snapshot.Content.Contents[name], expected)
 	}
 }
+
+// testingProbeScan performs a scan of the specified root in probe mode using
+// the specified probe options.
+func testingProbeScan(root string, probeOptions *behavior.ProbeOptions) (*Snapshot, error) {
+	ignorer, err := mutagenignore.NewIgnorer(nil)
+	if err != nil {
+		return nil, fmt.Errorf("unable to create ignorer: %w", err)
+	}
+	snapshot, _, _, err := Scan(
+		context.Background(),
+		root,
+		nil, nil,
+		newTestingHasher(), nil, CacheTrustMode_CacheTrustModeStrict,
+		ignorer, nil,
+		behavior.ProbeMode_ProbeModeProbe, probeOptions,
+		SymbolicLinkMode_SymbolicLinkModePortable,
+		SpecialFileMode_SpecialFileModeIgnore,
+		NameNormalizationMode_NameNormalizationModePreserve,
+		PermissionsMode_PermissionsModePortable,
+		false,
+		false,
+		0,
+		0,
+		nil, 0,
+	)
+	return snapshot, err
+}
+
+// testingClearBehaviorCache removes any cached behavior information for the
+// filesystem on which the specified path resides.
+func testingClearBehaviorCache(t *testing.T, path string) {
+	// Mark ourselves as a helper function.
+	t.Helper()
+
+	// Determine the device ID.
+	object, metadata, err := filesystem.Open(path, false)
+	if err != nil {
+		t.Fatal("unable to open path:", err)
+	}
+	object.Close()
+
+	// Clear the cache entries.
+	behaviorCache.Lock()
+	delete(behaviorCache.preservesExecutability, metadata.DeviceID)
+	delete(behaviorCache.decomposesUnicode, metadata.DeviceID)
+	delete(behaviorCache.ignoresCase, metadata.DeviceID)
+	behaviorCache.Unlock()
+}
+
+// TestScanProbeDirectory tests that behavior probing uses an alternate probe
+// directory when one is specified.
+func TestScanProbeDirectory(t *testing.T) {
+	// Create a root with some content and a probe directory on the same
+	// filesystem.
+	parent := t.TempDir()
+	root := filepath.Join(parent, "root")
+	probeDirectory := filepath.Join(parent, "probe")
+	if err := os.Mkdir(root, 0700); err != nil {
+		t.Fatal("unable to create root:", err)
+	} else if err = os.Mkdir(probeDirectory, 0700); err != nil {
+		t.Fatal("unable to create probe directory:", err)
+	} else if err = os.WriteFile(filepath.Join(root, "file"), []byte("content"), 0600); err != nil {
+		t.Fatal("unable to create file:", err)
+	}
+
+	// Ensure that no cached behavior information will short-circuit probing.
+	testingClearBehaviorCache(t, root)
+
+	// Perform a reference scan without any probe options.
+	expected, err := testingProbeScan(root, nil)
+	if err != nil {
+		t.Fatal("unable to perform reference scan:", err)
+	}
+	testingClearBehaviorCache(t, root)
+
+	// Perform a scan using the probe directory and ensure that the results
+	// match the reference scan.
+	snapshot, err := testingProbeScan(root, &behavior.ProbeOptions{Directory: probeDirectory})
+	if err != nil {
+		t.Fatal("unable to perform scan with probe directory:", err)
+	} else if snapshot.PreservesExecutability != expected.PreservesExecutability {
+		t.Error("executability preservation behavior does not match reference scan")
+	} else if snapshot.DecomposesUnicode != expected.DecomposesUnicode {
+		t.Error("Unicode decomposition behavior does not match reference scan")
+	} else if !snapshot.Content.Equal(expected.Content, true) {
+		t.Error("scan content does not match reference scan")
+	}
+	testingClearBehaviorCache(t, root)
+
+	// Ensure that no probe files were left in either location.
+	for _, directory := range []string{root, probeDirectory} {
+		contents, err := os.ReadDir(directory)
+		if err != nil {
+			t.Fatal("unable to read directory contents:", err)
+		}
+		for _, c := range contents {
+			if c.Name() != "file" {
+				t.Error("unexpected content after probing:", filepath.Join(directory, c.Name()))
+			}
+		}
+	}
+
+	// Perform a scan using a non-existent probe directory and ensure that it
+	// fails, indicating that the configured location (rather than the root) is
+	// used for probing.
+	if _, err := testingProbeScan(root, &behavior.ProbeOptions{
+		Directory: filepath.Join(parent, "missing"),
+	}); err == nil {
+		t.Error("scan with non-existent probe directory succeeded unexpectedly")
+	}
+}
+
+// TestScanProbeAssumptions tests that explicitly stated behavior assumptions
+// cause probing to be skipped and are reflected in the resulting snapshot.
+func TestScanProbeAssumptions(t *testing.T) {
+	// Create a root with some content.
+	root := t.TempDir()
+	if err := os.WriteFile(filepath.Join(root, "file"), []byte("content"), 0600); err != nil {
+		t.Fatal("unable to create file:", err)
+	}
+
+	// Set up test cases. We specify a non-existent probe directory in each
+	// case, which will cause the scan to fail if any probing is attempted.
+	testCases := []struct {
+		executabilityPreservation behavior.ProbeAssumption
+		unicodeDecomposition      behavior.ProbeAssumption
+		caseInsensitivity         behavior.ProbeAssumption
+	}{
+		{behavior.ProbeAssumption_ProbeAssumptionTrue, behavior.ProbeAssumption_ProbeAssumptionTrue, behavior.ProbeAssumption_ProbeAssumptionTrue},
+		{behavior.ProbeAssumption_ProbeAssumptionTrue, behavior.ProbeAssumption_ProbeAssumptionFalse, behavior.ProbeAssumption_ProbeAssumptionFalse},
+		{behavior.ProbeAssumption_ProbeAssumptionFalse, behavior.ProbeAssumption_ProbeAssumptionTrue, behavior.ProbeAssumption_ProbeAssumptionFalse},
+		{behavior.ProbeAssumption_ProbeAssumptionFalse, behavior.ProbeAssumption_ProbeAssumptionFalse, behavior.ProbeAssumption_ProbeAssumptionTrue},
+	}
+
+	// Process test cases.
+	for i, testCase := range testCases {
+		// Ensure that cached behavior information doesn't obscure probing.
+		testingClearBehaviorCache(t, root)
+
+		// Perform the scan.
+		snapshot, err := testingProbeScan(root, &behavior.ProbeOptions{
+			Directory:                 filepath.Join(root, "missing"),
+			ExecutabilityPreservation: testCase.executabilityPreservation,
+			UnicodeDecomposition:      testCase.unicodeDecomposition,
+			CaseInsensitivity:         testCase.caseInsensitivity,
+		})
+		if err != nil {
+			t.Errorf("test index %d: unable to perform scan: %v", i, err)
+			continue
+		}
+
+		// Verify that the snapshot reflects the assumptions.
+		expectedPreserves, _ := testCase.executabilityPreservation.Assumed()
+		expectedDecomposes, _ := testCase.unicodeDecomposition.Assumed()
+		expectedIgnoresCase, _ := testCase.caseInsensitivity.Assumed()
+		if snapshot.PreservesExecutability != expectedPreserves {
+			t.Errorf("test index %d: executability preservation behavior does not match assumption", i)
+		}
+		if snapshot.DecomposesUnicode != expectedDecomposes {
+			t.Errorf("test index %d: Unicode decomposition behavior does not match assumption", i)
+		}
+		if snapshot.IgnoresCase != expectedIgnoresCase {
+			t.Errorf("test index %d: case insensitivity behavior does not match assumption", i)
+		}
+		if snapshot.Content.Contents["file"] == nil {
+			t.Errorf("test index %d: scan content missing file", i)
+		}
+	}
+}
+
+// TestScanDirectoryListingObserver tests that Scan reports directory listings
+// that meet the directory listing threshold to the directory listing observer.
+func TestScanDirectoryListingObserver(t *testing.T) {
+	// Create a root with a nested directory.
+	root := t.TempDir()
+	if err := os.MkdirAll(filepath.Join(root, "parent", "child"), 0700); err != nil {
+		t.Fatal("unable to create directories:", err)
+	}
+
+	// Create an ignorer.
+	ignorer, err := mutagenignore.NewIgnorer(nil)
+	if err != nil {
+		t.Fatal("unable to create ignorer:", err)
+	}
+
+	// Set up test cases.
+	testCases := []struct {
+		threshold time.Duration
+		expected  []string
+	}{
+		{0, []string{"", "parent", "parent/child"}},
+		{time.Hour, nil},
+	}
+
+	// Process test cases.
+	for i, testCase := range testCases {
+		// Perform a scan, recording observed paths.
+		var observed []string
+		observer := func(path string, duration time.Duration) {
+			if duration < testCase.threshold {
+				t.Errorf("test index %d: observed duration below threshold for \"%s\"", i, path)
+			}
+			observed = append(observed, path)
+		}
+		if _, _, _, err := Scan(
+			context.Background(),
+			root,
+			nil, nil,
+			newTestingHasher(), nil, CacheTrustMode_CacheTrustModeStrict,
+			ignorer, nil,
+			behavior.ProbeMode_ProbeModeAssume, nil,
+			SymbolicLinkMode_SymbolicLinkModePortable,
+			SpecialFileMode_SpecialFileModeIgnore,
+			NameNormalizationMode_NameNormalizationModePreserve,
+			PermissionsMode_PermissionsModePortable,
+			false,
+			false,
+			0,
+			0,
+			observer, testCase.threshold,
+		); err != nil {
+			t.Fatalf("test index %d: unable to perform scan: %v", i, err)
+		}
+
+		// Verify the observed paths.
+		sort.Strings(observed)
+		if !slices.Equal(observed, testCase.expected) {
+			t.Errorf("test index %d: observed paths do not match expected: %v != %v",
+				i, observed, testCase.expected,
+			)
+		}
+	}
+}
+
+// TestScanModificationTimes tests that scans record file modification times
+// only when requested, including when reusing cached digests.
+func TestScanModificationTimes(t *testing.T) {
+	// Create a file with a known modification time.
+	root := t.TempDir()
+	file := filepath.Join(root, "file")
+	modificationTime := time.Date(2020, time.January, 1, 0, 0, 0, 0, time.UTC)
+	if err := os.WriteFile(file, []byte(tF1Content), 0600); err != nil {
+		t.Fatal("unable to create file:", err)
+	} else if err = os.Chtimes(file, modificationTime, modificationTime); err != nil {
+		t.Fatal("unable to set file modification time:", err)
+	}
+
+	// Create an ignorer.
+	ignorer, err := mutagenignore.NewIgnorer(nil)
+	if err != nil {
+		t.Fatal("unable to create ignorer:", err)
+	}
+
+	// Perform a scan without modification times and ensure that none are
+	// recorded.
+	snapshot, cache, _, err := Scan(
+		context.Background(),
+		root,
+		nil, nil,
+		newTestingHasher(), nil, CacheTrustMode_CacheTrustModeStrict,
+		ignorer, nil,
+		behavior.ProbeMode_ProbeModeProbe, nil,
+		SymbolicLinkMode_SymbolicLinkModePortable,
+		SpecialFileMode_SpecialFileModeIgnore,
+		NameNormalizationMode_NameNormalizationModePreserve,
+		PermissionsMode_PermissionsModePortable,
+		false,
+		false,
+		0,
+		0,
+		nil, 0,
+	)
+	if err != nil {
+		t.Fatal("unable to perform scan:", err)
+	} else if snapshot.Content.Contents["file"].ModificationTime != nil {
+		t.Error("modification time recorded unexpectedly")
+	}
+
+	// Perform a scan with modification times (using the cache from the previous
+	// scan) and ensure that the correct modification time is recorded.
+	snapshot, _, _, err = Scan(
+		context.Background(),
+		root,
+		nil, nil,
+		newTestingHasher(), cache, CacheTrustMode_CacheTrustModeStrict,
+		ignorer, nil,
+		behavior.ProbeMode_ProbeModeProbe, nil,
+		SymbolicLinkMode_SymbolicLinkModePortable,
+		SpecialFileMode_SpecialFileModeIgnore,
+		NameNormalizationMode_NameNormalizationModePreserve,
+		PermissionsMode_PermissionsModePortable,
+		false,
+		true,
+		0,
+		0,
+		nil, 0,
+	)
+	if err != nil {
+		t.Fatal("unable to perform scan:", err)
+	} else if err = snapshot.EnsureValid(); err != nil {
+		t.Fatal("scan produced invalid snapshot:", err)
+	} else if recorded := snapshot.Content.Contents["file"].ModificationTime; recorded == nil {
+		t.Error("modification time not recorded")
+	} else if !recorded.AsTime().Equal(modificationTime) {
+		t.Errorf("recorded modification time (%s) does not match expected (%s)",
+			recorded.AsTime(), modificationTime,
+		)
+	}
+}
+
+// TestScanCacheTrustMode tests that cached digests are reused for files whose
+// file identifiers have changed only if the cache trust mode allows it.
+func TestScanCacheTrustMode(t *testing.T) {
+	// Create a root containing a file.
+	root := t.TempDir()
+	if err := os.WriteFile(filepath.Join(root, "file"), []byte(tF1Content), 0600); err != nil {
+		t.Fatal("unable to create file:", err)
+	}
+
+	// Create an ignorer.
+	ignorer, err := mutagenignore.NewIgnorer(nil)
+	if err != nil {
+		t.Fatal("unable to create ignorer:", err)
+	}
+
+	// Perform an initial scan to generate a cache.
+	_, cache, _, err := Scan(
+		context.Background(),
+		root,
+		nil, nil,
+		newTestingHasher(), nil, CacheTrustMode_CacheTrustModeStrict,
+		ignorer, nil,
+		behavior.ProbeMode_ProbeModeProbe, nil,
+		SymbolicLinkMode_SymbolicLinkModePortable,
+		SpecialFileMode_SpecialFileModeIgnore,
+		NameNormalizationMode_NameNormalizationModePreserve,
+		PermissionsMode_PermissionsModePortable,
+		false,
+		false,
+		0,
+		0,
+		nil, 0,
+	)
+	if err != nil {
+		t.Fatal("unable to perform scan:", err)
+	}
+
+	// Simulate a cache persisted before the file was assigned a new file
+	// identifier (e.g. by a container restart). We also replace the cached
+	// digest so that we can tell whether or not it was reused.
+	cached, ok := cache.Entries["file"]
+	if !ok {
+		t.Fatal("file not found in cache")
+	}
+	bogus := []byte("not a real digest")
+	cached.FileID++
+	cached.Digest = bogus
+
+	// Set up test cases.
+	testCases := []struct {
+		mode         CacheTrustMode
+		expectReused bool
+	}{
+		{CacheTrustMode_CacheTrustModeStrict, false},
+		{CacheTrustMode_CacheTrustModeIgnoreFileID, true},
+	}
+
+	// Process test cases.
+	for _, testCase := range testCases {
+		snapshot, _, _, err := Scan(
+			context.Background(),
+			root,
+			nil, nil,
+			newTestingHasher(), cache, testCase.mode,
+			ignorer, nil,
+			behavior.ProbeMode_ProbeModeProbe, nil,
+			SymbolicLinkMode_SymbolicLinkModePortable,
+			SpecialFileMode_SpecialFileModeIgnore,
+			NameNormalizationMode_NameNormalizationModePreserve,
+			PermissionsMode_PermissionsModePortable,
+			false,
+			false,
+			0,
+			0,
+			nil, 0,
+		)
+		if err != nil {
+			t.Fatalf("%s: unable to perform scan: %v", testCase.mode.Description(), err)
+		}
+		reused := bytes.Equal(snapshot.Content.Contents["file"].Digest, bogus)
+		if reused != testCase.expectReused {
+			t.Errorf("%s: cached digest reuse (%t) does not match expected (%t)",
+				testCase.mode.Description(), reused, testCase.expectReused,
+			)
+		}
+	}
+}
+
+// TestScanUnstableDirectoryListing tests that scanning with directory listing
+// retries enabled re-reads directories whose listings are momentarily
+// inconsistent until they stabilize.
+func TestScanUnstableDirectoryListing(t *testing.T) {
+	// Directory listing verification isn't supported on Windows.
+	if runtime.GOOS == "windows" {
+		t.Skip()
+	}
+
+	// Create a root containing a file.
+	root := t.TempDir()
+	if err := os.WriteFile(filepath.Join(root, "file"), []byte(tF1Content), 0600); err != nil {
+		t.Fatal("unable to create file:", err)
+	}
+
+	// Simulate an unstable directory listing by omitting all content from the
+	// first listing of the root. Subsequent listings are accurate.
+	var reads int
+	readDirectoryContents = func(directory *filesystem.Directory) ([]*filesystem.Metadata, error) {
+		contents, err := directory.ReadContents()
+		reads++
+		if reads == 1 {
+			return nil, err
+		}
+		return contents, err
+	}
+	defer func() {
+		readDirectoryContents = (*filesystem.Directory).ReadContents
+	}()
+
+	// Create an ignorer.
+	ignorer, err := mutagenignore.NewIgnorer(nil)
+	if err != nil {
+		t.Fatal("unable to create ignorer:", err)
+	}
+
+	// Set up test cases.
+	testCases := []struct {
+		retries       uint32
+		expectFile    bool
+		expectedReads int
+	}{
+		{0, false, 1},
+		{1, true, 2},
+		{3, true, 3},
+	}
+
+	// Process test cases.
+	for i, testCase := range testCases {
+		// Reset the read count.
+		reads = 0
+
+		// Perform the scan.
+		snapshot, _, _, err := Scan(
+			context.Background(),
+			root,
+			nil, nil,
+			newTestingHasher(), nil, CacheTrustMode_CacheTrustModeStrict,
+			ignorer, nil,
+			behavior.ProbeMode_ProbeModeAssume, nil,
+			SymbolicLinkMode_SymbolicLinkModePortable,
+			SpecialFileMode_SpecialFileModeIgnore,
+			NameNormalizationMode_NameNormalizationModePreserve,
+			PermissionsMode_PermissionsModePortable,
+			false,
+			false,
+			testCase.retries,
+			0,
+			nil, 0,
+		)
+		if err != nil {
+			t.Fatalf("test index %d: unable to perform scan: %v", i, err)
+		}
+
+		// Verify the scan result.
+		if _, ok := snapshot.Content.Contents["file"]; ok != testCase.expectFile {
+			t.Errorf("test index %d: file presence does not match expected: %t != %t",
+				i, ok, testCase.expectFile,
+			)
+		}
+		if reads != testCase.expectedReads {
+			t.Errorf("test index %d: directory read count does not match expected: %d != %d",
+				i, reads, testCase.expectedReads,
+			)
+		}
+	}
+}
+
+// TestScanDigestSampling tests that scanning with a sampling hasher records
+// full digests in snapshots and caches, records sampled digests in caches for
+// large files, and uses those sampled digests to detect changes to large files
+// whose metadata has changed.
+func TestScanDigestSampling(t *testing.T) {
+	// Create a sampling hasher factory.
+	hasherFactory := hashing.NewSamplingFactory(newTestingHasher, hashing.MinimumSamplingThreshold)
+
+	// Create file content that exceeds the sampling threshold.
+	content := make([]byte, hashing.MinimumSamplingThreshold+hashing.SampleSize)
+	for i := range content {
+		content[i] = byte(i)
+	}
+
+	// Create a root with a large file and a small file.
+	root := t.TempDir()
+	large, small := filepath.Join(root, "large"), filepath.Join(root, "small")
+	if err := os.WriteFile(small, []byte(tF1Content), 0600); err != nil {
+		t.Fatal("unable to create small file:", err)
+	}
+
+	// Create an ignorer.
+	ignorer, err := mutagenignore.NewIgnorer(nil)
+	if err != nil {
+		t.Fatal("unable to create ignorer:", err)
+	}
+
+	// Create a function to write the large file and rescan using the previous
+	// cache. We give each version of the file a distinct modification time to
+	// ensure that its cached digest can't be reused based on metadata alone.
+	var cache *Cache
+	modificationTime := time.Now().Add(-time.Hour)
+	scan := func(content []byte) *Snapshot {
+		if err := os.WriteFile(large, content, 0600); err != nil {
+			t.Fatal("unable to write large file:", err)
+		}
+		modificationTime = modificationTime.Add(time.Second)
+		if err := os.Chtimes(large, modificationTime, modificationTime); err != nil {
+			t.Fatal("unable to set large file modification time:", err)
+		}
+		snapshot, newCache, _, err := Scan(
+			context.Background(),
+			root,
+			nil, nil,
+			hasherFactory(), cache, CacheTrustMode_CacheTrustModeStrict,
+			ignorer, nil,
+			behavior.ProbeMode_ProbeModeProbe, nil,
+			SymbolicLinkMode_SymbolicLinkModePortable,
+			SpecialFileMode_SpecialFileModeIgnore,
+			NameNormalizationMode_NameNormalizationModePreserve,
+			PermissionsMode_PermissionsModePortable,
+			false,
+			false,
+			0,
+			0,
+			nil, 0,
+		)
+		if err != nil {
+			t.Fatal("unable to perform scan:", err)
+		} else if err = snapshot.EnsureValid(); err != nil {
+			t.Fatal("scan produced invalid snapshot:", err)
+		}
+		cache = newCache
+		return snapshot
+	}
+
+	// Create a function to compute full content digests.
+	fullDigest := func(content []byte) []byte {
+		hasher := newTestingHasher()
+		hasher.Write(content)
+		return hasher.Sum(nil)
+	}
+
+	// Perform an initial scan and verify that the large file has a full
+	// content digest and a cached sampled digest.
+	snapshot := scan(content)
+	digest := snapshot.Content.Contents["large"].Digest
+	if !bytes.Equal(digest, fullDigest(content)) {
+		t.Error("large file digest does not match full content digest")
+	} else if !bytes.Equal(cache.Entries["large"].Digest, digest) {
+		t.Error("large file cache digest does not match snapshot digest")
+	} else if len(cache.Entries["large"].SampledDigest) == 0 {
+		t.Error("large file cache entry has no sampled digest")
+	}
+
+	// Verify that files below the threshold have full content digests and no
+	// sampled digests.
+	if !bytes.Equal(snapshot.Content.Contents["small"].Digest, tF1.Digest) {
+		t.Error("small file digest does not match full content digest")
+	} else if len(cache.Entries["small"].SampledDigest) != 0 {
+		t.Error("small file cache entry has sampled digest")
+	}
+
+	// Verify that a change confined to the prefix is detected (and yields a
+	// full content digest), even though the suffix (which is also sampled) and
+	// size are unchanged.
+	prefixChanged := bytes.Clone(content)
+	prefixChanged[0]++
+	if !bytes.Equal(scan(prefixChanged).Content.Contents["large"].Digest, fullDigest(prefixChanged)) {
+		t.Error("prefix change not detected")
+	}
+
+	// Verify that a change confined to the suffix is detected.
+	suffixChanged := bytes.Clone(prefixChanged)
+	suffixChanged[len(suffixChanged)-1]++
+	if !bytes.Equal(scan(suffixChanged).Content.Contents["large"].Digest, fullDigest(suffixChanged)) {
+		t.Error("suffix change not detected")
+	}
+
+	// Verify that a change outside of the sampled regions isn't detected and
+	// that the previous full digest is reused. This is the documented risk of
+	// sampling.
+	middleChanged := bytes.Clone(suffixChanged)
+	middleChanged[len(middleChanged)/2]++
+	if !bytes.Equal(scan(middleChanged).Content.Contents["large"].Digest, fullDigest(suffixChanged)) {
+		t.Error("previous digest not reused for unsampled change")
+	}
+}
+
+// TestScanMaximumFileSize tests that files exceeding the maximum file size are
+// recorded as oversized content without being read and that they don't yield
+// transitions or problems during reconciliation and transition planning.
+func TestScanMaximumFileSize(t *testing.T) {
+	// Define the file sizes used for the test.
+	const (
+		maximumFileSize = 10 * 1000
+		largeFileSize   = 800 * 1000 * 1000
+	)
+
+	// Create an alpha root with a large file and a small file. We create the
+	// large file as a sparse file to avoid actually writing its contents.
+	alpha := t.TempDir()
+	large, small := filepath.Join(alpha, "large"), filepath.Join(alpha, "small")
+	if err := os.WriteFile(small, []byte(tF1Content), 0600); err != nil {
+		t.Fatal("unable to create small file:", err)
+	} else if err := os.WriteFile(large, nil, 0600); err != nil {
+		t.Fatal("unable to create large file:", err)
+	} else if err := os.Truncate(large, largeFileSize); err != nil {
+		t.Fatal("unable to resize large file:", err)
+	}
+
+	// Create an empty beta root.
+	beta := t.TempDir()
+
+	// Create an ignorer.
+	ignorer, err := mutagenignore.NewIgnorer(nil)
+	if err != nil {
+		t.Fatal("unable to create ignorer:", err)
+	}
+
+	// Create a function to perform a scan with the maximum file size.
+	scan := func(root string) (*Snapshot, *Cache) {
+		snapshot, cache, _, err := Scan(
+			context.Background(),
+			root,
+			nil, nil,
+			newTestingHasher(), nil, CacheTrustMode_CacheTrustModeStrict,
+			ignorer, nil,
+			behavior.ProbeMode_ProbeModeProbe, nil,
+			SymbolicLinkMode_SymbolicLinkModePortable,
+			SpecialFileMode_SpecialFileModeIgnore,
+			NameNormalizationMode_NameNormalizationModePreserve,
+			PermissionsMode_PermissionsModePortable,
+			false,
+			false,
+			0,
+			maximumFileSize,
+			nil, 0,
+		)
+		if err != nil {
+			t.Fatal("unable to perform scan:", err)
+		} else if err = snapshot.EnsureValid(); err != nil {
+			t.Fatal("scan produced invalid snapshot:", err)
+		}
+		return snapshot, cache
+	}
+
+	// Scan alpha and verify that the large file is recorded as oversized
+	// content (without a cache entry) while the small file is unaffected.
+	alphaSnapshot, alphaCache := scan(alpha)
+	if !alphaSnapshot.Content.Contents["large"].Equal(tO, true) {
+		t.Error("large file not recorded as oversized content")
+	} else if _, ok := alphaCache.Entries["large"]; ok {
+		t.Error("cache entry recorded for oversized content")
+	}
+	if !alphaSnapshot.Content.Contents["small"].Equal(tF1, true) {
+		t.Error("small file not recorded as file")
+	}
+	if alphaSnapshot.Files != 1 {
+		t.Error("file count does not match expected:", alphaSnapshot.Files)
+	} else if alphaSnapshot.TotalFileSize != uint64(len(tF1Content)) {
+		t.Error("total file size does not match expected:", alphaSnapshot.TotalFileSize)
+	}
+	if problems := alphaSnapshot.Content.Problems(); len(problems) > 0 {
+		t.Error("scan problems reported for oversized content:", problems)
+	}
+
+	// Scan beta and reconcile, verifying that only the small file propagates.
+	betaSnapshot, betaCache := scan(beta)
+	_, alphaChanges, betaChanges, conflicts := Reconcile(
+		nil, alphaSnapshot.Content, betaSnapshot.Content,
+		SynchronizationMode_SynchronizationModeTwoWaySafe,
+		TypeChangeMode_TypeChangeModePropagate,
+		0, 0, nil,
+	)
+	if len(alphaChanges) > 0 {
+		t.Error("unexpected alpha changes:", alphaChanges)
+	} else if len(conflicts) > 0 {
+		t.Error("unexpected conflicts:", conflicts)
+	}
+	expectedBetaChanges := []*Change{{Path: "small", New: tF1}}
+	if !testingChangeListsEqual(betaChanges, expectedBetaChanges) {
+		t.Errorf("beta changes do not match expected: %v != %v", betaChanges, expectedBetaChanges)
+	}
+
+	// Verify that applying the beta changes wouldn't yield any problems.
+	_, problems, _ := Transition(
+		context.Background(),
+		beta,
+		betaChanges,
+		betaCache,
+		SymbolicLinkMode_SymbolicLinkModePortable,
+		SymbolicLinkReplacementMode_SymbolicLinkReplacementModeRequireEmpty,
+		SymbolicLinkCycleMode_SymbolicLinkCycleModeAllow,
+		0600,
+		0700,
+		nil,
+		betaSnapshot.DecomposesUnicode,
+		TransitionMode_TransitionModeInPlace,
+		nil,
+		true,
+	)
+	if len(problems) > 0 {
+		t.Error("transition problems reported:", problems)
+	}
+}
+
+// TestScanTruncatedDigests tests that scanning with a truncating hasher records
+// truncated digests in the snapshot (but not the cache) and that typical
+// content changes are still detected.
+func TestScanTruncatedDigests(t *testing.T) {
+	// Create a truncating hasher factory.
+	hasherFactory := hashing.NewTruncatingFactory(newTestingHasher, hashing.MinimumTruncatedDigestLength)
+
+	// Create a root.
+	root := t.TempDir()
+	path := filepath.Join(root, "file")
+
+	// Create an ignorer.
+	ignorer, err := mutagenignore.NewIgnorer(nil)
+	if err != nil {
+		t.Fatal("unable to create ignorer:", err)
+	}
+
+	// Create a function to write the file and scan the root. We don't use a
+	// cache since modifications might not change the file's modification time.
+	scan := func(content []byte) ([]byte, *Cache) {
+		if err := os.WriteFile(path, content, 0600); err != nil {
+			t.Fatal("unable to write file:", err)
+		}
+		snapshot, cache, _, err := Scan(
+			context.Background(),
+			root,
+			nil, nil,
+			hasherFactory(), nil, CacheTrustMode_CacheTrustModeStrict,
+			ignorer, nil,
+			behavior.ProbeMode_ProbeModeProbe, nil,
+			SymbolicLinkMode_SymbolicLinkModePortable,
+			SpecialFileMode_SpecialFileModeIgnore,
+			NameNormalizationMode_NameNormalizationModePreserve,
+			PermissionsMode_PermissionsModePortable,
+			false,
+			false,
+			0,
+			0,
+			nil, 0,
+		)
+		if err != nil {
+			t.Fatal("unable to perform scan:", err)
+		} else if err = snapshot.EnsureValid(); err != nil {
+			t.Fatal("scan produced invalid snapshot:", err)
+		} else if err = cache.EnsureValid(); err != nil {
+			t.Fatal("scan produced invalid cache:", err)
+		}
+		return snapshot.Content.Contents["file"].Digest, cache
+	}
+
+	// Perform an initial scan and verify that the snapshot digest is a
+	// truncated prefix of the full digest and that the cache digest isn't
+	// truncated.
+	content := []byte(strings.Repeat("truncated digest content\n", 1000))
+	digest, cache := scan(content)
+	full := newTestingHasher()
+	full.Write(content)
+	if len(digest) != hashing.MinimumTruncatedDigestLength {
+		t.Fatal("snapshot digest has unexpected length:", len(digest))
+	} else if !bytes.Equal(digest, full.Sum(nil)[:len(digest)]) {
+		t.Error("snapshot digest is not a prefix of full digest")
+	} else if !bytes.Equal(cache.Entries["file"].Digest, full.Sum(nil)) {
+		t.Error("cache digest does not match full digest")
+	}
+
+	// Verify that typical changes are detected.
+	singleByte := bytes.Clone(content)
+	singleByte[len(singleByte)/2]++
+	changes := map[string][]byte{
+		"single byte change": singleByte,
+		"append":             append(bytes.Clone(content), '!'),
+		"truncation":         content[:len(content)-1],
+		"replacement":        []byte(tF1Content),
+		"empty":              nil,
+	}
+	for description, changed := range changes {
+		if changedDigest, _ := scan(changed); bytes.Equal(changedDigest, digest) {
+			t.Errorf("%s not detected", description)
+		}
+	}
+}
+
+// TestScanNameNormalization tests that scans record directory and file names
+// as single path components and that content with names that aren't in
+// Unicode Normalization Form C is only synchronizable when names are
+// preserved.
+func TestScanNameNormalization(t *testing.T) {
+	// Create content with canonical and non-canonical names.
+	const canonical = "caf\u00e9"
+	const nonCanonical = "cafe\u0301"
+	root := t.TempDir()
+	if err := os.Mkdir(filepath.Join(root, "dir"), 0700); err != nil {
+		t.Fatal("unable to create directory:", err)
+	} else if err = os.Mkdir(filepath.Join(root, "dir", canonical), 0700); err != nil {
+		t.Fatal("unable to create canonically named directory:", err)
+	} else if err = os.Mkdir(filepath.Join(root, nonCanonical), 0700); err != nil {
+		t.Fatal("unable to create non-canonically named directory:", err)
+	} else if err = os.WriteFile(filepath.Join(root, "dir", nonCanonical), []byte(tF1Content), 0600); err != nil {
+		t.Fatal("unable to create non-canonically named file:", err)
+	}
+
+	// If the filesystem normalizes names, then names will be recomposed (or
+	// collide) and there's nothing to test.
+	if contents, err := os.ReadDir(root); err != nil {
+		t.Fatal("unable to read root contents:", err)
+	} else if len(contents) != 2 || (contents[0].Name() != nonCanonical && contents[1].Name() != nonCanonical) {
+		t.Skip("filesystem normalizes names")
+	}
+
+	// Create an ignorer.
+	ignorer, err := mutagenignore.NewIgnorer(nil)
+	if err != nil {
+		t.Fatal("unable to create ignorer:", err)
+	}
+
+	// Set up test cases.
+	tests := []struct {
+		mode     NameNormalizationMode
+		expected *Entry
+	}{
+		{
+			NameNormalizationMode_NameNormalizationModePreserve,
+			&Entry{Kind: EntryKind_Directory, Contents: map[string]*Entry{
+				"dir": {Kind: EntryKind_Directory, Contents: map[string]*Entry{
+					canonical:    tD0,
+					nonCanonical: tF1,
+				}},
+				nonCanonical: tD0,
+			}},
+		},
+		{
+			NameNormalizationMode_NameNormalizationModeRequireNFC,
+			&Entry{Kind: EntryKind_Directory, Contents: map[string]*Entry{
+				"dir": {Kind: EntryKind_Directory, Contents: map[string]*Entry{
+					canonical:    tD0,
+					nonCanonical: {Kind: EntryKind_Problematic, Problem: "*"},
+				}},
+				nonCanonical: {Kind: EntryKind_Problematic, Problem: "*"},
+			}},
+		},
+	}
+
+	// Process test cases.
+	for i, test := range tests {
+		snapshot, _, _, err := Scan(
+			context.Background(),
+			root,
+			nil, nil,
+			newTestingHasher(), nil, CacheTrustMode_CacheTrustModeStrict,
+			ignorer, nil,
+			behavior.ProbeMode_ProbeModeProbe, nil,
+			SymbolicLinkMode_SymbolicLinkModePortable,
+			SpecialFileMode_SpecialFileModeIgnore,
+			test.mode,
+			PermissionsMode_PermissionsModePortable,
+			false,
+			false,
+			0,
+			0,
+			nil, 0,
+		)
+		if err != nil {
+			t.Fatalf("test index %d: unable to perform scan: %v", i, err)
+		} else if err = snapshot.EnsureValid(); err != nil {
+			t.Fatalf("test index %d: scan produced invalid snapshot: %v", i, err)
+		} else if !snapshot.Content.Equal(test.expected, true) {
+			t.Errorf("test index %d: scanned content does not match expected", i)
+		}
+	}
+}
+
+// TestScanHashingStatistics tests that scans report hashing statistics for
+// hashed file content and that content whose digest is reused from the cache
+// isn't counted.
+func TestScanHashingStatistics(t *testing.T) {
+	// Create a root with some files.
+	root := t.TempDir()
+	var totalSize uint64
+	for f := 0; f < 3; f++ {
+		content := []byte(strings.Repeat("hashing statistics content\n", 100*(f+1)))
+		if err := os.WriteFile(filepath.Join(root, fmt.Sprintf("file%d", f)), content, 0600); err != nil {
+			t.Fatal("unable to create file:", err)
+		}
+		totalSize += uint64(len(content))
+	}
+
+	// Create an ignorer.
+	ignorer, err := mutagenignore.NewIgnorer(nil)
+	if err != nil {
+		t.Fatal("unable to create ignorer:", err)
+	}
+
+	// Create a function to scan the root with the specified cache.
+	scan := func(cache *Cache) (*Snapshot, *Cache) {
+		snapshot, newCache, _, err := Scan(
+			context.Background(),
+			root,
+			nil, nil,
+			newTestingHasher(), cache, CacheTrustMode_CacheTrustModeStrict,
+			ignorer, nil,
+			behavior.ProbeMode_ProbeModeProbe, nil,
+			SymbolicLinkMode_SymbolicLinkModePortable,
+			SpecialFileMode_SpecialFileModeIgnore,
+			NameNormalizationMode_NameNormalizationModePreserve,
+			PermissionsMode_PermissionsModePortable,
+			false,
+			false,
+			0,
+			0,
+			nil, 0,
+		)
+		if err != nil {
+			t.Fatal("unable to perform scan:", err)
+		}
+		return snapshot, newCache
+	}
+
+	// Perform a cold scan and verify that all content was hashed.
+	snapshot, cache := scan(nil)
+	if snapshot.HashedBytes != totalSize {
+		t.Error("hashed bytes does not match total file size:", snapshot.HashedBytes, "!=", totalSize)
+	}
+	if snapshot.HashingDuration == 0 {
+		t.Error("hashing duration not recorded")
+	}
+
+	// Perform a warm scan and verify that no content was hashed.
+	snapshot, _ = scan(cache)
+	if snapshot.HashedBytes != 0 {
+		t.Error("content hashed despite cache:", snapshot.HashedBytes)
+	} else if snapshot.HashingDuration != 0 {
+		t.Error("hashing duration recorded despite cache:", snapshot.HashingDuration)
+	}
+}
@@ -0,0 +1,403 @@
+package core
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/mutagen-io/mutagen/pkg/filesystem"
+	"github.com/mutagen-io/mutagen/pkg/synchronization/core/fastpath"
+)
+
+var (
+	// errShadowedDirectoryModified indicates that a shadowed directory was
+	// modified while its shadow copy was in use.
+	errShadowedDirectoryModified = errors.New("shadowed directory modified concurrently")
+)
+
+// shadowRecord records the state of a filesystem entry within a shadowed
+// directory at the time that its shadow copy was created.
+type shadowRecord struct {
+	// info is the entry metadata.
+	info fs.FileInfo
+	// target is the symbolic link target, if the entry is a symbolic link.
+	target string
+}
+
+// matches determines whether or not the record matches the specified entry.
+func (r *shadowRecord) matches(path string, info fs.FileInfo) (bool, error) {
+	// Check identity and mode.
+	if !os.SameFile(r.info, info) || r.info.Mode() != info.Mode() {
+		return false, nil
+	}
+
+	// Check kind-specific properties.
+	if info.Mode().IsRegular() {
+		return info.Size() == r.info.Size() && info.ModTime().Equal(r.info.ModTime()), nil
+	} else if info.Mode()&fs.ModeSymlink != 0 {
+		target, err := os.Readlink(path)
+		if err != nil {
+			return false, fmt.Errorf("unable to read symbolic link target: %w", err)
+		}
+		return target == r.target, nil
+	}
+	return true, nil
+}
+
+// shadowManifest records the state of a shadowed directory's contents at the
+// time that its shadow copy was created, keyed by directory-relative path.
+type shadowManifest map[string]*shadowRecord
+
+// createShadowDirectory creates a shadow copy of the (non-symbolic-link)
+// directory at source at the (non-existent) target path. Regular files (and
+// other non-directory, non-symbolic-link content) are shared with the source
+// via hard links, which preserves the file identity and metadata that are
+// checked by the transitioner, symbolic links are recreated, and directories
+// are recreated with matching ownership and permissions. It returns a manifest
+// that can be used to detect modifications to the source directory.
+func (t *transitioner) createShadowDirectory(source, target string) (shadowManifest, error) {
+	// Create the manifest.
+	manifest := make(shadowManifest)
+
+	// Track the directories that we've created along with their intended
+	// permissions. We defer setting permissions until after populating them
+	// since they may not allow us to create content.
+	type createdDirectory struct {
+		path string
+		mode fs.FileMode
+	}
+	var directories []createdDirectory
+
+	// Walk the source directory and replicate its contents.
+	err := filepath.WalkDir(source, func(path string, entry fs.DirEntry, err error) error {
+		// Watch for traversal errors.
+		if err != nil {
+			return err
+		}
+
+		// Check for cancellation, since replication can take a significant
+		// amount of time for large directories.
+		select {
+		case <-t.cancelled:
+			return errTransitionCancelled
+		default:
+		}
+
+		// Grab the entry metadata. For the walk root, this will have been
+		// obtained using os.Lstat, so it will never be the target of a symbolic
+		// link.
+		info, err := entry.Info()
+		if err != nil {
+			return fmt.Errorf("unable to query metadata: %w", err)
+		}
+
+		// Compute the relative path and the target path.
+		relative, err := filepath.Rel(source, path)
+		if err != nil {
+			return fmt.Errorf("unable to compute relative path: %w", err)
+		}
+		destination := filepath.Join(target, relative)
+
+		// Replicate the entry and record it in the manifest.
+		record := &shadowRecord{info: info}
+		if info.IsDir() {
+			if err := os.Mkdir(destination, 0700); err != nil {
+				return fmt.Errorf("unable to create directory: %w", err)
+			} else if err := copyShadowOwnership(info, destination); err != nil {
+				return fmt.Errorf("unable to set directory ownership: %w", err)
+			}
+			directories = append(directories, createdDirectory{
+				destination,
+				info.Mode() & (fs.ModePerm | fs.ModeSetuid | fs.ModeSetgid | fs.ModeSticky),
+			})
+		} else if info.Mode()&fs.ModeSymlink != 0 {
+			if record.target, err = os.Readlink(path); err != nil {
+				return fmt.Errorf("unable to read symbolic link target: %w", err)
+			} else if err = os.Symlink(record.target, destination); err != nil {
+				return fmt.Errorf("unable to create symbolic link: %w", err)
+			} else if err = copyShadowOwnership(info, destination); err != nil {
+				return fmt.Errorf("unable to set symbolic link ownership: %w", err)
+			}
+		} else if err := os.Link(path, destination); err != nil {
+			return fmt.Errorf("unable to create hard link: %w", err)
+		}
+		manifest[filepath.ToSlash(relative)] = record
+
+		// Success.
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	// Set directory permissions, working from the bottom up.
+	for d := len(directories) - 1; d >= 0; d-- {
+		if err := os.Chmod(directories[d].path, directories[d].mode); err != nil {
+			return nil, fmt.Errorf("unable to set directory permissions: %w", err)
+		}
+	}
+
+	// Success.
+	return manifest, nil
+}
+
+// verifyShadowedDirectory verifies that the contents of the directory at the
+// specified path match those recorded in the specified manifest. It returns
+// errShadowedDirectoryModified if they don't.
+func verifyShadowedDirectory(directory string, manifest shadowManifest) error {
+	// Walk the directory and compare its contents against the manifest.
+	var count int
+	err := filepath.WalkDir(directory, func(path string, entry fs.DirEntry, err error) error {
+		// Watch for traversal errors.
+		if err != nil {
+			return err
+		}
+
+		// Grab the entry metadata.
+		info, err := entry.Info()
+		if err != nil {
+			return fmt.Errorf("unable to query metadata: %w", err)
+		}
+
+		// Compute the relative path and look up the corresponding record.
+		relative, err := filepath.Rel(directory, path)
+		if err != nil {
+			return fmt.Errorf("unable to compute relative path: %w", err)
+		}
+		record, ok := manifest[filepath.ToSlash(relative)]
+		if !ok {
+			return errShadowedDirectoryModified
+		}
+		count++
+
+		// Compare the entry against the record.
+		if match, err := record.matches(path, info); err != nil {
+			return err
+		} else if !match {
+			return errShadowedDirectoryModified
+		}
+
+		// Success.
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	// Ensure that no content has been removed.
+	if count != len(manifest) {
+		return errShadowedDirectoryModified
+	}
+
+	// Success.
+	return nil
+}
+
+// removeShadowContainer removes a shadow container and its contents. It
+// ensures that directories within the container are writable so that their
+// contents can be removed. Any errors are ignored, since leftover content will
+// have a temporary name and thus be ignored by scans.
+func removeShadowContainer(container string) {
+	filepath.WalkDir(container, func(path string, entry fs.DirEntry, err error) error {
+		if err == nil && entry.IsDir() {
+			os.Chmod(path, 0700)
+		}
+		return nil
+	})
+	os.RemoveAll(container)
+}
+
+// shadowProvider is a Provider implementation that serves files created within
+// a shadow directory before falling back to another provider. It's used to
+// reapply transitions in place if a shadow directory can't be committed, since
+// files staged for the affected transitions will have already been moved into
+// the shadow directory.
+type shadowProvider struct {
+	// container is the path to the shadow container.
+	container string
+	// digests maps the container-relative paths of files created within the
+	// shadow directory to their content digests.
+	digests map[string][]byte
+	// provider is the fallback provider.
+	provider Provider
+}
+
+// record records the files within the specified entry (located at the
+// specified path) as being available from the shadow directory.
+func (p *shadowProvider) record(path string, entry *Entry) {
+	if entry == nil {
+		return
+	} else if entry.Kind == EntryKind_File {
+		p.digests[path] = entry.Digest
+	} else if entry.Kind == EntryKind_Directory {
+		prefix := fastpath.Joinable(path)
+		for name, child := range entry.Contents {
+			p.record(prefix+name, child)
+		}
+	}
+}
+
+// Provide implements Provider.Provide.
+func (p *shadowProvider) Provide(path string, digest []byte) (string, error) {
+	if d, ok := p.digests[path]; ok && bytes.Equal(d, digest) {
+		return filepath.Join(p.container, filepath.FromSlash(path)), nil
+	}
+	return p.provider.Provide(path, digest)
+}
+
+// transitionWithShadowDirectories performs transitions using shadow copies of
+// the top-level directories that they affect, falling back to in-place
+// transitions where shadow copies can't be used. It returns results in the
+// same order as the transitions.
+func (t *transitioner) transitionWithShadowDirectories(transitions []*Change) []*Entry {
+	// Set up results.
+	results := make([]*Entry, len(transitions))
+
+	// Group transitions by the top-level directory in which they reside.
+	// Transitions targeting the synchronization root or its direct children
+	// aren't contained within a top-level directory and are thus applied in
+	// place. Permission-only file changes are also applied in place since they
+	// modify files (which are shared with the source directory in shadow
+	// copies) rather than replacing them.
+	var names []string
+	groups := make(map[string][]int)
+	for i, transition := range transitions {
+		permissionsOnly := transition.Old != nil && transition.New != nil &&
+			transition.Old.Kind == EntryKind_File &&
+			transition.New.Kind == EntryKind_File &&
+			bytes.Equal(transition.Old.Digest, transition.New.Digest)
+		slash := strings.IndexByte(transition.Path, '/')
+		if permissionsOnly || slash < 0 {
+			results[i] = t.transition(transition)
+			continue
+		}
+		name := transition.Path[:slash]
+		if _, ok := groups[name]; !ok {
+			names = append(names, name)
+		}
+		groups[name] = append(groups[name], i)
+	}
+
+	// Process each top-level directory.
+	for _, name := range names {
+		t.transitionShadowDirectory(name, transitions, groups[name], results)
+	}
+
+	// Done.
+	return results
+}
+
+// transitionShadowDirectory performs the transitions at the specified indices,
+// all of which reside within the specified top-level directory, by applying
+// them to a shadow copy of the directory and atomically exchanging it into
+// place. If a shadow copy can't be created or committed, then the transitions
+// are applied in place. Results are stored at the corresponding indices.
+func (t *transitioner) transitionShadowDirectory(name string, transitions []*Change, indices []int, results []*Entry) {
+	// Define an in-place fallback.
+	inPlace := func() {
+		for _, i := range indices {
+			results[i] = t.transition(transitions[i])
+		}
+	}
+
+	// Verify that the directory exists (with the proper casing) and that it is
+	// in fact a directory (and not a symbolic link). If not, then we'll let the
+	// in-place transitions handle (and report) the issue.
+	live := filepath.Join(t.root, name)
+	if root, _, err := filesystem.OpenDirectory(t.root, false); err != nil {
+		inPlace()
+		return
+	} else if found, err := t.nameExistsInDirectoryWithProperCase(name, root); err != nil || !found {
+		root.Close()
+		inPlace()
+		return
+	} else {
+		root.Close()
+	}
+	if info, err := os.Lstat(live); err != nil || !info.IsDir() {
+		inPlace()
+		return
+	}
+
+	// Create a temporary container for the shadow directory alongside the
+	// directory. Because it has a temporary name prefix, it'll be ignored by
+	// scans. If the shadow directory can't be created (e.g. because hard links
+	// aren't supported or the directory contains a mount point), then we fall
+	// back to in-place transitions.
+	container, err := os.MkdirTemp(t.root, shadowDirectoryTemporaryNamePrefix)
+	if err != nil {
+		inPlace()
+		return
+	}
+	shadow := filepath.Join(container, name)
+	manifest, err := t.createShadowDirectory(live, shadow)
+	if err != nil {
+		removeShadowContainer(container)
+		inPlace()
+		return
+	}
+
+	// Track the state needed to undo the shadow transitions' bookkeeping in the
+	// event that we need to fall back to in-place transitions.
+	problemCount := len(t.problems)
+	providerMissingFiles := t.providerMissingFiles
+
+	// Perform transitions within the shadow directory. Since the container
+	// mirrors the layout of the synchronization root for this directory, we
+	// can simply treat it as the root.
+	root := t.root
+	t.root = container
+	for _, i := range indices {
+		results[i] = t.transition(transitions[i])
+	}
+	t.root = root
+
+	// Atomically exchange the shadow directory into place. If that succeeds,
+	// then verify that the original directory (now located in the container)
+	// wasn't modified while we were working on the shadow copy, because such
+	// modifications would otherwise be lost. If it was modified, then exchange
+	// the original directory back into place.
+	err = filesystem.Exchange(shadow, live)
+	if err == nil {
+		if err = verifyShadowedDirectory(shadow, manifest); err != nil {
+			if rollbackErr := filesystem.Exchange(shadow, live); rollbackErr != nil {
+				// If we can't roll back, then we preserve the original directory
+				// inside the container so that any modifications can be
+				// recovered manually.
+				t.recordProblem(name, fmt.Errorf(
+					"unable to restore concurrently modified directory (original contents preserved at %s): %w",
+					shadow, rollbackErr,
+				))
+				return
+			}
+		}
+	}
+
+	// If the shadow directory was committed, then we're done.
+	if err == nil {
+		removeShadowContainer(container)
+		return
+	}
+
+	// Otherwise, reapply the transitions in place. Any files that were staged
+	// for these transitions will have been moved into the shadow directory, so
+	// we serve them from there.
+	t.problems = t.problems[:problemCount]
+	t.providerMissingFiles = providerMissingFiles
+	provider := &shadowProvider{
+		container: container,
+		digests:   make(map[string][]byte),
+		provider:  t.provider,
+	}
+	for _, i := range indices {
+		provider.record(transitions[i].Path, results[i])
+	}
+	t.provider = provider
+	inPlace()
+	t.provider = provider.provider
+	removeShadowContainer(container)
+}
@@ -0,0 +1,31 @@
+//go:build !windows
+
+package core
+
+import (
+	"io/fs"
+	"os"
+	"syscall"
+)
+
+// copyShadowOwnership sets the ownership of the entry at the specified path to
+// match that described by the specified metadata. It avoids changing ownership
+// if it already matches, since doing so may require elevated privileges.
+func copyShadowOwnership(info fs.FileInfo, path string) error {
+	// Extract the source ownership.
+	source, ok := info.Sys().(*syscall.Stat_t)
+	if !ok {
+		return nil
+	}
+
+	// Check whether or not the ownership already matches.
+	if current, err := os.Lstat(path); err != nil {
+		return err
+	} else if target, ok := current.Sys().(*syscall.Stat_t); ok &&
+		target.Uid == source.Uid && target.Gid == source.Gid {
+		return nil
+	}
+
+	// Set the ownership.
+	return os.Lchown(path, int(source.Uid), int(source.Gid))
+}
@@ -0,0 +1,313 @@
+package core
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/mutagen-io/mutagen/pkg/filesystem"
+	"github.com/mutagen-io/mutagen/pkg/filesystem/behavior"
+	mutagenignore "github.com/mutagen-io/mutagen/pkg/synchronization/core/ignore/mutagen"
+)
+
+// testingShadowSetup creates a synchronization root containing a top-level
+// directory named "directory" with the specified files (all with the specified
+// content), scans it, and returns the root, the resulting snapshot, and cache.
+// It skips the test if shadow directories can't be used in the test
+// environment.
+func testingShadowSetup(t *testing.T, names []string, content string) (string, *Snapshot, *Cache) {
+	// Create the root and verify that atomic exchange is supported within it.
+	root := t.TempDir()
+	first, second := filepath.Join(root, "first"), filepath.Join(root, "second")
+	if err := os.Mkdir(first, 0700); err != nil {
+		t.Fatal("unable to create exchange probe directory:", err)
+	} else if err = os.Mkdir(second, 0700); err != nil {
+		t.Fatal("unable to create exchange probe directory:", err)
+	}
+	if err := filesystem.Exchange(first, second); err == filesystem.ErrExchangeUnsupported {
+		t.Skip("atomic exchange not supported")
+	} else if err != nil {
+		t.Fatal("unable to perform exchange probe:", err)
+	}
+	if err := os.Remove(first); err != nil {
+		t.Fatal("unable to remove exchange probe directory:", err)
+	} else if err = os.Remove(second); err != nil {
+		t.Fatal("unable to remove exchange probe directory:", err)
+	}
+
+	// Create content.
+	directory := filepath.Join(root, "directory")
+	if err := os.Mkdir(directory, 0700); err != nil {
+		t.Fatal("unable to create directory:", err)
+	}
+	for _, name := range names {
+		if err := os.WriteFile(filepath.Join(directory, name), []byte(content), 0600); err != nil {
+			t.Fatal("unable to create file:", err)
+		}
+	}
+
+	// Perform a scan.
+	ignorer, err := mutagenignore.NewIgnorer(nil)
+	if err != nil {
+		t.Fatal("unable to create ignorer:", err)
+	}
+	snapshot, cache, _, err := Scan(
+		context.Background(),
+		root,
+		nil, nil,
+		newTestingHasher(), nil, CacheTrustMode_CacheTrustModeStrict,
+		ignorer, nil,
+		behavior.ProbeMode_ProbeModeProbe, nil,
+		SymbolicLinkMode_SymbolicLinkModePortable,
+		SpecialFileMode_SpecialFileModeIgnore,
+		NameNormalizationMode_NameNormalizationModePreserve,
+		PermissionsMode_PermissionsModePortable,
+		false,
+		false,
+		0,
+		0,
+		nil, 0,
+	)
+	if err != nil {
+		t.Fatal("unable to perform scan:", err)
+	}
+
+	// Done.
+	return root, snapshot, cache
+}
+
+// testingShadowContainersRemoved verifies that no shadow containers remain
+// within the specified root.
+func testingShadowContainersRemoved(t *testing.T, root string) {
+	contents, err := os.ReadDir(root)
+	if err != nil {
+		t.Fatal("unable to read root contents:", err)
+	}
+	for _, c := range contents {
+		if strings.HasPrefix(c.Name(), shadowDirectoryTemporaryNamePrefix) {
+			t.Error("shadow container not removed:", c.Name())
+		}
+	}
+}
+
+// TestTransitionShadowDirectoryAtomicity tests that changes applied in shadow
+// directory transition mode become visible atomically to concurrent readers
+// within the affected directory.
+func TestTransitionShadowDirectoryAtomicity(t *testing.T) {
+	// Create content and compute transitions that update all files.
+	var names []string
+	for i := 0; i < 64; i++ {
+		names = append(names, fmt.Sprintf("file%02d", i))
+	}
+	root, snapshot, cache := testingShadowSetup(t, names, "old")
+	var transitions []*Change
+	contentMap := make(testingContentMap)
+	for _, name := range names {
+		path := "directory/" + name
+		transitions = append(transitions, &Change{
+			Path: path,
+			Old:  snapshot.Content.Contents["directory"].Contents[name],
+			New:  &Entry{Kind: EntryKind_File, Digest: testingDigest("new")},
+		})
+		contentMap[path] = []byte("new")
+	}
+
+	// Start readers that repeatedly read all files in the directory (relative
+	// to a directory handle, so that each pass observes a single directory
+	// instance) and record whether or not they ever see a mix of old and new
+	// content.
+	done := make(chan struct{})
+	var readers sync.WaitGroup
+	var observationsLock sync.Mutex
+	var observations, mixed int
+	for r := 0; r < 4; r++ {
+		readers.Add(1)
+		go func() {
+			defer readers.Done()
+			for {
+				select {
+				case <-done:
+					return
+				default:
+				}
+				directory, _, err := filesystem.OpenDirectory(filepath.Join(root, "directory"), false)
+				if err != nil {
+					continue
+				}
+				var old, new int
+				var failed bool
+				for _, name := range names {
+					file, _, err := directory.OpenFile(name)
+					if err != nil {
+						failed = true
+						break
+					}
+					content, err := io.ReadAll(file)
+					file.Close()
+					if err != nil {
+						failed = true
+						break
+					} else if string(content) == "old" {
+						old++
+					} else if string(content) == "new" {
+						new++
+					}
+				}
+				directory.Close()
+				if failed {
+					continue
+				}
+				observationsLock.Lock()
+				observations++
+				if old > 0 && new > 0 {
+					mixed++
+				}
+				observationsLock.Unlock()
+			}
+		}()
+	}
+
+	// Perform the transition and stop the readers.
+	provider := &testingProvider{
+		storage:    t.TempDir(),
+		contentMap: contentMap,
+		hasher:     newTestingHasher(),
+	}
+	results, problems, missingFiles := Transition(
+		context.Background(),
+		root,
+		transitions,
+		cache,
+		SymbolicLinkMode_SymbolicLinkModePortable,
+		SymbolicLinkReplacementMode_SymbolicLinkReplacementModeReplace,
+		SymbolicLinkCycleMode_SymbolicLinkCycleModeAllow,
+		0600,
+		0700,
+		nil,
+		snapshot.DecomposesUnicode,
+		TransitionMode_TransitionModeShadowDirectory,
+		provider,
+		false,
+	)
+	close(done)
+	readers.Wait()
+
+	// Verify transition results.
+	if len(problems) > 0 {
+		t.Fatal("transition encountered problems:", problems[0].Path, problems[0].Error)
+	} else if missingFiles {
+		t.Fatal("transition reported missing files")
+	}
+	for i, result := range results {
+		if !result.Equal(transitions[i].New, true) {
+			t.Errorf("result %d does not match expected", i)
+		}
+	}
+
+	// Verify on-disk content.
+	for _, name := range names {
+		if content, err := os.ReadFile(filepath.Join(root, "directory", name)); err != nil {
+			t.Fatal("unable to read file:", err)
+		} else if string(content) != "new" {
+			t.Errorf("file %s has unexpected content: %s", name, content)
+		}
+	}
+	testingShadowContainersRemoved(t, root)
+
+	// Verify that readers never observed a partially updated directory.
+	if mixed > 0 {
+		t.Errorf("readers observed partially updated directory %d times (out of %d)", mixed, observations)
+	}
+}
+
+// testingModifyingProvider is a Provider that modifies a directory the first
+// time that it's invoked, simulating concurrent modification.
+type testingModifyingProvider struct {
+	// Provider is the underlying provider.
+	Provider
+	// path is the path of the file to create.
+	path string
+	// once restricts the modification to a single invocation.
+	once sync.Once
+}
+
+// Provide implements Provider.Provide.
+func (p *testingModifyingProvider) Provide(path string, digest []byte) (string, error) {
+	var err error
+	p.once.Do(func() {
+		err = os.WriteFile(p.path, []byte("concurrent"), 0600)
+	})
+	if err != nil {
+		return "", err
+	}
+	return p.Provider.Provide(path, digest)
+}
+
+// TestTransitionShadowDirectoryConcurrentModification tests that modifications
+// made to a directory while its shadow copy is in use aren't lost and that
+// transitions are reapplied in place in that case.
+func TestTransitionShadowDirectoryConcurrentModification(t *testing.T) {
+	// Create content and compute a transition that updates one file.
+	root, snapshot, cache := testingShadowSetup(t, []string{"file", "other"}, "old")
+	transitions := []*Change{{
+		Path: "directory/file",
+		Old:  snapshot.Content.Contents["directory"].Contents["file"],
+		New:  &Entry{Kind: EntryKind_File, Digest: testingDigest("new")},
+	}}
+
+	// Perform the transition using a provider that creates content in the
+	// original directory while the shadow copy is in use.
+	modified := filepath.Join(root, "directory", "concurrent")
+	provider := &testingModifyingProvider{
+		Provider: &testingProvider{
+			storage:    t.TempDir(),
+			contentMap: testingContentMap{"directory/file": []byte("new")},
+			hasher:     newTestingHasher(),
+		},
+		path: modified,
+	}
+	results, problems, missingFiles := Transition(
+		context.Background(),
+		root,
+		transitions,
+		cache,
+		SymbolicLinkMode_SymbolicLinkModePortable,
+		SymbolicLinkReplacementMode_SymbolicLinkReplacementModeReplace,
+		SymbolicLinkCycleMode_SymbolicLinkCycleModeAllow,
+		0600,
+		0700,
+		nil,
+		snapshot.DecomposesUnicode,
+		TransitionMode_TransitionModeShadowDirectory,
+		provider,
+		false,
+	)
+
+	// Verify transition results.
+	if len(problems) > 0 {
+		t.Fatal("transition encountered problems:", problems[0].Path, problems[0].Error)
+	} else if missingFiles {
+		t.Fatal("transition reported missing files")
+	} else if len(results) != 1 || !results[0].Equal(transitions[0].New, true) {
+		t.Fatal("transition results do not match expected")
+	}
+
+	// Verify that both the transition and the concurrent modification are
+	// reflected on disk.
+	if content, err := os.ReadFile(filepath.Join(root, "directory", "file")); err != nil {
+		t.Fatal("unable to read transitioned file:", err)
+	} else if string(content) != "new" {
+		t.Error("transitioned file has unexpected content:", string(content))
+	}
+	if content, err := os.ReadFile(modified); err != nil {
+		t.Fatal("concurrent modification lost:", err)
+	} else if string(content) != "concurrent" {
+		t.Error("concurrently modified file has unexpected content:", string(content))
+	}
+	testingShadowContainersRemoved(t, root)
+}
@@ -0,0 +1,13 @@
+package core
+
+import (
+	"io/fs"
+)
+
+// copyShadowOwnership sets the ownership of the entry at the specified path to
+// match that described by the specified metadata. Shadow directories aren't
+// used on Windows (since it doesn't support atomic exchange), so this function
+// is a no-op.
+func copyShadowOwnership(_ fs.FileInfo, _ string) error {
+	return nil
+}
@@ -0,0 +1,9 @@
+package core
+
+// SpecialFileMarkerContent is the content used for the regular marker files
+// that represent special files (e.g. FIFOs and sockets) when they're created by
+// transition operations. Scans performed in the placeholder special file mode
+// identify regular files with this exact content as special files, which is
+// what allows the marker and the original special file to be considered
+// equivalent.
+var SpecialFileMarkerContent = []byte("This file is a placeholder created by Mutagen to represent a special file (e.g. a FIFO or socket).\n")
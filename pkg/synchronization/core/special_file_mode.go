@@ -0,0 +1,72 @@
+package core
+
+import (
+	"fmt"
+)
+
+// IsDefault indicates whether or not the special file mode is
+// SpecialFileMode_SpecialFileModeDefault.
+func (m SpecialFileMode) IsDefault() bool {
+	return m == SpecialFileMode_SpecialFileModeDefault
+}
+
+// MarshalText implements encoding.TextMarshaler.MarshalText.
+func (m SpecialFileMode) MarshalText() ([]byte, error) {
+	var result string
+	switch m {
+	case SpecialFileMode_SpecialFileModeDefault:
+	case SpecialFileMode_SpecialFileModeIgnore:
+		result = "ignore"
+	case SpecialFileMode_SpecialFileModePlaceholder:
+		result = "placeholder"
+	default:
+		result = "unknown"
+	}
+	return []byte(result), nil
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler.UnmarshalText.
+func (m *SpecialFileMode) UnmarshalText(textBytes []byte) error {
+	// Convert the bytes to a string.
+	text := string(textBytes)
+
+	// Convert to a special file mode.
+	switch text {
+	case "ignore":
+		*m = SpecialFileMode_SpecialFileModeIgnore
+	case "placeholder":
+		*m = SpecialFileMode_SpecialFileModePlaceholder
+	default:
+		return fmt.Errorf("unknown special file mode specification: %s", text)
+	}
+
+	// Success.
+	return nil
+}
+
+// Supported indicates whether or not a particular special file mode is a
+// valid, non-default value.
+func (m SpecialFileMode) Supported() bool {
+	switch m {
+	case SpecialFileMode_SpecialFileModeIgnore:
+		return true
+	case SpecialFileMode_SpecialFileModePlaceholder:
+		return true
+	default:
+		return false
+	}
+}
+
+// Description returns a human-readable description of a special file mode.
+func (m SpecialFileMode) Description() string {
+	switch m {
+	case SpecialFileMode_SpecialFileModeDefault:
+		return "Default"
+	case SpecialFileMode_SpecialFileModeIgnore:
+		return "Ignore"
+	case SpecialFileMode_SpecialFileModePlaceholder:
+		return "Placeholder"
+	default:
+		return "Unknown"
+	}
+}
@@ -0,0 +1,112 @@
+package core
+
+import (
+	"testing"
+)
+
+// TestSpecialFileModeIsDefault tests SpecialFileMode.IsDefault.
+func TestSpecialFileModeIsDefault(t *testing.T) {
+	// Define test cases.
+	tests := []struct {
+		value    SpecialFileMode
+		expected bool
+	}{
+		{SpecialFileMode_SpecialFileModeDefault - 1, false},
+		{SpecialFileMode_SpecialFileModeDefault, true},
+		{SpecialFileMode_SpecialFileModeIgnore, false},
+		{SpecialFileMode_SpecialFileModePlaceholder, false},
+		{SpecialFileMode_SpecialFileModePlaceholder + 1, false},
+	}
+
+	// Process test cases.
+	for i, test := range tests {
+		if result := test.value.IsDefault(); result && !test.expected {
+			t.Errorf("test index %d: value was unexpectedly classified as default", i)
+		} else if !result && test.expected {
+			t.Errorf("test index %d: value was unexpectedly classified as non-default", i)
+		}
+	}
+}
+
+// TestSpecialFileModeUnmarshalText tests SpecialFileMode.UnmarshalText.
+func TestSpecialFileModeUnmarshalText(t *testing.T) {
+	// Define test cases.
+	tests := []struct {
+		text          string
+		expectedMode  SpecialFileMode
+		expectFailure bool
+	}{
+		{"", SpecialFileMode_SpecialFileModeDefault, true},
+		{"asdf", SpecialFileMode_SpecialFileModeDefault, true},
+		{"ignore", SpecialFileMode_SpecialFileModeIgnore, false},
+		{"placeholder", SpecialFileMode_SpecialFileModePlaceholder, false},
+	}
+
+	// Process test cases.
+	for _, test := range tests {
+		var mode SpecialFileMode
+		if err := mode.UnmarshalText([]byte(test.text)); err != nil {
+			if !test.expectFailure {
+				t.Errorf("unable to unmarshal text (%s): %s", test.text, err)
+			}
+		} else if test.expectFailure {
+			t.Error("unmarshaling succeeded unexpectedly for text:", test.text)
+		} else if mode != test.expectedMode {
+			t.Errorf(
+				"unmarshaled mode (%s) does not match expected (%s)",
+				mode,
+				test.expectedMode,
+			)
+		}
+	}
+}
+
+// TestSpecialFileModeSupported tests SpecialFileMode.Supported.
+func TestSpecialFileModeSupported(t *testing.T) {
+	// Set up test cases.
+	testCases := []struct {
+		mode            SpecialFileMode
+		expectSupported bool
+	}{
+		{SpecialFileMode_SpecialFileModeDefault, false},
+		{SpecialFileMode_SpecialFileModeIgnore, true},
+		{SpecialFileMode_SpecialFileModePlaceholder, true},
+		{(SpecialFileMode_SpecialFileModePlaceholder + 1), false},
+	}
+
+	// Process test cases.
+	for _, testCase := range testCases {
+		if supported := testCase.mode.Supported(); supported != testCase.expectSupported {
+			t.Errorf(
+				"mode support status (%t) does not match expected (%t)",
+				supported,
+				testCase.expectSupported,
+			)
+		}
+	}
+}
+
+// TestSpecialFileModeDescription tests SpecialFileMode.Description.
+func TestSpecialFileModeDescription(t *testing.T) {
+	// Set up test cases.
+	testCases := []struct {
+		mode                SpecialFileMode
+		expectedDescription string
+	}{
+		{SpecialFileMode_SpecialFileModeDefault, "Default"},
+		{SpecialFileMode_SpecialFileModeIgnore, "Ignore"},
+		{SpecialFileMode_SpecialFileModePlaceholder, "Placeholder"},
+		{(SpecialFileMode_SpecialFileModePlaceholder + 1), "Unknown"},
+	}
+
+	// Process test cases.
+	for _, testCase := range testCases {
+		if description := testCase.mode.Description(); description != testCase.expectedDescription {
+			t.Errorf(
+				"mode description (%s) does not match expected (%s)",
+				description,
+				testCase.expectedDescription,
+			)
+		}
+	}
+}
@@ -0,0 +1,135 @@
+package core
+
+import (
+	"strings"
+
+	"github.com/mutagen-io/mutagen/pkg/synchronization/core/fastpath"
+)
+
+// resolveSymbolicLinkTarget lexically resolves the target of the symbolic link
+// at the specified path, returning the synchronization-root-relative path that
+// it references. It returns false if the target is absolute or references a
+// location outside of the synchronization root (or if the symbolic link is the
+// synchronization root itself), in which case the target can't be resolved
+// within the synchronization root.
+func resolveSymbolicLinkTarget(path, target string) (string, bool) {
+	// Absolute targets (and targets relative to the synchronization root's
+	// parent) can't be resolved within the synchronization root.
+	if path == "" || target == "" || target[0] == '/' {
+		return "", false
+	}
+
+	// Start with the components of the symbolic link's parent path.
+	var components []string
+	if parent := fastpath.Dir(path); parent != "" {
+		components = strings.Split(parent, "/")
+	}
+
+	// Apply the target components.
+	for _, component := range strings.Split(target, "/") {
+		if component == "" || component == "." {
+			continue
+		} else if component == ".." {
+			if len(components) == 0 {
+				return "", false
+			}
+			components = components[:len(components)-1]
+		} else {
+			components = append(components, component)
+		}
+	}
+
+	// Done.
+	return strings.Join(components, "/"), true
+}
+
+// pathIsWithinOrEqual determines whether or not a synchronization-root-relative
+// path is equal to or contained within another.
+func pathIsWithinOrEqual(path, parent string) bool {
+	return parent == "" || path == parent || strings.HasPrefix(path, parent+"/")
+}
+
+// symbolicLinkFormsCycle determines whether or not the symbolic link at the
+// specified path forms a cycle, given a map of all symbolic links (and their
+// targets) being considered. A symbolic link forms a cycle if its resolution
+// (following any of the considered symbolic links referenced along the way)
+// leads back to a path at or beneath itself (which would make it unresolvable)
+// or to one of its ancestor directories (which would make its parent hierarchy
+// infinitely traversable). Symbolic links that merely lead into a cycle formed
+// by other symbolic links aren't considered to form a cycle themselves.
+func symbolicLinkFormsCycle(path string, links map[string]string) bool {
+	// Track the symbolic links visited during resolution.
+	visited := make(map[string]bool)
+
+	// Resolve the symbolic link, one symbolic link at a time.
+	link, remainder := path, ""
+	for {
+		// Record the visit.
+		visited[link] = true
+
+		// Resolve the current symbolic link and append any unresolved portion
+		// of the path being resolved.
+		resolved, ok := resolveSymbolicLinkTarget(link, links[link])
+		if !ok {
+			return false
+		} else if remainder != "" {
+			resolved = fastpath.Joinable(resolved) + remainder
+		}
+
+		// Check whether or not resolution has led back to the symbolic link or
+		// one of its ancestors.
+		if pathIsWithinOrEqual(resolved, path) || pathIsWithinOrEqual(path, resolved) {
+			return true
+		}
+
+		// Find the first considered symbolic link referenced by the resolved
+		// path. If there isn't one, then resolution is complete.
+		var found bool
+		components := strings.Split(resolved, "/")
+		for c := range components {
+			prefix := strings.Join(components[:c+1], "/")
+			if _, ok := links[prefix]; ok {
+				link, remainder = prefix, strings.Join(components[c+1:], "/")
+				found = true
+				break
+			}
+		}
+		if !found {
+			return false
+		}
+
+		// If we've already visited the symbolic link, then we've found a cycle
+		// that doesn't involve the original symbolic link.
+		if visited[link] {
+			return false
+		}
+	}
+}
+
+// findSymbolicLinkCycles identifies the symbolic links created by a set of
+// transitions that would form cycles (see symbolicLinkFormsCycle). Only the
+// symbolic links created by the transitions are considered, both as candidates
+// and for resolution; any other symbolic links within the synchronization root
+// are treated as non-existent. The result is a set of paths.
+func findSymbolicLinkCycles(transitions []*Change) map[string]bool {
+	// Collect the symbolic links created by the transitions.
+	links := make(map[string]string)
+	for _, transition := range transitions {
+		transition.New.walk(transition.Path, func(path string, entry *Entry) {
+			if entry != nil && entry.Kind == EntryKind_SymbolicLink {
+				links[path] = entry.Target
+			}
+		}, false)
+	}
+
+	// Identify cycles.
+	cycles := make(map[string]bool)
+	for path := range links {
+		if symbolicLinkFormsCycle(path, links) {
+			cycles[path] = true
+		}
+	}
+
+	// Done.
+	return cycles
+}
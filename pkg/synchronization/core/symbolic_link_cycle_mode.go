@@ -0,0 +1,72 @@
+package core
+
+import (
+	"fmt"
+)
+
+// IsDefault indicates whether or not the symbolic link cycle mode is
+// SymbolicLinkCycleMode_SymbolicLinkCycleModeDefault.
+func (m SymbolicLinkCycleMode) IsDefault() bool {
+	return m == SymbolicLinkCycleMode_SymbolicLinkCycleModeDefault
+}
+
+// MarshalText implements encoding.TextMarshaler.MarshalText.
+func (m SymbolicLinkCycleMode) MarshalText() ([]byte, error) {
+	var result string
+	switch m {
+	case SymbolicLinkCycleMode_SymbolicLinkCycleModeDefault:
+	case SymbolicLinkCycleMode_SymbolicLinkCycleModeAllow:
+		result = "allow"
+	case SymbolicLinkCycleMode_SymbolicLinkCycleModeRefuse:
+		result = "refuse"
+	default:
+		result = "unknown"
+	}
+	return []byte(result), nil
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler.UnmarshalText.
+func (m *SymbolicLinkCycleMode) UnmarshalText(textBytes []byte) error {
+	// Convert the bytes to a string.
+	text := string(textBytes)
+
+	// Convert to a symbolic link cycle mode.
+	switch text {
+	case "allow":
+		*m = SymbolicLinkCycleMode_SymbolicLinkCycleModeAllow
+	case "refuse":
+		*m = SymbolicLinkCycleMode_SymbolicLinkCycleModeRefuse
+	default:
+		return fmt.Errorf("unknown symbolic link cycle mode specification: %s", text)
+	}
+
+	// Success.
+	return nil
+}
+
+// Supported indicates whether or not a particular symbolic link cycle mode is a
+// valid, non-default value.
+func (m SymbolicLinkCycleMode) Supported() bool {
+	switch m {
+	case SymbolicLinkCycleMode_SymbolicLinkCycleModeAllow:
+		return true
+	case SymbolicLinkCycleMode_SymbolicLinkCycleModeRefuse:
+		return true
+	default:
+		return false
+	}
+}
+
+// Description returns a human-readable description of a symbolic link cycle mode.
+func (m SymbolicLinkCycleMode) Description() string {
+	switch m {
+	case SymbolicLinkCycleMode_SymbolicLinkCycleModeDefault:
+		return "Default"
+	case SymbolicLinkCycleMode_SymbolicLinkCycleModeAllow:
+		return "Allow"
+	case SymbolicLinkCycleMode_SymbolicLinkCycleModeRefuse:
+		return "Refuse"
+	default:
+		return "Unknown"
+	}
+}
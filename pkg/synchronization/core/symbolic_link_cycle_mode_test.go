@@ -0,0 +1,112 @@
+package core
+
+import (
+	"testing"
+)
+
+// TestSymbolicLinkCycleModeIsDefault tests SymbolicLinkCycleMode.IsDefault.
+func TestSymbolicLinkCycleModeIsDefault(t *testing.T) {
+	// Define test cases.
+	tests := []struct {
+		value    SymbolicLinkCycleMode
+		expected bool
+	}{
+		{SymbolicLinkCycleMode_SymbolicLinkCycleModeDefault - 1, false},
+		{SymbolicLinkCycleMode_SymbolicLinkCycleModeDefault, true},
+		{SymbolicLinkCycleMode_SymbolicLinkCycleModeAllow, false},
+		{SymbolicLinkCycleMode_SymbolicLinkCycleModeRefuse, false},
+		{SymbolicLinkCycleMode_SymbolicLinkCycleModeRefuse + 1, false},
+	}
+
+	// Process test cases.
+	for i, test := range tests {
+		if result := test.value.IsDefault(); result && !test.expected {
+			t.Errorf("test index %d: value was unexpectedly classified as default", i)
+		} else if !result && test.expected {
+			t.Errorf("test index %d: value was unexpectedly classified as non-default", i)
+		}
+	}
+}
+
+// TestSymbolicLinkCycleModeUnmarshalText tests SymbolicLinkCycleMode.UnmarshalText.
+func TestSymbolicLinkCycleModeUnmarshalText(t *testing.T) {
+	// Define test cases.
+	tests := []struct {
+		text          string
+		expectedMode  SymbolicLinkCycleMode
+		expectFailure bool
+	}{
+		{"", SymbolicLinkCycleMode_SymbolicLinkCycleModeDefault, true},
+		{"asdf", SymbolicLinkCycleMode_SymbolicLinkCycleModeDefault, true},
+		{"allow", SymbolicLinkCycleMode_SymbolicLinkCycleModeAllow, false},
+		{"refuse", SymbolicLinkCycleMode_SymbolicLinkCycleModeRefuse, false},
+	}
+
+	// Process test cases.
+	for _, test := range tests {
+		var mode SymbolicLinkCycleMode
+		if err := mode.UnmarshalText([]byte(test.text)); err != nil {
+			if !test.expectFailure {
+				t.Errorf("unable to unmarshal text (%s): %s", test.text, err)
+			}
+		} else if test.expectFailure {
+			t.Error("unmarshaling succeeded unexpectedly for text:", test.text)
+		} else if mode != test.expectedMode {
+			t.Errorf(
+				"unmarshaled mode (%s) does not match expected (%s)",
+				mode,
+				test.expectedMode,
+			)
+		}
+	}
+}
+
+// TestSymbolicLinkCycleModeSupported tests SymbolicLinkCycleMode.Supported.
+func TestSymbolicLinkCycleModeSupported(t *testing.T) {
+	// Set up test cases.
+	testCases := []struct {
+		mode            SymbolicLinkCycleMode
+		expectSupported bool
+	}{
+		{SymbolicLinkCycleMode_SymbolicLinkCycleModeDefault, false},
+		{SymbolicLinkCycleMode_SymbolicLinkCycleModeAllow, true},
+		{SymbolicLinkCycleMode_SymbolicLinkCycleModeRefuse, true},
+		{(SymbolicLinkCycleMode_SymbolicLinkCycleModeRefuse + 1), false},
+	}
+
+	// Process test cases.
+	for _, testCase := range testCases {
+		if supported := testCase.mode.Supported(); supported != testCase.expectSupported {
+			t.Errorf(
+				"mode support status (%t) does not match expected (%t)",
+				supported,
+				testCase.expectSupported,
+			)
+		}
+	}
+}
+
+// TestSymbolicLinkCycleModeDescription tests SymbolicLinkCycleMode.Description.
+func TestSymbolicLinkCycleModeDescription(t *testing.T) {
+	// Set up test cases.
+	testCases := []struct {
+		mode                SymbolicLinkCycleMode
+		expectedDescription string
+	}{
+		{SymbolicLinkCycleMode_SymbolicLinkCycleModeDefault, "Default"},
+		{SymbolicLinkCycleMode_SymbolicLinkCycleModeAllow, "Allow"},
+		{SymbolicLinkCycleMode_SymbolicLinkCycleModeRefuse, "Refuse"},
+		{(SymbolicLinkCycleMode_SymbolicLinkCycleModeRefuse + 1), "Unknown"},
+	}
+
+	// Process test cases.
+	for _, testCase := range testCases {
+		if description := testCase.mode.Description(); description != testCase.expectedDescription {
+			t.Errorf(
+				"mode description (%s) does not match expected (%s)",
+				description,
+				testCase.expectedDescription,
+			)
+		}
+	}
+}
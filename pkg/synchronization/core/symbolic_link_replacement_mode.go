@@ -0,0 +1,73 @@
+package core
+
+import (
+	"fmt"
+)
+
+// IsDefault indicates whether or not the symbolic link replacement mode is
+// SymbolicLinkReplacementMode_SymbolicLinkReplacementModeDefault.
+func (m SymbolicLinkReplacementMode) IsDefault() bool {
+	return m == SymbolicLinkReplacementMode_SymbolicLinkReplacementModeDefault
+}
+
+// MarshalText implements encoding.TextMarshaler.MarshalText.
+func (m SymbolicLinkReplacementMode) MarshalText() ([]byte, error) {
+	var result string
+	switch m {
+	case SymbolicLinkReplacementMode_SymbolicLinkReplacementModeDefault:
+	case SymbolicLinkReplacementMode_SymbolicLinkReplacementModeRequireEmpty:
+		result = "require-empty"
+	case SymbolicLinkReplacementMode_SymbolicLinkReplacementModeReplace:
+		result = "replace"
+	default:
+		result = "unknown"
+	}
+	return []byte(result), nil
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler.UnmarshalText.
+func (m *SymbolicLinkReplacementMode) UnmarshalText(textBytes []byte) error {
+	// Convert the bytes to a string.
+	text := string(textBytes)
+
+	// Convert to a symbolic link replacement mode.
+	switch text {
+	case "require-empty":
+		*m = SymbolicLinkReplacementMode_SymbolicLinkReplacementModeRequireEmpty
+	case "replace":
+		*m = SymbolicLinkReplacementMode_SymbolicLinkReplacementModeReplace
+	default:
+		return fmt.Errorf("unknown symbolic link replacement mode specification: %s", text)
+	}
+
+	// Success.
+	return nil
+}
+
+// Supported indicates whether or not a particular symbolic link replacement
+// mode is a valid, non-default value.
+func (m SymbolicLinkReplacementMode) Supported() bool {
+	switch m {
+	case SymbolicLinkReplacementMode_SymbolicLinkReplacementModeRequireEmpty:
+		return true
+	case SymbolicLinkReplacementMode_SymbolicLinkReplacementModeReplace:
+		return true
+	default:
+		return false
+	}
+}
+
+// Description returns a human-readable description of a symbolic link
+// replacement mode.
+func (m SymbolicLinkReplacementMode) Description() string {
+	switch m {
+	case SymbolicLinkReplacementMode_SymbolicLinkReplacementModeDefault:
+		return "Default"
+	case SymbolicLinkReplacementMode_SymbolicLinkReplacementModeRequireEmpty:
+		return "Require Empty"
+	case SymbolicLinkReplacementMode_SymbolicLinkReplacementModeReplace:
+		return "Replace"
+	default:
+		return "Unknown"
+	}
+}
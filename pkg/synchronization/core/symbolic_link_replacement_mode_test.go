@@ -0,0 +1,116 @@
+package core
+
+import (
+	"testing"
+)
+
+// TestSymbolicLinkReplacementModeIsDefault tests
+// SymbolicLinkReplacementMode.IsDefault.
+func TestSymbolicLinkReplacementModeIsDefault(t *testing.T) {
+	// Define test cases.
+	tests := []struct {
+		value    SymbolicLinkReplacementMode
+		expected bool
+	}{
+		{SymbolicLinkReplacementMode_SymbolicLinkReplacementModeDefault - 1, false},
+		{SymbolicLinkReplacementMode_SymbolicLinkReplacementModeDefault, true},
+		{SymbolicLinkReplacementMode_SymbolicLinkReplacementModeRequireEmpty, false},
+		{SymbolicLinkReplacementMode_SymbolicLinkReplacementModeReplace, false},
+		{SymbolicLinkReplacementMode_SymbolicLinkReplacementModeReplace + 1, false},
+	}
+
+	// Process test cases.
+	for i, test := range tests {
+		if result := test.value.IsDefault(); result && !test.expected {
+			t.Errorf("test index %d: value was unexpectedly classified as default", i)
+		} else if !result && test.expected {
+			t.Errorf("test index %d: value was unexpectedly classified as non-default", i)
+		}
+	}
+}
+
+// TestSymbolicLinkReplacementModeUnmarshalText tests
+// SymbolicLinkReplacementMode.UnmarshalText.
+func TestSymbolicLinkReplacementModeUnmarshalText(t *testing.T) {
+	// Define test cases.
+	tests := []struct {
+		text          string
+		expectedMode  SymbolicLinkReplacementMode
+		expectFailure bool
+	}{
+		{"", SymbolicLinkReplacementMode_SymbolicLinkReplacementModeDefault, true},
+		{"asdf", SymbolicLinkReplacementMode_SymbolicLinkReplacementModeDefault, true},
+		{"require-empty", SymbolicLinkReplacementMode_SymbolicLinkReplacementModeRequireEmpty, false},
+		{"replace", SymbolicLinkReplacementMode_SymbolicLinkReplacementModeReplace, false},
+	}
+
+	// Process test cases.
+	for _, test := range tests {
+		var mode SymbolicLinkReplacementMode
+		if err := mode.UnmarshalText([]byte(test.text)); err != nil {
+			if !test.expectFailure {
+				t.Errorf("unable to unmarshal text (%s): %s", test.text, err)
+			}
+		} else if test.expectFailure {
+			t.Error("unmarshaling succeeded unexpectedly for text:", test.text)
+		} else if mode != test.expectedMode {
+			t.Errorf(
+				"unmarshaled mode (%s) does not match expected (%s)",
+				mode,
+				test.expectedMode,
+			)
+		}
+	}
+}
+
+// TestSymbolicLinkReplacementModeSupported tests
+// SymbolicLinkReplacementMode.Supported.
+func TestSymbolicLinkReplacementModeSupported(t *testing.T) {
+	// Set up test cases.
+	testCases := []struct {
+		mode            SymbolicLinkReplacementMode
+		expectSupported bool
+	}{
+		{SymbolicLinkReplacementMode_SymbolicLinkReplacementModeDefault, false},
+		{SymbolicLinkReplacementMode_SymbolicLinkReplacementModeRequireEmpty, true},
+		{SymbolicLinkReplacementMode_SymbolicLinkReplacementModeReplace, true},
+		{(SymbolicLinkReplacementMode_SymbolicLinkReplacementModeReplace + 1), false},
+	}
+
+	// Process test cases.
+	for _, testCase := range testCases {
+		if supported := testCase.mode.Supported(); supported != testCase.expectSupported {
+			t.Errorf(
+				"mode support status (%t) does not match expected (%t)",
+				supported,
+				testCase.expectSupported,
+			)
+		}
+	}
+}
+
+// TestSymbolicLinkReplacementModeDescription tests
+// SymbolicLinkReplacementMode.Description.
+func TestSymbolicLinkReplacementModeDescription(t *testing.T) {
+	// Set up test cases.
+	testCases := []struct {
+		mode                SymbolicLinkReplacementMode
+		expectedDescription string
+	}{
+		{SymbolicLinkReplacementMode_SymbolicLinkReplacementModeDefault, "Default"},
+		{SymbolicLinkReplacementMode_SymbolicLinkReplacementModeRequireEmpty, "Require Empty"},
+		{SymbolicLinkReplacementMode_SymbolicLinkReplacementModeReplace, "Replace"},
+		{(SymbolicLinkReplacementMode_SymbolicLinkReplacementModeReplace + 1), "Unknown"},
+	}
+
+	// Process test cases.
+	for _, testCase := range testCases {
+		if description := testCase.mode.Description(); description != testCase.expectedDescription {
+			t.Errorf(
+				"mode description (%s) does not match expected (%s)",
+				description,
+				testCase.expectedDescription,
+			)
+		}
+	}
+}
@@ -93,11 +93,15 @@ func (g *testingContentManager) generate() (string, error) {
 		[]*Change{creation},
 		nil,
 		SymbolicLinkMode_SymbolicLinkModePOSIXRaw,
+		SymbolicLinkReplacementMode_SymbolicLinkReplacementModeReplace,
+		SymbolicLinkCycleMode_SymbolicLinkCycleModeAllow,
 		0600,
 		0700,
 		nil,
 		false,
+		TransitionMode_TransitionModeInPlace,
 		provider,
+		false,
 	)
 	if missingFiles {
 		return "", errors.New("content map missing file definitions")
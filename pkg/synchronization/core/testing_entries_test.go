@@ -1,5 +1,9 @@
 package core
 
+import (
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
 // nested creates a directory entry containing an entry with the specified name.
 func nested(name string, entry *Entry) *Entry {
 	return &Entry{Contents: map[string]*Entry{name: entry}}
@@ -42,12 +46,22 @@ var tF3 = &Entry{Kind: EntryKind_File, Digest: testingDigest(tF3Content)}
 // tF3E is an executable version of tF3 for testing.
 var tF3E = &Entry{Kind: EntryKind_File, Digest: testingDigest(tF3Content), Executable: true}
 
+// tF1M is a version of tF1 with a modification time for testing.
+var tF1M = &Entry{
+	Kind:             EntryKind_File,
+	Digest:           testingDigest(tF1Content),
+	ModificationTime: &timestamppb.Timestamp{Seconds: 1},
+}
+
 // tSR is a symbolic link entry with a relative path for testing.
 var tSR = &Entry{Kind: EntryKind_SymbolicLink, Target: "file"}
 
 // tSA is a symbolic link entry with an absolute path for testing.
 var tSA = &Entry{Kind: EntryKind_SymbolicLink, Target: "/path/file"}
 
+// tX is a special file entry for testing.
+var tX = &Entry{Kind: EntryKind_SpecialFile}
+
 // tU is an untracked entry for testing.
 var tU = &Entry{Kind: EntryKind_Untracked}
 
@@ -60,6 +74,9 @@ var tP2 = &Entry{Kind: EntryKind_Problematic, Problem: "another bad thing happen
 // tPInvalidUTF8 is a problematic entry indicating non-UTF-8 filename encoding.
 var tPInvalidUTF8 = &Entry{Kind: EntryKind_Problematic, Problem: "non-UTF-8 filename"}
 
+// tO is an oversized entry for testing.
+var tO = &Entry{Kind: EntryKind_Oversized}
+
 // tPD0 is an empty phantom directory for testing.
 var tPD0 = &Entry{Kind: EntryKind_PhantomDirectory}
 
@@ -89,6 +106,13 @@ var tD0 = &Entry{}
 // tD1 is a directory entry (containing tF1 with name "file") for testing.
 var tD1 = &Entry{Contents: map[string]*Entry{"file": tF1}}
 
+// tD1A is a directory entry (identical to tD1, but with an aggregate digest)
+// for testing.
+var tD1A = &Entry{
+	Contents:        tD1.Contents,
+	AggregateDigest: aggregateDigest(newTestingHasher(), tD1.Contents),
+}
+
 // tD2 is a directory entry (containing tF2 with name "file") for testing.
 var tD2 = &Entry{Contents: map[string]*Entry{"file": tF2}}
 
@@ -172,9 +196,36 @@ var tDMU = &Entry{Contents: map[string]*Entry{
 	"untracked":                     tU,
 }}
 
+// tDRC is a directory entry (containing tF1 with name "README", tF2 with name
+// "readme", and tF3E with name "other") for testing.
+var tDRC = &Entry{Contents: map[string]*Entry{
+	"README": tF1,
+	"readme": tF2,
+	"other":  tF3E,
+}}
+
+// tDRCP is a version of tDRC where the entries whose names differ only in case
+// have been replaced by problematic entries indicating case collisions.
+var tDRCP = &Entry{Contents: map[string]*Entry{
+	"README": {
+		Kind:        EntryKind_Problematic,
+		Problem:     "name differs only in case from \"readme\"",
+		ProblemCode: ProblemCode_ProblemCodeCaseCollision,
+	},
+	"readme": {
+		Kind:        EntryKind_Problematic,
+		Problem:     "name differs only in case from \"README\"",
+		ProblemCode: ProblemCode_ProblemCodeCaseCollision,
+	},
+	"other": tF3E,
+}}
+
 // tDU is a directory entry (containing tU with name "untracked") for testing.
 var tDU = &Entry{Contents: map[string]*Entry{"untracked": tU}}
 
+// tDO is a directory entry (containing tO with name "oversized") for testing.
+var tDO = &Entry{Contents: map[string]*Entry{"oversized": tO}}
+
 // tDP1 is a directory entry (containing tP1 with name "problematic") for
 // testing.
 var tDP1 = &Entry{Contents: map[string]*Entry{"problematic": tP1}}
@@ -186,6 +237,9 @@ var tDP2 = &Entry{Contents: map[string]*Entry{"problematic": tP2}}
 // tDPD0 is a directory entry (containing tPD0 with name "phantom") for testing.
 var tDPD0 = &Entry{Contents: map[string]*Entry{"phantom": tPD0}}
 
+// tIDM is an invalid directory entry (with a modification time) for testing.
+var tIDM = &Entry{ModificationTime: &timestamppb.Timestamp{Seconds: 1}}
+
 // tIDDE is an invalid directory entry (with an empty but non-nil file digest)
 // for testing.
 var tIDDE = &Entry{Digest: []byte{}}
@@ -234,6 +288,13 @@ var tIFT = &Entry{Kind: EntryKind_File, Target: "invalid target"}
 // tIFP is an invalid file entry (with a problem) for testing.
 var tIFP = &Entry{Kind: EntryKind_File, Problem: "invalid problem"}
 
+// tIFA is an invalid file entry (with an aggregate digest) for testing.
+var tIFA = &Entry{
+	Kind:            EntryKind_File,
+	Digest:          testingDigest(tF1Content),
+	AggregateDigest: testingDigest("invalid aggregate"),
+}
+
 // tIFDN is an invalid file entry (with a nil digest) for testing.
 var tIFDN = &Entry{Kind: EntryKind_File}
 
@@ -261,9 +322,32 @@ var tISE = &Entry{Kind: EntryKind_SymbolicLink, Executable: true}
 // tISP is an invalid symbolic link entry (with a problem) for testing.
 var tISP = &Entry{Kind: EntryKind_SymbolicLink, Problem: "invalid problem"}
 
+// tISM is an invalid symbolic link entry (with a modification time) for
+// testing.
+var tISM = &Entry{
+	Kind:             EntryKind_SymbolicLink,
+	Target:           "file",
+	ModificationTime: &timestamppb.Timestamp{Seconds: 1},
+}
+
 // tISTE is an invalid symbolic link entry (with an empty target) for testing.
 var tISTE = &Entry{Kind: EntryKind_SymbolicLink}
 
+// tIXC is an invalid special file entry (with a content map) for testing.
+var tIXC = &Entry{Kind: EntryKind_SpecialFile, Contents: map[string]*Entry{"file": tF1}}
+
+// tIXD is an invalid special file entry (with a digest) for testing.
+var tIXD = &Entry{Kind: EntryKind_SpecialFile, Digest: tF1.Digest}
+
+// tIXE is an invalid special file entry (marked executable) for testing.
+var tIXE = &Entry{Kind: EntryKind_SpecialFile, Executable: true}
+
+// tIXT is an invalid special file entry (with a target) for testing.
+var tIXT = &Entry{Kind: EntryKind_SpecialFile, Target: "file"}
+
+// tIXP is an invalid special file entry (with a problem) for testing.
+var tIXP = &Entry{Kind: EntryKind_SpecialFile, Problem: "invalid problem"}
+
 // tIUCE is an invalid untracked entry (with an empty but non-nil content
 // map) for testing.
 var tIUCE = &Entry{Kind: EntryKind_Untracked, Contents: map[string]*Entry{}}
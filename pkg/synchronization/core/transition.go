@@ -24,6 +24,14 @@ const (
 	// intermediate temporary files used in cross-device renames.
 	crossDeviceRenameTemporaryNamePrefix = filesystem.TemporaryNamePrefix + "cross-device-rename"
 
+	// specialFileMarkerTemporaryNamePrefix is the file name prefix to use for
+	// intermediate temporary files used when creating special file markers.
+	specialFileMarkerTemporaryNamePrefix = filesystem.TemporaryNamePrefix + "special-file-marker"
+
+	// shadowDirectoryTemporaryNamePrefix is the file name prefix to use for
+	// the temporary containers that hold shadow directories.
+	shadowDirectoryTemporaryNamePrefix = filesystem.TemporaryNamePrefix + "shadow-directory"
+
 	// transitionCopyBufferSize specifies the size of the internal buffer that a
 	// transitioner uses to copy file data (e.g. when performing cross-device
 	// renames).
@@ -50,7 +58,8 @@ var (
 type Provider interface {
 	// Provide returns a filesystem path to a file containing the contents
 	// with the expected path and content digest. The provider does not need to
-	// ensure that the file exists.
+	// ensure that the file exists, but errors that it returns wrapping
+	// fs.ErrNotExist are treated as indicating missing staged content.
 	Provide(path string, digest []byte) (string, error)
 }
 
@@ -58,12 +67,21 @@ type Provider interface {
 type transitioner struct {
 	// cancelled is the cancellation channel from the transition context.
 	cancelled <-chan struct{}
-	// root is the path to the synchronization root.
+	// root is the path to the synchronization root. While performing
+	// transitions within a shadow directory, it is temporarily set to the path
+	// of the shadow container, which mirrors the layout of the root.
 	root string
 	// cache is the file digest cache generated by scan.
 	cache *Cache
 	// symbolicLinkMode is the symbolic link mode being used.
 	symbolicLinkMode SymbolicLinkMode
+	// symbolicLinkReplacementMode is the symbolic link replacement mode being
+	// used.
+	symbolicLinkReplacementMode SymbolicLinkReplacementMode
+	// symbolicLinkCycles is the set of paths at which symbolic links would
+	// form cycles and thus shouldn't be created. It is nil if symbolic link
+	// cycles are allowed.
+	symbolicLinkCycles map[string]bool
 	// defaultFileMode is the default file permission mode to use when creating
 	// and updating files. If executability information is being propagated,
 	// then it will be used as a base to construct final file permissions.
@@ -87,11 +105,15 @@ type transitioner struct {
 	// providerMissingFiles indicates that the staged file provider returned an
 	// os.IsNotExist error for at least one file that was expected to be staged.
 	providerMissingFiles bool
+	// dryRun indicates that transitions should only be validated. In this
+	// mode, the just-in-time checks against on-disk content are performed, but
+	// the filesystem isn't modified and the provider isn't invoked.
+	dryRun bool
 }
 
 // recordProblem records a new problem.
 func (t *transitioner) recordProblem(path string, err error) {
-	t.problems = append(t.problems, &Problem{Path: path, Error: err.Error()})
+	t.problems = append(t.problems, &Problem{Path: path, Error: err.Error(), Code: ProblemCodeForError(err)})
 }
 
 // nameExistsInDirectoryWithProperCase is a utility method that checks if a name
@@ -281,9 +303,9 @@ func (t *transitioner) ensureExpectedFile(parent *filesystem.Directory, name, pa
 		metadata.ModificationTime.Equal(cached.ModificationTime.AsTime()) &&
 		metadata.Size == cached.Size &&
 		metadata.FileID == cached.FileID &&
-		bytes.Equal(cached.Digest, expected.Digest)
+		cachedDigestMatches(cached.Digest, expected.Digest)
 	if !match {
-		return errors.New("modification detected")
+		return errModificationDetected
 	}
 
 	// Success.
@@ -318,6 +340,41 @@ func (t *transitioner) ensureExpectedSymbolicLink(parent *filesystem.Directory,
 	return nil
 }
 
+// ensureExpectedSpecialFile ensures that the content specified by name within
+// the specified directory is either a special file or a special file marker.
+func (t *transitioner) ensureExpectedSpecialFile(parent *filesystem.Directory, name, path string) error {
+	// Grab metadata for this path.
+	metadata, err := parent.ReadContentMetadata(name)
+	if err != nil {
+		return fmt.Errorf("unable to grab special file statistics: %w", err)
+	}
+
+	// Handle validation based on type. Actual special files don't have any
+	// content that we can validate. Marker files are validated against the
+	// cache (which will only contain an entry for them if the scan identified
+	// them as markers) using the same metadata comparison as ensureExpectedFile.
+	switch metadata.Mode & filesystem.ModeTypeMask {
+	case filesystem.ModeTypeNamedPipe, filesystem.ModeTypeSocket:
+		return nil
+	case filesystem.ModeTypeFile:
+		cached, ok := t.cache.Entries[path]
+		if !ok {
+			return errors.New("unable to find cache information for path")
+		}
+		match := metadata.Mode == filesystem.Mode(cached.Mode) &&
+			metadata.ModificationTime.Equal(cached.ModificationTime.AsTime()) &&
+			metadata.Size == cached.Size &&
+			metadata.FileID == cached.FileID &&
+			metadata.Size == uint64(len(SpecialFileMarkerContent))
+		if !match {
+			return errModificationDetected
+		}
+		return nil
+	default:
+		return errors.New("unexpected content type")
+	}
+}
+
 // removeFile removes the file specified by name within the specified directory,
 // enforcing that it matches the specified entry.
 func (t *transitioner) removeFile(parent *filesystem.Directory, name, path string, expected *Entry) error {
@@ -327,6 +384,11 @@ func (t *transitioner) removeFile(parent *filesystem.Directory, name, path strin
 		return fmt.Errorf("unable to validate existing file: %w", err)
 	}
 
+	// If we're performing a dry run, then we're done.
+	if t.dryRun {
+		return nil
+	}
+
 	// RACE: There is a race condition here between the file check and the file
 	// removal that we have to live with due to limitations in filesystem APIs.
 	// The worst case fallout is removal of contents that are modified during
@@ -350,6 +412,11 @@ func (t *transitioner) removeSymbolicLink(parent *filesystem.Directory, name, pa
 		return fmt.Errorf("unable to validate existing symbolic link: %w", err)
 	}
 
+	// If we're performing a dry run, then we're done.
+	if t.dryRun {
+		return nil
+	}
+
 	// RACE: There is a race condition here between the symbolic link check and
 	// the symbolic link removal that we have to live with due to limitations in
 	// filesystem APIs. The worst case fallout is removal of contents that are
@@ -359,6 +426,30 @@ func (t *transitioner) removeSymbolicLink(parent *filesystem.Directory, name, pa
 	return parent.RemoveSymbolicLink(name)
 }
 
+// removeSpecialFile removes the special file (or special file marker)
+// specified by name within the specified directory.
+func (t *transitioner) removeSpecialFile(parent *filesystem.Directory, name, path string) error {
+	// Ensure that the existing content hasn't been modified from what we're
+	// expecting.
+	if err := t.ensureExpectedSpecialFile(parent, name, path); err != nil {
+		return fmt.Errorf("unable to validate existing special file: %w", err)
+	}
+
+	// If we're performing a dry run, then we're done.
+	if t.dryRun {
+		return nil
+	}
+
+	// RACE: There is a race condition here between the special file check and
+	// the special file removal that we have to live with due to limitations in
+	// filesystem APIs. The worst case fallout is removal of contents that are
+	// modified during this window.
+
+	// Attempt to remove the special file. File removal works for special files
+	// as well.
+	return parent.RemoveFile(name)
+}
+
 // removeDirectory (recursively) removes the directory specified by name within
 // the specified directory, enforcing that it matches the specified entry. If
 // only a portion of the directory can be removed, the provided entry will be
@@ -446,6 +537,12 @@ ContentLoop:
 				t.recordProblem(contentPath, fmt.Errorf("unable to remove symbolic link: %w", err))
 				continue
 			}
+		} else if entry.Kind == EntryKind_SpecialFile {
+			if err = t.removeSpecialFile(directory, contentName, contentPath); err != nil {
+				contentRemovalFailed = true
+				t.recordProblem(contentPath, fmt.Errorf("unable to remove special file: %w", err))
+				continue
+			}
 		} else {
 			contentRemovalFailed = true
 			t.recordProblem(contentPath, errors.New("unknown entry type found in removal target"))
@@ -488,9 +585,12 @@ ContentLoop:
 
 	// If we weren't cancelled, didn't encounter any unknown content, and we
 	// successfully removed all on-disk content, then we can attempt to remove
-	// the directory itself.
+	// the directory itself (unless we're performing a dry run, in which case
+	// the removal is assumed to succeed).
 	if !cancelled && !unknownContentEncountered && !contentRemovalFailed {
-		if err := parent.RemoveDirectory(name); err != nil {
+		if t.dryRun {
+			return true
+		} else if err := parent.RemoveDirectory(name); err != nil {
 			t.recordProblem(path, fmt.Errorf("unable to remove directory: %w", err))
 		} else {
 			return true
@@ -540,6 +640,11 @@ func (t *transitioner) remove(path string, entry *Entry) *Entry {
 			t.recordProblem(path, fmt.Errorf("unable to remove symbolic link: %w", err))
 			return entry
 		}
+	} else if entry.Kind == EntryKind_SpecialFile {
+		if err := t.removeSpecialFile(parent, name, path); err != nil {
+			t.recordProblem(path, fmt.Errorf("unable to remove special file: %w", err))
+			return entry
+		}
 	} else {
 		t.recordProblem(path, errors.New("removal requested for unknown entry type"))
 		return entry
@@ -561,6 +666,12 @@ func (t *transitioner) findAndMoveStagedFileIntoPlace(
 	name string,
 	replace bool,
 ) error {
+	// If we're performing a dry run, then we don't check for the staged file,
+	// so there's nothing to do.
+	if t.dryRun {
+		return nil
+	}
+
 	// Compute the new file mode. If we're in a mode where executability
 	// information is being propagated, then we'll already have enforced that
 	// the default file mode doesn't contain executability bits, and therefore
@@ -575,9 +686,13 @@ func (t *transitioner) findAndMoveStagedFileIntoPlace(
 
 	// Compute the path to the staged file. This does not ensure that the file
 	// exists, which we'll instead detect when setting permissions or attempting
-	// to rename or copy the file into place.
+	// to rename or copy the file into place, though the provider may indicate
+	// non-existence directly (e.g. if staged content failed verification).
 	stagedPath, err := t.provider.Provide(path, target.Digest)
 	if err != nil {
+		if errors.Is(err, fs.ErrNotExist) {
+			t.providerMissingFiles = true
+		}
 		return fmt.Errorf("unable to compute staged file path: %w", err)
 	}
 
@@ -705,6 +820,11 @@ func (t *transitioner) swapFile(path string, oldEntry, newEntry *Entry) error {
 		return fmt.Errorf("unable to validate existing file: %w", err)
 	}
 
+	// If we're performing a dry run, then we're done.
+	if t.dryRun {
+		return nil
+	}
+
 	// RACE: There is a race condition here between the file check and the file
 	// replacement that we have to live with due to limitations in filesystem
 	// APIs. The worst case fallout is replacement of contents that are modified
@@ -758,6 +878,16 @@ func (t *transitioner) createSymbolicLink(parent *filesystem.Directory, name, pa
 		}
 	}
 
+	// Verify that the symbolic link won't form a cycle.
+	if t.symbolicLinkCycles[path] {
+		return errors.New("symbolic link would form a cycle")
+	}
+
+	// If we're performing a dry run, then we're done.
+	if t.dryRun {
+		return nil
+	}
+
 	// Create the symbolic link.
 	if err := parent.CreateSymbolicLink(name, target.Target); err != nil {
 		return err
@@ -793,14 +923,60 @@ func (t *transitioner) createSymbolicLink(parent *filesystem.Directory, name, pa
 	return nil
 }
 
+// createSpecialFile creates a marker file representing the target special file
+// at the specified path.
+func (t *transitioner) createSpecialFile(parent *filesystem.Directory, name string) error {
+	// If we're performing a dry run, then there's nothing to validate.
+	if t.dryRun {
+		return nil
+	}
+
+	// Create a temporary file in the target directory. We can't defer its
+	// closure because we'll want to rename it or remove it on failure, which we
+	// can't do (on some platforms, notably Windows) if the file handle is open.
+	temporaryName, temporary, err := parent.CreateTemporaryFile(specialFileMarkerTemporaryNamePrefix)
+	if err != nil {
+		return fmt.Errorf("unable to create temporary file for special file marker: %w", err)
+	}
+
+	// Write the marker content.
+	_, writeErr := temporary.Write(SpecialFileMarkerContent)
+	temporary.Close()
+	if writeErr != nil {
+		parent.RemoveFile(temporaryName)
+		return fmt.Errorf("unable to write special file marker content: %w", writeErr)
+	}
+
+	// Set permissions on the temporary file. Special files are never marked as
+	// executable, so we just use the default file mode.
+	if err := parent.SetPermissions(temporaryName, t.defaultOwnership, t.defaultFileMode); err != nil {
+		parent.RemoveFile(temporaryName)
+		return fmt.Errorf("unable to set special file marker permissions: %w", err)
+	}
+
+	// Rename the file into place.
+	if err := filesystem.Rename(parent, temporaryName, parent, name, false); err != nil {
+		parent.RemoveFile(temporaryName)
+		return fmt.Errorf("unable to relocate special file marker: %w", err)
+	}
+
+	// Success.
+	return nil
+}
+
 // createDirectory creates the target directory at the specified path. If only a
 // portion of the directory can be created, an entry representing that portion
 // will be returned.
 func (t *transitioner) createDirectory(parent *filesystem.Directory, name, path string, target *Entry) *Entry {
-	// Attempt to create the directory.
-	if err := parent.CreateDirectory(name); err != nil {
-		t.recordProblem(path, fmt.Errorf("unable to create directory: %w", err))
-		return nil
+	// Attempt to create the directory. If we're performing a dry run, then the
+	// directory isn't created and its contents are validated without a parent
+	// directory, because none of the content creation operations will access
+	// the filesystem in that case.
+	if !t.dryRun {
+		if err := parent.CreateDirectory(name); err != nil {
+			t.recordProblem(path, fmt.Errorf("unable to create directory: %w", err))
+			return nil
+		}
 	}
 
 	// Create a slim copy of the target that we'll populate as we create its
@@ -818,9 +994,11 @@ func (t *transitioner) createDirectory(parent *filesystem.Directory, name, path
 	// operation because it's indicative of the fact that something's wrong.
 	// However, since we did succeed in creating the directory, we return that
 	// portion.
-	if err := parent.SetPermissions(name, t.defaultOwnership, t.defaultDirectoryMode); err != nil {
-		t.recordProblem(path, fmt.Errorf("unable to set directory permissions: %w", err))
-		return created
+	if !t.dryRun {
+		if err := parent.SetPermissions(name, t.defaultOwnership, t.defaultDirectoryMode); err != nil {
+			t.recordProblem(path, fmt.Errorf("unable to set directory permissions: %w", err))
+			return created
+		}
 	}
 
 	// If there are contents in the target, allocate a map for created, because
@@ -831,13 +1009,15 @@ func (t *transitioner) createDirectory(parent *filesystem.Directory, name, path
 		// Allocate the content map.
 		created.Contents = make(map[string]*Entry, len(target.Contents))
 
-		// Open the directory.
-		if d, err := parent.OpenDirectory(name); err != nil {
-			t.recordProblem(path, fmt.Errorf("unable to open new directory: %w", err))
-			return created
-		} else {
-			directory = d
-			defer directory.Close()
+		// Open the directory (unless we're performing a dry run).
+		if !t.dryRun {
+			if d, err := parent.OpenDirectory(name); err != nil {
+				t.recordProblem(path, fmt.Errorf("unable to open new directory: %w", err))
+				return created
+			} else {
+				directory = d
+				defer directory.Close()
+			}
 		}
 	}
 
@@ -879,6 +1059,12 @@ ContentLoop:
 			} else {
 				created.Contents[name] = entry
 			}
+		} else if entry.Kind == EntryKind_SpecialFile {
+			if err := t.createSpecialFile(directory, name); err != nil {
+				t.recordProblem(contentPath, fmt.Errorf("unable to create special file: %w", err))
+			} else {
+				created.Contents[name] = entry
+			}
 		} else {
 			t.recordProblem(contentPath, errors.New("creation requested for unknown entry type"))
 		}
@@ -890,8 +1076,10 @@ ContentLoop:
 
 // create creates the target content at the specified path. If only a portion of
 // the content can be created, an entry representing that portion will be
-// returned.
-func (t *transitioner) create(path string, target *Entry) *Entry {
+// returned. The replacing parameter indicates whether or not the content is
+// replacing content that the transition removed, which is only used during dry
+// runs (where that content will still exist on disk).
+func (t *transitioner) create(path string, target *Entry, replacing bool) *Entry {
 	// If the target is nil, we're done.
 	if target == nil {
 		return nil
@@ -906,6 +1094,19 @@ func (t *transitioner) create(path string, target *Entry) *Entry {
 	}
 	defer parent.Close()
 
+	// Creation never replaces existing content, so if we're performing a dry
+	// run (and thus won't have the filesystem reject creation), then verify
+	// that nothing unexpected exists at the target location.
+	if t.dryRun && !replacing {
+		if _, err := parent.ReadContentMetadata(name); err == nil {
+			t.recordProblem(path, fmt.Errorf("unable to create content: %w", fs.ErrExist))
+			return nil
+		} else if !errors.Is(err, fs.ErrNotExist) {
+			t.recordProblem(path, fmt.Errorf("unable to verify content absence: %w", err))
+			return nil
+		}
+	}
+
 	// Handle creation based on type.
 	if target.Kind == EntryKind_Directory {
 		return t.createDirectory(parent, name, path, target)
@@ -923,93 +1124,146 @@ func (t *transitioner) create(path string, target *Entry) *Entry {
 		} else {
 			return target
 		}
+	} else if target.Kind == EntryKind_SpecialFile {
+		if err := t.createSpecialFile(parent, name); err != nil {
+			t.recordProblem(path, fmt.Errorf("unable to create special file: %w", err))
+			return nil
+		} else {
+			return target
+		}
 	} else {
 		t.recordProblem(path, errors.New("creation requested for unknown entry type"))
 		return nil
 	}
 }
 
+// transition performs a single transition, returning the resulting entry.
+func (t *transitioner) transition(transition *Change) *Entry {
+	// Check for cancellation. Even if cancelled, we still need to yield a
+	// result, so we just mark the transition as having encountered
+	// cancellation.
+	select {
+	case <-t.cancelled:
+		t.recordProblem(transition.Path, errTransitionCancelled)
+		return transition.Old
+	default:
+	}
+
+	// Handle the special case where both old and new are a file. In this case
+	// we can do a simple swap. It makes sense to handle this specially because
+	// it is a very common case and doing it with a swap will remove any window
+	// where the path is empty on the filesystem.
+	fileToFile := transition.Old != nil && transition.New != nil &&
+		transition.Old.Kind == EntryKind_File &&
+		transition.New.Kind == EntryKind_File
+	if fileToFile {
+		if err := t.swapFile(transition.Path, transition.Old, transition.New); err != nil {
+			t.recordProblem(transition.Path, fmt.Errorf("unable to swap file: %w", err))
+			return transition.Old
+		}
+		return transition.New
+	}
+
+	// If a non-empty directory would be replaced by a symbolic link, then
+	// verify that this replacement is allowed. If it's not, then leave the
+	// directory in place.
+	directoryToSymbolicLink := transition.Old != nil && transition.New != nil &&
+		transition.Old.Kind == EntryKind_Directory &&
+		transition.New.Kind == EntryKind_SymbolicLink
+	if directoryToSymbolicLink && len(transition.Old.Contents) > 0 &&
+		t.symbolicLinkReplacementMode != SymbolicLinkReplacementMode_SymbolicLinkReplacementModeReplace {
+		t.recordProblem(transition.Path, errors.New("refusing to replace non-empty directory with symbolic link"))
+		return transition.Old
+	}
+
+	// Reduce whatever we expect to see on disk to nil (remove it). If we don't
+	// expect to see anything (transition.Old == nil), this is a no-op. If this
+	// fails, then return the reduced entry.
+	if r := t.remove(transition.Path, transition.Old); r != nil {
+		return r
+	}
+
+	// At this point, we should have nil on disk. Transition to whatever the new
+	// entry is (or at least as much of it as we can create). If the new entry
+	// is nil, then this is a no-op.
+	return t.create(transition.Path, transition.New, transition.Old != nil)
+}
+
 // Transition provides recursive filesystem transitioning facilities for
 // synchronization roots, allowing the application of changes after
 // reconciliation. The path to the provided synchronization root must be
 // absolute and normalized (using filepath.Clean). The function returns a slice
 // of the resulting entries, problems, and a boolean indicating whether or not
-// the provider was missing files.
+// the provider was missing files. The transition mode controls the strategy
+// used to apply changes (see TransitionMode for details) and must not be
+// TransitionMode_TransitionModeDefault. The symbolic link replacement mode
+// controls whether or not non-empty directories can be replaced by symbolic
+// links (see SymbolicLinkReplacementMode for details). A default value is
+// treated as SymbolicLinkReplacementMode_SymbolicLinkReplacementModeRequireEmpty.
+// The symbolic link cycle mode controls whether or not symbolic links that
+// would form cycles are created (see SymbolicLinkCycleMode for details). A
+// default value is treated as SymbolicLinkCycleMode_SymbolicLinkCycleModeAllow.
+// Cycle detection is lexical and only considers the symbolic links created by
+// the provided transitions: a symbolic link forms a cycle if resolving it
+// (following other symbolic links being created) leads back to itself or to one
+// of its ancestor directories. Symbolic links already on disk (and targets that
+// are absolute or reference locations outside of the synchronization root)
+// aren't followed. If dryRun is true, then the transitions are only validated:
+// the same just-in-time checks against on-disk content are performed (so
+// concurrent modifications are still reported as problems), but the filesystem
+// isn't modified, the transition mode is ignored, and the provider isn't
+// invoked (and may be nil). The results and problems then represent those that
+// would be expected from applying the transitions, though the availability of
+// staged files isn't verified.
 func Transition(
 	ctx context.Context,
 	root string,
 	transitions []*Change,
 	cache *Cache,
 	symbolicLinkMode SymbolicLinkMode,
+	symbolicLinkReplacementMode SymbolicLinkReplacementMode,
+	symbolicLinkCycleMode SymbolicLinkCycleMode,
 	defaultFileMode filesystem.Mode,
 	defaultDirectoryMode filesystem.Mode,
 	defaultOwnership *filesystem.OwnershipSpecification,
 	recomposeUnicode bool,
+	transitionMode TransitionMode,
 	provider Provider,
+	dryRun bool,
 ) ([]*Entry, []*Problem, bool) {
 	// Extract the cancellation channel.
 	cancelled := ctx.Done()
 
 	// Create the transitioner.
 	transitioner := &transitioner{
-		cancelled:            cancelled,
-		root:                 root,
-		cache:                cache,
-		symbolicLinkMode:     symbolicLinkMode,
-		defaultFileMode:      defaultFileMode,
-		defaultDirectoryMode: defaultDirectoryMode,
-		defaultOwnership:     defaultOwnership,
-		copyBuffer:           make([]byte, transitionCopyBufferSize),
-		recomposeUnicode:     recomposeUnicode,
-		provider:             provider,
-	}
-
-	// Set up results.
-	var results []*Entry
-
-	// Iterate through transitions.
-	for _, t := range transitions {
-		// Check for cancellation. Even if cancelled, we still need to yield a
-		// result, so we'll continue looping through transitions and just mark
-		// them as having encountered cancellation.
-		select {
-		case <-cancelled:
-			results = append(results, t.Old)
-			transitioner.recordProblem(t.Path, errTransitionCancelled)
-			continue
-		default:
-		}
+		cancelled:                   cancelled,
+		root:                        root,
+		cache:                       cache,
+		symbolicLinkMode:            symbolicLinkMode,
+		symbolicLinkReplacementMode: symbolicLinkReplacementMode,
+		defaultFileMode:             defaultFileMode,
+		defaultDirectoryMode:        defaultDirectoryMode,
+		defaultOwnership:            defaultOwnership,
+		copyBuffer:                  make([]byte, transitionCopyBufferSize),
+		recomposeUnicode:            recomposeUnicode,
+		provider:                    provider,
+		dryRun:                      dryRun,
+	}
 
-		// Handle the special case where both old and new are a file. In this
-		// case we can do a simple swap. It makes sense to handle this specially
-		// because it is a very common case and doing it with a swap will remove
-		// any window where the path is empty on the filesystem.
-		fileToFile := t.Old != nil && t.New != nil &&
-			t.Old.Kind == EntryKind_File &&
-			t.New.Kind == EntryKind_File
-		if fileToFile {
-			if err := transitioner.swapFile(t.Path, t.Old, t.New); err != nil {
-				results = append(results, t.Old)
-				transitioner.recordProblem(t.Path, fmt.Errorf("unable to swap file: %w", err))
-			} else {
-				results = append(results, t.New)
-			}
-			continue
-		}
+	// If symbolic link cycles are being refused, then identify them.
+	if symbolicLinkCycleMode == SymbolicLinkCycleMode_SymbolicLinkCycleModeRefuse {
+		transitioner.symbolicLinkCycles = findSymbolicLinkCycles(transitions)
+	}
 
-		// Reduce whatever we expect to see on disk to nil (remove it). If we
-		// don't expect to see anything (t.Old == nil), this is a no-op. If this
-		// fails, then record the reduced entry and continue to the next
-		// transition.
-		if r := transitioner.remove(t.Path, t.Old); r != nil {
-			results = append(results, r)
-			continue
+	// Perform transitions using the requested strategy. Shadow directories
+	// are only used on platforms that support atomic exchange.
+	var results []*Entry
+	if transitionMode == TransitionMode_TransitionModeShadowDirectory && filesystem.ExchangeSupported && !dryRun {
+		results = transitioner.transitionWithShadowDirectories(transitions)
+	} else {
+		for _, t := range transitions {
+			results = append(results, transitioner.transition(t))
 		}
-
-		// At this point, we should have nil on disk. Transition to whatever the
-		// new entry is (or at least as much of it as we can create). If the new
-		// entry is nil, then this is a no-op.
-		results = append(results, transitioner.create(t.Path, t.New))
 	}
 
 	// Done.
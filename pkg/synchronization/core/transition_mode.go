@@ -0,0 +1,72 @@
+package core
+
+import (
+	"fmt"
+)
+
+// IsDefault indicates whether or not the transition mode is
+// TransitionMode_TransitionModeDefault.
+func (m TransitionMode) IsDefault() bool {
+	return m == TransitionMode_TransitionModeDefault
+}
+
+// MarshalText implements encoding.TextMarshaler.MarshalText.
+func (m TransitionMode) MarshalText() ([]byte, error) {
+	var result string
+	switch m {
+	case TransitionMode_TransitionModeDefault:
+	case TransitionMode_TransitionModeInPlace:
+		result = "in-place"
+	case TransitionMode_TransitionModeShadowDirectory:
+		result = "shadow-directory"
+	default:
+		result = "unknown"
+	}
+	return []byte(result), nil
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler.UnmarshalText.
+func (m *TransitionMode) UnmarshalText(textBytes []byte) error {
+	// Convert the bytes to a string.
+	text := string(textBytes)
+
+	// Convert to a transition mode.
+	switch text {
+	case "in-place":
+		*m = TransitionMode_TransitionModeInPlace
+	case "shadow-directory":
+		*m = TransitionMode_TransitionModeShadowDirectory
+	default:
+		return fmt.Errorf("unknown transition mode specification: %s", text)
+	}
+
+	// Success.
+	return nil
+}
+
+// Supported indicates whether or not a particular transition mode is a
+// valid, non-default value.
+func (m TransitionMode) Supported() bool {
+	switch m {
+	case TransitionMode_TransitionModeInPlace:
+		return true
+	case TransitionMode_TransitionModeShadowDirectory:
+		return true
+	default:
+		return false
+	}
+}
+
+// Description returns a human-readable description of a transition mode.
+func (m TransitionMode) Description() string {
+	switch m {
+	case TransitionMode_TransitionModeDefault:
+		return "Default"
+	case TransitionMode_TransitionModeInPlace:
+		return "In Place"
+	case TransitionMode_TransitionModeShadowDirectory:
+		return "Shadow Directory"
+	default:
+		return "Unknown"
+	}
+}
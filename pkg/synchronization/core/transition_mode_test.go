@@ -0,0 +1,112 @@
+package core
+
+import (
+	"testing"
+)
+
+// TestTransitionModeIsDefault tests TransitionMode.IsDefault.
+func TestTransitionModeIsDefault(t *testing.T) {
+	// Define test cases.
+	tests := []struct {
+		value    TransitionMode
+		expected bool
+	}{
+		{TransitionMode_TransitionModeDefault - 1, false},
+		{TransitionMode_TransitionModeDefault, true},
+		{TransitionMode_TransitionModeInPlace, false},
+		{TransitionMode_TransitionModeShadowDirectory, false},
+		{TransitionMode_TransitionModeShadowDirectory + 1, false},
+	}
+
+	// Process test cases.
+	for i, test := range tests {
+		if result := test.value.IsDefault(); result && !test.expected {
+			t.Errorf("test index %d: value was unexpectedly classified as default", i)
+		} else if !result && test.expected {
+			t.Errorf("test index %d: value was unexpectedly classified as non-default", i)
+		}
+	}
+}
+
+// TestTransitionModeUnmarshalText tests TransitionMode.UnmarshalText.
+func TestTransitionModeUnmarshalText(t *testing.T) {
+	// Define test cases.
+	tests := []struct {
+		text          string
+		expectedMode  TransitionMode
+		expectFailure bool
+	}{
+		{"", TransitionMode_TransitionModeDefault, true},
+		{"asdf", TransitionMode_TransitionModeDefault, true},
+		{"in-place", TransitionMode_TransitionModeInPlace, false},
+		{"shadow-directory", TransitionMode_TransitionModeShadowDirectory, false},
+	}
+
+	// Process test cases.
+	for _, test := range tests {
+		var mode TransitionMode
+		if err := mode.UnmarshalText([]byte(test.text)); err != nil {
+			if !test.expectFailure {
+				t.Errorf("unable to unmarshal text (%s): %s", test.text, err)
+			}
+		} else if test.expectFailure {
+			t.Error("unmarshaling succeeded unexpectedly for text:", test.text)
+		} else if mode != test.expectedMode {
+			t.Errorf(
+				"unmarshaled mode (%s) does not match expected (%s)",
+				mode,
+				test.expectedMode,
+			)
+		}
+	}
+}
+
+// TestTransitionModeSupported tests TransitionMode.Supported.
+func TestTransitionModeSupported(t *testing.T) {
+	// Set up test cases.
+	testCases := []struct {
+		mode            TransitionMode
+		expectSupported bool
+	}{
+		{TransitionMode_TransitionModeDefault, false},
+		{TransitionMode_TransitionModeInPlace, true},
+		{TransitionMode_TransitionModeShadowDirectory, true},
+		{(TransitionMode_TransitionModeShadowDirectory + 1), false},
+	}
+
+	// Process test cases.
+	for _, testCase := range testCases {
+		if supported := testCase.mode.Supported(); supported != testCase.expectSupported {
+			t.Errorf(
+				"mode support status (%t) does not match expected (%t)",
+				supported,
+				testCase.expectSupported,
+			)
+		}
+	}
+}
+
+// TestTransitionModeDescription tests TransitionMode.Description.
+func TestTransitionModeDescription(t *testing.T) {
+	// Set up test cases.
+	testCases := []struct {
+		mode                TransitionMode
+		expectedDescription string
+	}{
+		{TransitionMode_TransitionModeDefault, "Default"},
+		{TransitionMode_TransitionModeInPlace, "In Place"},
+		{TransitionMode_TransitionModeShadowDirectory, "Shadow Directory"},
+		{(TransitionMode_TransitionModeShadowDirectory + 1), "Unknown"},
+	}
+
+	// Process test cases.
+	for _, testCase := range testCases {
+		if description := testCase.mode.Description(); description != testCase.expectedDescription {
+			t.Errorf(
+				"mode description (%s) does not match expected (%s)",
+				description,
+				testCase.expectedDescription,
+			)
+		}
+	}
+}
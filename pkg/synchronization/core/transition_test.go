@@ -736,11 +736,18 @@ func TestTransition(t *testing.T) {
 				backgroundCtx,
 				root,
 				nil, nil,
-				hasher, nil,
+				hasher, nil, CacheTrustMode_CacheTrustModeStrict,
 				ignorer, nil,
-				behavior.ProbeMode_ProbeModeProbe,
+				behavior.ProbeMode_ProbeModeProbe, nil,
 				test.symbolicLinkMode,
+				SpecialFileMode_SpecialFileModeIgnore,
+				NameNormalizationMode_NameNormalizationModePreserve,
 				PermissionsMode_PermissionsModePortable,
+				false,
+				false,
+				0,
+				0,
+				nil, 0,
 			)
 			if err != nil {
 				t.Errorf("%s: unable to perform scan of baseline on %s filesystem: %v",
@@ -771,11 +778,15 @@ func TestTransition(t *testing.T) {
 				test.transitions,
 				cache,
 				test.symbolicLinkMode,
+				SymbolicLinkReplacementMode_SymbolicLinkReplacementModeReplace,
+				SymbolicLinkCycleMode_SymbolicLinkCycleModeAllow,
 				0600,
 				0700,
 				nil,
 				snapshot.DecomposesUnicode,
+				TransitionMode_TransitionModeInPlace,
 				provider,
+				false,
 			)
 
 			// Check results.
@@ -810,3 +821,384 @@ func TestTransition(t *testing.T) {
 		}
 	}
 }
+
+// TestTransitionSymbolicLinkReplacementMode tests the replacement of empty and
+// non-empty directories with symbolic links under each symbolic link
+// replacement mode.
+func TestTransitionSymbolicLinkReplacementMode(t *testing.T) {
+	// Set up test cases.
+	testCases := []struct {
+		// description is a description of the test case.
+		description string
+		// directory is the directory to be replaced.
+		directory *Entry
+		// directoryContentMap is the content map for directory.
+		directoryContentMap testingContentMap
+		// mode is the symbolic link replacement mode.
+		mode SymbolicLinkReplacementMode
+		// expectReplacement indicates whether or not replacement is expected.
+		expectReplacement bool
+	}{
+		{"empty directory with require-empty", tD0, nil, SymbolicLinkReplacementMode_SymbolicLinkReplacementModeRequireEmpty, true},
+		{"non-empty directory with require-empty", tD1, tD1ContentMap, SymbolicLinkReplacementMode_SymbolicLinkReplacementModeRequireEmpty, false},
+		{"non-empty directory with default", tD1, tD1ContentMap, SymbolicLinkReplacementMode_SymbolicLinkReplacementModeDefault, false},
+		{"empty directory with replace", tD0, nil, SymbolicLinkReplacementMode_SymbolicLinkReplacementModeReplace, true},
+		{"non-empty directory with replace", tD1, tD1ContentMap, SymbolicLinkReplacementMode_SymbolicLinkReplacementModeReplace, true},
+	}
+
+	// Process test cases.
+	for _, testCase := range testCases {
+		// Generate the baseline content, which consists of the directory nested
+		// within a root directory.
+		contentMap := make(testingContentMap, len(testCase.directoryContentMap))
+		for path, content := range testCase.directoryContentMap {
+			contentMap["directory/"+path] = content
+		}
+		generator := &testingContentManager{
+			storage:            t.TempDir(),
+			baseline:           nested("directory", testCase.directory),
+			baselineContentMap: contentMap,
+		}
+		root, err := generator.generate()
+		if err != nil {
+			t.Fatalf("%s: unable to generate test content: %v", testCase.description, err)
+		}
+
+		// Perform a scan to extract filesystem behavior and a cache.
+		ignorer, err := mutagenignore.NewIgnorer(nil)
+		if err != nil {
+			t.Fatalf("%s: unable to create ignorer: %v", testCase.description, err)
+		}
+		snapshot, cache, _, err := Scan(
+			context.Background(),
+			root,
+			nil, nil,
+			newTestingHasher(), nil, CacheTrustMode_CacheTrustModeStrict,
+			ignorer, nil,
+			behavior.ProbeMode_ProbeModeProbe, nil,
+			SymbolicLinkMode_SymbolicLinkModePortable,
+			SpecialFileMode_SpecialFileModeIgnore,
+			NameNormalizationMode_NameNormalizationModePreserve,
+			PermissionsMode_PermissionsModePortable,
+			false,
+			false,
+			0,
+			0,
+			nil, 0,
+		)
+		if err != nil {
+			t.Fatalf("%s: unable to perform scan: %v", testCase.description, err)
+		}
+
+		// Perform the replacement.
+		transitions := []*Change{{Path: "directory", Old: testCase.directory, New: tSR}}
+		provider := &testingProvider{
+			storage: t.TempDir(),
+			hasher:  newTestingHasher(),
+		}
+		results, problems, _ := Transition(
+			context.Background(),
+			root,
+			transitions,
+			cache,
+			SymbolicLinkMode_SymbolicLinkModePortable,
+			testCase.mode,
+			SymbolicLinkCycleMode_SymbolicLinkCycleModeAllow,
+			0600,
+			0700,
+			nil,
+			snapshot.DecomposesUnicode,
+			TransitionMode_TransitionModeInPlace,
+			provider,
+			false,
+		)
+
+		// Verify the results and on-disk content.
+		info, err := os.Lstat(filepath.Join(root, "directory"))
+		if err != nil {
+			t.Errorf("%s: unable to query replacement path: %v", testCase.description, err)
+		}
+		if testCase.expectReplacement {
+			if len(problems) > 0 {
+				t.Errorf("%s: transition encountered problems: %s", testCase.description, problems[0].Error)
+			} else if !results[0].Equal(tSR, true) {
+				t.Errorf("%s: result does not match symbolic link", testCase.description)
+			} else if info != nil && info.Mode()&os.ModeSymlink == 0 {
+				t.Errorf("%s: directory not replaced by symbolic link on disk", testCase.description)
+			}
+		} else {
+			if len(problems) != 1 {
+				t.Errorf("%s: refused replacement not reported as problem", testCase.description)
+			} else if !results[0].Equal(testCase.directory, true) {
+				t.Errorf("%s: result does not match original directory", testCase.description)
+			} else if info != nil && !info.IsDir() {
+				t.Errorf("%s: directory not preserved on disk", testCase.description)
+			}
+		}
+
+		// Remove the test content.
+		if err := generator.remove(); err != nil {
+			t.Errorf("%s: unable to remove test content: %v", testCase.description, err)
+		}
+	}
+}
+
+// TestTransitionSymbolicLinkCycleMode tests that transitions creating symbolic
+// links that would form cycles are reported as problems (with the symbolic
+// links left uncreated) only when symbolic link cycles are refused.
+func TestTransitionSymbolicLinkCycleMode(t *testing.T) {
+	// Define helper functions to create entries.
+	link := func(target string) *Entry {
+		return &Entry{Kind: EntryKind_SymbolicLink, Target: target}
+	}
+	directory := func(contents map[string]*Entry) *Entry {
+		return &Entry{Kind: EntryKind_Directory, Contents: contents}
+	}
+
+	// Set up test cases.
+	testCases := []struct {
+		// description is a description of the test case.
+		description string
+		// transitions are the transitions to perform on an empty root.
+		transitions []*Change
+		// cycles are the paths of symbolic links that form cycles.
+		cycles []string
+	}{
+		{
+			"self-referential symbolic link",
+			[]*Change{{Path: "self", New: link("self")}},
+			[]string{"self"},
+		},
+		{
+			"mutually referential symbolic links",
+			[]*Change{{Path: "a", New: link("b")}, {Path: "b", New: link("a")}},
+			[]string{"a", "b"},
+		},
+		{
+			"symbolic link to ancestor",
+			[]*Change{{Path: "directory", New: directory(map[string]*Entry{"up": link("..")})}},
+			[]string{"directory/up"},
+		},
+		{
+			"symbolic link to root",
+			[]*Change{{Path: "current", New: link(".")}},
+			[]string{"current"},
+		},
+		{
+			"cycle through directory contents",
+			[]*Change{
+				{Path: "x", New: link("directory/y")},
+				{Path: "directory", New: directory(map[string]*Entry{"y": link("../x")})},
+			},
+			[]string{"x", "directory/y"},
+		},
+		{
+			"symbolic link into cycle",
+			[]*Change{
+				{Path: "entry", New: link("a")},
+				{Path: "a", New: link("b")},
+				{Path: "b", New: link("a")},
+			},
+			[]string{"a", "b"},
+		},
+		{
+			"acyclic symbolic links",
+			[]*Change{
+				{Path: "first", New: link("second")},
+				{Path: "second", New: link("directory/missing")},
+				{Path: "directory", New: directory(map[string]*Entry{"sibling": link("../first")})},
+			},
+			nil,
+		},
+	}
+
+	// Process test cases.
+	for _, testCase := range testCases {
+		for _, mode := range []SymbolicLinkCycleMode{
+			SymbolicLinkCycleMode_SymbolicLinkCycleModeAllow,
+			SymbolicLinkCycleMode_SymbolicLinkCycleModeRefuse,
+		} {
+			// Compute the expected problem paths.
+			expected := make(map[string]bool)
+			if mode == SymbolicLinkCycleMode_SymbolicLinkCycleModeRefuse {
+				for _, path := range testCase.cycles {
+					expected[path] = true
+				}
+			}
+
+			// Perform the transitions on an empty root.
+			root := t.TempDir()
+			_, problems, _ := Transition(
+				context.Background(),
+				root,
+				testCase.transitions,
+				&Cache{},
+				SymbolicLinkMode_SymbolicLinkModePortable,
+				SymbolicLinkReplacementMode_SymbolicLinkReplacementModeRequireEmpty,
+				mode,
+				0600,
+				0700,
+				nil,
+				false,
+				TransitionMode_TransitionModeInPlace,
+				&testingProvider{storage: t.TempDir(), hasher: newTestingHasher()},
+				false,
+			)
+
+			// Verify that exactly the expected problems were reported.
+			if len(problems) != len(expected) {
+				t.Errorf("%s (%s): problem count (%d) does not match expected (%d)",
+					testCase.description, mode.Description(), len(problems), len(expected),
+				)
+			}
+			for _, problem := range problems {
+				if !expected[problem.Path] {
+					t.Errorf("%s (%s): unexpected problem at \"%s\": %s",
+						testCase.description, mode.Description(), problem.Path, problem.Error,
+					)
+				}
+			}
+
+			// Verify that only the symbolic links forming cycles are absent.
+			for _, transition := range testCase.transitions {
+				transition.New.walk(transition.Path, func(path string, entry *Entry) {
+					if entry.Kind != EntryKind_SymbolicLink {
+						return
+					}
+					_, err := os.Lstat(filepath.Join(root, filepath.FromSlash(path)))
+					if expected[path] && err == nil {
+						t.Errorf("%s (%s): symbolic link forming cycle created at \"%s\"",
+							testCase.description, mode.Description(), path,
+						)
+					} else if !expected[path] && err != nil {
+						t.Errorf("%s (%s): symbolic link not created at \"%s\": %v",
+							testCase.description, mode.Description(), path, err,
+						)
+					}
+				}, false)
+			}
+		}
+	}
+}
+
+// TestTransitionDryRun tests that a dry run of Transition reports the expected
+// results and problems (including those arising from modifications made after
+// scanning) without modifying the filesystem.
+func TestTransitionDryRun(t *testing.T) {
+	// Create a synchronization root with some content.
+	root := t.TempDir()
+	for _, path := range []string{"removed", "modified", "directory/child"} {
+		if err := os.MkdirAll(filepath.Dir(filepath.Join(root, path)), 0700); err != nil {
+			t.Fatal("unable to create parent directory:", err)
+		} else if err := os.WriteFile(filepath.Join(root, path), []byte(path), 0600); err != nil {
+			t.Fatal("unable to create file:", err)
+		}
+	}
+
+	// Perform a scan.
+	ignorer, err := mutagenignore.NewIgnorer(nil)
+	if err != nil {
+		t.Fatal("unable to create ignorer:", err)
+	}
+	snapshot, cache, _, err := Scan(
+		context.Background(),
+		root,
+		nil, nil,
+		newTestingHasher(), nil, CacheTrustMode_CacheTrustModeStrict,
+		ignorer, nil,
+		behavior.ProbeMode_ProbeModeProbe, nil,
+		SymbolicLinkMode_SymbolicLinkModePortable,
+		SpecialFileMode_SpecialFileModeIgnore,
+		NameNormalizationMode_NameNormalizationModePreserve,
+		PermissionsMode_PermissionsModePortable,
+		false,
+		false,
+		0,
+		0,
+		nil, 0,
+	)
+	if err != nil {
+		t.Fatal("unable to perform scan:", err)
+	}
+
+	// Modify content after scanning. We also adjust the modification time of
+	// the modified file to ensure that the modification is detectable.
+	modifiedPath := filepath.Join(root, "modified")
+	if err := os.WriteFile(modifiedPath, []byte("modified after scan"), 0600); err != nil {
+		t.Fatal("unable to modify file:", err)
+	} else if err := os.Chtimes(modifiedPath, time.Now(), time.Now().Add(time.Hour)); err != nil {
+		t.Fatal("unable to adjust modification time:", err)
+	}
+	if err := os.WriteFile(filepath.Join(root, "appeared"), []byte("appeared"), 0600); err != nil {
+		t.Fatal("unable to create file:", err)
+	}
+
+	// Perform a dry run of the transitions.
+	contents := snapshot.Content.Contents
+	replacement := &Entry{Kind: EntryKind_File, Digest: []byte{1}}
+	transitions := []*Change{
+		{Path: "removed", Old: contents["removed"]},
+		{Path: "modified", Old: contents["modified"], New: replacement},
+		{Path: "directory", Old: contents["directory"]},
+		{Path: "created", New: replacement},
+		{Path: "appeared", New: replacement},
+	}
+	results, problems, missingFiles := Transition(
+		context.Background(),
+		root,
+		transitions,
+		cache,
+		SymbolicLinkMode_SymbolicLinkModePortable,
+		SymbolicLinkReplacementMode_SymbolicLinkReplacementModeRequireEmpty,
+		SymbolicLinkCycleMode_SymbolicLinkCycleModeAllow,
+		0600,
+		0700,
+		nil,
+		snapshot.DecomposesUnicode,
+		TransitionMode_TransitionModeInPlace,
+		nil,
+		true,
+	)
+	if missingFiles {
+		t.Error("dry run reported missing files")
+	}
+
+	// Verify results.
+	expected := []*Entry{nil, contents["modified"], nil, replacement, nil}
+	if len(results) != len(expected) {
+		t.Fatalf("result count (%d) does not match expected (%d)", len(results), len(expected))
+	}
+	for r, result := range results {
+		if !result.Equal(expected[r], true) {
+			t.Errorf("result for \"%s\" does not match expected", transitions[r].Path)
+		}
+	}
+
+	// Verify problems.
+	if len(problems) != 2 {
+		t.Errorf("problem count (%d) does not match expected (2)", len(problems))
+	}
+	expectedCodes := map[string]ProblemCode{
+		"modified": ProblemCode_ProblemCodeModified,
+		"appeared": ProblemCode_ProblemCodeAlreadyExists,
+	}
+	for _, problem := range problems {
+		if code, ok := expectedCodes[problem.Path]; !ok {
+			t.Errorf("unexpected problem at \"%s\": %s", problem.Path, problem.Error)
+		} else if problem.Code != code {
+			t.Errorf("problem code at \"%s\" does not match expected: %s != %s",
+				problem.Path, problem.Code.Description(), code.Description(),
+			)
+		}
+	}
+
+	// Verify that the filesystem wasn't modified.
+	for _, path := range []string{"removed", "directory/child", "appeared"} {
+		if _, err := os.Lstat(filepath.Join(root, filepath.FromSlash(path))); err != nil {
+			t.Errorf("content at \"%s\" not preserved: %v", path, err)
+		}
+	}
+	if _, err := os.Lstat(filepath.Join(root, "created")); !os.IsNotExist(err) {
+		t.Error("content created on disk")
+	}
+}
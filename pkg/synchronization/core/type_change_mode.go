@@ -0,0 +1,72 @@
+package core
+
+import (
+	"fmt"
+)
+
+// IsDefault indicates whether or not the type change mode is
+// TypeChangeMode_TypeChangeModeDefault.
+func (m TypeChangeMode) IsDefault() bool {
+	return m == TypeChangeMode_TypeChangeModeDefault
+}
+
+// MarshalText implements encoding.TextMarshaler.MarshalText.
+func (m TypeChangeMode) MarshalText() ([]byte, error) {
+	var result string
+	switch m {
+	case TypeChangeMode_TypeChangeModeDefault:
+	case TypeChangeMode_TypeChangeModePropagate:
+		result = "propagate"
+	case TypeChangeMode_TypeChangeModeConflict:
+		result = "conflict"
+	default:
+		result = "unknown"
+	}
+	return []byte(result), nil
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler.UnmarshalText.
+func (m *TypeChangeMode) UnmarshalText(textBytes []byte) error {
+	// Convert the bytes to a string.
+	text := string(textBytes)
+
+	// Convert to a type change mode.
+	switch text {
+	case "propagate":
+		*m = TypeChangeMode_TypeChangeModePropagate
+	case "conflict":
+		*m = TypeChangeMode_TypeChangeModeConflict
+	default:
+		return fmt.Errorf("unknown type change mode specification: %s", text)
+	}
+
+	// Success.
+	return nil
+}
+
+// Supported indicates whether or not a particular type change mode is a
+// valid, non-default value.
+func (m TypeChangeMode) Supported() bool {
+	switch m {
+	case TypeChangeMode_TypeChangeModePropagate:
+		return true
+	case TypeChangeMode_TypeChangeModeConflict:
+		return true
+	default:
+		return false
+	}
+}
+
+// Description returns a human-readable description of a type change mode.
+func (m TypeChangeMode) Description() string {
+	switch m {
+	case TypeChangeMode_TypeChangeModeDefault:
+		return "Default"
+	case TypeChangeMode_TypeChangeModePropagate:
+		return "Propagate"
+	case TypeChangeMode_TypeChangeModeConflict:
+		return "Conflict"
+	default:
+		return "Unknown"
+	}
+}
@@ -0,0 +1,112 @@
+package core
+
+import (
+	"testing"
+)
+
+// TestTypeChangeModeIsDefault tests TypeChangeMode.IsDefault.
+func TestTypeChangeModeIsDefault(t *testing.T) {
+	// Define test cases.
+	tests := []struct {
+		value    TypeChangeMode
+		expected bool
+	}{
+		{TypeChangeMode_TypeChangeModeDefault - 1, false},
+		{TypeChangeMode_TypeChangeModeDefault, true},
+		{TypeChangeMode_TypeChangeModePropagate, false},
+		{TypeChangeMode_TypeChangeModeConflict, false},
+		{TypeChangeMode_TypeChangeModeConflict + 1, false},
+	}
+
+	// Process test cases.
+	for i, test := range tests {
+		if result := test.value.IsDefault(); result && !test.expected {
+			t.Errorf("test index %d: value was unexpectedly classified as default", i)
+		} else if !result && test.expected {
+			t.Errorf("test index %d: value was unexpectedly classified as non-default", i)
+		}
+	}
+}
+
+// TestTypeChangeModeUnmarshalText tests TypeChangeMode.UnmarshalText.
+func TestTypeChangeModeUnmarshalText(t *testing.T) {
+	// Define test cases.
+	tests := []struct {
+		text          string
+		expectedMode  TypeChangeMode
+		expectFailure bool
+	}{
+		{"", TypeChangeMode_TypeChangeModeDefault, true},
+		{"asdf", TypeChangeMode_TypeChangeModeDefault, true},
+		{"propagate", TypeChangeMode_TypeChangeModePropagate, false},
+		{"conflict", TypeChangeMode_TypeChangeModeConflict, false},
+	}
+
+	// Process test cases.
+	for _, test := range tests {
+		var mode TypeChangeMode
+		if err := mode.UnmarshalText([]byte(test.text)); err != nil {
+			if !test.expectFailure {
+				t.Errorf("unable to unmarshal text (%s): %s", test.text, err)
+			}
+		} else if test.expectFailure {
+			t.Error("unmarshaling succeeded unexpectedly for text:", test.text)
+		} else if mode != test.expectedMode {
+			t.Errorf(
+				"unmarshaled mode (%s) does not match expected (%s)",
+				mode,
+				test.expectedMode,
+			)
+		}
+	}
+}
+
+// TestTypeChangeModeSupported tests TypeChangeMode.Supported.
+func TestTypeChangeModeSupported(t *testing.T) {
+	// Set up test cases.
+	testCases := []struct {
+		mode            TypeChangeMode
+		expectSupported bool
+	}{
+		{TypeChangeMode_TypeChangeModeDefault, false},
+		{TypeChangeMode_TypeChangeModePropagate, true},
+		{TypeChangeMode_TypeChangeModeConflict, true},
+		{(TypeChangeMode_TypeChangeModeConflict + 1), false},
+	}
+
+	// Process test cases.
+	for _, testCase := range testCases {
+		if supported := testCase.mode.Supported(); supported != testCase.expectSupported {
+			t.Errorf(
+				"mode support status (%t) does not match expected (%t)",
+				supported,
+				testCase.expectSupported,
+			)
+		}
+	}
+}
+
+// TestTypeChangeModeDescription tests TypeChangeMode.Description.
+func TestTypeChangeModeDescription(t *testing.T) {
+	// Set up test cases.
+	testCases := []struct {
+		mode                TypeChangeMode
+		expectedDescription string
+	}{
+		{TypeChangeMode_TypeChangeModeDefault, "Default"},
+		{TypeChangeMode_TypeChangeModePropagate, "Propagate"},
+		{TypeChangeMode_TypeChangeModeConflict, "Conflict"},
+		{(TypeChangeMode_TypeChangeModeConflict + 1), "Unknown"},
+	}
+
+	// Process test cases.
+	for _, testCase := range testCases {
+		if description := testCase.mode.Description(); description != testCase.expectedDescription {
+			t.Errorf(
+				"mode description (%s) does not match expected (%s)",
+				description,
+				testCase.expectedDescription,
+			)
+		}
+	}
+}
@@ -0,0 +1,12 @@
+package core
+
+// VerificationDivergences compares the content of an endpoint (as captured by a
+// verification scan) against the ancestor and returns the paths of the top-most
+// locations at which the endpoint's synchronizable content diverges from the
+// last synchronized state (in sorted depth-first order). Unsynchronizable
+// content is ignored unless it replaces content recorded in the ancestor, in
+// which case its location is reported as divergent. Paths are computed assuming
+// that the entries represent the synchronization root.
+func VerificationDivergences(ancestor, content *Entry) []string {
+	return DivergentPaths(ancestor, content.synchronizable())
+}
@@ -0,0 +1,34 @@
+package core
+
+import (
+	"slices"
+	"testing"
+)
+
+// TestVerificationDivergences tests VerificationDivergences.
+func TestVerificationDivergences(t *testing.T) {
+	// Set up test cases.
+	testCases := []struct {
+		description string
+		ancestor    *Entry
+		content     *Entry
+		expected    []string
+	}{
+		{"both nil", nil, nil, nil},
+		{"matching content", tD1, tD1, nil},
+		{"modified file", tD1, tD2, []string{"file"}},
+		{"deleted root", tD1, nil, []string{""}},
+		{"untracked content", tD0, tDU, nil},
+		{"problematic content", tD0, tDP1, nil},
+		{"problematic replacement", tD1, &Entry{Contents: map[string]*Entry{"file": tP1}}, []string{"file"}},
+	}
+
+	// Process test cases.
+	for _, testCase := range testCases {
+		if result := VerificationDivergences(testCase.ancestor, testCase.content); !slices.Equal(result, testCase.expected) {
+			t.Errorf("%s: divergences do not match expected: %v != %v",
+				testCase.description, result, testCase.expected,
+			)
+		}
+	}
+}
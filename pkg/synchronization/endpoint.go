@@ -2,6 +2,7 @@ package synchronization
 
 import (
 	"context"
+	"time"
 
 	"github.com/mutagen-io/mutagen/pkg/synchronization/core"
 	"github.com/mutagen-io/mutagen/pkg/synchronization/rsync"
@@ -26,11 +27,29 @@ type Endpoint interface {
 	// which case the transfer of the initial snapshot may be less than optimal.
 	// The full parameter forces the function to perform a full (but still warm)
 	// scan, avoiding any acceleration that might be available on the endpoint.
-	// The function returns the scan result, any error that occurred while
-	// trying to perform the scan, and a boolean indicating whether or not to
-	// re-try the scan if an error occurred. Any non-fatal problems encountered
-	// during the scan can be extracted from the resulting content.
-	Scan(ctx context.Context, ancestor *core.Entry, full bool) (*core.Snapshot, error, bool)
+	// The scope parameter lists normalized synchronization-root-relative paths
+	// (see NormalizeScope) whose subtrees must be fully re-checked, even if the
+	// scan is otherwise accelerated. Content outside of these subtrees may
+	// still be provided by acceleration. The scope is irrelevant if full is
+	// true. The deferred parameter permits the endpoint to skip re-scanning and
+	// instead return the last snapshot that it returned, updated to reflect the
+	// results of any subsequent transitions. Such a snapshot won't reflect any
+	// other modifications made since the last regular scan. Endpoints may
+	// ignore this parameter, and it's irrelevant if full is true or a scope is
+	// specified. The function returns the scan result, any error that occurred
+	// while trying to perform the scan, and a boolean indicating whether or not
+	// to re-try the scan if an error occurred. Any non-fatal problems
+	// encountered during the scan can be extracted from the resulting content.
+	Scan(ctx context.Context, ancestor *core.Entry, full bool, scope []string, deferred bool) (*core.Snapshot, error, bool)
+
+	// Verify performs a full cold scan of the endpoint's synchronization root,
+	// re-hashing all content without relying on caches or acceleration, and
+	// returns the result. Unlike Scan, it doesn't update the endpoint's state
+	// (e.g. its snapshot, caches, or scan generation), so it can be performed
+	// between synchronization cycles without affecting them. It's used to
+	// detect content that has silently diverged from the last synchronized
+	// state (e.g. due to storage corruption).
+	Verify(ctx context.Context) (*core.Snapshot, error)
 
 	// Stage performs file staging on the endpoint. It accepts a list of file
 	// paths and a separate list of desired digests corresponding to those
@@ -50,7 +69,13 @@ type Endpoint interface {
 	// on the endpoint. This method is allowed to modify the provided argument
 	// slices. If the returned receiver fails, the endpoint should be considered
 	// tainted and not used (though shutdown can and should still be invoked).
-	Stage(paths []string, digests [][]byte) ([]string, []*rsync.Signature, rsync.Receiver, error)
+	// The endpoint may also defer staging of some paths (e.g. to bound the
+	// memory used by signatures), in which case those paths are omitted from
+	// the returned list and the returned boolean is true. In that case, once
+	// the receiver has been finalized, the caller should invoke Stage again
+	// (without an intervening scan) with the original paths and digests in
+	// order to stage the deferred paths.
+	Stage(paths []string, digests [][]byte) ([]string, []*rsync.Signature, rsync.Receiver, bool, error)
 
 	// Supply transmits files in a streaming fashion using the rsync algorithm
 	// to the specified receiver.
@@ -60,14 +85,43 @@ type Endpoint interface {
 	// the respective results of the specified change operations, a list of
 	// non-fatal problems encountered during the transition operation, a boolean
 	// indicating whether or not the endpoint was missing staged files, and any
-	// error occurred while trying to perform the transition operation.
+	// error occurred while trying to perform the transition operation. If
+	// dryRun is true, then the transitions are only validated against on-disk
+	// content (see core.Transition), and the results and problems represent
+	// those that would be expected from applying them. A dry run doesn't
+	// require staging, but it does require a preceding scan.
 	// TODO: Should we consider pre-emptability for transition? It could
 	// probably be done by just checking for cancellation during each transition
 	// path and reporting "cancelled" for problems arising after that, but
 	// usually the long-blocking transitions are going to be the ones where
 	// we're creating the root with a huge number of files and wouldn't catch
 	// cancellation until they're all done anyway.
-	Transition(ctx context.Context, transitions []*core.Change) ([]*core.Entry, []*core.Problem, bool, error)
+	Transition(ctx context.Context, transitions []*core.Change, dryRun bool) ([]*core.Entry, []*core.Problem, bool, error)
+
+	// ClockOffset returns the offset of the endpoint's clock relative to the
+	// local clock, as measured when the endpoint was connected. Positive values
+	// indicate that the endpoint's clock is ahead. Local endpoints always
+	// return 0.
+	ClockOffset() time.Duration
+
+	// WatchOverflows returns the number of times that the endpoint's native
+	// filesystem watcher has failed due to an internal event overflow. For
+	// remote endpoints, this value is updated with each scan.
+	WatchOverflows() uint64
+
+	// AccelerationUnavailable returns a description of the reason that
+	// accelerated scanning has been unavailable, if it's been continuously
+	// unavailable (despite being allowed) for a sustained period due to watch
+	// failures, in which case scans are falling back to full scans. Otherwise
+	// it returns an empty string. For remote endpoints, this value is updated
+	// with each scan.
+	AccelerationUnavailable() string
+
+	// OversizedFiles returns the number of files that the endpoint refused to
+	// stage during its last transition operation because they exceeded the
+	// maximum staging file size. For remote endpoints, this value is updated
+	// with each transition.
+	OversizedFiles() uint64
 
 	// Shutdown terminates any resources associated with the endpoint. For local
 	// endpoints, Shutdown will not preempt calls, but for remote endpoints it
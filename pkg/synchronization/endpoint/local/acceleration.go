@@ -0,0 +1,52 @@
+package local
+
+import (
+	"sync"
+	"time"
+)
+
+// accelerationStatus tracks the availability of accelerated scanning on an
+// endpoint where acceleration is allowed, so that sustained fallback to full
+// scans can be reported. Acceleration is considered unavailable from the first
+// failure that prevents it (such as a watch failure) until it's next enabled.
+// It is safe for concurrent usage.
+type accelerationStatus struct {
+	// lock serializes access to unavailableSince and reason.
+	lock sync.Mutex
+	// unavailableSince is the time at which acceleration became unavailable. It
+	// is zero if acceleration hasn't failed since it was last available.
+	unavailableSince time.Time
+	// reason is a description of the most recent failure that prevented
+	// acceleration.
+	reason string
+}
+
+// unavailable records a failure that prevents acceleration.
+func (s *accelerationStatus) unavailable(reason string) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	if s.unavailableSince.IsZero() {
+		s.unavailableSince = time.Now()
+	}
+	s.reason = reason
+}
+
+// available records that acceleration is available.
+func (s *accelerationStatus) available() {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	s.unavailableSince = time.Time{}
+	s.reason = ""
+}
+
+// report returns the reason that acceleration is unavailable if it has been
+// unavailable for at least the specified duration (as of the specified time),
+// otherwise it returns an empty string.
+func (s *accelerationStatus) report(now time.Time, threshold time.Duration) string {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	if s.unavailableSince.IsZero() || now.Sub(s.unavailableSince) < threshold {
+		return ""
+	}
+	return s.reason
+}
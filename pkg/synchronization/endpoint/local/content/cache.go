@@ -0,0 +1,347 @@
+package content
+
+import (
+	"bytes"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"hash"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/mutagen-io/mutagen/pkg/filesystem"
+)
+
+var (
+	// errDigestEmpty is returned when an empty digest is provided.
+	errDigestEmpty = errors.New("digest empty")
+	// ErrDigestMismatch is returned when cached content does not match its
+	// expected digest.
+	ErrDigestMismatch = errors.New("content does not match expected digest")
+)
+
+// evictionLock serializes eviction operations within the current process.
+// Multiple caches (e.g. those belonging to different sessions) may share the
+// same root, so we don't want them performing concurrent eviction scans.
+var evictionLock sync.Mutex
+
+// Cache is a persistent content-addressable store of file content, keyed by
+// digest. Content is verified against its digest both when it's inserted and
+// when it's read back, and content that fails verification on read-back is
+// removed from the cache.
+//
+// The cache's eviction policy is least-recently-used, with usage tracked via
+// the modification time of cached content (which is updated whenever content
+// is inserted or opened). After content has been inserted, a call to Evict will
+// remove the least-recently-used content until the total size of cached content
+// no longer exceeds the maximum cache size. Content that individually exceeds
+// the maximum cache size is never inserted.
+//
+// Because its state lives entirely on disk, multiple Cache instances may share
+// the same root, even across processes. Cache methods are safe for concurrent
+// invocation.
+type Cache struct {
+	// root is the path to the directory used for storage.
+	root string
+	// maximumSize is the maximum total size of cached content.
+	maximumSize uint64
+	// hasherPool is a pool of hash.Hash for computing content digests.
+	hasherPool sync.Pool
+	// dirtyLock serializes access to dirty.
+	dirtyLock sync.Mutex
+	// dirty indicates whether or not content has been inserted since the last
+	// eviction.
+	dirty bool
+}
+
+// NewCache creates a new cache instance with the specified parameters. The
+// root directory will be created on demand.
+func NewCache(root string, maximumSize uint64, hasherFactory func() hash.Hash) *Cache {
+	return &Cache{
+		root:        root,
+		maximumSize: maximumSize,
+		hasherPool: sync.Pool{
+			New: func() any {
+				return hasherFactory()
+			},
+		},
+	}
+}
+
+// target computes the storage path for content with the specified digest,
+// along with its prefix directory path. Callers must verify that the digest is
+// non-empty.
+func (c *Cache) target(digest []byte) (string, string) {
+	digestHex := hex.EncodeToString(digest)
+	prefix := filepath.Join(c.root, digestHex[:2])
+	return filepath.Join(prefix, digestHex), prefix
+}
+
+// Open opens the content with the specified digest for reading, returning the
+// content reader and the content size. If the content isn't present in the
+// cache, then an error that wraps fs.ErrNotExist is returned. The returned
+// reader verifies the content against the digest once the content has been
+// fully read.
+func (c *Cache) Open(digest []byte) (*Reader, uint64, error) {
+	// Verify that the digest is non-empty.
+	if len(digest) == 0 {
+		return nil, 0, errDigestEmpty
+	}
+
+	// Compute the storage path.
+	target, _ := c.target(digest)
+
+	// Open the content and ensure that it's a regular file.
+	file, err := os.Open(target)
+	if err != nil {
+		return nil, 0, err
+	}
+	metadata, err := file.Stat()
+	if err != nil {
+		file.Close()
+		return nil, 0, fmt.Errorf("unable to query cached content: %w", err)
+	} else if !metadata.Mode().IsRegular() {
+		file.Close()
+		return nil, 0, fmt.Errorf("cached content is not a regular file: %w", fs.ErrNotExist)
+	}
+
+	// Mark the content as recently used. If this fails, the content will just
+	// be evicted sooner than it would have been otherwise.
+	now := time.Now()
+	os.Chtimes(target, now, now)
+
+	// Acquire and reset a hasher.
+	hasher := c.hasherPool.Get().(hash.Hash)
+	hasher.Reset()
+
+	// Success.
+	return &Reader{
+		cache:  c,
+		file:   file,
+		target: target,
+		digest: digest,
+		hasher: hasher,
+	}, uint64(metadata.Size()), nil
+}
+
+// Insert copies the content at the specified path into the cache under the
+// specified digest. The content is verified against the digest before being
+// committed. If content with the specified digest is already cached, then it's
+// simply marked as recently used. Content that exceeds the maximum cache size
+// is ignored.
+func (c *Cache) Insert(digest []byte, path string) error {
+	// Verify that the digest is non-empty.
+	if len(digest) == 0 {
+		return errDigestEmpty
+	}
+
+	// Compute the storage path. If content already exists at that location,
+	// then mark it as recently used and we're done. If the content has been
+	// corrupted, then it will be removed when it's next read back.
+	target, prefix := c.target(digest)
+	if metadata, err := os.Lstat(target); err == nil && metadata.Mode().IsRegular() {
+		now := time.Now()
+		os.Chtimes(target, now, now)
+		return nil
+	}
+
+	// Open the source content and defer its closure.
+	source, err := os.Open(path)
+	if err != nil {
+		return fmt.Errorf("unable to open source content: %w", err)
+	}
+	defer source.Close()
+
+	// Ensure that the content will fit in the cache.
+	if metadata, err := source.Stat(); err != nil {
+		return fmt.Errorf("unable to query source content: %w", err)
+	} else if uint64(metadata.Size()) > c.maximumSize {
+		return nil
+	}
+
+	// Ensure that the prefix directory (and thus the root) exists.
+	if err := os.MkdirAll(prefix, 0700); err != nil {
+		return fmt.Errorf("unable to create prefix directory: %w", err)
+	}
+
+	// Create a temporary file in the root to receive the content.
+	temporary, err := os.CreateTemp(c.root, "insert")
+	if err != nil {
+		return fmt.Errorf("unable to create temporary file: %w", err)
+	}
+
+	// Acquire and reset a hasher.
+	hasher := c.hasherPool.Get().(hash.Hash)
+	hasher.Reset()
+	defer c.hasherPool.Put(hasher)
+
+	// Copy the content, computing its digest in the process.
+	_, err = io.Copy(io.MultiWriter(temporary, hasher), source)
+	if closeErr := temporary.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		os.Remove(temporary.Name())
+		return fmt.Errorf("unable to copy content: %w", err)
+	}
+
+	// Verify the content digest.
+	if !bytes.Equal(hasher.Sum(nil), digest) {
+		os.Remove(temporary.Name())
+		return ErrDigestMismatch
+	}
+
+	// Relocate the content to its target destination.
+	if err := filesystem.Rename(nil, temporary.Name(), nil, target, true); err != nil {
+		os.Remove(temporary.Name())
+		return fmt.Errorf("unable to relocate content: %w", err)
+	}
+
+	// Record that eviction may be necessary.
+	c.dirtyLock.Lock()
+	c.dirty = true
+	c.dirtyLock.Unlock()
+
+	// Success.
+	return nil
+}
+
+// cachedContent represents a single piece of cached content on disk.
+type cachedContent struct {
+	// path is the path to the content.
+	path string
+	// size is the size of the content.
+	size uint64
+	// lastUsed is the last time that the content was used.
+	lastUsed time.Time
+}
+
+// Evict removes least-recently-used content from the cache until the total size
+// of cached content no longer exceeds the maximum cache size. It only performs
+// work if content has been inserted by this instance since the last eviction.
+func (c *Cache) Evict() error {
+	// Check whether or not eviction is necessary.
+	c.dirtyLock.Lock()
+	dirty := c.dirty
+	c.dirty = false
+	c.dirtyLock.Unlock()
+	if !dirty {
+		return nil
+	}
+
+	// Serialize eviction within the process.
+	evictionLock.Lock()
+	defer evictionLock.Unlock()
+
+	// Enumerate cached content, tracking its total size.
+	var contents []cachedContent
+	var totalSize uint64
+	prefixes, err := os.ReadDir(c.root)
+	if err != nil {
+		if errors.Is(err, fs.ErrNotExist) {
+			return nil
+		}
+		return fmt.Errorf("unable to read cache root: %w", err)
+	}
+	for _, prefix := range prefixes {
+		if !prefix.IsDir() {
+			continue
+		}
+		prefixPath := filepath.Join(c.root, prefix.Name())
+		entries, err := os.ReadDir(prefixPath)
+		if err != nil {
+			return fmt.Errorf("unable to read prefix directory: %w", err)
+		}
+		for _, entry := range entries {
+			if !entry.Type().IsRegular() {
+				continue
+			}
+			metadata, err := entry.Info()
+			if err != nil {
+				continue
+			}
+			size := uint64(metadata.Size())
+			contents = append(contents, cachedContent{
+				path:     filepath.Join(prefixPath, entry.Name()),
+				size:     size,
+				lastUsed: metadata.ModTime(),
+			})
+			totalSize += size
+		}
+	}
+
+	// Remove the least-recently-used content until we're within limits.
+	sort.Slice(contents, func(i, j int) bool {
+		return contents[i].lastUsed.Before(contents[j].lastUsed)
+	})
+	for _, content := range contents {
+		if totalSize <= c.maximumSize {
+			break
+		}
+		if err := os.Remove(content.path); err != nil && !errors.Is(err, fs.ErrNotExist) {
+			return fmt.Errorf("unable to remove cached content: %w", err)
+		}
+		totalSize -= content.size
+	}
+
+	// Success.
+	return nil
+}
+
+// Reader provides verified read access to cached content.
+type Reader struct {
+	// cache is the parent cache.
+	cache *Cache
+	// file is the underlying content file.
+	file *os.File
+	// target is the path to the content.
+	target string
+	// digest is the expected content digest.
+	digest []byte
+	// hasher computes the digest of the content as it's read. It's set to nil
+	// once it has been returned to the pool.
+	hasher hash.Hash
+}
+
+// Read implements io.Reader.Read. Once the content has been fully read, it's
+// verified against the expected digest. If verification fails, then the content
+// is removed from the cache and ErrDigestMismatch is returned instead of
+// io.EOF.
+func (r *Reader) Read(buffer []byte) (int, error) {
+	// If verification has already been performed, then we're done.
+	if r.hasher == nil {
+		return 0, io.EOF
+	}
+
+	// Perform the read and update the digest.
+	n, err := r.file.Read(buffer)
+	r.hasher.Write(buffer[:n])
+
+	// If we've reached the end of the content, then verify it.
+	if err == io.EOF {
+		digest := r.hasher.Sum(nil)
+		r.cache.hasherPool.Put(r.hasher)
+		r.hasher = nil
+		if !bytes.Equal(digest, r.digest) {
+			os.Remove(r.target)
+			return n, ErrDigestMismatch
+		}
+	}
+
+	// Done.
+	return n, err
+}
+
+// Close implements io.Closer.Close.
+func (r *Reader) Close() error {
+	if r.hasher != nil {
+		r.cache.hasherPool.Put(r.hasher)
+		r.hasher = nil
+	}
+	return r.file.Close()
+}
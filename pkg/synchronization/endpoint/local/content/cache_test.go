@@ -0,0 +1,76 @@
+package content
+
+import (
+	"crypto/sha1"
+	"errors"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+// TestCacheEviction tests that Evict removes least-recently-used content until
+// the cache is within its size limit and that oversized content is never
+// inserted.
+func TestCacheEviction(t *testing.T) {
+	// Create a cache that can hold two pieces of content.
+	const contentSize = 1024
+	cache := NewCache(filepath.Join(t.TempDir(), "cache"), 2*contentSize, sha1.New)
+
+	// Insert three pieces of content with distinct usage times.
+	source := t.TempDir()
+	var digests [][]byte
+	for i := 0; i < 3; i++ {
+		data := make([]byte, contentSize)
+		data[0] = byte(i)
+		digest := sha1.Sum(data)
+		digests = append(digests, digest[:])
+		path := filepath.Join(source, "file")
+		if err := os.WriteFile(path, data, 0600); err != nil {
+			t.Fatal("unable to create source file:", err)
+		} else if err = cache.Insert(digest[:], path); err != nil {
+			t.Fatal("unable to insert content:", err)
+		}
+		target, _ := cache.target(digest[:])
+		usage := time.Now().Add(time.Duration(i-3) * time.Hour)
+		if err := os.Chtimes(target, usage, usage); err != nil {
+			t.Fatal("unable to set content usage time:", err)
+		}
+	}
+
+	// Perform eviction and verify that only the least-recently-used content was
+	// removed.
+	if err := cache.Evict(); err != nil {
+		t.Fatal("unable to perform eviction:", err)
+	}
+	for i, digest := range digests {
+		reader, _, err := cache.Open(digest)
+		if i == 0 {
+			if !errors.Is(err, fs.ErrNotExist) {
+				t.Error("least-recently-used content not evicted")
+			}
+			continue
+		} else if err != nil {
+			t.Fatalf("unable to open content %d: %v", i, err)
+		}
+		if _, err := io.Copy(io.Discard, reader); err != nil {
+			t.Errorf("unable to read content %d: %v", i, err)
+		}
+		reader.Close()
+	}
+
+	// Verify that oversized content isn't inserted.
+	data := make([]byte, 3*contentSize)
+	digest := sha1.Sum(data)
+	path := filepath.Join(source, "oversized")
+	if err := os.WriteFile(path, data, 0600); err != nil {
+		t.Fatal("unable to create source file:", err)
+	} else if err = cache.Insert(digest[:], path); err != nil {
+		t.Fatal("unable to insert content:", err)
+	}
+	if _, _, err := cache.Open(digest[:]); !errors.Is(err, fs.ErrNotExist) {
+		t.Error("oversized content inserted")
+	}
+}
@@ -0,0 +1,4 @@
+// Package content provides a persistent, size-bounded, content-addressable
+// cache of file content that can be used to avoid re-transferring previously
+// seen content across synchronization cycles, sessions, and daemon restarts.
+package content
@@ -1,13 +1,17 @@
 package local
 
 import (
+	"bytes"
 	"context"
 	"errors"
 	"fmt"
 	"hash"
 	"io"
+	"io/fs"
+	"os"
 	"path/filepath"
 	"strings"
+	"sync/atomic"
 	"time"
 
 	"github.com/mutagen-io/mutagen/pkg/encoding"
@@ -18,14 +22,19 @@ import (
 	"github.com/mutagen-io/mutagen/pkg/sidecar"
 	"github.com/mutagen-io/mutagen/pkg/state"
 	"github.com/mutagen-io/mutagen/pkg/synchronization"
+	"github.com/mutagen-io/mutagen/pkg/synchronization/compression"
 	"github.com/mutagen-io/mutagen/pkg/synchronization/core"
 	"github.com/mutagen-io/mutagen/pkg/synchronization/core/fastpath"
 	"github.com/mutagen-io/mutagen/pkg/synchronization/core/ignore"
 	dockerignore "github.com/mutagen-io/mutagen/pkg/synchronization/core/ignore/docker"
 	mutagenignore "github.com/mutagen-io/mutagen/pkg/synchronization/core/ignore/mutagen"
+	"github.com/mutagen-io/mutagen/pkg/synchronization/endpoint/local/content"
 	"github.com/mutagen-io/mutagen/pkg/synchronization/endpoint/local/staging"
+	"github.com/mutagen-io/mutagen/pkg/synchronization/hashing"
 	"github.com/mutagen-io/mutagen/pkg/synchronization/rsync"
 	"github.com/mutagen-io/mutagen/pkg/timeutil"
+
+	"google.golang.org/protobuf/types/known/timestamppb"
 )
 
 const (
@@ -39,6 +48,15 @@ const (
 	// triggering of scan operations by the non-recursive watch in watchPoll
 	// will be coalesced.
 	watchPollScanSignalCoalescingWindow = 10 * time.Millisecond
+	// watchPollDiffMaximumDepth is the maximum depth to which watchPoll
+	// computes differences between successive snapshots when establishing
+	// watches and logging changes. Differences beneath this depth are reported
+	// as a single change at the cutoff, which is then watched in their place.
+	watchPollDiffMaximumDepth = 16
+	// accelerationUnavailableReportingDelay is the length of time for which
+	// accelerated scanning must be continuously unavailable (due to watch
+	// failures) before its unavailability is reported.
+	accelerationUnavailableReportingDelay = time.Minute
 )
 
 // reifiedWatchMode describes a fully reified watch mode based on the watch mode
@@ -70,6 +88,19 @@ type endpoint struct {
 	// maximumEntryCount is the maximum number of entries that the endpoint will
 	// synchronize. This field is static and thus safe for concurrent reads.
 	maximumEntryCount uint64
+	// maximumSignatureMemory is the maximum total (estimated) memory that the
+	// endpoint will dedicate to rsync signatures in a single staging operation.
+	// This field is static and thus safe for concurrent reads.
+	maximumSignatureMemory uint64
+	// rsyncBlockSize is the block size that the endpoint will use when
+	// computing rsync signatures. A zero value indicates that the block size
+	// should be chosen automatically. This field is static and thus safe for
+	// concurrent reads.
+	rsyncBlockSize uint64
+	// maximumInFlightBytes is the maximum total size of rsync operation data
+	// that the endpoint will buffer while supplying files. This field is static
+	// and thus safe for concurrent reads.
+	maximumInFlightBytes uint64
 	// watchMode indicates the watch mode being used. This field is static and
 	// thus safe for concurrent reads.
 	watchMode reifiedWatchMode
@@ -79,12 +110,82 @@ type endpoint struct {
 	// probeMode is the probe mode. This field is static and thus safe for
 	// concurrent reads.
 	probeMode behavior.ProbeMode
+	// probeOptions are the probe options. This field is static and thus safe
+	// for concurrent reads.
+	probeOptions *behavior.ProbeOptions
 	// symbolicLinkMode is the symbolic link mode. This field is static and thus
 	// safe for concurrent reads.
 	symbolicLinkMode core.SymbolicLinkMode
+	// symbolicLinkReplacementMode is the symbolic link replacement mode. This
+	// field is static and thus safe for concurrent reads.
+	symbolicLinkReplacementMode core.SymbolicLinkReplacementMode
+	// symbolicLinkCycleMode is the symbolic link cycle mode. This field is
+	// static and thus safe for concurrent reads.
+	symbolicLinkCycleMode core.SymbolicLinkCycleMode
+	// specialFileMode is the special file mode. This field is static and thus
+	// safe for concurrent reads.
+	specialFileMode core.SpecialFileMode
+	// nameNormalizationMode is the name normalization mode. This field is
+	// static and thus safe for concurrent reads.
+	nameNormalizationMode core.NameNormalizationMode
+	// transitionMode is the transition mode. This field is static and thus
+	// safe for concurrent reads.
+	transitionMode core.TransitionMode
 	// permissionsMode is the permissions mode. This field is static and thus
 	// safe for concurrent reads.
 	permissionsMode core.PermissionsMode
+	// aggregateDigests indicates whether or not scans should compute directory
+	// aggregate digests. This field is static and thus safe for concurrent
+	// reads.
+	aggregateDigests bool
+	// modificationTimes indicates whether or not scans should record file
+	// modification times. This field is static and thus safe for concurrent
+	// reads.
+	modificationTimes bool
+	// directoryListingRetries is the maximum number of times that a directory
+	// listing will be re-read during scanning in an attempt to obtain a stable
+	// listing. This field is static and thus safe for concurrent reads.
+	directoryListingRetries uint32
+	// slowDirectoryListingThreshold is the minimum directory listing duration
+	// for which a directory listing will be logged (at the trace level) during
+	// scanning. This field is static and thus safe for concurrent reads.
+	slowDirectoryListingThreshold time.Duration
+	// maximumFileSize is the maximum size of files to include in scans, with
+	// zero indicating no limit. This field is static and thus safe for
+	// concurrent reads.
+	maximumFileSize uint64
+	// cacheSaveThreshold is the minimum number of cache entries that must
+	// differ from the last saved cache before the cache is written to disk.
+	// This field is static and thus safe for concurrent reads.
+	cacheSaveThreshold uint64
+	// maximumRecheckPaths is the maximum number of re-check paths that will be
+	// used for a baseline-based scan before falling back to a full scan. A
+	// value of 0 indicates no limit. This field is static and thus safe for
+	// concurrent reads.
+	maximumRecheckPaths uint64
+	// scanSubpaths is the list of normalized synchronization-root-relative
+	// paths whose subtrees are the only content re-scanned by regular scans
+	// once a baseline snapshot is available. If empty, then partial scanning
+	// is disabled. This field is static and thus safe for concurrent reads.
+	scanSubpaths []string
+	// requireRoot indicates whether or not the synchronization root is required
+	// to exist (rather than being treated as empty if absent). This field is
+	// static and thus safe for concurrent reads.
+	requireRoot bool
+	// overlayBase is the path to the overlay base directory, if any. If
+	// non-empty, then the endpoint presents the merged view of the overlay
+	// base (as an immutable lower layer) and the synchronization root (as the
+	// upper layer). This field is static and thus safe for concurrent reads.
+	overlayBase string
+	// deltificationTimeLimit is the maximum amount of time that will be spent
+	// computing the rsync delta for an individual file being supplied. A zero
+	// value indicates no limit. This field is static and thus safe for
+	// concurrent reads.
+	deltificationTimeLimit time.Duration
+	// stagingCompressionAlgorithm is the compression algorithm to use for
+	// rsync operation data when supplying files. This field is static and thus
+	// safe for concurrent reads.
+	stagingCompressionAlgorithm compression.Algorithm
 	// defaultFileMode is the default file permission mode to use in "portable"
 	// permission propagation. This field is static and thus safe for concurrent
 	// reads.
@@ -118,6 +219,30 @@ type endpoint struct {
 	// pollSignal is the coalescer used to signal Poll callers. This field is
 	// static and thus safe for concurrent usage.
 	pollSignal *state.Coalescer
+	// watchQueueSize is the internal event queue size to use for native
+	// watchers. This field is static and thus safe for concurrent reads.
+	watchQueueSize int
+	// suppressInitialModifications indicates whether or not poll-based watching
+	// should suppress modification notifications for its first successful scan.
+	// This field is static and thus safe for concurrent reads.
+	suppressInitialModifications bool
+	// watchOverflows is the number of times that the native watcher has failed
+	// due to an internal event overflow. This field is safe for concurrent
+	// usage.
+	watchOverflows atomic.Uint64
+	// accelerationStatus tracks the availability of accelerated scanning when
+	// using recursive watching. This field is safe for concurrent usage.
+	accelerationStatus accelerationStatus
+	// sharedScanKey is the key under which the endpoint shares scan results
+	// with other endpoints in the same process. It is empty if scan sharing is
+	// disabled. This field is static and thus safe for concurrent reads.
+	sharedScanKey string
+	// scanGeneration is a counter that's incremented whenever the snapshot is
+	// replaced by a scan, whenever a watcher event is observed, and whenever a
+	// transition is performed. It's used to detect whether or not a Scan call
+	// returned the same snapshot as a previous call. This field is safe for
+	// concurrent usage.
+	scanGeneration atomic.Uint64
 	// recursiveWatchRetryEstablish is a channel used by Transition to signal to
 	// the recursive watching Goroutine (if any) that it should try to
 	// re-establish watching. It is a non-buffered channel, with reads only
@@ -127,15 +252,19 @@ type endpoint struct {
 	// timer-based signal)). This field is static and never closed, and is thus
 	// safe for concurrent send operations.
 	recursiveWatchRetryEstablish chan struct{}
-	// scanLock serializes access to accelerate, recheckPaths, snapshot, hasher,
-	// cache, ignorer, ignoreCache, cacheWriteError, and lastScanEntryCount.
+	// scanLock serializes access to accelerate, recheckPaths,
+	// partialRecheckPaths, snapshot, snapshotGeneration, hasher, cache,
+	// ignorer, ignoreCache, overlayCache, overlayIgnoreCache, overlayLower,
+	// overlayUpper, cacheWriteError, and lastScanEntryCount.
 	// This lock is not required by the Endpoint interface (which doesn't permit
 	// concurrent usage), but rather the endpoint's background worker Goroutines
 	// for cache saving and filesystem watching. This lock notably excludes
-	// coverage of scannedSinceLastStageCall, scannedSinceLastTransitionCall,
-	// lastReturnedScanCache, lastReturnedScanSnapshotDecomposesUnicode, which
-	// are only updated by Scan and read by Stage and Transition, thus making
-	// them safe under Endpoint's (non-concurrent) interface.
+	// coverage of scannedSinceLastStageCall, stagingDeferred,
+	// scannedSinceLastTransitionCall, lastReturnedScanCache,
+	// lastReturnedScanSnapshotDecomposesUnicode, lastReturnedOverlayLower,
+	// lastReturnedOverlayUpper, and deferrableSnapshot, which are only accessed
+	// by Scan, Stage, and Transition, thus making them safe under Endpoint's
+	// (non-concurrent) interface.
 	//
 	// Instead of being implemented as a mutex, this lock is implemented as a
 	// semaphore, allowing for preemption when waiting on its acquisition. This
@@ -154,17 +283,45 @@ type endpoint struct {
 	// in recursive watching mode. This map will be non-nil if and only if
 	// accelerate is true and recursive watching is being used.
 	recheckPaths map[string]bool
+	// partialRecheckPaths is the set of transition root paths to re-check in
+	// the next partial scan, since they may reside outside of the scan
+	// subpaths. It's only populated if partial scanning is enabled.
+	partialRecheckPaths map[string]bool
 	// snapshot is the snapshot from the last scan.
 	snapshot *core.Snapshot
+	// snapshotGeneration is the scan generation at the time that snapshot was
+	// generated (or adopted from a shared scan).
+	snapshotGeneration uint64
 	// hasher is the hasher used for scans.
 	hasher hash.Hash
+	// emptyFileDigest is the digest of empty file content under the session's
+	// hashing algorithm. It's used to stage empty files locally, without
+	// relying on transmission. This field is static and thus safe for
+	// concurrent reads.
+	emptyFileDigest []byte
 	// cache is the cache from the last successful scan on the endpoint.
 	cache *core.Cache
+	// cacheTrustMode is the cache trust mode to use for the next scan. It is
+	// initially the configured cache trust mode, which applies to the cache
+	// loaded from disk, and it reverts to strict trust after the first
+	// successful scan. It should only be accessed with the scan lock held.
+	cacheTrustMode core.CacheTrustMode
 	// ignorer is the ignorer to use for scans.
 	ignorer ignore.Ignorer
 	// ignoreCache is the ignore cache from the last successful scan on the
 	// endpoint.
 	ignoreCache ignore.IgnoreCache
+	// overlayCache is the cache from the last successful scan of the overlay
+	// base. Unlike cache, it's held only in memory.
+	overlayCache *core.Cache
+	// overlayIgnoreCache is the ignore cache from the last successful scan of
+	// the overlay base.
+	overlayIgnoreCache ignore.IgnoreCache
+	// overlayLower is the overlay base content from the last scan.
+	overlayLower *core.Entry
+	// overlayUpper is the synchronization root content (including overlay
+	// markers) from the last scan.
+	overlayUpper *core.Entry
 	// cacheWriteError is the last error encountered when trying to write the
 	// cache to disk, if any.
 	cacheWriteError error
@@ -173,6 +330,10 @@ type endpoint struct {
 	// scannedSinceLastStageCall tracks whether or not a scan operation has
 	// occurred since the last staging operation.
 	scannedSinceLastStageCall bool
+	// stagingDeferred tracks whether or not the last staging operation deferred
+	// staging of some paths, in which case another staging operation is
+	// permitted without an intervening scan.
+	stagingDeferred bool
 	// scannedSinceLastTransitionCall tracks whether or not a scan operation has
 	// occurred since the last transitioning operation.
 	scannedSinceLastTransitionCall bool
@@ -186,11 +347,66 @@ type endpoint struct {
 	// likely being the same as the value in the current snapshot, it needs to
 	// be tracked separately for the same reasons as lastReturnedScanCache.
 	lastReturnedScanSnapshotDecomposesUnicode bool
+	// lastReturnedOverlayLower is the overlay base content corresponding to the
+	// last snapshot returned by Scan. It's tracked separately for the same
+	// reasons as lastReturnedScanCache.
+	lastReturnedOverlayLower *core.Entry
+	// lastReturnedOverlayUpper is the synchronization root content
+	// corresponding to the last snapshot returned by Scan. It's tracked
+	// separately for the same reasons as lastReturnedScanCache.
+	lastReturnedOverlayUpper *core.Entry
+	// deferrableSnapshot is the last snapshot returned by Scan, updated to
+	// reflect the results of any subsequent transitions. It's returned by
+	// deferred scans in lieu of re-scanning. If it's nil, then deferred scans
+	// are performed as regular scans.
+	deferrableSnapshot *core.Snapshot
+	// stagingRoot is the path to the staging root. It's used to identify (and
+	// filter out) events generated by staging when using non-recursive
+	// watching. This field is static and thus safe for concurrent reads.
+	stagingRoot string
+	// stagingRootRelative is the synchronization-root-relative path to the
+	// staging root, if the staging root resides within the synchronization
+	// root, otherwise it's empty. It's used to identify (and filter out)
+	// events generated by staging when using recursive watching. This field is
+	// static and thus safe for concurrent reads.
+	stagingRootRelative string
+	// maximumRenameDetectionFileSize is the maximum file size for which
+	// staging will attempt to source content from existing files within the
+	// synchronization root. This field is static and thus safe for concurrent
+	// reads.
+	maximumRenameDetectionFileSize uint64
+	// renameDetectionDigestLength is the length to which digests are truncated
+	// in reverse lookup maps. A zero value indicates that digests aren't
+	// truncated. This field is static and thus safe for concurrent reads.
+	renameDetectionDigestLength uint32
+	// renameDetectionRetries is the maximum number of times that staging from
+	// an existing file within the synchronization root will be retried if the
+	// file is modified during copying. This field is static and thus safe for
+	// concurrent reads.
+	renameDetectionRetries uint32
+	// digestsTruncated indicates whether or not snapshot digests are
+	// truncated, in which case they can't be used to identify content across
+	// paths and rename detection is disabled. This field is static and thus
+	// safe for concurrent reads.
+	digestsTruncated bool
+	// contentCache is the persistent content cache. It is nil if the content
+	// cache is disabled (including when snapshot digests are truncated). This
+	// field is static and thus safe for concurrent reads.
+	contentCache *content.Cache
+	// oversizedFiles is the number of files that the stager refused to provide
+	// during the last transition operation because they exceeded the maximum
+	// staging file size.
+	oversizedFiles uint64
 	// stager is the staging coordinator. It is not safe for concurrent usage,
 	// but since Endpoint doesn't allow concurrent usage, we know that the
 	// stager will only be used in at most one of Stage or Transition methods at
 	// any given time.
 	stager stager
+	// signatureCache caches rsync signatures for base files across staging
+	// operations, keyed by path. Its total (estimated) memory usage is bounded
+	// by maximumSignatureMemory. It is only used by Stage, so it doesn't
+	// require synchronization.
+	signatureCache map[string]*signatureCacheEntry
 }
 
 // NewEndpoint creates a new local endpoint instance using the specified session
@@ -212,12 +428,89 @@ func NewEndpoint(
 		synchronizationMode == core.SynchronizationMode_SynchronizationModeOneWayReplica
 	readOnly := alpha && unidirectional
 
-	// Compute the effective hashing algorithm and create the hasher factory.
+	// Determine whether or not the root is required to exist and, if so, verify
+	// that it does. We re-verify this after each scan in case the root is
+	// removed while the endpoint is connected.
+	rootExistenceMode := configuration.RootExistenceMode
+	if rootExistenceMode.IsDefault() {
+		rootExistenceMode = version.DefaultRootExistenceMode()
+	}
+	requireRoot := rootExistenceMode == synchronization.RootExistenceMode_RootExistenceModeRequire
+	if requireRoot {
+		if _, err := os.Lstat(root); err != nil {
+			if errors.Is(err, fs.ErrNotExist) {
+				return nil, fmt.Errorf("synchronization root (%s) does not exist", root)
+			}
+			return nil, fmt.Errorf("unable to query synchronization root: %w", err)
+		}
+	}
+
+	// Compute the effective hashing algorithm, digest sampling threshold, and
+	// digest length and create the hasher factories. Truncation and sampling
+	// are only applied to the scan hasher, where truncation only affects the
+	// digests recorded in snapshots and sampled digests are used solely to
+	// detect changes. Staged and cached content is verified using full
+	// digests.
 	hashingAlgorithm := configuration.HashingAlgorithm
 	if hashingAlgorithm.IsDefault() {
 		hashingAlgorithm = version.DefaultHashingAlgorithm()
 	}
+	digestSamplingThreshold := configuration.DigestSamplingThreshold
+	if digestSamplingThreshold == 0 {
+		digestSamplingThreshold = version.DefaultDigestSamplingThreshold()
+	}
+	digestLength := configuration.DigestLength
+	if digestLength == 0 {
+		digestLength = version.DefaultDigestLength()
+	}
 	hasherFactory := hashingAlgorithm.Factory()
+	snapshotHasherFactory := hashing.NewTruncatingFactory(hasherFactory, digestLength)
+	scanHasherFactory := hashing.NewSamplingFactory(snapshotHasherFactory, digestSamplingThreshold)
+	digestsTruncated := snapshotHasherFactory().Size() < hasherFactory().Size()
+
+	// Determine the watch queue size.
+	watchQueueSize := configuration.WatchQueueSize
+	if watchQueueSize == 0 {
+		watchQueueSize = version.DefaultWatchQueueSize()
+	}
+
+	// Determine the initial poll modification mode.
+	initialPollModificationMode := configuration.InitialPollModificationMode
+	if initialPollModificationMode.IsDefault() {
+		initialPollModificationMode = version.DefaultInitialPollModificationMode()
+	}
+	suppressInitialModifications := initialPollModificationMode ==
+		synchronization.InitialPollModificationMode_InitialPollModificationModeSuppress
+
+	// Determine the directory listing retry count.
+	directoryListingRetries := configuration.DirectoryListingRetries
+	if directoryListingRetries == 0 {
+		directoryListingRetries = version.DefaultDirectoryListingRetries()
+	}
+
+	// Determine the slow directory listing threshold.
+	slowDirectoryListingThreshold := configuration.SlowDirectoryListingThreshold
+	if slowDirectoryListingThreshold == 0 {
+		slowDirectoryListingThreshold = version.DefaultSlowDirectoryListingThreshold()
+	}
+
+	// Determine the rename detection retry count.
+	renameDetectionRetries := configuration.RenameDetectionRetries
+	if renameDetectionRetries == 0 {
+		renameDetectionRetries = version.DefaultRenameDetectionRetries()
+	}
+
+	// Determine the cache save threshold.
+	cacheSaveThreshold := configuration.CacheSaveThreshold
+	if cacheSaveThreshold == 0 {
+		cacheSaveThreshold = version.DefaultCacheSaveThreshold()
+	}
+
+	// Determine the maximum re-check path count.
+	maximumRecheckPaths := configuration.MaximumRecheckPaths
+	if maximumRecheckPaths == 0 {
+		maximumRecheckPaths = version.DefaultMaximumRecheckPaths()
+	}
 
 	// Determine the maximum entry count.
 	maximumEntryCount := configuration.MaximumEntryCount
@@ -231,6 +524,36 @@ func NewEndpoint(
 		maximumStagingFileSize = version.DefaultMaximumStagingFileSize()
 	}
 
+	// Compute the effective oversized file mode.
+	oversizedFileMode := configuration.OversizedFileMode
+	if oversizedFileMode.IsDefault() {
+		oversizedFileMode = version.DefaultOversizedFileMode()
+	}
+
+	// Determine the maximum rename detection file size.
+	maximumRenameDetectionFileSize := configuration.MaximumRenameDetectionFileSize
+	if maximumRenameDetectionFileSize == 0 {
+		maximumRenameDetectionFileSize = version.DefaultMaximumRenameDetectionFileSize()
+	}
+
+	// Determine the maximum signature memory.
+	maximumSignatureMemory := configuration.MaximumSignatureMemory
+	if maximumSignatureMemory == 0 {
+		maximumSignatureMemory = version.DefaultMaximumSignatureMemory()
+	}
+
+	// Determine the rsync block size.
+	rsyncBlockSize := configuration.RsyncBlockSize
+	if rsyncBlockSize == 0 {
+		rsyncBlockSize = version.DefaultRsyncBlockSize()
+	}
+
+	// Determine the maximum in-flight bytes.
+	maximumInFlightBytes := configuration.MaximumInFlightBytes
+	if maximumInFlightBytes == 0 {
+		maximumInFlightBytes = version.DefaultMaximumInFlightBytes()
+	}
+
 	// Compute the effective watch mode.
 	watchMode := configuration.WatchMode
 	if watchMode.IsDefault() {
@@ -255,13 +578,27 @@ func NewEndpoint(
 		panic("unhandled watch mode")
 	}
 
+	// Compute the overlay base path, if any. We normalize it for the same
+	// reasons as the probe directory.
+	var overlayBase string
+	if configuration.OverlayBase != "" {
+		if normalized, err := filesystem.Normalize(configuration.OverlayBase); err != nil {
+			return nil, fmt.Errorf("unable to normalize overlay base path: %w", err)
+		} else if normalized == root {
+			return nil, errors.New("overlay base is the synchronization root")
+		} else {
+			overlayBase = normalized
+		}
+	}
+
 	// Compute the effective scan mode and determine whether or not scan
-	// acceleration is allowed.
+	// acceleration is allowed. Acceleration is disallowed in overlay mode,
+	// since watching doesn't cover the overlay base.
 	scanMode := configuration.ScanMode
 	if scanMode.IsDefault() {
 		scanMode = version.DefaultScanMode()
 	}
-	accelerationAllowed := scanMode == synchronization.ScanMode_ScanModeAccelerated
+	accelerationAllowed := scanMode == synchronization.ScanMode_ScanModeAccelerated && overlayBase == ""
 
 	// Compute the effective probe mode.
 	probeMode := configuration.ProbeMode
@@ -269,12 +606,63 @@ func NewEndpoint(
 		probeMode = version.DefaultProbeMode()
 	}
 
+	// Compute the probe options. If an alternate probe directory has been
+	// specified, then we normalize it, since it may be specified relative to
+	// the user's home directory.
+	probeOptions := &behavior.ProbeOptions{
+		ExecutabilityPreservation: configuration.AssumeExecutabilityPreservation,
+		UnicodeDecomposition:      configuration.AssumeUnicodeDecomposition,
+	}
+	if configuration.ProbeDirectory != "" {
+		if normalized, err := filesystem.Normalize(configuration.ProbeDirectory); err != nil {
+			return nil, fmt.Errorf("unable to normalize probe directory path: %w", err)
+		} else {
+			probeOptions.Directory = normalized
+		}
+	}
+
 	// Compute the effective symbolic link mode.
 	symbolicLinkMode := configuration.SymbolicLinkMode
 	if symbolicLinkMode.IsDefault() {
 		symbolicLinkMode = version.DefaultSymbolicLinkMode()
 	}
 
+	// Determine the symbolic link replacement mode.
+	symbolicLinkReplacementMode := configuration.SymbolicLinkReplacementMode
+	if symbolicLinkReplacementMode.IsDefault() {
+		symbolicLinkReplacementMode = version.DefaultSymbolicLinkReplacementMode()
+	}
+
+	// Determine the symbolic link cycle mode.
+	symbolicLinkCycleMode := configuration.SymbolicLinkCycleMode
+	if symbolicLinkCycleMode.IsDefault() {
+		symbolicLinkCycleMode = version.DefaultSymbolicLinkCycleMode()
+	}
+
+	// Compute the effective special file mode.
+	specialFileMode := configuration.SpecialFileMode
+	if specialFileMode.IsDefault() {
+		specialFileMode = version.DefaultSpecialFileMode()
+	}
+
+	// Compute the effective cache trust mode.
+	cacheTrustMode := configuration.CacheTrustMode
+	if cacheTrustMode.IsDefault() {
+		cacheTrustMode = version.DefaultCacheTrustMode()
+	}
+
+	// Compute the effective name normalization mode.
+	nameNormalizationMode := configuration.NameNormalizationMode
+	if nameNormalizationMode.IsDefault() {
+		nameNormalizationMode = version.DefaultNameNormalizationMode()
+	}
+
+	// Compute the effective transition mode.
+	transitionMode := configuration.TransitionMode
+	if transitionMode.IsDefault() {
+		transitionMode = version.DefaultTransitionMode()
+	}
+
 	// Compute the effective ignore syntax.
 	ignoreSyntax := configuration.IgnoreSyntax
 	if ignoreSyntax.IsDefault() {
@@ -311,6 +699,20 @@ func NewEndpoint(
 		ignorer = ignore.IgnoreVCS(ignorer)
 	}
 
+	// Compute the effective OS metadata ignore mode and add OS metadata ignores
+	// if necessary.
+	ignoreOSMetadataMode := configuration.IgnoreOSMetadataMode
+	if ignoreOSMetadataMode.IsDefault() {
+		ignoreOSMetadataMode = version.DefaultIgnoreOSMetadataMode()
+	}
+	if ignoreOSMetadataMode == ignore.IgnoreOSMetadataMode_IgnoreOSMetadataModeIgnore {
+		ignorer = ignore.IgnoreOSMetadata(ignorer, nil)
+	}
+
+	// Restrict the ignorer to the manifest, if any. This is performed last so
+	// that ignores still apply to manifested content.
+	ignorer = ignore.IgnoreUnmanifested(ignorer, configuration.Manifest)
+
 	// Track whether or not any non-default ownership or directory permissions
 	// are set. We don't care about non-default file permissions since we're
 	// only tracking this to set volume root ownership and permissions in
@@ -362,6 +764,37 @@ func NewEndpoint(
 		return nil, fmt.Errorf("unable to create ownership specification: %w", err)
 	}
 
+	// Compute the effective aggregate digest mode. Aggregate digests aren't
+	// computed in overlay mode, since merged directories don't carry them.
+	aggregateDigestMode := configuration.AggregateDigestMode
+	if aggregateDigestMode.IsDefault() {
+		aggregateDigestMode = version.DefaultAggregateDigestMode()
+	}
+	aggregateDigests := aggregateDigestMode == synchronization.AggregateDigestMode_AggregateDigestModeEnabled &&
+		overlayBase == ""
+
+	// Compute the effective scan sharing mode and, if sharing is enabled,
+	// compute the key under which scan results will be shared. Sharing is
+	// only allowed if scan acceleration is allowed, since reusing shared
+	// results is a form of acceleration (and since shared results can only be
+	// invalidated by accelerated scanning).
+	scanSharingMode := configuration.ScanSharingMode
+	if scanSharingMode.IsDefault() {
+		scanSharingMode = version.DefaultScanSharingMode()
+	}
+	var scanKey string
+	if scanSharingMode == synchronization.ScanSharingMode_ScanSharingModeEnabled && accelerationAllowed {
+		scanKey = sharedScanKey(root,
+			hashingAlgorithm, digestSamplingThreshold, digestLength,
+			ignoreSyntax, ignoreVCSMode, ignoreOSMetadataMode, ignores, configuration.Manifest,
+			probeMode, probeOptions.Directory,
+			probeOptions.ExecutabilityPreservation, probeOptions.UnicodeDecomposition,
+			symbolicLinkMode, specialFileMode, nameNormalizationMode, permissionsMode,
+			synchronizationMode == core.SynchronizationMode_SynchronizationModeTwoWayNewest,
+			configuration.MaximumFileSize, aggregateDigests,
+		)
+	}
+
 	// Compute the cache path if this isn't an ephemeral endpoint.
 	cachePath, err := pathForCache(sessionIdentifier, alpha)
 	if err != nil {
@@ -426,6 +859,43 @@ func NewEndpoint(
 		return nil, fmt.Errorf("unable to compute staging root: %w", err)
 	}
 
+	// Determine the maximum content cache size and, if the content cache is
+	// enabled, create it. The content cache is shared across sessions and thus
+	// requires that digests identify content, so we don't create it if
+	// snapshot digests are truncated.
+	maximumContentCacheSize := configuration.MaximumContentCacheSize
+	if maximumContentCacheSize == 0 {
+		maximumContentCacheSize = version.DefaultMaximumContentCacheSize()
+	}
+	var contentCache *content.Cache
+	if maximumContentCacheSize != 0 && !digestsTruncated {
+		hashingAlgorithmName, _ := hashingAlgorithm.MarshalText()
+		contentCacheRoot, err := pathForContentCache(string(hashingAlgorithmName), digestSamplingThreshold, digestLength)
+		if err != nil {
+			return nil, fmt.Errorf("unable to compute content cache root: %w", err)
+		}
+		contentCache = content.NewCache(contentCacheRoot, maximumContentCacheSize, hasherFactory)
+	}
+
+	// Determine the deltification time limit.
+	maximumDeltificationTime := configuration.MaximumDeltificationTime
+	if maximumDeltificationTime == 0 {
+		maximumDeltificationTime = version.DefaultMaximumDeltificationTime()
+	}
+	deltificationTimeLimit := time.Duration(maximumDeltificationTime) * time.Millisecond
+
+	// Determine the staging compression algorithm.
+	stagingCompressionAlgorithm := configuration.StagingCompressionAlgorithm
+	if stagingCompressionAlgorithm.IsDefault() {
+		stagingCompressionAlgorithm = version.DefaultStagingCompressionAlgorithm()
+	}
+
+	// Determine the maximum staged content age.
+	maximumStagedContentAge := configuration.MaximumStagedContentAge
+	if maximumStagedContentAge == 0 {
+		maximumStagedContentAge = version.DefaultMaximumStagedContentAge()
+	}
+
 	// HACK: If non-default ownership or permissions have been set and the
 	// synchronization root is a volume mount point in a Mutagen sidecar
 	// container with no pre-existing content, then set the ownership and
@@ -461,32 +931,70 @@ func NewEndpoint(
 
 	// Create the endpoint.
 	endpoint := &endpoint{
-		logger:                       logger,
-		root:                         root,
-		readOnly:                     readOnly,
-		maximumEntryCount:            maximumEntryCount,
-		watchMode:                    actualWatchMode,
-		accelerationAllowed:          accelerationAllowed,
-		probeMode:                    probeMode,
-		symbolicLinkMode:             symbolicLinkMode,
-		permissionsMode:              permissionsMode,
-		defaultFileMode:              defaultFileMode,
-		defaultDirectoryMode:         defaultDirectoryMode,
-		defaultOwnership:             defaultOwnership,
-		workerCancel:                 workerCancel,
-		saveCacheSignal:              saveCacheSignal,
-		saveCacheDone:                saveCacheDone,
-		watchDone:                    watchDone,
-		pollSignal:                   state.NewCoalescer(pollSignalCoalescingWindow),
-		recursiveWatchRetryEstablish: make(chan struct{}),
-		scanLock:                     scanLock,
-		hasher:                       hasherFactory(),
-		cache:                        cache,
-		ignorer:                      ignorer,
+		logger:                         logger,
+		root:                           root,
+		readOnly:                       readOnly,
+		maximumEntryCount:              maximumEntryCount,
+		maximumSignatureMemory:         maximumSignatureMemory,
+		rsyncBlockSize:                 rsyncBlockSize,
+		maximumInFlightBytes:           maximumInFlightBytes,
+		watchMode:                      actualWatchMode,
+		accelerationAllowed:            accelerationAllowed,
+		probeMode:                      probeMode,
+		probeOptions:                   probeOptions,
+		symbolicLinkMode:               symbolicLinkMode,
+		symbolicLinkReplacementMode:    symbolicLinkReplacementMode,
+		symbolicLinkCycleMode:          symbolicLinkCycleMode,
+		specialFileMode:                specialFileMode,
+		nameNormalizationMode:          nameNormalizationMode,
+		transitionMode:                 transitionMode,
+		permissionsMode:                permissionsMode,
+		aggregateDigests:               aggregateDigests,
+		modificationTimes:              synchronizationMode == core.SynchronizationMode_SynchronizationModeTwoWayNewest,
+		directoryListingRetries:        directoryListingRetries,
+		slowDirectoryListingThreshold:  time.Duration(slowDirectoryListingThreshold) * time.Millisecond,
+		maximumFileSize:                configuration.MaximumFileSize,
+		cacheSaveThreshold:             cacheSaveThreshold,
+		maximumRecheckPaths:            maximumRecheckPaths,
+		scanSubpaths:                   configuration.ScanSubpaths,
+		requireRoot:                    requireRoot,
+		overlayBase:                    overlayBase,
+		deltificationTimeLimit:         deltificationTimeLimit,
+		stagingCompressionAlgorithm:    stagingCompressionAlgorithm,
+		defaultFileMode:                defaultFileMode,
+		defaultDirectoryMode:           defaultDirectoryMode,
+		defaultOwnership:               defaultOwnership,
+		workerCancel:                   workerCancel,
+		saveCacheSignal:                saveCacheSignal,
+		saveCacheDone:                  saveCacheDone,
+		watchDone:                      watchDone,
+		pollSignal:                     state.NewCoalescer(pollSignalCoalescingWindow),
+		watchQueueSize:                 int(watchQueueSize),
+		suppressInitialModifications:   suppressInitialModifications,
+		sharedScanKey:                  scanKey,
+		recursiveWatchRetryEstablish:   make(chan struct{}),
+		scanLock:                       scanLock,
+		partialRecheckPaths:            make(map[string]bool),
+		hasher:                         scanHasherFactory(),
+		emptyFileDigest:                snapshotHasherFactory().Sum(nil),
+		cache:                          cache,
+		cacheTrustMode:                 cacheTrustMode,
+		ignorer:                        ignorer,
+		overlayCache:                   &core.Cache{},
+		stagingRoot:                    stagingRoot,
+		stagingRootRelative:            rootRelativeStagingPath(root, stagingRoot),
+		maximumRenameDetectionFileSize: maximumRenameDetectionFileSize,
+		renameDetectionDigestLength:    configuration.RenameDetectionDigestLength,
+		renameDetectionRetries:         renameDetectionRetries,
+		digestsTruncated:               digestsTruncated,
+		contentCache:                   contentCache,
 		stager: staging.NewStager(
+			logger.Sublogger("staging"),
 			stagingRoot,
 			hideStagingRoot,
 			maximumStagingFileSize,
+			oversizedFileMode,
+			time.Duration(maximumStagedContentAge)*time.Second,
 			hasherFactory,
 		),
 	}
@@ -536,6 +1044,20 @@ func (e *endpoint) unlockScanLock() {
 	e.scanLock <- struct{}{}
 }
 
+// cacheSaveRequired determines whether or not a cache should be saved to disk
+// based on the last saved cache (which may be nil if no cache has been saved)
+// and the cache save threshold. Because differences are always measured
+// against the last saved cache, changes that fall below the threshold aren't
+// lost, they simply accumulate until the threshold is reached.
+func cacheSaveRequired(lastSaved, current *core.Cache, threshold uint64) bool {
+	if lastSaved == nil {
+		return true
+	} else if current == lastSaved {
+		return false
+	}
+	return current.Differences(lastSaved) >= threshold
+}
+
 // saveCache serializes the cache and writes the result to disk at regular
 // intervals. It runs as a background Goroutine for all endpoints.
 func (e *endpoint) saveCache(ctx context.Context, cachePath string, signal <-chan struct{}) {
@@ -566,9 +1088,9 @@ func (e *endpoint) saveCache(ctx context.Context, cachePath string, signal <-cha
 			// Grab the scan lock.
 			e.lockScanLock(context.Background())
 
-			// If the cache hasn't changed since the last write, then skip this
-			// save request.
-			if e.cache == lastSavedCache {
+			// If the cache hasn't changed sufficiently since the last write,
+			// then skip this save request.
+			if !cacheSaveRequired(lastSavedCache, e.cache, e.cacheSaveThreshold) {
 				e.unlockScanLock()
 				continue
 			}
@@ -607,32 +1129,45 @@ func (e *endpoint) watchPoll(ctx context.Context, pollingInterval uint32, nonRec
 	// adjust some behaviors in that case.
 	first := true
 
+	// Log if we won't suppress modification notifications for our first
+	// successful scan.
+	if !e.suppressInitialModifications {
+		logger.Debug("Initial modification suppression disabled")
+	}
+
 	// Track the previous snapshot.
 	previous := &core.Snapshot{}
 
 	// If non-recursive watching is available, then set up a non-recursive
 	// watcher (and ensure its termination). Since non-recursive watching is a
 	// best-effort basis to reduce latency, we don't try to re-establish this
-	// watcher if it fails.
+	// watcher if it fails, unless it fails due to an internal event overflow,
+	// in which case the failure is likely transient. We track whether or not
+	// watches need to be re-established for existing content after such a
+	// re-establishment.
 	var watcher watching.NonRecursiveWatcher
 	var watchEvents <-chan string
 	var watchErrors <-chan error
-	if nonRecursiveWatchingAllowed && watching.NonRecursiveWatchingSupported {
+	var rewatch bool
+	establishWatcher := func() {
 		logger.Debug("Creating non-recursive watcher")
-		if w, err := watching.NewNonRecursiveWatcher(); err != nil {
+		if w, err := watching.NewNonRecursiveWatcher(e.watchQueueSize); err != nil {
 			logger.Debug("Unable to create non-recursive watcher:", err)
 		} else {
 			logger.Debug("Successfully created non-recursive watcher")
 			watcher = w
 			watchEvents = watcher.Events()
 			watchErrors = watcher.Errors()
-			defer func() {
-				if watcher != nil {
-					watcher.Terminate()
-				}
-			}()
 		}
 	}
+	if nonRecursiveWatchingAllowed && watching.NonRecursiveWatchingSupported {
+		establishWatcher()
+		defer func() {
+			if watcher != nil {
+				watcher.Terminate()
+			}
+		}()
+	}
 
 	// Create (and defer termination of) a coalescer that we can use to drive
 	// polling when using non-recursive watching. This is only required if a
@@ -658,11 +1193,14 @@ func (e *endpoint) watchPoll(ctx context.Context, pollingInterval uint32, nonRec
 		// Of course, if our scan fails on the first try, then we'll allow a
 		// notification (due to these "artificial" modifications) to be sent
 		// after the first successful scan, but that will at least occur after
-		// the initial polling duration.
+		// the initial polling duration. This suppression can be disabled (using
+		// the initial poll modification mode), in which case the first
+		// successful scan will trigger a notification if there's existing
+		// content.
 		var skipWaiting, ignoreModifications bool
 		if first {
 			skipWaiting = true
-			ignoreModifications = true
+			ignoreModifications = e.suppressInitialModifications
 			first = false
 		}
 
@@ -701,6 +1239,16 @@ func (e *endpoint) watchPoll(ctx context.Context, pollingInterval uint32, nonRec
 				watcher = nil
 				watchErrors = nil
 
+				// If the watcher failed due to an internal event overflow, then
+				// record the overflow and re-establish the watcher, since the
+				// failure is likely due to a transient burst of events. We'll
+				// need to re-establish watches for existing content.
+				if err == watching.ErrWatchInternalOverflow {
+					e.watchOverflows.Add(1)
+					establishWatcher()
+					rewatch = true
+				}
+
 				// Strobe the re-scan signal an continue polling.
 				performScanSignal.Strobe()
 				continue
@@ -713,8 +1261,11 @@ func (e *endpoint) watchPoll(ctx context.Context, pollingInterval uint32, nonRec
 				// temporary directories will never be added to the watcher
 				// (since they'll never be included in the scan), and thus we'll
 				// never see changes to their contents that would need to be
-				// filtered out.
-				ignore := strings.HasPrefix(filepath.Base(path), filesystem.TemporaryNamePrefix)
+				// filtered out. We do, however, explicitly exclude the staging
+				// root, since it won't necessarily have a temporary name and
+				// may reside within watched content.
+				ignore := strings.HasPrefix(filepath.Base(path), filesystem.TemporaryNamePrefix) ||
+					pathIsWithin(path, e.stagingRoot, filepath.Separator)
 				if ignore {
 					logger.Tracef("Ignoring event path: \"%s\"", path)
 					continue
@@ -722,6 +1273,9 @@ func (e *endpoint) watchPoll(ctx context.Context, pollingInterval uint32, nonRec
 					logger.Tracef("Processing event path: \"%s\"", path)
 				}
 
+				// Invalidate the scan generation.
+				e.scanGeneration.Add(1)
+
 				// Strobe the re-scan signal and continue polling.
 				performScanSignal.Strobe()
 				continue
@@ -759,6 +1313,9 @@ func (e *endpoint) watchPoll(ctx context.Context, pollingInterval uint32, nonRec
 			logger.Debug("Accelerated scanning now available")
 		}
 
+		// Share the scan results, if possible.
+		e.publishSharedScan()
+
 		// Extract scan parameters so that we can release the scan lock.
 		snapshot := e.snapshot
 
@@ -769,12 +1326,21 @@ func (e *endpoint) watchPoll(ctx context.Context, pollingInterval uint32, nonRec
 		modified := !snapshot.Equal(previous)
 
 		// If we have a working non-recursive watcher, or we're performing trace
-		// logging, then perform a full diff to determine what's changed. This
-		// will let us determine the most recently updated paths that we should
-		// watch, as well as establish those watches. Any watch establishment
-		// errors will be reported on the watch errors channel.
+		// logging, then perform a (depth-limited) diff to determine what's
+		// changed. This will let us determine the most recently updated paths
+		// that we should watch, as well as establish those watches. Any watch
+		// establishment errors will be reported on the watch errors channel.
+		//
+		// If the watcher has been re-established, then we compute the diff
+		// against an empty baseline so that watches are established for
+		// existing content.
 		if watcher != nil || logger.Level() >= logging.LevelTrace {
-			changes := core.Diff(previous.Content, snapshot.Content)
+			baseline := previous.Content
+			if rewatch && watcher != nil {
+				baseline = nil
+				rewatch = false
+			}
+			changes := core.DiffLimited(baseline, snapshot.Content, watchPollDiffMaximumDepth)
 			for _, change := range changes {
 				logger.Tracef("Observed change at \"%s\"", change.Path)
 				if watcher != nil && change.New != nil &&
@@ -796,6 +1362,9 @@ func (e *endpoint) watchPoll(ctx context.Context, pollingInterval uint32, nonRec
 
 			// Strobe the poll signal.
 			e.pollSignal.Strobe()
+		} else if modified {
+			// Log the suppression of modifications.
+			logger.Debug("Suppressing modification notification for initial scan")
 		} else {
 			// Log the lack of modifications.
 			logger.Debug("No unignored modifications detected")
@@ -832,11 +1401,16 @@ WatchEstablishment:
 	for {
 		// Attempt to establish the watch.
 		logger.Debug("Attempting to establish recursive watch")
-		watcher, err = watching.NewRecursiveWatcher(e.root)
+		watcher, err = watching.NewRecursiveWatcher(e.root, e.watchQueueSize)
 		if err != nil {
 			// Log the failure.
 			logger.Debug("Unable to establish recursive watch:", err)
 
+			// Record that acceleration is unavailable, if necessary.
+			if e.accelerationAllowed {
+				e.accelerationStatus.unavailable(fmt.Sprintf("unable to establish recursive watch: %v", err))
+			}
+
 			// Strobe the poll signal (since nothing else will be driving
 			// synchronization from this endpoint at this point in time).
 			e.pollSignal.Strobe()
@@ -896,11 +1470,14 @@ WatchEstablishment:
 				e.lockScanLock(context.Background())
 				if err := e.scan(ctx, nil, nil); err != nil {
 					logger.Debug("Unable to perform baseline scan:", err)
+					e.accelerationStatus.unavailable(fmt.Sprintf("unable to perform baseline scan: %v", err))
 					timer.Reset(pollingDuration)
 				} else {
 					logger.Debug("Accelerated scanning now available")
+					e.accelerationStatus.available()
 					e.accelerate = true
 					e.recheckPaths = make(map[string]bool)
+					e.publishSharedScan()
 				}
 				e.unlockScanLock()
 
@@ -914,14 +1491,24 @@ WatchEstablishment:
 				logger.Debug("Recursive watching error:", err)
 
 				// If acceleration is allowed on the endpoint, then disable scan
-				// acceleration and clear out the re-check paths.
+				// acceleration, clear out the re-check paths, and record the
+				// reason for the loss of acceleration.
 				if e.accelerationAllowed {
 					e.lockScanLock(context.Background())
 					e.accelerate = false
 					e.recheckPaths = nil
 					e.unlockScanLock()
+					if err == watching.ErrWatchInternalOverflow {
+						e.accelerationStatus.unavailable("watch event overflow")
+					} else {
+						e.accelerationStatus.unavailable(fmt.Sprintf("recursive watching failed: %v", err))
+					}
 				}
 
+				// Withdraw any shared scan results, since we can no longer
+				// detect modifications that would invalidate them.
+				sharedScans.withdraw(e)
+
 				// Stop and drain the timer, which may be running.
 				timeutil.StopAndDrainTimer(timer)
 
@@ -938,6 +1525,7 @@ WatchEstablishment:
 				// process them. In that case, wait one polling interval before
 				// attempting to re-establish the watch.
 				if err == watching.ErrWatchInternalOverflow {
+					e.watchOverflows.Add(1)
 					logger.Debug("Waiting before watch re-establishment")
 					timer.Reset(pollingDuration)
 					select {
@@ -956,8 +1544,13 @@ WatchEstablishment:
 				// check the entire path for a temporary prefix to identify
 				// temporary directories (whose contents may have non-temporary
 				// names, such as in the case of internal staging directories).
+				// Finally, we explicitly exclude the staging root (if it's
+				// within the synchronization root), since it won't necessarily
+				// have a temporary name (e.g. if the Mutagen data directory
+				// resides within the synchronization root).
 				ignore := strings.HasPrefix(path, filesystem.TemporaryNamePrefix) ||
-					strings.HasPrefix(fastpath.Base(path), filesystem.TemporaryNamePrefix)
+					strings.HasPrefix(fastpath.Base(path), filesystem.TemporaryNamePrefix) ||
+					pathIsWithin(path, e.stagingRootRelative, '/')
 				if ignore {
 					logger.Tracef("Ignoring event path: \"%s\"", path)
 					continue
@@ -965,6 +1558,9 @@ WatchEstablishment:
 					logger.Tracef("Processing event path: \"%s\"", path)
 				}
 
+				// Invalidate the scan generation.
+				e.scanGeneration.Add(1)
+
 				// If acceleration is allowed (and currently available) on the
 				// endpoint, then register the path as a re-check path. We only
 				// need to do this if acceleration is already available,
@@ -973,7 +1569,7 @@ WatchEstablishment:
 				if e.accelerationAllowed {
 					e.lockScanLock(context.Background())
 					if e.accelerate {
-						e.recheckPaths[path] = true
+						e.addRecheckPath(path)
 					}
 					e.unlockScanLock()
 				}
@@ -997,30 +1593,93 @@ func (e *endpoint) Poll(ctx context.Context) error {
 	return nil
 }
 
+// directoryListingObserver returns the directory listing observer to use for
+// scans, which logs slow directory listings at the trace level. It returns nil
+// if trace logging is disabled, in which case scans won't time listings.
+func (e *endpoint) directoryListingObserver() core.DirectoryListingObserver {
+	if e.logger.Level() < logging.LevelTrace {
+		return nil
+	}
+	return func(path string, duration time.Duration) {
+		e.logger.Tracef("Slow directory listing at \"%s\" (%s)", path, duration)
+	}
+}
+
 // scan is the internal function which performs a scan operation on the root and
 // updates the endpoint scan parameters. The caller must hold the scan lock.
 func (e *endpoint) scan(ctx context.Context, baseline *core.Snapshot, recheckPaths map[string]bool) error {
+	// If we're operating in overlay mode, then scan the overlay base first.
+	// Acceleration is disallowed in overlay mode, so there won't be a baseline
+	// or re-check paths in this case. We avoid probing the base, since probing
+	// would create files within it.
+	var lower *core.Snapshot
+	var newOverlayCache *core.Cache
+	var newOverlayIgnoreCache ignore.IgnoreCache
+	if e.overlayBase != "" {
+		var err error
+		lower, newOverlayCache, newOverlayIgnoreCache, err = core.Scan(
+			ctx,
+			e.overlayBase,
+			nil, nil,
+			e.hasher, e.overlayCache, core.CacheTrustMode_CacheTrustModeStrict,
+			e.ignorer, e.overlayIgnoreCache,
+			behavior.ProbeMode_ProbeModeAssume, nil,
+			e.symbolicLinkMode,
+			e.specialFileMode,
+			e.nameNormalizationMode,
+			e.permissionsMode,
+			false,
+			e.modificationTimes,
+			e.directoryListingRetries,
+			e.maximumFileSize,
+			e.directoryListingObserver(), e.slowDirectoryListingThreshold,
+		)
+		if err != nil {
+			return fmt.Errorf("unable to scan overlay base: %w", err)
+		}
+	}
+
 	// Perform a full (warm) scan, watching for errors.
 	snapshot, newCache, newIgnoreCache, err := core.Scan(
 		ctx,
 		e.root,
 		baseline, recheckPaths,
-		e.hasher, e.cache,
+		e.hasher, e.cache, e.cacheTrustMode,
 		e.ignorer, e.ignoreCache,
-		e.probeMode,
+		e.probeMode, e.probeOptions,
 		e.symbolicLinkMode,
+		e.specialFileMode,
+		e.nameNormalizationMode,
 		e.permissionsMode,
+		e.aggregateDigests,
+		e.modificationTimes,
+		e.directoryListingRetries,
+		e.maximumFileSize,
+		e.directoryListingObserver(), e.slowDirectoryListingThreshold,
 	)
 	if err != nil {
 		return err
 	}
 
-	// Update the snapshot.
+	// If we're operating in overlay mode, then record the layers and compute
+	// the merged snapshot.
+	if lower != nil {
+		e.overlayLower = lower.Content
+		e.overlayUpper = snapshot.Content
+		e.overlayCache = newOverlayCache
+		e.overlayIgnoreCache = newOverlayIgnoreCache
+		snapshot = core.MergeOverlaySnapshots(lower, snapshot, newOverlayCache, newCache)
+	}
+
+	// Update the snapshot and invalidate the scan generation.
 	e.snapshot = snapshot
+	e.snapshotGeneration = e.scanGeneration.Add(1)
 
-	// Update caches.
+	// Update caches. Since the cache is now generated by this endpoint, strict
+	// cache trust can be used from this point forward.
 	e.cache = newCache
 	e.ignoreCache = newIgnoreCache
+	e.cacheTrustMode = core.CacheTrustMode_CacheTrustModeStrict
 
 	// Update the last scan entry count.
 	e.lastScanEntryCount = snapshot.Content.Count()
@@ -1036,7 +1695,7 @@ func (e *endpoint) scan(ctx context.Context, baseline *core.Snapshot, recheckPat
 }
 
 // Scan implements the Scan method for local endpoints.
-func (e *endpoint) Scan(ctx context.Context, _ *core.Entry, full bool) (*core.Snapshot, error, bool) {
+func (e *endpoint) Scan(ctx context.Context, _ *core.Entry, full bool, scope []string, deferred bool) (*core.Snapshot, error, bool) {
 	// Grab the scan lock and defer its release. If lock acquisition is
 	// preempted, then the controller has cancelled the request.
 	if !e.lockScanLock(ctx) {
@@ -1051,6 +1710,25 @@ func (e *endpoint) Scan(ctx context.Context, _ *core.Entry, full bool) (*core.Sn
 		return nil, fmt.Errorf("unable to save cache to disk: %w", e.cacheWriteError), false
 	}
 
+	// If a deferred scan has been requested (and neither a full nor a scoped
+	// scan is required), then return the deferrable snapshot without
+	// re-scanning. Transition verifies its operations against the filesystem
+	// just-in-time, so the only consequence of modifications that aren't
+	// reflected in the snapshot is that they'll go unobserved until the next
+	// regular scan. We don't update lastReturnedScanCache (or the related
+	// values) because the deferrable snapshot is derived from the snapshot to
+	// which they correspond. We do increment the scan generation, since the
+	// deferrable snapshot may differ from the snapshot associated with the
+	// current generation.
+	if deferred && !full && len(scope) == 0 && e.deferrableSnapshot != nil {
+		e.logger.Debug("Performing deferred scan with last returned snapshot")
+		e.scanGeneration.Add(1)
+		e.lastScanEntryCount = e.deferrableSnapshot.Content.Count()
+		e.scannedSinceLastStageCall = true
+		e.scannedSinceLastTransitionCall = true
+		return e.deferrableSnapshot, nil, false
+	}
+
 	// Perform a scan.
 	//
 	// We check to see if we can accelerate the scanning process by using
@@ -1070,17 +1748,75 @@ func (e *endpoint) Scan(ctx context.Context, _ *core.Entry, full bool) (*core.Sn
 	// accelerated scanning with recursive watching, there's no need to disable
 	// acceleration on failure so long as the watch is still established (and if
 	// it's not, that will handled elsewhere).
+	//
+	// If a scope has been specified, then we treat every directory within the
+	// scoped subtrees of the baseline snapshot as a re-check path, which forces
+	// the scan to re-read those directories (and re-check their files) rather
+	// than trusting the baseline. For poll-based watching, this means that we
+	// perform a baseline-based re-scan instead of re-using the last scan.
+	//
+	// If acceleration isn't available but partial scanning is enabled (and a
+	// baseline snapshot is available), then we perform a baseline-based re-scan
+	// that re-checks only the scan subpaths (along with any paths modified by
+	// transitions since the last scan), treating all other content as
+	// unchanged. Since the resulting snapshot is still complete, reconciliation
+	// remains correct for content outside of the scan subpaths, which is simply
+	// represented by its last-known state (as in the case of a scoped flush).
+	//
+	// In any case, if the number of re-check paths exceeds the maximum allowed
+	// count, then we fall back to a full (warm) scan, since re-checking a large
+	// number of individual paths is unlikely to be cheaper.
 	if e.accelerate && !full {
 		if e.watchMode == reifiedWatchModeRecursive {
-			e.logger.Debug("Performing accelerated scan with", len(e.recheckPaths), "recheck paths")
-			if err := e.scan(ctx, e.snapshot, e.recheckPaths); err != nil {
-				return nil, err, !errors.Is(err, core.ErrScanCancelled)
+			addScopeRecheckPaths(e.recheckPaths, e.snapshot.Content, scope)
+			if e.recheckPathsExceeded(e.recheckPaths) {
+				e.logger.Debug("Performing full scan due to", len(e.recheckPaths), "recheck paths")
+				if err := e.scan(ctx, nil, nil); err != nil {
+					return nil, err, true
+				}
 			} else {
-				e.recheckPaths = make(map[string]bool)
+				e.logger.Debug("Performing accelerated scan with", len(e.recheckPaths), "recheck paths")
+				if err := e.scan(ctx, e.snapshot, e.recheckPaths); err != nil {
+					return nil, err, !errors.Is(err, core.ErrScanCancelled)
+				}
+			}
+			e.recheckPaths = make(map[string]bool)
+		} else if len(scope) > 0 {
+			recheckPaths := make(map[string]bool)
+			addScopeRecheckPaths(recheckPaths, e.snapshot.Content, scope)
+			if e.recheckPathsExceeded(recheckPaths) {
+				e.logger.Debug("Performing full scan due to", len(recheckPaths), "recheck paths")
+				if err := e.scan(ctx, nil, nil); err != nil {
+					return nil, err, true
+				}
+			} else {
+				e.logger.Debug("Performing scoped scan with", len(recheckPaths), "recheck paths")
+				if err := e.scan(ctx, e.snapshot, recheckPaths); err != nil {
+					return nil, err, !errors.Is(err, core.ErrScanCancelled)
+				}
 			}
 		} else {
 			e.logger.Debug("Performing accelerated scan with existing snapshot")
 		}
+	} else if !full && e.adoptSharedScan() {
+		e.logger.Debug("Performing accelerated scan with shared snapshot")
+	} else if !full && len(e.scanSubpaths) > 0 && e.snapshot != nil {
+		recheckPaths := make(map[string]bool, len(e.partialRecheckPaths))
+		for path := range e.partialRecheckPaths {
+			recheckPaths[path] = true
+		}
+		addScopeRecheckPaths(recheckPaths, e.snapshot.Content, e.scanSubpaths)
+		if e.recheckPathsExceeded(recheckPaths) {
+			e.logger.Debug("Performing full scan due to", len(recheckPaths), "recheck paths")
+			if err := e.scan(ctx, nil, nil); err != nil {
+				return nil, err, true
+			}
+		} else {
+			e.logger.Debug("Performing partial scan with", len(recheckPaths), "recheck paths")
+			if err := e.scan(ctx, e.snapshot, recheckPaths); err != nil {
+				return nil, err, !errors.Is(err, core.ErrScanCancelled)
+			}
+		}
 	} else {
 		e.logger.Debug("Performing full scan")
 		if err := e.scan(ctx, nil, nil); err != nil {
@@ -1088,6 +1824,18 @@ func (e *endpoint) Scan(ctx context.Context, _ *core.Entry, full bool) (*core.Sn
 		}
 	}
 
+	// Any paths modified by transitions are now reflected in the snapshot.
+	if len(e.partialRecheckPaths) > 0 {
+		e.partialRecheckPaths = make(map[string]bool)
+	}
+
+	// If the root is required to exist, then verify that the scan found it. We
+	// don't recommend a retry in this case, since the absence is unlikely to be
+	// caused by concurrent modifications.
+	if e.requireRoot && e.snapshot.Content == nil {
+		return nil, fmt.Errorf("synchronization root (%s) does not exist", e.root), false
+	}
+
 	// Verify that we haven't exceeded the maximum entry count.
 	// TODO: Do we actually want to enforce this count in the scan operation so
 	// that we don't hold those entries in memory? Right now this is mostly
@@ -1106,63 +1854,351 @@ func (e *endpoint) Scan(ctx context.Context, _ *core.Entry, full bool) (*core.Sn
 	// Store the values corresponding to the snapshot that we'll return.
 	e.lastReturnedScanCache = e.cache
 	e.lastReturnedScanSnapshotDecomposesUnicode = e.snapshot.DecomposesUnicode
+	e.lastReturnedOverlayLower = e.overlayLower
+	e.lastReturnedOverlayUpper = e.overlayUpper
+
+	// Record the snapshot for use by deferred scans. This isn't supported in
+	// overlay mode, since Transition requires the layer contents corresponding
+	// to the snapshot that resulted in its operations.
+	if e.overlayBase == "" {
+		e.deferrableSnapshot = e.snapshot
+	}
+
+	// Share the scan results, if possible.
+	e.publishSharedScan()
 
 	// Success.
 	return e.snapshot, nil, false
 }
 
+// publishSharedScan publishes the current scan results for reuse by other
+// endpoints if scan sharing is enabled and accelerated scanning is available.
+// Results are only published while accelerated scanning is available because
+// that's what allows their invalidation to be detected. This method must be
+// called with the scan lock held.
+func (e *endpoint) publishSharedScan() {
+	if e.sharedScanKey == "" || !e.accelerate {
+		return
+	}
+	sharedScans.publish(e.sharedScanKey, &sharedScan{
+		publisher:   e,
+		root:        e.root,
+		generation:  e.snapshotGeneration,
+		snapshot:    e.snapshot,
+		cache:       e.cache,
+		ignoreCache: e.ignoreCache,
+	})
+}
+
+// adoptSharedScan attempts to adopt scan results published by another endpoint
+// in lieu of performing a full scan. It returns true if current results were
+// adopted. If the published results are no longer current, then they aren't
+// adopted, but their cache will still be used to avoid re-hashing unchanged
+// files if this endpoint doesn't already have a populated cache. This method
+// must be called with the scan lock held.
+func (e *endpoint) adoptSharedScan() bool {
+	// Look for shared scan results from another endpoint.
+	if e.sharedScanKey == "" {
+		return false
+	}
+	shared := sharedScans.lookup(e.sharedScanKey)
+	if shared == nil || shared.publisher == e {
+		return false
+	}
+
+	// If the results aren't current, then just seed the cache if necessary.
+	// The cache was generated in this process, so strict trust is appropriate.
+	if !shared.current() {
+		if len(e.cache.GetEntries()) == 0 {
+			e.cache = shared.cache
+			e.cacheTrustMode = core.CacheTrustMode_CacheTrustModeStrict
+		}
+		return false
+	}
+
+	// Adopt the results and invalidate the scan generation.
+	e.snapshot = shared.snapshot
+	e.snapshotGeneration = e.scanGeneration.Add(1)
+	e.cache = shared.cache
+	e.ignoreCache = shared.ignoreCache
+	e.cacheTrustMode = core.CacheTrustMode_CacheTrustModeStrict
+	e.lastScanEntryCount = shared.snapshot.Content.Count()
+
+	// Trigger an asynchronous cache save operation.
+	select {
+	case e.saveCacheSignal <- struct{}{}:
+	default:
+	}
+
+	// Success.
+	return true
+}
+
+// addRecheckPath registers a path as a re-check path for the next accelerated
+// scan. Once the re-check path set has exceeded the maximum re-check path
+// count, no further paths are recorded, since the next scan will be a full scan
+// anyway. This method must be called with the scan lock held and only when
+// accelerating scans in recursive watching mode.
+func (e *endpoint) addRecheckPath(path string) {
+	if !e.recheckPathsExceeded(e.recheckPaths) {
+		e.recheckPaths[path] = true
+	}
+}
+
+// recheckPathsExceeded returns whether or not the specified re-check path set
+// exceeds the maximum re-check path count.
+func (e *endpoint) recheckPathsExceeded(recheckPaths map[string]bool) bool {
+	return e.maximumRecheckPaths != 0 && uint64(len(recheckPaths)) > e.maximumRecheckPaths
+}
+
+// addScopeRecheckPaths adds re-check paths to the specified set that will force
+// a baseline-based scan to fully re-check the subtrees rooted at the specified
+// scope paths. It does this by adding each scope path and the path of every
+// directory underneath it in the baseline content. Content that doesn't exist
+// in the baseline doesn't need to be enumerated, since it will be scanned
+// fully once its parent is re-checked.
+func addScopeRecheckPaths(recheckPaths map[string]bool, baseline *core.Entry, scope []string) {
+	for _, path := range scope {
+		// Register the scope path itself.
+		recheckPaths[path] = true
+
+		// Locate the baseline entry corresponding to the scope path.
+		entry := baseline
+		if path != "" {
+			for _, component := range strings.Split(path, "/") {
+				if entry == nil || entry.Kind != core.EntryKind_Directory {
+					entry = nil
+					break
+				}
+				entry = entry.Contents[component]
+			}
+		}
+
+		// Register any directories contained within the entry.
+		addDirectoryRecheckPaths(recheckPaths, path, entry)
+	}
+}
+
+// addDirectoryRecheckPaths is the recursive implementation underlying
+// addScopeRecheckPaths. It registers the specified path if the entry is a
+// directory and then recurses into its contents.
+func addDirectoryRecheckPaths(recheckPaths map[string]bool, path string, entry *core.Entry) {
+	if entry == nil || entry.Kind != core.EntryKind_Directory {
+		return
+	}
+	recheckPaths[path] = true
+	for name, child := range entry.Contents {
+		addDirectoryRecheckPaths(recheckPaths, fastpath.Joinable(path)+name, child)
+	}
+}
+
 // stageFromRoot attempts to perform staging from local files by using a reverse
-// lookup map.
+// lookup map. If the source file is modified during copying, then the copy will
+// be retried up to the configured rename detection retry count. The reverse
+// lookup map may be nil, in which case no staging is performed.
 func (e *endpoint) stageFromRoot(
 	path string,
 	digest []byte,
 	reverseLookupMap *core.ReverseLookupMap,
 	opener *filesystem.Opener,
 ) bool {
+	// If rename detection is disabled, then there's nothing we can do.
+	if reverseLookupMap == nil {
+		return false
+	}
+
 	// See if we can find a path within the root that has a matching digest.
 	sourcePath, sourcePathOk := reverseLookupMap.Lookup(digest)
 	if !sourcePathOk {
 		return false
 	}
 
+	// If the source file exceeds the maximum rename detection file size, then
+	// don't bother opening it. Copying very large files from within the root
+	// can be more expensive than transferring them (e.g. on slow disks), and
+	// the read-back verification doubles the I/O involved. We use the size
+	// from the scan rather than the file's current size, since any change to
+	// the file would cause the copy to fail verification anyway.
+	if size, ok := reverseLookupMap.Size(sourcePath); !ok || size > e.maximumRenameDetectionFileSize {
+		return false
+	}
+
+	// Attempt to copy from the source file, retrying if it fails verification.
+	for attempt := uint32(0); attempt <= e.renameDetectionRetries; attempt++ {
+		copied, retry := e.stageFromRootOnce(path, digest, sourcePath, opener)
+		if copied {
+			return true
+		} else if !retry {
+			return false
+		}
+	}
+
+	// If we've exhausted our retries, then record that the source file kept
+	// changing so that the resulting transfer is explicable.
+	e.logger.Debugf("Source file \"%s\" modified while staging \"%s\" (%d retries exhausted)",
+		sourcePath, path, e.renameDetectionRetries,
+	)
+	return false
+}
+
+// stageFromRootOnce performs a single attempt at staging from a local file. It
+// returns whether or not staging succeeded and, if it didn't, whether or not
+// the failure occurred during copying and verification (e.g. due to the source
+// file being modified during the copy operation) and is thus worth retrying.
+func (e *endpoint) stageFromRootOnce(
+	path string,
+	digest []byte,
+	sourcePath string,
+	opener *filesystem.Opener,
+) (bool, bool) {
 	// Open the source file and defer its closure.
-	source, _, err := opener.OpenFile(sourcePath)
+	source, metadata, err := opener.OpenFile(sourcePath)
+	if err != nil {
+		return false, false
+	}
+	defer source.Close()
+
+	// Create a staging sink. We explicitly manage its closure below.
+	sink, err := e.stager.Sink(path, digest, metadata.Size)
+	if err != nil {
+		return false, false
+	}
+
+	// Copy data to the sink and close it, then check for errors. Closure will
+	// verify that everything staged correctly, ensuring that the source file
+	// wasn't modified during the copy operation.
+	_, err = io.Copy(sink, source)
+	if closeErr := sink.Close(); err == nil {
+		err = closeErr
+	}
+	return err == nil, err != nil
+}
+
+// stageFromContentCache attempts to perform staging using content from the
+// persistent content cache. The content cache verifies content as it's read, so
+// corrupted content will fail to stage (and will be evicted from the cache).
+func (e *endpoint) stageFromContentCache(path string, digest []byte) bool {
+	// If the content cache is disabled, then there's nothing we can do.
+	if e.contentCache == nil {
+		return false
+	}
+
+	// Open the cached content and defer its closure.
+	source, size, err := e.contentCache.Open(digest)
 	if err != nil {
 		return false
 	}
 	defer source.Close()
 
 	// Create a staging sink. We explicitly manage its closure below.
-	sink, err := e.stager.Sink(path)
+	sink, err := e.stager.Sink(path, digest, size)
 	if err != nil {
 		return false
 	}
 
-	// Copy data to the sink and close it, then check for copy errors.
+	// Copy data to the sink and close it, then check for errors.
 	_, err = io.Copy(sink, source)
-	sink.Close()
+	if closeErr := sink.Close(); err == nil {
+		err = closeErr
+	}
+	return err == nil
+}
+
+// stageEmpty stages empty file content for the specified path. Empty content
+// can always be produced locally, so this avoids a dependency on transmission
+// (and its failure modes) that might otherwise leave an empty file absent.
+func (e *endpoint) stageEmpty(path string) bool {
+	// Create a staging sink and close it without writing any content. Closure
+	// will verify that the content staged correctly.
+	sink, err := e.stager.Sink(path, e.emptyFileDigest, 0)
 	if err != nil {
 		return false
 	}
+	return sink.Close() == nil
+}
 
-	// Verify that everything staged correctly, ensuring that the source file
-	// wasn't modified during the copy operation.
-	success, _ := e.stager.Contains(path, digest)
-	return success
+// Verify implements the Verify method for local endpoints.
+func (e *endpoint) Verify(ctx context.Context) (*core.Snapshot, error) {
+	// Grab the scan lock and defer its release. If lock acquisition is
+	// preempted, then the controller has cancelled the request.
+	if !e.lockScanLock(ctx) {
+		return nil, fmt.Errorf("verification cancelled: %w", context.Canceled)
+	}
+	defer e.unlockScanLock()
+
+	// If we're operating in overlay mode, then scan the overlay base first. As
+	// in regular scans, we avoid probing the base.
+	var lower *core.Snapshot
+	var lowerCache *core.Cache
+	if e.overlayBase != "" {
+		var err error
+		lower, lowerCache, _, err = core.Scan(
+			ctx,
+			e.overlayBase,
+			nil, nil,
+			e.hasher, nil, core.CacheTrustMode_CacheTrustModeStrict,
+			e.ignorer, nil,
+			behavior.ProbeMode_ProbeModeAssume, nil,
+			e.symbolicLinkMode,
+			e.specialFileMode,
+			e.nameNormalizationMode,
+			e.permissionsMode,
+			false,
+			e.modificationTimes,
+			e.directoryListingRetries,
+			e.maximumFileSize,
+			e.directoryListingObserver(), e.slowDirectoryListingThreshold,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("unable to scan overlay base: %w", err)
+		}
+	}
+
+	// Perform a cold scan. We don't provide a cache, which forces all file
+	// content to be re-hashed, and we discard the resulting caches, since
+	// they're not the caches corresponding to the endpoint's snapshot.
+	snapshot, cache, _, err := core.Scan(
+		ctx,
+		e.root,
+		nil, nil,
+		e.hasher, nil, core.CacheTrustMode_CacheTrustModeStrict,
+		e.ignorer, nil,
+		e.probeMode, e.probeOptions,
+		e.symbolicLinkMode,
+		e.specialFileMode,
+		e.nameNormalizationMode,
+		e.permissionsMode,
+		false,
+		e.modificationTimes,
+		e.directoryListingRetries,
+		e.maximumFileSize,
+		e.directoryListingObserver(), e.slowDirectoryListingThreshold,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	// If we're operating in overlay mode, then compute the merged snapshot.
+	if lower != nil {
+		snapshot = core.MergeOverlaySnapshots(lower, snapshot, lowerCache, cache)
+	}
+
+	// Success.
+	return snapshot, nil
 }
 
 // Stage implements the Stage method for local endpoints.
-func (e *endpoint) Stage(paths []string, digests [][]byte) ([]string, []*rsync.Signature, rsync.Receiver, error) {
+func (e *endpoint) Stage(paths []string, digests [][]byte) ([]string, []*rsync.Signature, rsync.Receiver, bool, error) {
 	// If we're in a read-only mode, we shouldn't be staging files.
 	if e.readOnly {
-		return nil, nil, nil, errors.New("endpoint is in read-only mode")
+		return nil, nil, nil, false, errors.New("endpoint is in read-only mode")
 	}
 
 	// Validate argument lengths and bail if there's nothing to stage.
 	if len(paths) != len(digests) {
-		return nil, nil, nil, errors.New("path count does not match digest count")
+		return nil, nil, nil, false, errors.New("path count does not match digest count")
 	} else if len(paths) == 0 {
-		return nil, nil, nil, nil
+		return nil, nil, nil, false, nil
 	}
 
 	// Grab the scan lock. We'll need this to verify the last scan entry count
@@ -1171,27 +2207,39 @@ func (e *endpoint) Stage(paths []string, digests [][]byte) ([]string, []*rsync.S
 
 	// Verify that we've performed a scan since the last staging operation, that
 	// way our count check is valid. If we haven't, then the controller is
-	// either malfunctioning or malicious.
-	if !e.scannedSinceLastStageCall {
+	// either malfunctioning or malicious. The only exception is if the last
+	// staging operation deferred staging of some paths, in which case the
+	// count check remains valid because no scan has occurred.
+	if !e.scannedSinceLastStageCall && !e.stagingDeferred {
 		e.unlockScanLock()
-		return nil, nil, nil, errors.New("multiple staging operations performed without scan")
+		return nil, nil, nil, false, errors.New("multiple staging operations performed without scan")
 	}
+	firstStagingOperation := e.scannedSinceLastStageCall
 	e.scannedSinceLastStageCall = false
+	e.stagingDeferred = false
 
 	// Verify that the number of paths provided isn't going to put us over the
 	// maximum number of allowed entries.
 	if e.maximumEntryCount != 0 && (e.maximumEntryCount-e.lastScanEntryCount) < uint64(len(paths)) {
 		e.unlockScanLock()
-		return nil, nil, nil, errors.New("staging would exceeded allowed entry count")
+		return nil, nil, nil, false, errors.New("staging would exceeded allowed entry count")
 	}
 
 	// Generate a reverse lookup map from the cache, which we'll use shortly to
-	// detect renames and copies.
-	reverseLookupMap, err := e.cache.GenerateReverseLookupMap()
-	if err != nil {
-		e.unlockScanLock()
-		return nil, nil, nil, fmt.Errorf("unable to generate reverse lookup map: %w", err)
+	// detect renames and copies. If snapshot digests are truncated, then they
+	// can't be used to identify content at other paths, so we skip rename and
+	// copy detection. We also record whether or not the root contains any
+	// files while we have access to the cache.
+	var reverseLookupMap *core.ReverseLookupMap
+	if !e.digestsTruncated {
+		var err error
+		reverseLookupMap, err = e.cache.GenerateReverseLookupMap(e.renameDetectionDigestLength)
+		if err != nil {
+			e.unlockScanLock()
+			return nil, nil, nil, false, fmt.Errorf("unable to generate reverse lookup map: %w", err)
+		}
 	}
+	rootExistsAndHasFileContents := len(e.cache.GetEntries()) > 0
 
 	// Release the scan lock.
 	e.unlockScanLock()
@@ -1199,7 +2247,15 @@ func (e *endpoint) Stage(paths []string, digests [][]byte) ([]string, []*rsync.S
 	// Inform the stager that we're about to begin staging and transition
 	// operations.
 	if err := e.stager.Initialize(); err != nil {
-		return nil, nil, nil, fmt.Errorf("unable to initialize stager: %w", err)
+		return nil, nil, nil, false, fmt.Errorf("unable to initialize stager: %w", err)
+	}
+
+	// If this is the first staging operation since the last scan (i.e. the
+	// first staging operation of the synchronization cycle), then reap expired
+	// staged content. We don't reap during subsequent (deferred) staging
+	// operations, since those could reap content staged earlier in the cycle.
+	if firstStagingOperation {
+		e.stager.Reap()
 	}
 
 	// Create an opener that we can use file opening and defer its closure. We
@@ -1211,28 +2267,46 @@ func (e *endpoint) Stage(paths []string, digests [][]byte) ([]string, []*rsync.S
 	// Filter the path list by looking for files that we can source locally.
 	//
 	// First, check if the content can be provided from the stager, which
-	// indicates that a previous staging operation was interrupted.
+	// indicates that a previous staging operation was interrupted. If the
+	// interruption occurred while the content was being received, then the
+	// stager won't be able to provide it, but it may have retained the partial
+	// content, which we'll use below to resume reception.
+	//
+	// Second, check if the content is empty, in which case we can stage it
+	// directly. This guarantees that empty files on the other endpoint are
+	// always reproduced as empty files (rather than remaining absent) without
+	// any dependence on transmission.
 	//
-	// Second, use a reverse lookup map (generated from the cache) and see if we
+	// Third, use a reverse lookup map (generated from the cache) and see if we
 	// can find (and stage) any files locally, which indicates that a file has
 	// been copied or renamed.
 	//
+	// Fourth, check if the content is available in the persistent content
+	// cache, which indicates that it was received previously (potentially by
+	// another session or before a restart).
+	//
 	// If we manage to handle all files, then we can abort staging.
 	filteredPaths := paths[:0]
+	filteredDigests := digests[:0]
 	for p, path := range paths {
 		digest := digests[p]
 		if available, err := e.stager.Contains(path, digest); err != nil {
-			return nil, nil, nil, fmt.Errorf("unable to query file staging status: %w", err)
+			return nil, nil, nil, false, fmt.Errorf("unable to query file staging status: %w", err)
 		} else if available {
 			continue
+		} else if bytes.Equal(digest, e.emptyFileDigest) && e.stageEmpty(path) {
+			continue
 		} else if e.stageFromRoot(path, digest, reverseLookupMap, opener) {
 			continue
+		} else if e.stageFromContentCache(path, digest) {
+			continue
 		} else {
 			filteredPaths = append(filteredPaths, path)
+			filteredDigests = append(filteredDigests, digest)
 		}
 	}
 	if len(filteredPaths) == 0 {
-		return nil, nil, nil, nil
+		return nil, nil, nil, false, nil
 	}
 
 	// Create an rsync engine.
@@ -1244,45 +2318,180 @@ func (e *endpoint) Stage(paths []string, digests [][]byte) ([]string, []*rsync.S
 	//
 	// If the root doesn't exist or doesn't contain any files, then we can just
 	// use an empty signature straight away.
-	rootExistsAndHasFileContents := reverseLookupMap.Length() > 0
+	//
+	// We also bound the total (estimated) memory used by the signatures. If the
+	// signature for a path would exceed the remaining signature memory budget,
+	// then the path is deferred (i.e. queued) to a subsequent staging
+	// operation. We always allow at least one non-empty signature per staging
+	// operation to guarantee progress, even if it exceeds the budget alone.
+	//
+	// Signatures computed by previous staging operations are re-used if the
+	// base file's size and modification time haven't changed.
+	//
+	// If the stager has retained partial content for a path from an interrupted
+	// reception, then the signature is computed from that content (which the
+	// receiver will then use as the base) so that only the remainder of the
+	// file needs to be transmitted.
 	emptySignature := &rsync.Signature{}
-	signatures := make([]*rsync.Signature, len(filteredPaths))
+	requiredPaths := filteredPaths[:0]
+	requiredDigests := filteredDigests[:0]
+	var signatures []*rsync.Signature
+	var signatureMemory uint64
+	var deferred bool
+	signatureCache := make(map[string]*signatureCacheEntry)
+	var reusedSignatures, resumedPaths int
 	for p, path := range filteredPaths {
+		digest := filteredDigests[p]
+		if partial, err := e.stager.Resume(path, digest); err == nil && partial != nil {
+			size, err := partial.Seek(0, io.SeekEnd)
+			if err == nil {
+				_, err = partial.Seek(0, io.SeekStart)
+			}
+			if err == nil && signatureMemory > 0 {
+				estimate := rsync.EstimateSignatureMemoryUsage(uint64(size), e.rsyncBlockSize)
+				if signatureMemory >= e.maximumSignatureMemory || estimate > e.maximumSignatureMemory-signatureMemory {
+					partial.Close()
+					deferred = true
+					continue
+				}
+			}
+			var signature *rsync.Signature
+			if err == nil {
+				signature, err = engine.Signature(partial, e.rsyncBlockSize)
+			}
+			partial.Close()
+			if err == nil {
+				requiredPaths = append(requiredPaths, path)
+				requiredDigests = append(requiredDigests, digest)
+				signatures = append(signatures, signature)
+				signatureMemory += signature.MemoryUsage()
+				resumedPaths++
+				continue
+			}
+		}
 		if !rootExistsAndHasFileContents {
-			signatures[p] = emptySignature
-		} else if base, _, err := opener.OpenFile(path); err != nil {
-			signatures[p] = emptySignature
-		} else if signature, err := engine.Signature(base, 0); err != nil {
+			requiredPaths = append(requiredPaths, path)
+			requiredDigests = append(requiredDigests, digest)
+			signatures = append(signatures, emptySignature)
+			continue
+		}
+		base, metadata, err := opener.OpenFile(path)
+		if err != nil {
+			requiredPaths = append(requiredPaths, path)
+			requiredDigests = append(requiredDigests, digest)
+			signatures = append(signatures, emptySignature)
+			continue
+		}
+		if signatureMemory > 0 {
+			estimate := rsync.EstimateSignatureMemoryUsage(metadata.Size, e.rsyncBlockSize)
+			if signatureMemory >= e.maximumSignatureMemory || estimate > e.maximumSignatureMemory-signatureMemory {
+				base.Close()
+				deferred = true
+				continue
+			}
+		}
+		requiredPaths = append(requiredPaths, path)
+		requiredDigests = append(requiredDigests, digest)
+		var previous *rsync.Signature
+		var previousModificationTime time.Time
+		if entry, ok := e.signatureCache[path]; ok {
+			previous, previousModificationTime = entry.signature, entry.modificationTime
+		}
+		if signature, reused, err := engine.SignatureCached(
+			base, e.rsyncBlockSize,
+			metadata.ModificationTime,
+			previous, previousModificationTime,
+		); err != nil {
 			base.Close()
-			signatures[p] = emptySignature
+			signatures = append(signatures, emptySignature)
 		} else {
 			base.Close()
-			signatures[p] = signature
+			signatures = append(signatures, signature)
+			signatureMemory += signature.MemoryUsage()
+			signatureCache[path] = &signatureCacheEntry{signature, metadata.ModificationTime}
+			if reused {
+				reusedSignatures++
+			}
+		}
+	}
+	if reusedSignatures > 0 {
+		e.logger.Debugf("Re-used %d cached signature(s)", reusedSignatures)
+	}
+	if resumedPaths > 0 {
+		e.logger.Debugf("Resuming reception of %d partially staged file(s)", resumedPaths)
+	}
+
+	// Retain cached signatures from previous staging operations for paths that
+	// weren't examined by this staging operation (e.g. those that were
+	// deferred), so long as they fit within the signature memory budget. Even
+	// if a stale signature is re-used, the receiver's digest verification will
+	// prevent incorrect content from being staged.
+	cacheMemory := signatureMemory
+	for path, entry := range e.signatureCache {
+		if _, ok := signatureCache[path]; ok {
+			continue
+		} else if usage := entry.signature.MemoryUsage(); cacheMemory < e.maximumSignatureMemory && usage <= e.maximumSignatureMemory-cacheMemory {
+			signatureCache[path] = entry
+			cacheMemory += usage
 		}
 	}
+	e.signatureCache = signatureCache
+	if deferred {
+		e.logger.Debugf("Deferring staging of %d file(s) due to signature memory limit",
+			len(filteredPaths)-len(requiredPaths),
+		)
+	}
+
+	// Determine the sinker for received files. If the content cache is enabled,
+	// then received files are inserted into it once they've been staged.
+	var sinker rsync.Sinker = e.stager
+	if e.contentCache != nil {
+		sinker = &contentCachingSinker{e.stager, e.contentCache}
+	}
 
-	// Create a receiver.
-	receiver, err := rsync.NewReceiver(e.root, filteredPaths, signatures, e.stager)
+	// Create a receiver. It will verify each received file against its expected
+	// digest before the file becomes available for transitions.
+	receiver, err := rsync.NewReceiver(e.root, requiredPaths, requiredDigests, signatures, sinker)
 	if err != nil {
-		return nil, nil, nil, fmt.Errorf("unable to create rsync receiver: %w", err)
+		return nil, nil, nil, false, fmt.Errorf("unable to create rsync receiver: %w", err)
 	}
 
+	// Record whether or not paths were deferred.
+	e.stagingDeferred = deferred
+
 	// Done.
-	return filteredPaths, signatures, receiver, nil
+	return requiredPaths, signatures, receiver, deferred, nil
 }
 
 // Supply implements the supply method for local endpoints.
 func (e *endpoint) Supply(paths []string, signatures []*rsync.Signature, receiver rsync.Receiver) error {
-	return rsync.Transmit(e.root, paths, signatures, receiver)
+	// In overlay mode, files that aren't present in the synchronization root
+	// are sourced from the overlay base. Files hidden by whiteout markers won't
+	// be requested, since they aren't present in the merged view.
+	roots := []string{e.root}
+	if e.overlayBase != "" {
+		roots = append(roots, e.overlayBase)
+	}
+	return rsync.TransmitWithInFlightLimit(
+		roots, paths, signatures, receiver,
+		e.deltificationTimeLimit, e.logger,
+		e.stagingCompressionAlgorithm, e.maximumInFlightBytes,
+	)
 }
 
 // Transition implements the Transition method for local endpoints.
-func (e *endpoint) Transition(ctx context.Context, transitions []*core.Change) ([]*core.Entry, []*core.Problem, bool, error) {
+func (e *endpoint) Transition(ctx context.Context, transitions []*core.Change, dryRun bool) ([]*core.Entry, []*core.Problem, bool, error) {
 	// If we're in a read-only mode, we shouldn't be performing transitions.
 	if e.readOnly {
 		return nil, nil, false, errors.New("endpoint is in read-only mode")
 	}
 
+	// Reset the oversized file count. It will be updated if the transition is
+	// performed (dry runs don't involve staging and thus leave it untouched).
+	if !dryRun {
+		e.oversizedFiles = 0
+	}
+
 	// Grab the scan lock and defer its release.
 	e.lockScanLock(context.Background())
 	defer e.unlockScanLock()
@@ -1332,21 +2541,60 @@ func (e *endpoint) Transition(ctx context.Context, transitions []*core.Change) (
 	// read lastReturnedScanCache and lastReturnedScanSnapshotDecomposesUnicode
 	// because these aren't updated concurrently and thus don't fall under the
 	// scope of the scan lock.
+	//
+	// In overlay mode, the transitions (which are expressed against the merged
+	// view) are first converted into transitions against the synchronization
+	// root (the upper layer), and the results are converted back afterward.
 	e.unlockScanLock()
+	rootTransitions := transitions
+	var overlayProblems []*core.Problem
+	if e.overlayBase != "" {
+		rootTransitions, overlayProblems = core.OverlayTransitions(
+			e.lastReturnedOverlayLower, e.lastReturnedOverlayUpper, transitions,
+		)
+	}
 	results, problems, stagerMissingFiles := core.Transition(
 		ctx,
 		e.root,
-		transitions,
+		rootTransitions,
 		e.lastReturnedScanCache,
 		e.symbolicLinkMode,
+		e.symbolicLinkReplacementMode,
+		e.symbolicLinkCycleMode,
 		e.defaultFileMode,
 		e.defaultDirectoryMode,
 		e.defaultOwnership,
 		e.lastReturnedScanSnapshotDecomposesUnicode,
+		e.transitionMode,
 		e.stager,
+		dryRun,
 	)
+	if e.overlayBase != "" {
+		results = core.OverlayResults(
+			e.lastReturnedOverlayLower, e.lastReturnedOverlayUpper,
+			transitions, rootTransitions, results,
+		)
+		problems = append(overlayProblems, problems...)
+	}
 	e.lockScanLock(context.Background())
 
+	// If this was a dry run, then nothing was modified on disk or staged, so
+	// there's no endpoint state that needs to be updated.
+	if dryRun {
+		return results, problems, false, nil
+	}
+
+	// Record cache entries for any placeholders created by the transition.
+	e.recordPlaceholders(e.stager.Placeholders())
+
+	// Invalidate the scan generation and any shared scan results for the
+	// synchronization root. The latter is performed regardless of whether or
+	// not scan sharing is enabled on this endpoint, since other endpoints
+	// sharing scan results for the root may not observe the modifications
+	// before their shared results are reused.
+	e.scanGeneration.Add(1)
+	sharedScans.invalidate(e.root)
+
 	// Determine whether or not the transition made any changes on disk.
 	var transitionMadeChanges bool
 	for r, result := range results {
@@ -1356,6 +2604,13 @@ func (e *endpoint) Transition(ctx context.Context, transitions []*core.Change) (
 		}
 	}
 
+	// Update the deferrable snapshot to reflect the transition results. If the
+	// results can't be applied, then deferred scans are disabled until the
+	// next regular scan.
+	if e.deferrableSnapshot != nil && transitionMadeChanges {
+		e.deferrableSnapshot = deferrableSnapshotAfterTransition(e.deferrableSnapshot, transitions, results)
+	}
+
 	// If we're using recursive watching and we made any changes to disk, then
 	// send a signal to trigger watch establishment (if needed), because if no
 	// watch is currently established due to the synchronization root not having
@@ -1395,7 +2650,19 @@ func (e *endpoint) Transition(ctx context.Context, transitions []*core.Change) (
 			e.accelerate = false
 		} else if e.watchMode == reifiedWatchModeRecursive {
 			for _, transition := range transitions {
-				e.recheckPaths[transition.Path] = true
+				e.addRecheckPath(transition.Path)
+			}
+		}
+	}
+
+	// Similarly, if partial scanning is enabled, then ensure that the next
+	// partial scan re-checks any modified paths, since they may reside outside
+	// of the scan subpaths. For the same reasons as in the recursive watching
+	// case, only transition roots need to be included.
+	if len(e.scanSubpaths) > 0 && transitionMadeChanges {
+		for _, transition := range transitions {
+			if !e.recheckPathsExceeded(e.partialRecheckPaths) {
+				e.partialRecheckPaths[transition.Path] = true
 			}
 		}
 	}
@@ -1432,12 +2699,131 @@ func (e *endpoint) Transition(ctx context.Context, transitions []*core.Change) (
 	// staging directory? It could be due to an easily correctable error, at
 	// which point you wouldn't want to restage if you're talking about lots of
 	// files.
+	e.oversizedFiles = e.stager.Oversized()
 	e.stager.Finalize()
 
+	// Enforce the content cache size limit if content was inserted.
+	if e.contentCache != nil {
+		if err := e.contentCache.Evict(); err != nil {
+			e.logger.Warnf("Unable to perform content cache eviction: %v", err)
+		}
+	}
+
 	// Done.
 	return results, problems, stagerMissingFiles, nil
 }
 
+// deferrableSnapshotAfterTransition computes the deferrable snapshot resulting
+// from the application of transition results to a deferrable snapshot. It
+// returns nil if the results can't be applied. Content statistics are carried
+// over from the original snapshot and are thus approximate.
+func deferrableSnapshotAfterTransition(snapshot *core.Snapshot, transitions []*core.Change, results []*core.Entry) *core.Snapshot {
+	// Convert the results to changes and apply them.
+	changes := make([]*core.Change, len(transitions))
+	for t, transition := range transitions {
+		changes[t] = &core.Change{Path: transition.Path, New: results[t]}
+	}
+	content, err := core.Apply(snapshot.Content, changes)
+	if err != nil {
+		return nil
+	}
+
+	// Create the updated snapshot.
+	return &core.Snapshot{
+		Content:                content,
+		PreservesExecutability: snapshot.PreservesExecutability,
+		DecomposesUnicode:      snapshot.DecomposesUnicode,
+		Directories:            snapshot.Directories,
+		Files:                  snapshot.Files,
+		SymbolicLinks:          snapshot.SymbolicLinks,
+		TotalFileSize:          snapshot.TotalFileSize,
+	}
+}
+
+// recordPlaceholders records cache entries for placeholder files created in
+// lieu of oversized content. Each entry associates a placeholder's on-disk
+// metadata with the digest of the content that it represents, so scans will
+// report the placeholder as that content (rather than as empty content) for as
+// long as the placeholder remains unmodified. This is what distinguishes
+// placeholders from genuinely empty files and prevents them from being seen as
+// changes. Placeholders that can't be found (e.g. because the transition failed
+// to relocate them) are ignored. This method must be called with the scan lock
+// held.
+func (e *endpoint) recordPlaceholders(placeholders map[string][]byte) {
+	// If there are no placeholders, then there's nothing to record.
+	if len(placeholders) == 0 {
+		return
+	}
+
+	// Create a new cache containing the existing entries, since caches are
+	// treated as immutable.
+	cache := &core.Cache{Entries: make(map[string]*core.CacheEntry, len(e.cache.GetEntries())+len(placeholders))}
+	for path, entry := range e.cache.GetEntries() {
+		cache.Entries[path] = entry
+	}
+
+	// Record placeholder entries.
+	for path, digest := range placeholders {
+		placeholder, metadata, err := filesystem.Open(filepath.Join(e.root, path), false)
+		if err != nil {
+			continue
+		}
+		placeholder.Close()
+		if metadata.Mode&filesystem.ModeTypeMask != filesystem.ModeTypeFile || metadata.Size != 0 {
+			continue
+		}
+		modificationTime := timestamppb.New(metadata.ModificationTime)
+		if modificationTime.CheckValid() != nil {
+			continue
+		}
+		cache.Entries[path] = &core.CacheEntry{
+			Mode:             uint32(metadata.Mode),
+			ModificationTime: modificationTime,
+			Size:             metadata.Size,
+			FileID:           metadata.FileID,
+			Digest:           digest,
+		}
+	}
+
+	// Update the cache and trigger an asynchronous cache save operation, since
+	// losing placeholder entries would cause placeholders to be seen as
+	// modifications.
+	e.cache = cache
+	select {
+	case e.saveCacheSignal <- struct{}{}:
+	default:
+	}
+}
+
+// ScanGeneration returns the endpoint's current scan generation. If the scan
+// generation is the same before and after a Scan call, then that call returned
+// the snapshot that was current when the generation last changed, and no
+// watcher events or transitions have been observed since.
+func (e *endpoint) ScanGeneration() uint64 {
+	return e.scanGeneration.Load()
+}
+
+// ClockOffset implements the ClockOffset method for local endpoints.
+func (e *endpoint) ClockOffset() time.Duration {
+	return 0
+}
+
+// WatchOverflows implements the WatchOverflows method for local endpoints.
+func (e *endpoint) WatchOverflows() uint64 {
+	return e.watchOverflows.Load()
+}
+
+// AccelerationUnavailable implements the AccelerationUnavailable method for
+// local endpoints.
+func (e *endpoint) AccelerationUnavailable() string {
+	return e.accelerationStatus.report(time.Now(), accelerationUnavailableReportingDelay)
+}
+
+// OversizedFiles implements the OversizedFiles method for local endpoints.
+func (e *endpoint) OversizedFiles() uint64 {
+	return e.oversizedFiles
+}
+
 // Shutdown implements the Shutdown method for local endpoints.
 func (e *endpoint) Shutdown() error {
 	// Signal background worker Goroutines to terminate.
@@ -1447,6 +2833,9 @@ func (e *endpoint) Shutdown() error {
 	<-e.saveCacheDone
 	<-e.watchDone
 
+	// Withdraw any shared scan results.
+	sharedScans.withdraw(e)
+
 	// Terminate the polling coalescer.
 	e.pollSignal.Terminate()
 
@@ -1,3 +1,2794 @@
 package local
 
-// TODO: Implement.
+import (
+	"bytes"
+	"context"
+	"crypto/sha1"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"io/fs"
+	"math/rand"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	"github.com/mutagen-io/mutagen/pkg/filesystem/watching"
+	"github.com/mutagen-io/mutagen/pkg/logging"
+	"github.com/mutagen-io/mutagen/pkg/synchronization"
+	"github.com/mutagen-io/mutagen/pkg/synchronization/core"
+	"github.com/mutagen-io/mutagen/pkg/synchronization/rsync"
+)
+
+// TODO: Implement tests for additional functionality.
+
+// TestCacheSaveThresholdReducesWrites tests that a cache save threshold reduces
+// the volume of cache writes under frequent small changes without losing those
+// changes.
+func TestCacheSaveThresholdReducesWrites(t *testing.T) {
+	// Create a baseline cache with a reasonable number of entries.
+	const entryCount = 1000
+	baseline := &core.Cache{Entries: make(map[string]*core.CacheEntry, entryCount)}
+	for i := 0; i < entryCount; i++ {
+		baseline.Entries[fmt.Sprintf("file%d", i)] = &core.CacheEntry{
+			ModificationTime: timestamppb.New(time.Unix(1, 0)),
+			Size:             uint64(i),
+		}
+	}
+
+	// Generate a sequence of caches, each of which modifies a single entry
+	// relative to its predecessor, mimicking a busy session.
+	const changeCount = 100
+	caches := make([]*core.Cache, changeCount)
+	previous := baseline
+	for i := range caches {
+		cache := &core.Cache{Entries: make(map[string]*core.CacheEntry, entryCount)}
+		for path, entry := range previous.Entries {
+			cache.Entries[path] = entry
+		}
+		cache.Entries[fmt.Sprintf("file%d", i)] = &core.CacheEntry{
+			ModificationTime: timestamppb.New(time.Unix(int64(i+2), 0)),
+			Size:             uint64(i),
+		}
+		caches[i] = cache
+		previous = cache
+	}
+
+	// Compute the number of writes and the total write volume that the cache
+	// sequence generates for a particular threshold.
+	simulate := func(threshold uint64) (int, int) {
+		lastSaved := baseline
+		var writes, volume int
+		for _, cache := range caches {
+			if cacheSaveRequired(lastSaved, cache, threshold) {
+				writes++
+				volume += proto.Size(cache)
+				lastSaved = cache
+			}
+		}
+		return writes, volume
+	}
+
+	// Verify that the default threshold writes after every change.
+	defaultWrites, defaultVolume := simulate(synchronization.Version_Version1.DefaultCacheSaveThreshold())
+	if defaultWrites != changeCount {
+		t.Errorf("write count with default threshold (%d) does not match expected (%d)", defaultWrites, changeCount)
+	}
+
+	// Verify that a higher threshold batches writes and reduces write volume.
+	batchedWrites, batchedVolume := simulate(10)
+	if batchedWrites != changeCount/10 {
+		t.Errorf("write count with batching threshold (%d) does not match expected (%d)", batchedWrites, changeCount/10)
+	} else if batchedVolume >= defaultVolume {
+		t.Errorf("write volume with batching threshold (%d) not less than default (%d)", batchedVolume, defaultVolume)
+	}
+
+	// Verify that an unchanged cache is never written and that a missing last
+	// saved cache always triggers a write.
+	if cacheSaveRequired(baseline, baseline, 1) {
+		t.Error("save required for unchanged cache")
+	} else if !cacheSaveRequired(nil, baseline, 10) {
+		t.Error("save not required without previously saved cache")
+	}
+}
+
+// TestAddScopeRecheckPaths tests addScopeRecheckPaths.
+func TestAddScopeRecheckPaths(t *testing.T) {
+	// Create a baseline.
+	baseline := &core.Entry{
+		Kind: core.EntryKind_Directory,
+		Contents: map[string]*core.Entry{
+			"file": {Kind: core.EntryKind_File},
+			"a": {
+				Kind: core.EntryKind_Directory,
+				Contents: map[string]*core.Entry{
+					"file": {Kind: core.EntryKind_File},
+					"b": {
+						Kind: core.EntryKind_Directory,
+						Contents: map[string]*core.Entry{
+							"c": {Kind: core.EntryKind_Directory},
+						},
+					},
+				},
+			},
+			"d": {Kind: core.EntryKind_Directory},
+		},
+	}
+
+	// Set up test cases.
+	testCases := []struct {
+		scope    []string
+		expected []string
+	}{
+		{nil, nil},
+		{[]string{"a"}, []string{"a", "a/b", "a/b/c"}},
+		{[]string{"a/b"}, []string{"a/b", "a/b/c"}},
+		{[]string{"a/file"}, []string{"a/file"}},
+		{[]string{"file/x"}, []string{"file/x"}},
+		{[]string{"missing"}, []string{"missing"}},
+		{[]string{"a/b", "d"}, []string{"a/b", "a/b/c", "d"}},
+	}
+
+	// Process test cases.
+	for i, testCase := range testCases {
+		recheckPaths := make(map[string]bool)
+		addScopeRecheckPaths(recheckPaths, baseline, testCase.scope)
+		if len(recheckPaths) != len(testCase.expected) {
+			t.Errorf("test index %d: recheck path count mismatch: %d != %d",
+				i, len(recheckPaths), len(testCase.expected),
+			)
+		}
+		for _, path := range testCase.expected {
+			if !recheckPaths[path] {
+				t.Errorf("test index %d: expected recheck path (%s) missing", i, path)
+			}
+		}
+	}
+}
+
+// pollWithTimeout polls the specified endpoint with the specified timeout and
+// returns whether or not a poll event was received.
+func pollWithTimeout(t *testing.T, endpoint synchronization.Endpoint, timeout time.Duration) bool {
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+	if err := endpoint.Poll(ctx); err != nil {
+		t.Fatal("unable to poll endpoint:", err)
+	}
+	return ctx.Err() == nil
+}
+
+// TestStagingRootEventsFiltered tests that filesystem events within a staging
+// root located inside the synchronization root don't trigger poll events.
+func TestStagingRootEventsFiltered(t *testing.T) {
+	// Create a synchronization root and place the Mutagen data directory (and
+	// thus the staging root) inside of it.
+	root := t.TempDir()
+	t.Setenv("MUTAGEN_DATA_DIRECTORY", filepath.Join(root, "data"))
+
+	// Create the endpoint and defer its shutdown. We use a long polling
+	// interval so that only event-driven rescans can generate poll events.
+	configuration := &synchronization.Configuration{
+		WatchPollingInterval: 3600,
+	}
+	e, err := NewEndpoint(
+		logging.NewLogger(logging.LevelDisabled, io.Discard),
+		root,
+		"session",
+		synchronization.Version_Version1,
+		configuration,
+		true,
+	)
+	if err != nil {
+		t.Fatal("unable to create endpoint:", err)
+	}
+	defer e.Shutdown()
+
+	// Ensure that the staging root resides within the synchronization root.
+	stagingRoot := e.(*endpoint).stagingRoot
+	if e.(*endpoint).stagingRootRelative == "" {
+		t.Fatal("staging root not identified as residing within synchronization root")
+	}
+
+	// Wait for any poll events generated by watch establishment to drain.
+	for pollWithTimeout(t, e, time.Second) {
+	}
+
+	// Create the staging root and, in order to ensure that it gets picked up
+	// by watching, generate an event outside the staging root that triggers a
+	// rescan. This also acts as a control: if no poll event is received, then
+	// our environment doesn't support native event watching and the test is
+	// inconclusive.
+	if err := os.MkdirAll(stagingRoot, 0700); err != nil {
+		t.Fatal("unable to create staging root:", err)
+	}
+	if err := os.WriteFile(filepath.Join(root, "file"), []byte("content"), 0600); err != nil {
+		t.Fatal("unable to write file:", err)
+	}
+	if !pollWithTimeout(t, e, 10*time.Second) {
+		t.Skip("native filesystem watching appears to be unavailable")
+	}
+	for pollWithTimeout(t, e, time.Second) {
+	}
+
+	// Generate events inside the staging root and ensure that they don't
+	// trigger a poll event.
+	for _, name := range []string{"first", "second"} {
+		if err := os.WriteFile(filepath.Join(stagingRoot, name), []byte(name), 0600); err != nil {
+			t.Fatal("unable to write staged file:", err)
+		}
+	}
+	if pollWithTimeout(t, e, 2*time.Second) {
+		t.Error("staging root events triggered poll event")
+	}
+}
+
+// TestInitialPollModificationSuppression tests that poll-based watching only
+// suppresses modification notifications for its initial scan if suppression is
+// enabled.
+func TestInitialPollModificationSuppression(t *testing.T) {
+	// Set up test cases.
+	testCases := []struct {
+		mode         synchronization.InitialPollModificationMode
+		expectStrobe bool
+	}{
+		{synchronization.InitialPollModificationMode_InitialPollModificationModeDefault, false},
+		{synchronization.InitialPollModificationMode_InitialPollModificationModeSuppress, false},
+		{synchronization.InitialPollModificationMode_InitialPollModificationModeReport, true},
+	}
+
+	// Process test cases.
+	for i, testCase := range testCases {
+		// Create a synchronization root with existing content. We keep the
+		// Mutagen data directory outside the root.
+		root := t.TempDir()
+		t.Setenv("MUTAGEN_DATA_DIRECTORY", t.TempDir())
+		if err := os.WriteFile(filepath.Join(root, "file"), []byte("content"), 0600); err != nil {
+			t.Fatalf("test index %d: unable to write file: %v", i, err)
+		}
+
+		// Create the endpoint using forced polling with a long polling
+		// interval so that only the initial scan can generate poll events.
+		e, err := NewEndpoint(
+			logging.NewLogger(logging.LevelDisabled, io.Discard),
+			root,
+			"session",
+			synchronization.Version_Version1,
+			&synchronization.Configuration{
+				WatchMode:                   synchronization.WatchMode_WatchModeForcePoll,
+				WatchPollingInterval:        3600,
+				InitialPollModificationMode: testCase.mode,
+			},
+			true,
+		)
+		if err != nil {
+			t.Fatalf("test index %d: unable to create endpoint: %v", i, err)
+		}
+
+		// Check whether or not the initial scan triggers a poll event.
+		if strobed := pollWithTimeout(t, e, 2*time.Second); strobed != testCase.expectStrobe {
+			t.Errorf("test index %d: poll event status does not match expected: %t != %t",
+				i, strobed, testCase.expectStrobe,
+			)
+		}
+
+		// Shut down the endpoint.
+		if err := e.Shutdown(); err != nil {
+			t.Errorf("test index %d: unable to shut down endpoint: %v", i, err)
+		}
+	}
+}
+
+// TestStageSignatureMemoryLimit tests that staging bounds the total memory used
+// by rsync signatures, deferring files that would exceed the limit to
+// subsequent staging operations.
+func TestStageSignatureMemoryLimit(t *testing.T) {
+	// Set up parameters. We choose a limit that will only accommodate a few
+	// large file signatures at a time.
+	const (
+		fileCount              = 16
+		fileSize               = 1 << 20
+		maximumSignatureMemory = 64 * 1024
+	)
+	signatureMemory := rsync.EstimateSignatureMemoryUsage(fileSize, 0)
+	if signatureMemory*fileCount <= maximumSignatureMemory {
+		t.Fatal("signature memory limit too large for test")
+	} else if signatureMemory > maximumSignatureMemory {
+		t.Fatal("signature memory limit too small for test")
+	}
+
+	// Create a synchronization root containing many large files and a source
+	// directory containing new versions of those files. We use deterministic
+	// random content so that signatures are non-trivial.
+	root := t.TempDir()
+	source := t.TempDir()
+	t.Setenv("MUTAGEN_DATA_DIRECTORY", t.TempDir())
+	random := rand.New(rand.NewSource(0))
+	var paths []string
+	var digests [][]byte
+	for f := 0; f < fileCount; f++ {
+		path := fmt.Sprintf("file%02d", f)
+		old := make([]byte, fileSize)
+		random.Read(old)
+		if err := os.WriteFile(filepath.Join(root, path), old, 0600); err != nil {
+			t.Fatal("unable to create file:", err)
+		}
+		new := append([]byte("modified"), old...)
+		if err := os.WriteFile(filepath.Join(source, path), new, 0600); err != nil {
+			t.Fatal("unable to create source file:", err)
+		}
+		digest := sha1.Sum(new)
+		paths = append(paths, path)
+		digests = append(digests, digest[:])
+	}
+
+	// Create the endpoint and defer its shutdown.
+	e, err := NewEndpoint(
+		logging.NewLogger(logging.LevelDisabled, io.Discard),
+		root,
+		"session",
+		synchronization.Version_Version1,
+		&synchronization.Configuration{
+			WatchMode:              synchronization.WatchMode_WatchModeNoWatch,
+			MaximumSignatureMemory: maximumSignatureMemory,
+		},
+		true,
+	)
+	if err != nil {
+		t.Fatal("unable to create endpoint:", err)
+	}
+	defer e.Shutdown()
+
+	// Perform a scan.
+	if _, err, _ := e.Scan(context.Background(), nil, true, nil, false); err != nil {
+		t.Fatal("unable to perform scan:", err)
+	}
+
+	// Perform staging until no paths are deferred, verifying that signature
+	// memory stays under the limit for each staging operation.
+	var rounds, staged int
+	for deferred := true; deferred; rounds++ {
+		if rounds > fileCount {
+			t.Fatal("staging failed to make progress")
+		}
+		stagePaths := append([]string(nil), paths...)
+		stageDigests := append([][]byte(nil), digests...)
+		filteredPaths, signatures, receiver, d, err := e.Stage(stagePaths, stageDigests)
+		if err != nil {
+			t.Fatal("unable to begin staging:", err)
+		} else if len(filteredPaths) == 0 {
+			t.Fatal("staging operation staged no paths")
+		}
+		deferred = d
+		var total uint64
+		for _, signature := range signatures {
+			total += signature.MemoryUsage()
+		}
+		if total > maximumSignatureMemory {
+			t.Errorf("signature memory (%d) exceeded limit (%d)", total, maximumSignatureMemory)
+		}
+		if err := rsync.Transmit(source, filteredPaths, signatures, receiver); err != nil {
+			t.Fatal("unable to transmit files:", err)
+		}
+		staged += len(filteredPaths)
+	}
+
+	// Verify that staging was actually split across multiple operations and
+	// that every file was staged exactly once.
+	if rounds < 2 {
+		t.Error("staging was not deferred")
+	} else if staged != fileCount {
+		t.Errorf("staged file count (%d) does not match expected (%d)", staged, fileCount)
+	}
+	for p, path := range paths {
+		if available, err := e.(*endpoint).stager.Contains(path, digests[p]); err != nil {
+			t.Fatal("unable to query staging status:", err)
+		} else if !available {
+			t.Error("file not staged:", path)
+		}
+	}
+
+	// Verify that a further staging operation (without a scan) is rejected now
+	// that nothing is deferred.
+	if _, _, _, _, err := e.Stage(append([]string(nil), paths...), append([][]byte(nil), digests...)); err == nil {
+		t.Error("staging operation without scan succeeded unexpectedly")
+	}
+}
+
+// TestStageRsyncBlockSize tests that staging computes rsync signatures using
+// the configured block size.
+func TestStageRsyncBlockSize(t *testing.T) {
+	// Set up parameters. We choose a file size whose optimal block size differs
+	// from the configured block size.
+	const (
+		fileSize       = 1 << 20
+		rsyncBlockSize = 1 << 12
+	)
+	if rsync.OptimalBlockSizeForBaseLength(fileSize) == rsyncBlockSize {
+		t.Fatal("configured block size matches optimal block size")
+	}
+
+	// Create a synchronization root containing a file.
+	root := t.TempDir()
+	t.Setenv("MUTAGEN_DATA_DIRECTORY", t.TempDir())
+	if err := os.WriteFile(filepath.Join(root, "file"), make([]byte, fileSize), 0600); err != nil {
+		t.Fatal("unable to create file:", err)
+	}
+
+	// Create the endpoint and defer its shutdown.
+	e, err := NewEndpoint(
+		logging.NewLogger(logging.LevelDisabled, io.Discard),
+		root,
+		"session",
+		synchronization.Version_Version1,
+		&synchronization.Configuration{
+			WatchMode:      synchronization.WatchMode_WatchModeNoWatch,
+			RsyncBlockSize: rsyncBlockSize,
+		},
+		true,
+	)
+	if err != nil {
+		t.Fatal("unable to create endpoint:", err)
+	}
+	defer e.Shutdown()
+
+	// Perform a scan.
+	if _, err, _ := e.Scan(context.Background(), nil, true, nil, false); err != nil {
+		t.Fatal("unable to perform scan:", err)
+	}
+
+	// Begin staging new content for the file and verify the signature.
+	digest := sha1.Sum([]byte("new content"))
+	_, signatures, _, _, err := e.Stage([]string{"file"}, [][]byte{digest[:]})
+	if err != nil {
+		t.Fatal("unable to begin staging:", err)
+	} else if len(signatures) != 1 {
+		t.Fatal("unexpected number of signatures:", len(signatures))
+	} else if signatures[0].BlockSize != rsyncBlockSize {
+		t.Errorf("signature block size (%d) does not match configured block size (%d)",
+			signatures[0].BlockSize, rsyncBlockSize,
+		)
+	}
+}
+
+// TestStageSignatureCache tests that staging re-uses cached signatures for
+// unchanged base files and recomputes them for files modified in-place without
+// a change in size.
+func TestStageSignatureCache(t *testing.T) {
+	// Create a synchronization root containing a file.
+	root := t.TempDir()
+	t.Setenv("MUTAGEN_DATA_DIRECTORY", t.TempDir())
+	path := filepath.Join(root, "file")
+	original := bytes.Repeat([]byte("original content "), 4096)
+	if err := os.WriteFile(path, original, 0600); err != nil {
+		t.Fatal("unable to create file:", err)
+	}
+
+	// Create the endpoint and defer its shutdown.
+	e, err := NewEndpoint(
+		logging.NewLogger(logging.LevelDisabled, io.Discard),
+		root,
+		"session",
+		synchronization.Version_Version1,
+		&synchronization.Configuration{
+			WatchMode: synchronization.WatchMode_WatchModeNoWatch,
+		},
+		true,
+	)
+	if err != nil {
+		t.Fatal("unable to create endpoint:", err)
+	}
+	defer e.Shutdown()
+
+	// Create a function to scan and begin staging new content for the file.
+	digest := sha1.Sum([]byte("new content"))
+	stage := func() *rsync.Signature {
+		if _, err, _ := e.Scan(context.Background(), nil, true, nil, false); err != nil {
+			t.Fatal("unable to perform scan:", err)
+		}
+		_, signatures, _, _, err := e.Stage([]string{"file"}, [][]byte{digest[:]})
+		if err != nil {
+			t.Fatal("unable to begin staging:", err)
+		} else if len(signatures) != 1 {
+			t.Fatal("unexpected number of signatures:", len(signatures))
+		}
+		return signatures[0]
+	}
+
+	// Stage twice and verify that the signature is re-used.
+	first := stage()
+	if second := stage(); second != first {
+		t.Error("signature not re-used for unchanged file")
+	}
+
+	// Modify the file in-place without changing its size and ensure that its
+	// modification time changes.
+	modified := append([]byte(nil), original...)
+	copy(modified[len(modified)/2:], "modified")
+	if err := os.WriteFile(path, modified, 0600); err != nil {
+		t.Fatal("unable to modify file:", err)
+	}
+	modificationTime := time.Now().Add(time.Minute)
+	if err := os.Chtimes(path, modificationTime, modificationTime); err != nil {
+		t.Fatal("unable to update file modification time:", err)
+	}
+
+	// Stage again and verify that the signature was recomputed.
+	expected := rsync.NewEngine().BytesSignature(modified, 0)
+	if third := stage(); third == first {
+		t.Error("signature re-used for modified file")
+	} else if !bytes.Equal(third.Fingerprint(), expected.Fingerprint()) {
+		t.Error("signature does not match modified content")
+	}
+}
+
+// TestEmptyFileSynchronization tests that an empty file on one endpoint is
+// reproduced as an empty (rather than absent) file on the other endpoint across
+// a full synchronization cycle, and that its removal is reproduced as absence.
+func TestEmptyFileSynchronization(t *testing.T) {
+	// Create roots with an empty file on alpha.
+	alphaRoot := t.TempDir()
+	betaRoot := t.TempDir()
+	t.Setenv("MUTAGEN_DATA_DIRECTORY", t.TempDir())
+	alphaPath := filepath.Join(alphaRoot, "empty")
+	betaPath := filepath.Join(betaRoot, "empty")
+	if err := os.WriteFile(alphaPath, nil, 0600); err != nil {
+		t.Fatal("unable to create empty file:", err)
+	}
+
+	// Create endpoints and defer their shutdown.
+	configuration := &synchronization.Configuration{
+		WatchMode: synchronization.WatchMode_WatchModeNoWatch,
+	}
+	alpha, err := NewEndpoint(
+		logging.NewLogger(logging.LevelDisabled, io.Discard),
+		alphaRoot,
+		"session",
+		synchronization.Version_Version1,
+		configuration,
+		true,
+	)
+	if err != nil {
+		t.Fatal("unable to create alpha endpoint:", err)
+	}
+	defer alpha.Shutdown()
+	beta, err := NewEndpoint(
+		logging.NewLogger(logging.LevelDisabled, io.Discard),
+		betaRoot,
+		"session",
+		synchronization.Version_Version1,
+		configuration,
+		false,
+	)
+	if err != nil {
+		t.Fatal("unable to create beta endpoint:", err)
+	}
+	defer beta.Shutdown()
+
+	// cycle performs a synchronization cycle that propagates the state of the
+	// empty file on alpha to beta and then returns beta's resulting entry.
+	cycle := func() *core.Entry {
+		// Scan both endpoints.
+		alphaSnapshot, err, _ := alpha.Scan(context.Background(), nil, true, nil, false)
+		if err != nil {
+			t.Fatal("unable to scan alpha:", err)
+		}
+		betaSnapshot, err, _ := beta.Scan(context.Background(), nil, true, nil, false)
+		if err != nil {
+			t.Fatal("unable to scan beta:", err)
+		}
+
+		// Compute the transition.
+		change := &core.Change{
+			Path: "empty",
+			Old:  betaSnapshot.Content.Contents["empty"],
+			New:  alphaSnapshot.Content.Contents["empty"],
+		}
+
+		// Perform staging if necessary. Empty content should always be staged
+		// locally without requiring transmission.
+		if change.New != nil {
+			paths, _, receiver, _, err := beta.Stage([]string{"empty"}, [][]byte{change.New.Digest})
+			if err != nil {
+				t.Fatal("unable to perform staging:", err)
+			} else if len(paths) != 0 || receiver != nil {
+				t.Error("empty file required transmission")
+			}
+		}
+
+		// Perform the transition.
+		results, problems, missingFiles, err := beta.Transition(context.Background(), []*core.Change{change}, false)
+		if err != nil {
+			t.Fatal("unable to perform transition:", err)
+		} else if len(problems) > 0 {
+			t.Fatal("transition encountered problems:", problems[0].Error)
+		} else if missingFiles {
+			t.Fatal("transition reported missing files")
+		} else if !results[0].Equal(change.New, true) {
+			t.Error("transition result does not match expected")
+		}
+
+		// Rescan beta and return the resulting entry.
+		betaSnapshot, err, _ = beta.Scan(context.Background(), nil, true, nil, false)
+		if err != nil {
+			t.Fatal("unable to rescan beta:", err)
+		}
+		return betaSnapshot.Content.Contents["empty"]
+	}
+
+	// Verify that the empty file is created as an empty file on beta.
+	if entry := cycle(); entry == nil || entry.Kind != core.EntryKind_File {
+		t.Fatal("empty file not propagated as file")
+	}
+	if info, err := os.Stat(betaPath); err != nil {
+		t.Fatal("unable to query empty file on beta:", err)
+	} else if info.Size() != 0 {
+		t.Error("file on beta is not empty")
+	}
+
+	// Remove the file on alpha and verify that it becomes absent on beta.
+	if err := os.Remove(alphaPath); err != nil {
+		t.Fatal("unable to remove empty file:", err)
+	}
+	if entry := cycle(); entry != nil {
+		t.Error("removed file still present in beta snapshot")
+	}
+	if _, err := os.Lstat(betaPath); !os.IsNotExist(err) {
+		t.Error("removed file still present on beta")
+	}
+}
+
+// TestWatchOverflowTracking tests that the endpoint honors its configured watch
+// queue size and tracks native watcher internal event overflows.
+func TestWatchOverflowTracking(t *testing.T) {
+	// Skip this test if non-recursive watching isn't what portable watching
+	// uses on this platform.
+	if watching.RecursiveWatchingSupported || !watching.NonRecursiveWatchingSupported {
+		t.Skip()
+	}
+
+	// Create a synchronization root, keeping the Mutagen data directory outside
+	// of it.
+	root := t.TempDir()
+	t.Setenv("MUTAGEN_DATA_DIRECTORY", t.TempDir())
+
+	// Create the endpoint with a minimal queue size and defer its shutdown. We
+	// use a long polling interval so that only event-driven rescans occur.
+	e, err := NewEndpoint(
+		logging.NewLogger(logging.LevelDisabled, io.Discard),
+		root,
+		"session",
+		synchronization.Version_Version1,
+		&synchronization.Configuration{
+			WatchPollingInterval: 3600,
+			WatchQueueSize:       1,
+		},
+		true,
+	)
+	if err != nil {
+		t.Fatal("unable to create endpoint:", err)
+	}
+	defer e.Shutdown()
+
+	// Verify that the configured queue size is being used.
+	if queueSize := e.(*endpoint).watchQueueSize; queueSize != 1 {
+		t.Fatal("watch queue size does not match configuration:", queueSize)
+	}
+
+	// Wait for any poll events generated by watch establishment to drain and
+	// verify that no overflows have occurred yet.
+	for pollWithTimeout(t, e, time.Second) {
+	}
+	if overflows := e.WatchOverflows(); overflows != 0 {
+		t.Fatal("unexpected watch overflows before event burst:", overflows)
+	}
+
+	// Generate a burst of events that will overflow the watcher's queue and
+	// wait for the overflow to be recorded.
+	for f := 0; f < 256; f++ {
+		name := filepath.Join(root, fmt.Sprintf("file%03d", f))
+		if err := os.WriteFile(name, []byte("content"), 0600); err != nil {
+			t.Fatal("unable to write file:", err)
+		}
+	}
+	deadline := time.Now().Add(10 * time.Second)
+	for e.WatchOverflows() == 0 && time.Now().Before(deadline) {
+		time.Sleep(10 * time.Millisecond)
+	}
+	if e.WatchOverflows() == 0 {
+		t.Error("watch overflow not recorded")
+	}
+}
+
+// TestOversizedFileModes tests that files exceeding the maximum staging file
+// size are handled according to the configured oversized file mode and that
+// refused files are never partially staged.
+func TestOversizedFileModes(t *testing.T) {
+	// Set up parameters.
+	const (
+		maximumStagingFileSize = 1024
+		fileSize               = 4 * maximumStagingFileSize
+	)
+
+	// Set up test cases.
+	testCases := []struct {
+		mode          synchronization.OversizedFileMode
+		expectStaged  bool
+		expectProblem bool
+	}{
+		{synchronization.OversizedFileMode_OversizedFileModeDefault, false, true},
+		{synchronization.OversizedFileMode_OversizedFileModeSkip, false, true},
+		{synchronization.OversizedFileMode_OversizedFileModeHalt, false, true},
+		{synchronization.OversizedFileMode_OversizedFileModeWarn, true, false},
+	}
+
+	// Process test cases.
+	for i, testCase := range testCases {
+		// Create a synchronization root and a source directory containing an
+		// oversized file.
+		root := t.TempDir()
+		source := t.TempDir()
+		t.Setenv("MUTAGEN_DATA_DIRECTORY", t.TempDir())
+		content := make([]byte, fileSize)
+		rand.New(rand.NewSource(0)).Read(content)
+		if err := os.WriteFile(filepath.Join(source, "large"), content, 0600); err != nil {
+			t.Fatalf("test index %d: unable to create source file: %v", i, err)
+		}
+		digest := sha1.Sum(content)
+
+		// Create the endpoint.
+		e, err := NewEndpoint(
+			logging.NewLogger(logging.LevelDisabled, io.Discard),
+			root,
+			"session",
+			synchronization.Version_Version1,
+			&synchronization.Configuration{
+				WatchMode:              synchronization.WatchMode_WatchModeNoWatch,
+				MaximumStagingFileSize: maximumStagingFileSize,
+				OversizedFileMode:      testCase.mode,
+			},
+			false,
+		)
+		if err != nil {
+			t.Fatalf("test index %d: unable to create endpoint: %v", i, err)
+		}
+
+		// Perform a scan.
+		if _, err, _ := e.Scan(context.Background(), nil, true, nil, false); err != nil {
+			t.Fatalf("test index %d: unable to perform scan: %v", i, err)
+		}
+
+		// Perform staging.
+		paths, signatures, receiver, _, err := e.Stage([]string{"large"}, [][]byte{digest[:]})
+		if err != nil {
+			t.Fatalf("test index %d: unable to begin staging: %v", i, err)
+		} else if len(paths) != 1 {
+			t.Fatalf("test index %d: oversized file not requested for staging", i)
+		}
+		if err := rsync.Transmit(source, paths, signatures, receiver); err != nil {
+			t.Fatalf("test index %d: unable to transmit file: %v", i, err)
+		}
+
+		// Verify the staging status of the file. If the file was refused, then
+		// verify that no storage was left behind for it.
+		stager := e.(*endpoint).stager
+		if staged, err := stager.Contains("large", digest[:]); err != nil {
+			t.Fatalf("test index %d: unable to query staging status: %v", i, err)
+		} else if staged != testCase.expectStaged {
+			t.Errorf("test index %d: staging status does not match expected: %t != %t",
+				i, staged, testCase.expectStaged,
+			)
+		}
+		if !testCase.expectStaged {
+			err := filepath.WalkDir(e.(*endpoint).stagingRoot, func(path string, entry fs.DirEntry, err error) error {
+				if err != nil {
+					return err
+				} else if !entry.IsDir() {
+					t.Errorf("test index %d: refused file partially staged at %s", i, path)
+				}
+				return nil
+			})
+			if err != nil {
+				t.Fatalf("test index %d: unable to walk staging root: %v", i, err)
+			}
+		}
+
+		// Perform the transition.
+		change := &core.Change{
+			Path: "large",
+			New:  &core.Entry{Kind: core.EntryKind_File, Digest: digest[:]},
+		}
+		_, problems, missingFiles, err := e.Transition(context.Background(), []*core.Change{change}, false)
+		if err != nil {
+			t.Fatalf("test index %d: unable to perform transition: %v", i, err)
+		} else if missingFiles {
+			t.Errorf("test index %d: transition reported missing files", i)
+		}
+		if testCase.expectProblem {
+			if len(problems) != 1 {
+				t.Errorf("test index %d: oversized file not reported as problem", i)
+			} else if problems[0].Path != "large" {
+				t.Errorf("test index %d: problem reported for incorrect path: %s", i, problems[0].Path)
+			} else if !strings.Contains(problems[0].Error, "maximum staging file size") {
+				t.Errorf("test index %d: problem does not indicate oversized file: %s", i, problems[0].Error)
+			}
+			if e.OversizedFiles() != 1 {
+				t.Errorf("test index %d: oversized file count does not match expected: %d != 1",
+					i, e.OversizedFiles(),
+				)
+			}
+			if _, err := os.Lstat(filepath.Join(root, "large")); !os.IsNotExist(err) {
+				t.Errorf("test index %d: oversized file created in root", i)
+			}
+		} else {
+			if len(problems) > 0 {
+				t.Errorf("test index %d: transition encountered problems: %s", i, problems[0].Error)
+			}
+			if e.OversizedFiles() != 0 {
+				t.Errorf("test index %d: oversized file count non-zero", i)
+			}
+			if data, err := os.ReadFile(filepath.Join(root, "large")); err != nil {
+				t.Errorf("test index %d: unable to read transitioned file: %v", i, err)
+			} else if !bytes.Equal(data, content) {
+				t.Errorf("test index %d: transitioned file content does not match expected", i)
+			}
+		}
+
+		// Shut down the endpoint.
+		if err := e.Shutdown(); err != nil {
+			t.Errorf("test index %d: unable to shut down endpoint: %v", i, err)
+		}
+	}
+}
+
+// TestMaximumStagingFileSizePerEndpoint tests that endpoints enforce their own
+// maximum staging file sizes when those sizes are overridden on a per-endpoint
+// basis.
+func TestMaximumStagingFileSizePerEndpoint(t *testing.T) {
+	// Set up parameters.
+	const fileSize = 4096
+
+	// Create the session and endpoint-specific configurations. Alpha's limit is
+	// below the file size and beta's limit is above it.
+	configuration := &synchronization.Configuration{
+		WatchMode:              synchronization.WatchMode_WatchModeNoWatch,
+		MaximumStagingFileSize: fileSize / 2,
+	}
+	configurationAlpha := &synchronization.Configuration{MaximumStagingFileSize: fileSize / 4}
+	configurationBeta := &synchronization.Configuration{MaximumStagingFileSize: fileSize * 4}
+	if err := configurationAlpha.EnsureValid(true); err != nil {
+		t.Fatal("alpha configuration invalid:", err)
+	} else if err := configurationBeta.EnsureValid(true); err != nil {
+		t.Fatal("beta configuration invalid:", err)
+	}
+
+	// Create a source directory containing the file.
+	source := t.TempDir()
+	t.Setenv("MUTAGEN_DATA_DIRECTORY", t.TempDir())
+	content := make([]byte, fileSize)
+	rand.New(rand.NewSource(0)).Read(content)
+	if err := os.WriteFile(filepath.Join(source, "file"), content, 0600); err != nil {
+		t.Fatal("unable to create source file:", err)
+	}
+	digest := sha1.Sum(content)
+
+	// Set up test cases.
+	testCases := []struct {
+		name          string
+		configuration *synchronization.Configuration
+		expectStaged  bool
+	}{
+		{"alpha", configurationAlpha, false},
+		{"beta", configurationBeta, true},
+	}
+
+	// Process test cases.
+	for _, testCase := range testCases {
+		// Create the endpoint using the merged configuration, just as the
+		// synchronization controller would.
+		root := t.TempDir()
+		e, err := NewEndpoint(
+			logging.NewLogger(logging.LevelDisabled, io.Discard),
+			root,
+			"session-"+testCase.name,
+			synchronization.Version_Version1,
+			synchronization.MergeConfigurations(configuration, testCase.configuration),
+			testCase.name == "alpha",
+		)
+		if err != nil {
+			t.Fatalf("%s: unable to create endpoint: %v", testCase.name, err)
+		}
+
+		// Perform a scan.
+		if _, err, _ := e.Scan(context.Background(), nil, true, nil, false); err != nil {
+			t.Fatalf("%s: unable to perform scan: %v", testCase.name, err)
+		}
+
+		// Perform staging.
+		paths, signatures, receiver, _, err := e.Stage([]string{"file"}, [][]byte{digest[:]})
+		if err != nil {
+			t.Fatalf("%s: unable to begin staging: %v", testCase.name, err)
+		}
+		if err := rsync.Transmit(source, paths, signatures, receiver); err != nil {
+			t.Fatalf("%s: unable to transmit file: %v", testCase.name, err)
+		}
+
+		// Perform the transition and verify that the endpoint's own limit was
+		// enforced.
+		change := &core.Change{
+			Path: "file",
+			New:  &core.Entry{Kind: core.EntryKind_File, Digest: digest[:]},
+		}
+		_, problems, _, err := e.Transition(context.Background(), []*core.Change{change}, false)
+		if err != nil {
+			t.Fatalf("%s: unable to perform transition: %v", testCase.name, err)
+		}
+		_, statErr := os.Lstat(filepath.Join(root, "file"))
+		if testCase.expectStaged {
+			if len(problems) > 0 {
+				t.Errorf("%s: transition encountered problems: %s", testCase.name, problems[0].Error)
+			} else if statErr != nil {
+				t.Errorf("%s: file not created in root: %v", testCase.name, statErr)
+			}
+		} else {
+			if len(problems) != 1 || !strings.Contains(problems[0].Error, "maximum staging file size") {
+				t.Errorf("%s: oversized file not reported as problem", testCase.name)
+			} else if !os.IsNotExist(statErr) {
+				t.Errorf("%s: oversized file created in root", testCase.name)
+			}
+		}
+
+		// Shut down the endpoint.
+		if err := e.Shutdown(); err != nil {
+			t.Errorf("%s: unable to shut down endpoint: %v", testCase.name, err)
+		}
+	}
+}
+
+// TestOversizedFilePlaceholders tests that files exceeding the maximum staging
+// file size are represented by empty placeholder files in placeholder mode, that
+// placeholders are reported as the content they represent (and are thus
+// distinguished from genuinely empty files), and that modified placeholders are
+// detected as modifications.
+func TestOversizedFilePlaceholders(t *testing.T) {
+	// Set up parameters.
+	const (
+		maximumStagingFileSize = 1024
+		fileSize               = 4 * maximumStagingFileSize
+	)
+
+	// Create roots with an oversized file and an empty file on alpha.
+	alphaRoot := t.TempDir()
+	betaRoot := t.TempDir()
+	t.Setenv("MUTAGEN_DATA_DIRECTORY", t.TempDir())
+	content := make([]byte, fileSize)
+	rand.New(rand.NewSource(0)).Read(content)
+	if err := os.WriteFile(filepath.Join(alphaRoot, "large"), content, 0600); err != nil {
+		t.Fatal("unable to create oversized file:", err)
+	}
+	if err := os.WriteFile(filepath.Join(alphaRoot, "empty"), nil, 0600); err != nil {
+		t.Fatal("unable to create empty file:", err)
+	}
+
+	// Create endpoints and defer their shutdown.
+	alpha, err := NewEndpoint(
+		logging.NewLogger(logging.LevelDisabled, io.Discard),
+		alphaRoot,
+		"session",
+		synchronization.Version_Version1,
+		&synchronization.Configuration{
+			WatchMode: synchronization.WatchMode_WatchModeNoWatch,
+		},
+		true,
+	)
+	if err != nil {
+		t.Fatal("unable to create alpha endpoint:", err)
+	}
+	defer alpha.Shutdown()
+	beta, err := NewEndpoint(
+		logging.NewLogger(logging.LevelDisabled, io.Discard),
+		betaRoot,
+		"session",
+		synchronization.Version_Version1,
+		&synchronization.Configuration{
+			WatchMode:              synchronization.WatchMode_WatchModeNoWatch,
+			MaximumStagingFileSize: maximumStagingFileSize,
+			OversizedFileMode:      synchronization.OversizedFileMode_OversizedFileModePlaceholder,
+		},
+		false,
+	)
+	if err != nil {
+		t.Fatal("unable to create beta endpoint:", err)
+	}
+	defer beta.Shutdown()
+
+	// cycle performs a synchronization cycle that propagates any differences
+	// in alpha's content to beta and returns alpha's snapshot, beta's
+	// resulting snapshot, and the number of transitions performed.
+	cycle := func() (*core.Snapshot, *core.Snapshot, int) {
+		// Scan both endpoints.
+		alphaSnapshot, err, _ := alpha.Scan(context.Background(), nil, true, nil, false)
+		if err != nil {
+			t.Fatal("unable to scan alpha:", err)
+		}
+		betaSnapshot, err, _ := beta.Scan(context.Background(), nil, true, nil, false)
+		if err != nil {
+			t.Fatal("unable to scan beta:", err)
+		}
+
+		// Compute transitions.
+		var transitions []*core.Change
+		var paths []string
+		var digests [][]byte
+		for _, name := range []string{"empty", "large"} {
+			old := betaSnapshot.Content.Contents[name]
+			new := alphaSnapshot.Content.Contents[name]
+			if old.Equal(new, true) {
+				continue
+			}
+			transitions = append(transitions, &core.Change{Path: name, Old: old, New: new})
+			paths = append(paths, name)
+			digests = append(digests, new.Digest)
+		}
+
+		// Perform staging and transitioning if necessary.
+		if len(transitions) > 0 {
+			paths, signatures, receiver, _, err := beta.Stage(paths, digests)
+			if err != nil {
+				t.Fatal("unable to perform staging:", err)
+			} else if receiver != nil {
+				if err := rsync.Transmit(alphaRoot, paths, signatures, receiver); err != nil {
+					t.Fatal("unable to transmit files:", err)
+				}
+			}
+			_, problems, missingFiles, err := beta.Transition(context.Background(), transitions, false)
+			if err != nil {
+				t.Fatal("unable to perform transition:", err)
+			} else if len(problems) > 0 {
+				t.Fatal("transition encountered problems:", problems[0].Error)
+			} else if missingFiles {
+				t.Fatal("transition reported missing files")
+			}
+		}
+
+		// Rescan beta.
+		betaSnapshot, err, _ = beta.Scan(context.Background(), nil, true, nil, false)
+		if err != nil {
+			t.Fatal("unable to rescan beta:", err)
+		}
+		return alphaSnapshot, betaSnapshot, len(transitions)
+	}
+
+	// Perform an initial cycle and verify that the oversized file appears as
+	// a placeholder on beta that's reported as the content it represents,
+	// while the empty file is reported as empty content.
+	alphaSnapshot, betaSnapshot, _ := cycle()
+	if info, err := os.Lstat(filepath.Join(betaRoot, "large")); err != nil {
+		t.Fatal("unable to query placeholder:", err)
+	} else if !info.Mode().IsRegular() || info.Size() != 0 {
+		t.Error("oversized file not represented by empty placeholder")
+	}
+	if !betaSnapshot.Content.Equal(alphaSnapshot.Content, true) {
+		t.Error("beta content does not match alpha content after initial cycle")
+	}
+	if bytes.Equal(betaSnapshot.Content.Contents["large"].Digest, betaSnapshot.Content.Contents["empty"].Digest) {
+		t.Error("placeholder not distinguished from empty file")
+	}
+
+	// Verify that subsequent cycles don't perform any transitions.
+	if _, _, transitions := cycle(); transitions != 0 {
+		t.Error("placeholder caused synchronization churn")
+	}
+
+	// Modify the oversized file on alpha and verify that the placeholder is
+	// updated to represent the new content.
+	content[0]++
+	if err := os.WriteFile(filepath.Join(alphaRoot, "large"), content, 0600); err != nil {
+		t.Fatal("unable to modify oversized file:", err)
+	}
+	alphaSnapshot, betaSnapshot, transitions := cycle()
+	if transitions != 1 {
+		t.Error("oversized file modification not propagated")
+	} else if !betaSnapshot.Content.Equal(alphaSnapshot.Content, true) {
+		t.Error("beta content does not match alpha content after modification")
+	}
+
+	// Modify the placeholder on beta and verify that the modification is seen.
+	if err := os.WriteFile(filepath.Join(betaRoot, "large"), []byte("modified"), 0600); err != nil {
+		t.Fatal("unable to modify placeholder:", err)
+	}
+	betaSnapshot, err, _ = beta.Scan(context.Background(), nil, true, nil, false)
+	if err != nil {
+		t.Fatal("unable to scan beta:", err)
+	} else if betaSnapshot.Content.Contents["large"].Equal(alphaSnapshot.Content.Contents["large"], true) {
+		t.Error("placeholder modification not detected")
+	}
+}
+
+// TestStagedContentVerification tests that content reconstructed during
+// staging is verified against its expected digest and that content failing
+// verification is rejected rather than transitioned into place.
+func TestStagedContentVerification(t *testing.T) {
+	// Create a synchronization root and a source directory containing a file
+	// whose transmitted content is corrupted relative to its expected digest.
+	root := t.TempDir()
+	source := t.TempDir()
+	t.Setenv("MUTAGEN_DATA_DIRECTORY", t.TempDir())
+	content := make([]byte, 64*1024)
+	rand.New(rand.NewSource(0)).Read(content)
+	digest := sha1.Sum(content)
+	corrupted := append([]byte(nil), content...)
+	corrupted[len(corrupted)/2] ^= 0xff
+	if err := os.WriteFile(filepath.Join(source, "file"), corrupted, 0600); err != nil {
+		t.Fatal("unable to create source file:", err)
+	}
+
+	// Create the endpoint and defer its shutdown.
+	e, err := NewEndpoint(
+		logging.NewLogger(logging.LevelDisabled, io.Discard),
+		root,
+		"session",
+		synchronization.Version_Version1,
+		&synchronization.Configuration{
+			WatchMode: synchronization.WatchMode_WatchModeNoWatch,
+		},
+		false,
+	)
+	if err != nil {
+		t.Fatal("unable to create endpoint:", err)
+	}
+	defer e.Shutdown()
+
+	// Perform a scan.
+	if _, err, _ := e.Scan(context.Background(), nil, true, nil, false); err != nil {
+		t.Fatal("unable to perform scan:", err)
+	}
+
+	// Perform staging with the corrupted content.
+	paths, signatures, receiver, _, err := e.Stage([]string{"file"}, [][]byte{digest[:]})
+	if err != nil {
+		t.Fatal("unable to begin staging:", err)
+	} else if len(paths) != 1 {
+		t.Fatal("file not requested for staging")
+	}
+	if err := rsync.Transmit(source, paths, signatures, receiver); err != nil {
+		t.Fatal("unable to transmit file:", err)
+	}
+
+	// Verify that the corrupted content was rejected by the stager.
+	if staged, err := e.(*endpoint).stager.Contains("file", digest[:]); err != nil {
+		t.Fatal("unable to query staging status:", err)
+	} else if staged {
+		t.Error("corrupted content staged")
+	}
+
+	// Perform the transition and verify that it reports a verification problem
+	// and missing files, and that the file isn't created in the root.
+	change := &core.Change{
+		Path: "file",
+		New:  &core.Entry{Kind: core.EntryKind_File, Digest: digest[:]},
+	}
+	results, problems, missingFiles, err := e.Transition(context.Background(), []*core.Change{change}, false)
+	if err != nil {
+		t.Fatal("unable to perform transition:", err)
+	} else if len(problems) != 1 {
+		t.Fatal("corrupted content not reported as problem")
+	} else if !strings.Contains(problems[0].Error, "digest verification") {
+		t.Error("problem does not indicate failed verification:", problems[0].Error)
+	} else if !missingFiles {
+		t.Error("transition did not report missing files")
+	} else if results[0] != nil {
+		t.Error("transition result indicates file creation")
+	}
+	if _, err := os.Lstat(filepath.Join(root, "file")); !os.IsNotExist(err) {
+		t.Error("corrupted content transitioned into root")
+	}
+}
+
+// TestRenameDetectionFileSizeLimit tests that staging only sources content from
+// existing files within the synchronization root if they don't exceed the
+// maximum rename detection file size.
+func TestRenameDetectionFileSizeLimit(t *testing.T) {
+	// Set up parameters.
+	const fileSize = 64 * 1024
+
+	// Set up test cases.
+	testCases := []struct {
+		maximumRenameDetectionFileSize uint64
+		expectTransfer                 bool
+	}{
+		{0, false},
+		{fileSize, false},
+		{fileSize / 2, true},
+	}
+
+	// Process test cases.
+	for i, testCase := range testCases {
+		// Create a synchronization root containing a file.
+		root := t.TempDir()
+		t.Setenv("MUTAGEN_DATA_DIRECTORY", t.TempDir())
+		content := make([]byte, fileSize)
+		rand.New(rand.NewSource(0)).Read(content)
+		if err := os.WriteFile(filepath.Join(root, "original"), content, 0600); err != nil {
+			t.Fatalf("test index %d: unable to create file: %v", i, err)
+		}
+		digest := sha1.Sum(content)
+
+		// Create the endpoint.
+		e, err := NewEndpoint(
+			logging.NewLogger(logging.LevelDisabled, io.Discard),
+			root,
+			"session",
+			synchronization.Version_Version1,
+			&synchronization.Configuration{
+				WatchMode:                      synchronization.WatchMode_WatchModeNoWatch,
+				MaximumRenameDetectionFileSize: testCase.maximumRenameDetectionFileSize,
+			},
+			false,
+		)
+		if err != nil {
+			t.Fatalf("test index %d: unable to create endpoint: %v", i, err)
+		}
+
+		// Perform a scan to populate the cache.
+		if _, err, _ := e.Scan(context.Background(), nil, true, nil, false); err != nil {
+			t.Fatalf("test index %d: unable to perform scan: %v", i, err)
+		}
+
+		// Request staging of identical content at a different path and verify
+		// whether or not it was sourced from the existing file.
+		paths, _, _, _, err := e.Stage([]string{"renamed"}, [][]byte{digest[:]})
+		if err != nil {
+			t.Fatalf("test index %d: unable to begin staging: %v", i, err)
+		} else if transfer := len(paths) == 1; transfer != testCase.expectTransfer {
+			t.Errorf("test index %d: transfer requirement does not match expected: %t != %t",
+				i, transfer, testCase.expectTransfer,
+			)
+		}
+
+		// Shut down the endpoint.
+		if err := e.Shutdown(); err != nil {
+			t.Errorf("test index %d: unable to shut down endpoint: %v", i, err)
+		}
+	}
+}
+
+// modifyingStager is a stager wrapper that transiently modifies a source file
+// while the first sink that it creates is being written, emulating a source
+// file that changes once during staging and then stabilizes.
+type modifyingStager struct {
+	stager
+	// path is the path of the source file to modify.
+	path string
+	// original is the original content of the source file.
+	original []byte
+	// modified indicates whether or not the source file has been modified.
+	modified bool
+}
+
+// Sink implements rsync.Sinker.Sink.
+func (s *modifyingStager) Sink(path string, digest []byte, expectedSize uint64) (io.WriteCloser, error) {
+	// Create the underlying sink.
+	sink, err := s.stager.Sink(path, digest, expectedSize)
+	if err != nil || s.modified {
+		return sink, err
+	}
+
+	// Modify the source file in-place and arrange for its restoration once the
+	// sink is closed.
+	s.modified = true
+	modified := bytes.Repeat([]byte{0}, len(s.original))
+	if err := os.WriteFile(s.path, modified, 0600); err != nil {
+		sink.Close()
+		return nil, err
+	}
+	return &restoringSink{sink, s.path, s.original}, nil
+}
+
+// restoringSink is a sink wrapper that restores a source file's content before
+// closing the underlying sink.
+type restoringSink struct {
+	io.WriteCloser
+	// path is the path of the source file to restore.
+	path string
+	// original is the original content of the source file.
+	original []byte
+}
+
+// Close implements io.Closer.Close.
+func (s *restoringSink) Close() error {
+	if err := os.WriteFile(s.path, s.original, 0600); err != nil {
+		s.WriteCloser.Close()
+		return err
+	}
+	return s.WriteCloser.Close()
+}
+
+// TestRenameDetectionRetries tests that staging from an existing file within
+// the synchronization root is retried if the file changes during copying.
+func TestRenameDetectionRetries(t *testing.T) {
+	// Set up test cases.
+	testCases := []struct {
+		renameDetectionRetries uint32
+		expectTransfer         bool
+	}{
+		{0, true},
+		{1, false},
+	}
+
+	// Process test cases.
+	for i, testCase := range testCases {
+		// Create a synchronization root containing a file.
+		root := t.TempDir()
+		t.Setenv("MUTAGEN_DATA_DIRECTORY", t.TempDir())
+		content := make([]byte, 64*1024)
+		rand.New(rand.NewSource(0)).Read(content)
+		original := filepath.Join(root, "original")
+		if err := os.WriteFile(original, content, 0600); err != nil {
+			t.Fatalf("test index %d: unable to create file: %v", i, err)
+		}
+		digest := sha1.Sum(content)
+
+		// Create the endpoint.
+		e, err := NewEndpoint(
+			logging.NewLogger(logging.LevelDisabled, io.Discard),
+			root,
+			"session",
+			synchronization.Version_Version1,
+			&synchronization.Configuration{
+				WatchMode:              synchronization.WatchMode_WatchModeNoWatch,
+				RenameDetectionRetries: testCase.renameDetectionRetries,
+			},
+			false,
+		)
+		if err != nil {
+			t.Fatalf("test index %d: unable to create endpoint: %v", i, err)
+		}
+
+		// Perform a scan to populate the cache.
+		if _, err, _ := e.Scan(context.Background(), nil, true, nil, false); err != nil {
+			t.Fatalf("test index %d: unable to perform scan: %v", i, err)
+		}
+
+		// Arrange for the source file to change during the first copy.
+		local := e.(*endpoint)
+		local.stager = &modifyingStager{stager: local.stager, path: original, original: content}
+
+		// Request staging of identical content at a different path and verify
+		// whether or not it was sourced from the existing file.
+		paths, _, _, _, err := e.Stage([]string{"renamed"}, [][]byte{digest[:]})
+		if err != nil {
+			t.Fatalf("test index %d: unable to begin staging: %v", i, err)
+		} else if transfer := len(paths) == 1; transfer != testCase.expectTransfer {
+			t.Errorf("test index %d: transfer requirement does not match expected: %t != %t",
+				i, transfer, testCase.expectTransfer,
+			)
+		}
+
+		// Shut down the endpoint.
+		if err := e.Shutdown(); err != nil {
+			t.Errorf("test index %d: unable to shut down endpoint: %v", i, err)
+		}
+	}
+}
+
+// TestContentCacheAcrossRestarts tests that content received by one endpoint is
+// inserted into the persistent content cache and can satisfy staging for an
+// endpoint belonging to a different session after a restart, and that content
+// failing verification on read-back is evicted rather than staged.
+func TestContentCacheAcrossRestarts(t *testing.T) {
+	// Create a source directory containing a file to be transmitted and an
+	// isolated data directory that will persist across endpoint instances.
+	source := t.TempDir()
+	t.Setenv("MUTAGEN_DATA_DIRECTORY", t.TempDir())
+	content := make([]byte, 64*1024)
+	rand.New(rand.NewSource(0)).Read(content)
+	digest := sha1.Sum(content)
+	if err := os.WriteFile(filepath.Join(source, "file"), content, 0600); err != nil {
+		t.Fatal("unable to create source file:", err)
+	}
+
+	// Set up the endpoint configuration.
+	configuration := &synchronization.Configuration{
+		WatchMode:               synchronization.WatchMode_WatchModeNoWatch,
+		MaximumContentCacheSize: 1024 * 1024,
+	}
+
+	// Create a function to create an endpoint for a session and perform a scan.
+	newEndpoint := func(session string) (synchronization.Endpoint, string) {
+		root := t.TempDir()
+		e, err := NewEndpoint(
+			logging.NewLogger(logging.LevelDisabled, io.Discard),
+			root,
+			session,
+			synchronization.Version_Version1,
+			configuration,
+			false,
+		)
+		if err != nil {
+			t.Fatal("unable to create endpoint:", err)
+		}
+		if _, err, _ := e.Scan(context.Background(), nil, true, nil, false); err != nil {
+			t.Fatal("unable to perform scan:", err)
+		}
+		return e, root
+	}
+
+	// Create a function to perform a transition that creates the file.
+	transition := func(e synchronization.Endpoint) {
+		change := &core.Change{
+			Path: "file",
+			New:  &core.Entry{Kind: core.EntryKind_File, Digest: digest[:]},
+		}
+		if _, problems, missingFiles, err := e.Transition(context.Background(), []*core.Change{change}, false); err != nil {
+			t.Fatal("unable to perform transition:", err)
+		} else if len(problems) != 0 {
+			t.Fatal("transition encountered problems:", problems[0].Error)
+		} else if missingFiles {
+			t.Fatal("transition reported missing files")
+		}
+	}
+
+	// Stage and transition the file using transmission in a first session and
+	// then shut down the endpoint.
+	first, _ := newEndpoint("first")
+	paths, signatures, receiver, _, err := first.Stage([]string{"file"}, [][]byte{digest[:]})
+	if err != nil {
+		t.Fatal("unable to begin staging:", err)
+	} else if len(paths) != 1 {
+		t.Fatal("file not requested for staging")
+	}
+	if err := rsync.Transmit(source, paths, signatures, receiver); err != nil {
+		t.Fatal("unable to transmit file:", err)
+	}
+	transition(first)
+	if err := first.Shutdown(); err != nil {
+		t.Fatal("unable to shut down endpoint:", err)
+	}
+
+	// Create an endpoint for a second session and verify that the content can
+	// be staged without transmission.
+	second, root := newEndpoint("second")
+	if paths, _, _, _, err := second.Stage([]string{"file"}, [][]byte{digest[:]}); err != nil {
+		t.Fatal("unable to begin staging:", err)
+	} else if len(paths) != 0 {
+		t.Error("cached content not used for staging")
+	}
+	transition(second)
+	if data, err := os.ReadFile(filepath.Join(root, "file")); err != nil {
+		t.Fatal("unable to read transitioned file:", err)
+	} else if !bytes.Equal(data, content) {
+		t.Error("transitioned file content does not match expected")
+	}
+	if err := second.Shutdown(); err != nil {
+		t.Fatal("unable to shut down endpoint:", err)
+	}
+
+	// Corrupt the cached content.
+	contentCacheRoot, err := pathForContentCache("sha1",
+		synchronization.Version_Version1.DefaultDigestSamplingThreshold(),
+		synchronization.Version_Version1.DefaultDigestLength(),
+	)
+	if err != nil {
+		t.Fatal("unable to compute content cache root:", err)
+	}
+	digestHex := hex.EncodeToString(digest[:])
+	cached := filepath.Join(contentCacheRoot, digestHex[:2], digestHex)
+	corrupted := append([]byte(nil), content...)
+	corrupted[len(corrupted)/2] ^= 0xff
+	if err := os.WriteFile(cached, corrupted, 0600); err != nil {
+		t.Fatal("unable to corrupt cached content:", err)
+	}
+
+	// Create an endpoint for a third session and verify that the corrupted
+	// content isn't used for staging and that it's evicted from the cache.
+	third, _ := newEndpoint("third")
+	defer third.Shutdown()
+	if paths, _, _, _, err := third.Stage([]string{"file"}, [][]byte{digest[:]}); err != nil {
+		t.Fatal("unable to begin staging:", err)
+	} else if len(paths) != 1 {
+		t.Error("corrupted cached content used for staging")
+	}
+	if _, err := os.Lstat(cached); !os.IsNotExist(err) {
+		t.Error("corrupted cached content not evicted")
+	}
+}
+
+// TestStageResumesInterruptedReception tests that content partially received by
+// an interrupted staging operation is retained across endpoint restarts and
+// used as the base for a subsequent staging operation, so that only the
+// remainder of the file is transmitted.
+func TestStageResumesInterruptedReception(t *testing.T) {
+	// Set up parameters.
+	const (
+		fileSize      = 1024 * 1024
+		interruptSize = fileSize / 2
+	)
+
+	// Create a synchronization root, a source directory containing a file to
+	// be transmitted, and an isolated data directory that will persist across
+	// endpoint instances.
+	root := t.TempDir()
+	source := t.TempDir()
+	t.Setenv("MUTAGEN_DATA_DIRECTORY", t.TempDir())
+	content := make([]byte, fileSize)
+	rand.New(rand.NewSource(0)).Read(content)
+	digest := sha1.Sum(content)
+	if err := os.WriteFile(filepath.Join(source, "file"), content, 0600); err != nil {
+		t.Fatal("unable to create source file:", err)
+	}
+
+	// Create a function to create an endpoint and perform a scan.
+	newEndpoint := func() synchronization.Endpoint {
+		e, err := NewEndpoint(
+			logging.NewLogger(logging.LevelDisabled, io.Discard),
+			root,
+			"session",
+			synchronization.Version_Version1,
+			&synchronization.Configuration{
+				WatchMode: synchronization.WatchMode_WatchModeNoWatch,
+			},
+			false,
+		)
+		if err != nil {
+			t.Fatal("unable to create endpoint:", err)
+		}
+		if _, err, _ := e.Scan(context.Background(), nil, true, nil, false); err != nil {
+			t.Fatal("unable to perform scan:", err)
+		}
+		return e
+	}
+
+	// Begin staging on a first endpoint and interrupt transmission once at
+	// least half of the file has been received, then shut down the endpoint.
+	first := newEndpoint()
+	paths, signatures, receiver, _, err := first.Stage([]string{"file"}, [][]byte{digest[:]})
+	if err != nil {
+		t.Fatal("unable to begin staging:", err)
+	} else if len(paths) != 1 {
+		t.Fatal("file not requested for staging")
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	receiver = rsync.NewPreemptableReceiver(ctx, rsync.NewMonitoringReceiver(
+		receiver, paths, signatures,
+		func(state *rsync.ReceiverState) error {
+			if state != nil && state.ReceivedSize >= interruptSize {
+				cancel()
+			}
+			return nil
+		},
+	))
+	if err := rsync.Transmit(source, paths, signatures, receiver); err == nil {
+		t.Fatal("transmission not interrupted")
+	}
+	if err := first.Shutdown(); err != nil {
+		t.Fatal("unable to shut down endpoint:", err)
+	}
+
+	// Create a second endpoint and verify that staging uses the partially
+	// received content as the base.
+	second := newEndpoint()
+	defer second.Shutdown()
+	paths, signatures, receiver, _, err = second.Stage([]string{"file"}, [][]byte{digest[:]})
+	if err != nil {
+		t.Fatal("unable to begin staging:", err)
+	} else if len(paths) != 1 {
+		t.Fatal("file not requested for staging")
+	} else if len(signatures[0].Hashes) == 0 {
+		t.Fatal("partially received content not used as base")
+	}
+	signature := signatures[0]
+	partialSize := uint64(len(signature.Hashes)-1)*signature.BlockSize + signature.LastBlockSize
+	if partialSize < interruptSize {
+		t.Fatalf("partially received content too small: %d < %d", partialSize, interruptSize)
+	}
+
+	// Verify that transmission against the resumption base only requires the
+	// remainder of the file (plus at most one block) to be sent as literal
+	// data, and then complete transmission.
+	if _, literal, err := rsync.NewEngine().Match(signature, bytes.NewReader(content)); err != nil {
+		t.Fatal("unable to compute match:", err)
+	} else if limit := fileSize - partialSize + signature.BlockSize; literal > limit {
+		t.Errorf("resumed transmission requires too much literal data: %d > %d", literal, limit)
+	}
+	if err := rsync.Transmit(source, paths, signatures, receiver); err != nil {
+		t.Fatal("unable to transmit file:", err)
+	}
+
+	// Perform the transition and verify the file content.
+	change := &core.Change{
+		Path: "file",
+		New:  &core.Entry{Kind: core.EntryKind_File, Digest: digest[:]},
+	}
+	if _, problems, missingFiles, err := second.Transition(context.Background(), []*core.Change{change}, false); err != nil {
+		t.Fatal("unable to perform transition:", err)
+	} else if len(problems) != 0 {
+		t.Fatal("transition encountered problems:", problems[0].Error)
+	} else if missingFiles {
+		t.Fatal("transition reported missing files")
+	}
+	if data, err := os.ReadFile(filepath.Join(root, "file")); err != nil {
+		t.Fatal("unable to read transitioned file:", err)
+	} else if !bytes.Equal(data, content) {
+		t.Error("transitioned file content does not match expected")
+	}
+}
+
+// TestManifestSynchronization tests that a manifest restricts synchronization
+// to exactly the manifested paths (and their parents) out of a larger tree, and
+// that ignores still apply to manifested paths.
+func TestManifestSynchronization(t *testing.T) {
+	// Create roots and populate alpha with a tree that's larger than the
+	// manifest. All files are empty so that staging doesn't require any
+	// transmission.
+	alphaRoot := t.TempDir()
+	betaRoot := t.TempDir()
+	t.Setenv("MUTAGEN_DATA_DIRECTORY", t.TempDir())
+	for _, directory := range []string{"a/b", "a/c", "d/inner", "e"} {
+		if err := os.MkdirAll(filepath.Join(alphaRoot, directory), 0700); err != nil {
+			t.Fatal("unable to create directory:", err)
+		}
+	}
+	for _, file := range []string{"top", "other", "a/b/file", "a/b/other", "a/c/file", "d/inner/file", "e/secret"} {
+		if err := os.WriteFile(filepath.Join(alphaRoot, file), nil, 0600); err != nil {
+			t.Fatal("unable to create file:", err)
+		}
+	}
+
+	// Create endpoints and defer their shutdown. We include a manifested path
+	// that's also ignored, which should remain unsynchronized.
+	configuration := &synchronization.Configuration{
+		WatchMode: synchronization.WatchMode_WatchModeNoWatch,
+		Ignores:   []string{"secret"},
+		Manifest:  []string{"top", "a/b/file", "d", "e/secret"},
+	}
+	alpha, err := NewEndpoint(
+		logging.NewLogger(logging.LevelDisabled, io.Discard),
+		alphaRoot,
+		"session",
+		synchronization.Version_Version1,
+		configuration,
+		true,
+	)
+	if err != nil {
+		t.Fatal("unable to create alpha endpoint:", err)
+	}
+	defer alpha.Shutdown()
+	beta, err := NewEndpoint(
+		logging.NewLogger(logging.LevelDisabled, io.Discard),
+		betaRoot,
+		"session",
+		synchronization.Version_Version1,
+		configuration,
+		false,
+	)
+	if err != nil {
+		t.Fatal("unable to create beta endpoint:", err)
+	}
+	defer beta.Shutdown()
+
+	// Scan both endpoints and perform reconciliation.
+	alphaSnapshot, err, _ := alpha.Scan(context.Background(), nil, true, nil, false)
+	if err != nil {
+		t.Fatal("unable to scan alpha:", err)
+	}
+	betaSnapshot, err, _ := beta.Scan(context.Background(), nil, true, nil, false)
+	if err != nil {
+		t.Fatal("unable to scan beta:", err)
+	}
+	_, alphaChanges, betaChanges, conflicts := core.Reconcile(
+		nil, alphaSnapshot.Content, betaSnapshot.Content,
+		core.SynchronizationMode_SynchronizationModeTwoWaySafe,
+		core.TypeChangeMode_TypeChangeModePropagate,
+		0, 0,
+		nil,
+	)
+	if len(alphaChanges) != 0 {
+		t.Error("reconciliation generated alpha changes")
+	} else if len(conflicts) != 0 {
+		t.Error("reconciliation generated conflicts")
+	}
+
+	// Perform staging on beta.
+	paths, digests := core.TransitionDependencies(betaChanges)
+	if len(paths) > 0 {
+		paths, _, receiver, _, err := beta.Stage(paths, digests)
+		if err != nil {
+			t.Fatal("unable to perform staging:", err)
+		} else if len(paths) != 0 || receiver != nil {
+			t.Fatal("empty files required transmission")
+		}
+	}
+
+	// Perform the transition on beta.
+	if _, problems, missingFiles, err := beta.Transition(context.Background(), betaChanges, false); err != nil {
+		t.Fatal("unable to perform transition:", err)
+	} else if len(problems) > 0 {
+		t.Fatal("transition encountered problems:", problems[0].Error)
+	} else if missingFiles {
+		t.Fatal("transition reported missing files")
+	}
+
+	// Verify that beta contains exactly the expected content.
+	expected := map[string]bool{
+		"top":      true,
+		"a":        true,
+		"a/b":      true,
+		"a/b/file": true,
+		"d":        true,
+		"e":        true,
+	}
+	err = filepath.WalkDir(betaRoot, func(path string, _ fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		} else if path == betaRoot {
+			return nil
+		}
+		relative, err := filepath.Rel(betaRoot, path)
+		if err != nil {
+			return err
+		}
+		relative = filepath.ToSlash(relative)
+		if !expected[relative] {
+			t.Error("unexpected content on beta:", relative)
+		}
+		delete(expected, relative)
+		return nil
+	})
+	if err != nil {
+		t.Fatal("unable to walk beta:", err)
+	}
+	for path := range expected {
+		t.Error("expected content missing on beta:", path)
+	}
+}
+
+// TestRootExistenceModeRequire tests that an endpoint requiring its root to
+// exist fails clearly when the root is missing, both at creation and when
+// scanning, while the default mode treats a missing root as empty.
+func TestRootExistenceModeRequire(t *testing.T) {
+	// Compute a root path that doesn't exist and use an isolated data
+	// directory.
+	root := filepath.Join(t.TempDir(), "root")
+	t.Setenv("MUTAGEN_DATA_DIRECTORY", t.TempDir())
+
+	// Create configurations.
+	requireConfiguration := &synchronization.Configuration{
+		WatchMode:         synchronization.WatchMode_WatchModeNoWatch,
+		RootExistenceMode: synchronization.RootExistenceMode_RootExistenceModeRequire,
+	}
+	defaultConfiguration := &synchronization.Configuration{
+		WatchMode: synchronization.WatchMode_WatchModeNoWatch,
+	}
+
+	// Verify that endpoint creation fails with a clear error if the root is
+	// required.
+	if e, err := NewEndpoint(
+		logging.NewLogger(logging.LevelDisabled, io.Discard),
+		root,
+		"session",
+		synchronization.Version_Version1,
+		requireConfiguration,
+		true,
+	); err == nil {
+		e.Shutdown()
+		t.Fatal("endpoint creation succeeded with missing required root")
+	} else if !strings.Contains(err.Error(), "does not exist") {
+		t.Error("endpoint creation error does not indicate missing root:", err)
+	}
+
+	// Verify that the default mode treats the missing root as empty.
+	e, err := NewEndpoint(
+		logging.NewLogger(logging.LevelDisabled, io.Discard),
+		root,
+		"session",
+		synchronization.Version_Version1,
+		defaultConfiguration,
+		true,
+	)
+	if err != nil {
+		t.Fatal("unable to create endpoint with missing root in default mode:", err)
+	}
+	if snapshot, err, _ := e.Scan(context.Background(), nil, true, nil, false); err != nil {
+		t.Error("unable to scan missing root in default mode:", err)
+	} else if snapshot.Content != nil {
+		t.Error("missing root scanned with content in default mode")
+	}
+	e.Shutdown()
+
+	// Create the root and an endpoint that requires it, and defer the
+	// endpoint's shutdown.
+	if err := os.Mkdir(root, 0700); err != nil {
+		t.Fatal("unable to create root:", err)
+	}
+	e, err = NewEndpoint(
+		logging.NewLogger(logging.LevelDisabled, io.Discard),
+		root,
+		"session",
+		synchronization.Version_Version1,
+		requireConfiguration,
+		true,
+	)
+	if err != nil {
+		t.Fatal("unable to create endpoint with existing required root:", err)
+	}
+	defer e.Shutdown()
+
+	// Verify that scanning succeeds while the root exists.
+	if _, err, _ := e.Scan(context.Background(), nil, true, nil, false); err != nil {
+		t.Fatal("unable to scan existing required root:", err)
+	}
+
+	// Remove the root and verify that scanning fails with a clear error and no
+	// retry recommendation.
+	if err := os.Remove(root); err != nil {
+		t.Fatal("unable to remove root:", err)
+	}
+	if _, err, tryAgain := e.Scan(context.Background(), nil, true, nil, false); err == nil {
+		t.Error("scan succeeded with missing required root")
+	} else if !strings.Contains(err.Error(), "does not exist") {
+		t.Error("scan error does not indicate missing root:", err)
+	} else if tryAgain {
+		t.Error("scan recommended retry for missing required root")
+	}
+}
+
+// TestMaximumRecheckPathsFallback tests that exceeding the maximum re-check path
+// count during an accelerated scan triggers a full scan, both for recursive
+// watching and for scoped scans with poll-based watching.
+func TestMaximumRecheckPathsFallback(t *testing.T) {
+	// Use an isolated data directory.
+	t.Setenv("MUTAGEN_DATA_DIRECTORY", t.TempDir())
+
+	// Set up test cases.
+	testCases := []struct {
+		// description is a description of the test case.
+		description string
+		// watchMode is the reified watch mode to simulate.
+		watchMode reifiedWatchMode
+		// maximumRecheckPaths is the maximum re-check path count.
+		maximumRecheckPaths uint64
+		// expectFullScan indicates whether or not a full scan is expected.
+		expectFullScan bool
+	}{
+		{"recursive unlimited", reifiedWatchModeRecursive, 0, false},
+		{"recursive within limit", reifiedWatchModeRecursive, 2, false},
+		{"recursive exceeding limit", reifiedWatchModeRecursive, 1, true},
+		{"poll unlimited", reifiedWatchModePoll, 0, false},
+		{"poll exceeding limit", reifiedWatchModePoll, 1, true},
+	}
+
+	// Process test cases.
+	for _, testCase := range testCases {
+		// Create a root with two subdirectories.
+		root := t.TempDir()
+		for _, path := range []string{"checked/a", "checked/b", "unchecked/c"} {
+			if err := os.MkdirAll(filepath.Join(root, filepath.Dir(path)), 0700); err != nil {
+				t.Fatalf("%s: unable to create directory: %v", testCase.description, err)
+			} else if err := os.WriteFile(filepath.Join(root, path), []byte(path), 0600); err != nil {
+				t.Fatalf("%s: unable to create file: %v", testCase.description, err)
+			}
+		}
+
+		// Create the endpoint.
+		configuration := &synchronization.Configuration{
+			WatchMode:           synchronization.WatchMode_WatchModeNoWatch,
+			MaximumRecheckPaths: testCase.maximumRecheckPaths,
+		}
+		created, err := NewEndpoint(
+			logging.NewLogger(logging.LevelDisabled, io.Discard),
+			root,
+			"session",
+			synchronization.Version_Version1,
+			configuration,
+			true,
+		)
+		if err != nil {
+			t.Fatalf("%s: unable to create endpoint: %v", testCase.description, err)
+		}
+		e := created.(*endpoint)
+
+		// Perform a baseline scan.
+		if _, err, _ := e.Scan(context.Background(), nil, true, nil, false); err != nil {
+			e.Shutdown()
+			t.Fatalf("%s: unable to perform baseline scan: %v", testCase.description, err)
+		}
+
+		// Create content that's only visible to a full scan.
+		if err := os.WriteFile(filepath.Join(root, "unchecked", "new"), nil, 0600); err != nil {
+			e.Shutdown()
+			t.Fatalf("%s: unable to create file: %v", testCase.description, err)
+		}
+
+		// Simulate acceleration with two re-check paths in the checked
+		// subdirectory.
+		var scope []string
+		e.accelerate = true
+		e.watchMode = testCase.watchMode
+		if testCase.watchMode == reifiedWatchModeRecursive {
+			e.recheckPaths = map[string]bool{"checked/a": true, "checked/b": true}
+		} else {
+			scope = []string{"checked/a", "checked/b"}
+		}
+
+		// Perform an accelerated scan and check whether or not the new content
+		// was seen.
+		snapshot, err, _ := e.Scan(context.Background(), nil, false, scope, false)
+		e.Shutdown()
+		if err != nil {
+			t.Fatalf("%s: unable to perform accelerated scan: %v", testCase.description, err)
+		}
+		_, seen := snapshot.Content.Contents["unchecked"].Contents["new"]
+		if seen != testCase.expectFullScan {
+			t.Errorf("%s: new content visibility does not match expected: %t != %t",
+				testCase.description, seen, testCase.expectFullScan,
+			)
+		}
+		if testCase.watchMode == reifiedWatchModeRecursive && len(e.recheckPaths) != 0 {
+			t.Errorf("%s: re-check paths not reset after scan", testCase.description)
+		}
+	}
+}
+
+// TestOverlaySynchronization tests that an endpoint operating in overlay mode
+// presents the merged view of its overlay base and synchronization root, that
+// changes land in the synchronization root, and that the overlay base is never
+// modified.
+func TestOverlaySynchronization(t *testing.T) {
+	// Create the overlay base and record its content.
+	t.Setenv("MUTAGEN_DATA_DIRECTORY", t.TempDir())
+	baseRoot := t.TempDir()
+	baseContents := map[string]string{
+		"kept":             "base kept",
+		"modified":         "base original",
+		"removed":          "base removed",
+		"directory/nested": "base nested",
+	}
+	for path, content := range baseContents {
+		fullPath := filepath.Join(baseRoot, filepath.FromSlash(path))
+		if err := os.MkdirAll(filepath.Dir(fullPath), 0700); err != nil {
+			t.Fatal("unable to create base directory:", err)
+		} else if err := os.WriteFile(fullPath, []byte(content), 0600); err != nil {
+			t.Fatal("unable to create base file:", err)
+		}
+	}
+
+	// Create alpha with the base content plus modifications.
+	alphaRoot := t.TempDir()
+	alphaContents := map[string]string{
+		"kept":              "base kept",
+		"modified":          "alpha modified",
+		"created":           "alpha created",
+		"directory/nested":  "base nested",
+		"directory/created": "alpha nested",
+	}
+	for path, content := range alphaContents {
+		fullPath := filepath.Join(alphaRoot, filepath.FromSlash(path))
+		if err := os.MkdirAll(filepath.Dir(fullPath), 0700); err != nil {
+			t.Fatal("unable to create alpha directory:", err)
+		} else if err := os.WriteFile(fullPath, []byte(content), 0600); err != nil {
+			t.Fatal("unable to create alpha file:", err)
+		}
+	}
+
+	// Create endpoints and defer their shutdown. Beta's synchronization root
+	// doesn't exist initially and should be created as needed.
+	betaRoot := filepath.Join(t.TempDir(), "upper")
+	configuration := &synchronization.Configuration{
+		WatchMode: synchronization.WatchMode_WatchModeNoWatch,
+	}
+	alpha, err := NewEndpoint(
+		logging.NewLogger(logging.LevelDisabled, io.Discard),
+		alphaRoot,
+		"session",
+		synchronization.Version_Version1,
+		configuration,
+		true,
+	)
+	if err != nil {
+		t.Fatal("unable to create alpha endpoint:", err)
+	}
+	defer alpha.Shutdown()
+	beta, err := NewEndpoint(
+		logging.NewLogger(logging.LevelDisabled, io.Discard),
+		betaRoot,
+		"session",
+		synchronization.Version_Version1,
+		&synchronization.Configuration{
+			WatchMode:   synchronization.WatchMode_WatchModeNoWatch,
+			OverlayBase: baseRoot,
+		},
+		false,
+	)
+	if err != nil {
+		t.Fatal("unable to create beta endpoint:", err)
+	}
+	defer beta.Shutdown()
+
+	// Scan both endpoints and verify that beta presents the base content.
+	alphaSnapshot, err, _ := alpha.Scan(context.Background(), nil, true, nil, false)
+	if err != nil {
+		t.Fatal("unable to scan alpha:", err)
+	}
+	betaSnapshot, err, _ := beta.Scan(context.Background(), nil, true, nil, false)
+	if err != nil {
+		t.Fatal("unable to scan beta:", err)
+	} else if betaSnapshot.Files != uint64(len(baseContents)) {
+		t.Error("beta file count does not match base:", betaSnapshot.Files, "!=", len(baseContents))
+	}
+
+	// Compute the transitions and stage the files that differ from the base.
+	transitions := core.Diff(betaSnapshot.Content, alphaSnapshot.Content)
+	paths := []string{"created", "directory/created", "modified"}
+	digests := make([][]byte, len(paths))
+	for p, path := range paths {
+		entry := alphaSnapshot.Content
+		for _, component := range strings.Split(path, "/") {
+			entry = entry.Contents[component]
+		}
+		digests[p] = entry.Digest
+	}
+	if paths, signatures, receiver, _, err := beta.Stage(paths, digests); err != nil {
+		t.Fatal("unable to perform staging:", err)
+	} else if receiver != nil {
+		if err := alpha.Supply(paths, signatures, receiver); err != nil {
+			t.Fatal("unable to supply files:", err)
+		}
+	}
+
+	// Perform the transitions.
+	results, problems, missingFiles, err := beta.Transition(context.Background(), transitions, false)
+	if err != nil {
+		t.Fatal("unable to perform transition:", err)
+	} else if len(problems) > 0 {
+		t.Fatal("transition encountered problems:", problems[0].Error)
+	} else if missingFiles {
+		t.Fatal("transition reported missing files")
+	}
+	for r, result := range results {
+		if !result.Equal(transitions[r].New, true) {
+			t.Error("transition result does not match expected at path:", transitions[r].Path)
+		}
+	}
+
+	// Verify that beta's merged view now matches alpha.
+	betaSnapshot, err, _ = beta.Scan(context.Background(), nil, true, nil, false)
+	if err != nil {
+		t.Fatal("unable to rescan beta:", err)
+	} else if !betaSnapshot.Content.Equal(alphaSnapshot.Content, true) {
+		t.Error("beta merged view does not match alpha")
+	}
+
+	// Verify that the changes landed in beta's synchronization root.
+	upperContents := map[string]string{
+		"modified":          "alpha modified",
+		"created":           "alpha created",
+		"directory/created": "alpha nested",
+	}
+	for path, expected := range upperContents {
+		if content, err := os.ReadFile(filepath.Join(betaRoot, filepath.FromSlash(path))); err != nil {
+			t.Error("unable to read upper file:", err)
+		} else if string(content) != expected {
+			t.Error("upper file content incorrect at path:", path)
+		}
+	}
+	if info, err := os.Lstat(filepath.Join(betaRoot, core.OverlayWhiteoutPrefix+"removed")); err != nil {
+		t.Error("whiteout marker not created:", err)
+	} else if !info.IsDir() {
+		t.Error("whiteout marker is not a directory")
+	}
+	for _, path := range []string{"kept", "directory/nested"} {
+		if _, err := os.Lstat(filepath.Join(betaRoot, filepath.FromSlash(path))); !os.IsNotExist(err) {
+			t.Error("unmodified base content copied to upper layer at path:", path)
+		}
+	}
+
+	// Verify that the overlay base is untouched.
+	var baseFiles int
+	err = filepath.WalkDir(baseRoot, func(path string, entry fs.DirEntry, err error) error {
+		if err != nil || entry.IsDir() {
+			return err
+		}
+		baseFiles++
+		relative, err := filepath.Rel(baseRoot, path)
+		if err != nil {
+			return err
+		}
+		if expected, ok := baseContents[filepath.ToSlash(relative)]; !ok {
+			t.Error("unexpected content in base:", relative)
+		} else if content, err := os.ReadFile(path); err != nil {
+			return err
+		} else if string(content) != expected {
+			t.Error("base content modified at path:", relative)
+		}
+		return nil
+	})
+	if err != nil {
+		t.Fatal("unable to walk base:", err)
+	} else if baseFiles != len(baseContents) {
+		t.Error("base file count changed:", baseFiles, "!=", len(baseContents))
+	}
+}
+
+// TestScanSharing tests that scan results published by one endpoint are reused
+// by another endpoint targeting the same synchronization root and that shared
+// results are invalidated when the root changes.
+func TestScanSharing(t *testing.T) {
+	// Use an isolated data directory.
+	t.Setenv("MUTAGEN_DATA_DIRECTORY", t.TempDir())
+
+	// Create a root with some content.
+	root := t.TempDir()
+	if err := os.WriteFile(filepath.Join(root, "file"), []byte("content"), 0600); err != nil {
+		t.Fatal("unable to create file:", err)
+	}
+
+	// Create endpoints for the root. We disable watching so that we can
+	// simulate the availability of acceleration and the observation of
+	// watcher events deterministically.
+	create := func(session string, mode synchronization.ScanSharingMode) *endpoint {
+		created, err := NewEndpoint(
+			logging.NewLogger(logging.LevelDisabled, io.Discard),
+			root,
+			session,
+			synchronization.Version_Version1,
+			&synchronization.Configuration{
+				WatchMode:       synchronization.WatchMode_WatchModeNoWatch,
+				ScanSharingMode: mode,
+			},
+			true,
+		)
+		if err != nil {
+			t.Fatal("unable to create endpoint:", err)
+		}
+		return created.(*endpoint)
+	}
+	publisher := create("publisher", synchronization.ScanSharingMode_ScanSharingModeEnabled)
+	defer publisher.Shutdown()
+	consumer := create("consumer", synchronization.ScanSharingMode_ScanSharingModeEnabled)
+	defer consumer.Shutdown()
+	isolated := create("isolated", synchronization.ScanSharingMode_ScanSharingModeDisabled)
+	defer isolated.Shutdown()
+
+	// Ensure that the endpoints share results only if sharing is enabled.
+	if publisher.sharedScanKey == "" || publisher.sharedScanKey != consumer.sharedScanKey {
+		t.Fatal("endpoints with sharing enabled don't share a scan key")
+	} else if isolated.sharedScanKey != "" {
+		t.Fatal("endpoint with sharing disabled has a scan key")
+	}
+
+	// Perform a scan on the publisher while simulating the availability of
+	// acceleration, which will publish its results.
+	publish := func() *core.Snapshot {
+		publisher.accelerate = true
+		snapshot, err, _ := publisher.Scan(context.Background(), nil, true, nil, false)
+		if err != nil {
+			t.Fatal("unable to perform publisher scan:", err)
+		}
+		return snapshot
+	}
+	published := publish()
+
+	// Ensure that the consumer reuses the published results.
+	if snapshot, err, _ := consumer.Scan(context.Background(), nil, false, nil, false); err != nil {
+		t.Fatal("unable to perform consumer scan:", err)
+	} else if snapshot != published {
+		t.Error("consumer did not reuse shared snapshot")
+	} else if consumer.cache != publisher.cache {
+		t.Error("consumer did not reuse shared cache")
+	}
+
+	// Ensure that the endpoint with sharing disabled doesn't reuse results.
+	if snapshot, err, _ := isolated.Scan(context.Background(), nil, false, nil, false); err != nil {
+		t.Fatal("unable to perform isolated scan:", err)
+	} else if snapshot == published {
+		t.Error("endpoint with sharing disabled reused shared snapshot")
+	}
+
+	// Modify the root and simulate the publisher observing the modification
+	// via a watcher event. Ensure that the consumer rescans.
+	if err := os.WriteFile(filepath.Join(root, "new"), []byte("new"), 0600); err != nil {
+		t.Fatal("unable to create file:", err)
+	}
+	publisher.scanGeneration.Add(1)
+	if snapshot, err, _ := consumer.Scan(context.Background(), nil, false, nil, false); err != nil {
+		t.Fatal("unable to perform consumer scan:", err)
+	} else if snapshot == published {
+		t.Error("consumer reused stale shared snapshot")
+	} else if _, ok := snapshot.Content.Contents["new"]; !ok {
+		t.Error("consumer scan did not observe modification")
+	}
+
+	// Republish results and ensure that a transition performed by the
+	// consumer invalidates them.
+	published = publish()
+	if snapshot, err, _ := consumer.Scan(context.Background(), nil, false, nil, false); err != nil {
+		t.Fatal("unable to perform consumer scan:", err)
+	} else if snapshot != published {
+		t.Fatal("consumer did not reuse republished snapshot")
+	}
+	change := &core.Change{Path: "directory", New: &core.Entry{Kind: core.EntryKind_Directory}}
+	if _, problems, _, err := consumer.Transition(context.Background(), []*core.Change{change}, false); err != nil {
+		t.Fatal("unable to perform transition:", err)
+	} else if len(problems) > 0 {
+		t.Fatal("transition encountered problems:", problems[0].Error)
+	}
+	if sharedScans.lookup(publisher.sharedScanKey) != nil {
+		t.Error("shared scan results not invalidated by transition")
+	}
+
+	// Republish results and ensure that they're withdrawn when the publisher
+	// shuts down.
+	publish()
+	publisher.Shutdown()
+	if sharedScans.lookup(publisher.sharedScanKey) != nil {
+		t.Error("shared scan results not withdrawn at shutdown")
+	}
+}
+
+// TestScanSharingMaximumFileSize tests that endpoints that differ only in their
+// maximum file size don't share scan results.
+func TestScanSharingMaximumFileSize(t *testing.T) {
+	// Use an isolated data directory.
+	t.Setenv("MUTAGEN_DATA_DIRECTORY", t.TempDir())
+
+	// Create a root with some content.
+	root := t.TempDir()
+	if err := os.WriteFile(filepath.Join(root, "file"), []byte("content"), 0600); err != nil {
+		t.Fatal("unable to create file:", err)
+	}
+
+	// Create endpoints for the root that differ only in their maximum file
+	// size. We disable watching so that we can simulate the availability of
+	// acceleration deterministically.
+	create := func(session string, maximumFileSize uint64) *endpoint {
+		created, err := NewEndpoint(
+			logging.NewLogger(logging.LevelDisabled, io.Discard),
+			root,
+			session,
+			synchronization.Version_Version1,
+			&synchronization.Configuration{
+				WatchMode:       synchronization.WatchMode_WatchModeNoWatch,
+				ScanSharingMode: synchronization.ScanSharingMode_ScanSharingModeEnabled,
+				MaximumFileSize: maximumFileSize,
+			},
+			true,
+		)
+		if err != nil {
+			t.Fatal("unable to create endpoint:", err)
+		}
+		return created.(*endpoint)
+	}
+	publisher := create("publisher", 0)
+	defer publisher.Shutdown()
+	limited := create("limited", 1)
+	defer limited.Shutdown()
+
+	// Ensure that the endpoints have distinct scan keys.
+	if publisher.sharedScanKey == "" || limited.sharedScanKey == "" {
+		t.Fatal("endpoints with sharing enabled lack scan keys")
+	} else if publisher.sharedScanKey == limited.sharedScanKey {
+		t.Fatal("endpoints with differing maximum file sizes share a scan key")
+	}
+
+	// Publish scan results from the unlimited endpoint.
+	publisher.accelerate = true
+	published, err, _ := publisher.Scan(context.Background(), nil, true, nil, false)
+	if err != nil {
+		t.Fatal("unable to perform publisher scan:", err)
+	}
+
+	// Ensure that the limited endpoint doesn't reuse the published results and
+	// that it applies its maximum file size.
+	if snapshot, err, _ := limited.Scan(context.Background(), nil, false, nil, false); err != nil {
+		t.Fatal("unable to perform limited scan:", err)
+	} else if snapshot == published {
+		t.Error("endpoint with differing maximum file size reused shared snapshot")
+	} else if entry := snapshot.Content.Contents["file"]; entry == nil || entry.Kind != core.EntryKind_Oversized {
+		t.Error("limited scan did not record file as oversized")
+	}
+}
+
+// TestAccelerationStatus tests that acceleration unavailability is only
+// reported once it's been sustained for the reporting threshold and that it's
+// cleared once acceleration becomes available.
+func TestAccelerationStatus(t *testing.T) {
+	// Create the status tracker and verify that nothing is initially reported.
+	var status accelerationStatus
+	if reason := status.report(time.Now(), 0); reason != "" {
+		t.Error("acceleration unavailability reported initially:", reason)
+	}
+
+	// Record repeated failures and verify that the most recent reason is only
+	// reported once the threshold has been exceeded.
+	status.unavailable("recursive watching failed: first")
+	status.unavailable("watch event overflow")
+	if reason := status.report(time.Now(), time.Hour); reason != "" {
+		t.Error("acceleration unavailability reported before threshold:", reason)
+	}
+	if reason := status.report(time.Now().Add(2*time.Hour), time.Hour); reason != "watch event overflow" {
+		t.Error("acceleration unavailability reason does not match expected:", reason)
+	}
+
+	// Verify that subsequent failures don't reset the unavailability period.
+	status.unavailable("recursive watching failed: second")
+	if reason := status.report(time.Now().Add(2*time.Hour), time.Hour); reason != "recursive watching failed: second" {
+		t.Error("acceleration unavailability reason does not match expected:", reason)
+	}
+
+	// Record availability and verify that nothing is reported.
+	status.available()
+	if reason := status.report(time.Now().Add(2*time.Hour), time.Hour); reason != "" {
+		t.Error("acceleration unavailability reported after availability:", reason)
+	}
+}
+
+// TestAccelerationUnavailableReporting tests that persistent recursive watch
+// failures cause acceleration unavailability to be reported.
+func TestAccelerationUnavailableReporting(t *testing.T) {
+	// Skip this test if recursive watching isn't supported.
+	if !watching.RecursiveWatchingSupported {
+		t.Skip("recursive watching not supported")
+	}
+
+	// Create an endpoint without watching.
+	t.Setenv("MUTAGEN_DATA_DIRECTORY", t.TempDir())
+	e, err := NewEndpoint(
+		logging.NewLogger(logging.LevelDisabled, io.Discard),
+		t.TempDir(),
+		"session",
+		synchronization.Version_Version1,
+		&synchronization.Configuration{
+			WatchMode: synchronization.WatchMode_WatchModeNoWatch,
+		},
+		false,
+	)
+	if err != nil {
+		t.Fatal("unable to create endpoint:", err)
+	}
+	defer e.Shutdown()
+	local := e.(*endpoint)
+
+	// Verify that nothing is reported before any watch failures.
+	if reason := local.AccelerationUnavailable(); reason != "" {
+		t.Fatal("acceleration unavailability reported before watching:", reason)
+	}
+
+	// Allow acceleration, but force recursive watch establishment to fail
+	// persistently by using an invalid queue size, and start watching.
+	local.accelerationAllowed = true
+	local.watchQueueSize = 0
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan struct{})
+	go func() {
+		local.watchRecursive(ctx, 1)
+		close(done)
+	}()
+	defer func() {
+		cancel()
+		<-done
+	}()
+
+	// Wait for acceleration unavailability to be recorded. Rather than waiting
+	// for the reporting delay to elapse, we evaluate the status as of a time
+	// beyond the reporting delay.
+	deadline := time.Now().Add(10 * time.Second)
+	var reason string
+	for reason == "" && time.Now().Before(deadline) {
+		time.Sleep(10 * time.Millisecond)
+		reason = local.accelerationStatus.report(
+			time.Now().Add(accelerationUnavailableReportingDelay),
+			accelerationUnavailableReportingDelay,
+		)
+	}
+	if reason == "" {
+		t.Fatal("acceleration unavailability not recorded")
+	} else if !strings.Contains(reason, "unable to establish recursive watch") {
+		t.Error("acceleration unavailability reason does not match expected:", reason)
+	}
+
+	// Verify that unavailability isn't reported before the reporting delay.
+	if reason := local.AccelerationUnavailable(); reason != "" {
+		t.Error("acceleration unavailability reported before reporting delay:", reason)
+	}
+}
+
+// TestDeferredScan tests that deferred scans return the last returned snapshot
+// updated to reflect subsequent transitions (without observing other
+// modifications), that they permit subsequent transitions, and that regular
+// scans observe all modifications.
+func TestDeferredScan(t *testing.T) {
+	// Create a root with some initial content.
+	root := t.TempDir()
+	if err := os.WriteFile(filepath.Join(root, "existing"), nil, 0600); err != nil {
+		t.Fatal("unable to create initial content:", err)
+	}
+
+	// Create an endpoint without watching.
+	t.Setenv("MUTAGEN_DATA_DIRECTORY", t.TempDir())
+	e, err := NewEndpoint(
+		logging.NewLogger(logging.LevelDisabled, io.Discard),
+		root,
+		"session",
+		synchronization.Version_Version1,
+		&synchronization.Configuration{
+			WatchMode: synchronization.WatchMode_WatchModeNoWatch,
+		},
+		false,
+	)
+	if err != nil {
+		t.Fatal("unable to create endpoint:", err)
+	}
+	defer e.Shutdown()
+
+	// Verify that a deferred scan without a previous snapshot performs a
+	// regular scan.
+	snapshot, err, _ := e.Scan(context.Background(), nil, false, nil, true)
+	if err != nil {
+		t.Fatal("unable to perform initial scan:", err)
+	} else if snapshot.Content.Contents["existing"] == nil {
+		t.Fatal("initial scan did not observe existing content")
+	}
+
+	// Create a directory via transition.
+	created := &core.Entry{Kind: core.EntryKind_Directory}
+	transitions := []*core.Change{{Path: "created", New: created}}
+	if _, problems, _, err := e.Transition(context.Background(), transitions, false); err != nil {
+		t.Fatal("unable to perform transition:", err)
+	} else if len(problems) > 0 {
+		t.Fatal("transition encountered problems:", problems[0].Error)
+	}
+
+	// Create content on disk via other means.
+	if err := os.WriteFile(filepath.Join(root, "external"), nil, 0600); err != nil {
+		t.Fatal("unable to create external content:", err)
+	}
+
+	// Perform a deferred scan and verify that it reflects the transition but
+	// not the external modification.
+	snapshot, err, _ = e.Scan(context.Background(), nil, false, nil, true)
+	if err != nil {
+		t.Fatal("unable to perform deferred scan:", err)
+	} else if snapshot.Content.Contents["created"] == nil {
+		t.Error("deferred scan does not reflect transition")
+	} else if snapshot.Content.Contents["external"] != nil {
+		t.Error("deferred scan unexpectedly observed external modification")
+	}
+
+	// Verify that a transition is permitted after the deferred scan.
+	transitions = []*core.Change{{Path: "created", Old: created}}
+	if _, problems, _, err := e.Transition(context.Background(), transitions, false); err != nil {
+		t.Fatal("unable to perform transition after deferred scan:", err)
+	} else if len(problems) > 0 {
+		t.Fatal("transition after deferred scan encountered problems:", problems[0].Error)
+	}
+
+	// Perform a regular scan and verify that it observes all modifications.
+	snapshot, err, _ = e.Scan(context.Background(), nil, false, nil, false)
+	if err != nil {
+		t.Fatal("unable to perform regular scan:", err)
+	} else if snapshot.Content.Contents["created"] != nil {
+		t.Error("regular scan observed removed content")
+	} else if snapshot.Content.Contents["external"] == nil {
+		t.Error("regular scan did not observe external modification")
+	}
+}
+
+// TestPartialScan tests that partial scanning only observes modifications
+// within the scan subpaths (and at paths modified by transitions), while full
+// scans observe all modifications.
+func TestPartialScan(t *testing.T) {
+	// Create a root with some initial content.
+	root := t.TempDir()
+	for _, path := range []string{"sub", "other"} {
+		if err := os.Mkdir(filepath.Join(root, path), 0700); err != nil {
+			t.Fatal("unable to create initial directory:", err)
+		}
+		if err := os.WriteFile(filepath.Join(root, path, "file"), nil, 0600); err != nil {
+			t.Fatal("unable to create initial file:", err)
+		}
+	}
+
+	// Create an endpoint without watching that's restricted to a subpath.
+	t.Setenv("MUTAGEN_DATA_DIRECTORY", t.TempDir())
+	e, err := NewEndpoint(
+		logging.NewLogger(logging.LevelDisabled, io.Discard),
+		root,
+		"session",
+		synchronization.Version_Version1,
+		&synchronization.Configuration{
+			WatchMode:    synchronization.WatchMode_WatchModeNoWatch,
+			ScanSubpaths: []string{"sub"},
+		},
+		false,
+	)
+	if err != nil {
+		t.Fatal("unable to create endpoint:", err)
+	}
+	defer e.Shutdown()
+
+	// Define a helper to look up content in a snapshot.
+	lookup := func(snapshot *core.Snapshot, parent, name string) *core.Entry {
+		return snapshot.Content.Contents[parent].GetContents()[name]
+	}
+
+	// Perform an initial scan, which will be a full scan since no baseline is
+	// available.
+	snapshot, err, _ := e.Scan(context.Background(), nil, false, nil, false)
+	if err != nil {
+		t.Fatal("unable to perform initial scan:", err)
+	} else if lookup(snapshot, "sub", "file") == nil || lookup(snapshot, "other", "file") == nil {
+		t.Fatal("initial scan did not observe initial content")
+	}
+
+	// Create content both inside and outside of the scan subpath.
+	for _, path := range []string{"sub", "other"} {
+		if err := os.WriteFile(filepath.Join(root, path, "new"), nil, 0600); err != nil {
+			t.Fatal("unable to create new content:", err)
+		}
+	}
+
+	// Perform a partial scan and verify that it only observes the content
+	// inside of the scan subpath.
+	snapshot, err, _ = e.Scan(context.Background(), nil, false, nil, false)
+	if err != nil {
+		t.Fatal("unable to perform partial scan:", err)
+	} else if lookup(snapshot, "sub", "new") == nil {
+		t.Error("partial scan did not observe content inside scan subpath")
+	} else if lookup(snapshot, "other", "new") != nil {
+		t.Error("partial scan observed content outside scan subpath")
+	} else if lookup(snapshot, "other", "file") == nil {
+		t.Error("partial scan lost content outside scan subpath")
+	}
+
+	// Create a directory outside of the scan subpath via transition and
+	// verify that a partial scan observes it.
+	transitions := []*core.Change{{Path: "other/created", New: &core.Entry{Kind: core.EntryKind_Directory}}}
+	if _, problems, _, err := e.Transition(context.Background(), transitions, false); err != nil {
+		t.Fatal("unable to perform transition:", err)
+	} else if len(problems) > 0 {
+		t.Fatal("transition encountered problems:", problems[0].Error)
+	}
+	snapshot, err, _ = e.Scan(context.Background(), nil, false, nil, false)
+	if err != nil {
+		t.Fatal("unable to perform partial scan after transition:", err)
+	} else if lookup(snapshot, "other", "created") == nil {
+		t.Error("partial scan did not observe transitioned content")
+	}
+
+	// Perform a full scan and verify that it observes all content.
+	snapshot, err, _ = e.Scan(context.Background(), nil, true, nil, false)
+	if err != nil {
+		t.Fatal("unable to perform full scan:", err)
+	} else if lookup(snapshot, "sub", "new") == nil || lookup(snapshot, "other", "new") == nil {
+		t.Error("full scan did not observe all content")
+	}
+}
+
+// TestVerify tests that verification re-hashes content without relying on the
+// endpoint's cache and without modifying the endpoint's state.
+func TestVerify(t *testing.T) {
+	// Create a root with a file.
+	root := t.TempDir()
+	path := filepath.Join(root, "file")
+	if err := os.WriteFile(path, []byte("original"), 0600); err != nil {
+		t.Fatal("unable to create file:", err)
+	}
+
+	// Create an endpoint without watching.
+	t.Setenv("MUTAGEN_DATA_DIRECTORY", t.TempDir())
+	e, err := NewEndpoint(
+		logging.NewLogger(logging.LevelDisabled, io.Discard),
+		root,
+		"session",
+		synchronization.Version_Version1,
+		&synchronization.Configuration{WatchMode: synchronization.WatchMode_WatchModeNoWatch},
+		false,
+	)
+	if err != nil {
+		t.Fatal("unable to create endpoint:", err)
+	}
+	defer e.Shutdown()
+
+	// Perform an initial scan and record the scan generation.
+	snapshot, err, _ := e.Scan(context.Background(), nil, true, nil, false)
+	if err != nil {
+		t.Fatal("unable to perform initial scan:", err)
+	}
+	original := snapshot.Content.Contents["file"]
+	generation := e.(*endpoint).ScanGeneration()
+
+	// Verify that verification matches the scan.
+	if verified, err := e.Verify(context.Background()); err != nil {
+		t.Fatal("unable to perform verification:", err)
+	} else if !verified.Content.Equal(snapshot.Content, true) {
+		t.Error("verification result does not match scan result")
+	}
+
+	// Modify the file in-place, preserving its size and modification time, to
+	// simulate silent corruption.
+	info, err := os.Stat(path)
+	if err != nil {
+		t.Fatal("unable to query file metadata:", err)
+	} else if err := os.WriteFile(path, []byte("modified"), 0600); err != nil {
+		t.Fatal("unable to modify file:", err)
+	} else if err := os.Chtimes(path, info.ModTime(), info.ModTime()); err != nil {
+		t.Fatal("unable to restore modification time:", err)
+	}
+
+	// Verify that verification detects the modification.
+	if verified, err := e.Verify(context.Background()); err != nil {
+		t.Fatal("unable to perform verification:", err)
+	} else if modified := verified.Content.Contents["file"]; modified == nil {
+		t.Error("verification did not observe file")
+	} else if modified.Equal(original, true) {
+		t.Error("verification did not detect modification")
+	}
+
+	// Verify that verification didn't modify the endpoint's state.
+	if e.(*endpoint).ScanGeneration() != generation {
+		t.Error("verification modified scan generation")
+	}
+}
+
+// TestAggregateDigestMode tests that endpoint scans compute directory aggregate
+// digests by default and only omit them if aggregate digests are disabled.
+func TestAggregateDigestMode(t *testing.T) {
+	// Use an isolated data directory.
+	t.Setenv("MUTAGEN_DATA_DIRECTORY", t.TempDir())
+
+	// Create a root with some content.
+	root := t.TempDir()
+	if err := os.Mkdir(filepath.Join(root, "directory"), 0700); err != nil {
+		t.Fatal("unable to create directory:", err)
+	}
+	if err := os.WriteFile(filepath.Join(root, "directory", "file"), []byte("content"), 0600); err != nil {
+		t.Fatal("unable to create file:", err)
+	}
+
+	// Set up test cases.
+	testCases := []struct {
+		mode     synchronization.AggregateDigestMode
+		expected bool
+	}{
+		{synchronization.AggregateDigestMode_AggregateDigestModeDefault, true},
+		{synchronization.AggregateDigestMode_AggregateDigestModeDisabled, false},
+		{synchronization.AggregateDigestMode_AggregateDigestModeEnabled, true},
+	}
+
+	// Process test cases.
+	for i, testCase := range testCases {
+		// Create an endpoint and perform a scan.
+		e, err := NewEndpoint(
+			logging.NewLogger(logging.LevelDisabled, io.Discard),
+			root,
+			fmt.Sprintf("session%d", i),
+			synchronization.Version_Version1,
+			&synchronization.Configuration{
+				WatchMode:           synchronization.WatchMode_WatchModeNoWatch,
+				AggregateDigestMode: testCase.mode,
+			},
+			true,
+		)
+		if err != nil {
+			t.Fatalf("test index %d: unable to create endpoint: %v", i, err)
+		}
+		snapshot, err, _ := e.Scan(context.Background(), nil, true, nil, false)
+		e.Shutdown()
+		if err != nil {
+			t.Fatalf("test index %d: unable to perform scan: %v", i, err)
+		}
+
+		// Verify the presence or absence of aggregate digests.
+		for _, directory := range []*core.Entry{snapshot.Content, snapshot.Content.Contents["directory"]} {
+			if present := directory.AggregateDigest != nil; present != testCase.expected {
+				t.Errorf("test index %d: aggregate digest presence (%t) does not match expected (%t)",
+					i, present, testCase.expected,
+				)
+			}
+		}
+	}
+}
+
+// TestPollScanAggregateDigests tests that independent full scans of an
+// unchanged root (as performed by poll-based watching) yield snapshots with
+// matching root aggregate digests, allowing their comparison to short-circuit.
+func TestPollScanAggregateDigests(t *testing.T) {
+	// Use an isolated data directory.
+	t.Setenv("MUTAGEN_DATA_DIRECTORY", t.TempDir())
+
+	// Create a root with some content.
+	root := t.TempDir()
+	if err := os.Mkdir(filepath.Join(root, "directory"), 0700); err != nil {
+		t.Fatal("unable to create directory:", err)
+	}
+	if err := os.WriteFile(filepath.Join(root, "directory", "file"), []byte("content"), 0600); err != nil {
+		t.Fatal("unable to create file:", err)
+	}
+
+	// Create an endpoint with the default configuration.
+	created, err := NewEndpoint(
+		logging.NewLogger(logging.LevelDisabled, io.Discard),
+		root,
+		"session",
+		synchronization.Version_Version1,
+		&synchronization.Configuration{
+			WatchMode: synchronization.WatchMode_WatchModeNoWatch,
+		},
+		true,
+	)
+	if err != nil {
+		t.Fatal("unable to create endpoint:", err)
+	}
+	e := created.(*endpoint)
+	defer e.Shutdown()
+
+	// Perform two full scans in the same manner as the polling loop.
+	scan := func() *core.Snapshot {
+		e.lockScanLock(context.Background())
+		defer e.unlockScanLock()
+		if err := e.scan(context.Background(), nil, nil); err != nil {
+			t.Fatal("unable to perform scan:", err)
+		}
+		return e.snapshot
+	}
+	first, second := scan(), scan()
+
+	// Verify that the snapshots share no content but carry matching root
+	// aggregate digests and compare equal.
+	if first.Content == second.Content {
+		t.Fatal("independent scans share root content")
+	} else if first.Content.AggregateDigest == nil {
+		t.Fatal("scan did not compute root aggregate digest")
+	} else if !bytes.Equal(first.Content.AggregateDigest, second.Content.AggregateDigest) {
+		t.Error("root aggregate digests of unchanged root differ")
+	} else if !second.Equal(first) {
+		t.Error("snapshots of unchanged root compared unequal")
+	}
+}
+
+// TestSlowDirectoryListingThreshold tests that the slow directory listing
+// threshold is computed from the endpoint configuration.
+func TestSlowDirectoryListingThreshold(t *testing.T) {
+	// Use an isolated data directory.
+	t.Setenv("MUTAGEN_DATA_DIRECTORY", t.TempDir())
+
+	// Set up test cases.
+	testCases := []struct {
+		threshold uint32
+		expected  time.Duration
+	}{
+		{0, 100 * time.Millisecond},
+		{250, 250 * time.Millisecond},
+	}
+
+	// Process test cases.
+	for i, testCase := range testCases {
+		e, err := NewEndpoint(
+			logging.NewLogger(logging.LevelDisabled, io.Discard),
+			t.TempDir(),
+			fmt.Sprintf("session%d", i),
+			synchronization.Version_Version1,
+			&synchronization.Configuration{
+				WatchMode:                     synchronization.WatchMode_WatchModeNoWatch,
+				SlowDirectoryListingThreshold: testCase.threshold,
+			},
+			true,
+		)
+		if err != nil {
+			t.Fatalf("test index %d: unable to create endpoint: %v", i, err)
+		}
+		threshold := e.(*endpoint).slowDirectoryListingThreshold
+		e.Shutdown()
+		if threshold != testCase.expected {
+			t.Errorf("test index %d: threshold does not match expected: %v != %v",
+				i, threshold, testCase.expected,
+			)
+		}
+	}
+}
@@ -3,6 +3,7 @@ package local
 import (
 	"fmt"
 	"path/filepath"
+	"strings"
 
 	"github.com/mutagen-io/mutagen/pkg/filesystem"
 )
@@ -65,6 +66,27 @@ func pathForMutagenStagingRoot(session string, alpha bool) (string, error) {
 	return filepath.Join(stagingDataPath, stagingRootName), nil
 }
 
+// pathForContentCache computes the path to the content cache root for the
+// given hashing algorithm name, digest sampling threshold, and digest length.
+// Content caches are shared between sessions, but are partitioned by all digest
+// parameters since content is keyed by digest. It ensures that the content
+// cache subdirectory of the Mutagen data directory exists, but it does not
+// create the content cache root itself.
+func pathForContentCache(algorithm string, samplingThreshold uint64, digestLength uint32) (string, error) {
+	// Compute the path to the content cache root parent and ensure that it
+	// exists.
+	contentDataPath, err := filesystem.Mutagen(true, filesystem.MutagenSynchronizationContentDirectoryName)
+	if err != nil {
+		return "", fmt.Errorf("unable to create content cache data directory: %w", err)
+	}
+
+	// Compute the content cache root name.
+	contentCacheRootName := fmt.Sprintf("%s-%d-%d", algorithm, samplingThreshold, digestLength)
+
+	// Compute the combined path.
+	return filepath.Join(contentDataPath, contentCacheRootName), nil
+}
+
 // pathForNeighboringStagingRoot computes the path to the staging root which
 // neighbors the synchronization root for the given root, session identifier,
 // and endpoint. It does not create the directory or any parent directories.
@@ -111,3 +133,30 @@ func pathForInternalStagingRoot(root, session string, alpha bool) (string, error
 	// Compute the path to the staging root.
 	return filepath.Join(root, stagingRootName), nil
 }
+
+// rootRelativeStagingPath computes the synchronization-root-relative path of
+// the specified staging root (in forward-slash-separated format) if the staging
+// root resides within the synchronization root. If it doesn't, then an empty
+// string is returned.
+func rootRelativeStagingPath(root, stagingRoot string) string {
+	// Compute the relative path, treating any failure as an indication that
+	// the staging root doesn't reside within the synchronization root.
+	relative, err := filepath.Rel(root, stagingRoot)
+	if err != nil || relative == "." || relative == ".." ||
+		strings.HasPrefix(relative, ".."+string(filepath.Separator)) {
+		return ""
+	}
+
+	// Convert the path to a synchronization-root-relative path.
+	return filepath.ToSlash(relative)
+}
+
+// pathIsWithin determines whether or not a path is equal to or nested within a
+// parent path, using the specified path separator. An empty parent path is not
+// considered to contain any paths.
+func pathIsWithin(path, parent string, separator byte) bool {
+	if parent == "" || !strings.HasPrefix(path, parent) {
+		return false
+	}
+	return len(path) == len(parent) || path[len(parent)] == separator
+}
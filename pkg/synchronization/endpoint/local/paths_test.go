@@ -1,3 +1,97 @@
 package local
 
-// TODO: Implement.
+import (
+	"path/filepath"
+	"testing"
+)
+
+// TODO: Implement tests for additional functionality.
+
+// TestRootRelativeStagingPath tests rootRelativeStagingPath.
+func TestRootRelativeStagingPath(t *testing.T) {
+	// Compute a root for testing.
+	root := filepath.Join(t.TempDir(), "root")
+
+	// Set up test cases.
+	testCases := []struct {
+		stagingRoot string
+		expected    string
+	}{
+		{root, ""},
+		{filepath.Dir(root), ""},
+		{filepath.Join(filepath.Dir(root), "staging"), ""},
+		{filepath.Join(filepath.Dir(root), "rootstaging"), ""},
+		{filepath.Join(root, "staging"), "staging"},
+		{filepath.Join(root, "data", "staging", "session-alpha"), "data/staging/session-alpha"},
+		{filepath.Join(root, "..staging"), "..staging"},
+	}
+
+	// Process test cases.
+	for i, testCase := range testCases {
+		if relative := rootRelativeStagingPath(root, testCase.stagingRoot); relative != testCase.expected {
+			t.Errorf("test index %d: relative path does not match expected: %s != %s",
+				i, relative, testCase.expected,
+			)
+		}
+	}
+}
+
+// TestPathIsWithin tests pathIsWithin.
+func TestPathIsWithin(t *testing.T) {
+	// Set up test cases.
+	testCases := []struct {
+		path     string
+		parent   string
+		expected bool
+	}{
+		{"", "", false},
+		{"a", "", false},
+		{"a", "a", true},
+		{"a/b", "a", true},
+		{"a/b/c", "a/b", true},
+		{"ab", "a", false},
+		{"a", "a/b", false},
+		{"b/a", "a", false},
+	}
+
+	// Process test cases.
+	for i, testCase := range testCases {
+		if within := pathIsWithin(testCase.path, testCase.parent, '/'); within != testCase.expected {
+			t.Errorf("test index %d: result does not match expected: %t != %t",
+				i, within, testCase.expected,
+			)
+		}
+	}
+}
+
+// TestPathForContentCachePartitioning tests that pathForContentCache partitions
+// content caches by all digest parameters.
+func TestPathForContentCachePartitioning(t *testing.T) {
+	// Use an isolated data directory.
+	t.Setenv("MUTAGEN_DATA_DIRECTORY", t.TempDir())
+
+	// Set up test cases.
+	testCases := []struct {
+		algorithm         string
+		samplingThreshold uint64
+		digestLength      uint32
+	}{
+		{"sha1", 0, 0},
+		{"sha256", 0, 0},
+		{"sha1", 1024 * 1024, 0},
+		{"sha1", 0, 8},
+		{"sha1", 1024 * 1024, 8},
+	}
+
+	// Verify that each parameter combination yields a distinct root.
+	roots := make(map[string]bool, len(testCases))
+	for i, testCase := range testCases {
+		root, err := pathForContentCache(testCase.algorithm, testCase.samplingThreshold, testCase.digestLength)
+		if err != nil {
+			t.Fatalf("test index %d: unable to compute content cache root: %v", i, err)
+		} else if roots[root] {
+			t.Errorf("test index %d: content cache root not distinct: %s", i, root)
+		}
+		roots[root] = true
+	}
+}
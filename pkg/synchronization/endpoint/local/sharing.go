@@ -0,0 +1,107 @@
+package local
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+
+	"github.com/mutagen-io/mutagen/pkg/synchronization/core"
+	"github.com/mutagen-io/mutagen/pkg/synchronization/core/ignore"
+)
+
+// sharedScanKey computes the key under which scan results are shared. Scan
+// results are only shared between endpoints with the same synchronization root
+// and the same values for all parameters that affect scan results, so the key
+// encodes the root and those parameters. The parameters should be scalar values
+// or slices thereof (rather than pointers), since they're encoded using their
+// Go-syntax representations.
+func sharedScanKey(root string, parameters ...any) string {
+	var key strings.Builder
+	fmt.Fprintf(&key, "%q", root)
+	for _, parameter := range parameters {
+		fmt.Fprintf(&key, "\x00%#v", parameter)
+	}
+	return key.String()
+}
+
+// sharedScan is a set of scan results published by an endpoint for reuse by
+// other endpoints in the same process. Its contents are treated as immutable.
+type sharedScan struct {
+	// publisher is the endpoint that published the scan results.
+	publisher *endpoint
+	// root is the synchronization root that was scanned.
+	root string
+	// generation is the publisher's scan generation at the time that the
+	// snapshot was generated.
+	generation uint64
+	// snapshot is the snapshot generated by the scan.
+	snapshot *core.Snapshot
+	// cache is the cache generated by the scan.
+	cache *core.Cache
+	// ignoreCache is the ignore cache generated by the scan.
+	ignoreCache ignore.IgnoreCache
+}
+
+// current indicates whether or not the shared snapshot still reflects the
+// contents of the synchronization root, at least to the same extent that the
+// publisher's own accelerated scans would. Shared scan results are only
+// published while the publisher is using accelerated scanning, and any watcher
+// event, transition, or scan on the publisher invalidates its scan generation,
+// so a snapshot is current if and only if the publisher's scan generation is
+// unchanged. Failure of the publisher's watcher and shutdown of the publisher
+// are handled by withdrawing its published results entirely.
+func (s *sharedScan) current() bool {
+	return s.publisher.scanGeneration.Load() == s.generation
+}
+
+// sharedScanRegistry is a registry of shared scan results.
+type sharedScanRegistry struct {
+	// scansLock serializes access to scans.
+	scansLock sync.Mutex
+	// scans maps shared scan keys to the most recently published results.
+	scans map[string]*sharedScan
+}
+
+// sharedScans is the process-wide shared scan registry.
+var sharedScans = &sharedScanRegistry{scans: make(map[string]*sharedScan)}
+
+// publish registers scan results under the specified key, replacing any
+// previously published results.
+func (r *sharedScanRegistry) publish(key string, scan *sharedScan) {
+	r.scansLock.Lock()
+	r.scans[key] = scan
+	r.scansLock.Unlock()
+}
+
+// lookup returns the scan results published under the specified key, if any.
+// The results may no longer be current.
+func (r *sharedScanRegistry) lookup(key string) *sharedScan {
+	r.scansLock.Lock()
+	defer r.scansLock.Unlock()
+	return r.scans[key]
+}
+
+// withdraw removes any scan results published by the specified endpoint.
+func (r *sharedScanRegistry) withdraw(publisher *endpoint) {
+	r.scansLock.Lock()
+	defer r.scansLock.Unlock()
+	for key, scan := range r.scans {
+		if scan.publisher == publisher {
+			delete(r.scans, key)
+		}
+	}
+}
+
+// invalidate removes any scan results published for the specified
+// synchronization root. It's used by endpoints that modify a synchronization
+// root, since other endpoints targeting the same root may not have observed the
+// modifications yet.
+func (r *sharedScanRegistry) invalidate(root string) {
+	r.scansLock.Lock()
+	defer r.scansLock.Unlock()
+	for key, scan := range r.scans {
+		if scan.root == root {
+			delete(r.scans, key)
+		}
+	}
+}
@@ -1,7 +1,11 @@
 package local
 
 import (
+	"io"
+	"time"
+
 	"github.com/mutagen-io/mutagen/pkg/synchronization/core"
+	"github.com/mutagen-io/mutagen/pkg/synchronization/endpoint/local/content"
 	"github.com/mutagen-io/mutagen/pkg/synchronization/rsync"
 )
 
@@ -16,15 +20,34 @@ type stager interface {
 	// Sink, or Provide, even after a previously failed staging session for
 	// which Finalize was not invoked.
 	Initialize() error
+	// Reap removes expired content that has persisted from previous (failed)
+	// staging operations. It's called at most once per synchronization cycle,
+	// after Initialize and before the cycle's first call to Contains, so
+	// content that the stager reports as available (including content staged
+	// by deferred staging operations within the same cycle) is never reaped
+	// before the transition that uses it.
+	Reap()
 	// Contains returns whether or not the stager contains the specified
 	// content.
 	Contains(path string, digest []byte) (bool, error)
 	// Sinker is the interface that the stager must implement to receive files
-	// over an rsync transmission stream.
+	// over an rsync transmission stream. The sinks that it returns must also
+	// implement rsync.Suspender.
 	rsync.Sinker
+	// Resumer is the interface that the stager must implement to allow
+	// interrupted receptions to be resumed, including across restarts.
+	rsync.Resumer
 	// Provider is the interface that the stager must implement to provide files
 	// for transition operations after staging is complete.
 	core.Provider
+	// Oversized returns the number of Provide calls that have failed since the
+	// last call to Finalize because the corresponding file was refused staging
+	// for exceeding the maximum staging file size.
+	Oversized() uint64
+	// Placeholders returns the paths for which placeholder files have been
+	// provided (in lieu of oversized content) since the last call to Finalize,
+	// mapped to the digests of the content that they represent.
+	Placeholders() map[string][]byte
 	// Finalize informs the stager that staging has completed and that no
 	// further Contains, Sink, or Provide calls will be made until after the
 	// next call to Initialize. Implementations should use this method to clean
@@ -32,3 +55,70 @@ type stager interface {
 	// resources.
 	Finalize() error
 }
+
+// signatureCacheEntry is a cached rsync signature for a base file.
+type signatureCacheEntry struct {
+	// signature is the signature of the base file.
+	signature *rsync.Signature
+	// modificationTime is the modification time of the base file at the time
+	// that the signature was computed.
+	modificationTime time.Time
+}
+
+// contentCachingSinker is an rsync.Sinker that wraps a stager and inserts
+// successfully staged content into a content cache.
+type contentCachingSinker struct {
+	// stager is the underlying stager.
+	stager stager
+	// cache is the content cache.
+	cache *content.Cache
+}
+
+// Sink implements rsync.Sinker.Sink.
+func (s *contentCachingSinker) Sink(path string, digest []byte, expectedSize uint64) (io.WriteCloser, error) {
+	sink, err := s.stager.Sink(path, digest, expectedSize)
+	if err != nil {
+		return nil, err
+	}
+	return &contentCachingSink{sink, s, path, digest}, nil
+}
+
+// Resume implements rsync.Resumer.Resume.
+func (s *contentCachingSinker) Resume(path string, digest []byte) (io.ReadSeekCloser, error) {
+	return s.stager.Resume(path, digest)
+}
+
+// contentCachingSink implements io.WriteCloser for contentCachingSinker's Sink
+// method.
+type contentCachingSink struct {
+	// WriteCloser is the underlying stager sink.
+	io.WriteCloser
+	// sinker is the parent sinker.
+	sinker *contentCachingSinker
+	// path is the path associated with the sink.
+	path string
+	// digest is the expected digest of the content.
+	digest []byte
+}
+
+// Suspend implements rsync.Suspender.Suspend. Suspended content isn't inserted
+// into the content cache.
+func (s *contentCachingSink) Suspend() error {
+	if suspender, ok := s.WriteCloser.(rsync.Suspender); ok {
+		return suspender.Suspend()
+	}
+	return s.WriteCloser.Close()
+}
+
+// Close implements io.Closer.Close. Once the underlying sink has successfully
+// committed (and verified) the content, the content is inserted into the
+// content cache. Insertion is best-effort, so any failure is ignored.
+func (s *contentCachingSink) Close() error {
+	if err := s.WriteCloser.Close(); err != nil {
+		return err
+	}
+	if staged, err := s.sinker.stager.Provide(s.path, s.digest); err == nil {
+		s.sinker.cache.Insert(s.digest, staged)
+	}
+	return nil
+}
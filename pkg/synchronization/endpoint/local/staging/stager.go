@@ -1,22 +1,95 @@
 package staging
 
 import (
+	"errors"
+	"fmt"
 	"hash"
 	"io"
+	"io/fs"
+	"maps"
+	"sync"
+	"time"
 
+	"github.com/mutagen-io/mutagen/pkg/logging"
+	"github.com/mutagen-io/mutagen/pkg/synchronization"
 	"github.com/mutagen-io/mutagen/pkg/synchronization/endpoint/local/staging/store"
 )
 
+var (
+	// errOversizedFile is returned by Sink when a file is refused because it
+	// exceeds the maximum staging file size.
+	errOversizedFile = errors.New("file exceeds maximum staging file size")
+)
+
+// verificationError is the error returned by Provide for content that was
+// rejected because it failed digest verification. It unwraps to
+// fs.ErrNotExist since the verified content is absent from the stager, which is
+// usually the result of the file being modified during transmission.
+type verificationError struct{}
+
+// Error implements error.Error.
+func (verificationError) Error() string {
+	return "staged content failed digest verification"
+}
+
+// Unwrap returns fs.ErrNotExist.
+func (verificationError) Unwrap() error {
+	return fs.ErrNotExist
+}
+
 // Stager is an implementation of local.stager that uses a content-addressable
 // store to stage files.
 type Stager struct {
+	// logger is the underlying logger.
+	logger *logging.Logger
 	// store is the stager's underlying store.
 	store *store.Store
+	// maximumFileSize is the maximum size of an individual file that the
+	// stager will stage without consulting oversizedFileMode.
+	maximumFileSize uint64
+	// oversizedFileMode is the behavior to use for files exceeding
+	// maximumFileSize. It must be a non-default value.
+	oversizedFileMode synchronization.OversizedFileMode
+	// maximumStagedContentAge is the maximum age of staged content that has
+	// persisted from previous (failed) staging operations before it's reaped
+	// by Reap. A zero value disables reaping.
+	maximumStagedContentAge time.Duration
+	// refusedLock serializes access to refused, oversizedProvided, and
+	// placeholders.
+	refusedLock sync.Mutex
+	// refused maps paths whose content was refused (either due to their size
+	// or due to failed digest verification) to the error that should be
+	// reported when attempting to provide them.
+	refused map[string]error
+	// oversizedProvided is the number of Provide calls that have failed due to
+	// files having been refused staging for their size since the last call to
+	// Finalize.
+	oversizedProvided uint64
+	// placeholders maps paths for which placeholders have been provided since
+	// the last call to Finalize to the digests of the content that they
+	// represent.
+	placeholders map[string][]byte
 }
 
 // NewStager creates a new stager.
-func NewStager(root string, hideRoot bool, maximumFileSize uint64, hasherFactory func() hash.Hash) *Stager {
-	return &Stager{store.NewStore(root, hideRoot, maximumFileSize, hasherFactory)}
+func NewStager(
+	logger *logging.Logger,
+	root string,
+	hideRoot bool,
+	maximumFileSize uint64,
+	oversizedFileMode synchronization.OversizedFileMode,
+	maximumStagedContentAge time.Duration,
+	hasherFactory func() hash.Hash,
+) *Stager {
+	return &Stager{
+		logger:                  logger,
+		store:                   store.NewStore(root, hideRoot, hasherFactory),
+		maximumFileSize:         maximumFileSize,
+		oversizedFileMode:       oversizedFileMode,
+		maximumStagedContentAge: maximumStagedContentAge,
+		refused:                 make(map[string]error),
+		placeholders:            make(map[string][]byte),
+	}
 }
 
 // Initialize implements local.stager.Initialize.
@@ -24,34 +97,144 @@ func (s *Stager) Initialize() error {
 	return s.store.Initialize()
 }
 
+// Reap implements local.stager.Reap. If a maximum staged content age is set,
+// then staged content exceeding that age is reaped. Reaped content that's still
+// needed will simply be restaged. A reaping failure isn't fatal, since it
+// doesn't affect the correctness of staging, so it's only logged.
+func (s *Stager) Reap() {
+	if s.maximumStagedContentAge > 0 {
+		if reaped, err := s.store.Reap(s.maximumStagedContentAge); err != nil {
+			s.logger.Warn("Unable to reap expired staged content:", err)
+		} else if reaped > 0 {
+			s.logger.Debugf("Reaped %d expired staged file(s)", reaped)
+		}
+	}
+}
+
 // Contains implements local.stager.Contains.
 func (s *Stager) Contains(path string, digest []byte) (bool, error) {
 	return s.store.Contains(path, digest)
 }
 
-// Sink implements rsync.Sinker.Sink.
-func (s *Stager) Sink(path string) (io.WriteCloser, error) {
-	storage, err := s.store.Allocate()
+// refuse records that content for the specified path was refused.
+func (s *Stager) refuse(path string, err error) {
+	s.refusedLock.Lock()
+	s.refused[path] = err
+	s.refusedLock.Unlock()
+}
+
+// Sink implements rsync.Sinker.Sink. Files whose expected size exceeds the
+// maximum staging file size are handled according to the oversized file mode.
+// If they're refused, then no storage is allocated for them, so they're never
+// partially staged. Content is verified against the expected digest when the
+// sink is closed and is only committed if verification succeeds.
+func (s *Stager) Sink(path string, digest []byte, expectedSize uint64) (io.WriteCloser, error) {
+	// Determine the storage size limit, refusing the file if necessary.
+	maximumSize := s.maximumFileSize
+	if expectedSize > s.maximumFileSize {
+		if s.oversizedFileMode != synchronization.OversizedFileMode_OversizedFileModeWarn {
+			s.refuse(path, fmt.Errorf("%w (%d > %d bytes)", errOversizedFile, expectedSize, s.maximumFileSize))
+			return nil, errOversizedFile
+		}
+		s.logger.Warnf("Staging file (%s) that exceeds maximum staging file size (%d > %d bytes)",
+			path, expectedSize, s.maximumFileSize,
+		)
+		maximumSize = expectedSize
+	}
+
+	// Clear any previous refusal for the path.
+	s.refusedLock.Lock()
+	delete(s.refused, path)
+	s.refusedLock.Unlock()
+
+	// Allocate storage.
+	storage, err := s.store.Allocate(maximumSize)
 	if err != nil {
 		return nil, err
 	}
-	return &Sink{path, storage}, nil
+	return &Sink{s, path, digest, storage}, nil
 }
 
-// Provide implements core.Provider.Provide.
+// Resume implements rsync.Resumer.Resume. It provides the partial content
+// retained by a suspended sink for the path and digest, if any.
+func (s *Stager) Resume(path string, digest []byte) (io.ReadSeekCloser, error) {
+	// Open the partial content. We have to be careful not to return a typed
+	// nil pointer as a non-nil interface.
+	partial, err := s.store.Partial(path, digest)
+	if err != nil || partial == nil {
+		return nil, err
+	}
+	return partial, nil
+}
+
+// Provide implements core.Provider.Provide. If content for the path was
+// refused, then an error indicating the reason is returned, unless the content
+// was refused for its size and the oversized file mode is placeholder mode, in
+// which case an empty placeholder file is provided instead.
 func (s *Stager) Provide(path string, digest []byte) (string, error) {
+	// Check if content for the path was refused. We still check the store in
+	// that case, because the content may have been staged by a previous
+	// (failed) staging operation.
+	s.refusedLock.Lock()
+	refusal, refused := s.refused[path]
+	s.refusedLock.Unlock()
+	if refused {
+		if available, _ := s.store.Contains(path, digest); !available {
+			if errors.Is(refusal, errOversizedFile) &&
+				s.oversizedFileMode == synchronization.OversizedFileMode_OversizedFileModePlaceholder {
+				placeholder, err := s.store.Placeholder(path, digest)
+				if err != nil {
+					return "", err
+				}
+				s.refusedLock.Lock()
+				s.placeholders[path] = digest
+				s.refusedLock.Unlock()
+				return placeholder, nil
+			} else if errors.Is(refusal, errOversizedFile) {
+				s.refusedLock.Lock()
+				s.oversizedProvided++
+				s.refusedLock.Unlock()
+			}
+			return "", refusal
+		}
+	}
+
+	// Compute the path.
 	return s.store.Path(path, digest)
 }
 
+// Oversized implements local.stager.Oversized.
+func (s *Stager) Oversized() uint64 {
+	s.refusedLock.Lock()
+	defer s.refusedLock.Unlock()
+	return s.oversizedProvided
+}
+
+// Placeholders implements local.stager.Placeholders.
+func (s *Stager) Placeholders() map[string][]byte {
+	s.refusedLock.Lock()
+	defer s.refusedLock.Unlock()
+	return maps.Clone(s.placeholders)
+}
+
 // Finalize implements local.stager.Finalize.
 func (s *Stager) Finalize() error {
+	s.refusedLock.Lock()
+	clear(s.refused)
+	s.oversizedProvided = 0
+	clear(s.placeholders)
+	s.refusedLock.Unlock()
 	return s.store.Finalize()
 }
 
 // Sink implements io.WriterCloser for Stager's Sink method.
 type Sink struct {
+	// stager is the parent stager.
+	stager *Stager
 	// path is the path associated with the sink.
 	path string
+	// digest is the expected digest of the content.
+	digest []byte
 	// storage is the underlying file storage.
 	storage *store.Storage
 }
@@ -61,7 +244,18 @@ func (s *Sink) Write(data []byte) (int, error) {
 	return s.storage.Write(data)
 }
 
-// Close implements io.Closer.Close.
+// Suspend implements rsync.Suspender.Suspend. The content received thus far is
+// retained so that it can be provided by Resume.
+func (s *Sink) Suspend() error {
+	return s.storage.Suspend(s.path, s.digest)
+}
+
+// Close implements io.Closer.Close. If the content fails digest verification,
+// then it is discarded and the path is recorded as refused.
 func (s *Sink) Close() error {
-	return s.storage.Commit(s.path)
+	err := s.storage.Commit(s.path, s.digest)
+	if errors.Is(err, store.ErrDigestMismatch) {
+		s.stager.refuse(s.path, verificationError{})
+	}
+	return err
 }
@@ -1,3 +1,199 @@
 package staging
 
-// TODO: Implement.
+import (
+	"bytes"
+	"crypto/sha1"
+	"io"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/mutagen-io/mutagen/pkg/logging"
+	"github.com/mutagen-io/mutagen/pkg/synchronization"
+	"github.com/mutagen-io/mutagen/pkg/synchronization/hashing"
+)
+
+// testStage stages the specified content at the specified path, returning its
+// digest.
+func testStage(t *testing.T, stager *Stager, path, content string) []byte {
+	t.Helper()
+	digest := sha1.Sum([]byte(content))
+	sink, err := stager.Sink(path, digest[:], uint64(len(content)))
+	if err != nil {
+		t.Fatal("unable to create sink:", err)
+	}
+	if _, err := io.WriteString(sink, content); err != nil {
+		t.Fatal("unable to write content:", err)
+	} else if err = sink.Close(); err != nil {
+		t.Fatal("unable to commit content:", err)
+	}
+	return digest[:]
+}
+
+// TestStagerReapsExpiredContent tests that Reap reaps staged content that
+// exceeds the maximum staged content age while keeping recent content and that
+// Initialize doesn't reap content.
+func TestStagerReapsExpiredContent(t *testing.T) {
+	// Create a stager with a maximum staged content age.
+	stager := NewStager(
+		logging.NewLogger(logging.LevelDisabled, io.Discard),
+		filepath.Join(t.TempDir(), "staging"),
+		false,
+		1024,
+		synchronization.OversizedFileMode_OversizedFileModeSkip,
+		time.Hour,
+		sha1.New,
+	)
+
+	// Initialize the stager and stage content.
+	if err := stager.Initialize(); err != nil {
+		t.Fatal("unable to initialize stager:", err)
+	}
+	oldDigest := testStage(t, stager, "old", "old content")
+	recentDigest := testStage(t, stager, "recent", "recent content")
+
+	// Age the old content beyond the maximum staged content age.
+	oldPath, err := stager.Provide("old", oldDigest)
+	if err != nil {
+		t.Fatal("unable to compute old content path:", err)
+	}
+	oldTime := time.Now().Add(-2 * time.Hour)
+	if err := os.Chtimes(oldPath, oldTime, oldTime); err != nil {
+		t.Fatal("unable to age old content:", err)
+	}
+
+	// Simulate a deferred staging operation by re-initializing the stager
+	// without finalizing it and verify that the old content wasn't reaped.
+	if err := stager.Initialize(); err != nil {
+		t.Fatal("unable to re-initialize stager:", err)
+	}
+	if contains, err := stager.Contains("old", oldDigest); err != nil {
+		t.Fatal("unable to query old content:", err)
+	} else if !contains {
+		t.Error("old content reaped by initialization")
+	}
+
+	// Simulate the start of a new synchronization cycle after a failed
+	// transition by reaping.
+	stager.Reap()
+
+	// Verify that the old content was reaped and the recent content was kept.
+	if contains, err := stager.Contains("old", oldDigest); err != nil {
+		t.Fatal("unable to query old content:", err)
+	} else if contains {
+		t.Error("old content not reaped")
+	}
+	if contains, err := stager.Contains("recent", recentDigest); err != nil {
+		t.Fatal("unable to query recent content:", err)
+	} else if !contains {
+		t.Error("recent content reaped")
+	}
+
+	// Finalize the stager.
+	if err := stager.Finalize(); err != nil {
+		t.Fatal("unable to finalize stager:", err)
+	}
+}
+
+// TestStagerWithoutMaximumAgeKeepsContent tests that Reap doesn't reap staged
+// content if no maximum staged content age is set.
+func TestStagerWithoutMaximumAgeKeepsContent(t *testing.T) {
+	// Create a stager without a maximum staged content age.
+	stager := NewStager(
+		logging.NewLogger(logging.LevelDisabled, io.Discard),
+		filepath.Join(t.TempDir(), "staging"),
+		false,
+		1024,
+		synchronization.OversizedFileMode_OversizedFileModeSkip,
+		0,
+		sha1.New,
+	)
+
+	// Initialize the stager, stage content, and age it.
+	if err := stager.Initialize(); err != nil {
+		t.Fatal("unable to initialize stager:", err)
+	}
+	digest := testStage(t, stager, "old", "old content")
+	path, err := stager.Provide("old", digest)
+	if err != nil {
+		t.Fatal("unable to compute content path:", err)
+	}
+	oldTime := time.Now().Add(-24 * time.Hour)
+	if err := os.Chtimes(path, oldTime, oldTime); err != nil {
+		t.Fatal("unable to age content:", err)
+	}
+
+	// Re-initialize the stager, reap, and verify that the content was kept.
+	if err := stager.Initialize(); err != nil {
+		t.Fatal("unable to re-initialize stager:", err)
+	}
+	stager.Reap()
+	if contains, err := stager.Contains("old", digest); err != nil {
+		t.Fatal("unable to query content:", err)
+	} else if !contains {
+		t.Error("content reaped without maximum staged content age")
+	}
+
+	// Finalize the stager.
+	if err := stager.Finalize(); err != nil {
+		t.Fatal("unable to finalize stager:", err)
+	}
+}
+
+// TestStagerTruncatedDigests tests that content is verified against truncated
+// expected digests using full digests and that digests truncated below the
+// minimum truncated digest length are rejected.
+func TestStagerTruncatedDigests(t *testing.T) {
+	// Create and initialize a stager.
+	stager := NewStager(
+		logging.NewLogger(logging.LevelDisabled, io.Discard),
+		filepath.Join(t.TempDir(), "staging"),
+		false,
+		1024,
+		synchronization.OversizedFileMode_OversizedFileModeSkip,
+		0,
+		sha1.New,
+	)
+	if err := stager.Initialize(); err != nil {
+		t.Fatal("unable to initialize stager:", err)
+	}
+
+	// Create a function to attempt staging with a particular digest.
+	const content = "content"
+	stage := func(path string, digest []byte) error {
+		sink, err := stager.Sink(path, digest, uint64(len(content)))
+		if err != nil {
+			return err
+		}
+		if _, err := io.WriteString(sink, content); err != nil {
+			sink.Close()
+			return err
+		}
+		return sink.Close()
+	}
+	digest := sha1.Sum([]byte(content))
+
+	// Verify that content staged with a truncated digest is verified and can be
+	// queried using that digest.
+	if err := stage("truncated", digest[:hashing.MinimumTruncatedDigestLength]); err != nil {
+		t.Fatal("unable to stage content with truncated digest:", err)
+	}
+	if contains, err := stager.Contains("truncated", digest[:hashing.MinimumTruncatedDigestLength]); err != nil {
+		t.Fatal("unable to query truncated content:", err)
+	} else if !contains {
+		t.Error("content staged with truncated digest not found")
+	}
+
+	// Verify that mismatched truncated digests are rejected.
+	mismatched := bytes.Clone(digest[:hashing.MinimumTruncatedDigestLength])
+	mismatched[0]++
+	if stage("mismatched", mismatched) == nil {
+		t.Error("content staged with mismatched truncated digest")
+	}
+
+	// Verify that overly truncated digests are rejected.
+	if stage("short", digest[:hashing.MinimumTruncatedDigestLength-1]) == nil {
+		t.Error("content staged with overly truncated digest")
+	}
+}
@@ -2,6 +2,7 @@ package store
 
 import (
 	"bufio"
+	"bytes"
 	"encoding/hex"
 	"errors"
 	"fmt"
@@ -10,12 +11,15 @@ import (
 	"io/fs"
 	"os"
 	"path/filepath"
+	"strings"
 	"sync"
+	"time"
 
 	"github.com/zeebo/xxh3"
 
 	"github.com/mutagen-io/mutagen/pkg/filesystem"
 	"github.com/mutagen-io/mutagen/pkg/stream"
+	"github.com/mutagen-io/mutagen/pkg/synchronization/hashing"
 )
 
 var (
@@ -25,29 +29,41 @@ var (
 	// errDigestEmpty is returned when an empty digest is provided or a hash
 	// function generates an empty digest.
 	errDigestEmpty = errors.New("digest empty")
+	// ErrDigestMismatch is returned when committed content does not match its
+	// expected digest.
+	ErrDigestMismatch = errors.New("content does not match expected digest")
 )
 
 const (
 	// storageWriteBufferSize is the buffer size to use for storage writes.
 	storageWriteBufferSize = 64 * 1024
+	// storagePattern is the pattern used for temporary storage files.
+	storagePattern = "storage"
+	// partialSuffix is the suffix used for partial content retained by
+	// suspended storage. Partial content is stored alongside committed content
+	// in prefix directories, so it's subject to the same reaping.
+	partialSuffix = ".partial"
+	// placeholderSuffix is the suffix used for placeholder files created by
+	// Placeholder. Placeholders are stored alongside committed content, but
+	// their distinct naming ensures that they're never mistaken for committed
+	// content.
+	placeholderSuffix = ".placeholder"
 )
 
 // Store implements content-addressable storage for staging files. In addition
 // to standard CAS addressing, it adds an additional level of addressing based
 // on the expected path for content within the synchronization root. After
-// Initialize is called, the Allocate, Contains, and Path methods may be invoked
-// concurrently, and the Storage instances returned by Allocate may be used
-// concurrently. Initialize and Finalize may never be called concurrently with
-// any other methods or while outstanding Storage instances (not finalized with
-// Commit or Discard) exist.
+// Initialize is called, the Allocate, Contains, Partial, Path, and Placeholder
+// methods may be invoked concurrently, and the Storage instances returned by
+// Allocate may be used concurrently. Initialize and Finalize may never be
+// called concurrently with any other methods or while outstanding Storage
+// instances (not finalized with Commit, Suspend, or Discard) exist.
 type Store struct {
 	// root is the path to the directory used for storage.
 	root string
 	// hidden indicates whether or not the root storage directory should be
 	// hidden on the filesystem.
 	hidden bool
-	// maximumFileSize is the maximum allowed size for a single storage file.
-	maximumFileSize uint64
 	// writeBufferPool is a pool of bufio.Writer for buffering storage writes.
 	// When not in use, their writer is set to io.Discard.
 	writeBufferPool sync.Pool
@@ -71,11 +87,10 @@ type Store struct {
 }
 
 // NewStore creates a new store instance with the specified parameters.
-func NewStore(root string, hidden bool, maximumFileSize uint64, contentHasherFactory func() hash.Hash) *Store {
+func NewStore(root string, hidden bool, contentHasherFactory func() hash.Hash) *Store {
 	return &Store{
-		root:            root,
-		hidden:          hidden,
-		maximumFileSize: maximumFileSize,
+		root:   root,
+		hidden: hidden,
 		writeBufferPool: sync.Pool{
 			New: func() any {
 				return bufio.NewWriterSize(io.Discard, storageWriteBufferSize)
@@ -181,15 +196,16 @@ func (s *Store) Initialize() error {
 	return nil
 }
 
-// Allocate allocates temporary storage for receiving data.
-func (s *Store) Allocate() (*Storage, error) {
+// Allocate allocates temporary storage for receiving data. The storage will
+// refuse writes that would cause its size to exceed maximumSize.
+func (s *Store) Allocate(maximumSize uint64) (*Storage, error) {
 	// Verify that the store is initialized.
 	if !s.initialized {
 		return nil, errStoreUninitialized
 	}
 
 	// Create a temporary storage file in the staging root.
-	storage, err := os.CreateTemp(s.root, "storage")
+	storage, err := os.CreateTemp(s.root, storagePattern)
 	if err != nil {
 		return nil, fmt.Errorf("unable to create temporary storage file: %w", err)
 	}
@@ -207,11 +223,12 @@ func (s *Store) Allocate() (*Storage, error) {
 
 	// Success.
 	return &Storage{
-		store:   s,
-		storage: storage,
-		hasher:  hasher,
-		writer:  writer,
-		buffer:  buffer,
+		store:       s,
+		storage:     storage,
+		hasher:      hasher,
+		writer:      writer,
+		buffer:      buffer,
+		maximumSize: maximumSize,
 	}, nil
 }
 
@@ -287,6 +304,103 @@ func (s *Store) Contains(path string, digest []byte) (bool, error) {
 	return true, nil
 }
 
+// ensurePrefix ensures that the prefix directory with the specified byte value
+// and name exists. This method is safe for concurrent invocation.
+func (s *Store) ensurePrefix(prefixByte byte, prefix string) error {
+	s.prefixLock.Lock()
+	defer s.prefixLock.Unlock()
+	if !s.prefixExists[prefixByte] {
+		if err := os.Mkdir(filepath.Join(s.root, prefix), 0700); err != nil {
+			return fmt.Errorf("unable to create prefix directory (%s): %w", prefix, err)
+		}
+		s.prefixExists[prefixByte] = true
+	}
+	return nil
+}
+
+// Partial opens the partial content retained by suspended storage (see
+// Storage.Suspend) for the specified path and digest. If no partial content
+// exists, then it returns nil (and no error). The caller is responsible for
+// closing the result.
+func (s *Store) Partial(path string, digest []byte) (*os.File, error) {
+	// Verify that the store is initialized.
+	if !s.initialized {
+		return nil, errStoreUninitialized
+	}
+
+	// Verify that the digest is non-empty.
+	if len(digest) == 0 {
+		return nil, errDigestEmpty
+	}
+
+	// If the corresponding prefix directory doesn't exist, then there can't be
+	// any partial content.
+	s.prefixLock.RLock()
+	prefixExists := s.prefixExists[digest[0]]
+	s.prefixLock.RUnlock()
+	if !prefixExists {
+		return nil, nil
+	}
+
+	// Compute the partial content path.
+	target, _ := s.target(path, digest)
+
+	// Open the partial content, if any.
+	partial, err := os.Open(target + partialSuffix)
+	if err != nil {
+		if errors.Is(err, fs.ErrNotExist) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("unable to open partial content: %w", err)
+	}
+
+	// Success.
+	return partial, nil
+}
+
+// Placeholder creates an empty placeholder file to stand in for the content
+// with the specified path and digest, returning the path to the placeholder.
+// Any existing placeholder for the path and digest is replaced. Placeholders
+// aren't considered by Contains, so they never satisfy staging requirements.
+func (s *Store) Placeholder(path string, digest []byte) (string, error) {
+	// Verify that the store is initialized.
+	if !s.initialized {
+		return "", errStoreUninitialized
+	}
+
+	// Verify that the digest is non-empty.
+	if len(digest) == 0 {
+		return "", errDigestEmpty
+	}
+
+	// Compute the placeholder path and ensure that its prefix directory exists.
+	target, prefix := s.target(path, digest)
+	if err := s.ensurePrefix(digest[0], prefix); err != nil {
+		return "", err
+	}
+	placeholder := target + placeholderSuffix
+
+	// Create the placeholder.
+	file, err := os.OpenFile(placeholder, os.O_WRONLY|os.O_CREATE|os.O_TRUNC, 0600)
+	if err != nil {
+		return "", fmt.Errorf("unable to create placeholder: %w", err)
+	} else if err = file.Close(); err != nil {
+		return "", fmt.Errorf("unable to close placeholder: %w", err)
+	}
+
+	// Success.
+	return placeholder, nil
+}
+
+// partialSize returns the size of the partial content at the specified partial
+// content path, or zero if no partial content exists.
+func partialSize(partial string) uint64 {
+	if metadata, err := os.Lstat(partial); err == nil && metadata.Mode().IsRegular() {
+		return uint64(metadata.Size())
+	}
+	return 0
+}
+
 // Path provides the storage path for the specified addressing parameters. It
 // does not verify that the content exists. Callers should instead verify
 // existence via Contains or by storing the specified content.
@@ -308,6 +422,80 @@ func (s *Store) Path(path string, digest []byte) (string, error) {
 	return target, nil
 }
 
+// reapDirectory removes files in the specified directory with modification
+// times before the cutoff, returning the number of files removed. If
+// storageOnly is true, then only temporary storage files are considered.
+func reapDirectory(directory string, cutoff time.Time, storageOnly bool) (uint64, error) {
+	// Read the directory contents.
+	contents, err := os.ReadDir(directory)
+	if err != nil {
+		return 0, fmt.Errorf("unable to read directory contents: %w", err)
+	}
+
+	// Remove expired files.
+	var reaped uint64
+	for _, content := range contents {
+		if !content.Type().IsRegular() {
+			continue
+		} else if storageOnly && !strings.HasPrefix(content.Name(), storagePattern) {
+			continue
+		}
+		if metadata, err := content.Info(); err != nil {
+			if errors.Is(err, fs.ErrNotExist) {
+				continue
+			}
+			return reaped, fmt.Errorf("unable to query file metadata: %w", err)
+		} else if !metadata.ModTime().Before(cutoff) {
+			continue
+		}
+		if err := os.Remove(filepath.Join(directory, content.Name())); err != nil && !errors.Is(err, fs.ErrNotExist) {
+			return reaped, fmt.Errorf("unable to remove expired file: %w", err)
+		}
+		reaped++
+	}
+
+	// Success.
+	return reaped, nil
+}
+
+// Reap removes content from the store (including orphaned temporary storage)
+// that was last modified more than maximumAge ago, returning the number of
+// files removed. Since content modification times aren't updated after commit,
+// the age of committed content is the time since it was staged. This method is
+// subject to the same concurrency restrictions as Initialize and Finalize, and
+// the store must be initialized.
+func (s *Store) Reap(maximumAge time.Duration) (uint64, error) {
+	// Verify that the store is initialized.
+	if !s.initialized {
+		return 0, errStoreUninitialized
+	}
+
+	// Compute the cutoff time.
+	cutoff := time.Now().Add(-maximumAge)
+
+	// Reap orphaned temporary storage in the storage root.
+	reaped, err := reapDirectory(s.root, cutoff, true)
+	if err != nil {
+		return reaped, fmt.Errorf("unable to reap storage root: %w", err)
+	}
+
+	// Reap committed content in prefix directories.
+	for p, exists := range s.prefixExists {
+		if !exists {
+			continue
+		}
+		prefix := hex.EncodeToString([]byte{byte(p)})
+		prefixReaped, err := reapDirectory(filepath.Join(s.root, prefix), cutoff, false)
+		reaped += prefixReaped
+		if err != nil {
+			return reaped, fmt.Errorf("unable to reap prefix directory (%s): %w", prefix, err)
+		}
+	}
+
+	// Success.
+	return reaped, nil
+}
+
 // Finalize remove's the store's on-disk content and resets its internal state.
 // After calling Finalize, the Initialize method must be called before the Store
 // can be used again.
@@ -338,6 +526,9 @@ type Storage struct {
 	writer io.Writer
 	// buffer is the write buffer targeting writer.
 	buffer *bufio.Writer
+	// maximumSize is the maximum number of bytes that can be written to the
+	// file.
+	maximumSize uint64
 	// currentSize is the number of bytes that have been written to the file.
 	currentSize uint64
 }
@@ -345,7 +536,7 @@ type Storage struct {
 // Write implements io.Writer.Write for the storage.
 func (s *Storage) Write(data []byte) (int, error) {
 	// Watch for size violations.
-	if (s.store.maximumFileSize - s.currentSize) < uint64(len(data)) {
+	if (s.maximumSize - s.currentSize) < uint64(len(data)) {
 		return 0, errors.New("maximum file size reached")
 	}
 
@@ -361,9 +552,23 @@ func (s *Storage) Write(data []byte) (int, error) {
 	return n, err
 }
 
+// digestMatches determines whether or not a content digest matches an expected
+// digest. The expected digest may be a truncated snapshot digest, in which case
+// it need only match a prefix of the content digest, though it can't be
+// truncated below hashing.MinimumTruncatedDigestLength.
+func digestMatches(digest, expected []byte) bool {
+	if len(expected) < len(digest) && len(expected) < hashing.MinimumTruncatedDigestLength {
+		return false
+	}
+	return bytes.HasPrefix(digest, expected)
+}
+
 // Commit closes the storage and commits the data to the store, with an address
-// computed by a combination of the content digest and the specified path.
-func (s *Storage) Commit(path string) error {
+// computed by a combination of the expected digest and the specified path. The
+// content is verified against the expected digest (see digestMatches) before
+// being committed. If verification fails, then the data is discarded and
+// ErrDigestMismatch is returned.
+func (s *Storage) Commit(path string, expectedDigest []byte) error {
 	// Close the underlying storage.
 	if err := s.buffer.Flush(); err != nil {
 		return fmt.Errorf("unable to flush content to disk: %w", err)
@@ -387,21 +592,19 @@ func (s *Storage) Commit(path string) error {
 		return errDigestEmpty
 	}
 
-	// Compute the prefix byte and storage path for the content.
-	prefixByte := digest[0]
-	target, prefix := s.store.target(path, digest)
+	// Verify that the content matches the expected digest.
+	if !digestMatches(digest, expectedDigest) {
+		os.Remove(s.storage.Name())
+		return ErrDigestMismatch
+	}
 
-	// Ensure that the prefix directory exists.
-	s.store.prefixLock.Lock()
-	if !s.store.prefixExists[prefixByte] {
-		if err := os.Mkdir(filepath.Join(s.store.root, prefix), 0700); err != nil {
-			s.store.prefixLock.Unlock()
-			os.Remove(s.storage.Name())
-			return fmt.Errorf("unable to create prefix directory (%s): %w", prefix, err)
-		}
-		s.store.prefixExists[prefixByte] = true
+	// Compute the storage path for the content and ensure that its prefix
+	// directory exists.
+	target, prefix := s.store.target(path, expectedDigest)
+	if err := s.store.ensurePrefix(expectedDigest[0], prefix); err != nil {
+		os.Remove(s.storage.Name())
+		return err
 	}
-	s.store.prefixLock.Unlock()
 
 	// Relocate the temporary file to its target destination.
 	if err := filesystem.Rename(nil, s.storage.Name(), nil, target, true); err != nil {
@@ -409,6 +612,64 @@ func (s *Storage) Commit(path string) error {
 		return fmt.Errorf("unable to relocate storage: %w", err)
 	}
 
+	// Remove any partial content that was retained for the content, since it's
+	// no longer needed.
+	os.Remove(target + partialSuffix)
+
+	// Success.
+	return nil
+}
+
+// Suspend closes the storage and retains the data written thus far as partial
+// content for the specified path and expected digest, which can later be
+// retrieved using Store.Partial. Any existing partial content for the path and
+// digest is replaced, unless it's larger than the data written to the storage,
+// in which case the storage is discarded. If no data has been written, then
+// the storage is simply discarded.
+func (s *Storage) Suspend(path string, expectedDigest []byte) error {
+	// If no data has been written or there's no digest with which to address
+	// the partial content, then just discard the storage.
+	if s.currentSize == 0 || len(expectedDigest) == 0 {
+		return s.Discard()
+	}
+
+	// Close the underlying storage.
+	if err := s.buffer.Flush(); err != nil {
+		s.Discard()
+		return fmt.Errorf("unable to flush content to disk: %w", err)
+	} else if err = s.storage.Close(); err != nil {
+		return fmt.Errorf("unable to close underlying storage: %w", err)
+	}
+
+	// Return the buffer to the pool.
+	s.buffer.Reset(io.Discard)
+	s.store.writeBufferPool.Put(s.buffer)
+
+	// Return the hasher to the pool.
+	s.store.contentHasherPool.Put(s.hasher)
+
+	// Compute the partial content path and ensure that its prefix directory
+	// exists.
+	target, prefix := s.store.target(path, expectedDigest)
+	partial := target + partialSuffix
+	if err := s.store.ensurePrefix(expectedDigest[0], prefix); err != nil {
+		os.Remove(s.storage.Name())
+		return err
+	}
+
+	// If existing partial content is larger than what we've received, then
+	// keep it instead.
+	if partialSize(partial) > s.currentSize {
+		os.Remove(s.storage.Name())
+		return nil
+	}
+
+	// Relocate the temporary file to the partial content path.
+	if err := filesystem.Rename(nil, s.storage.Name(), nil, partial, true); err != nil {
+		os.Remove(s.storage.Name())
+		return fmt.Errorf("unable to relocate partial storage: %w", err)
+	}
+
 	// Success.
 	return nil
 }
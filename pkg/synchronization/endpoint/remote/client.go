@@ -6,11 +6,14 @@ import (
 	"errors"
 	"fmt"
 	"io"
+	"sync"
+	"time"
 
 	"google.golang.org/protobuf/proto"
 
 	"github.com/mutagen-io/mutagen/pkg/encoding"
 	"github.com/mutagen-io/mutagen/pkg/logging"
+	"github.com/mutagen-io/mutagen/pkg/multiplexing"
 	streampkg "github.com/mutagen-io/mutagen/pkg/stream"
 	"github.com/mutagen-io/mutagen/pkg/synchronization"
 	"github.com/mutagen-io/mutagen/pkg/synchronization/compression"
@@ -19,21 +22,47 @@ import (
 )
 
 // endpointClient provides an implementation of synchronization.Endpoint by
-// acting as a proxy for a remotely hosted synchronization.Endpoint.
+// acting as a proxy for a remotely hosted synchronization.Endpoint. Unlike most
+// synchronization.Endpoint implementations, its methods are safe for concurrent
+// invocation, with concurrent operations being pipelined across request
+// streams (see the ordering guarantees in transport.go).
 type endpointClient struct {
 	// logger is the underlying logger.
 	logger *logging.Logger
 	// closer close the compression resources and the control stream.
 	closer io.Closer
-	// flusher flushes the outbound control stream.
-	flusher streampkg.Flusher
-	// encoder is the control stream encoder.
-	encoder *encoding.ProtobufEncoder
-	// decoder is the control stream decoder.
-	decoder *encoding.ProtobufDecoder
+	// multiplexer is the control stream multiplexer. It is nil if the control
+	// stream isn't multiplexed.
+	multiplexer *multiplexing.Multiplexer
+	// streams is the pool of idle request streams. Operations must take a
+	// stream from the pool for their duration and return it when done.
+	streams chan *requestStream
+	// stateLock serializes access to lastSnapshotBytes,
+	// lastSnapshotGeneration, watchOverflows, accelerationUnavailable, and
+	// oversizedFiles.
+	stateLock sync.Mutex
 	// lastSnapshotBytes is the serialized form of the last snapshot received
 	// from the remote endpoint.
 	lastSnapshotBytes []byte
+	// lastSnapshotGeneration is the generation token received with the last
+	// snapshot received from the remote endpoint. A zero value indicates that
+	// no generation token is available.
+	lastSnapshotGeneration uint64
+	// clockOffset is the measured offset of the remote clock relative to the
+	// local clock.
+	clockOffset time.Duration
+	// stagingCompressionAlgorithm is the compression algorithm to negotiate
+	// for rsync operation data transmitted during staging and supplying.
+	stagingCompressionAlgorithm compression.Algorithm
+	// watchOverflows is the number of native watcher internal event overflows
+	// reported by the remote endpoint in its last scan response.
+	watchOverflows uint64
+	// accelerationUnavailable is the acceleration unavailability reason
+	// reported by the remote endpoint in its last scan response.
+	accelerationUnavailable string
+	// oversizedFiles is the number of files that the remote endpoint reported
+	// refusing to stage in its last transition response.
+	oversizedFiles uint64
 }
 
 // NewEndpoint creates a new remote synchronization.Endpoint operating over the
@@ -125,33 +154,102 @@ func NewEndpoint(
 		return nil, fmt.Errorf("remote error: %s", response.Error)
 	}
 
+	// Perform a round-trip clock exchange to measure the offset of the remote
+	// clock relative to our own.
+	sent := time.Now()
+	if err := encoder.Encode(&ClockRequest{}); err != nil {
+		return nil, fmt.Errorf("unable to encode clock request: %w", err)
+	} else if err = flusher.Flush(); err != nil {
+		return nil, fmt.Errorf("unable to transmit clock request: %w", err)
+	}
+	clockResponse := &ClockResponse{}
+	if err := decoder.Decode(clockResponse); err != nil {
+		return nil, fmt.Errorf("unable to receive clock response: %w", err)
+	} else if err = clockResponse.ensureValid(); err != nil {
+		return nil, fmt.Errorf("invalid clock response: %w", err)
+	}
+	offset, uncertainty := clockOffset(sent, time.Now(), clockResponse.Time.AsTime())
+	logger.Debugf("Measured clock offset of %s (± %s)", offset, uncertainty)
+
+	// Compute the effective clock skew mode and tolerance.
+	clockSkewMode := configuration.ClockSkewMode
+	if clockSkewMode.IsDefault() {
+		clockSkewMode = version.DefaultClockSkewMode()
+	}
+	clockSkewTolerance := configuration.ClockSkewTolerance
+	if clockSkewTolerance == 0 {
+		clockSkewTolerance = version.DefaultClockSkewTolerance()
+	}
+
+	// Check the clock offset against the tolerance.
+	if err := checkClockSkew(
+		logger,
+		offset, uncertainty,
+		clockSkewMode,
+		time.Duration(clockSkewTolerance)*time.Second,
+	); err != nil {
+		return nil, err
+	}
+
+	// Compute the effective stream concurrency.
+	streamConcurrency := configuration.StreamConcurrency
+	if streamConcurrency == 0 {
+		streamConcurrency = version.DefaultStreamConcurrency()
+	}
+
+	// Compute the effective staging compression algorithm.
+	stagingCompressionAlgorithm := configuration.StagingCompressionAlgorithm
+	if stagingCompressionAlgorithm.IsDefault() {
+		stagingCompressionAlgorithm = version.DefaultStagingCompressionAlgorithm()
+	}
+
+	// Set up the request streams. If only a single stream is required, then
+	// we use the control stream directly, otherwise we multiplex the control
+	// stream and open the required number of streams.
+	streams := make(chan *requestStream, streamConcurrency)
+	var multiplexer *multiplexing.Multiplexer
+	if streamConcurrency == 1 {
+		streams <- &requestStream{flusher: flusher, encoder: encoder, decoder: decoder}
+	} else {
+		multiplexer = multiplexing.Multiplex(&controlStreamCarrier{
+			reader:  inbound,
+			writer:  outbound,
+			flusher: flusher,
+			closer:  stream,
+		}, false, nil)
+		for i := uint32(0); i < streamConcurrency; i++ {
+			s, err := multiplexer.OpenStream(context.Background())
+			if err != nil {
+				multiplexer.Close()
+				return nil, fmt.Errorf("unable to open request stream: %w", err)
+			}
+			streams <- newMultiplexedRequestStream(&multiplexedStream{s, multiplexer})
+		}
+	}
+
 	// Success.
 	successful = true
 	return &endpointClient{
-		logger:  logger,
-		closer:  closer,
-		flusher: flusher,
-		encoder: encoder,
-		decoder: decoder,
+		logger:                      logger,
+		closer:                      closer,
+		multiplexer:                 multiplexer,
+		streams:                     streams,
+		clockOffset:                 offset,
+		stagingCompressionAlgorithm: stagingCompressionAlgorithm,
 	}, nil
 }
 
-// encodeAndFlush encodes a Protocol Buffers message using the underlying
-// encoder and then flushes the control stream.
-func (c *endpointClient) encodeAndFlush(message proto.Message) error {
-	if err := c.encoder.Encode(message); err != nil {
-		return err
-	} else if err = c.flusher.Flush(); err != nil {
-		return fmt.Errorf("message transmission failed: %w", err)
-	}
-	return nil
-}
-
 // Poll implements the Poll method for remote endpoints.
 func (c *endpointClient) Poll(ctx context.Context) error {
+	// Acquire a request stream and defer its release.
+	stream := <-c.streams
+	defer func() {
+		c.streams <- stream
+	}()
+
 	// Create and send the poll request.
 	request := &EndpointRequest{Poll: &PollRequest{}}
-	if err := c.encodeAndFlush(request); err != nil {
+	if err := stream.encodeAndFlush(request); err != nil {
 		return fmt.Errorf("unable to send poll request: %w", err)
 	}
 
@@ -164,7 +262,7 @@ func (c *endpointClient) Poll(ctx context.Context) error {
 	completionSendErrors := make(chan error, 1)
 	go func() {
 		<-completionCtx.Done()
-		if err := c.encodeAndFlush(&PollCompletionRequest{}); err != nil {
+		if err := stream.encodeAndFlush(&PollCompletionRequest{}); err != nil {
 			completionSendErrors <- fmt.Errorf("unable to send completion request: %w", err)
 		} else {
 			completionSendErrors <- nil
@@ -175,7 +273,7 @@ func (c *endpointClient) Poll(ctx context.Context) error {
 	response := &PollResponse{}
 	responseReceiveErrors := make(chan error, 1)
 	go func() {
-		if err := c.decoder.Decode(response); err != nil {
+		if err := stream.decoder.Decode(response); err != nil {
 			responseReceiveErrors <- fmt.Errorf("unable to receive poll response: %w", err)
 		} else if err = response.ensureValid(); err != nil {
 			responseReceiveErrors <- fmt.Errorf("invalid poll response: %w", err)
@@ -219,7 +317,7 @@ func (c *endpointClient) Poll(ctx context.Context) error {
 }
 
 // Scan implements the Scan method for remote endpoints.
-func (c *endpointClient) Scan(ctx context.Context, ancestor *core.Entry, full bool) (*core.Snapshot, error, bool) {
+func (c *endpointClient) Scan(ctx context.Context, ancestor *core.Entry, full bool, scope []string, deferred bool) (*core.Snapshot, error, bool) {
 	// Create an rsync engine.
 	engine := rsync.NewEngine()
 
@@ -227,10 +325,15 @@ func (c *endpointClient) Scan(ctx context.Context, ancestor *core.Entry, full bo
 	// If we have the bytes from the last received snapshot, then use those,
 	// because they'll be more acccurate, but otherwise use the provided
 	// ancestor (with some probabilistic assumptions about filesystem behavior).
-	var baselineBytes []byte
-	if c.lastSnapshotBytes != nil {
+	// We also grab the generation token corresponding to the last received
+	// snapshot, which will allow the remote to avoid retransmitting it if it
+	// hasn't changed.
+	c.stateLock.Lock()
+	baselineBytes := c.lastSnapshotBytes
+	baselineGeneration := c.lastSnapshotGeneration
+	c.stateLock.Unlock()
+	if baselineBytes != nil {
 		c.logger.Debug("Using last snapshot bytes as baseline")
-		baselineBytes = c.lastSnapshotBytes
 	} else {
 		c.logger.Debug("Using ancestor-based snapshot as baseline")
 		var err error
@@ -247,14 +350,23 @@ func (c *endpointClient) Scan(ctx context.Context, ancestor *core.Entry, full bo
 	// Compute the base signature.
 	baselineSignature := engine.BytesSignature(baselineBytes, 0)
 
+	// Acquire a request stream and defer its release.
+	stream := <-c.streams
+	defer func() {
+		c.streams <- stream
+	}()
+
 	// Create and send the scan request.
 	request := &EndpointRequest{
 		Scan: &ScanRequest{
 			BaselineSnapshotSignature: baselineSignature,
 			Full:                      full,
+			Scope:                     scope,
+			Generation:                baselineGeneration,
+			Deferred:                  deferred,
 		},
 	}
-	if err := c.encodeAndFlush(request); err != nil {
+	if err := stream.encodeAndFlush(request); err != nil {
 		return nil, fmt.Errorf("unable to send scan request: %w", err), false
 	}
 
@@ -267,7 +379,7 @@ func (c *endpointClient) Scan(ctx context.Context, ancestor *core.Entry, full bo
 	completionSendErrors := make(chan error, 1)
 	go func() {
 		<-completionCtx.Done()
-		if err := c.encodeAndFlush(&ScanCompletionRequest{}); err != nil {
+		if err := stream.encodeAndFlush(&ScanCompletionRequest{}); err != nil {
 			completionSendErrors <- fmt.Errorf("unable to send completion request: %w", err)
 		} else {
 			completionSendErrors <- nil
@@ -278,7 +390,7 @@ func (c *endpointClient) Scan(ctx context.Context, ancestor *core.Entry, full bo
 	response := &ScanResponse{}
 	responseReceiveErrors := make(chan error, 1)
 	go func() {
-		if err := c.decoder.Decode(response); err != nil {
+		if err := stream.decoder.Decode(response); err != nil {
 			responseReceiveErrors <- fmt.Errorf("unable to receive scan response: %w", err)
 		} else if err = response.ensureValid(); err != nil {
 			responseReceiveErrors <- fmt.Errorf("invalid scan response: %w", err)
@@ -312,19 +424,35 @@ func (c *endpointClient) Scan(ctx context.Context, ancestor *core.Entry, full bo
 		return nil, completionSendErr, false
 	}
 
+	// Record the watch overflow count and acceleration status.
+	c.stateLock.Lock()
+	c.watchOverflows = response.WatchOverflows
+	c.accelerationUnavailable = response.AccelerationUnavailable
+	c.stateLock.Unlock()
+
 	// Check for remote errors.
 	if response.Error != "" {
 		return nil, fmt.Errorf("remote error: %s", response.Error), response.TryAgain
 	}
 
-	// Apply the remote's deltas to the expected snapshot.
-	snapshotBytes, err := engine.PatchBytes(baselineBytes, baselineSignature, response.SnapshotDelta)
-	if err != nil {
+	// If the remote indicated that the snapshot is unchanged, then the baseline
+	// bytes already represent the snapshot. Otherwise, apply the remote's
+	// deltas to the expected snapshot.
+	var snapshotBytes []byte
+	if response.Unchanged {
+		if response.Generation != baselineGeneration {
+			return nil, errors.New("unchanged snapshot generation does not match baseline"), false
+		}
+		c.logger.Debug("Snapshot unchanged since last scan")
+		snapshotBytes = baselineBytes
+	} else if patched, err := engine.PatchBytes(baselineBytes, baselineSignature, response.SnapshotDelta); err != nil {
 		return nil, fmt.Errorf("unable to patch base snapshot: %w", err), false
+	} else {
+		snapshotBytes = patched
 	}
 
 	// If logging is enabled, then compute snapshot transmission statistics.
-	if c.logger.Level() >= logging.LevelDebug {
+	if !response.Unchanged && c.logger.Level() >= logging.LevelDebug {
 		var dataOperations, totalDataSize, blockOperations int
 		for _, operation := range response.SnapshotDelta {
 			if dataSize := len(operation.Data); dataSize > 0 {
@@ -350,7 +478,7 @@ func (c *endpointClient) Scan(ctx context.Context, ancestor *core.Entry, full bo
 	// ScanResponse, but because this method requires rsync-based patching and
 	// Protocol Buffers decoding before it actually has the underlying response,
 	// we can't perform this validation in ScanResponse.ensureValid.
-	if err = snapshot.EnsureValid(); err != nil {
+	if err := snapshot.EnsureValid(); err != nil {
 		return nil, fmt.Errorf("invalid snapshot received: %w", err), false
 	}
 
@@ -362,43 +490,165 @@ func (c *endpointClient) Scan(ctx context.Context, ancestor *core.Entry, full bo
 	// its next transmission (after being populated) is going to be far closer
 	// to ancestor than to the empty snapshot that it just sent, and thus we'll
 	// want to use the serialized ancestor snapshot as the baseline until we
-	// receive a populated snapshot.
+	// receive a populated snapshot. The generation token is only valid for the
+	// bytes that it accompanies, so we store it alongside them, and otherwise
+	// discard any existing token.
+	c.stateLock.Lock()
 	if snapshot.Content != nil {
 		c.lastSnapshotBytes = snapshotBytes
+		c.lastSnapshotGeneration = response.Generation
+	} else {
+		c.lastSnapshotGeneration = 0
 	}
+	c.stateLock.Unlock()
 
 	// Success.
 	return snapshot, nil, false
 }
 
+// Verify implements the Verify method for remote endpoints.
+func (c *endpointClient) Verify(ctx context.Context) (*core.Snapshot, error) {
+	// Create an rsync engine.
+	engine := rsync.NewEngine()
+
+	// Use the bytes from the last received snapshot (if any) as the baseline
+	// for receiving the verification snapshot. Unless content has diverged,
+	// the verification snapshot should be nearly identical. We don't update
+	// the stored bytes with the verification snapshot, since they're used as
+	// the baseline for regular scans and are associated with a generation.
+	c.stateLock.Lock()
+	baselineBytes := c.lastSnapshotBytes
+	c.stateLock.Unlock()
+	baselineSignature := engine.BytesSignature(baselineBytes, 0)
+
+	// Acquire a request stream and defer its release.
+	stream := <-c.streams
+	defer func() {
+		c.streams <- stream
+	}()
+
+	// Create and send the verify request.
+	request := &EndpointRequest{
+		Verify: &VerifyRequest{
+			BaselineSnapshotSignature: baselineSignature,
+		},
+	}
+	if err := stream.encodeAndFlush(request); err != nil {
+		return nil, fmt.Errorf("unable to send verify request: %w", err)
+	}
+
+	// Create a subcontext that we can cancel to regulate transmission of the
+	// completion request.
+	completionCtx, cancel := context.WithCancel(ctx)
+
+	// Create a Goroutine that will send a verify completion request when the
+	// subcontext is cancelled.
+	completionSendErrors := make(chan error, 1)
+	go func() {
+		<-completionCtx.Done()
+		if err := stream.encodeAndFlush(&VerifyCompletionRequest{}); err != nil {
+			completionSendErrors <- fmt.Errorf("unable to send completion request: %w", err)
+		} else {
+			completionSendErrors <- nil
+		}
+	}()
+
+	// Create a Goroutine that will receive a verify response.
+	response := &VerifyResponse{}
+	responseReceiveErrors := make(chan error, 1)
+	go func() {
+		if err := stream.decoder.Decode(response); err != nil {
+			responseReceiveErrors <- fmt.Errorf("unable to receive verify response: %w", err)
+		} else if err = response.ensureValid(); err != nil {
+			responseReceiveErrors <- fmt.Errorf("invalid verify response: %w", err)
+		} else {
+			responseReceiveErrors <- nil
+		}
+	}()
+
+	// Wait for both a completion request to be sent and a response to be
+	// received. The logic here mirrors that in Scan.
+	var completionSendErr, responseReceiveErr error
+	select {
+	case completionSendErr = <-completionSendErrors:
+		cancel()
+		responseReceiveErr = <-responseReceiveErrors
+	case responseReceiveErr = <-responseReceiveErrors:
+		cancel()
+		completionSendErr = <-completionSendErrors
+	}
+
+	// Check for transmission errors.
+	if responseReceiveErr != nil {
+		return nil, responseReceiveErr
+	} else if completionSendErr != nil {
+		return nil, completionSendErr
+	}
+
+	// Check for remote errors.
+	if response.Error != "" {
+		return nil, fmt.Errorf("remote error: %s", response.Error)
+	}
+
+	// Apply the remote's deltas to the baseline.
+	snapshotBytes, err := engine.PatchBytes(baselineBytes, baselineSignature, response.SnapshotDelta)
+	if err != nil {
+		return nil, fmt.Errorf("unable to patch base snapshot: %w", err)
+	}
+
+	// Unmarshal the snapshot and ensure that it's valid since it came over the
+	// network.
+	snapshot := &core.Snapshot{}
+	if err := proto.Unmarshal(snapshotBytes, snapshot); err != nil {
+		return nil, fmt.Errorf("unable to unmarshal snapshot: %w", err)
+	} else if err = snapshot.EnsureValid(); err != nil {
+		return nil, fmt.Errorf("invalid snapshot received: %w", err)
+	}
+
+	// Success.
+	return snapshot, nil
+}
+
 // Stage implements the Stage method for remote endpoints.
-func (c *endpointClient) Stage(paths []string, digests [][]byte) ([]string, []*rsync.Signature, rsync.Receiver, error) {
+func (c *endpointClient) Stage(paths []string, digests [][]byte) ([]string, []*rsync.Signature, rsync.Receiver, bool, error) {
 	// Validate argument lengths and bail if there's nothing to stage.
 	if len(paths) != len(digests) {
-		return nil, nil, nil, errors.New("path count does not match digest count")
+		return nil, nil, nil, false, errors.New("path count does not match digest count")
 	} else if len(paths) == 0 {
-		return nil, nil, nil, nil
+		return nil, nil, nil, false, nil
 	}
 
+	// Acquire a request stream. If staging proceeds to rsync transmission,
+	// then the stream will remain held until the receiver that we return is
+	// finalized, otherwise we release it when we return.
+	stream := <-c.streams
+	var holdStream bool
+	defer func() {
+		if !holdStream {
+			c.streams <- stream
+		}
+	}()
+
 	// Create and send the stage request.
 	request := &EndpointRequest{
 		Stage: &StageRequest{
-			Paths:   paths,
-			Digests: digests,
+			Paths:       paths,
+			Digests:     digests,
+			Compression: c.stagingCompressionAlgorithm,
 		},
 	}
-	if err := c.encodeAndFlush(request); err != nil {
-		return nil, nil, nil, fmt.Errorf("unable to send stage request: %w", err)
+	if err := stream.encodeAndFlush(request); err != nil {
+		return nil, nil, nil, false, fmt.Errorf("unable to send stage request: %w", err)
 	}
 
 	// Receive the response and check for remote errors.
 	response := &StageResponse{}
-	if err := c.decoder.Decode(response); err != nil {
-		return nil, nil, nil, fmt.Errorf("unable to receive stage response: %w", err)
+	if err := stream.decoder.Decode(response); err != nil {
+		return nil, nil, nil, false, fmt.Errorf("unable to receive stage response: %w", err)
 	} else if err = response.ensureValid(paths); err != nil {
-		return nil, nil, nil, fmt.Errorf("invalid stage response: %w", err)
+		return nil, nil, nil, false, fmt.Errorf("invalid stage response: %w", err)
 	} else if response.Error != "" {
-		return nil, nil, nil, fmt.Errorf("remote error: %s", response.Error)
+		return nil, nil, nil, false, fmt.Errorf("remote error: %s", response.Error)
 	}
 
 	// Handle the shorthand mechanism used by the remote to indicate that all
@@ -411,28 +661,61 @@ func (c *endpointClient) Stage(paths []string, digests [][]byte) ([]string, []*r
 	// If everything was already staged, then we can abort the staging
 	// operation.
 	if len(requiredPaths) == 0 {
-		return nil, nil, nil, nil
+		return nil, nil, nil, false, nil
+	}
+
+	// Determine whether or not the remote agreed to decompress operation data
+	// compressed with our staging compression algorithm. Remotes that don't
+	// support operation data compression won't set an algorithm.
+	var decompression compression.Algorithm
+	if response.Compression == c.stagingCompressionAlgorithm {
+		decompression = response.Compression
 	}
 
 	// Create an encoding receiver that can transmit rsync operations to the
-	// remote.
-	encoder := &protobufRsyncEncoder{encoder: c.encoder, flusher: c.flusher}
-	receiver := rsync.NewEncodingReceiver(encoder)
+	// remote. Its encoder will wait for the remote to acknowledge completion
+	// of staging and then release the request stream once finalized.
+	encoder := &stagingRsyncEncoder{
+		protobufRsyncEncoder: &protobufRsyncEncoder{encoder: stream.encoder, flusher: stream.flusher},
+		decoder:              stream.decoder,
+		remaining:            len(requiredPaths),
+		release: func() {
+			c.streams <- stream
+		},
+	}
+	receiver := rsync.NewDecompressingEncodingReceiver(encoder, decompression)
+	holdStream = true
 
 	// Success.
-	return requiredPaths, response.Signatures, receiver, nil
+	return requiredPaths, response.Signatures, receiver, response.Deferred, nil
 }
 
 // Supply implements the Supply method for remote endpoints.
 func (c *endpointClient) Supply(paths []string, signatures []*rsync.Signature, receiver rsync.Receiver) error {
+	// Acquire a request stream and defer its release.
+	stream := <-c.streams
+	defer func() {
+		c.streams <- stream
+	}()
+
+	// Determine whether or not the receiver can decompress operation data
+	// compressed with our staging compression algorithm. Remotes that don't
+	// support operation data compression will ignore this and transmit
+	// uncompressed data.
+	var decompression compression.Algorithm
+	if receiver.SupportsDecompression(c.stagingCompressionAlgorithm) {
+		decompression = c.stagingCompressionAlgorithm
+	}
+
 	// Create and send the supply request.
 	request := &EndpointRequest{
 		Supply: &SupplyRequest{
-			Paths:      paths,
-			Signatures: signatures,
+			Paths:       paths,
+			Signatures:  signatures,
+			Compression: decompression,
 		},
 	}
-	if err := c.encodeAndFlush(request); err != nil {
+	if err := stream.encodeAndFlush(request); err != nil {
 		// TODO: Should we find a way to finalize the receiver here? That's a
 		// private rsync method, and there shouldn't be any resources in the
 		// receiver in need of finalizing here, but it would be worth thinking
@@ -450,7 +733,7 @@ func (c *endpointClient) Supply(paths []string, signatures []*rsync.Signature, r
 	// The endpoint should now forward rsync operations, so we need to decode
 	// and forward them to the receiver. If this operation completes
 	// successfully, supplying is complete and successful.
-	decoder := &protobufRsyncDecoder{decoder: c.decoder}
+	decoder := &protobufRsyncDecoder{decoder: stream.decoder}
 	if err := rsync.DecodeToReceiver(decoder, uint64(len(paths)), receiver); err != nil {
 		return fmt.Errorf("unable to decode and forward rsync operations: %w", err)
 	}
@@ -460,14 +743,21 @@ func (c *endpointClient) Supply(paths []string, signatures []*rsync.Signature, r
 }
 
 // Transition implements the Transition method for remote endpoints.
-func (c *endpointClient) Transition(ctx context.Context, transitions []*core.Change) ([]*core.Entry, []*core.Problem, bool, error) {
+func (c *endpointClient) Transition(ctx context.Context, transitions []*core.Change, dryRun bool) ([]*core.Entry, []*core.Problem, bool, error) {
+	// Acquire a request stream and defer its release.
+	stream := <-c.streams
+	defer func() {
+		c.streams <- stream
+	}()
+
 	// Create and send the transition request.
 	request := &EndpointRequest{
 		Transition: &TransitionRequest{
 			Transitions: transitions,
+			DryRun:      dryRun,
 		},
 	}
-	if err := c.encodeAndFlush(request); err != nil {
+	if err := stream.encodeAndFlush(request); err != nil {
 		return nil, nil, false, fmt.Errorf("unable to send transition request: %w", err)
 	}
 
@@ -480,7 +770,7 @@ func (c *endpointClient) Transition(ctx context.Context, transitions []*core.Cha
 	completionSendErrors := make(chan error, 1)
 	go func() {
 		<-completionCtx.Done()
-		if err := c.encodeAndFlush(&TransitionCompletionRequest{}); err != nil {
+		if err := stream.encodeAndFlush(&TransitionCompletionRequest{}); err != nil {
 			completionSendErrors <- fmt.Errorf("unable to send completion request: %w", err)
 		} else {
 			completionSendErrors <- nil
@@ -491,7 +781,7 @@ func (c *endpointClient) Transition(ctx context.Context, transitions []*core.Cha
 	response := &TransitionResponse{}
 	responseReceiveErrors := make(chan error, 1)
 	go func() {
-		if err := c.decoder.Decode(response); err != nil {
+		if err := stream.decoder.Decode(response); err != nil {
 			responseReceiveErrors <- fmt.Errorf("unable to receive transition response: %w", err)
 		} else if err = response.ensureValid(len(transitions)); err != nil {
 			responseReceiveErrors <- fmt.Errorf("invalid transition response: %w", err)
@@ -525,6 +815,11 @@ func (c *endpointClient) Transition(ctx context.Context, transitions []*core.Cha
 		return nil, nil, false, completionSendErr
 	}
 
+	// Record the oversized file count.
+	c.stateLock.Lock()
+	c.oversizedFiles = response.OversizedFiles
+	c.stateLock.Unlock()
+
 	// Check for remote errors.
 	if response.Error != "" {
 		return nil, nil, false, fmt.Errorf("remote error: %s", response.Error)
@@ -540,8 +835,42 @@ func (c *endpointClient) Transition(ctx context.Context, transitions []*core.Cha
 	return results, response.Problems, response.StagerMissingFiles, nil
 }
 
+// ClockOffset implements the ClockOffset method for remote endpoints.
+func (c *endpointClient) ClockOffset() time.Duration {
+	return c.clockOffset
+}
+
+// WatchOverflows implements the WatchOverflows method for remote endpoints.
+func (c *endpointClient) WatchOverflows() uint64 {
+	c.stateLock.Lock()
+	defer c.stateLock.Unlock()
+	return c.watchOverflows
+}
+
+// AccelerationUnavailable implements the AccelerationUnavailable method for
+// remote endpoints.
+func (c *endpointClient) AccelerationUnavailable() string {
+	c.stateLock.Lock()
+	defer c.stateLock.Unlock()
+	return c.accelerationUnavailable
+}
+
+// OversizedFiles implements the OversizedFiles method for remote endpoints.
+func (c *endpointClient) OversizedFiles() uint64 {
+	c.stateLock.Lock()
+	defer c.stateLock.Unlock()
+	return c.oversizedFiles
+}
+
 // Shutdown implements the Shutdown method for remote endpoints.
 func (c *endpointClient) Shutdown() error {
+	// If the control stream is multiplexed, then close the multiplexer. This
+	// will close the underlying stream and cause all request stream
+	// reads/writes to unblock.
+	if c.multiplexer != nil {
+		c.multiplexer.Close()
+	}
+
 	// Close the compression resources and the control stream. This will cause
 	// all control stream reads/writes to unblock.
 	return c.closer.Close()
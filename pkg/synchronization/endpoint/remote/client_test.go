@@ -1,3 +1,390 @@
 package remote
 
-// TODO: Implement.
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"crypto/sha1"
+	"errors"
+	"io"
+	"net"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	"github.com/mutagen-io/mutagen/pkg/encoding"
+	"github.com/mutagen-io/mutagen/pkg/logging"
+	streampkg "github.com/mutagen-io/mutagen/pkg/stream"
+	"github.com/mutagen-io/mutagen/pkg/synchronization"
+	"github.com/mutagen-io/mutagen/pkg/synchronization/compression"
+	"github.com/mutagen-io/mutagen/pkg/synchronization/core"
+	"github.com/mutagen-io/mutagen/pkg/synchronization/rsync"
+)
+
+// TODO: Implement tests for additional functionality.
+
+// serveSkewedHandshake implements the server side of the endpoint handshake
+// over the specified connection, reporting a clock that's offset from the local
+// clock by the specified skew. It closes the connection once the handshake is
+// complete.
+func serveSkewedHandshake(connection net.Conn, skew time.Duration) {
+	// Ensure that the connection is closed when we're done.
+	defer connection.Close()
+
+	// Perform the compression handshake and set up the control stream.
+	algorithm, err := compression.ServerHandshake(connection)
+	if err != nil {
+		return
+	}
+	inbound := bufio.NewReader(algorithm.Decompress(bufio.NewReader(connection)))
+	compressedOutbound := bufio.NewWriter(connection)
+	compressor := algorithm.Compress(compressedOutbound)
+	outbound := bufio.NewWriter(compressor)
+	flusher := streampkg.NewMultiFlusher(outbound, compressor, compressedOutbound)
+	encoder := encoding.NewProtobufEncoder(outbound)
+	decoder := encoding.NewProtobufDecoder(inbound)
+
+	// Handle initialization.
+	if err := decoder.Decode(&InitializeSynchronizationRequest{}); err != nil {
+		return
+	} else if err = encoder.Encode(&InitializeSynchronizationResponse{}); err != nil {
+		return
+	} else if err = flusher.Flush(); err != nil {
+		return
+	}
+
+	// Handle the clock exchange.
+	if err := decoder.Decode(&ClockRequest{}); err != nil {
+		return
+	} else if err = encoder.Encode(&ClockResponse{Time: timestamppb.New(time.Now().Add(skew))}); err != nil {
+		return
+	}
+	flusher.Flush()
+}
+
+// TestNewEndpointClockSkew tests that NewEndpoint measures the clock offset of
+// the remote endpoint and warns about or refuses excessive clock skew based on
+// the clock skew mode.
+func TestNewEndpointClockSkew(t *testing.T) {
+	// Set up test cases.
+	testCases := []struct {
+		skew          time.Duration
+		mode          synchronization.ClockSkewMode
+		expectWarning bool
+		expectFailure bool
+	}{
+		{0, synchronization.ClockSkewMode_ClockSkewModeRefuse, false, false},
+		{time.Hour, synchronization.ClockSkewMode_ClockSkewModeWarn, true, false},
+		{time.Hour, synchronization.ClockSkewMode_ClockSkewModeRefuse, false, true},
+		{-time.Hour, synchronization.ClockSkewMode_ClockSkewModeRefuse, false, true},
+	}
+
+	// Process test cases.
+	for i, testCase := range testCases {
+		// Create a connection and serve the handshake with a skewed clock.
+		client, server := net.Pipe()
+		go serveSkewedHandshake(server, testCase.skew)
+
+		// Create the endpoint.
+		output := &bytes.Buffer{}
+		endpoint, err := NewEndpoint(
+			logging.NewLogger(logging.LevelWarn, output),
+			client,
+			"/root",
+			"session",
+			synchronization.Version_Version1,
+			&synchronization.Configuration{
+				ClockSkewMode:      testCase.mode,
+				ClockSkewTolerance: 60,
+			},
+			true,
+		)
+
+		// Check the result.
+		if err != nil {
+			if !testCase.expectFailure {
+				t.Errorf("test case %d: endpoint creation failed unexpectedly: %v", i, err)
+			}
+			continue
+		} else if testCase.expectFailure {
+			t.Errorf("test case %d: endpoint creation succeeded unexpectedly", i)
+		}
+		if warned := strings.Contains(output.String(), "exceeds tolerance"); warned != testCase.expectWarning {
+			t.Errorf("test case %d: warning status does not match expected: %t != %t",
+				i, warned, testCase.expectWarning,
+			)
+		}
+		offset := endpoint.ClockOffset()
+		if offset < testCase.skew-time.Minute || offset > testCase.skew+time.Minute {
+			t.Errorf("test case %d: measured offset (%s) not close to skew (%s)",
+				i, offset, testCase.skew,
+			)
+		}
+		endpoint.Shutdown()
+	}
+}
+
+// TestEndpointStreamConcurrency tests that a remote endpoint operating with
+// multiple concurrent request streams can pipeline operations and that it
+// correctly serves operations issued concurrently.
+func TestEndpointStreamConcurrency(t *testing.T) {
+	// Create a synchronization root, a source directory containing a file to
+	// be staged, and an isolated data directory for staging.
+	root := t.TempDir()
+	source := t.TempDir()
+	t.Setenv("MUTAGEN_DATA_DIRECTORY", t.TempDir())
+	content := []byte("multiplexed content")
+	digest := sha1.Sum(content)
+	if err := os.WriteFile(filepath.Join(source, "file"), content, 0600); err != nil {
+		t.Fatal("unable to create source file:", err)
+	}
+
+	// Create a connection and serve an endpoint on one end of it.
+	client, server := net.Pipe()
+	go ServeEndpoint(logging.NewLogger(logging.LevelDisabled, io.Discard), server)
+
+	// Create the endpoint client and defer its shutdown.
+	endpoint, err := NewEndpoint(
+		logging.NewLogger(logging.LevelDisabled, io.Discard),
+		client,
+		root,
+		"session",
+		synchronization.Version_Version1,
+		&synchronization.Configuration{
+			WatchMode:         synchronization.WatchMode_WatchModeNoWatch,
+			StreamConcurrency: 4,
+		},
+		true,
+	)
+	if err != nil {
+		t.Fatal("unable to create endpoint:", err)
+	}
+	defer endpoint.Shutdown()
+
+	// Perform an initial scan.
+	if _, err, _ := endpoint.Scan(context.Background(), nil, true, nil, false); err != nil {
+		t.Fatal("unable to perform initial scan:", err)
+	}
+
+	// Start a poll operation, which will hold a request stream until it's
+	// cancelled. With a single request stream, this would block all other
+	// operations.
+	pollCtx, cancelPoll := context.WithCancel(context.Background())
+	defer cancelPoll()
+	pollErrors := make(chan error, 1)
+	go func() {
+		pollErrors <- endpoint.Poll(pollCtx)
+	}()
+
+	// Begin staging, which will hold a request stream until the receiver is
+	// finalized.
+	paths, signatures, receiver, _, err := endpoint.Stage([]string{"file"}, [][]byte{digest[:]})
+	if err != nil {
+		t.Fatal("unable to begin staging:", err)
+	} else if len(paths) != 1 {
+		t.Fatal("file not requested for staging")
+	}
+
+	// Perform several scans concurrently while staging is still in progress.
+	const scanCount = 4
+	scanErrors := make(chan error, scanCount)
+	for i := 0; i < scanCount; i++ {
+		go func() {
+			if snapshot, err, _ := endpoint.Scan(context.Background(), nil, true, nil, false); err != nil {
+				scanErrors <- err
+			} else if snapshot.Content == nil || snapshot.Content.Kind != core.EntryKind_Directory {
+				scanErrors <- errors.New("unexpected snapshot content")
+			} else {
+				scanErrors <- nil
+			}
+		}()
+	}
+	for i := 0; i < scanCount; i++ {
+		if err := <-scanErrors; err != nil {
+			t.Error("concurrent scan failed:", err)
+		}
+	}
+
+	// Complete staging. Finalization of the receiver waits for the remote to
+	// acknowledge that staging is complete.
+	if err := rsync.Transmit(source, paths, signatures, receiver); err != nil {
+		t.Fatal("unable to transmit file:", err)
+	}
+
+	// Perform a transition to create the file. Because staging has completed,
+	// the transition is guaranteed to observe the staged content.
+	change := &core.Change{
+		Path: "file",
+		New:  &core.Entry{Kind: core.EntryKind_File, Digest: digest[:]},
+	}
+	results, problems, missingFiles, err := endpoint.Transition(context.Background(), []*core.Change{change}, false)
+	if err != nil {
+		t.Fatal("unable to perform transition:", err)
+	} else if len(problems) != 0 {
+		t.Fatal("transition encountered problems:", problems)
+	} else if missingFiles {
+		t.Fatal("transition reported missing files")
+	} else if len(results) != 1 || results[0] == nil || results[0].Kind != core.EntryKind_File {
+		t.Fatal("transition result does not indicate file creation")
+	}
+	if data, err := os.ReadFile(filepath.Join(root, "file")); err != nil {
+		t.Fatal("unable to read transitioned file:", err)
+	} else if !bytes.Equal(data, content) {
+		t.Error("transitioned file content does not match expected")
+	}
+
+	// Verify that a subsequent scan observes the transition.
+	if snapshot, err, _ := endpoint.Scan(context.Background(), nil, true, nil, false); err != nil {
+		t.Fatal("unable to perform scan:", err)
+	} else if snapshot.Content.Contents["file"] == nil {
+		t.Error("scan did not observe transitioned file")
+	}
+
+	// Cancel polling and ensure that it completes successfully.
+	cancelPoll()
+	if err := <-pollErrors; err != nil {
+		t.Error("polling failed:", err)
+	}
+
+}
+
+// TestScanGenerationUnchanged tests that a re-scan of an unchanged remote
+// endpoint yields an unchanged (payload-free) response based on the generation
+// token from the previous scan, and that invalidating the generation results in
+// full snapshot transmission.
+func TestScanGenerationUnchanged(t *testing.T) {
+	// Create a synchronization root with some content and an isolated data
+	// directory.
+	root := t.TempDir()
+	t.Setenv("MUTAGEN_DATA_DIRECTORY", t.TempDir())
+	if err := os.WriteFile(filepath.Join(root, "file"), []byte("content"), 0600); err != nil {
+		t.Fatal("unable to create file:", err)
+	}
+
+	// Create a connection and serve an endpoint on one end of it.
+	client, server := net.Pipe()
+	go ServeEndpoint(logging.NewLogger(logging.LevelDisabled, io.Discard), server)
+
+	// Create the endpoint client and defer its shutdown. We use poll-based
+	// watching with a long polling interval and accelerated scanning so that
+	// the endpoint will re-use its initial background scan.
+	output := &bytes.Buffer{}
+	endpoint, err := NewEndpoint(
+		logging.NewLogger(logging.LevelDebug, output),
+		client,
+		root,
+		"session",
+		synchronization.Version_Version1,
+		&synchronization.Configuration{
+			WatchMode:            synchronization.WatchMode_WatchModeForcePoll,
+			WatchPollingInterval: 3600,
+			ScanMode:             synchronization.ScanMode_ScanModeAccelerated,
+		},
+		true,
+	)
+	if err != nil {
+		t.Fatal("unable to create endpoint:", err)
+	}
+	defer endpoint.Shutdown()
+
+	// Perform scans until we receive a generation token. This may take a few
+	// attempts if our scans race with the endpoint's initial background scan.
+	var snapshot *core.Snapshot
+	for i := 0; i < 100; i++ {
+		if snapshot, err, _ = endpoint.Scan(context.Background(), nil, false, nil, false); err != nil {
+			t.Fatal("unable to perform scan:", err)
+		} else if endpoint.(*endpointClient).lastSnapshotGeneration != 0 {
+			break
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+	if endpoint.(*endpointClient).lastSnapshotGeneration == 0 {
+		t.Fatal("generation token not received")
+	}
+
+	// Verify that an unchanged re-scan yields an unchanged response and the
+	// same snapshot.
+	output.Reset()
+	if rescanned, err, _ := endpoint.Scan(context.Background(), nil, false, nil, false); err != nil {
+		t.Fatal("unable to perform re-scan:", err)
+	} else if !strings.Contains(output.String(), "Snapshot unchanged since last scan") {
+		t.Error("unchanged re-scan did not yield unchanged response")
+	} else if !rescanned.Equal(snapshot) {
+		t.Error("unchanged re-scan snapshot does not match original")
+	}
+
+	// Verify that a full scan, which invalidates the generation, transmits the
+	// snapshot.
+	output.Reset()
+	if rescanned, err, _ := endpoint.Scan(context.Background(), nil, true, nil, false); err != nil {
+		t.Fatal("unable to perform full scan:", err)
+	} else if strings.Contains(output.String(), "Snapshot unchanged since last scan") {
+		t.Error("full scan yielded unchanged response")
+	} else if !rescanned.Equal(snapshot) {
+		t.Error("full scan snapshot does not match original")
+	}
+}
+
+// TestVerify tests that verification of a remote endpoint yields a snapshot
+// matching that of a regular scan without disturbing the generation token used
+// by regular scans.
+func TestVerify(t *testing.T) {
+	// Create a synchronization root with some content and an isolated data
+	// directory.
+	root := t.TempDir()
+	t.Setenv("MUTAGEN_DATA_DIRECTORY", t.TempDir())
+	if err := os.WriteFile(filepath.Join(root, "file"), []byte("content"), 0600); err != nil {
+		t.Fatal("unable to create file:", err)
+	}
+
+	// Create a connection and serve an endpoint on one end of it.
+	client, server := net.Pipe()
+	go ServeEndpoint(logging.NewLogger(logging.LevelDisabled, io.Discard), server)
+
+	// Create the endpoint client and defer its shutdown.
+	endpoint, err := NewEndpoint(
+		logging.NewLogger(logging.LevelDisabled, io.Discard),
+		client,
+		root,
+		"session",
+		synchronization.Version_Version1,
+		&synchronization.Configuration{
+			WatchMode: synchronization.WatchMode_WatchModeNoWatch,
+		},
+		true,
+	)
+	if err != nil {
+		t.Fatal("unable to create endpoint:", err)
+	}
+	defer endpoint.Shutdown()
+
+	// Verify that verification works without a baseline.
+	if verified, err := endpoint.Verify(context.Background()); err != nil {
+		t.Fatal("unable to perform verification without baseline:", err)
+	} else if verified.Content.Contents["file"] == nil {
+		t.Error("verification without baseline did not observe file")
+	}
+
+	// Perform a scan and record the resulting baseline state.
+	snapshot, err, _ := endpoint.Scan(context.Background(), nil, true, nil, false)
+	if err != nil {
+		t.Fatal("unable to perform scan:", err)
+	}
+	c := endpoint.(*endpointClient)
+	baselineBytes, baselineGeneration := c.lastSnapshotBytes, c.lastSnapshotGeneration
+
+	// Verify that verification yields the same snapshot and leaves the
+	// baseline state untouched.
+	if verified, err := endpoint.Verify(context.Background()); err != nil {
+		t.Fatal("unable to perform verification:", err)
+	} else if !verified.Content.Equal(snapshot.Content, true) {
+		t.Error("verification snapshot does not match scan snapshot")
+	}
+	if !bytes.Equal(c.lastSnapshotBytes, baselineBytes) || c.lastSnapshotGeneration != baselineGeneration {
+		t.Error("verification modified baseline state")
+	}
+}
@@ -0,0 +1,60 @@
+package remote
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/mutagen-io/mutagen/pkg/logging"
+	"github.com/mutagen-io/mutagen/pkg/synchronization"
+)
+
+// clockOffset estimates the offset of a remote clock relative to the local
+// clock based on a single round-trip clock exchange. The sent and received
+// arguments are the local times at which the clock request was sent and the
+// clock response was received, respectively, and remote is the time reported
+// in the clock response. The remote time is assumed to have been captured at
+// the midpoint of the round trip, meaning that the error of the estimate is
+// bounded by half of the round-trip time, which is returned as the uncertainty
+// of the estimate. Positive offsets indicate that the remote clock is ahead of
+// the local clock.
+func clockOffset(sent, received, remote time.Time) (offset, uncertainty time.Duration) {
+	uncertainty = received.Sub(sent) / 2
+	offset = remote.Sub(sent.Add(uncertainty))
+	return
+}
+
+// checkClockSkew checks a measured clock offset against the specified
+// tolerance. The offset is only considered excessive if it exceeds the
+// tolerance even after accounting for its measurement uncertainty. If the offset
+// is excessive, then the behavior depends on the clock skew mode: in warn mode
+// a warning is logged to the specified logger, and in refuse mode an error is
+// returned.
+func checkClockSkew(
+	logger *logging.Logger,
+	offset, uncertainty time.Duration,
+	mode synchronization.ClockSkewMode,
+	tolerance time.Duration,
+) error {
+	// Compute the magnitude of the offset, accounting for uncertainty.
+	magnitude := offset
+	if magnitude < 0 {
+		magnitude = -magnitude
+	}
+	magnitude -= uncertainty
+
+	// If the offset is within tolerance, then there's nothing to do.
+	if magnitude <= tolerance {
+		return nil
+	}
+
+	// Handle excessive skew based on the mode.
+	switch mode {
+	case synchronization.ClockSkewMode_ClockSkewModeWarn:
+		logger.Warnf("Endpoint clock offset (%s) exceeds tolerance (%s)", offset, tolerance)
+		return nil
+	case synchronization.ClockSkewMode_ClockSkewModeRefuse:
+		return fmt.Errorf("endpoint clock offset (%s) exceeds tolerance (%s)", offset, tolerance)
+	default:
+		panic("unhandled clock skew mode")
+	}
+}
@@ -0,0 +1,86 @@
+package remote
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/mutagen-io/mutagen/pkg/logging"
+	"github.com/mutagen-io/mutagen/pkg/synchronization"
+)
+
+// TestClockOffset tests that clockOffset correctly estimates clock offsets and
+// their uncertainties.
+func TestClockOffset(t *testing.T) {
+	// Create a reference time.
+	sent := time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	// Set up test cases.
+	testCases := []struct {
+		received            time.Time
+		remote              time.Time
+		expectedOffset      time.Duration
+		expectedUncertainty time.Duration
+	}{
+		{sent, sent, 0, 0},
+		{sent.Add(2 * time.Second), sent.Add(time.Second), 0, time.Second},
+		{sent.Add(2 * time.Second), sent.Add(time.Hour + time.Second), time.Hour, time.Second},
+		{sent.Add(2 * time.Second), sent.Add(-time.Hour + time.Second), -time.Hour, time.Second},
+	}
+
+	// Process test cases.
+	for i, testCase := range testCases {
+		offset, uncertainty := clockOffset(sent, testCase.received, testCase.remote)
+		if offset != testCase.expectedOffset {
+			t.Errorf("test case %d: offset does not match expected: %s != %s",
+				i, offset, testCase.expectedOffset,
+			)
+		}
+		if uncertainty != testCase.expectedUncertainty {
+			t.Errorf("test case %d: uncertainty does not match expected: %s != %s",
+				i, uncertainty, testCase.expectedUncertainty,
+			)
+		}
+	}
+}
+
+// TestCheckClockSkew tests that checkClockSkew warns about or refuses excessive
+// clock skew depending on the clock skew mode.
+func TestCheckClockSkew(t *testing.T) {
+	// Set up test cases.
+	testCases := []struct {
+		offset        time.Duration
+		uncertainty   time.Duration
+		mode          synchronization.ClockSkewMode
+		expectWarning bool
+		expectFailure bool
+	}{
+		{0, 0, synchronization.ClockSkewMode_ClockSkewModeWarn, false, false},
+		{0, 0, synchronization.ClockSkewMode_ClockSkewModeRefuse, false, false},
+		{30 * time.Second, 0, synchronization.ClockSkewMode_ClockSkewModeRefuse, false, false},
+		{-30 * time.Second, 0, synchronization.ClockSkewMode_ClockSkewModeRefuse, false, false},
+		{90 * time.Second, 45 * time.Second, synchronization.ClockSkewMode_ClockSkewModeRefuse, false, false},
+		{time.Hour, 0, synchronization.ClockSkewMode_ClockSkewModeWarn, true, false},
+		{-time.Hour, 0, synchronization.ClockSkewMode_ClockSkewModeWarn, true, false},
+		{time.Hour, 0, synchronization.ClockSkewMode_ClockSkewModeRefuse, false, true},
+		{-time.Hour, time.Second, synchronization.ClockSkewMode_ClockSkewModeRefuse, false, true},
+	}
+
+	// Process test cases.
+	for i, testCase := range testCases {
+		output := &bytes.Buffer{}
+		logger := logging.NewLogger(logging.LevelWarn, output)
+		err := checkClockSkew(logger, testCase.offset, testCase.uncertainty, testCase.mode, time.Minute)
+		if err != nil && !testCase.expectFailure {
+			t.Errorf("test case %d: check failed unexpectedly: %v", i, err)
+		} else if err == nil && testCase.expectFailure {
+			t.Errorf("test case %d: check succeeded unexpectedly", i)
+		}
+		if warned := strings.Contains(output.String(), "exceeds tolerance"); warned != testCase.expectWarning {
+			t.Errorf("test case %d: warning status does not match expected: %t != %t",
+				i, warned, testCase.expectWarning,
+			)
+		}
+	}
+}
@@ -3,6 +3,8 @@ package remote
 import (
 	"errors"
 	"fmt"
+
+	"github.com/mutagen-io/mutagen/pkg/synchronization"
 )
 
 const (
@@ -14,6 +16,9 @@ const (
 	// controlStreamCompressorBufferSize is the buffer size to use for
 	// compressor input and decompressor output.
 	controlStreamUncompressedBufferSize = 64 * 1024
+	// requestStreamBufferSize is the buffer size to use for multiplexed
+	// request stream buffering.
+	requestStreamBufferSize = 32 * 1024
 )
 
 // ensureValid ensures that the InitializeSynchronizationRequest's invariants
@@ -62,6 +67,35 @@ func (r *InitializeSynchronizationResponse) ensureValid() error {
 	return nil
 }
 
+// ensureValid ensures that the ClockRequest's invariants are respected.
+func (r *ClockRequest) ensureValid() error {
+	// A nil clock request is not valid.
+	if r == nil {
+		return errors.New("nil clock request")
+	}
+
+	// Success.
+	return nil
+}
+
+// ensureValid ensures that the ClockResponse's invariants are respected.
+func (r *ClockResponse) ensureValid() error {
+	// A nil clock response is not valid.
+	if r == nil {
+		return errors.New("nil clock response")
+	}
+
+	// Ensure that the time is present and valid.
+	if r.Time == nil {
+		return errors.New("missing time")
+	} else if err := r.Time.CheckValid(); err != nil {
+		return fmt.Errorf("invalid time: %w", err)
+	}
+
+	// Success.
+	return nil
+}
+
 // ensureValid ensures that the PollRequest's invariants are respected.
 func (r *PollRequest) ensureValid() error {
 	// A nil poll request is not valid.
@@ -110,6 +144,13 @@ func (r *ScanRequest) ensureValid() error {
 
 	// Full is correct regardless of value, so no validation is required.
 
+	// Generation is correct regardless of value, so no validation is required.
+
+	// Ensure that the scope is valid.
+	if err := synchronization.EnsureValidScope(r.Scope); err != nil {
+		return fmt.Errorf("invalid scope: %w", err)
+	}
+
 	// Success.
 	return nil
 }
@@ -147,6 +188,18 @@ func (r *ScanResponse) ensureValid() error {
 		}
 	}
 
+	// If the snapshot is unchanged, then make sure that no snapshot delta or
+	// error was provided and that a generation token is present.
+	if r.Unchanged {
+		if len(r.SnapshotDelta) > 0 {
+			return errors.New("non-empty snapshot delta present on unchanged snapshot")
+		} else if r.Error != "" {
+			return errors.New("error present on unchanged snapshot")
+		} else if r.Generation == 0 {
+			return errors.New("generation token missing on unchanged snapshot")
+		}
+	}
+
 	// Success.
 	return nil
 }
@@ -214,6 +267,12 @@ func (r *StageResponse) ensureValid(paths []string) error {
 		return errors.New("number of paths requested greater than original path count")
 	}
 
+	// Verify that paths are present if staging has been deferred, because
+	// deferral is only allowed if progress is being made.
+	if r.Deferred && p == 0 {
+		return errors.New("staging deferred without paths")
+	}
+
 	// Verify that all signatures are valid.
 	for _, signature := range r.Signatures {
 		if err := signature.EnsureValid(); err != nil {
@@ -232,6 +291,17 @@ func (r *StageResponse) ensureValid(paths []string) error {
 	return nil
 }
 
+// ensureValid ensures that StageCompletionResponse's invariants are respected.
+func (r *StageCompletionResponse) ensureValid() error {
+	// A nil stage completion response is not valid.
+	if r == nil {
+		return errors.New("nil stage completion response")
+	}
+
+	// Success.
+	return nil
+}
+
 // ensureValid ensures that SupplyRequest's invariants are respected.
 func (r *SupplyRequest) ensureValid() error {
 	// A nil supply request is not valid.
@@ -317,6 +387,57 @@ func (r *TransitionResponse) ensureValid(expectedCount int) error {
 	return nil
 }
 
+// ensureValid ensures that the VerifyRequest's invariants are respected.
+func (r *VerifyRequest) ensureValid() error {
+	// A nil verify request is not valid.
+	if r == nil {
+		return errors.New("nil verify request")
+	}
+
+	// Ensure that the baseline snapshot signature is valid.
+	if err := r.BaselineSnapshotSignature.EnsureValid(); err != nil {
+		return fmt.Errorf("invalid baseline snapshot signature: %w", err)
+	}
+
+	// Success.
+	return nil
+}
+
+// ensureValid ensures that the VerifyCompletionRequest's invariants are
+// respected.
+func (r *VerifyCompletionRequest) ensureValid() error {
+	// A nil verify completion request is not valid.
+	if r == nil {
+		return errors.New("nil verify completion request")
+	}
+
+	// Success.
+	return nil
+}
+
+// ensureValid ensures that the VerifyResponse's invariants are respected.
+func (r *VerifyResponse) ensureValid() error {
+	// A nil verify response is not valid.
+	if r == nil {
+		return errors.New("nil verify response")
+	}
+
+	// Ensure that each snapshot delta operation is valid.
+	for _, operation := range r.SnapshotDelta {
+		if err := operation.EnsureValid(); err != nil {
+			return fmt.Errorf("invalid snapshot delta operation: %w", err)
+		}
+	}
+
+	// If an error is set, then make sure that no snapshot delta was provided.
+	if r.Error != "" && len(r.SnapshotDelta) > 0 {
+		return errors.New("non-empty snapshot delta present on error")
+	}
+
+	// Success.
+	return nil
+}
+
 // ensureValid ensures that EndpointRequest's invariants are respected.
 func (r *EndpointRequest) ensureValid() error {
 	// A nil endpoint request is not valid.
@@ -341,6 +462,9 @@ func (r *EndpointRequest) ensureValid() error {
 	if r.Transition != nil {
 		set++
 	}
+	if r.Verify != nil {
+		set++
+	}
 	if set != 1 {
 		return errors.New("invalid number of fields set")
 	}
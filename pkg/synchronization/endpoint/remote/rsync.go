@@ -46,6 +46,64 @@ func (e *protobufRsyncEncoder) Finalize() error {
 	return nil
 }
 
+// stagingRsyncEncoder implements rsync.Encoder for client-side staging. It
+// wraps a protobufRsyncEncoder and, when finalized, waits for the remote to
+// acknowledge completion of staging before releasing its request stream.
+type stagingRsyncEncoder struct {
+	// protobufRsyncEncoder is the underlying encoder.
+	*protobufRsyncEncoder
+	// decoder is the Protocol Buffers decoder for the request stream.
+	decoder *encoding.ProtobufDecoder
+	// remaining is the number of files whose transmission has yet to complete.
+	remaining int
+	// release releases the request stream.
+	release func()
+}
+
+// Encode implements rsync.Encoder.Encode.
+func (e *stagingRsyncEncoder) Encode(transmission *rsync.Transmission) error {
+	// Encode the transmission.
+	if err := e.protobufRsyncEncoder.Encode(transmission); err != nil {
+		return err
+	}
+
+	// Track file completion.
+	if transmission.Done {
+		e.remaining--
+	}
+
+	// Success.
+	return nil
+}
+
+// Finalize implements rsync.Encoder.Finalize.
+func (e *stagingRsyncEncoder) Finalize() error {
+	// Release the request stream once we're done.
+	defer e.release()
+
+	// Finalize the underlying encoder.
+	if err := e.protobufRsyncEncoder.Finalize(); err != nil {
+		return err
+	}
+
+	// If transmission failed or was incomplete, then the remote won't
+	// acknowledge completion, and the endpoint will be considered failed.
+	if e.protobufRsyncEncoder.error != nil || e.remaining > 0 {
+		return nil
+	}
+
+	// Wait for the remote to acknowledge that it has processed all operations.
+	response := &StageCompletionResponse{}
+	if err := e.decoder.Decode(response); err != nil {
+		return fmt.Errorf("unable to receive stage completion response: %w", err)
+	} else if err = response.ensureValid(); err != nil {
+		return fmt.Errorf("invalid stage completion response: %w", err)
+	}
+
+	// Success.
+	return nil
+}
+
 // protobufRsyncDecoder implements rsync.Decoder using Protocol Buffers.
 type protobufRsyncDecoder struct {
 	// decoder is the underlying Protocol Buffers decoder.
@@ -8,10 +8,12 @@ import (
 	"io"
 
 	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/timestamppb"
 
 	"github.com/mutagen-io/mutagen/pkg/encoding"
 	"github.com/mutagen-io/mutagen/pkg/filesystem"
 	"github.com/mutagen-io/mutagen/pkg/logging"
+	"github.com/mutagen-io/mutagen/pkg/multiplexing"
 	streampkg "github.com/mutagen-io/mutagen/pkg/stream"
 	"github.com/mutagen-io/mutagen/pkg/synchronization"
 	"github.com/mutagen-io/mutagen/pkg/synchronization/compression"
@@ -20,17 +22,24 @@ import (
 	"github.com/mutagen-io/mutagen/pkg/synchronization/rsync"
 )
 
+// scanGenerationTracker is an optional interface that underlying endpoints can
+// implement to allow the transmission of unchanged scan results to be elided.
+type scanGenerationTracker interface {
+	// ScanGeneration returns the endpoint's current scan generation. If the
+	// scan generation is the same before and after a Scan call, then the call
+	// must have returned the snapshot that was current when the generation last
+	// changed. Any event that might change the endpoint's snapshot (e.g. a
+	// watcher event) must change the scan generation.
+	ScanGeneration() uint64
+}
+
 // endpointServer wraps a local endpoint instances and dispatches requests to
-// this endpoint from an endpoint client.
+// this endpoint from an endpoint client over a single request stream.
 type endpointServer struct {
 	// endpoint is the underlying local endpoint.
 	endpoint synchronization.Endpoint
-	// flusher flushes the outbound control stream.
-	flusher streampkg.Flusher
-	// encoder is the control stream encoder.
-	encoder *encoding.ProtobufEncoder
-	// decoder is the control stream decoder.
-	decoder *encoding.ProtobufDecoder
+	// requestStream is the request stream being served.
+	*requestStream
 }
 
 // ServeEndpoint creates and serves a endpoint server on the specified stream.
@@ -129,27 +138,64 @@ func ServeEndpoint(logger *logging.Logger, stream io.ReadWriteCloser) error {
 		return fmt.Errorf("unable to transmit initialize response: %w", err)
 	}
 
-	// Create the server.
-	server := &endpointServer{
-		endpoint: endpoint,
-		flusher:  flusher,
-		encoder:  encoder,
-		decoder:  decoder,
+	// Receive the clock request and respond with our current time.
+	clockRequest := &ClockRequest{}
+	if err := decoder.Decode(clockRequest); err != nil {
+		return fmt.Errorf("unable to receive clock request: %w", err)
+	} else if err = clockRequest.ensureValid(); err != nil {
+		return fmt.Errorf("invalid clock request: %w", err)
+	}
+	if err = encoder.Encode(&ClockResponse{Time: timestamppb.Now()}); err != nil {
+		return fmt.Errorf("unable to encode clock response: %w", err)
+	} else if err = flusher.Flush(); err != nil {
+		return fmt.Errorf("unable to transmit clock response: %w", err)
 	}
 
-	// Server until an error occurs.
-	return server.serve()
-}
+	// Compute the effective stream concurrency.
+	streamConcurrency := request.Configuration.StreamConcurrency
+	if streamConcurrency == 0 {
+		streamConcurrency = request.Version.DefaultStreamConcurrency()
+	}
 
-// encodeAndFlush encodes a Protocol Buffers message using the underlying
-// encoder and then flushes the control stream.
-func (s *endpointServer) encodeAndFlush(message proto.Message) error {
-	if err := s.encoder.Encode(message); err != nil {
-		return err
-	} else if err = s.flusher.Flush(); err != nil {
-		return fmt.Errorf("message transmission failed: %w", err)
+	// If only a single request stream is required, then serve requests
+	// directly on the control stream until an error occurs.
+	if streamConcurrency == 1 {
+		server := &endpointServer{
+			endpoint:      endpoint,
+			requestStream: &requestStream{flusher: flusher, encoder: encoder, decoder: decoder},
+		}
+		return server.serve()
 	}
-	return nil
+
+	// Otherwise, multiplex the control stream and defer closure of the
+	// multiplexer.
+	multiplexer := multiplexing.Multiplex(&controlStreamCarrier{
+		reader:  inbound,
+		writer:  outbound,
+		flusher: flusher,
+		closer:  stream,
+	}, true, nil)
+	defer multiplexer.Close()
+
+	// Accept the request streams and serve each of them independently. The
+	// underlying endpoint serializes its own operations as necessary.
+	serveErrors := make(chan error, streamConcurrency)
+	for i := uint32(0); i < streamConcurrency; i++ {
+		stream, err := multiplexer.AcceptStream(context.Background())
+		if err != nil {
+			return fmt.Errorf("unable to accept request stream: %w", err)
+		}
+		server := &endpointServer{
+			endpoint:      endpoint,
+			requestStream: newMultiplexedRequestStream(stream),
+		}
+		go func() {
+			serveErrors <- server.serve()
+		}()
+	}
+
+	// Serve until an error occurs on any request stream.
+	return <-serveErrors
 }
 
 // serve is the main request handling loop.
@@ -188,6 +234,10 @@ func (s *endpointServer) serve() error {
 			if err := s.serveTransition(request.Transition); err != nil {
 				return fmt.Errorf("unable to serve transition request: %w", err)
 			}
+		} else if request.Verify != nil {
+			if err := s.serveVerify(request.Verify); err != nil {
+				return fmt.Errorf("unable to serve verify request: %w", err)
+			}
 		} else {
 			// TODO: Should we panic here? The request validation already
 			// ensures that one and only one message component is set, so we
@@ -301,14 +351,38 @@ func (s *endpointServer) serveScan(request *ScanRequest) error {
 		// Create an rsync engine.
 		engine := rsync.NewEngine()
 
-		// Perform a scan and set up the response.
+		// If the underlying endpoint tracks scan generations, then record the
+		// generation before scanning.
+		tracker, tracked := s.endpoint.(scanGenerationTracker)
+		var generation uint64
+		if tracked {
+			generation = tracker.ScanGeneration()
+		}
+
+		// Perform a scan and determine whether or not the scan generation
+		// remained stable, in which case the resulting snapshot can be
+		// identified by that generation. We still perform the scan in this
+		// case (which will be inexpensive if the generation is stable) because
+		// the endpoint tracks scan operations to regulate other operations.
+		snapshot, err, tryAgain := s.endpoint.Scan(ctx, nil, request.Full, request.Scope, request.Deferred)
+		if tracked && tracker.ScanGeneration() != generation {
+			generation = 0
+		}
+
+		// Set up the response. If the snapshot corresponds to the generation
+		// provided by the client, then the client already has the snapshot
+		// and we can avoid serializing and transmitting it.
 		var response *ScanResponse
-		snapshot, err, tryAgain := s.endpoint.Scan(ctx, nil, request.Full)
 		if err != nil {
 			response = &ScanResponse{
 				Error:    err.Error(),
 				TryAgain: tryAgain,
 			}
+		} else if generation != 0 && generation == request.Generation {
+			response = &ScanResponse{
+				Generation: generation,
+				Unchanged:  true,
+			}
 		} else if snapshotBytes, err := marshaling.Marshal(snapshot); err != nil {
 			response = &ScanResponse{
 				Error: fmt.Errorf("unable to marshal snapshot: %w", err).Error(),
@@ -320,9 +394,14 @@ func (s *endpointServer) serveScan(request *ScanRequest) error {
 					request.BaselineSnapshotSignature,
 					0,
 				),
+				Generation: generation,
 			}
 		}
 
+		// Record the watch overflow count and acceleration status.
+		response.WatchOverflows = s.endpoint.WatchOverflows()
+		response.AccelerationUnavailable = s.endpoint.AccelerationUnavailable()
+
 		// Send the response.
 		if err := s.encodeAndFlush(response); err != nil {
 			responseSendErrors <- fmt.Errorf("unable to transmit response: %w", err)
@@ -367,7 +446,7 @@ func (s *endpointServer) serveStage(request *StageRequest) error {
 	}
 
 	// Begin staging.
-	paths, signatures, receiver, err := s.endpoint.Stage(request.Paths, request.Digests)
+	paths, signatures, receiver, deferred, err := s.endpoint.Stage(request.Paths, request.Digests)
 	if err != nil {
 		s.encodeAndFlush(&StageResponse{Error: err.Error()})
 		return fmt.Errorf("unable to begin staging: %w", err)
@@ -381,10 +460,19 @@ func (s *endpointServer) serveStage(request *StageRequest) error {
 		responsePaths = nil
 	}
 
+	// If the client requested operation data compression and the receiver is
+	// able to decompress the requested algorithm, then agree to its use.
+	var decompression compression.Algorithm
+	if len(paths) > 0 && !request.Compression.IsDefault() && receiver.SupportsDecompression(request.Compression) {
+		decompression = request.Compression
+	}
+
 	// Send the response.
 	response := &StageResponse{
-		Paths:      responsePaths,
-		Signatures: signatures,
+		Paths:       responsePaths,
+		Signatures:  signatures,
+		Deferred:    deferred,
+		Compression: decompression,
 	}
 	if err = s.encodeAndFlush(response); err != nil {
 		return fmt.Errorf("unable to send stage response: %w", err)
@@ -403,6 +491,12 @@ func (s *endpointServer) serveStage(request *StageRequest) error {
 		return fmt.Errorf("unable to decode and forward rsync operations: %w", err)
 	}
 
+	// Acknowledge completion of staging so that the remote knows that staged
+	// files are visible to operations on other request streams.
+	if err = s.encodeAndFlush(&StageCompletionResponse{}); err != nil {
+		return fmt.Errorf("unable to send stage completion response: %w", err)
+	}
+
 	// Success.
 	return nil
 }
@@ -414,9 +508,10 @@ func (s *endpointServer) serveSupply(request *SupplyRequest) error {
 		return fmt.Errorf("invalid supply request: %w", err)
 	}
 
-	// Create an encoding receiver to transmit rsync operations to the remote.
+	// Create an encoding receiver to transmit rsync operations to the remote,
+	// allowing operation data compression if the remote indicated support.
 	encoder := &protobufRsyncEncoder{encoder: s.encoder, flusher: s.flusher}
-	receiver := rsync.NewEncodingReceiver(encoder)
+	receiver := rsync.NewDecompressingEncodingReceiver(encoder, request.Compression)
 
 	// Perform supplying.
 	if err := s.endpoint.Supply(request.Paths, request.Signatures, receiver); err != nil {
@@ -456,7 +551,7 @@ func (s *endpointServer) serveTransition(request *TransitionRequest) error {
 	go func() {
 		// Perform the transition and set up the response.
 		var response *TransitionResponse
-		results, problems, stagerMissingFiles, err := s.endpoint.Transition(ctx, request.Transitions)
+		results, problems, stagerMissingFiles, err := s.endpoint.Transition(ctx, request.Transitions, request.DryRun)
 		if err != nil {
 			response = &TransitionResponse{
 				Error: err.Error(),
@@ -472,6 +567,7 @@ func (s *endpointServer) serveTransition(request *TransitionRequest) error {
 				Results:            wrappedResults,
 				Problems:           problems,
 				StagerMissingFiles: stagerMissingFiles,
+				OversizedFiles:     s.endpoint.OversizedFiles(),
 			}
 		}
 
@@ -510,3 +606,89 @@ func (s *endpointServer) serveTransition(request *TransitionRequest) error {
 	// Success.
 	return nil
 }
+
+// serveVerify serves a verify request.
+func (s *endpointServer) serveVerify(request *VerifyRequest) error {
+	// Ensure the request is valid.
+	if err := request.ensureValid(); err != nil {
+		return fmt.Errorf("invalid verify request: %w", err)
+	}
+
+	// Create a cancellable context for executing the verification.
+	ctx, cancel := context.WithCancel(context.Background())
+
+	// Start a Goroutine to watch for the completion request.
+	completionReceiveErrors := make(chan error, 1)
+	go func() {
+		request := &VerifyCompletionRequest{}
+		if err := s.decoder.Decode(request); err != nil {
+			completionReceiveErrors <- fmt.Errorf("unable to receive completion request: %w", err)
+		} else if err = request.ensureValid(); err != nil {
+			completionReceiveErrors <- fmt.Errorf("received invalid completion request: %w", err)
+		} else {
+			completionReceiveErrors <- nil
+		}
+	}()
+
+	// Start a Goroutine to execute the verification and send a response when
+	// done.
+	responseSendErrors := make(chan error, 1)
+	go func() {
+		// Configure Protocol Buffers marshaling to be deterministic.
+		marshaling := proto.MarshalOptions{Deterministic: true}
+
+		// Perform verification and set up the response.
+		var response *VerifyResponse
+		if snapshot, err := s.endpoint.Verify(ctx); err != nil {
+			response = &VerifyResponse{
+				Error: err.Error(),
+			}
+		} else if snapshotBytes, err := marshaling.Marshal(snapshot); err != nil {
+			response = &VerifyResponse{
+				Error: fmt.Errorf("unable to marshal snapshot: %w", err).Error(),
+			}
+		} else {
+			response = &VerifyResponse{
+				SnapshotDelta: rsync.NewEngine().DeltifyBytes(
+					snapshotBytes,
+					request.BaselineSnapshotSignature,
+					0,
+				),
+			}
+		}
+
+		// Send the response.
+		if err := s.encodeAndFlush(response); err != nil {
+			responseSendErrors <- fmt.Errorf("unable to transmit response: %w", err)
+		} else {
+			responseSendErrors <- nil
+		}
+	}()
+
+	// Wait for both a completion request to be received and a response to be
+	// sent. Both of these will occur, though their order is not known. If the
+	// completion request is received first, then we cancel the subcontext to
+	// preempt the verification and force transmission of a response. If the
+	// response is sent first, then we know the completion request is on its
+	// way. In this case, we still cancel the subcontext we created as required
+	// by the context package to avoid leaking resources.
+	var responseSendErr, completionReceiveErr error
+	select {
+	case completionReceiveErr = <-completionReceiveErrors:
+		cancel()
+		responseSendErr = <-responseSendErrors
+	case responseSendErr = <-responseSendErrors:
+		cancel()
+		completionReceiveErr = <-completionReceiveErrors
+	}
+
+	// Check for errors.
+	if responseSendErr != nil {
+		return responseSendErr
+	} else if completionReceiveErr != nil {
+		return completionReceiveErr
+	}
+
+	// Success.
+	return nil
+}
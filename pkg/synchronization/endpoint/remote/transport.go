@@ -0,0 +1,196 @@
+package remote
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"sync"
+
+	"google.golang.org/protobuf/proto"
+
+	"github.com/mutagen-io/mutagen/pkg/encoding"
+	"github.com/mutagen-io/mutagen/pkg/multiplexing"
+	streampkg "github.com/mutagen-io/mutagen/pkg/stream"
+)
+
+// The remote endpoint protocol operates over one or more request streams. Once
+// initialization and clock exchange have completed over the control stream, the
+// control stream either becomes the sole request stream (if the effective
+// stream concurrency is 1) or is multiplexed into the specified number of
+// request streams (if the effective stream concurrency is greater than 1). Each
+// request stream is served independently by the remote endpoint.
+//
+// The following ordering and consistency guarantees apply:
+//
+//   - Each endpoint operation (including any completion request and any rsync
+//     operations that it transmits or receives) executes on exactly one request
+//     stream, which it holds exclusively until it completes. For staging, the
+//     operation completes when the receiver returned by Stage is finalized,
+//     which waits for the remote to acknowledge that it has processed all
+//     rsync operations and that the staged files are visible.
+//   - Operations on a single request stream are processed by the remote in the
+//     order in which they were sent.
+//   - Operations on different request streams are processed concurrently and
+//     have no ordering guarantees relative to one another. An operation is only
+//     guaranteed to observe the effects of another operation if it was started
+//     after that operation completed.
+//   - Values reported alongside operation responses (e.g. watch overflow and
+//     oversized file counts) reflect the most recently completed operation of
+//     the relevant type.
+
+// requestStream encapsulates the encoding and decoding state for a single
+// request stream.
+type requestStream struct {
+	// flusher flushes the outbound stream.
+	flusher streampkg.Flusher
+	// encoder is the stream encoder.
+	encoder *encoding.ProtobufEncoder
+	// decoder is the stream decoder.
+	decoder *encoding.ProtobufDecoder
+}
+
+// newMultiplexedRequestStream creates a new request stream on top of a
+// multiplexed stream. Compression is already handled by the underlying control
+// stream, so only buffering is added.
+func newMultiplexedRequestStream(stream io.ReadWriter) *requestStream {
+	inbound := bufio.NewReaderSize(stream, requestStreamBufferSize)
+	outbound := bufio.NewWriterSize(stream, requestStreamBufferSize)
+	return &requestStream{
+		flusher: outbound,
+		encoder: encoding.NewProtobufEncoder(outbound),
+		decoder: encoding.NewProtobufDecoder(inbound),
+	}
+}
+
+// multiplexedStream adapts a multiplexed stream so that its errors also wrap
+// the internal error (if any) that caused the multiplexer to close. This allows
+// the underlying cause of a failure (e.g. agent termination) to be identified.
+type multiplexedStream struct {
+	// Stream is the underlying multiplexed stream.
+	*multiplexing.Stream
+	// multiplexer is the parent multiplexer.
+	multiplexer *multiplexing.Multiplexer
+}
+
+// annotate wraps an error with the multiplexer's internal error, if any.
+func (s *multiplexedStream) annotate(err error) error {
+	if err == nil {
+		return nil
+	} else if internalErr := s.multiplexer.InternalError(); internalErr != nil {
+		return fmt.Errorf("%w: %w", err, internalErr)
+	}
+	return err
+}
+
+// Read implements io.Reader.Read.
+func (s *multiplexedStream) Read(buffer []byte) (int, error) {
+	n, err := s.Stream.Read(buffer)
+	return n, s.annotate(err)
+}
+
+// Write implements io.Writer.Write.
+func (s *multiplexedStream) Write(data []byte) (int, error) {
+	n, err := s.Stream.Write(data)
+	return n, s.annotate(err)
+}
+
+// encodeAndFlush encodes a Protocol Buffers message using the underlying
+// encoder and then flushes the stream.
+func (s *requestStream) encodeAndFlush(message proto.Message) error {
+	if err := s.encoder.Encode(message); err != nil {
+		return err
+	} else if err = s.flusher.Flush(); err != nil {
+		return fmt.Errorf("message transmission failed: %w", err)
+	}
+	return nil
+}
+
+// controlStreamCarrier adapts the buffered and compressed control stream to
+// serve as a multiplexing.Carrier. Because the multiplexer doesn't wait for its
+// reader and writer Goroutines to terminate when closed, the carrier guarantees
+// that no reads or writes are in progress (or will be performed) once it has
+// been closed, allowing the control stream pipeline to be safely closed after
+// the multiplexer.
+type controlStreamCarrier struct {
+	// reader is the buffered inbound control stream.
+	reader *bufio.Reader
+	// writer is the buffered outbound control stream.
+	writer io.Writer
+	// flusher flushes the outbound control stream.
+	flusher streampkg.Flusher
+	// closer closes the underlying transport stream. It must unblock any
+	// pending reads and writes.
+	closer io.Closer
+	// readLock serializes reads and closure.
+	readLock sync.Mutex
+	// writeLock serializes writes and closure.
+	writeLock sync.Mutex
+	// closed indicates whether or not the carrier has been closed. It may be
+	// read while holding either lock, but must be set while holding both.
+	closed bool
+}
+
+// Read implements io.Reader.Read.
+func (c *controlStreamCarrier) Read(buffer []byte) (int, error) {
+	c.readLock.Lock()
+	defer c.readLock.Unlock()
+	if c.closed {
+		return 0, io.ErrClosedPipe
+	}
+	return c.reader.Read(buffer)
+}
+
+// ReadByte implements io.ByteReader.ReadByte.
+func (c *controlStreamCarrier) ReadByte() (byte, error) {
+	c.readLock.Lock()
+	defer c.readLock.Unlock()
+	if c.closed {
+		return 0, io.ErrClosedPipe
+	}
+	return c.reader.ReadByte()
+}
+
+// Discard implements multiplexing.Carrier.Discard.
+func (c *controlStreamCarrier) Discard(n int) (int, error) {
+	c.readLock.Lock()
+	defer c.readLock.Unlock()
+	if c.closed {
+		return 0, io.ErrClosedPipe
+	}
+	return c.reader.Discard(n)
+}
+
+// Write implements io.Writer.Write. It flushes the outbound control stream
+// after each write, since the multiplexer expects writes to be transmitted.
+func (c *controlStreamCarrier) Write(data []byte) (int, error) {
+	c.writeLock.Lock()
+	defer c.writeLock.Unlock()
+	if c.closed {
+		return 0, io.ErrClosedPipe
+	}
+	n, err := c.writer.Write(data)
+	if err != nil {
+		return n, err
+	} else if err = c.flusher.Flush(); err != nil {
+		return n, fmt.Errorf("unable to flush control stream: %w", err)
+	}
+	return n, nil
+}
+
+// Close implements io.Closer.Close.
+func (c *controlStreamCarrier) Close() error {
+	// Close the underlying transport stream to unblock any pending reads and
+	// writes.
+	err := c.closer.Close()
+
+	// Wait for any pending reads and writes to complete and mark the carrier
+	// as closed.
+	c.readLock.Lock()
+	c.writeLock.Lock()
+	c.closed = true
+	c.writeLock.Unlock()
+	c.readLock.Unlock()
+
+	// Done.
+	return err
+}
@@ -0,0 +1,31 @@
+package synchronization
+
+import (
+	"fmt"
+)
+
+// entryCountApproachingMaximum determines whether or not an entry count has
+// reached 90% of the specified maximum entry count, at which point a warning
+// should be issued ahead of the count exceeding the maximum (which will cause
+// scans to fail). A maximum of 0 is treated as no maximum.
+func entryCountApproachingMaximum(count, maximum uint64) bool {
+	// Handle the case of no maximum.
+	if maximum == 0 {
+		return false
+	}
+
+	// Compute the threshold in a manner that avoids overflow.
+	return count >= maximum-maximum/10
+}
+
+// entryCountWarning computes the warning (if any) to report for an endpoint
+// entry count. The endpoint argument should be "Alpha" or "Beta". If no warning
+// is required, then an empty string is returned.
+func entryCountWarning(endpoint string, count, maximum uint64) string {
+	if !entryCountApproachingMaximum(count, maximum) {
+		return ""
+	}
+	return fmt.Sprintf("%s entry count (%d) is approaching the maximum allowed entry count (%d)",
+		endpoint, count, maximum,
+	)
+}
@@ -0,0 +1,49 @@
+package synchronization
+
+import (
+	"math"
+	"testing"
+)
+
+// TestEntryCountApproachingMaximum tests entryCountApproachingMaximum.
+func TestEntryCountApproachingMaximum(t *testing.T) {
+	// Define test cases.
+	testCases := []struct {
+		count    uint64
+		maximum  uint64
+		expected bool
+	}{
+		{0, 0, false},
+		{math.MaxUint64, 0, false},
+		{0, 10, false},
+		{8, 10, false},
+		{9, 10, true},
+		{10, 10, true},
+		{11, 10, true},
+		{899, 1000, false},
+		{900, 1000, true},
+		{1000, math.MaxUint64, false},
+		{math.MaxUint64, math.MaxUint64, true},
+	}
+
+	// Process test cases.
+	for i, testCase := range testCases {
+		if approaching := entryCountApproachingMaximum(testCase.count, testCase.maximum); approaching != testCase.expected {
+			t.Errorf("test case %d: approaching (%t) does not match expected (%t)",
+				i, approaching, testCase.expected,
+			)
+		}
+	}
+}
+
+// TestEntryCountWarning tests that entryCountWarning only generates warnings
+// near the maximum and that it includes the relevant counts.
+func TestEntryCountWarning(t *testing.T) {
+	if warning := entryCountWarning("Alpha", 10, 100); warning != "" {
+		t.Error("unexpected warning:", warning)
+	}
+	expected := "Beta entry count (95) is approaching the maximum allowed entry count (100)"
+	if warning := entryCountWarning("Beta", 95, 100); warning != expected {
+		t.Errorf("warning does not match expected: %q != %q", warning, expected)
+	}
+}
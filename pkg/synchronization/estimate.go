@@ -0,0 +1,298 @@
+package synchronization
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/mutagen-io/mutagen/pkg/filesystem/behavior"
+	"github.com/mutagen-io/mutagen/pkg/synchronization/core"
+	"github.com/mutagen-io/mutagen/pkg/synchronization/core/ignore"
+	dockerignore "github.com/mutagen-io/mutagen/pkg/synchronization/core/ignore/docker"
+	mutagenignore "github.com/mutagen-io/mutagen/pkg/synchronization/core/ignore/mutagen"
+	"github.com/mutagen-io/mutagen/pkg/synchronization/hashing"
+)
+
+const (
+	// estimateThroughputProbeSize is the maximum number of bytes that will be
+	// read from alpha when measuring throughput for an estimate.
+	estimateThroughputProbeSize = 16 * 1024 * 1024
+	// estimateThroughputProbeDuration is the maximum amount of time that will
+	// be spent measuring throughput for an estimate.
+	estimateThroughputProbeDuration = time.Second
+)
+
+// Estimate represents an estimate of the cost of performing an initial
+// alpha-to-beta synchronization between two roots.
+type Estimate struct {
+	// Entries is the number of synchronizable entries (directories, files,
+	// symbolic links, and special files) that would need to be created or
+	// replaced on beta.
+	Entries uint64
+	// Files is the number of files included in Entries.
+	Files uint64
+	// Bytes is the total size of the files included in Files.
+	Bytes uint64
+	// Throughput is the measured read throughput (in bytes per second) of a
+	// sample of the content on alpha. It will be zero if no probe could be
+	// performed (e.g. if there were no bytes to transfer). Since the sampled
+	// files were just read by the alpha scan, the probe requests their
+	// eviction from the page cache before reading them.
+	Throughput float64
+	// ThroughputCached indicates that page cache eviction couldn't be
+	// requested for the sampled files (e.g. because the platform doesn't
+	// support it), in which case Throughput likely reflects cached reads and
+	// may vastly exceed the actual read throughput.
+	ThroughputCached bool
+	// Duration is the estimated time required to read Bytes from alpha at the
+	// measured throughput. It doesn't account for transmission or for writing
+	// content on beta, so it's a lower bound on the time that an initial
+	// synchronization will take. It will be zero if Throughput is zero.
+	Duration time.Duration
+}
+
+// estimateScan performs a transient scan of a root for estimation purposes,
+// using the specified session version and configuration. Filesystem behavior
+// is assumed rather than probed so that the root is never modified.
+func estimateScan(ctx context.Context, root string, version Version, configuration *Configuration) (*core.Snapshot, *core.Cache, error) {
+	// Compute the effective hashing algorithm and digest length and create the
+	// hasher. We don't apply digest sampling since estimation scans don't use a
+	// cache, meaning that sampled digests couldn't be used to avoid hashing.
+	hashingAlgorithm := configuration.HashingAlgorithm
+	if hashingAlgorithm.IsDefault() {
+		hashingAlgorithm = version.DefaultHashingAlgorithm()
+	}
+	digestLength := configuration.DigestLength
+	if digestLength == 0 {
+		digestLength = version.DefaultDigestLength()
+	}
+	hasher := hashing.NewTruncatingFactory(hashingAlgorithm.Factory(), digestLength)()
+
+	// Compute the effective symbolic link mode.
+	symbolicLinkMode := configuration.SymbolicLinkMode
+	if symbolicLinkMode.IsDefault() {
+		symbolicLinkMode = version.DefaultSymbolicLinkMode()
+	}
+
+	// Compute the effective special file mode.
+	specialFileMode := configuration.SpecialFileMode
+	if specialFileMode.IsDefault() {
+		specialFileMode = version.DefaultSpecialFileMode()
+	}
+
+	// Compute the effective name normalization mode.
+	nameNormalizationMode := configuration.NameNormalizationMode
+	if nameNormalizationMode.IsDefault() {
+		nameNormalizationMode = version.DefaultNameNormalizationMode()
+	}
+
+	// Compute the effective permissions mode.
+	permissionsMode := configuration.PermissionsMode
+	if permissionsMode.IsDefault() {
+		permissionsMode = version.DefaultPermissionsMode()
+	}
+
+	// Compute the effective ignore syntax.
+	ignoreSyntax := configuration.IgnoreSyntax
+	if ignoreSyntax.IsDefault() {
+		ignoreSyntax = version.DefaultIgnoreSyntax()
+	}
+
+	// Compute a combined ignore list and create the ignorer.
+	var ignores []string
+	ignores = append(ignores, configuration.DefaultIgnores...)
+	ignores = append(ignores, configuration.Ignores...)
+	var ignorer ignore.Ignorer
+	if ignoreSyntax == ignore.Syntax_SyntaxMutagen {
+		if i, err := mutagenignore.NewIgnorer(ignores); err != nil {
+			return nil, nil, fmt.Errorf("unable to create Mutagen-style ignorer: %w", err)
+		} else {
+			ignorer = i
+		}
+	} else if ignoreSyntax == ignore.Syntax_SyntaxDocker {
+		if i, err := dockerignore.NewIgnorer(ignores); err != nil {
+			return nil, nil, fmt.Errorf("unable to create Docker-style ignorer: %w", err)
+		} else {
+			ignorer = i
+		}
+	} else {
+		panic("unhandled ignore syntax")
+	}
+
+	// Compute the effective VCS ignore mode and add VCS ignores if necessary.
+	ignoreVCSMode := configuration.IgnoreVCSMode
+	if ignoreVCSMode.IsDefault() {
+		ignoreVCSMode = version.DefaultIgnoreVCSMode()
+	}
+	if ignoreVCSMode == ignore.IgnoreVCSMode_IgnoreVCSModeIgnore {
+		ignorer = ignore.IgnoreVCS(ignorer)
+	}
+
+	// Compute the effective OS metadata ignore mode and add OS metadata ignores
+	// if necessary.
+	ignoreOSMetadataMode := configuration.IgnoreOSMetadataMode
+	if ignoreOSMetadataMode.IsDefault() {
+		ignoreOSMetadataMode = version.DefaultIgnoreOSMetadataMode()
+	}
+	if ignoreOSMetadataMode == ignore.IgnoreOSMetadataMode_IgnoreOSMetadataModeIgnore {
+		ignorer = ignore.IgnoreOSMetadata(ignorer, nil)
+	}
+
+	// Restrict the ignorer to the manifest, if any. This is performed last so
+	// that ignores still apply to manifested content.
+	ignorer = ignore.IgnoreUnmanifested(ignorer, configuration.Manifest)
+
+	// Perform a cold scan.
+	snapshot, cache, _, err := core.Scan(
+		ctx,
+		root,
+		nil, nil,
+		hasher, nil, core.CacheTrustMode_CacheTrustModeStrict,
+		ignorer, nil,
+		behavior.ProbeMode_ProbeModeAssume, nil,
+		symbolicLinkMode,
+		specialFileMode,
+		nameNormalizationMode,
+		permissionsMode,
+		false,
+		false,
+		0,
+		0,
+		nil, 0,
+	)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	// Success.
+	return snapshot, cache, nil
+}
+
+// tallyEstimateEntry recursively adds the synchronizable contents of an entry
+// to an estimate, using the specified cache to determine file sizes and
+// recording file paths in the specified list.
+func tallyEstimateEntry(estimate *Estimate, path string, entry *core.Entry, cache *core.Cache, files *[]string) {
+	// Ignore nil and unsynchronizable content, since it won't be transferred.
+	if entry == nil {
+		return
+	} else if entry.Kind != core.EntryKind_Directory &&
+		entry.Kind != core.EntryKind_File &&
+		entry.Kind != core.EntryKind_SymbolicLink &&
+		entry.Kind != core.EntryKind_SpecialFile {
+		return
+	}
+
+	// Count the entry and, if it's a file, its size.
+	estimate.Entries++
+	if entry.Kind == core.EntryKind_File {
+		estimate.Files++
+		if cacheEntry, ok := cache.Entries[path]; ok {
+			estimate.Bytes += cacheEntry.Size
+		}
+		*files = append(*files, path)
+	}
+
+	// Handle contents.
+	for name, child := range entry.Contents {
+		childPath := name
+		if path != "" {
+			childPath = path + "/" + name
+		}
+		tallyEstimateEntry(estimate, childPath, child, cache, files)
+	}
+}
+
+// measureEstimateThroughput measures read throughput by reading the specified
+// files (relative to root) until either the probe size or probe duration has
+// been exceeded. Before each file is read, its eviction from the page cache is
+// requested, since the files will typically have just been read by scanning.
+// It returns the measured throughput in bytes per second (or 0 if no content
+// could be read) and whether or not eviction couldn't be requested for any of
+// the files that were read.
+func measureEstimateThroughput(ctx context.Context, root string, files []string) (float64, bool) {
+	// Track the amount of content read, whether or not any of it may have been
+	// cached, and the start time.
+	var total int64
+	var cached bool
+	start := time.Now()
+
+	// Read files until we've exhausted either our budget or our inputs.
+	for _, path := range files {
+		// Check for cancellation and probe budget exhaustion.
+		if ctx.Err() != nil {
+			break
+		} else if total >= estimateThroughputProbeSize || time.Since(start) >= estimateThroughputProbeDuration {
+			break
+		}
+
+		// Read the file. We ignore failures here since the file may have been
+		// modified or removed since scanning and this is only an estimate.
+		file, err := os.Open(filepath.Join(root, filepath.FromSlash(path)))
+		if err != nil {
+			continue
+		}
+		evicted := evictFromPageCache(file)
+		copied, _ := io.Copy(io.Discard, io.LimitReader(file, estimateThroughputProbeSize-total))
+		file.Close()
+		if copied > 0 && !evicted {
+			cached = true
+		}
+		total += copied
+	}
+
+	// Compute the throughput.
+	elapsed := time.Since(start)
+	if total == 0 || elapsed <= 0 {
+		return 0, false
+	}
+	return float64(total) / elapsed.Seconds(), cached
+}
+
+// EstimateLocal computes an estimate of the cost of an initial alpha-to-beta
+// synchronization between two local roots. Both roots are scanned transiently
+// and neither is modified. The specified configuration should be the merged
+// session configuration, though endpoint-specific behavior is not considered.
+func EstimateLocal(ctx context.Context, alpha, beta string, version Version, configuration *Configuration) (*Estimate, error) {
+	// Validate the version and configuration.
+	if !version.Supported() {
+		return nil, errors.New("unknown or unsupported session version")
+	} else if configuration == nil {
+		return nil, errors.New("no configuration specified")
+	} else if err := configuration.EnsureValid(false); err != nil {
+		return nil, fmt.Errorf("invalid configuration: %w", err)
+	}
+
+	// Scan both roots.
+	alphaSnapshot, alphaCache, err := estimateScan(ctx, alpha, version, configuration)
+	if err != nil {
+		return nil, fmt.Errorf("unable to scan alpha: %w", err)
+	}
+	betaSnapshot, _, err := estimateScan(ctx, beta, version, configuration)
+	if err != nil {
+		return nil, fmt.Errorf("unable to scan beta: %w", err)
+	}
+
+	// Compute the changes needed to make beta look like alpha and tally the
+	// content that they'd require transferring.
+	result := &Estimate{}
+	var files []string
+	for _, change := range core.Diff(betaSnapshot.Content, alphaSnapshot.Content) {
+		tallyEstimateEntry(result, change.Path, change.New, alphaCache, &files)
+	}
+
+	// If there's content to transfer, then perform a throughput probe and use
+	// it to compute a rough transfer time estimate.
+	if result.Bytes > 0 {
+		result.Throughput, result.ThroughputCached = measureEstimateThroughput(ctx, alpha, files)
+		if result.Throughput > 0 {
+			result.Duration = time.Duration(float64(result.Bytes) / result.Throughput * float64(time.Second))
+		}
+	}
+
+	// Success.
+	return result, nil
+}
@@ -0,0 +1,15 @@
+package synchronization
+
+import (
+	"os"
+
+	"golang.org/x/sys/unix"
+)
+
+// evictFromPageCache requests that the operating system evict a file's content
+// from the page cache so that subsequent reads are served from storage. It
+// returns whether or not the request was successful. Successful requests are
+// advisory and may not evict all content (e.g. on memory-backed filesystems).
+func evictFromPageCache(file *os.File) bool {
+	return unix.Fadvise(int(file.Fd()), 0, 0, unix.FADV_DONTNEED) == nil
+}
@@ -0,0 +1,13 @@
+//go:build !linux
+
+package synchronization
+
+import (
+	"os"
+)
+
+// evictFromPageCache is a no-op on platforms that don't support page cache
+// eviction requests. It always returns false.
+func evictFromPageCache(_ *os.File) bool {
+	return false
+}
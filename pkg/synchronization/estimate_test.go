@@ -0,0 +1,119 @@
+package synchronization
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"runtime"
+	"testing"
+	"time"
+)
+
+// TestEstimateLocal tests that EstimateLocal computes an estimate matching a
+// known difference between two roots and that it doesn't modify either root.
+func TestEstimateLocal(t *testing.T) {
+	// Create alpha and beta roots with a shared directory and file.
+	alpha := t.TempDir()
+	beta := t.TempDir()
+	for _, root := range []string{alpha, beta} {
+		if err := os.Mkdir(filepath.Join(root, "shared"), 0755); err != nil {
+			t.Fatal("unable to create shared directory:", err)
+		} else if err = os.WriteFile(filepath.Join(root, "shared", "file"), []byte("hello"), 0644); err != nil {
+			t.Fatal("unable to create shared file:", err)
+		}
+	}
+
+	// Create alpha-only content.
+	if err := os.WriteFile(filepath.Join(alpha, "file"), []byte("0123456789"), 0644); err != nil {
+		t.Fatal("unable to create alpha file:", err)
+	} else if err = os.Mkdir(filepath.Join(alpha, "directory"), 0755); err != nil {
+		t.Fatal("unable to create alpha directory:", err)
+	} else if err = os.WriteFile(filepath.Join(alpha, "directory", "file"), []byte("abc"), 0644); err != nil {
+		t.Fatal("unable to create alpha nested file:", err)
+	}
+
+	// Compute the estimate.
+	estimate, err := EstimateLocal(context.Background(), alpha, beta, DefaultVersion, &Configuration{})
+	if err != nil {
+		t.Fatal("unable to compute estimate:", err)
+	}
+
+	// Verify the estimate.
+	if estimate.Entries != 3 {
+		t.Error("estimated entry count incorrect:", estimate.Entries, "!=", 3)
+	}
+	if estimate.Files != 2 {
+		t.Error("estimated file count incorrect:", estimate.Files, "!=", 2)
+	}
+	if estimate.Bytes != 13 {
+		t.Error("estimated byte count incorrect:", estimate.Bytes, "!=", 13)
+	}
+	if estimate.Throughput < 0 {
+		t.Error("negative throughput measured")
+	}
+	if estimate.Throughput == 0 && estimate.Duration != 0 {
+		t.Error("non-zero duration estimated without throughput measurement")
+	} else if estimate.Throughput > 0 {
+		expected := time.Duration(float64(estimate.Bytes) / estimate.Throughput * float64(time.Second))
+		if estimate.Duration != expected {
+			t.Error("estimated duration incorrect:", estimate.Duration, "!=", expected)
+		}
+	}
+
+	// Verify that beta wasn't modified.
+	if contents, err := os.ReadDir(beta); err != nil {
+		t.Fatal("unable to read beta contents:", err)
+	} else if len(contents) != 1 || contents[0].Name() != "shared" {
+		t.Error("beta contents modified by estimate")
+	}
+}
+
+// TestEstimateLocalIdentical tests that EstimateLocal returns an empty estimate
+// for identical roots.
+func TestEstimateLocalIdentical(t *testing.T) {
+	// Create identical alpha and beta roots.
+	alpha := t.TempDir()
+	beta := t.TempDir()
+	for _, root := range []string{alpha, beta} {
+		if err := os.WriteFile(filepath.Join(root, "file"), []byte("content"), 0644); err != nil {
+			t.Fatal("unable to create file:", err)
+		}
+	}
+
+	// Compute the estimate.
+	estimate, err := EstimateLocal(context.Background(), alpha, beta, DefaultVersion, &Configuration{})
+	if err != nil {
+		t.Fatal("unable to compute estimate:", err)
+	}
+
+	// Verify the estimate.
+	if *estimate != (Estimate{}) {
+		t.Error("non-empty estimate for identical roots:", *estimate)
+	}
+}
+
+// TestMeasureEstimateThroughput tests that measureEstimateThroughput measures a
+// non-zero throughput for readable content and that it only reports cached
+// measurements on platforms that don't support page cache eviction.
+func TestMeasureEstimateThroughput(t *testing.T) {
+	// Create a root with a file and record it alongside a missing file.
+	root := t.TempDir()
+	if err := os.WriteFile(filepath.Join(root, "file"), make([]byte, 1024*1024), 0644); err != nil {
+		t.Fatal("unable to create file:", err)
+	}
+	files := []string{"missing", "file"}
+
+	// Perform a measurement.
+	throughput, cached := measureEstimateThroughput(context.Background(), root, files)
+	if throughput <= 0 {
+		t.Error("non-positive throughput measured:", throughput)
+	}
+	if expected := runtime.GOOS != "linux"; cached != expected {
+		t.Error("cached measurement status incorrect:", cached, "!=", expected)
+	}
+
+	// Verify that a measurement without readable content yields nothing.
+	if throughput, cached := measureEstimateThroughput(context.Background(), root, files[:1]); throughput != 0 || cached {
+		t.Error("measurement without readable content produced results")
+	}
+}
@@ -0,0 +1,108 @@
+package synchronization
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+
+	"github.com/mutagen-io/mutagen/pkg/synchronization/rsync"
+)
+
+const (
+	// MaximumFetchSize is the maximum size of file content that can be fetched
+	// from an endpoint on demand. It's chosen to fit comfortably within the
+	// maximum gRPC message size used by the daemon.
+	MaximumFetchSize = 16 * 1024 * 1024
+)
+
+var (
+	// errFetchTooLarge indicates that fetched content exceeded the maximum
+	// fetch size.
+	errFetchTooLarge = fmt.Errorf("file exceeds maximum fetch size (%d bytes)", MaximumFetchSize)
+)
+
+// fetchTarget is an io.WriteCloser that accumulates fetched content in memory,
+// refusing content that exceeds the maximum fetch size.
+type fetchTarget struct {
+	// sinker is the parent sinker.
+	sinker *fetchSinker
+	// buffer stores the received content.
+	buffer bytes.Buffer
+	// failed indicates whether or not a write has failed.
+	failed bool
+}
+
+// Write implements io.Writer.Write.
+func (t *fetchTarget) Write(data []byte) (int, error) {
+	if t.buffer.Len()+len(data) > MaximumFetchSize {
+		t.failed = true
+		t.sinker.err = errFetchTooLarge
+		return 0, errFetchTooLarge
+	}
+	return t.buffer.Write(data)
+}
+
+// Close implements io.Closer.Close. If all writes succeeded, then it records
+// the accumulated content as the fetch result.
+func (t *fetchTarget) Close() error {
+	if !t.failed {
+		t.sinker.content = t.buffer.Bytes()
+		t.sinker.complete = true
+	}
+	return nil
+}
+
+// fetchSinker is an rsync.Sinker that captures the content of a single file in
+// memory. Because the digest of fetched content isn't known in advance, no
+// digest verification is performed.
+type fetchSinker struct {
+	// content is the received content.
+	content []byte
+	// complete indicates whether or not content was fully received.
+	complete bool
+	// err records the reason that content was refused, if any.
+	err error
+}
+
+// Sink implements rsync.Sinker.Sink.
+func (s *fetchSinker) Sink(_ string, _ []byte, expectedSize uint64) (io.WriteCloser, error) {
+	if expectedSize > MaximumFetchSize {
+		s.err = errFetchTooLarge
+		return nil, errFetchTooLarge
+	}
+	return &fetchTarget{sinker: s}, nil
+}
+
+// fetchFromEndpoint fetches the content of the file at the specified path from
+// an endpoint using the endpoint's supply operation with an empty base. It
+// returns the content, any error that prevented the file from being fetched,
+// and any error that occurred with the supply operation itself. The latter
+// indicates that the endpoint should be considered failed.
+func fetchFromEndpoint(endpoint Endpoint, path string) ([]byte, error, error) {
+	// Set up the transfer parameters. We use an empty signature so that the
+	// file is transmitted in its entirety.
+	paths := []string{path}
+	signatures := []*rsync.Signature{{}}
+	sinker := &fetchSinker{}
+
+	// Create the receiver. The root is irrelevant since no base will be opened
+	// for an empty signature.
+	receiver, err := rsync.NewReceiver("", paths, [][]byte{nil}, signatures, sinker)
+	if err != nil {
+		return nil, fmt.Errorf("unable to create receiver: %w", err), nil
+	}
+
+	// Perform the transfer.
+	if err := endpoint.Supply(paths, signatures, receiver); err != nil {
+		return nil, nil, err
+	}
+
+	// Determine the result.
+	if sinker.complete {
+		return sinker.content, nil, nil
+	} else if sinker.err != nil {
+		return nil, sinker.err, nil
+	}
+	return nil, errors.New("unable to read file (it may not exist or may not be a regular file)"), nil
+}
@@ -24,8 +24,14 @@ func (a Algorithm) MarshalText() ([]byte, error) {
 		result = "sha256"
 	case Algorithm_AlgorithmXXH128:
 		result = "xxh128"
+	case Algorithm_AlgorithmXXH3:
+		result = "xxh3"
 	default:
-		result = "unknown"
+		if registered := lookupRegistered(a); registered != nil {
+			result = registered.name
+		} else {
+			result = "unknown"
+		}
 	}
 	return []byte(result), nil
 }
@@ -35,7 +41,20 @@ func (a *Algorithm) UnmarshalText(textBytes []byte) error {
 	// Convert the bytes to a string.
 	text := string(textBytes)
 
-	// Convert to a hashing algorithm.
+	// Convert to a hashing algorithm, checking built-in algorithms first and
+	// then registered algorithms.
+	if a.unmarshalBuiltin(text) {
+		return nil
+	} else if registered, ok := lookupRegisteredByName(text); ok {
+		*a = registered
+		return nil
+	}
+	return fmt.Errorf("unknown hashing algorithm specification: %s", text)
+}
+
+// unmarshalBuiltin attempts to convert a text specification to a built-in
+// hashing algorithm, returning true on success.
+func (a *Algorithm) unmarshalBuiltin(text string) bool {
 	switch text {
 	case "sha1":
 		*a = Algorithm_AlgorithmSHA1
@@ -43,12 +62,12 @@ func (a *Algorithm) UnmarshalText(textBytes []byte) error {
 		*a = Algorithm_AlgorithmSHA256
 	case "xxh128":
 		*a = Algorithm_AlgorithmXXH128
+	case "xxh3":
+		*a = Algorithm_AlgorithmXXH3
 	default:
-		return fmt.Errorf("unknown hashing algorithm specification: %s", text)
+		return false
 	}
-
-	// Success.
-	return nil
+	return true
 }
 
 // AlgorithmSupportStatus encodes support status for a hashing algorithm.
@@ -76,7 +95,12 @@ func (a Algorithm) SupportStatus() AlgorithmSupportStatus {
 		return AlgorithmSupportStatusSupported
 	case Algorithm_AlgorithmXXH128:
 		return xxh128SupportStatus()
+	case Algorithm_AlgorithmXXH3:
+		return AlgorithmSupportStatusSupported
 	default:
+		if registered := lookupRegistered(a); registered != nil && registered.supported {
+			return AlgorithmSupportStatusSupported
+		}
 		return AlgorithmSupportStatusUnsupported
 	}
 }
@@ -92,7 +116,12 @@ func (a Algorithm) Description() string {
 		return "SHA-256"
 	case Algorithm_AlgorithmXXH128:
 		return "XXH128"
+	case Algorithm_AlgorithmXXH3:
+		return "XXH3"
 	default:
+		if registered := lookupRegistered(a); registered != nil {
+			return registered.name
+		}
 		return "Unknown"
 	}
 }
@@ -107,7 +136,12 @@ func (a Algorithm) Factory() func() hash.Hash {
 		return sha256.New
 	case Algorithm_AlgorithmXXH128:
 		return newXXH128Factory()
+	case Algorithm_AlgorithmXXH3:
+		return newXXH3
 	default:
+		if registered := lookupRegistered(a); registered != nil {
+			return registered.factory
+		}
 		panic("default or unknown hashing algorithm")
 	}
 }
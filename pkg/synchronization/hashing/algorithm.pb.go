@@ -34,6 +34,10 @@ const (
 	Algorithm_AlgorithmSHA256 Algorithm = 2
 	// Algorithm_AlgorithmXXH128 specifies that XXH128 hashing should be used.
 	Algorithm_AlgorithmXXH128 Algorithm = 3
+	// Algorithm_AlgorithmXXH3 specifies that 128-bit XXH3 hashing should be
+	// used. It's a fast non-cryptographic algorithm that's only appropriate if
+	// both endpoints are trusted.
+	Algorithm_AlgorithmXXH3 Algorithm = 4
 )
 
 // Enum value maps for Algorithm.
@@ -43,12 +47,14 @@ var (
 		1: "AlgorithmSHA1",
 		2: "AlgorithmSHA256",
 		3: "AlgorithmXXH128",
+		4: "AlgorithmXXH3",
 	}
 	Algorithm_value = map[string]int32{
 		"AlgorithmDefault": 0,
 		"AlgorithmSHA1":    1,
 		"AlgorithmSHA256":  2,
 		"AlgorithmXXH128":  3,
+		"AlgorithmXXH3":    4,
 	}
 )
 
@@ -85,17 +91,18 @@ var file_synchronization_hashing_algorithm_proto_rawDesc = []byte{
 	0x0a, 0x27, 0x73, 0x79, 0x6e, 0x63, 0x68, 0x72, 0x6f, 0x6e, 0x69, 0x7a, 0x61, 0x74, 0x69, 0x6f,
 	0x6e, 0x2f, 0x68, 0x61, 0x73, 0x68, 0x69, 0x6e, 0x67, 0x2f, 0x61, 0x6c, 0x67, 0x6f, 0x72, 0x69,
 	0x74, 0x68, 0x6d, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x12, 0x07, 0x68, 0x61, 0x73, 0x68, 0x69,
-	0x6e, 0x67, 0x2a, 0x5e, 0x0a, 0x09, 0x41, 0x6c, 0x67, 0x6f, 0x72, 0x69, 0x74, 0x68, 0x6d, 0x12,
+	0x6e, 0x67, 0x2a, 0x71, 0x0a, 0x09, 0x41, 0x6c, 0x67, 0x6f, 0x72, 0x69, 0x74, 0x68, 0x6d, 0x12,
 	0x14, 0x0a, 0x10, 0x41, 0x6c, 0x67, 0x6f, 0x72, 0x69, 0x74, 0x68, 0x6d, 0x44, 0x65, 0x66, 0x61,
 	0x75, 0x6c, 0x74, 0x10, 0x00, 0x12, 0x11, 0x0a, 0x0d, 0x41, 0x6c, 0x67, 0x6f, 0x72, 0x69, 0x74,
 	0x68, 0x6d, 0x53, 0x48, 0x41, 0x31, 0x10, 0x01, 0x12, 0x13, 0x0a, 0x0f, 0x41, 0x6c, 0x67, 0x6f,
 	0x72, 0x69, 0x74, 0x68, 0x6d, 0x53, 0x48, 0x41, 0x32, 0x35, 0x36, 0x10, 0x02, 0x12, 0x13, 0x0a,
 	0x0f, 0x41, 0x6c, 0x67, 0x6f, 0x72, 0x69, 0x74, 0x68, 0x6d, 0x58, 0x58, 0x48, 0x31, 0x32, 0x38,
-	0x10, 0x03, 0x42, 0x3b, 0x5a, 0x39, 0x67, 0x69, 0x74, 0x68, 0x75, 0x62, 0x2e, 0x63, 0x6f, 0x6d,
-	0x2f, 0x6d, 0x75, 0x74, 0x61, 0x67, 0x65, 0x6e, 0x2d, 0x69, 0x6f, 0x2f, 0x6d, 0x75, 0x74, 0x61,
-	0x67, 0x65, 0x6e, 0x2f, 0x70, 0x6b, 0x67, 0x2f, 0x73, 0x79, 0x6e, 0x63, 0x68, 0x72, 0x6f, 0x6e,
-	0x69, 0x7a, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x2f, 0x68, 0x61, 0x73, 0x68, 0x69, 0x6e, 0x67, 0x62,
-	0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
+	0x10, 0x03, 0x12, 0x11, 0x0a, 0x0d, 0x41, 0x6c, 0x67, 0x6f, 0x72, 0x69, 0x74, 0x68, 0x6d, 0x58,
+	0x58, 0x48, 0x33, 0x10, 0x04, 0x42, 0x3b, 0x5a, 0x39, 0x67, 0x69, 0x74, 0x68, 0x75, 0x62, 0x2e,
+	0x63, 0x6f, 0x6d, 0x2f, 0x6d, 0x75, 0x74, 0x61, 0x67, 0x65, 0x6e, 0x2d, 0x69, 0x6f, 0x2f, 0x6d,
+	0x75, 0x74, 0x61, 0x67, 0x65, 0x6e, 0x2f, 0x70, 0x6b, 0x67, 0x2f, 0x73, 0x79, 0x6e, 0x63, 0x68,
+	0x72, 0x6f, 0x6e, 0x69, 0x7a, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x2f, 0x68, 0x61, 0x73, 0x68, 0x69,
+	0x6e, 0x67, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
 }
 
 var (
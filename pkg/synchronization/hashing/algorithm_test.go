@@ -18,6 +18,7 @@ func TestAlgorithmUnmarshal(t *testing.T) {
 		{"sha1", Algorithm_AlgorithmSHA1, false},
 		{"sha256", Algorithm_AlgorithmSHA256, false},
 		{"xxh128", Algorithm_AlgorithmXXH128, false},
+		{"xxh3", Algorithm_AlgorithmXXH3, false},
 	}
 
 	// Process test cases.
@@ -51,7 +52,8 @@ func TestAlgorithmSupportStatus(t *testing.T) {
 		{Algorithm_AlgorithmSHA1, AlgorithmSupportStatusSupported},
 		{Algorithm_AlgorithmSHA256, AlgorithmSupportStatusSupported},
 		{Algorithm_AlgorithmXXH128, xxh128SupportStatus()},
-		{(Algorithm_AlgorithmXXH128 + 1), AlgorithmSupportStatusUnsupported},
+		{Algorithm_AlgorithmXXH3, AlgorithmSupportStatusSupported},
+		{(Algorithm_AlgorithmXXH3 + 1), AlgorithmSupportStatusUnsupported},
 	}
 
 	// Process test cases.
@@ -78,7 +80,8 @@ func TestAlgorithmDescription(t *testing.T) {
 		{Algorithm_AlgorithmSHA1, "SHA-1"},
 		{Algorithm_AlgorithmSHA256, "SHA-256"},
 		{Algorithm_AlgorithmXXH128, "XXH128"},
-		{(Algorithm_AlgorithmXXH128 + 1), "Unknown"},
+		{Algorithm_AlgorithmXXH3, "XXH3"},
+		{(Algorithm_AlgorithmXXH3 + 1), "Unknown"},
 	}
 
 	// Process test cases.
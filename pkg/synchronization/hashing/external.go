@@ -0,0 +1,410 @@
+package hashing
+
+import (
+	"bufio"
+	"crypto/rand"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"hash"
+	"io"
+	"os/exec"
+	"sync"
+)
+
+// The external hasher protocol is a simple framed request/response protocol
+// spoken over the standard input and output streams of an external hasher
+// subprocess. All integers are encoded as 4-byte big-endian unsigned values.
+//
+// When started, the subprocess writes a single integer indicating the length
+// of the digests that it computes (which must be between 1 and
+// MaximumExternalDigestLength, inclusive). It then serves digest requests
+// sequentially until its standard input is closed, at which point it should
+// exit. Each digest request consists of zero or more content frames, each of
+// which is an integer content length (which must be non-zero) followed by that
+// many bytes of content, followed by a terminating zero integer. The
+// subprocess responds to each request by writing the digest of the
+// concatenated frame contents. The subprocess's standard error stream is
+// discarded.
+
+const (
+	// MaximumExternalDigestLength is the maximum digest length supported for
+	// external hashers.
+	MaximumExternalDigestLength = 512
+	// externalHasherBlockSize is the block size reported by ExternalHasher.
+	externalHasherBlockSize = 64
+	// externalHasherWriteBufferSize is the size of the buffer used to batch
+	// frames written to external hasher subprocesses.
+	externalHasherWriteBufferSize = 32 * 1024
+)
+
+// externalHasherProcess represents a running external hasher subprocess.
+type externalHasherProcess struct {
+	// command is the subprocess command.
+	command *exec.Cmd
+	// input is the buffered subprocess standard input.
+	input *bufio.Writer
+	// inputCloser closes the subprocess standard input.
+	inputCloser io.Closer
+	// output is the subprocess standard output.
+	output io.Reader
+	// frameHeader is a reusable frame header buffer.
+	frameHeader [4]byte
+}
+
+// writeFrame writes a content frame (or, for empty content, a terminator).
+func (p *externalHasherProcess) writeFrame(content []byte) error {
+	binary.BigEndian.PutUint32(p.frameHeader[:], uint32(len(content)))
+	if _, err := p.input.Write(p.frameHeader[:]); err != nil {
+		return err
+	}
+	_, err := p.input.Write(content)
+	return err
+}
+
+// finish writes a request terminator and reads the resulting digest into the
+// specified buffer.
+func (p *externalHasherProcess) finish(digest []byte) error {
+	if err := p.writeFrame(nil); err != nil {
+		return err
+	} else if err := p.input.Flush(); err != nil {
+		return err
+	}
+	_, err := io.ReadFull(p.output, digest)
+	return err
+}
+
+// terminate closes the subprocess standard input and forcibly terminates the
+// subprocess. It's used for subprocesses that have failed.
+func (p *externalHasherProcess) terminate() {
+	p.inputCloser.Close()
+	p.command.Process.Kill()
+	p.command.Wait()
+}
+
+// externalHasherPool manages the subprocesses used by a registered external
+// hasher. Each subprocess serves one hasher at a time, so the pool starts
+// additional subprocesses as needed and retains idle subprocesses for reuse.
+type externalHasherPool struct {
+	// path is the subprocess executable path.
+	path string
+	// arguments are the subprocess arguments.
+	arguments []string
+	// size is the digest length.
+	size int
+	// idleLock serializes access to idle.
+	idleLock sync.Mutex
+	// idle are the idle subprocesses.
+	idle []*externalHasherProcess
+}
+
+// start starts a new subprocess and validates its digest length.
+func (p *externalHasherPool) start() (*externalHasherProcess, int, error) {
+	// Set up the subprocess and its standard input and output pipes.
+	command := exec.Command(p.path, p.arguments...)
+	input, err := command.StdinPipe()
+	if err != nil {
+		return nil, 0, fmt.Errorf("unable to create standard input pipe: %w", err)
+	}
+	output, err := command.StdoutPipe()
+	if err != nil {
+		return nil, 0, fmt.Errorf("unable to create standard output pipe: %w", err)
+	}
+
+	// Start the subprocess.
+	if err := command.Start(); err != nil {
+		return nil, 0, fmt.Errorf("unable to start external hasher: %w", err)
+	}
+	process := &externalHasherProcess{
+		command:     command,
+		input:       bufio.NewWriterSize(input, externalHasherWriteBufferSize),
+		inputCloser: input,
+		output:      output,
+	}
+
+	// Read and validate the digest length.
+	var header [4]byte
+	if _, err := io.ReadFull(output, header[:]); err != nil {
+		process.terminate()
+		return nil, 0, fmt.Errorf("unable to read external hasher digest length: %w", err)
+	}
+	size := binary.BigEndian.Uint32(header[:])
+	if size == 0 || size > MaximumExternalDigestLength {
+		process.terminate()
+		return nil, 0, fmt.Errorf("invalid external hasher digest length: %d", size)
+	}
+
+	// Success.
+	return process, int(size), nil
+}
+
+// acquire returns an idle subprocess or starts a new one.
+func (p *externalHasherPool) acquire() (*externalHasherProcess, error) {
+	// Check for an idle subprocess.
+	p.idleLock.Lock()
+	if count := len(p.idle); count > 0 {
+		process := p.idle[count-1]
+		p.idle[count-1] = nil
+		p.idle = p.idle[:count-1]
+		p.idleLock.Unlock()
+		return process, nil
+	}
+	p.idleLock.Unlock()
+
+	// Start a new subprocess and verify that its digest length hasn't changed.
+	process, size, err := p.start()
+	if err != nil {
+		return nil, err
+	} else if size != p.size {
+		process.terminate()
+		return nil, fmt.Errorf("external hasher digest length changed: %d != %d", size, p.size)
+	}
+	return process, nil
+}
+
+// release returns a subprocess (which must be between requests) to the pool.
+func (p *externalHasherPool) release(process *externalHasherProcess) {
+	p.idleLock.Lock()
+	p.idle = append(p.idle, process)
+	p.idleLock.Unlock()
+}
+
+// ExternalHasher is a hash.Hash implementation that computes digests using an
+// external hasher subprocess (see RegisterExternal). Because hash.Hash doesn't
+// provide a mechanism for reporting errors from Sum, subprocess failures are
+// reported by Write (when possible) and by Err. If a failure occurs, Sum
+// returns a random digest (so that the result won't match any other digest)
+// until the hasher is reset. Writing content after invoking Sum isn't
+// supported and is reported as a failure. Hashers hold a subprocess between
+// their first write and their invocation of Sum or Reset.
+type ExternalHasher struct {
+	// pool is the subprocess pool.
+	pool *externalHasherPool
+	// process is the subprocess serving the current request, if any.
+	process *externalHasherProcess
+	// digest is the digest for the current request, if computed.
+	digest []byte
+	// err is the error that occurred during the current request, if any.
+	err error
+}
+
+// fail records a failure, terminating the current subprocess (if any).
+func (h *ExternalHasher) fail(err error) {
+	if h.process != nil {
+		h.process.terminate()
+		h.process = nil
+	}
+	h.err = fmt.Errorf("external hasher failed: %w", err)
+}
+
+// Write implements hash.Hash.Write.
+func (h *ExternalHasher) Write(data []byte) (int, error) {
+	// Check for previous failures and writes after Sum.
+	if h.err != nil {
+		return 0, h.err
+	} else if h.digest != nil {
+		h.fail(errors.New("write after digest computation"))
+		return 0, h.err
+	}
+
+	// Empty writes are no-ops, since they'd be interpreted as terminators.
+	if len(data) == 0 {
+		return 0, nil
+	}
+
+	// Acquire a subprocess if necessary.
+	if h.process == nil {
+		process, err := h.pool.acquire()
+		if err != nil {
+			h.fail(err)
+			return 0, h.err
+		}
+		h.process = process
+	}
+
+	// Write the content frame.
+	if err := h.process.writeFrame(data); err != nil {
+		h.fail(err)
+		return 0, h.err
+	}
+
+	// Success.
+	return len(data), nil
+}
+
+// Sum implements hash.Hash.Sum.
+func (h *ExternalHasher) Sum(b []byte) []byte {
+	// If we've already failed, then return a random digest.
+	if h.err != nil {
+		return h.appendRandomDigest(b)
+	}
+
+	// Compute the digest if necessary.
+	if h.digest == nil {
+		// Acquire a subprocess if necessary (e.g. for empty content).
+		if h.process == nil {
+			process, err := h.pool.acquire()
+			if err != nil {
+				h.fail(err)
+				return h.appendRandomDigest(b)
+			}
+			h.process = process
+		}
+
+		// Request the digest.
+		digest := make([]byte, h.pool.size)
+		if err := h.process.finish(digest); err != nil {
+			h.fail(err)
+			return h.appendRandomDigest(b)
+		}
+
+		// Release the subprocess and record the digest.
+		h.pool.release(h.process)
+		h.process = nil
+		h.digest = digest
+	}
+
+	// Done.
+	return append(b, h.digest...)
+}
+
+// appendRandomDigest appends a random digest to the specified slice.
+func (h *ExternalHasher) appendRandomDigest(b []byte) []byte {
+	digest := make([]byte, h.pool.size)
+	rand.Read(digest)
+	return append(b, digest...)
+}
+
+// Reset implements hash.Hash.Reset.
+func (h *ExternalHasher) Reset() {
+	// If a subprocess is serving a request, then complete the request so that
+	// the subprocess can be reused.
+	if h.process != nil {
+		if err := h.process.finish(make([]byte, h.pool.size)); err != nil {
+			h.process.terminate()
+		} else {
+			h.pool.release(h.process)
+		}
+		h.process = nil
+	}
+
+	// Reset state.
+	h.digest = nil
+	h.err = nil
+}
+
+// Size implements hash.Hash.Size.
+func (h *ExternalHasher) Size() int {
+	return h.pool.size
+}
+
+// BlockSize implements hash.Hash.BlockSize.
+func (h *ExternalHasher) BlockSize() int {
+	return externalHasherBlockSize
+}
+
+// Err returns any failure that occurred since the hasher was last reset.
+func (h *ExternalHasher) Err() error {
+	return h.err
+}
+
+// Err returns any failure reported by the specified hasher (or any hashers
+// that it wraps) since it was last reset. Only ExternalHasher instances report
+// failures, so this function will always return nil for other hashers.
+func Err(hasher hash.Hash) error {
+	switch h := hasher.(type) {
+	case *ExternalHasher:
+		return h.err
+	case *TruncatingHasher:
+		return Err(h.Hash)
+	case *SamplingHasher:
+		if err := Err(h.full); err != nil {
+			return err
+		}
+		return Err(h.sampler)
+	default:
+		return nil
+	}
+}
+
+// RegisterExternal registers an external hashing algorithm (see Register) that
+// computes digests using subprocesses created from the specified executable
+// path and arguments. The subprocess must implement the external hasher
+// protocol documented in this package. A subprocess is started during
+// registration to determine the digest length, so registration will fail if
+// the subprocess can't be started or doesn't implement the protocol. External
+// hashers are always treated as supported.
+func RegisterExternal(name, path string, arguments ...string) (Algorithm, error) {
+	// Create the subprocess pool and start an initial subprocess to determine
+	// the digest length, retaining it for later use.
+	pool := &externalHasherPool{path: path, arguments: arguments}
+	process, size, err := pool.start()
+	if err != nil {
+		return Algorithm_AlgorithmDefault, err
+	}
+	pool.size = size
+
+	// Register the algorithm.
+	algorithm, err := Register(name, func() hash.Hash {
+		return &ExternalHasher{pool: pool}
+	}, true)
+	if err != nil {
+		process.terminate()
+		return Algorithm_AlgorithmDefault, err
+	}
+	pool.release(process)
+
+	// Success.
+	return algorithm, nil
+}
+
+// ServeExternalHasher implements the subprocess side of the external hasher
+// protocol using hashers from the specified factory, reading requests from
+// input and writing responses to output. It's intended for implementing
+// external hashers in Go. It returns nil when input is closed between
+// requests.
+func ServeExternalHasher(factory func() hash.Hash, input io.Reader, output io.Writer) error {
+	// Create the hasher and validate its digest length.
+	hasher := factory()
+	size := hasher.Size()
+	if size == 0 || size > MaximumExternalDigestLength {
+		return fmt.Errorf("unsupported digest length: %d", size)
+	}
+
+	// Write the digest length.
+	var header [4]byte
+	binary.BigEndian.PutUint32(header[:], uint32(size))
+	if _, err := output.Write(header[:]); err != nil {
+		return fmt.Errorf("unable to write digest length: %w", err)
+	}
+
+	// Serve requests.
+	input = bufio.NewReader(input)
+	var inRequest bool
+	for {
+		// Read the next frame header.
+		if _, err := io.ReadFull(input, header[:]); err != nil {
+			if err == io.EOF && !inRequest {
+				return nil
+			}
+			return fmt.Errorf("unable to read frame header: %w", err)
+		}
+
+		// If this is a terminator, then write the digest and reset.
+		length := binary.BigEndian.Uint32(header[:])
+		if length == 0 {
+			if _, err := output.Write(hasher.Sum(nil)); err != nil {
+				return fmt.Errorf("unable to write digest: %w", err)
+			}
+			hasher.Reset()
+			inRequest = false
+			continue
+		}
+
+		// Otherwise hash the frame content.
+		inRequest = true
+		if _, err := io.CopyN(hasher, input, int64(length)); err != nil {
+			return fmt.Errorf("unable to read frame content: %w", err)
+		}
+	}
+}
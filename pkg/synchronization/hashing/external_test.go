@@ -0,0 +1,125 @@
+package hashing
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/binary"
+	"io"
+	"os"
+	"testing"
+)
+
+// externalHasherTestModeEnvironmentVariable is the environment variable used
+// to make the test executable act as an external hasher subprocess.
+const externalHasherTestModeEnvironmentVariable = "MUTAGEN_TEST_EXTERNAL_HASHER_MODE"
+
+// TestMain is the entry point for tests. It replaces the default test entry
+// point so that the test executable can act as an external hasher subprocess.
+func TestMain(m *testing.M) {
+	// Act as an external hasher subprocess if requested.
+	switch os.Getenv(externalHasherTestModeEnvironmentVariable) {
+	case "sha256":
+		if err := ServeExternalHasher(sha256.New, os.Stdin, os.Stdout); err != nil {
+			os.Exit(1)
+		}
+		os.Exit(0)
+	case "failing":
+		var header [4]byte
+		binary.BigEndian.PutUint32(header[:], sha256.Size)
+		os.Stdout.Write(header[:])
+		io.ReadFull(os.Stdin, header[:])
+		os.Exit(1)
+	}
+
+	// Run tests.
+	os.Exit(m.Run())
+}
+
+// TestExternalHasher tests that external hashers compute the digests computed
+// by their subprocesses, including when used concurrently and after resets.
+func TestExternalHasher(t *testing.T) {
+	// Register an external hasher backed by the test executable.
+	t.Setenv(externalHasherTestModeEnvironmentVariable, "sha256")
+	algorithm, err := RegisterExternal("test-external-sha256", os.Args[0])
+	if err != nil {
+		t.Fatal("unable to register external hasher:", err)
+	} else if algorithm.SupportStatus() != AlgorithmSupportStatusSupported {
+		t.Error("external hasher not supported")
+	}
+
+	// Create hashers and verify their digest length.
+	factory := algorithm.Factory()
+	first, second := factory(), factory()
+	if size := first.Size(); size != sha256.Size {
+		t.Fatal("external hasher size does not match expected:", size)
+	}
+
+	// Compute interleaved digests using both hashers.
+	content := bytes.Repeat([]byte("external hasher content"), 10000)
+	first.Write(content[:100])
+	second.Write(content)
+	first.Write(content[100:])
+	expected := sha256.Sum256(content)
+	if digest := first.Sum(nil); !bytes.Equal(digest, expected[:]) {
+		t.Error("first digest does not match expected")
+	}
+	if digest := second.Sum(nil); !bytes.Equal(digest, expected[:]) {
+		t.Error("second digest does not match expected")
+	}
+	if digest := first.Sum(nil); !bytes.Equal(digest, expected[:]) {
+		t.Error("repeated digest does not match expected")
+	}
+
+	// Verify that resetting mid-request and hashing empty content work.
+	first.Reset()
+	first.Write(content)
+	first.Reset()
+	empty := sha256.Sum256(nil)
+	if digest := first.Sum(nil); !bytes.Equal(digest, empty[:]) {
+		t.Error("empty digest does not match expected")
+	} else if err := Err(first); err != nil {
+		t.Error("external hasher reported failure:", err)
+	}
+
+	// Verify that wrapping hashers work.
+	truncated := NewTruncatingFactory(factory, MinimumTruncatedDigestLength)()
+	truncated.Write(content)
+	if digest := truncated.Sum(nil); !bytes.Equal(digest, expected[:MinimumTruncatedDigestLength]) {
+		t.Error("truncated digest does not match expected")
+	}
+}
+
+// TestExternalHasherFailure tests that external hasher subprocess failures are
+// reported and that registration fails for invalid subprocesses.
+func TestExternalHasherFailure(t *testing.T) {
+	// Verify that registration fails for subprocesses that can't be started.
+	if _, err := RegisterExternal("test-external-missing", "/nonexistent/external/hasher"); err == nil {
+		t.Error("registration of missing external hasher succeeded")
+	}
+
+	// Register an external hasher whose subprocesses fail on first use.
+	t.Setenv(externalHasherTestModeEnvironmentVariable, "failing")
+	algorithm, err := RegisterExternal("test-external-failing", os.Args[0])
+	if err != nil {
+		t.Fatal("unable to register external hasher:", err)
+	}
+
+	// Attempt to compute a digest and verify that the failure is reported.
+	hasher := algorithm.Factory()()
+	hasher.Write([]byte("content"))
+	digest := hasher.Sum(nil)
+	if Err(hasher) == nil {
+		t.Fatal("external hasher failure not reported")
+	} else if len(digest) != sha256.Size {
+		t.Error("digest length does not match expected:", len(digest))
+	}
+	if _, err := hasher.Write([]byte("content")); err == nil {
+		t.Error("write succeeded after failure")
+	}
+
+	// Verify that resetting clears the failure.
+	hasher.Reset()
+	if err := Err(hasher); err != nil {
+		t.Error("failure not cleared by reset:", err)
+	}
+}
@@ -0,0 +1,129 @@
+package hashing
+
+import (
+	"errors"
+	"fmt"
+	"hash"
+	"hash/fnv"
+	"math"
+	"sync"
+)
+
+// registeredAlgorithmBase is the lowest Algorithm value assigned to registered
+// algorithms. It's chosen to leave ample room for future built-in algorithms.
+const registeredAlgorithmBase Algorithm = 1 << 16
+
+// registeredAlgorithmValue computes the Algorithm value for a registered
+// algorithm name. The value is derived from a 32-bit FNV-1a hash of the name,
+// folded into the range [registeredAlgorithmBase, math.MaxInt32], so that it
+// remains stable across processes and independent of registration order. This
+// is necessary because Algorithm values are persisted in session
+// configurations.
+func registeredAlgorithmValue(name string) Algorithm {
+	hasher := fnv.New32a()
+	hasher.Write([]byte(name))
+	return registeredAlgorithmBase + Algorithm(hasher.Sum32()%uint32(math.MaxInt32-registeredAlgorithmBase+1))
+}
+
+// registeredAlgorithm stores information about a registered algorithm.
+type registeredAlgorithm struct {
+	// name is the algorithm's text specification.
+	name string
+	// factory is the algorithm's hasher factory.
+	factory func() hash.Hash
+	// supported indicates whether or not the algorithm is supported.
+	supported bool
+}
+
+var (
+	// registryLock serializes access to registry and registryByName.
+	registryLock sync.RWMutex
+	// registry maps registered Algorithm values to their registrations.
+	registry = make(map[Algorithm]*registeredAlgorithm)
+	// registryByName maps registered algorithm names to their Algorithm values.
+	registryByName = make(map[string]Algorithm)
+)
+
+// isBuiltinName determines whether or not a name is reserved for a built-in
+// algorithm.
+func isBuiltinName(name string) bool {
+	var algorithm Algorithm
+	return algorithm.unmarshalBuiltin(name)
+}
+
+// Register registers an additional hashing algorithm, allowing Mutagen to be
+// extended with hashing implementations when used as a library. Once
+// registered, the algorithm can be specified by name and is handled by
+// UnmarshalText, MarshalText, SupportStatus, Description, and Factory. The
+// resulting Algorithm value is derived from the name, so it's stable across
+// processes and registration orders, but registered algorithms are only known
+// to processes that register them, so they should only be used in sessions
+// whose endpoints are both hosted in the registering process (e.g. local
+// endpoints). Sessions using an algorithm that hasn't been registered will fail
+// to load. Registration is safe for concurrent use, but should generally be
+// performed during initialization, before any sessions are created or loaded.
+// Names must be unique and can't conflict with built-in algorithm names or
+// (in the unlikely event of a hash collision) other registered names.
+func Register(name string, factory func() hash.Hash, supported bool) (Algorithm, error) {
+	// Validate arguments.
+	if name == "" {
+		return Algorithm_AlgorithmDefault, errors.New("empty algorithm name")
+	} else if factory == nil {
+		return Algorithm_AlgorithmDefault, errors.New("nil hasher factory")
+	} else if isBuiltinName(name) {
+		return Algorithm_AlgorithmDefault, fmt.Errorf("algorithm name conflicts with built-in algorithm: %s", name)
+	}
+
+	// Lock the registry and defer its release.
+	registryLock.Lock()
+	defer registryLock.Unlock()
+
+	// Ensure that the name isn't already registered.
+	if _, ok := registryByName[name]; ok {
+		return Algorithm_AlgorithmDefault, fmt.Errorf("algorithm already registered: %s", name)
+	}
+
+	// Compute the algorithm value and ensure that it doesn't collide with that
+	// of another registered algorithm.
+	algorithm := registeredAlgorithmValue(name)
+	if existing, ok := registry[algorithm]; ok {
+		return Algorithm_AlgorithmDefault, fmt.Errorf("algorithm value for %s collides with registered algorithm: %s", name, existing.name)
+	}
+
+	// Register the algorithm.
+	registry[algorithm] = &registeredAlgorithm{
+		name:      name,
+		factory:   factory,
+		supported: supported,
+	}
+	registryByName[name] = algorithm
+
+	// Success.
+	return algorithm, nil
+}
+
+// lookupRegistered looks up the registration for an algorithm, returning nil
+// if the algorithm isn't a registered algorithm.
+func lookupRegistered(algorithm Algorithm) *registeredAlgorithm {
+	if algorithm < registeredAlgorithmBase {
+		return nil
+	}
+	registryLock.RLock()
+	defer registryLock.RUnlock()
+	return registry[algorithm]
+}
+
+// lookupRegisteredByName looks up a registered algorithm by name.
+func lookupRegisteredByName(name string) (Algorithm, bool) {
+	registryLock.RLock()
+	defer registryLock.RUnlock()
+	algorithm, ok := registryByName[name]
+	return algorithm, ok
+}
+
+// IsUnregistered indicates whether or not an algorithm value lies in the range
+// reserved for registered algorithms without a corresponding algorithm having
+// been registered in the current process.
+func (a Algorithm) IsUnregistered() bool {
+	return a >= registeredAlgorithmBase && lookupRegistered(a) == nil
+}
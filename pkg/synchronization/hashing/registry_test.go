@@ -0,0 +1,126 @@
+package hashing
+
+import (
+	"crypto/sha512"
+	"sync"
+	"testing"
+)
+
+// TestRegister tests that algorithm registration extends Algorithm behavior and
+// rejects invalid or duplicate registrations.
+func TestRegister(t *testing.T) {
+	// Register an algorithm.
+	algorithm, err := Register("test-sha512", sha512.New, true)
+	if err != nil {
+		t.Fatal("unable to register algorithm:", err)
+	}
+
+	// Verify that the registered algorithm behaves as expected.
+	var unmarshaled Algorithm
+	if err := unmarshaled.UnmarshalText([]byte("test-sha512")); err != nil {
+		t.Error("unable to unmarshal registered algorithm:", err)
+	} else if unmarshaled != algorithm {
+		t.Error("unmarshaled algorithm does not match registered algorithm")
+	}
+	if text, err := algorithm.MarshalText(); err != nil {
+		t.Error("unable to marshal registered algorithm:", err)
+	} else if string(text) != "test-sha512" {
+		t.Error("marshaled registered algorithm does not match expected:", string(text))
+	}
+	if algorithm.SupportStatus() != AlgorithmSupportStatusSupported {
+		t.Error("registered algorithm not supported")
+	}
+	if description := algorithm.Description(); description != "test-sha512" {
+		t.Error("registered algorithm description does not match expected:", description)
+	}
+	if size := algorithm.Factory()().Size(); size != sha512.Size {
+		t.Error("registered algorithm hasher size does not match expected:", size)
+	}
+
+	// Verify that unsupported registrations are reported as such.
+	unsupported, err := Register("test-unsupported", sha512.New, false)
+	if err != nil {
+		t.Fatal("unable to register unsupported algorithm:", err)
+	} else if unsupported.SupportStatus() != AlgorithmSupportStatusUnsupported {
+		t.Error("unsupported registered algorithm reported as supported")
+	}
+
+	// Verify that invalid and duplicate registrations are rejected.
+	if _, err := Register("", sha512.New, true); err == nil {
+		t.Error("registration with empty name succeeded")
+	}
+	if _, err := Register("test-nil", nil, true); err == nil {
+		t.Error("registration with nil factory succeeded")
+	}
+	if _, err := Register("sha256", sha512.New, true); err == nil {
+		t.Error("registration with built-in name succeeded")
+	}
+	if _, err := Register("test-sha512", sha512.New, true); err == nil {
+		t.Error("duplicate registration succeeded")
+	}
+}
+
+// TestRegisterConcurrent tests that concurrent registrations of the same name
+// result in exactly one successful registration.
+func TestRegisterConcurrent(t *testing.T) {
+	// Perform concurrent registrations.
+	const registrations = 16
+	var wait sync.WaitGroup
+	var lock sync.Mutex
+	var successes int
+	for i := 0; i < registrations; i++ {
+		wait.Add(1)
+		go func() {
+			defer wait.Done()
+			if _, err := Register("test-concurrent", sha512.New, true); err == nil {
+				lock.Lock()
+				successes++
+				lock.Unlock()
+			}
+		}()
+	}
+	wait.Wait()
+
+	// Verify that exactly one registration succeeded.
+	if successes != 1 {
+		t.Error("number of successful registrations does not match expected:", successes)
+	}
+}
+
+// TestRegisterStableValue tests that registered algorithm values are derived
+// from algorithm names rather than registration order, and that unregistered
+// values in the registered range are identified as such.
+func TestRegisterStableValue(t *testing.T) {
+	// Compute the expected value. This is fixed in the test to ensure that
+	// values persisted in session configurations remain valid across releases.
+	const expected Algorithm = 1185939659
+
+	// Verify that the value is identified as unregistered before registration.
+	if !expected.IsUnregistered() {
+		t.Error("algorithm value not identified as unregistered before registration")
+	}
+
+	// Perform an unrelated registration to ensure that registration order
+	// doesn't affect the resulting value.
+	if _, err := Register("test-stable-other", sha512.New, true); err != nil {
+		t.Fatal("unable to register unrelated algorithm:", err)
+	}
+
+	// Register the algorithm and verify its value.
+	algorithm, err := Register("test-stable", sha512.New, true)
+	if err != nil {
+		t.Fatal("unable to register algorithm:", err)
+	} else if algorithm != expected {
+		t.Error("registered algorithm value does not match expected:", algorithm, "!=", expected)
+	}
+
+	// Verify that the value is no longer identified as unregistered.
+	if algorithm.IsUnregistered() {
+		t.Error("registered algorithm identified as unregistered")
+	}
+
+	// Verify that built-in algorithms aren't identified as unregistered.
+	if Algorithm_AlgorithmSHA256.IsUnregistered() {
+		t.Error("built-in algorithm identified as unregistered")
+	}
+}
@@ -0,0 +1,191 @@
+package hashing
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"hash"
+	"io"
+)
+
+const (
+	// SampleSize is the number of bytes sampled from each end of content when
+	// computing a sampled digest.
+	SampleSize = 64 * 1024
+	// MinimumSamplingThreshold is the minimum non-zero sampling threshold. It
+	// ensures that sampled regions never overlap.
+	MinimumSamplingThreshold = 2 * SampleSize
+)
+
+// sampledDigestDomain is written to the underlying hasher before sampled
+// digest data in order to separate sampled digests from full content digests.
+var sampledDigestDomain = []byte("mutagen sampled digest\x00")
+
+// SamplingHasher is a hash.Hash implementation that computes a full content
+// digest for content at or below a size threshold and a sampled digest for
+// content above that threshold. A sampled digest covers only the content size
+// and the first and last SampleSize bytes of content, meaning that changes to
+// content outside of those regions (that don't also change its size) won't be
+// reflected in the digest. This trades correctness for speed, so sampling
+// should only be used when explicitly requested, and sampled digests should
+// only be used to detect changes (and never to identify content).
+//
+// Because content may be written incrementally, a SamplingHasher still requires
+// that all content be written to it when used as a hash.Hash. Its Sample method
+// can be used to compute a digest from seekable content without reading the
+// unsampled regions.
+type SamplingHasher struct {
+	// threshold is the size above which digests are sampled.
+	threshold uint64
+	// full is the hasher used for full content digests.
+	full hash.Hash
+	// sampler is the hasher used for sampled digests.
+	sampler hash.Hash
+	// size is the number of bytes written since the last reset.
+	size uint64
+	// prefix stores the first SampleSize bytes written.
+	prefix []byte
+	// suffix stores the last SampleSize bytes written.
+	suffix []byte
+}
+
+// NewSamplingFactory creates a hasher factory that returns SamplingHasher
+// instances wrapping hashers from the specified factory. If threshold is zero,
+// then the specified factory is returned directly and no sampling is performed.
+// A non-zero threshold should be at least MinimumSamplingThreshold.
+func NewSamplingFactory(factory func() hash.Hash, threshold uint64) func() hash.Hash {
+	if threshold == 0 {
+		return factory
+	}
+	return func() hash.Hash {
+		return &SamplingHasher{
+			threshold: threshold,
+			full:      factory(),
+			sampler:   factory(),
+			prefix:    make([]byte, 0, SampleSize),
+			suffix:    make([]byte, 0, SampleSize),
+		}
+	}
+}
+
+// Threshold returns the size above which digests are sampled.
+func (h *SamplingHasher) Threshold() uint64 {
+	return h.threshold
+}
+
+// Unwrap returns the underlying hasher used for full content digests. It may
+// be used for computing digests that shouldn't be sampled, but it shares state
+// with the SamplingHasher and thus shouldn't be used concurrently with it.
+func (h *SamplingHasher) Unwrap() hash.Hash {
+	return h.full
+}
+
+// Write implements hash.Hash.Write.
+func (h *SamplingHasher) Write(data []byte) (int, error) {
+	// Update the full content digest until the threshold is exceeded, after
+	// which it won't be used.
+	if h.size+uint64(len(data)) <= h.threshold {
+		h.full.Write(data)
+	}
+	h.size += uint64(len(data))
+
+	// Record any remaining prefix content.
+	if remaining := SampleSize - len(h.prefix); remaining > 0 {
+		if remaining > len(data) {
+			remaining = len(data)
+		}
+		h.prefix = append(h.prefix, data[:remaining]...)
+	}
+
+	// Record suffix content, discarding content that's no longer within the
+	// trailing sample region.
+	if len(data) >= SampleSize {
+		h.suffix = append(h.suffix[:0], data[len(data)-SampleSize:]...)
+	} else {
+		if excess := len(h.suffix) + len(data) - SampleSize; excess > 0 {
+			h.suffix = h.suffix[:copy(h.suffix, h.suffix[excess:])]
+		}
+		h.suffix = append(h.suffix, data...)
+	}
+
+	// Success.
+	return len(data), nil
+}
+
+// Sum implements hash.Hash.Sum.
+func (h *SamplingHasher) Sum(b []byte) []byte {
+	if h.size <= h.threshold {
+		return h.full.Sum(b)
+	}
+	return append(b, h.sampledDigest(h.prefix, h.suffix)...)
+}
+
+// sampledDigest computes a sampled digest using the current content size.
+func (h *SamplingHasher) sampledDigest(prefix, suffix []byte) []byte {
+	var size [8]byte
+	binary.BigEndian.PutUint64(size[:], h.size)
+	h.sampler.Reset()
+	h.sampler.Write(sampledDigestDomain)
+	h.sampler.Write(size[:])
+	h.sampler.Write(prefix)
+	h.sampler.Write(suffix)
+	return h.sampler.Sum(nil)
+}
+
+// Reset implements hash.Hash.Reset.
+func (h *SamplingHasher) Reset() {
+	h.full.Reset()
+	h.size = 0
+	h.prefix = h.prefix[:0]
+	h.suffix = h.suffix[:0]
+}
+
+// Size implements hash.Hash.Size.
+func (h *SamplingHasher) Size() int {
+	return h.full.Size()
+}
+
+// BlockSize implements hash.Hash.BlockSize.
+func (h *SamplingHasher) BlockSize() int {
+	return h.full.BlockSize()
+}
+
+// Sample computes a sampled digest for content of the specified size (which
+// must exceed the hasher's threshold) by reading only the sampled regions of
+// the content. The result is identical to that obtained by writing the full
+// content to the hasher and invoking Sum. The hasher is reset by this method.
+func (h *SamplingHasher) Sample(content io.ReadSeeker, size uint64) ([]byte, error) {
+	// Validate the content size.
+	if size <= h.threshold {
+		return nil, errors.New("content size does not exceed sampling threshold")
+	}
+
+	// Reset the hasher state.
+	h.Reset()
+	h.size = size
+
+	// Compute the size of each sample region.
+	sampleSize := uint64(SampleSize)
+	if size < sampleSize {
+		sampleSize = size
+	}
+
+	// Read the prefix.
+	h.prefix = h.prefix[:sampleSize]
+	if _, err := content.Seek(0, io.SeekStart); err != nil {
+		return nil, fmt.Errorf("unable to seek to prefix: %w", err)
+	} else if _, err = io.ReadFull(content, h.prefix); err != nil {
+		return nil, fmt.Errorf("unable to read prefix: %w", err)
+	}
+
+	// Read the suffix.
+	h.suffix = h.suffix[:sampleSize]
+	if _, err := content.Seek(int64(size-sampleSize), io.SeekStart); err != nil {
+		return nil, fmt.Errorf("unable to seek to suffix: %w", err)
+	} else if _, err = io.ReadFull(content, h.suffix); err != nil {
+		return nil, fmt.Errorf("unable to read suffix: %w", err)
+	}
+
+	// Compute the digest.
+	return h.sampledDigest(h.prefix, h.suffix), nil
+}
@@ -0,0 +1,75 @@
+package hashing
+
+import (
+	"bytes"
+	"crypto/sha1"
+	"testing"
+)
+
+// TestSamplingHasher tests that SamplingHasher computes identical digests
+// regardless of how content is written to it and that these digests match
+// those computed by Sample.
+func TestSamplingHasher(t *testing.T) {
+	// Create a hasher factory.
+	factory := NewSamplingFactory(sha1.New, MinimumSamplingThreshold)
+
+	// Set up test cases.
+	testCases := []struct {
+		size    int
+		sampled bool
+	}{
+		{0, false},
+		{MinimumSamplingThreshold, false},
+		{MinimumSamplingThreshold + 1, true},
+		{3*MinimumSamplingThreshold + 17, true},
+	}
+
+	// Process test cases.
+	for i, testCase := range testCases {
+		// Create content.
+		content := make([]byte, testCase.size)
+		for c := range content {
+			content[c] = byte(c % 251)
+		}
+
+		// Compute the digest using a single write.
+		hasher := factory()
+		hasher.Write(content)
+		expected := hasher.Sum(nil)
+
+		// Verify that the digest matches the full content digest if and only
+		// if sampling isn't expected.
+		full := sha1.Sum(content)
+		if sampled := !bytes.Equal(expected, full[:]); sampled != testCase.sampled {
+			t.Errorf("test case %d: sampling status does not match expected: %t != %t",
+				i, sampled, testCase.sampled,
+			)
+		}
+
+		// Verify that incremental writes of various sizes yield the same digest.
+		for _, chunkSize := range []int{1000, SampleSize - 1, SampleSize, SampleSize + 1} {
+			hasher.Reset()
+			for offset := 0; offset < len(content); offset += chunkSize {
+				end := offset + chunkSize
+				if end > len(content) {
+					end = len(content)
+				}
+				hasher.Write(content[offset:end])
+			}
+			if !bytes.Equal(hasher.Sum(nil), expected) {
+				t.Errorf("test case %d: digest with chunk size %d does not match expected",
+					i, chunkSize,
+				)
+			}
+		}
+
+		// Verify that sampling yields the same digest, if applicable.
+		if testCase.sampled {
+			if digest, err := hasher.(*SamplingHasher).Sample(bytes.NewReader(content), uint64(len(content))); err != nil {
+				t.Errorf("test case %d: unable to sample content: %v", i, err)
+			} else if !bytes.Equal(digest, expected) {
+				t.Errorf("test case %d: sampled digest does not match expected", i)
+			}
+		}
+	}
+}
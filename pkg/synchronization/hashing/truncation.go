@@ -0,0 +1,60 @@
+package hashing
+
+import (
+	"hash"
+)
+
+// MinimumTruncatedDigestLength is the minimum non-zero truncated digest length
+// (in bytes). At this length, the probability that a content change goes
+// undetected due to a digest collision is roughly 2^-64 per change. This only
+// holds when comparing successive versions of content at a single path. By the
+// birthday bound, collisions among large numbers of distinct pieces of content
+// are far more likely, so truncated digests shouldn't be used to identify
+// content across paths.
+const MinimumTruncatedDigestLength = 8
+
+// TruncatingHasher is a hash.Hash implementation that truncates the digests
+// computed by an underlying hasher. Truncated digests reduce the memory and
+// storage required for snapshots, at the cost of an increased (but, for
+// reasonable lengths, still negligible) probability that a content change won't
+// be reflected in the digest.
+type TruncatingHasher struct {
+	// hash.Hash is the underlying hasher.
+	hash.Hash
+	// length is the truncated digest length.
+	length int
+}
+
+// NewTruncatingFactory creates a hasher factory that returns TruncatingHasher
+// instances wrapping hashers from the specified factory. If length is zero or
+// isn't less than the underlying digest length, then the specified factory is
+// returned directly and no truncation is performed. A non-zero length should be
+// at least MinimumTruncatedDigestLength.
+func NewTruncatingFactory(factory func() hash.Hash, length uint32) func() hash.Hash {
+	if length == 0 || uint64(length) >= uint64(factory().Size()) {
+		return factory
+	}
+	return func() hash.Hash {
+		return &TruncatingHasher{
+			Hash:   factory(),
+			length: int(length),
+		}
+	}
+}
+
+// Unwrap returns the underlying hasher used to compute full digests. It shares
+// state with the TruncatingHasher and thus shouldn't be used concurrently with
+// it.
+func (h *TruncatingHasher) Unwrap() hash.Hash {
+	return h.Hash
+}
+
+// Sum implements hash.Hash.Sum.
+func (h *TruncatingHasher) Sum(b []byte) []byte {
+	return append(b, h.Hash.Sum(nil)[:h.length]...)
+}
+
+// Size implements hash.Hash.Size.
+func (h *TruncatingHasher) Size() int {
+	return h.length
+}
@@ -0,0 +1,42 @@
+package hashing
+
+import (
+	"bytes"
+	"crypto/sha1"
+	"testing"
+)
+
+// TestTruncatingHasher tests that TruncatingHasher computes prefixes of full
+// digests and that truncation is disabled for lengths that wouldn't shorten
+// digests.
+func TestTruncatingHasher(t *testing.T) {
+	// Create content and compute its full digest.
+	content := []byte("truncated digest content")
+	full := sha1.Sum(content)
+
+	// Set up test cases.
+	testCases := []struct {
+		length         uint32
+		expectedLength int
+	}{
+		{0, sha1.Size},
+		{MinimumTruncatedDigestLength, MinimumTruncatedDigestLength},
+		{sha1.Size - 1, sha1.Size - 1},
+		{sha1.Size, sha1.Size},
+		{sha1.Size + 1, sha1.Size},
+	}
+
+	// Process test cases.
+	for i, testCase := range testCases {
+		hasher := NewTruncatingFactory(sha1.New, testCase.length)()
+		if size := hasher.Size(); size != testCase.expectedLength {
+			t.Errorf("test case %d: hasher size does not match expected: %d != %d",
+				i, size, testCase.expectedLength,
+			)
+		}
+		hasher.Write(content)
+		if digest := hasher.Sum(nil); !bytes.Equal(digest, full[:testCase.expectedLength]) {
+			t.Errorf("test case %d: digest is not a prefix of full digest", i)
+		}
+	}
+}
@@ -0,0 +1,29 @@
+package hashing
+
+import (
+	"hash"
+
+	"github.com/zeebo/xxh3"
+)
+
+// xxh3Hash implements hash.Hash using 128-bit XXH3 hashing.
+type xxh3Hash struct {
+	// Hasher is the underlying hasher.
+	*xxh3.Hasher
+}
+
+// newXXH3 creates a new 128-bit XXH3 hasher.
+func newXXH3() hash.Hash {
+	return &xxh3Hash{xxh3.New()}
+}
+
+// Sum implements hash.Hash.Sum.
+func (h *xxh3Hash) Sum(b []byte) []byte {
+	sum := h.Sum128().Bytes()
+	return append(b, sum[:]...)
+}
+
+// Size implements hash.Hash.Size.
+func (h *xxh3Hash) Size() int {
+	return 16
+}
@@ -0,0 +1,26 @@
+package hashing
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/zeebo/xxh3"
+)
+
+// TestXXH3 tests that the XXH3 hasher computes 128-bit XXH3 digests.
+func TestXXH3(t *testing.T) {
+	// Compute a digest.
+	content := []byte("xxh3 digest content")
+	hasher := Algorithm_AlgorithmXXH3.Factory()()
+	hasher.Write(content)
+	digest := hasher.Sum(nil)
+
+	// Verify the digest.
+	expected := xxh3.Hash128(content).Bytes()
+	if hasher.Size() != len(expected) {
+		t.Error("hasher size does not match digest size:", hasher.Size(), "!=", len(expected))
+	}
+	if !bytes.Equal(digest, expected[:]) {
+		t.Error("digest does not match expected")
+	}
+}
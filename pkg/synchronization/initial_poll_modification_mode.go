@@ -0,0 +1,73 @@
+package synchronization
+
+import (
+	"fmt"
+)
+
+// IsDefault indicates whether or not the initial poll modification mode is
+// InitialPollModificationMode_InitialPollModificationModeDefault.
+func (m InitialPollModificationMode) IsDefault() bool {
+	return m == InitialPollModificationMode_InitialPollModificationModeDefault
+}
+
+// MarshalText implements encoding.TextMarshaler.MarshalText.
+func (m InitialPollModificationMode) MarshalText() ([]byte, error) {
+	var result string
+	switch m {
+	case InitialPollModificationMode_InitialPollModificationModeDefault:
+	case InitialPollModificationMode_InitialPollModificationModeSuppress:
+		result = "suppress"
+	case InitialPollModificationMode_InitialPollModificationModeReport:
+		result = "report"
+	default:
+		result = "unknown"
+	}
+	return []byte(result), nil
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler.UnmarshalText.
+func (m *InitialPollModificationMode) UnmarshalText(textBytes []byte) error {
+	// Convert the bytes to a string.
+	text := string(textBytes)
+
+	// Convert to an initial poll modification mode.
+	switch text {
+	case "suppress":
+		*m = InitialPollModificationMode_InitialPollModificationModeSuppress
+	case "report":
+		*m = InitialPollModificationMode_InitialPollModificationModeReport
+	default:
+		return fmt.Errorf("unknown initial poll modification mode specification: %s", text)
+	}
+
+	// Success.
+	return nil
+}
+
+// Supported indicates whether or not a particular initial poll modification
+// mode is a valid, non-default value.
+func (m InitialPollModificationMode) Supported() bool {
+	switch m {
+	case InitialPollModificationMode_InitialPollModificationModeSuppress:
+		return true
+	case InitialPollModificationMode_InitialPollModificationModeReport:
+		return true
+	default:
+		return false
+	}
+}
+
+// Description returns a human-readable description of an initial poll
+// modification mode.
+func (m InitialPollModificationMode) Description() string {
+	switch m {
+	case InitialPollModificationMode_InitialPollModificationModeDefault:
+		return "Default"
+	case InitialPollModificationMode_InitialPollModificationModeSuppress:
+		return "Suppress"
+	case InitialPollModificationMode_InitialPollModificationModeReport:
+		return "Report"
+	default:
+		return "Unknown"
+	}
+}
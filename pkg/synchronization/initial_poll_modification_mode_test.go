@@ -0,0 +1,91 @@
+package synchronization
+
+import (
+	"testing"
+)
+
+// TestInitialPollModificationModeUnmarshal tests that unmarshaling from a
+// string specification succeeeds for InitialPollModificationMode.
+func TestInitialPollModificationModeUnmarshal(t *testing.T) {
+	// Set up test cases.
+	testCases := []struct {
+		text          string
+		expectedMode  InitialPollModificationMode
+		expectFailure bool
+	}{
+		{"", InitialPollModificationMode_InitialPollModificationModeDefault, true},
+		{"asdf", InitialPollModificationMode_InitialPollModificationModeDefault, true},
+		{"suppress", InitialPollModificationMode_InitialPollModificationModeSuppress, false},
+		{"report", InitialPollModificationMode_InitialPollModificationModeReport, false},
+	}
+
+	// Process test cases.
+	for _, testCase := range testCases {
+		var mode InitialPollModificationMode
+		if err := mode.UnmarshalText([]byte(testCase.text)); err != nil {
+			if !testCase.expectFailure {
+				t.Errorf("unable to unmarshal text (%s): %s", testCase.text, err)
+			}
+		} else if testCase.expectFailure {
+			t.Error("unmarshaling succeeded unexpectedly for text:", testCase.text)
+		} else if mode != testCase.expectedMode {
+			t.Errorf(
+				"unmarshaled mode (%s) does not match expected (%s)",
+				mode,
+				testCase.expectedMode,
+			)
+		}
+	}
+}
+
+// TestInitialPollModificationModeSupported tests that
+// InitialPollModificationMode support detection works as expected.
+func TestInitialPollModificationModeSupported(t *testing.T) {
+	// Set up test cases.
+	testCases := []struct {
+		mode            InitialPollModificationMode
+		expectSupported bool
+	}{
+		{InitialPollModificationMode_InitialPollModificationModeDefault, false},
+		{InitialPollModificationMode_InitialPollModificationModeSuppress, true},
+		{InitialPollModificationMode_InitialPollModificationModeReport, true},
+		{(InitialPollModificationMode_InitialPollModificationModeReport + 1), false},
+	}
+
+	// Process test cases.
+	for _, testCase := range testCases {
+		if supported := testCase.mode.Supported(); supported != testCase.expectSupported {
+			t.Errorf(
+				"mode support status (%t) does not match expected (%t)",
+				supported,
+				testCase.expectSupported,
+			)
+		}
+	}
+}
+
+// TestInitialPollModificationModeDescription tests that
+// InitialPollModificationMode description generation works as expected.
+func TestInitialPollModificationModeDescription(t *testing.T) {
+	// Set up test cases.
+	testCases := []struct {
+		mode                InitialPollModificationMode
+		expectedDescription string
+	}{
+		{InitialPollModificationMode_InitialPollModificationModeDefault, "Default"},
+		{InitialPollModificationMode_InitialPollModificationModeSuppress, "Suppress"},
+		{InitialPollModificationMode_InitialPollModificationModeReport, "Report"},
+		{(InitialPollModificationMode_InitialPollModificationModeReport + 1), "Unknown"},
+	}
+
+	// Process test cases.
+	for _, testCase := range testCases {
+		if description := testCase.mode.Description(); description != testCase.expectedDescription {
+			t.Errorf(
+				"mode description (%s) does not match expected (%s)",
+				description,
+				testCase.expectedDescription,
+			)
+		}
+	}
+}
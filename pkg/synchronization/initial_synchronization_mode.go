@@ -0,0 +1,73 @@
+package synchronization
+
+import (
+	"fmt"
+)
+
+// IsDefault indicates whether or not the initial synchronization mode is
+// InitialSynchronizationMode_InitialSynchronizationModeDefault.
+func (m InitialSynchronizationMode) IsDefault() bool {
+	return m == InitialSynchronizationMode_InitialSynchronizationModeDefault
+}
+
+// MarshalText implements encoding.TextMarshaler.MarshalText.
+func (m InitialSynchronizationMode) MarshalText() ([]byte, error) {
+	var result string
+	switch m {
+	case InitialSynchronizationMode_InitialSynchronizationModeDefault:
+	case InitialSynchronizationMode_InitialSynchronizationModeAutomatic:
+		result = "automatic"
+	case InitialSynchronizationMode_InitialSynchronizationModeForce:
+		result = "force"
+	default:
+		result = "unknown"
+	}
+	return []byte(result), nil
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler.UnmarshalText.
+func (m *InitialSynchronizationMode) UnmarshalText(textBytes []byte) error {
+	// Convert the bytes to a string.
+	text := string(textBytes)
+
+	// Convert to an initial synchronization mode.
+	switch text {
+	case "automatic":
+		*m = InitialSynchronizationMode_InitialSynchronizationModeAutomatic
+	case "force":
+		*m = InitialSynchronizationMode_InitialSynchronizationModeForce
+	default:
+		return fmt.Errorf("unknown initial synchronization mode specification: %s", text)
+	}
+
+	// Success.
+	return nil
+}
+
+// Supported indicates whether or not a particular initial synchronization mode
+// is a valid, non-default value.
+func (m InitialSynchronizationMode) Supported() bool {
+	switch m {
+	case InitialSynchronizationMode_InitialSynchronizationModeAutomatic:
+		return true
+	case InitialSynchronizationMode_InitialSynchronizationModeForce:
+		return true
+	default:
+		return false
+	}
+}
+
+// Description returns a human-readable description of an initial
+// synchronization mode.
+func (m InitialSynchronizationMode) Description() string {
+	switch m {
+	case InitialSynchronizationMode_InitialSynchronizationModeDefault:
+		return "Default"
+	case InitialSynchronizationMode_InitialSynchronizationModeAutomatic:
+		return "Automatic"
+	case InitialSynchronizationMode_InitialSynchronizationModeForce:
+		return "Force"
+	default:
+		return "Unknown"
+	}
+}
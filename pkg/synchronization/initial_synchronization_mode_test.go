@@ -0,0 +1,91 @@
+package synchronization
+
+import (
+	"testing"
+)
+
+// TestInitialSynchronizationModeUnmarshal tests that unmarshaling from a string
+// specification succeeeds for InitialSynchronizationMode.
+func TestInitialSynchronizationModeUnmarshal(t *testing.T) {
+	// Set up test cases.
+	testCases := []struct {
+		text          string
+		expectedMode  InitialSynchronizationMode
+		expectFailure bool
+	}{
+		{"", InitialSynchronizationMode_InitialSynchronizationModeDefault, true},
+		{"asdf", InitialSynchronizationMode_InitialSynchronizationModeDefault, true},
+		{"automatic", InitialSynchronizationMode_InitialSynchronizationModeAutomatic, false},
+		{"force", InitialSynchronizationMode_InitialSynchronizationModeForce, false},
+	}
+
+	// Process test cases.
+	for _, testCase := range testCases {
+		var mode InitialSynchronizationMode
+		if err := mode.UnmarshalText([]byte(testCase.text)); err != nil {
+			if !testCase.expectFailure {
+				t.Errorf("unable to unmarshal text (%s): %s", testCase.text, err)
+			}
+		} else if testCase.expectFailure {
+			t.Error("unmarshaling succeeded unexpectedly for text:", testCase.text)
+		} else if mode != testCase.expectedMode {
+			t.Errorf(
+				"unmarshaled mode (%s) does not match expected (%s)",
+				mode,
+				testCase.expectedMode,
+			)
+		}
+	}
+}
+
+// TestInitialSynchronizationModeSupported tests that InitialSynchronizationMode
+// support detection works as expected.
+func TestInitialSynchronizationModeSupported(t *testing.T) {
+	// Set up test cases.
+	testCases := []struct {
+		mode            InitialSynchronizationMode
+		expectSupported bool
+	}{
+		{InitialSynchronizationMode_InitialSynchronizationModeDefault, false},
+		{InitialSynchronizationMode_InitialSynchronizationModeAutomatic, true},
+		{InitialSynchronizationMode_InitialSynchronizationModeForce, true},
+		{(InitialSynchronizationMode_InitialSynchronizationModeForce + 1), false},
+	}
+
+	// Process test cases.
+	for _, testCase := range testCases {
+		if supported := testCase.mode.Supported(); supported != testCase.expectSupported {
+			t.Errorf(
+				"mode support status (%t) does not match expected (%t)",
+				supported,
+				testCase.expectSupported,
+			)
+		}
+	}
+}
+
+// TestInitialSynchronizationModeDescription tests that
+// InitialSynchronizationMode description generation works as expected.
+func TestInitialSynchronizationModeDescription(t *testing.T) {
+	// Set up test cases.
+	testCases := []struct {
+		mode                InitialSynchronizationMode
+		expectedDescription string
+	}{
+		{InitialSynchronizationMode_InitialSynchronizationModeDefault, "Default"},
+		{InitialSynchronizationMode_InitialSynchronizationModeAutomatic, "Automatic"},
+		{InitialSynchronizationMode_InitialSynchronizationModeForce, "Force"},
+		{(InitialSynchronizationMode_InitialSynchronizationModeForce + 1), "Unknown"},
+	}
+
+	// Process test cases.
+	for _, testCase := range testCases {
+		if description := testCase.mode.Description(); description != testCase.expectedDescription {
+			t.Errorf(
+				"mode description (%s) does not match expected (%s)",
+				description,
+				testCase.expectedDescription,
+			)
+		}
+	}
+}
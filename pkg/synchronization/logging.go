@@ -27,10 +27,14 @@ func formatEntryForLogging(entry *core.Entry) string {
 		return fmt.Sprintf("File (Digest %x)", entry.Digest)
 	} else if entry.Kind == core.EntryKind_SymbolicLink {
 		return fmt.Sprintf("Symbolic Link (Target %s)", entry.Target)
+	} else if entry.Kind == core.EntryKind_SpecialFile {
+		return "Special File"
 	} else if entry.Kind == core.EntryKind_Untracked {
 		return "Untracked content"
 	} else if entry.Kind == core.EntryKind_Problematic {
 		return fmt.Sprintf("Problematic content (%s)", entry.Problem)
+	} else if entry.Kind == core.EntryKind_Oversized {
+		return "Oversized content"
 	}
 	return "<unknown>"
 }
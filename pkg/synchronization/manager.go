@@ -2,13 +2,18 @@ package synchronization
 
 import (
 	"context"
+	"encoding/binary"
 	"errors"
 	"fmt"
 	"sort"
+	"sync"
+
+	"google.golang.org/protobuf/proto"
 
 	"github.com/mutagen-io/mutagen/pkg/filesystem"
 	"github.com/mutagen-io/mutagen/pkg/identifier"
 	"github.com/mutagen-io/mutagen/pkg/logging"
+	"github.com/mutagen-io/mutagen/pkg/prompting"
 	"github.com/mutagen-io/mutagen/pkg/selection"
 	"github.com/mutagen-io/mutagen/pkg/state"
 	"github.com/mutagen-io/mutagen/pkg/synchronization/core"
@@ -28,6 +33,10 @@ const (
 	// problems that will be reported by Manager.List for a single endpoint in a
 	// session before transition problem list truncation for that endpoint.
 	maximumListTransitionProblems = 10
+	// maximumListVerificationDivergences is the maximum number of verification
+	// divergences that will be reported by Manager.List for a single endpoint
+	// in a session before divergence list truncation for that endpoint.
+	maximumListVerificationDivergences = 10
 )
 
 // Manager provides synchronization session management facilities. Its methods
@@ -42,10 +51,27 @@ type Manager struct {
 	sessionsLock *state.TrackingLock
 	// sessions maps sessions to their respective controllers.
 	sessions map[string]*controller
+	// deterministicCreationLock serializes the creation of sessions with
+	// deterministic identifiers, ensuring that concurrent requests to create the
+	// same session can't both succeed.
+	deterministicCreationLock sync.Mutex
+	// allowedRoots is the list of resolved root path prefixes under which local
+	// synchronization roots may be created. If nil, then all roots are allowed.
+	allowedRoots []string
 }
 
-// NewManager creates a new Manager instance.
+// NewManager creates a new Manager instance. If the
+// MUTAGEN_DAEMON_ALLOWED_ROOTS environment variable is set, then session
+// creation will be restricted to local roots that fall within one of the
+// specified path prefixes. Setting the variable without specifying any prefixes
+// is treated as an error.
 func NewManager(logger *logging.Logger) (*Manager, error) {
+	// Load any root restrictions.
+	allowedRoots, err := loadAllowedRoots()
+	if err != nil {
+		return nil, fmt.Errorf("unable to load allowed roots: %w", err)
+	}
+
 	// Create a tracker and corresponding lock to watch for state changes.
 	tracker := state.NewTracker()
 	sessionsLock := state.NewTrackingLock(tracker)
@@ -85,6 +111,7 @@ func NewManager(logger *logging.Logger) (*Manager, error) {
 		tracker:      tracker,
 		sessionsLock: sessionsLock,
 		sessions:     sessions,
+		allowedRoots: allowedRoots,
 	}, nil
 }
 
@@ -202,7 +229,57 @@ func (m *Manager) Shutdown() {
 	}
 }
 
-// Create tells the manager to create a new session.
+// deterministicSessionIdentifier computes a session identifier derived from a
+// hash of the session's defining parameters. Sessions created with the same
+// endpoints, configurations, name, and labels will yield the same identifier.
+func deterministicSessionIdentifier(
+	alpha, beta *url.URL,
+	configuration, configurationAlpha, configurationBeta *Configuration,
+	name string,
+	labels map[string]string,
+) (string, error) {
+	// Create a buffer to accumulate the defining parameters. Each component is
+	// length-prefixed to keep the encoding unambiguous.
+	var data []byte
+	appendComponent := func(component []byte) {
+		data = binary.AppendUvarint(data, uint64(len(component)))
+		data = append(data, component...)
+	}
+
+	// Encode messages. We use deterministic Protocol Buffers marshaling to
+	// ensure that encodings are stable across invocations.
+	marshaler := proto.MarshalOptions{Deterministic: true}
+	for _, message := range []proto.Message{
+		alpha, beta,
+		configuration, configurationAlpha, configurationBeta,
+	} {
+		encoded, err := marshaler.Marshal(message)
+		if err != nil {
+			return "", fmt.Errorf("unable to encode session parameters: %w", err)
+		}
+		appendComponent(encoded)
+	}
+
+	// Encode the name and labels, sorting the latter by key.
+	appendComponent([]byte(name))
+	keys := make([]string, 0, len(labels))
+	for key := range labels {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	for _, key := range keys {
+		appendComponent([]byte(key))
+		appendComponent([]byte(labels[key]))
+	}
+
+	// Derive the identifier.
+	return identifier.NewDeterministic(identifier.PrefixSynchronization, data)
+}
+
+// Create tells the manager to create a new session. If deterministic is true,
+// then the session identifier is derived from the session's defining parameters
+// and, if a matching session already exists, its identifier is returned without
+// creating a new session.
 func (m *Manager) Create(
 	ctx context.Context,
 	alpha, beta *url.URL,
@@ -210,14 +287,63 @@ func (m *Manager) Create(
 	name string,
 	labels map[string]string,
 	paused bool,
+	deterministic bool,
 	prompter string,
 ) (string, error) {
-	// Create a unique session identifier.
-	id, err := identifier.New(identifier.PrefixSynchronization)
-	if err != nil {
+	// Verify that the session roots are allowed.
+	if err := ensureRootAllowed(alpha, m.allowedRoots); err != nil {
+		return "", fmt.Errorf("alpha root not allowed: %w", err)
+	} else if err = ensureRootAllowed(beta, m.allowedRoots); err != nil {
+		return "", fmt.Errorf("beta root not allowed: %w", err)
+	}
+
+	// Create the session identifier. If a deterministic identifier has been
+	// requested, then derive it from the session's defining parameters and
+	// check whether or not a matching session already exists, in which case
+	// creation is a no-op.
+	var id string
+	var err error
+	if deterministic {
+		m.deterministicCreationLock.Lock()
+		defer m.deterministicCreationLock.Unlock()
+		id, err = deterministicSessionIdentifier(
+			alpha, beta,
+			configuration, configurationAlpha, configurationBeta,
+			name,
+			labels,
+		)
+		if err != nil {
+			return "", fmt.Errorf("unable to generate identifier for session: %w", err)
+		}
+		m.sessionsLock.Lock()
+		_, exists := m.sessions[id]
+		m.sessionsLock.UnlockWithoutNotify()
+		if exists {
+			m.logger.Info("Matching session already exists:", id)
+			return id, nil
+		}
+	} else if id, err = identifier.New(identifier.PrefixSynchronization); err != nil {
 		return "", fmt.Errorf("unable to generate identifier for session: %w", err)
 	}
 
+	// Check for overlap between the session's local roots and those of existing
+	// sessions and handle any overlaps according to the root overlap mode.
+	rootOverlapMode := configuration.RootOverlapMode
+	if rootOverlapMode.IsDefault() {
+		rootOverlapMode = DefaultVersion.DefaultRootOverlapMode()
+	}
+	if overlaps, err := m.findRootOverlaps(alpha, beta); err != nil {
+		return "", fmt.Errorf("unable to check for root overlaps: %w", err)
+	} else if len(overlaps) > 0 {
+		if rootOverlapMode == RootOverlapMode_RootOverlapModeRefuse {
+			return "", fmt.Errorf("root overlap detected: %s", overlaps[0])
+		}
+		for _, overlap := range overlaps {
+			m.logger.Warn("Root overlap detected:", overlap)
+			prompting.Message(prompter, "Warning: "+overlap)
+		}
+	}
+
 	// Attempt to create a session.
 	controller, err := newSession(
 		ctx,
@@ -244,6 +370,76 @@ func (m *Manager) Create(
 	return controller.session.Identifier, nil
 }
 
+// findRootOverlaps identifies overlaps between the specified endpoint URLs and
+// the roots of existing sessions, returning a description of each overlap.
+// Only local roots are considered, since remote roots can't be compared
+// reliably from the daemon's perspective.
+func (m *Manager) findRootOverlaps(alpha, beta *url.URL) ([]string, error) {
+	// Define the endpoint URL and root types used for comparison.
+	type endpointURL struct {
+		endpoint string
+		url      *url.URL
+	}
+	type root struct {
+		endpoint string
+		path     string
+	}
+
+	// Resolve the local roots of the prospective session.
+	var roots []root
+	for _, candidate := range []endpointURL{{"alpha", alpha}, {"beta", beta}} {
+		if candidate.url.Protocol != url.Protocol_Local {
+			continue
+		}
+		path, err := resolvePath(candidate.url.Path)
+		if err != nil {
+			return nil, fmt.Errorf("unable to resolve %s root path: %w", candidate.endpoint, err)
+		}
+		roots = append(roots, root{candidate.endpoint, path})
+	}
+	if len(roots) == 0 {
+		return nil, nil
+	}
+
+	// Grab the existing sessions, sorted by identifier for stable reporting.
+	m.sessionsLock.Lock()
+	sessions := make([]*Session, 0, len(m.sessions))
+	for _, controller := range m.sessions {
+		sessions = append(sessions, controller.session)
+	}
+	m.sessionsLock.UnlockWithoutNotify()
+	sort.Slice(sessions, func(i, j int) bool {
+		return sessions[i].Identifier < sessions[j].Identifier
+	})
+
+	// Compare against the local roots of existing sessions. Existing roots
+	// that can't be resolved are skipped, since they'll be handled (and
+	// reported) by their own sessions.
+	var overlaps []string
+	for _, session := range sessions {
+		for _, existing := range []endpointURL{{"alpha", session.Alpha}, {"beta", session.Beta}} {
+			if existing.url.Protocol != url.Protocol_Local {
+				continue
+			}
+			path, err := resolvePath(existing.url.Path)
+			if err != nil {
+				continue
+			}
+			for _, r := range roots {
+				if rootsOverlap(r.path, path) {
+					overlaps = append(overlaps, fmt.Sprintf(
+						"%s root (%s) overlaps with %s root (%s) of session %s",
+						r.endpoint, r.path, existing.endpoint, path, session.Identifier,
+					))
+				}
+			}
+		}
+	}
+
+	// Done.
+	return overlaps, nil
+}
+
 // List requests a state snapshot for the specified sessions. Session states
 // will be ordered by creation time, from oldest to newest. Problem and conflict
 // lists will sorted by path and truncated to reasonable lengths, and conflicts
@@ -264,7 +460,8 @@ func (m *Manager) List(ctx context.Context, selection *selection.Selection, prev
 	// Create a static snapshot of the state from each controller, then perform
 	// additional deep copying of problem and conflict lists, sort these lists
 	// based on path, truncate them if they're too long, and convert conflicts
-	// to their slim representation.
+	// to their slim representation. Problem lists may have already been
+	// truncated by the controller, so excluded problem counts are accumulated.
 	//
 	// HACK: We're relying a lot on understanding the internals of currentState
 	// and its call stack. It promises a static snapshot of the state, but we
@@ -300,7 +497,7 @@ func (m *Manager) List(ctx context.Context, selection *selection.Selection, prev
 		state.AlphaState.ScanProblems = core.CopyProblems(state.AlphaState.ScanProblems)
 		core.SortProblems(state.AlphaState.ScanProblems)
 		if len(state.AlphaState.ScanProblems) > maximumListScanProblems {
-			state.AlphaState.ExcludedScanProblems = uint64(len(state.AlphaState.ScanProblems) - maximumListScanProblems)
+			state.AlphaState.ExcludedScanProblems += uint64(len(state.AlphaState.ScanProblems) - maximumListScanProblems)
 			state.AlphaState.ScanProblems = state.AlphaState.ScanProblems[:maximumListScanProblems]
 		}
 
@@ -308,7 +505,7 @@ func (m *Manager) List(ctx context.Context, selection *selection.Selection, prev
 		state.AlphaState.TransitionProblems = core.CopyProblems(state.AlphaState.TransitionProblems)
 		core.SortProblems(state.AlphaState.TransitionProblems)
 		if len(state.AlphaState.TransitionProblems) > maximumListTransitionProblems {
-			state.AlphaState.ExcludedTransitionProblems = uint64(len(state.AlphaState.TransitionProblems) - maximumListTransitionProblems)
+			state.AlphaState.ExcludedTransitionProblems += uint64(len(state.AlphaState.TransitionProblems) - maximumListTransitionProblems)
 			state.AlphaState.TransitionProblems = state.AlphaState.TransitionProblems[:maximumListTransitionProblems]
 		}
 
@@ -316,7 +513,7 @@ func (m *Manager) List(ctx context.Context, selection *selection.Selection, prev
 		state.BetaState.ScanProblems = core.CopyProblems(state.BetaState.ScanProblems)
 		core.SortProblems(state.BetaState.ScanProblems)
 		if len(state.BetaState.ScanProblems) > maximumListScanProblems {
-			state.BetaState.ExcludedScanProblems = uint64(len(state.BetaState.ScanProblems) - maximumListScanProblems)
+			state.BetaState.ExcludedScanProblems += uint64(len(state.BetaState.ScanProblems) - maximumListScanProblems)
 			state.BetaState.ScanProblems = state.BetaState.ScanProblems[:maximumListScanProblems]
 		}
 
@@ -324,10 +521,21 @@ func (m *Manager) List(ctx context.Context, selection *selection.Selection, prev
 		state.BetaState.TransitionProblems = core.CopyProblems(state.BetaState.TransitionProblems)
 		core.SortProblems(state.BetaState.TransitionProblems)
 		if len(state.BetaState.TransitionProblems) > maximumListTransitionProblems {
-			state.BetaState.ExcludedTransitionProblems = uint64(len(state.BetaState.TransitionProblems) - maximumListTransitionProblems)
+			state.BetaState.ExcludedTransitionProblems += uint64(len(state.BetaState.TransitionProblems) - maximumListTransitionProblems)
 			state.BetaState.TransitionProblems = state.BetaState.TransitionProblems[:maximumListTransitionProblems]
 		}
 
+		// Truncate verification divergences. These are already sorted and
+		// aren't modified in-place, so no copy is required.
+		if len(state.AlphaState.VerificationDivergences) > maximumListVerificationDivergences {
+			state.AlphaState.ExcludedVerificationDivergences = uint64(len(state.AlphaState.VerificationDivergences) - maximumListVerificationDivergences)
+			state.AlphaState.VerificationDivergences = state.AlphaState.VerificationDivergences[:maximumListVerificationDivergences]
+		}
+		if len(state.BetaState.VerificationDivergences) > maximumListVerificationDivergences {
+			state.BetaState.ExcludedVerificationDivergences = uint64(len(state.BetaState.VerificationDivergences) - maximumListVerificationDivergences)
+			state.BetaState.VerificationDivergences = state.BetaState.VerificationDivergences[:maximumListVerificationDivergences]
+		}
+
 		// Store the state snapshot.
 		states[i] = state
 	}
@@ -345,7 +553,15 @@ func (m *Manager) List(ctx context.Context, selection *selection.Selection, prev
 }
 
 // Flush tells the manager to flush sessions matching the given specifications.
-func (m *Manager) Flush(ctx context.Context, selection *selection.Selection, prompter string, skipWait bool) error {
+// If a non-empty scope is provided, then the flush is restricted to the subtrees
+// rooted at the specified synchronization-root-relative paths.
+func (m *Manager) Flush(ctx context.Context, selection *selection.Selection, prompter string, skipWait bool, scope []string) error {
+	// Normalize the scope.
+	scope, err := NormalizeScope(scope)
+	if err != nil {
+		return fmt.Errorf("invalid flush scope: %w", err)
+	}
+
 	// Extract the controllers for the sessions of interest.
 	controllers, err := m.selectControllers(selection)
 	if err != nil {
@@ -354,7 +570,7 @@ func (m *Manager) Flush(ctx context.Context, selection *selection.Selection, pro
 
 	// Attempt to flush the sessions.
 	for _, controller := range controllers {
-		if err := controller.flush(ctx, prompter, skipWait); err != nil {
+		if err := controller.flush(ctx, prompter, skipWait, scope); err != nil {
 			return fmt.Errorf("unable to flush session: %w", err)
 		}
 	}
@@ -363,6 +579,59 @@ func (m *Manager) Flush(ctx context.Context, selection *selection.Selection, pro
 	return nil
 }
 
+// Fetch tells the manager to fetch the content of a single file from one of the
+// endpoints of the session matching the given specifications. The selection
+// must match exactly one session. The path is a synchronization-root-relative
+// path and must not refer to the synchronization root itself.
+func (m *Manager) Fetch(ctx context.Context, selection *selection.Selection, beta bool, path string) ([]byte, error) {
+	// Normalize the path.
+	path, err := normalizeScopePath(path)
+	if err != nil {
+		return nil, fmt.Errorf("invalid fetch path: %w", err)
+	} else if path == "" {
+		return nil, errors.New("invalid fetch path: path refers to synchronization root")
+	}
+
+	// Extract the controller for the session of interest.
+	controllers, err := m.selectControllers(selection)
+	if err != nil {
+		return nil, fmt.Errorf("unable to locate requested session: %w", err)
+	} else if len(controllers) != 1 {
+		return nil, errors.New("fetch requires exactly one session")
+	}
+
+	// Perform the fetch.
+	content, err := controllers[0].fetch(ctx, beta, path)
+	if err != nil {
+		return nil, fmt.Errorf("unable to fetch file: %w", err)
+	}
+
+	// Success.
+	return content, nil
+}
+
+// Preview tells the manager to compute a preview of the next synchronization
+// cycle for the session matching the given specifications. The selection must
+// match exactly one session, and that session must be paused.
+func (m *Manager) Preview(ctx context.Context, selection *selection.Selection, prompter string) (*Preview, error) {
+	// Extract the controller for the session of interest.
+	controllers, err := m.selectControllers(selection)
+	if err != nil {
+		return nil, fmt.Errorf("unable to locate requested session: %w", err)
+	} else if len(controllers) != 1 {
+		return nil, errors.New("preview requires exactly one session")
+	}
+
+	// Compute the preview.
+	preview, err := controllers[0].preview(ctx, prompter)
+	if err != nil {
+		return nil, fmt.Errorf("unable to preview session: %w", err)
+	}
+
+	// Success.
+	return preview, nil
+}
+
 // Pause tells the manager to pause sessions matching the given specifications.
 func (m *Manager) Pause(ctx context.Context, selection *selection.Selection, prompter string) error {
 	// Extract the controllers for the sessions of interest.
@@ -445,3 +714,30 @@ func (m *Manager) Terminate(ctx context.Context, selection *selection.Selection,
 	// Success.
 	return nil
 }
+
+// Usage computes aggregate resource usage across all sessions. If
+// includeDiskUsage is true, then staging disk usage will also be computed,
+// which requires walking the staging directory.
+func (m *Manager) Usage(includeDiskUsage bool) (*Usage, error) {
+	// Aggregate usage across all controllers.
+	controllers := m.allControllers()
+	usage := &Usage{Sessions: uint64(len(controllers))}
+	for _, controller := range controllers {
+		trackedEntries, snapshotMemory, connections := controller.usage()
+		usage.TrackedEntries += trackedEntries
+		usage.SnapshotMemory += snapshotMemory
+		usage.Connections += connections
+	}
+
+	// Compute staging disk usage, if requested.
+	if includeDiskUsage {
+		stagingDiskUsage, err := stagingDiskUsage()
+		if err != nil {
+			return nil, fmt.Errorf("unable to compute staging disk usage: %w", err)
+		}
+		usage.StagingDiskUsage = stagingDiskUsage
+	}
+
+	// Success.
+	return usage, nil
+}
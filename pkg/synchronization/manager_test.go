@@ -1,3 +1,324 @@
 package synchronization
 
-// TODO: Implement.
+import (
+	"context"
+	"io"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"google.golang.org/protobuf/proto"
+
+	"github.com/mutagen-io/mutagen/pkg/filesystem"
+	"github.com/mutagen-io/mutagen/pkg/logging"
+	"github.com/mutagen-io/mutagen/pkg/selection"
+	"github.com/mutagen-io/mutagen/pkg/state"
+	"github.com/mutagen-io/mutagen/pkg/synchronization/core"
+	"github.com/mutagen-io/mutagen/pkg/url"
+)
+
+// TestManagerCreateDeterministic tests that creating the same session twice
+// with deterministic identifiers yields a single session.
+func TestManagerCreateDeterministic(t *testing.T) {
+	// Create an isolated data directory and a manager.
+	t.Setenv("MUTAGEN_DATA_DIRECTORY", t.TempDir())
+	manager, err := NewManager(logging.NewLogger(logging.LevelDisabled, io.Discard))
+	if err != nil {
+		t.Fatal("unable to create manager:", err)
+	}
+	defer manager.Shutdown()
+
+	// Set up session parameters. We create sessions pre-paused so that no
+	// endpoint connections are required.
+	alpha := &url.URL{Protocol: url.Protocol_Local, Path: t.TempDir()}
+	beta := &url.URL{Protocol: url.Protocol_Local, Path: t.TempDir()}
+	configuration := &Configuration{Ignores: []string{"ignored"}}
+	labels := map[string]string{"first": "1", "second": "2"}
+	create := func(name string, deterministic bool) string {
+		id, err := manager.Create(
+			context.Background(),
+			alpha, beta,
+			configuration, &Configuration{}, &Configuration{},
+			name,
+			labels,
+			true,
+			deterministic,
+			"",
+		)
+		if err != nil {
+			t.Fatal("unable to create session:", err)
+		}
+		return id
+	}
+
+	// Create the same session twice and verify that the identifiers match.
+	first := create("session", true)
+	if second := create("session", true); second != first {
+		t.Error("deterministic session identifiers differ:", first, second)
+	}
+
+	// Create a session with different parameters and verify that it yields a
+	// different identifier.
+	if other := create("other", true); other == first {
+		t.Error("sessions with different parameters have the same identifier")
+	}
+
+	// Verify that only two sessions exist.
+	_, states, err := manager.List(context.Background(), &selection.Selection{All: true}, 0)
+	if err != nil {
+		t.Fatal("unable to list sessions:", err)
+	} else if len(states) != 2 {
+		t.Error("unexpected session count:", len(states))
+	}
+
+	// Verify that non-deterministic creation still creates a new session.
+	if id := create("session", false); id == first {
+		t.Error("non-deterministic creation reused deterministic identifier")
+	}
+}
+
+// TestManagerCreateAllowedRoots tests that session creation is restricted to
+// allowed roots when root restrictions are specified.
+func TestManagerCreateAllowedRoots(t *testing.T) {
+	// Create an allowed root prefix and a directory outside of it.
+	allowed := t.TempDir()
+	disallowed := t.TempDir()
+
+	// Create an isolated data directory and a manager with root restrictions.
+	t.Setenv("MUTAGEN_DATA_DIRECTORY", t.TempDir())
+	t.Setenv(allowedRootsEnvironmentVariable, allowed)
+	manager, err := NewManager(logging.NewLogger(logging.LevelDisabled, io.Discard))
+	if err != nil {
+		t.Fatal("unable to create manager:", err)
+	}
+	defer manager.Shutdown()
+
+	// Set up a session creation function. We create sessions pre-paused so
+	// that no endpoint connections are required.
+	create := func(alphaPath, betaPath string) error {
+		_, err := manager.Create(
+			context.Background(),
+			&url.URL{Protocol: url.Protocol_Local, Path: alphaPath},
+			&url.URL{Protocol: url.Protocol_Local, Path: betaPath},
+			&Configuration{}, &Configuration{}, &Configuration{},
+			"",
+			nil,
+			true,
+			false,
+			"",
+		)
+		return err
+	}
+
+	// Verify that a session with both roots in the allowed prefix (including
+	// one that doesn't exist yet) can be created.
+	if err := create(filepath.Join(allowed, "alpha"), filepath.Join(allowed, "beta", "nested")); err != nil {
+		t.Error("unable to create session with allowed roots:", err)
+	}
+
+	// Verify that a session with a root outside of the allowed prefix can't
+	// be created.
+	if create(filepath.Join(allowed, "alpha"), disallowed) == nil {
+		t.Error("session with disallowed root created successfully")
+	}
+
+	// Verify that a root can't escape the allowed prefix using a parent
+	// directory reference.
+	if create(filepath.Join(allowed, "..", filepath.Base(disallowed)), filepath.Join(allowed, "beta")) == nil {
+		t.Error("session with escaping root created successfully")
+	}
+
+	// Verify that a root can't escape the allowed prefix using a symbolic link.
+	link := filepath.Join(allowed, "link")
+	if err := os.Symlink(disallowed, link); err != nil {
+		t.Fatal("unable to create symbolic link:", err)
+	}
+	if create(filepath.Join(link, "alpha"), filepath.Join(allowed, "beta")) == nil {
+		t.Error("session with root escaping via symbolic link created successfully")
+	}
+
+	// Verify that only the allowed session was created.
+	_, states, err := manager.List(context.Background(), &selection.Selection{All: true}, 0)
+	if err != nil {
+		t.Fatal("unable to list sessions:", err)
+	} else if len(states) != 1 {
+		t.Error("unexpected session count:", len(states))
+	}
+}
+
+// TestManagerCreateRootOverlap tests that overlap between the local roots of a
+// new session and those of existing sessions is handled according to the root
+// overlap mode.
+func TestManagerCreateRootOverlap(t *testing.T) {
+	// Set up test cases. Paths are relative to a common parent directory, in
+	// which an existing session with alpha and beta roots is created.
+	testCases := []struct {
+		description string
+		alpha       string
+		beta        string
+		mode        RootOverlapMode
+		expectError bool
+	}{
+		{"equal warn", "alpha", "other", RootOverlapMode_RootOverlapModeWarn, false},
+		{"equal refuse", "alpha", "other", RootOverlapMode_RootOverlapModeRefuse, true},
+		{"nested warn", "other", filepath.Join("beta", "nested"), RootOverlapMode_RootOverlapModeWarn, false},
+		{"nested refuse", "other", filepath.Join("beta", "nested"), RootOverlapMode_RootOverlapModeRefuse, true},
+		{"containing warn", "", "other", RootOverlapMode_RootOverlapModeWarn, false},
+		{"containing refuse", "", "other", RootOverlapMode_RootOverlapModeRefuse, true},
+		{"disjoint warn", "alpha2", "other", RootOverlapMode_RootOverlapModeWarn, false},
+		{"disjoint refuse", "alpha2", "other", RootOverlapMode_RootOverlapModeRefuse, false},
+		{"default", "alpha", "other", RootOverlapMode_RootOverlapModeDefault, false},
+	}
+
+	// Process test cases.
+	for _, testCase := range testCases {
+		t.Run(testCase.description, func(t *testing.T) {
+			// Create an isolated data directory and a manager.
+			t.Setenv("MUTAGEN_DATA_DIRECTORY", t.TempDir())
+			manager, err := NewManager(logging.NewLogger(logging.LevelDisabled, io.Discard))
+			if err != nil {
+				t.Fatal("unable to create manager:", err)
+			}
+			defer manager.Shutdown()
+
+			// Set up a session creation function. We create sessions
+			// pre-paused so that no endpoint connections are required.
+			parent := t.TempDir()
+			create := func(alphaPath, betaPath string, mode RootOverlapMode) error {
+				_, err := manager.Create(
+					context.Background(),
+					&url.URL{Protocol: url.Protocol_Local, Path: filepath.Join(parent, alphaPath)},
+					&url.URL{Protocol: url.Protocol_Local, Path: filepath.Join(parent, betaPath)},
+					&Configuration{RootOverlapMode: mode}, &Configuration{}, &Configuration{},
+					"",
+					nil,
+					true,
+					false,
+					"",
+				)
+				return err
+			}
+
+			// Create the existing session.
+			if err := create("alpha", "beta", RootOverlapMode_RootOverlapModeRefuse); err != nil {
+				t.Fatal("unable to create existing session:", err)
+			}
+
+			// Create the new session and verify the result.
+			err = create(testCase.alpha, testCase.beta, testCase.mode)
+			if err != nil && !testCase.expectError {
+				t.Error("unable to create session:", err)
+			} else if err == nil && testCase.expectError {
+				t.Error("session with overlapping root created successfully")
+			}
+
+			// Verify the session count.
+			expectedCount := 2
+			if testCase.expectError {
+				expectedCount = 1
+			}
+			_, states, err := manager.List(context.Background(), &selection.Selection{All: true}, 0)
+			if err != nil {
+				t.Fatal("unable to list sessions:", err)
+			} else if len(states) != expectedCount {
+				t.Error("unexpected session count:", len(states))
+			}
+		})
+	}
+}
+
+// TestManagerEmptyAllowedRoots tests that manager creation fails if root
+// restrictions are specified without any allowed root prefixes.
+func TestManagerEmptyAllowedRoots(t *testing.T) {
+	// Create an isolated data directory.
+	t.Setenv("MUTAGEN_DATA_DIRECTORY", t.TempDir())
+
+	// Verify that empty specifications are rejected.
+	for _, specification := range []string{"", string(filepath.ListSeparator)} {
+		t.Setenv(allowedRootsEnvironmentVariable, specification)
+		if manager, err := NewManager(logging.NewLogger(logging.LevelDisabled, io.Discard)); err == nil {
+			manager.Shutdown()
+			t.Errorf("manager created with empty allowed roots specification: %q", specification)
+		}
+	}
+}
+
+// TestManagerUsage tests that Manager.Usage aggregates resource usage across a
+// known set of sessions.
+func TestManagerUsage(t *testing.T) {
+	// Create an isolated data directory and a manager.
+	t.Setenv("MUTAGEN_DATA_DIRECTORY", t.TempDir())
+	manager, err := NewManager(logging.NewLogger(logging.LevelDisabled, io.Discard))
+	if err != nil {
+		t.Fatal("unable to create manager:", err)
+	}
+	defer manager.Shutdown()
+
+	// Register a known set of sessions and defer their removal (since they
+	// aren't running and thus can't be shut down by the manager). The first
+	// has completed a scan and is connected to both endpoints, while the
+	// second is disconnected.
+	defer func() {
+		manager.sessionsLock.Lock()
+		delete(manager.sessions, "connected")
+		delete(manager.sessions, "disconnected")
+		manager.sessionsLock.UnlockWithoutNotify()
+	}()
+	snapshot := &core.Snapshot{
+		Content: &core.Entry{Contents: map[string]*core.Entry{
+			"file": {Kind: core.EntryKind_File, Digest: []byte{0}},
+		}},
+		Files: 1,
+	}
+	manager.sessions["connected"] = &controller{
+		stateLock: state.NewTrackingLock(manager.tracker),
+		state: &State{
+			AlphaState: &EndpointState{Connected: true, Directories: 1, Files: 1},
+			BetaState:  &EndpointState{Connected: true, Directories: 1, Files: 1, SymbolicLinks: 1},
+		},
+		heldAlphaSnapshot: snapshot,
+		heldBetaSnapshot:  snapshot,
+		heldAncestor:      snapshot.Content,
+	}
+	manager.sessions["disconnected"] = &controller{
+		stateLock: state.NewTrackingLock(manager.tracker),
+		state: &State{
+			AlphaState: &EndpointState{Directories: 2, Files: 3},
+			BetaState:  &EndpointState{},
+		},
+	}
+
+	// Create content in the staging directory.
+	stagingDirectoryPath, err := filesystem.Mutagen(true, filesystem.MutagenSynchronizationStagingDirectoryName)
+	if err != nil {
+		t.Fatal("unable to compute staging directory path:", err)
+	} else if err = os.MkdirAll(filepath.Join(stagingDirectoryPath, "root"), 0700); err != nil {
+		t.Fatal("unable to create staging root:", err)
+	} else if err = os.WriteFile(filepath.Join(stagingDirectoryPath, "root", "staged"), make([]byte, 100), 0600); err != nil {
+		t.Fatal("unable to create staged file:", err)
+	}
+
+	// Compute usage without disk usage and verify the results.
+	expectedSnapshotMemory := uint64(2*proto.Size(snapshot) + proto.Size(snapshot.Content))
+	usage, err := manager.Usage(false)
+	if err != nil {
+		t.Fatal("unable to compute usage:", err)
+	} else if usage.Sessions != 2 {
+		t.Error("unexpected session count:", usage.Sessions)
+	} else if usage.TrackedEntries != 10 {
+		t.Error("unexpected tracked entry count:", usage.TrackedEntries)
+	} else if usage.Connections != 2 {
+		t.Error("unexpected connection count:", usage.Connections)
+	} else if usage.SnapshotMemory != expectedSnapshotMemory {
+		t.Errorf("snapshot memory (%d) does not match expected (%d)", usage.SnapshotMemory, expectedSnapshotMemory)
+	} else if usage.StagingDiskUsage != 0 {
+		t.Error("staging disk usage computed unexpectedly")
+	}
+
+	// Compute usage with disk usage and verify the result.
+	if usage, err = manager.Usage(true); err != nil {
+		t.Fatal("unable to compute usage with disk usage:", err)
+	} else if usage.StagingDiskUsage != 100 {
+		t.Error("unexpected staging disk usage:", usage.StagingDiskUsage)
+	}
+}
@@ -0,0 +1,88 @@
+package synchronization
+
+import (
+	"fmt"
+)
+
+// IsDefault indicates whether or not the oversized file mode is
+// OversizedFileMode_OversizedFileModeDefault.
+func (m OversizedFileMode) IsDefault() bool {
+	return m == OversizedFileMode_OversizedFileModeDefault
+}
+
+// MarshalText implements encoding.TextMarshaler.MarshalText.
+func (m OversizedFileMode) MarshalText() ([]byte, error) {
+	var result string
+	switch m {
+	case OversizedFileMode_OversizedFileModeDefault:
+	case OversizedFileMode_OversizedFileModeSkip:
+		result = "skip"
+	case OversizedFileMode_OversizedFileModeHalt:
+		result = "halt"
+	case OversizedFileMode_OversizedFileModeWarn:
+		result = "warn"
+	case OversizedFileMode_OversizedFileModePlaceholder:
+		result = "placeholder"
+	default:
+		result = "unknown"
+	}
+	return []byte(result), nil
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler.UnmarshalText.
+func (m *OversizedFileMode) UnmarshalText(textBytes []byte) error {
+	// Convert the bytes to a string.
+	text := string(textBytes)
+
+	// Convert to an oversized file mode.
+	switch text {
+	case "skip":
+		*m = OversizedFileMode_OversizedFileModeSkip
+	case "halt":
+		*m = OversizedFileMode_OversizedFileModeHalt
+	case "warn":
+		*m = OversizedFileMode_OversizedFileModeWarn
+	case "placeholder":
+		*m = OversizedFileMode_OversizedFileModePlaceholder
+	default:
+		return fmt.Errorf("unknown oversized file mode specification: %s", text)
+	}
+
+	// Success.
+	return nil
+}
+
+// Supported indicates whether or not a particular oversized file mode is a
+// valid, non-default value.
+func (m OversizedFileMode) Supported() bool {
+	switch m {
+	case OversizedFileMode_OversizedFileModeSkip:
+		return true
+	case OversizedFileMode_OversizedFileModeHalt:
+		return true
+	case OversizedFileMode_OversizedFileModeWarn:
+		return true
+	case OversizedFileMode_OversizedFileModePlaceholder:
+		return true
+	default:
+		return false
+	}
+}
+
+// Description returns a human-readable description of an oversized file mode.
+func (m OversizedFileMode) Description() string {
+	switch m {
+	case OversizedFileMode_OversizedFileModeDefault:
+		return "Default"
+	case OversizedFileMode_OversizedFileModeSkip:
+		return "Skip"
+	case OversizedFileMode_OversizedFileModeHalt:
+		return "Halt"
+	case OversizedFileMode_OversizedFileModeWarn:
+		return "Warn"
+	case OversizedFileMode_OversizedFileModePlaceholder:
+		return "Placeholder"
+	default:
+		return "Unknown"
+	}
+}
@@ -0,0 +1,168 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.35.1
+// 	protoc        v5.28.2
+// source: synchronization/oversized_file_mode.proto
+
+package synchronization
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	reflect "reflect"
+	sync "sync"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+// OversizedFileMode specifies the behavior to use when a file that needs to be
+// staged exceeds the maximum staging file size.
+type OversizedFileMode int32
+
+const (
+	// OversizedFileMode_OversizedFileModeDefault represents an unspecified
+	// oversized file mode. It should be converted to one of the following
+	// values based on the desired default behavior.
+	OversizedFileMode_OversizedFileModeDefault OversizedFileMode = 0
+	// OversizedFileMode_OversizedFileModeSkip specifies that oversized files
+	// should not be staged and should instead be reported as transition
+	// problems.
+	OversizedFileMode_OversizedFileModeSkip OversizedFileMode = 1
+	// OversizedFileMode_OversizedFileModeHalt specifies that oversized files
+	// should not be staged and that the session should be halted if any are
+	// encountered.
+	OversizedFileMode_OversizedFileModeHalt OversizedFileMode = 2
+	// OversizedFileMode_OversizedFileModeWarn specifies that oversized files
+	// should be staged anyway, with a warning logged for each.
+	OversizedFileMode_OversizedFileModeWarn OversizedFileMode = 3
+	// OversizedFileMode_OversizedFileModePlaceholder specifies that oversized
+	// files should not be staged and should instead be represented by empty
+	// placeholder files. Placeholders are distinguished from genuinely empty
+	// files by the endpoint's scan cache, which associates each unmodified
+	// placeholder with the digest of the content that it represents. If a
+	// placeholder is modified (or the cache is lost), then it's treated as a
+	// regular file, so this mode is best suited to one-way synchronization.
+	OversizedFileMode_OversizedFileModePlaceholder OversizedFileMode = 4
+)
+
+// Enum value maps for OversizedFileMode.
+var (
+	OversizedFileMode_name = map[int32]string{
+		0: "OversizedFileModeDefault",
+		1: "OversizedFileModeSkip",
+		2: "OversizedFileModeHalt",
+		3: "OversizedFileModeWarn",
+		4: "OversizedFileModePlaceholder",
+	}
+	OversizedFileMode_value = map[string]int32{
+		"OversizedFileModeDefault":     0,
+		"OversizedFileModeSkip":        1,
+		"OversizedFileModeHalt":        2,
+		"OversizedFileModeWarn":        3,
+		"OversizedFileModePlaceholder": 4,
+	}
+)
+
+func (x OversizedFileMode) Enum() *OversizedFileMode {
+	p := new(OversizedFileMode)
+	*p = x
+	return p
+}
+
+func (x OversizedFileMode) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (OversizedFileMode) Descriptor() protoreflect.EnumDescriptor {
+	return file_synchronization_oversized_file_mode_proto_enumTypes[0].Descriptor()
+}
+
+func (OversizedFileMode) Type() protoreflect.EnumType {
+	return &file_synchronization_oversized_file_mode_proto_enumTypes[0]
+}
+
+func (x OversizedFileMode) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use OversizedFileMode.Descriptor instead.
+func (OversizedFileMode) EnumDescriptor() ([]byte, []int) {
+	return file_synchronization_oversized_file_mode_proto_rawDescGZIP(), []int{0}
+}
+
+var File_synchronization_oversized_file_mode_proto protoreflect.FileDescriptor
+
+var file_synchronization_oversized_file_mode_proto_rawDesc = []byte{
+	0x0a, 0x29, 0x73, 0x79, 0x6e, 0x63, 0x68, 0x72, 0x6f, 0x6e, 0x69, 0x7a, 0x61, 0x74, 0x69, 0x6f,
+	0x6e, 0x2f, 0x6f, 0x76, 0x65, 0x72, 0x73, 0x69, 0x7a, 0x65, 0x64, 0x5f, 0x66, 0x69, 0x6c, 0x65,
+	0x5f, 0x6d, 0x6f, 0x64, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x12, 0x0f, 0x73, 0x79, 0x6e,
+	0x63, 0x68, 0x72, 0x6f, 0x6e, 0x69, 0x7a, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x2a, 0xa4, 0x01, 0x0a,
+	0x11, 0x4f, 0x76, 0x65, 0x72, 0x73, 0x69, 0x7a, 0x65, 0x64, 0x46, 0x69, 0x6c, 0x65, 0x4d, 0x6f,
+	0x64, 0x65, 0x12, 0x1c, 0x0a, 0x18, 0x4f, 0x76, 0x65, 0x72, 0x73, 0x69, 0x7a, 0x65, 0x64, 0x46,
+	0x69, 0x6c, 0x65, 0x4d, 0x6f, 0x64, 0x65, 0x44, 0x65, 0x66, 0x61, 0x75, 0x6c, 0x74, 0x10, 0x00,
+	0x12, 0x19, 0x0a, 0x15, 0x4f, 0x76, 0x65, 0x72, 0x73, 0x69, 0x7a, 0x65, 0x64, 0x46, 0x69, 0x6c,
+	0x65, 0x4d, 0x6f, 0x64, 0x65, 0x53, 0x6b, 0x69, 0x70, 0x10, 0x01, 0x12, 0x19, 0x0a, 0x15, 0x4f,
+	0x76, 0x65, 0x72, 0x73, 0x69, 0x7a, 0x65, 0x64, 0x46, 0x69, 0x6c, 0x65, 0x4d, 0x6f, 0x64, 0x65,
+	0x48, 0x61, 0x6c, 0x74, 0x10, 0x02, 0x12, 0x19, 0x0a, 0x15, 0x4f, 0x76, 0x65, 0x72, 0x73, 0x69,
+	0x7a, 0x65, 0x64, 0x46, 0x69, 0x6c, 0x65, 0x4d, 0x6f, 0x64, 0x65, 0x57, 0x61, 0x72, 0x6e, 0x10,
+	0x03, 0x12, 0x20, 0x0a, 0x1c, 0x4f, 0x76, 0x65, 0x72, 0x73, 0x69, 0x7a, 0x65, 0x64, 0x46, 0x69,
+	0x6c, 0x65, 0x4d, 0x6f, 0x64, 0x65, 0x50, 0x6c, 0x61, 0x63, 0x65, 0x68, 0x6f, 0x6c, 0x64, 0x65,
+	0x72, 0x10, 0x04, 0x42, 0x33, 0x5a, 0x31, 0x67, 0x69, 0x74, 0x68, 0x75, 0x62, 0x2e, 0x63, 0x6f,
+	0x6d, 0x2f, 0x6d, 0x75, 0x74, 0x61, 0x67, 0x65, 0x6e, 0x2d, 0x69, 0x6f, 0x2f, 0x6d, 0x75, 0x74,
+	0x61, 0x67, 0x65, 0x6e, 0x2f, 0x70, 0x6b, 0x67, 0x2f, 0x73, 0x79, 0x6e, 0x63, 0x68, 0x72, 0x6f,
+	0x6e, 0x69, 0x7a, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
+}
+
+var (
+	file_synchronization_oversized_file_mode_proto_rawDescOnce sync.Once
+	file_synchronization_oversized_file_mode_proto_rawDescData = file_synchronization_oversized_file_mode_proto_rawDesc
+)
+
+func file_synchronization_oversized_file_mode_proto_rawDescGZIP() []byte {
+	file_synchronization_oversized_file_mode_proto_rawDescOnce.Do(func() {
+		file_synchronization_oversized_file_mode_proto_rawDescData = protoimpl.X.CompressGZIP(file_synchronization_oversized_file_mode_proto_rawDescData)
+	})
+	return file_synchronization_oversized_file_mode_proto_rawDescData
+}
+
+var file_synchronization_oversized_file_mode_proto_enumTypes = make([]protoimpl.EnumInfo, 1)
+var file_synchronization_oversized_file_mode_proto_goTypes = []any{
+	(OversizedFileMode)(0), // 0: synchronization.OversizedFileMode
+}
+var file_synchronization_oversized_file_mode_proto_depIdxs = []int32{
+	0, // [0:0] is the sub-list for method output_type
+	0, // [0:0] is the sub-list for method input_type
+	0, // [0:0] is the sub-list for extension type_name
+	0, // [0:0] is the sub-list for extension extendee
+	0, // [0:0] is the sub-list for field type_name
+}
+
+func init() { file_synchronization_oversized_file_mode_proto_init() }
+func file_synchronization_oversized_file_mode_proto_init() {
+	if File_synchronization_oversized_file_mode_proto != nil {
+		return
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: file_synchronization_oversized_file_mode_proto_rawDesc,
+			NumEnums:      1,
+			NumMessages:   0,
+			NumExtensions: 0,
+			NumServices:   0,
+		},
+		GoTypes:           file_synchronization_oversized_file_mode_proto_goTypes,
+		DependencyIndexes: file_synchronization_oversized_file_mode_proto_depIdxs,
+		EnumInfos:         file_synchronization_oversized_file_mode_proto_enumTypes,
+	}.Build()
+	File_synchronization_oversized_file_mode_proto = out.File
+	file_synchronization_oversized_file_mode_proto_rawDesc = nil
+	file_synchronization_oversized_file_mode_proto_goTypes = nil
+	file_synchronization_oversized_file_mode_proto_depIdxs = nil
+}
@@ -0,0 +1,97 @@
+package synchronization
+
+import (
+	"testing"
+)
+
+// TestOversizedFileModeUnmarshal tests that unmarshaling from a string
+// specification succeeeds for OversizedFileMode.
+func TestOversizedFileModeUnmarshal(t *testing.T) {
+	// Set up test cases.
+	testCases := []struct {
+		text          string
+		expectedMode  OversizedFileMode
+		expectFailure bool
+	}{
+		{"", OversizedFileMode_OversizedFileModeDefault, true},
+		{"asdf", OversizedFileMode_OversizedFileModeDefault, true},
+		{"skip", OversizedFileMode_OversizedFileModeSkip, false},
+		{"halt", OversizedFileMode_OversizedFileModeHalt, false},
+		{"warn", OversizedFileMode_OversizedFileModeWarn, false},
+		{"placeholder", OversizedFileMode_OversizedFileModePlaceholder, false},
+	}
+
+	// Process test cases.
+	for _, testCase := range testCases {
+		var mode OversizedFileMode
+		if err := mode.UnmarshalText([]byte(testCase.text)); err != nil {
+			if !testCase.expectFailure {
+				t.Errorf("unable to unmarshal text (%s): %s", testCase.text, err)
+			}
+		} else if testCase.expectFailure {
+			t.Error("unmarshaling succeeded unexpectedly for text:", testCase.text)
+		} else if mode != testCase.expectedMode {
+			t.Errorf(
+				"unmarshaled mode (%s) does not match expected (%s)",
+				mode,
+				testCase.expectedMode,
+			)
+		}
+	}
+}
+
+// TestOversizedFileModeSupported tests that OversizedFileMode support detection
+// works as expected.
+func TestOversizedFileModeSupported(t *testing.T) {
+	// Set up test cases.
+	testCases := []struct {
+		mode            OversizedFileMode
+		expectSupported bool
+	}{
+		{OversizedFileMode_OversizedFileModeDefault, false},
+		{OversizedFileMode_OversizedFileModeSkip, true},
+		{OversizedFileMode_OversizedFileModeHalt, true},
+		{OversizedFileMode_OversizedFileModeWarn, true},
+		{OversizedFileMode_OversizedFileModePlaceholder, true},
+		{(OversizedFileMode_OversizedFileModePlaceholder + 1), false},
+	}
+
+	// Process test cases.
+	for _, testCase := range testCases {
+		if supported := testCase.mode.Supported(); supported != testCase.expectSupported {
+			t.Errorf(
+				"mode support status (%t) does not match expected (%t)",
+				supported,
+				testCase.expectSupported,
+			)
+		}
+	}
+}
+
+// TestOversizedFileModeDescription tests that OversizedFileMode description
+// generation works as expected.
+func TestOversizedFileModeDescription(t *testing.T) {
+	// Set up test cases.
+	testCases := []struct {
+		mode                OversizedFileMode
+		expectedDescription string
+	}{
+		{OversizedFileMode_OversizedFileModeDefault, "Default"},
+		{OversizedFileMode_OversizedFileModeSkip, "Skip"},
+		{OversizedFileMode_OversizedFileModeHalt, "Halt"},
+		{OversizedFileMode_OversizedFileModeWarn, "Warn"},
+		{OversizedFileMode_OversizedFileModePlaceholder, "Placeholder"},
+		{(OversizedFileMode_OversizedFileModePlaceholder + 1), "Unknown"},
+	}
+
+	// Process test cases.
+	for _, testCase := range testCases {
+		if description := testCase.mode.Description(); description != testCase.expectedDescription {
+			t.Errorf(
+				"mode description (%s) does not match expected (%s)",
+				description,
+				testCase.expectedDescription,
+			)
+		}
+	}
+}
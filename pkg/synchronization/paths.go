@@ -33,3 +33,17 @@ func pathForArchive(session string) (string, error) {
 	// Success.
 	return filepath.Join(archivesDirectoryPath, session), nil
 }
+
+// pathForReconciliationRecordings computes the path to the reconciliation
+// recording directory for the given session identifier. The directory itself
+// is not created.
+func pathForReconciliationRecordings(session string) (string, error) {
+	// Compute/create the recordings directory.
+	recordingsDirectoryPath, err := filesystem.Mutagen(true, filesystem.MutagenSynchronizationRecordingsDirectoryName)
+	if err != nil {
+		return "", fmt.Errorf("unable to compute/create recordings directory: %w", err)
+	}
+
+	// Success.
+	return filepath.Join(recordingsDirectoryPath, session), nil
+}
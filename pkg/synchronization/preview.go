@@ -0,0 +1,163 @@
+package synchronization
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/mutagen-io/mutagen/pkg/synchronization/core"
+)
+
+// EnsureValid ensures that Preview's invariants are respected.
+func (p *Preview) EnsureValid() error {
+	// A nil preview is not valid.
+	if p == nil {
+		return errors.New("nil preview")
+	}
+
+	// Ensure that all transitions are valid. Each should contain only
+	// synchronizable content.
+	for _, t := range p.AlphaTransitions {
+		if err := t.EnsureValid(true); err != nil {
+			return fmt.Errorf("invalid alpha transition: %w", err)
+		}
+	}
+	for _, t := range p.BetaTransitions {
+		if err := t.EnsureValid(true); err != nil {
+			return fmt.Errorf("invalid beta transition: %w", err)
+		}
+	}
+
+	// Ensure that all problems are valid.
+	for _, problem := range p.AlphaProblems {
+		if err := problem.EnsureValid(); err != nil {
+			return fmt.Errorf("invalid alpha problem: %w", err)
+		}
+	}
+	for _, problem := range p.BetaProblems {
+		if err := problem.EnsureValid(); err != nil {
+			return fmt.Errorf("invalid beta problem: %w", err)
+		}
+	}
+
+	// Ensure that all conflicts are valid.
+	for _, c := range p.Conflicts {
+		if err := c.EnsureValid(); err != nil {
+			return fmt.Errorf("invalid conflict: %w", err)
+		}
+	}
+
+	// Success.
+	return nil
+}
+
+// PreviewLocal computes a preview of an initial synchronization cycle between
+// two local roots. Both roots are scanned transiently, reconciled without an
+// ancestor, and the resulting transitions are validated using a dry run of
+// core.Transition, so neither root is modified. The specified configuration
+// should be the merged session configuration, though endpoint-specific behavior
+// is not considered.
+func PreviewLocal(ctx context.Context, alpha, beta string, version Version, configuration *Configuration) (*Preview, error) {
+	// Validate the version and configuration.
+	if !version.Supported() {
+		return nil, errors.New("unknown or unsupported session version")
+	} else if configuration == nil {
+		return nil, errors.New("no configuration specified")
+	} else if err := configuration.EnsureValid(false); err != nil {
+		return nil, fmt.Errorf("invalid configuration: %w", err)
+	}
+
+	// Scan both roots.
+	alphaSnapshot, alphaCache, err := estimateScan(ctx, alpha, version, configuration)
+	if err != nil {
+		return nil, fmt.Errorf("unable to scan alpha: %w", err)
+	}
+	betaSnapshot, betaCache, err := estimateScan(ctx, beta, version, configuration)
+	if err != nil {
+		return nil, fmt.Errorf("unable to scan beta: %w", err)
+	}
+
+	// Compute the effective synchronization and type change modes.
+	synchronizationMode := configuration.SynchronizationMode
+	if synchronizationMode.IsDefault() {
+		synchronizationMode = version.DefaultSynchronizationMode()
+	}
+	typeChangeMode := configuration.TypeChangeMode
+	if typeChangeMode.IsDefault() {
+		typeChangeMode = version.DefaultTypeChangeMode()
+	}
+
+	// Compute the effective symbolic link modes.
+	symbolicLinkMode := configuration.SymbolicLinkMode
+	if symbolicLinkMode.IsDefault() {
+		symbolicLinkMode = version.DefaultSymbolicLinkMode()
+	}
+	symbolicLinkReplacementMode := configuration.SymbolicLinkReplacementMode
+	if symbolicLinkReplacementMode.IsDefault() {
+		symbolicLinkReplacementMode = version.DefaultSymbolicLinkReplacementMode()
+	}
+	symbolicLinkCycleMode := configuration.SymbolicLinkCycleMode
+	if symbolicLinkCycleMode.IsDefault() {
+		symbolicLinkCycleMode = version.DefaultSymbolicLinkCycleMode()
+	}
+
+	// If one root ignores case, then mark content on the other root whose
+	// names differ only in case, since that content would collide.
+	alphaContent, betaContent := alphaSnapshot.Content, betaSnapshot.Content
+	if alphaSnapshot.IgnoresCase {
+		betaContent = core.MarkCaseCollisions(betaContent)
+	}
+	if betaSnapshot.IgnoresCase {
+		alphaContent = core.MarkCaseCollisions(alphaContent)
+	}
+
+	// Reconcile the roots.
+	_, alphaTransitions, betaTransitions, conflicts := core.Reconcile(
+		nil,
+		alphaContent,
+		betaContent,
+		synchronizationMode,
+		typeChangeMode,
+		0, 0,
+		nil,
+	)
+
+	// Perform a dry run of the transitions on each root.
+	_, alphaProblems, _ := core.Transition(
+		ctx,
+		alpha,
+		alphaTransitions,
+		alphaCache,
+		symbolicLinkMode,
+		symbolicLinkReplacementMode,
+		symbolicLinkCycleMode,
+		0, 0, nil,
+		alphaSnapshot.DecomposesUnicode,
+		core.TransitionMode_TransitionModeInPlace,
+		nil,
+		true,
+	)
+	_, betaProblems, _ := core.Transition(
+		ctx,
+		beta,
+		betaTransitions,
+		betaCache,
+		symbolicLinkMode,
+		symbolicLinkReplacementMode,
+		symbolicLinkCycleMode,
+		0, 0, nil,
+		betaSnapshot.DecomposesUnicode,
+		core.TransitionMode_TransitionModeInPlace,
+		nil,
+		true,
+	)
+
+	// Success.
+	return &Preview{
+		AlphaTransitions: alphaTransitions,
+		AlphaProblems:    alphaProblems,
+		BetaTransitions:  betaTransitions,
+		BetaProblems:     betaProblems,
+		Conflicts:        conflicts,
+	}, nil
+}
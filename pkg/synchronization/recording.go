@@ -0,0 +1,89 @@
+package synchronization
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync/atomic"
+	"time"
+
+	"github.com/mutagen-io/mutagen/pkg/encoding"
+	"github.com/mutagen-io/mutagen/pkg/synchronization/core"
+)
+
+const (
+	// reconciliationRecordingExtension is the file extension used for
+	// serialized reconciliation recordings.
+	reconciliationRecordingExtension = ".reconciliation"
+)
+
+// reconciliationRecordingSequence is a process-wide counter used to ensure that
+// recording names are unique.
+var reconciliationRecordingSequence atomic.Uint64
+
+// saveReconciliationRecording saves a reconciliation recording to the specified
+// recording directory and then removes the oldest recordings in that directory
+// until at most maximum recordings remain. Recordings are named using the
+// recording time and a sequence number, so they sort chronologically. Each
+// recording is a serialized core.ReconciliationRecording Protocol Buffers
+// message.
+func saveReconciliationRecording(directory string, recording *core.ReconciliationRecording, maximum uint32) (string, error) {
+	// Ensure that the recording directory exists.
+	if err := os.MkdirAll(directory, 0700); err != nil {
+		return "", fmt.Errorf("unable to create recording directory: %w", err)
+	}
+
+	// Compute the recording path.
+	name := fmt.Sprintf("%s_%020d%s",
+		time.Now().UTC().Format("20060102T150405.000000000Z"),
+		reconciliationRecordingSequence.Add(1),
+		reconciliationRecordingExtension,
+	)
+	path := filepath.Join(directory, name)
+
+	// Save the recording.
+	if err := encoding.MarshalAndSaveProtobuf(path, recording); err != nil {
+		return "", err
+	}
+
+	// Rotate the recording directory.
+	if err := rotateReconciliationRecordings(directory, maximum); err != nil {
+		return path, fmt.Errorf("unable to rotate recordings: %w", err)
+	}
+
+	// Success.
+	return path, nil
+}
+
+// rotateReconciliationRecordings removes the oldest recordings in the specified
+// recording directory until at most maximum recordings remain.
+func rotateReconciliationRecordings(directory string, maximum uint32) error {
+	// Read the directory contents.
+	contents, err := os.ReadDir(directory)
+	if err != nil {
+		return fmt.Errorf("unable to read recording directory: %w", err)
+	}
+
+	// Extract recording names. Since names begin with a fixed-width timestamp,
+	// lexicographical order is chronological order.
+	var names []string
+	for _, c := range contents {
+		if name := c.Name(); strings.HasSuffix(name, reconciliationRecordingExtension) {
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+
+	// Remove excess recordings, starting with the oldest.
+	for len(names) > int(maximum) {
+		if err := os.Remove(filepath.Join(directory, names[0])); err != nil {
+			return fmt.Errorf("unable to remove recording: %w", err)
+		}
+		names = names[1:]
+	}
+
+	// Success.
+	return nil
+}
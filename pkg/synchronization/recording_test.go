@@ -0,0 +1,40 @@
+package synchronization
+
+import (
+	"os"
+	"testing"
+
+	"github.com/mutagen-io/mutagen/pkg/synchronization/core"
+)
+
+// TestSaveReconciliationRecordingRotation tests that saving reconciliation
+// recordings removes the oldest recordings once the maximum is exceeded.
+func TestSaveReconciliationRecordingRotation(t *testing.T) {
+	// Create a temporary recording directory.
+	directory := t.TempDir()
+
+	// Save more recordings than the maximum, tracking their paths.
+	var paths []string
+	for i := 0; i < 5; i++ {
+		path, err := saveReconciliationRecording(directory, &core.ReconciliationRecording{}, 3)
+		if err != nil {
+			t.Fatal("unable to save recording:", err)
+		}
+		paths = append(paths, path)
+	}
+
+	// Verify that only the newest recordings remain.
+	for i, path := range paths {
+		_, err := os.Stat(path)
+		if i < 2 && !os.IsNotExist(err) {
+			t.Error("old recording not removed:", path)
+		} else if i >= 2 && err != nil {
+			t.Error("new recording missing:", path)
+		}
+	}
+	if contents, err := os.ReadDir(directory); err != nil {
+		t.Fatal("unable to read recording directory:", err)
+	} else if len(contents) != 3 {
+		t.Error("unexpected recording count:", len(contents), "!=", 3)
+	}
+}
@@ -0,0 +1,72 @@
+package synchronization
+
+import (
+	"fmt"
+)
+
+// IsDefault indicates whether or not the root existence mode is
+// RootExistenceMode_RootExistenceModeDefault.
+func (m RootExistenceMode) IsDefault() bool {
+	return m == RootExistenceMode_RootExistenceModeDefault
+}
+
+// MarshalText implements encoding.TextMarshaler.MarshalText.
+func (m RootExistenceMode) MarshalText() ([]byte, error) {
+	var result string
+	switch m {
+	case RootExistenceMode_RootExistenceModeDefault:
+	case RootExistenceMode_RootExistenceModeCreate:
+		result = "create"
+	case RootExistenceMode_RootExistenceModeRequire:
+		result = "require"
+	default:
+		result = "unknown"
+	}
+	return []byte(result), nil
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler.UnmarshalText.
+func (m *RootExistenceMode) UnmarshalText(textBytes []byte) error {
+	// Convert the bytes to a string.
+	text := string(textBytes)
+
+	// Convert to a root existence mode.
+	switch text {
+	case "create":
+		*m = RootExistenceMode_RootExistenceModeCreate
+	case "require":
+		*m = RootExistenceMode_RootExistenceModeRequire
+	default:
+		return fmt.Errorf("unknown root existence mode specification: %s", text)
+	}
+
+	// Success.
+	return nil
+}
+
+// Supported indicates whether or not a particular root existence mode is a
+// valid, non-default value.
+func (m RootExistenceMode) Supported() bool {
+	switch m {
+	case RootExistenceMode_RootExistenceModeCreate:
+		return true
+	case RootExistenceMode_RootExistenceModeRequire:
+		return true
+	default:
+		return false
+	}
+}
+
+// Description returns a human-readable description of a root existence mode.
+func (m RootExistenceMode) Description() string {
+	switch m {
+	case RootExistenceMode_RootExistenceModeDefault:
+		return "Default"
+	case RootExistenceMode_RootExistenceModeCreate:
+		return "Create"
+	case RootExistenceMode_RootExistenceModeRequire:
+		return "Require"
+	default:
+		return "Unknown"
+	}
+}
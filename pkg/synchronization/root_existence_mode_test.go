@@ -0,0 +1,91 @@
+package synchronization
+
+import (
+	"testing"
+)
+
+// TestRootExistenceModeUnmarshal tests that unmarshaling from a string
+// specification succeeeds for RootExistenceMode.
+func TestRootExistenceModeUnmarshal(t *testing.T) {
+	// Set up test cases.
+	testCases := []struct {
+		text          string
+		expectedMode  RootExistenceMode
+		expectFailure bool
+	}{
+		{"", RootExistenceMode_RootExistenceModeDefault, true},
+		{"asdf", RootExistenceMode_RootExistenceModeDefault, true},
+		{"create", RootExistenceMode_RootExistenceModeCreate, false},
+		{"require", RootExistenceMode_RootExistenceModeRequire, false},
+	}
+
+	// Process test cases.
+	for _, testCase := range testCases {
+		var mode RootExistenceMode
+		if err := mode.UnmarshalText([]byte(testCase.text)); err != nil {
+			if !testCase.expectFailure {
+				t.Errorf("unable to unmarshal text (%s): %s", testCase.text, err)
+			}
+		} else if testCase.expectFailure {
+			t.Error("unmarshaling succeeded unexpectedly for text:", testCase.text)
+		} else if mode != testCase.expectedMode {
+			t.Errorf(
+				"unmarshaled mode (%s) does not match expected (%s)",
+				mode,
+				testCase.expectedMode,
+			)
+		}
+	}
+}
+
+// TestRootExistenceModeSupported tests that RootExistenceMode support detection
+// works as expected.
+func TestRootExistenceModeSupported(t *testing.T) {
+	// Set up test cases.
+	testCases := []struct {
+		mode            RootExistenceMode
+		expectSupported bool
+	}{
+		{RootExistenceMode_RootExistenceModeDefault, false},
+		{RootExistenceMode_RootExistenceModeCreate, true},
+		{RootExistenceMode_RootExistenceModeRequire, true},
+		{(RootExistenceMode_RootExistenceModeRequire + 1), false},
+	}
+
+	// Process test cases.
+	for _, testCase := range testCases {
+		if supported := testCase.mode.Supported(); supported != testCase.expectSupported {
+			t.Errorf(
+				"mode support status (%t) does not match expected (%t)",
+				supported,
+				testCase.expectSupported,
+			)
+		}
+	}
+}
+
+// TestRootExistenceModeDescription tests that RootExistenceMode description
+// generation works as expected.
+func TestRootExistenceModeDescription(t *testing.T) {
+	// Set up test cases.
+	testCases := []struct {
+		mode                RootExistenceMode
+		expectedDescription string
+	}{
+		{RootExistenceMode_RootExistenceModeDefault, "Default"},
+		{RootExistenceMode_RootExistenceModeCreate, "Create"},
+		{RootExistenceMode_RootExistenceModeRequire, "Require"},
+		{(RootExistenceMode_RootExistenceModeRequire + 1), "Unknown"},
+	}
+
+	// Process test cases.
+	for _, testCase := range testCases {
+		if description := testCase.mode.Description(); description != testCase.expectedDescription {
+			t.Errorf(
+				"mode description (%s) does not match expected (%s)",
+				description,
+				testCase.expectedDescription,
+			)
+		}
+	}
+}
@@ -0,0 +1,73 @@
+package synchronization
+
+import (
+	"fmt"
+)
+
+// IsDefault indicates whether or not the root overlap mode is
+// RootOverlapMode_RootOverlapModeDefault.
+func (m RootOverlapMode) IsDefault() bool {
+	return m == RootOverlapMode_RootOverlapModeDefault
+}
+
+// MarshalText implements encoding.TextMarshaler.MarshalText.
+func (m RootOverlapMode) MarshalText() ([]byte, error) {
+	var result string
+	switch m {
+	case RootOverlapMode_RootOverlapModeDefault:
+	case RootOverlapMode_RootOverlapModeWarn:
+		result = "warn"
+	case RootOverlapMode_RootOverlapModeRefuse:
+		result = "refuse"
+	default:
+		result = "unknown"
+	}
+	return []byte(result), nil
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler.UnmarshalText.
+func (m *RootOverlapMode) UnmarshalText(textBytes []byte) error {
+	// Convert the bytes to a string.
+	text := string(textBytes)
+
+	// Convert to a root overlap mode.
+	switch text {
+	case "warn":
+		*m = RootOverlapMode_RootOverlapModeWarn
+	case "refuse":
+		*m = RootOverlapMode_RootOverlapModeRefuse
+	default:
+		return fmt.Errorf("unknown root overlap mode specification: %s", text)
+	}
+
+	// Success.
+	return nil
+}
+
+// Supported indicates whether or not a particular root overlap mode is a
+// valid, non-default value.
+func (m RootOverlapMode) Supported() bool {
+	switch m {
+	case RootOverlapMode_RootOverlapModeWarn:
+		return true
+	case RootOverlapMode_RootOverlapModeRefuse:
+		return true
+	default:
+		return false
+	}
+}
+
+// Description returns a human-readable description of a root overlap
+// mode.
+func (m RootOverlapMode) Description() string {
+	switch m {
+	case RootOverlapMode_RootOverlapModeDefault:
+		return "Default"
+	case RootOverlapMode_RootOverlapModeWarn:
+		return "Warn"
+	case RootOverlapMode_RootOverlapModeRefuse:
+		return "Refuse"
+	default:
+		return "Unknown"
+	}
+}
@@ -0,0 +1,91 @@
+package synchronization
+
+import (
+	"testing"
+)
+
+// TestRootOverlapModeUnmarshal tests that unmarshaling from a string
+// specification succeeeds for RootOverlapMode.
+func TestRootOverlapModeUnmarshal(t *testing.T) {
+	// Set up test cases.
+	testCases := []struct {
+		text          string
+		expectedMode  RootOverlapMode
+		expectFailure bool
+	}{
+		{"", RootOverlapMode_RootOverlapModeDefault, true},
+		{"asdf", RootOverlapMode_RootOverlapModeDefault, true},
+		{"warn", RootOverlapMode_RootOverlapModeWarn, false},
+		{"refuse", RootOverlapMode_RootOverlapModeRefuse, false},
+	}
+
+	// Process test cases.
+	for _, testCase := range testCases {
+		var mode RootOverlapMode
+		if err := mode.UnmarshalText([]byte(testCase.text)); err != nil {
+			if !testCase.expectFailure {
+				t.Errorf("unable to unmarshal text (%s): %s", testCase.text, err)
+			}
+		} else if testCase.expectFailure {
+			t.Error("unmarshaling succeeded unexpectedly for text:", testCase.text)
+		} else if mode != testCase.expectedMode {
+			t.Errorf(
+				"unmarshaled mode (%s) does not match expected (%s)",
+				mode,
+				testCase.expectedMode,
+			)
+		}
+	}
+}
+
+// TestRootOverlapModeSupported tests that RootOverlapMode support
+// detection works as expected.
+func TestRootOverlapModeSupported(t *testing.T) {
+	// Set up test cases.
+	testCases := []struct {
+		mode            RootOverlapMode
+		expectSupported bool
+	}{
+		{RootOverlapMode_RootOverlapModeDefault, false},
+		{RootOverlapMode_RootOverlapModeWarn, true},
+		{RootOverlapMode_RootOverlapModeRefuse, true},
+		{(RootOverlapMode_RootOverlapModeRefuse + 1), false},
+	}
+
+	// Process test cases.
+	for _, testCase := range testCases {
+		if supported := testCase.mode.Supported(); supported != testCase.expectSupported {
+			t.Errorf(
+				"mode support status (%t) does not match expected (%t)",
+				supported,
+				testCase.expectSupported,
+			)
+		}
+	}
+}
+
+// TestRootOverlapModeDescription tests that RootOverlapMode
+// description generation works as expected.
+func TestRootOverlapModeDescription(t *testing.T) {
+	// Set up test cases.
+	testCases := []struct {
+		mode                RootOverlapMode
+		expectedDescription string
+	}{
+		{RootOverlapMode_RootOverlapModeDefault, "Default"},
+		{RootOverlapMode_RootOverlapModeWarn, "Warn"},
+		{RootOverlapMode_RootOverlapModeRefuse, "Refuse"},
+		{(RootOverlapMode_RootOverlapModeRefuse + 1), "Unknown"},
+	}
+
+	// Process test cases.
+	for _, testCase := range testCases {
+		if description := testCase.mode.Description(); description != testCase.expectedDescription {
+			t.Errorf(
+				"mode description (%s) does not match expected (%s)",
+				description,
+				testCase.expectedDescription,
+			)
+		}
+	}
+}
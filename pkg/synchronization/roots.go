@@ -0,0 +1,140 @@
+package synchronization
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/mutagen-io/mutagen/pkg/filesystem"
+	"github.com/mutagen-io/mutagen/pkg/url"
+)
+
+const (
+	// allowedRootsEnvironmentVariable is the environment variable used to
+	// specify the list of root path prefixes under which the daemon will allow
+	// local synchronization roots to be created. Prefixes are separated by the
+	// platform's path list separator (e.g. ':' on POSIX systems).
+	allowedRootsEnvironmentVariable = "MUTAGEN_DAEMON_ALLOWED_ROOTS"
+)
+
+// resolvePath converts a path to a canonical absolute form for the purposes of
+// root prefix matching. It expands home directory tildes, converts the path to
+// an absolute path, cleans it (resolving any ".." components lexically), and
+// then resolves symbolic links in the longest existing ancestor of the path.
+// The last step prevents roots from escaping a prefix via a symbolic link,
+// even if the root itself doesn't exist yet.
+func resolvePath(path string) (string, error) {
+	// Perform lexical normalization.
+	path, err := filesystem.Normalize(path)
+	if err != nil {
+		return "", err
+	}
+
+	// Find the longest existing ancestor and resolve symbolic links within it,
+	// re-appending any non-existent trailing components.
+	var trailing []string
+	for current := path; ; {
+		if resolved, err := filepath.EvalSymlinks(current); err == nil {
+			for i := len(trailing) - 1; i >= 0; i-- {
+				resolved = filepath.Join(resolved, trailing[i])
+			}
+			return resolved, nil
+		} else if !errors.Is(err, fs.ErrNotExist) {
+			return "", fmt.Errorf("unable to resolve symbolic links: %w", err)
+		}
+		parent := filepath.Dir(current)
+		if parent == current {
+			return path, nil
+		}
+		trailing = append(trailing, filepath.Base(current))
+		current = parent
+	}
+}
+
+// loadAllowedRoots loads and resolves the allowed root path prefixes specified
+// in the environment. If the environment variable is unset, then it returns
+// nil, which indicates that all roots are allowed. If the environment variable
+// is set but doesn't specify any prefixes, then an error is returned, since
+// treating an empty allowlist as unrestricted would fail open.
+func loadAllowedRoots() ([]string, error) {
+	// Grab the specification from the environment.
+	specification, ok := os.LookupEnv(allowedRootsEnvironmentVariable)
+	if !ok {
+		return nil, nil
+	}
+
+	// Parse and resolve prefixes, requiring that they be absolute (modulo
+	// tilde expansion) to avoid any dependence on the daemon's working
+	// directory.
+	var prefixes []string
+	for _, prefix := range filepath.SplitList(specification) {
+		if prefix == "" {
+			continue
+		} else if !filepath.IsAbs(prefix) && !strings.HasPrefix(prefix, "~") {
+			return nil, fmt.Errorf("allowed root prefix is not absolute: %s", prefix)
+		}
+		resolved, err := resolvePath(prefix)
+		if err != nil {
+			return nil, fmt.Errorf("unable to resolve allowed root prefix (%s): %w", prefix, err)
+		}
+		prefixes = append(prefixes, resolved)
+	}
+
+	// Ensure that at least one prefix was specified.
+	if len(prefixes) == 0 {
+		return nil, errors.New("no allowed root prefixes specified")
+	}
+
+	// Success.
+	return prefixes, nil
+}
+
+// pathHasPrefix determines whether or not a resolved path is equal to or
+// contained within a resolved prefix. Matching is performed on whole path
+// components, so "/workspace" is a prefix of "/workspace/project" but not of
+// "/workspace2".
+func pathHasPrefix(path, prefix string) bool {
+	if path == prefix {
+		return true
+	}
+	if !strings.HasSuffix(prefix, string(filepath.Separator)) {
+		prefix += string(filepath.Separator)
+	}
+	return strings.HasPrefix(path, prefix)
+}
+
+// rootsOverlap determines whether or not two resolved root paths overlap, i.e.
+// whether or not they're equal or one is contained within the other.
+func rootsOverlap(first, second string) bool {
+	return pathHasPrefix(first, second) || pathHasPrefix(second, first)
+}
+
+// ensureRootAllowed verifies that an endpoint URL is permitted by a list of
+// resolved root prefixes. Only local URLs are subject to restriction, since the
+// roots of remote URLs don't reside on the daemon's filesystem. If the prefix
+// list is nil, then all roots are allowed.
+func ensureRootAllowed(endpointURL *url.URL, prefixes []string) error {
+	// Check whether or not restrictions apply.
+	if prefixes == nil || endpointURL.Protocol != url.Protocol_Local {
+		return nil
+	}
+
+	// Resolve the root path.
+	root, err := resolvePath(endpointURL.Path)
+	if err != nil {
+		return fmt.Errorf("unable to resolve root path: %w", err)
+	}
+
+	// Check for a matching prefix.
+	for _, prefix := range prefixes {
+		if pathHasPrefix(root, prefix) {
+			return nil
+		}
+	}
+
+	// No matching prefix was found.
+	return fmt.Errorf("root (%s) is not within an allowed root prefix", root)
+}
@@ -0,0 +1,136 @@
+package rsync
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+
+	"github.com/mutagen-io/mutagen/pkg/stream"
+	"github.com/mutagen-io/mutagen/pkg/synchronization/compression"
+)
+
+const (
+	// maximumDecompressedDataSize is the maximum size of decompressed
+	// operation data that a receiver will accept. It is well above the data
+	// operation size used by transmitters and exists to prevent a malicious or
+	// broken transmitter from exhausting memory.
+	maximumDecompressedDataSize = 1 << 20
+)
+
+// compressionResetter is the interface implemented by compressors that can be
+// reset to write to a new stream, allowing their resources to be re-used.
+type compressionResetter interface {
+	// Reset discards the compressor's state and resets it to write to the
+	// specified stream.
+	Reset(io.Writer)
+}
+
+// decompressionResetter is the interface implemented by decompressors that can
+// be reset to read from a new stream, allowing their resources to be re-used.
+type decompressionResetter interface {
+	// Reset discards the decompressor's state and resets it to read from the
+	// specified stream. The dictionary is unused and should be nil.
+	Reset(io.Reader, []byte) error
+}
+
+// supportsDataCompression returns whether or not an algorithm can be used for
+// compressing operation data.
+func supportsDataCompression(algorithm compression.Algorithm) bool {
+	return !algorithm.IsDefault() &&
+		algorithm.SupportStatus() == compression.AlgorithmSupportStatusSupported
+}
+
+// dataCompressor compresses operation data, re-using compression resources
+// between operations where possible. Each operation's data is compressed
+// independently.
+type dataCompressor struct {
+	// algorithm is the compression algorithm.
+	algorithm compression.Algorithm
+	// buffer is the buffer holding compressed data.
+	buffer bytes.Buffer
+	// compressor is the compressor, if one has been created.
+	compressor stream.WriteFlushCloser
+}
+
+// newDataCompressor creates a new operation data compressor using the
+// specified algorithm, which must be supported.
+func newDataCompressor(algorithm compression.Algorithm) *dataCompressor {
+	return &dataCompressor{algorithm: algorithm}
+}
+
+// compress compresses the specified data. The resulting slice is only valid
+// until the next call to compress.
+func (c *dataCompressor) compress(data []byte) ([]byte, error) {
+	// Reset the buffer.
+	c.buffer.Reset()
+
+	// Reset or create the compressor.
+	if resetter, ok := c.compressor.(compressionResetter); ok {
+		resetter.Reset(&c.buffer)
+	} else {
+		c.compressor = c.algorithm.Compress(&c.buffer)
+	}
+
+	// Compress the data.
+	if _, err := c.compressor.Write(data); err != nil {
+		return nil, fmt.Errorf("unable to compress data: %w", err)
+	} else if err = c.compressor.Close(); err != nil {
+		return nil, fmt.Errorf("unable to finalize compressed data: %w", err)
+	}
+
+	// Success.
+	return c.buffer.Bytes(), nil
+}
+
+// dataDecompressor decompresses operation data, re-using decompression
+// resources between operations where possible.
+type dataDecompressor struct {
+	// algorithm is the compression algorithm.
+	algorithm compression.Algorithm
+	// source is the reader for compressed data.
+	source bytes.Reader
+	// buffer is the buffer holding decompressed data.
+	buffer bytes.Buffer
+	// decompressor is the decompressor, if one has been created.
+	decompressor io.ReadCloser
+}
+
+// decompress decompresses the specified data. The resulting slice is only
+// valid until the next call to decompress.
+func (d *dataDecompressor) decompress(data []byte) ([]byte, error) {
+	// Reset the source and buffer.
+	d.source.Reset(data)
+	d.buffer.Reset()
+
+	// Reset or create the decompressor.
+	if resetter, ok := d.decompressor.(decompressionResetter); ok {
+		if err := resetter.Reset(&d.source, nil); err != nil {
+			return nil, fmt.Errorf("unable to reset decompressor: %w", err)
+		}
+	} else {
+		if d.decompressor != nil {
+			d.decompressor.Close()
+		}
+		d.decompressor = d.algorithm.Decompress(&d.source)
+	}
+
+	// Decompress the data, enforcing the maximum decompressed size.
+	limited := io.LimitReader(d.decompressor, maximumDecompressedDataSize+1)
+	if _, err := d.buffer.ReadFrom(limited); err != nil {
+		return nil, fmt.Errorf("unable to decompress data: %w", err)
+	} else if d.buffer.Len() > maximumDecompressedDataSize {
+		return nil, errors.New("decompressed data too large")
+	}
+
+	// Success.
+	return d.buffer.Bytes(), nil
+}
+
+// close releases decompression resources.
+func (d *dataDecompressor) close() {
+	if d.decompressor != nil {
+		d.decompressor.Close()
+		d.decompressor = nil
+	}
+}
@@ -0,0 +1,92 @@
+package rsync
+
+import (
+	"errors"
+	"fmt"
+)
+
+// OperationDescription describes the placement of an operation's content in the
+// base and target. It is intended for debugging and analysis purposes.
+type OperationDescription struct {
+	// Data indicates whether or not the operation is a data operation.
+	Data bool
+	// Trailer indicates whether or not the operation is a trailer operation.
+	// Trailer operations have no content, so their length is zero.
+	Trailer bool
+	// TargetOffset is the offset in the target at which the operation's content
+	// begins.
+	TargetOffset uint64
+	// Length is the length of the operation's content in the target.
+	Length uint64
+	// Start is the index of the first block copied by a block operation. It is
+	// zero for data operations.
+	Start uint64
+	// Count is the number of blocks copied by a block operation. It is zero for
+	// data operations.
+	Count uint64
+	// BaseOffset is the offset in the base from which a block operation copies
+	// content. It is zero for data operations.
+	BaseOffset uint64
+}
+
+// String formats the operation description in a human-readable form.
+func (d *OperationDescription) String() string {
+	if d.Trailer {
+		return fmt.Sprintf("trailer target=[%d,%d)", d.TargetOffset, d.TargetOffset)
+	} else if d.Data {
+		return fmt.Sprintf("data   target=[%d,%d) length=%d",
+			d.TargetOffset, d.TargetOffset+d.Length, d.Length,
+		)
+	}
+	return fmt.Sprintf("blocks target=[%d,%d) length=%d base=[%d,%d) blocks=[%d,%d)",
+		d.TargetOffset, d.TargetOffset+d.Length, d.Length,
+		d.BaseOffset, d.BaseOffset+d.Length,
+		d.Start, d.Start+d.Count,
+	)
+}
+
+// DescribeOperations computes descriptions for an operation sequence generated
+// against the specified base signature, tracking the offsets and lengths of each
+// operation's content in the base and target. The signature and operations are
+// validated by this function.
+func DescribeOperations(signature *Signature, operations []*Operation) ([]*OperationDescription, error) {
+	// Validate the signature.
+	if err := signature.EnsureValid(); err != nil {
+		return nil, fmt.Errorf("invalid signature: %w", err)
+	}
+
+	// Compute descriptions.
+	blocks := uint64(len(signature.Hashes))
+	results := make([]*OperationDescription, len(operations))
+	var targetOffset uint64
+	for i, operation := range operations {
+		// Validate the operation.
+		if err := operation.EnsureValid(); err != nil {
+			return nil, fmt.Errorf("invalid operation at index %d: %w", i, err)
+		}
+
+		// Compute the description.
+		description := &OperationDescription{TargetOffset: targetOffset}
+		if operation.isTrailer() {
+			description.Trailer = true
+		} else if len(operation.Data) > 0 {
+			description.Data = true
+			description.Length = uint64(len(operation.Data))
+		} else {
+			if operation.Start >= blocks || operation.Count > blocks-operation.Start {
+				return nil, errors.New("block operation references blocks outside of signature")
+			}
+			description.Start = operation.Start
+			description.Count = operation.Count
+			description.BaseOffset = operation.Start * signature.BlockSize
+			description.Length = signature.blockRangeLength(operation.Start, operation.Count)
+		}
+		results[i] = description
+
+		// Update the target offset.
+		targetOffset += description.Length
+	}
+
+	// Success.
+	return results, nil
+}
@@ -4,11 +4,13 @@ import (
 	"bufio"
 	"bytes"
 	"crypto/sha1"
+	"encoding/binary"
 	"errors"
 	"fmt"
 	"hash"
 	"io"
 	"math"
+	"time"
 
 	"google.golang.org/protobuf/proto"
 )
@@ -72,6 +74,55 @@ func (s *Signature) EnsureValid() error {
 	return nil
 }
 
+// Fingerprint computes a digest that uniquely identifies the signature's block
+// size and block hashes. Signatures with matching fingerprints are equivalent
+// for the purposes of deltification and patching. The signature must be valid.
+func (s *Signature) Fingerprint() []byte {
+	// Create the hasher and a buffer for encoding integers.
+	hasher := sha1.New()
+	var buffer [8]byte
+
+	// Hash the block sizes and block count.
+	binary.BigEndian.PutUint64(buffer[:], s.BlockSize)
+	hasher.Write(buffer[:])
+	binary.BigEndian.PutUint64(buffer[:], s.LastBlockSize)
+	hasher.Write(buffer[:])
+	binary.BigEndian.PutUint64(buffer[:], uint64(len(s.Hashes)))
+	hasher.Write(buffer[:])
+
+	// Hash the block hashes. The strong hashes are length-prefixed to avoid
+	// ambiguity between adjacent hashes.
+	for _, h := range s.Hashes {
+		binary.BigEndian.PutUint32(buffer[:4], h.Weak)
+		hasher.Write(buffer[:4])
+		binary.BigEndian.PutUint64(buffer[:], uint64(len(h.Strong)))
+		hasher.Write(buffer[:])
+		hasher.Write(h.Strong)
+	}
+
+	// Done.
+	return hasher.Sum(nil)
+}
+
+// baseLength computes the length of the base from which the signature was
+// generated. The signature must be valid.
+func (s *Signature) baseLength() uint64 {
+	if len(s.Hashes) == 0 {
+		return 0
+	}
+	return uint64(len(s.Hashes)-1)*s.BlockSize + s.LastBlockSize
+}
+
+// blockRangeLength computes the length of the base content covered by the
+// specified range of blocks. The signature must be valid and the block range
+// must lie within the signature.
+func (s *Signature) blockRangeLength(start, count uint64) uint64 {
+	if start+count == uint64(len(s.Hashes)) {
+		return (count-1)*s.BlockSize + s.LastBlockSize
+	}
+	return count * s.BlockSize
+}
+
 // isEmpty return true if the signature represents an empty file.
 func (s *Signature) isEmpty() bool {
 	// In theory, we might also want to test that LastBlockSize == 0 and that
@@ -80,6 +131,13 @@ func (s *Signature) isEmpty() bool {
 	return s.BlockSize == 0
 }
 
+// MemoryUsage returns an estimate of the in-memory size (in bytes) of the
+// signature. The estimate is only approximate, but it's consistent with the
+// estimate returned by EstimateSignatureMemoryUsage.
+func (s *Signature) MemoryUsage() uint64 {
+	return signatureMemoryUsageBase + uint64(len(s.Hashes))*blockHashMemoryUsage
+}
+
 // EnsureValid verifies that operation invariants are respected.
 func (o *Operation) EnsureValid() error {
 	// A nil operation is not valid.
@@ -88,7 +146,15 @@ func (o *Operation) EnsureValid() error {
 	}
 
 	// Ensure that the operation parameters are valid.
-	if len(o.Data) > 0 {
+	if len(o.Digest) > 0 {
+		if len(o.Data) > 0 {
+			return errors.New("trailer operation with data")
+		} else if o.Start != 0 {
+			return errors.New("trailer operation with non-0 block start index")
+		} else if o.Count != 0 {
+			return errors.New("trailer operation with non-0 block count")
+		}
+	} else if len(o.Data) > 0 {
 		if o.Start != 0 {
 			return errors.New("data operation with non-0 block start index")
 		} else if o.Count != 0 {
@@ -112,12 +178,20 @@ func (o *Operation) resetToZeroMaintainingCapacity() {
 	// Reset start and count.
 	o.Start = 0
 	o.Count = 0
+
+	// Reset the digest, but maintain its capacity.
+	o.Digest = o.Digest[:0]
 }
 
 // isZeroValue indicates whether or not an Operation has its zero-value. It's
 // worth noting that the zero-value state is not a valid state for an Operation.
 func (o *Operation) isZeroValue() bool {
-	return len(o.Data) == 0 && o.Start == 0 && o.Count == 0
+	return len(o.Data) == 0 && o.Start == 0 && o.Count == 0 && len(o.Digest) == 0
+}
+
+// isTrailer indicates whether or not an Operation is a trailer operation.
+func (o *Operation) isTrailer() bool {
+	return len(o.Digest) > 0
 }
 
 const (
@@ -134,6 +208,15 @@ const (
 	// DefaultBlockSize is the default block size that will be used if a zero
 	// value is passed into Engine.Signature for the blockSize parameter.
 	DefaultBlockSize = 1 << 13
+	// MinimumBlockSize is the minimum block size that can be explicitly
+	// configured for use with Engine.Signature. Smaller blocks would yield
+	// signatures dominated by block hash overhead.
+	MinimumBlockSize = 1 << 9
+	// MaximumBlockSize is the maximum block size that can be explicitly
+	// configured for use with Engine.Signature. It bounds the size of the
+	// in-memory block buffers used by engines and is well below the (2^32)-1
+	// limit imposed by the weak hash algorithm.
+	MaximumBlockSize = 1 << 24
 	// DefaultMaximumDataOperationSize is the default maximum data size
 	// permitted per operation. The optimal value for this isn't at all
 	// correlated with block size - it's just what's reasonable to hold
@@ -165,11 +248,55 @@ func OptimalBlockSizeForBaseLength(baseLength uint64) uint64 {
 	return result
 }
 
+const (
+	// signatureMemoryUsageBase is the approximate in-memory size (in bytes) of
+	// a Signature without any block hashes.
+	signatureMemoryUsageBase = 64
+	// blockHashMemoryUsage is the approximate in-memory size (in bytes) of a
+	// single BlockHash within a Signature, including the reference to it, its
+	// strong hash, and allocation overhead.
+	blockHashMemoryUsage = 96
+)
+
+// EstimateSignatureMemoryUsage estimates the in-memory size (in bytes) of the
+// signature that Engine.Signature would compute for a base of the specified
+// length using the specified block size. If the block size is 0, then the
+// optimal block size is assumed. This allows signature memory usage to be
+// bounded without computing the signature.
+func EstimateSignatureMemoryUsage(baseLength, blockSize uint64) uint64 {
+	// Empty bases have signatures without any block hashes.
+	if baseLength == 0 {
+		return signatureMemoryUsageBase
+	}
+
+	// Compute the number of blocks.
+	if blockSize == 0 {
+		blockSize = OptimalBlockSizeForBaseLength(baseLength)
+	}
+	blocks := baseLength / blockSize
+	if baseLength%blockSize != 0 {
+		blocks++
+	}
+
+	// Compute the estimate.
+	return signatureMemoryUsageBase + blocks*blockHashMemoryUsage
+}
+
 // OptimalBlockSizeForBase is a convenience function that will determine the
 // optimal block size for a base that implements io.Seeker. It calls down to
 // OptimalBlockSizeForBaseLength. After determining the base's length, it will
 // attempt to reset the base to its original position.
 func OptimalBlockSizeForBase(base io.Seeker) (uint64, error) {
+	if length, err := seekerLength(base); err != nil {
+		return 0, err
+	} else {
+		return OptimalBlockSizeForBaseLength(length), nil
+	}
+}
+
+// seekerLength computes the length of a seekable stream without modifying its
+// current offset.
+func seekerLength(base io.Seeker) (uint64, error) {
 	if currentOffset, err := base.Seek(0, io.SeekCurrent); err != nil {
 		return 0, fmt.Errorf("unable to determine current base offset: %w", err)
 	} else if currentOffset < 0 {
@@ -181,7 +308,7 @@ func OptimalBlockSizeForBase(base io.Seeker) (uint64, error) {
 	} else if _, err = base.Seek(currentOffset, io.SeekStart); err != nil {
 		return 0, fmt.Errorf("unable to reset base: %w", err)
 	} else {
-		return OptimalBlockSizeForBaseLength(uint64(length)), nil
+		return uint64(length), nil
 	}
 }
 
@@ -208,6 +335,17 @@ type Engine struct {
 	// operation is a re-usable operation object used for transmissions to avoid
 	// allocations.
 	operation *Operation
+	// deltificationTimeLimit is the maximum amount of time that Deltify will
+	// spend searching for block matches in a single target. A zero value
+	// indicates no limit.
+	deltificationTimeLimit time.Duration
+	// deltificationFallbacks is the number of Deltify operations that have
+	// exceeded the deltification time limit and fallen back to transmitting
+	// literal data.
+	deltificationFallbacks uint64
+	// trailerHasher is the hash function used to compute trailer digests. It
+	// is lazily created when first required.
+	trailerHasher hash.Hash
 }
 
 // NewEngine creates a new rsync engine.
@@ -228,6 +366,24 @@ func NewEngine() *Engine {
 	}
 }
 
+// SetDeltificationTimeLimit sets the maximum amount of time that Deltify will
+// spend searching for block matches in a single target stream. Once this limit
+// is exceeded, the remainder of the target is transmitted as literal data. This
+// bounds the CPU time spent on pathological content (e.g. highly repetitive
+// data that generates large numbers of weak hash collisions) at the cost of
+// less efficient deltas. The resulting operation stream remains correct. A zero
+// value (the default) indicates no limit.
+func (e *Engine) SetDeltificationTimeLimit(limit time.Duration) {
+	e.deltificationTimeLimit = limit
+}
+
+// DeltificationFallbacks returns the number of Deltify operations performed by
+// the engine that exceeded the deltification time limit and fell back to
+// transmitting literal data.
+func (e *Engine) DeltificationFallbacks() uint64 {
+	return e.deltificationFallbacks
+}
+
 // bufferWithSize lazily allocates the engine's internal buffer, ensuring that
 // it is the required size. The capacity of the internal buffer is retained
 // between calls to avoid allocations if possible.
@@ -248,6 +404,14 @@ func (e *Engine) bufferWithSize(size uint64) []byte {
 	return e.buffer
 }
 
+const (
+	// deltificationTimeLimitCheckInterval is the number of block match search
+	// iterations that Deltify will perform between checks of the deltification
+	// time limit. Checking on every iteration would add a non-trivial cost to
+	// the common (non-pathological) search path.
+	deltificationTimeLimitCheckInterval = 1024
+)
+
 const (
 	// m is the weak hash modulus. I think they now recommend that it be the
 	// largest prime less than 2^16, but this value is fine as well.
@@ -395,6 +559,44 @@ func (e *Engine) BytesSignature(base []byte, blockSize uint64) *Signature {
 	return result
 }
 
+// SignatureCached computes the signature for a base stream, re-using a
+// previously computed signature if the base appears to be unchanged since that
+// signature was computed. The base is considered unchanged if its current
+// modification time matches the modification time recorded when the previous
+// signature was computed and if its length (which requires that base implement
+// io.Seeker) matches the length covered by the previous signature. The previous
+// signature must also have been computed with the requested block size (or, if
+// the block size is 0, the block size that would be chosen automatically). Zero
+// modification times are treated as unknown and never match. The previous
+// signature may be nil, in which case the signature is always computed. This
+// method returns the signature and a flag indicating whether or not the
+// previous signature was re-used. As with the scan cache, modifications that
+// preserve both the length and modification time of the base can't be detected.
+func (e *Engine) SignatureCached(
+	base io.Reader, blockSize uint64,
+	modificationTime time.Time,
+	previous *Signature, previousModificationTime time.Time,
+) (*Signature, bool, error) {
+	// Determine whether or not the previous signature can be re-used.
+	if previous != nil && !modificationTime.IsZero() && modificationTime.Equal(previousModificationTime) {
+		if baseSeeker, ok := base.(io.Seeker); ok {
+			if length, err := seekerLength(baseSeeker); err == nil && length == previous.baseLength() {
+				expectedBlockSize := blockSize
+				if expectedBlockSize == 0 {
+					expectedBlockSize = OptimalBlockSizeForBaseLength(length)
+				}
+				if previous.isEmpty() || previous.BlockSize == expectedBlockSize {
+					return previous, true, nil
+				}
+			}
+		}
+	}
+
+	// Otherwise compute the signature.
+	signature, err := e.Signature(base, blockSize)
+	return signature, false, err
+}
+
 // dualModeReader unifies the io.Reader and io.ByteReader interfaces. It is used
 // in deltify operations to ensure that bytes can be efficiently extracted from
 // targets.
@@ -470,13 +672,16 @@ func (e *Engine) chunkAndTransmitAll(target io.Reader, maxDataOpSize uint64, tra
 // operations to the provided transmission function. The internal engine buffer
 // will be resized to the sum of the maximum data operation size plus the block
 // size, and retained for the lifetime of the engine, so a reasonable value
-// for the maximum data operation size should be provided. For performance
-// reasons, this method does not validate that the provided signature satisfies
-// expected invariants. It is the responsibility of the caller to verify that
-// the signature is valid by calling its EnsureValid method. This is not
-// necessary for signatures generated in the same process, but should be done
-// for signatures received from untrusted locations (e.g. over the network). An
-// invalid signature can result in undefined behavior.
+// for the maximum data operation size should be provided. If the engine has a
+// deltification time limit set (see SetDeltificationTimeLimit) and block match
+// searching exceeds that limit, then the remainder of the target is transmitted
+// as literal data. For performance reasons, this method does not validate that
+// the provided signature satisfies expected invariants. It is the
+// responsibility of the caller to verify that the signature is valid by calling
+// its EnsureValid method. This is not necessary for signatures generated in the
+// same process, but should be done for signatures received from untrusted
+// locations (e.g. over the network). An invalid signature can result in
+// undefined behavior.
 func (e *Engine) Deltify(target io.Reader, base *Signature, maxDataOpSize uint64, transmit OperationTransmitter) error {
 	// Verify that the maximum data operation size is sane.
 	if maxDataOpSize == 0 {
@@ -602,6 +807,15 @@ func (e *Engine) Deltify(target io.Reader, base *Signature, maxDataOpSize uint64
 	// buffer.
 	var weak, r1, r2 uint32
 
+	// Compute the deadline for block match searching, if any, and track the
+	// number of search iterations so that we can check it periodically.
+	var deadline time.Time
+	if e.deltificationTimeLimit > 0 {
+		deadline = time.Now().Add(e.deltificationTimeLimit)
+	}
+	var iterations uint64
+	var deadlineExceeded bool
+
 	// Loop over the contents of the file and search for matches.
 	for {
 		// If the buffer is empty, then we need to read in a block's worth of
@@ -665,11 +879,44 @@ func (e *Engine) Deltify(target io.Reader, base *Signature, maxDataOpSize uint64
 			copy(buffer[:base.BlockSize], buffer[occupancy-base.BlockSize:occupancy])
 			occupancy = base.BlockSize
 		}
+
+		// Check whether or not we've exceeded the deadline. At this point, the
+		// buffer contains only data that hasn't been transmitted, so we can
+		// safely abandon the search.
+		if !deadline.IsZero() {
+			iterations++
+			if iterations%deltificationTimeLimitCheckInterval == 0 && time.Now().After(deadline) {
+				deadlineExceeded = true
+				break
+			}
+		}
 	}
 
-	// If we have a short last block and the occupancy of the buffer is large
-	// enough that it could match, then check for a match.
-	if haveShortLastBlock && occupancy >= base.LastBlockSize {
+	// If we exceeded the deadline, then fall back to transmitting the remainder
+	// of the target as literal data. Any pending coalesced block operation will
+	// be transmitted before this data, so the resulting operation stream is
+	// still correct, just less efficient. Otherwise, if we have a short last
+	// block and the occupancy of the buffer is large enough that it could
+	// match, then check for a match.
+	if deadlineExceeded {
+		e.deltificationFallbacks++
+		for {
+			if err := sendData(buffer[:occupancy]); err != nil {
+				return fmt.Errorf("unable to transmit literal data: %w", err)
+			}
+			if n, err := io.ReadFull(bufferedTarget, buffer[:maxDataOpSize]); err == io.EOF {
+				occupancy = 0
+				break
+			} else if err == io.ErrUnexpectedEOF {
+				occupancy = uint64(n)
+				break
+			} else if err != nil {
+				return fmt.Errorf("unable to read target: %w", err)
+			} else {
+				occupancy = uint64(n)
+			}
+		}
+	} else if haveShortLastBlock && occupancy >= base.LastBlockSize {
 		potentialLastBlockMatch := buffer[occupancy-base.LastBlockSize : occupancy]
 		// For short blocks, we still use the full block size when computing the
 		// weak hash. We could alternatively use the short block length, but it
@@ -704,6 +951,70 @@ func (e *Engine) Deltify(target io.Reader, base *Signature, maxDataOpSize uint64
 	return nil
 }
 
+// newTrailerHasher creates a new hash function for computing trailer digests.
+func newTrailerHasher() hash.Hash {
+	return sha1.New()
+}
+
+// DeltifyWithTrailer is a variant of Deltify that emits a final trailer
+// operation carrying a strong digest of the entire target stream. Receivers
+// that verify trailers (see NewReceiverWithTrailerVerification) use this digest
+// to verify patched output independently of any expected digest, allowing
+// corruption introduced in transport to be distinguished from other failures.
+// Trailer operations are not understood by older receivers, so this method
+// should only be used if the receiver is known to support them. The trailer
+// operation is only transmitted if deltification succeeds. The same signature
+// validity requirements as those outlined for Deltify apply.
+func (e *Engine) DeltifyWithTrailer(target io.Reader, base *Signature, maxDataOpSize uint64, transmit OperationTransmitter) error {
+	// Create or reset the trailer hasher.
+	if e.trailerHasher == nil {
+		e.trailerHasher = newTrailerHasher()
+	} else {
+		e.trailerHasher.Reset()
+	}
+
+	// Perform deltification, digesting the target as it's read. Deltification
+	// always reads the target in its entirety.
+	if err := e.Deltify(io.TeeReader(target, e.trailerHasher), base, maxDataOpSize, transmit); err != nil {
+		return err
+	}
+
+	// Transmit the trailer operation.
+	*e.operation = Operation{
+		Digest: e.trailerHasher.Sum(e.strongHashBuffer[:0]),
+	}
+	return transmit(e.operation)
+}
+
+// Match performs the block matching pass of deltification for the target data
+// stream against the base signature, returning the number of target bytes that
+// were matched to base blocks and the number of target bytes that would need
+// to be transmitted as literal data. The sum of these values is always the
+// length of the target. Matching is performed by Deltify itself (with the
+// resulting operations tallied rather than transmitted), so its results are
+// always consistent with deltification, including any deltification time
+// limit. The same signature validity requirements as those outlined for
+// Deltify apply.
+func (e *Engine) Match(base *Signature, target io.Reader) (matchedBytes, literalBytes uint64, err error) {
+	// Create an operation transmitter that tallies operation content.
+	tally := func(o *Operation) error {
+		if len(o.Data) > 0 {
+			literalBytes += uint64(len(o.Data))
+		} else if o.Count > 0 {
+			matchedBytes += base.blockRangeLength(o.Start, o.Count)
+		}
+		return nil
+	}
+
+	// Perform deltification.
+	if err = e.Deltify(target, base, 0, tally); err != nil {
+		return 0, 0, err
+	}
+
+	// Success.
+	return matchedBytes, literalBytes, nil
+}
+
 // DeltifyBytes computes delta operations for a byte slice. Unlike the streaming
 // Deltify method, it returns a slice of operations, which should be reasonable
 // since the target data can already fit into memory. The internal engine buffer
@@ -739,6 +1050,108 @@ func (e *Engine) DeltifyBytes(target []byte, base *Signature, maxDataOpSize uint
 	return delta
 }
 
+// operationsTransmissionSize computes the total encoded size of a sequence of
+// operations, which approximates the number of bytes required to transmit them.
+func operationsTransmissionSize(operations []*Operation) uint64 {
+	var result uint64
+	for _, o := range operations {
+		result += uint64(proto.Size(o))
+	}
+	return result
+}
+
+// deltifyToSlice performs deltification against the target from its start and
+// collects the resulting operations.
+func (e *Engine) deltifyToSlice(target io.ReadSeeker, base *Signature, maxDataOpSize uint64) ([]*Operation, error) {
+	// Rewind the target.
+	if _, err := target.Seek(0, io.SeekStart); err != nil {
+		return nil, fmt.Errorf("unable to seek to start of target: %w", err)
+	}
+
+	// Perform deltification and collect operations.
+	var delta []*Operation
+	transmit := func(o *Operation) error {
+		delta = append(delta, proto.Clone(o).(*Operation))
+		return nil
+	}
+	if err := e.Deltify(target, base, maxDataOpSize, transmit); err != nil {
+		return nil, err
+	}
+
+	// Success.
+	return delta, nil
+}
+
+// DeltifyAdaptive computes delta operations to reconstitute the target stream
+// using the base stream, adapting the block size to the content. It first
+// performs a standard deltification using the provided base signature. If that
+// leaves target data unmatched, then it performs additional passes using
+// signatures with successively halved block sizes (down to MinimumBlockSize),
+// which allow matches to be found closer to the edges of modified regions.
+// Shrinking stops once a pass fails to reduce the transmission size of the
+// resulting operations. Because block operations index fixed-size blocks,
+// the block size is adapted for the whole stream rather than for individual
+// regions. If no adaptive pass yields an improvement, then the result of the
+// standard deltification is returned.
+//
+// Since finer signatures must be computed from the base content itself, this
+// method is only usable when both streams are available locally. It returns
+// the signature against which the operations must be applied, which is the
+// provided signature if the standard deltification result is used. The
+// provided signature is subject to the same validity requirements as those
+// outlined for Deltify.
+func (e *Engine) DeltifyAdaptive(target, base io.ReadSeeker, signature *Signature, maxDataOpSize uint64) (*Signature, []*Operation, error) {
+	// Perform the standard deltification.
+	delta, err := e.deltifyToSlice(target, signature, maxDataOpSize)
+	if err != nil {
+		return nil, nil, err
+	}
+	size := operationsTransmissionSize(delta)
+
+	// If the base is empty or all target data was matched, then there's nothing
+	// to improve upon.
+	unmatched := false
+	for _, o := range delta {
+		if len(o.Data) > 0 {
+			unmatched = true
+			break
+		}
+	}
+	if signature.isEmpty() || !unmatched {
+		return signature, delta, nil
+	}
+
+	// Perform adaptive passes with successively smaller block sizes, keeping
+	// the best result.
+	for blockSize := signature.BlockSize / 2; blockSize >= MinimumBlockSize; blockSize /= 2 {
+		// Compute a signature for the base with the reduced block size.
+		if _, err := base.Seek(0, io.SeekStart); err != nil {
+			return nil, nil, fmt.Errorf("unable to seek to start of base: %w", err)
+		}
+		candidateSignature, err := e.Signature(base, blockSize)
+		if err != nil {
+			return nil, nil, fmt.Errorf("unable to compute adaptive signature: %w", err)
+		}
+
+		// Perform deltification against the reduced signature.
+		candidateDelta, err := e.deltifyToSlice(target, candidateSignature, maxDataOpSize)
+		if err != nil {
+			return nil, nil, fmt.Errorf("unable to perform adaptive deltification: %w", err)
+		}
+
+		// If this pass didn't improve on the best result, then further passes
+		// are unlikely to do so.
+		candidateSize := operationsTransmissionSize(candidateDelta)
+		if candidateSize >= size {
+			break
+		}
+		signature, delta, size = candidateSignature, candidateDelta, candidateSize
+	}
+
+	// Success.
+	return signature, delta, nil
+}
+
 // Patch applies a single operation against a base stream to reconstitute the
 // target into the destination stream. For performance reasons, this method does
 // not validate that the provided signature and operation satisfy expected
@@ -749,8 +1162,11 @@ func (e *Engine) DeltifyBytes(target []byte, base *Signature, maxDataOpSize uint
 // untrusted locations (e.g. over the network). An invalid signature or
 // operation can result in undefined behavior.
 func (e *Engine) Patch(destination io.Writer, base io.ReadSeeker, signature *Signature, operation *Operation) error {
-	// Handle the operation based on type.
-	if len(operation.Data) > 0 {
+	// Handle the operation based on type. Trailer operations don't contribute
+	// any content to the target, so they're ignored.
+	if operation.isTrailer() {
+		return nil
+	} else if len(operation.Data) > 0 {
 		// Write data operations directly to the destination.
 		if _, err := destination.Write(operation.Data); err != nil {
 			return fmt.Errorf("unable to write data: %w", err)
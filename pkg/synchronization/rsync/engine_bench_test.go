@@ -0,0 +1,74 @@
+package rsync
+
+import (
+	"bytes"
+	"fmt"
+	"testing"
+)
+
+// generateLogRotationFixture generates base and target content simulating a
+// log file to which the specified number of lines have been prepended, with an
+// equal number of the oldest lines rotated out of the end of the file.
+func generateLogRotationFixture(lines, prepended int) ([]byte, []byte) {
+	// Generate log lines, with the newest lines first.
+	entries := make([][]byte, lines+prepended)
+	for i := range entries {
+		sequence := lines + prepended - i
+		entries[i] = []byte(fmt.Sprintf(
+			"2024-01-01T00:%02d:%02d.%06dZ INFO request %d handled in %dms\n",
+			(sequence/60)%60, sequence%60, sequence*7919%1000000, sequence, sequence%97,
+		))
+	}
+
+	// Create the base and target.
+	base := bytes.Join(entries[prepended:], nil)
+	target := bytes.Join(entries[:lines], nil)
+
+	// Done.
+	return base, target
+}
+
+// benchmarkDeltifyLogRotation benchmarks deltification on a log rotation
+// fixture and reports the number of transmitted bytes.
+func benchmarkDeltifyLogRotation(b *testing.B, adaptive bool) {
+	// Generate the fixture.
+	base, target := generateLogRotationFixture(50000, 25)
+
+	// Create an engine and compute the base signature.
+	engine := NewEngine()
+	signature := engine.BytesSignature(base, 0)
+
+	// Reset the benchmark timer to exclude the setup time.
+	b.ResetTimer()
+
+	// Perform the benchmark.
+	var transmitted uint64
+	for i := 0; i < b.N; i++ {
+		if adaptive {
+			_, delta, err := engine.DeltifyAdaptive(
+				bytes.NewReader(target), bytes.NewReader(base), signature, 0,
+			)
+			if err != nil {
+				b.Fatal("unable to perform adaptive deltification:", err)
+			}
+			transmitted = operationsTransmissionSize(delta)
+		} else {
+			transmitted = operationsTransmissionSize(engine.DeltifyBytes(target, signature, 0))
+		}
+	}
+
+	// Report the number of transmitted bytes.
+	b.ReportMetric(float64(transmitted), "transmitted-bytes")
+}
+
+// BenchmarkDeltifyLogRotation benchmarks standard deltification on a log
+// rotation fixture.
+func BenchmarkDeltifyLogRotation(b *testing.B) {
+	benchmarkDeltifyLogRotation(b, false)
+}
+
+// BenchmarkDeltifyAdaptiveLogRotation benchmarks adaptive deltification on a
+// log rotation fixture.
+func BenchmarkDeltifyAdaptiveLogRotation(b *testing.B) {
+	benchmarkDeltifyLogRotation(b, true)
+}
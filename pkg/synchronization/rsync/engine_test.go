@@ -2,8 +2,12 @@ package rsync
 
 import (
 	"bytes"
+	"crypto/sha1"
 	"math/rand"
 	"testing"
+	"time"
+
+	"google.golang.org/protobuf/proto"
 )
 
 // TestBlockHashNilInvalid verifies that a nil block hash is treated as invalid.
@@ -165,6 +169,31 @@ func TestOperationBlocksValid(t *testing.T) {
 	}
 }
 
+// TestOperationTrailerWithParametersInvalid verifies the EnsureValid behavior
+// of Operation in the case of trailer operations with data or block parameters.
+func TestOperationTrailerWithParametersInvalid(t *testing.T) {
+	digest := []byte{0}
+	operations := []*Operation{
+		{Digest: digest, Data: []byte{0}},
+		{Digest: digest, Start: 1},
+		{Digest: digest, Count: 1},
+	}
+	for _, operation := range operations {
+		if operation.EnsureValid() == nil {
+			t.Error("trailer operation with parameters considered valid")
+		}
+	}
+}
+
+// TestOperationTrailerValid verifies the EnsureValid behavior of Operation in
+// the case of a valid trailer operation.
+func TestOperationTrailerValid(t *testing.T) {
+	operation := &Operation{Digest: []byte{0}}
+	if err := operation.EnsureValid(); err != nil {
+		t.Error("valid trailer operation considered invalid")
+	}
+}
+
 // TestMinimumBlockSize verifies that OptimalBlockSizeForBaseLength returns a
 // sane minimum block size.
 func TestMinimumBlockSize(t *testing.T) {
@@ -208,6 +237,29 @@ func TestOptimalBlockSizeForBase(t *testing.T) {
 	}
 }
 
+// TestEstimateSignatureMemoryUsage verifies that signature memory usage
+// estimates are consistent with the memory usage of computed signatures.
+func TestEstimateSignatureMemoryUsage(t *testing.T) {
+	// Create an engine.
+	engine := NewEngine()
+
+	// Process test cases, using both the optimal block size and explicitly
+	// specified block sizes.
+	for i, baseLength := range []uint64{0, 1, 1023, 1024, 1025, 1234567, 11 << 20} {
+		for _, blockSize := range []uint64{0, MinimumBlockSize, 1 << 16} {
+			signature, err := engine.Signature(bytes.NewReader(make([]byte, baseLength)), blockSize)
+			if err != nil {
+				t.Fatalf("test index %d: unable to compute signature with block size %d: %v", i, blockSize, err)
+			}
+			if estimate, actual := EstimateSignatureMemoryUsage(baseLength, blockSize), signature.MemoryUsage(); estimate != actual {
+				t.Errorf("test index %d: estimated memory usage with block size %d (%d) does not match actual (%d)",
+					i, blockSize, estimate, actual,
+				)
+			}
+		}
+	}
+}
+
 // testDataGenerator generates repeatable random byte sequences with optional
 // mutations and data prepending.
 type testDataGenerator struct {
@@ -528,3 +580,472 @@ func TestDifferentDataDifferentLength(t *testing.T) {
 	}
 	test.run(t)
 }
+
+// TestDeltificationTimeLimitFallback verifies that a pathological target that
+// generates a weak hash collision at every search position triggers the
+// deltification time limit and that the resulting literal data fallback still
+// produces a correct delta.
+func TestDeltificationTimeLimitFallback(t *testing.T) {
+	// Create a base consisting of blocks of repetitive content, each of which
+	// is perturbed in a way that preserves its weak hash (the sum and weighted
+	// sum of its bytes) but not its strong hash.
+	const blockSize = 4096
+	const blockCount = 256
+	base := bytes.Repeat([]byte{100}, blockSize*blockCount)
+	for b := 0; b < blockCount; b++ {
+		offset := b*blockSize + b
+		base[offset]++
+		base[offset+1] -= 2
+		base[offset+2]++
+	}
+
+	// Create a target that begins with content matching the base (so that a
+	// coalesced block operation is pending when the fallback occurs) and then
+	// continues with unperturbed repetitive content, which will collide with
+	// every base block's weak hash at every position but never match.
+	target := append([]byte{}, base[:2*blockSize]...)
+	target = append(target, bytes.Repeat([]byte{100}, 1024*1024)...)
+
+	// Create an engine with a deltification time limit.
+	engine := NewEngine()
+	engine.SetDeltificationTimeLimit(10 * time.Millisecond)
+
+	// Compute the base signature and a delta.
+	signature := engine.BytesSignature(base, blockSize)
+	delta := engine.DeltifyBytes(target, signature, 0)
+
+	// Verify that the time limit triggered a fallback and that block matching
+	// still occurred before the fallback.
+	if fallbacks := engine.DeltificationFallbacks(); fallbacks != 1 {
+		t.Error("unexpected number of deltification fallbacks:", fallbacks, "!=", 1)
+	}
+	var haveBlockOperations bool
+	for _, o := range delta {
+		if err := o.EnsureValid(); err != nil {
+			t.Fatal("invalid operation:", err)
+		} else if o.Count > 0 {
+			haveBlockOperations = true
+		}
+	}
+	if !haveBlockOperations {
+		t.Error("delta did not contain block operations")
+	}
+
+	// Verify that the delta reconstitutes the target.
+	patched, err := engine.PatchBytes(base, signature, delta)
+	if err != nil {
+		t.Fatal("unable to patch bytes:", err)
+	} else if !bytes.Equal(patched, target) {
+		t.Error("patched data did not match expected")
+	}
+}
+
+// TestDeltificationTimeLimitNotExceeded verifies that a deltification time
+// limit that isn't exceeded doesn't trigger a fallback or otherwise affect
+// deltification.
+func TestDeltificationTimeLimitNotExceeded(t *testing.T) {
+	// Generate base and target data.
+	base := testDataGenerator{1234567, 473, nil, nil}.generate()
+	target := testDataGenerator{1234567, 473, []int{83, 830, 8300}, nil}.generate()
+
+	// Create an engine with a generous deltification time limit.
+	engine := NewEngine()
+	engine.SetDeltificationTimeLimit(time.Hour)
+
+	// Compute the base signature and a delta.
+	signature := engine.BytesSignature(base, 0)
+	delta := engine.DeltifyBytes(target, signature, 0)
+
+	// Verify that no fallback occurred and that the delta is still minimal.
+	if fallbacks := engine.DeltificationFallbacks(); fallbacks != 0 {
+		t.Error("unexpected number of deltification fallbacks:", fallbacks, "!=", 0)
+	}
+	if expected := NewEngine().DeltifyBytes(target, signature, 0); len(delta) != len(expected) {
+		t.Error("delta length differs from unlimited deltification:", len(delta), "!=", len(expected))
+	}
+
+	// Verify that the delta reconstitutes the target.
+	patched, err := engine.PatchBytes(base, signature, delta)
+	if err != nil {
+		t.Fatal("unable to patch bytes:", err)
+	} else if !bytes.Equal(patched, target) {
+		t.Error("patched data did not match expected")
+	}
+}
+
+// TestDeltifyAdaptiveImprovement verifies that adaptive deltification reduces
+// the transmission size for a target with scattered mutations and that the
+// resulting delta reconstitutes the target.
+func TestDeltifyAdaptiveImprovement(t *testing.T) {
+	// Generate base and target data.
+	base := testDataGenerator{65536, 473, nil, nil}.generate()
+	target := testDataGenerator{65536, 473, []int{100, 20000, 40000, 60000}, nil}.generate()
+
+	// Create an engine and compute the base signature.
+	engine := NewEngine()
+	signature := engine.BytesSignature(base, 8192)
+
+	// Compute standard and adaptive deltas.
+	standard := engine.DeltifyBytes(target, signature, 0)
+	adaptiveSignature, adaptive, err := engine.DeltifyAdaptive(
+		bytes.NewReader(target), bytes.NewReader(base), signature, 0,
+	)
+	if err != nil {
+		t.Fatal("unable to perform adaptive deltification:", err)
+	}
+
+	// Verify that the block size was reduced and that the delta improved.
+	if adaptiveSignature.BlockSize >= signature.BlockSize {
+		t.Error("adaptive block size not reduced:", adaptiveSignature.BlockSize, ">=", signature.BlockSize)
+	}
+	if a, s := operationsTransmissionSize(adaptive), operationsTransmissionSize(standard); a >= s {
+		t.Error("adaptive delta not smaller than standard delta:", a, ">=", s)
+	}
+
+	// Verify that the delta reconstitutes the target.
+	patched, err := engine.PatchBytes(base, adaptiveSignature, adaptive)
+	if err != nil {
+		t.Fatal("unable to patch bytes:", err)
+	} else if !bytes.Equal(patched, target) {
+		t.Error("patched data did not match expected")
+	}
+}
+
+// TestDeltifyAdaptiveFallback verifies that adaptive deltification falls back
+// to the standard deltification result when no improvement is possible.
+func TestDeltifyAdaptiveFallback(t *testing.T) {
+	// Set up test cases.
+	testCases := []struct {
+		base   testDataGenerator
+		target testDataGenerator
+	}{
+		{testDataGenerator{0, 473, nil, nil}, testDataGenerator{10000, 473, nil, nil}},
+		{testDataGenerator{10000, 473, nil, nil}, testDataGenerator{10000, 473, nil, nil}},
+		{testDataGenerator{10000, 473, nil, nil}, testDataGenerator{10000, 182, nil, nil}},
+	}
+
+	// Process test cases.
+	for i, testCase := range testCases {
+		// Generate base and target data.
+		base := testCase.base.generate()
+		target := testCase.target.generate()
+
+		// Create an engine and compute the base signature.
+		engine := NewEngine()
+		signature := engine.BytesSignature(base, 2048)
+
+		// Perform adaptive deltification.
+		adaptiveSignature, adaptive, err := engine.DeltifyAdaptive(
+			bytes.NewReader(target), bytes.NewReader(base), signature, 0,
+		)
+		if err != nil {
+			t.Fatalf("test case %d: unable to perform adaptive deltification: %v", i, err)
+		}
+
+		// Verify that the standard result was used.
+		if adaptiveSignature != signature {
+			t.Errorf("test case %d: adaptive signature used", i)
+		}
+		standard := engine.DeltifyBytes(target, signature, 0)
+		if a, s := operationsTransmissionSize(adaptive), operationsTransmissionSize(standard); a != s {
+			t.Errorf("test case %d: delta size differs from standard: %d != %d", i, a, s)
+		}
+
+		// Verify that the delta reconstitutes the target.
+		patched, err := engine.PatchBytes(base, adaptiveSignature, adaptive)
+		if err != nil {
+			t.Fatalf("test case %d: unable to patch bytes: %v", i, err)
+		} else if !bytes.Equal(patched, target) {
+			t.Errorf("test case %d: patched data did not match expected", i)
+		}
+	}
+}
+
+// TestDescribeOperations tests that operation descriptions reconstruct the
+// target when their content is copied from the base and operation data.
+func TestDescribeOperations(t *testing.T) {
+	// Create a base and a target that shares content with it.
+	random := rand.New(rand.NewSource(time.Now().UnixNano()))
+	base := make([]byte, 10*1024+123)
+	random.Read(base)
+	literal := make([]byte, 2*1024)
+	random.Read(literal)
+	var target []byte
+	target = append(target, base[:3*1024]...)
+	target = append(target, literal...)
+	target = append(target, base[5*1024:]...)
+
+	// Compute the operations.
+	engine := NewEngine()
+	signature := engine.BytesSignature(base, 1024)
+	operations := engine.DeltifyBytes(target, signature, 0)
+
+	// Compute the descriptions.
+	descriptions, err := DescribeOperations(signature, operations)
+	if err != nil {
+		t.Fatal("unable to describe operations:", err)
+	} else if len(descriptions) != len(operations) {
+		t.Fatal("description count does not match operation count")
+	}
+
+	// Reconstruct the target using only the description offsets and lengths.
+	var reconstructed []byte
+	var haveBlocks, haveData bool
+	for i, description := range descriptions {
+		if description.TargetOffset != uint64(len(reconstructed)) {
+			t.Fatalf("description %d has incorrect target offset: %d != %d",
+				i, description.TargetOffset, len(reconstructed),
+			)
+		}
+		if description.Data {
+			haveData = true
+			reconstructed = append(reconstructed, operations[i].Data[:description.Length]...)
+		} else {
+			haveBlocks = true
+			reconstructed = append(reconstructed, base[description.BaseOffset:description.BaseOffset+description.Length]...)
+		}
+	}
+	if !bytes.Equal(reconstructed, target) {
+		t.Error("reconstructed target does not match original")
+	}
+	if !haveBlocks || !haveData {
+		t.Error("expected both block and data operations")
+	}
+}
+
+// TestDescribeOperationsInvalidBlockRange tests that DescribeOperations rejects
+// block operations that reference blocks outside of the signature.
+func TestDescribeOperationsInvalidBlockRange(t *testing.T) {
+	signature := NewEngine().BytesSignature(make([]byte, 4096), 1024)
+	if _, err := DescribeOperations(signature, []*Operation{{Start: 3, Count: 2}}); err == nil {
+		t.Error("out-of-range block operation described without error")
+	}
+}
+
+// TestSignatureFingerprint tests that signature fingerprints match for
+// equivalent signatures and differ for signatures of differing content.
+func TestSignatureFingerprint(t *testing.T) {
+	engine := NewEngine()
+	base := bytes.Repeat([]byte("fingerprint"), 1000)
+	modified := append([]byte(nil), base...)
+	modified[len(modified)/2] ^= 0xff
+	first := engine.BytesSignature(base, 1024)
+	second := engine.BytesSignature(base, 1024)
+	if !bytes.Equal(first.Fingerprint(), second.Fingerprint()) {
+		t.Error("fingerprints differ for identical content")
+	}
+	if bytes.Equal(first.Fingerprint(), engine.BytesSignature(modified, 1024).Fingerprint()) {
+		t.Error("fingerprints match for differing content")
+	}
+	if bytes.Equal(first.Fingerprint(), engine.BytesSignature(base, 2048).Fingerprint()) {
+		t.Error("fingerprints match for differing block sizes")
+	}
+	if bytes.Equal(first.Fingerprint(), (&Signature{}).Fingerprint()) {
+		t.Error("fingerprint matches empty signature fingerprint")
+	}
+}
+
+// TestSignatureCached tests that SignatureCached only re-uses a previous
+// signature when the base's modification time, length, and block size match,
+// including when the base is modified in-place without a change in size.
+func TestSignatureCached(t *testing.T) {
+	// Create a base and compute its signature.
+	engine := NewEngine()
+	base := bytes.Repeat([]byte("cached signature content "), 2000)
+	modificationTime := time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)
+	previous, reused, err := engine.SignatureCached(bytes.NewReader(base), 0, modificationTime, nil, time.Time{})
+	if err != nil {
+		t.Fatal("unable to compute signature:", err)
+	} else if reused {
+		t.Fatal("signature re-used without previous signature")
+	}
+
+	// Modify the base in-place without changing its size.
+	modified := append([]byte(nil), base...)
+	copy(modified[len(modified)/2:], "modified")
+	expected := engine.BytesSignature(modified, 0)
+
+	// Set up test cases.
+	testCases := []struct {
+		// description is a description of the test case.
+		description string
+		// base is the base content.
+		base []byte
+		// blockSize is the requested block size.
+		blockSize uint64
+		// modificationTime is the base modification time.
+		modificationTime time.Time
+		// previousModificationTime is the cached modification time.
+		previousModificationTime time.Time
+		// expectReuse indicates whether or not re-use is expected.
+		expectReuse bool
+	}{
+		{"unchanged", base, 0, modificationTime, modificationTime, true},
+		{"unchanged with explicit block size", base, previous.BlockSize, modificationTime, modificationTime, true},
+		{"unchanged with differing block size", base, previous.BlockSize / 2, modificationTime, modificationTime, false},
+		{"unknown modification time", base, 0, time.Time{}, time.Time{}, false},
+		{"modified in-place with new modification time", modified, 0, modificationTime.Add(time.Second), modificationTime, false},
+		{"truncated with preserved modification time", base[:len(base)-1], 0, modificationTime, modificationTime, false},
+	}
+
+	// Process test cases.
+	for _, testCase := range testCases {
+		signature, reused, err := engine.SignatureCached(
+			bytes.NewReader(testCase.base), testCase.blockSize,
+			testCase.modificationTime,
+			previous, testCase.previousModificationTime,
+		)
+		if err != nil {
+			t.Errorf("%s: unable to compute signature: %v", testCase.description, err)
+			continue
+		} else if reused != testCase.expectReuse {
+			t.Errorf("%s: re-use does not match expected: %t != %t",
+				testCase.description, reused, testCase.expectReuse,
+			)
+		}
+		recomputed := engine.BytesSignature(testCase.base, testCase.blockSize)
+		if !bytes.Equal(signature.Fingerprint(), recomputed.Fingerprint()) {
+			t.Errorf("%s: signature does not match recomputed signature", testCase.description)
+		}
+	}
+
+	// Verify that the in-place modification is reflected in the signature.
+	signature, _, err := engine.SignatureCached(
+		bytes.NewReader(modified), 0,
+		modificationTime.Add(time.Second),
+		previous, modificationTime,
+	)
+	if err != nil {
+		t.Fatal("unable to compute signature:", err)
+	} else if !bytes.Equal(signature.Fingerprint(), expected.Fingerprint()) {
+		t.Error("signature for modified base does not match expected")
+	} else if bytes.Equal(signature.Fingerprint(), previous.Fingerprint()) {
+		t.Error("signature for modified base matches previous signature")
+	}
+}
+
+// TestDeltifyWithTrailer tests that DeltifyWithTrailer emits the standard
+// operation stream followed by a trailer operation carrying the target digest,
+// and that the resulting operations can still be patched and described.
+func TestDeltifyWithTrailer(t *testing.T) {
+	// Generate base and target data and compute the base signature.
+	base := testDataGenerator{length: 10 * 1024, seed: 473}.generate()
+	target := testDataGenerator{length: 10 * 1024, seed: 473, mutations: []int{2048}}.generate()
+	engine := NewEngine()
+	signature := engine.BytesSignature(base, 1024)
+
+	// Perform deltification with and without a trailer.
+	expected := engine.DeltifyBytes(target, signature, 0)
+	var delta []*Operation
+	transmit := func(o *Operation) error {
+		delta = append(delta, proto.Clone(o).(*Operation))
+		return nil
+	}
+	if err := engine.DeltifyWithTrailer(bytes.NewReader(target), signature, 0, transmit); err != nil {
+		t.Fatal("unable to perform deltification:", err)
+	}
+
+	// Verify the operations.
+	if len(delta) != len(expected)+1 {
+		t.Fatal("operation count does not match expected:", len(delta), "!=", len(expected)+1)
+	}
+	for i, o := range expected {
+		if !proto.Equal(delta[i], o) {
+			t.Errorf("operation %d does not match standard deltification", i)
+		}
+	}
+	trailer := delta[len(delta)-1]
+	digest := sha1.Sum(target)
+	if err := trailer.EnsureValid(); err != nil {
+		t.Error("trailer operation invalid:", err)
+	} else if !trailer.isTrailer() {
+		t.Error("final operation is not a trailer operation")
+	} else if !bytes.Equal(trailer.Digest, digest[:]) {
+		t.Error("trailer digest does not match target digest")
+	}
+
+	// Verify that the trailer doesn't affect patching or description.
+	if patched, err := engine.PatchBytes(base, signature, delta); err != nil {
+		t.Fatal("unable to patch bytes:", err)
+	} else if !bytes.Equal(patched, target) {
+		t.Error("patched data did not match expected")
+	}
+	if descriptions, err := DescribeOperations(signature, delta); err != nil {
+		t.Error("unable to describe operations:", err)
+	} else if !descriptions[len(descriptions)-1].Trailer {
+		t.Error("trailer operation not described as trailer")
+	}
+}
+
+// TestMatch tests that Match reports matched and literal byte counts that sum
+// to the target length and that agree with deltification.
+func TestMatch(t *testing.T) {
+	// Create a base and targets that share varying amounts of content with it.
+	random := rand.New(rand.NewSource(time.Now().UnixNano()))
+	base := make([]byte, 10*1024+123)
+	random.Read(base)
+	literal := make([]byte, 2*1024)
+	random.Read(literal)
+	var modified []byte
+	modified = append(modified, base[:3*1024]...)
+	modified = append(modified, literal...)
+	modified = append(modified, base[5*1024:]...)
+
+	// Set up test cases.
+	engine := NewEngine()
+	signature := engine.BytesSignature(base, 1024)
+	testCases := []struct {
+		// description is a description of the test case.
+		description string
+		// signature is the base signature.
+		signature *Signature
+		// target is the target content.
+		target []byte
+		// expectMatched indicates whether or not any bytes are expected to
+		// match.
+		expectMatched bool
+		// expectLiteral indicates whether or not any literal bytes are
+		// expected.
+		expectLiteral bool
+	}{
+		{"identical", signature, base, true, false},
+		{"modified", signature, modified, true, true},
+		{"unrelated", signature, literal, false, true},
+		{"empty target", signature, nil, false, false},
+		{"empty base", &Signature{}, modified, false, true},
+	}
+
+	// Process test cases.
+	for _, testCase := range testCases {
+		// Perform matching.
+		matched, literal, err := engine.Match(testCase.signature, bytes.NewReader(testCase.target))
+		if err != nil {
+			t.Errorf("%s: matching failed: %v", testCase.description, err)
+			continue
+		}
+
+		// Verify the counts.
+		if matched+literal != uint64(len(testCase.target)) {
+			t.Errorf("%s: matched (%d) and literal (%d) byte counts don't sum to target length (%d)",
+				testCase.description, matched, literal, len(testCase.target),
+			)
+		}
+		if (matched > 0) != testCase.expectMatched {
+			t.Errorf("%s: unexpected matched byte count: %d", testCase.description, matched)
+		}
+		if (literal > 0) != testCase.expectLiteral {
+			t.Errorf("%s: unexpected literal byte count: %d", testCase.description, literal)
+		}
+
+		// Verify that the literal byte count agrees with deltification.
+		var dataBytes uint64
+		for _, operation := range engine.DeltifyBytes(testCase.target, testCase.signature, 0) {
+			dataBytes += uint64(len(operation.Data))
+		}
+		if literal != dataBytes {
+			t.Errorf("%s: literal byte count doesn't match deltification: %d != %d",
+				testCase.description, literal, dataBytes,
+			)
+		}
+	}
+}
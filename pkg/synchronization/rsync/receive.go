@@ -5,9 +5,15 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"hash"
 	"io"
+	"sync"
+	"time"
+
+	"google.golang.org/protobuf/types/known/durationpb"
 
 	"github.com/mutagen-io/mutagen/pkg/filesystem"
+	"github.com/mutagen-io/mutagen/pkg/synchronization/compression"
 )
 
 // EnsureValid ensures that ReceiverState's invariants are respected.
@@ -27,15 +33,32 @@ func (s *ReceiverState) EnsureValid() error {
 		return errors.New("too many files received")
 	}
 
+	// Ensure that the estimated time remaining is valid, if present.
+	if s.EstimatedRemaining != nil {
+		if err := s.EstimatedRemaining.CheckValid(); err != nil {
+			return fmt.Errorf("invalid estimated time remaining: %w", err)
+		}
+	}
+
 	// Success.
 	return nil
 }
 
+// ErrPatchDigestMismatch indicates that the digest of patched output didn't
+// match the digest carried by a trailer operation, which generally indicates
+// that operations were corrupted in transport.
+var ErrPatchDigestMismatch = errors.New("patched output does not match trailer digest")
+
 // Receiver manages the streaming reception of multiple files. It should be used
 // in conjunction with the Transmit function.
 type Receiver interface {
 	// Receive processes a single message in a transmission stream.
 	Receive(*Transmission) error
+	// SupportsDecompression indicates whether or not the receiver (or the
+	// receiver to which it ultimately forwards transmissions) is able to
+	// decompress operation data compressed with the specified algorithm. It is
+	// used by transmitters to negotiate operation data compression.
+	SupportsDecompression(compression.Algorithm) bool
 	// finalize indicates that the transmission stream is completed and that no
 	// more messages will be received. This may indicate the successful
 	// completion of transmission, but could also indicate that the stream has
@@ -47,9 +70,39 @@ type Receiver interface {
 
 // Sinker provides the interface for a receiver to store incoming files.
 type Sinker interface {
-	// Sink should return a new io.WriteCloser for staging the given path. Each
+	// Sink should return a new io.WriteCloser for staging the given path. The
+	// expected size of the file is provided (as reported by the transmitter) so
+	// that the sinker can refuse the file before any content is received. The
+	// expected digest of the file is also provided, and the result's Close
+	// method must verify the received content against this digest, returning
+	// an error (and not retaining the content) if verification fails. Each
 	// result it returns will be closed before Sink is invoked again.
-	Sink(path string) (io.WriteCloser, error)
+	Sink(path string, digest []byte, expectedSize uint64) (io.WriteCloser, error)
+}
+
+// Suspender is an optional interface that may be implemented by the sinks
+// returned from Sinker.Sink. If a receiver is finalized while a file's
+// operation stream is still incomplete (i.e. the transmission was
+// interrupted), then it will invoke Suspend instead of Close on the file's
+// sink. Suspend should close the sink without committing its content, but
+// should retain the content written thus far so that it can be provided by
+// Resumer.Resume for a subsequent reception of the same file.
+type Suspender interface {
+	// Suspend closes the sink, retaining the content written thus far.
+	Suspend() error
+}
+
+// Resumer is an optional interface that may be implemented by a Sinker to
+// allow interrupted file receptions to be resumed. Since the content written
+// to a sink is the patched prefix of the target, it can serve as the base for a
+// subsequent reception of the same file, allowing the transmitter to send only
+// the remainder of the file (plus block operations covering the prefix).
+type Resumer interface {
+	// Resume returns the content retained by a suspended sink for the
+	// specified path and expected digest. If there is no such content, then it
+	// should return a nil result (and no error). The receiver is responsible
+	// for closing the result.
+	Resume(path string, digest []byte) (io.ReadSeekCloser, error)
 }
 
 // emptyReadSeekCloser is an implementation of io.ReadSeekCloser that is empty.
@@ -73,6 +126,8 @@ type receiver struct {
 	root string
 	// paths is the list of paths to receive.
 	paths []string
+	// digests is the list of expected digests for the paths.
+	digests [][]byte
 	// signatures is the list of signatures corresponding to the bases for these
 	// paths.
 	signatures []*Signature
@@ -97,15 +152,41 @@ type receiver struct {
 	// target is the destination for the current file. It should be non-nil if
 	// and only if base is non-nil. It should be nil if burning.
 	target io.WriteCloser
+	// decompressor is the decompressor for compressed operation data. It is
+	// lazily created when compressed operation data is first received.
+	decompressor *dataDecompressor
+	// decompressed is a re-usable operation for holding decompressed data.
+	decompressed *Operation
+	// verifyTrailers indicates whether or not trailer operations are required
+	// and verified against patched output.
+	verifyTrailers bool
+	// patchHasher digests the patched output for the current file. It is only
+	// used if verifyTrailers is true.
+	patchHasher hash.Hash
+	// patchTarget is the destination for patched output for the current file.
+	// It is non-nil if and only if target is non-nil.
+	patchTarget io.Writer
+	// trailerVerified indicates whether or not a trailer operation has been
+	// verified for the current file.
+	trailerVerified bool
 }
 
-// NewReceiver creates a new receiver that stores files on disk. It is the
+// NewReceiver creates a new receiver that stores files on disk. Each received
+// file is verified (by the sinker) against its expected digest. It is the
 // responsibility of the caller to ensure that the provided signatures are valid
 // by invoking their EnsureValid method. In order for the receiver to perform
 // efficiently, paths should be passed in depth-first traversal order.
-func NewReceiver(root string, paths []string, signatures []*Signature, sinker Sinker) (Receiver, error) {
+//
+// If the sinker implements Resumer, then any content that it has retained for a
+// path is used as that path's base (in place of the file within the root), in
+// which case the corresponding signature must have been computed from that
+// content. If the receiver is finalized during a file's reception, then the
+// file's sink is suspended if it implements Suspender.
+func NewReceiver(root string, paths []string, digests [][]byte, signatures []*Signature, sinker Sinker) (Receiver, error) {
 	// Ensure that the receiving request is sane.
-	if len(paths) != len(signatures) {
+	if len(paths) != len(digests) {
+		return nil, errors.New("number of paths does not match number of digests")
+	} else if len(paths) != len(signatures) {
 		return nil, errors.New("number of paths does not match number of signatures")
 	}
 
@@ -113,6 +194,7 @@ func NewReceiver(root string, paths []string, signatures []*Signature, sinker Si
 	return &receiver{
 		root:       root,
 		paths:      paths,
+		digests:    digests,
 		signatures: signatures,
 		opener:     filesystem.NewOpener(root),
 		sinker:     sinker,
@@ -121,6 +203,67 @@ func NewReceiver(root string, paths []string, signatures []*Signature, sinker Si
 	}, nil
 }
 
+// NewReceiverWithTrailerVerification is a variant of NewReceiver that requires
+// each file's operation stream to end with a trailer operation (see
+// Engine.DeltifyWithTrailer) and verifies the trailer digest against the
+// patched output before the file's sink is closed. If verification fails, or
+// if a file's operation stream completes successfully without a trailer, then
+// reception fails with ErrPatchDigestMismatch. Because the sink has already
+// received the patched content at that point, sinks should continue to perform
+// their own verification against expected digests.
+func NewReceiverWithTrailerVerification(root string, paths []string, digests [][]byte, signatures []*Signature, sinker Sinker) (Receiver, error) {
+	// Create the underlying receiver.
+	result, err := NewReceiver(root, paths, digests, signatures, sinker)
+	if err != nil {
+		return nil, err
+	}
+
+	// Enable trailer verification.
+	r := result.(*receiver)
+	r.verifyTrailers = true
+	r.patchHasher = newTrailerHasher()
+
+	// Done.
+	return r, nil
+}
+
+// closeFile closes out the base and target for the current file.
+func (r *receiver) closeFile() {
+	r.base.Close()
+	r.base = nil
+	r.target.Close()
+	r.target = nil
+	r.patchTarget = nil
+}
+
+// suspendFile closes out the base and target for the current file, suspending
+// the target (if supported) rather than closing it. The base is closed first
+// since it may be the content retained by a previously suspended target.
+func (r *receiver) suspendFile() {
+	r.base.Close()
+	r.base = nil
+	if suspender, ok := r.target.(Suspender); ok {
+		suspender.Suspend()
+	} else {
+		r.target.Close()
+	}
+	r.target = nil
+	r.patchTarget = nil
+}
+
+// openBase opens the base for the specified path. If the sinker supports
+// resumption and has content retained for the path, then that content is used
+// as the base, otherwise the file at the path within the root is used.
+func (r *receiver) openBase(path string, digest []byte) (io.ReadSeekCloser, error) {
+	if resumer, ok := r.sinker.(Resumer); ok {
+		if base, err := resumer.Resume(path, digest); err == nil && base != nil {
+			return base, nil
+		}
+	}
+	base, _, err := r.opener.OpenFile(path)
+	return base, err
+}
+
 // Receive processes incoming messages by storing files to disk.
 func (r *receiver) Receive(transmission *Transmission) error {
 	// Check that we haven't been finalized.
@@ -146,17 +289,29 @@ func (r *receiver) Receive(transmission *Transmission) error {
 
 		// Close out base and target if they're open, because we're done with
 		// this file. If they're not open, and we're not burning, it means that
-		// we have an empty file. Since we won't have opened any sink for the
-		// file (no operations came in for it), open one quickly and close it.
-		// Since we're already at the end of the stream for this file, there's
-		// no need to start burning operations if this fails.
+		// we have an empty file (unless the transmitter reported an error, in
+		// which case it was unable to read the file at all). Since we won't
+		// have opened any sink for the file (no operations came in for it),
+		// open one quickly and close it. Since we're already at the end of the
+		// stream for this file, there's no need to start burning operations if
+		// this fails. In either case, closing the target verifies the
+		// reconstructed content against its expected digest, and content that
+		// fails verification is rejected by the sinker rather than becoming
+		// available for transitions.
+		//
+		// If trailer verification is enabled, then a successfully completed
+		// operation stream must have included a verified trailer, otherwise
+		// the stream may have been truncated in transport.
+		if r.verifyTrailers && !r.burning && transmission.Error == "" && !r.trailerVerified {
+			if r.base != nil {
+				r.closeFile()
+			}
+			return fmt.Errorf("missing trailer for %s: %w", r.paths[r.received], ErrPatchDigestMismatch)
+		}
 		if r.base != nil {
-			r.base.Close()
-			r.base = nil
-			r.target.Close()
-			r.target = nil
-		} else if !r.burning {
-			if target, _ := r.sinker.Sink(r.paths[r.received]); target != nil {
+			r.closeFile()
+		} else if !r.burning && transmission.Error == "" {
+			if target, _ := r.sinker.Sink(r.paths[r.received], r.digests[r.received], 0); target != nil {
 				target.Close()
 			}
 		}
@@ -192,16 +347,17 @@ func (r *receiver) Receive(transmission *Transmission) error {
 		// terminal error.
 		if signature.isEmpty() {
 			r.base = newEmptyReadSeekCloser()
-		} else if base, _, err := r.opener.OpenFile(path); err != nil {
+		} else if base, err := r.openBase(path, r.digests[r.received]); err != nil {
 			r.burning = true
 			return nil
 		} else {
 			r.base = base
 		}
 
-		// Create a sink. If that fails, then we need to close out the base and
-		// burn this file stream, but it's not a terminal error.
-		if target, err := r.sinker.Sink(path); err != nil {
+		// Create a sink. The first transmission for a file carries its expected
+		// size. If that fails, then we need to close out the base and burn this
+		// file stream, but it's not a terminal error.
+		if target, err := r.sinker.Sink(path, r.digests[r.received], transmission.ExpectedSize); err != nil {
 			r.base.Close()
 			r.base = nil
 			r.burning = true
@@ -209,15 +365,50 @@ func (r *receiver) Receive(transmission *Transmission) error {
 		} else {
 			r.target = target
 		}
+
+		// Set up the patch destination, digesting patched output if trailer
+		// verification is enabled.
+		r.patchTarget = r.target
+		if r.verifyTrailers {
+			r.patchHasher.Reset()
+			r.patchTarget = io.MultiWriter(r.target, r.patchHasher)
+			r.trailerVerified = false
+		}
+	}
+
+	// If this is a trailer operation, then verify it if required. Trailers
+	// don't contribute content, so there's nothing to patch.
+	if transmission.Operation.isTrailer() {
+		if r.verifyTrailers {
+			if !bytes.Equal(r.patchHasher.Sum(nil), transmission.Operation.Digest) {
+				path := r.paths[r.received]
+				r.closeFile()
+				return fmt.Errorf("unable to verify %s: %w", path, ErrPatchDigestMismatch)
+			}
+			r.trailerVerified = true
+		}
+		return nil
+	}
+
+	// Decompress the operation data, if necessary. If that fails, then we need
+	// to close out the base, target, and burn this file stream, but it's not a
+	// terminal error.
+	operation := transmission.Operation
+	if !transmission.Compression.IsDefault() {
+		if data, err := r.decompress(transmission.Compression, operation.Data); err != nil {
+			r.closeFile()
+			r.burning = true
+			return nil
+		} else {
+			r.decompressed.Data = data
+			operation = r.decompressed
+		}
 	}
 
 	// Apply the operation. If that fails, then we need to close out the base,
 	// target, and burn this file stream, but it's not a terminal error.
-	if err := r.engine.Patch(r.target, r.base, signature, transmission.Operation); err != nil {
-		r.base.Close()
-		r.base = nil
-		r.target.Close()
-		r.target = nil
+	if err := r.engine.Patch(r.patchTarget, r.base, signature, operation); err != nil {
+		r.closeFile()
 		r.burning = true
 		return nil
 	}
@@ -226,6 +417,33 @@ func (r *receiver) Receive(transmission *Transmission) error {
 	return nil
 }
 
+// decompress decompresses operation data using the specified algorithm.
+func (r *receiver) decompress(algorithm compression.Algorithm, data []byte) ([]byte, error) {
+	// Verify that the algorithm is supported.
+	if !supportsDataCompression(algorithm) {
+		return nil, errors.New("unsupported compression algorithm")
+	}
+
+	// Create or replace the decompressor if necessary.
+	if r.decompressor == nil || r.decompressor.algorithm != algorithm {
+		if r.decompressor != nil {
+			r.decompressor.close()
+		}
+		r.decompressor = &dataDecompressor{algorithm: algorithm}
+		r.decompressed = &Operation{}
+	}
+
+	// Perform decompression.
+	return r.decompressor.decompress(data)
+}
+
+// SupportsDecompression indicates whether or not the receiver can decompress
+// operation data compressed with the specified algorithm, which is the case
+// for any supported algorithm.
+func (r *receiver) SupportsDecompression(algorithm compression.Algorithm) bool {
+	return supportsDataCompression(algorithm)
+}
+
 // finalize aborts reception (if still in-progress) closes any open receiver
 // resources.
 func (r *receiver) finalize() error {
@@ -234,12 +452,17 @@ func (r *receiver) finalize() error {
 		return errors.New("receiver finalized multiple times")
 	}
 
-	// Close any open internal resources.
+	// Close any open internal resources. If a file's reception is still in
+	// progress, then the transmission was interrupted, so suspend its target
+	// to allow reception to be resumed later.
 	if r.base != nil {
-		r.base.Close()
-		r.base = nil
-		r.target.Close()
-		r.target = nil
+		r.suspendFile()
+	}
+
+	// Release any decompression resources.
+	if r.decompressor != nil {
+		r.decompressor.close()
+		r.decompressor = nil
 	}
 
 	// Close the file opener.
@@ -252,12 +475,216 @@ func (r *receiver) finalize() error {
 	return nil
 }
 
+// bufferReceiver is a Receiver implementation that reconstructs files in
+// memory.
+type bufferReceiver struct {
+	// paths is the list of paths to receive.
+	paths []string
+	// bases is the list of base contents for the paths.
+	bases [][]byte
+	// signatures is the list of signatures corresponding to the bases for these
+	// paths.
+	signatures []*Signature
+	// engine is the rsync Engine.
+	engine *Engine
+	// received is the number of files received.
+	received uint64
+	// total is the total number of files to receive (the number of paths).
+	total uint64
+	// finalized indicates whether or not the receiver has been finalized.
+	finalized bool
+	// base is the base for the current file. It should be non-nil if and only
+	// if target is non-nil.
+	base *bytes.Reader
+	// target is the buffer for the current file. It should be non-nil if and
+	// only if base is non-nil.
+	target *bytes.Buffer
+	// decompressor is the decompressor for compressed operation data. It is
+	// lazily created when compressed operation data is first received.
+	decompressor *dataDecompressor
+	// decompressed is a re-usable operation for holding decompressed data.
+	decompressed *Operation
+	// results maps paths to their fully reconstructed contents.
+	results map[string][]byte
+}
+
+// NewBufferReceiver creates a new receiver that reconstructs files in memory
+// (using in-memory bases) rather than storing them on disk. It is primarily
+// intended for exercising transmission flows in tests. Unlike the receiver
+// created by NewReceiver, failures to apply operations for a file aren't
+// tolerated and are instead returned from Receive. Files for which the
+// transmitter reported an error aren't included in the results. The returned
+// function provides access to the reconstructed files and should be called
+// once transmission has completed. It is the responsibility of the caller to
+// ensure that the provided signatures are valid by invoking their EnsureValid
+// method.
+func NewBufferReceiver(paths []string, bases [][]byte, signatures []*Signature) (Receiver, func() map[string][]byte, error) {
+	// Ensure that the receiving request is sane.
+	if len(paths) != len(bases) {
+		return nil, nil, errors.New("number of paths does not match number of bases")
+	} else if len(paths) != len(signatures) {
+		return nil, nil, errors.New("number of paths does not match number of signatures")
+	}
+
+	// Create the receiver.
+	receiver := &bufferReceiver{
+		paths:      paths,
+		bases:      bases,
+		signatures: signatures,
+		engine:     NewEngine(),
+		total:      uint64(len(paths)),
+		results:    make(map[string][]byte, len(paths)),
+	}
+
+	// Done.
+	return receiver, func() map[string][]byte { return receiver.results }, nil
+}
+
+// Receive processes incoming messages by reconstructing files in memory.
+func (r *bufferReceiver) Receive(transmission *Transmission) error {
+	// Check that we haven't been finalized.
+	if r.finalized {
+		panic("receive called on finalized receiver")
+	}
+
+	// Make sure that we're not seeing a transmission after receiving all files.
+	// If we are, it's a terminal error.
+	if r.received == r.total {
+		return errors.New("unexpected file transmission")
+	}
+
+	// Extract the path.
+	path := r.paths[r.received]
+
+	// Handle done transmissions. If the transmitter reported an error, then we
+	// discard any partially reconstructed content. Otherwise we record the
+	// result, which will be empty if no operations were received.
+	if transmission.Done {
+		if transmission.Error == "" {
+			if r.target != nil {
+				r.results[path] = r.target.Bytes()
+			} else {
+				r.results[path] = []byte{}
+			}
+		}
+		r.base = nil
+		r.target = nil
+		r.received++
+		return nil
+	}
+
+	// Extract the signature for this file.
+	signature := r.signatures[r.received]
+
+	// Check if we are starting a new file stream and need to create the base
+	// and target.
+	if r.base == nil {
+		r.base = bytes.NewReader(r.bases[r.received])
+		r.target = &bytes.Buffer{}
+	}
+
+	// Decompress the operation data, if necessary.
+	operation := transmission.Operation
+	if !transmission.Compression.IsDefault() {
+		if data, err := r.decompress(transmission.Compression, operation.Data); err != nil {
+			return fmt.Errorf("unable to decompress operation data for %s: %w", path, err)
+		} else {
+			r.decompressed.Data = data
+			operation = r.decompressed
+		}
+	}
+
+	// Apply the operation.
+	if err := r.engine.Patch(r.target, r.base, signature, operation); err != nil {
+		return fmt.Errorf("unable to patch %s: %w", path, err)
+	}
+
+	// Success.
+	return nil
+}
+
+// decompress decompresses operation data using the specified algorithm.
+func (r *bufferReceiver) decompress(algorithm compression.Algorithm, data []byte) ([]byte, error) {
+	// Verify that the algorithm is supported.
+	if !supportsDataCompression(algorithm) {
+		return nil, errors.New("unsupported compression algorithm")
+	}
+
+	// Create or replace the decompressor if necessary.
+	if r.decompressor == nil || r.decompressor.algorithm != algorithm {
+		if r.decompressor != nil {
+			r.decompressor.close()
+		}
+		r.decompressor = &dataDecompressor{algorithm: algorithm}
+		r.decompressed = &Operation{}
+	}
+
+	// Perform decompression.
+	return r.decompressor.decompress(data)
+}
+
+// SupportsDecompression indicates whether or not the receiver can decompress
+// operation data compressed with the specified algorithm, which is the case
+// for any supported algorithm.
+func (r *bufferReceiver) SupportsDecompression(algorithm compression.Algorithm) bool {
+	return supportsDataCompression(algorithm)
+}
+
+// finalize aborts reception (if still in-progress) and releases any receiver
+// resources.
+func (r *bufferReceiver) finalize() error {
+	// Watch for double finalization.
+	if r.finalized {
+		return errors.New("receiver finalized multiple times")
+	}
+
+	// Discard any partially reconstructed content.
+	r.base = nil
+	r.target = nil
+
+	// Release any decompression resources.
+	if r.decompressor != nil {
+		r.decompressor.close()
+		r.decompressor = nil
+	}
+
+	// Mark the receiver as finalized.
+	r.finalized = true
+
+	// Success.
+	return nil
+}
+
 // Monitor is the interface that monitors must implement to capture state
 // information from a monitoring receiver. The state object provided to this
 // function must not be modified or retained. When the monitoring receiver is
 // finalized, the Monitor callback will receive a nil state value.
 type Monitor func(*ReceiverState) error
 
+const (
+	// rateWindowDuration is the duration of the sliding window over which
+	// monitoring receivers compute receive rates.
+	rateWindowDuration = 5 * time.Second
+	// minimumRateWindowDuration is the minimum span of progress samples
+	// required before monitoring receivers will report a receive rate. This
+	// avoids reporting wildly inaccurate rates at the start of a file or when
+	// a single transmission (e.g. a large block operation for a sparse file)
+	// covers a large number of bytes in a very short time.
+	minimumRateWindowDuration = 250 * time.Millisecond
+	// maximumRateSamples is the maximum number of progress samples retained
+	// by monitoring receivers for rate computation.
+	maximumRateSamples = 64
+)
+
+// rateSample is a progress sample used for receive rate computation.
+type rateSample struct {
+	// time is the time at which the sample was recorded.
+	time time.Time
+	// receivedSize is the number of bytes received for the current path at the
+	// time of the sample.
+	receivedSize uint64
+}
+
 // monitoringReceiver is a Receiver implementation that can invoke a callback
 // with information about the status of transmission.
 type monitoringReceiver struct {
@@ -274,6 +701,10 @@ type monitoringReceiver struct {
 	startOfFile bool
 	// state is the current receiver state.
 	state *ReceiverState
+	// now is the clock used for rate computation.
+	now func() time.Time
+	// samples are the progress samples for the current path, ordered by time.
+	samples []rateSample
 }
 
 // NewMonitoringReceiver wraps a receiver and provides monitoring information
@@ -294,6 +725,48 @@ func NewMonitoringReceiver(receiver Receiver, paths []string, signatures []*Sign
 		state: &ReceiverState{
 			ExpectedFiles: uint64(len(paths)),
 		},
+		now: time.Now,
+	}
+}
+
+// updateRate records a progress sample for the current path and updates the
+// rate and time remaining estimates in the receiver state.
+func (r *monitoringReceiver) updateRate() {
+	// Record the sample and discard samples that have fallen outside of the
+	// window. We always retain at least one sample prior to the newest sample
+	// so that a rate can be computed across a sparse update interval. The
+	// sample window is seeded with an initial sample at the start of each file.
+	now := r.now()
+	r.samples = append(r.samples, rateSample{now, r.state.ReceivedSize})
+	var expired int
+	for expired < len(r.samples)-2 && now.Sub(r.samples[expired+1].time) >= rateWindowDuration {
+		expired++
+	}
+	if excess := len(r.samples) - expired - maximumRateSamples; excess > 0 {
+		expired += excess
+	}
+	if expired > 0 {
+		r.samples = append(r.samples[:0], r.samples[expired:]...)
+	}
+
+	// Compute the rate over the window, if possible.
+	oldest := r.samples[0]
+	elapsed := now.Sub(oldest.time)
+	if elapsed < minimumRateWindowDuration || r.state.ReceivedSize <= oldest.receivedSize {
+		r.state.BytesPerSecond = 0
+		r.state.EstimatedRemaining = nil
+		return
+	}
+	rate := float64(r.state.ReceivedSize-oldest.receivedSize) / elapsed.Seconds()
+	r.state.BytesPerSecond = uint64(rate)
+
+	// Compute the estimated time remaining. The expected size is only an
+	// estimate, so if we've exceeded it, then we don't provide an estimate.
+	if r.state.ExpectedSize > r.state.ReceivedSize {
+		remaining := float64(r.state.ExpectedSize-r.state.ReceivedSize) / rate
+		r.state.EstimatedRemaining = durationpb.New(time.Duration(remaining * float64(time.Second)))
+	} else {
+		r.state.EstimatedRemaining = nil
 	}
 }
 
@@ -312,16 +785,21 @@ func (r *monitoringReceiver) Receive(transmission *Transmission) error {
 	}
 
 	// If we're at the start of a new file, then compute the path and reset the
-	// per-file statistics.
+	// per-file statistics, including the rate window.
 	if r.startOfFile {
 		r.state.Path = r.paths[r.state.ReceivedFiles]
 		r.state.ReceivedSize = 0
 		r.state.ExpectedSize = transmission.ExpectedSize
+		r.state.BytesPerSecond = 0
+		r.state.EstimatedRemaining = nil
+		r.samples = append(r.samples[:0], rateSample{r.now(), 0})
 	}
 
-	// Compute the amount of data contained in this transmission.
+	// Compute the amount of data contained in this transmission. For
+	// compressed operation data, this is the compressed (i.e. transmitted)
+	// size.
 	var dataSize uint64
-	if !transmission.Done {
+	if !transmission.Done && !transmission.Operation.isTrailer() {
 		if d := len(transmission.Operation.Data); d > 0 {
 			dataSize = uint64(d)
 		} else {
@@ -339,6 +817,13 @@ func (r *monitoringReceiver) Receive(transmission *Transmission) error {
 	r.state.ReceivedSize += dataSize
 	r.state.TotalReceivedSize += dataSize
 
+	// Update rate statistics. We don't record samples for end-of-file
+	// transmissions, since they don't carry data and we reset the window at
+	// the start of the next file anyway.
+	if !transmission.Done {
+		r.updateRate()
+	}
+
 	// Provide the updated state to the monitor if relevant.
 	if !transmission.Done || r.startOfFile {
 		if err := r.monitor(r.state); err != nil {
@@ -359,6 +844,11 @@ func (r *monitoringReceiver) Receive(transmission *Transmission) error {
 	return nil
 }
 
+// SupportsDecompression forwards the query to the underlying receiver.
+func (r *monitoringReceiver) SupportsDecompression(algorithm compression.Algorithm) bool {
+	return r.receiver.SupportsDecompression(algorithm)
+}
+
 // finalize invokes finalize on the underlying receiver. It also performs a
 // final empty status update, though it doesn't check for an error when doing
 // so.
@@ -371,6 +861,186 @@ func (r *monitoringReceiver) finalize() error {
 	return r.receiver.finalize()
 }
 
+// pipelinedTransmissionOverhead is the nominal size (in bytes) attributed to
+// each transmission queued by a pipelined receiver, in addition to the size of
+// its operation data. It ensures that a pipelined receiver's queue length is
+// bounded even for transmissions that don't carry data.
+const pipelinedTransmissionOverhead = 64
+
+// pipelinedReceiver is a Receiver implementation that forwards transmissions to
+// an underlying receiver from a separate Goroutine, allowing the transmitter to
+// continue deltification while the underlying receiver is busy. The total size
+// of transmissions that have been queued but not yet processed by the
+// underlying receiver is bounded, with Receive blocking until space becomes
+// available.
+type pipelinedReceiver struct {
+	// receiver is the underlying receiver.
+	receiver Receiver
+	// maximumInFlightBytes is the maximum total size of in-flight
+	// transmissions.
+	maximumInFlightBytes uint64
+	// lock serializes access to the fields below.
+	lock sync.Mutex
+	// changed is signaled when the queue, inFlightBytes, closed, or err fields
+	// change.
+	changed *sync.Cond
+	// queue is the queue of transmissions awaiting forwarding.
+	queue []*Transmission
+	// inFlightBytes is the total size of queued transmissions and the
+	// transmission (if any) currently being processed by the underlying
+	// receiver.
+	inFlightBytes uint64
+	// closed indicates whether or not the queue has been closed.
+	closed bool
+	// err is the first error returned by the underlying receiver.
+	err error
+	// done is closed when the forwarding Goroutine exits.
+	done chan struct{}
+	// finalized indicates whether or not the receiver has been finalized.
+	finalized bool
+}
+
+// newPipelinedReceiver creates a new pipelined receiver that forwards to the
+// specified receiver, allowing at most maximumInFlightBytes of transmissions to
+// be in-flight at any given time. A transmission larger than this limit is
+// still admitted if nothing else is in-flight, which guarantees progress.
+func newPipelinedReceiver(receiver Receiver, maximumInFlightBytes uint64) *pipelinedReceiver {
+	// Create the receiver.
+	result := &pipelinedReceiver{
+		receiver:             receiver,
+		maximumInFlightBytes: maximumInFlightBytes,
+		done:                 make(chan struct{}),
+	}
+	result.changed = sync.NewCond(&result.lock)
+
+	// Start forwarding.
+	go result.forward()
+
+	// Done.
+	return result
+}
+
+// pipelinedTransmissionSize computes the size attributed to a transmission
+// for the purposes of in-flight tracking.
+func pipelinedTransmissionSize(transmission *Transmission) uint64 {
+	return pipelinedTransmissionOverhead + uint64(len(transmission.Operation.GetData()))
+}
+
+// forward forwards queued transmissions to the underlying receiver until the
+// queue is closed and drained or the underlying receiver fails.
+func (r *pipelinedReceiver) forward() {
+	// Signal completion when we're done.
+	defer close(r.done)
+
+	// Loop and forward transmissions.
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	for {
+		// Wait for a transmission or closure.
+		for len(r.queue) == 0 && !r.closed {
+			r.changed.Wait()
+		}
+		if len(r.queue) == 0 {
+			return
+		}
+
+		// Dequeue the transmission and forward it without holding the lock.
+		transmission := r.queue[0]
+		r.queue[0] = nil
+		r.queue = r.queue[1:]
+		r.lock.Unlock()
+		err := r.receiver.Receive(transmission)
+		r.lock.Lock()
+
+		// Release the transmission's in-flight allocation and handle errors.
+		// If the underlying receiver has failed, then we discard anything
+		// that's still queued.
+		r.inFlightBytes -= pipelinedTransmissionSize(transmission)
+		if err != nil {
+			r.err = err
+			r.queue = nil
+			r.inFlightBytes = 0
+			r.changed.Broadcast()
+			return
+		}
+		r.changed.Broadcast()
+	}
+}
+
+// Receive queues a copy of the transmission for forwarding, blocking until the
+// transmission fits within the in-flight limit. Errors from the underlying
+// receiver are reported by subsequent calls to Receive (and by finalize).
+func (r *pipelinedReceiver) Receive(transmission *Transmission) error {
+	// Copy the transmission, since the transmitter may re-use it (and its
+	// operation data buffer).
+	copied := &Transmission{
+		ExpectedSize: transmission.ExpectedSize,
+		Done:         transmission.Done,
+		Error:        transmission.Error,
+		Compression:  transmission.Compression,
+	}
+	if operation := transmission.Operation; operation != nil {
+		copied.Operation = &Operation{
+			Data:   bytes.Clone(operation.Data),
+			Start:  operation.Start,
+			Count:  operation.Count,
+			Digest: bytes.Clone(operation.Digest),
+		}
+	}
+	size := pipelinedTransmissionSize(copied)
+
+	// Wait for the transmission to fit within the in-flight limit.
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	for r.err == nil && r.inFlightBytes > 0 && r.inFlightBytes+size > r.maximumInFlightBytes {
+		r.changed.Wait()
+	}
+	if r.err != nil {
+		return r.err
+	}
+
+	// Queue the transmission.
+	r.queue = append(r.queue, copied)
+	r.inFlightBytes += size
+	r.changed.Broadcast()
+
+	// Success.
+	return nil
+}
+
+// SupportsDecompression forwards the query to the underlying receiver.
+func (r *pipelinedReceiver) SupportsDecompression(algorithm compression.Algorithm) bool {
+	return r.receiver.SupportsDecompression(algorithm)
+}
+
+// finalize waits for all queued transmissions to be forwarded and then invokes
+// finalize on the underlying receiver. If the underlying receiver failed to
+// receive a transmission, then that error is returned.
+func (r *pipelinedReceiver) finalize() error {
+	// Watch for double finalization.
+	if r.finalized {
+		return errors.New("receiver finalized multiple times")
+	}
+	r.finalized = true
+
+	// Close the queue and wait for forwarding to complete.
+	r.lock.Lock()
+	r.closed = true
+	r.changed.Broadcast()
+	r.lock.Unlock()
+	<-r.done
+
+	// Finalize the underlying receiver.
+	finalizeErr := r.receiver.finalize()
+
+	// Report any forwarding error first, since it will have been the original
+	// cause of failure.
+	if r.err != nil {
+		return r.err
+	}
+	return finalizeErr
+}
+
 // preemptableReceiver is a Receiver implementation that provides preemption
 // facilities.
 type preemptableReceiver struct {
@@ -404,6 +1074,11 @@ func (r *preemptableReceiver) Receive(transmission *Transmission) error {
 	return r.receiver.Receive(transmission)
 }
 
+// SupportsDecompression forwards the query to the underlying receiver.
+func (r *preemptableReceiver) SupportsDecompression(algorithm compression.Algorithm) bool {
+	return r.receiver.SupportsDecompression(algorithm)
+}
+
 // finalize invokes finalize on the underlying receiver.
 func (r *preemptableReceiver) finalize() error {
 	return r.receiver.finalize()
@@ -428,6 +1103,10 @@ type Encoder interface {
 type encodingReceiver struct {
 	// encoder is the Encoder to use for encoding messages.
 	encoder Encoder
+	// decompression is the compression algorithm that the decoding receiver
+	// is known to support for operation data. It is the default value if no
+	// compression is supported.
+	decompression compression.Algorithm
 	// finalized indicates whether or not the receiver has been finalized.
 	finalized bool
 }
@@ -441,6 +1120,18 @@ func NewEncodingReceiver(encoder Encoder) Receiver {
 	}
 }
 
+// NewDecompressingEncodingReceiver is a variant of NewEncodingReceiver for
+// cases where the receiver to which DecodeToReceiver will forward messages is
+// known to support decompression of operation data with the specified
+// algorithm (e.g. because it was negotiated with a remote endpoint). It allows
+// transmitters to compress operation data using that algorithm.
+func NewDecompressingEncodingReceiver(encoder Encoder, decompression compression.Algorithm) Receiver {
+	return &encodingReceiver{
+		encoder:       encoder,
+		decompression: decompression,
+	}
+}
+
 // Receive encodes the specified transmission using the underlying encoder.
 func (r *encodingReceiver) Receive(transmission *Transmission) error {
 	// Encode the transmission.
@@ -452,6 +1143,13 @@ func (r *encodingReceiver) Receive(transmission *Transmission) error {
 	return nil
 }
 
+// SupportsDecompression indicates whether or not the receiver to which messages
+// will be forwarded is known to support decompression with the specified
+// algorithm.
+func (r *encodingReceiver) SupportsDecompression(algorithm compression.Algorithm) bool {
+	return !algorithm.IsDefault() && algorithm == r.decompression
+}
+
 // finalize finalizes the encoding receiver, which means that it calls Finalize
 // on its underlying Encoder.
 func (r *encodingReceiver) finalize() error {
@@ -1,3 +1,189 @@
 package rsync
 
-// TODO: Implement.
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"google.golang.org/protobuf/proto"
+
+	"github.com/mutagen-io/mutagen/pkg/synchronization/compression"
+)
+
+// TestBufferReceiverInvalidLengths tests that NewBufferReceiver rejects
+// mismatched path, base, and signature counts.
+func TestBufferReceiverInvalidLengths(t *testing.T) {
+	if _, _, err := NewBufferReceiver([]string{"file"}, nil, []*Signature{{}}); err == nil {
+		t.Error("buffer receiver created with mismatched base count")
+	}
+	if _, _, err := NewBufferReceiver([]string{"file"}, [][]byte{nil}, nil); err == nil {
+		t.Error("buffer receiver created with mismatched signature count")
+	}
+}
+
+// TestBufferReceiverTransmit tests that files transmitted to a buffer receiver
+// are reconstructed in memory, with and without operation data compression.
+func TestBufferReceiverTransmit(t *testing.T) {
+	// Create a source root and bases that share content with the files in it.
+	root := t.TempDir()
+	paths := []string{"absent", "empty", "modified", "new"}
+	contents := map[string][]byte{
+		"empty":    nil,
+		"modified": []byte(strings.Repeat("shared content ", 4096) + "appended content"),
+		"new":      []byte("new content"),
+	}
+	for path, content := range contents {
+		if err := os.WriteFile(filepath.Join(root, path), content, 0600); err != nil {
+			t.Fatal("unable to create test file:", err)
+		}
+	}
+	bases := [][]byte{nil, nil, []byte(strings.Repeat("shared content ", 4096)), nil}
+	engine := NewEngine()
+	signatures := make([]*Signature, len(bases))
+	for b, base := range bases {
+		signatures[b] = engine.BytesSignature(base, 0)
+	}
+
+	// Perform transmission with and without compression.
+	for _, algorithm := range []compression.Algorithm{
+		compression.Algorithm_AlgorithmNone,
+		compression.Algorithm_AlgorithmDeflate,
+	} {
+		receiver, results, err := NewBufferReceiver(paths, bases, signatures)
+		if err != nil {
+			t.Fatal("unable to create buffer receiver:", err)
+		}
+		if err := TransmitWithCompression(root, paths, signatures, receiver, 0, nil, algorithm); err != nil {
+			t.Fatalf("%s: transmission failed: %v", algorithm.Description(), err)
+		}
+
+		// Verify the results. The absent file can't be read by the transmitter
+		// and shouldn't be included.
+		received := results()
+		if _, ok := received["absent"]; ok {
+			t.Errorf("%s: absent file received", algorithm.Description())
+		}
+		for path, content := range contents {
+			if result, ok := received[path]; !ok {
+				t.Errorf("%s: %s not received", algorithm.Description(), path)
+			} else if !bytes.Equal(result, content) {
+				t.Errorf("%s: %s received content does not match original", algorithm.Description(), path)
+			}
+		}
+	}
+}
+
+// TestBufferReceiverPatchError tests that a buffer receiver surfaces failures
+// to apply operations.
+func TestBufferReceiverPatchError(t *testing.T) {
+	// Create a receiver whose signature references blocks absent from the base.
+	signature := NewEngine().BytesSignature([]byte("base content"), 0)
+	receiver, _, err := NewBufferReceiver([]string{"file"}, [][]byte{nil}, []*Signature{signature})
+	if err != nil {
+		t.Fatal("unable to create buffer receiver:", err)
+	}
+	defer receiver.finalize()
+
+	// Attempt to apply a block operation.
+	err = receiver.Receive(&Transmission{Operation: &Operation{Start: 0, Count: 1}})
+	if err == nil {
+		t.Fatal("patch failure not reported")
+	} else if !strings.Contains(err.Error(), "file") {
+		t.Error("patch failure does not identify path:", err)
+	}
+}
+
+// TestMonitoringReceiverRate tests that a monitoring receiver computes receive
+// rates and time remaining estimates, that it resets its rate window between
+// files, and that it doesn't report rates for large jumps in received size
+// over very short intervals (e.g. block operations for sparse files).
+func TestMonitoringReceiverRate(t *testing.T) {
+	// Create a base for the second file and compute its signature.
+	base := make([]byte, 1<<20)
+	engine := NewEngine()
+	signature := engine.BytesSignature(base, 0)
+
+	// Create a monitoring receiver with a controllable clock that records the
+	// states that it reports.
+	paths := []string{"first", "second"}
+	signatures := []*Signature{{}, signature}
+	underlying, _, err := NewBufferReceiver(paths, [][]byte{nil, base}, signatures)
+	if err != nil {
+		t.Fatal("unable to create buffer receiver:", err)
+	}
+	var states []*ReceiverState
+	monitor := func(state *ReceiverState) error {
+		if state != nil {
+			states = append(states, proto.Clone(state).(*ReceiverState))
+		}
+		return nil
+	}
+	receiver := NewMonitoringReceiver(underlying, paths, signatures, monitor)
+	now := time.Unix(0, 0)
+	receiver.(*monitoringReceiver).now = func() time.Time { return now }
+
+	// Transmit the first file as a sequence of data operations received at
+	// regular intervals.
+	data := make([]byte, 1000)
+	for i := 0; i < 10; i++ {
+		transmission := &Transmission{ExpectedSize: 10000, Operation: &Operation{Data: data}}
+		if err := receiver.Receive(transmission); err != nil {
+			t.Fatal("unable to receive transmission:", err)
+		}
+		if i < 9 {
+			now = now.Add(100 * time.Millisecond)
+		}
+	}
+	if err := receiver.Receive(&Transmission{Done: true}); err != nil {
+		t.Fatal("unable to receive transmission:", err)
+	}
+
+	// Verify that no rate was reported before the minimum window duration had
+	// elapsed and that a rate and estimate were reported afterwards. At the
+	// fifth operation, 5000 bytes have been received in 400 milliseconds.
+	for i := 0; i < 3; i++ {
+		if states[i].BytesPerSecond != 0 || states[i].EstimatedRemaining != nil {
+			t.Errorf("rate reported at operation %d before minimum window duration", i)
+		}
+	}
+	if states[4].BytesPerSecond != 12500 {
+		t.Error("rate does not match expected:", states[4].BytesPerSecond, "!=", 12500)
+	}
+	if remaining := states[4].EstimatedRemaining.AsDuration(); remaining < 399*time.Millisecond || remaining > 401*time.Millisecond {
+		t.Error("estimated time remaining does not match expected:", remaining)
+	}
+	if states[9].EstimatedRemaining != nil {
+		t.Error("estimated time remaining reported for completed file")
+	}
+
+	// Transmit the second file, starting with a block operation that covers
+	// the entire base and followed by a data operation received later.
+	now = now.Add(time.Second)
+	transmissions := []*Transmission{
+		{ExpectedSize: uint64(len(base)) + 1000, Operation: &Operation{Count: uint64(len(signature.Hashes))}},
+		{ExpectedSize: uint64(len(base)) + 1000, Operation: &Operation{Data: data}},
+		{Done: true},
+	}
+	for i, transmission := range transmissions {
+		if i == 1 {
+			now = now.Add(time.Second)
+		}
+		if err := receiver.Receive(transmission); err != nil {
+			t.Fatal("unable to receive transmission:", err)
+		}
+	}
+
+	// Verify that the rate window was reset at the start of the second file and
+	// that the large jump in received size wasn't reported as a rate.
+	if states[10].Path != "second" {
+		t.Fatal("state path does not match expected:", states[10].Path)
+	} else if states[10].BytesPerSecond != 0 || states[10].EstimatedRemaining != nil {
+		t.Error("rate reported immediately after large block operation")
+	}
+	if expected := uint64(len(base)) + 1000; states[11].BytesPerSecond != expected {
+		t.Error("rate does not match expected:", states[11].BytesPerSecond, "!=", expected)
+	}
+}
@@ -2,6 +2,8 @@ package rsync
 
 import (
 	"errors"
+
+	"github.com/mutagen-io/mutagen/pkg/synchronization/compression"
 )
 
 // resetToZeroMaintainingCapacity resets a Transmission to its zero value, with
@@ -23,6 +25,9 @@ func (t *Transmission) resetToZeroMaintainingCapacity() {
 
 	// Reset the error parameter.
 	t.Error = ""
+
+	// Reset the compression algorithm.
+	t.Compression = compression.Algorithm_AlgorithmDefault
 }
 
 // EnsureValid ensures that the Transmission's invariants are respected.
@@ -39,6 +44,8 @@ func (t *Transmission) EnsureValid() error {
 			return errors.New("non-zero expected file size at end of stream")
 		} else if t.Operation != nil && !t.Operation.isZeroValue() {
 			return errors.New("operation present at end of stream")
+		} else if !t.Compression.IsDefault() {
+			return errors.New("compression specified at end of stream")
 		}
 	} else {
 		if t.Operation == nil {
@@ -47,6 +54,8 @@ func (t *Transmission) EnsureValid() error {
 			return errors.New("invalid operation in stream")
 		} else if t.Error != "" {
 			return errors.New("error in middle of stream")
+		} else if !t.Compression.IsDefault() && len(t.Operation.Data) == 0 {
+			return errors.New("compression specified for non-data operation")
 		}
 	}
 
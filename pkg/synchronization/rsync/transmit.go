@@ -3,8 +3,12 @@ package rsync
 import (
 	"errors"
 	"fmt"
+	"io"
+	"time"
 
 	"github.com/mutagen-io/mutagen/pkg/filesystem"
+	"github.com/mutagen-io/mutagen/pkg/logging"
+	"github.com/mutagen-io/mutagen/pkg/synchronization/compression"
 )
 
 // Transmit performs streaming transmission of files (in rsync deltified form)
@@ -13,29 +17,133 @@ import (
 // In order for this function to perform efficiently, paths should be passed in
 // depth-first traversal order.
 func Transmit(root string, paths []string, signatures []*Signature, receiver Receiver) error {
+	return TransmitWithDeltificationTimeLimit(root, paths, signatures, receiver, 0, nil)
+}
+
+// TransmitWithDeltificationTimeLimit is a variant of Transmit that limits the
+// time spent computing the delta for each file (see
+// Engine.SetDeltificationTimeLimit). Files that exceed the limit are still
+// transmitted correctly, but the remainder of their content is sent as literal
+// data. Any such fallbacks are reported to the specified logger, which may be
+// nil.
+func TransmitWithDeltificationTimeLimit(
+	root string, paths []string, signatures []*Signature, receiver Receiver,
+	deltificationTimeLimit time.Duration, logger *logging.Logger,
+) error {
+	return TransmitWithCompression(
+		root, paths, signatures, receiver,
+		deltificationTimeLimit, logger,
+		compression.Algorithm_AlgorithmNone,
+	)
+}
+
+// TransmitWithCompression is a variant of TransmitWithDeltificationTimeLimit
+// that compresses operation data using the specified algorithm. Compression is
+// only performed if the receiver indicates support for decompressing data
+// compressed with the algorithm, otherwise operation data is transmitted
+// uncompressed. Each data operation is compressed independently, and its data
+// is only transmitted in compressed form if compression reduces its size. A
+// default or none algorithm disables compression.
+func TransmitWithCompression(
+	root string, paths []string, signatures []*Signature, receiver Receiver,
+	deltificationTimeLimit time.Duration, logger *logging.Logger,
+	algorithm compression.Algorithm,
+) error {
+	return TransmitFromRoots(
+		[]string{root}, paths, signatures, receiver,
+		deltificationTimeLimit, logger,
+		algorithm,
+	)
+}
+
+// TransmitFromRoots is a variant of TransmitWithCompression that sources files
+// from multiple roots. Each file is opened from the first root (in the order
+// specified) from which it can be opened. This is useful for transmitting from
+// layered content, such as an overlay.
+func TransmitFromRoots(
+	roots []string, paths []string, signatures []*Signature, receiver Receiver,
+	deltificationTimeLimit time.Duration, logger *logging.Logger,
+	algorithm compression.Algorithm,
+) error {
+	return TransmitWithInFlightLimit(
+		roots, paths, signatures, receiver,
+		deltificationTimeLimit, logger,
+		algorithm, 0,
+	)
+}
+
+// TransmitWithInFlightLimit is a variant of TransmitFromRoots that pipelines
+// deltification with reception. Operations are forwarded to the receiver from a
+// separate Goroutine, so deltification can proceed while the receiver is busy,
+// but the total size of operations (primarily their literal data) that have
+// been generated but not yet processed by the receiver is limited to
+// maximumInFlightBytes. Once that limit is reached, deltification blocks until
+// the receiver catches up, which bounds the memory used for buffering. A zero
+// limit disables pipelining, in which case each operation is forwarded to the
+// receiver synchronously.
+func TransmitWithInFlightLimit(
+	roots []string, paths []string, signatures []*Signature, receiver Receiver,
+	deltificationTimeLimit time.Duration, logger *logging.Logger,
+	algorithm compression.Algorithm, maximumInFlightBytes uint64,
+) error {
 	// Ensure that the transmission request is sane.
 	if len(paths) != len(signatures) {
 		receiver.finalize()
 		return errors.New("number of paths does not match number of signatures")
+	} else if len(roots) == 0 {
+		receiver.finalize()
+		return errors.New("no roots specified")
 	}
 
-	// Create a file opener that we can use to safely open files, and defer its
-	// closure.
-	opener := filesystem.NewOpener(root)
-	defer opener.Close()
+	// Enable pipelining if requested.
+	if maximumInFlightBytes > 0 {
+		receiver = newPipelinedReceiver(receiver, maximumInFlightBytes)
+	}
+
+	// Create file openers that we can use to safely open files, and defer
+	// their closure.
+	openers := make([]*filesystem.Opener, len(roots))
+	for r, root := range roots {
+		openers[r] = filesystem.NewOpener(root)
+		defer openers[r].Close()
+	}
 
 	// Create an rsync engine.
 	engine := NewEngine()
+	engine.SetDeltificationTimeLimit(deltificationTimeLimit)
 
 	// Create a transmission object that we can re-use to avoid allocating.
 	transmission := &Transmission{}
 
+	// Negotiate operation data compression with the receiver. If compression
+	// is in use, then create a compressor and an operation that we can re-use
+	// for transmitting compressed data.
+	var compressor *dataCompressor
+	var compressed *Operation
+	if !algorithm.IsDefault() && algorithm != compression.Algorithm_AlgorithmNone {
+		if supportsDataCompression(algorithm) && receiver.SupportsDecompression(algorithm) {
+			compressor = newDataCompressor(algorithm)
+			compressed = &Operation{}
+		} else {
+			logger.Debugf("Receiver doesn't support %s compression, transmitting uncompressed data",
+				algorithm.Description(),
+			)
+		}
+	}
+
 	// Handle the requested files.
 	for i, p := range paths {
 		// Open the file and extract its size. Failure here is non-terminal, but
 		// we need to inform the receiver. If sending the message fails, that is
 		// a terminal error.
-		file, metadata, err := opener.OpenFile(p)
+		var file io.ReadSeekCloser
+		var metadata *filesystem.Metadata
+		var err error
+		for _, opener := range openers {
+			if file, metadata, err = opener.OpenFile(p); err == nil {
+				break
+			}
+		}
 		if err != nil {
 			*transmission = Transmission{
 				Done:  true,
@@ -56,13 +164,24 @@ func Transmit(root string, paths []string, signatures []*Signature, receiver Rec
 		var transmitError error
 		transmit := func(o *Operation) error {
 			*transmission = Transmission{ExpectedSize: fileSize, Operation: o}
+			if compressor != nil && len(o.Data) > 0 {
+				if data, err := compressor.compress(o.Data); err == nil && len(data) < len(o.Data) {
+					compressed.Data = data
+					transmission.Operation = compressed
+					transmission.Compression = algorithm
+				}
+			}
 			transmitError = receiver.Receive(transmission)
 			fileSize = 0
 			return transmitError
 		}
 
-		// Perform deltification.
+		// Perform deltification and note if it fell back to literal data.
+		fallbacks := engine.DeltificationFallbacks()
 		err = engine.Deltify(file, signatures[i], 0, transmit)
+		if engine.DeltificationFallbacks() > fallbacks {
+			logger.Debugf("Deltification time limit exceeded for %s, transmitted remainder as literal data", p)
+		}
 
 		// Close the file.
 		file.Close()
@@ -1,3 +1,500 @@
 package rsync
 
-// TODO: Implement.
+import (
+	"bytes"
+	"errors"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"google.golang.org/protobuf/proto"
+
+	"github.com/mutagen-io/mutagen/pkg/synchronization/compression"
+)
+
+// testSinkerTarget is an io.WriteCloser that records its content in a test
+// sinker upon closure.
+type testSinkerTarget struct {
+	// Buffer stores the received content.
+	bytes.Buffer
+	// sinker is the parent sinker.
+	sinker *testSinker
+	// path is the path being received.
+	path string
+}
+
+// Close implements io.Closer.Close.
+func (t *testSinkerTarget) Close() error {
+	t.sinker.received[t.path] = t.Bytes()
+	return nil
+}
+
+// testSinker is a Sinker implementation that stores received files in memory.
+// It doesn't perform digest verification.
+type testSinker struct {
+	// received maps paths to their received content.
+	received map[string][]byte
+}
+
+// Sink implements Sinker.Sink.
+func (s *testSinker) Sink(path string, _ []byte, _ uint64) (io.WriteCloser, error) {
+	return &testSinkerTarget{sinker: s, path: path}, nil
+}
+
+// recordingEncoder is an Encoder implementation that records transmissions.
+type recordingEncoder struct {
+	// transmissions are the recorded transmissions.
+	transmissions []*Transmission
+}
+
+// Encode implements Encoder.Encode.
+func (e *recordingEncoder) Encode(transmission *Transmission) error {
+	e.transmissions = append(e.transmissions, proto.Clone(transmission).(*Transmission))
+	return nil
+}
+
+// Finalize implements Encoder.Finalize.
+func (e *recordingEncoder) Finalize() error {
+	return nil
+}
+
+// replayingDecoder is a Decoder implementation that replays transmissions.
+type replayingDecoder struct {
+	// transmissions are the transmissions to replay.
+	transmissions []*Transmission
+}
+
+// Decode implements Decoder.Decode.
+func (d *replayingDecoder) Decode(transmission *Transmission) error {
+	if len(d.transmissions) == 0 {
+		return io.EOF
+	}
+	proto.Merge(transmission, d.transmissions[0])
+	d.transmissions = d.transmissions[1:]
+	return nil
+}
+
+// Finalize implements Decoder.Finalize.
+func (d *replayingDecoder) Finalize() error {
+	return nil
+}
+
+// compressionTrackingReceiver is a Receiver implementation that wraps another
+// receiver and tracks whether or not compressed operation data is received.
+type compressionTrackingReceiver struct {
+	// Receiver is the underlying receiver.
+	Receiver
+	// compressed indicates whether or not compressed data has been received.
+	compressed bool
+}
+
+// Receive implements Receiver.Receive.
+func (r *compressionTrackingReceiver) Receive(transmission *Transmission) error {
+	if err := transmission.EnsureValid(); err != nil {
+		return err
+	}
+	r.compressed = r.compressed || !transmission.Compression.IsDefault()
+	return r.Receiver.Receive(transmission)
+}
+
+// TestTransmitWithCompression tests that operation data compression is only
+// used when supported by the receiver (including receivers on the other side
+// of an encoder, such as those on remote endpoints of differing versions) and
+// that files are received correctly regardless of whether or not compression
+// is used.
+func TestTransmitWithCompression(t *testing.T) {
+	// Create a source root containing compressible and incompressible files.
+	root := t.TempDir()
+	paths := []string{"compressible", "empty", "incompressible"}
+	contents := map[string][]byte{
+		"compressible":   []byte(strings.Repeat("compressible content ", 10000)),
+		"empty":          nil,
+		"incompressible": make([]byte, 3*DefaultMaximumDataOperationSize/2),
+	}
+	for i := range contents["incompressible"] {
+		contents["incompressible"][i] = byte((i * 7919) >> (i % 11))
+	}
+	for path, content := range contents {
+		if err := os.WriteFile(filepath.Join(root, path), content, 0600); err != nil {
+			t.Fatal("unable to create test file:", err)
+		}
+	}
+	signatures := []*Signature{{}, {}, {}}
+	digests := make([][]byte, len(paths))
+
+	// Set up test cases.
+	testCases := []struct {
+		// description is a description of the test case.
+		description string
+		// algorithm is the algorithm requested by the transmitter.
+		algorithm compression.Algorithm
+		// encoded indicates whether or not transmissions should be passed
+		// through an encoding receiver, simulating a remote receiver.
+		encoded bool
+		// decompression is the algorithm that the simulated remote receiver
+		// supports decompressing. A default value simulates a remote that
+		// doesn't support operation data compression.
+		decompression compression.Algorithm
+		// expectCompression indicates whether or not compressed transmissions
+		// are expected.
+		expectCompression bool
+	}{
+		{"local uncompressed", compression.Algorithm_AlgorithmNone, false, 0, false},
+		{"local compressed", compression.Algorithm_AlgorithmDeflate, false, 0, true},
+		{"remote compressed", compression.Algorithm_AlgorithmDeflate, true, compression.Algorithm_AlgorithmDeflate, true},
+		{"remote without compression support", compression.Algorithm_AlgorithmDeflate, true, 0, false},
+		{"remote with mismatched compression support", compression.Algorithm_AlgorithmDeflate, true, compression.Algorithm_AlgorithmZstandard, false},
+	}
+
+	// Process test cases.
+	for _, testCase := range testCases {
+		// Create the final receiver.
+		sinker := &testSinker{received: make(map[string][]byte)}
+		receiver, err := NewReceiver(t.TempDir(), paths, digests, signatures, sinker)
+		if err != nil {
+			t.Fatalf("%s: unable to create receiver: %v", testCase.description, err)
+		}
+
+		// Perform transmission, simulating a remote receiver if necessary and
+		// tracking whether or not compression was used.
+		var compressed bool
+		if testCase.encoded {
+			encoder := &recordingEncoder{}
+			encodingReceiver := NewDecompressingEncodingReceiver(encoder, testCase.decompression)
+			if err := TransmitWithCompression(root, paths, signatures, encodingReceiver, 0, nil, testCase.algorithm); err != nil {
+				t.Fatalf("%s: transmission failed: %v", testCase.description, err)
+			}
+			for _, transmission := range encoder.transmissions {
+				compressed = compressed || !transmission.Compression.IsDefault()
+			}
+			decoder := &replayingDecoder{transmissions: encoder.transmissions}
+			if err := DecodeToReceiver(decoder, uint64(len(paths)), receiver); err != nil {
+				t.Fatalf("%s: decoding failed: %v", testCase.description, err)
+			}
+		} else {
+			tracker := &compressionTrackingReceiver{Receiver: receiver}
+			if err := TransmitWithCompression(root, paths, signatures, tracker, 0, nil, testCase.algorithm); err != nil {
+				t.Fatalf("%s: transmission failed: %v", testCase.description, err)
+			}
+			compressed = tracker.compressed
+		}
+
+		// Check compression usage.
+		if compressed != testCase.expectCompression {
+			t.Errorf("%s: compression usage does not match expected: %t != %t",
+				testCase.description, compressed, testCase.expectCompression,
+			)
+		}
+
+		// Verify received content.
+		for _, path := range paths {
+			if received, ok := sinker.received[path]; !ok {
+				t.Errorf("%s: %s not received", testCase.description, path)
+			} else if !bytes.Equal(received, contents[path]) {
+				t.Errorf("%s: %s received content does not match original", testCase.description, path)
+			}
+		}
+	}
+}
+
+// TestReceiverUnsupportedCompression tests that a receiver refuses operation
+// data compressed with an unsupported algorithm without failing reception of
+// subsequent files.
+func TestReceiverUnsupportedCompression(t *testing.T) {
+	// Create a receiver for two files.
+	paths := []string{"first", "second"}
+	sinker := &testSinker{received: make(map[string][]byte)}
+	receiver, err := NewReceiver(t.TempDir(), paths, make([][]byte, 2), []*Signature{{}, {}}, sinker)
+	if err != nil {
+		t.Fatal("unable to create receiver:", err)
+	}
+
+	// Send the first file with an unknown compression algorithm and the second
+	// file uncompressed.
+	transmissions := []*Transmission{
+		{ExpectedSize: 4, Operation: &Operation{Data: []byte("data")}, Compression: compression.Algorithm(255)},
+		{Done: true},
+		{ExpectedSize: 4, Operation: &Operation{Data: []byte("data")}},
+		{Done: true},
+	}
+	for _, transmission := range transmissions {
+		if err := receiver.Receive(transmission); err != nil {
+			t.Fatal("reception failed:", err)
+		}
+	}
+	if err := receiver.finalize(); err != nil {
+		t.Fatal("unable to finalize receiver:", err)
+	}
+
+	// Verify the results.
+	if !bytes.Equal(sinker.received["second"], []byte("data")) {
+		t.Error("second file not received correctly")
+	}
+	if received, ok := sinker.received["first"]; ok && bytes.Equal(received, []byte("data")) {
+		t.Error("first file received despite unsupported compression")
+	}
+}
+
+// TestReceiverTrailerVerification tests that a receiver with trailer
+// verification enabled accepts intact operation streams and rejects corrupted
+// or truncated streams with ErrPatchDigestMismatch, and that receivers without
+// trailer verification ignore trailers.
+func TestReceiverTrailerVerification(t *testing.T) {
+	// Compute transmissions for a file, including a trailer operation.
+	content := []byte(strings.Repeat("trailer content ", 1024))
+	var transmissions []*Transmission
+	transmit := func(o *Operation) error {
+		transmissions = append(transmissions, &Transmission{
+			ExpectedSize: uint64(len(content)),
+			Operation:    proto.Clone(o).(*Operation),
+		})
+		return nil
+	}
+	if err := NewEngine().DeltifyWithTrailer(bytes.NewReader(content), &Signature{}, 1024, transmit); err != nil {
+		t.Fatal("unable to perform deltification:", err)
+	}
+	transmissions = append(transmissions, &Transmission{Done: true})
+
+	// Create corrupted and truncated variants of the transmissions.
+	corrupted := make([]*Transmission, len(transmissions))
+	for i, transmission := range transmissions {
+		corrupted[i] = proto.Clone(transmission).(*Transmission)
+	}
+	corrupted[1].Operation.Data[0]++
+	truncated := append(transmissions[:len(transmissions)-2:len(transmissions)-2], transmissions[len(transmissions)-1])
+
+	// Set up test cases.
+	testCases := []struct {
+		// description is a description of the test case.
+		description string
+		// transmissions are the transmissions to send.
+		transmissions []*Transmission
+		// verify indicates whether or not trailer verification is enabled.
+		verify bool
+		// expectMismatch indicates whether or not a digest mismatch is
+		// expected.
+		expectMismatch bool
+	}{
+		{"intact", transmissions, true, false},
+		{"intact without verification", transmissions, false, false},
+		{"corrupted", corrupted, true, true},
+		{"truncated", truncated, true, true},
+	}
+
+	// Process test cases.
+	for _, testCase := range testCases {
+		// Create the receiver.
+		sinker := &testSinker{received: make(map[string][]byte)}
+		paths, digests, signatures := []string{"file"}, [][]byte{nil}, []*Signature{{}}
+		var receiver Receiver
+		var err error
+		if testCase.verify {
+			receiver, err = NewReceiverWithTrailerVerification(t.TempDir(), paths, digests, signatures, sinker)
+		} else {
+			receiver, err = NewReceiver(t.TempDir(), paths, digests, signatures, sinker)
+		}
+		if err != nil {
+			t.Fatalf("%s: unable to create receiver: %v", testCase.description, err)
+		}
+
+		// Send the transmissions.
+		err = nil
+		for _, transmission := range testCase.transmissions {
+			if err = transmission.EnsureValid(); err != nil {
+				t.Fatalf("%s: invalid transmission: %v", testCase.description, err)
+			} else if err = receiver.Receive(transmission); err != nil {
+				break
+			}
+		}
+		receiver.finalize()
+
+		// Verify the result.
+		if testCase.expectMismatch {
+			if !errors.Is(err, ErrPatchDigestMismatch) {
+				t.Errorf("%s: digest mismatch not reported: %v", testCase.description, err)
+			}
+		} else if err != nil {
+			t.Errorf("%s: reception failed: %v", testCase.description, err)
+		} else if !bytes.Equal(sinker.received["file"], content) {
+			t.Errorf("%s: received content does not match original", testCase.description)
+		}
+	}
+}
+
+// TestTransmitFromRoots tests that files are sourced from the first root from
+// which they can be opened.
+func TestTransmitFromRoots(t *testing.T) {
+	// Create roots with overlapping content.
+	first, second := t.TempDir(), t.TempDir()
+	files := []struct {
+		root    string
+		path    string
+		content string
+	}{
+		{first, "shared", "first shared"},
+		{second, "shared", "second shared"},
+		{second, "fallback", "second fallback"},
+	}
+	for _, file := range files {
+		if err := os.WriteFile(filepath.Join(file.root, file.path), []byte(file.content), 0600); err != nil {
+			t.Fatal("unable to create test file:", err)
+		}
+	}
+
+	// Perform transmission.
+	paths := []string{"fallback", "shared"}
+	signatures := []*Signature{{}, {}}
+	sinker := &testSinker{received: make(map[string][]byte)}
+	receiver, err := NewReceiver(t.TempDir(), paths, make([][]byte, len(paths)), signatures, sinker)
+	if err != nil {
+		t.Fatal("unable to create receiver:", err)
+	}
+	if err := TransmitFromRoots([]string{first, second}, paths, signatures, receiver, 0, nil, compression.Algorithm_AlgorithmNone); err != nil {
+		t.Fatal("transmission failed:", err)
+	}
+
+	// Verify received content.
+	if received := string(sinker.received["shared"]); received != "first shared" {
+		t.Error("shared file not sourced from first root:", received)
+	}
+	if received := string(sinker.received["fallback"]); received != "second fallback" {
+		t.Error("fallback file not sourced from second root:", received)
+	}
+}
+
+// gatedReceiver is a Receiver implementation that blocks in Receive until
+// released, simulating a slow receiver.
+type gatedReceiver struct {
+	// gate is closed to release blocked calls to Receive.
+	gate chan struct{}
+	// received is the number of transmissions received.
+	received int
+}
+
+// Receive implements Receiver.Receive.
+func (r *gatedReceiver) Receive(_ *Transmission) error {
+	<-r.gate
+	r.received++
+	return nil
+}
+
+// SupportsDecompression implements Receiver.SupportsDecompression.
+func (r *gatedReceiver) SupportsDecompression(_ compression.Algorithm) bool {
+	return false
+}
+
+// finalize implements Receiver.finalize.
+func (r *gatedReceiver) finalize() error {
+	return nil
+}
+
+// TestPipelinedReceiverBlocks tests that a pipelined receiver blocks the
+// transmitter once its in-flight limit is reached, rather than buffering
+// additional data, and that it resumes once the underlying receiver catches up.
+func TestPipelinedReceiverBlocks(t *testing.T) {
+	// Create a pipelined receiver that forwards to a blocked receiver.
+	const maximumInFlightBytes = 4096
+	underlying := &gatedReceiver{gate: make(chan struct{})}
+	receiver := newPipelinedReceiver(underlying, maximumInFlightBytes)
+	transmission := &Transmission{
+		Operation: &Operation{Data: make([]byte, maximumInFlightBytes/2-pipelinedTransmissionOverhead)},
+	}
+
+	// Queue transmissions up to the in-flight limit. These should be admitted
+	// without blocking.
+	for i := 0; i < 2; i++ {
+		if err := receiver.Receive(transmission); err != nil {
+			t.Fatal("unable to queue transmission:", err)
+		}
+	}
+
+	// Queue another transmission, which should block until the underlying
+	// receiver makes progress.
+	received := make(chan error, 1)
+	go func() {
+		received <- receiver.Receive(transmission)
+	}()
+	select {
+	case err := <-received:
+		t.Fatal("transmission admitted beyond in-flight limit:", err)
+	case <-time.After(100 * time.Millisecond):
+	}
+	receiver.lock.Lock()
+	inFlightBytes := receiver.inFlightBytes
+	receiver.lock.Unlock()
+	if inFlightBytes > maximumInFlightBytes {
+		t.Error("in-flight bytes exceed limit:", inFlightBytes)
+	}
+
+	// Release the underlying receiver and ensure that the blocked transmission
+	// is admitted.
+	close(underlying.gate)
+	select {
+	case err := <-received:
+		if err != nil {
+			t.Fatal("unable to queue transmission:", err)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("transmission not admitted after underlying receiver progressed")
+	}
+
+	// Finalize the receiver and ensure that all transmissions were forwarded.
+	if err := receiver.finalize(); err != nil {
+		t.Fatal("unable to finalize receiver:", err)
+	} else if underlying.received != 3 {
+		t.Error("unexpected number of forwarded transmissions:", underlying.received)
+	}
+}
+
+// slowReceiver is a Receiver implementation that wraps another receiver and
+// delays each transmission.
+type slowReceiver struct {
+	// Receiver is the underlying receiver.
+	Receiver
+}
+
+// Receive implements Receiver.Receive.
+func (r *slowReceiver) Receive(transmission *Transmission) error {
+	time.Sleep(time.Millisecond)
+	return r.Receiver.Receive(transmission)
+}
+
+// TestTransmitWithInFlightLimit tests that files are received correctly when
+// transmission is pipelined with a small in-flight limit.
+func TestTransmitWithInFlightLimit(t *testing.T) {
+	// Create a source root containing a file spanning many data operations.
+	root := t.TempDir()
+	content := make([]byte, 8*DefaultMaximumDataOperationSize+123)
+	for i := range content {
+		content[i] = byte((i * 7919) >> (i % 13))
+	}
+	if err := os.WriteFile(filepath.Join(root, "file"), content, 0600); err != nil {
+		t.Fatal("unable to create test file:", err)
+	}
+
+	// Perform transmission with an in-flight limit that only admits a single
+	// data operation at a time.
+	paths := []string{"file"}
+	signatures := []*Signature{{}}
+	sinker := &testSinker{received: make(map[string][]byte)}
+	receiver, err := NewReceiver(t.TempDir(), paths, make([][]byte, len(paths)), signatures, sinker)
+	if err != nil {
+		t.Fatal("unable to create receiver:", err)
+	}
+	receiver = &slowReceiver{Receiver: receiver}
+	if err := TransmitWithInFlightLimit(
+		[]string{root}, paths, signatures, receiver,
+		0, nil,
+		compression.Algorithm_AlgorithmNone, DefaultMaximumDataOperationSize,
+	); err != nil {
+		t.Fatal("transmission failed:", err)
+	}
+
+	// Verify received content.
+	if !bytes.Equal(sinker.received["file"], content) {
+		t.Error("received content does not match original")
+	}
+}
@@ -1,6 +1,9 @@
 package synchronization
 
 import (
+	"fmt"
+
+	"github.com/mutagen-io/mutagen/pkg/filesystem"
 	"github.com/mutagen-io/mutagen/pkg/synchronization/core"
 )
 
@@ -36,6 +39,84 @@ func oneEndpointEmptiedRoot(ancestor, alpha, beta *core.Entry) bool {
 	return (alphaEmptied || betaEmptied) && !(alphaEmptied && betaEmptied)
 }
 
+// capabilityMismatches computes the filesystem capabilities that differ between
+// alpha and beta snapshots. Since capabilities are only probed when content is
+// present, mismatches are only computed if both snapshots have content.
+func capabilityMismatches(alpha, beta *core.Snapshot) []*CapabilityMismatch {
+	// If either snapshot lacks content, then its capabilities are unknown.
+	if alpha.Content == nil || beta.Content == nil {
+		return nil
+	}
+
+	// Compare capabilities.
+	var result []*CapabilityMismatch
+	if alpha.PreservesExecutability != beta.PreservesExecutability {
+		result = append(result, &CapabilityMismatch{
+			Capability: "executability preservation",
+			Alpha:      alpha.PreservesExecutability,
+			Beta:       beta.PreservesExecutability,
+		})
+	}
+	if alpha.DecomposesUnicode != beta.DecomposesUnicode {
+		result = append(result, &CapabilityMismatch{
+			Capability: "Unicode decomposition",
+			Alpha:      alpha.DecomposesUnicode,
+			Beta:       beta.DecomposesUnicode,
+		})
+	}
+
+	// Done.
+	return result
+}
+
+// configurationIncompatibilities computes the ways in which an endpoint's
+// effective configuration is incompatible with the filesystem capabilities
+// probed in a snapshot from that endpoint. Since capabilities are only probed
+// when content exists, no incompatibilities are reported for snapshots without
+// content. Filesystems that don't preserve executability generally don't store
+// POSIX permissions at all, so ownership settings are also treated as
+// incompatible with such filesystems.
+func configurationIncompatibilities(
+	configuration *Configuration,
+	permissionsMode core.PermissionsMode,
+	snapshot *core.Snapshot,
+) []string {
+	// If the snapshot lacks content, then its capabilities are unknown.
+	if snapshot.Content == nil {
+		return nil
+	}
+
+	// Check for settings that require executability preservation.
+	var result []string
+	if !snapshot.PreservesExecutability {
+		executableBits := filesystem.ModePermissionUserExecute |
+			filesystem.ModePermissionGroupExecute |
+			filesystem.ModePermissionOthersExecute
+		if permissionsMode == core.PermissionsMode_PermissionsModeManual &&
+			filesystem.Mode(configuration.DefaultFileMode)&executableBits != 0 {
+			result = append(result, fmt.Sprintf(
+				"default file mode (%#o) includes executability bits, but filesystem does not preserve executability",
+				configuration.DefaultFileMode,
+			))
+		}
+		if configuration.DefaultOwner != "" {
+			result = append(result, fmt.Sprintf(
+				"default file owner (%s) specified, but filesystem does not preserve POSIX permissions",
+				configuration.DefaultOwner,
+			))
+		}
+		if configuration.DefaultGroup != "" {
+			result = append(result, fmt.Sprintf(
+				"default file group (%s) specified, but filesystem does not preserve POSIX permissions",
+				configuration.DefaultGroup,
+			))
+		}
+	}
+
+	// Done.
+	return result
+}
+
 // containsRootDeletion determines whether or not any of the specified changes
 // is a root deletion change.
 func containsRootDeletion(changes []*core.Change) bool {
@@ -92,3 +173,47 @@ func filteredPathsAreSubset(filteredPaths, originalPaths []string) bool {
 	// Success.
 	return true
 }
+
+// heldDigests computes the set of file content digests held within the
+// specified content.
+func heldDigests(content *core.Entry) map[string]bool {
+	result := make(map[string]bool)
+	var visit func(*core.Entry)
+	visit = func(entry *core.Entry) {
+		if entry == nil {
+			return
+		} else if entry.Kind == core.EntryKind_File {
+			result[string(entry.Digest)] = true
+		}
+		for _, child := range entry.Contents {
+			visit(child)
+		}
+	}
+	visit(content)
+	return result
+}
+
+// heldStagingPaths returns the set of staging paths whose target digests are
+// present in the specified set of held digests. The paths and digests slices
+// must be parallel.
+func heldStagingPaths(paths []string, digests [][]byte, held map[string]bool) map[string]bool {
+	result := make(map[string]bool)
+	for p, path := range paths {
+		if held[string(digests[p])] {
+			result[path] = true
+		}
+	}
+	return result
+}
+
+// countHeldStagingPaths counts the number of paths in a list of staging paths
+// that are present in a set of held staging paths.
+func countHeldStagingPaths(paths []string, heldPaths map[string]bool) int {
+	var result int
+	for _, path := range paths {
+		if heldPaths[path] {
+			result++
+		}
+	}
+	return result
+}
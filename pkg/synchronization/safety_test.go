@@ -2,6 +2,8 @@ package synchronization
 
 import (
 	"testing"
+
+	"github.com/mutagen-io/mutagen/pkg/synchronization/core"
 )
 
 // TODO: Implement tests for additional functions.
@@ -45,3 +47,42 @@ func TestFilteredPathsAreSubset(t *testing.T) {
 		}
 	}
 }
+
+// TestHeldStagingPaths tests that heldDigests and heldStagingPaths correctly
+// identify staging paths whose content is already held within a snapshot.
+func TestHeldStagingPaths(t *testing.T) {
+	// Create content holding a pair of files.
+	content := &core.Entry{
+		Kind: core.EntryKind_Directory,
+		Contents: map[string]*core.Entry{
+			"backup": {
+				Kind: core.EntryKind_Directory,
+				Contents: map[string]*core.Entry{
+					"restored": {Kind: core.EntryKind_File, Digest: []byte{1}},
+				},
+			},
+			"other": {Kind: core.EntryKind_File, Digest: []byte{2}},
+			"link":  {Kind: core.EntryKind_SymbolicLink, Target: "other"},
+		},
+	}
+
+	// Compute held staging paths.
+	paths := []string{"a", "b", "c"}
+	digests := [][]byte{{1}, {3}, {2}}
+	heldPaths := heldStagingPaths(paths, digests, heldDigests(content))
+
+	// Verify the result.
+	if len(heldPaths) != 2 {
+		t.Fatal("unexpected number of held paths:", len(heldPaths), "!=", 2)
+	} else if !heldPaths["a"] || !heldPaths["c"] {
+		t.Error("held paths not identified correctly")
+	}
+	if count := countHeldStagingPaths([]string{"b", "c"}, heldPaths); count != 1 {
+		t.Error("unexpected held path count:", count, "!=", 1)
+	}
+
+	// Verify that nil content doesn't hold anything.
+	if heldPaths = heldStagingPaths(paths, digests, heldDigests(nil)); len(heldPaths) != 0 {
+		t.Error("nil content reported as holding paths")
+	}
+}
@@ -0,0 +1,73 @@
+package synchronization
+
+import (
+	"fmt"
+)
+
+// IsDefault indicates whether or not the scan sharing mode is
+// ScanSharingMode_ScanSharingModeDefault.
+func (m ScanSharingMode) IsDefault() bool {
+	return m == ScanSharingMode_ScanSharingModeDefault
+}
+
+// MarshalText implements encoding.TextMarshaler.MarshalText.
+func (m ScanSharingMode) MarshalText() ([]byte, error) {
+	var result string
+	switch m {
+	case ScanSharingMode_ScanSharingModeDefault:
+	case ScanSharingMode_ScanSharingModeDisabled:
+		result = "disabled"
+	case ScanSharingMode_ScanSharingModeEnabled:
+		result = "enabled"
+	default:
+		result = "unknown"
+	}
+	return []byte(result), nil
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler.UnmarshalText.
+func (m *ScanSharingMode) UnmarshalText(textBytes []byte) error {
+	// Convert the bytes to a string.
+	text := string(textBytes)
+
+	// Convert to a scan sharing mode.
+	switch text {
+	case "disabled":
+		*m = ScanSharingMode_ScanSharingModeDisabled
+	case "enabled":
+		*m = ScanSharingMode_ScanSharingModeEnabled
+	default:
+		return fmt.Errorf("unknown scan sharing mode specification: %s", text)
+	}
+
+	// Success.
+	return nil
+}
+
+// Supported indicates whether or not a particular scan sharing mode is a
+// valid, non-default value.
+func (m ScanSharingMode) Supported() bool {
+	switch m {
+	case ScanSharingMode_ScanSharingModeDisabled:
+		return true
+	case ScanSharingMode_ScanSharingModeEnabled:
+		return true
+	default:
+		return false
+	}
+}
+
+// Description returns a human-readable description of a root overlap
+// mode.
+func (m ScanSharingMode) Description() string {
+	switch m {
+	case ScanSharingMode_ScanSharingModeDefault:
+		return "Default"
+	case ScanSharingMode_ScanSharingModeDisabled:
+		return "Disabled"
+	case ScanSharingMode_ScanSharingModeEnabled:
+		return "Enabled"
+	default:
+		return "Unknown"
+	}
+}
@@ -0,0 +1,91 @@
+package synchronization
+
+import (
+	"testing"
+)
+
+// TestScanSharingModeUnmarshal tests that unmarshaling from a string
+// specification succeeeds for ScanSharingMode.
+func TestScanSharingModeUnmarshal(t *testing.T) {
+	// Set up test cases.
+	testCases := []struct {
+		text          string
+		expectedMode  ScanSharingMode
+		expectFailure bool
+	}{
+		{"", ScanSharingMode_ScanSharingModeDefault, true},
+		{"asdf", ScanSharingMode_ScanSharingModeDefault, true},
+		{"disabled", ScanSharingMode_ScanSharingModeDisabled, false},
+		{"enabled", ScanSharingMode_ScanSharingModeEnabled, false},
+	}
+
+	// Process test cases.
+	for _, testCase := range testCases {
+		var mode ScanSharingMode
+		if err := mode.UnmarshalText([]byte(testCase.text)); err != nil {
+			if !testCase.expectFailure {
+				t.Errorf("unable to unmarshal text (%s): %s", testCase.text, err)
+			}
+		} else if testCase.expectFailure {
+			t.Error("unmarshaling succeeded unexpectedly for text:", testCase.text)
+		} else if mode != testCase.expectedMode {
+			t.Errorf(
+				"unmarshaled mode (%s) does not match expected (%s)",
+				mode,
+				testCase.expectedMode,
+			)
+		}
+	}
+}
+
+// TestScanSharingModeSupported tests that ScanSharingMode support
+// detection works as expected.
+func TestScanSharingModeSupported(t *testing.T) {
+	// Set up test cases.
+	testCases := []struct {
+		mode            ScanSharingMode
+		expectSupported bool
+	}{
+		{ScanSharingMode_ScanSharingModeDefault, false},
+		{ScanSharingMode_ScanSharingModeDisabled, true},
+		{ScanSharingMode_ScanSharingModeEnabled, true},
+		{(ScanSharingMode_ScanSharingModeEnabled + 1), false},
+	}
+
+	// Process test cases.
+	for _, testCase := range testCases {
+		if supported := testCase.mode.Supported(); supported != testCase.expectSupported {
+			t.Errorf(
+				"mode support status (%t) does not match expected (%t)",
+				supported,
+				testCase.expectSupported,
+			)
+		}
+	}
+}
+
+// TestScanSharingModeDescription tests that ScanSharingMode
+// description generation works as expected.
+func TestScanSharingModeDescription(t *testing.T) {
+	// Set up test cases.
+	testCases := []struct {
+		mode                ScanSharingMode
+		expectedDescription string
+	}{
+		{ScanSharingMode_ScanSharingModeDefault, "Default"},
+		{ScanSharingMode_ScanSharingModeDisabled, "Disabled"},
+		{ScanSharingMode_ScanSharingModeEnabled, "Enabled"},
+		{(ScanSharingMode_ScanSharingModeEnabled + 1), "Unknown"},
+	}
+
+	// Process test cases.
+	for _, testCase := range testCases {
+		if description := testCase.mode.Description(); description != testCase.expectedDescription {
+			t.Errorf(
+				"mode description (%s) does not match expected (%s)",
+				description,
+				testCase.expectedDescription,
+			)
+		}
+	}
+}
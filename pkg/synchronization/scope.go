@@ -0,0 +1,78 @@
+package synchronization
+
+import (
+	"errors"
+	"fmt"
+	"path"
+	"sort"
+	"strings"
+)
+
+// normalizeScopePath normalizes a single scope path. It returns an empty string
+// if the path refers to the synchronization root.
+func normalizeScopePath(scopePath string) (string, error) {
+	// Convert any Windows-style separators and clean the path.
+	scopePath = path.Clean(strings.ReplaceAll(scopePath, "\\", "/"))
+
+	// Reject paths that aren't relative to (and contained within) the
+	// synchronization root.
+	if path.IsAbs(scopePath) {
+		return "", errors.New("path is absolute")
+	} else if scopePath == ".." || strings.HasPrefix(scopePath, "../") {
+		return "", errors.New("path escapes synchronization root")
+	}
+
+	// Convert references to the synchronization root.
+	if scopePath == "." {
+		return "", nil
+	}
+
+	// Success.
+	return scopePath, nil
+}
+
+// NormalizeScope normalizes a list of user-provided synchronization scope paths
+// (e.g. those used to scope a flush operation) into synchronization-root-
+// relative paths. The resulting list is sorted and contains no duplicates. If
+// any of the paths refers to the synchronization root (or if no paths are
+// provided), then the scope covers the entire synchronization root and a nil
+// list is returned.
+func NormalizeScope(scope []string) ([]string, error) {
+	// Normalize each path and track those that we've seen.
+	var result []string
+	seen := make(map[string]bool, len(scope))
+	for _, scopePath := range scope {
+		normalized, err := normalizeScopePath(scopePath)
+		if err != nil {
+			return nil, fmt.Errorf("invalid scope path (%s): %w", scopePath, err)
+		} else if normalized == "" {
+			return nil, nil
+		} else if seen[normalized] {
+			continue
+		}
+		seen[normalized] = true
+		result = append(result, normalized)
+	}
+
+	// Sort the result.
+	sort.Strings(result)
+
+	// Success.
+	return result, nil
+}
+
+// EnsureValidScope ensures that a synchronization scope consists only of
+// normalized, non-root, synchronization-root-relative paths, as would be
+// returned by NormalizeScope.
+func EnsureValidScope(scope []string) error {
+	for _, scopePath := range scope {
+		if scopePath == "" {
+			return errors.New("scope contains synchronization root")
+		} else if normalized, err := normalizeScopePath(scopePath); err != nil {
+			return fmt.Errorf("invalid scope path (%s): %w", scopePath, err)
+		} else if normalized != scopePath {
+			return fmt.Errorf("scope path (%s) is not normalized", scopePath)
+		}
+	}
+	return nil
+}
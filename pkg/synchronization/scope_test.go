@@ -0,0 +1,72 @@
+package synchronization
+
+import (
+	"slices"
+	"testing"
+)
+
+// TestNormalizeScope tests NormalizeScope.
+func TestNormalizeScope(t *testing.T) {
+	// Set up test cases.
+	testCases := []struct {
+		scope       []string
+		expected    []string
+		expectError bool
+	}{
+		{nil, nil, false},
+		{[]string{}, nil, false},
+		{[]string{"a"}, []string{"a"}, false},
+		{[]string{"a/"}, []string{"a"}, false},
+		{[]string{"./a/b"}, []string{"a/b"}, false},
+		{[]string{"a\\b"}, []string{"a/b"}, false},
+		{[]string{"b", "a", "b"}, []string{"a", "b"}, false},
+		{[]string{"a", "."}, nil, false},
+		{[]string{"a/.."}, nil, false},
+		{[]string{"/a"}, nil, true},
+		{[]string{".."}, nil, true},
+		{[]string{"a", "../b"}, nil, true},
+	}
+
+	// Process test cases.
+	for i, testCase := range testCases {
+		result, err := NormalizeScope(testCase.scope)
+		if testCase.expectError {
+			if err == nil {
+				t.Errorf("test index %d: normalization succeeded unexpectedly", i)
+			}
+			continue
+		} else if err != nil {
+			t.Errorf("test index %d: unable to normalize scope: %v", i, err)
+			continue
+		}
+		if !slices.Equal(result, testCase.expected) {
+			t.Errorf("test index %d: result does not match expected: %v != %v", i, result, testCase.expected)
+		}
+	}
+}
+
+// TestEnsureValidScope tests EnsureValidScope.
+func TestEnsureValidScope(t *testing.T) {
+	// Set up test cases.
+	testCases := []struct {
+		scope    []string
+		expected bool
+	}{
+		{nil, true},
+		{[]string{"a", "b/c"}, true},
+		{[]string{""}, false},
+		{[]string{"a/"}, false},
+		{[]string{"./a"}, false},
+		{[]string{"/a"}, false},
+		{[]string{"../a"}, false},
+	}
+
+	// Process test cases.
+	for i, testCase := range testCases {
+		if err := EnsureValidScope(testCase.scope); err == nil && !testCase.expected {
+			t.Errorf("test index %d: invalid scope classified as valid", i)
+		} else if err != nil && testCase.expected {
+			t.Errorf("test index %d: valid scope classified as invalid: %v", i, err)
+		}
+	}
+}
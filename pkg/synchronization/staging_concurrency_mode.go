@@ -0,0 +1,73 @@
+package synchronization
+
+import (
+	"fmt"
+)
+
+// IsDefault indicates whether or not the staging concurrency mode is
+// StagingConcurrencyMode_StagingConcurrencyModeDefault.
+func (m StagingConcurrencyMode) IsDefault() bool {
+	return m == StagingConcurrencyMode_StagingConcurrencyModeDefault
+}
+
+// MarshalText implements encoding.TextMarshaler.MarshalText.
+func (m StagingConcurrencyMode) MarshalText() ([]byte, error) {
+	var result string
+	switch m {
+	case StagingConcurrencyMode_StagingConcurrencyModeDefault:
+	case StagingConcurrencyMode_StagingConcurrencyModeSequential:
+		result = "sequential"
+	case StagingConcurrencyMode_StagingConcurrencyModeConcurrent:
+		result = "concurrent"
+	default:
+		result = "unknown"
+	}
+	return []byte(result), nil
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler.UnmarshalText.
+func (m *StagingConcurrencyMode) UnmarshalText(textBytes []byte) error {
+	// Convert the bytes to a string.
+	text := string(textBytes)
+
+	// Convert to a staging concurrency mode.
+	switch text {
+	case "sequential":
+		*m = StagingConcurrencyMode_StagingConcurrencyModeSequential
+	case "concurrent":
+		*m = StagingConcurrencyMode_StagingConcurrencyModeConcurrent
+	default:
+		return fmt.Errorf("unknown staging concurrency mode specification: %s", text)
+	}
+
+	// Success.
+	return nil
+}
+
+// Supported indicates whether or not a particular staging concurrency mode
+// is a valid, non-default value.
+func (m StagingConcurrencyMode) Supported() bool {
+	switch m {
+	case StagingConcurrencyMode_StagingConcurrencyModeSequential:
+		return true
+	case StagingConcurrencyMode_StagingConcurrencyModeConcurrent:
+		return true
+	default:
+		return false
+	}
+}
+
+// Description returns a human-readable description of a staging concurrency
+// mode.
+func (m StagingConcurrencyMode) Description() string {
+	switch m {
+	case StagingConcurrencyMode_StagingConcurrencyModeDefault:
+		return "Default"
+	case StagingConcurrencyMode_StagingConcurrencyModeSequential:
+		return "Sequential"
+	case StagingConcurrencyMode_StagingConcurrencyModeConcurrent:
+		return "Concurrent"
+	default:
+		return "Unknown"
+	}
+}
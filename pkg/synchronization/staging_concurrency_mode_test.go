@@ -0,0 +1,91 @@
+package synchronization
+
+import (
+	"testing"
+)
+
+// TestStagingConcurrencyModeUnmarshal tests that unmarshaling from a string
+// specification succeeeds for StagingConcurrencyMode.
+func TestStagingConcurrencyModeUnmarshal(t *testing.T) {
+	// Set up test cases.
+	testCases := []struct {
+		text          string
+		expectedMode  StagingConcurrencyMode
+		expectFailure bool
+	}{
+		{"", StagingConcurrencyMode_StagingConcurrencyModeDefault, true},
+		{"asdf", StagingConcurrencyMode_StagingConcurrencyModeDefault, true},
+		{"sequential", StagingConcurrencyMode_StagingConcurrencyModeSequential, false},
+		{"concurrent", StagingConcurrencyMode_StagingConcurrencyModeConcurrent, false},
+	}
+
+	// Process test cases.
+	for _, testCase := range testCases {
+		var mode StagingConcurrencyMode
+		if err := mode.UnmarshalText([]byte(testCase.text)); err != nil {
+			if !testCase.expectFailure {
+				t.Errorf("unable to unmarshal text (%s): %s", testCase.text, err)
+			}
+		} else if testCase.expectFailure {
+			t.Error("unmarshaling succeeded unexpectedly for text:", testCase.text)
+		} else if mode != testCase.expectedMode {
+			t.Errorf(
+				"unmarshaled mode (%s) does not match expected (%s)",
+				mode,
+				testCase.expectedMode,
+			)
+		}
+	}
+}
+
+// TestStagingConcurrencyModeSupported tests that StagingConcurrencyMode
+// support detection works as expected.
+func TestStagingConcurrencyModeSupported(t *testing.T) {
+	// Set up test cases.
+	testCases := []struct {
+		mode            StagingConcurrencyMode
+		expectSupported bool
+	}{
+		{StagingConcurrencyMode_StagingConcurrencyModeDefault, false},
+		{StagingConcurrencyMode_StagingConcurrencyModeSequential, true},
+		{StagingConcurrencyMode_StagingConcurrencyModeConcurrent, true},
+		{(StagingConcurrencyMode_StagingConcurrencyModeConcurrent + 1), false},
+	}
+
+	// Process test cases.
+	for _, testCase := range testCases {
+		if supported := testCase.mode.Supported(); supported != testCase.expectSupported {
+			t.Errorf(
+				"mode support status (%t) does not match expected (%t)",
+				supported,
+				testCase.expectSupported,
+			)
+		}
+	}
+}
+
+// TestStagingConcurrencyModeDescription tests that StagingConcurrencyMode
+// description generation works as expected.
+func TestStagingConcurrencyModeDescription(t *testing.T) {
+	// Set up test cases.
+	testCases := []struct {
+		mode                StagingConcurrencyMode
+		expectedDescription string
+	}{
+		{StagingConcurrencyMode_StagingConcurrencyModeDefault, "Default"},
+		{StagingConcurrencyMode_StagingConcurrencyModeSequential, "Sequential"},
+		{StagingConcurrencyMode_StagingConcurrencyModeConcurrent, "Concurrent"},
+		{(StagingConcurrencyMode_StagingConcurrencyModeConcurrent + 1), "Unknown"},
+	}
+
+	// Process test cases.
+	for _, testCase := range testCases {
+		if description := testCase.mode.Description(); description != testCase.expectedDescription {
+			t.Errorf(
+				"mode description (%s) does not match expected (%s)",
+				description,
+				testCase.expectedDescription,
+			)
+		}
+	}
+}
@@ -36,6 +36,18 @@ func (s Status) Description() string {
 		return "Applying changes"
 	case Status_Saving:
 		return "Saving archive"
+	case Status_HaltedOnConflictThreshold:
+		return "Halted due to excessive conflicts"
+	case Status_HaltedOnOversizedFile:
+		return "Halted due to oversized files"
+	case Status_Staging:
+		return "Staging files on alpha and beta"
+	case Status_HaltedOnPersistentConflict:
+		return "Halted due to persistent conflicts"
+	case Status_HaltedOnCapabilityMismatch:
+		return "Halted due to filesystem capability mismatch"
+	case Status_HaltedOnConfigurationIncompatibility:
+		return "Halted due to configuration incompatibility"
 	default:
 		return "Unknown"
 	}
@@ -73,6 +85,18 @@ func (s Status) MarshalText() ([]byte, error) {
 		result = "transitioning"
 	case Status_Saving:
 		result = "saving"
+	case Status_HaltedOnConflictThreshold:
+		result = "halted-on-conflict-threshold"
+	case Status_HaltedOnOversizedFile:
+		result = "halted-on-oversized-file"
+	case Status_Staging:
+		result = "staging"
+	case Status_HaltedOnPersistentConflict:
+		result = "halted-on-persistent-conflict"
+	case Status_HaltedOnCapabilityMismatch:
+		result = "halted-on-capability-mismatch"
+	case Status_HaltedOnConfigurationIncompatibility:
+		result = "halted-on-configuration-incompatibility"
 	default:
 		result = "unknown"
 	}
@@ -114,6 +138,18 @@ func (s *Status) UnmarshalText(textBytes []byte) error {
 		*s = Status_Transitioning
 	case "saving":
 		*s = Status_Saving
+	case "halted-on-conflict-threshold":
+		*s = Status_HaltedOnConflictThreshold
+	case "halted-on-oversized-file":
+		*s = Status_HaltedOnOversizedFile
+	case "staging":
+		*s = Status_Staging
+	case "halted-on-persistent-conflict":
+		*s = Status_HaltedOnPersistentConflict
+	case "halted-on-capability-mismatch":
+		*s = Status_HaltedOnCapabilityMismatch
+	case "halted-on-configuration-incompatibility":
+		*s = Status_HaltedOnConfigurationIncompatibility
 	default:
 		return fmt.Errorf("unknown synchronization status: %s", text)
 	}
@@ -154,6 +190,11 @@ func (s *EndpointState) ensureValid() error {
 		return errors.New("excluded transition problems reported with no transition problems reported")
 	}
 
+	// Ensure that verification divergence truncation is sane.
+	if s.ExcludedVerificationDivergences > 0 && len(s.VerificationDivergences) == 0 {
+		return errors.New("excluded verification divergences reported with no verification divergences reported")
+	}
+
 	// Ensure that staging progress is valid.
 	if err := s.StagingProgress.EnsureValid(); err != nil {
 		return fmt.Errorf("invalid staging progress: %w", err)
@@ -189,6 +230,13 @@ func (s *State) EnsureValid() error {
 		return errors.New("excluded conflicts reported with no conflicts reported")
 	}
 
+	// Ensure that capability mismatches are valid.
+	for _, m := range s.CapabilityMismatches {
+		if m == nil {
+			return errors.New("nil capability mismatch detected")
+		}
+	}
+
 	// Ensure that endpoint states are valid.
 	if err := s.AlphaState.ensureValid(); err != nil {
 		return fmt.Errorf("invalid alpha endpoint state: %w", err)
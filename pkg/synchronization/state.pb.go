@@ -66,6 +66,25 @@ const (
 	// Status_Saving indicates that the session is recording synchronization
 	// history to disk.
 	Status_Saving Status = 13
+	// Status_HaltedOnConflictThreshold indicates that the session is halted due
+	// to the conflict count safety check.
+	Status_HaltedOnConflictThreshold Status = 14
+	// Status_HaltedOnOversizedFile indicates that the session is halted due to
+	// files exceeding the maximum staging file size.
+	Status_HaltedOnOversizedFile Status = 15
+	// Status_Staging indicates that the session is staging files on alpha and
+	// beta concurrently.
+	Status_Staging Status = 16
+	// Status_HaltedOnPersistentConflict indicates that the session is halted
+	// due to the conflict persistence safety check.
+	Status_HaltedOnPersistentConflict Status = 17
+	// Status_HaltedOnCapabilityMismatch indicates that the session is halted
+	// due to the alpha and beta filesystems reporting differing capabilities.
+	Status_HaltedOnCapabilityMismatch Status = 18
+	// Status_HaltedOnConfigurationIncompatibility indicates that the session is
+	// halted due to an endpoint's configuration being incompatible with the
+	// capabilities of its filesystem.
+	Status_HaltedOnConfigurationIncompatibility Status = 19
 )
 
 // Enum value maps for Status.
@@ -85,22 +104,34 @@ var (
 		11: "StagingBeta",
 		12: "Transitioning",
 		13: "Saving",
+		14: "HaltedOnConflictThreshold",
+		15: "HaltedOnOversizedFile",
+		16: "Staging",
+		17: "HaltedOnPersistentConflict",
+		18: "HaltedOnCapabilityMismatch",
+		19: "HaltedOnConfigurationIncompatibility",
 	}
 	Status_value = map[string]int32{
-		"Disconnected":           0,
-		"HaltedOnRootEmptied":    1,
-		"HaltedOnRootDeletion":   2,
-		"HaltedOnRootTypeChange": 3,
-		"ConnectingAlpha":        4,
-		"ConnectingBeta":         5,
-		"Watching":               6,
-		"Scanning":               7,
-		"WaitingForRescan":       8,
-		"Reconciling":            9,
-		"StagingAlpha":           10,
-		"StagingBeta":            11,
-		"Transitioning":          12,
-		"Saving":                 13,
+		"Disconnected":                         0,
+		"HaltedOnRootEmptied":                  1,
+		"HaltedOnRootDeletion":                 2,
+		"HaltedOnRootTypeChange":               3,
+		"ConnectingAlpha":                      4,
+		"ConnectingBeta":                       5,
+		"Watching":                             6,
+		"Scanning":                             7,
+		"WaitingForRescan":                     8,
+		"Reconciling":                          9,
+		"StagingAlpha":                         10,
+		"StagingBeta":                          11,
+		"Transitioning":                        12,
+		"Saving":                               13,
+		"HaltedOnConflictThreshold":            14,
+		"HaltedOnOversizedFile":                15,
+		"Staging":                              16,
+		"HaltedOnPersistentConflict":           17,
+		"HaltedOnCapabilityMismatch":           18,
+		"HaltedOnConfigurationIncompatibility": 19,
 	}
 )
 
@@ -180,6 +211,42 @@ type EndpointState struct {
 	// StagingProgress is the rsync staging progress. It is non-nil if and only
 	// if the endpoint is currently staging files.
 	StagingProgress *rsync.ReceiverState `protobuf:"bytes,11,opt,name=stagingProgress,proto3" json:"stagingProgress,omitempty"`
+	// ClockOffset is the measured offset (in nanoseconds) of the endpoint's
+	// clock relative to the controller's clock, with positive values indicating
+	// that the endpoint's clock is ahead. It is always zero for local
+	// endpoints.
+	ClockOffset int64 `protobuf:"varint,12,opt,name=clockOffset,proto3" json:"clockOffset,omitempty"`
+	// WatchOverflows is the number of times that the endpoint's native
+	// filesystem watcher has failed due to an internal event overflow, as of
+	// the last successful scan of the endpoint.
+	WatchOverflows uint64 `protobuf:"varint,13,opt,name=watchOverflows,proto3" json:"watchOverflows,omitempty"`
+	// ConfigurationIncompatibilities are human-readable descriptions of the
+	// ways in which the endpoint's effective configuration was incompatible
+	// with the capabilities of its filesystem, as of the last successful scan
+	// of the endpoint.
+	ConfigurationIncompatibilities []string `protobuf:"bytes,14,rep,name=configurationIncompatibilities,proto3" json:"configurationIncompatibilities,omitempty"`
+	// HashedBytes is the number of bytes of file content hashed during the last
+	// scan of the endpoint.
+	HashedBytes uint64 `protobuf:"varint,15,opt,name=hashedBytes,proto3" json:"hashedBytes,omitempty"`
+	// HashingDuration is the time (in nanoseconds) spent hashing file content
+	// during the last scan of the endpoint.
+	HashingDuration uint64 `protobuf:"varint,16,opt,name=hashingDuration,proto3" json:"hashingDuration,omitempty"`
+	// AccelerationUnavailable is a description of the reason that accelerated
+	// scanning has been unavailable on the endpoint for a sustained period (in
+	// which case scans are falling back to full scans), as of the last
+	// successful scan of the endpoint. It is empty if acceleration is
+	// available, disallowed, or only briefly unavailable.
+	AccelerationUnavailable string `protobuf:"bytes,17,opt,name=accelerationUnavailable,proto3" json:"accelerationUnavailable,omitempty"`
+	// VerificationDivergences are the paths at which the endpoint's content
+	// diverged from the last synchronized state during the last background
+	// verification pass. This list may be a truncated version of the full list
+	// if too many divergences are encountered to report via the API, in which
+	// case ExcludedVerificationDivergences will be non-zero.
+	VerificationDivergences []string `protobuf:"bytes,18,rep,name=verificationDivergences,proto3" json:"verificationDivergences,omitempty"`
+	// ExcludedVerificationDivergences is the number of divergences that have
+	// been excluded from VerificationDivergences due to truncation. This value
+	// can be non-zero only if VerificationDivergences is non-empty.
+	ExcludedVerificationDivergences uint64 `protobuf:"varint,19,opt,name=excludedVerificationDivergences,proto3" json:"excludedVerificationDivergences,omitempty"`
 }
 
 func (x *EndpointState) Reset() {
@@ -289,6 +356,128 @@ func (x *EndpointState) GetStagingProgress() *rsync.ReceiverState {
 	return nil
 }
 
+func (x *EndpointState) GetClockOffset() int64 {
+	if x != nil {
+		return x.ClockOffset
+	}
+	return 0
+}
+
+func (x *EndpointState) GetWatchOverflows() uint64 {
+	if x != nil {
+		return x.WatchOverflows
+	}
+	return 0
+}
+
+func (x *EndpointState) GetConfigurationIncompatibilities() []string {
+	if x != nil {
+		return x.ConfigurationIncompatibilities
+	}
+	return nil
+}
+
+func (x *EndpointState) GetHashedBytes() uint64 {
+	if x != nil {
+		return x.HashedBytes
+	}
+	return 0
+}
+
+func (x *EndpointState) GetHashingDuration() uint64 {
+	if x != nil {
+		return x.HashingDuration
+	}
+	return 0
+}
+
+func (x *EndpointState) GetAccelerationUnavailable() string {
+	if x != nil {
+		return x.AccelerationUnavailable
+	}
+	return ""
+}
+
+func (x *EndpointState) GetVerificationDivergences() []string {
+	if x != nil {
+		return x.VerificationDivergences
+	}
+	return nil
+}
+
+func (x *EndpointState) GetExcludedVerificationDivergences() uint64 {
+	if x != nil {
+		return x.ExcludedVerificationDivergences
+	}
+	return 0
+}
+
+// CapabilityMismatch describes a filesystem capability that differs between the
+// alpha and beta endpoints.
+type CapabilityMismatch struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// Capability is a human-readable description of the capability.
+	Capability string `protobuf:"bytes,1,opt,name=capability,proto3" json:"capability,omitempty"`
+	// Alpha indicates whether or not the alpha filesystem has the capability.
+	Alpha bool `protobuf:"varint,2,opt,name=alpha,proto3" json:"alpha,omitempty"`
+	// Beta indicates whether or not the beta filesystem has the capability.
+	Beta bool `protobuf:"varint,3,opt,name=beta,proto3" json:"beta,omitempty"`
+}
+
+func (x *CapabilityMismatch) Reset() {
+	*x = CapabilityMismatch{}
+	mi := &file_synchronization_state_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CapabilityMismatch) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CapabilityMismatch) ProtoMessage() {}
+
+func (x *CapabilityMismatch) ProtoReflect() protoreflect.Message {
+	mi := &file_synchronization_state_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CapabilityMismatch.ProtoReflect.Descriptor instead.
+func (*CapabilityMismatch) Descriptor() ([]byte, []int) {
+	return file_synchronization_state_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *CapabilityMismatch) GetCapability() string {
+	if x != nil {
+		return x.Capability
+	}
+	return ""
+}
+
+func (x *CapabilityMismatch) GetAlpha() bool {
+	if x != nil {
+		return x.Alpha
+	}
+	return false
+}
+
+func (x *CapabilityMismatch) GetBeta() bool {
+	if x != nil {
+		return x.Beta
+	}
+	return false
+}
+
 // State encodes the current state of a synchronization session. It is mutable
 // within the context of the daemon, so it should be accessed and modified in a
 // synchronized fashion. Outside of the daemon (e.g. when returned via the API),
@@ -306,6 +495,11 @@ type State struct {
 	// LastError is the last error to occur during synchronization. It is
 	// cleared after a successful synchronization cycle.
 	LastError string `protobuf:"bytes,3,opt,name=lastError,proto3" json:"lastError,omitempty"`
+	// AgentTerminated indicates whether or not LastError was caused by an
+	// endpoint's agent process terminating unexpectedly (as opposed to, e.g.,
+	// loss of connectivity to the endpoint). It is cleared along with
+	// LastError.
+	AgentTerminated bool `protobuf:"varint,10,opt,name=agentTerminated,proto3" json:"agentTerminated,omitempty"`
 	// SuccessfulCycles is the number of successful synchronization cycles to
 	// occur since successfully connecting to the endpoints.
 	SuccessfulCycles uint64 `protobuf:"varint,4,opt,name=successfulCycles,proto3" json:"successfulCycles,omitempty"`
@@ -322,11 +516,22 @@ type State struct {
 	AlphaState *EndpointState `protobuf:"bytes,7,opt,name=alphaState,proto3" json:"alphaState,omitempty"`
 	// BetaState encodes the state of the beta endpoint. It is always non-nil.
 	BetaState *EndpointState `protobuf:"bytes,8,opt,name=betaState,proto3" json:"betaState,omitempty"`
+	// CapabilityMismatches are the filesystem capabilities that differed
+	// between the alpha and beta endpoints in the last successful scan. They
+	// are only computed if both endpoints have content.
+	CapabilityMismatches []*CapabilityMismatch `protobuf:"bytes,9,rep,name=capabilityMismatches,proto3" json:"capabilityMismatches,omitempty"`
+	// Verifications is the number of background verification passes to
+	// complete since successfully connecting to the endpoints.
+	Verifications uint64 `protobuf:"varint,11,opt,name=verifications,proto3" json:"verifications,omitempty"`
+	// Warnings are non-fatal conditions identified during the last successful
+	// scan that may require user attention, such as an endpoint's entry count
+	// approaching its maximum allowed entry count.
+	Warnings []string `protobuf:"bytes,12,rep,name=warnings,proto3" json:"warnings,omitempty"`
 }
 
 func (x *State) Reset() {
 	*x = State{}
-	mi := &file_synchronization_state_proto_msgTypes[1]
+	mi := &file_synchronization_state_proto_msgTypes[2]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -338,7 +543,7 @@ func (x *State) String() string {
 func (*State) ProtoMessage() {}
 
 func (x *State) ProtoReflect() protoreflect.Message {
-	mi := &file_synchronization_state_proto_msgTypes[1]
+	mi := &file_synchronization_state_proto_msgTypes[2]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -351,7 +556,7 @@ func (x *State) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use State.ProtoReflect.Descriptor instead.
 func (*State) Descriptor() ([]byte, []int) {
-	return file_synchronization_state_proto_rawDescGZIP(), []int{1}
+	return file_synchronization_state_proto_rawDescGZIP(), []int{2}
 }
 
 func (x *State) GetSession() *Session {
@@ -375,6 +580,13 @@ func (x *State) GetLastError() string {
 	return ""
 }
 
+func (x *State) GetAgentTerminated() bool {
+	if x != nil {
+		return x.AgentTerminated
+	}
+	return false
+}
+
 func (x *State) GetSuccessfulCycles() uint64 {
 	if x != nil {
 		return x.SuccessfulCycles
@@ -410,6 +622,27 @@ func (x *State) GetBetaState() *EndpointState {
 	return nil
 }
 
+func (x *State) GetCapabilityMismatches() []*CapabilityMismatch {
+	if x != nil {
+		return x.CapabilityMismatches
+	}
+	return nil
+}
+
+func (x *State) GetVerifications() uint64 {
+	if x != nil {
+		return x.Verifications
+	}
+	return 0
+}
+
+func (x *State) GetWarnings() []string {
+	if x != nil {
+		return x.Warnings
+	}
+	return nil
+}
+
 var File_synchronization_state_proto protoreflect.FileDescriptor
 
 var file_synchronization_state_proto_rawDesc = []byte{
@@ -424,7 +657,7 @@ var file_synchronization_state_proto_rawDesc = []byte{
 	0x69, 0x6f, 0x6e, 0x2f, 0x63, 0x6f, 0x72, 0x65, 0x2f, 0x63, 0x6f, 0x6e, 0x66, 0x6c, 0x69, 0x63,
 	0x74, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x1a, 0x22, 0x73, 0x79, 0x6e, 0x63, 0x68, 0x72, 0x6f,
 	0x6e, 0x69, 0x7a, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x2f, 0x63, 0x6f, 0x72, 0x65, 0x2f, 0x70, 0x72,
-	0x6f, 0x62, 0x6c, 0x65, 0x6d, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x22, 0xf1, 0x03, 0x0a, 0x0d,
+	0x6f, 0x62, 0x6c, 0x65, 0x6d, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x22, 0x8d, 0x07, 0x0a, 0x0d,
 	0x45, 0x6e, 0x64, 0x70, 0x6f, 0x69, 0x6e, 0x74, 0x53, 0x74, 0x61, 0x74, 0x65, 0x12, 0x1c, 0x0a,
 	0x09, 0x63, 0x6f, 0x6e, 0x6e, 0x65, 0x63, 0x74, 0x65, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x08,
 	0x52, 0x09, 0x63, 0x6f, 0x6e, 0x6e, 0x65, 0x63, 0x74, 0x65, 0x64, 0x12, 0x18, 0x0a, 0x07, 0x73,
@@ -455,54 +688,109 @@ var file_synchronization_state_proto_rawDesc = []byte{
 	0x3e, 0x0a, 0x0f, 0x73, 0x74, 0x61, 0x67, 0x69, 0x6e, 0x67, 0x50, 0x72, 0x6f, 0x67, 0x72, 0x65,
 	0x73, 0x73, 0x18, 0x0b, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x14, 0x2e, 0x72, 0x73, 0x79, 0x6e, 0x63,
 	0x2e, 0x52, 0x65, 0x63, 0x65, 0x69, 0x76, 0x65, 0x72, 0x53, 0x74, 0x61, 0x74, 0x65, 0x52, 0x0f,
-	0x73, 0x74, 0x61, 0x67, 0x69, 0x6e, 0x67, 0x50, 0x72, 0x6f, 0x67, 0x72, 0x65, 0x73, 0x73, 0x22,
-	0x90, 0x03, 0x0a, 0x05, 0x53, 0x74, 0x61, 0x74, 0x65, 0x12, 0x32, 0x0a, 0x07, 0x73, 0x65, 0x73,
-	0x73, 0x69, 0x6f, 0x6e, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x18, 0x2e, 0x73, 0x79, 0x6e,
-	0x63, 0x68, 0x72, 0x6f, 0x6e, 0x69, 0x7a, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x2e, 0x53, 0x65, 0x73,
-	0x73, 0x69, 0x6f, 0x6e, 0x52, 0x07, 0x73, 0x65, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x12, 0x2f, 0x0a,
-	0x06, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x17, 0x2e,
+	0x73, 0x74, 0x61, 0x67, 0x69, 0x6e, 0x67, 0x50, 0x72, 0x6f, 0x67, 0x72, 0x65, 0x73, 0x73, 0x12,
+	0x20, 0x0a, 0x0b, 0x63, 0x6c, 0x6f, 0x63, 0x6b, 0x4f, 0x66, 0x66, 0x73, 0x65, 0x74, 0x18, 0x0c,
+	0x20, 0x01, 0x28, 0x03, 0x52, 0x0b, 0x63, 0x6c, 0x6f, 0x63, 0x6b, 0x4f, 0x66, 0x66, 0x73, 0x65,
+	0x74, 0x12, 0x26, 0x0a, 0x0e, 0x77, 0x61, 0x74, 0x63, 0x68, 0x4f, 0x76, 0x65, 0x72, 0x66, 0x6c,
+	0x6f, 0x77, 0x73, 0x18, 0x0d, 0x20, 0x01, 0x28, 0x04, 0x52, 0x0e, 0x77, 0x61, 0x74, 0x63, 0x68,
+	0x4f, 0x76, 0x65, 0x72, 0x66, 0x6c, 0x6f, 0x77, 0x73, 0x12, 0x46, 0x0a, 0x1e, 0x63, 0x6f, 0x6e,
+	0x66, 0x69, 0x67, 0x75, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x49, 0x6e, 0x63, 0x6f, 0x6d, 0x70,
+	0x61, 0x74, 0x69, 0x62, 0x69, 0x6c, 0x69, 0x74, 0x69, 0x65, 0x73, 0x18, 0x0e, 0x20, 0x03, 0x28,
+	0x09, 0x52, 0x1e, 0x63, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x75, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e,
+	0x49, 0x6e, 0x63, 0x6f, 0x6d, 0x70, 0x61, 0x74, 0x69, 0x62, 0x69, 0x6c, 0x69, 0x74, 0x69, 0x65,
+	0x73, 0x12, 0x20, 0x0a, 0x0b, 0x68, 0x61, 0x73, 0x68, 0x65, 0x64, 0x42, 0x79, 0x74, 0x65, 0x73,
+	0x18, 0x0f, 0x20, 0x01, 0x28, 0x04, 0x52, 0x0b, 0x68, 0x61, 0x73, 0x68, 0x65, 0x64, 0x42, 0x79,
+	0x74, 0x65, 0x73, 0x12, 0x28, 0x0a, 0x0f, 0x68, 0x61, 0x73, 0x68, 0x69, 0x6e, 0x67, 0x44, 0x75,
+	0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x10, 0x20, 0x01, 0x28, 0x04, 0x52, 0x0f, 0x68, 0x61,
+	0x73, 0x68, 0x69, 0x6e, 0x67, 0x44, 0x75, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x38, 0x0a,
+	0x17, 0x61, 0x63, 0x63, 0x65, 0x6c, 0x65, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x55, 0x6e, 0x61,
+	0x76, 0x61, 0x69, 0x6c, 0x61, 0x62, 0x6c, 0x65, 0x18, 0x11, 0x20, 0x01, 0x28, 0x09, 0x52, 0x17,
+	0x61, 0x63, 0x63, 0x65, 0x6c, 0x65, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x55, 0x6e, 0x61, 0x76,
+	0x61, 0x69, 0x6c, 0x61, 0x62, 0x6c, 0x65, 0x12, 0x38, 0x0a, 0x17, 0x76, 0x65, 0x72, 0x69, 0x66,
+	0x69, 0x63, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x44, 0x69, 0x76, 0x65, 0x72, 0x67, 0x65, 0x6e, 0x63,
+	0x65, 0x73, 0x18, 0x12, 0x20, 0x03, 0x28, 0x09, 0x52, 0x17, 0x76, 0x65, 0x72, 0x69, 0x66, 0x69,
+	0x63, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x44, 0x69, 0x76, 0x65, 0x72, 0x67, 0x65, 0x6e, 0x63, 0x65,
+	0x73, 0x12, 0x48, 0x0a, 0x1f, 0x65, 0x78, 0x63, 0x6c, 0x75, 0x64, 0x65, 0x64, 0x56, 0x65, 0x72,
+	0x69, 0x66, 0x69, 0x63, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x44, 0x69, 0x76, 0x65, 0x72, 0x67, 0x65,
+	0x6e, 0x63, 0x65, 0x73, 0x18, 0x13, 0x20, 0x01, 0x28, 0x04, 0x52, 0x1f, 0x65, 0x78, 0x63, 0x6c,
+	0x75, 0x64, 0x65, 0x64, 0x56, 0x65, 0x72, 0x69, 0x66, 0x69, 0x63, 0x61, 0x74, 0x69, 0x6f, 0x6e,
+	0x44, 0x69, 0x76, 0x65, 0x72, 0x67, 0x65, 0x6e, 0x63, 0x65, 0x73, 0x22, 0x5e, 0x0a, 0x12, 0x43,
+	0x61, 0x70, 0x61, 0x62, 0x69, 0x6c, 0x69, 0x74, 0x79, 0x4d, 0x69, 0x73, 0x6d, 0x61, 0x74, 0x63,
+	0x68, 0x12, 0x1e, 0x0a, 0x0a, 0x63, 0x61, 0x70, 0x61, 0x62, 0x69, 0x6c, 0x69, 0x74, 0x79, 0x18,
+	0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0a, 0x63, 0x61, 0x70, 0x61, 0x62, 0x69, 0x6c, 0x69, 0x74,
+	0x79, 0x12, 0x14, 0x0a, 0x05, 0x61, 0x6c, 0x70, 0x68, 0x61, 0x18, 0x02, 0x20, 0x01, 0x28, 0x08,
+	0x52, 0x05, 0x61, 0x6c, 0x70, 0x68, 0x61, 0x12, 0x12, 0x0a, 0x04, 0x62, 0x65, 0x74, 0x61, 0x18,
+	0x03, 0x20, 0x01, 0x28, 0x08, 0x52, 0x04, 0x62, 0x65, 0x74, 0x61, 0x22, 0xd5, 0x04, 0x0a, 0x05,
+	0x53, 0x74, 0x61, 0x74, 0x65, 0x12, 0x32, 0x0a, 0x07, 0x73, 0x65, 0x73, 0x73, 0x69, 0x6f, 0x6e,
+	0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x18, 0x2e, 0x73, 0x79, 0x6e, 0x63, 0x68, 0x72, 0x6f,
+	0x6e, 0x69, 0x7a, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x2e, 0x53, 0x65, 0x73, 0x73, 0x69, 0x6f, 0x6e,
+	0x52, 0x07, 0x73, 0x65, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x12, 0x2f, 0x0a, 0x06, 0x73, 0x74, 0x61,
+	0x74, 0x75, 0x73, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x17, 0x2e, 0x73, 0x79, 0x6e, 0x63,
+	0x68, 0x72, 0x6f, 0x6e, 0x69, 0x7a, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x2e, 0x53, 0x74, 0x61, 0x74,
+	0x75, 0x73, 0x52, 0x06, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x12, 0x1c, 0x0a, 0x09, 0x6c, 0x61,
+	0x73, 0x74, 0x45, 0x72, 0x72, 0x6f, 0x72, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x6c,
+	0x61, 0x73, 0x74, 0x45, 0x72, 0x72, 0x6f, 0x72, 0x12, 0x28, 0x0a, 0x0f, 0x61, 0x67, 0x65, 0x6e,
+	0x74, 0x54, 0x65, 0x72, 0x6d, 0x69, 0x6e, 0x61, 0x74, 0x65, 0x64, 0x18, 0x0a, 0x20, 0x01, 0x28,
+	0x08, 0x52, 0x0f, 0x61, 0x67, 0x65, 0x6e, 0x74, 0x54, 0x65, 0x72, 0x6d, 0x69, 0x6e, 0x61, 0x74,
+	0x65, 0x64, 0x12, 0x2a, 0x0a, 0x10, 0x73, 0x75, 0x63, 0x63, 0x65, 0x73, 0x73, 0x66, 0x75, 0x6c,
+	0x43, 0x79, 0x63, 0x6c, 0x65, 0x73, 0x18, 0x04, 0x20, 0x01, 0x28, 0x04, 0x52, 0x10, 0x73, 0x75,
+	0x63, 0x63, 0x65, 0x73, 0x73, 0x66, 0x75, 0x6c, 0x43, 0x79, 0x63, 0x6c, 0x65, 0x73, 0x12, 0x2c,
+	0x0a, 0x09, 0x63, 0x6f, 0x6e, 0x66, 0x6c, 0x69, 0x63, 0x74, 0x73, 0x18, 0x05, 0x20, 0x03, 0x28,
+	0x0b, 0x32, 0x0e, 0x2e, 0x63, 0x6f, 0x72, 0x65, 0x2e, 0x43, 0x6f, 0x6e, 0x66, 0x6c, 0x69, 0x63,
+	0x74, 0x52, 0x09, 0x63, 0x6f, 0x6e, 0x66, 0x6c, 0x69, 0x63, 0x74, 0x73, 0x12, 0x2c, 0x0a, 0x11,
+	0x65, 0x78, 0x63, 0x6c, 0x75, 0x64, 0x65, 0x64, 0x43, 0x6f, 0x6e, 0x66, 0x6c, 0x69, 0x63, 0x74,
+	0x73, 0x18, 0x06, 0x20, 0x01, 0x28, 0x04, 0x52, 0x11, 0x65, 0x78, 0x63, 0x6c, 0x75, 0x64, 0x65,
+	0x64, 0x43, 0x6f, 0x6e, 0x66, 0x6c, 0x69, 0x63, 0x74, 0x73, 0x12, 0x3e, 0x0a, 0x0a, 0x61, 0x6c,
+	0x70, 0x68, 0x61, 0x53, 0x74, 0x61, 0x74, 0x65, 0x18, 0x07, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1e,
+	0x2e, 0x73, 0x79, 0x6e, 0x63, 0x68, 0x72, 0x6f, 0x6e, 0x69, 0x7a, 0x61, 0x74, 0x69, 0x6f, 0x6e,
+	0x2e, 0x45, 0x6e, 0x64, 0x70, 0x6f, 0x69, 0x6e, 0x74, 0x53, 0x74, 0x61, 0x74, 0x65, 0x52, 0x0a,
+	0x61, 0x6c, 0x70, 0x68, 0x61, 0x53, 0x74, 0x61, 0x74, 0x65, 0x12, 0x3c, 0x0a, 0x09, 0x62, 0x65,
+	0x74, 0x61, 0x53, 0x74, 0x61, 0x74, 0x65, 0x18, 0x08, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1e, 0x2e,
 	0x73, 0x79, 0x6e, 0x63, 0x68, 0x72, 0x6f, 0x6e, 0x69, 0x7a, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x2e,
-	0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x52, 0x06, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x12, 0x1c,
-	0x0a, 0x09, 0x6c, 0x61, 0x73, 0x74, 0x45, 0x72, 0x72, 0x6f, 0x72, 0x18, 0x03, 0x20, 0x01, 0x28,
-	0x09, 0x52, 0x09, 0x6c, 0x61, 0x73, 0x74, 0x45, 0x72, 0x72, 0x6f, 0x72, 0x12, 0x2a, 0x0a, 0x10,
-	0x73, 0x75, 0x63, 0x63, 0x65, 0x73, 0x73, 0x66, 0x75, 0x6c, 0x43, 0x79, 0x63, 0x6c, 0x65, 0x73,
-	0x18, 0x04, 0x20, 0x01, 0x28, 0x04, 0x52, 0x10, 0x73, 0x75, 0x63, 0x63, 0x65, 0x73, 0x73, 0x66,
-	0x75, 0x6c, 0x43, 0x79, 0x63, 0x6c, 0x65, 0x73, 0x12, 0x2c, 0x0a, 0x09, 0x63, 0x6f, 0x6e, 0x66,
-	0x6c, 0x69, 0x63, 0x74, 0x73, 0x18, 0x05, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x0e, 0x2e, 0x63, 0x6f,
-	0x72, 0x65, 0x2e, 0x43, 0x6f, 0x6e, 0x66, 0x6c, 0x69, 0x63, 0x74, 0x52, 0x09, 0x63, 0x6f, 0x6e,
-	0x66, 0x6c, 0x69, 0x63, 0x74, 0x73, 0x12, 0x2c, 0x0a, 0x11, 0x65, 0x78, 0x63, 0x6c, 0x75, 0x64,
-	0x65, 0x64, 0x43, 0x6f, 0x6e, 0x66, 0x6c, 0x69, 0x63, 0x74, 0x73, 0x18, 0x06, 0x20, 0x01, 0x28,
-	0x04, 0x52, 0x11, 0x65, 0x78, 0x63, 0x6c, 0x75, 0x64, 0x65, 0x64, 0x43, 0x6f, 0x6e, 0x66, 0x6c,
-	0x69, 0x63, 0x74, 0x73, 0x12, 0x3e, 0x0a, 0x0a, 0x61, 0x6c, 0x70, 0x68, 0x61, 0x53, 0x74, 0x61,
-	0x74, 0x65, 0x18, 0x07, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1e, 0x2e, 0x73, 0x79, 0x6e, 0x63, 0x68,
-	0x72, 0x6f, 0x6e, 0x69, 0x7a, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x2e, 0x45, 0x6e, 0x64, 0x70, 0x6f,
-	0x69, 0x6e, 0x74, 0x53, 0x74, 0x61, 0x74, 0x65, 0x52, 0x0a, 0x61, 0x6c, 0x70, 0x68, 0x61, 0x53,
-	0x74, 0x61, 0x74, 0x65, 0x12, 0x3c, 0x0a, 0x09, 0x62, 0x65, 0x74, 0x61, 0x53, 0x74, 0x61, 0x74,
-	0x65, 0x18, 0x08, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1e, 0x2e, 0x73, 0x79, 0x6e, 0x63, 0x68, 0x72,
-	0x6f, 0x6e, 0x69, 0x7a, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x2e, 0x45, 0x6e, 0x64, 0x70, 0x6f, 0x69,
-	0x6e, 0x74, 0x53, 0x74, 0x61, 0x74, 0x65, 0x52, 0x09, 0x62, 0x65, 0x74, 0x61, 0x53, 0x74, 0x61,
-	0x74, 0x65, 0x2a, 0x97, 0x02, 0x0a, 0x06, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x12, 0x10, 0x0a,
-	0x0c, 0x44, 0x69, 0x73, 0x63, 0x6f, 0x6e, 0x6e, 0x65, 0x63, 0x74, 0x65, 0x64, 0x10, 0x00, 0x12,
-	0x17, 0x0a, 0x13, 0x48, 0x61, 0x6c, 0x74, 0x65, 0x64, 0x4f, 0x6e, 0x52, 0x6f, 0x6f, 0x74, 0x45,
-	0x6d, 0x70, 0x74, 0x69, 0x65, 0x64, 0x10, 0x01, 0x12, 0x18, 0x0a, 0x14, 0x48, 0x61, 0x6c, 0x74,
-	0x65, 0x64, 0x4f, 0x6e, 0x52, 0x6f, 0x6f, 0x74, 0x44, 0x65, 0x6c, 0x65, 0x74, 0x69, 0x6f, 0x6e,
-	0x10, 0x02, 0x12, 0x1a, 0x0a, 0x16, 0x48, 0x61, 0x6c, 0x74, 0x65, 0x64, 0x4f, 0x6e, 0x52, 0x6f,
-	0x6f, 0x74, 0x54, 0x79, 0x70, 0x65, 0x43, 0x68, 0x61, 0x6e, 0x67, 0x65, 0x10, 0x03, 0x12, 0x13,
-	0x0a, 0x0f, 0x43, 0x6f, 0x6e, 0x6e, 0x65, 0x63, 0x74, 0x69, 0x6e, 0x67, 0x41, 0x6c, 0x70, 0x68,
-	0x61, 0x10, 0x04, 0x12, 0x12, 0x0a, 0x0e, 0x43, 0x6f, 0x6e, 0x6e, 0x65, 0x63, 0x74, 0x69, 0x6e,
-	0x67, 0x42, 0x65, 0x74, 0x61, 0x10, 0x05, 0x12, 0x0c, 0x0a, 0x08, 0x57, 0x61, 0x74, 0x63, 0x68,
-	0x69, 0x6e, 0x67, 0x10, 0x06, 0x12, 0x0c, 0x0a, 0x08, 0x53, 0x63, 0x61, 0x6e, 0x6e, 0x69, 0x6e,
-	0x67, 0x10, 0x07, 0x12, 0x14, 0x0a, 0x10, 0x57, 0x61, 0x69, 0x74, 0x69, 0x6e, 0x67, 0x46, 0x6f,
-	0x72, 0x52, 0x65, 0x73, 0x63, 0x61, 0x6e, 0x10, 0x08, 0x12, 0x0f, 0x0a, 0x0b, 0x52, 0x65, 0x63,
-	0x6f, 0x6e, 0x63, 0x69, 0x6c, 0x69, 0x6e, 0x67, 0x10, 0x09, 0x12, 0x10, 0x0a, 0x0c, 0x53, 0x74,
-	0x61, 0x67, 0x69, 0x6e, 0x67, 0x41, 0x6c, 0x70, 0x68, 0x61, 0x10, 0x0a, 0x12, 0x0f, 0x0a, 0x0b,
-	0x53, 0x74, 0x61, 0x67, 0x69, 0x6e, 0x67, 0x42, 0x65, 0x74, 0x61, 0x10, 0x0b, 0x12, 0x11, 0x0a,
-	0x0d, 0x54, 0x72, 0x61, 0x6e, 0x73, 0x69, 0x74, 0x69, 0x6f, 0x6e, 0x69, 0x6e, 0x67, 0x10, 0x0c,
-	0x12, 0x0a, 0x0a, 0x06, 0x53, 0x61, 0x76, 0x69, 0x6e, 0x67, 0x10, 0x0d, 0x42, 0x33, 0x5a, 0x31,
-	0x67, 0x69, 0x74, 0x68, 0x75, 0x62, 0x2e, 0x63, 0x6f, 0x6d, 0x2f, 0x6d, 0x75, 0x74, 0x61, 0x67,
-	0x65, 0x6e, 0x2d, 0x69, 0x6f, 0x2f, 0x6d, 0x75, 0x74, 0x61, 0x67, 0x65, 0x6e, 0x2f, 0x70, 0x6b,
-	0x67, 0x2f, 0x73, 0x79, 0x6e, 0x63, 0x68, 0x72, 0x6f, 0x6e, 0x69, 0x7a, 0x61, 0x74, 0x69, 0x6f,
-	0x6e, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
+	0x45, 0x6e, 0x64, 0x70, 0x6f, 0x69, 0x6e, 0x74, 0x53, 0x74, 0x61, 0x74, 0x65, 0x52, 0x09, 0x62,
+	0x65, 0x74, 0x61, 0x53, 0x74, 0x61, 0x74, 0x65, 0x12, 0x57, 0x0a, 0x14, 0x63, 0x61, 0x70, 0x61,
+	0x62, 0x69, 0x6c, 0x69, 0x74, 0x79, 0x4d, 0x69, 0x73, 0x6d, 0x61, 0x74, 0x63, 0x68, 0x65, 0x73,
+	0x18, 0x09, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x23, 0x2e, 0x73, 0x79, 0x6e, 0x63, 0x68, 0x72, 0x6f,
+	0x6e, 0x69, 0x7a, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x2e, 0x43, 0x61, 0x70, 0x61, 0x62, 0x69, 0x6c,
+	0x69, 0x74, 0x79, 0x4d, 0x69, 0x73, 0x6d, 0x61, 0x74, 0x63, 0x68, 0x52, 0x14, 0x63, 0x61, 0x70,
+	0x61, 0x62, 0x69, 0x6c, 0x69, 0x74, 0x79, 0x4d, 0x69, 0x73, 0x6d, 0x61, 0x74, 0x63, 0x68, 0x65,
+	0x73, 0x12, 0x24, 0x0a, 0x0d, 0x76, 0x65, 0x72, 0x69, 0x66, 0x69, 0x63, 0x61, 0x74, 0x69, 0x6f,
+	0x6e, 0x73, 0x18, 0x0b, 0x20, 0x01, 0x28, 0x04, 0x52, 0x0d, 0x76, 0x65, 0x72, 0x69, 0x66, 0x69,
+	0x63, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x12, 0x1a, 0x0a, 0x08, 0x77, 0x61, 0x72, 0x6e, 0x69,
+	0x6e, 0x67, 0x73, 0x18, 0x0c, 0x20, 0x03, 0x28, 0x09, 0x52, 0x08, 0x77, 0x61, 0x72, 0x6e, 0x69,
+	0x6e, 0x67, 0x73, 0x2a, 0xc8, 0x03, 0x0a, 0x06, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x12, 0x10,
+	0x0a, 0x0c, 0x44, 0x69, 0x73, 0x63, 0x6f, 0x6e, 0x6e, 0x65, 0x63, 0x74, 0x65, 0x64, 0x10, 0x00,
+	0x12, 0x17, 0x0a, 0x13, 0x48, 0x61, 0x6c, 0x74, 0x65, 0x64, 0x4f, 0x6e, 0x52, 0x6f, 0x6f, 0x74,
+	0x45, 0x6d, 0x70, 0x74, 0x69, 0x65, 0x64, 0x10, 0x01, 0x12, 0x18, 0x0a, 0x14, 0x48, 0x61, 0x6c,
+	0x74, 0x65, 0x64, 0x4f, 0x6e, 0x52, 0x6f, 0x6f, 0x74, 0x44, 0x65, 0x6c, 0x65, 0x74, 0x69, 0x6f,
+	0x6e, 0x10, 0x02, 0x12, 0x1a, 0x0a, 0x16, 0x48, 0x61, 0x6c, 0x74, 0x65, 0x64, 0x4f, 0x6e, 0x52,
+	0x6f, 0x6f, 0x74, 0x54, 0x79, 0x70, 0x65, 0x43, 0x68, 0x61, 0x6e, 0x67, 0x65, 0x10, 0x03, 0x12,
+	0x13, 0x0a, 0x0f, 0x43, 0x6f, 0x6e, 0x6e, 0x65, 0x63, 0x74, 0x69, 0x6e, 0x67, 0x41, 0x6c, 0x70,
+	0x68, 0x61, 0x10, 0x04, 0x12, 0x12, 0x0a, 0x0e, 0x43, 0x6f, 0x6e, 0x6e, 0x65, 0x63, 0x74, 0x69,
+	0x6e, 0x67, 0x42, 0x65, 0x74, 0x61, 0x10, 0x05, 0x12, 0x0c, 0x0a, 0x08, 0x57, 0x61, 0x74, 0x63,
+	0x68, 0x69, 0x6e, 0x67, 0x10, 0x06, 0x12, 0x0c, 0x0a, 0x08, 0x53, 0x63, 0x61, 0x6e, 0x6e, 0x69,
+	0x6e, 0x67, 0x10, 0x07, 0x12, 0x14, 0x0a, 0x10, 0x57, 0x61, 0x69, 0x74, 0x69, 0x6e, 0x67, 0x46,
+	0x6f, 0x72, 0x52, 0x65, 0x73, 0x63, 0x61, 0x6e, 0x10, 0x08, 0x12, 0x0f, 0x0a, 0x0b, 0x52, 0x65,
+	0x63, 0x6f, 0x6e, 0x63, 0x69, 0x6c, 0x69, 0x6e, 0x67, 0x10, 0x09, 0x12, 0x10, 0x0a, 0x0c, 0x53,
+	0x74, 0x61, 0x67, 0x69, 0x6e, 0x67, 0x41, 0x6c, 0x70, 0x68, 0x61, 0x10, 0x0a, 0x12, 0x0f, 0x0a,
+	0x0b, 0x53, 0x74, 0x61, 0x67, 0x69, 0x6e, 0x67, 0x42, 0x65, 0x74, 0x61, 0x10, 0x0b, 0x12, 0x11,
+	0x0a, 0x0d, 0x54, 0x72, 0x61, 0x6e, 0x73, 0x69, 0x74, 0x69, 0x6f, 0x6e, 0x69, 0x6e, 0x67, 0x10,
+	0x0c, 0x12, 0x0a, 0x0a, 0x06, 0x53, 0x61, 0x76, 0x69, 0x6e, 0x67, 0x10, 0x0d, 0x12, 0x1d, 0x0a,
+	0x19, 0x48, 0x61, 0x6c, 0x74, 0x65, 0x64, 0x4f, 0x6e, 0x43, 0x6f, 0x6e, 0x66, 0x6c, 0x69, 0x63,
+	0x74, 0x54, 0x68, 0x72, 0x65, 0x73, 0x68, 0x6f, 0x6c, 0x64, 0x10, 0x0e, 0x12, 0x19, 0x0a, 0x15,
+	0x48, 0x61, 0x6c, 0x74, 0x65, 0x64, 0x4f, 0x6e, 0x4f, 0x76, 0x65, 0x72, 0x73, 0x69, 0x7a, 0x65,
+	0x64, 0x46, 0x69, 0x6c, 0x65, 0x10, 0x0f, 0x12, 0x0b, 0x0a, 0x07, 0x53, 0x74, 0x61, 0x67, 0x69,
+	0x6e, 0x67, 0x10, 0x10, 0x12, 0x1e, 0x0a, 0x1a, 0x48, 0x61, 0x6c, 0x74, 0x65, 0x64, 0x4f, 0x6e,
+	0x50, 0x65, 0x72, 0x73, 0x69, 0x73, 0x74, 0x65, 0x6e, 0x74, 0x43, 0x6f, 0x6e, 0x66, 0x6c, 0x69,
+	0x63, 0x74, 0x10, 0x11, 0x12, 0x1e, 0x0a, 0x1a, 0x48, 0x61, 0x6c, 0x74, 0x65, 0x64, 0x4f, 0x6e,
+	0x43, 0x61, 0x70, 0x61, 0x62, 0x69, 0x6c, 0x69, 0x74, 0x79, 0x4d, 0x69, 0x73, 0x6d, 0x61, 0x74,
+	0x63, 0x68, 0x10, 0x12, 0x12, 0x28, 0x0a, 0x24, 0x48, 0x61, 0x6c, 0x74, 0x65, 0x64, 0x4f, 0x6e,
+	0x43, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x75, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x49, 0x6e, 0x63,
+	0x6f, 0x6d, 0x70, 0x61, 0x74, 0x69, 0x62, 0x69, 0x6c, 0x69, 0x74, 0x79, 0x10, 0x13, 0x42, 0x33,
+	0x5a, 0x31, 0x67, 0x69, 0x74, 0x68, 0x75, 0x62, 0x2e, 0x63, 0x6f, 0x6d, 0x2f, 0x6d, 0x75, 0x74,
+	0x61, 0x67, 0x65, 0x6e, 0x2d, 0x69, 0x6f, 0x2f, 0x6d, 0x75, 0x74, 0x61, 0x67, 0x65, 0x6e, 0x2f,
+	0x70, 0x6b, 0x67, 0x2f, 0x73, 0x79, 0x6e, 0x63, 0x68, 0x72, 0x6f, 0x6e, 0x69, 0x7a, 0x61, 0x74,
+	0x69, 0x6f, 0x6e, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
 }
 
 var (
@@ -518,30 +806,32 @@ func file_synchronization_state_proto_rawDescGZIP() []byte {
 }
 
 var file_synchronization_state_proto_enumTypes = make([]protoimpl.EnumInfo, 1)
-var file_synchronization_state_proto_msgTypes = make([]protoimpl.MessageInfo, 2)
+var file_synchronization_state_proto_msgTypes = make([]protoimpl.MessageInfo, 3)
 var file_synchronization_state_proto_goTypes = []any{
 	(Status)(0),                 // 0: synchronization.Status
 	(*EndpointState)(nil),       // 1: synchronization.EndpointState
-	(*State)(nil),               // 2: synchronization.State
-	(*core.Problem)(nil),        // 3: core.Problem
-	(*rsync.ReceiverState)(nil), // 4: rsync.ReceiverState
-	(*Session)(nil),             // 5: synchronization.Session
-	(*core.Conflict)(nil),       // 6: core.Conflict
+	(*CapabilityMismatch)(nil),  // 2: synchronization.CapabilityMismatch
+	(*State)(nil),               // 3: synchronization.State
+	(*core.Problem)(nil),        // 4: core.Problem
+	(*rsync.ReceiverState)(nil), // 5: rsync.ReceiverState
+	(*Session)(nil),             // 6: synchronization.Session
+	(*core.Conflict)(nil),       // 7: core.Conflict
 }
 var file_synchronization_state_proto_depIdxs = []int32{
-	3, // 0: synchronization.EndpointState.scanProblems:type_name -> core.Problem
-	3, // 1: synchronization.EndpointState.transitionProblems:type_name -> core.Problem
-	4, // 2: synchronization.EndpointState.stagingProgress:type_name -> rsync.ReceiverState
-	5, // 3: synchronization.State.session:type_name -> synchronization.Session
+	4, // 0: synchronization.EndpointState.scanProblems:type_name -> core.Problem
+	4, // 1: synchronization.EndpointState.transitionProblems:type_name -> core.Problem
+	5, // 2: synchronization.EndpointState.stagingProgress:type_name -> rsync.ReceiverState
+	6, // 3: synchronization.State.session:type_name -> synchronization.Session
 	0, // 4: synchronization.State.status:type_name -> synchronization.Status
-	6, // 5: synchronization.State.conflicts:type_name -> core.Conflict
+	7, // 5: synchronization.State.conflicts:type_name -> core.Conflict
 	1, // 6: synchronization.State.alphaState:type_name -> synchronization.EndpointState
 	1, // 7: synchronization.State.betaState:type_name -> synchronization.EndpointState
-	8, // [8:8] is the sub-list for method output_type
-	8, // [8:8] is the sub-list for method input_type
-	8, // [8:8] is the sub-list for extension type_name
-	8, // [8:8] is the sub-list for extension extendee
-	0, // [0:8] is the sub-list for field type_name
+	2, // 8: synchronization.State.capabilityMismatches:type_name -> synchronization.CapabilityMismatch
+	9, // [9:9] is the sub-list for method output_type
+	9, // [9:9] is the sub-list for method input_type
+	9, // [9:9] is the sub-list for extension type_name
+	9, // [9:9] is the sub-list for extension extendee
+	0, // [0:9] is the sub-list for field type_name
 }
 
 func init() { file_synchronization_state_proto_init() }
@@ -556,7 +846,7 @@ func file_synchronization_state_proto_init() {
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: file_synchronization_state_proto_rawDesc,
 			NumEnums:      1,
-			NumMessages:   2,
+			NumMessages:   3,
 			NumExtensions: 0,
 			NumServices:   0,
 		},
@@ -29,6 +29,12 @@ func TestStatusUnmarshal(t *testing.T) {
 		{"staging-beta", Status_StagingBeta, false},
 		{"transitioning", Status_Transitioning, false},
 		{"saving", Status_Saving, false},
+		{"halted-on-conflict-threshold", Status_HaltedOnConflictThreshold, false},
+		{"halted-on-oversized-file", Status_HaltedOnOversizedFile, false},
+		{"staging", Status_Staging, false},
+		{"halted-on-persistent-conflict", Status_HaltedOnPersistentConflict, false},
+		{"halted-on-capability-mismatch", Status_HaltedOnCapabilityMismatch, false},
+		{"halted-on-configuration-incompatibility", Status_HaltedOnConfigurationIncompatibility, false},
 	}
 
 	// Process test cases.
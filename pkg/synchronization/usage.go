@@ -0,0 +1,69 @@
+package synchronization
+
+import (
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+
+	"github.com/mutagen-io/mutagen/pkg/filesystem"
+)
+
+// Usage encodes aggregate resource usage across synchronization sessions.
+type Usage struct {
+	// Sessions is the total number of sessions.
+	Sessions uint64
+	// TrackedEntries is the total number of synchronizable entries contained in
+	// the last snapshots from all session endpoints.
+	TrackedEntries uint64
+	// SnapshotMemory is the approximate memory usage (in bytes) of snapshots
+	// and ancestors held by synchronization loops. It's computed using the
+	// serialized size of the held content and is thus only an approximation.
+	// Scan caches are held by endpoints and aren't included.
+	SnapshotMemory uint64
+	// Connections is the number of currently connected session endpoints.
+	Connections uint64
+	// StagingDiskUsage is the total size (in bytes) of files in the staging
+	// directory of the current process. It doesn't include staging performed
+	// by remote endpoints or staging performed in neighboring directories. It
+	// is only computed if requested.
+	StagingDiskUsage uint64
+}
+
+// stagingDiskUsage computes the total size of files in the staging directory.
+func stagingDiskUsage() (uint64, error) {
+	// Compute the path to the staging directory. We don't attempt to create
+	// it, because if it doesn't exist, then there's no usage to report.
+	stagingDirectoryPath, err := filesystem.Mutagen(false, filesystem.MutagenSynchronizationStagingDirectoryName)
+	if err != nil {
+		return 0, fmt.Errorf("unable to compute staging directory path: %w", err)
+	}
+
+	// Walk the staging directory and total file sizes. Since staging roots may
+	// be modified or removed concurrently, we ignore non-existence errors.
+	var total uint64
+	err = filepath.WalkDir(stagingDirectoryPath, func(_ string, entry fs.DirEntry, err error) error {
+		if err != nil {
+			if os.IsNotExist(err) {
+				return nil
+			}
+			return err
+		} else if !entry.Type().IsRegular() {
+			return nil
+		}
+		if info, err := entry.Info(); err != nil {
+			if !os.IsNotExist(err) {
+				return err
+			}
+		} else {
+			total += uint64(info.Size())
+		}
+		return nil
+	})
+	if err != nil {
+		return 0, fmt.Errorf("unable to walk staging directory: %w", err)
+	}
+
+	// Success.
+	return total, nil
+}
@@ -0,0 +1,73 @@
+package synchronization
+
+import (
+	"fmt"
+)
+
+// IsDefault indicates whether or not the verification mode is
+// VerificationMode_VerificationModeDefault.
+func (m VerificationMode) IsDefault() bool {
+	return m == VerificationMode_VerificationModeDefault
+}
+
+// MarshalText implements encoding.TextMarshaler.MarshalText.
+func (m VerificationMode) MarshalText() ([]byte, error) {
+	var result string
+	switch m {
+	case VerificationMode_VerificationModeDefault:
+	case VerificationMode_VerificationModeDisabled:
+		result = "disabled"
+	case VerificationMode_VerificationModePeriodic:
+		result = "periodic"
+	default:
+		result = "unknown"
+	}
+	return []byte(result), nil
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler.UnmarshalText.
+func (m *VerificationMode) UnmarshalText(textBytes []byte) error {
+	// Convert the bytes to a string.
+	text := string(textBytes)
+
+	// Convert to a verification mode.
+	switch text {
+	case "disabled":
+		*m = VerificationMode_VerificationModeDisabled
+	case "periodic":
+		*m = VerificationMode_VerificationModePeriodic
+	default:
+		return fmt.Errorf("unknown verification mode specification: %s", text)
+	}
+
+	// Success.
+	return nil
+}
+
+// Supported indicates whether or not a particular verification mode is a
+// valid, non-default value.
+func (m VerificationMode) Supported() bool {
+	switch m {
+	case VerificationMode_VerificationModeDisabled:
+		return true
+	case VerificationMode_VerificationModePeriodic:
+		return true
+	default:
+		return false
+	}
+}
+
+// Description returns a human-readable description of a verification
+// mode.
+func (m VerificationMode) Description() string {
+	switch m {
+	case VerificationMode_VerificationModeDefault:
+		return "Default"
+	case VerificationMode_VerificationModeDisabled:
+		return "Disabled"
+	case VerificationMode_VerificationModePeriodic:
+		return "Periodic"
+	default:
+		return "Unknown"
+	}
+}
@@ -0,0 +1,91 @@
+package synchronization
+
+import (
+	"testing"
+)
+
+// TestVerificationModeUnmarshal tests that unmarshaling from a string
+// specification succeeeds for VerificationMode.
+func TestVerificationModeUnmarshal(t *testing.T) {
+	// Set up test cases.
+	testCases := []struct {
+		text          string
+		expectedMode  VerificationMode
+		expectFailure bool
+	}{
+		{"", VerificationMode_VerificationModeDefault, true},
+		{"asdf", VerificationMode_VerificationModeDefault, true},
+		{"disabled", VerificationMode_VerificationModeDisabled, false},
+		{"periodic", VerificationMode_VerificationModePeriodic, false},
+	}
+
+	// Process test cases.
+	for _, testCase := range testCases {
+		var mode VerificationMode
+		if err := mode.UnmarshalText([]byte(testCase.text)); err != nil {
+			if !testCase.expectFailure {
+				t.Errorf("unable to unmarshal text (%s): %s", testCase.text, err)
+			}
+		} else if testCase.expectFailure {
+			t.Error("unmarshaling succeeded unexpectedly for text:", testCase.text)
+		} else if mode != testCase.expectedMode {
+			t.Errorf(
+				"unmarshaled mode (%s) does not match expected (%s)",
+				mode,
+				testCase.expectedMode,
+			)
+		}
+	}
+}
+
+// TestVerificationModeSupported tests that VerificationMode support
+// detection works as expected.
+func TestVerificationModeSupported(t *testing.T) {
+	// Set up test cases.
+	testCases := []struct {
+		mode            VerificationMode
+		expectSupported bool
+	}{
+		{VerificationMode_VerificationModeDefault, false},
+		{VerificationMode_VerificationModeDisabled, true},
+		{VerificationMode_VerificationModePeriodic, true},
+		{(VerificationMode_VerificationModePeriodic + 1), false},
+	}
+
+	// Process test cases.
+	for _, testCase := range testCases {
+		if supported := testCase.mode.Supported(); supported != testCase.expectSupported {
+			t.Errorf(
+				"mode support status (%t) does not match expected (%t)",
+				supported,
+				testCase.expectSupported,
+			)
+		}
+	}
+}
+
+// TestVerificationModeDescription tests that VerificationMode
+// description generation works as expected.
+func TestVerificationModeDescription(t *testing.T) {
+	// Set up test cases.
+	testCases := []struct {
+		mode                VerificationMode
+		expectedDescription string
+	}{
+		{VerificationMode_VerificationModeDefault, "Default"},
+		{VerificationMode_VerificationModeDisabled, "Disabled"},
+		{VerificationMode_VerificationModePeriodic, "Periodic"},
+		{(VerificationMode_VerificationModePeriodic + 1), "Unknown"},
+	}
+
+	// Process test cases.
+	for _, testCase := range testCases {
+		if description := testCase.mode.Description(); description != testCase.expectedDescription {
+			t.Errorf(
+				"mode description (%s) does not match expected (%s)",
+				description,
+				testCase.expectedDescription,
+			)
+		}
+	}
+}
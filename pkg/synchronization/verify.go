@@ -0,0 +1,59 @@
+package synchronization
+
+import (
+	"context"
+	"fmt"
+	"sync"
+
+	"github.com/mutagen-io/mutagen/pkg/synchronization/core"
+)
+
+// verifyEndpoints performs a background verification pass. It performs
+// verification scans of both endpoints concurrently and records the paths at
+// which each endpoint's content diverges from the ancestor (i.e. the last
+// synchronized state) in the session state. If verification of either endpoint
+// fails, then the error is returned and the session state isn't modified.
+func (c *controller) verifyEndpoints(ctx context.Context, alpha, beta Endpoint, ancestor *core.Entry) error {
+	// Perform verification scans concurrently.
+	var αSnapshot, βSnapshot *core.Snapshot
+	var αVerifyErr, βVerifyErr error
+	verifyDone := &sync.WaitGroup{}
+	verifyDone.Add(2)
+	go func() {
+		αSnapshot, αVerifyErr = alpha.Verify(ctx)
+		verifyDone.Done()
+	}()
+	go func() {
+		βSnapshot, βVerifyErr = beta.Verify(ctx)
+		verifyDone.Done()
+	}()
+	verifyDone.Wait()
+
+	// Check for verification errors.
+	if αVerifyErr != nil {
+		return fmt.Errorf("alpha verification error: %w", αVerifyErr)
+	} else if βVerifyErr != nil {
+		return fmt.Errorf("beta verification error: %w", βVerifyErr)
+	}
+
+	// Compute divergences.
+	αDivergences := core.VerificationDivergences(ancestor, αSnapshot.Content)
+	βDivergences := core.VerificationDivergences(ancestor, βSnapshot.Content)
+	if len(αDivergences) > 0 || len(βDivergences) > 0 {
+		c.logger.Warnf("Background verification found %d alpha and %d beta divergence(s)",
+			len(αDivergences), len(βDivergences),
+		)
+	} else {
+		c.logger.Debug("Background verification found no divergences")
+	}
+
+	// Record the results.
+	c.stateLock.Lock()
+	c.state.AlphaState.VerificationDivergences = αDivergences
+	c.state.BetaState.VerificationDivergences = βDivergences
+	c.state.Verifications++
+	c.stateLock.Unlock()
+
+	// Success.
+	return nil
+}
@@ -57,6 +57,17 @@ func (v Version) DefaultMaximumEntryCount() uint64 {
 	}
 }
 
+// DefaultMaximumConflictCount returns the default maximum conflict count for
+// the session version.
+func (v Version) DefaultMaximumConflictCount() uint64 {
+	switch v {
+	case Version_Version1:
+		return math.MaxUint64
+	default:
+		panic("unknown or unsupported session version")
+	}
+}
+
 // DefaultMaximumStagingFileSize returns the default maximum staging file size
 // for the session version.
 func (v Version) DefaultMaximumStagingFileSize() uint64 {
@@ -68,6 +79,17 @@ func (v Version) DefaultMaximumStagingFileSize() uint64 {
 	}
 }
 
+// DefaultMaximumSignatureMemory returns the default maximum signature memory
+// for the session version.
+func (v Version) DefaultMaximumSignatureMemory() uint64 {
+	switch v {
+	case Version_Version1:
+		return math.MaxUint64
+	default:
+		panic("unknown or unsupported session version")
+	}
+}
+
 // DefaultProbeMode returns the default probe mode for the session version.
 func (v Version) DefaultProbeMode() behavior.ProbeMode {
 	switch v {
@@ -98,6 +120,17 @@ func (v Version) DefaultStageMode() StageMode {
 	}
 }
 
+// DefaultTransitionMode returns the default transition mode for the session
+// version.
+func (v Version) DefaultTransitionMode() core.TransitionMode {
+	switch v {
+	case Version_Version1:
+		return core.TransitionMode_TransitionModeInPlace
+	default:
+		panic("unknown or unsupported session version")
+	}
+}
+
 // DefaultSymbolicLinkMode returns the default symbolic link mode for the
 // session version.
 func (v Version) DefaultSymbolicLinkMode() core.SymbolicLinkMode {
@@ -109,6 +142,28 @@ func (v Version) DefaultSymbolicLinkMode() core.SymbolicLinkMode {
 	}
 }
 
+// DefaultSymbolicLinkReplacementMode returns the default symbolic link
+// replacement mode for the session version.
+func (v Version) DefaultSymbolicLinkReplacementMode() core.SymbolicLinkReplacementMode {
+	switch v {
+	case Version_Version1:
+		return core.SymbolicLinkReplacementMode_SymbolicLinkReplacementModeRequireEmpty
+	default:
+		panic("unknown or unsupported session version")
+	}
+}
+
+// DefaultSymbolicLinkCycleMode returns the default symbolic link cycle mode for
+// the session version.
+func (v Version) DefaultSymbolicLinkCycleMode() core.SymbolicLinkCycleMode {
+	switch v {
+	case Version_Version1:
+		return core.SymbolicLinkCycleMode_SymbolicLinkCycleModeAllow
+	default:
+		panic("unknown or unsupported session version")
+	}
+}
+
 // DefaultWatchMode returns the default watch mode for the session version.
 func (v Version) DefaultWatchMode() WatchMode {
 	switch v {
@@ -130,6 +185,17 @@ func (v Version) DefaultWatchPollingInterval() uint32 {
 	}
 }
 
+// DefaultWatchQueueSize returns the default watch queue size for the session
+// version.
+func (v Version) DefaultWatchQueueSize() uint32 {
+	switch v {
+	case Version_Version1:
+		return 50
+	default:
+		panic("unknown or unsupported session version")
+	}
+}
+
 // DefaultIgnoreSyntax returns the default ignore syntax for the session
 // version.
 func (v Version) DefaultIgnoreSyntax() ignore.Syntax {
@@ -141,6 +207,17 @@ func (v Version) DefaultIgnoreSyntax() ignore.Syntax {
 	}
 }
 
+// DefaultPhantomDirectoryMode returns the default phantom directory mode for the
+// session version.
+func (v Version) DefaultPhantomDirectoryMode() core.PhantomDirectoryMode {
+	switch v {
+	case Version_Version1:
+		return core.PhantomDirectoryMode_PhantomDirectoryModeAncestorDriven
+	default:
+		panic("unknown or unsupported session version")
+	}
+}
+
 // DefaultIgnoreVCSMode returns the default VCS ignore mode for the session
 // version.
 func (v Version) DefaultIgnoreVCSMode() ignore.IgnoreVCSMode {
@@ -152,6 +229,17 @@ func (v Version) DefaultIgnoreVCSMode() ignore.IgnoreVCSMode {
 	}
 }
 
+// DefaultIgnoreOSMetadataMode returns the default OS metadata ignore mode for
+// the session version.
+func (v Version) DefaultIgnoreOSMetadataMode() ignore.IgnoreOSMetadataMode {
+	switch v {
+	case Version_Version1:
+		return ignore.IgnoreOSMetadataMode_IgnoreOSMetadataModePropagate
+	default:
+		panic("unknown or unsupported session version")
+	}
+}
+
 // DefaultPermissionsMode returns the default permissions mode for the session
 // version.
 func (v Version) DefaultPermissionsMode() core.PermissionsMode {
@@ -235,3 +323,439 @@ func (v Version) DefaultCompressionAlgorithm() compression.Algorithm {
 		panic("unknown or unsupported session version")
 	}
 }
+
+// DefaultStagingCompressionAlgorithm returns the default staging compression
+// algorithm for the session version.
+func (v Version) DefaultStagingCompressionAlgorithm() compression.Algorithm {
+	switch v {
+	case Version_Version1:
+		return compression.Algorithm_AlgorithmNone
+	default:
+		panic("unknown or unsupported session version")
+	}
+}
+
+// DefaultSpecialFileMode returns the default special file mode for the session
+// version.
+func (v Version) DefaultSpecialFileMode() core.SpecialFileMode {
+	switch v {
+	case Version_Version1:
+		return core.SpecialFileMode_SpecialFileModeIgnore
+	default:
+		panic("unknown or unsupported session version")
+	}
+}
+
+// DefaultClockSkewMode returns the default clock skew mode for the session
+// version.
+func (v Version) DefaultClockSkewMode() ClockSkewMode {
+	switch v {
+	case Version_Version1:
+		return ClockSkewMode_ClockSkewModeWarn
+	default:
+		panic("unknown or unsupported session version")
+	}
+}
+
+// DefaultClockSkewTolerance returns the default clock skew tolerance (in
+// seconds) for the session version.
+func (v Version) DefaultClockSkewTolerance() uint32 {
+	switch v {
+	case Version_Version1:
+		return 60
+	default:
+		panic("unknown or unsupported session version")
+	}
+}
+
+// DefaultInitialSynchronizationMode returns the default initial synchronization
+// mode for the session version.
+func (v Version) DefaultInitialSynchronizationMode() InitialSynchronizationMode {
+	switch v {
+	case Version_Version1:
+		return InitialSynchronizationMode_InitialSynchronizationModeAutomatic
+	default:
+		panic("unknown or unsupported session version")
+	}
+}
+
+// DefaultInitialPollModificationMode returns the default initial poll
+// modification mode for the session version.
+func (v Version) DefaultInitialPollModificationMode() InitialPollModificationMode {
+	switch v {
+	case Version_Version1:
+		return InitialPollModificationMode_InitialPollModificationModeSuppress
+	default:
+		panic("unknown or unsupported session version")
+	}
+}
+
+// DefaultOversizedFileMode returns the default oversized file mode for the
+// session version.
+func (v Version) DefaultOversizedFileMode() OversizedFileMode {
+	switch v {
+	case Version_Version1:
+		return OversizedFileMode_OversizedFileModeSkip
+	default:
+		panic("unknown or unsupported session version")
+	}
+}
+
+// DefaultMaximumRenameDetectionFileSize returns the default maximum rename
+// detection file size for the session version.
+func (v Version) DefaultMaximumRenameDetectionFileSize() uint64 {
+	switch v {
+	case Version_Version1:
+		return math.MaxUint64
+	default:
+		panic("unknown or unsupported session version")
+	}
+}
+
+// DefaultMaximumContentCacheSize returns the default maximum content cache size
+// for the session version. A zero value indicates that the content cache is
+// disabled.
+func (v Version) DefaultMaximumContentCacheSize() uint64 {
+	switch v {
+	case Version_Version1:
+		return 0
+	default:
+		panic("unknown or unsupported session version")
+	}
+}
+
+// DefaultStreamConcurrency returns the default stream concurrency for the
+// session version.
+func (v Version) DefaultStreamConcurrency() uint32 {
+	switch v {
+	case Version_Version1:
+		return 1
+	default:
+		panic("unknown or unsupported session version")
+	}
+}
+
+// DefaultDirectoryListingRetries returns the default directory listing retry
+// count for the session version.
+func (v Version) DefaultDirectoryListingRetries() uint32 {
+	switch v {
+	case Version_Version1:
+		return 0
+	default:
+		panic("unknown or unsupported session version")
+	}
+}
+
+// DefaultRenameDetectionRetries returns the default rename detection retry
+// count for the session version.
+func (v Version) DefaultRenameDetectionRetries() uint32 {
+	switch v {
+	case Version_Version1:
+		return 0
+	default:
+		panic("unknown or unsupported session version")
+	}
+}
+
+// DefaultStagingConcurrencyMode returns the default staging concurrency mode
+// for the session version.
+func (v Version) DefaultStagingConcurrencyMode() StagingConcurrencyMode {
+	switch v {
+	case Version_Version1:
+		return StagingConcurrencyMode_StagingConcurrencyModeSequential
+	default:
+		panic("unknown or unsupported session version")
+	}
+}
+
+// DefaultTypeChangeMode returns the default type change mode for the session
+// version.
+func (v Version) DefaultTypeChangeMode() core.TypeChangeMode {
+	switch v {
+	case Version_Version1:
+		return core.TypeChangeMode_TypeChangeModePropagate
+	default:
+		panic("unknown or unsupported session version")
+	}
+}
+
+// DefaultCacheSaveThreshold returns the default cache save threshold for the
+// session version.
+func (v Version) DefaultCacheSaveThreshold() uint64 {
+	switch v {
+	case Version_Version1:
+		return 1
+	default:
+		panic("unknown or unsupported session version")
+	}
+}
+
+// DefaultMaximumRecheckPaths returns the default maximum re-check path count
+// for the session version. A zero value indicates no limit.
+func (v Version) DefaultMaximumRecheckPaths() uint64 {
+	switch v {
+	case Version_Version1:
+		return 0
+	default:
+		panic("unknown or unsupported session version")
+	}
+}
+
+// DefaultMaximumDeltificationTime returns the default maximum deltification
+// time (in milliseconds) for the session version. A zero value indicates no
+// limit.
+func (v Version) DefaultMaximumDeltificationTime() uint32 {
+	switch v {
+	case Version_Version1:
+		return 0
+	default:
+		panic("unknown or unsupported session version")
+	}
+}
+
+// DefaultMaximumStagedContentAge returns the default maximum staged content
+// age (in seconds) for the session version. A zero value indicates that staged
+// content isn't removed based on age.
+func (v Version) DefaultMaximumStagedContentAge() uint32 {
+	switch v {
+	case Version_Version1:
+		return 0
+	default:
+		panic("unknown or unsupported session version")
+	}
+}
+
+// DefaultRootExistenceMode returns the default root existence mode for the
+// session version.
+func (v Version) DefaultRootExistenceMode() RootExistenceMode {
+	switch v {
+	case Version_Version1:
+		return RootExistenceMode_RootExistenceModeCreate
+	default:
+		panic("unknown or unsupported session version")
+	}
+}
+
+// DefaultMaximumConflictPersistence returns the default maximum conflict
+// persistence (in synchronization cycles) for the session version. A zero value
+// indicates that conflicts can persist indefinitely.
+func (v Version) DefaultMaximumConflictPersistence() uint32 {
+	switch v {
+	case Version_Version1:
+		return 0
+	default:
+		panic("unknown or unsupported session version")
+	}
+}
+
+// DefaultDigestSamplingThreshold returns the default digest sampling threshold
+// for the session version. A zero value indicates that digests aren't sampled.
+func (v Version) DefaultDigestSamplingThreshold() uint64 {
+	switch v {
+	case Version_Version1:
+		return 0
+	default:
+		panic("unknown or unsupported session version")
+	}
+}
+
+// DefaultDigestLength returns the default digest length for the session
+// version. A zero value indicates that digests aren't truncated.
+func (v Version) DefaultDigestLength() uint32 {
+	switch v {
+	case Version_Version1:
+		return 0
+	default:
+		panic("unknown or unsupported session version")
+	}
+}
+
+// DefaultRsyncBlockSize returns the default rsync block size for the session
+// version. A zero value indicates that the block size is chosen automatically.
+func (v Version) DefaultRsyncBlockSize() uint64 {
+	switch v {
+	case Version_Version1:
+		return 0
+	default:
+		panic("unknown or unsupported session version")
+	}
+}
+
+// DefaultMaximumInFlightBytes returns the default maximum in-flight rsync
+// operation data size for the session version. A zero value indicates that
+// pipelining is disabled and that operations are forwarded synchronously.
+func (v Version) DefaultMaximumInFlightBytes() uint64 {
+	switch v {
+	case Version_Version1:
+		return 0
+	default:
+		panic("unknown or unsupported session version")
+	}
+}
+
+// DefaultCacheTrustMode returns the default cache trust mode for the session
+// version.
+func (v Version) DefaultCacheTrustMode() core.CacheTrustMode {
+	switch v {
+	case Version_Version1:
+		return core.CacheTrustMode_CacheTrustModeStrict
+	default:
+		panic("unknown or unsupported session version")
+	}
+}
+
+// DefaultNameNormalizationMode returns the default name normalization mode for
+// the session version.
+func (v Version) DefaultNameNormalizationMode() core.NameNormalizationMode {
+	switch v {
+	case Version_Version1:
+		return core.NameNormalizationMode_NameNormalizationModePreserve
+	default:
+		panic("unknown or unsupported session version")
+	}
+}
+
+// DefaultCapabilityMismatchMode returns the default capability mismatch mode
+// for the session version.
+func (v Version) DefaultCapabilityMismatchMode() CapabilityMismatchMode {
+	switch v {
+	case Version_Version1:
+		return CapabilityMismatchMode_CapabilityMismatchModeAllow
+	default:
+		panic("unknown or unsupported session version")
+	}
+}
+
+// DefaultRootOverlapMode returns the default root overlap mode for the session
+// version.
+func (v Version) DefaultRootOverlapMode() RootOverlapMode {
+	switch v {
+	case Version_Version1:
+		return RootOverlapMode_RootOverlapModeWarn
+	default:
+		panic("unknown or unsupported session version")
+	}
+}
+
+// DefaultScanSharingMode returns the default scan sharing mode for the session
+// version.
+func (v Version) DefaultScanSharingMode() ScanSharingMode {
+	switch v {
+	case Version_Version1:
+		return ScanSharingMode_ScanSharingModeDisabled
+	default:
+		panic("unknown or unsupported session version")
+	}
+}
+
+// DefaultAggregateDigestMode returns the default aggregate digest mode for the
+// session version.
+func (v Version) DefaultAggregateDigestMode() AggregateDigestMode {
+	switch v {
+	case Version_Version1:
+		return AggregateDigestMode_AggregateDigestModeEnabled
+	default:
+		panic("unknown or unsupported session version")
+	}
+}
+
+// DefaultVerificationMode returns the default verification mode for the session
+// version.
+func (v Version) DefaultVerificationMode() VerificationMode {
+	switch v {
+	case Version_Version1:
+		return VerificationMode_VerificationModeDisabled
+	default:
+		panic("unknown or unsupported session version")
+	}
+}
+
+// DefaultVerificationInterval returns the default background verification
+// interval (in seconds) for the session version.
+func (v Version) DefaultVerificationInterval() uint32 {
+	switch v {
+	case Version_Version1:
+		return 86400
+	default:
+		panic("unknown or unsupported session version")
+	}
+}
+
+// DefaultMaximumReportedProblems returns the default maximum number of scan or
+// transition problems reported for each endpoint for the session version.
+func (v Version) DefaultMaximumReportedProblems() uint32 {
+	switch v {
+	case Version_Version1:
+		return 100
+	default:
+		panic("unknown or unsupported session version")
+	}
+}
+
+// DefaultMaximumReconciliationRecordings returns the default maximum number of
+// reconciliation recordings retained for the session version. A value of 0
+// indicates that reconciliation recording is disabled.
+func (v Version) DefaultMaximumReconciliationRecordings() uint32 {
+	switch v {
+	case Version_Version1:
+		return 0
+	default:
+		panic("unknown or unsupported session version")
+	}
+}
+
+// DefaultSlowDirectoryListingThreshold returns the default slow directory
+// listing threshold (in milliseconds) for the session version.
+func (v Version) DefaultSlowDirectoryListingThreshold() uint32 {
+	switch v {
+	case Version_Version1:
+		return 100
+	default:
+		panic("unknown or unsupported session version")
+	}
+}
+
+// DefaultConfigurationIncompatibilityMode returns the default configuration
+// incompatibility mode for the session version.
+func (v Version) DefaultConfigurationIncompatibilityMode() ConfigurationIncompatibilityMode {
+	switch v {
+	case Version_Version1:
+		return ConfigurationIncompatibilityMode_ConfigurationIncompatibilityModeAllow
+	default:
+		panic("unknown or unsupported session version")
+	}
+}
+
+// DefaultAgentTerminationReconnectDelay returns the default agent termination
+// reconnect delay (in milliseconds) for the session version.
+func (v Version) DefaultAgentTerminationReconnectDelay() uint32 {
+	switch v {
+	case Version_Version1:
+		return 1000
+	default:
+		panic("unknown or unsupported session version")
+	}
+}
+
+// DefaultMaximumBetaScanDeferral returns the default maximum beta scan deferral
+// (in synchronization cycles) for the session version. A zero value indicates
+// that beta scan deferral is disabled.
+func (v Version) DefaultMaximumBetaScanDeferral() uint32 {
+	switch v {
+	case Version_Version1:
+		return 0
+	default:
+		panic("unknown or unsupported session version")
+	}
+}
+
+// DefaultWatchdogTimeout returns the default watchdog timeout (in seconds) for
+// the session version. A zero value indicates that the watchdog is disabled.
+func (v Version) DefaultWatchdogTimeout() uint32 {
+	switch v {
+	case Version_Version1:
+		return 0
+	default:
+		panic("unknown or unsupported session version")
+	}
+}
@@ -48,6 +48,28 @@ func TestDefaultWatchPollingIntervalNonZero(t *testing.T) {
 	}
 }
 
+// TestDefaultVerificationIntervalNonZero verifies that
+// DefaultVerificationInterval results are non-zero, which is required for
+// scheduling background verification.
+func TestDefaultVerificationIntervalNonZero(t *testing.T) {
+	for _, version := range supportedSessionVersions {
+		if version.DefaultVerificationInterval() == 0 {
+			t.Error("zero-valued default verification interval")
+		}
+	}
+}
+
+// TestDefaultMaximumReportedProblemsNonZero verifies that
+// DefaultMaximumReportedProblems results are non-zero, which is required for
+// any problems to be reported.
+func TestDefaultMaximumReportedProblemsNonZero(t *testing.T) {
+	for _, version := range supportedSessionVersions {
+		if version.DefaultMaximumReportedProblems() == 0 {
+			t.Error("zero-valued default maximum reported problems")
+		}
+	}
+}
+
 // TestDefaultFileModeValid verifies that DefaultFileMode results are valid for
 // use in the default permissions mode.
 func TestDefaultFileModeValid(t *testing.T) {
@@ -0,0 +1,86 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/spf13/pflag"
+
+	"github.com/dustin/go-humanize"
+
+	"github.com/mutagen-io/mutagen/cmd"
+
+	"github.com/mutagen-io/mutagen/pkg/encoding"
+	"github.com/mutagen-io/mutagen/pkg/synchronization/core"
+)
+
+const usage = `offline_scan [-h|--help] [--no-executability] [--digest-length=<length>]
+             [-o|--output=<path>] <cache> [<archive>]
+`
+
+func main() {
+	// Parse command line arguments.
+	flagSet := pflag.NewFlagSet("offline_scan", pflag.ContinueOnError)
+	flagSet.SetOutput(io.Discard)
+	var noExecutability bool
+	var digestLength uint32
+	var output string
+	flagSet.BoolVar(&noExecutability, "no-executability", false, "treat the filesystem as not preserving executability")
+	flagSet.Uint32Var(&digestLength, "digest-length", 0, "truncate digests to the specified length (in bytes)")
+	flagSet.StringVarP(&output, "output", "o", "", "specify a path at which to save the reconstructed snapshot")
+	if err := flagSet.Parse(os.Args[1:]); err != nil {
+		if err == pflag.ErrHelp {
+			fmt.Fprint(os.Stdout, usage)
+			return
+		} else {
+			cmd.Fatal(fmt.Errorf("unable to parse command line: %w", err))
+		}
+	}
+	arguments := flagSet.Args()
+	if len(arguments) < 1 || len(arguments) > 2 {
+		cmd.Fatal(errors.New("invalid number of paths specified"))
+	}
+
+	// Load and validate the cache.
+	cache := &core.Cache{}
+	if err := encoding.LoadAndUnmarshalProtobuf(arguments[0], cache); err != nil {
+		cmd.Fatal(fmt.Errorf("unable to load cache: %w", err))
+	} else if err = cache.EnsureValid(); err != nil {
+		cmd.Fatal(fmt.Errorf("invalid cache: %w", err))
+	}
+
+	// Load and validate the archive, if any.
+	var ancestor *core.Entry
+	if len(arguments) == 2 {
+		archive := &core.Archive{}
+		if err := encoding.LoadAndUnmarshalProtobuf(arguments[1], archive); err != nil {
+			cmd.Fatal(fmt.Errorf("unable to load archive: %w", err))
+		} else if err = archive.Content.EnsureValid(true); err != nil {
+			cmd.Fatal(fmt.Errorf("invalid archive: %w", err))
+		}
+		ancestor = archive.Content
+	}
+
+	// Reconstruct the snapshot.
+	snapshot, err := core.ReconstructSnapshot(cache, ancestor, !noExecutability, digestLength)
+	if err != nil {
+		cmd.Fatal(fmt.Errorf("unable to reconstruct snapshot: %w", err))
+	}
+
+	// Print a summary of the snapshot.
+	fmt.Printf("%d director(ies), %d file(s) (%s), %d symbolic link(s)\n",
+		snapshot.Directories,
+		snapshot.Files,
+		humanize.Bytes(snapshot.TotalFileSize),
+		snapshot.SymbolicLinks,
+	)
+
+	// Save the snapshot, if requested.
+	if output != "" {
+		if err := encoding.MarshalAndSaveProtobuf(output, snapshot); err != nil {
+			cmd.Fatal(fmt.Errorf("unable to save snapshot: %w", err))
+		}
+	}
+}
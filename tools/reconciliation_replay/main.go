@@ -0,0 +1,57 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"os"
+
+	"github.com/mutagen-io/mutagen/cmd"
+
+	"github.com/mutagen-io/mutagen/pkg/encoding"
+	"github.com/mutagen-io/mutagen/pkg/synchronization/core"
+)
+
+func main() {
+	// Parse arguments.
+	if len(os.Args) < 2 {
+		cmd.Fatal(errors.New("no recordings specified"))
+	}
+	paths := os.Args[1:]
+
+	// Replay each recording.
+	var failed bool
+	for _, path := range paths {
+		// Load and validate the recording.
+		recording := &core.ReconciliationRecording{}
+		if err := encoding.LoadAndUnmarshalProtobuf(path, recording); err != nil {
+			cmd.Error(fmt.Errorf("unable to load recording (%s): %w", path, err))
+			failed = true
+			continue
+		} else if err = recording.EnsureValid(); err != nil {
+			cmd.Error(fmt.Errorf("invalid recording (%s): %w", path, err))
+			failed = true
+			continue
+		}
+
+		// Print a summary of the recording.
+		fmt.Printf("%s: %s, %d ancestor change(s), %d alpha change(s), %d beta change(s), %d conflict(s)\n",
+			path,
+			recording.SynchronizationMode.Description(),
+			len(recording.AncestorChanges),
+			len(recording.AlphaChanges),
+			len(recording.BetaChanges),
+			len(recording.Conflicts),
+		)
+
+		// Replay the recording.
+		if err := recording.Replay(); err != nil {
+			cmd.Error(fmt.Errorf("replay of recording (%s) diverged: %w", path, err))
+			failed = true
+		}
+	}
+
+	// Exit with an error status if any recording failed to replay.
+	if failed {
+		os.Exit(1)
+	}
+}
@@ -0,0 +1,96 @@
+package main
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/spf13/pflag"
+
+	"github.com/dustin/go-humanize"
+
+	"github.com/mutagen-io/mutagen/cmd"
+
+	"github.com/mutagen-io/mutagen/pkg/synchronization/rsync"
+)
+
+const usage = `rsync_dump [-h|--help] [-b|--block-size=<size>]
+           [-m|--maximum-data-operation-size=<size>] <base> <target>
+`
+
+func main() {
+	// Parse command line arguments.
+	flagSet := pflag.NewFlagSet("rsync_dump", pflag.ContinueOnError)
+	flagSet.SetOutput(io.Discard)
+	var blockSize, maximumDataOperationSize uint64
+	flagSet.Uint64VarP(&blockSize, "block-size", "b", 0, "specify block size (0 for optimal)")
+	flagSet.Uint64VarP(&maximumDataOperationSize, "maximum-data-operation-size", "m", 0, "specify maximum data operation size (0 for default)")
+	if err := flagSet.Parse(os.Args[1:]); err != nil {
+		if err == pflag.ErrHelp {
+			fmt.Fprint(os.Stdout, usage)
+			return
+		} else {
+			cmd.Fatal(fmt.Errorf("unable to parse command line: %w", err))
+		}
+	}
+	arguments := flagSet.Args()
+	if len(arguments) != 2 {
+		cmd.Fatal(errors.New("base and target paths must be specified"))
+	}
+
+	// Read the base and target.
+	base, err := os.ReadFile(arguments[0])
+	if err != nil {
+		cmd.Fatal(fmt.Errorf("unable to read base: %w", err))
+	}
+	target, err := os.ReadFile(arguments[1])
+	if err != nil {
+		cmd.Fatal(fmt.Errorf("unable to read target: %w", err))
+	}
+
+	// Compute the base signature and the operations needed to reconstruct the
+	// target.
+	engine := rsync.NewEngine()
+	signature := engine.BytesSignature(base, blockSize)
+	operations := engine.DeltifyBytes(target, signature, maximumDataOperationSize)
+
+	// Describe the operations.
+	descriptions, err := rsync.DescribeOperations(signature, operations)
+	if err != nil {
+		cmd.Fatal(fmt.Errorf("unable to describe operations: %w", err))
+	}
+
+	// Print signature information.
+	fmt.Printf("Base: %s (%d block(s) of size %d, last block size %d)\n",
+		humanize.Bytes(uint64(len(base))),
+		len(signature.Hashes),
+		signature.BlockSize,
+		signature.LastBlockSize,
+	)
+	fmt.Printf("Target: %s\n", humanize.Bytes(uint64(len(target))))
+
+	// Print the operations and tally their content.
+	var blockBytes, dataBytes uint64
+	for i, description := range descriptions {
+		fmt.Printf("%6d %s\n", i, description)
+		if description.Data {
+			dataBytes += description.Length
+		} else {
+			blockBytes += description.Length
+		}
+	}
+	fmt.Printf("%d operation(s): %s copied from base, %s of literal data\n",
+		len(descriptions),
+		humanize.Bytes(blockBytes),
+		humanize.Bytes(dataBytes),
+	)
+
+	// Verify that the operations reconstruct the target.
+	if patched, err := engine.PatchBytes(base, signature, operations); err != nil {
+		cmd.Fatal(fmt.Errorf("unable to apply operations: %w", err))
+	} else if !bytes.Equal(patched, target) {
+		cmd.Fatal(errors.New("operations do not reconstruct target"))
+	}
+}
@@ -152,11 +152,18 @@ func main() {
 		ctx,
 		path,
 		nil, nil,
-		hasher, nil,
+		hasher, nil, core.CacheTrustMode_CacheTrustModeStrict,
 		ignorer, nil,
-		behavior.ProbeMode_ProbeModeProbe,
+		behavior.ProbeMode_ProbeModeProbe, nil,
 		core.SymbolicLinkMode_SymbolicLinkModePortable,
+		core.SpecialFileMode_SpecialFileModeIgnore,
+		core.NameNormalizationMode_NameNormalizationModePreserve,
 		core.PermissionsMode_PermissionsModePortable,
+		false,
+		false,
+		0,
+		0,
+		nil, 0,
 	)
 	if err != nil {
 		cmd.Fatal(fmt.Errorf("unable to perform cold scan: %w", err))
@@ -187,11 +194,18 @@ func main() {
 		ctx,
 		path,
 		nil, nil,
-		hasher, cache,
+		hasher, cache, core.CacheTrustMode_CacheTrustModeStrict,
 		ignorer, ignoreCache,
-		behavior.ProbeMode_ProbeModeProbe,
+		behavior.ProbeMode_ProbeModeProbe, nil,
 		core.SymbolicLinkMode_SymbolicLinkModePortable,
+		core.SpecialFileMode_SpecialFileModeIgnore,
+		core.NameNormalizationMode_NameNormalizationModePreserve,
 		core.PermissionsMode_PermissionsModePortable,
+		false,
+		false,
+		0,
+		0,
+		nil, 0,
 	)
 	if err != nil {
 		cmd.Fatal(fmt.Errorf("unable to perform warm scan: %w", err))
@@ -230,11 +244,18 @@ func main() {
 		ctx,
 		path,
 		nil, nil,
-		hasher, cache,
+		hasher, cache, core.CacheTrustMode_CacheTrustModeStrict,
 		ignorer, ignoreCache,
-		behavior.ProbeMode_ProbeModeProbe,
+		behavior.ProbeMode_ProbeModeProbe, nil,
 		core.SymbolicLinkMode_SymbolicLinkModePortable,
+		core.SpecialFileMode_SpecialFileModeIgnore,
+		core.NameNormalizationMode_NameNormalizationModePreserve,
 		core.PermissionsMode_PermissionsModePortable,
+		false,
+		false,
+		0,
+		0,
+		nil, 0,
 	)
 	if err != nil {
 		cmd.Fatal(fmt.Errorf("unable to perform second warm scan: %w", err))
@@ -271,11 +292,18 @@ func main() {
 		ctx,
 		path,
 		snapshot, map[string]bool{"fake path": true},
-		hasher, cache,
+		hasher, cache, core.CacheTrustMode_CacheTrustModeStrict,
 		ignorer, ignoreCache,
-		behavior.ProbeMode_ProbeModeProbe,
+		behavior.ProbeMode_ProbeModeProbe, nil,
 		core.SymbolicLinkMode_SymbolicLinkModePortable,
+		core.SpecialFileMode_SpecialFileModeIgnore,
+		core.NameNormalizationMode_NameNormalizationModePreserve,
 		core.PermissionsMode_PermissionsModePortable,
+		false,
+		false,
+		0,
+		0,
+		nil, 0,
 	)
 	if err != nil {
 		cmd.Fatal(fmt.Errorf("unable to perform accelerated scan (with re-check paths): %w", err))
@@ -310,11 +338,18 @@ func main() {
 		ctx,
 		path,
 		snapshot, nil,
-		hasher, cache,
+		hasher, cache, core.CacheTrustMode_CacheTrustModeStrict,
 		ignorer, ignoreCache,
-		behavior.ProbeMode_ProbeModeProbe,
+		behavior.ProbeMode_ProbeModeProbe, nil,
 		core.SymbolicLinkMode_SymbolicLinkModePortable,
+		core.SpecialFileMode_SpecialFileModeIgnore,
+		core.NameNormalizationMode_NameNormalizationModePreserve,
 		core.PermissionsMode_PermissionsModePortable,
+		false,
+		false,
+		0,
+		0,
+		nil, 0,
 	)
 	if err != nil {
 		cmd.Fatal(fmt.Errorf("unable to perform accelerated scan (without re-check paths): %w", err))
@@ -363,6 +398,7 @@ func main() {
 	start = time.Now()
 	_, _, αDirectoryCount, βDirectoryCount := core.ReifyPhantomDirectories(
 		snapshot.Content, snapshot.Content, snapshot.Content,
+		core.PhantomDirectoryMode_PhantomDirectoryModeAncestorDriven,
 	)
 	stop = time.Now()
 	fmt.Println("Phantom directory reification took", stop.Sub(start))
@@ -525,7 +561,7 @@ func main() {
 
 	// Generate a reverse lookup map for the cache.
 	start = time.Now()
-	if _, err = cache.GenerateReverseLookupMap(); err != nil {
+	if _, err = cache.GenerateReverseLookupMap(0); err != nil {
 		cmd.Fatal(fmt.Errorf("unable to generate reverse lookup map: %w", err))
 	}
 	stop = time.Now()
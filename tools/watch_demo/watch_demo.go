@@ -11,6 +11,11 @@ import (
 	"github.com/mutagen-io/mutagen/pkg/filesystem/watching"
 )
 
+const (
+	// watchQueueSize is the watcher queue size to use.
+	watchQueueSize = 50
+)
+
 func main() {
 	// Parse arguments.
 	if len(os.Args) != 2 {
@@ -30,14 +35,14 @@ func main() {
 	// watcher we establish as a RecursiveWatcher.
 	var watcher watching.RecursiveWatcher
 	if watching.RecursiveWatchingSupported {
-		if w, err := watching.NewRecursiveWatcher(watchRoot); err != nil {
+		if w, err := watching.NewRecursiveWatcher(watchRoot, watchQueueSize); err != nil {
 			cmd.Fatal(fmt.Errorf("unable to establish recursive watch: %w", err))
 		} else {
 			watcher = w
 			fmt.Println("Watching", watchRoot, "with recursive watching")
 		}
 	} else if watching.NonRecursiveWatchingSupported {
-		if w, err := watching.NewNonRecursiveWatcher(); err != nil {
+		if w, err := watching.NewNonRecursiveWatcher(watchQueueSize); err != nil {
 			cmd.Fatal(fmt.Errorf("unable to establish non-recursive watch: %w", err))
 		} else {
 			w.Watch(watchRoot)